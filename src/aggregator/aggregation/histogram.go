@@ -0,0 +1,153 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aggregation
+
+import (
+	"time"
+
+	"github.com/m3db/m3/src/aggregator/aggregation/histogram"
+	native "github.com/m3db/m3/src/dbnode/encoding/histogram"
+	"github.com/m3db/m3/src/metrics/aggregation"
+)
+
+// Histogram aggregates values into an exponential histogram. Values with a
+// native histogram annotation are histograms forwarded from an earlier
+// pipeline stage and are merged rather than added. Histogram APIs are not
+// thread-safe.
+type Histogram struct {
+	lastAt     time.Time
+	histogram  *histogram.Histogram
+	annotation []byte
+}
+
+// NewHistogram creates a new histogram.
+func NewHistogram(histogramOpts histogram.Options, _ Options) Histogram {
+	return Histogram{
+		histogram: histogram.NewHistogram(histogramOpts),
+	}
+}
+
+// Add adds a histogram value.
+func (h *Histogram) Add(timestamp time.Time, value float64, annotation []byte) {
+	h.recordLastAt(timestamp)
+
+	if native.IsAnnotation(annotation) {
+		n, err := native.DecodeAnnotation(annotation)
+		if err == nil && h.histogram.MergeNative(n) == nil {
+			return
+		}
+	}
+
+	h.histogram.Add(value)
+	h.annotation = MaybeReplaceAnnotation(h.annotation, annotation)
+}
+
+// AddBatch adds a batch of histogram values.
+func (h *Histogram) AddBatch(timestamp time.Time, values []float64, annotation []byte) {
+	h.recordLastAt(timestamp)
+	for _, v := range values {
+		h.histogram.Add(v)
+	}
+	h.annotation = MaybeReplaceAnnotation(h.annotation, annotation)
+}
+
+func (h *Histogram) recordLastAt(timestamp time.Time) {
+	if h.lastAt.IsZero() || timestamp.After(h.lastAt) {
+		h.lastAt = timestamp
+	}
+}
+
+// LastAt returns the time of the last value received.
+func (h *Histogram) LastAt() time.Time { return h.lastAt }
+
+// Quantile returns an estimate of the value at a given quantile.
+func (h *Histogram) Quantile(q float64) float64 { return h.histogram.Quantile(q) }
+
+// Count returns the number of values received.
+func (h *Histogram) Count() float64 { return h.histogram.Count() }
+
+// Min returns the minimum histogram value.
+func (h *Histogram) Min() float64 { return h.histogram.Min() }
+
+// Max returns the maximum histogram value.
+func (h *Histogram) Max() float64 { return h.histogram.Max() }
+
+// Sum returns the sum of histogram values.
+func (h *Histogram) Sum() float64 { return h.histogram.Sum() }
+
+// SumSq returns the squared sum of histogram values, NaN once a forwarded
+// histogram has been merged.
+func (h *Histogram) SumSq() float64 { return h.histogram.SumSq() }
+
+// Mean returns the mean histogram value.
+func (h *Histogram) Mean() float64 {
+	if h.histogram.Count() == 0 {
+		return 0.0
+	}
+	return h.histogram.Sum() / h.histogram.Count()
+}
+
+// Stdev returns the standard deviation of the histogram values.
+func (h *Histogram) Stdev() float64 {
+	return stdev(int64(h.histogram.Count()), h.histogram.SumSq(), h.histogram.Sum())
+}
+
+// ValueOf returns the value for the aggregation type, the value of the
+// histogram aggregation type is the number of values.
+func (h *Histogram) ValueOf(aggType aggregation.Type) float64 {
+	if q, ok := aggType.Quantile(); ok {
+		return h.Quantile(q)
+	}
+
+	switch aggType {
+	case aggregation.Min:
+		return h.Min()
+	case aggregation.Max:
+		return h.Max()
+	case aggregation.Mean:
+		return h.Mean()
+	case aggregation.Count, aggregation.Histogram:
+		return h.Count()
+	case aggregation.Sum:
+		return h.Sum()
+	case aggregation.SumSq:
+		return h.SumSq()
+	case aggregation.Stdev:
+		return h.Stdev()
+	}
+	return 0
+}
+
+// Annotation returns the annotation associated with the histogram.
+func (h *Histogram) Annotation() []byte {
+	return h.annotation
+}
+
+// AppendHistogram appends the histogram encoded as a native histogram
+// annotation to the buffer.
+func (h *Histogram) AppendHistogram(buf []byte) []byte {
+	return native.AppendAnnotation(buf, h.histogram.Native())
+}
+
+// Close closes the histogram.
+func (h *Histogram) Close() {
+	h.histogram = nil
+}
@@ -0,0 +1,374 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package histogram
+
+import (
+	"math"
+	"sort"
+
+	native "github.com/m3db/m3/src/dbnode/encoding/histogram"
+)
+
+// Histogram is an exponential histogram, the positive bucket with index i
+// holds the values in (base^(i-1), base^i] where base is 2^(2^-schema), and
+// the negative buckets mirror the positive ones. Values whose absolute value
+// is at most the zero threshold are counted in the zero bucket.
+//
+// The schema of a histogram is reduced (halving the number of buckets) each
+// time it holds more than the max number of buckets, and when merging with a
+// histogram of a lower schema. Histogram APIs are not thread-safe.
+type Histogram struct {
+	schema        int32
+	maxBuckets    int
+	zeroThreshold float64
+	zeroCount     float64
+	count         float64
+	sum           float64
+	sumSq         float64
+	min           float64
+	max           float64
+	positive      map[int32]float64
+	negative      map[int32]float64
+}
+
+// NewHistogram creates a new histogram.
+func NewHistogram(opts Options) *Histogram {
+	return &Histogram{
+		schema:        opts.Schema(),
+		maxBuckets:    opts.MaxBuckets(),
+		zeroThreshold: opts.ZeroThreshold(),
+		positive:      make(map[int32]float64),
+		negative:      make(map[int32]float64),
+	}
+}
+
+// Add adds a value, NaN values are ignored.
+func (h *Histogram) Add(value float64) {
+	if math.IsNaN(value) {
+		return
+	}
+	if h.count == 0 || value < h.min {
+		h.min = value
+	}
+	if h.count == 0 || value > h.max {
+		h.max = value
+	}
+	h.count++
+	h.sum += value
+	h.sumSq += value * value
+
+	switch {
+	case math.Abs(value) <= h.zeroThreshold:
+		h.zeroCount++
+	case value > 0:
+		h.positive[bucketIndex(value, h.schema)]++
+	default:
+		h.negative[bucketIndex(-value, h.schema)]++
+	}
+	h.maybeReduceSchema()
+}
+
+// Merge merges another histogram into the histogram.
+func (h *Histogram) Merge(other *Histogram) {
+	if other.count == 0 {
+		return
+	}
+	if h.count == 0 || other.min < h.min {
+		h.min = other.min
+	}
+	if h.count == 0 || other.max > h.max {
+		h.max = other.max
+	}
+	h.count += other.count
+	h.sum += other.sum
+	h.sumSq += other.sumSq
+	h.zeroCount += other.zeroCount
+
+	if other.schema < h.schema {
+		h.reduceSchema(other.schema)
+	}
+	delta := other.schema - h.schema
+	for idx, count := range other.positive {
+		h.positive[downscaleIndex(idx, delta)] += count
+	}
+	for idx, count := range other.negative {
+		h.negative[downscaleIndex(idx, delta)] += count
+	}
+	h.widenZeroBucket(math.Max(h.zeroThreshold, other.zeroThreshold))
+	h.maybeReduceSchema()
+}
+
+// MergeNative merges a native histogram into the histogram. Native
+// histograms do not record the min, max and sum of squares of their values,
+// the bounds of their lowest and highest buckets are used as the min and max
+// and the sum of squares of the histogram becomes NaN.
+func (h *Histogram) MergeNative(n native.Histogram) error {
+	if err := n.Validate(); err != nil {
+		return err
+	}
+	other := &Histogram{
+		schema:        n.Schema,
+		maxBuckets:    h.maxBuckets,
+		zeroThreshold: n.ZeroThreshold,
+		zeroCount:     n.ZeroCount,
+		count:         n.Count,
+		sum:           n.Sum,
+		sumSq:         math.NaN(),
+		positive:      make(map[int32]float64, len(n.PositiveBuckets)),
+		negative:      make(map[int32]float64, len(n.NegativeBuckets)),
+	}
+	forEachBucket(n.PositiveSpans, n.PositiveBuckets, func(idx int32, count float64) {
+		other.positive[idx] += count
+	})
+	forEachBucket(n.NegativeSpans, n.NegativeBuckets, func(idx int32, count float64) {
+		other.negative[idx] += count
+	})
+	if buckets := n.Buckets(); len(buckets) > 0 {
+		other.min = buckets[0].Lower
+		other.max = buckets[len(buckets)-1].Upper
+	}
+	h.Merge(other)
+	return nil
+}
+
+// Schema returns the current schema of the histogram.
+func (h *Histogram) Schema() int32 { return h.schema }
+
+// Count returns the number of values.
+func (h *Histogram) Count() float64 { return h.count }
+
+// Sum returns the sum of the values.
+func (h *Histogram) Sum() float64 { return h.sum }
+
+// SumSq returns the sum of the squared values.
+func (h *Histogram) SumSq() float64 { return h.sumSq }
+
+// Min returns the min value, or zero if there are no values.
+func (h *Histogram) Min() float64 { return h.min }
+
+// Max returns the max value, or zero if there are no values.
+func (h *Histogram) Max() float64 { return h.max }
+
+// NumBuckets returns the number of populated buckets excluding the zero
+// bucket.
+func (h *Histogram) NumBuckets() int { return len(h.positive) + len(h.negative) }
+
+// Quantile returns an estimate of the value at the given quantile, linearly
+// interpolated within the bucket the quantile falls in, or zero if there are
+// no values.
+func (h *Histogram) Quantile(q float64) float64 {
+	if h.count == 0 {
+		return 0
+	}
+	if q <= 0 {
+		return h.min
+	}
+	if q >= 1 {
+		return h.max
+	}
+
+	var (
+		rank       = q * h.count
+		cumulative float64
+		result     = h.max
+		found      bool
+	)
+	h.forEachBucketAscending(func(lower, upper, count float64) bool {
+		if cumulative+count < rank {
+			cumulative += count
+			return true
+		}
+		result = lower + (upper-lower)*(rank-cumulative)/count
+		found = true
+		return false
+	})
+	if !found {
+		return h.max
+	}
+	return math.Min(math.Max(result, h.min), h.max)
+}
+
+// Native returns the histogram as a native histogram. The histogram holds
+// the values of a single aggregation, so it is a gauge histogram.
+func (h *Histogram) Native() native.Histogram {
+	n := native.Histogram{
+		CounterResetHint: native.GaugeType,
+		Schema:           h.schema,
+		ZeroThreshold:    h.zeroThreshold,
+		ZeroCount:        h.zeroCount,
+		Count:            h.count,
+		Sum:              h.sum,
+	}
+	n.PositiveSpans, n.PositiveBuckets = toSpans(h.positive)
+	n.NegativeSpans, n.NegativeBuckets = toSpans(h.negative)
+	return n
+}
+
+func (h *Histogram) maybeReduceSchema() {
+	for h.NumBuckets() > h.maxBuckets && h.schema > native.MinSchema {
+		h.reduceSchema(h.schema - 1)
+	}
+}
+
+func (h *Histogram) reduceSchema(schema int32) {
+	delta := h.schema - schema
+	h.positive = downscaleBuckets(h.positive, delta)
+	h.negative = downscaleBuckets(h.negative, delta)
+	h.schema = schema
+}
+
+// widenZeroBucket moves the buckets whose values are all within the zero
+// threshold to the zero bucket.
+func (h *Histogram) widenZeroBucket(threshold float64) {
+	h.zeroThreshold = threshold
+	for _, buckets := range []map[int32]float64{h.positive, h.negative} {
+		for idx, count := range buckets {
+			if upperBound(idx, h.schema) <= threshold {
+				h.zeroCount += count
+				delete(buckets, idx)
+			}
+		}
+	}
+}
+
+// forEachBucketAscending calls fn with the bounds and count of each
+// populated bucket in ascending order of their bounds until fn returns
+// false.
+func (h *Histogram) forEachBucketAscending(fn func(lower, upper, count float64) bool) {
+	negative := sortedIndexes(h.negative)
+	for i := len(negative) - 1; i >= 0; i-- {
+		idx := negative[i]
+		if !fn(-upperBound(idx, h.schema), -upperBound(idx-1, h.schema), h.negative[idx]) {
+			return
+		}
+	}
+	if h.zeroCount > 0 {
+		if !fn(-h.zeroThreshold, h.zeroThreshold, h.zeroCount) {
+			return
+		}
+	}
+	for _, idx := range sortedIndexes(h.positive) {
+		if !fn(upperBound(idx-1, h.schema), upperBound(idx, h.schema), h.positive[idx]) {
+			return
+		}
+	}
+}
+
+// bucketIndex returns the index of the bucket holding a positive value.
+func bucketIndex(value float64, schema int32) int32 {
+	if schema <= 0 {
+		// NB: each bucket spans 2^-schema powers of two and the powers of two
+		// are the upper bounds of their buckets.
+		frac, exp := math.Frexp(value)
+		if frac == 0.5 {
+			exp--
+		}
+		return ((int32(exp) - 1) >> uint(-schema)) + 1
+	}
+
+	idx := int32(math.Ceil(math.Log2(value) * float64(int32(1)<<uint(schema))))
+	// NB: the logarithm can be off by one ulp, the bounds are the reference.
+	for upperBound(idx, schema) < value {
+		idx++
+	}
+	for upperBound(idx-1, schema) >= value {
+		idx--
+	}
+	return idx
+}
+
+// upperBound returns the upper bound of the positive bucket with the given
+// index.
+func upperBound(idx, schema int32) float64 {
+	if schema <= 0 {
+		return math.Ldexp(1, int(idx)<<uint(-schema))
+	}
+	return math.Exp2(float64(idx) / float64(int32(1)<<uint(schema)))
+}
+
+// downscaleIndex returns the index of the bucket holding the bucket with the
+// given index once the schema is reduced by delta.
+func downscaleIndex(idx, delta int32) int32 {
+	if delta <= 0 {
+		return idx
+	}
+	return ((idx - 1) >> uint(delta)) + 1
+}
+
+func downscaleBuckets(buckets map[int32]float64, delta int32) map[int32]float64 {
+	if delta <= 0 || len(buckets) == 0 {
+		return buckets
+	}
+	downscaled := make(map[int32]float64, len(buckets))
+	for idx, count := range buckets {
+		downscaled[downscaleIndex(idx, delta)] += count
+	}
+	return downscaled
+}
+
+func sortedIndexes(buckets map[int32]float64) []int32 {
+	indexes := make([]int32, 0, len(buckets))
+	for idx := range buckets {
+		indexes = append(indexes, idx)
+	}
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+	return indexes
+}
+
+func toSpans(buckets map[int32]float64) ([]native.Span, []float64) {
+	if len(buckets) == 0 {
+		return nil, nil
+	}
+	var (
+		indexes = sortedIndexes(buckets)
+		spans   []native.Span
+		counts  = make([]float64, 0, len(indexes))
+		prev    int32
+	)
+	for i, idx := range indexes {
+		switch {
+		case i == 0:
+			spans = append(spans, native.Span{Offset: idx, Length: 1})
+		case idx == prev+1:
+			spans[len(spans)-1].Length++
+		default:
+			spans = append(spans, native.Span{Offset: idx - prev - 1, Length: 1})
+		}
+		counts = append(counts, buckets[idx])
+		prev = idx
+	}
+	return spans, counts
+}
+
+func forEachBucket(spans []native.Span, counts []float64, fn func(idx int32, count float64)) {
+	var (
+		idx int32
+		i   int
+	)
+	for _, span := range spans {
+		idx += span.Offset
+		for j := uint32(0); j < span.Length && i < len(counts); j++ {
+			fn(idx, counts[i])
+			idx++
+			i++
+		}
+	}
+}
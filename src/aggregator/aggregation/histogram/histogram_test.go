@@ -0,0 +1,168 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package histogram
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	native "github.com/m3db/m3/src/dbnode/encoding/histogram"
+)
+
+func TestBucketIndex(t *testing.T) {
+	inputs := []struct {
+		value    float64
+		schema   int32
+		expected int32
+	}{
+		{value: 1, schema: 0, expected: 0},
+		{value: 1.5, schema: 0, expected: 1},
+		{value: 2, schema: 0, expected: 1},
+		{value: 3, schema: 0, expected: 2},
+		{value: 0.5, schema: 0, expected: -1},
+		{value: 4, schema: -1, expected: 1},
+		{value: 5, schema: -1, expected: 2},
+		{value: 16, schema: -1, expected: 2},
+		{value: 1, schema: 3, expected: 0},
+		{value: 2, schema: 3, expected: 8},
+		{value: 2.0001, schema: 3, expected: 9},
+		{value: 1.5, schema: 1, expected: 2},
+	}
+	for _, input := range inputs {
+		idx := bucketIndex(input.value, input.schema)
+		require.Equal(t, input.expected, idx, "value=%v schema=%d", input.value, input.schema)
+		require.True(t, upperBound(idx-1, input.schema) < input.value)
+		require.True(t, upperBound(idx, input.schema) >= input.value)
+	}
+}
+
+func TestHistogramAdd(t *testing.T) {
+	h := NewHistogram(NewOptions().SetSchema(0))
+	for _, v := range []float64{1, 2, 3, 0, -1, math.NaN()} {
+		h.Add(v)
+	}
+
+	require.Equal(t, 5.0, h.Count())
+	require.Equal(t, 5.0, h.Sum())
+	require.Equal(t, 15.0, h.SumSq())
+	require.Equal(t, -1.0, h.Min())
+	require.Equal(t, 3.0, h.Max())
+
+	n := h.Native()
+	require.NoError(t, n.Validate())
+	require.Equal(t, native.GaugeType, n.CounterResetHint)
+	require.Equal(t, 1.0, n.ZeroCount)
+	require.Equal(t, []native.Span{{Offset: 0, Length: 3}}, n.PositiveSpans)
+	require.Equal(t, []float64{1, 1, 1}, n.PositiveBuckets)
+	require.Equal(t, []native.Span{{Offset: 0, Length: 1}}, n.NegativeSpans)
+	require.Equal(t, []float64{1}, n.NegativeBuckets)
+}
+
+func TestHistogramReducesSchemaOverMaxBuckets(t *testing.T) {
+	h := NewHistogram(NewOptions().SetSchema(8).SetMaxBuckets(10))
+	for i := 1; i <= 1000; i++ {
+		h.Add(float64(i))
+	}
+
+	require.True(t, h.NumBuckets() <= 10)
+	require.True(t, h.Schema() < 8)
+	require.Equal(t, 1000.0, h.Count())
+
+	n := h.Native()
+	require.NoError(t, n.Validate())
+	var total float64
+	for _, c := range n.PositiveBuckets {
+		total += c
+	}
+	require.Equal(t, 1000.0, total)
+}
+
+func TestHistogramMerge(t *testing.T) {
+	var (
+		opts = NewOptions()
+		h1   = NewHistogram(opts.SetSchema(3))
+		h2   = NewHistogram(opts.SetSchema(1).SetZeroThreshold(0.5))
+		all  = NewHistogram(opts.SetSchema(1).SetZeroThreshold(0.5))
+	)
+	for i := 0; i < 100; i++ {
+		v := float64(i) / 10
+		h1.Add(v)
+		all.Add(v)
+		h2.Add(-v)
+		all.Add(-v)
+	}
+
+	h1.Merge(h2)
+	require.Equal(t, int32(1), h1.Schema())
+	require.Equal(t, all.Count(), h1.Count())
+	require.InDelta(t, all.Sum(), h1.Sum(), 1e-9)
+	require.Equal(t, all.Min(), h1.Min())
+	require.Equal(t, all.Max(), h1.Max())
+	require.Equal(t, all.Native(), h1.Native())
+}
+
+func TestHistogramMergeNative(t *testing.T) {
+	var (
+		opts = NewOptions().SetSchema(2)
+		h1   = NewHistogram(opts)
+		h2   = NewHistogram(opts)
+	)
+	for i := 1; i <= 100; i++ {
+		h1.Add(float64(i))
+	}
+
+	require.NoError(t, h2.MergeNative(h1.Native()))
+	require.Equal(t, h1.Count(), h2.Count())
+	require.Equal(t, h1.Sum(), h2.Sum())
+	require.True(t, math.IsNaN(h2.SumSq()))
+	require.True(t, h2.Min() <= 1)
+	require.True(t, h2.Max() >= 100)
+	require.Equal(t, h1.Native(), h2.Native())
+
+	require.Error(t, h2.MergeNative(native.Histogram{Schema: native.MaxSchema + 1}))
+}
+
+func TestHistogramQuantile(t *testing.T) {
+	h := NewHistogram(NewOptions())
+	require.Equal(t, 0.0, h.Quantile(0.5))
+
+	var (
+		rnd    = rand.New(rand.NewSource(0)) //nolint:gosec
+		values = make([]float64, 10000)
+	)
+	for i := range values {
+		values[i] = rnd.NormFloat64() * 100
+		h.Add(values[i])
+	}
+	sort.Float64s(values)
+
+	require.Equal(t, values[0], h.Quantile(0))
+	require.Equal(t, values[len(values)-1], h.Quantile(1))
+	for _, q := range []float64{0.1, 0.25, 0.5, 0.75, 0.9, 0.99} {
+		expected := values[int(q*float64(len(values)))]
+		// NB: with schema 3 the relative width of each bucket is ~9%.
+		require.InDelta(t, expected, h.Quantile(q), math.Abs(expected)*0.1+1, "q=%v", q)
+	}
+}
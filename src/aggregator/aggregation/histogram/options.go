@@ -0,0 +1,133 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package histogram
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	native "github.com/m3db/m3/src/dbnode/encoding/histogram"
+)
+
+const (
+	// defaultSchema divides each power of two into 8 buckets, which bounds
+	// the relative error of quantiles to around 4%.
+	defaultSchema     = 3
+	defaultMaxBuckets = 160
+	// defaultZeroThreshold is the zero threshold used by Prometheus clients.
+	defaultZeroThreshold = 2.938735877055719e-39
+)
+
+var (
+	errInvalidSchema = fmt.Errorf("histogram schema must be between %d and %d",
+		native.MinSchema, native.MaxSchema)
+	errInvalidMaxBuckets    = errors.New("histogram max buckets must be positive")
+	errInvalidZeroThreshold = errors.New("histogram zero threshold must be a non-negative number")
+)
+
+// Options represent the options of histograms.
+type Options interface {
+	// SetSchema sets the schema (resolution) of new histograms, each power
+	// of two is divided into 2^schema buckets.
+	SetSchema(value int32) Options
+
+	// Schema returns the schema (resolution) of new histograms, each power
+	// of two is divided into 2^schema buckets.
+	Schema() int32
+
+	// SetMaxBuckets sets the max number of buckets of a histogram, the schema
+	// of histograms with more buckets is reduced until they fit.
+	SetMaxBuckets(value int) Options
+
+	// MaxBuckets returns the max number of buckets of a histogram, the schema
+	// of histograms with more buckets is reduced until they fit.
+	MaxBuckets() int
+
+	// SetZeroThreshold sets the threshold under which the absolute value of
+	// values is counted in the zero bucket.
+	SetZeroThreshold(value float64) Options
+
+	// ZeroThreshold returns the threshold under which the absolute value of
+	// values is counted in the zero bucket.
+	ZeroThreshold() float64
+
+	// Validate validates the options.
+	Validate() error
+}
+
+type options struct {
+	schema        int32
+	maxBuckets    int
+	zeroThreshold float64
+}
+
+// NewOptions creates a new set of histogram options.
+func NewOptions() Options {
+	return &options{
+		schema:        defaultSchema,
+		maxBuckets:    defaultMaxBuckets,
+		zeroThreshold: defaultZeroThreshold,
+	}
+}
+
+func (o *options) SetSchema(value int32) Options {
+	opts := *o
+	opts.schema = value
+	return &opts
+}
+
+func (o *options) Schema() int32 {
+	return o.schema
+}
+
+func (o *options) SetMaxBuckets(value int) Options {
+	opts := *o
+	opts.maxBuckets = value
+	return &opts
+}
+
+func (o *options) MaxBuckets() int {
+	return o.maxBuckets
+}
+
+func (o *options) SetZeroThreshold(value float64) Options {
+	opts := *o
+	opts.zeroThreshold = value
+	return &opts
+}
+
+func (o *options) ZeroThreshold() float64 {
+	return o.zeroThreshold
+}
+
+func (o *options) Validate() error {
+	if o.schema < native.MinSchema || o.schema > native.MaxSchema {
+		return errInvalidSchema
+	}
+	if o.maxBuckets <= 0 {
+		return errInvalidMaxBuckets
+	}
+	if o.zeroThreshold < 0 || math.IsNaN(o.zeroThreshold) {
+		return errInvalidZeroThreshold
+	}
+	return nil
+}
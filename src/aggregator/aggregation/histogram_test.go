@@ -0,0 +1,84 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aggregation
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/aggregator/aggregation/histogram"
+	native "github.com/m3db/m3/src/dbnode/encoding/histogram"
+	"github.com/m3db/m3/src/metrics/aggregation"
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+func TestHistogramAggregations(t *testing.T) {
+	h := NewHistogram(histogram.NewOptions(), NewOptions(instrument.NewOptions()))
+	require.Equal(t, 0.0, h.ValueOf(aggregation.Count))
+	require.Equal(t, 0.0, h.ValueOf(aggregation.Mean))
+	require.Equal(t, 0.0, h.ValueOf(aggregation.P50))
+
+	now := time.Now()
+	for i := 1; i <= 100; i++ {
+		h.Add(now.Add(time.Duration(i)*time.Second), float64(i), nil)
+	}
+	h.AddBatch(now, []float64{101, 102}, []byte("foo"))
+
+	require.Equal(t, now.Add(100*time.Second), h.LastAt())
+	require.Equal(t, 102.0, h.ValueOf(aggregation.Count))
+	require.Equal(t, 102.0, h.ValueOf(aggregation.Histogram))
+	require.Equal(t, 5253.0, h.ValueOf(aggregation.Sum))
+	require.Equal(t, 51.5, h.ValueOf(aggregation.Mean))
+	require.Equal(t, 1.0, h.ValueOf(aggregation.Min))
+	require.Equal(t, 102.0, h.ValueOf(aggregation.Max))
+	require.InDelta(t, 29.588, h.ValueOf(aggregation.Stdev), 0.001)
+	require.InDelta(t, 51.5, h.ValueOf(aggregation.P50), 5)
+	require.InDelta(t, 97, h.ValueOf(aggregation.P95), 5)
+	require.Equal(t, []byte("foo"), h.Annotation())
+
+	n, err := native.DecodeAnnotation(h.AppendHistogram(nil))
+	require.NoError(t, err)
+	require.Equal(t, 102.0, n.Count)
+	require.Equal(t, 5253.0, n.Sum)
+}
+
+func TestHistogramMergesForwardedHistograms(t *testing.T) {
+	var (
+		opts = NewOptions(instrument.NewOptions())
+		h1   = NewHistogram(histogram.NewOptions(), opts)
+		h2   = NewHistogram(histogram.NewOptions(), opts)
+		now  = time.Now()
+	)
+	h1.AddBatch(now, []float64{1, 2, 3}, nil)
+	h2.AddBatch(now, []float64{4, 5}, nil)
+
+	merged := NewHistogram(histogram.NewOptions(), opts)
+	merged.Add(now, h1.ValueOf(aggregation.Histogram), h1.AppendHistogram(nil))
+	merged.Add(now, h2.ValueOf(aggregation.Histogram), h2.AppendHistogram(nil))
+
+	require.Equal(t, 5.0, merged.ValueOf(aggregation.Count))
+	require.Equal(t, 15.0, merged.ValueOf(aggregation.Sum))
+	require.True(t, math.IsNaN(merged.ValueOf(aggregation.SumSq)))
+	require.Nil(t, merged.Annotation())
+}
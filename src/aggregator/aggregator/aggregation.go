@@ -49,6 +49,10 @@ func (a *counterAggregation) AddUnion(t time.Time, mu unaggregated.MetricUnion)
 	a.Counter.Update(t, mu.CounterVal, mu.Annotation)
 }
 
+func (a *counterAggregation) AppendHistogram(buf []byte) []byte {
+	return buf
+}
+
 // timerAggregation is a timer aggregation.
 type timerAggregation struct {
 	aggregation.Timer
@@ -70,6 +74,10 @@ func (a *timerAggregation) AddUnion(timestamp time.Time, mu unaggregated.MetricU
 	a.Timer.AddBatch(timestamp, mu.BatchTimerVal, mu.Annotation)
 }
 
+func (a *timerAggregation) AppendHistogram(buf []byte) []byte {
+	return buf
+}
+
 // gaugeAggregation is a gauge aggregation.
 type gaugeAggregation struct {
 	aggregation.Gauge
@@ -91,3 +99,28 @@ func (a *gaugeAggregation) UpdateVal(t time.Time, value float64, prevValue float
 func (a *gaugeAggregation) AddUnion(t time.Time, mu unaggregated.MetricUnion) {
 	a.Gauge.Update(t, mu.GaugeVal, mu.Annotation)
 }
+
+func (a *gaugeAggregation) AppendHistogram(buf []byte) []byte {
+	return buf
+}
+
+// histogramAggregation is a histogram aggregation.
+type histogramAggregation struct {
+	aggregation.Histogram
+}
+
+func newHistogramAggregation(h aggregation.Histogram) histogramAggregation {
+	return histogramAggregation{Histogram: h}
+}
+
+func (a *histogramAggregation) Add(timestamp time.Time, value float64, annotation []byte) {
+	a.Histogram.Add(timestamp, value, annotation)
+}
+
+func (a *histogramAggregation) UpdateVal(t time.Time, value float64, prevValue float64) error {
+	return errors.New("histograms do not support updating values")
+}
+
+func (a *histogramAggregation) AddUnion(timestamp time.Time, mu unaggregated.MetricUnion) {
+	a.Histogram.AddBatch(timestamp, mu.BatchTimerVal, mu.Annotation)
+}
@@ -89,6 +89,16 @@ type Aggregator interface {
 	// Status returns the run-time status of the aggregator.
 	Status() RuntimeStatus
 
+	// ShardSummaries returns the summaries of the shards owned by the aggregator
+	// with up to topN entries of each shard by cardinality.
+	ShardSummaries(topN int) []ShardSummary
+
+	// EntrySummaries returns the summaries of up to limit entries of a shard.
+	EntrySummaries(shard uint32, limit int) ([]EntrySummary, error)
+
+	// EntrySnapshots returns the snapshots of the entries of a metric ID.
+	EntrySnapshots(id id.RawID) ([]EntrySnapshot, error)
+
 	// Close closes the aggregator.
 	Close() error
 }
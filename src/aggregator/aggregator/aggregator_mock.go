@@ -33,6 +33,7 @@ import (
 	"github.com/m3db/m3/src/cluster/shard"
 	"github.com/m3db/m3/src/metrics/metadata"
 	"github.com/m3db/m3/src/metrics/metric/aggregated"
+	"github.com/m3db/m3/src/metrics/metric/id"
 	"github.com/m3db/m3/src/metrics/metric/unaggregated"
 	"github.com/m3db/m3/src/metrics/policy"
 	"github.com/m3db/m3/src/x/watch"
@@ -147,6 +148,36 @@ func (mr *MockAggregatorMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockAggregator)(nil).Close))
 }
 
+// EntrySnapshots mocks base method.
+func (m *MockAggregator) EntrySnapshots(arg0 id.RawID) ([]EntrySnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EntrySnapshots", arg0)
+	ret0, _ := ret[0].([]EntrySnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EntrySnapshots indicates an expected call of EntrySnapshots.
+func (mr *MockAggregatorMockRecorder) EntrySnapshots(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EntrySnapshots", reflect.TypeOf((*MockAggregator)(nil).EntrySnapshots), arg0)
+}
+
+// EntrySummaries mocks base method.
+func (m *MockAggregator) EntrySummaries(arg0 uint32, arg1 int) ([]EntrySummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EntrySummaries", arg0, arg1)
+	ret0, _ := ret[0].([]EntrySummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EntrySummaries indicates an expected call of EntrySummaries.
+func (mr *MockAggregatorMockRecorder) EntrySummaries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EntrySummaries", reflect.TypeOf((*MockAggregator)(nil).EntrySummaries), arg0, arg1)
+}
+
 // Open mocks base method.
 func (m *MockAggregator) Open() error {
 	m.ctrl.T.Helper()
@@ -175,6 +206,20 @@ func (mr *MockAggregatorMockRecorder) Resign() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resign", reflect.TypeOf((*MockAggregator)(nil).Resign))
 }
 
+// ShardSummaries mocks base method.
+func (m *MockAggregator) ShardSummaries(arg0 int) []ShardSummary {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShardSummaries", arg0)
+	ret0, _ := ret[0].([]ShardSummary)
+	return ret0
+}
+
+// ShardSummaries indicates an expected call of ShardSummaries.
+func (mr *MockAggregatorMockRecorder) ShardSummaries(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShardSummaries", reflect.TypeOf((*MockAggregator)(nil).ShardSummaries), arg0)
+}
+
 // Status mocks base method.
 func (m *MockAggregator) Status() RuntimeStatus {
 	m.ctrl.T.Helper()
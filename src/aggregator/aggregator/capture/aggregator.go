@@ -177,6 +177,16 @@ func (agg *aggregator) Resign() error              { return nil }
 func (agg *aggregator) Status() aggr.RuntimeStatus { return aggr.RuntimeStatus{} }
 func (agg *aggregator) Close() error               { return nil }
 
+func (agg *aggregator) ShardSummaries(topN int) []aggr.ShardSummary { return nil }
+
+func (agg *aggregator) EntrySummaries(shard uint32, limit int) ([]aggr.EntrySummary, error) {
+	return nil, nil
+}
+
+func (agg *aggregator) EntrySnapshots(id id.RawID) ([]aggr.EntrySnapshot, error) {
+	return nil, nil
+}
+
 func (agg *aggregator) NumMetricsAdded() int {
 	agg.RLock()
 	numMetricsAdded := agg.numMetricsAdded
@@ -27,10 +27,12 @@ package aggregator
 import (
 	"fmt"
 	"math"
+	"sort"
 	"sync"
 	"time"
 
 	raggregation "github.com/m3db/m3/src/aggregator/aggregation"
+	maggregation "github.com/m3db/m3/src/metrics/aggregation"
 	"github.com/m3db/m3/src/metrics/metadata"
 	"github.com/m3db/m3/src/metrics/metric/aggregated"
 	"github.com/m3db/m3/src/metrics/metric/unaggregated"
@@ -502,6 +504,9 @@ func (e *CounterElem) appendConsumeStateWithLock(
 		cState.values = append(cState.values, agg.lockedAgg.aggregation.ValueOf(aggType))
 	}
 	cState.annotation = raggregation.MaybeReplaceAnnotation(cState.annotation, agg.lockedAgg.aggregation.Annotation())
+	if e.aggTypes.Contains(maggregation.Histogram) {
+		cState.histogram = agg.lockedAgg.aggregation.AppendHistogram(cState.histogram)
+	}
 	agg.lockedAgg.dirty = false
 	agg.lockedAgg.mtx.Unlock()
 
@@ -526,6 +531,39 @@ func (e *CounterElem) appendConsumeStateWithLock(
 	return toConsume, true
 }
 
+// Snapshot returns a snapshot of the element and its pending aggregations.
+func (e *CounterElem) Snapshot() ElemSnapshot {
+	e.RLock()
+	defer e.RUnlock()
+
+	snapshot := e.snapshotWithLock()
+	snapshot.Type = e.Type().String()
+	snapshot.Aggregations = make([]AggregationSnapshot, 0, len(e.values))
+	for _, agg := range e.values {
+		agg.lockedAgg.mtx.Lock()
+		if agg.lockedAgg.closed {
+			agg.lockedAgg.mtx.Unlock()
+			continue
+		}
+		values := make(map[string]SnapshotValue, len(e.aggTypes))
+		for _, aggType := range e.aggTypes {
+			values[aggType.String()] = SnapshotValue(agg.lockedAgg.aggregation.ValueOf(aggType))
+		}
+		snapshot.Aggregations = append(snapshot.Aggregations, AggregationSnapshot{
+			StartAt:       agg.startAt.ToTime(),
+			LastUpdatedAt: agg.lockedAgg.lastUpdatedAt.ToTime(),
+			Dirty:         agg.lockedAgg.dirty,
+			ResendEnabled: agg.lockedAgg.resendEnabled,
+			Values:        values,
+		})
+		agg.lockedAgg.mtx.Unlock()
+	}
+	sort.Slice(snapshot.Aggregations, func(i, j int) bool {
+		return snapshot.Aggregations[i].StartAt.Before(snapshot.Aggregations[j].StartAt)
+	})
+	return snapshot
+}
+
 // Close closes the element.
 func (e *CounterElem) Close() {
 	e.Lock()
@@ -767,6 +805,12 @@ func (e *CounterElem) processValue(
 	for aggTypeIdx, aggType := range e.aggTypes {
 		var extraDp transformation.Datapoint
 		value := cState.values[aggTypeIdx]
+		annotation := cState.annotation
+		if aggType == maggregation.Histogram {
+			// NB: the histogram aggregation is flushed as a native histogram
+			// sample whose value is the count of the histogram.
+			annotation = cState.histogram
+		}
 		for _, transformOp := range transformations {
 			unaryOp, isUnaryOp := transformOp.UnaryTransform()
 			binaryOp, isBinaryOp := transformOp.BinaryTransform()
@@ -860,17 +904,17 @@ func (e *CounterElem) processValue(
 			for _, point := range toFlush {
 				switch e.idPrefixSuffixType {
 				case NoPrefixNoSuffix:
-					flushLocalFn(nil, e.id, nil, point.TimeNanos, point.Value, cState.annotation,
+					flushLocalFn(nil, e.id, nil, point.TimeNanos, point.Value, annotation,
 						e.sp, e.routePolicy)
 				case WithPrefixWithSuffix:
 					flushLocalFn(e.FullPrefix(e.opts), e.id, e.TypeStringFor(e.aggTypesOpts, aggType),
-						point.TimeNanos, point.Value, cState.annotation, e.sp, e.routePolicy)
+						point.TimeNanos, point.Value, annotation, e.sp, e.routePolicy)
 				}
 			}
 		} else {
 			forwardedAggregationKey, _ := e.ForwardedAggregationKey()
 			flushForwardedFn(e.writeForwardedMetricFn, forwardedAggregationKey,
-				int64(timestamp), value, prevValue, cState.annotation, cState.resendEnabled, e.routePolicy)
+				int64(timestamp), value, prevValue, annotation, cState.resendEnabled, e.routePolicy)
 		}
 		// add latenessAllowed and jitter to the timestamp of the aggregation, since those should not be
 		// counted towards the processing lag.
@@ -0,0 +1,347 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aggregator
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	schema "github.com/m3db/m3/src/aggregator/generated/proto/flush"
+	"github.com/m3db/m3/src/aggregator/hash"
+	"github.com/m3db/m3/src/metrics/metric"
+	"github.com/m3db/m3/src/metrics/metric/id"
+	xerrors "github.com/m3db/m3/src/x/errors"
+)
+
+// ShardSummary is a summary of a shard owned by the aggregator.
+type ShardSummary struct {
+	Shard      uint32         `json:"shard"`
+	NumEntries int            `json:"numEntries"`
+	TopEntries []EntrySummary `json:"topEntries,omitempty"`
+}
+
+// EntrySummary is a summary of an entry, the cardinality of an entry is the
+// number of aggregated series produced by its elements.
+type EntrySummary struct {
+	ID          string `json:"id"`
+	MetricType  string `json:"metricType"`
+	Category    string `json:"category"`
+	NumElems    int    `json:"numElems"`
+	Cardinality int    `json:"cardinality"`
+}
+
+// EntrySnapshot is a snapshot of an entry and its elements.
+type EntrySnapshot struct {
+	EntrySummary
+	Shard uint32         `json:"shard"`
+	Elems []ElemSnapshot `json:"elems"`
+}
+
+// ElemSnapshot is a snapshot of an element and its pending aggregations.
+type ElemSnapshot struct {
+	Type              string                `json:"type"`
+	StoragePolicy     string                `json:"storagePolicy"`
+	ListType          string                `json:"listType"`
+	AggregationTypes  []string              `json:"aggregationTypes"`
+	Pipeline          string                `json:"pipeline"`
+	NumForwardedTimes int                   `json:"numForwardedTimes"`
+	ResendEnabled     bool                  `json:"resendEnabled"`
+	Tombstoned        bool                  `json:"tombstoned"`
+	Forwarded         *ForwardedSnapshot    `json:"forwarded,omitempty"`
+	LastFlushedAt     *time.Time            `json:"lastFlushedAt,omitempty"`
+	Aggregations      []AggregationSnapshot `json:"aggregations"`
+
+	listType   metricListType
+	resolution time.Duration
+}
+
+// ForwardedSnapshot describes the metric an element forwards its aggregated
+// values to.
+type ForwardedSnapshot struct {
+	ID                string `json:"id"`
+	Pipeline          string `json:"pipeline"`
+	NumForwardedTimes int    `json:"numForwardedTimes"`
+}
+
+// AggregationSnapshot is a snapshot of an aggregation pending flush, the
+// values are keyed by aggregation type.
+type AggregationSnapshot struct {
+	StartAt       time.Time                `json:"startAt"`
+	LastUpdatedAt time.Time                `json:"lastUpdatedAt"`
+	Dirty         bool                     `json:"dirty"`
+	ResendEnabled bool                     `json:"resendEnabled"`
+	Values        map[string]SnapshotValue `json:"values"`
+}
+
+// SnapshotValue is an aggregated value, values that are not finite are
+// marshalled as JSON strings since JSON numbers cannot represent them.
+type SnapshotValue float64
+
+// MarshalJSON marshals the value as JSON.
+func (v SnapshotValue) MarshalJSON() ([]byte, error) {
+	f := float64(v)
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return []byte(strconv.Quote(strconv.FormatFloat(f, 'g', -1, 64))), nil
+	}
+	return []byte(strconv.FormatFloat(f, 'g', -1, 64)), nil
+}
+
+func (agg *aggregator) ShardSummaries(topN int) []ShardSummary {
+	agg.RLock()
+	shards := make([]*aggregatorShard, 0, len(agg.shardIDs))
+	for _, shardID := range agg.shardIDs {
+		if shard := agg.shards[shardID]; shard != nil {
+			shards = append(shards, shard)
+		}
+	}
+	agg.RUnlock()
+
+	summaries := make([]ShardSummary, 0, len(shards))
+	for _, shard := range shards {
+		summaries = append(summaries, shard.metricMap.summary(topN))
+	}
+	return summaries
+}
+
+func (agg *aggregator) EntrySummaries(shardID uint32, limit int) ([]EntrySummary, error) {
+	agg.RLock()
+	var shard *aggregatorShard
+	if int(shardID) < len(agg.shards) {
+		shard = agg.shards[shardID]
+	}
+	agg.RUnlock()
+
+	if shard == nil {
+		return nil, xerrors.NewInvalidParamsError(errShardNotOwned)
+	}
+	return shard.metricMap.entrySummaries(limit), nil
+}
+
+func (agg *aggregator) EntrySnapshots(metricID id.RawID) ([]EntrySnapshot, error) {
+	shard, err := agg.shardFor(metricID)
+	if err != nil {
+		return nil, xerrors.NewInvalidParamsError(err)
+	}
+
+	// NB: flush times are only used to report when the elements were last
+	// flushed so the snapshots are still returned if they cannot be read.
+	var shardFlushTimes *schema.ShardFlushTimes
+	if flushTimes, err := agg.flushTimesManager.Get(); err == nil && flushTimes != nil {
+		shardFlushTimes = flushTimes.ByShard[shard.ID()]
+	}
+
+	snapshots := shard.metricMap.entrySnapshots(metricID)
+	for i := range snapshots {
+		snapshots[i].Shard = shard.ID()
+		for j := range snapshots[i].Elems {
+			elem := &snapshots[i].Elems[j]
+			if nanos, ok := lastFlushedNanos(shardFlushTimes, elem); ok {
+				lastFlushedAt := time.Unix(0, nanos)
+				elem.LastFlushedAt = &lastFlushedAt
+			}
+		}
+	}
+	return snapshots, nil
+}
+
+func lastFlushedNanos(flushTimes *schema.ShardFlushTimes, elem *ElemSnapshot) (int64, bool) {
+	if flushTimes == nil {
+		return 0, false
+	}
+	resolution := int64(elem.resolution)
+	switch elem.listType {
+	case standardMetricListType:
+		nanos, ok := flushTimes.StandardByResolution[resolution]
+		return nanos, ok
+	case timedMetricListType:
+		nanos, ok := flushTimes.TimedByResolution[resolution]
+		return nanos, ok
+	case forwardedMetricListType:
+		byNumForwardedTimes, ok := flushTimes.ForwardedByResolution[resolution]
+		if !ok || byNumForwardedTimes == nil {
+			return 0, false
+		}
+		nanos, ok := byNumForwardedTimes.ByNumForwardedTimes[int32(elem.NumForwardedTimes)]
+		return nanos, ok
+	default:
+		return 0, false
+	}
+}
+
+// summary returns the summary of the map with its topN entries by
+// cardinality.
+func (m *metricMap) summary(topN int) ShardSummary {
+	var (
+		numEntries int
+		top        = make(entrySummaryHeap, 0, topN)
+	)
+	m.forEachEntry(func(entry hashedEntry) {
+		summary, ok := entry.entry.summary(entry.key)
+		if !ok {
+			return
+		}
+		numEntries++
+		if topN <= 0 {
+			return
+		}
+		if len(top) < topN {
+			heap.Push(&top, summary)
+		} else if summary.Cardinality > top[0].Cardinality {
+			top[0] = summary
+			heap.Fix(&top, 0)
+		}
+	})
+	sort.Slice(top, func(i, j int) bool { return top[i].Cardinality > top[j].Cardinality })
+	return ShardSummary{
+		Shard:      m.shard,
+		NumEntries: numEntries,
+		TopEntries: top,
+	}
+}
+
+// entrySummaries returns the summaries of up to limit entries, all entries
+// are returned if limit is not positive.
+func (m *metricMap) entrySummaries(limit int) []EntrySummary {
+	var summaries []EntrySummary
+	m.forEachEntry(func(entry hashedEntry) {
+		if limit > 0 && len(summaries) >= limit {
+			return
+		}
+		if summary, ok := entry.entry.summary(entry.key); ok {
+			summaries = append(summaries, summary)
+		}
+	})
+	return summaries
+}
+
+// entrySnapshots returns the snapshots of the entries of a metric ID, there
+// is an entry for each metric type and category the ID was written with.
+func (m *metricMap) entrySnapshots(metricID id.RawID) []EntrySnapshot {
+	idHash := hash.Murmur3Hash128(metricID)
+	var entries []hashedEntry
+	m.RLock()
+	for _, mt := range metric.ValidTypes {
+		for _, category := range validMetricCategories {
+			key := entryKey{
+				idHash:         idHash,
+				metricType:     metricType(mt),
+				metricCategory: category,
+			}
+			if entry, ok := m.lookupEntryWithLock(key); ok {
+				entries = append(entries, hashedEntry{key: key, entry: entry})
+			}
+		}
+	}
+	m.RUnlock()
+
+	snapshots := make([]EntrySnapshot, 0, len(entries))
+	for _, entry := range entries {
+		if snapshot, ok := entry.entry.snapshot(entry.key); ok {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	return snapshots
+}
+
+// summary returns the summary of the entry, or false if the entry is closed.
+func (e *Entry) summary(key entryKey) (EntrySummary, bool) {
+	e.mtx.RLock()
+	defer e.mtx.RUnlock()
+
+	if e.closed {
+		return EntrySummary{}, false
+	}
+	summary := EntrySummary{
+		MetricType: metric.Type(key.metricType).String(),
+		Category:   key.metricCategory.String(),
+		NumElems:   len(e.aggregations),
+	}
+	for _, agg := range e.aggregations {
+		elem, ok := agg.elem.Value.(metricElem)
+		if !ok {
+			continue
+		}
+		if summary.ID == "" {
+			summary.ID = string(elem.ID())
+		}
+		summary.Cardinality += elem.numAggregationTypes()
+	}
+	return summary, true
+}
+
+// snapshot returns the snapshot of the entry, or false if the entry is
+// closed.
+func (e *Entry) snapshot(key entryKey) (EntrySnapshot, bool) {
+	// NB: the elements are snapshotted with the entry locked since elements
+	// are only tombstoned, and then collected and returned to their pool by
+	// their list, with the entry lock held.
+	e.mtx.RLock()
+	defer e.mtx.RUnlock()
+
+	if e.closed {
+		return EntrySnapshot{}, false
+	}
+	snapshot := EntrySnapshot{
+		EntrySummary: EntrySummary{
+			MetricType: metric.Type(key.metricType).String(),
+			Category:   key.metricCategory.String(),
+			NumElems:   len(e.aggregations),
+		},
+		Elems: make([]ElemSnapshot, 0, len(e.aggregations)),
+	}
+	for _, agg := range e.aggregations {
+		elem, ok := agg.elem.Value.(metricElem)
+		if !ok {
+			continue
+		}
+		elemSnapshot := elem.Snapshot()
+		elemSnapshot.Pipeline = agg.key.pipeline.String()
+		elemSnapshot.ResendEnabled = agg.resendEnabled
+		if snapshot.ID == "" {
+			snapshot.ID = string(elem.ID())
+		}
+		snapshot.Cardinality += len(elemSnapshot.AggregationTypes)
+		snapshot.Elems = append(snapshot.Elems, elemSnapshot)
+	}
+	return snapshot, true
+}
+
+// entrySummaryHeap is a min heap of entry summaries by cardinality.
+type entrySummaryHeap []EntrySummary
+
+func (h entrySummaryHeap) Len() int           { return len(h) }
+func (h entrySummaryHeap) Less(i, j int) bool { return h[i].Cardinality < h[j].Cardinality }
+func (h entrySummaryHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *entrySummaryHeap) Push(x interface{}) {
+	*h = append(*h, x.(EntrySummary))
+}
+
+func (h *entrySummaryHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
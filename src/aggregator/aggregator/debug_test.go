@@ -0,0 +1,223 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aggregator
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	schema "github.com/m3db/m3/src/aggregator/generated/proto/flush"
+	"github.com/m3db/m3/src/metrics/aggregation"
+	"github.com/m3db/m3/src/metrics/metadata"
+	"github.com/m3db/m3/src/metrics/metric"
+	"github.com/m3db/m3/src/metrics/metric/unaggregated"
+	"github.com/m3db/m3/src/metrics/policy"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+var (
+	testDebugStoragePolicies = []policy.StoragePolicy{
+		policy.NewStoragePolicy(10*time.Second, xtime.Second, 6*time.Hour),
+		policy.NewStoragePolicy(time.Minute, xtime.Minute, 2*24*time.Hour),
+	}
+	testDebugMetadatas = metadata.StagedMetadatas{
+		{
+			Metadata: metadata.Metadata{
+				Pipelines: []metadata.PipelineMetadata{
+					{
+						AggregationID:   aggregation.DefaultID,
+						StoragePolicies: testDebugStoragePolicies,
+					},
+				},
+			},
+		},
+	}
+	testDebugSingleMetadatas = metadata.StagedMetadatas{
+		{
+			Metadata: metadata.Metadata{
+				Pipelines: []metadata.PipelineMetadata{
+					{
+						AggregationID:   aggregation.DefaultID,
+						StoragePolicies: testDebugStoragePolicies[:1],
+					},
+				},
+			},
+		},
+	}
+)
+
+func TestAggregatorShardSummaries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	agg, _ := testAggregator(t, ctrl)
+	require.NoError(t, agg.Open())
+	agg.shardFn = func([]byte, uint32) uint32 { return 1 }
+
+	require.NoError(t, agg.AddUntimed(unaggregated.MetricUnion{
+		Type:       metric.CounterType,
+		ID:         []byte("foo"),
+		CounterVal: 1234,
+	}, testDebugMetadatas))
+	require.NoError(t, agg.AddUntimed(unaggregated.MetricUnion{
+		Type:       metric.CounterType,
+		ID:         []byte("bar"),
+		CounterVal: 5678,
+	}, testDebugSingleMetadatas))
+
+	summaries := agg.ShardSummaries(1)
+	require.Equal(t, testNumShards, len(summaries))
+	for _, summary := range summaries {
+		if summary.Shard != 1 {
+			require.Equal(t, 0, summary.NumEntries)
+			require.Empty(t, summary.TopEntries)
+			continue
+		}
+		require.Equal(t, 2, summary.NumEntries)
+		require.Equal(t, []EntrySummary{
+			{
+				ID:          "foo",
+				MetricType:  "counter",
+				Category:    "untimed",
+				NumElems:    2,
+				Cardinality: 2,
+			},
+		}, summary.TopEntries)
+	}
+
+	entries, err := agg.EntrySummaries(1, 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(entries))
+
+	entries, err = agg.EntrySummaries(1, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(entries))
+}
+
+func TestAggregatorEntrySummariesShardNotOwned(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	agg, _ := testAggregator(t, ctrl)
+	require.NoError(t, agg.Open())
+
+	_, err := agg.EntrySummaries(testNumShards+1, 0)
+	require.Error(t, err)
+	require.True(t, xerrors.IsInvalidParams(err))
+}
+
+func TestAggregatorEntrySnapshots(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	agg, _ := testAggregator(t, ctrl)
+	require.NoError(t, agg.Open())
+	agg.shardFn = func([]byte, uint32) uint32 { return 1 }
+
+	lastFlushedNanos := time.Now().Truncate(time.Minute).UnixNano()
+	flushTimesManager := NewMockFlushTimesManager(ctrl)
+	flushTimesManager.EXPECT().Get().Return(&schema.ShardSetFlushTimes{
+		ByShard: map[uint32]*schema.ShardFlushTimes{
+			1: {
+				StandardByResolution: map[int64]int64{
+					int64(10 * time.Second): lastFlushedNanos,
+				},
+			},
+		},
+	}, nil)
+	agg.flushTimesManager = flushTimesManager
+
+	require.NoError(t, agg.AddUntimed(unaggregated.MetricUnion{
+		Type:       metric.CounterType,
+		ID:         []byte("foo"),
+		CounterVal: 1234,
+	}, testDebugMetadatas))
+
+	snapshots, err := agg.EntrySnapshots([]byte("foo"))
+	require.NoError(t, err)
+	require.Equal(t, 1, len(snapshots))
+
+	snapshot := snapshots[0]
+	require.Equal(t, uint32(1), snapshot.Shard)
+	require.Equal(t, EntrySummary{
+		ID:          "foo",
+		MetricType:  "counter",
+		Category:    "untimed",
+		NumElems:    2,
+		Cardinality: 2,
+	}, snapshot.EntrySummary)
+	require.Equal(t, 2, len(snapshot.Elems))
+
+	for _, elem := range snapshot.Elems {
+		require.Equal(t, "counter", elem.Type)
+		require.Equal(t, standardMetricListType.String(), elem.ListType)
+		require.Equal(t, []string{aggregation.Sum.String()}, elem.AggregationTypes)
+		require.Nil(t, elem.Forwarded)
+		require.Equal(t, 1, len(elem.Aggregations))
+		require.Equal(t, map[string]SnapshotValue{
+			aggregation.Sum.String(): 1234,
+		}, elem.Aggregations[0].Values)
+		require.True(t, elem.Aggregations[0].Dirty)
+
+		switch elem.StoragePolicy {
+		case testDebugStoragePolicies[0].String():
+			require.NotNil(t, elem.LastFlushedAt)
+			require.Equal(t, lastFlushedNanos, elem.LastFlushedAt.UnixNano())
+		case testDebugStoragePolicies[1].String():
+			require.Nil(t, elem.LastFlushedAt)
+		default:
+			require.FailNow(t, "unexpected storage policy", elem.StoragePolicy)
+		}
+	}
+}
+
+func TestAggregatorEntrySnapshotsNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	agg, _ := testAggregator(t, ctrl)
+	require.NoError(t, agg.Open())
+	agg.shardFn = func([]byte, uint32) uint32 { return 1 }
+
+	flushTimesManager := NewMockFlushTimesManager(ctrl)
+	flushTimesManager.EXPECT().Get().Return(nil, nil)
+	agg.flushTimesManager = flushTimesManager
+
+	snapshots, err := agg.EntrySnapshots([]byte("foo"))
+	require.NoError(t, err)
+	require.Empty(t, snapshots)
+}
+
+func TestSnapshotValueMarshalJSON(t *testing.T) {
+	b, err := json.Marshal(map[string]SnapshotValue{
+		"finite": 1.5,
+		"nan":    SnapshotValue(math.NaN()),
+		"inf":    SnapshotValue(math.Inf(-1)),
+	})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"finite":1.5,"nan":"NaN","inf":"-Inf"}`, string(b))
+}
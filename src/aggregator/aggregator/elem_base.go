@@ -156,6 +156,12 @@ type metricElem interface {
 	// will be deleted once its aggregated values have been flushed.
 	MarkAsTombstoned()
 
+	// Snapshot returns a snapshot of the element and its pending aggregations.
+	Snapshot() ElemSnapshot
+
+	// numAggregationTypes returns the number of aggregation types of the element.
+	numAggregationTypes() int
+
 	// Close closes the element.
 	Close()
 }
@@ -208,6 +214,9 @@ type elemBase struct {
 type consumeState struct {
 	// the annotation copied from the lockedAgg.
 	annotation []byte
+	// the encoded native histogram copied from the lockedAgg, only set for
+	// elems with the histogram aggregation type.
+	histogram []byte
 	// the values copied from the lockedAgg.
 	values []float64
 	// the start time of the aggregation.
@@ -227,6 +236,7 @@ type consumeState struct {
 func (c *consumeState) Reset() {
 	*c = consumeState{
 		annotation: c.annotation[:0],
+		histogram:  c.histogram[:0],
 		values:     c.values[:0],
 	}
 }
@@ -522,6 +532,38 @@ func (e *elemBase) MarkAsTombstoned() {
 	e.Unlock()
 }
 
+func (e *elemBase) numAggregationTypes() int {
+	e.RLock()
+	n := len(e.aggTypes)
+	e.RUnlock()
+	return n
+}
+
+// snapshotWithLock returns a snapshot of the element base.
+func (e *elemBase) snapshotWithLock() ElemSnapshot {
+	aggTypes := make([]string, 0, len(e.aggTypes))
+	for _, aggType := range e.aggTypes {
+		aggTypes = append(aggTypes, aggType.String())
+	}
+	snapshot := ElemSnapshot{
+		StoragePolicy:     e.sp.String(),
+		ListType:          e.listType.String(),
+		AggregationTypes:  aggTypes,
+		NumForwardedTimes: e.numForwardedTimes,
+		Tombstoned:        e.tombstoned,
+		listType:          e.listType,
+		resolution:        e.sp.Resolution().Window,
+	}
+	if e.parsedPipeline.HasRollup {
+		snapshot.Forwarded = &ForwardedSnapshot{
+			ID:                string(e.parsedPipeline.Rollup.ID),
+			Pipeline:          e.parsedPipeline.Remainder.String(),
+			NumForwardedTimes: e.numForwardedTimes + 1,
+		}
+	}
+	return snapshot
+}
+
 type counterElemBase struct{}
 
 func (e counterElemBase) Type() metric.Type { return metric.CounterType }
@@ -647,6 +689,41 @@ func (e *gaugeElemBase) ResetSetData(
 
 func (e *gaugeElemBase) Close() {}
 
+type histogramElemBase struct{}
+
+func (e histogramElemBase) Type() metric.Type { return metric.HistogramType }
+
+func (e histogramElemBase) FullPrefix(opts Options) []byte { return opts.FullTimerPrefix() }
+
+func (e histogramElemBase) DefaultAggregationTypes(aggTypesOpts maggregation.TypesOptions) maggregation.Types {
+	return aggTypesOpts.DefaultHistogramAggregationTypes()
+}
+
+func (e histogramElemBase) TypeStringFor(aggTypesOpts maggregation.TypesOptions, aggType maggregation.Type) []byte {
+	return aggTypesOpts.TypeStringForTimer(aggType)
+}
+
+func (e histogramElemBase) ElemPool(opts Options) HistogramElemPool { return opts.HistogramElemPool() }
+
+func (e histogramElemBase) NewAggregation(opts Options, aggOpts raggregation.Options) histogramAggregation {
+	return newHistogramAggregation(raggregation.NewHistogram(opts.HistogramOptions(), aggOpts))
+}
+
+func (e *histogramElemBase) ResetSetData(
+	_ maggregation.TypesOptions,
+	aggTypes maggregation.Types,
+	_ bool,
+) error {
+	// NB: histograms are built from timer values so they support the same
+	// aggregation types as timers.
+	if !aggTypes.IsValidForTimer() {
+		return fmt.Errorf("invalid aggregation types %s for histogram", aggTypes.String())
+	}
+	return nil
+}
+
+func (e *histogramElemBase) Close() {}
+
 // nolint: maligned
 type parsedPipeline struct {
 	// Whether the source pipeline contains derivative transformations at its head.
@@ -825,3 +902,22 @@ func (l *lockedTimerAggregation) close() {
 	*l = lockedTimerAggregation{}
 	lockedTimerAggregationPool.Put(l)
 }
+
+var lockedHistogramAggregationPool = sync.Pool{New: func() interface{} { return &lockedHistogramAggregation{} }}
+
+func lockedHistogramAggregationFromPool(
+	aggregation histogramAggregation,
+	sourcesSeen map[uint32]*bitset.BitSet,
+) *lockedHistogramAggregation {
+	l := lockedHistogramAggregationPool.Get().(*lockedHistogramAggregation)
+	l.aggregation = aggregation
+	l.sourcesSeen = sourcesSeen
+
+	return l
+}
+
+func (l *lockedHistogramAggregation) close() {
+	l.aggregation.Close()
+	*l = lockedHistogramAggregation{}
+	lockedHistogramAggregationPool.Put(l)
+}
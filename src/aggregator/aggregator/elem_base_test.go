@@ -291,6 +291,41 @@ func TestTimerElemBaseResetSetDataInvalidTypes(t *testing.T) {
 	require.True(t, strings.Contains(err.Error(), "invalid aggregation types Last for timer"))
 }
 
+func TestHistogramElemBase(t *testing.T) {
+	opts := newTestOptions()
+	aggTypesOpts := opts.AggregationTypesOptions()
+	e := histogramElemBase{}
+	require.Equal(t, metric.HistogramType, e.Type())
+	require.Equal(t, []byte("stats.timers."), e.FullPrefix(opts))
+	require.Equal(t, maggregation.Types{maggregation.Histogram}, e.DefaultAggregationTypes(aggTypesOpts))
+	require.Equal(t, []byte(".histogram"), e.TypeStringFor(aggTypesOpts, maggregation.Histogram))
+	require.True(t, opts.HistogramElemPool() == e.ElemPool(opts))
+}
+
+func TestHistogramElemBaseNewAggregation(t *testing.T) {
+	e := histogramElemBase{}
+	la := e.NewAggregation(newTestOptions(), raggregation.Options{})
+	la.AddUnion(time.Now(), unaggregated.MetricUnion{
+		Type:          metric.TimerType,
+		BatchTimerVal: []float64{100.0, 200.0},
+	})
+	la.AddUnion(time.Now(), unaggregated.MetricUnion{
+		Type:          metric.TimerType,
+		BatchTimerVal: []float64{300.0, 400.0, 500.0},
+	})
+	require.Equal(t, float64(300.0), la.ValueOf(maggregation.Mean))
+	require.Equal(t, float64(5.0), la.ValueOf(maggregation.Histogram))
+	require.NotEmpty(t, la.AppendHistogram(nil))
+	require.Error(t, la.UpdateVal(time.Now(), 1.0, 2.0))
+}
+
+func TestHistogramElemBaseResetSetDataInvalidTypes(t *testing.T) {
+	e := histogramElemBase{}
+	err := e.ResetSetData(nil, maggregation.Types{maggregation.Last}, false)
+	require.Error(t, err)
+	require.True(t, strings.Contains(err.Error(), "invalid aggregation types Last for histogram"))
+}
+
 func TestGaugeElemBase(t *testing.T) {
 	opts := newTestOptions()
 	aggTypesOpts := opts.AggregationTypesOptions()
@@ -34,6 +34,7 @@ import (
 
 	raggregation "github.com/m3db/m3/src/aggregator/aggregation"
 	"github.com/m3db/m3/src/aggregator/aggregation/quantile/cm"
+	"github.com/m3db/m3/src/dbnode/encoding/histogram"
 	maggregation "github.com/m3db/m3/src/metrics/aggregation"
 	"github.com/m3db/m3/src/metrics/metadata"
 	"github.com/m3db/m3/src/metrics/metric"
@@ -1291,6 +1292,57 @@ func TestTimerElemClose(t *testing.T) {
 	require.NotNil(t, e.values)
 }
 
+func TestHistogramElemConsumeHistogramAggregation(t *testing.T) {
+	elemData := testTimerElemData
+	elemData.AggTypes = maggregation.Types{maggregation.Count, maggregation.Histogram}
+	elemData.Pipeline = applied.DefaultPipeline
+	elemData.IDPrefixSuffixType = WithPrefixWithSuffix
+	e, err := NewHistogramElem(elemData, NewElemOptions(newTestOptions()))
+	require.NoError(t, err)
+
+	timestamp := time.Unix(0, testAlignedStarts[0])
+	require.NoError(t, e.AddUnion(timestamp, testBatchTimer, false))
+
+	type flushed struct {
+		suffix     []byte
+		value      float64
+		annotation []byte
+	}
+	var results []flushed
+	localFn := func(
+		_ []byte,
+		_ id.RawID,
+		idSuffix []byte,
+		_ int64,
+		value float64,
+		annotation []byte,
+		_ policy.StoragePolicy,
+		_ policy.RoutingPolicy,
+	) {
+		results = append(results, flushed{
+			suffix:     idSuffix,
+			value:      value,
+			annotation: append([]byte(nil), annotation...),
+		})
+	}
+	forwardFn, _ := testFlushForwardedMetricFn()
+	onForwardedFlushedFn, _ := testOnForwardedFlushedFn()
+	require.False(t, e.Consume(testAlignedStarts[2], isStandardMetricEarlierThan, standardMetricTimestampNanos,
+		standardMetricTargetNanos, localFn, forwardFn, onForwardedFlushedFn, 0, consumeType))
+
+	require.Equal(t, 2, len(results))
+	require.Equal(t, []byte(".count"), results[0].suffix)
+	require.Equal(t, 5.0, results[0].value)
+	require.Empty(t, results[0].annotation)
+	require.Equal(t, []byte(".histogram"), results[1].suffix)
+	require.Equal(t, 5.0, results[1].value)
+
+	h, err := histogram.DecodeAnnotation(results[1].annotation)
+	require.NoError(t, err)
+	require.Equal(t, 5.0, h.Count)
+	require.InDelta(t, 18.0, h.Sum, 1e-9)
+}
+
 func TestTimerFindOrCreateNoSourceSet(t *testing.T) {
 	e, err := NewTimerElem(testTimerElemData, NewElemOptions(newTestOptions()))
 	require.NoError(t, err)
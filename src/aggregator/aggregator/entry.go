@@ -642,9 +642,17 @@ func (e *Entry) addNewAggregationKeyWithLock(
 	case metric.CounterType:
 		newElem = e.opts.CounterElemPool().Get()
 	case metric.TimerType:
-		newElem = e.opts.TimerElemPool().Get()
+		// NB: timer values aggregated with the histogram aggregation type are
+		// kept in an exponential histogram rather than a quantile stream.
+		if aggTypes.Contains(aggregation.Histogram) {
+			newElem = e.opts.HistogramElemPool().Get()
+		} else {
+			newElem = e.opts.TimerElemPool().Get()
+		}
 	case metric.GaugeType:
 		newElem = e.opts.GaugeElemPool().Get()
+	case metric.HistogramType:
+		newElem = e.opts.HistogramElemPool().Get()
 	default:
 		return nil, errInvalidMetricType
 	}
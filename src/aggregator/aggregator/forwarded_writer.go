@@ -23,13 +23,16 @@ package aggregator
 import (
 	"errors"
 	"fmt"
+	"math"
 
 	"github.com/uber-go/tally"
 	"go.uber.org/atomic"
 
 	"github.com/m3db/m3/src/aggregator/aggregation"
+	"github.com/m3db/m3/src/aggregator/aggregation/histogram"
 	"github.com/m3db/m3/src/aggregator/client"
 	"github.com/m3db/m3/src/aggregator/hash"
+	native "github.com/m3db/m3/src/dbnode/encoding/histogram"
 	"github.com/m3db/m3/src/metrics/metadata"
 	"github.com/m3db/m3/src/metrics/metric"
 	"github.com/m3db/m3/src/metrics/metric/aggregated"
@@ -41,6 +44,10 @@ import (
 )
 
 var (
+	// NB: the schema and buckets of the merged histograms are kept as is, the
+	// aggregation receiving the forwarded histogram applies its own limits.
+	forwardedHistogramOpts = histogram.NewOptions().SetSchema(native.MaxSchema).SetMaxBuckets(math.MaxInt32)
+
 	errMetricNotFound         = errors.New("metric not found")
 	errAggregationKeyNotFound = errors.New("aggregation key not found")
 	errForwardedWriterClosed  = errors.New("forwarded metric writer is closed")
@@ -332,14 +339,43 @@ func (agg *forwardedAggregationWithKey) add(timeNanos xtime.UnixNano, value floa
 	}
 	bucket := agg.buckets[idx]
 	bucket.timeNanos = timeNanos
-	bucket.values = append(bucket.values, value)
-	bucket.prevValues = append(bucket.prevValues, prevValue)
-	bucket.annotation = aggregation.MaybeReplaceAnnotation(bucket.annotation, annotation)
+	if merged, ok := mergeHistogramAnnotations(bucket.annotation, annotation); ok && len(bucket.values) > 0 {
+		// NB: forwarded histograms are merged into a single value since the
+		// receiving aggregation would otherwise merge the histogram carried by
+		// the annotation once for each value.
+		bucket.values[0] += value
+		bucket.prevValues[0] += prevValue
+		bucket.annotation = merged
+	} else {
+		bucket.values = append(bucket.values, value)
+		bucket.prevValues = append(bucket.prevValues, prevValue)
+		bucket.annotation = aggregation.MaybeReplaceAnnotation(bucket.annotation, annotation)
+	}
 	bucket.resendEnabled = resendEnabled
 	bucket.routePolicy = routePolicy
 	agg.buckets[idx] = bucket
 }
 
+// mergeHistogramAnnotations merges two native histogram annotations into the
+// buffer of the first one, returning false if either is not a native
+// histogram annotation.
+func mergeHistogramAnnotations(dst, src []byte) ([]byte, bool) {
+	if !native.IsAnnotation(dst) || !native.IsAnnotation(src) {
+		return nil, false
+	}
+	h := histogram.NewHistogram(forwardedHistogramOpts)
+	for _, annotation := range [][]byte{dst, src} {
+		n, err := native.DecodeAnnotation(annotation)
+		if err != nil {
+			return nil, false
+		}
+		if err := h.MergeNative(n); err != nil {
+			return nil, false
+		}
+	}
+	return native.AppendAnnotation(dst[:0], h.Native()), true
+}
+
 type forwardedAggregationMetrics struct {
 	added                  tally.Counter
 	removed                tally.Counter
@@ -29,7 +29,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/m3db/m3/src/aggregator/aggregation/histogram"
 	"github.com/m3db/m3/src/aggregator/client"
+	native "github.com/m3db/m3/src/dbnode/encoding/histogram"
 	"github.com/m3db/m3/src/metrics/aggregation"
 	"github.com/m3db/m3/src/metrics/metadata"
 	"github.com/m3db/m3/src/metrics/metric"
@@ -484,6 +486,49 @@ func TestForwardedWriterPrepare(t *testing.T) {
 	require.Equal(t, 1, agg.byKey[0].currRefCnt)
 }
 
+func TestForwardedWriterMergesHistograms(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		c      = client.NewMockAdminClient(ctrl)
+		opts   = NewOptions(clock.NewOptions()).SetAdminClient(c)
+		w      = newForwardedWriter(0, opts)
+		mt     = metric.HistogramType
+		mid    = id.RawID("foo")
+		aggKey = testForwardedWriterAggregationKey
+		h1     = histogram.NewHistogram(histogram.NewOptions())
+		h2     = histogram.NewHistogram(histogram.NewOptions())
+	)
+	h1.Add(1)
+	h1.Add(2)
+	h2.Add(3)
+
+	writeFn, onDoneFn, err := w.Register(testRegisterable{
+		metricType: mt,
+		id:         mid,
+		key:        aggKey,
+	})
+	require.NoError(t, err)
+
+	writeFn(aggKey, 1234, 2, 0, native.AppendAnnotation(nil, h1.Native()), false, policy.RoutingPolicy{})
+	writeFn(aggKey, 1234, 1, 0, native.AppendAnnotation(nil, h2.Native()), false, policy.RoutingPolicy{})
+
+	var written aggregated.ForwardedMetric
+	c.EXPECT().WriteForwarded(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(m aggregated.ForwardedMetric, _ metadata.ForwardMetadata) error {
+			written = m
+			return nil
+		})
+	require.NoError(t, onDoneFn(aggKey, nil))
+
+	require.Equal(t, []float64{3}, written.Values)
+	merged, err := native.DecodeAnnotation(written.Annotation)
+	require.NoError(t, err)
+	require.Equal(t, 3.0, merged.Count)
+	require.Equal(t, 6.0, merged.Sum)
+}
+
 func TestForwardedWriterResend(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
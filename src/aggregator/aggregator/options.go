@@ -24,6 +24,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/m3db/m3/src/aggregator/aggregation/histogram"
 	"github.com/m3db/m3/src/aggregator/aggregation/quantile/cm"
 	"github.com/m3db/m3/src/aggregator/aggregator/handler"
 	"github.com/m3db/m3/src/aggregator/aggregator/handler/writer"
@@ -139,6 +140,12 @@ type Options interface {
 	// StreamOptions returns the stream options.
 	StreamOptions() cm.Options
 
+	// SetHistogramOptions sets the histogram options.
+	SetHistogramOptions(value histogram.Options) Options
+
+	// HistogramOptions returns the histogram options.
+	HistogramOptions() histogram.Options
+
 	// SetAdminClient sets the administrative client.
 	SetAdminClient(value client.AdminClient) Options
 
@@ -303,6 +310,12 @@ type Options interface {
 	// GaugeElemPool returns the gauge element pool.
 	GaugeElemPool() GaugeElemPool
 
+	// SetHistogramElemPool sets the histogram element pool.
+	SetHistogramElemPool(value HistogramElemPool) Options
+
+	// HistogramElemPool returns the histogram element pool.
+	HistogramElemPool() HistogramElemPool
+
 	/// Read-only derived options.
 
 	// FullCounterPrefix returns the full prefix for counters.
@@ -360,6 +373,7 @@ type options struct {
 	clockOpts                        clock.Options
 	instrumentOpts                   instrument.Options
 	streamOpts                       cm.Options
+	histogramOpts                    histogram.Options
 	adminClient                      client.AdminClient
 	runtimeOptsManager               runtime.OptionsManager
 	placementManager                 PlacementManager
@@ -387,6 +401,7 @@ type options struct {
 	counterElemPool                  CounterElemPool
 	timerElemPool                    TimerElemPool
 	gaugeElemPool                    GaugeElemPool
+	histogramElemPool                HistogramElemPool
 	verboseErrors                    bool
 	addToReset                       bool
 	timedMetricsFlushOffsetEnabled   bool
@@ -416,6 +431,7 @@ func NewOptions(clockOpts clock.Options) Options {
 		clockOpts:                        clockOpts,
 		instrumentOpts:                   instrument.NewOptions(),
 		streamOpts:                       cm.NewOptions(),
+		histogramOpts:                    histogram.NewOptions(),
 		runtimeOptsManager:               runtime.NewOptionsManager(runtime.NewOptions()),
 		shardFn:                          sharding.Murmur32Hash.MustShardFn(),
 		bufferDurationBeforeShardCutover: defaultBufferDurationBeforeShardCutover,
@@ -539,6 +555,16 @@ func (o *options) StreamOptions() cm.Options {
 	return o.streamOpts
 }
 
+func (o *options) SetHistogramOptions(value histogram.Options) Options {
+	opts := *o
+	opts.histogramOpts = value
+	return &opts
+}
+
+func (o *options) HistogramOptions() histogram.Options {
+	return o.histogramOpts
+}
+
 func (o *options) SetAdminClient(value client.AdminClient) Options {
 	opts := *o
 	opts.adminClient = value
@@ -809,6 +835,16 @@ func (o *options) GaugeElemPool() GaugeElemPool {
 	return o.gaugeElemPool
 }
 
+func (o *options) SetHistogramElemPool(value HistogramElemPool) Options {
+	opts := *o
+	opts.histogramElemPool = value
+	return &opts
+}
+
+func (o *options) HistogramElemPool() HistogramElemPool {
+	return o.histogramElemPool
+}
+
 func (o *options) SetVerboseErrors(value bool) Options {
 	opts := *o
 	opts.verboseErrors = value
@@ -858,6 +894,11 @@ func (o *options) initPools() {
 	o.gaugeElemPool.Init(func() *GaugeElem {
 		return MustNewGaugeElem(ElemData{}, elemOpts)
 	})
+
+	o.histogramElemPool = NewHistogramElemPool(nil)
+	o.histogramElemPool.Init(func() *HistogramElem {
+		return MustNewHistogramElem(ElemData{}, elemOpts)
+	})
 }
 
 func (o *options) computeAllDerived() {
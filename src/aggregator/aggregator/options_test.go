@@ -28,6 +28,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/m3db/m3/src/aggregator/aggregation/histogram"
 	"github.com/m3db/m3/src/aggregator/aggregation/quantile/cm"
 	"github.com/m3db/m3/src/aggregator/aggregator/handler"
 	"github.com/m3db/m3/src/aggregator/aggregator/handler/writer"
@@ -64,6 +65,7 @@ func TestOptionsValidateDefault(t *testing.T) {
 	require.NotNil(t, o.InstrumentOptions())
 	require.NotNil(t, o.TimeLock())
 	require.NotNil(t, o.StreamOptions())
+	require.NotNil(t, o.HistogramOptions())
 	require.NotNil(t, o.EntryPool())
 	require.NotNil(t, o.CounterElemPool())
 	require.NotNil(t, o.TimerElemPool())
@@ -123,6 +125,12 @@ func TestSetStreamOptions(t *testing.T) {
 	require.Equal(t, value, o.StreamOptions())
 }
 
+func TestSetHistogramOptions(t *testing.T) {
+	value := histogram.NewOptions().SetSchema(1)
+	o := newTestOptions().SetHistogramOptions(value)
+	require.Equal(t, value, o.HistogramOptions())
+}
+
 func TestSetAdminClient(t *testing.T) {
 	var c client.AdminClient = &client.M3MsgClient{}
 	o := newTestOptions().SetAdminClient(c)
@@ -236,6 +244,12 @@ func TestSetGaugeElemPool(t *testing.T) {
 	require.Equal(t, value, o.GaugeElemPool())
 }
 
+func TestSetHistogramElemPool(t *testing.T) {
+	value := NewHistogramElemPool(nil)
+	o := newTestOptions().SetHistogramElemPool(value)
+	require.Equal(t, value, o.HistogramElemPool())
+}
+
 func newTestOptions() Options {
 	return NewOptions(clock.NewOptions())
 }
@@ -24,10 +24,14 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/m3db/m3/src/aggregator/aggregator"
+	"github.com/m3db/m3/src/metrics/metric/id"
 	xerrors "github.com/m3db/m3/src/x/errors"
 )
 
@@ -36,17 +40,38 @@ const (
 	HealthPath = "/health"
 	ResignPath = "/resign"
 	StatusPath = "/status"
+
+	DebugShardsPath  = "/debug/shards"
+	DebugEntriesPath = "/debug/entries"
+	DebugElemsPath   = "/debug/elems"
+)
+
+const (
+	defaultDebugTopN  = 10
+	defaultDebugLimit = 100
+
+	// The entries returned by the debug endpoints are collected while iterating
+	// over the metric map of a shard so the number of entries is bounded.
+	maxDebugTopN  = 1000
+	maxDebugLimit = 1000
 )
 
 var (
 	errRequestMustBeGet  = xerrors.NewInvalidParamsError(errors.New("request must be GET"))
 	errRequestMustBePost = xerrors.NewInvalidParamsError(errors.New("request must be POST"))
+	errNoShard           = xerrors.NewInvalidParamsError(errors.New("shard must be specified"))
+	errNoID              = xerrors.NewInvalidParamsError(errors.New("id must be specified"))
 )
 
-func registerHandlers(mux *http.ServeMux, aggregator aggregator.Aggregator) {
+func registerHandlers(mux *http.ServeMux, aggregator aggregator.Aggregator, opts Options) {
 	registerHealthHandler(mux)
 	registerResignHandler(mux, aggregator)
 	registerStatusHandler(mux, aggregator)
+	if opts.DebugEndpointsEnabled() {
+		registerDebugShardsHandler(mux, aggregator)
+		registerDebugEntriesHandler(mux, aggregator)
+		registerDebugElemsHandler(mux, aggregator)
+	}
 }
 
 func registerHealthHandler(mux *http.ServeMux) {
@@ -92,6 +117,101 @@ func registerStatusHandler(mux *http.ServeMux, aggregator aggregator.Aggregator)
 	})
 }
 
+func registerDebugShardsHandler(mux *http.ServeMux, aggregator aggregator.Aggregator) {
+	mux.HandleFunc(DebugShardsPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if httpMethod := strings.ToUpper(r.Method); httpMethod != http.MethodGet {
+			writeErrorResponse(w, errRequestMustBeGet)
+			return
+		}
+
+		topN, err := parseIntParam(r, "top", defaultDebugTopN, 0, maxDebugTopN)
+		if err != nil {
+			writeErrorResponse(w, err)
+			return
+		}
+
+		response := NewDebugShardsResponse()
+		response.Shards = aggregator.ShardSummaries(topN)
+		writeResponse(w, response, nil)
+	})
+}
+
+func registerDebugEntriesHandler(mux *http.ServeMux, aggregator aggregator.Aggregator) {
+	mux.HandleFunc(DebugEntriesPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if httpMethod := strings.ToUpper(r.Method); httpMethod != http.MethodGet {
+			writeErrorResponse(w, errRequestMustBeGet)
+			return
+		}
+
+		if r.URL.Query().Get("shard") == "" {
+			writeErrorResponse(w, errNoShard)
+			return
+		}
+		shard, err := parseIntParam(r, "shard", 0, 0, math.MaxUint32)
+		if err != nil {
+			writeErrorResponse(w, err)
+			return
+		}
+		limit, err := parseIntParam(r, "limit", defaultDebugLimit, 1, maxDebugLimit)
+		if err != nil {
+			writeErrorResponse(w, err)
+			return
+		}
+
+		entries, err := aggregator.EntrySummaries(uint32(shard), limit)
+		if err != nil {
+			writeErrorResponse(w, err)
+			return
+		}
+		response := NewDebugEntriesResponse()
+		response.Entries = entries
+		writeResponse(w, response, nil)
+	})
+}
+
+func registerDebugElemsHandler(mux *http.ServeMux, aggregator aggregator.Aggregator) {
+	mux.HandleFunc(DebugElemsPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if httpMethod := strings.ToUpper(r.Method); httpMethod != http.MethodGet {
+			writeErrorResponse(w, errRequestMustBeGet)
+			return
+		}
+
+		metricID := r.URL.Query().Get("id")
+		if metricID == "" {
+			writeErrorResponse(w, errNoID)
+			return
+		}
+
+		entries, err := aggregator.EntrySnapshots(id.RawID(metricID))
+		if err != nil {
+			writeErrorResponse(w, err)
+			return
+		}
+		response := NewDebugElemsResponse()
+		response.Entries = entries
+		writeResponse(w, response, nil)
+	})
+}
+
+func parseIntParam(r *http.Request, name string, defaultValue, minValue, maxValue int) (int, error) {
+	str := r.URL.Query().Get(name)
+	if str == "" {
+		return defaultValue, nil
+	}
+	value, err := strconv.Atoi(str)
+	if err != nil || value < minValue || value > maxValue {
+		return 0, xerrors.NewInvalidParamsError(
+			fmt.Errorf("invalid %s: %s, must be between %d and %d", name, str, minValue, maxValue))
+	}
+	return value, nil
+}
+
 // Response is an HTTP response.
 type Response struct {
 	State string `json:"state,omitempty"`
@@ -104,12 +224,46 @@ type StatusResponse struct {
 	Status aggregator.RuntimeStatus `json:"status,omitempty"`
 }
 
+// DebugShardsResponse is a debug response of the shards owned by the aggregator.
+type DebugShardsResponse struct {
+	Response
+	Shards []aggregator.ShardSummary `json:"shards"`
+}
+
+// DebugEntriesResponse is a debug response of the entries of a shard.
+type DebugEntriesResponse struct {
+	Response
+	Entries []aggregator.EntrySummary `json:"entries"`
+}
+
+// DebugElemsResponse is a debug response of the entries of a metric ID and
+// their elements.
+type DebugElemsResponse struct {
+	Response
+	Entries []aggregator.EntrySnapshot `json:"entries"`
+}
+
 // NewResponse creates a new empty response.
 func NewResponse() Response { return Response{} }
 
 // NewStatusResponse creates a new empty status response.
 func NewStatusResponse() StatusResponse { return StatusResponse{} }
 
+// NewDebugShardsResponse creates a new empty debug shards response.
+func NewDebugShardsResponse() DebugShardsResponse {
+	return DebugShardsResponse{Response: newSuccessResponse()}
+}
+
+// NewDebugEntriesResponse creates a new empty debug entries response.
+func NewDebugEntriesResponse() DebugEntriesResponse {
+	return DebugEntriesResponse{Response: newSuccessResponse()}
+}
+
+// NewDebugElemsResponse creates a new empty debug elems response.
+func NewDebugElemsResponse() DebugElemsResponse {
+	return DebugElemsResponse{Response: newSuccessResponse()}
+}
+
 func newSuccessResponse() Response {
 	return Response{State: "OK"}
 }
@@ -0,0 +1,63 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/aggregator/aggregator"
+)
+
+func TestDebugHandlersLimits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	agg := aggregator.NewMockAggregator(ctrl)
+	agg.EXPECT().ShardSummaries(maxDebugTopN).Return(nil)
+	agg.EXPECT().EntrySummaries(uint32(4294967295), maxDebugLimit).Return(nil, nil)
+
+	mux := http.NewServeMux()
+	registerHandlers(mux, agg, NewOptions().SetDebugEndpointsEnabled(true))
+
+	tests := []struct {
+		url          string
+		expectedCode int
+	}{
+		{url: DebugShardsPath + "?top=1000", expectedCode: http.StatusOK},
+		{url: DebugShardsPath + "?top=1001", expectedCode: http.StatusBadRequest},
+		{url: DebugShardsPath + "?top=-1", expectedCode: http.StatusBadRequest},
+		{url: DebugEntriesPath + "?shard=4294967295&limit=1000", expectedCode: http.StatusOK},
+		{url: DebugEntriesPath + "?shard=4294967296", expectedCode: http.StatusBadRequest},
+		{url: DebugEntriesPath + "?shard=1&limit=1001", expectedCode: http.StatusBadRequest},
+		{url: DebugEntriesPath + "?shard=1&limit=0", expectedCode: http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodGet, test.url, nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		require.Equal(t, test.expectedCode, w.Code, test.url)
+	}
+}
@@ -49,12 +49,19 @@ type Options interface {
 
 	// SetMux sets the http mux for the server.
 	SetMux(value *http.ServeMux) Options
+
+	// SetDebugEndpointsEnabled sets whether the debug endpoints are enabled.
+	SetDebugEndpointsEnabled(value bool) Options
+
+	// DebugEndpointsEnabled returns whether the debug endpoints are enabled.
+	DebugEndpointsEnabled() bool
 }
 
 type options struct {
-	readTimeout  time.Duration
-	writeTimeout time.Duration
-	mux          *http.ServeMux
+	readTimeout           time.Duration
+	writeTimeout          time.Duration
+	mux                   *http.ServeMux
+	debugEndpointsEnabled bool
 }
 
 // NewOptions creates a new set of server options.
@@ -95,3 +102,13 @@ func (o *options) SetMux(value *http.ServeMux) Options {
 	opts.mux = value
 	return &opts
 }
+
+func (o *options) SetDebugEndpointsEnabled(value bool) Options {
+	opts := *o
+	opts.debugEndpointsEnabled = value
+	return &opts
+}
+
+func (o *options) DebugEndpointsEnabled() bool {
+	return o.debugEndpointsEnabled
+}
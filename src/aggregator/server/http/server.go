@@ -70,7 +70,7 @@ func (s *server) ListenAndServe() error {
 }
 
 func (s *server) Serve(l net.Listener) error {
-	registerHandlers(s.opts.Mux(), s.aggregator)
+	registerHandlers(s.opts.Mux(), s.aggregator, s.opts)
 
 	// create and register debug handler
 	debugWriter, err := xdebug.NewZipWriterWithDefaultSources(
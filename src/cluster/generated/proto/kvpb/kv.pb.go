@@ -32,6 +32,8 @@
 		KeyValueUpdateResult
 		QueryLimits
 		QueryLimit
+		SeriesLimits
+		SeriesLimit
 */
 package kvpb
 
@@ -196,11 +198,61 @@ func (m *QueryLimit) GetForceWaited() bool {
 	return false
 }
 
+type SeriesLimits struct {
+	Limits []*SeriesLimit `protobuf:"bytes,1,rep,name=limits" json:"limits,omitempty"`
+}
+
+func (m *SeriesLimits) Reset()                    { *m = SeriesLimits{} }
+func (m *SeriesLimits) String() string            { return proto.CompactTextString(m) }
+func (*SeriesLimits) ProtoMessage()               {}
+func (*SeriesLimits) Descriptor() ([]byte, []int) { return fileDescriptorKv, []int{4} }
+
+func (m *SeriesLimits) GetLimits() []*SeriesLimit {
+	if m != nil {
+		return m.Limits
+	}
+	return nil
+}
+
+type SeriesLimit struct {
+	TagName         string `protobuf:"bytes,1,opt,name=tagName,proto3" json:"tagName,omitempty"`
+	TagValue        string `protobuf:"bytes,2,opt,name=tagValue,proto3" json:"tagValue,omitempty"`
+	MaxActiveSeries int64  `protobuf:"varint,3,opt,name=maxActiveSeries,proto3" json:"maxActiveSeries,omitempty"`
+}
+
+func (m *SeriesLimit) Reset()                    { *m = SeriesLimit{} }
+func (m *SeriesLimit) String() string            { return proto.CompactTextString(m) }
+func (*SeriesLimit) ProtoMessage()               {}
+func (*SeriesLimit) Descriptor() ([]byte, []int) { return fileDescriptorKv, []int{5} }
+
+func (m *SeriesLimit) GetTagName() string {
+	if m != nil {
+		return m.TagName
+	}
+	return ""
+}
+
+func (m *SeriesLimit) GetTagValue() string {
+	if m != nil {
+		return m.TagValue
+	}
+	return ""
+}
+
+func (m *SeriesLimit) GetMaxActiveSeries() int64 {
+	if m != nil {
+		return m.MaxActiveSeries
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*KeyValueUpdate)(nil), "kvpb.KeyValueUpdate")
 	proto.RegisterType((*KeyValueUpdateResult)(nil), "kvpb.KeyValueUpdateResult")
 	proto.RegisterType((*QueryLimits)(nil), "kvpb.QueryLimits")
 	proto.RegisterType((*QueryLimit)(nil), "kvpb.QueryLimit")
+	proto.RegisterType((*SeriesLimits)(nil), "kvpb.SeriesLimits")
+	proto.RegisterType((*SeriesLimit)(nil), "kvpb.SeriesLimit")
 }
 func (m *KeyValueUpdate) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
@@ -384,6 +436,71 @@ func (m *QueryLimit) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *SeriesLimits) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SeriesLimits) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Limits) > 0 {
+		for _, msg := range m.Limits {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintKv(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *SeriesLimit) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SeriesLimit) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.TagName) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintKv(dAtA, i, uint64(len(m.TagName)))
+		i += copy(dAtA[i:], m.TagName)
+	}
+	if len(m.TagValue) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintKv(dAtA, i, uint64(len(m.TagValue)))
+		i += copy(dAtA[i:], m.TagValue)
+	}
+	if m.MaxActiveSeries != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintKv(dAtA, i, uint64(m.MaxActiveSeries))
+	}
+	return i, nil
+}
+
 func encodeVarintKv(dAtA []byte, offset int, v uint64) int {
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
@@ -468,6 +585,34 @@ func (m *QueryLimit) Size() (n int) {
 	return n
 }
 
+func (m *SeriesLimits) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Limits) > 0 {
+		for _, e := range m.Limits {
+			l = e.Size()
+			n += 1 + l + sovKv(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *SeriesLimit) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.TagName)
+	if l > 0 {
+		n += 1 + l + sovKv(uint64(l))
+	}
+	l = len(m.TagValue)
+	if l > 0 {
+		n += 1 + l + sovKv(uint64(l))
+	}
+	if m.MaxActiveSeries != 0 {
+		n += 1 + sovKv(uint64(m.MaxActiveSeries))
+	}
+	return n
+}
 func sovKv(x uint64) (n int) {
 	for {
 		n++
@@ -1056,6 +1201,214 @@ func (m *QueryLimit) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *SeriesLimits) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowKv
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SeriesLimits: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SeriesLimits: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limits", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKv
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthKv
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Limits = append(m.Limits, &SeriesLimit{})
+			if err := m.Limits[len(m.Limits)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipKv(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthKv
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SeriesLimit) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowKv
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SeriesLimit: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SeriesLimit: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TagName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKv
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthKv
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TagName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TagValue", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKv
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthKv
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TagValue = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxActiveSeries", wireType)
+			}
+			m.MaxActiveSeries = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKv
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxActiveSeries |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipKv(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthKv
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipKv(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -1166,30 +1519,34 @@ func init() {
 }
 
 var fileDescriptorKv = []byte{
-	// 391 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x52, 0x4d, 0xab, 0xd3, 0x40,
-	0x14, 0x35, 0xe6, 0xf9, 0x78, 0xef, 0x16, 0x35, 0x0c, 0x0f, 0xe9, 0x2a, 0x84, 0xa0, 0xd0, 0x55,
-	0x06, 0xec, 0xd6, 0x55, 0xd1, 0x95, 0x15, 0x74, 0x8a, 0x1f, 0x0b, 0x37, 0x93, 0x99, 0xdb, 0x1a,
-	0x32, 0xc9, 0x94, 0xcc, 0xa4, 0x36, 0xbf, 0xc0, 0xad, 0x0b, 0x7f, 0x94, 0x4b, 0x7f, 0x82, 0xd4,
-	0x3f, 0x22, 0x33, 0x29, 0xb4, 0x95, 0xd6, 0x76, 0x13, 0xee, 0x39, 0xb9, 0xe7, 0xdc, 0x3b, 0x87,
-	0x0b, 0x2f, 0x16, 0x85, 0xfd, 0xd2, 0xe6, 0x99, 0xd0, 0x15, 0xad, 0xc6, 0x32, 0xa7, 0xd5, 0x98,
-	0x9a, 0x46, 0x50, 0xa1, 0x5a, 0x63, 0xb1, 0xa1, 0x0b, 0xac, 0xb1, 0xe1, 0x16, 0x25, 0x5d, 0x36,
-	0xda, 0x6a, 0x5a, 0xae, 0x96, 0x39, 0x2d, 0x57, 0x99, 0x47, 0xe4, 0xca, 0xc1, 0xf4, 0x2d, 0x3c,
-	0x7a, 0x8d, 0xdd, 0x07, 0xae, 0x5a, 0x7c, 0xbf, 0x94, 0xdc, 0x22, 0x89, 0x20, 0x2c, 0xb1, 0x1b,
-	0x06, 0x49, 0x30, 0xba, 0x65, 0xae, 0x24, 0x77, 0xf0, 0x60, 0xe5, 0x1a, 0x86, 0xf7, 0x3d, 0xd7,
-	0x03, 0xf2, 0x04, 0xae, 0x85, 0xae, 0xaa, 0xc2, 0x0e, 0xc3, 0x24, 0x18, 0xdd, 0xb0, 0x2d, 0x4a,
-	0xa7, 0x70, 0x77, 0xe8, 0xc8, 0xd0, 0xb4, 0xca, 0x1e, 0xf1, 0x8d, 0x20, 0xd4, 0x4a, 0x6e, 0x5d,
-	0x5d, 0xe9, 0x98, 0x1a, 0xbf, 0x7a, 0xc3, 0x5b, 0xe6, 0xca, 0xf4, 0x5b, 0x08, 0x83, 0x77, 0x2d,
-	0x36, 0xdd, 0xb4, 0xa8, 0x0a, 0x6b, 0xc8, 0x27, 0x88, 0x2b, 0xbe, 0x66, 0x28, 0xb0, 0xb6, 0xaa,
-	0x73, 0x7f, 0x0a, 0x94, 0x33, 0xf7, 0x35, 0x13, 0xa5, 0x45, 0x69, 0xfc, 0x80, 0xc1, 0xf3, 0x28,
-	0x73, 0xcf, 0xcb, 0x76, 0x52, 0x76, 0x46, 0x47, 0xe6, 0xf0, 0xec, 0x54, 0xc7, 0xcb, 0xc2, 0x94,
-	0x93, 0xce, 0xa2, 0x61, 0xc8, 0xfb, 0x7d, 0x8f, 0x0d, 0xb8, 0x4c, 0x4e, 0x3e, 0x43, 0xf2, 0xbf,
-	0x46, 0x3f, 0x22, 0x3c, 0x31, 0xe2, 0xac, 0xf2, 0x78, 0x3e, 0x6f, 0xd0, 0x72, 0xc9, 0x2d, 0xf7,
-	0xde, 0x57, 0x97, 0xe7, 0xb3, 0xaf, 0x4b, 0x7f, 0x04, 0x00, 0xbb, 0x76, 0x77, 0x14, 0xca, 0x15,
-	0x3e, 0xef, 0x90, 0xf5, 0x80, 0x8c, 0xe0, 0xb1, 0xd2, 0xba, 0xcc, 0xb9, 0x28, 0x67, 0x28, 0x74,
-	0x2d, 0x8d, 0x8f, 0x2b, 0x64, 0xff, 0xd2, 0xe4, 0x29, 0x3c, 0x9c, 0xeb, 0x46, 0xe0, 0xab, 0xb5,
-	0x40, 0x94, 0x28, 0xb7, 0x57, 0x74, 0x48, 0x92, 0x04, 0x06, 0x9e, 0xf8, 0xc8, 0x0b, 0x8b, 0xfd,
-	0xee, 0x37, 0x6c, 0x9f, 0x9a, 0x44, 0x3f, 0x37, 0x71, 0xf0, 0x6b, 0x13, 0x07, 0xbf, 0x37, 0x71,
-	0xf0, 0xfd, 0x4f, 0x7c, 0x2f, 0xbf, 0xf6, 0xf7, 0x3d, 0xfe, 0x1b, 0x00, 0x00, 0xff, 0xff, 0x1c,
-	0x39, 0x9e, 0xf7, 0x1f, 0x03, 0x00, 0x00,
+	// 461 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x53, 0xcd, 0x6e, 0xd3, 0x40,
+	0x10, 0xc6, 0x6c, 0x09, 0xe9, 0x98, 0x9f, 0xb0, 0xaa, 0x50, 0xc4, 0xc1, 0x8a, 0x2c, 0x90, 0xc2,
+	0x25, 0x96, 0x9a, 0x13, 0x12, 0x17, 0x22, 0x38, 0x51, 0x10, 0x6c, 0x45, 0xe1, 0xc0, 0x65, 0xbd,
+	0x3b, 0x0d, 0x96, 0xbd, 0xd9, 0xc8, 0xbb, 0x0e, 0xf1, 0x13, 0x70, 0xe5, 0xc0, 0x43, 0x71, 0xe4,
+	0x11, 0x50, 0x78, 0x11, 0xb4, 0x6b, 0x43, 0xdd, 0x2a, 0x6d, 0x73, 0xb1, 0xe6, 0xfb, 0xfc, 0xcd,
+	0x37, 0x3f, 0x9a, 0x85, 0xe7, 0xf3, 0xcc, 0x7e, 0xa9, 0xd2, 0x89, 0xd0, 0x2a, 0x51, 0x53, 0x99,
+	0x26, 0x6a, 0x9a, 0x98, 0x52, 0x24, 0xa2, 0xa8, 0x8c, 0xc5, 0x32, 0x99, 0xe3, 0x02, 0x4b, 0x6e,
+	0x51, 0x26, 0xcb, 0x52, 0x5b, 0x9d, 0xe4, 0xab, 0x65, 0x9a, 0xe4, 0xab, 0x89, 0x47, 0x74, 0xcf,
+	0xc1, 0xf8, 0x1d, 0xdc, 0x7b, 0x8d, 0xf5, 0x09, 0x2f, 0x2a, 0xfc, 0xb0, 0x94, 0xdc, 0x22, 0x1d,
+	0x00, 0xc9, 0xb1, 0x1e, 0x06, 0xa3, 0x60, 0xbc, 0xcf, 0x5c, 0x48, 0x0f, 0xe0, 0xd6, 0xca, 0x09,
+	0x86, 0x37, 0x3d, 0xd7, 0x00, 0xfa, 0x10, 0x7a, 0x42, 0x2b, 0x95, 0xd9, 0x21, 0x19, 0x05, 0xe3,
+	0x3e, 0x6b, 0x51, 0x7c, 0x04, 0x07, 0xe7, 0x1d, 0x19, 0x9a, 0xaa, 0xb0, 0x5b, 0x7c, 0x07, 0x40,
+	0x74, 0x21, 0x5b, 0x57, 0x17, 0x3a, 0x66, 0x81, 0x5f, 0xbd, 0xe1, 0x3e, 0x73, 0x61, 0xfc, 0x8d,
+	0x40, 0xf8, 0xbe, 0xc2, 0xb2, 0x3e, 0xca, 0x54, 0x66, 0x0d, 0xfd, 0x04, 0x91, 0xe2, 0x6b, 0x86,
+	0x02, 0x17, 0xb6, 0xa8, 0xdd, 0x9f, 0x0c, 0xe5, 0xb1, 0xfb, 0x9a, 0x59, 0xa1, 0x45, 0x6e, 0x7c,
+	0x81, 0xf0, 0x70, 0x30, 0x71, 0xe3, 0x4d, 0xce, 0x52, 0xd9, 0x35, 0x79, 0xf4, 0x14, 0x9e, 0x5c,
+	0xa6, 0x78, 0x99, 0x99, 0x7c, 0x56, 0x5b, 0x34, 0x0c, 0x79, 0xd3, 0xef, 0xb6, 0x02, 0xbb, 0xa5,
+	0xd3, 0xcf, 0x30, 0xba, 0x4a, 0xe8, 0x4b, 0x90, 0x4b, 0x4a, 0x5c, 0x9b, 0xb9, 0x7d, 0x3f, 0x6f,
+	0xd0, 0x72, 0xc9, 0x2d, 0xf7, 0xde, 0x7b, 0xbb, 0xef, 0xa7, 0x9b, 0x17, 0xff, 0x08, 0x00, 0xce,
+	0xe4, 0xee, 0x28, 0x0a, 0x17, 0xf8, 0x7d, 0x13, 0xd6, 0x00, 0x3a, 0x86, 0xfb, 0x85, 0xd6, 0x79,
+	0xca, 0x45, 0x7e, 0x8c, 0x42, 0x2f, 0xa4, 0xf1, 0xeb, 0x22, 0xec, 0x22, 0x4d, 0x1f, 0xc3, 0xdd,
+	0x53, 0x5d, 0x0a, 0x7c, 0xb5, 0x16, 0x88, 0x12, 0x65, 0x7b, 0x45, 0xe7, 0x49, 0x3a, 0x82, 0xd0,
+	0x13, 0x1f, 0x79, 0x66, 0xb1, 0xe9, 0xbd, 0xcf, 0xba, 0x54, 0xfc, 0x0c, 0xee, 0x34, 0x2b, 0x68,
+	0x0f, 0xe4, 0x29, 0xf4, 0x7c, 0x2b, 0xee, 0x10, 0xc8, 0x38, 0x3c, 0x7c, 0xd0, 0x0c, 0xda, 0xd1,
+	0xb0, 0x56, 0x10, 0x2b, 0x08, 0x3b, 0x34, 0x1d, 0xc2, 0x6d, 0xcb, 0xe7, 0x6f, 0xb9, 0xc2, 0xf6,
+	0x48, 0xff, 0x41, 0xfa, 0x08, 0xfa, 0x96, 0xcf, 0x4f, 0x3a, 0x6f, 0xe0, 0x3f, 0x76, 0x13, 0x2b,
+	0xbe, 0x7e, 0x21, 0x6c, 0xb6, 0xc2, 0xc6, 0xcd, 0x4f, 0x42, 0xd8, 0x45, 0x7a, 0x36, 0xf8, 0xb9,
+	0x89, 0x82, 0x5f, 0x9b, 0x28, 0xf8, 0xbd, 0x89, 0x82, 0xef, 0x7f, 0xa2, 0x1b, 0x69, 0xcf, 0xbf,
+	0xc4, 0xe9, 0xdf, 0x01, 0x00, 0x7b, 0xc2, 0x73, 0x4b, 0xc9, 0x03, 0x00, 0x00,
 }
@@ -31,6 +31,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/m3db/m3/src/aggregator/aggregation/histogram"
 	"github.com/m3db/m3/src/aggregator/aggregation/quantile/cm"
 	"github.com/m3db/m3/src/aggregator/aggregator"
 	"github.com/m3db/m3/src/aggregator/aggregator/handler"
@@ -94,6 +95,9 @@ type AggregatorConfiguration struct {
 	// Stream configuration for computing quantiles.
 	Stream streamConfiguration `yaml:"stream"`
 
+	// Histogram configuration for the histogram aggregation type.
+	Histogram histogramConfiguration `yaml:"histogram"`
+
 	// Client configuration.
 	Client aggclient.Configuration `yaml:"client"`
 
@@ -171,6 +175,9 @@ type AggregatorConfiguration struct {
 	// Pool of gauge elements.
 	GaugeElemPool pool.ObjectPoolConfiguration `yaml:"gaugeElemPool"`
 
+	// Pool of histogram elements.
+	HistogramElemPool pool.ObjectPoolConfiguration `yaml:"histogramElemPool"`
+
 	// Pool of entries.
 	EntryPool pool.ObjectPoolConfiguration `yaml:"entryPool"`
 
@@ -307,6 +314,13 @@ func (c *AggregatorConfiguration) NewAggregatorOptions(
 	}
 	opts = opts.SetStreamOptions(streamOpts)
 
+	// Set histogram options.
+	histogramOpts, err := c.Histogram.NewHistogramOptions()
+	if err != nil {
+		return nil, err
+	}
+	opts = opts.SetHistogramOptions(histogramOpts)
+
 	// Set administrative client.
 	// TODO(xichen): client retry threshold likely needs to be low for faster retries.
 	iOpts = instrumentOpts.SetMetricsScope(scope.SubScope("client"))
@@ -489,6 +503,15 @@ func (c *AggregatorConfiguration) NewAggregatorOptions(
 		return aggregator.MustNewGaugeElem(aggregator.ElemData{}, elemOpts)
 	})
 
+	// Set histogram elem pool.
+	iOpts = instrumentOpts.SetMetricsScope(scope.SubScope("histogram-elem-pool"))
+	histogramElemPoolOpts := c.HistogramElemPool.NewObjectPoolOptions(iOpts)
+	histogramElemPool := aggregator.NewHistogramElemPool(histogramElemPoolOpts)
+	opts = opts.SetHistogramElemPool(histogramElemPool)
+	histogramElemPool.Init(func() *aggregator.HistogramElem {
+		return aggregator.MustNewHistogramElem(aggregator.ElemData{}, elemOpts)
+	})
+
 	// Set entry pool.
 	iOpts = instrumentOpts.SetMetricsScope(scope.SubScope("entry-pool"))
 	entryPoolOpts := c.EntryPool.NewObjectPoolOptions(iOpts)
@@ -592,6 +615,40 @@ func (c *streamConfiguration) NewStreamOptions(_ instrument.Options) (cm.Options
 	return opts, nil
 }
 
+// histogramConfiguration contains configuration for the exponential histograms
+// of the histogram aggregation type.
+type histogramConfiguration struct {
+	// Schema is the initial resolution of the histograms, each power of two
+	// is split into 2^schema buckets.
+	Schema *int32 `yaml:"schema"`
+
+	// MaxBuckets is the max number of buckets of a histogram before its
+	// schema is reduced.
+	MaxBuckets *int `yaml:"maxBuckets"`
+
+	// ZeroThreshold is the threshold under which absolute values are counted
+	// in the zero bucket.
+	ZeroThreshold *float64 `yaml:"zeroThreshold"`
+}
+
+func (c *histogramConfiguration) NewHistogramOptions() (histogram.Options, error) {
+	opts := histogram.NewOptions()
+	if c.Schema != nil {
+		opts = opts.SetSchema(*c.Schema)
+	}
+	if c.MaxBuckets != nil {
+		opts = opts.SetMaxBuckets(*c.MaxBuckets)
+	}
+	if c.ZeroThreshold != nil {
+		opts = opts.SetZeroThreshold(*c.ZeroThreshold)
+	}
+
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
+
 type placementManagerConfiguration struct {
 	KVConfig kv.OverrideConfiguration       `yaml:"kvConfig"`
 	Watcher  placement.WatcherConfiguration `yaml:"placementWatcher"`
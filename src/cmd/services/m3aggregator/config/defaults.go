@@ -311,5 +311,6 @@ var (
 		CounterElemPool:            pool.ObjectPoolConfiguration{Size: 4096},
 		TimerElemPool:              pool.ObjectPoolConfiguration{Size: 4096},
 		GaugeElemPool:              pool.ObjectPoolConfiguration{Size: 4096},
+		HistogramElemPool:          pool.ObjectPoolConfiguration{Size: 4096},
 	}
 )
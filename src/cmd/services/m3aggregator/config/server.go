@@ -163,6 +163,11 @@ type HTTPServerConfiguration struct {
 
 	// HTTP server write timeout.
 	WriteTimeout time.Duration `yaml:"writeTimeout"`
+
+	// DebugEndpointsEnabled enables the endpoints to inspect the metrics
+	// being aggregated, they are not authenticated and expose metric IDs
+	// and values so they are disabled by default.
+	DebugEndpointsEnabled bool `yaml:"debugEndpointsEnabled"`
 }
 
 // NewServerOptions create a new set of http server options.
@@ -174,5 +179,6 @@ func (c *HTTPServerConfiguration) NewServerOptions() http.Options {
 	if c.WriteTimeout != 0 {
 		opts = opts.SetWriteTimeout(c.WriteTimeout)
 	}
+	opts = opts.SetDebugEndpointsEnabled(c.DebugEndpointsEnabled)
 	return opts
 }
@@ -101,6 +101,15 @@ func (d *asyncDownsampler) NewMetricsAppender() (MetricsAppender, error) {
 	return d.downsampler.NewMetricsAppender()
 }
 
+func (d *asyncDownsampler) PreviewRuleSet(rs view.RuleSet) (RuleSetPreview, error) {
+	d.RLock()
+	defer d.RUnlock()
+	if d.err != nil {
+		return RuleSetPreview{}, d.err
+	}
+	return d.downsampler.PreviewRuleSet(rs)
+}
+
 func (d *asyncDownsampler) Enabled() bool {
 	d.RLock()
 	defer d.RUnlock()
@@ -100,6 +100,21 @@ func (mr *MockDownsamplerMockRecorder) NewMetricsAppender() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewMetricsAppender", reflect.TypeOf((*MockDownsampler)(nil).NewMetricsAppender))
 }
 
+// PreviewRuleSet mocks base method.
+func (m *MockDownsampler) PreviewRuleSet(arg0 view.RuleSet) (RuleSetPreview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewRuleSet", arg0)
+	ret0, _ := ret[0].(RuleSetPreview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewRuleSet indicates an expected call of PreviewRuleSet.
+func (mr *MockDownsamplerMockRecorder) PreviewRuleSet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewRuleSet", reflect.TypeOf((*MockDownsampler)(nil).PreviewRuleSet), arg0)
+}
+
 // MockMetricsAppender is a mock of MetricsAppender interface.
 type MockMetricsAppender struct {
 	ctrl     *gomock.Controller
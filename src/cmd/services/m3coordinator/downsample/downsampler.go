@@ -45,6 +45,9 @@ type Downsampler interface {
 	// downsampler is enabled if there are aggregated ClusterNamespaces
 	// that exist as downsampling only applies to aggregations.
 	Enabled() bool
+	// PreviewRuleSet estimates the impact of a ruleset on the series of its
+	// namespace recently sampled by the downsampler.
+	PreviewRuleSet(rs view.RuleSet) (RuleSetPreview, error)
 }
 
 // MetricsAppender is a metrics appender that can build a samples
@@ -150,6 +153,7 @@ func defaultMetricsAppenderOptions(opts DownsamplerOptions, agg agg) metricsAppe
 		debugLogging:   debugLogging,
 		logger:         logger,
 		untimedRollups: agg.untimedRollups,
+		sampler:        agg.sampler,
 		metrics:        metrics,
 	}
 }
@@ -38,6 +38,7 @@ import (
 	clusterclient "github.com/m3db/m3/src/cluster/client"
 	"github.com/m3db/m3/src/cluster/kv"
 	"github.com/m3db/m3/src/cluster/kv/mem"
+	coordmodel "github.com/m3db/m3/src/cmd/services/m3coordinator/model"
 	dbclient "github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/metrics/aggregation"
 	"github.com/m3db/m3/src/metrics/generated/proto/metricpb"
@@ -72,6 +73,8 @@ var (
 	testAggregationStoragePolicies = []policy.StoragePolicy{
 		policy.MustParseStoragePolicy("2s:1d"),
 	}
+	rollupTagName  = []byte(coordmodel.RollupTagName)
+	rollupTagValue = []byte(coordmodel.RollupTagValue)
 )
 
 const (
@@ -3481,6 +3484,7 @@ type testDownsamplerOptions struct {
 	remoteClientMock   *client.MockClient
 	rulesConfig        *RulesConfiguration
 	matcherConfig      MatcherConfiguration
+	seriesSampling     SeriesSamplingConfiguration
 
 	// Test ingest and expectations overrides
 	ingest *testDownsamplerOptionsIngest
@@ -3568,6 +3572,7 @@ func newTestDownsampler(t *testing.T, opts testDownsamplerOptions) testDownsampl
 	}
 	cfg.Matcher = opts.matcherConfig
 	cfg.UntimedRollups = opts.untimedRollups
+	cfg.SeriesSampling = opts.seriesSampling
 
 	clusterClient := clusterclient.NewMockClient(gomock.NewController(t))
 	kvStore := opts.kvStore
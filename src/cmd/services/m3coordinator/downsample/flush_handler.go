@@ -34,6 +34,7 @@ import (
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/storage/m3/storagemetadata"
+	"github.com/m3db/m3/src/query/tenant"
 	"github.com/m3db/m3/src/query/ts"
 	"github.com/m3db/m3/src/x/convert"
 	"github.com/m3db/m3/src/x/instrument"
@@ -66,16 +67,18 @@ type downsamplerFlushHandler struct {
 }
 
 type downsamplerFlushHandlerMetrics struct {
-	flushSuccess tally.Counter
-	flushErrors  tally.Counter
+	flushSuccess       tally.Counter
+	flushErrors        tally.Counter
+	flushTenantDropped tally.Counter
 }
 
 func newDownsamplerFlushHandlerMetrics(
 	scope tally.Scope,
 ) downsamplerFlushHandlerMetrics {
 	return downsamplerFlushHandlerMetrics{
-		flushSuccess: scope.Counter("flush-success"),
-		flushErrors:  scope.Counter("flush-errors"),
+		flushSuccess:       scope.Counter("flush-success"),
+		flushErrors:        scope.Counter("flush-errors"),
+		flushTenantDropped: scope.Counter("flush-tenant-dropped"),
 	}
 }
 
@@ -193,6 +196,13 @@ func (w *downsamplerFlushHandlerWriter) Write(
 		}
 
 		if err := w.handler.storage.Write(w.ctx, writeQuery); err != nil {
+			if tenant.IsNamespaceNotWritten(err) {
+				// NB: the aggregated series of a tenant are only written to
+				// the namespaces of the tenant, the downsampler aggregates
+				// them into all of the aggregated namespaces.
+				w.handler.metrics.flushTenantDropped.Inc(1)
+				return
+			}
 			logger.Error("downsampler flush error failed write", zap.Error(err))
 			w.handler.metrics.flushErrors.Inc(1)
 			return
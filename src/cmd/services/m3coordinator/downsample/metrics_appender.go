@@ -118,6 +118,7 @@ type metricsAppenderOptions struct {
 	matcher                      matcher.Matcher
 	tagEncoderPool               serialize.TagEncoderPool
 	untimedRollups               bool
+	sampler                      *seriesSampler
 
 	clockOpts    clock.Options
 	debugLogging bool
@@ -142,15 +143,7 @@ func newMetricsAppender(
 			tagIter.Reset(tagPairs)
 			return tagIter
 		},
-		nameTagFn: func(id []byte) ([]byte, []byte, error) {
-			name, err := resolveEncodedTagsNameTag(id, nameTag)
-			if err != nil && !errors.Is(err, errNoMetricNameTag) {
-				return nil, nil, err
-			}
-			// ID is always the encoded tags for IDs in the downsampler
-			tags := id
-			return name, tags, nil
-		},
+		nameTagFn: rules.NewEncodedTagsNameAndTagsFn(nameTag),
 	}
 }
 
@@ -286,6 +279,12 @@ func (a *metricsAppender) SamplesAppender(opts SampleAppenderOptions) (SamplesAp
 		}, nil
 	}
 
+	// Only sample series that rules are applied to, so that previewing rules
+	// against the sampled series reflects what the rules would do.
+	if a.sampler != nil {
+		a.sampler.record(unownedID, nowNanos)
+	}
+
 	// Next, apply any mapping rules that match. We track which storage policies have been applied based on the
 	// mapping rules that match. Any storage policies that have been applied will be skipped when applying
 	// the auto-mapping rules to avoid redundant writes (i.e. overwriting each other).
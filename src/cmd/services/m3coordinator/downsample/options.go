@@ -21,7 +21,6 @@
 package downsample
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
 	"runtime"
@@ -41,7 +40,6 @@ import (
 	placementstorage "github.com/m3db/m3/src/cluster/placement/storage"
 	"github.com/m3db/m3/src/cluster/services"
 	"github.com/m3db/m3/src/metrics/aggregation"
-	"github.com/m3db/m3/src/metrics/filters"
 	"github.com/m3db/m3/src/metrics/generated/proto/aggregationpb"
 	"github.com/m3db/m3/src/metrics/generated/proto/pipelinepb"
 	"github.com/m3db/m3/src/metrics/generated/proto/rulepb"
@@ -52,7 +50,6 @@ import (
 	"github.com/m3db/m3/src/metrics/metadata"
 	"github.com/m3db/m3/src/metrics/metric"
 	"github.com/m3db/m3/src/metrics/metric/aggregated"
-	"github.com/m3db/m3/src/metrics/metric/id"
 	"github.com/m3db/m3/src/metrics/metric/unaggregated"
 	"github.com/m3db/m3/src/metrics/pipeline"
 	"github.com/m3db/m3/src/metrics/policy"
@@ -65,7 +62,6 @@ import (
 	"github.com/m3db/m3/src/query/storage/m3"
 	"github.com/m3db/m3/src/query/storage/m3/storagemetadata"
 	"github.com/m3db/m3/src/x/clock"
-	"github.com/m3db/m3/src/x/ident"
 	"github.com/m3db/m3/src/x/instrument"
 	xio "github.com/m3db/m3/src/x/io"
 	"github.com/m3db/m3/src/x/pool"
@@ -111,6 +107,8 @@ var (
 	errNoTagDecoderPoolOptions      = errors.New("downsampling enabled with tag decoder pool options not set")
 	errNoMetricsAppenderPoolOptions = errors.New("downsampling enabled with metrics appender pool options not set")
 	errRollupRuleNoTransforms       = errors.New("rollup rule has no transforms set")
+	errTransformNoOperation         = errors.New("transform has no operation set")
+	errMappingRuleDropAndRelabel    = errors.New("mapping rule cannot drop and relabel metrics")
 )
 
 // CustomRuleStoreFn is a function to swap the backend used for the rule stores.
@@ -253,6 +251,10 @@ type agg struct {
 	matcher        matcher.Matcher
 	pools          aggPools
 	untimedRollups bool
+
+	ruleSetOpts       rules.Options
+	namespaceResolver namespace.Resolver
+	sampler           *seriesSampler
 }
 
 // Configuration configurates a downsampler.
@@ -281,6 +283,9 @@ type Configuration struct {
 	// Pool of gauge elements.
 	GaugeElemPool pool.ObjectPoolConfiguration `yaml:"gaugeElemPool"`
 
+	// Pool of histogram elements.
+	HistogramElemPool pool.ObjectPoolConfiguration `yaml:"histogramElemPool"`
+
 	// BufferPastLimits specifies the buffer past limits.
 	BufferPastLimits []BufferPastLimitConfiguration `yaml:"bufferPastLimits"`
 
@@ -290,6 +295,31 @@ type Configuration struct {
 
 	// UntimedRollups indicates rollup rules should be untimed.
 	UntimedRollups bool `yaml:"untimedRollups"`
+
+	// SeriesSampling configures sampling the series seen by the downsampler
+	// to preview the impact of rules against live traffic.
+	SeriesSampling SeriesSamplingConfiguration `yaml:"seriesSampling"`
+}
+
+// SeriesSamplingConfiguration is the configuration for sampling the series
+// seen by the downsampler.
+type SeriesSamplingConfiguration struct {
+	// Enabled enables sampling the series seen by the downsampler.
+	Enabled bool `yaml:"enabled"`
+
+	// Size is the maximum number of distinct series sampled, defaults to 10000.
+	Size int `yaml:"size"`
+
+	// Window is the period sampled series are rotated at, a sample covers
+	// the series seen in the last one to two windows. Defaults to 10 minutes.
+	Window time.Duration `yaml:"window"`
+}
+
+func (c SeriesSamplingConfiguration) newSeriesSampler(clockOpts clock.Options) *seriesSampler {
+	if !c.Enabled {
+		return nil
+	}
+	return newSeriesSampler(c.Size, c.Window, clockOpts.NowFn()().UnixNano())
 }
 
 // MatcherConfiguration is the configuration for the rule matcher.
@@ -376,6 +406,11 @@ type MappingRuleConfiguration struct {
 	// writing the metric out. So effectively treat it as an untimed metric.
 	Tags []Tag `yaml:"tags"`
 
+	// Relabel are relabel operations rewriting the tags of the matched metrics,
+	// which are then aggregated and kept under the relabeled metric instead
+	// of the matched metric. Cannot be used along with drop.
+	Relabel []pipeline.RelabelOp `yaml:"relabel"`
+
 	// Optional fields follow.
 
 	// Name is optional.
@@ -414,6 +449,18 @@ func (r MappingRuleConfiguration) Rule() (view.MappingRule, error) {
 		drop = policy.DropIfOnlyMatch
 	}
 
+	if r.Drop && len(r.Relabel) > 0 {
+		return view.MappingRule{}, errMappingRuleDropAndRelabel
+	}
+
+	var relabel []pipeline.RelabelOp
+	if len(r.Relabel) > 0 {
+		relabel = make([]pipeline.RelabelOp, 0, len(r.Relabel))
+		for _, op := range r.Relabel {
+			relabel = append(relabel, op.Clone())
+		}
+	}
+
 	tags := make([]models.Tag, 0, len(r.Tags))
 	for _, tag := range r.Tags {
 		tags = append(tags, models.Tag{
@@ -430,6 +477,7 @@ func (r MappingRuleConfiguration) Rule() (view.MappingRule, error) {
 		StoragePolicies: storagePolicies,
 		DropPolicy:      drop,
 		Tags:            tags,
+		Relabel:         relabel,
 	}, nil
 }
 
@@ -504,8 +552,10 @@ func (r RollupRuleConfiguration) Rule() (view.RollupRule, error) {
 	}
 
 	ops := make([]pipeline.OpUnion, 0, len(r.Transforms))
-	for _, elem := range r.Transforms {
-		// TODO: make sure only one of "Rollup" or "Aggregate" or "Transform" is not nil
+	for i, elem := range r.Transforms {
+		if err := elem.validate(); err != nil {
+			return view.RollupRule{}, fmt.Errorf("invalid transform at index %d: %w", i, err)
+		}
 		switch {
 		case elem.Rollup != nil:
 			cfg := elem.Rollup
@@ -573,6 +623,11 @@ func (r RollupRuleConfiguration) Rule() (view.RollupRule, error) {
 				return view.RollupRule{}, err
 			}
 			ops = append(ops, op)
+		case elem.Relabel != nil:
+			ops = append(ops, pipeline.OpUnion{
+				Type:    pipeline.RelabelOpType,
+				Relabel: elem.Relabel.Clone(),
+			})
 		}
 	}
 
@@ -612,6 +667,34 @@ type TransformConfiguration struct {
 	Rollup    *RollupOperationConfiguration    `yaml:"rollup"`
 	Aggregate *AggregateOperationConfiguration `yaml:"aggregate"`
 	Transform *TransformOperationConfiguration `yaml:"transform"`
+	// Relabel is a relabel operation rewriting the tags of the metric before
+	// the rollup operations that follow it, the separator, regex and
+	// replacement default to those of Prometheus relabeling.
+	Relabel *pipeline.RelabelOp `yaml:"relabel"`
+}
+
+func (c TransformConfiguration) validate() error {
+	numOps := 0
+	if c.Rollup != nil {
+		numOps++
+	}
+	if c.Aggregate != nil {
+		numOps++
+	}
+	if c.Transform != nil {
+		numOps++
+	}
+	if c.Relabel != nil {
+		numOps++
+	}
+	if numOps == 0 {
+		return errTransformNoOperation
+	}
+	if numOps > 1 {
+		return fmt.Errorf("transform must specify only one of rollup, aggregate, "+
+			"transform or relabel operations: numOperations=%d", numOps)
+	}
+	return nil
 }
 
 // RollupOperationConfiguration is a rollup operation.
@@ -735,13 +818,15 @@ func (cfg Configuration) newAggregator(o DownsamplerOptions) (agg, error) {
 
 	pools := o.newAggregatorPools()
 	ruleSetOpts := o.newAggregatorRulesOptions(pools)
+	namespaceResolver := namespace.NewResolver([]byte(namespaceTag), nil)
+	sampler := cfg.SeriesSampling.newSeriesSampler(clockOpts)
 
 	matcherOpts := matcher.NewOptions().
 		SetClockOptions(clockOpts).
 		SetInstrumentOptions(instrumentOpts).
 		SetRuleSetOptions(ruleSetOpts).
 		SetKVStore(o.RulesKVStore).
-		SetNamespaceResolver(namespace.NewResolver([]byte(namespaceTag), nil)).
+		SetNamespaceResolver(namespaceResolver).
 		SetRequireNamespaceWatchOnInit(cfg.Matcher.RequireNamespaceWatchOnInit).
 		SetInterruptedCh(o.InterruptedCh)
 
@@ -859,10 +944,13 @@ func (cfg Configuration) newAggregator(o DownsamplerOptions) (agg, error) {
 		}
 
 		return agg{
-			clientRemote:   client,
-			matcher:        matcher,
-			pools:          pools,
-			untimedRollups: cfg.UntimedRollups,
+			clientRemote:      client,
+			matcher:           matcher,
+			pools:             pools,
+			untimedRollups:    cfg.UntimedRollups,
+			ruleSetOpts:       ruleSetOpts,
+			namespaceResolver: namespaceResolver,
+			sampler:           sampler,
 		}, nil
 	}
 
@@ -978,6 +1066,16 @@ func (cfg Configuration) newAggregator(o DownsamplerOptions) (agg, error) {
 		return aggregator.MustNewGaugeElem(aggregator.ElemData{}, elemOpts)
 	})
 
+	// Set histogram elem pool.
+	histogramElemPoolOpts := cfg.HistogramElemPool.NewObjectPoolOptions(
+		instrumentOpts.SetMetricsScope(scope.SubScope("histogram-elem-pool")),
+	)
+	histogramElemPool := aggregator.NewHistogramElemPool(histogramElemPoolOpts)
+	aggregatorOpts = aggregatorOpts.SetHistogramElemPool(histogramElemPool)
+	histogramElemPool.Init(func() *aggregator.HistogramElem {
+		return aggregator.MustNewHistogramElem(aggregator.ElemData{}, elemOpts)
+	})
+
 	adminAggClient := newAggregatorLocalAdminClient()
 	aggregatorOpts = aggregatorOpts.SetAdminClient(adminAggClient)
 
@@ -1005,10 +1103,13 @@ func (cfg Configuration) newAggregator(o DownsamplerOptions) (agg, error) {
 	}
 
 	return agg{
-		aggregator:     aggregatorInstance,
-		matcher:        matcher,
-		pools:          pools,
-		untimedRollups: cfg.UntimedRollups,
+		aggregator:        aggregatorInstance,
+		matcher:           matcher,
+		pools:             pools,
+		untimedRollups:    cfg.UntimedRollups,
+		ruleSetOpts:       ruleSetOpts,
+		namespaceResolver: namespaceResolver,
+		sampler:           sampler,
 	}, nil
 }
 
@@ -1054,48 +1155,8 @@ func (o DownsamplerOptions) newAggregatorPools() aggPools {
 }
 
 func (o DownsamplerOptions) newAggregatorRulesOptions(pools aggPools) rules.Options {
-	nameTag := o.NameTagOrDefault()
-	tagsFilterOpts := filters.TagsFilterOptions{
-		NameTagKey: nameTag,
-	}
-
-	isRollupIDFn := func(name []byte, tags []byte) bool {
-		return isRollupID(tags, pools.metricTagsIteratorPool)
-	}
-
-	newRollupIDProviderPool := newRollupIDProviderPool(pools.tagEncoderPool,
-		o.TagEncoderPoolOptions, ident.BytesID(nameTag))
-	newRollupIDProviderPool.Init()
-
-	newRollupIDFn := func(newName []byte, tagPairs []id.TagPair) []byte {
-		// First filter out any tags that have a prefix that
-		// are not included in output metric IDs (such as metric
-		// type tags that are just used for filtering like __m3_type__).
-		filtered := tagPairs[:0]
-	TagPairsFilterLoop:
-		for i := range tagPairs {
-			for _, filter := range defaultFilterOutTagPrefixes {
-				if bytes.HasPrefix(tagPairs[i].Name, filter) {
-					continue TagPairsFilterLoop
-				}
-			}
-			filtered = append(filtered, tagPairs[i])
-		}
-
-		// Create the rollup using filtered tag pairs.
-		rollupIDProvider := newRollupIDProviderPool.Get()
-		id, err := rollupIDProvider.provide(newName, filtered)
-		if err != nil {
-			panic(err) // Encoding should never fail
-		}
-		rollupIDProvider.finalize()
-		return id
-	}
-
-	return rules.NewOptions().
-		SetTagsFilterOptions(tagsFilterOpts).
-		SetNewRollupIDFn(newRollupIDFn).
-		SetIsRollupIDFn(isRollupIDFn)
+	return rules.NewRuleSetOptionsWithPools(o.NameTagOrDefault(), pools.tagEncoderPool,
+		o.TagEncoderPoolOptions, pools.metricTagsIteratorPool)
 }
 
 func (o DownsamplerOptions) newAggregatorMatcher(
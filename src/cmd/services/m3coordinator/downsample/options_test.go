@@ -26,9 +26,12 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
 
 	"github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/metrics/aggregation"
+	"github.com/m3db/m3/src/metrics/metric/id"
+	"github.com/m3db/m3/src/metrics/pipeline"
 	"github.com/m3db/m3/src/metrics/policy"
 	"github.com/m3db/m3/src/query/storage/m3"
 	"github.com/m3db/m3/src/x/ident"
@@ -106,3 +109,125 @@ func TestAutoMappingRules(t *testing.T) {
 		},
 	}, rules)
 }
+
+func TestRollupRuleConfigurationRelabelTransform(t *testing.T) {
+	input := `
+filter: name:http_requests
+transforms:
+  - relabel:
+      action: replace
+      sourceTags: ["host"]
+      regex: "[^.]+\\.(.*)"
+      targetTag: dc
+  - rollup:
+      metricName: http_requests_by_dc
+      groupBy: ["dc"]
+      aggregations: ["Sum"]
+storagePolicies:
+  - resolution: 10s
+    retention: 48h
+`
+	var cfg RollupRuleConfiguration
+	require.NoError(t, yaml.Unmarshal([]byte(input), &cfg))
+
+	rule, err := cfg.Rule()
+	require.NoError(t, err)
+	require.Len(t, rule.Targets, 1)
+
+	targetPipeline := rule.Targets[0].Pipeline
+	require.Equal(t, 2, targetPipeline.Len())
+	require.Equal(t, pipeline.RelabelOpType, targetPipeline.At(0).Type)
+	require.Equal(t, pipeline.RollupOpType, targetPipeline.At(1).Type)
+
+	relabelOp := targetPipeline.At(0).Relabel
+	require.Equal(t, pipeline.ReplaceRelabelAction, relabelOp.Action)
+	require.Equal(t, "$1", string(relabelOp.Replacement))
+	require.Equal(t, []id.TagPair{
+		{Name: []byte("dc"), Value: []byte("dc1")},
+		{Name: []byte("host"), Value: []byte("host-1.dc1")},
+	}, relabelOp.Apply([]id.TagPair{
+		{Name: []byte("host"), Value: []byte("host-1.dc1")},
+	}))
+}
+
+func TestRollupRuleConfigurationTransformOperations(t *testing.T) {
+	inputs := []struct {
+		name      string
+		transform string
+		expectErr bool
+	}{
+		{
+			name: "no operation",
+			transform: `
+  - {}
+`,
+			expectErr: true,
+		},
+		{
+			name: "more than one operation",
+			transform: `
+  - relabel:
+      action: labeldrop
+      regex: host
+    rollup:
+      metricName: http_requests_by_dc
+      groupBy: ["dc"]
+      aggregations: ["Sum"]
+`,
+			expectErr: true,
+		},
+		{
+			name: "one operation",
+			transform: `
+  - rollup:
+      metricName: http_requests_by_dc
+      groupBy: ["dc"]
+      aggregations: ["Sum"]
+`,
+		},
+	}
+
+	for _, input := range inputs {
+		t.Run(input.name, func(t *testing.T) {
+			var cfg RollupRuleConfiguration
+			require.NoError(t, yaml.Unmarshal([]byte("transforms:"+input.transform), &cfg))
+
+			_, err := cfg.Rule()
+			if input.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMappingRuleConfigurationRelabel(t *testing.T) {
+	input := `
+filter: name:http_requests
+aggregations: ["Sum"]
+relabel:
+  - action: replace
+    sourceTags: ["host"]
+    regex: "[^.]+\\.(.*)"
+    targetTag: dc
+  - action: labeldrop
+    regex: host
+storagePolicies:
+  - resolution: 10s
+    retention: 48h
+`
+	var cfg MappingRuleConfiguration
+	require.NoError(t, yaml.Unmarshal([]byte(input), &cfg))
+
+	rule, err := cfg.Rule()
+	require.NoError(t, err)
+	require.Len(t, rule.Relabel, 2)
+	require.Equal(t, pipeline.ReplaceRelabelAction, rule.Relabel[0].Action)
+	require.Equal(t, pipeline.LabelDropRelabelAction, rule.Relabel[1].Action)
+
+	cfg.Drop = true
+	cfg.StoragePolicies = nil
+	_, err = cfg.Rule()
+	require.Error(t, err)
+}
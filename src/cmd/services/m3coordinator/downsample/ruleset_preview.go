@@ -0,0 +1,196 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package downsample
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/m3db/m3/src/metrics/metadata"
+	"github.com/m3db/m3/src/metrics/metric/id"
+	"github.com/m3db/m3/src/metrics/rules"
+	"github.com/m3db/m3/src/metrics/rules/view"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/serialize"
+)
+
+// ErrSeriesSamplingDisabled is returned when previewing a ruleset while the
+// downsampler is not sampling the series it sees.
+var ErrSeriesSamplingDisabled = errors.New("downsampler series sampling is not enabled")
+
+// RuleSetPreview is the estimated impact of a ruleset on the series of its
+// namespace recently seen by the downsampler.
+type RuleSetPreview struct {
+	// SampledSeries is the number of sampled series of the namespace.
+	SampledSeries int
+	// EstimatedSeries is the estimated number of series of the namespace.
+	EstimatedSeries float64
+	// DroppedSeries is the number of sampled series the ruleset drops.
+	DroppedSeries int
+	// DroppedRatio is the ratio of the series of the namespace the ruleset drops.
+	DroppedRatio float64
+	// RollupTargets are the previews of the rollup targets that match any
+	// of the sampled series.
+	RollupTargets []RollupTargetPreview
+}
+
+// RollupTargetPreview is the estimated impact of a rollup target.
+type RollupTargetPreview struct {
+	// RuleName is the name of the rollup rule.
+	RuleName string
+	// TargetIndex is the index of the target within the rollup rule.
+	TargetIndex int
+	// SampledSeries is the number of sampled series rolled up by the target.
+	SampledSeries int
+	// EstimatedSeries is the estimated number of series rolled up by the target.
+	EstimatedSeries float64
+	// SampledOutputSeries is the number of distinct rollup series the
+	// sampled series are rolled up into.
+	SampledOutputSeries int
+	// EstimatedOutputSeries is the estimated number of distinct rollup series
+	// output by the target.
+	EstimatedOutputSeries float64
+}
+
+type rollupTargetKey struct {
+	ruleName    string
+	targetIndex int
+}
+
+type rollupTargetSample struct {
+	sampledSeries int
+	outputSeries  map[string]int
+}
+
+func (d *downsampler) PreviewRuleSet(rsv view.RuleSet) (RuleSetPreview, error) {
+	d.RLock()
+	agg := d.agg
+	d.RUnlock()
+
+	if agg.sampler == nil {
+		return RuleSetPreview{}, ErrSeriesSamplingDisabled
+	}
+	rs, err := rules.NewRuleSetFromView(rsv, 0, agg.ruleSetOpts)
+	if err != nil {
+		return RuleSetPreview{}, xerrors.NewInvalidParamsError(err)
+	}
+
+	var (
+		sample    = agg.sampler.snapshot()
+		nowNanos  = d.opts.ClockOptions.NowFn()().UnixNano()
+		limits    = d.opts.TagDecoderOptions.TagSerializationLimits()
+		idIter    = serialize.NewUncheckedMetricTagsIterator(limits)
+		tagIter   = serialize.NewUncheckedMetricTagsIterator(limits)
+		namespace = []byte(rsv.Namespace)
+		matchOpts = rules.MatchOptions{
+			NameAndTagsFn: rules.NewEncodedTagsNameAndTagsFn(d.opts.NameTagOrDefault()),
+			SortedTagIteratorFn: func(tagPairs []byte) id.SortedTagIterator {
+				tagIter.Reset(tagPairs)
+				return tagIter
+			},
+		}
+		targets = make(map[rollupTargetKey]*rollupTargetSample)
+		preview RuleSetPreview
+	)
+	for _, seriesID := range sample.IDs {
+		idIter.Reset(seriesID)
+		if !bytes.Equal(agg.namespaceResolver.Resolve(idIter), namespace) {
+			continue
+		}
+		preview.SampledSeries++
+
+		explanation, err := rules.Explain(rs, idIter, nowNanos, matchOpts)
+		if err != nil {
+			return RuleSetPreview{}, err
+		}
+
+		// Series are only dropped by the mapping rules of the latest staged
+		// metadata, as series that no rule matches fall back to the
+		// auto-mapping rules.
+		staged := explanation.MatchResult.ForExistingIDAt(nowNanos)
+		if !staged.IsDefault() && len(staged) != 0 {
+			// NB: applying the drop policies mutates the pipelines in place.
+			pipelines := append(metadata.PipelineMetadatas(nil), staged[len(staged)-1].Pipelines...)
+			if _, res := pipelines.ApplyOrRemoveDropPolicies(); res == metadata.AppliedEffectiveDropPolicyResult {
+				preview.DroppedSeries++
+			}
+		}
+
+		for _, target := range explanation.RollupTargets {
+			key := rollupTargetKey{ruleName: target.RuleName, targetIndex: target.TargetIndex}
+			targetSample, ok := targets[key]
+			if !ok {
+				targetSample = &rollupTargetSample{outputSeries: make(map[string]int)}
+				targets[key] = targetSample
+			}
+			targetSample.sampledSeries++
+			targetSample.outputSeries[string(target.RollupID)]++
+		}
+	}
+
+	if preview.SampledSeries == 0 {
+		return preview, nil
+	}
+
+	// Every series is sampled with the same probability, so the number of
+	// series is scaled up from the sample by the same factor.
+	scale := sample.EstimatedSeries / float64(len(sample.IDs))
+	preview.EstimatedSeries = float64(preview.SampledSeries) * scale
+	preview.DroppedRatio = float64(preview.DroppedSeries) / float64(preview.SampledSeries)
+	for key, targetSample := range targets {
+		estimatedSeries := float64(targetSample.sampledSeries) * scale
+		preview.RollupTargets = append(preview.RollupTargets, RollupTargetPreview{
+			RuleName:              key.ruleName,
+			TargetIndex:           key.targetIndex,
+			SampledSeries:         targetSample.sampledSeries,
+			EstimatedSeries:       estimatedSeries,
+			SampledOutputSeries:   len(targetSample.outputSeries),
+			EstimatedOutputSeries: math.Min(estimatedOutputSeries(targetSample.outputSeries, scale), estimatedSeries),
+		})
+	}
+	sort.Slice(preview.RollupTargets, func(i, j int) bool {
+		if preview.RollupTargets[i].RuleName != preview.RollupTargets[j].RuleName {
+			return preview.RollupTargets[i].RuleName < preview.RollupTargets[j].RuleName
+		}
+		return preview.RollupTargets[i].TargetIndex < preview.RollupTargets[j].TargetIndex
+	})
+	return preview, nil
+}
+
+// estimatedOutputSeries estimates the number of distinct rollup series from
+// the number of sampled series rolled up into each of them. A rollup series
+// that more than one sampled series is rolled up into most likely groups
+// many series and is counted once, while one that a single sampled series is
+// rolled up into stands for as many rollup series as each sampled series
+// stands for series.
+func estimatedOutputSeries(outputSeries map[string]int, scale float64) float64 {
+	var estimate float64
+	for _, sampledSeries := range outputSeries {
+		if sampledSeries > 1 {
+			estimate++
+		} else {
+			estimate += scale
+		}
+	}
+	return estimate
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package downsample
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/metrics/aggregation"
+	"github.com/m3db/m3/src/metrics/pipeline"
+	"github.com/m3db/m3/src/metrics/policy"
+	"github.com/m3db/m3/src/metrics/rules/view"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+func TestDownsamplerPreviewRuleSet(t *testing.T) {
+	testDownsampler := newTestDownsampler(t, testDownsamplerOptions{
+		seriesSampling: SeriesSamplingConfiguration{Enabled: true},
+	})
+	downsampler := testDownsampler.downsampler
+
+	appender, err := downsampler.NewMetricsAppender()
+	require.NoError(t, err)
+	defer appender.Finalize()
+
+	for i := 0; i < 10; i++ {
+		appender.NextMetric()
+		appender.AddTag([]byte("__name__"), []byte("http_requests"))
+		appender.AddTag([]byte("service"), []byte(fmt.Sprintf("service-%d", i%2)))
+		appender.AddTag([]byte("instance"), []byte(fmt.Sprintf("instance-%d", i)))
+		_, err := appender.SamplesAppender(SampleAppenderOptions{})
+		require.NoError(t, err)
+	}
+	for i := 0; i < 5; i++ {
+		appender.NextMetric()
+		appender.AddTag([]byte("__name__"), []byte("debug_info"))
+		appender.AddTag([]byte("instance"), []byte(fmt.Sprintf("instance-%d", i)))
+		_, err := appender.SamplesAppender(SampleAppenderOptions{})
+		require.NoError(t, err)
+	}
+
+	rollupOp, err := pipeline.NewRollupOp(
+		pipeline.GroupByRollupType,
+		"http_requests_by_service",
+		[]string{"service"},
+		aggregation.DefaultID,
+	)
+	require.NoError(t, err)
+
+	preview, err := downsampler.PreviewRuleSet(view.RuleSet{
+		Namespace: "default",
+		MappingRules: []view.MappingRule{
+			{
+				Name:       "drop_debug_info",
+				Filter:     "__name__:debug_info",
+				DropPolicy: policy.DropMust,
+			},
+		},
+		RollupRules: []view.RollupRule{
+			{
+				Name:   "http_requests_by_service",
+				Filter: "__name__:http_requests",
+				Targets: []view.RollupTarget{
+					{
+						Pipeline: pipeline.NewPipeline([]pipeline.OpUnion{
+							{
+								Type:   pipeline.RollupOpType,
+								Rollup: rollupOp,
+							},
+						}),
+						StoragePolicies: policy.StoragePolicies{
+							policy.NewStoragePolicy(time.Minute, xtime.Minute, 48*time.Hour),
+						},
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, RuleSetPreview{
+		SampledSeries:   15,
+		EstimatedSeries: 15,
+		DroppedSeries:   5,
+		DroppedRatio:    1.0 / 3,
+		RollupTargets: []RollupTargetPreview{
+			{
+				RuleName:              "http_requests_by_service",
+				TargetIndex:           0,
+				SampledSeries:         10,
+				EstimatedSeries:       10,
+				SampledOutputSeries:   2,
+				EstimatedOutputSeries: 2,
+			},
+		},
+	}, preview)
+
+	// Series of other namespaces are not previewed.
+	preview, err = downsampler.PreviewRuleSet(view.RuleSet{Namespace: "other"})
+	require.NoError(t, err)
+	require.Equal(t, RuleSetPreview{}, preview)
+}
+
+func TestDownsamplerPreviewRuleSetInvalidRuleSet(t *testing.T) {
+	testDownsampler := newTestDownsampler(t, testDownsamplerOptions{
+		seriesSampling: SeriesSamplingConfiguration{Enabled: true},
+	})
+
+	_, err := testDownsampler.downsampler.PreviewRuleSet(view.RuleSet{
+		Namespace: "default",
+		MappingRules: []view.MappingRule{
+			{Name: "rule", Filter: "__name__:foo"},
+			{Name: "rule", Filter: "__name__:bar"},
+		},
+	})
+	require.Error(t, err)
+	require.True(t, xerrors.IsInvalidParams(err))
+}
+
+func TestDownsamplerPreviewRuleSetSamplingDisabled(t *testing.T) {
+	testDownsampler := newTestDownsampler(t, testDownsamplerOptions{})
+
+	_, err := testDownsampler.downsampler.PreviewRuleSet(view.RuleSet{Namespace: "default"})
+	require.Equal(t, ErrSeriesSamplingDisabled, err)
+}
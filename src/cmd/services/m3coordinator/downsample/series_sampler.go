@@ -0,0 +1,205 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package downsample
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cespare/xxhash/v2"
+	"go.uber.org/atomic"
+)
+
+const (
+	defaultSeriesSamplerSize   = 10000
+	defaultSeriesSamplerWindow = 10 * time.Minute
+)
+
+// seriesSampler keeps a bounded sample of the distinct series recently seen
+// by the downsampler. The series with the smallest hashes of their IDs are
+// kept (a bottom-k sketch) so that each series is sampled at most once no
+// matter how often it is written, every series is equally likely to be
+// sampled, and the number of distinct series seen can be estimated from the
+// largest hash kept.
+//
+// Sampled series are kept in two generations that are rotated each window,
+// so a snapshot covers the series seen in the last one to two windows.
+type seriesSampler struct {
+	sync.Mutex
+
+	size   int
+	window time.Duration
+
+	// threshold is the hash a series must be under to enter the current
+	// generation, which lets most series skip taking the lock.
+	threshold     *atomic.Uint64
+	rotateAtNanos *atomic.Int64
+	curr          *seriesSamplerGeneration
+	prev          *seriesSamplerGeneration
+}
+
+func newSeriesSampler(size int, window time.Duration, nowNanos int64) *seriesSampler {
+	if size <= 0 {
+		size = defaultSeriesSamplerSize
+	}
+	if window <= 0 {
+		window = defaultSeriesSamplerWindow
+	}
+	return &seriesSampler{
+		size:          size,
+		window:        window,
+		threshold:     atomic.NewUint64(math.MaxUint64),
+		rotateAtNanos: atomic.NewInt64(nowNanos + int64(window)),
+		curr:          newSeriesSamplerGeneration(size),
+		prev:          newSeriesSamplerGeneration(0),
+	}
+}
+
+// record records a write for the series with the given ID, the ID is copied
+// if the series is sampled.
+func (s *seriesSampler) record(id []byte, nowNanos int64) {
+	hash := xxhash.Sum64(id)
+	if hash >= s.threshold.Load() && nowNanos < s.rotateAtNanos.Load() {
+		return
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	if rotateAtNanos := s.rotateAtNanos.Load(); nowNanos >= rotateAtNanos {
+		s.prev = s.curr
+		s.curr = newSeriesSamplerGeneration(s.size)
+		if nowNanos >= rotateAtNanos+int64(s.window) {
+			// No series were recorded for over a window, drop the previous
+			// generation as well.
+			s.prev = newSeriesSamplerGeneration(0)
+		}
+		s.rotateAtNanos.Store(nowNanos + int64(s.window))
+		s.threshold.Store(math.MaxUint64)
+	}
+
+	if hash >= s.threshold.Load() {
+		return
+	}
+	if s.curr.add(hash, id, s.size) {
+		s.threshold.Store(s.curr.threshold(s.size))
+	}
+}
+
+// seriesSample is a sample of the distinct series recently seen.
+type seriesSample struct {
+	// IDs are the IDs of the sampled series.
+	IDs [][]byte
+	// EstimatedSeries is the estimated number of distinct series seen.
+	EstimatedSeries float64
+}
+
+// snapshot returns the sample of the series seen in the current and the
+// previous generations.
+func (s *seriesSampler) snapshot() seriesSample {
+	s.Lock()
+	hashes := make([]uint64, 0, len(s.curr.ids)+len(s.prev.ids))
+	ids := make(map[uint64][]byte, len(s.curr.ids)+len(s.prev.ids))
+	for _, gen := range []*seriesSamplerGeneration{s.prev, s.curr} {
+		for hash, id := range gen.ids {
+			if _, ok := ids[hash]; !ok {
+				hashes = append(hashes, hash)
+			}
+			ids[hash] = id
+		}
+	}
+	s.Unlock()
+
+	// The union of two bottom-k sketches is a bottom-k sketch of the union
+	// once trimmed back to the k smallest hashes.
+	sort.Slice(hashes, func(i, j int) bool { return hashes[i] < hashes[j] })
+	if len(hashes) > s.size {
+		hashes = hashes[:s.size]
+	}
+
+	sample := seriesSample{
+		IDs:             make([][]byte, 0, len(hashes)),
+		EstimatedSeries: float64(len(hashes)),
+	}
+	for _, hash := range hashes {
+		sample.IDs = append(sample.IDs, ids[hash])
+	}
+	if len(hashes) == s.size && s.size > 1 {
+		// The k-th smallest of n uniform hashes is expected at k/(n+1) of
+		// the hash space, (k-1) over its normalized value is unbiased for n.
+		kth := float64(hashes[len(hashes)-1]) / math.MaxUint64
+		sample.EstimatedSeries = math.Max(float64(s.size-1)/kth, float64(s.size))
+	}
+	return sample
+}
+
+type seriesSamplerGeneration struct {
+	ids    map[uint64][]byte
+	hashes seriesSamplerHashHeap
+}
+
+func newSeriesSamplerGeneration(size int) *seriesSamplerGeneration {
+	return &seriesSamplerGeneration{
+		ids:    make(map[uint64][]byte, size),
+		hashes: make(seriesSamplerHashHeap, 0, size),
+	}
+}
+
+// add adds a series to the generation evicting the series with the largest
+// hash if the generation is over size, returns whether the series was added.
+func (g *seriesSamplerGeneration) add(hash uint64, id []byte, size int) bool {
+	if _, ok := g.ids[hash]; ok {
+		return false
+	}
+	g.ids[hash] = append([]byte(nil), id...)
+	heap.Push(&g.hashes, hash)
+	if len(g.hashes) > size {
+		delete(g.ids, heap.Pop(&g.hashes).(uint64))
+	}
+	return true
+}
+
+// threshold returns the hash a series must be under to be added.
+func (g *seriesSamplerGeneration) threshold(size int) uint64 {
+	if len(g.hashes) < size {
+		return math.MaxUint64
+	}
+	return g.hashes[0]
+}
+
+// seriesSamplerHashHeap is a max heap of hashes.
+type seriesSamplerHashHeap []uint64
+
+func (h seriesSamplerHashHeap) Len() int            { return len(h) }
+func (h seriesSamplerHashHeap) Less(i, j int) bool  { return h[i] > h[j] }
+func (h seriesSamplerHashHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *seriesSamplerHashHeap) Push(x interface{}) { *h = append(*h, x.(uint64)) }
+
+func (h *seriesSamplerHashHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package downsample
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeriesSamplerSamplesDistinctSeries(t *testing.T) {
+	now := time.Now().UnixNano()
+	sampler := newSeriesSampler(100, time.Minute, now)
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 10; j++ {
+			sampler.record([]byte(fmt.Sprintf("series-%d", j)), now)
+		}
+	}
+
+	sample := sampler.snapshot()
+	require.Equal(t, 10, len(sample.IDs))
+	require.Equal(t, 10.0, sample.EstimatedSeries)
+
+	ids := make([]string, 0, len(sample.IDs))
+	for _, id := range sample.IDs {
+		ids = append(ids, string(id))
+	}
+	sort.Strings(ids)
+	require.Equal(t, "series-0", ids[0])
+	require.Equal(t, "series-9", ids[9])
+}
+
+func TestSeriesSamplerEstimatesSeries(t *testing.T) {
+	var (
+		now        = time.Now().UnixNano()
+		sampler    = newSeriesSampler(1000, time.Minute, now)
+		numSeries  = 100000
+		sampledIDs = make(map[string]struct{})
+	)
+	for i := 0; i < numSeries; i++ {
+		sampler.record([]byte(fmt.Sprintf("series-%d", i)), now)
+	}
+
+	sample := sampler.snapshot()
+	require.Equal(t, 1000, len(sample.IDs))
+	for _, id := range sample.IDs {
+		sampledIDs[string(id)] = struct{}{}
+	}
+	require.Equal(t, 1000, len(sampledIDs))
+
+	// The relative standard error of the estimate is about 1/sqrt(k).
+	require.InEpsilon(t, float64(numSeries), sample.EstimatedSeries, 0.15)
+}
+
+func TestSeriesSamplerRotatesGenerations(t *testing.T) {
+	var (
+		now     = time.Now().UnixNano()
+		window  = time.Minute
+		sampler = newSeriesSampler(100, window, now)
+	)
+	sampler.record([]byte("first"), now)
+
+	// Still sampled for the window after the one it was recorded in.
+	now += int64(window)
+	sampler.record([]byte("second"), now)
+	require.Equal(t, 2, len(sampler.snapshot().IDs))
+
+	now += int64(window)
+	sampler.record([]byte("third"), now)
+	sample := sampler.snapshot()
+	require.Equal(t, 2, len(sample.IDs))
+	for _, id := range sample.IDs {
+		require.NotEqual(t, "first", string(id))
+	}
+
+	// Nothing recorded for over a window drops both generations.
+	now += 2 * int64(window)
+	sampler.record([]byte("fourth"), now)
+	sample = sampler.snapshot()
+	require.Equal(t, 1, len(sample.IDs))
+	require.Equal(t, "fourth", string(sample.IDs[0]))
+}
@@ -22,10 +22,13 @@
 package ingestcarbon
 
 import (
+	"bufio"
 	"bytes"
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"regexp"
 	"sort"
@@ -58,6 +61,12 @@ const (
 	maxResourcePoolNameSize = 1024
 	maxPooledTagsSize       = 16
 	defaultResourcePoolSize = 4096
+
+	// maxPickleMessageSize is the largest pickle message accepted, the same
+	// as the limit of carbon itself.
+	maxPickleMessageSize = 1 << 20
+	// maxPacketSize is the largest UDP packet.
+	maxPacketSize = 65535
 )
 
 var (
@@ -93,12 +102,26 @@ func (o *Options) Validate() error {
 	return nil
 }
 
+// Ingester ingests carbon metrics. As a server handler it handles connections
+// using the plaintext line protocol.
+type Ingester interface {
+	m3xserver.Handler
+
+	// PickleHandler returns a server handler for connections using the pickle
+	// protocol.
+	PickleHandler() m3xserver.Handler
+
+	// HandlePackets handles UDP packets using the plaintext line protocol
+	// until the connection is closed.
+	HandlePackets(conn net.PacketConn)
+}
+
 // NewIngester returns an ingester for carbon metrics.
 func NewIngester(
 	downsamplerAndWriter ingest.DownsamplerAndWriter,
 	clusterNamespacesWatcher m3.ClusterNamespacesWatcher,
 	opts Options,
-) (m3xserver.Handler, error) {
+) (Ingester, error) {
 	err := opts.Validate()
 	if err != nil {
 		return nil, err
@@ -276,61 +299,173 @@ func (i *ingester) Handle(conn net.Conn) {
 		// Interfaces require a context be passed, but M3DB client already has timeouts
 		// built in and allocating a new context each time is expensive so we just pass
 		// the same context always and rely on M3DB client timeouts.
-		ctx     = context.Background()
-		wg      = sync.WaitGroup{}
-		s       = carbon.NewScanner(conn, i.opts.InstrumentOptions)
-		logger  = i.opts.InstrumentOptions.Logger()
-		rewrite = &i.opts.IngesterConfig.Rewrite
+		ctx    = context.Background()
+		wg     = sync.WaitGroup{}
+		s      = carbon.NewScanner(conn, i.opts.InstrumentOptions)
+		logger = i.opts.InstrumentOptions.Logger()
 	)
 
 	logger.Debug("handling new carbon ingestion connection")
 	for s.Scan() {
-		received := time.Now()
 		name, timestamp, value := s.Metric()
+		i.ingest(ctx, &wg, name, timestamp, value)
+
+		i.metrics.malformed.Inc(int64(s.MalformedCount))
+		s.MalformedCount = 0
+	}
 
-		resources := i.getLineResources()
+	if err := s.Err(); err != nil {
+		logger.Error("encountered error during carbon ingestion when scanning connection", zap.Error(err))
+	}
 
-		// Copy name since scanner bytes are recycled.
-		resources.name = copyAndRewrite(resources.name, name, rewrite)
+	logger.Debug("waiting for outstanding carbon ingestion writes to complete")
+	wg.Wait()
+	logger.Debug("all outstanding writes completed, shutting down carbon ingestion handler")
 
-		wg.Add(1)
-		i.opts.WorkerPool.Go(func() {
-			ok := i.write(ctx, resources, xtime.ToUnixNano(timestamp), value)
-			if ok {
-				i.metrics.success.Inc(1)
-			}
+	// Don't close the connection, that is the server's responsibility.
+}
+
+func (i *ingester) PickleHandler() m3xserver.Handler {
+	return pickleHandler{ingester: i}
+}
 
-			now := time.Now()
+// pickleHandler handles connections using the carbon pickle protocol, each
+// message is a 4 byte big endian length followed by a pickled list of
+// (path, (timestamp, value)) tuples.
+type pickleHandler struct {
+	*ingester
+}
 
-			// Always record age regardless of success/failure since
-			// sometimes errors can be due to how old the metrics are
-			// and not recording age would obscure this visibility from
-			// the metrics of how fresh/old the incoming metrics are.
-			age := now.Sub(timestamp)
-			i.metrics.ingestLatency.RecordDuration(age)
+func (h pickleHandler) Handle(conn net.Conn) {
+	var (
+		ctx    = context.Background()
+		wg     = sync.WaitGroup{}
+		r      = bufio.NewReader(conn)
+		logger = h.opts.InstrumentOptions.Logger()
+		header [4]byte
+		buf    []byte
+	)
 
-			// Also record write latency (not relative to metric timestamp).
-			i.metrics.writeLatency.RecordDuration(now.Sub(received))
+	logger.Debug("handling new carbon pickle ingestion connection")
+	for {
+		if _, err := io.ReadFull(r, header[:]); err != nil {
+			if err != io.EOF {
+				logger.Error("encountered error during carbon pickle ingestion when reading connection",
+					zap.Error(err))
+			}
+			break
+		}
 
-			// The contract is that after the DownsamplerAndWriter returns, any resources
-			// that it needed to hold onto have already been copied.
-			i.putLineResources(resources)
-			wg.Done()
-		})
+		size := binary.BigEndian.Uint32(header[:])
+		if size > maxPickleMessageSize {
+			// NB: the connection can't be read from past a message that is not
+			// read in full so stop handling it.
+			logger.Error("carbon pickle message too large",
+				zap.Uint32("size", size), zap.Int("maxSize", maxPickleMessageSize))
+			h.metrics.malformed.Inc(1)
+			break
+		}
 
-		i.metrics.malformed.Inc(int64(s.MalformedCount))
-		s.MalformedCount = 0
+		if cap(buf) < int(size) {
+			buf = make([]byte, size)
+		}
+		buf = buf[:size]
+		if _, err := io.ReadFull(r, buf); err != nil {
+			logger.Error("encountered error during carbon pickle ingestion when reading connection",
+				zap.Error(err))
+			break
+		}
+
+		metrics, malformed, err := parsePickleMessage(buf)
+		if err != nil {
+			logger.Error("error trying to parse malformed carbon pickle message", zap.Error(err))
+			h.metrics.malformed.Inc(1)
+			continue
+		}
+
+		for _, metric := range metrics {
+			h.ingest(ctx, &wg, metric.Name, metric.Time, metric.Val)
+		}
+		h.metrics.malformed.Inc(int64(malformed))
 	}
 
-	if err := s.Err(); err != nil {
-		logger.Error("encountered error during carbon ingestion when scanning connection", zap.Error(err))
+	logger.Debug("waiting for outstanding carbon pickle ingestion writes to complete")
+	wg.Wait()
+	logger.Debug("all outstanding writes completed, shutting down carbon pickle ingestion handler")
+}
+
+func (i *ingester) HandlePackets(conn net.PacketConn) {
+	var (
+		ctx     = context.Background()
+		wg      = sync.WaitGroup{}
+		logger  = i.opts.InstrumentOptions.Logger()
+		buf     = make([]byte, maxPacketSize)
+		metrics []carbon.Metric
+	)
+
+	logger.Debug("handling carbon ingestion packets")
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				logger.Error("encountered error during carbon ingestion when reading packets", zap.Error(err))
+			}
+			break
+		}
+
+		var malformed int
+		metrics, malformed = carbon.ParseAndAppendPacket(metrics[:0], buf[:n])
+		for _, metric := range metrics {
+			// Names are copied before ingest returns so the buffer can be reused.
+			i.ingest(ctx, &wg, metric.Name, metric.Time, metric.Val)
+		}
+		i.metrics.malformed.Inc(int64(malformed))
 	}
 
 	logger.Debug("waiting for outstanding carbon ingestion writes to complete")
 	wg.Wait()
-	logger.Debug("all outstanding writes completed, shutting down carbon ingestion handler")
+	logger.Debug("all outstanding writes completed, shutting down carbon ingestion packet handler")
+}
 
-	// Don't close the connection, that is the server's responsibility.
+// ingest rewrites the name of a metric and writes it asynchronously, the name
+// is copied so it can be reused once ingest returns.
+func (i *ingester) ingest(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	name []byte,
+	timestamp time.Time,
+	value float64,
+) {
+	received := time.Now()
+	resources := i.getLineResources()
+
+	// Copy name since scanner bytes are recycled.
+	resources.name = copyAndRewrite(resources.name, name, &i.opts.IngesterConfig.Rewrite)
+
+	wg.Add(1)
+	i.opts.WorkerPool.Go(func() {
+		ok := i.write(ctx, resources, xtime.ToUnixNano(timestamp), value)
+		if ok {
+			i.metrics.success.Inc(1)
+		}
+
+		now := time.Now()
+
+		// Always record age regardless of success/failure since
+		// sometimes errors can be due to how old the metrics are
+		// and not recording age would obscure this visibility from
+		// the metrics of how fresh/old the incoming metrics are.
+		age := now.Sub(timestamp)
+		i.metrics.ingestLatency.RecordDuration(age)
+
+		// Also record write latency (not relative to metric timestamp).
+		i.metrics.writeLatency.RecordDuration(now.Sub(received))
+
+		// The contract is that after the DownsamplerAndWriter returns, any resources
+		// that it needed to hold onto have already been copied.
+		i.putLineResources(resources)
+		wg.Done()
+	})
 }
 
 func (i *ingester) write(
@@ -478,6 +613,16 @@ func newCarbonIngesterMetrics(scope tally.Scope) (carbonIngesterMetrics, error)
 //	__g0__:foo
 //	__g1__:bar
 //	__g2__:baz
+//
+// Tagged names in the Graphite 1.1 format, such as:
+//
+//	foo.bar.baz;dc=us;host=a
+//
+// become
+//
+//	dc:us
+//	host:a
+//	name:foo.bar.baz
 func GenerateTagsFromName(
 	name []byte,
 	opts models.TagOptions,
@@ -504,6 +649,10 @@ func generateTagsFromName(
 		return models.EmptyTags(), errCannotGenerateTagsFromEmptyName
 	}
 
+	if graphite.IsTaggedName(name) {
+		return generateTagsFromTaggedName(name, opts, tags)
+	}
+
 	numTags := bytes.Count(name, carbonSeparatorBytes) + 1
 
 	if cap(tags) >= numTags {
@@ -549,6 +698,32 @@ func generateTagsFromName(
 	return models.Tags{Opts: opts, Tags: tags}, nil
 }
 
+func generateTagsFromTaggedName(
+	name []byte,
+	opts models.TagOptions,
+	tags []models.Tag,
+) (models.Tags, error) {
+	path, taggedTags, err := graphite.ParseTaggedName(name)
+	if err != nil {
+		return models.EmptyTags(),
+			fmt.Errorf("carbon metric: %s has invalid tags: %w", string(name), err)
+	}
+
+	numTags := len(taggedTags) + 1
+	if cap(tags) >= numTags {
+		tags = tags[:0]
+	} else {
+		tags = make([]models.Tag, 0, numTags)
+	}
+
+	tags = append(tags, models.Tag{Name: graphite.NameTag, Value: path})
+	for _, tag := range taggedTags {
+		tags = append(tags, models.Tag{Name: tag.Name, Value: tag.Value})
+	}
+
+	return models.Tags{Opts: opts, Tags: tags}.Normalize(), nil
+}
+
 // Compile all the carbon ingestion rules into matcher so that we can
 // perform matching. Also, generate all the mapping rules and storage
 // policies that we will need to pass to the DownsamplerAndWriter upfront
@@ -23,6 +23,7 @@ package ingestcarbon
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
@@ -44,6 +45,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/metrics/aggregation"
 	"github.com/m3db/m3/src/metrics/policy"
+	"github.com/m3db/m3/src/query/api/v1/handler/graphite/pickle"
 	"github.com/m3db/m3/src/query/graphite/graphite"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage/m3"
@@ -261,6 +263,101 @@ func TestIngesterHandleConn(t *testing.T) {
 	assertTestMetricsAreEqual(t, testMetrics, found)
 }
 
+func TestIngesterHandlePickleConn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDownsamplerAndWriter, found := newMockDownsamplerAndWriter(ctrl,
+		func(_ []downsample.AutoMappingRule) {})
+
+	session := client.NewMockSession(ctrl)
+	watcher := newTestWatcher(t, session, m3.AggregatedClusterNamespaceDefinition{
+		NamespaceID: ident.StringID("10s:48h"),
+		Resolution:  10 * time.Second,
+		Retention:   48 * time.Hour,
+		Session:     session,
+	})
+
+	var buf bytes.Buffer
+	for i := 0; i < len(testMetrics); i += 1000 {
+		end := i + 1000
+		if end > len(testMetrics) {
+			end = len(testMetrics)
+		}
+		writePickleMessage(t, &buf, testMetrics[i:end])
+
+		// Follow each message with one that can't be unpickled to test that
+		// the following messages are still read.
+		writePickleMessageBytes(&buf, []byte("garbage"))
+	}
+
+	byteConn := &byteConn{b: bytes.NewReader(buf.Bytes())}
+	ingester, err := NewIngester(mockDownsamplerAndWriter, watcher, newTestOpts(testRulesMatchAll))
+	require.NoError(t, err)
+	ingester.PickleHandler().Handle(byteConn)
+
+	assertTestMetricsAreEqual(t, testMetrics, *found)
+}
+
+func TestIngesterHandlePickleConnMessageTooLarge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDownsamplerAndWriter, found := newMockDownsamplerAndWriter(ctrl,
+		func(_ []downsample.AutoMappingRule) {})
+
+	session := client.NewMockSession(ctrl)
+	watcher := newTestWatcher(t, session, m3.AggregatedClusterNamespaceDefinition{
+		NamespaceID: ident.StringID("10s:48h"),
+		Resolution:  10 * time.Second,
+		Retention:   48 * time.Hour,
+		Session:     session,
+	})
+
+	var buf bytes.Buffer
+	writePickleMessage(t, &buf, testMetrics[:1])
+	writePickleMessageBytes(&buf, make([]byte, maxPickleMessageSize+1))
+	writePickleMessage(t, &buf, testMetrics[1:2])
+
+	byteConn := &byteConn{b: bytes.NewReader(buf.Bytes())}
+	ingester, err := NewIngester(mockDownsamplerAndWriter, watcher, newTestOpts(testRulesMatchAll))
+	require.NoError(t, err)
+	ingester.PickleHandler().Handle(byteConn)
+
+	// Messages after a message that is too large are not read.
+	assertTestMetricsAreEqual(t, testMetrics[:1], *found)
+}
+
+func TestIngesterHandlePackets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDownsamplerAndWriter, found := newMockDownsamplerAndWriter(ctrl,
+		func(_ []downsample.AutoMappingRule) {})
+
+	session := client.NewMockSession(ctrl)
+	watcher := newTestWatcher(t, session, m3.AggregatedClusterNamespaceDefinition{
+		NamespaceID: ident.StringID("10s:48h"),
+		Resolution:  10 * time.Second,
+		Retention:   48 * time.Hour,
+		Session:     session,
+	})
+
+	// Split the lines of the test packet into packets of at most 1000 bytes.
+	var (
+		packets [][]byte
+		packet  []byte
+	)
+	for _, line := range bytes.SplitAfter(testPacket, []byte("\n")) {
+		if len(packet)+len(line) > 1000 {
+			packets = append(packets, packet)
+			packet = nil
+		}
+		packet = append(packet, line...)
+	}
+	packets = append(packets, packet)
+
+	ingester, err := NewIngester(mockDownsamplerAndWriter, watcher, newTestOpts(testRulesMatchAll))
+	require.NoError(t, err)
+	ingester.HandlePackets(&packetConn{packets: packets})
+
+	assertTestMetricsAreEqual(t, testMetrics, *found)
+}
+
 func TestIngesterHonorsMatchers(t *testing.T) {
 	tests := []struct {
 		name                 string
@@ -601,6 +698,23 @@ func TestGenerateTagsFromName(t *testing.T) {
 				{Name: graphite.TagName(2), Value: []byte("baz")},
 			},
 		},
+		{
+			name: "foo.bar.baz;host=a;dc=us",
+			id:   "foo.bar.baz;dc=us;host=a",
+			expectedTags: []models.Tag{
+				{Name: []byte("dc"), Value: []byte("us")},
+				{Name: []byte("host"), Value: []byte("a")},
+				{Name: graphite.NameTag, Value: []byte("foo.bar.baz")},
+			},
+		},
+		{
+			name: "foo.bar;dc=eu;dc=us",
+			id:   "foo.bar;dc=us",
+			expectedTags: []models.Tag{
+				{Name: []byte("dc"), Value: []byte("us")},
+				{Name: graphite.NameTag, Value: []byte("foo.bar")},
+			},
+		},
 		{
 			name:         "foo..bar..baz..",
 			expectedErr:  fmt.Errorf("carbon metric: foo..bar..baz.. has duplicate separator"),
@@ -626,6 +740,20 @@ func TestGenerateTagsFromName(t *testing.T) {
 	}
 }
 
+func TestGenerateTagsFromTaggedNameInvalid(t *testing.T) {
+	opts := models.NewTagOptions().SetIDSchemeType(models.TypeGraphite)
+	for _, name := range []string{
+		"foo.bar;dc",
+		"foo.bar;dc=",
+		"foo.bar;dc=~us",
+		"foo.bar;__g0__=us",
+		";dc=us",
+	} {
+		_, err := GenerateTagsFromName([]byte(name), opts)
+		require.Error(t, err, name)
+	}
+}
+
 func newTestOpts(rules CarbonIngesterRules) Options {
 	cfg := config.CarbonIngesterConfiguration{Rules: rules.Rules}
 	opts := testOptions
@@ -705,6 +833,76 @@ func (b *byteConn) SetWriteDeadline(t time.Time) error {
 	panic("not_implemented")
 }
 
+// packetConn implements the net.PacketConn interface so that we can test the
+// packet handler without going over the network.
+type packetConn struct {
+	packets [][]byte
+}
+
+func (p *packetConn) ReadFrom(buf []byte) (int, net.Addr, error) {
+	if len(p.packets) == 0 {
+		return 0, nil, net.ErrClosed
+	}
+
+	n := copy(buf, p.packets[0])
+	p.packets = p.packets[1:]
+	return n, nil, nil
+}
+
+func (p *packetConn) WriteTo(buf []byte, addr net.Addr) (int, error) {
+	panic("not_implemented")
+}
+
+func (p *packetConn) Close() error {
+	p.packets = nil
+	return nil
+}
+
+func (p *packetConn) LocalAddr() net.Addr {
+	panic("not_implemented")
+}
+
+func (p *packetConn) SetDeadline(t time.Time) error {
+	panic("not_implemented")
+}
+
+func (p *packetConn) SetReadDeadline(t time.Time) error {
+	panic("not_implemented")
+}
+
+func (p *packetConn) SetWriteDeadline(t time.Time) error {
+	panic("not_implemented")
+}
+
+// writePickleMessage writes the metrics as a carbon pickle message with an
+// invalid metric at the end.
+func writePickleMessage(t *testing.T, buf *bytes.Buffer, metrics []testMetric) {
+	var msg bytes.Buffer
+	w := pickle.NewWriter(&msg)
+	w.BeginList()
+	for _, metric := range metrics {
+		w.BeginList()
+		w.WriteString(string(metric.metric))
+		w.BeginList()
+		w.WriteInt(metric.timestamp)
+		w.WriteFloat64(metric.value)
+		w.EndList()
+		w.EndList()
+	}
+	w.WriteString("garbage")
+	w.EndList()
+	require.NoError(t, w.Close())
+
+	writePickleMessageBytes(buf, msg.Bytes())
+}
+
+func writePickleMessageBytes(buf *bytes.Buffer, msg []byte) {
+	var header [4]byte
+	binary.BigEndian.PutUint32(header[:], uint32(len(msg)))
+	buf.Write(header[:])
+	buf.Write(msg)
+}
+
 type testMetric struct {
 	metric    []byte
 	tags      models.Tags
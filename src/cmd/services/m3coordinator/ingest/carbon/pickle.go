@@ -0,0 +1,120 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ingestcarbon
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/m3db/m3/src/metrics/carbon"
+	"github.com/m3db/m3/src/query/api/v1/handler/graphite/pickle"
+)
+
+var errPickleMessageNotList = errors.New("carbon pickle message is not a list")
+
+// parsePickleMessage parses a carbon pickle message, a pickled list of
+// (path, (timestamp, value)) tuples, and returns the metrics and the number
+// of malformed metrics.
+func parsePickleMessage(b []byte) ([]carbon.Metric, int, error) {
+	v, err := pickle.Unpickle(b)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	items, ok := v.([]interface{})
+	if !ok {
+		return nil, 0, errPickleMessageNotList
+	}
+
+	var (
+		metrics   = make([]carbon.Metric, 0, len(items))
+		malformed int
+	)
+	for _, item := range items {
+		metric, err := parsePickleMetric(item)
+		if err != nil {
+			malformed++
+			continue
+		}
+		metrics = append(metrics, metric)
+	}
+
+	return metrics, malformed, nil
+}
+
+func parsePickleMetric(v interface{}) (carbon.Metric, error) {
+	metric, ok := v.([]interface{})
+	if !ok || len(metric) != 2 {
+		return carbon.Metric{}, fmt.Errorf("invalid carbon pickle metric: %v", v)
+	}
+
+	name, ok := metric[0].(string)
+	if !ok || len(name) == 0 || !utf8.ValidString(name) {
+		return carbon.Metric{}, fmt.Errorf("invalid carbon pickle metric name: %v", metric[0])
+	}
+
+	datapoint, ok := metric[1].([]interface{})
+	if !ok || len(datapoint) != 2 {
+		return carbon.Metric{}, fmt.Errorf("invalid carbon pickle datapoint: %v", metric[1])
+	}
+
+	timestamp, err := pickleNumber(datapoint[0])
+	if err != nil || math.IsNaN(timestamp) || math.IsInf(timestamp, 0) {
+		return carbon.Metric{}, fmt.Errorf("invalid carbon pickle timestamp: %v", datapoint[0])
+	}
+
+	value, err := pickleNumber(datapoint[1])
+	if err != nil {
+		return carbon.Metric{}, fmt.Errorf("invalid carbon pickle value: %v", datapoint[1])
+	}
+
+	return carbon.Metric{
+		Name: []byte(name),
+		// Timestamps are truncated to seconds, the same as plaintext metrics.
+		Time: time.Unix(int64(timestamp), 0),
+		Val:  value,
+	}, nil
+}
+
+// pickleNumber returns the value of a pickled number, carbon clients can
+// send numbers as strings.
+func pickleNumber(v interface{}) (float64, error) {
+	switch v := v.(type) {
+	case int64:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	case string:
+		return strconv.ParseFloat(strings.TrimSpace(v), 64)
+	default:
+		return 0, fmt.Errorf("invalid carbon pickle number: %v", v)
+	}
+}
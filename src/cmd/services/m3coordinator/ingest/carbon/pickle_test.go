@@ -0,0 +1,65 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ingestcarbon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/metrics/carbon"
+)
+
+func TestParsePickleMessage(t *testing.T) {
+	// Pickled by python with protocol 2 of:
+	// [('foo.bar', (1600000000, 1.5)), ('baz', ('1600000010.7', '-2')),
+	//  ('qux;dc=us', (1600000020.0, 3)), ('bad', 1), ('', (1, 1)),
+	//  ('bad', ('1', 'x'))]
+	msg := []byte("\x80\x02]q\x00(X\x07\x00\x00\x00foo.barq\x01J\x00\x10^_G?\xf8\x00\x00\x00\x00\x00\x00\x86q\x02\x86q\x03" +
+		"X\x03\x00\x00\x00bazq\x04X\x0c\x00\x00\x001600000010.7q\x05X\x02\x00\x00\x00-2q\x06\x86q\x07\x86q\x08" +
+		"X\t\x00\x00\x00qux;dc=usq\tGA\xd7\xd7\x84\x05\x00\x00\x00K\x03\x86q\n\x86q\x0b" +
+		"X\x03\x00\x00\x00badq\x0cK\x01\x86q\rX\x00\x00\x00\x00q\x0eK\x01K\x01\x86q\x0f\x86q\x10" +
+		"h\x0cX\x01\x00\x00\x001q\x11X\x01\x00\x00\x00xq\x12\x86q\x13\x86q\x14e.")
+
+	metrics, malformed, err := parsePickleMessage(msg)
+	require.NoError(t, err)
+	assert.Equal(t, 3, malformed)
+	assert.Equal(t, []carbon.Metric{
+		{Name: []byte("foo.bar"), Time: time.Unix(1600000000, 0), Val: 1.5},
+		{Name: []byte("baz"), Time: time.Unix(1600000010, 0), Val: -2},
+		{Name: []byte("qux;dc=us"), Time: time.Unix(1600000020, 0), Val: 3},
+	}, metrics)
+}
+
+func TestParsePickleMessageInvalid(t *testing.T) {
+	for _, msg := range []string{
+		"garbage",
+		// A dict.
+		"}.",
+		// An int.
+		"K\x01.",
+	} {
+		_, _, err := parsePickleMessage([]byte(msg))
+		assert.Error(t, err, "message %q", msg)
+	}
+}
@@ -21,7 +21,10 @@
 package ingestcarbon
 
 import (
+	"bytes"
+
 	"github.com/m3db/m3/src/cmd/services/m3query/config"
+	"github.com/m3db/m3/src/query/graphite/graphite"
 )
 
 // nolint: gocyclo
@@ -34,6 +37,13 @@ func copyAndRewrite(
 		return append(dst[:0], src...)
 	}
 
+	// Only the name of tagged series is rewritten, tags are validated
+	// separately when the tags are generated.
+	var tags []byte
+	if idx := bytes.IndexByte(src, graphite.TagSeparator); idx >= 0 {
+		src, tags = src[:idx], src[idx:]
+	}
+
 	// Copy into dst as we rewrite.
 	dst = dst[:0]
 	leadingDots := true
@@ -84,5 +94,5 @@ func copyAndRewrite(
 		// Remove trailing dot.
 		dst = dst[:i]
 	}
-	return dst
+	return append(dst, tags...)
 }
@@ -113,6 +113,14 @@ func TestCopyAndRewrite(t *testing.T) {
 				Cleanup: true,
 			},
 		},
+		{
+			name:     "tagged name with rewrite cleanup",
+			input:    "foo$$..bar.;dc=us-east;path=/a/b",
+			expected: "foo_.bar;dc=us-east;path=/a/b",
+			cfg: &config.CarbonIngesterRewriteConfiguration{
+				Cleanup: true,
+			},
+		},
 	}
 
 	for _, test := range tests {
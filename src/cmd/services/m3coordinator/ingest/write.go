@@ -31,6 +31,7 @@ import (
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/storage/m3/storagemetadata"
+	"github.com/m3db/m3/src/query/tenant"
 	"github.com/m3db/m3/src/query/ts"
 	xerrors "github.com/m3db/m3/src/x/errors"
 	"github.com/m3db/m3/src/x/instrument"
@@ -199,7 +200,7 @@ func (d *downsamplerAndWriter) Write(
 		d.metrics.dropped.report(source)
 	} else if d.shouldWrite(overrides) {
 		err := d.writeToStorage(ctx, tags, datapoints, unit, annotation, overrides, source)
-		if err != nil {
+		if err != nil && !d.tenantDownsampledOnly(overrides, err) {
 			multiErr = multiErr.Add(err)
 		}
 	}
@@ -207,6 +208,20 @@ func (d *downsamplerAndWriter) Write(
 	return multiErr.FinalError()
 }
 
+// tenantDownsampledOnly returns whether a write to the default unaggregated
+// namespace failed because the tenant of the series only writes aggregated
+// namespaces, the series is then only written downsampled.
+func (d *downsamplerAndWriter) tenantDownsampledOnly(
+	overrides WriteOptions,
+	err error,
+) bool {
+	if !tenant.IsNamespaceNotWritten(err) {
+		return false
+	}
+	_, writeOverride := d.writeOverrideStoragePolicies(overrides)
+	return !writeOverride && d.shouldDownsample(overrides)
+}
+
 func (d *downsamplerAndWriter) shouldWrite(
 	overrides WriteOptions,
 ) bool {
@@ -468,7 +483,7 @@ func (d *downsamplerAndWriter) WriteBatch(
 					if err == nil {
 						err = d.store.Write(ctx, writeQuery)
 					}
-					if err != nil {
+					if err != nil && !d.tenantDownsampledOnly(overrides, err) {
 						addError(err)
 					}
 					wg.Done()
@@ -36,6 +36,7 @@ import (
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/storage/m3"
+	"github.com/m3db/m3/src/query/tenant"
 	testm3 "github.com/m3db/m3/src/query/test/m3"
 	"github.com/m3db/m3/src/query/ts"
 	xerrors "github.com/m3db/m3/src/x/errors"
@@ -231,6 +232,43 @@ func TestDownsampleAndWrite(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestDownsampleAndWriteTenantNamespaceNotWritten(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		store       = storage.NewMockStorage(ctrl)
+		downsampler = downsample.NewMockDownsampler(ctrl)
+		tenantErr   = xerrors.NewInvalidParamsError(
+			fmt.Errorf("%w: tenant=a", tenant.ErrNamespaceNotWritten))
+	)
+	downsampler.EXPECT().Enabled().Return(true).AnyTimes()
+	store.EXPECT().Write(gomock.Any(), gomock.Any()).Return(tenantErr).Times(2)
+	downAndWrite := NewDownsamplerAndWriter(store, downsampler, testWorkerPool,
+		instrument.NewOptions()).(*downsamplerAndWriter)
+
+	// The series of a tenant that does not write the unaggregated namespace
+	// are only written downsampled.
+	expectDefaultDownsampling(ctrl, testDatapoints1, downsampler, zeroDownsamplerAppenderOpts)
+	err := downAndWrite.Write(
+		context.Background(), testTags1, testDatapoints1, xtime.Second, testAnnotation1, defaultOverride, source)
+	require.NoError(t, err)
+
+	// But writes to the namespaces requested by the caller are rejected.
+	overrides := WriteOptions{
+		DownsampleOverride: true,
+		WriteOverride:      true,
+		WriteStoragePolicies: policy.StoragePolicies{
+			policy.MustParseStoragePolicy("1m:48h"),
+		},
+	}
+	err = downAndWrite.Write(
+		context.Background(), testTags1, testDatapoints1, xtime.Second, testAnnotation1, overrides, source)
+	require.Error(t, err)
+	require.True(t, xerrors.IsInvalidParams(err))
+	require.True(t, tenant.IsNamespaceNotWritten(err))
+}
+
 func TestDownsampleAndWriteWithBadTags(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -24,12 +24,14 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/m3db/m3/src/dbnode/backup"
 	"github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/persist/fs/migration"
 	"github.com/m3db/m3/src/dbnode/storage"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/bootstrapper"
+	bbackup "github.com/m3db/m3/src/dbnode/storage/bootstrap/bootstrapper/backup"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/bootstrapper/commitlog"
 	bfs "github.com/m3db/m3/src/dbnode/storage/bootstrap/bootstrapper/fs"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/bootstrapper/peers"
@@ -151,6 +153,10 @@ type BootstrapConfiguration struct {
 	// Peers bootstrapper configuration.
 	Peers *BootstrapPeersConfiguration `yaml:"peers"`
 
+	// Backup bootstrapper configuration, the backup bootstrapper runs after
+	// the filesystem bootstrapper when set.
+	Backup *BootstrapBackupConfiguration `yaml:"backup"`
+
 	// CacheSeriesMetadata determines whether individual bootstrappers cache
 	// series metadata across all calls (namespaces / shards / blocks).
 	CacheSeriesMetadata *bool `yaml:"cacheSeriesMetadata"`
@@ -267,6 +273,20 @@ type BootstrapPeersConfiguration struct {
 	StreamPersistShardFlushConcurrency *int `yaml:"streamPersistShardFlushConcurrency"`
 }
 
+// BootstrapBackupConfiguration specifies config for the backup bootstrapper.
+type BootstrapBackupConfiguration struct {
+	// Store is the blob store the backups are restored from.
+	Store backup.StoreConfiguration `yaml:"store"`
+
+	// HostID is the ID of the host whose backup is restored, defaults to the
+	// ID of this host.
+	HostID string `yaml:"hostID"`
+
+	// BackupID is the ID of the backup restored, defaults to the latest
+	// backup of the host.
+	BackupID string `yaml:"backupID"`
+}
+
 // New creates a bootstrap process based on the bootstrap configuration.
 func (bsc BootstrapConfiguration) New(
 	rsOpts result.Options,
@@ -306,30 +326,27 @@ func (bsc BootstrapConfiguration) New(
 		case bootstrapper.NoOpNoneBootstrapperName:
 			bs = bootstrapper.NewNoOpNoneBootstrapperProvider()
 		case bfs.FileSystemBootstrapperName:
-			fsCfg := bsc.filesystemConfig()
-			fsbOpts := bfs.NewOptions().
-				SetInstrumentOptions(opts.InstrumentOptions()).
-				SetResultOptions(rsOpts).
-				SetFilesystemOptions(fsOpts).
-				SetIndexOptions(opts.IndexOptions()).
-				SetPersistManager(opts.PersistManager()).
-				SetIndexClaimsManager(opts.IndexClaimsManager()).
-				SetCompactor(compactor).
-				SetRuntimeOptionsManager(opts.RuntimeOptionsManager()).
-				SetIdentifierPool(opts.IdentifierPool()).
-				SetMigrationOptions(fsCfg.migration().NewOptions()).
-				SetStorageOptions(opts).
-				SetIndexSegmentsVerify(bsc.VerifyOrDefault().VerifyIndexSegmentsOrDefault())
-			if v := bsc.IndexSegmentConcurrency; v != nil {
-				fsbOpts = fsbOpts.SetIndexSegmentConcurrency(*v)
-			}
-			if err := fsbOpts.Validate(); err != nil {
+			fsbOpts, err := bsc.filesystemBootstrapperOptions(rsOpts, opts, compactor)
+			if err != nil {
 				return nil, err
 			}
 			bs, err = bfs.NewFileSystemBootstrapperProvider(fsbOpts, bs)
 			if err != nil {
 				return nil, err
 			}
+		case bbackup.BackupBootstrapperName:
+			fsbOpts, err := bsc.filesystemBootstrapperOptions(rsOpts, opts, compactor)
+			if err != nil {
+				return nil, err
+			}
+			bOpts, err := bsc.backupConfig().newOptions(fsbOpts, origin)
+			if err != nil {
+				return nil, err
+			}
+			bs, err = bbackup.NewBackupBootstrapperProvider(bOpts, bs)
+			if err != nil {
+				return nil, err
+			}
 		case commitlog.CommitLogBootstrapperName:
 			cCfg := bsc.commitlogConfig()
 			cOpts := commitlog.NewOptions().
@@ -401,6 +418,66 @@ func (bsc BootstrapConfiguration) New(
 	return bootstrap.NewProcessProvider(bs, providerOpts, rsOpts, fsOpts)
 }
 
+func (bsc BootstrapConfiguration) filesystemBootstrapperOptions(
+	rsOpts result.Options,
+	opts storage.Options,
+	compactor *compaction.Compactor,
+) (bfs.Options, error) {
+	fsCfg := bsc.filesystemConfig()
+	fsbOpts := bfs.NewOptions().
+		SetInstrumentOptions(opts.InstrumentOptions()).
+		SetResultOptions(rsOpts).
+		SetFilesystemOptions(opts.CommitLogOptions().FilesystemOptions()).
+		SetIndexOptions(opts.IndexOptions()).
+		SetPersistManager(opts.PersistManager()).
+		SetIndexClaimsManager(opts.IndexClaimsManager()).
+		SetCompactor(compactor).
+		SetRuntimeOptionsManager(opts.RuntimeOptionsManager()).
+		SetIdentifierPool(opts.IdentifierPool()).
+		SetMigrationOptions(fsCfg.migration().NewOptions()).
+		SetStorageOptions(opts).
+		SetIndexSegmentsVerify(bsc.VerifyOrDefault().VerifyIndexSegmentsOrDefault())
+	if v := bsc.IndexSegmentConcurrency; v != nil {
+		fsbOpts = fsbOpts.SetIndexSegmentConcurrency(*v)
+	}
+	if err := fsbOpts.Validate(); err != nil {
+		return nil, err
+	}
+	return fsbOpts, nil
+}
+
+func (c BootstrapBackupConfiguration) newOptions(
+	fsbOpts bfs.Options,
+	origin topology.Host,
+) (bbackup.Options, error) {
+	store, err := c.Store.NewStore()
+	if err != nil {
+		return nil, err
+	}
+	manager, err := backup.NewManager(backup.NewOptions().
+		SetStore(store).
+		SetHostID(origin.ID()).
+		SetFilesystemOptions(fsbOpts.FilesystemOptions()).
+		SetInstrumentOptions(fsbOpts.InstrumentOptions()))
+	if err != nil {
+		return nil, err
+	}
+
+	hostID := c.HostID
+	if hostID == "" {
+		hostID = origin.ID()
+	}
+	bOpts := bbackup.NewOptions().
+		SetFilesystemBootstrapperOptions(fsbOpts).
+		SetBackupManager(manager).
+		SetHostID(hostID).
+		SetBackupID(c.BackupID)
+	if err := bOpts.Validate(); err != nil {
+		return nil, err
+	}
+	return bOpts, nil
+}
+
 func (bsc BootstrapConfiguration) filesystemConfig() BootstrapFilesystemConfiguration {
 	if cfg := bsc.Filesystem; cfg != nil {
 		return *cfg
@@ -422,7 +499,32 @@ func (bsc BootstrapConfiguration) peersConfig() BootstrapPeersConfiguration {
 	return BootstrapPeersConfiguration{}
 }
 
+func (bsc BootstrapConfiguration) backupConfig() BootstrapBackupConfiguration {
+	if cfg := bsc.Backup; cfg != nil {
+		return *cfg
+	}
+	return BootstrapBackupConfiguration{}
+}
+
 func (bsc BootstrapConfiguration) orderedBootstrappers() []string {
+	ordered := bsc.modeOrderedBootstrappers()
+	if bsc.Backup == nil {
+		return ordered
+	}
+
+	// The backup bootstrapper restores what the filesystem bootstrapper
+	// could not find on disk.
+	withBackup := make([]string, 0, len(ordered)+1)
+	for _, name := range ordered {
+		withBackup = append(withBackup, name)
+		if name == bfs.FileSystemBootstrapperName {
+			withBackup = append(withBackup, bbackup.BackupBootstrapperName)
+		}
+	}
+	return withBackup
+}
+
+func (bsc BootstrapConfiguration) modeOrderedBootstrappers() []string {
 	if bsc.BootstrapMode != nil {
 		switch *bsc.BootstrapMode {
 		case DefaultBootstrapMode:
@@ -36,13 +36,16 @@ import (
 	"gopkg.in/yaml.v2"
 
 	coordinatorcfg "github.com/m3db/m3/src/cmd/services/m3query/config"
+	"github.com/m3db/m3/src/dbnode/backup"
 	"github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/dbnode/discovery"
 	"github.com/m3db/m3/src/dbnode/environment"
+	"github.com/m3db/m3/src/dbnode/storage"
 	"github.com/m3db/m3/src/dbnode/storage/repair"
 	"github.com/m3db/m3/src/dbnode/storage/series"
 	"github.com/m3db/m3/src/x/config/hostid"
 	"github.com/m3db/m3/src/x/debug/config"
+	"github.com/m3db/m3/src/x/ident"
 	"github.com/m3db/m3/src/x/instrument"
 	xlog "github.com/m3db/m3/src/x/log"
 	"github.com/m3db/m3/src/x/opentracing"
@@ -203,6 +206,18 @@ type DBConfiguration struct {
 	// The replication policy for replicating data between clusters.
 	Replication *ReplicationPolicy `yaml:"replication"`
 
+	// The tile aggregation configuration for aggregating flushed blocks of
+	// namespaces into other namespaces.
+	TileAggregation *TileAggregationConfiguration `yaml:"tileAggregation"`
+
+	// The backup configuration for periodically backing up the filesets of
+	// namespaces to a blob store.
+	Backup *BackupConfiguration `yaml:"backup"`
+
+	// The cold tier configuration for offloading the filesets of blocks older
+	// than the cold tier age of their namespace to a blob store.
+	ColdTier *ColdTierConfiguration `yaml:"coldTier"`
+
 	// The pooling policy.
 	PoolingPolicy *PoolingPolicy `yaml:"pooling"`
 
@@ -221,6 +236,9 @@ type DBConfiguration struct {
 	// Proto contains the configuration specific to running in the ProtoDataMode.
 	Proto *ProtoConfiguration `yaml:"proto"`
 
+	// NativeHistograms contains the configuration for storing Prometheus native histograms.
+	NativeHistograms *NativeHistogramsConfiguration `yaml:"nativeHistograms"`
+
 	// Tracing configures opentracing. If not provided, tracing is disabled.
 	Tracing *opentracing.TracingConfiguration `yaml:"tracing"`
 
@@ -389,6 +407,10 @@ func (c *DBConfiguration) Validate() error {
 		return err
 	}
 
+	if c.NativeHistograms.EnabledOrDefault() && c.Proto != nil && c.Proto.Enabled {
+		return errors.New("nativeHistograms can not be enabled in Proto data mode")
+	}
+
 	if err := c.Transforms.Validate(); err != nil {
 		return err
 	}
@@ -588,6 +610,110 @@ type RepairPolicy struct {
 	DebugShadowComparisonsPercentage float64 `yaml:"debugShadowComparisonsPercentage"`
 }
 
+// TileAggregationConfiguration is the tile aggregation configuration.
+type TileAggregationConfiguration struct {
+	// Enabled enables the tile aggregator used by the AggregateTiles endpoint
+	// and the aggregation of the rules, the endpoint is a no-op otherwise.
+	Enabled bool `yaml:"enabled"`
+
+	// The interval at which flushed blocks are checked for aggregation.
+	CheckInterval time.Duration `yaml:"checkInterval"`
+
+	// The rules aggregating source namespaces into target namespaces.
+	Rules []TileAggregationRuleConfiguration `yaml:"rules"`
+}
+
+// TileAggregationRules returns the tile aggregation rules.
+func (c *TileAggregationConfiguration) TileAggregationRules() ([]storage.TileAggregationRule, error) {
+	rules := make([]storage.TileAggregationRule, 0, len(c.Rules))
+	for _, r := range c.Rules {
+		rule, err := r.TileAggregationRule()
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// TileAggregationRuleConfiguration is the configuration of a rule aggregating
+// the tiles of a source namespace into a target namespace.
+type TileAggregationRuleConfiguration struct {
+	// The namespace the datapoints are read from.
+	SourceNamespace string `yaml:"sourceNamespace" validate:"nonzero"`
+
+	// The namespace the tiles are written to.
+	TargetNamespace string `yaml:"targetNamespace" validate:"nonzero"`
+
+	// The size of the tiles.
+	Step time.Duration `yaml:"step" validate:"nonzero"`
+
+	// The aggregation applied to the datapoints of each tile, one of last,
+	// sum, min, max or count. Defaults to last.
+	Aggregation string `yaml:"aggregation"`
+}
+
+// TileAggregationRule returns the tile aggregation rule.
+func (c TileAggregationRuleConfiguration) TileAggregationRule() (storage.TileAggregationRule, error) {
+	aggregation := storage.TileAggregationLast
+	if c.Aggregation != "" {
+		var err error
+		aggregation, err = storage.ParseTileAggregation(c.Aggregation)
+		if err != nil {
+			return storage.TileAggregationRule{}, err
+		}
+	}
+
+	rule := storage.TileAggregationRule{
+		SourceNamespace: ident.StringID(c.SourceNamespace),
+		TargetNamespace: ident.StringID(c.TargetNamespace),
+		Step:            c.Step,
+		Aggregation:     aggregation,
+	}
+	if err := rule.Validate(); err != nil {
+		return storage.TileAggregationRule{}, err
+	}
+	return rule, nil
+}
+
+// BackupConfiguration is the backup configuration.
+type BackupConfiguration struct {
+	// The blob store the backups are uploaded to.
+	Store backup.StoreConfiguration `yaml:"store"`
+
+	// The namespaces backed up, all namespaces are backed up if empty.
+	Namespaces []string `yaml:"namespaces"`
+
+	// The interval between backups, defaults to a day.
+	Interval time.Duration `yaml:"interval"`
+
+	// The number of backups retained, all backups are retained if zero.
+	Retain int `yaml:"retain" validate:"min=0"`
+}
+
+// NamespaceIDs returns the IDs of the namespaces backed up.
+func (c *BackupConfiguration) NamespaceIDs() []ident.ID {
+	ids := make([]ident.ID, 0, len(c.Namespaces))
+	for _, ns := range c.Namespaces {
+		ids = append(ids, ident.StringID(ns))
+	}
+	return ids
+}
+
+// ColdTierConfiguration is the cold tier configuration.
+type ColdTierConfiguration struct {
+	// The blob store the filesets are offloaded to.
+	Store backup.StoreConfiguration `yaml:"store"`
+
+	// How long the filesets fetched from the blob store are cached on disk,
+	// defaults to an hour.
+	CacheTTL time.Duration `yaml:"cacheTTL"`
+
+	// How long the files in the blob store of the filesets removed from disk
+	// are kept before they are deleted, defaults to a day.
+	OrphanGracePeriod time.Duration `yaml:"orphanGracePeriod"`
+}
+
 // ReplicationPolicy is the replication policy.
 type ReplicationPolicy struct {
 	Clusters []ReplicatedCluster `yaml:"clusters"`
@@ -681,6 +807,22 @@ func (c *ProtoConfiguration) Validate() error {
 	return nil
 }
 
+// NativeHistogramsConfiguration is the configuration for storing Prometheus
+// native histograms.
+type NativeHistogramsConfiguration struct {
+	// Enabled specifies whether series are encoded with the histogram encoding
+	// scheme, which stores native histograms in a dedicated column instead of
+	// datapoint annotations. Nodes always read both schemes, but nodes and
+	// coordinators of earlier versions can not read the histogram scheme so
+	// every node and coordinator must be upgraded before enabling it.
+	Enabled bool `yaml:"enabled"`
+}
+
+// EnabledOrDefault returns whether the histogram encoding scheme is enabled.
+func (c *NativeHistogramsConfiguration) EnabledOrDefault() bool {
+	return c != nil && c.Enabled
+}
+
 // NewEtcdEmbedConfig creates a new embedded etcd config from kv config.
 func NewEtcdEmbedConfig(cfg DBConfiguration) (*embed.Config, error) {
 	newKVCfg := embed.NewConfig()
@@ -427,6 +427,7 @@ func TestConfiguration(t *testing.T) {
     commitlog:
       returnUnfulfilledForCorruptCommitLogFiles: false
     peers: null
+    backup: null
     cacheSeriesMetadata: null
     indexSegmentConcurrency: null
     verify: null
@@ -473,6 +474,9 @@ func TestConfiguration(t *testing.T) {
     debugShadowComparisonsEnabled: false
     debugShadowComparisonsPercentage: 0
   replication: null
+  tileAggregation: null
+  backup: null
+  coldTier: null
   pooling:
     blockAllocSize: 16
     thriftBytesPoolAllocSize: 2048
@@ -703,6 +707,7 @@ func TestConfiguration(t *testing.T) {
   writeNewSeriesAsync: true
   writeNewSeriesBackoffDuration: 2ms
   proto: null
+  nativeHistograms: null
   tracing:
     serviceName: ""
     backend: jaeger
@@ -761,6 +766,7 @@ func TestConfiguration(t *testing.T) {
     maxOutstandingRepairedBytes: 0
     maxEncodersPerBlock: 0
     writeNewSeriesPerSecond: 0
+    seriesLimitsShardSlack: null
   tchannel: null
   debug:
     mutexProfileFraction: 0
@@ -1023,6 +1029,20 @@ db:
 	}, cfg.DB.Proto.SchemaRegistry)
 }
 
+func TestNativeHistogramsConfig(t *testing.T) {
+	cfg := DBConfiguration{
+		NativeHistograms: &NativeHistogramsConfiguration{Enabled: true},
+	}
+	require.True(t, cfg.NativeHistograms.EnabledOrDefault())
+	require.NoError(t, cfg.Validate())
+
+	cfg.Proto = &ProtoConfiguration{Enabled: true}
+	require.Error(t, cfg.Validate())
+
+	cfg.NativeHistograms = nil
+	require.False(t, cfg.NativeHistograms.EnabledOrDefault())
+}
+
 func TestBootstrapCommitLogConfig(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -22,6 +22,8 @@ package config
 
 import "time"
 
+const defaultSeriesLimitsShardSlack = 0.2
+
 // LimitsConfiguration contains configuration for configurable limits that can be applied to M3DB.
 type LimitsConfiguration struct {
 	// MaxRecentlyQueriedSeriesDiskBytesRead sets the upper limit on time series bytes
@@ -72,6 +74,20 @@ type LimitsConfiguration struct {
 
 	// Write new series limit per second to limit overwhelming during new ID bursts.
 	WriteNewSeriesPerSecond int `yaml:"writeNewSeriesPerSecond" validate:"min=0"`
+
+	// SeriesLimitsShardSlack is the fraction of its even part of an active
+	// series limit that each shard accepts on top of it, to absorb series
+	// that are not evenly spread across the shards. Defaults to 0.2.
+	SeriesLimitsShardSlack *float64 `yaml:"seriesLimitsShardSlack"`
+}
+
+// SeriesLimitsShardSlackOrDefault returns the active series limits shard
+// slack or the default.
+func (c LimitsConfiguration) SeriesLimitsShardSlackOrDefault() float64 {
+	if c.SeriesLimitsShardSlack == nil || *c.SeriesLimitsShardSlack < 0 {
+		return defaultSeriesLimitsShardSlack
+	}
+	return *c.SeriesLimitsShardSlack
 }
 
 // MaxRecentQueryResourceLimitConfiguration sets an upper limit on resources consumed by all queries
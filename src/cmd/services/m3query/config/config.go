@@ -27,6 +27,7 @@ import (
 
 	etcdclient "github.com/m3db/m3/src/cluster/client/etcd"
 	"github.com/m3db/m3/src/cluster/placement"
+	"github.com/m3db/m3/src/cluster/services"
 	"github.com/m3db/m3/src/cmd/services/m3coordinator/downsample"
 	ingestm3msg "github.com/m3db/m3/src/cmd/services/m3coordinator/ingest/m3msg"
 	"github.com/m3db/m3/src/cmd/services/m3coordinator/server/m3msg"
@@ -38,6 +39,7 @@ import (
 	"github.com/m3db/m3/src/query/storage/m3"
 	"github.com/m3db/m3/src/query/storage/m3/consolidators"
 	"github.com/m3db/m3/src/query/storage/m3/storagemetadata"
+	"github.com/m3db/m3/src/query/tenant"
 	xconfig "github.com/m3db/m3/src/x/config"
 	"github.com/m3db/m3/src/x/debug/config"
 	"github.com/m3db/m3/src/x/instrument"
@@ -67,6 +69,9 @@ const (
 
 	defaultCarbonIngesterListenAddress = "0.0.0.0:7204"
 
+	defaultOTLPGRPCListenAddress = "0.0.0.0:4317"
+	defaultOTLPMaxRequestBytes   = 16 << 20
+
 	defaultQueryTimeout = 30 * time.Second
 
 	defaultPrometheusMaxSamplesPerQuery = 100000000
@@ -179,6 +184,19 @@ type Configuration struct {
 	// Carbon is the carbon configuration.
 	Carbon *CarbonConfiguration `yaml:"carbon"`
 
+	// OTLP is the OpenTelemetry OTLP metrics ingestion configuration.
+	OTLP *OTLPConfiguration `yaml:"otlp"`
+
+	// Exemplars is the exemplar storage configuration.
+	Exemplars *ExemplarsConfiguration `yaml:"exemplars"`
+
+	// Metadata is the Prometheus metric metadata storage configuration.
+	Metadata *MetadataConfiguration `yaml:"metadata"`
+
+	// Rules is the Prometheus recording and alerting rule evaluation
+	// configuration.
+	Rules *RulesConfiguration `yaml:"rules"`
+
 	// Middleware is middleware-specific configuration.
 	Middleware MiddlewareConfiguration `yaml:"middleware"`
 
@@ -188,6 +206,10 @@ type Configuration struct {
 	// Limits specifies limits on per-query resource usage.
 	Limits LimitsConfiguration `yaml:"limits"`
 
+	// Tenants is the configuration of the tenants, the reads and writes of
+	// a tenant are isolated to the series and namespaces of the tenant.
+	Tenants *tenant.Configuration `yaml:"tenants"`
+
 	// LookbackDuration determines the lookback duration for queries
 	LookbackDuration *time.Duration `yaml:"lookbackDuration"`
 
@@ -296,6 +318,8 @@ type QueryConfiguration struct {
 	// RequireSeriesEndpointStartEndTime requires requests to /series endpoint
 	// to specify a start and end time to prevent unbounded queries.
 	RequireSeriesEndpointStartEndTime bool `yaml:"requireSeriesEndpointStartEndTime"`
+	// ResultsCache configures caching of range query results.
+	ResultsCache *ResultsCacheConfiguration `yaml:"resultsCache"`
 }
 
 // TimeoutOrDefault returns the configured timeout or default value.
@@ -333,6 +357,20 @@ func (c QueryConfiguration) RestrictTagsAsStorageRestrictByTag() (*storage.Restr
 	return opts, true, nil
 }
 
+// ResultsCacheConfiguration is the range query results cache configuration.
+type ResultsCacheConfiguration struct {
+	// SplitInterval is the interval range queries are split by and results
+	// are cached per, defaults to 24h.
+	SplitInterval time.Duration `yaml:"splitInterval"`
+	// MaxFreshness is how long before now results are not cached since they
+	// can still change, defaults to 10m.
+	MaxFreshness time.Duration `yaml:"maxFreshness"`
+	// TTL is how long results are cached for, defaults to 1h.
+	TTL time.Duration `yaml:"ttl"`
+	// MaxEntries is the maximum number of cached results, defaults to 10000.
+	MaxEntries int `yaml:"maxEntries"`
+}
+
 // RestrictTagsConfiguration applies tag restriction to all queries.
 type RestrictTagsConfiguration struct {
 	Restrict []StringMatch `yaml:"match"`
@@ -626,10 +664,14 @@ type PrometheusMiddlewareConfiguration struct {
 
 // CarbonIngesterConfiguration is the configuration struct for carbon ingestion.
 type CarbonIngesterConfiguration struct {
-	ListenAddress  string                             `yaml:"listenAddress"`
-	MaxConcurrency int                                `yaml:"maxConcurrency"`
-	Rewrite        CarbonIngesterRewriteConfiguration `yaml:"rewrite"`
-	Rules          []CarbonIngesterRuleConfiguration  `yaml:"rules"`
+	ListenAddress string `yaml:"listenAddress"`
+	// PickleListenAddress if set starts a TCP listener for the pickle protocol.
+	PickleListenAddress string `yaml:"pickleListenAddress"`
+	// UDPListenAddress if set starts a UDP listener for the plaintext protocol.
+	UDPListenAddress string                             `yaml:"udpListenAddress"`
+	MaxConcurrency   int                                `yaml:"maxConcurrency"`
+	Rewrite          CarbonIngesterRewriteConfiguration `yaml:"rewrite"`
+	Rules            []CarbonIngesterRuleConfiguration  `yaml:"rules"`
 }
 
 // CarbonIngesterRewriteConfiguration is the configuration for rewriting
@@ -643,6 +685,139 @@ type CarbonIngesterRewriteConfiguration struct {
 	Cleanup bool `yaml:"cleanup"`
 }
 
+// OTLPConfiguration is the configuration for OTLP metrics ingestion. The
+// OTLP/HTTP endpoint is always served by the API server, the gRPC receiver
+// is only started if configured.
+type OTLPConfiguration struct {
+	// PromoteResourceAttributes is a list of resource attributes that are
+	// added as labels to every series, in addition to the job and instance
+	// labels derived from service.name and service.instance.id.
+	PromoteResourceAttributes []string `yaml:"promoteResourceAttributes"`
+	// MaxRequestBytes is the max size in bytes of OTLP/HTTP request bodies,
+	// both as received and once decompressed, defaults to 16MiB.
+	MaxRequestBytes int64 `yaml:"maxRequestBytes"`
+	// GRPC if set starts an OTLP/gRPC metrics receiver.
+	GRPC *OTLPGRPCConfiguration `yaml:"grpc"`
+}
+
+// MaxRequestBytesOrDefault returns the specified max size of OTLP/HTTP
+// request bodies if provided, or the default value if not.
+func (c *OTLPConfiguration) MaxRequestBytesOrDefault() int64 {
+	if c != nil && c.MaxRequestBytes > 0 {
+		return c.MaxRequestBytes
+	}
+
+	return defaultOTLPMaxRequestBytes
+}
+
+// OTLPGRPCConfiguration is the configuration for the OTLP/gRPC receiver.
+type OTLPGRPCConfiguration struct {
+	// ListenAddress is the gRPC listen address.
+	ListenAddress string `yaml:"listenAddress"`
+	// MaxRecvMsgSize is the max message size in bytes the receiver accepts.
+	MaxRecvMsgSize int `yaml:"maxRecvMsgSize"`
+}
+
+// ListenAddressOrDefault returns the specified OTLP/gRPC listen address if
+// provided, or the default value if not.
+func (c *OTLPGRPCConfiguration) ListenAddressOrDefault() string {
+	if c.ListenAddress != "" {
+		return c.ListenAddress
+	}
+
+	return defaultOTLPGRPCListenAddress
+}
+
+// ExemplarsConfiguration is the configuration for exemplar storage. Exemplars
+// received with Prometheus remote write are only stored if configured.
+type ExemplarsConfiguration struct {
+	// Namespace is the namespace exemplars are written to, it must be an
+	// indexed namespace in the unaggregated cluster. The namespace retention
+	// is the exemplar retention. It must not be used to store metrics.
+	Namespace string `yaml:"namespace" validate:"nonzero"`
+	// WriteConcurrency is the number of series whose exemplars are written
+	// concurrently, defaults to 64.
+	WriteConcurrency int `yaml:"writeConcurrency"`
+}
+
+// MetadataConfiguration is the configuration for storing the Prometheus metric
+// metadata received with remote write in the cluster KV store.
+type MetadataConfiguration struct {
+	// Enabled enables storing metric metadata.
+	Enabled bool `yaml:"enabled"`
+	// SyncInterval is how often metadata changes are written to the KV store
+	// and the metadata written by other coordinators is read back.
+	SyncInterval time.Duration `yaml:"syncInterval"`
+	// Retention is how long the metadata of a metric is kept once it is no
+	// longer written.
+	Retention time.Duration `yaml:"retention"`
+	// MaxMetricsPerShard is the maximum number of metrics stored under each
+	// of the KV keys metadata is spread across, the least recently written
+	// metrics are evicted from full keys.
+	MaxMetricsPerShard int `yaml:"maxMetricsPerShard"`
+}
+
+// RulesConfiguration is the configuration for evaluating Prometheus recording
+// and alerting rules, rules are only evaluated if configured.
+type RulesConfiguration struct {
+	// Files are the paths of Prometheus rule files to load, the last element
+	// of a path may be a glob pattern.
+	Files []string `yaml:"files"`
+
+	// KVKey is the cluster KV key of a Prometheus rule file to load, the value
+	// is the YAML rule file stored as a string proto.
+	KVKey string `yaml:"kvKey"`
+
+	// EvaluationInterval is the interval rule groups are evaluated at if a
+	// group does not set an interval.
+	EvaluationInterval time.Duration `yaml:"evaluationInterval"`
+
+	// ReloadInterval is how often rule files are reloaded.
+	ReloadInterval time.Duration `yaml:"reloadInterval"`
+
+	// ExternalLabels are labels added to the alerts sent to Alertmanager.
+	ExternalLabels map[string]string `yaml:"externalLabels"`
+
+	// ExternalURL is the URL the coordinator is reachable at, it is used for
+	// the generator URL of alerts.
+	ExternalURL string `yaml:"externalURL"`
+
+	// Alertmanagers are the Alertmanager-compatible webhooks alerts are sent
+	// to.
+	Alertmanagers []AlertmanagerConfiguration `yaml:"alertmanagers"`
+
+	// LeaderElection if set elects the coordinator that writes the results of
+	// recording rules and sends alerts, it must be set if more than one
+	// coordinator evaluates the same rules.
+	LeaderElection *RulesLeaderElectionConfiguration `yaml:"leaderElection"`
+}
+
+// RulesLeaderElectionConfiguration is the configuration of the leader election
+// of the coordinators evaluating the same rules, elections are held in the
+// cluster management etcd.
+type RulesLeaderElectionConfiguration struct {
+	// ServiceID is the service the election is held for, coordinators
+	// evaluating the same rules must use the same service ID.
+	ServiceID services.ServiceIDConfiguration `yaml:"serviceID"`
+
+	// ElectionID is the ID of the election, defaults to "ruler".
+	ElectionID string `yaml:"electionID"`
+
+	// Election configures the election timeouts and the leadership TTL.
+	Election services.ElectionConfiguration `yaml:"election"`
+}
+
+// AlertmanagerConfiguration is the configuration of an Alertmanager-compatible
+// webhook.
+type AlertmanagerConfiguration struct {
+	// URL is the URL alerts are posted to, for Alertmanager this is the
+	// /api/v2/alerts endpoint.
+	URL string `yaml:"url" validate:"nonzero"`
+
+	// Timeout is the timeout for sending alerts.
+	Timeout time.Duration `yaml:"timeout"`
+}
+
 // LookbackDurationOrDefault validates the LookbackDuration
 func (c Configuration) LookbackDurationOrDefault() (time.Duration, error) {
 	if c.LookbackDuration == nil {
@@ -159,6 +159,8 @@ func main() {
 		if err != nil {
 			log.Fatalf("unable to open reader for shard %v: %v", shard, err)
 		}
+		log.Infof("reading shard %d fileset with %s compression",
+			shard, reader.Status().Compression)
 
 		for {
 			entry, err := reader.StreamingRead()
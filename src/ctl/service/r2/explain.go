@@ -0,0 +1,400 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package r2
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/m3db/m3/src/metrics/filters"
+	"github.com/m3db/m3/src/metrics/metadata"
+	metricid "github.com/m3db/m3/src/metrics/metric/id"
+	"github.com/m3db/m3/src/metrics/metric/id/m3"
+	"github.com/m3db/m3/src/metrics/rules"
+	"github.com/m3db/m3/src/metrics/rules/view"
+	"github.com/m3db/m3/src/x/ident"
+	"github.com/m3db/m3/src/x/pool"
+	"github.com/m3db/m3/src/x/serialize"
+)
+
+const (
+	defaultExplainNameTag = "__name__"
+	invalidIDChars        = "+,="
+
+	// coordinatorIDFormat is the format of the metric IDs matched by the
+	// coordinator downsampler, the serialized tags of the metrics.
+	coordinatorIDFormat = "coordinator"
+	// m3IDFormat is the m3 metric ID format, e.g. m3+name+tag1=value1.
+	m3IDFormat = "m3"
+)
+
+// ruleSetExplanation explains how metrics are matched against a ruleset.
+type ruleSetExplanation struct {
+	Namespace string              `json:"namespace"`
+	Version   int                 `json:"version"`
+	Metrics   []metricExplanation `json:"metrics"`
+}
+
+// metricExplanation explains how a metric is matched against a ruleset.
+type metricExplanation struct {
+	ID           string                `json:"id,omitempty"`
+	Tags         map[string]string     `json:"tags"`
+	MappingRules []matchedRule         `json:"mappingRules"`
+	RollupRules  []matchedRule         `json:"rollupRules"`
+	Pipelines    []pipelineExplanation `json:"pipelines"`
+	Dropped      bool                  `json:"dropped"`
+	KeepOriginal bool                  `json:"keepOriginal"`
+	RollupIDs    []rollupIDExplanation `json:"rollupIDs"`
+}
+
+// matchedRule is a rule whose filter matches a metric.
+type matchedRule struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+}
+
+// pipelineExplanation is a pipeline a metric is aggregated with.
+type pipelineExplanation struct {
+	AggregationTypes []string `json:"aggregationTypes,omitempty"`
+	StoragePolicies  []string `json:"storagePolicies,omitempty"`
+	Pipeline         string   `json:"pipeline,omitempty"`
+	DropPolicy       string   `json:"dropPolicy,omitempty"`
+}
+
+// rollupIDExplanation is a rolled up metric that would be emitted for a metric.
+type rollupIDExplanation struct {
+	ID        string                `json:"id,omitempty"`
+	Tags      map[string]string     `json:"tags"`
+	Pipelines []pipelineExplanation `json:"pipelines"`
+}
+
+// explainIDFormat is the format of the metric IDs metrics are matched
+// against the rules with.
+type explainIDFormat struct {
+	ruleSetOpts rules.Options
+	matchOpts   rules.MatchOptions
+	newIDFn     func(tags map[string]string, nameTag string) (metricid.ID, error)
+	tagsFn      func(metricID []byte, nameTag string) map[string]string
+	// readableIDs is whether the IDs are readable and reported along with
+	// the tags of the metrics.
+	readableIDs bool
+}
+
+func newExplainIDFormat(format string, nameTag string) (explainIDFormat, error) {
+	switch format {
+	case "", coordinatorIDFormat:
+		return explainIDFormat{
+			ruleSetOpts: rules.NewRuleSetOptions([]byte(nameTag)),
+			matchOpts:   rules.NewRuleSetMatchOptions([]byte(nameTag)),
+			newIDFn:     newCoordinatorMetricIDFn(),
+			tagsFn:      coordinatorMetricTags,
+		}, nil
+	case m3IDFormat:
+		return explainIDFormat{
+			ruleSetOpts: rules.NewOptions().
+				SetTagsFilterOptions(filters.TagsFilterOptions{
+					NameTagKey:    []byte(nameTag),
+					NameAndTagsFn: m3.NameAndTags,
+				}).
+				SetNewRollupIDFn(m3.NewRollupID).
+				SetIsRollupIDFn(m3.IsRollupID),
+			matchOpts: rules.MatchOptions{
+				NameAndTagsFn:       m3.NameAndTags,
+				SortedTagIteratorFn: m3.NewSortedTagIterator,
+			},
+			newIDFn:     newM3MetricID,
+			tagsFn:      m3MetricTags,
+			readableIDs: true,
+		}, nil
+	default:
+		return explainIDFormat{}, NewBadInputError(fmt.Sprintf(
+			"invalid id format %s, must be one of: %s, %s", format, coordinatorIDFormat, m3IDFormat))
+	}
+}
+
+// newRuleSetExplanation matches metrics against the latest rules of a ruleset as if
+// they were all in effect at the given time. The metric IDs are built in the
+// given ID format with the name taken from the value of the name tag.
+func newRuleSetExplanation(
+	rsv view.RuleSet,
+	metrics []explainMetric,
+	nameTag string,
+	idFormat string,
+	timeNanos int64,
+) (ruleSetExplanation, error) {
+	if nameTag == "" {
+		nameTag = defaultExplainNameTag
+	}
+	format, err := newExplainIDFormat(idFormat, nameTag)
+	if err != nil {
+		return ruleSetExplanation{}, err
+	}
+	rs, err := rules.NewRuleSetFromView(rsv, 0, format.ruleSetOpts)
+	if err != nil {
+		return ruleSetExplanation{}, NewBadInputError(fmt.Sprintf("invalid ruleset: %v", err))
+	}
+
+	explanations := make([]metricExplanation, 0, len(metrics))
+	for _, metric := range metrics {
+		metricID, err := format.newIDFn(metric.Tags, nameTag)
+		if err != nil {
+			return ruleSetExplanation{}, err
+		}
+		explanation, err := rules.Explain(rs, metricID, timeNanos, format.matchOpts)
+		if err != nil {
+			return ruleSetExplanation{}, NewBadInputError(
+				fmt.Sprintf("could not match metric %v: %v", metric.Tags, err))
+		}
+		explanations = append(explanations, newMetricExplanation(
+			metricID.Bytes(), metric.Tags, explanation, format, nameTag, timeNanos))
+	}
+
+	return ruleSetExplanation{
+		Namespace: rsv.Namespace,
+		Version:   rsv.Version,
+		Metrics:   explanations,
+	}, nil
+}
+
+func newMetricExplanation(
+	metricID []byte,
+	tags map[string]string,
+	explanation rules.Explanation,
+	format explainIDFormat,
+	nameTag string,
+	timeNanos int64,
+) metricExplanation {
+	res := metricExplanation{
+		Tags:         tags,
+		MappingRules: make([]matchedRule, 0, len(explanation.MappingRules)),
+		RollupRules:  make([]matchedRule, 0, len(explanation.RollupRules)),
+		KeepOriginal: explanation.MatchResult.KeepOriginal(),
+	}
+	if format.readableIDs {
+		res.ID = string(metricID)
+	}
+	for _, mr := range explanation.MappingRules {
+		res.MappingRules = append(res.MappingRules, matchedRule{ID: mr.ID, Name: mr.Name})
+	}
+	for _, rr := range explanation.RollupRules {
+		res.RollupRules = append(res.RollupRules, matchedRule{ID: rr.ID, Name: rr.Name})
+	}
+
+	var pipelines metadata.PipelineMetadatas
+	for _, sm := range explanation.MatchResult.ForExistingIDAt(timeNanos) {
+		pipelines = append(pipelines, sm.Pipelines...)
+	}
+	res.Pipelines = newPipelineExplanations(pipelines)
+
+	// NB: applying the drop policies mutates the pipelines in place.
+	pipelines = append(metadata.PipelineMetadatas(nil), pipelines...)
+	_, dropResult := pipelines.ApplyOrRemoveDropPolicies()
+	res.Dropped = dropResult == metadata.AppliedEffectiveDropPolicyResult
+
+	numRollupIDs := explanation.MatchResult.NumNewRollupIDs()
+	res.RollupIDs = make([]rollupIDExplanation, 0, numRollupIDs)
+	for i := 0; i < numRollupIDs; i++ {
+		rollupID := explanation.MatchResult.ForNewRollupIDsAt(i, timeNanos)
+		var rollupPipelines metadata.PipelineMetadatas
+		for _, sm := range rollupID.Metadatas {
+			rollupPipelines = append(rollupPipelines, sm.Pipelines...)
+		}
+		rollupIDExplanation := rollupIDExplanation{
+			Tags:      format.tagsFn(rollupID.ID, nameTag),
+			Pipelines: newPipelineExplanations(rollupPipelines),
+		}
+		if format.readableIDs {
+			rollupIDExplanation.ID = string(rollupID.ID)
+		}
+		res.RollupIDs = append(res.RollupIDs, rollupIDExplanation)
+	}
+	return res
+}
+
+func newPipelineExplanations(pipelines metadata.PipelineMetadatas) []pipelineExplanation {
+	res := make([]pipelineExplanation, 0, len(pipelines))
+	for _, p := range pipelines {
+		var aggTypes []string
+		if types, err := p.AggregationID.Types(); err == nil {
+			for _, aggType := range types {
+				aggTypes = append(aggTypes, aggType.String())
+			}
+		}
+		storagePolicies := make([]string, 0, len(p.StoragePolicies))
+		for _, sp := range p.StoragePolicies {
+			storagePolicies = append(storagePolicies, sp.String())
+		}
+		explanation := pipelineExplanation{
+			AggregationTypes: aggTypes,
+			StoragePolicies:  storagePolicies,
+		}
+		if !p.Pipeline.IsEmpty() {
+			explanation.Pipeline = p.Pipeline.String()
+		}
+		if !p.DropPolicy.IsDefault() {
+			explanation.DropPolicy = p.DropPolicy.String()
+		}
+		res = append(res, explanation)
+	}
+	return res
+}
+
+// m3MetricID is a metric ID in the m3 metric ID format.
+type m3MetricID []byte
+
+func (id m3MetricID) Bytes() []byte { return id }
+
+func (id m3MetricID) TagValue(tagName []byte) ([]byte, bool) {
+	_, tags, err := m3.NameAndTags(id)
+	if err != nil {
+		return nil, false
+	}
+	it := m3.NewSortedTagIterator(tags)
+	defer it.Close()
+	for it.Next() {
+		name, value := it.Current()
+		if bytes.Equal(name, tagName) {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+func newM3MetricID(tags map[string]string, nameTag string) (metricid.ID, error) {
+	name, ok := tags[nameTag]
+	if !ok || name == "" {
+		return nil, NewBadInputError(fmt.Sprintf("metric has no %s tag: %v", nameTag, tags))
+	}
+	if strings.ContainsAny(name, invalidIDChars) {
+		return nil, NewBadInputError(fmt.Sprintf("invalid metric name: %s", name))
+	}
+
+	tagNames := make([]string, 0, len(tags))
+	for tagName, tagValue := range tags {
+		if tagName == nameTag {
+			continue
+		}
+		if tagName == "" || strings.ContainsAny(tagName, invalidIDChars) ||
+			strings.ContainsRune(tagValue, ',') {
+			return nil, NewBadInputError(fmt.Sprintf("invalid tag: %s=%s", tagName, tagValue))
+		}
+		tagNames = append(tagNames, tagName)
+	}
+	sort.Strings(tagNames)
+
+	var buf bytes.Buffer
+	buf.WriteString("m3+")
+	buf.WriteString(name)
+	buf.WriteByte('+')
+	for i, tagName := range tagNames {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(tagName)
+		buf.WriteByte('=')
+		buf.WriteString(tags[tagName])
+	}
+	return m3MetricID(buf.Bytes()), nil
+}
+
+// m3MetricTags returns the tags of an m3 metric ID with the name under the
+// name tag.
+func m3MetricTags(metricID []byte, nameTag string) map[string]string {
+	name, tagPairs, err := m3.NameAndTags(metricID)
+	if err != nil {
+		return nil
+	}
+	tags := map[string]string{nameTag: string(name)}
+	it := m3.NewSortedTagIterator(tagPairs)
+	defer it.Close()
+	for it.Next() {
+		tagName, tagValue := it.Current()
+		tags[string(tagName)] = string(tagValue)
+	}
+	return tags
+}
+
+// coordinatorMetricID is a metric ID in the coordinator metric ID format,
+// the serialized tags of the metric including its name tag.
+type coordinatorMetricID []byte
+
+func (id coordinatorMetricID) Bytes() []byte { return id }
+
+func (id coordinatorMetricID) TagValue(tagName []byte) ([]byte, bool) {
+	it := serialize.NewUncheckedMetricTagsIterator(serialize.NewTagSerializationLimits())
+	it.Reset(id)
+	return it.TagValue(tagName)
+}
+
+// newCoordinatorMetricIDFn returns a function that creates coordinator metric
+// IDs reusing a single tag encoder, it is not safe for concurrent use.
+func newCoordinatorMetricIDFn() func(tags map[string]string, nameTag string) (metricid.ID, error) {
+	encoderPool := serialize.NewTagEncoderPool(serialize.NewTagEncoderOptions(),
+		pool.NewObjectPoolOptions().SetSize(1))
+	encoderPool.Init()
+	tagEncoder := encoderPool.Get()
+
+	return func(tags map[string]string, nameTag string) (metricid.ID, error) {
+		if name := tags[nameTag]; name == "" {
+			return nil, NewBadInputError(fmt.Sprintf("metric has no %s tag: %v", nameTag, tags))
+		}
+
+		tagNames := make([]string, 0, len(tags))
+		for tagName, tagValue := range tags {
+			if tagName == "" || tagValue == "" {
+				return nil, NewBadInputError(fmt.Sprintf("invalid tag: %s=%s", tagName, tagValue))
+			}
+			tagNames = append(tagNames, tagName)
+		}
+		sort.Strings(tagNames)
+
+		sortedTags := make([]ident.Tag, 0, len(tagNames))
+		for _, tagName := range tagNames {
+			sortedTags = append(sortedTags, ident.StringTag(tagName, tags[tagName]))
+		}
+		tagEncoder.Reset()
+		if err := tagEncoder.Encode(ident.NewTagsIterator(ident.NewTags(sortedTags...))); err != nil {
+			return nil, NewBadInputError(fmt.Sprintf("invalid tags %v: %v", tags, err))
+		}
+		data, ok := tagEncoder.Data()
+		if !ok {
+			return nil, fmt.Errorf("could not encode tags: %v", tags)
+		}
+		return coordinatorMetricID(append([]byte(nil), data.Bytes()...)), nil
+	}
+}
+
+// coordinatorMetricTags returns the tags of a coordinator metric ID.
+func coordinatorMetricTags(metricID []byte, _ string) map[string]string {
+	it := serialize.NewUncheckedMetricTagsIterator(serialize.NewTagSerializationLimits())
+	it.Reset(metricID)
+	tags := make(map[string]string)
+	for it.Next() {
+		tagName, tagValue := it.Current()
+		tags[string(tagName)] = string(tagValue)
+	}
+	if it.Err() != nil {
+		return nil
+	}
+	return tags
+}
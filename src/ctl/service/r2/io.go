@@ -30,6 +30,7 @@ import (
 
 	validator "gopkg.in/go-playground/validator.v9"
 
+	"github.com/m3db/m3/src/metrics/rules/view"
 	"github.com/m3db/m3/src/metrics/rules/view/changes"
 )
 
@@ -88,3 +89,18 @@ type updateRuleSetRequest struct {
 	RuleSetChanges changes.RuleSetChanges `json:"rulesetChanges"`
 	RuleSetVersion int                    `json:"rulesetVersion"`
 }
+
+type explainRuleSetRequest struct {
+	// RuleSet is the candidate ruleset, the latest ruleset of the namespace
+	// is explained if not set.
+	RuleSet *view.RuleSet   `json:"ruleset,omitempty"`
+	Metrics []explainMetric `json:"metrics" validate:"required"`
+	NameTag string          `json:"nameTag,omitempty"`
+	// IDFormat is the format of the metric IDs, either coordinator (the
+	// default) or m3.
+	IDFormat string `json:"idFormat,omitempty"`
+}
+
+type explainMetric struct {
+	Tags map[string]string `json:"tags" validate:"required"`
+}
@@ -73,6 +73,36 @@ func validateRuleSet(s *service, r *http.Request) (data interface{}, err error)
 	return "Ruleset is valid", nil
 }
 
+func explainRuleSet(s *service, r *http.Request) (data interface{}, err error) {
+	vars := mux.Vars(r)
+	var req explainRuleSetRequest
+	if err := parseRequest(&req, r.Body); err != nil {
+		return nil, err
+	}
+	if len(req.Metrics) == 0 {
+		return nil, NewBadInputError("invalid request: no metrics to explain")
+	}
+
+	var ruleset view.RuleSet
+	if req.RuleSet != nil {
+		ruleset = *req.RuleSet
+		if vars[namespaceIDVar] != ruleset.Namespace {
+			return nil, NewBadInputError(fmt.Sprintf(
+				"namespaceID param %s and ruleset namespaceID %s do not match",
+				vars[namespaceIDVar],
+				ruleset.Namespace,
+			))
+		}
+	} else {
+		if ruleset, err = s.store.FetchRuleSetSnapshot(vars[namespaceIDVar]); err != nil {
+			return nil, err
+		}
+	}
+
+	return newRuleSetExplanation(ruleset, req.Metrics, req.NameTag, req.IDFormat,
+		s.nowFn().UnixNano())
+}
+
 func updateRuleSet(s *service, r *http.Request) (data interface{}, err error) {
 	var req updateRuleSetRequest
 	if err := parseRequest(&req, r.Body); err != nil {
@@ -294,6 +294,211 @@ func TestUpdateRuleSetEmptyRequest(t *testing.T) {
 	println(err.Error())
 }
 
+func TestExplainRuleSet(t *testing.T) {
+	body := []byte(`{
+		"ruleset": {
+			"id": "ns",
+			"mappingRules": [
+				{
+					"id": "mappingRuleID",
+					"name": "mappingRule",
+					"filter": "__name__:http_requests env:prod",
+					"storagePolicies": ["10s:2d"]
+				},
+				{
+					"name": "dropRule",
+					"filter": "__name__:debug_*",
+					"dropPolicy": "drop_must"
+				}
+			],
+			"rollupRules": [
+				{
+					"name": "rollupRule",
+					"filter": "__name__:http_requests",
+					"targets": [
+						{
+							"pipeline": [
+								{
+									"rollup": {
+										"newName": "http_requests_by_service",
+										"tags": ["service"],
+										"aggregation": ["Sum"]
+									}
+								}
+							],
+							"storagePolicies": ["1m:40d"]
+						}
+					]
+				}
+			]
+		},
+		"metrics": [
+			{"tags": {"__name__": "http_requests", "env": "prod", "service": "api"}},
+			{"tags": {"__name__": "debug_requests", "env": "prod"}}
+		],
+		"idFormat": "m3"
+	}`)
+	req := mux.SetURLVars(newTestPostRequest(body), map[string]string{namespaceIDVar: "ns"})
+	data, err := explainRuleSet(newTestService(nil), req)
+	require.NoError(t, err)
+
+	explanation, ok := data.(ruleSetExplanation)
+	require.True(t, ok)
+	require.Equal(t, "ns", explanation.Namespace)
+	require.Equal(t, 2, len(explanation.Metrics))
+
+	matched := explanation.Metrics[0]
+	require.Equal(t, "m3+http_requests+env=prod,service=api", matched.ID)
+	require.Equal(t, []matchedRule{{ID: "mappingRuleID", Name: "mappingRule"}}, matched.MappingRules)
+	require.Equal(t, 1, len(matched.RollupRules))
+	require.Equal(t, "rollupRule", matched.RollupRules[0].Name)
+	require.Equal(t, []pipelineExplanation{{StoragePolicies: []string{"10s:2d"}}}, matched.Pipelines)
+	require.False(t, matched.Dropped)
+	require.Equal(t, []rollupIDExplanation{
+		{
+			ID: "m3+http_requests_by_service+m3_rollup=true,service=api",
+			Tags: map[string]string{
+				"__name__":  "http_requests_by_service",
+				"m3_rollup": "true",
+				"service":   "api",
+			},
+			Pipelines: []pipelineExplanation{
+				{
+					AggregationTypes: []string{"Sum"},
+					StoragePolicies:  []string{"1m:40d"},
+				},
+			},
+		},
+	}, matched.RollupIDs)
+
+	dropped := explanation.Metrics[1]
+	require.Equal(t, 1, len(dropped.MappingRules))
+	require.Equal(t, "dropRule", dropped.MappingRules[0].Name)
+	require.Empty(t, dropped.RollupRules)
+	require.Empty(t, dropped.RollupIDs)
+	require.True(t, dropped.Dropped)
+}
+
+func TestExplainRuleSetCoordinatorIDFormat(t *testing.T) {
+	body := []byte(`{
+		"ruleset": {
+			"id": "ns",
+			"rollupRules": [
+				{
+					"name": "rollupRule",
+					"filter": "__name__:http_requests",
+					"targets": [
+						{
+							"pipeline": [
+								{
+									"rollup": {
+										"newName": "http_requests_by_service",
+										"tags": ["service"],
+										"aggregation": ["Sum"]
+									}
+								}
+							],
+							"storagePolicies": ["1m:40d"]
+						}
+					]
+				}
+			]
+		},
+		"metrics": [
+			{"tags": {"__name__": "http_requests", "env": "prod", "service": "api"}}
+		]
+	}`)
+	req := mux.SetURLVars(newTestPostRequest(body), map[string]string{namespaceIDVar: "ns"})
+	data, err := explainRuleSet(newTestService(nil), req)
+	require.NoError(t, err)
+
+	explanation, ok := data.(ruleSetExplanation)
+	require.True(t, ok)
+	require.Equal(t, 1, len(explanation.Metrics))
+
+	// The metrics are rolled up as by the coordinator downsampler.
+	matched := explanation.Metrics[0]
+	require.Empty(t, matched.ID)
+	require.Equal(t, 1, len(matched.RollupRules))
+	require.Equal(t, []rollupIDExplanation{
+		{
+			Tags: map[string]string{
+				"__name__":   "http_requests_by_service",
+				"__rollup__": "true",
+				"service":    "api",
+			},
+			Pipelines: []pipelineExplanation{
+				{
+					AggregationTypes: []string{"Sum"},
+					StoragePolicies:  []string{"1m:40d"},
+				},
+			},
+		},
+	}, matched.RollupIDs)
+}
+
+func TestExplainRuleSetInvalidRequest(t *testing.T) {
+	inputs := []struct {
+		name string
+		body string
+	}{
+		{
+			name: "no metrics",
+			body: `{"ruleset": {"id": "ns"}}`,
+		},
+		{
+			name: "namespace mismatch",
+			body: `{"ruleset": {"id": "other"}, "metrics": [{"tags": {"__name__": "foo"}}]}`,
+		},
+		{
+			name: "no name tag",
+			body: `{"ruleset": {"id": "ns"}, "metrics": [{"tags": {"env": "prod"}}]}`,
+		},
+		{
+			name: "invalid tag value",
+			body: `{"ruleset": {"id": "ns"}, "metrics": [{"tags": {"__name__": "foo", "env": "a,b"}}],
+				"idFormat": "m3"}`,
+		},
+		{
+			name: "empty tag value",
+			body: `{"ruleset": {"id": "ns"}, "metrics": [{"tags": {"__name__": "foo", "env": ""}}]}`,
+		},
+		{
+			name: "invalid id format",
+			body: `{"ruleset": {"id": "ns"}, "metrics": [{"tags": {"__name__": "foo"}}],
+				"idFormat": "graphite"}`,
+		},
+		{
+			name: "invalid filter",
+			body: `{"ruleset": {"id": "ns", "mappingRules": [{"name": "r", "filter": "__name__"}]},
+				"metrics": [{"tags": {"__name__": "foo"}}]}`,
+		},
+	}
+	for _, input := range inputs {
+		t.Run(input.name, func(t *testing.T) {
+			req := mux.SetURLVars(newTestPostRequest([]byte(input.body)),
+				map[string]string{namespaceIDVar: "ns"})
+			_, err := explainRuleSet(newTestService(nil), req)
+			require.Error(t, err)
+			require.IsType(t, badInputError(""), err)
+		})
+	}
+}
+
+func TestExplainRuleSetLatestRuleSet(t *testing.T) {
+	body := []byte(`{"metrics": [{"tags": {"__name__": "foo"}}]}`)
+	req := mux.SetURLVars(newTestPostRequest(body), map[string]string{namespaceIDVar: "ns"})
+	data, err := explainRuleSet(newTestService(nil), req)
+	require.NoError(t, err)
+
+	explanation, ok := data.(ruleSetExplanation)
+	require.True(t, ok)
+	require.Equal(t, 1, len(explanation.Metrics))
+	require.Empty(t, explanation.Metrics[0].MappingRules)
+	require.Empty(t, explanation.Metrics[0].RollupRules)
+	require.False(t, explanation.Metrics[0].Dropped)
+}
+
 func newTestService(store store.Store) *service {
 	if store == nil {
 		store = newMockStore()
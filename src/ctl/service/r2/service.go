@@ -49,6 +49,7 @@ var (
 	namespacePrefix     = fmt.Sprintf("%s/{%s}", namespacePath, namespaceIDVar)
 	validateRuleSetPath = fmt.Sprintf("%s/{%s}/ruleset/validate", namespacePath, namespaceIDVar)
 	updateRuleSetPath   = fmt.Sprintf("%s/{%s}/ruleset/update", namespacePath, namespaceIDVar)
+	explainRuleSetPath  = fmt.Sprintf("%s/{%s}/ruleset/explain", namespacePath, namespaceIDVar)
 
 	mappingRuleRoot        = fmt.Sprintf("%s/%s", namespacePrefix, mappingRulePrefix)
 	mappingRuleWithIDPath  = fmt.Sprintf("%s/{%s}", mappingRuleRoot, ruleIDVar)
@@ -78,6 +79,7 @@ type serviceMetrics struct {
 	deleteRollupRule        instrument.MethodMetrics
 	fetchRollupRuleHistory  instrument.MethodMetrics
 	updateRuleSet           instrument.MethodMetrics
+	explainRuleSet          instrument.MethodMetrics
 }
 
 func newServiceMetrics(scope tally.Scope, opts instrument.TimerOptions) serviceMetrics {
@@ -98,12 +100,15 @@ func newServiceMetrics(scope tally.Scope, opts instrument.TimerOptions) serviceM
 		deleteRollupRule:        instrument.NewMethodMetrics(scope, "deleteRollupRule", opts),
 		fetchRollupRuleHistory:  instrument.NewMethodMetrics(scope, "fetchRollupRuleHistory", opts),
 		updateRuleSet:           instrument.NewMethodMetrics(scope, "updateRuleSet", opts),
+		explainRuleSet:          instrument.NewMethodMetrics(scope, "explainRuleSet", opts),
 	}
 }
 
 var authorizationRegistry = map[route]auth.AuthorizationType{
 	// This validation route should only require read access.
 	{path: validateRuleSetPath, method: http.MethodPost}: auth.ReadOnlyAuthorization,
+	// The explain route never persists the ruleset so it also only requires read access.
+	{path: explainRuleSetPath, method: http.MethodPost}: auth.ReadOnlyAuthorization,
 }
 
 func defaultAuthorizationTypeForHTTPMethod(method string) (auth.AuthorizationType, error) {
@@ -174,6 +179,7 @@ func (s *service) RegisterHandlers(router *mux.Router) error {
 		{route: route{path: namespacePrefix, method: http.MethodDelete}, handler: s.deleteNamespace},
 		{route: route{path: validateRuleSetPath, method: http.MethodPost}, handler: s.validateRuleSet},
 		{route: route{path: updateRuleSetPath, method: http.MethodPost}, handler: s.updateRuleSet},
+		{route: route{path: explainRuleSetPath, method: http.MethodPost}, handler: s.explainRuleSet},
 
 		// Mapping Rule actions.
 		{route: route{path: mappingRuleRoot, method: http.MethodPost}, handler: s.createMappingRule},
@@ -284,6 +290,14 @@ func (s *service) updateRuleSet(w http.ResponseWriter, r *http.Request) error {
 	return s.sendResponse(w, http.StatusOK, data)
 }
 
+func (s *service) explainRuleSet(w http.ResponseWriter, r *http.Request) error {
+	data, err := s.handleRoute(explainRuleSet, r, s.metrics.explainRuleSet)
+	if err != nil {
+		return err
+	}
+	return s.sendResponse(w, http.StatusOK, data)
+}
+
 func (s *service) deleteNamespace(w http.ResponseWriter, r *http.Request) error {
 	data, err := s.handleRoute(deleteNamespace, r, s.metrics.deleteNamespace)
 	if err != nil {
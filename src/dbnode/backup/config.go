@@ -0,0 +1,88 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package backup
+
+import "errors"
+
+var errStoreConfiguration = errors.New("backup store requires exactly one of local or s3 to be set")
+
+// StoreConfiguration is the configuration of a backup blob store, exactly
+// one of the stores must be set.
+type StoreConfiguration struct {
+	// Local stores the backups in a directory of the local filesystem.
+	Local *LocalStoreConfiguration `yaml:"local"`
+
+	// S3 stores the backups in an S3 compatible object store.
+	S3 *S3StoreConfiguration `yaml:"s3"`
+}
+
+// NewStore creates the configured store.
+func (c StoreConfiguration) NewStore() (Store, error) {
+	switch {
+	case c.Local != nil && c.S3 == nil:
+		return NewLocalStore(c.Local.Directory)
+	case c.S3 != nil && c.Local == nil:
+		return NewS3Store(c.S3.options())
+	}
+	return nil, errStoreConfiguration
+}
+
+// LocalStoreConfiguration is the configuration of a local filesystem store.
+type LocalStoreConfiguration struct {
+	// Directory is the directory the backups are stored in.
+	Directory string `yaml:"directory" validate:"nonzero"`
+}
+
+// S3StoreConfiguration is the configuration of an S3 compatible store.
+type S3StoreConfiguration struct {
+	// Bucket is the bucket the backups are stored in.
+	Bucket string `yaml:"bucket" validate:"nonzero"`
+
+	// Prefix is prepended to the keys of the backups.
+	Prefix string `yaml:"prefix"`
+
+	// Region is the region of the bucket, defaults to us-east-1.
+	Region string `yaml:"region"`
+
+	// Endpoint overrides the endpoint of the object store, used for S3
+	// compatible stores other than AWS.
+	Endpoint string `yaml:"endpoint"`
+
+	// ForcePathStyle addresses buckets by path rather than by host.
+	ForcePathStyle bool `yaml:"forcePathStyle"`
+
+	// AccessKeyID and SecretAccessKey are static credentials, the default
+	// AWS credential chain is used if not set.
+	AccessKeyID     string `yaml:"accessKeyID"`
+	SecretAccessKey string `yaml:"secretAccessKey"`
+}
+
+func (c S3StoreConfiguration) options() S3StoreOptions {
+	return S3StoreOptions{
+		Bucket:          c.Bucket,
+		Prefix:          c.Prefix,
+		Region:          c.Region,
+		Endpoint:        c.Endpoint,
+		ForcePathStyle:  c.ForcePathStyle,
+		AccessKeyID:     c.AccessKeyID,
+		SecretAccessKey: c.SecretAccessKey,
+	}
+}
@@ -0,0 +1,579 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+const (
+	// IDFormat is the time format of backup IDs, which sort in the order the
+	// backups were taken.
+	IDFormat = "20060102T150405.000000000Z"
+
+	manifestFileName = "manifest.json"
+
+	// maxUploadAttempts is the number of times the filesets of a block are
+	// listed again when their files are removed while being uploaded.
+	maxUploadAttempts = 3
+)
+
+type manager struct {
+	opts   Options
+	store  Store
+	fsOpts fs.Options
+	logger *zap.Logger
+
+	metrics managerMetrics
+}
+
+type managerMetrics struct {
+	backups            tally.Counter
+	backupErrors       tally.Counter
+	uploadedFiles      tally.Counter
+	restoredFileSets   tally.Counter
+	restoredCommitLogs tally.Counter
+	prunedBackups      tally.Counter
+}
+
+func newManagerMetrics(scope tally.Scope) managerMetrics {
+	return managerMetrics{
+		backups:            scope.Counter("backups"),
+		backupErrors:       scope.Counter("backup-errors"),
+		uploadedFiles:      scope.Counter("uploaded-files"),
+		restoredFileSets:   scope.Counter("restored-filesets"),
+		restoredCommitLogs: scope.Counter("restored-commitlogs"),
+		prunedBackups:      scope.Counter("pruned-backups"),
+	}
+}
+
+// NewManager returns a new backup manager.
+func NewManager(opts Options) (Manager, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	iOpts := opts.InstrumentOptions()
+	return &manager{
+		opts:    opts,
+		store:   opts.Store(),
+		fsOpts:  opts.FilesystemOptions(),
+		logger:  iOpts.Logger(),
+		metrics: newManagerMetrics(iOpts.MetricsScope().SubScope("backup")),
+	}, nil
+}
+
+func (m *manager) Backup(ctx context.Context, namespaces []ident.ID) (Manifest, error) {
+	createdAt := m.opts.ClockOptions().NowFn()().UTC()
+	manifest := Manifest{
+		ID:        createdAt.Format(IDFormat),
+		HostID:    m.opts.HostID(),
+		CreatedAt: createdAt,
+	}
+	if err := m.backup(ctx, &manifest, namespaces); err != nil {
+		m.metrics.backupErrors.Inc(1)
+		return Manifest{}, err
+	}
+	m.metrics.backups.Inc(1)
+	return manifest, nil
+}
+
+func (m *manager) backup(ctx context.Context, manifest *Manifest, namespaces []ident.ID) error {
+	for _, ns := range namespaces {
+		nsManifest, err := m.backupNamespace(ctx, manifest, ns)
+		if err != nil {
+			return fmt.Errorf("error backing up namespace %s: %w", ns.String(), err)
+		}
+		manifest.Namespaces = append(manifest.Namespaces, nsManifest)
+	}
+
+	metadatas, _, err := fs.SortedSnapshotMetadataFiles(m.fsOpts)
+	if err != nil {
+		return err
+	}
+	if len(metadatas) > 0 {
+		files, err := m.upload(ctx, manifest, metadatas[len(metadatas)-1].AbsoluteFilePaths())
+		if err != nil {
+			return err
+		}
+		manifest.SnapshotMetadata = &FileSet{Files: files}
+
+		// Writes after the snapshot are only in the commit logs, the active
+		// commit log is uploaded as written so far.
+		latest := metadatas[len(metadatas)-1]
+		commitLogs, err := commitLogsSince(m.fsOpts.FilePathPrefix(), latest.CommitlogIdentifier.FilePath)
+		if err != nil {
+			return err
+		}
+		manifest.CommitLogs, err = m.upload(ctx, manifest, commitLogs)
+		if err != nil {
+			return err
+		}
+	}
+
+	// The manifest is uploaded last, a backup without manifest is incomplete.
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	key := manifestKey(manifest.HostID, manifest.ID)
+	return m.store.Put(ctx, key, bytes.NewReader(data))
+}
+
+func (m *manager) backupNamespace(
+	ctx context.Context,
+	manifest *Manifest,
+	ns ident.ID,
+) (NamespaceManifest, error) {
+	var (
+		prefix     = m.fsOpts.FilePathPrefix()
+		nsManifest = NamespaceManifest{ID: ns.String()}
+	)
+	shards, err := namespaceShards(prefix, ns)
+	if err != nil {
+		return NamespaceManifest{}, err
+	}
+	for _, shard := range shards {
+		shard := shard
+		data, err := m.uploadLatestVolumes(ctx, manifest, shard, func() (fs.FileSetFilesSlice, error) {
+			return fs.DataFiles(prefix, ns, shard)
+		})
+		if err != nil {
+			return NamespaceManifest{}, err
+		}
+		nsManifest.Data = append(nsManifest.Data, data...)
+
+		snapshots, err := m.uploadLatestVolumes(ctx, manifest, shard, func() (fs.FileSetFilesSlice, error) {
+			return fs.SnapshotFiles(prefix, ns, shard)
+		})
+		if err != nil {
+			return NamespaceManifest{}, err
+		}
+		nsManifest.Snapshots = append(nsManifest.Snapshots, snapshots...)
+	}
+
+	index, err := m.uploadIndexVolumes(ctx, manifest, ns)
+	if err != nil {
+		return NamespaceManifest{}, err
+	}
+	nsManifest.Index = index
+	return nsManifest, nil
+}
+
+type indexVolume struct {
+	blockStart  xtime.UnixNano
+	volumeIndex int
+}
+
+// uploadIndexVolumes uploads the index filesets of a namespace. Index
+// filesets removed by cleanup while being uploaded have been superseded by
+// other volumes, the filesets are listed again to upload them instead.
+func (m *manager) uploadIndexVolumes(
+	ctx context.Context,
+	manifest *Manifest,
+	ns ident.ID,
+) ([]FileSet, error) {
+	var (
+		result   []FileSet
+		uploaded = make(map[indexVolume]struct{})
+	)
+	for attempt := 1; ; attempt++ {
+		infoFiles := fs.ReadIndexInfoFiles(fs.ReadIndexInfoFilesOptions{
+			FilePathPrefix:   m.fsOpts.FilePathPrefix(),
+			Namespace:        ns,
+			ReaderBufferSize: m.fsOpts.InfoReaderBufferSize(),
+		})
+		var removed bool
+		for _, infoFile := range infoFiles {
+			volume := indexVolume{
+				blockStart:  infoFile.ID.BlockStart,
+				volumeIndex: infoFile.ID.VolumeIndex,
+			}
+			if _, ok := uploaded[volume]; ok {
+				continue
+			}
+			if err := infoFile.Err.Error(); err != nil {
+				m.logger.Warn("skipping backup of unreadable index fileset",
+					zap.String("filepath", infoFile.Err.Filepath()), zap.Error(err))
+				continue
+			}
+			files, err := m.upload(ctx, manifest, infoFile.AbsoluteFilePaths)
+			if errors.Is(err, os.ErrNotExist) && attempt < maxUploadAttempts {
+				removed = true
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			uploaded[volume] = struct{}{}
+			shards := make([]uint32, 0, len(infoFile.Info.Shards))
+			shards = append(shards, infoFile.Info.Shards...)
+			sort.Slice(shards, func(i, j int) bool { return shards[i] < shards[j] })
+			result = append(result, FileSet{
+				Shards:      shards,
+				BlockStart:  infoFile.ID.BlockStart,
+				VolumeIndex: infoFile.ID.VolumeIndex,
+				Files:       files,
+			})
+		}
+		if !removed {
+			return result, nil
+		}
+	}
+}
+
+// uploadLatestVolumes uploads the latest volume of each block of the filesets
+// of a shard. Volumes superseded and removed by cleanup, or whose cached cold
+// tier files are evicted, while being uploaded are replaced by the latest
+// volume of their block listed again.
+func (m *manager) uploadLatestVolumes(
+	ctx context.Context,
+	manifest *Manifest,
+	shard uint32,
+	list func() (fs.FileSetFilesSlice, error),
+) ([]FileSet, error) {
+	filesets, err := list()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		result []FileSet
+		seen   = make(map[xtime.UnixNano]struct{})
+	)
+	for _, fileset := range filesets {
+		blockStart := fileset.ID.BlockStart
+		if _, ok := seen[blockStart]; ok {
+			continue
+		}
+		seen[blockStart] = struct{}{}
+
+		latest, ok := filesets.LatestVolumeForBlock(blockStart)
+		for attempt := 1; ok; attempt++ {
+			files, err := m.upload(ctx, manifest, latest.AbsoluteFilePaths)
+			if err == nil {
+				result = append(result, FileSet{
+					Shards:      []uint32{shard},
+					BlockStart:  blockStart,
+					VolumeIndex: latest.ID.VolumeIndex,
+					Files:       files,
+				})
+				break
+			}
+			if !errors.Is(err, os.ErrNotExist) || attempt >= maxUploadAttempts {
+				return nil, err
+			}
+
+			// NB: the block is skipped if it no longer has a volume, which
+			// is the case once it is out of retention.
+			filesets, err = list()
+			if err != nil {
+				return nil, err
+			}
+			latest, ok = filesets.LatestVolumeForBlock(blockStart)
+		}
+	}
+	return result, nil
+}
+
+// upload uploads files and returns their paths relative to the filesystem
+// prefix.
+func (m *manager) upload(ctx context.Context, manifest *Manifest, paths []string) ([]string, error) {
+	files := make([]string, 0, len(paths))
+	for _, p := range paths {
+		rel, err := filepath.Rel(m.fsOpts.FilePathPrefix(), p)
+		if err != nil {
+			return nil, err
+		}
+		rel = filepath.ToSlash(rel)
+		if err := m.uploadFile(ctx, fileKey(manifest.HostID, manifest.ID, rel), p); err != nil {
+			return nil, err
+		}
+		files = append(files, rel)
+	}
+	return files, nil
+}
+
+func (m *manager) uploadFile(ctx context.Context, key, p string) error {
+	f, err := os.Open(p) //nolint:gosec
+	if err != nil {
+		return err
+	}
+	defer f.Close() //nolint:errcheck
+	if err := m.store.Put(ctx, key, f); err != nil {
+		return err
+	}
+	m.metrics.uploadedFiles.Inc(1)
+	return nil
+}
+
+func (m *manager) Backups(ctx context.Context, hostID string) ([]string, error) {
+	keys, err := m.store.List(ctx, hostID+"/")
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, key := range keys {
+		parts := strings.Split(key, "/")
+		if len(parts) == 3 && parts[2] == manifestFileName {
+			ids = append(ids, parts[1])
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func (m *manager) Manifest(ctx context.Context, hostID, backupID string) (Manifest, error) {
+	r, err := m.store.Get(ctx, manifestKey(hostID, backupID))
+	if err != nil {
+		return Manifest{}, err
+	}
+	defer r.Close() //nolint:errcheck
+
+	var manifest Manifest
+	if err := json.NewDecoder(r).Decode(&manifest); err != nil {
+		return Manifest{}, fmt.Errorf("invalid backup manifest %s: %w", backupID, err)
+	}
+	return manifest, nil
+}
+
+func (m *manager) Restore(ctx context.Context, manifest Manifest, filesets []FileSet) (int, error) {
+	restored := 0
+	for _, fileset := range filesets {
+		ok, err := m.restoreFileSet(ctx, manifest, fileset.Files)
+		if err != nil {
+			return restored, err
+		}
+		if ok {
+			restored++
+			m.metrics.restoredFileSets.Inc(1)
+		}
+	}
+	return restored, nil
+}
+
+func (m *manager) RestoreCommitLogs(ctx context.Context, manifest Manifest) (int, error) {
+	var (
+		prefix   = m.fsOpts.FilePathPrefix()
+		restored = 0
+	)
+	for _, file := range manifest.CommitLogs {
+		if err := validateKey(file); err != nil {
+			return restored, err
+		}
+		p := filepath.Join(prefix, filepath.FromSlash(file))
+		exists, err := fs.FileExists(p)
+		if err != nil {
+			return restored, err
+		}
+		if exists {
+			continue
+		}
+		if err := m.downloadFile(ctx, fileKey(manifest.HostID, manifest.ID, file), p); err != nil {
+			return restored, err
+		}
+		restored++
+		m.metrics.restoredCommitLogs.Inc(1)
+	}
+	return restored, nil
+}
+
+// restoreFileSet downloads the files of a fileset unless its checkpoint file
+// exists on disk. The checkpoint file is downloaded last so that a fileset
+// interrupted while being restored is never considered complete.
+func (m *manager) restoreFileSet(ctx context.Context, manifest Manifest, files []string) (bool, error) {
+	var (
+		prefix     = m.fsOpts.FilePathPrefix()
+		checkpoint string
+		others     = make([]string, 0, len(files))
+	)
+	for _, file := range files {
+		if err := validateKey(file); err != nil {
+			return false, err
+		}
+		if strings.Contains(path.Base(file), fs.CheckpointFileSuffix) {
+			checkpoint = file
+			continue
+		}
+		others = append(others, file)
+	}
+	if checkpoint == "" {
+		return false, fmt.Errorf("backup fileset has no checkpoint file: %v", files)
+	}
+
+	exists, err := fs.CompleteCheckpointFileExists(filepath.Join(prefix, filepath.FromSlash(checkpoint)))
+	if err != nil {
+		return false, err
+	}
+	if exists {
+		return false, nil
+	}
+
+	for _, file := range append(others, checkpoint) {
+		if err := m.downloadFile(ctx, fileKey(manifest.HostID, manifest.ID, file),
+			filepath.Join(prefix, filepath.FromSlash(file))); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func (m *manager) downloadFile(ctx context.Context, key, p string) error {
+	r, err := m.store.Get(ctx, key)
+	if err != nil {
+		return fmt.Errorf("error downloading %s: %w", key, err)
+	}
+	defer r.Close() //nolint:errcheck
+
+	dir := filepath.Dir(p)
+	if err := os.MkdirAll(dir, m.fsOpts.NewDirectoryMode()); err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(dir, ".restore-*")
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()           //nolint:errcheck
+		os.Remove(f.Name()) //nolint:errcheck
+		return err
+	}
+	if err := f.Chmod(m.fsOpts.NewFileMode()); err != nil {
+		f.Close()           //nolint:errcheck
+		os.Remove(f.Name()) //nolint:errcheck
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name()) //nolint:errcheck
+		return err
+	}
+	return os.Rename(f.Name(), p)
+}
+
+func (m *manager) Prune(ctx context.Context, hostID string, retain int) error {
+	ids, err := m.Backups(ctx, hostID)
+	if err != nil {
+		return err
+	}
+	if retain < 1 {
+		retain = 1
+	}
+	for i := 0; i < len(ids)-retain; i++ {
+		if err := m.deleteBackup(ctx, hostID, ids[i]); err != nil {
+			return err
+		}
+		m.metrics.prunedBackups.Inc(1)
+	}
+	return nil
+}
+
+func (m *manager) deleteBackup(ctx context.Context, hostID, backupID string) error {
+	// Delete the manifest first so that the backup is no longer considered
+	// complete if the deletion is interrupted.
+	if err := m.store.Delete(ctx, manifestKey(hostID, backupID)); err != nil {
+		return err
+	}
+	keys, err := m.store.List(ctx, hostID+"/"+backupID+"/")
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err := m.store.Delete(ctx, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func manifestKey(hostID, backupID string) string {
+	return fileKey(hostID, backupID, manifestFileName)
+}
+
+func fileKey(hostID, backupID, file string) string {
+	return hostID + "/" + backupID + "/" + file
+}
+
+// namespaceShards returns the shards with a data or snapshot directory for
+// the namespace.
+func namespaceShards(prefix string, ns ident.ID) ([]uint32, error) {
+	seen := make(map[uint32]struct{})
+	for _, dir := range []string{
+		fs.NamespaceDataDirPath(prefix, ns),
+		fs.NamespaceSnapshotsDirPath(prefix, ns),
+	} {
+		entries, err := ioutil.ReadDir(dir)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			shard, err := strconv.ParseUint(entry.Name(), 10, 32)
+			if err != nil {
+				continue
+			}
+			seen[uint32(shard)] = struct{}{}
+		}
+	}
+	shards := make([]uint32, 0, len(seen))
+	for shard := range seen {
+		shards = append(shards, shard)
+	}
+	sort.Slice(shards, func(i, j int) bool { return shards[i] < shards[j] })
+	return shards, nil
+}
+
+// commitLogsSince returns the commit log files from the given commit log
+// onwards, or all of them if it no longer exists.
+func commitLogsSince(prefix, commitLog string) ([]string, error) {
+	files, err := fs.SortedCommitLogFiles(fs.CommitLogsDirPath(prefix))
+	if err != nil {
+		return nil, err
+	}
+	for i, file := range files {
+		if file == commitLog {
+			return files[i:], nil
+		}
+	}
+	return files, nil
+}
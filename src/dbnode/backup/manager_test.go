@@ -0,0 +1,322 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package backup
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pborman/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	idxpersist "github.com/m3db/m3/src/m3ninx/persist"
+	"github.com/m3db/m3/src/x/checked"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+const testBlockSize = 2 * time.Hour
+
+var testNamespace = ident.StringID("metrics")
+
+func newTestFsOptions(t *testing.T) fs.Options {
+	dir, err := ioutil.TempDir("", "backup-fs")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	return fs.NewOptions().SetFilePathPrefix(dir)
+}
+
+func writeTestDataFileSet(
+	t *testing.T,
+	fsOpts fs.Options,
+	shard uint32,
+	blockStart xtime.UnixNano,
+	volume int,
+) {
+	w, err := fs.NewWriter(fsOpts)
+	require.NoError(t, err)
+	require.NoError(t, w.Open(fs.DataWriterOpenOptions{
+		Identifier: fs.FileSetFileIdentifier{
+			Namespace:   testNamespace,
+			Shard:       shard,
+			BlockStart:  blockStart,
+			VolumeIndex: volume,
+		},
+		BlockSize: testBlockSize,
+	}))
+
+	data := checked.NewBytes([]byte{1, 2, 3}, nil)
+	data.IncRef()
+	metadata := persist.NewMetadataFromIDAndTags(ident.StringID("foo"),
+		ident.Tags{}, persist.MetadataOptions{})
+	require.NoError(t, w.Write(metadata, data, digest.Checksum(data.Bytes())))
+	data.DecRef()
+	require.NoError(t, w.Close())
+}
+
+func writeTestIndexFileSet(
+	t *testing.T,
+	fsOpts fs.Options,
+	blockStart xtime.UnixNano,
+	shards ...uint32,
+) {
+	w, err := fs.NewIndexWriter(fsOpts)
+	require.NoError(t, err)
+	shardsSet := make(map[uint32]struct{})
+	for _, shard := range shards {
+		shardsSet[shard] = struct{}{}
+	}
+	require.NoError(t, w.Open(fs.IndexWriterOpenOptions{
+		Identifier: fs.FileSetFileIdentifier{
+			FileSetContentType: persist.FileSetIndexContentType,
+			Namespace:          testNamespace,
+			BlockStart:         blockStart,
+		},
+		BlockSize:       testBlockSize,
+		FileSetType:     persist.FileSetFlushType,
+		Shards:          shardsSet,
+		IndexVolumeType: idxpersist.DefaultIndexVolumeType,
+	}))
+	require.NoError(t, w.Close())
+}
+
+func newTestManager(t *testing.T, store Store, fsOpts fs.Options, now time.Time) Manager {
+	opts := NewOptions().
+		SetStore(store).
+		SetHostID("host0").
+		SetFilesystemOptions(fsOpts)
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(func() time.Time {
+		return now
+	}))
+	m, err := NewManager(opts)
+	require.NoError(t, err)
+	return m
+}
+
+func TestManagerBackupAndRestore(t *testing.T) {
+	var (
+		ctx        = context.Background()
+		store      = newTestLocalStore(t)
+		srcOpts    = newTestFsOptions(t)
+		dstOpts    = newTestFsOptions(t)
+		now        = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+		blockStart = xtime.ToUnixNano(now.Truncate(testBlockSize)).Add(-testBlockSize)
+	)
+	writeTestDataFileSet(t, srcOpts, 1, blockStart, 0)
+	writeTestDataFileSet(t, srcOpts, 1, blockStart, 1)
+	writeTestDataFileSet(t, srcOpts, 2, blockStart, 0)
+	writeTestIndexFileSet(t, srcOpts, blockStart, 1, 2)
+
+	manifest, err := newTestManager(t, store, srcOpts, now).Backup(ctx, []ident.ID{testNamespace})
+	require.NoError(t, err)
+	require.Equal(t, "20261016T120000.000000000Z", manifest.ID)
+
+	ns, ok := manifest.Namespace(testNamespace)
+	require.True(t, ok)
+	require.Len(t, ns.Data, 2)
+	require.Equal(t, []uint32{1}, ns.Data[0].Shards)
+	require.Equal(t, 1, ns.Data[0].VolumeIndex)
+	require.Equal(t, []uint32{2}, ns.Data[1].Shards)
+	require.Len(t, ns.Index, 1)
+	require.Equal(t, []uint32{1, 2}, ns.Index[0].Shards)
+	require.Equal(t, blockStart, ns.Index[0].BlockStart)
+
+	restorer := newTestManager(t, store, dstOpts, now)
+	ids, err := restorer.Backups(ctx, "host0")
+	require.NoError(t, err)
+	require.Equal(t, []string{manifest.ID}, ids)
+
+	fetched, err := restorer.Manifest(ctx, "host0", manifest.ID)
+	require.NoError(t, err)
+	ns, ok = fetched.Namespace(testNamespace)
+	require.True(t, ok)
+
+	restored, err := restorer.Restore(ctx, fetched, append(ns.Data, ns.Index...))
+	require.NoError(t, err)
+	require.Equal(t, 3, restored)
+
+	files, err := fs.DataFiles(dstOpts.FilePathPrefix(), testNamespace, 1)
+	require.NoError(t, err)
+	latest, ok := files.LatestVolumeForBlock(blockStart)
+	require.True(t, ok)
+	require.Equal(t, 1, latest.ID.VolumeIndex)
+	for _, p := range latest.AbsoluteFilePaths {
+		rel, err := filepath.Rel(dstOpts.FilePathPrefix(), p)
+		require.NoError(t, err)
+		expected, err := ioutil.ReadFile(filepath.Join(srcOpts.FilePathPrefix(), rel))
+		require.NoError(t, err)
+		actual, err := ioutil.ReadFile(p)
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+	}
+
+	infoFiles := fs.ReadIndexInfoFiles(fs.ReadIndexInfoFilesOptions{
+		FilePathPrefix:   dstOpts.FilePathPrefix(),
+		Namespace:        testNamespace,
+		ReaderBufferSize: dstOpts.InfoReaderBufferSize(),
+	})
+	require.Len(t, infoFiles, 1)
+	require.NoError(t, infoFiles[0].Err.Error())
+
+	// Filesets complete on disk are not restored again.
+	restored, err = restorer.Restore(ctx, fetched, append(ns.Data, ns.Index...))
+	require.NoError(t, err)
+	require.Equal(t, 0, restored)
+}
+
+// cleanupStore removes a volume of a data fileset and writes the volume that
+// supersedes it the first time a file of the volume is uploaded.
+type cleanupStore struct {
+	Store
+
+	cleanup func()
+	match   string
+}
+
+func (s *cleanupStore) Put(ctx context.Context, key string, r io.ReadSeeker) error {
+	if s.cleanup != nil && strings.Contains(key, s.match) {
+		s.cleanup()
+		s.cleanup = nil
+	}
+	return s.Store.Put(ctx, key, r)
+}
+
+func TestManagerBackupVolumeRemovedWhileUploading(t *testing.T) {
+	var (
+		ctx        = context.Background()
+		srcOpts    = newTestFsOptions(t)
+		now        = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+		blockStart = xtime.ToUnixNano(now.Truncate(testBlockSize)).Add(-testBlockSize)
+	)
+	writeTestDataFileSet(t, srcOpts, 1, blockStart, 0)
+	store := &cleanupStore{
+		Store: newTestLocalStore(t),
+		match: "/1/fileset-",
+		cleanup: func() {
+			writeTestDataFileSet(t, srcOpts, 1, blockStart, 1)
+			files, err := fs.DataFiles(srcOpts.FilePathPrefix(), testNamespace, 1)
+			require.NoError(t, err)
+			for _, file := range files {
+				if file.ID.VolumeIndex == 0 {
+					require.NoError(t, fs.DeleteFiles(file.AbsoluteFilePaths))
+				}
+			}
+		},
+	}
+
+	manifest, err := newTestManager(t, store, srcOpts, now).Backup(ctx, []ident.ID{testNamespace})
+	require.NoError(t, err)
+
+	// The volume that superseded the removed volume is uploaded instead.
+	ns, ok := manifest.Namespace(testNamespace)
+	require.True(t, ok)
+	require.Len(t, ns.Data, 1)
+	require.Equal(t, 1, ns.Data[0].VolumeIndex)
+	for _, file := range ns.Data[0].Files {
+		require.Contains(t, file, "-1-")
+	}
+}
+
+func TestManagerPrune(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		store  = newTestLocalStore(t)
+		fsOpts = newTestFsOptions(t)
+		now    = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	)
+	writeTestDataFileSet(t, fsOpts, 1, xtime.ToUnixNano(now.Truncate(testBlockSize)), 0)
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		m := newTestManager(t, store, fsOpts, now.Add(time.Duration(i)*time.Hour))
+		manifest, err := m.Backup(ctx, []ident.ID{testNamespace})
+		require.NoError(t, err)
+		ids = append(ids, manifest.ID)
+	}
+
+	m := newTestManager(t, store, fsOpts, now)
+	require.NoError(t, m.Prune(ctx, "host0", 2))
+
+	backups, err := m.Backups(ctx, "host0")
+	require.NoError(t, err)
+	require.Equal(t, ids[1:], backups)
+
+	keys, err := store.List(ctx, "host0/"+ids[0]+"/")
+	require.NoError(t, err)
+	require.Empty(t, keys)
+}
+
+func TestManagerBackupAndRestoreCommitLogs(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		store   = newTestLocalStore(t)
+		srcOpts = newTestFsOptions(t)
+		dstOpts = newTestFsOptions(t)
+		now     = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+		prefix  = srcOpts.FilePathPrefix()
+	)
+	require.NoError(t, os.MkdirAll(fs.CommitLogsDirPath(prefix), 0755))
+	for i := 0; i < 3; i++ {
+		require.NoError(t, ioutil.WriteFile(fs.CommitLogFilePath(prefix, i), []byte{byte(i)}, 0644))
+	}
+	// The snapshot was taken while the second commit log was active.
+	require.NoError(t, fs.NewSnapshotMetadataWriter(srcOpts).Write(fs.SnapshotMetadataWriteArgs{
+		ID: fs.SnapshotMetadataIdentifier{
+			Index: 0,
+			UUID:  uuid.Parse("6645a373-bf82-42e7-84a6-f8452b137549"),
+		},
+		CommitlogIdentifier: persist.CommitLogFile{
+			FilePath: fs.CommitLogFilePath(prefix, 1),
+			Index:    1,
+		},
+	}))
+
+	manifest, err := newTestManager(t, store, srcOpts, now).Backup(ctx, []ident.ID{testNamespace})
+	require.NoError(t, err)
+	require.NotNil(t, manifest.SnapshotMetadata)
+	require.Len(t, manifest.CommitLogs, 2)
+
+	restorer := newTestManager(t, store, dstOpts, now)
+	restored, err := restorer.RestoreCommitLogs(ctx, manifest)
+	require.NoError(t, err)
+	require.Equal(t, 2, restored)
+
+	files, err := fs.SortedCommitLogFiles(fs.CommitLogsDirPath(dstOpts.FilePathPrefix()))
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		fs.CommitLogFilePath(dstOpts.FilePathPrefix(), 1),
+		fs.CommitLogFilePath(dstOpts.FilePathPrefix(), 2),
+	}, files)
+
+	// Commit logs on disk are not restored again.
+	restored, err = restorer.RestoreCommitLogs(ctx, manifest)
+	require.NoError(t, err)
+	require.Equal(t, 0, restored)
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package backup
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+const defaultS3Region = "us-east-1"
+
+var errS3NoBucket = errors.New("s3 backup store bucket not set")
+
+// S3StoreOptions are the options of a Store backed by an S3 compatible
+// object store.
+type S3StoreOptions struct {
+	// Bucket is the bucket the blobs are stored in.
+	Bucket string
+	// Prefix is prepended to the keys of the blobs.
+	Prefix string
+	// Region is the region of the bucket, defaults to us-east-1.
+	Region string
+	// Endpoint overrides the endpoint of the object store, used for S3
+	// compatible stores other than AWS.
+	Endpoint string
+	// ForcePathStyle addresses buckets by path rather than by host, which is
+	// required by most S3 compatible stores.
+	ForcePathStyle bool
+	// AccessKeyID and SecretAccessKey are static credentials, the default
+	// AWS credential chain is used if not set.
+	AccessKeyID     string
+	SecretAccessKey string
+	// HTTPClient is the HTTP client used for requests, optional.
+	HTTPClient *http.Client
+}
+
+type s3Store struct {
+	client *s3.S3
+	bucket string
+	prefix string
+}
+
+// NewS3Store returns a Store backed by an S3 compatible object store.
+func NewS3Store(opts S3StoreOptions) (Store, error) {
+	if opts.Bucket == "" {
+		return nil, errS3NoBucket
+	}
+
+	cfg := aws.NewConfig().
+		WithRegion(opts.Region).
+		WithS3ForcePathStyle(opts.ForcePathStyle)
+	if opts.Region == "" {
+		cfg = cfg.WithRegion(defaultS3Region)
+	}
+	if opts.Endpoint != "" {
+		cfg = cfg.WithEndpoint(opts.Endpoint)
+	}
+	if opts.AccessKeyID != "" {
+		cfg = cfg.WithCredentials(credentials.NewStaticCredentials(
+			opts.AccessKeyID, opts.SecretAccessKey, ""))
+	}
+	if opts.HTTPClient != nil {
+		cfg = cfg.WithHTTPClient(opts.HTTPClient)
+	}
+	sess, err := session.NewSession(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := strings.Trim(opts.Prefix, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+	return &s3Store{
+		client: s3.New(sess),
+		bucket: opts.Bucket,
+		prefix: prefix,
+	}, nil
+}
+
+func (s *s3Store) Put(ctx context.Context, key string, r io.ReadSeeker) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	_, err := s.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.prefix + key),
+		Body:   r,
+	})
+	return err
+}
+
+func (s *s3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+	out, err := s.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.prefix + key),
+	})
+	if err != nil {
+		if isS3NotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return out.Body, nil
+}
+
+func (s *s3Store) List(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	err := s.client.ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(s.prefix + prefix),
+	}, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, obj := range page.Contents {
+			keys = append(keys, strings.TrimPrefix(aws.StringValue(obj.Key), s.prefix))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (s *s3Store) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	_, err := s.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.prefix + key),
+	})
+	if err != nil && !isS3NotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func isS3NotFound(err error) bool {
+	var awsErr awserr.RequestFailure
+	if errors.As(err, &awsErr) && awsErr.StatusCode() == http.StatusNotFound {
+		return true
+	}
+	var codeErr awserr.Error
+	return errors.As(err, &codeErr) && codeErr.Code() == s3.ErrCodeNoSuchKey
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3 is a minimal stand-in for an S3 compatible object store addressed
+// by path.
+type fakeS3 struct {
+	sync.Mutex
+	objects map[string][]byte
+}
+
+func newFakeS3() *fakeS3 {
+	return &fakeS3{objects: make(map[string][]byte)}
+}
+
+type fakeS3ListResult struct {
+	XMLName     xml.Name `xml:"ListBucketResult"`
+	IsTruncated bool     `xml:"IsTruncated"`
+	Contents    []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+}
+
+func (s *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.Lock()
+	defer s.Unlock()
+
+	// Paths are /<bucket>/<key>, the bucket is not checked.
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 2)
+	key := ""
+	if len(parts) == 2 {
+		key = parts[1]
+	}
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Query().Get("list-type") == "2":
+		var (
+			prefix = r.URL.Query().Get("prefix")
+			result fakeS3ListResult
+			keys   []string
+		)
+		for k := range s.objects {
+			if strings.HasPrefix(k, prefix) {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			result.Contents = append(result.Contents, struct {
+				Key string `xml:"Key"`
+			}{Key: k})
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		_ = xml.NewEncoder(w).Encode(result)
+	case r.Method == http.MethodPut:
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		s.objects[key] = data
+	case r.Method == http.MethodGet:
+		data, ok := s.objects[key]
+		if !ok {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("<Error><Code>NoSuchKey</Code></Error>"))
+			return
+		}
+		_, _ = w.Write(data)
+	case r.Method == http.MethodDelete:
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func newTestS3Store(t *testing.T) Store {
+	server := httptest.NewServer(newFakeS3())
+	t.Cleanup(server.Close)
+
+	store, err := NewS3Store(S3StoreOptions{
+		Bucket:          "backups",
+		Prefix:          "m3db",
+		Endpoint:        server.URL,
+		ForcePathStyle:  true,
+		AccessKeyID:     "key",
+		SecretAccessKey: "secret",
+	})
+	require.NoError(t, err)
+	return store
+}
+
+func newTestLocalStore(t *testing.T) Store {
+	dir, err := ioutil.TempDir("", "backup-store")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	store, err := NewLocalStore(dir)
+	require.NoError(t, err)
+	return store
+}
+
+func TestStores(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		newStore func(t *testing.T) Store
+	}{
+		{name: "local", newStore: newTestLocalStore},
+		{name: "s3", newStore: newTestS3Store},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				ctx   = context.Background()
+				store = test.newStore(t)
+			)
+
+			_, err := store.Get(ctx, "host/a")
+			require.Equal(t, ErrNotFound, err)
+
+			for _, key := range []string{"host/b/2", "host/a", "host/b/1", "other/a"} {
+				require.NoError(t, store.Put(ctx, key, bytes.NewReader([]byte(key))))
+			}
+
+			r, err := store.Get(ctx, "host/b/1")
+			require.NoError(t, err)
+			data, err := ioutil.ReadAll(r)
+			require.NoError(t, err)
+			require.NoError(t, r.Close())
+			require.Equal(t, "host/b/1", string(data))
+
+			keys, err := store.List(ctx, "host/")
+			require.NoError(t, err)
+			require.Equal(t, []string{"host/a", "host/b/1", "host/b/2"}, keys)
+
+			require.NoError(t, store.Delete(ctx, "host/b/1"))
+			require.NoError(t, store.Delete(ctx, "host/b/1"))
+			keys, err = store.List(ctx, "host/b/")
+			require.NoError(t, err)
+			require.Equal(t, []string{"host/b/2"}, keys)
+
+			require.Error(t, store.Put(ctx, "../a", bytes.NewReader(nil)))
+		})
+	}
+}
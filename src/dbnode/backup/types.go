@@ -0,0 +1,171 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package backup implements backups of namespace filesets to blob stores.
+package backup
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/ident"
+	"github.com/m3db/m3/src/x/instrument"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+// ErrNotFound is returned by a Store when a key does not exist.
+var ErrNotFound = errors.New("blob not found")
+
+// Store is a blob store backups are uploaded to. Keys are slash separated
+// paths.
+type Store interface {
+	// Put writes the contents of the reader to the key, replacing any
+	// existing blob.
+	Put(ctx context.Context, key string, r io.ReadSeeker) error
+
+	// Get returns a reader of the blob of the key, or ErrNotFound.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// List returns the sorted keys starting with the prefix.
+	List(ctx context.Context, prefix string) ([]string, error)
+
+	// Delete deletes the blob of the key, deleting a key that does not exist
+	// is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// Manifest describes the filesets of a backup, it is uploaded once all the
+// files of the backup have been uploaded.
+type Manifest struct {
+	// ID is the ID of the backup.
+	ID string `json:"id"`
+	// HostID is the ID of the host the backup was taken from.
+	HostID string `json:"hostID"`
+	// CreatedAt is the time the backup was started.
+	CreatedAt time.Time `json:"createdAt"`
+	// Namespaces are the filesets of each namespace of the backup.
+	Namespaces []NamespaceManifest `json:"namespaces"`
+	// SnapshotMetadata are the files of the latest snapshot metadata, if any.
+	SnapshotMetadata *FileSet `json:"snapshotMetadata,omitempty"`
+	// CommitLogs are the commit log files the latest snapshot metadata
+	// requires to bootstrap the writes that were not snapshotted.
+	CommitLogs []string `json:"commitLogs,omitempty"`
+}
+
+// Namespace returns the manifest of a namespace of the backup.
+func (m Manifest) Namespace(id ident.ID) (NamespaceManifest, bool) {
+	for _, ns := range m.Namespaces {
+		if ns.ID == id.String() {
+			return ns, true
+		}
+	}
+	return NamespaceManifest{}, false
+}
+
+// NamespaceManifest describes the filesets of a namespace of a backup.
+type NamespaceManifest struct {
+	// ID is the namespace ID.
+	ID string `json:"id"`
+	// Data are the latest complete data filesets of each shard and block.
+	Data []FileSet `json:"data,omitempty"`
+	// Snapshots are the latest complete snapshot filesets of each shard and
+	// block.
+	Snapshots []FileSet `json:"snapshots,omitempty"`
+	// Index are the complete index filesets.
+	Index []FileSet `json:"index,omitempty"`
+}
+
+// FileSet describes the files of a fileset of a backup.
+type FileSet struct {
+	// Shards are the shards of the fileset, data and snapshot filesets
+	// belong to a single shard and snapshot metadata to none.
+	Shards []uint32 `json:"shards"`
+	// BlockStart is the start of the block of the fileset.
+	BlockStart xtime.UnixNano `json:"blockStart"`
+	// VolumeIndex is the volume index of the fileset.
+	VolumeIndex int `json:"volumeIndex"`
+	// Files are the paths of the files of the fileset relative to the
+	// filesystem prefix, which are also their keys relative to the backup.
+	Files []string `json:"files"`
+}
+
+// Manager takes and restores backups of the filesets of a host.
+type Manager interface {
+	// Backup uploads the complete filesets of the namespaces and returns the
+	// manifest of the backup.
+	Backup(ctx context.Context, namespaces []ident.ID) (Manifest, error)
+
+	// Backups returns the sorted IDs of the complete backups of a host.
+	Backups(ctx context.Context, hostID string) ([]string, error)
+
+	// Manifest returns the manifest of a backup of a host.
+	Manifest(ctx context.Context, hostID, backupID string) (Manifest, error)
+
+	// Restore downloads the filesets of a backup that are not complete on
+	// disk and returns the number of filesets downloaded.
+	Restore(ctx context.Context, manifest Manifest, filesets []FileSet) (int, error)
+
+	// RestoreCommitLogs downloads the commit logs of a backup that are not on
+	// disk and returns the number of commit logs downloaded.
+	RestoreCommitLogs(ctx context.Context, manifest Manifest) (int, error)
+
+	// Prune deletes all but the latest retain backups of a host.
+	Prune(ctx context.Context, hostID string, retain int) error
+}
+
+// Options are the backup options.
+type Options interface {
+	// Validate validates the options.
+	Validate() error
+
+	// SetStore sets the blob store.
+	SetStore(value Store) Options
+
+	// Store returns the blob store.
+	Store() Store
+
+	// SetHostID sets the ID of the host backups are taken from.
+	SetHostID(value string) Options
+
+	// HostID returns the ID of the host backups are taken from.
+	HostID() string
+
+	// SetFilesystemOptions sets the filesystem options.
+	SetFilesystemOptions(value fs.Options) Options
+
+	// FilesystemOptions returns the filesystem options.
+	FilesystemOptions() fs.Options
+
+	// SetClockOptions sets the clock options.
+	SetClockOptions(value clock.Options) Options
+
+	// ClockOptions returns the clock options.
+	ClockOptions() clock.Options
+
+	// SetInstrumentOptions sets the instrument options.
+	SetInstrumentOptions(value instrument.Options) Options
+
+	// InstrumentOptions returns the instrument options.
+	InstrumentOptions() instrument.Options
+}
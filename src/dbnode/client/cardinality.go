@@ -0,0 +1,316 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/m3db/m3/src/cluster/shard"
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/dbnode/topology"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+const cardinalityHostLimitFactor = 4
+
+type cardinalityOp struct {
+	request      rpc.CardinalityRequest
+	context      context.Context
+	completionFn completionFn
+}
+
+func (c *cardinalityOp) Size() int {
+	// Cardinality is always a single op
+	return 1
+}
+
+func (c *cardinalityOp) CompletionFn() completionFn {
+	return c.completionFn
+}
+
+type cardinalityResult struct {
+	result index.CardinalityResult
+	err    error
+}
+
+// cardinalityHostLimit returns the number of entries of the top lists
+// requested from each host for a limit. Hosts truncate their top lists
+// before they are merged, so more entries than the limit are requested to
+// make it more likely that the merged top lists are exact.
+func cardinalityHostLimit(limit int) int {
+	return limit * cardinalityHostLimitFactor
+}
+
+// cardinalityConsistencyResult merges the cardinality results of the hosts
+// of a cluster if the results achieved the read consistency level on every
+// shard. The merged result is approximate if any host did not respond.
+func cardinalityConsistencyResult(
+	level topology.ReadConsistencyLevel,
+	majority int,
+	topoMap topology.Map,
+	hosts []topology.Host,
+	results []cardinalityResult,
+	hostLimit int,
+	limit int,
+) (index.CardinalityResult, error) {
+	var (
+		enqueued        = make(map[uint32]int)
+		success         = make(map[uint32]int)
+		errs            []error
+		succeeded       []index.CardinalityResult
+		succeededShards int64
+	)
+	for idx, host := range hosts {
+		hostShardSet, ok := topoMap.LookupHostShardSet(host.ID())
+		if !ok {
+			continue
+		}
+
+		result := results[idx]
+		if result.err != nil {
+			errs = append(errs, xerrors.NewRenamedError(result.err,
+				fmt.Errorf("error computing cardinality on host %s: %v", host.ID(), result.err)))
+		} else {
+			succeeded = append(succeeded, result.result)
+			succeededShards += int64(len(hostShardSet.ShardSet().All()))
+		}
+
+		for _, hs := range hostShardSet.ShardSet().All() {
+			enqueued[hs.ID()]++
+			// Only available shards have indexed all the series of the shard.
+			if result.err == nil && hs.State() == shard.Available {
+				success[hs.ID()]++
+			}
+		}
+	}
+
+	for shardID, n := range enqueued {
+		if !topology.ReadConsistencyAchieved(level, majority, n, success[shardID]) {
+			return index.CardinalityResult{}, newConsistencyResultError(level, n, n, errs)
+		}
+	}
+
+	merged := mergeCardinalityResults(succeeded, int64(len(enqueued)),
+		succeededShards, hostLimit, limit)
+	if len(errs) > 0 {
+		merged.Approximate = true
+	}
+	return merged, nil
+}
+
+// mergeCardinalityResults merges the cardinality results of the hosts of a
+// cluster. Each series is indexed by every replica of its shard so the series
+// counts are summed and scaled by the number of shards over the number of
+// shard replicas of the hosts, which divides them by the number of replicas
+// when every host responded. The distinct label value counts cannot be summed
+// since hosts index overlapping values, so the maximum count of any host is
+// used instead.
+//
+// The top lists of the hosts are truncated to the host limit, the merged
+// result is marked approximate when the truncated entries could change the
+// merged top lists or their counts.
+func mergeCardinalityResults(
+	results []index.CardinalityResult,
+	numShards int64,
+	succeededShards int64,
+	hostLimit int,
+	limit int,
+) index.CardinalityResult {
+	if numShards < 1 || succeededShards < 1 {
+		numShards, succeededShards = 1, 1
+	}
+
+	var (
+		merged       index.CardinalityResult
+		byMetricName = newCardinalityStatsMerger(hostLimit, sumCardinalityValues)
+		byLabelName  = newCardinalityStatsMerger(hostLimit, maxCardinalityValues)
+		byLabelPair  = newCardinalityStatsMerger(hostLimit, sumCardinalityValues)
+		blocks       = make(map[xtime.UnixNano]index.BlockCardinality)
+		scale        = func(v int64) int64 {
+			return v * numShards / succeededShards
+		}
+	)
+	for _, r := range results {
+		merged.NumSeries += r.NumSeries
+		merged.Approximate = merged.Approximate || r.Approximate
+		byMetricName.add(r.SeriesCountByMetricName)
+		byLabelName.add(r.LabelValueCountByLabelName)
+		byLabelPair.add(r.SeriesCountByLabelValuePair)
+		for _, b := range r.Blocks {
+			curr := blocks[b.BlockStart]
+			curr.BlockStart = b.BlockStart
+			curr.NumSeries += b.NumSeries
+			curr.NewSeries += b.NewSeries
+			curr.RemovedSeries += b.RemovedSeries
+			blocks[b.BlockStart] = curr
+		}
+	}
+
+	var exact [3]bool
+	merged.NumSeries = scale(merged.NumSeries)
+	merged.SeriesCountByMetricName, exact[0] = byMetricName.top(limit, scale)
+	merged.LabelValueCountByLabelName, exact[1] = byLabelName.top(limit, nil)
+	merged.SeriesCountByLabelValuePair, exact[2] = byLabelPair.top(limit, scale)
+	merged.Approximate = merged.Approximate || !exact[0] || !exact[1] || !exact[2]
+	merged.Blocks = make([]index.BlockCardinality, 0, len(blocks))
+	for _, b := range blocks {
+		b.NumSeries = scale(b.NumSeries)
+		b.NewSeries = scale(b.NewSeries)
+		b.RemovedSeries = scale(b.RemovedSeries)
+		merged.Blocks = append(merged.Blocks, b)
+	}
+	sort.Slice(merged.Blocks, func(i, j int) bool {
+		return merged.Blocks[i].BlockStart.Before(merged.Blocks[j].BlockStart)
+	})
+	return merged
+}
+
+func sumCardinalityValues(a, b int64) int64 {
+	return a + b
+}
+
+func maxCardinalityValues(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// cardinalityStatsMerger merges the top lists of the hosts, a top list with
+// as many entries as the host limit may have been truncated and bounds the
+// values of the entries it does not contain by its smallest value.
+type cardinalityStatsMerger struct {
+	hostLimit int
+	combine   func(a, b int64) int64
+	values    map[string]int64
+	truncated []cardinalityTruncatedStats
+}
+
+type cardinalityTruncatedStats struct {
+	names map[string]struct{}
+	bound int64
+}
+
+func newCardinalityStatsMerger(
+	hostLimit int,
+	combine func(a, b int64) int64,
+) *cardinalityStatsMerger {
+	return &cardinalityStatsMerger{
+		hostLimit: hostLimit,
+		combine:   combine,
+		values:    make(map[string]int64),
+	}
+}
+
+func (m *cardinalityStatsMerger) add(stats []index.CardinalityStat) {
+	for _, stat := range stats {
+		m.values[string(stat.Name)] = m.combine(m.values[string(stat.Name)], stat.Value)
+	}
+	if len(stats) == 0 || len(stats) < m.hostLimit {
+		return
+	}
+
+	truncated := cardinalityTruncatedStats{
+		names: make(map[string]struct{}, len(stats)),
+		bound: stats[0].Value,
+	}
+	for _, stat := range stats {
+		truncated.names[string(stat.Name)] = struct{}{}
+		if stat.Value < truncated.bound {
+			truncated.bound = stat.Value
+		}
+	}
+	m.truncated = append(m.truncated, truncated)
+}
+
+// upperBound returns the largest value a name could have if the truncated
+// top lists that do not contain it were complete.
+func (m *cardinalityStatsMerger) upperBound(name string, value int64) int64 {
+	for _, t := range m.truncated {
+		if _, ok := t.names[name]; !ok {
+			value = m.combine(value, t.bound)
+		}
+	}
+	return value
+}
+
+// unseenUpperBound returns the largest value a name that no top list
+// contains could have.
+func (m *cardinalityStatsMerger) unseenUpperBound() int64 {
+	var value int64
+	for _, t := range m.truncated {
+		value = m.combine(value, t.bound)
+	}
+	return value
+}
+
+// top returns the top entries of the merged values, scaled if scale is set,
+// and whether the top entries and their values are exact.
+func (m *cardinalityStatsMerger) top(
+	limit int,
+	scale func(int64) int64,
+) ([]index.CardinalityStat, bool) {
+	stats := make([]index.CardinalityStat, 0, len(m.values))
+	for name, value := range m.values {
+		stats = append(stats, index.CardinalityStat{
+			Name:  []byte(name),
+			Value: value,
+		})
+	}
+	index.SortCardinalityStats(stats)
+
+	exact := true
+	if len(m.truncated) > 0 {
+		// An entry of the top list is exact if no truncated top list could
+		// have omitted a part of its value, and the top list is exact if no
+		// other entry, including ones that no host returned, could exceed
+		// its smallest value. Truncated top lists have at least as many
+		// entries as the limit so the top list is full.
+		topLen := limit
+		if topLen > len(stats) {
+			topLen = len(stats)
+		}
+		minValue := stats[topLen-1].Value
+		exact = m.unseenUpperBound() <= minValue
+		for i := 0; exact && i < len(stats); i++ {
+			stat := stats[i]
+			bound := m.upperBound(string(stat.Name), stat.Value)
+			if i < topLen {
+				exact = bound == stat.Value
+			} else {
+				exact = bound <= minValue
+			}
+		}
+	}
+
+	if len(stats) > limit {
+		stats = stats[:limit]
+	}
+	if scale != nil {
+		for i := range stats {
+			stats[i].Value = scale(stats[i].Value)
+		}
+	}
+	return stats, exact
+}
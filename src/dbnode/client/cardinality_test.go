@@ -0,0 +1,180 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package client
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/dbnode/topology"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+func TestMergeCardinalityResults(t *testing.T) {
+	var (
+		first  = xtime.Now().Truncate(time.Hour)
+		second = first.Add(time.Hour)
+	)
+	results := []index.CardinalityResult{
+		{
+			NumSeries: 4,
+			SeriesCountByMetricName: []index.CardinalityStat{
+				{Name: []byte("foo"), Value: 4},
+			},
+			LabelValueCountByLabelName: []index.CardinalityStat{
+				{Name: []byte("host"), Value: 3},
+				{Name: []byte("__name__"), Value: 1},
+			},
+			SeriesCountByLabelValuePair: []index.CardinalityStat{
+				{Name: []byte("__name__=foo"), Value: 4},
+			},
+			Blocks: []index.BlockCardinality{
+				{BlockStart: second, NumSeries: 4, NewSeries: 2},
+				{BlockStart: first, NumSeries: 2, NewSeries: 2},
+			},
+		},
+		{
+			NumSeries: 2,
+			SeriesCountByMetricName: []index.CardinalityStat{
+				{Name: []byte("bar"), Value: 2},
+			},
+			LabelValueCountByLabelName: []index.CardinalityStat{
+				{Name: []byte("host"), Value: 2},
+				{Name: []byte("__name__"), Value: 1},
+			},
+			SeriesCountByLabelValuePair: []index.CardinalityStat{
+				{Name: []byte("__name__=bar"), Value: 2},
+			},
+			Blocks: []index.BlockCardinality{
+				{BlockStart: second, NumSeries: 2, NewSeries: 2, RemovedSeries: 2},
+			},
+		},
+	}
+
+	require.Equal(t, index.CardinalityResult{
+		NumSeries: 3,
+		SeriesCountByMetricName: []index.CardinalityStat{
+			{Name: []byte("foo"), Value: 2},
+			{Name: []byte("bar"), Value: 1},
+		},
+		LabelValueCountByLabelName: []index.CardinalityStat{
+			{Name: []byte("host"), Value: 3},
+			{Name: []byte("__name__"), Value: 1},
+		},
+		SeriesCountByLabelValuePair: []index.CardinalityStat{
+			{Name: []byte("__name__=foo"), Value: 2},
+			{Name: []byte("__name__=bar"), Value: 1},
+		},
+		Blocks: []index.BlockCardinality{
+			{BlockStart: first, NumSeries: 1, NewSeries: 1},
+			{BlockStart: second, NumSeries: 3, NewSeries: 2, RemovedSeries: 1},
+		},
+	}, mergeCardinalityResults(results, 1, 2, 8, 2))
+}
+
+func TestMergeCardinalityResultsTruncated(t *testing.T) {
+	stats := func(values ...int64) []index.CardinalityStat {
+		names := []string{"a", "b", "c", "d"}
+		result := make([]index.CardinalityStat, 0, len(values))
+		for i, v := range values {
+			if v > 0 {
+				result = append(result, index.CardinalityStat{
+					Name:  []byte(names[i]),
+					Value: v,
+				})
+			}
+		}
+		index.SortCardinalityStats(result)
+		return result
+	}
+
+	// The truncated top lists cannot change the top entry.
+	results := []index.CardinalityResult{
+		{SeriesCountByMetricName: stats(10, 2, 0, 0)},
+		{SeriesCountByMetricName: stats(9, 0, 1, 0)},
+	}
+	merged := mergeCardinalityResults(results, 1, 1, 2, 1)
+	require.Equal(t, stats(19, 0, 0, 0), merged.SeriesCountByMetricName)
+	require.False(t, merged.Approximate)
+
+	// The entry truncated from the second top list could exceed the top
+	// entry of the merged top list.
+	results = []index.CardinalityResult{
+		{SeriesCountByMetricName: stats(10, 9, 0, 0)},
+		{SeriesCountByMetricName: stats(0, 0, 8, 8)},
+	}
+	merged = mergeCardinalityResults(results, 1, 1, 2, 1)
+	require.Equal(t, stats(10, 0, 0, 0), merged.SeriesCountByMetricName)
+	require.True(t, merged.Approximate)
+
+	// Top lists shorter than the host limit are complete.
+	merged = mergeCardinalityResults(results, 1, 1, 3, 1)
+	require.False(t, merged.Approximate)
+}
+
+func TestCardinalityConsistencyResult(t *testing.T) {
+	shardSet := sessionTestShardSet()
+	topoMap := topology.NewStaticMap(topology.NewStaticOptions().
+		SetReplicas(sessionTestReplicas).
+		SetShardSet(shardSet).
+		SetHostShardSets(sessionTestHostAndShards(shardSet)))
+	hosts := topoMap.Hosts()
+	majority := topoMap.MajorityReplicas()
+
+	result := index.CardinalityResult{
+		NumSeries: 4,
+		SeriesCountByMetricName: []index.CardinalityStat{
+			{Name: []byte("foo"), Value: 4},
+		},
+	}
+	results := []cardinalityResult{{result: result}, {result: result}, {result: result}}
+	merged, err := cardinalityConsistencyResult(topology.ReadConsistencyLevelMajority,
+		majority, topoMap, hosts, results, 8, 2)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), merged.NumSeries)
+	require.False(t, merged.Approximate)
+
+	// One unavailable host still achieves majority but the result is
+	// estimated from the other hosts.
+	results[2] = cardinalityResult{err: errors.New("unavailable")}
+	merged, err = cardinalityConsistencyResult(topology.ReadConsistencyLevelMajority,
+		majority, topoMap, hosts, results, 8, 2)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), merged.NumSeries)
+	require.Equal(t, []index.CardinalityStat{
+		{Name: []byte("foo"), Value: 4},
+	}, merged.SeriesCountByMetricName)
+	require.True(t, merged.Approximate)
+
+	// But not all.
+	_, err = cardinalityConsistencyResult(topology.ReadConsistencyLevelAll,
+		majority, topoMap, hosts, results, 8, 2)
+	require.Error(t, err)
+
+	// Nor majority with two unavailable hosts.
+	results[1] = cardinalityResult{err: errors.New("unavailable")}
+	_, err = cardinalityConsistencyResult(topology.ReadConsistencyLevelMajority,
+		majority, topoMap, hosts, results, 8, 2)
+	require.Error(t, err)
+}
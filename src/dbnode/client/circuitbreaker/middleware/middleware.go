@@ -166,6 +166,14 @@ func (c *client) DebugProfileStop(
 	return c.next.DebugProfileStop(ctx, req)
 }
 
+func (c *client) Cardinality(ctx thrift.Context, req *rpc.CardinalityRequest) (*rpc.CardinalityResult_, error) {
+	return c.next.Cardinality(ctx, req)
+}
+
+func (c *client) DeleteSeries(ctx thrift.Context, req *rpc.DeleteSeriesRequest) (*rpc.DeleteSeriesResult_, error) {
+	return c.next.DeleteSeries(ctx, req)
+}
+
 func (c *client) Fetch(ctx thrift.Context, req *rpc.FetchRequest) (*rpc.FetchResult_, error) {
 	return c.next.Fetch(ctx, req)
 }
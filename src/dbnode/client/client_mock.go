@@ -167,6 +167,21 @@ func (mr *MockSessionMockRecorder) Aggregate(ctx, namespace, q, opts interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Aggregate", reflect.TypeOf((*MockSession)(nil).Aggregate), ctx, namespace, q, opts)
 }
 
+// Cardinality mocks base method.
+func (m *MockSession) Cardinality(ctx context.Context, namespace ident.ID, opts index.CardinalityOptions) (index.CardinalityResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cardinality", ctx, namespace, opts)
+	ret0, _ := ret[0].(index.CardinalityResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cardinality indicates an expected call of Cardinality.
+func (mr *MockSessionMockRecorder) Cardinality(ctx, namespace, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cardinality", reflect.TypeOf((*MockSession)(nil).Cardinality), ctx, namespace, opts)
+}
+
 // Close mocks base method.
 func (m *MockSession) Close() error {
 	m.ctrl.T.Helper()
@@ -181,6 +196,21 @@ func (mr *MockSessionMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockSession)(nil).Close))
 }
 
+// DeleteSeries indicates an expected call of DeleteSeries.
+func (mr *MockSessionMockRecorder) DeleteSeries(ctx, namespace, q, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSeries", reflect.TypeOf((*MockSession)(nil).DeleteSeries), ctx, namespace, q, start, end)
+}
+
+// DeleteSeries mocks base method.
+func (m *MockSession) DeleteSeries(ctx context.Context, namespace ident.ID, q index.Query, start, end time.UnixNano) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSeries", ctx, namespace, q, start, end)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // Fetch mocks base method.
 func (m *MockSession) Fetch(namespace, id ident.ID, startInclusive, endExclusive time.UnixNano) (encoding.SeriesIterator, error) {
 	m.ctrl.T.Helper()
@@ -830,6 +860,21 @@ func (mr *MockAdminSessionMockRecorder) BorrowConnections(shardID, fn, opts inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BorrowConnections", reflect.TypeOf((*MockAdminSession)(nil).BorrowConnections), shardID, fn, opts)
 }
 
+// Cardinality mocks base method.
+func (m *MockAdminSession) Cardinality(ctx context.Context, namespace ident.ID, opts index.CardinalityOptions) (index.CardinalityResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cardinality", ctx, namespace, opts)
+	ret0, _ := ret[0].(index.CardinalityResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cardinality indicates an expected call of Cardinality.
+func (mr *MockAdminSessionMockRecorder) Cardinality(ctx, namespace, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cardinality", reflect.TypeOf((*MockAdminSession)(nil).Cardinality), ctx, namespace, opts)
+}
+
 // Close mocks base method.
 func (m *MockAdminSession) Close() error {
 	m.ctrl.T.Helper()
@@ -860,6 +905,21 @@ func (mr *MockAdminSessionMockRecorder) DedicatedConnection(shardID, opts interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DedicatedConnection", reflect.TypeOf((*MockAdminSession)(nil).DedicatedConnection), shardID, opts)
 }
 
+// DeleteSeries indicates an expected call of DeleteSeries.
+func (mr *MockAdminSessionMockRecorder) DeleteSeries(ctx, namespace, q, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSeries", reflect.TypeOf((*MockAdminSession)(nil).DeleteSeries), ctx, namespace, q, start, end)
+}
+
+// DeleteSeries mocks base method.
+func (m *MockAdminSession) DeleteSeries(ctx context.Context, namespace ident.ID, q index.Query, start, end time.UnixNano) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSeries", ctx, namespace, q, start, end)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // Fetch mocks base method.
 func (m *MockAdminSession) Fetch(namespace, id ident.ID, startInclusive, endExclusive time.UnixNano) (encoding.SeriesIterator, error) {
 	m.ctrl.T.Helper()
@@ -4980,6 +5040,21 @@ func (mr *MockclientSessionMockRecorder) BorrowConnections(shardID, fn, opts int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BorrowConnections", reflect.TypeOf((*MockclientSession)(nil).BorrowConnections), shardID, fn, opts)
 }
 
+// Cardinality mocks base method.
+func (m *MockclientSession) Cardinality(ctx context.Context, namespace ident.ID, opts index.CardinalityOptions) (index.CardinalityResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cardinality", ctx, namespace, opts)
+	ret0, _ := ret[0].(index.CardinalityResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cardinality indicates an expected call of Cardinality.
+func (mr *MockclientSessionMockRecorder) Cardinality(ctx, namespace, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cardinality", reflect.TypeOf((*MockclientSession)(nil).Cardinality), ctx, namespace, opts)
+}
+
 // Close mocks base method.
 func (m *MockclientSession) Close() error {
 	m.ctrl.T.Helper()
@@ -5010,6 +5085,21 @@ func (mr *MockclientSessionMockRecorder) DedicatedConnection(shardID, opts inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DedicatedConnection", reflect.TypeOf((*MockclientSession)(nil).DedicatedConnection), shardID, opts)
 }
 
+// DeleteSeries indicates an expected call of DeleteSeries.
+func (mr *MockclientSessionMockRecorder) DeleteSeries(ctx, namespace, q, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSeries", reflect.TypeOf((*MockclientSession)(nil).DeleteSeries), ctx, namespace, q, start, end)
+}
+
+// DeleteSeries mocks base method.
+func (m *MockclientSession) DeleteSeries(ctx context.Context, namespace ident.ID, q index.Query, start, end time.UnixNano) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSeries", ctx, namespace, q, start, end)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // Fetch mocks base method.
 func (m *MockclientSession) Fetch(namespace, id ident.ID, startInclusive, endExclusive time.UnixNano) (encoding.SeriesIterator, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,106 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/m3db/m3/src/cluster/shard"
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/topology"
+	xerrors "github.com/m3db/m3/src/x/errors"
+)
+
+type deleteSeriesOp struct {
+	request      rpc.DeleteSeriesRequest
+	context      context.Context
+	completionFn completionFn
+}
+
+func (d *deleteSeriesOp) Size() int {
+	// Delete series is always a single op
+	return 1
+}
+
+func (d *deleteSeriesOp) CompletionFn() completionFn {
+	return d.completionFn
+}
+
+type deleteSeriesResult struct {
+	numSeries int64
+	err       error
+}
+
+// deleteSeriesConsistencyResult returns the number of series deleted per
+// replica if the delete achieved the write consistency level on every shard.
+// Hosts only return the number of series deleted across all of their shards,
+// so the count per replica is estimated from the shards of the hosts that
+// succeeded and is exact when all of them do.
+func deleteSeriesConsistencyResult(
+	level topology.ConsistencyLevel,
+	majority int,
+	topoMap topology.Map,
+	hosts []topology.Host,
+	results []deleteSeriesResult,
+) (int64, error) {
+	var (
+		enqueued        = make(map[uint32]int)
+		success         = make(map[uint32]int)
+		errs            []error
+		deleted         int64
+		succeededShards int64
+	)
+	for idx, host := range hosts {
+		hostShardSet, ok := topoMap.LookupHostShardSet(host.ID())
+		if !ok {
+			continue
+		}
+
+		result := results[idx]
+		if result.err != nil {
+			errs = append(errs, xerrors.NewRenamedError(result.err,
+				fmt.Errorf("error deleting series from host %s: %v", host.ID(), result.err)))
+		} else {
+			deleted += result.numSeries
+			succeededShards += int64(len(hostShardSet.ShardSet().All()))
+		}
+
+		for _, hs := range hostShardSet.ShardSet().All() {
+			enqueued[hs.ID()]++
+			// Only available shards have deleted all the series of the shard.
+			if result.err == nil && hs.State() == shard.Available {
+				success[hs.ID()]++
+			}
+		}
+	}
+
+	for shardID, n := range enqueued {
+		if !topology.WriteConsistencyAchieved(level, majority, n, success[shardID]) {
+			return 0, newConsistencyResultError(level, n, n, errs)
+		}
+	}
+
+	if succeededShards == 0 {
+		return 0, nil
+	}
+	return deleted * int64(len(enqueued)) / succeededShards, nil
+}
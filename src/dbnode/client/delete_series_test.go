@@ -0,0 +1,69 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/dbnode/topology"
+)
+
+func TestDeleteSeriesConsistencyResult(t *testing.T) {
+	shardSet := sessionTestShardSet()
+	topoMap := topology.NewStaticMap(topology.NewStaticOptions().
+		SetReplicas(sessionTestReplicas).
+		SetShardSet(shardSet).
+		SetHostShardSets(sessionTestHostAndShards(shardSet)))
+	hosts := topoMap.Hosts()
+	majority := topoMap.MajorityReplicas()
+
+	// Every replica deleted the same series.
+	results := []deleteSeriesResult{{numSeries: 4}, {numSeries: 4}, {numSeries: 4}}
+	deleted, err := deleteSeriesConsistencyResult(topology.ConsistencyLevelMajority,
+		majority, topoMap, hosts, results)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), deleted)
+
+	// One unavailable host still achieves majority.
+	results[2] = deleteSeriesResult{err: errors.New("unavailable")}
+	deleted, err = deleteSeriesConsistencyResult(topology.ConsistencyLevelMajority,
+		majority, topoMap, hosts, results)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), deleted)
+
+	// But not all.
+	_, err = deleteSeriesConsistencyResult(topology.ConsistencyLevelAll,
+		majority, topoMap, hosts, results)
+	require.Error(t, err)
+
+	// Nor majority with two unavailable hosts.
+	results[1] = deleteSeriesResult{err: errors.New("unavailable")}
+	_, err = deleteSeriesConsistencyResult(topology.ConsistencyLevelMajority,
+		majority, topoMap, hosts, results)
+	require.Error(t, err)
+	deleted, err = deleteSeriesConsistencyResult(topology.ConsistencyLevelOne,
+		majority, topoMap, hosts, results)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), deleted)
+}
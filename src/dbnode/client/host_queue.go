@@ -315,6 +315,10 @@ func (q *queue) drain() {
 				}
 			case *truncateOp:
 				q.asyncTruncate(v)
+			case *deleteSeriesOp:
+				q.asyncDeleteSeries(v)
+			case *cardinalityOp:
+				q.asyncCardinality(v)
 			default:
 				completionFn := ops[i].CompletionFn()
 				completionFn(nil, errQueueUnknownOperation(q.host.ID()))
@@ -1019,6 +1023,70 @@ func (q *queue) asyncTruncate(op *truncateOp) {
 	})
 }
 
+func (q *queue) asyncCardinality(op *cardinalityOp) {
+	q.Add(1)
+
+	q.workerPool.Go(func() {
+		cleanup := q.Done
+
+		// All cardinality calls are required to provide a context with a deadline.
+		ctx, err := q.mustWrapAndCheckContext(op.context, "cardinality")
+		if err != nil {
+			op.completionFn(nil, err)
+			cleanup()
+			return
+		}
+
+		client, _, err := q.connPool.NextClient()
+		if err != nil {
+			// No client available
+			op.completionFn(nil, err)
+			cleanup()
+			return
+		}
+
+		if res, err := client.Cardinality(ctx, &op.request); err != nil {
+			op.completionFn(nil, err)
+		} else {
+			op.completionFn(res, nil)
+		}
+
+		cleanup()
+	})
+}
+
+func (q *queue) asyncDeleteSeries(op *deleteSeriesOp) {
+	q.Add(1)
+
+	q.workerPool.Go(func() {
+		cleanup := q.Done
+
+		// All delete series calls are required to provide a context with a deadline.
+		ctx, err := q.mustWrapAndCheckContext(op.context, "deleteSeries")
+		if err != nil {
+			op.completionFn(nil, err)
+			cleanup()
+			return
+		}
+
+		client, _, err := q.connPool.NextClient()
+		if err != nil {
+			// No client available
+			op.completionFn(nil, err)
+			cleanup()
+			return
+		}
+
+		if res, err := client.DeleteSeries(ctx, &op.request); err != nil {
+			op.completionFn(nil, err)
+		} else {
+			op.completionFn(res, nil)
+		}
+
+		cleanup()
+	})
+}
+
 func (q *queue) mustWrapAndCheckContext(
 	callingContext context.Context,
 	method string,
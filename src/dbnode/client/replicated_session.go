@@ -348,6 +348,38 @@ func (s replicatedSession) Truncate(namespace ident.ID) (int64, error) {
 	return s.session.Truncate(namespace)
 }
 
+// Cardinality computes the cardinality of the series indexed by a namespace
+// of the primary cluster.
+func (s replicatedSession) Cardinality(
+	ctx context.Context,
+	namespace ident.ID,
+	opts index.CardinalityOptions,
+) (index.CardinalityResult, error) {
+	return s.session.Cardinality(ctx, namespace, opts)
+}
+
+// DeleteSeries deletes the datapoints within the range of the series matching
+// the query, the deletes are also applied to the async clusters so that the
+// deleted datapoints are not left behind in the replicated data.
+func (s replicatedSession) DeleteSeries(
+	ctx context.Context,
+	namespace ident.ID,
+	q index.Query,
+	start, end xtime.UnixNano,
+) (int64, error) {
+	deleted, err := s.session.DeleteSeries(ctx, namespace, q, start, end)
+	if err != nil {
+		return deleted, err
+	}
+	for _, as := range s.asyncSessions {
+		if _, err := as.DeleteSeries(ctx, namespace, q, start, end); err != nil {
+			s.metrics.replicateError.Inc(1)
+			s.log.Error("could not delete series from async session", zap.Error(err))
+		}
+	}
+	return deleted, nil
+}
+
 // FetchBootstrapBlocksFromPeers will fetch the most fulfilled block
 // for each series using the runtime configurable bootstrap level consistency.
 func (s replicatedSession) FetchBootstrapBlocksFromPeers(
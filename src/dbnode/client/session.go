@@ -2288,6 +2288,125 @@ func (s *session) Truncate(namespace ident.ID) (int64, error) {
 	return truncated, resultErr.FinalError()
 }
 
+func (s *session) Cardinality(
+	ctx gocontext.Context,
+	namespace ident.ID,
+	opts index.CardinalityOptions,
+) (index.CardinalityResult, error) {
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = index.DefaultCardinalityLimit
+	}
+	hostLimit := cardinalityHostLimit(limit)
+	opts.Limit = hostLimit
+
+	request, err := convert.ToRPCCardinalityRequest(namespace, opts)
+	if err != nil {
+		return index.CardinalityResult{}, xerrors.NewInvalidParamsError(err)
+	}
+
+	var wg sync.WaitGroup
+
+	s.state.RLock()
+	var (
+		level    = s.state.readConsistencyLevelWithRLock(nil)
+		topoMap  = s.state.topoMap
+		hosts    = make([]topology.Host, 0, len(s.state.queues))
+		results  = make([]cardinalityResult, len(s.state.queues))
+		majority = topoMap.MajorityReplicas()
+	)
+	for idx, queue := range s.state.queues {
+		idx := idx
+		hosts = append(hosts, queue.Host())
+
+		// NB: each result is only written by the completion of its own
+		// op and read once all of the ops have completed.
+		c := &cardinalityOp{request: request, context: ctx}
+		c.completionFn = func(result interface{}, err error) {
+			if err == nil {
+				res := result.(*rpc.CardinalityResult_)
+				results[idx].result = convert.FromRPCCardinalityResult(res)
+			}
+			results[idx].err = err
+			wg.Done()
+		}
+
+		wg.Add(1)
+		if err := queue.Enqueue(c); err != nil {
+			results[idx].err = err
+			wg.Done()
+		}
+	}
+	s.state.RUnlock()
+
+	// Wait for the cardinality of all hosts since each host only indexes
+	// the series of the shards it owns.
+	wg.Wait()
+
+	result, err := cardinalityConsistencyResult(level, majority, topoMap,
+		hosts, results, hostLimit, limit)
+	if err != nil {
+		s.log.Error("failed to compute cardinality", zap.Error(err))
+		return index.CardinalityResult{}, err
+	}
+	return result, nil
+}
+
+func (s *session) DeleteSeries(
+	ctx gocontext.Context,
+	namespace ident.ID,
+	q index.Query,
+	start, end xtime.UnixNano,
+) (int64, error) {
+	request, err := convert.ToRPCDeleteSeriesRequest(namespace, q, start, end)
+	if err != nil {
+		return 0, xerrors.NewInvalidParamsError(err)
+	}
+
+	var wg sync.WaitGroup
+
+	s.state.RLock()
+	var (
+		level    = s.state.writeLevel
+		topoMap  = s.state.topoMap
+		hosts    = make([]topology.Host, 0, len(s.state.queues))
+		results  = make([]deleteSeriesResult, len(s.state.queues))
+		majority = topoMap.MajorityReplicas()
+	)
+	for idx, queue := range s.state.queues {
+		idx := idx
+		hosts = append(hosts, queue.Host())
+
+		// NB: each result is only written by the completion of its own
+		// op and read once all of the ops have completed.
+		d := &deleteSeriesOp{request: request, context: ctx}
+		d.completionFn = func(result interface{}, err error) {
+			if err == nil {
+				results[idx].numSeries = result.(*rpc.DeleteSeriesResult_).NumSeries
+			}
+			results[idx].err = err
+			wg.Done()
+		}
+
+		wg.Add(1)
+		if err := queue.Enqueue(d); err != nil {
+			results[idx].err = err
+			wg.Done()
+		}
+	}
+	s.state.RUnlock()
+
+	// Wait for the series to be deleted on all replicas
+	wg.Wait()
+
+	deleted, err := deleteSeriesConsistencyResult(level, majority, topoMap, hosts, results)
+	if err != nil {
+		s.log.Error("failed to delete series", zap.Error(err))
+		return 0, err
+	}
+	return deleted, nil
+}
+
 // NB(r): Excluding maligned struct check here as we can
 // live with a few extra bytes since this struct is only
 // ever passed by stack, its much more readable not optimized
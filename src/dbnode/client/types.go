@@ -136,6 +136,29 @@ type Session interface {
 		opts index.AggregationOptions,
 	) (AggregatedTagsIterator, FetchResponseMetadata, error)
 
+	// Cardinality computes the cardinality of the series indexed by a namespace
+	// across all hosts. The series counts of the hosts are summed and divided
+	// by the number of replicas, and the distinct label value counts are the
+	// maximum of any host, so the result is an estimate when shards are not
+	// evenly replicated or the top lists of the hosts differ.
+	Cardinality(
+		ctx gocontext.Context,
+		namespace ident.ID,
+		opts index.CardinalityOptions,
+	) (index.CardinalityResult, error)
+
+	// DeleteSeries deletes the datapoints within the range of the series matching
+	// the query on all replicas, succeeding if the write consistency level is
+	// achieved for every shard. It returns the number of series deleted per
+	// replica. Deleted datapoints are hidden from reads immediately and removed
+	// from disk by the next cold flush.
+	DeleteSeries(
+		ctx gocontext.Context,
+		namespace ident.ID,
+		q index.Query,
+		start, end xtime.UnixNano,
+	) (int64, error)
+
 	// ShardID returns the given shard for an ID for callers
 	// to easily discern what shard is failing when operations
 	// for given IDs begin failing.
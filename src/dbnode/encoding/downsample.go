@@ -0,0 +1,167 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package encoding
+
+import (
+	"math"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/ts"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+// DownsampleType describes how the datapoints of each step window of a
+// downsampled read are consolidated into a single datapoint.
+type DownsampleType uint8
+
+const (
+	// DownsampleLast keeps the last datapoint of each step window.
+	DownsampleLast DownsampleType = iota
+	// DownsampleSum sums the values of each step window.
+	DownsampleSum
+	// DownsampleMin keeps the lowest value of each step window.
+	DownsampleMin
+	// DownsampleMax keeps the highest value of each step window.
+	DownsampleMax
+	// DownsampleCount counts the datapoints of each step window.
+	DownsampleCount
+)
+
+func (t DownsampleType) String() string {
+	switch t {
+	case DownsampleLast:
+		return "last"
+	case DownsampleSum:
+		return "sum"
+	case DownsampleMin:
+		return "min"
+	case DownsampleMax:
+		return "max"
+	case DownsampleCount:
+		return "count"
+	}
+	return "unknown"
+}
+
+type downsampleIterator struct {
+	iter           Iterator
+	step           time.Duration
+	downsampleType DownsampleType
+
+	curr           ts.Datapoint
+	currUnit       xtime.Unit
+	currAnnotation ts.Annotation
+
+	pending bool
+	done    bool
+}
+
+// NewDownsampleIterator returns an iterator that consolidates the datapoints
+// of the iterator into a single datapoint per step window. Step windows are
+// aligned to the Unix epoch and include their end, i.e. (k*step, (k+1)*step],
+// so that a datapoint at an evaluation timestamp that is a multiple of the
+// step is part of the window evaluated at that timestamp. Each window is
+// returned at the timestamp of its last datapoint which keeps the datapoints
+// within the range of the underlying iterator.
+func NewDownsampleIterator(
+	iter Iterator,
+	step time.Duration,
+	downsampleType DownsampleType,
+) Iterator {
+	return &downsampleIterator{
+		iter:           iter,
+		step:           step,
+		downsampleType: downsampleType,
+	}
+}
+
+func (it *downsampleIterator) Next() bool {
+	if it.done {
+		return false
+	}
+	if !it.pending && !it.iter.Next() {
+		it.done = true
+		return false
+	}
+	it.pending = false
+
+	dp, unit, annotation := it.iter.Current()
+	windowEnd := it.windowEnd(dp.TimestampNanos)
+	value := it.initialValue(dp.Value)
+	for {
+		it.curr.TimestampNanos = dp.TimestampNanos
+		it.currUnit = unit
+		it.currAnnotation = append(it.currAnnotation[:0], annotation...)
+		if !it.iter.Next() {
+			it.done = true
+			break
+		}
+		dp, unit, annotation = it.iter.Current()
+		if it.windowEnd(dp.TimestampNanos) != windowEnd {
+			it.pending = true
+			break
+		}
+		value = it.consolidate(value, dp.Value)
+	}
+	it.curr.Value = value
+	return true
+}
+
+func (it *downsampleIterator) windowEnd(t xtime.UnixNano) xtime.UnixNano {
+	end := t.Truncate(it.step)
+	if end != t {
+		end = end.Add(it.step)
+	}
+	return end
+}
+
+func (it *downsampleIterator) initialValue(v float64) float64 {
+	if it.downsampleType == DownsampleCount {
+		return 1
+	}
+	return v
+}
+
+func (it *downsampleIterator) consolidate(acc, v float64) float64 {
+	switch it.downsampleType {
+	case DownsampleSum:
+		return acc + v
+	case DownsampleMin:
+		return math.Min(acc, v)
+	case DownsampleMax:
+		return math.Max(acc, v)
+	case DownsampleCount:
+		return acc + 1
+	default:
+		return v
+	}
+}
+
+func (it *downsampleIterator) Current() (ts.Datapoint, xtime.Unit, ts.Annotation) {
+	return it.curr, it.currUnit, it.currAnnotation
+}
+
+func (it *downsampleIterator) Err() error {
+	return it.iter.Err()
+}
+
+func (it *downsampleIterator) Close() {
+	it.iter.Close()
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package encoding
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/dbnode/ts"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+func TestDownsampleIterator(t *testing.T) {
+	start := xtime.Now().Truncate(time.Hour)
+	values := []testValue{
+		{1, start.Add(10 * time.Second), xtime.Second, nil},
+		{4, start.Add(50 * time.Second), xtime.Second, nil},
+		{2, start.Add(time.Minute), xtime.Second, ts.Annotation("a")},
+		{7, start.Add(90 * time.Second), xtime.Second, nil},
+		{3, start.Add(5 * time.Minute), xtime.Second, ts.Annotation("b")},
+	}
+
+	tests := []struct {
+		downsampleType DownsampleType
+		expected       []float64
+	}{
+		{downsampleType: DownsampleLast, expected: []float64{2, 7, 3}},
+		{downsampleType: DownsampleSum, expected: []float64{7, 7, 3}},
+		{downsampleType: DownsampleMin, expected: []float64{1, 7, 3}},
+		{downsampleType: DownsampleMax, expected: []float64{4, 7, 3}},
+		{downsampleType: DownsampleCount, expected: []float64{3, 1, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.downsampleType.String(), func(t *testing.T) {
+			iter := NewDownsampleIterator(newTestIterator(values),
+				time.Minute, tt.downsampleType)
+			defer iter.Close()
+
+			var (
+				actual      []float64
+				timestamps  []xtime.UnixNano
+				annotations []string
+			)
+			for iter.Next() {
+				dp, unit, annotation := iter.Current()
+				assert.Equal(t, xtime.Second, unit)
+				actual = append(actual, dp.Value)
+				timestamps = append(timestamps, dp.TimestampNanos)
+				annotations = append(annotations, string(annotation))
+			}
+			require.NoError(t, iter.Err())
+			assert.False(t, iter.Next())
+
+			assert.Equal(t, tt.expected, actual)
+			assert.Equal(t, []xtime.UnixNano{
+				start.Add(time.Minute),
+				start.Add(90 * time.Second),
+				start.Add(5 * time.Minute),
+			}, timestamps)
+			assert.Equal(t, []string{"a", "", "b"}, annotations)
+		})
+	}
+}
+
+func TestDownsampleIteratorEmpty(t *testing.T) {
+	iter := NewDownsampleIterator(newTestIterator(nil), time.Minute, DownsampleLast)
+	assert.False(t, iter.Next())
+	require.NoError(t, iter.Err())
+}
@@ -0,0 +1,273 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package histogram
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/m3db/m3/src/dbnode/ts"
+)
+
+const (
+	annotationVersion = 1
+
+	flagIntegerCounts = 1 << 0
+
+	// maxIntegerCount is the largest count that is stored as an integer, any
+	// float above can not represent every integer exactly.
+	maxIntegerCount = 1 << 53
+)
+
+var (
+	// annotationPrefix starts every histogram annotation. The leading zero
+	// byte is never a valid protobuf field tag, so histogram annotations can
+	// not be mistaken for an annotation.Payload.
+	annotationPrefix = []byte{0x00, 'n', 'h'}
+
+	errAnnotationTooShort = errors.New("histogram annotation is truncated")
+	errNotAnnotation      = errors.New("annotation is not a histogram annotation")
+)
+
+// IsAnnotation returns true if the annotation holds a histogram.
+func IsAnnotation(annotation ts.Annotation) bool {
+	return bytes.HasPrefix(annotation, annotationPrefix)
+}
+
+// AppendAnnotation encodes the histogram and appends it to buf.
+//
+// Histograms with integer counts (the common case for Prometheus native
+// histograms) store the counts as varints and the bucket counts as deltas to
+// the previous bucket, otherwise all counts are stored as float64 values.
+func AppendAnnotation(buf []byte, h Histogram) ts.Annotation {
+	integer := h.hasIntegerCounts()
+
+	var flags byte
+	if integer {
+		flags |= flagIntegerCounts
+	}
+
+	buf = append(buf, annotationPrefix...)
+	buf = append(buf, annotationVersion, flags, byte(h.CounterResetHint))
+	buf = binary.AppendVarint(buf, int64(h.Schema))
+	buf = appendFloat(buf, h.ZeroThreshold)
+	buf = appendFloat(buf, h.Sum)
+	if integer {
+		buf = binary.AppendUvarint(buf, uint64(h.Count))
+		buf = binary.AppendUvarint(buf, uint64(h.ZeroCount))
+	} else {
+		buf = appendFloat(buf, h.Count)
+		buf = appendFloat(buf, h.ZeroCount)
+	}
+	buf = appendBuckets(buf, h.PositiveSpans, h.PositiveBuckets, integer)
+	buf = appendBuckets(buf, h.NegativeSpans, h.NegativeBuckets, integer)
+	return buf
+}
+
+func (h Histogram) hasIntegerCounts() bool {
+	if !isIntegerCount(h.Count) || !isIntegerCount(h.ZeroCount) {
+		return false
+	}
+	for _, count := range h.PositiveBuckets {
+		if !isIntegerCount(count) {
+			return false
+		}
+	}
+	for _, count := range h.NegativeBuckets {
+		if !isIntegerCount(count) {
+			return false
+		}
+	}
+	return true
+}
+
+func isIntegerCount(v float64) bool {
+	return v >= 0 && v <= maxIntegerCount && v == math.Trunc(v)
+}
+
+func appendFloat(buf []byte, v float64) []byte {
+	return binary.LittleEndian.AppendUint64(buf, math.Float64bits(v))
+}
+
+func appendBuckets(buf []byte, spans []Span, buckets []float64, integer bool) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(spans)))
+	for _, span := range spans {
+		buf = binary.AppendVarint(buf, int64(span.Offset))
+		buf = binary.AppendUvarint(buf, uint64(span.Length))
+	}
+
+	var prev int64
+	for _, count := range buckets {
+		if !integer {
+			buf = appendFloat(buf, count)
+			continue
+		}
+		curr := int64(count)
+		buf = binary.AppendVarint(buf, curr-prev)
+		prev = curr
+	}
+	return buf
+}
+
+// DecodeAnnotation decodes a histogram from an annotation written with
+// AppendAnnotation.
+func DecodeAnnotation(annotation ts.Annotation) (Histogram, error) {
+	if !IsAnnotation(annotation) {
+		return Histogram{}, errNotAnnotation
+	}
+
+	d := decoder{buf: annotation[len(annotationPrefix):]}
+	version := d.byte()
+	if d.err == nil && version != annotationVersion {
+		return Histogram{}, fmt.Errorf("unknown histogram annotation version: %d", version)
+	}
+
+	var (
+		flags   = d.byte()
+		integer = flags&flagIntegerCounts != 0
+		h       = Histogram{
+			CounterResetHint: CounterResetHint(d.byte()),
+			Schema:           int32(d.varint()),
+			ZeroThreshold:    d.float(),
+			Sum:              d.float(),
+		}
+	)
+	if integer {
+		h.Count = float64(d.uvarint())
+		h.ZeroCount = float64(d.uvarint())
+	} else {
+		h.Count = d.float()
+		h.ZeroCount = d.float()
+	}
+	h.PositiveSpans, h.PositiveBuckets = d.buckets(integer)
+	h.NegativeSpans, h.NegativeBuckets = d.buckets(integer)
+	if d.err != nil {
+		return Histogram{}, d.err
+	}
+	if err := h.Validate(); err != nil {
+		return Histogram{}, err
+	}
+	return h, nil
+}
+
+type decoder struct {
+	buf []byte
+	err error
+}
+
+func (d *decoder) byte() byte {
+	if d.err != nil {
+		return 0
+	}
+	if len(d.buf) == 0 {
+		d.err = errAnnotationTooShort
+		return 0
+	}
+	v := d.buf[0]
+	d.buf = d.buf[1:]
+	return v
+}
+
+func (d *decoder) varint() int64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(d.buf)
+	if n <= 0 {
+		d.err = errAnnotationTooShort
+		return 0
+	}
+	d.buf = d.buf[n:]
+	return v
+}
+
+func (d *decoder) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(d.buf)
+	if n <= 0 {
+		d.err = errAnnotationTooShort
+		return 0
+	}
+	d.buf = d.buf[n:]
+	return v
+}
+
+func (d *decoder) float() float64 {
+	if d.err != nil {
+		return 0
+	}
+	if len(d.buf) < 8 {
+		d.err = errAnnotationTooShort
+		return 0
+	}
+	v := math.Float64frombits(binary.LittleEndian.Uint64(d.buf))
+	d.buf = d.buf[8:]
+	return v
+}
+
+func (d *decoder) buckets(integer bool) ([]Span, []float64) {
+	numSpans := d.uvarint()
+	if d.err != nil || numSpans == 0 {
+		return nil, nil
+	}
+	if numSpans > uint64(len(d.buf)) {
+		// Each span takes at least two bytes.
+		d.err = errAnnotationTooShort
+		return nil, nil
+	}
+
+	var (
+		spans      = make([]Span, 0, numSpans)
+		numBuckets uint64
+	)
+	for i := uint64(0); i < numSpans; i++ {
+		span := Span{Offset: int32(d.varint()), Length: uint32(d.uvarint())}
+		numBuckets += uint64(span.Length)
+		spans = append(spans, span)
+	}
+	if d.err != nil {
+		return nil, nil
+	}
+	if numBuckets > uint64(len(d.buf)) {
+		// Each bucket takes at least one byte.
+		d.err = errAnnotationTooShort
+		return nil, nil
+	}
+
+	var (
+		buckets = make([]float64, 0, numBuckets)
+		curr    int64
+	)
+	for i := uint64(0); i < numBuckets; i++ {
+		if !integer {
+			buckets = append(buckets, d.float())
+			continue
+		}
+		curr += d.varint()
+		buckets = append(buckets, float64(curr))
+	}
+	return spans, buckets
+}
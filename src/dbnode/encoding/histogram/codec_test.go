@@ -0,0 +1,77 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package histogram
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/dbnode/generated/proto/annotation"
+)
+
+func TestAnnotationRoundTrip(t *testing.T) {
+	integer := newTestHistogram()
+	integer.CounterResetHint = NotCounterReset
+
+	float := newTestHistogram()
+	float.Count = 15.5
+	float.PositiveBuckets = []float64{3.5, 4, 5}
+
+	empty := Histogram{Schema: -2, Sum: 1.5}
+
+	for _, h := range []Histogram{integer, float, empty} {
+		encoded := AppendAnnotation(nil, h)
+		require.True(t, IsAnnotation(encoded))
+
+		decoded, err := DecodeAnnotation(encoded)
+		require.NoError(t, err)
+		assert.Equal(t, h, decoded)
+	}
+}
+
+func TestAnnotationIntegerCountsAreCompact(t *testing.T) {
+	integer := newTestHistogram()
+	float := newTestHistogram()
+	float.Sum = integer.Sum
+	float.PositiveBuckets = []float64{3, 4, 5.5}
+
+	assert.Less(t, len(AppendAnnotation(nil, integer)),
+		len(AppendAnnotation(nil, float)))
+}
+
+func TestDecodeAnnotationErrors(t *testing.T) {
+	encoded := AppendAnnotation(nil, newTestHistogram())
+	for i := 0; i < len(encoded); i++ {
+		_, err := DecodeAnnotation(encoded[:i])
+		assert.Error(t, err, "truncated at %d", i)
+	}
+
+	payload := annotation.Payload{
+		OpenMetricsFamilyType: annotation.OpenMetricsFamilyType_GAUGE,
+	}
+	encodedPayload, err := payload.Marshal()
+	require.NoError(t, err)
+	assert.False(t, IsAnnotation(encodedPayload))
+	_, err = DecodeAnnotation(encodedPayload)
+	assert.Error(t, err)
+}
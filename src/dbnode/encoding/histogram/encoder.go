@@ -0,0 +1,348 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package histogram
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/cespare/xxhash/v2"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3/src/x/checked"
+	"github.com/m3db/m3/src/x/context"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+// Streams written by the histogram encoder have the following layout:
+//
+//	streamPrefix | version | uvarint(len(inner)) | inner | records
+//
+// where inner is a regular m3tsz stream holding the timestamps and values
+// (the observation count for histogram samples) and records holds one
+// histogram record per datapoint of the inner stream. m3tsz streams start
+// with a non-negative start time in nanoseconds so their first byte is never
+// 0xFF, which lets readers tell the two formats apart and fall back to
+// m3tsz for streams written before the scheme was enabled. Streams without
+// any histogram are written as plain m3tsz streams so that the scheme only
+// changes the streams of the series holding histograms.
+const (
+	streamVersion = 1
+
+	// recordNone marks a datapoint without a histogram.
+	recordNone byte = 0
+	// recordFull holds a length prefixed histogram annotation.
+	recordFull byte = 1
+	// recordDelta holds a histogram with the same schema, zero threshold and
+	// bucket layout as the previous histogram of the stream, storing the sum
+	// and the varint deltas of every count to the previous histogram.
+	recordDelta byte = 2
+)
+
+var (
+	streamPrefix = []byte{0xFF, 'n', 'h'}
+
+	emptyAnnotationChecksum = xxhash.Sum64(nil)
+
+	errEncoderClosed       = errors.New("histogram encoder is closed")
+	errNoEncodedDatapoints = errors.New("histogram encoder has no encoded datapoints")
+)
+
+type encoder struct {
+	opts  encoding.Options
+	inner encoding.Encoder
+
+	records []byte
+	prev    Histogram
+	// hasPrev is also whether the stream holds a histogram, the records are
+	// only kept once it does.
+	hasPrev     bool
+	antChecksum uint64
+	closed      bool
+}
+
+// NewEncoder creates a new encoder which stores datapoints with m3tsz and
+// the histograms held in datapoint annotations in a dedicated column.
+func NewEncoder(
+	start xtime.UnixNano,
+	intOptimized bool,
+	opts encoding.Options,
+) encoding.Encoder {
+	if opts == nil {
+		opts = encoding.NewOptions()
+	}
+	// The inner encoder is owned by this encoder and must never be returned
+	// to the pool on close.
+	innerOpts := opts.SetEncoderPool(nil)
+	return &encoder{
+		opts:        opts,
+		inner:       m3tsz.NewEncoder(start, nil, intOptimized, innerOpts),
+		antChecksum: emptyAnnotationChecksum,
+	}
+}
+
+func (enc *encoder) SetSchema(descr namespace.SchemaDescr) {}
+
+// Encode encodes the datapoint, histogram annotations are moved to the
+// histogram records while any other annotation is kept in the m3tsz stream.
+func (enc *encoder) Encode(dp ts.Datapoint, tu xtime.Unit, ant ts.Annotation) error {
+	if enc.closed {
+		return errEncoderClosed
+	}
+
+	if !IsAnnotation(ant) {
+		if err := enc.inner.Encode(dp, tu, ant); err != nil {
+			return err
+		}
+		if enc.hasPrev {
+			enc.records = append(enc.records, recordNone)
+		}
+		enc.antChecksum = xxhash.Sum64(ant)
+		return nil
+	}
+
+	h, err := DecodeAnnotation(ant)
+	if err != nil {
+		return err
+	}
+	numEncoded := enc.inner.NumEncoded()
+	if err := enc.inner.Encode(dp, tu, nil); err != nil {
+		return err
+	}
+	if !enc.hasPrev {
+		// The datapoints encoded before the first histogram have no record.
+		for i := 0; i < numEncoded; i++ {
+			enc.records = append(enc.records, recordNone)
+		}
+	}
+	if enc.hasPrev && canDelta(enc.prev, h) {
+		enc.records = appendDeltaRecord(enc.records, enc.prev, h)
+	} else {
+		enc.records = append(enc.records, recordFull)
+		enc.records = binary.AppendUvarint(enc.records, uint64(len(ant)))
+		enc.records = append(enc.records, ant...)
+	}
+	enc.prev = h
+	enc.hasPrev = true
+	enc.antChecksum = xxhash.Sum64(ant)
+	return nil
+}
+
+// canDelta returns true if curr can be stored as a delta to prev.
+func canDelta(prev, curr Histogram) bool {
+	return prev.Schema == curr.Schema &&
+		prev.ZeroThreshold == curr.ZeroThreshold &&
+		prev.hasIntegerCounts() && curr.hasIntegerCounts() &&
+		spansEqual(prev.PositiveSpans, curr.PositiveSpans) &&
+		spansEqual(prev.NegativeSpans, curr.NegativeSpans)
+}
+
+func spansEqual(a, b []Span) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func appendDeltaRecord(buf []byte, prev, curr Histogram) []byte {
+	buf = append(buf, recordDelta, byte(curr.CounterResetHint))
+	buf = appendFloat(buf, curr.Sum)
+	buf = binary.AppendVarint(buf, int64(curr.Count)-int64(prev.Count))
+	buf = binary.AppendVarint(buf, int64(curr.ZeroCount)-int64(prev.ZeroCount))
+	for i, count := range curr.PositiveBuckets {
+		buf = binary.AppendVarint(buf, int64(count)-int64(prev.PositiveBuckets[i]))
+	}
+	for i, count := range curr.NegativeBuckets {
+		buf = binary.AppendVarint(buf, int64(count)-int64(prev.NegativeBuckets[i]))
+	}
+	return buf
+}
+
+func (enc *encoder) Stream(ctx context.Context) (xio.SegmentReader, bool) {
+	if !enc.hasPrev {
+		return enc.inner.Stream(ctx)
+	}
+
+	reader, ok := enc.inner.Stream(ctx)
+	if !ok {
+		return nil, false
+	}
+	inner, err := reader.Segment()
+	if err != nil {
+		reader.Finalize()
+		return nil, false
+	}
+	segment := enc.newStream(inner)
+	reader.Finalize()
+	if segment.Len() == 0 {
+		return nil, false
+	}
+	return enc.segmentReader(segment), true
+}
+
+func (enc *encoder) segmentReader(segment ts.Segment) xio.SegmentReader {
+	if readerPool := enc.opts.SegmentReaderPool(); readerPool != nil {
+		reader := readerPool.Get()
+		reader.Reset(segment)
+		return reader
+	}
+	return xio.NewSegmentReader(segment)
+}
+
+// newStream returns the stream made of the inner m3tsz segment and the
+// histogram records, the bytes of the stream are taken from the bytes pool
+// and returned to it when the segment is finalized.
+func (enc *encoder) newStream(inner ts.Segment) ts.Segment {
+	var head, tail []byte
+	if inner.Head != nil {
+		head = inner.Head.Bytes()
+	}
+	if inner.Tail != nil {
+		tail = inner.Tail.Bytes()
+	}
+	innerLen := len(head) + len(tail)
+	if innerLen == 0 {
+		return ts.Segment{}
+	}
+
+	var lenBuf [binary.MaxVarintLen64]byte
+	lenBytes := lenBuf[:binary.PutUvarint(lenBuf[:], uint64(innerLen))]
+
+	data := enc.newBuffer(len(streamPrefix) + 1 + len(lenBytes) + innerLen +
+		len(enc.records))
+	data.IncRef()
+	data.AppendAll(streamPrefix)
+	data.Append(streamVersion)
+	data.AppendAll(lenBytes)
+	data.AppendAll(head)
+	data.AppendAll(tail)
+	data.AppendAll(enc.records)
+	data.DecRef()
+	return ts.NewSegment(data, nil, 0, ts.FinalizeHead)
+}
+
+func (enc *encoder) newBuffer(capacity int) checked.Bytes {
+	if bytesPool := enc.opts.BytesPool(); bytesPool != nil {
+		return bytesPool.Get(capacity)
+	}
+	return checked.NewBytes(make([]byte, 0, capacity), nil)
+}
+
+func (enc *encoder) NumEncoded() int {
+	return enc.inner.NumEncoded()
+}
+
+func (enc *encoder) LastEncoded() (ts.Datapoint, error) {
+	return enc.inner.LastEncoded()
+}
+
+func (enc *encoder) LastAnnotationChecksum() (uint64, error) {
+	if enc.inner.NumEncoded() == 0 {
+		return 0, errNoEncodedDatapoints
+	}
+	return enc.antChecksum, nil
+}
+
+func (enc *encoder) Empty() bool {
+	return enc.inner.Empty()
+}
+
+// Len returns the length of the final data stream that would be generated
+// by a call to Stream().
+func (enc *encoder) Len() int {
+	innerLen := enc.inner.Len()
+	if innerLen == 0 || !enc.hasPrev {
+		return innerLen
+	}
+	var lenBuf [binary.MaxVarintLen64]byte
+	return len(streamPrefix) + 1 + binary.PutUvarint(lenBuf[:], uint64(innerLen)) +
+		innerLen + len(enc.records)
+}
+
+func (enc *encoder) Reset(
+	start xtime.UnixNano,
+	capacity int,
+	schema namespace.SchemaDescr,
+) {
+	enc.inner.Reset(start, capacity, schema)
+	enc.reset()
+}
+
+func (enc *encoder) reset() {
+	enc.records = enc.records[:0]
+	enc.prev = Histogram{}
+	enc.hasPrev = false
+	enc.antChecksum = emptyAnnotationChecksum
+	enc.closed = false
+}
+
+func (enc *encoder) Close() {
+	if enc.closed {
+		return
+	}
+
+	enc.closed = true
+	enc.inner.Close()
+	enc.records = enc.records[:0]
+
+	if pool := enc.opts.EncoderPool(); pool != nil {
+		pool.Put(enc)
+	}
+}
+
+// Discard closes the encoder and transfers ownership of the data stream to
+// the caller.
+func (enc *encoder) Discard() ts.Segment {
+	segment := enc.discard()
+	enc.Close()
+	return segment
+}
+
+// DiscardReset does the same thing as Discard except it does not close the
+// encoder but resets it for reuse.
+func (enc *encoder) DiscardReset(
+	start xtime.UnixNano,
+	capacity int,
+	schema namespace.SchemaDescr,
+) ts.Segment {
+	segment := enc.discard()
+	enc.Reset(start, capacity, schema)
+	return segment
+}
+
+func (enc *encoder) discard() ts.Segment {
+	inner := enc.inner.DiscardReset(0, 0, nil)
+	if !enc.hasPrev {
+		return inner
+	}
+	segment := enc.newStream(inner)
+	inner.Finalize()
+	return segment
+}
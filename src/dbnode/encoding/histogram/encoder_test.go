@@ -0,0 +1,251 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package histogram
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3/src/x/context"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+type testDatapoint struct {
+	dp  ts.Datapoint
+	ant ts.Annotation
+}
+
+func newTestDatapoints(start xtime.UnixNano) []testDatapoint {
+	first := newTestHistogram()
+	second := newTestHistogram()
+	second.Count = 18
+	second.Sum = 50
+	second.PositiveBuckets = []float64{4, 5, 6}
+	// A new bucket layout can not be stored as a delta.
+	third := newTestHistogram()
+	third.Count = 20
+	third.PositiveSpans = []Span{{Offset: 1, Length: 4}}
+	third.PositiveBuckets = []float64{4, 5, 6, 2}
+	float := newTestHistogram()
+	float.Count = 20.5
+	float.PositiveSpans = []Span{{Offset: 1, Length: 4}}
+	float.PositiveBuckets = []float64{4, 5, 6, 2.5}
+
+	return []testDatapoint{
+		{dp: ts.Datapoint{Value: 1}, ant: ts.Annotation("plain")},
+		{dp: ts.Datapoint{Value: first.Count}, ant: AppendAnnotation(nil, first)},
+		{dp: ts.Datapoint{Value: second.Count}, ant: AppendAnnotation(nil, second)},
+		{dp: ts.Datapoint{Value: third.Count}, ant: AppendAnnotation(nil, third)},
+		{dp: ts.Datapoint{Value: 2}, ant: ts.Annotation("other")},
+		{dp: ts.Datapoint{Value: float.Count}, ant: AppendAnnotation(nil, float)},
+	}
+}
+
+func encodeTestDatapoints(
+	t *testing.T,
+	enc encoding.Encoder,
+	start xtime.UnixNano,
+	dps []testDatapoint,
+) {
+	for i, dp := range dps {
+		dp.dp.TimestampNanos = start.Add(time.Duration(i) * time.Second)
+		require.NoError(t, enc.Encode(dp.dp, xtime.Second, dp.ant))
+	}
+}
+
+func requireTestDatapoints(
+	t *testing.T,
+	it encoding.ReaderIterator,
+	start xtime.UnixNano,
+	dps []testDatapoint,
+) {
+	for i, expected := range dps {
+		require.True(t, it.Next(), "datapoint %d: %v", i, it.Err())
+		dp, unit, ant := it.Current()
+		assert.Equal(t, xtime.Second, unit)
+		assert.Equal(t, start.Add(time.Duration(i)*time.Second), dp.TimestampNanos)
+		assert.Equal(t, expected.dp.Value, dp.Value)
+		assert.Equal(t, expected.ant, ant, "datapoint %d", i)
+	}
+	require.False(t, it.Next())
+	require.NoError(t, it.Err())
+}
+
+func TestEncoderRoundTrip(t *testing.T) {
+	var (
+		start = xtime.Now().Truncate(time.Hour)
+		dps   = newTestDatapoints(start)
+		enc   = NewEncoder(start, true, nil)
+		ctx   = context.NewBackground()
+	)
+	defer ctx.Close()
+
+	encodeTestDatapoints(t, enc, start, dps)
+	require.Equal(t, len(dps), enc.NumEncoded())
+
+	last, err := enc.LastEncoded()
+	require.NoError(t, err)
+	assert.Equal(t, start.Add(time.Duration(len(dps)-1)*time.Second), last.TimestampNanos)
+
+	reader, ok := enc.Stream(ctx)
+	require.True(t, ok)
+	data, err := xio.ToBytes(reader)
+	require.Equal(t, io.EOF, err)
+	assert.Equal(t, enc.Len(), len(data))
+
+	it := NewReaderIterator(xio.NewBytesReader64(data), true, nil)
+	requireTestDatapoints(t, it, start, dps)
+}
+
+func TestEncoderDeltaRecordsAreCompact(t *testing.T) {
+	var (
+		start  = xtime.Now().Truncate(time.Hour)
+		h      = newTestHistogram()
+		ant    = AppendAnnotation(nil, h)
+		enc    = NewEncoder(start, true, nil).(*encoder)
+		numDps = 10
+	)
+	for i := 0; i < numDps; i++ {
+		dp := ts.Datapoint{TimestampNanos: start.Add(time.Duration(i) * time.Second), Value: h.Count}
+		require.NoError(t, enc.Encode(dp, xtime.Second, ant))
+	}
+
+	// Only the first histogram is stored in full, every following one is
+	// stored as a delta of less than half the size.
+	fullRecordLen := 2 + len(ant)
+	assert.Less(t, len(enc.records)-fullRecordLen, (numDps-1)*len(ant)/2)
+}
+
+func TestReaderIteratorReadsM3TSZStreams(t *testing.T) {
+	var (
+		start = xtime.Now().Truncate(time.Hour)
+		dps   = []testDatapoint{
+			{dp: ts.Datapoint{Value: 1}, ant: ts.Annotation("plain")},
+			{dp: ts.Datapoint{Value: 2.5}, ant: ts.Annotation("other")},
+		}
+		enc = m3tsz.NewEncoder(start, nil, true, nil)
+		ctx = context.NewBackground()
+	)
+	defer ctx.Close()
+
+	encodeTestDatapoints(t, enc, start, dps)
+	reader, ok := enc.Stream(ctx)
+	require.True(t, ok)
+
+	it := NewReaderIterator(reader, true, nil)
+	requireTestDatapoints(t, it, start, dps)
+}
+
+func TestEncoderWritesM3TSZStreamsWithoutHistograms(t *testing.T) {
+	var (
+		start = xtime.Now().Truncate(time.Hour)
+		dps   = []testDatapoint{
+			{dp: ts.Datapoint{Value: 1}, ant: ts.Annotation("plain")},
+			{dp: ts.Datapoint{Value: 2.5}, ant: ts.Annotation("other")},
+		}
+		enc      = NewEncoder(start, true, nil)
+		expected = m3tsz.NewEncoder(start, nil, true, nil)
+		ctx      = context.NewBackground()
+	)
+	defer ctx.Close()
+
+	encodeTestDatapoints(t, enc, start, dps)
+	encodeTestDatapoints(t, expected, start, dps)
+	assert.Equal(t, expected.Len(), enc.Len())
+
+	reader, ok := enc.Stream(ctx)
+	require.True(t, ok)
+	data, err := xio.ToBytes(reader)
+	require.Equal(t, io.EOF, err)
+	expectedReader, ok := expected.Stream(ctx)
+	require.True(t, ok)
+	expectedData, err := xio.ToBytes(expectedReader)
+	require.Equal(t, io.EOF, err)
+
+	// Series without histograms are not changed by the histogram encoder.
+	assert.Equal(t, expectedData, data)
+	assert.False(t, isHistogramStream(xio.NewBytesReader64(data)))
+}
+
+func TestEncoderDiscardReset(t *testing.T) {
+	var (
+		start = xtime.Now().Truncate(time.Hour)
+		dps   = newTestDatapoints(start)
+		enc   = NewEncoder(start, true, nil)
+	)
+	encodeTestDatapoints(t, enc, start, dps)
+
+	segment := enc.DiscardReset(start, 0, nil)
+	require.True(t, enc.Empty())
+	require.Equal(t, 0, enc.Len())
+
+	it := NewReaderIterator(xio.NewSegmentReader(segment), true, nil)
+	requireTestDatapoints(t, it, start, dps)
+
+	// The encoder can be reused after the reset.
+	encodeTestDatapoints(t, enc, start, dps[:2])
+	segment = enc.Discard()
+	it.Reset(xio.NewSegmentReader(segment), nil)
+	requireTestDatapoints(t, it, start, dps[:2])
+}
+
+func TestReaderIteratorTruncatedStream(t *testing.T) {
+	var (
+		start = xtime.Now().Truncate(time.Hour)
+		dps   = newTestDatapoints(start)
+		enc   = NewEncoder(start, true, nil)
+		ctx   = context.NewBackground()
+	)
+	defer ctx.Close()
+
+	encodeTestDatapoints(t, enc, start, dps)
+	reader, ok := enc.Stream(ctx)
+	require.True(t, ok)
+	data, err := xio.ToBytes(reader)
+	require.Equal(t, io.EOF, err)
+
+	it := NewReaderIterator(xio.NewBytesReader64(data[:len(data)-4]), true, nil)
+	for it.Next() {
+	}
+	require.Error(t, it.Err())
+}
+
+func TestEncoderRejectsInvalidHistogram(t *testing.T) {
+	var (
+		start = xtime.Now().Truncate(time.Hour)
+		enc   = NewEncoder(start, true, nil)
+		h     = newTestHistogram()
+	)
+	h.Schema = MaxSchema + 1
+
+	err := enc.Encode(ts.Datapoint{TimestampNanos: start}, xtime.Second,
+		AppendAnnotation(nil, h))
+	require.Error(t, err)
+	require.Equal(t, 0, enc.NumEncoded())
+}
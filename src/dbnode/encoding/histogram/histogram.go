@@ -0,0 +1,224 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package histogram contains the storage representation of Prometheus native
+// histograms. Native histogram samples are written to m3tsz streams like any
+// other sample: the datapoint value holds the observation count so that
+// readers unaware of histograms still see a meaningful series, while the
+// datapoint annotation holds the full histogram encoded with this package.
+// When the histogram encoding scheme is enabled, the encoder of this package
+// moves the histograms out of the annotations into a dedicated column of the
+// stream and the iterator returns them as annotations again.
+package histogram
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// CounterResetHint is a hint about whether a counter reset happened between
+// a histogram sample and the previous sample of the same series.
+type CounterResetHint uint8
+
+const (
+	// UnknownCounterReset means a counter reset has to be detected explicitly.
+	UnknownCounterReset CounterResetHint = iota
+	// CounterReset means the sample is the first one after a counter reset.
+	CounterReset
+	// NotCounterReset means there was no counter reset since the previous sample.
+	NotCounterReset
+	// GaugeType means the histogram is a gauge histogram which never resets.
+	GaugeType
+)
+
+const (
+	// MinSchema is the lowest bucket schema supported.
+	MinSchema = -4
+	// MaxSchema is the highest bucket schema supported.
+	MaxSchema = 8
+)
+
+var errInvalidSchema = fmt.Errorf("histogram schema must be between %d and %d",
+	MinSchema, MaxSchema)
+
+// Span describes a number of consecutive buckets, offset from the end of the
+// previous span (or from bucket index zero for the first span).
+type Span struct {
+	Offset int32
+	Length uint32
+}
+
+// Histogram is a native histogram sample. Bucket counts are absolute (not
+// delta encoded) so that integer and float histograms share a representation.
+type Histogram struct {
+	CounterResetHint CounterResetHint
+	Schema           int32
+	ZeroThreshold    float64
+	ZeroCount        float64
+	Count            float64
+	Sum              float64
+	PositiveSpans    []Span
+	PositiveBuckets  []float64
+	NegativeSpans    []Span
+	NegativeBuckets  []float64
+}
+
+// Validate returns an error if the histogram is not well formed.
+func (h Histogram) Validate() error {
+	if h.Schema < MinSchema || h.Schema > MaxSchema {
+		return errInvalidSchema
+	}
+	if err := validateSpans(h.PositiveSpans, len(h.PositiveBuckets)); err != nil {
+		return fmt.Errorf("positive buckets: %w", err)
+	}
+	if err := validateSpans(h.NegativeSpans, len(h.NegativeBuckets)); err != nil {
+		return fmt.Errorf("negative buckets: %w", err)
+	}
+	if h.ZeroThreshold < 0 || math.IsNaN(h.ZeroThreshold) {
+		return errors.New("zero threshold must be a non-negative number")
+	}
+	return nil
+}
+
+func validateSpans(spans []Span, numBuckets int) error {
+	var total int
+	for i, span := range spans {
+		if i > 0 && span.Offset < 0 {
+			return fmt.Errorf("span %d has negative offset %d", i, span.Offset)
+		}
+		total += int(span.Length)
+	}
+	if total != numBuckets {
+		return fmt.Errorf("spans cover %d buckets but %d buckets present",
+			total, numBuckets)
+	}
+	return nil
+}
+
+// Bucket is a single histogram bucket with its bounds.
+type Bucket struct {
+	Lower float64
+	Upper float64
+	Count float64
+}
+
+// Buckets returns all populated buckets in ascending order of their bounds,
+// starting with the negative buckets, then the zero bucket (if it has a non
+// zero count) and finally the positive buckets.
+func (h Histogram) Buckets() []Bucket {
+	buckets := make([]Bucket, 0,
+		len(h.NegativeBuckets)+len(h.PositiveBuckets)+1)
+
+	// Negative bucket indexes are mirrored, the bucket with the highest index
+	// covers the values furthest away from zero.
+	negative := make([]Bucket, 0, len(h.NegativeBuckets))
+	forEachBucketIndex(h.NegativeSpans, h.NegativeBuckets, func(idx int32, count float64) {
+		negative = append(negative, Bucket{
+			Lower: -upperBound(idx, h.Schema),
+			Upper: -upperBound(idx-1, h.Schema),
+			Count: count,
+		})
+	})
+	for i := len(negative) - 1; i >= 0; i-- {
+		buckets = append(buckets, negative[i])
+	}
+
+	if h.ZeroCount != 0 {
+		buckets = append(buckets, Bucket{
+			Lower: -h.ZeroThreshold,
+			Upper: h.ZeroThreshold,
+			Count: h.ZeroCount,
+		})
+	}
+
+	forEachBucketIndex(h.PositiveSpans, h.PositiveBuckets, func(idx int32, count float64) {
+		buckets = append(buckets, Bucket{
+			Lower: upperBound(idx-1, h.Schema),
+			Upper: upperBound(idx, h.Schema),
+			Count: count,
+		})
+	})
+
+	return buckets
+}
+
+// UpperBounds returns the upper bounds of all populated buckets in ascending
+// order.
+func (h Histogram) UpperBounds() []float64 {
+	buckets := h.Buckets()
+	bounds := make([]float64, 0, len(buckets))
+	for _, b := range buckets {
+		bounds = append(bounds, b.Upper)
+	}
+	return bounds
+}
+
+// CumulativeCount returns the number of observations less than or equal to
+// the given upper bound, in the same way as a classic histogram "le" bucket.
+// Buckets that straddle the bound are not counted, and +Inf returns the total
+// observation count.
+func (h Histogram) CumulativeCount(le float64) float64 {
+	if math.IsInf(le, 1) {
+		return h.Count
+	}
+
+	var count float64
+	for _, b := range h.Buckets() {
+		if b.Upper > le && !almostEqual(b.Upper, le) {
+			break
+		}
+		count += b.Count
+	}
+	return count
+}
+
+func forEachBucketIndex(spans []Span, buckets []float64, fn func(idx int32, count float64)) {
+	var (
+		idx int32
+		i   int
+	)
+	for _, span := range spans {
+		idx += span.Offset
+		for j := uint32(0); j < span.Length && i < len(buckets); j++ {
+			fn(idx, buckets[i])
+			idx++
+			i++
+		}
+	}
+}
+
+// upperBound returns the upper bound of the positive bucket with the given
+// index, each power of two is divided into 2^schema buckets.
+func upperBound(idx, schema int32) float64 {
+	if schema <= 0 {
+		return math.Ldexp(1, int(idx)<<uint(-schema))
+	}
+	return math.Exp2(float64(idx) / float64(int32(1)<<uint(schema)))
+}
+
+func almostEqual(a, b float64) bool {
+	const epsilon = 1e-12
+	if a == b {
+		return true
+	}
+	diff := math.Abs(a - b)
+	return diff <= epsilon*math.Max(math.Abs(a), math.Abs(b))
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package histogram
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestHistogram() Histogram {
+	// Schema 0 has bucket boundaries at powers of two, these buckets are:
+	// (-4,-2]: 1, (-1,1]: 2 (zero bucket), (1,2]: 3, (2,4]: 4, (8,16]: 5.
+	return Histogram{
+		Schema:          0,
+		ZeroThreshold:   1,
+		ZeroCount:       2,
+		Count:           15,
+		Sum:             42.5,
+		PositiveSpans:   []Span{{Offset: 1, Length: 2}, {Offset: 1, Length: 1}},
+		PositiveBuckets: []float64{3, 4, 5},
+		NegativeSpans:   []Span{{Offset: 2, Length: 1}},
+		NegativeBuckets: []float64{1},
+	}
+}
+
+func TestHistogramBuckets(t *testing.T) {
+	h := newTestHistogram()
+	require.NoError(t, h.Validate())
+
+	assert.Equal(t, []Bucket{
+		{Lower: -4, Upper: -2, Count: 1},
+		{Lower: -1, Upper: 1, Count: 2},
+		{Lower: 1, Upper: 2, Count: 3},
+		{Lower: 2, Upper: 4, Count: 4},
+		{Lower: 8, Upper: 16, Count: 5},
+	}, h.Buckets())
+	assert.Equal(t, []float64{-2, 1, 2, 4, 16}, h.UpperBounds())
+}
+
+func TestHistogramBucketsSchemas(t *testing.T) {
+	h := Histogram{
+		Schema:          2,
+		PositiveSpans:   []Span{{Offset: 0, Length: 2}},
+		PositiveBuckets: []float64{1, 1},
+	}
+	bounds := h.UpperBounds()
+	require.Len(t, bounds, 2)
+	assert.Equal(t, 1.0, bounds[0])
+	assert.InDelta(t, math.Pow(2, 0.25), bounds[1], 1e-12)
+
+	h.Schema = -1
+	assert.Equal(t, []float64{1, 4}, h.UpperBounds())
+}
+
+func TestHistogramCumulativeCount(t *testing.T) {
+	h := newTestHistogram()
+
+	tests := []struct {
+		le       float64
+		expected float64
+	}{
+		{le: -5, expected: 0},
+		{le: -2, expected: 1},
+		{le: 1, expected: 3},
+		{le: 3, expected: 6},
+		{le: 4, expected: 10},
+		{le: 10, expected: 10},
+		{le: 16, expected: 15},
+		{le: math.Inf(1), expected: 15},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, h.CumulativeCount(tt.le), "le=%v", tt.le)
+	}
+}
+
+func TestHistogramValidate(t *testing.T) {
+	h := newTestHistogram()
+	h.Schema = MaxSchema + 1
+	assert.Error(t, h.Validate())
+
+	h = newTestHistogram()
+	h.PositiveBuckets = h.PositiveBuckets[1:]
+	assert.Error(t, h.Validate())
+
+	h = newTestHistogram()
+	h.NegativeSpans = append(h.NegativeSpans, Span{Offset: -1, Length: 0})
+	assert.Error(t, h.Validate())
+
+	h = newTestHistogram()
+	h.ZeroThreshold = -1
+	assert.Error(t, h.Validate())
+}
@@ -0,0 +1,280 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package histogram
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+var (
+	errIteratorClosed  = errors.New("histogram iterator is closed")
+	errStreamTruncated = errors.New("histogram stream is truncated")
+)
+
+// DefaultReaderIteratorAllocFn returns a function for allocating reader
+// iterators which read both histogram and plain m3tsz streams.
+func DefaultReaderIteratorAllocFn(
+	opts encoding.Options,
+) func(r xio.Reader64, _ namespace.SchemaDescr) encoding.ReaderIterator {
+	return func(r xio.Reader64, _ namespace.SchemaDescr) encoding.ReaderIterator {
+		return NewReaderIterator(r, m3tsz.DefaultIntOptimizationEnabled, opts)
+	}
+}
+
+type readerIterator struct {
+	opts  encoding.Options
+	inner encoding.ReaderIterator
+
+	// data holds the stream when it was written by the histogram encoder,
+	// records is the unread part of its histogram records.
+	data    []byte
+	records decoder
+	native  bool
+
+	curr    Histogram
+	hasCurr bool
+	prev    Histogram
+	hasPrev bool
+	ant     ts.Annotation
+	err     error
+	closed  bool
+}
+
+// NewReaderIterator returns a new iterator for a given reader. Streams which
+// were not written by the histogram encoder are read as m3tsz streams.
+func NewReaderIterator(
+	reader xio.Reader64,
+	intOptimized bool,
+	opts encoding.Options,
+) encoding.ReaderIterator {
+	if opts == nil {
+		opts = encoding.NewOptions()
+	}
+	// The inner iterator is owned by this iterator and must never be
+	// returned to the pool on close.
+	innerOpts := opts.SetReaderIteratorPool(nil)
+	it := &readerIterator{
+		opts:  opts,
+		inner: m3tsz.NewReaderIterator(nil, intOptimized, innerOpts),
+	}
+	it.Reset(reader, nil)
+	return it
+}
+
+func (it *readerIterator) Next() bool {
+	if it.closed || it.err != nil {
+		return false
+	}
+	if !it.inner.Next() {
+		return false
+	}
+	if !it.native {
+		return true
+	}
+
+	it.hasCurr = false
+	if err := it.readRecord(); err != nil {
+		it.err = err
+		return false
+	}
+	return true
+}
+
+func (it *readerIterator) readRecord() error {
+	switch flag := it.records.byte(); flag {
+	case recordNone:
+	case recordFull:
+		n := it.records.uvarint()
+		if it.records.err != nil {
+			return it.records.err
+		}
+		if n > uint64(len(it.records.buf)) {
+			return errStreamTruncated
+		}
+		h, err := DecodeAnnotation(it.records.buf[:n])
+		if err != nil {
+			return err
+		}
+		it.records.buf = it.records.buf[n:]
+		it.setCurr(h)
+	case recordDelta:
+		if !it.hasPrev {
+			return errors.New("histogram delta record without a previous histogram")
+		}
+		h := it.readDelta()
+		if it.records.err != nil {
+			return it.records.err
+		}
+		it.setCurr(h)
+	default:
+		if it.records.err != nil {
+			return it.records.err
+		}
+		return fmt.Errorf("unknown histogram record: %d", flag)
+	}
+	return it.records.err
+}
+
+func (it *readerIterator) readDelta() Histogram {
+	var (
+		prev = it.prev
+		h    = Histogram{
+			CounterResetHint: CounterResetHint(it.records.byte()),
+			Schema:           prev.Schema,
+			ZeroThreshold:    prev.ZeroThreshold,
+			Sum:              it.records.float(),
+			PositiveSpans:    prev.PositiveSpans,
+			NegativeSpans:    prev.NegativeSpans,
+		}
+	)
+	h.Count = prev.Count + float64(it.records.varint())
+	h.ZeroCount = prev.ZeroCount + float64(it.records.varint())
+	h.PositiveBuckets = it.readBucketDeltas(prev.PositiveBuckets)
+	h.NegativeBuckets = it.readBucketDeltas(prev.NegativeBuckets)
+	return h
+}
+
+func (it *readerIterator) readBucketDeltas(prev []float64) []float64 {
+	if len(prev) == 0 {
+		return nil
+	}
+	buckets := make([]float64, 0, len(prev))
+	for _, count := range prev {
+		buckets = append(buckets, count+float64(it.records.varint()))
+	}
+	return buckets
+}
+
+func (it *readerIterator) setCurr(h Histogram) {
+	it.curr = h
+	it.hasCurr = true
+	it.prev = h
+	it.hasPrev = true
+	it.ant = AppendAnnotation(it.ant[:0], h)
+}
+
+// Current returns the value as well as the annotation associated with the
+// current datapoint, histograms are returned as histogram annotations.
+// Users should not hold on to the returned Annotation object as it may get
+// invalidated when the iterator calls Next().
+func (it *readerIterator) Current() (ts.Datapoint, xtime.Unit, ts.Annotation) {
+	dp, unit, ant := it.inner.Current()
+	if it.hasCurr {
+		ant = it.ant
+	}
+	return dp, unit, ant
+}
+
+func (it *readerIterator) Err() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.inner.Err()
+}
+
+func (it *readerIterator) Reset(reader xio.Reader64, schema namespace.SchemaDescr) {
+	it.data = it.data[:0]
+	it.records = decoder{}
+	it.native = false
+	it.curr = Histogram{}
+	it.hasCurr = false
+	it.prev = Histogram{}
+	it.hasPrev = false
+	it.err = nil
+	it.closed = false
+
+	if reader == nil || !isHistogramStream(reader) {
+		it.inner.Reset(reader, schema)
+		return
+	}
+
+	it.native = true
+	if it.data, it.err = readAll(it.data, reader); it.err != nil {
+		return
+	}
+	d := decoder{buf: it.data[len(streamPrefix):]}
+	if version := d.byte(); d.err == nil && version != streamVersion {
+		it.err = fmt.Errorf("unknown histogram stream version: %d", version)
+		return
+	}
+	innerLen := d.uvarint()
+	if d.err != nil || innerLen > uint64(len(d.buf)) {
+		it.err = errStreamTruncated
+		return
+	}
+	it.inner.Reset(xio.NewBytesReader64(d.buf[:innerLen]), schema)
+	it.records = decoder{buf: d.buf[innerLen:]}
+}
+
+// IsStream returns true if the stream was written by the histogram encoder,
+// which is only the case for streams holding a histogram.
+func IsStream(data []byte) bool {
+	return bytes.HasPrefix(data, streamPrefix)
+}
+
+func isHistogramStream(reader xio.Reader64) bool {
+	word, n, err := reader.Peek64()
+	if err != nil || int(n) < len(streamPrefix) {
+		return false
+	}
+	var prefix [8]byte
+	binary.BigEndian.PutUint64(prefix[:], word)
+	return bytes.Equal(prefix[:len(streamPrefix)], streamPrefix)
+}
+
+func readAll(buf []byte, reader xio.Reader64) ([]byte, error) {
+	var word [8]byte
+	for {
+		v, n, err := reader.Read64()
+		if err == io.EOF {
+			return buf, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		binary.BigEndian.PutUint64(word[:], v)
+		buf = append(buf, word[:n]...)
+	}
+}
+
+func (it *readerIterator) Close() {
+	if it.closed {
+		return
+	}
+
+	it.closed = true
+	it.err = errIteratorClosed
+	it.inner.Close()
+	if pool := it.opts.ReaderIteratorPool(); pool != nil {
+		pool.Put(it)
+	}
+}
@@ -93,6 +93,34 @@ func (x StagingStatus) String() string {
 }
 func (StagingStatus) EnumDescriptor() ([]byte, []int) { return fileDescriptorNamespace, []int{0} }
 
+// FileSetCompression is the compression applied to the data filesets of the namespace.
+type FileSetCompression int32
+
+const (
+	// Filesets are not compressed.
+	FileSetCompression_NONE FileSetCompression = 0
+	// Filesets are compressed with snappy.
+	FileSetCompression_SNAPPY FileSetCompression = 1
+	// Filesets are compressed with zstd.
+	FileSetCompression_ZSTD FileSetCompression = 2
+)
+
+var FileSetCompression_name = map[int32]string{
+	0: "NONE",
+	1: "SNAPPY",
+	2: "ZSTD",
+}
+var FileSetCompression_value = map[string]int32{
+	"NONE":   0,
+	"SNAPPY": 1,
+	"ZSTD":   2,
+}
+
+func (x FileSetCompression) String() string {
+	return proto.EnumName(FileSetCompression_name, int32(x))
+}
+func (FileSetCompression) EnumDescriptor() ([]byte, []int) { return fileDescriptorNamespace, []int{1} }
+
 type RetentionOptions struct {
 	RetentionPeriodNanos                     int64 `protobuf:"varint,1,opt,name=retentionPeriodNanos,proto3" json:"retentionPeriodNanos,omitempty"`
 	BlockSizeNanos                           int64 `protobuf:"varint,2,opt,name=blockSizeNanos,proto3" json:"blockSizeNanos,omitempty"`
@@ -196,6 +224,8 @@ type NamespaceOptions struct {
 	CacheBlocksOnRetrieve *google_protobuf1.BoolValue `protobuf:"bytes,12,opt,name=cacheBlocksOnRetrieve" json:"cacheBlocksOnRetrieve,omitempty"`
 	AggregationOptions    *AggregationOptions         `protobuf:"bytes,13,opt,name=aggregationOptions" json:"aggregationOptions,omitempty"`
 	StagingState          *StagingState               `protobuf:"bytes,14,opt,name=stagingState" json:"stagingState,omitempty"`
+	ColdTierAgeNanos      int64                       `protobuf:"varint,15,opt,name=coldTierAgeNanos,proto3" json:"coldTierAgeNanos,omitempty"`
+	FilesetCompression    FileSetCompression          `protobuf:"varint,16,opt,name=filesetCompression,proto3,enum=namespace.FileSetCompression" json:"filesetCompression,omitempty"`
 	// Use larger field ID to ensure new fields are always added before extended options.
 	ExtendedOptions *ExtendedOptions `protobuf:"bytes,1000,opt,name=extendedOptions" json:"extendedOptions,omitempty"`
 }
@@ -303,6 +333,20 @@ func (m *NamespaceOptions) GetStagingState() *StagingState {
 	return nil
 }
 
+func (m *NamespaceOptions) GetColdTierAgeNanos() int64 {
+	if m != nil {
+		return m.ColdTierAgeNanos
+	}
+	return 0
+}
+
+func (m *NamespaceOptions) GetFilesetCompression() FileSetCompression {
+	if m != nil {
+		return m.FilesetCompression
+	}
+	return FileSetCompression_NONE
+}
+
 func (m *NamespaceOptions) GetExtendedOptions() *ExtendedOptions {
 	if m != nil {
 		return m.ExtendedOptions
@@ -500,6 +544,7 @@ func init() {
 	proto.RegisterType((*NamespaceRuntimeOptions)(nil), "namespace.NamespaceRuntimeOptions")
 	proto.RegisterType((*ExtendedOptions)(nil), "namespace.ExtendedOptions")
 	proto.RegisterEnum("namespace.StagingStatus", StagingStatus_name, StagingStatus_value)
+	proto.RegisterEnum("namespace.FileSetCompression", FileSetCompression_name, FileSetCompression_value)
 }
 func (m *RetentionOptions) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
@@ -747,6 +792,18 @@ func (m *NamespaceOptions) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n7
 	}
+	if m.ColdTierAgeNanos != 0 {
+		dAtA[i] = 0x78
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(m.ColdTierAgeNanos))
+	}
+	if m.FilesetCompression != 0 {
+		dAtA[i] = 0x80
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(m.FilesetCompression))
+	}
 	if m.ExtendedOptions != nil {
 		dAtA[i] = 0xc2
 		i++
@@ -1132,6 +1189,12 @@ func (m *NamespaceOptions) Size() (n int) {
 		l = m.StagingState.Size()
 		n += 1 + l + sovNamespace(uint64(l))
 	}
+	if m.ColdTierAgeNanos != 0 {
+		n += 1 + sovNamespace(uint64(m.ColdTierAgeNanos))
+	}
+	if m.FilesetCompression != 0 {
+		n += 2 + sovNamespace(uint64(m.FilesetCompression))
+	}
 	if m.ExtendedOptions != nil {
 		l = m.ExtendedOptions.Size()
 		n += 2 + l + sovNamespace(uint64(l))
@@ -1928,6 +1991,44 @@ func (m *NamespaceOptions) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ColdTierAgeNanos", wireType)
+			}
+			m.ColdTierAgeNanos = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ColdTierAgeNanos |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 16:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FilesetCompression", wireType)
+			}
+			m.FilesetCompression = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FilesetCompression |= (FileSetCompression(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		case 1000:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field ExtendedOptions", wireType)
@@ -2918,68 +3019,72 @@ func init() {
 }
 
 var fileDescriptorNamespace = []byte{
-	// 1001 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x96, 0xdd, 0x6e, 0x1b, 0x45,
-	0x14, 0x80, 0xbb, 0xf9, 0x73, 0x72, 0xec, 0x24, 0xce, 0xa8, 0x10, 0x2b, 0x2d, 0xa6, 0x5a, 0x7e,
-	0x14, 0x55, 0xc8, 0xa6, 0xc9, 0x0d, 0x14, 0xa9, 0xe0, 0x24, 0x26, 0x72, 0x29, 0x8e, 0x35, 0x69,
-	0x29, 0xe4, 0x6e, 0x76, 0xf7, 0x78, 0xb3, 0xea, 0x7a, 0x66, 0x35, 0x33, 0xdb, 0x24, 0x3c, 0x43,
-	0x2f, 0x78, 0x0f, 0x5e, 0x84, 0x4b, 0x1e, 0x01, 0x05, 0x21, 0x71, 0xc3, 0x3b, 0xa0, 0x9d, 0xf5,
-	0x3a, 0xfb, 0xe3, 0x96, 0x88, 0x9b, 0x68, 0x72, 0xce, 0x77, 0x7e, 0xe6, 0xfc, 0xcc, 0x1a, 0x8e,
-	0xfd, 0x40, 0x9f, 0xc7, 0x4e, 0xc7, 0x15, 0x93, 0xee, 0x64, 0xdf, 0x73, 0xba, 0x93, 0xfd, 0xae,
-	0x92, 0x6e, 0xd7, 0x73, 0xb8, 0xf0, 0xb0, 0xeb, 0x23, 0x47, 0xc9, 0x34, 0x7a, 0xdd, 0x48, 0x0a,
-	0x2d, 0xba, 0x9c, 0x4d, 0x50, 0x45, 0xcc, 0xc5, 0x9b, 0x53, 0xc7, 0x68, 0xc8, 0xda, 0x4c, 0xb0,
-	0x73, 0xdf, 0x17, 0xc2, 0x0f, 0x31, 0x35, 0x71, 0xe2, 0x71, 0x57, 0x69, 0x19, 0xbb, 0x3a, 0x05,
-	0x77, 0xda, 0x65, 0xed, 0x85, 0x64, 0x51, 0x84, 0x52, 0x4d, 0xf5, 0x47, 0xff, 0x37, 0x23, 0xe5,
-	0x9e, 0xe3, 0x84, 0xa5, 0x5e, 0xec, 0x37, 0x8b, 0xd0, 0xa4, 0xa8, 0x91, 0xeb, 0x40, 0xf0, 0x93,
-	0x28, 0xf9, 0xab, 0xc8, 0x1e, 0xdc, 0x95, 0x99, 0x6c, 0x84, 0x32, 0x10, 0xde, 0x90, 0x71, 0xa1,
-	0x5a, 0xd6, 0x03, 0x6b, 0x77, 0x91, 0xce, 0xd5, 0x91, 0x4f, 0x61, 0xc3, 0x09, 0x85, 0xfb, 0xea,
-	0x34, 0xf8, 0x19, 0x53, 0x7a, 0xc1, 0xd0, 0x25, 0x29, 0xf9, 0x0c, 0xb6, 0x9c, 0x78, 0x3c, 0x46,
-	0xf9, 0x6d, 0xac, 0x63, 0x39, 0x45, 0x17, 0x0d, 0x5a, 0x55, 0x90, 0x5d, 0xd8, 0x4c, 0x85, 0x23,
-	0xa6, 0x74, 0xca, 0x2e, 0x19, 0xb6, 0x2c, 0x36, 0x64, 0x12, 0xe9, 0x88, 0x69, 0xd6, 0xbf, 0x8c,
-	0x02, 0x79, 0xd5, 0x5a, 0x7e, 0x60, 0xed, 0xae, 0xd2, 0xb2, 0x98, 0x9c, 0xc1, 0x6e, 0x49, 0xd4,
-	0x1b, 0x6b, 0x94, 0x43, 0xa1, 0x7b, 0xae, 0x8b, 0x4a, 0xe5, 0x6f, 0xbc, 0x62, 0x82, 0xdd, 0x9a,
-	0x27, 0x4f, 0x60, 0x67, 0x6c, 0xd2, 0xa7, 0xf3, 0xea, 0x57, 0x33, 0xde, 0xde, 0x41, 0xd8, 0x23,
-	0x68, 0x0c, 0xb8, 0x87, 0x97, 0x59, 0x27, 0x5a, 0x50, 0x43, 0xce, 0x9c, 0x10, 0x3d, 0x53, 0xfc,
-	0x55, 0x9a, 0xfd, 0x7b, 0xdb, 0x7a, 0xdb, 0xff, 0xac, 0x40, 0x73, 0x98, 0xf5, 0x3e, 0x73, 0xfb,
-	0x10, 0x9a, 0x8e, 0x10, 0x5a, 0x69, 0xc9, 0xa2, 0x7e, 0xc1, 0x7f, 0x45, 0x4e, 0x6c, 0x68, 0x8c,
-	0xc3, 0x58, 0x9d, 0x67, 0xdc, 0x82, 0xe1, 0x0a, 0xb2, 0xa4, 0xa9, 0x17, 0x32, 0xd0, 0xa8, 0x9e,
-	0x8b, 0x43, 0x31, 0x99, 0x04, 0xfa, 0x99, 0xf0, 0x4d, 0x53, 0x57, 0x69, 0x55, 0x91, 0xa4, 0xee,
-	0x86, 0xc8, 0x78, 0x3c, 0x8b, 0xbd, 0x64, 0xd0, 0x92, 0x94, 0x7c, 0x0c, 0xeb, 0x12, 0x23, 0x16,
-	0xc8, 0x0c, 0x4b, 0x1b, 0x5a, 0x14, 0x92, 0x63, 0x68, 0xca, 0xd2, 0x00, 0x9b, 0xb6, 0xd5, 0xf7,
-	0xee, 0x75, 0x6e, 0x96, 0xaf, 0x3c, 0xe3, 0xb4, 0x62, 0x94, 0x4c, 0x90, 0xe2, 0x2c, 0x52, 0xe7,
-	0x42, 0x67, 0x01, 0x6b, 0xe9, 0x04, 0x95, 0xc4, 0xe4, 0x2b, 0x68, 0x04, 0xb9, 0x2e, 0xb5, 0x56,
-	0x4d, 0xb8, 0xed, 0x5c, 0xb8, 0x7c, 0x13, 0x69, 0x01, 0x26, 0x4f, 0x60, 0x3d, 0xdd, 0xc0, 0xcc,
-	0x7a, 0xcd, 0x58, 0xb7, 0x72, 0xd6, 0xa7, 0x79, 0x3d, 0x2d, 0xe2, 0x49, 0xad, 0x5d, 0x11, 0x7a,
-	0x2f, 0x4d, 0x59, 0xb3, 0x44, 0x21, 0xad, 0x75, 0x45, 0x41, 0x9e, 0xc2, 0x86, 0x8c, 0xb9, 0x0e,
-	0x26, 0x59, 0xef, 0x5b, 0x75, 0x13, 0xce, 0xce, 0x85, 0x9b, 0x8d, 0x07, 0x2d, 0x90, 0xb4, 0x64,
-	0x49, 0x46, 0xf0, 0x9e, 0xcb, 0xdc, 0x73, 0x3c, 0x48, 0x26, 0x4c, 0x9d, 0x70, 0x8a, 0x5a, 0x06,
-	0xf8, 0x1a, 0x5b, 0x0d, 0xe3, 0x72, 0xa7, 0x93, 0xbe, 0x58, 0x9d, 0xec, 0xc5, 0xea, 0x1c, 0x08,
-	0x11, 0xfe, 0xc0, 0xc2, 0x18, 0xe9, 0x7c, 0x43, 0xf2, 0x3d, 0x10, 0xe6, 0xfb, 0x12, 0x7d, 0x96,
-	0xef, 0xde, 0xba, 0x71, 0xf7, 0x41, 0x2e, 0xc3, 0x5e, 0x05, 0xa2, 0x73, 0x0c, 0x93, 0xbe, 0x28,
-	0xcd, 0xfc, 0x80, 0xfb, 0xa7, 0x9a, 0x69, 0x6c, 0x6d, 0x54, 0xfa, 0x72, 0x9a, 0x53, 0xd3, 0x02,
-	0x4c, 0xfa, 0xb0, 0x89, 0x97, 0x1a, 0xb9, 0x87, 0x5e, 0x96, 0xc8, 0xdf, 0xb5, 0xe9, 0xc5, 0x6e,
-	0x1c, 0xf4, 0x8b, 0x08, 0x2d, 0xdb, 0xd8, 0x23, 0x20, 0xd5, 0x6c, 0xc9, 0x63, 0x68, 0xe4, 0xf2,
-	0x4d, 0x5e, 0xd2, 0xc5, 0xdd, 0xfa, 0xde, 0xfb, 0xf3, 0xaf, 0x48, 0x0b, 0xac, 0xcd, 0xa1, 0x9e,
-	0x53, 0x92, 0x36, 0x40, 0xa6, 0x9e, 0x6d, 0x6d, 0x4e, 0x42, 0xbe, 0x06, 0x60, 0x5a, 0xcb, 0xc0,
-	0x89, 0x35, 0xa6, 0x8f, 0x42, 0x7d, 0xef, 0xc3, 0x39, 0x81, 0xd0, 0xeb, 0xcd, 0x30, 0x9a, 0x33,
-	0xb1, 0xdf, 0x58, 0x70, 0x77, 0x1e, 0x94, 0x2c, 0x88, 0x44, 0x25, 0xc2, 0x38, 0xc9, 0x23, 0xff,
-	0x45, 0x28, 0x8b, 0xc9, 0x53, 0xd8, 0xf2, 0xc4, 0x05, 0x57, 0x6c, 0x12, 0x85, 0xb3, 0xc1, 0x4b,
-	0x53, 0xb9, 0x9f, 0x4b, 0xe5, 0xa8, 0xcc, 0xd0, 0xaa, 0x99, 0xfd, 0x09, 0x6c, 0x55, 0x38, 0xd2,
-	0x84, 0x45, 0x16, 0x86, 0xd3, 0xdb, 0x27, 0x47, 0xfb, 0x1b, 0x68, 0xe4, 0x9b, 0x4b, 0x3e, 0x87,
-	0x15, 0xa5, 0x99, 0x8e, 0xd3, 0x1c, 0x37, 0x8a, 0xfb, 0x75, 0x03, 0xc6, 0x8a, 0x4e, 0x39, 0xfb,
-	0x57, 0x0b, 0x56, 0x29, 0xfa, 0x81, 0xd2, 0xf2, 0x8a, 0x1c, 0x02, 0xcc, 0xf8, 0xac, 0x5d, 0x1f,
-	0x15, 0xde, 0x93, 0x14, 0xbc, 0x59, 0x1e, 0xd5, 0xe7, 0x5a, 0x5e, 0xd1, 0x9c, 0xd9, 0xce, 0x19,
-	0x6c, 0x96, 0xd4, 0x49, 0xe2, 0xaf, 0xf0, 0xca, 0xe4, 0xb4, 0x46, 0x93, 0x23, 0x79, 0x04, 0xcb,
-	0xaf, 0x93, 0x1d, 0x99, 0xd6, 0xe7, 0xde, 0xbc, 0xc5, 0xcc, 0xca, 0x93, 0x92, 0x8f, 0x17, 0xbe,
-	0xb0, 0xec, 0xbf, 0x2c, 0xd8, 0x7e, 0xcb, 0xe2, 0x12, 0x0f, 0xda, 0xe6, 0xd5, 0x35, 0xaf, 0x50,
-	0xc0, 0xfd, 0x11, 0xca, 0xc3, 0xd1, 0x8b, 0x43, 0xc1, 0xdd, 0x58, 0x4a, 0xe4, 0x6e, 0x1a, 0x3f,
-	0xe9, 0x45, 0x79, 0x63, 0x8f, 0x44, 0xec, 0x84, 0x98, 0xee, 0xec, 0x7f, 0xf8, 0x48, 0xa2, 0x98,
-	0x8f, 0xc0, 0xdb, 0xa3, 0x2c, 0xdc, 0x26, 0xca, 0xbb, 0x7d, 0xd8, 0x3f, 0xc2, 0x66, 0x69, 0xe7,
-	0x08, 0x81, 0x25, 0x7d, 0x15, 0xe1, 0xb4, 0x88, 0xe6, 0x4c, 0x1e, 0x41, 0x4d, 0x14, 0xe6, 0x6c,
-	0xbb, 0x12, 0xf5, 0xd4, 0xfc, 0xba, 0xa2, 0x19, 0xf7, 0xf0, 0x4b, 0x58, 0x2f, 0x0c, 0x02, 0xa9,
-	0x43, 0xed, 0xc5, 0xf0, 0xbb, 0xe1, 0xc9, 0xcb, 0x61, 0xf3, 0x0e, 0x69, 0x42, 0x63, 0x30, 0x1c,
-	0x3c, 0x1f, 0xf4, 0x9e, 0x0d, 0xce, 0x06, 0xc3, 0xe3, 0xa6, 0x45, 0xd6, 0x60, 0x99, 0xf6, 0x7b,
-	0x47, 0x3f, 0x35, 0x17, 0x0e, 0x9a, 0xbf, 0x5d, 0xb7, 0xad, 0xdf, 0xaf, 0xdb, 0xd6, 0x1f, 0xd7,
-	0x6d, 0xeb, 0x97, 0x3f, 0xdb, 0x77, 0x9c, 0x15, 0x13, 0x66, 0xff, 0xdf, 0x00, 0x00, 0x00, 0xff,
-	0xff, 0x48, 0x8b, 0xb8, 0x2d, 0x28, 0x0a, 0x00, 0x00,
+	// 1069 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x96, 0x5d, 0x6e, 0xdb, 0x46,
+	0x10, 0x80, 0x43, 0xd9, 0xb1, 0xe4, 0x91, 0x6c, 0x33, 0x8b, 0xb4, 0x11, 0xdc, 0x54, 0x0d, 0xd8,
+	0x1f, 0x08, 0x41, 0x21, 0x35, 0x76, 0x1f, 0xda, 0x14, 0x48, 0x2b, 0x5b, 0x8a, 0xa1, 0x34, 0x95,
+	0x85, 0x95, 0xd3, 0x34, 0x7e, 0x5b, 0x91, 0x23, 0x9a, 0x08, 0xc5, 0x25, 0x76, 0x97, 0xb1, 0xdd,
+	0x33, 0xe4, 0x26, 0xbd, 0x48, 0x1f, 0x7b, 0x84, 0xc2, 0x40, 0x81, 0x1c, 0xa3, 0xe0, 0x52, 0x94,
+	0xf9, 0xa3, 0xa4, 0x46, 0x5f, 0x0c, 0x7a, 0xe6, 0x9b, 0x1f, 0xce, 0x1f, 0x05, 0x47, 0xae, 0xa7,
+	0xce, 0xa2, 0x69, 0xc7, 0xe6, 0xf3, 0xee, 0x7c, 0xdf, 0x99, 0x76, 0xe7, 0xfb, 0x5d, 0x29, 0xec,
+	0xae, 0x33, 0x0d, 0xb8, 0x83, 0x5d, 0x17, 0x03, 0x14, 0x4c, 0xa1, 0xd3, 0x0d, 0x05, 0x57, 0xbc,
+	0x1b, 0xb0, 0x39, 0xca, 0x90, 0xd9, 0x78, 0xfd, 0xd4, 0xd1, 0x1a, 0xb2, 0xb9, 0x14, 0xec, 0xde,
+	0x77, 0x39, 0x77, 0x7d, 0x4c, 0x4c, 0xa6, 0xd1, 0xac, 0x2b, 0x95, 0x88, 0x6c, 0x95, 0x80, 0xbb,
+	0xad, 0xa2, 0xf6, 0x5c, 0xb0, 0x30, 0x44, 0x21, 0x17, 0xfa, 0xfe, 0xff, 0xcd, 0x48, 0xda, 0x67,
+	0x38, 0x67, 0x89, 0x17, 0xeb, 0xed, 0x1a, 0x98, 0x14, 0x15, 0x06, 0xca, 0xe3, 0xc1, 0x71, 0x18,
+	0xff, 0x95, 0x64, 0x0f, 0xee, 0x8a, 0x54, 0x36, 0x46, 0xe1, 0x71, 0x67, 0xc4, 0x02, 0x2e, 0x9b,
+	0xc6, 0x03, 0xa3, 0xbd, 0x46, 0x57, 0xea, 0xc8, 0x57, 0xb0, 0x3d, 0xf5, 0xb9, 0xfd, 0x7a, 0xe2,
+	0xfd, 0x8e, 0x09, 0x5d, 0xd1, 0x74, 0x41, 0x4a, 0xbe, 0x86, 0x3b, 0xd3, 0x68, 0x36, 0x43, 0xf1,
+	0x34, 0x52, 0x91, 0x58, 0xa0, 0x6b, 0x1a, 0x2d, 0x2b, 0x48, 0x1b, 0x76, 0x12, 0xe1, 0x98, 0x49,
+	0x95, 0xb0, 0xeb, 0x9a, 0x2d, 0x8a, 0x35, 0x19, 0x47, 0xea, 0x33, 0xc5, 0x06, 0x17, 0xa1, 0x27,
+	0x2e, 0x9b, 0xb7, 0x1f, 0x18, 0xed, 0x1a, 0x2d, 0x8a, 0xc9, 0x29, 0xb4, 0x0b, 0xa2, 0xde, 0x4c,
+	0xa1, 0x18, 0x71, 0xd5, 0xb3, 0x6d, 0x94, 0x32, 0xfb, 0xc6, 0x1b, 0x3a, 0xd8, 0x8d, 0x79, 0xf2,
+	0x04, 0x76, 0x67, 0x3a, 0x7d, 0xba, 0xaa, 0x7e, 0x55, 0xed, 0xed, 0x03, 0x84, 0x35, 0x86, 0xc6,
+	0x30, 0x70, 0xf0, 0x22, 0xed, 0x44, 0x13, 0xaa, 0x18, 0xb0, 0xa9, 0x8f, 0x8e, 0x2e, 0x7e, 0x8d,
+	0xa6, 0xff, 0xde, 0xb4, 0xde, 0xd6, 0xbb, 0x2a, 0x98, 0xa3, 0xb4, 0xf7, 0xa9, 0xdb, 0x87, 0x60,
+	0x4e, 0x39, 0x57, 0x52, 0x09, 0x16, 0x0e, 0x72, 0xfe, 0x4b, 0x72, 0x62, 0x41, 0x63, 0xe6, 0x47,
+	0xf2, 0x2c, 0xe5, 0x2a, 0x9a, 0xcb, 0xc9, 0xe2, 0xa6, 0x9e, 0x0b, 0x4f, 0xa1, 0x3c, 0xe1, 0x87,
+	0x7c, 0x3e, 0xf7, 0xd4, 0x73, 0xee, 0xea, 0xa6, 0xd6, 0x68, 0x59, 0x11, 0xa7, 0x6e, 0xfb, 0xc8,
+	0x82, 0x68, 0x19, 0x7b, 0x5d, 0xa3, 0x05, 0x29, 0xf9, 0x02, 0xb6, 0x04, 0x86, 0xcc, 0x13, 0x29,
+	0x96, 0x34, 0x34, 0x2f, 0x24, 0x47, 0x60, 0x8a, 0xc2, 0x00, 0xeb, 0xb6, 0xd5, 0xf7, 0x3e, 0xe9,
+	0x5c, 0x2f, 0x5f, 0x71, 0xc6, 0x69, 0xc9, 0x28, 0x9e, 0x20, 0x19, 0xb0, 0x50, 0x9e, 0x71, 0x95,
+	0x06, 0xac, 0x26, 0x13, 0x54, 0x10, 0x93, 0x1f, 0xa0, 0xe1, 0x65, 0xba, 0xd4, 0xac, 0xe9, 0x70,
+	0xf7, 0x32, 0xe1, 0xb2, 0x4d, 0xa4, 0x39, 0x98, 0x3c, 0x81, 0xad, 0x64, 0x03, 0x53, 0xeb, 0x4d,
+	0x6d, 0xdd, 0xcc, 0x58, 0x4f, 0xb2, 0x7a, 0x9a, 0xc7, 0xe3, 0x5a, 0xdb, 0xdc, 0x77, 0x5e, 0xea,
+	0xb2, 0xa6, 0x89, 0x42, 0x52, 0xeb, 0x92, 0x82, 0x3c, 0x83, 0x6d, 0x11, 0x05, 0xca, 0x9b, 0xa7,
+	0xbd, 0x6f, 0xd6, 0x75, 0x38, 0x2b, 0x13, 0x6e, 0x39, 0x1e, 0x34, 0x47, 0xd2, 0x82, 0x25, 0x19,
+	0xc3, 0x47, 0x36, 0xb3, 0xcf, 0xf0, 0x20, 0x9e, 0x30, 0x79, 0x1c, 0x50, 0x54, 0xc2, 0xc3, 0x37,
+	0xd8, 0x6c, 0x68, 0x97, 0xbb, 0x9d, 0xe4, 0x62, 0x75, 0xd2, 0x8b, 0xd5, 0x39, 0xe0, 0xdc, 0xff,
+	0x95, 0xf9, 0x11, 0xd2, 0xd5, 0x86, 0xe4, 0x17, 0x20, 0xcc, 0x75, 0x05, 0xba, 0x2c, 0xdb, 0xbd,
+	0x2d, 0xed, 0xee, 0xd3, 0x4c, 0x86, 0xbd, 0x12, 0x44, 0x57, 0x18, 0xc6, 0x7d, 0x91, 0x8a, 0xb9,
+	0x5e, 0xe0, 0x4e, 0x14, 0x53, 0xd8, 0xdc, 0x2e, 0xf5, 0x65, 0x92, 0x51, 0xd3, 0x1c, 0x1c, 0xef,
+	0x44, 0x5c, 0xbe, 0x13, 0x0f, 0x45, 0xcf, 0x5d, 0xac, 0xd4, 0x8e, 0x5e, 0xa9, 0x92, 0x3c, 0xce,
+	0x7b, 0xe6, 0xf9, 0x28, 0x51, 0x1d, 0xf2, 0x79, 0x28, 0x50, 0x4a, 0x8f, 0x07, 0x4d, 0xf3, 0x81,
+	0xd1, 0xde, 0xce, 0xe5, 0xfd, 0xd4, 0xf3, 0x71, 0x92, 0x83, 0xe8, 0x0a, 0x43, 0x32, 0x80, 0x1d,
+	0xbc, 0x50, 0x18, 0x38, 0xe8, 0xa4, 0x35, 0x78, 0x57, 0x5d, 0xd4, 0xf4, 0xda, 0xd9, 0x20, 0x8f,
+	0xd0, 0xa2, 0x8d, 0x35, 0x06, 0x52, 0x2e, 0x14, 0x79, 0x0c, 0x8d, 0x4c, 0xa9, 0xe2, 0x23, 0xbe,
+	0xd6, 0xae, 0xef, 0x7d, 0xbc, 0xba, 0xba, 0x34, 0xc7, 0x5a, 0x01, 0xd4, 0x33, 0x4a, 0xd2, 0x02,
+	0x48, 0xd5, 0xcb, 0x83, 0x91, 0x91, 0x90, 0x1f, 0x01, 0x98, 0x52, 0xc2, 0x9b, 0x46, 0x0a, 0x93,
+	0x7b, 0x54, 0xdf, 0xfb, 0x6c, 0x45, 0x20, 0x74, 0x7a, 0x4b, 0x8c, 0x66, 0x4c, 0xac, 0xb7, 0x06,
+	0xdc, 0x5d, 0x05, 0xc5, 0xbb, 0x29, 0x50, 0x72, 0x3f, 0x8a, 0xf3, 0xc8, 0x7e, 0x8c, 0x8a, 0x62,
+	0xf2, 0x0c, 0xee, 0x38, 0xfc, 0x3c, 0x90, 0x6c, 0x1e, 0xfa, 0xcb, 0x99, 0x4f, 0x52, 0xb9, 0x9f,
+	0x49, 0xa5, 0x5f, 0x64, 0x68, 0xd9, 0xcc, 0xfa, 0x12, 0xee, 0x94, 0x38, 0x62, 0xc2, 0x1a, 0xf3,
+	0xfd, 0xc5, 0xdb, 0xc7, 0x8f, 0xd6, 0x4f, 0xd0, 0xc8, 0xce, 0x15, 0xf9, 0x06, 0x36, 0xa4, 0x62,
+	0x2a, 0x4a, 0x72, 0xdc, 0xce, 0xaf, 0xf6, 0x35, 0x18, 0x49, 0xba, 0xe0, 0xac, 0x3f, 0x0c, 0xa8,
+	0x51, 0x74, 0x3d, 0xa9, 0xc4, 0x25, 0x39, 0x04, 0x58, 0xf2, 0x69, 0xbb, 0x3e, 0xcf, 0x9d, 0xb2,
+	0x04, 0xbc, 0xde, 0x5b, 0x39, 0x08, 0x94, 0xb8, 0xa4, 0x19, 0xb3, 0xdd, 0x53, 0xd8, 0x29, 0xa8,
+	0xe3, 0xc4, 0x5f, 0xe3, 0xa5, 0xce, 0x69, 0x93, 0xc6, 0x8f, 0xe4, 0x11, 0xdc, 0x7e, 0x13, 0xaf,
+	0x67, 0xb3, 0x52, 0xba, 0x97, 0xc5, 0x4f, 0x06, 0x4d, 0xc8, 0xc7, 0x95, 0xef, 0x0c, 0xeb, 0x1f,
+	0x03, 0xee, 0xbd, 0xe7, 0x66, 0x10, 0x07, 0x5a, 0xfa, 0xe0, 0xeb, 0x03, 0xe8, 0x05, 0xee, 0x18,
+	0xc5, 0xe1, 0xf8, 0xc5, 0x21, 0x0f, 0xec, 0x48, 0x08, 0x0c, 0xec, 0x24, 0x7e, 0xdc, 0x8b, 0xe2,
+	0xb1, 0xe8, 0xf3, 0x68, 0xea, 0x63, 0x72, 0x2e, 0xfe, 0xc3, 0x47, 0x1c, 0x45, 0x7f, 0x7f, 0xde,
+	0x1f, 0xa5, 0x72, 0x93, 0x28, 0x1f, 0xf6, 0x61, 0xfd, 0x06, 0x3b, 0x85, 0x9d, 0x23, 0x04, 0xd6,
+	0xd5, 0x65, 0x88, 0x8b, 0x22, 0xea, 0x67, 0xf2, 0x08, 0xaa, 0x3c, 0x37, 0x67, 0xf7, 0x4a, 0x51,
+	0x27, 0xfa, 0x87, 0x1d, 0x4d, 0xb9, 0x87, 0xdf, 0xc3, 0x56, 0x6e, 0x10, 0x48, 0x1d, 0xaa, 0x2f,
+	0x46, 0x3f, 0x8f, 0x8e, 0x5f, 0x8e, 0xcc, 0x5b, 0xc4, 0x84, 0xc6, 0x70, 0x34, 0x3c, 0x19, 0xf6,
+	0x9e, 0x0f, 0x4f, 0x87, 0xa3, 0x23, 0xd3, 0x20, 0x9b, 0x70, 0x9b, 0x0e, 0x7a, 0xfd, 0x57, 0x66,
+	0xe5, 0xe1, 0xb7, 0x40, 0xca, 0x57, 0x85, 0xd4, 0x60, 0x7d, 0x74, 0x3c, 0x1a, 0x98, 0xb7, 0x08,
+	0xc0, 0xc6, 0x64, 0xd4, 0x1b, 0x8f, 0x5f, 0x99, 0x46, 0x2c, 0x3d, 0x9d, 0x9c, 0xf4, 0xcd, 0xca,
+	0x41, 0xe3, 0xcf, 0xab, 0x96, 0xf1, 0xd7, 0x55, 0xcb, 0xf8, 0xfb, 0xaa, 0x65, 0x4c, 0x37, 0x74,
+	0x62, 0xfb, 0xff, 0x0e, 0x00, 0xe2, 0xb5, 0x5d, 0xd9, 0xd5, 0x0a, 0x00, 0x00,
 }
@@ -69,6 +69,8 @@ service Node {
 	void                           writeTaggedBatchRawV2(1: WriteTaggedBatchRawV2Request req) throws (1: WriteBatchRawErrors err)
 	void                           repair() throws (1: Error err)
 	TruncateResult                 truncate(1: TruncateRequest req) throws (1: Error err)
+	DeleteSeriesResult             deleteSeries(1: DeleteSeriesRequest req) throws (1: Error err)
+	CardinalityResult              cardinality(1: CardinalityRequest req) throws (1: Error err)
 
 	AggregateTilesResult aggregateTiles(1: AggregateTilesRequest req) throws (1: Error err)
 
@@ -173,6 +175,17 @@ struct Segment {
 	5: optional i64 checksum
 }
 
+// Enumeration of the functions that consolidate the datapoints of each step
+// window when a FetchTaggedRequest asks for downsampled data. Matches
+// DownsampleType in golang.
+enum DownsampleType {
+	LAST,
+	SUM,
+	MIN,
+	MAX,
+	COUNT
+}
+
 struct FetchTaggedRequest {
 	1: required binary nameSpace
 	2: required binary query
@@ -185,6 +198,8 @@ struct FetchTaggedRequest {
 	9: optional i64 docsLimit
 	10: optional binary source
 	11: optional bool requireNoWait = false
+	12: optional i64 downsampleStepNanos
+	13: optional DownsampleType downsampleType
 }
 
 struct FetchTaggedResult {
@@ -322,6 +337,48 @@ struct TruncateResult {
 	1: required i64 numSeries
 }
 
+struct DeleteSeriesRequest {
+	1: required binary nameSpace
+	2: required binary query
+	3: required i64 rangeStart
+	4: required i64 rangeEnd
+	5: optional TimeType rangeTimeType = TimeType.UNIX_SECONDS
+}
+
+struct DeleteSeriesResult {
+	1: required i64 numSeries
+}
+
+struct CardinalityRequest {
+	1: required binary nameSpace
+	2: required i64 rangeStart
+	3: required i64 rangeEnd
+	4: optional TimeType rangeTimeType = TimeType.UNIX_SECONDS
+	5: optional i64 limit
+	6: optional binary nameTag
+	7: optional binary query
+}
+
+struct CardinalityStat {
+	1: required binary name
+	2: required i64 value
+}
+
+struct CardinalityBlock {
+	1: required i64 blockStart
+	2: required i64 numSeries
+	3: required i64 newSeries
+	4: required i64 removedSeries
+}
+
+struct CardinalityResult {
+	1: required i64 numSeries
+	2: required list<CardinalityStat> seriesCountByMetricName
+	3: required list<CardinalityStat> labelValueCountByLabelName
+	4: required list<CardinalityStat> seriesCountByLabelValuePair
+	5: required list<CardinalityBlock> blocks
+}
+
 struct NodeHealthResult {
 	1: required bool ok
 	2: required string status
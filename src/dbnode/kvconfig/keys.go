@@ -52,4 +52,8 @@ const (
 
 	// QueryLimits is the KV config key for query limits enforced on each dbnode.
 	QueryLimits = "m3db.query.limits"
+
+	// SeriesLimits is the KV config key for the cluster wide active series
+	// limits keyed on a tag enforced on the write path of each dbnode.
+	SeriesLimits = "m3db.series.limits"
 )
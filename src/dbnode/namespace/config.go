@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/x/ident"
 )
@@ -58,6 +59,8 @@ type MetadataConfiguration struct {
 	CacheBlocksOnRetrieve *bool                   `yaml:"cacheBlocksOnRetrieve"`
 	Retention             retention.Configuration `yaml:"retention" validate:"nonzero"`
 	Index                 IndexConfiguration      `yaml:"index"`
+	ColdTierAge           time.Duration           `yaml:"coldTierAge"`
+	FileSetCompression    compression.Type        `yaml:"filesetCompression"`
 }
 
 // Metadata returns a Metadata corresponding to the receiver struct
@@ -88,6 +91,12 @@ func (mc *MetadataConfiguration) Metadata() (Metadata, error) {
 	if v := mc.CacheBlocksOnRetrieve; v != nil {
 		opts = opts.SetCacheBlocksOnRetrieve(*v)
 	}
+	if v := mc.ColdTierAge; v > 0 {
+		opts = opts.SetColdTierAge(v)
+	}
+	if v := mc.FileSetCompression; v != compression.NoneType {
+		opts = opts.SetFileSetCompression(v)
+	}
 	return NewMetadata(ident.StringID(mc.ID), opts)
 }
 
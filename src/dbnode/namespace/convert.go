@@ -31,6 +31,7 @@ import (
 	protobuftypes "github.com/gogo/protobuf/types"
 
 	nsproto "github.com/m3db/m3/src/dbnode/generated/proto/namespace"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/x/ident"
 	xtime "github.com/m3db/m3/src/x/time"
@@ -196,6 +197,11 @@ func ToMetadata(
 		return nil, err
 	}
 
+	filesetCompression, err := ToFileSetCompression(opts.FilesetCompression)
+	if err != nil {
+		return nil, err
+	}
+
 	mOpts := NewOptions().
 		SetBootstrapEnabled(opts.BootstrapEnabled).
 		SetFlushEnabled(opts.FlushEnabled).
@@ -210,7 +216,9 @@ func ToMetadata(
 		SetRuntimeOptions(runtimeOpts).
 		SetExtendedOptions(extendedOpts).
 		SetAggregationOptions(aggOpts).
-		SetStagingState(stagingState)
+		SetStagingState(stagingState).
+		SetColdTierAge(time.Duration(opts.ColdTierAgeNanos)).
+		SetFileSetCompression(filesetCompression)
 
 	if opts.CacheBlocksOnRetrieve != nil {
 		mOpts = mOpts.SetCacheBlocksOnRetrieve(opts.CacheBlocksOnRetrieve.Value)
@@ -232,6 +240,19 @@ func ToStagingState(state *nsproto.StagingState) (StagingState, error) {
 	return NewStagingState(state.Status)
 }
 
+// ToFileSetCompression converts nsproto.FileSetCompression to compression.Type.
+func ToFileSetCompression(value nsproto.FileSetCompression) (compression.Type, error) {
+	switch value {
+	case nsproto.FileSetCompression_NONE:
+		return compression.NoneType, nil
+	case nsproto.FileSetCompression_SNAPPY:
+		return compression.SnappyType, nil
+	case nsproto.FileSetCompression_ZSTD:
+		return compression.ZstdType, nil
+	}
+	return compression.NoneType, fmt.Errorf("invalid FileSetCompression: %v", value)
+}
+
 // ToAggregationOptions converts nsproto.AggregationOptions to AggregationOptions.
 func ToAggregationOptions(opts *nsproto.AggregationOptions) (AggregationOptions, error) {
 	aggOpts := NewAggregationOptions()
@@ -313,6 +334,11 @@ func OptionsToProto(opts Options) (*nsproto.NamespaceOptions, error) {
 		return nil, err
 	}
 
+	filesetCompression, err := toProtoFileSetCompression(opts.FileSetCompression())
+	if err != nil {
+		return nil, err
+	}
+
 	nsOpts := &nsproto.NamespaceOptions{
 		BootstrapEnabled:  opts.BootstrapEnabled(),
 		FlushEnabled:      opts.FlushEnabled(),
@@ -340,6 +366,8 @@ func OptionsToProto(opts Options) (*nsproto.NamespaceOptions, error) {
 		ExtendedOptions:       extendedOpts,
 		AggregationOptions:    toProtoAggregationOptions(opts.AggregationOptions()),
 		StagingState:          stagingState,
+		ColdTierAgeNanos:      opts.ColdTierAge().Nanoseconds(),
+		FilesetCompression:    filesetCompression,
 	}
 
 	return nsOpts, nil
@@ -361,6 +389,18 @@ func toProtoStagingState(state StagingState) (*nsproto.StagingState, error) {
 	return &nsproto.StagingState{Status: protoStatus}, nil
 }
 
+func toProtoFileSetCompression(value compression.Type) (nsproto.FileSetCompression, error) {
+	switch value {
+	case compression.NoneType:
+		return nsproto.FileSetCompression_NONE, nil
+	case compression.SnappyType:
+		return nsproto.FileSetCompression_SNAPPY, nil
+	case compression.ZstdType:
+		return nsproto.FileSetCompression_ZSTD, nil
+	}
+	return nsproto.FileSetCompression_NONE, fmt.Errorf("invalid FileSetCompression: %v", value)
+}
+
 func toProtoAggregationOptions(aggOpts AggregationOptions) *nsproto.AggregationOptions {
 	if aggOpts == nil || len(aggOpts.Aggregations()) == 0 {
 		return nil
@@ -30,6 +30,7 @@ import (
 
 	nsproto "github.com/m3db/m3/src/dbnode/generated/proto/namespace"
 	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/x/ident"
 	xtest "github.com/m3db/m3/src/x/test"
@@ -76,6 +77,8 @@ var (
 			SchemaOptions:         testSchemaOptions,
 			ExtendedOptions:       validExtendedOpts,
 			StagingState:          &nsproto.StagingState{Status: nsproto.StagingStatus_INITIALIZING},
+			ColdTierAgeNanos:      toNanos(600), // 10h
+			FilesetCompression:    nsproto.FileSetCompression_ZSTD,
 		},
 		{
 			BootstrapEnabled:  true,
@@ -220,7 +223,9 @@ func TestToProto(t *testing.T) {
 	md1, err := namespace.NewMetadata(ident.StringID("ns1"),
 		namespace.NewOptions().
 			SetBootstrapEnabled(true).
-			SetStagingState(state))
+			SetStagingState(state).
+			SetColdTierAge(24*time.Hour).
+			SetFileSetCompression(compression.SnappyType))
 	require.NoError(t, err)
 	md2, err := namespace.NewMetadata(ident.StringID("ns2"),
 		namespace.NewOptions().SetBootstrapEnabled(false))
@@ -415,6 +420,10 @@ func assertEqualMetadata(t *testing.T, name string, expected nsproto.NamespaceOp
 	require.Equal(t, expected.CleanupEnabled, opts.CleanupEnabled())
 	require.Equal(t, expected.RepairEnabled, opts.RepairEnabled())
 	require.Equal(t, expectedCacheBlocksOnRetrieve, opts.CacheBlocksOnRetrieve())
+	require.Equal(t, expected.ColdTierAgeNanos, opts.ColdTierAge().Nanoseconds())
+	expectedCompression, err := namespace.ToFileSetCompression(expected.FilesetCompression)
+	require.NoError(t, err)
+	require.Equal(t, expectedCompression, opts.FileSetCompression())
 	expectedSchemaReg, err := namespace.LoadSchemaHistory(expected.SchemaOptions)
 	require.NoError(t, err)
 	require.NotNil(t, expectedSchemaReg)
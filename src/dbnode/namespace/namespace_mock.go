@@ -29,6 +29,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/cluster/client"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/x/ident"
 	"github.com/m3db/m3/src/x/instrument"
@@ -117,6 +118,20 @@ func (mr *MockOptionsMockRecorder) CleanupEnabled() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanupEnabled", reflect.TypeOf((*MockOptions)(nil).CleanupEnabled))
 }
 
+// ColdTierAge mocks base method.
+func (m *MockOptions) ColdTierAge() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ColdTierAge")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// ColdTierAge indicates an expected call of ColdTierAge.
+func (mr *MockOptionsMockRecorder) ColdTierAge() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ColdTierAge", reflect.TypeOf((*MockOptions)(nil).ColdTierAge))
+}
+
 // ColdWritesEnabled mocks base method.
 func (m *MockOptions) ColdWritesEnabled() bool {
 	m.ctrl.T.Helper()
@@ -159,6 +174,20 @@ func (mr *MockOptionsMockRecorder) ExtendedOptions() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtendedOptions", reflect.TypeOf((*MockOptions)(nil).ExtendedOptions))
 }
 
+// FileSetCompression mocks base method.
+func (m *MockOptions) FileSetCompression() compression.Type {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FileSetCompression")
+	ret0, _ := ret[0].(compression.Type)
+	return ret0
+}
+
+// FileSetCompression indicates an expected call of FileSetCompression.
+func (mr *MockOptionsMockRecorder) FileSetCompression() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FileSetCompression", reflect.TypeOf((*MockOptions)(nil).FileSetCompression))
+}
+
 // FlushEnabled mocks base method.
 func (m *MockOptions) FlushEnabled() bool {
 	m.ctrl.T.Helper()
@@ -299,6 +328,20 @@ func (mr *MockOptionsMockRecorder) SetCleanupEnabled(value interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCleanupEnabled", reflect.TypeOf((*MockOptions)(nil).SetCleanupEnabled), value)
 }
 
+// SetColdTierAge mocks base method.
+func (m *MockOptions) SetColdTierAge(value time.Duration) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetColdTierAge", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetColdTierAge indicates an expected call of SetColdTierAge.
+func (mr *MockOptionsMockRecorder) SetColdTierAge(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetColdTierAge", reflect.TypeOf((*MockOptions)(nil).SetColdTierAge), value)
+}
+
 // SetColdWritesEnabled mocks base method.
 func (m *MockOptions) SetColdWritesEnabled(value bool) Options {
 	m.ctrl.T.Helper()
@@ -327,6 +370,20 @@ func (mr *MockOptionsMockRecorder) SetExtendedOptions(value interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetExtendedOptions", reflect.TypeOf((*MockOptions)(nil).SetExtendedOptions), value)
 }
 
+// SetFileSetCompression mocks base method.
+func (m *MockOptions) SetFileSetCompression(value compression.Type) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetFileSetCompression", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetFileSetCompression indicates an expected call of SetFileSetCompression.
+func (mr *MockOptionsMockRecorder) SetFileSetCompression(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFileSetCompression", reflect.TypeOf((*MockOptions)(nil).SetFileSetCompression), value)
+}
+
 // SetFlushEnabled mocks base method.
 func (m *MockOptions) SetFlushEnabled(value bool) Options {
 	m.ctrl.T.Helper()
@@ -22,7 +22,9 @@ package namespace
 
 import (
 	"errors"
+	"time"
 
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/retention"
 )
 
@@ -59,6 +61,9 @@ var (
 	errIndexBlockSizeMustBeAMultipleOfDataBlockSize = errors.New("index block size must be a multiple of data block size")
 	errNamespaceRuntimeOptionsNotSet                = errors.New("namespace runtime options is not set")
 	errAggregationOptionsNotSet                     = errors.New("aggregation options is not set")
+	errColdTierAgeNegative                          = errors.New("cold tier age must not be negative")
+	errColdTierAgeTooLarge                          = errors.New("cold tier age needs to be < namespace retention period")
+	errColdTierAgeTooSmall                          = errors.New("cold tier age needs to be >= block size + buffer past")
 )
 
 type options struct {
@@ -77,6 +82,8 @@ type options struct {
 	extendedOpts          ExtendedOptions
 	aggregationOpts       AggregationOptions
 	stagingState          StagingState
+	coldTierAge           time.Duration
+	filesetCompression    compression.Type
 }
 
 // NewSchemaHistory returns an empty schema history.
@@ -118,6 +125,24 @@ func (o *options) Validate() error {
 		return err
 	}
 
+	if err := o.filesetCompression.Validate(); err != nil {
+		return err
+	}
+
+	if o.coldTierAge < 0 {
+		return errColdTierAgeNegative
+	}
+	if o.coldTierAge > 0 {
+		if o.coldTierAge >= o.retentionOpts.RetentionPeriod() {
+			return errColdTierAgeTooLarge
+		}
+		// Blocks are only moved to the cold tier once they can no longer
+		// receive warm writes.
+		if o.coldTierAge < o.retentionOpts.BlockSize()+o.retentionOpts.BufferPast() {
+			return errColdTierAgeTooSmall
+		}
+	}
+
 	if !o.indexOpts.Enabled() {
 		return nil
 	}
@@ -160,7 +185,9 @@ func (o *options) Equal(value Options) bool {
 		o.schemaHis.Equal(value.SchemaHistory()) &&
 		o.runtimeOpts.Equal(value.RuntimeOptions()) &&
 		o.aggregationOpts.Equal(value.AggregationOptions()) &&
-		o.stagingState == value.StagingState()
+		o.stagingState == value.StagingState() &&
+		o.coldTierAge == value.ColdTierAge() &&
+		o.filesetCompression == value.FileSetCompression()
 }
 
 func (o *options) SetBootstrapEnabled(value bool) Options {
@@ -312,3 +339,23 @@ func (o *options) SetStagingState(value StagingState) Options {
 func (o *options) StagingState() StagingState {
 	return o.stagingState
 }
+
+func (o *options) SetColdTierAge(value time.Duration) Options {
+	opts := *o
+	opts.coldTierAge = value
+	return &opts
+}
+
+func (o *options) ColdTierAge() time.Duration {
+	return o.coldTierAge
+}
+
+func (o *options) SetFileSetCompression(value compression.Type) Options {
+	opts := *o
+	opts.filesetCompression = value
+	return &opts
+}
+
+func (o *options) FileSetCompression() compression.Type {
+	return o.filesetCompression
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/retention"
 )
 
@@ -217,3 +218,35 @@ func TestOptionsValidateStagingStatus(t *testing.T) {
 	o1 = o1.SetStagingState(StagingState{status: StagingStatus(12)})
 	require.Error(t, o1.Validate())
 }
+
+func TestOptionsValidateColdTierAge(t *testing.T) {
+	o1 := NewOptions()
+	retentionPeriod := o1.RetentionOptions().RetentionPeriod()
+
+	require.NoError(t, o1.SetColdTierAge(retentionPeriod/2).Validate())
+	require.Equal(t, errColdTierAgeNegative, o1.SetColdTierAge(-time.Hour).Validate())
+	require.Equal(t, errColdTierAgeTooLarge, o1.SetColdTierAge(retentionPeriod).Validate())
+	require.Equal(t, errColdTierAgeTooSmall, o1.SetColdTierAge(o1.RetentionOptions().BlockSize()).Validate())
+}
+
+func TestOptionsValidateFileSetCompression(t *testing.T) {
+	o1 := NewOptions()
+	require.NoError(t, o1.SetFileSetCompression(compression.ZstdType).Validate())
+	require.Error(t, o1.SetFileSetCompression(compression.Type(12)).Validate())
+}
+
+func TestOptionsEqualsFileSetCompression(t *testing.T) {
+	o1 := NewOptions()
+	o2 := o1.SetFileSetCompression(compression.SnappyType)
+	require.True(t, o2.Equal(o2))
+	require.False(t, o1.Equal(o2))
+	require.False(t, o2.Equal(o1))
+}
+
+func TestOptionsEqualsColdTierAge(t *testing.T) {
+	o1 := NewOptions()
+	o2 := o1.SetColdTierAge(time.Hour)
+	require.True(t, o2.Equal(o2))
+	require.False(t, o1.Equal(o2))
+	require.False(t, o2.Equal(o1))
+}
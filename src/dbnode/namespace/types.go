@@ -26,6 +26,7 @@ import (
 	protobuftypes "github.com/gogo/protobuf/types"
 
 	"github.com/m3db/m3/src/cluster/client"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/x/ident"
 	"github.com/m3db/m3/src/x/instrument"
@@ -131,6 +132,22 @@ type Options interface {
 
 	// StagingState returns the state related to a namespace's availability for use.
 	StagingState() StagingState
+
+	// SetColdTierAge sets the age after which flushed blocks of this namespace
+	// are moved to the cold tier, zero disables the cold tier.
+	SetColdTierAge(value time.Duration) Options
+
+	// ColdTierAge returns the age after which flushed blocks of this namespace
+	// are moved to the cold tier, zero disables the cold tier.
+	ColdTierAge() time.Duration
+
+	// SetFileSetCompression sets the compression codec used for the data and
+	// index files of filesets flushed for this namespace.
+	SetFileSetCompression(value compression.Type) Options
+
+	// FileSetCompression returns the compression codec used for the data and
+	// index files of filesets flushed for this namespace.
+	FileSetCompression() compression.Type
 }
 
 // IndexOptions controls the indexing options for a namespace.
@@ -26,6 +26,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
 	tterrors "github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/errors"
 	"github.com/m3db/m3/src/dbnode/storage/index"
@@ -212,7 +213,7 @@ func ToRPCError(err error) *rpc.Error {
 		return rpcErr
 	}
 
-	if limits.IsQueryLimitExceededError(err) {
+	if limits.IsQueryLimitExceededError(err) || limits.IsSeriesLimitExceededError(err) {
 		return tterrors.NewResourceExhaustedError(err)
 	}
 	if xerrors.IsInvalidParams(err) {
@@ -263,6 +264,14 @@ func FromRPCFetchTaggedRequest(
 	if len(req.Source) > 0 {
 		opts.Source = req.Source
 	}
+	if step := req.DownsampleStepNanos; step != nil && *step > 0 {
+		downsampleType, err := FromRPCDownsampleType(req.GetDownsampleType())
+		if err != nil {
+			return nil, index.Query{}, index.QueryOptions{}, false, err
+		}
+		opts.DownsampleStep = time.Duration(*step)
+		opts.DownsampleType = downsampleType
+	}
 
 	q, err := idx.Unmarshal(req.Query)
 	if err != nil {
@@ -326,9 +335,237 @@ func ToRPCFetchTaggedRequest(
 		request.Source = opts.Source
 	}
 
+	if opts.DownsampleStep > 0 {
+		downsampleType, err := ToRPCDownsampleType(opts.DownsampleType)
+		if err != nil {
+			return rpc.FetchTaggedRequest{}, err
+		}
+		step := int64(opts.DownsampleStep)
+		request.DownsampleStepNanos = &step
+		request.DownsampleType = &downsampleType
+	}
+
+	return request, nil
+}
+
+// FromRPCDownsampleType converts the rpc downsample type into the Go type.
+func FromRPCDownsampleType(t rpc.DownsampleType) (encoding.DownsampleType, error) {
+	switch t {
+	case rpc.DownsampleType_LAST:
+		return encoding.DownsampleLast, nil
+	case rpc.DownsampleType_SUM:
+		return encoding.DownsampleSum, nil
+	case rpc.DownsampleType_MIN:
+		return encoding.DownsampleMin, nil
+	case rpc.DownsampleType_MAX:
+		return encoding.DownsampleMax, nil
+	case rpc.DownsampleType_COUNT:
+		return encoding.DownsampleCount, nil
+	}
+	return 0, fmt.Errorf("unknown downsample type: %v", t)
+}
+
+// ToRPCDownsampleType converts the Go downsample type into the rpc type.
+func ToRPCDownsampleType(t encoding.DownsampleType) (rpc.DownsampleType, error) {
+	switch t {
+	case encoding.DownsampleLast:
+		return rpc.DownsampleType_LAST, nil
+	case encoding.DownsampleSum:
+		return rpc.DownsampleType_SUM, nil
+	case encoding.DownsampleMin:
+		return rpc.DownsampleType_MIN, nil
+	case encoding.DownsampleMax:
+		return rpc.DownsampleType_MAX, nil
+	case encoding.DownsampleCount:
+		return rpc.DownsampleType_COUNT, nil
+	}
+	return 0, fmt.Errorf("unknown downsample type: %v", t)
+}
+
+// FromRPCDeleteSeriesRequest converts the rpc request type for
+// DeleteSeriesRequest into the Go types of the delete series request.
+func FromRPCDeleteSeriesRequest(
+	req *rpc.DeleteSeriesRequest,
+) (ident.ID, index.Query, xtime.UnixNano, xtime.UnixNano, error) {
+	start, err := ToTime(req.RangeStart, req.RangeTimeType)
+	if err != nil {
+		return nil, index.Query{}, 0, 0, err
+	}
+
+	end, err := ToTime(req.RangeEnd, req.RangeTimeType)
+	if err != nil {
+		return nil, index.Query{}, 0, 0, err
+	}
+
+	q, err := idx.Unmarshal(req.Query)
+	if err != nil {
+		return nil, index.Query{}, 0, 0, err
+	}
+
+	ns := ident.StringID(string(req.NameSpace))
+	return ns, index.Query{Query: q}, start, end, nil
+}
+
+// ToRPCDeleteSeriesRequest converts the Go `client/` types into rpc request type
+// for DeleteSeriesRequest.
+func ToRPCDeleteSeriesRequest(
+	ns ident.ID,
+	q index.Query,
+	start, end xtime.UnixNano,
+) (rpc.DeleteSeriesRequest, error) {
+	rangeStart, err := ToValue(start, fetchTaggedTimeType)
+	if err != nil {
+		return rpc.DeleteSeriesRequest{}, err
+	}
+
+	rangeEnd, err := ToValue(end, fetchTaggedTimeType)
+	if err != nil {
+		return rpc.DeleteSeriesRequest{}, err
+	}
+
+	query, err := idx.Marshal(q.Query)
+	if err != nil {
+		return rpc.DeleteSeriesRequest{}, err
+	}
+
+	return rpc.DeleteSeriesRequest{
+		NameSpace:     ns.Bytes(),
+		Query:         query,
+		RangeStart:    rangeStart,
+		RangeEnd:      rangeEnd,
+		RangeTimeType: fetchTaggedTimeType,
+	}, nil
+}
+
+// FromRPCCardinalityRequest converts the rpc request type for
+// CardinalityRequest into the Go types of the cardinality request.
+func FromRPCCardinalityRequest(
+	req *rpc.CardinalityRequest,
+) (ident.ID, index.CardinalityOptions, error) {
+	start, err := ToTime(req.RangeStart, req.RangeTimeType)
+	if err != nil {
+		return nil, index.CardinalityOptions{}, err
+	}
+
+	end, err := ToTime(req.RangeEnd, req.RangeTimeType)
+	if err != nil {
+		return nil, index.CardinalityOptions{}, err
+	}
+
+	opts := index.CardinalityOptions{
+		StartInclusive: start,
+		EndExclusive:   end,
+		NameField:      req.NameTag,
+	}
+	if req.Limit != nil {
+		opts.Limit = int(*req.Limit)
+	}
+	if req.Query != nil {
+		q, err := idx.Unmarshal(req.Query)
+		if err != nil {
+			return nil, index.CardinalityOptions{}, err
+		}
+		opts.Query = index.Query{Query: q}
+	}
+
+	ns := ident.StringID(string(req.NameSpace))
+	return ns, opts, nil
+}
+
+// ToRPCCardinalityRequest converts the Go `client/` types into rpc request
+// type for CardinalityRequest.
+func ToRPCCardinalityRequest(
+	ns ident.ID,
+	opts index.CardinalityOptions,
+) (rpc.CardinalityRequest, error) {
+	rangeStart, err := ToValue(opts.StartInclusive, fetchTaggedTimeType)
+	if err != nil {
+		return rpc.CardinalityRequest{}, err
+	}
+
+	rangeEnd, err := ToValue(opts.EndExclusive, fetchTaggedTimeType)
+	if err != nil {
+		return rpc.CardinalityRequest{}, err
+	}
+
+	request := rpc.CardinalityRequest{
+		NameSpace:     ns.Bytes(),
+		RangeStart:    rangeStart,
+		RangeEnd:      rangeEnd,
+		RangeTimeType: fetchTaggedTimeType,
+		NameTag:       opts.NameField,
+	}
+	if opts.Limit > 0 {
+		limit := int64(opts.Limit)
+		request.Limit = &limit
+	}
+	if opts.Query.SearchQuery() != nil {
+		query, err := idx.Marshal(opts.Query.Query)
+		if err != nil {
+			return rpc.CardinalityRequest{}, err
+		}
+		request.Query = query
+	}
 	return request, nil
 }
 
+// ToRPCCardinalityResult converts the cardinality of a namespace into the
+// rpc result type for CardinalityResult.
+func ToRPCCardinalityResult(r index.CardinalityResult) *rpc.CardinalityResult_ {
+	res := rpc.NewCardinalityResult_()
+	res.NumSeries = r.NumSeries
+	res.SeriesCountByMetricName = toRPCCardinalityStats(r.SeriesCountByMetricName)
+	res.LabelValueCountByLabelName = toRPCCardinalityStats(r.LabelValueCountByLabelName)
+	res.SeriesCountByLabelValuePair = toRPCCardinalityStats(r.SeriesCountByLabelValuePair)
+	res.Blocks = make([]*rpc.CardinalityBlock, 0, len(r.Blocks))
+	for _, b := range r.Blocks {
+		res.Blocks = append(res.Blocks, &rpc.CardinalityBlock{
+			BlockStart:    int64(b.BlockStart),
+			NumSeries:     b.NumSeries,
+			NewSeries:     b.NewSeries,
+			RemovedSeries: b.RemovedSeries,
+		})
+	}
+	return res
+}
+
+// FromRPCCardinalityResult converts the rpc result type for
+// CardinalityResult into the cardinality of a namespace.
+func FromRPCCardinalityResult(r *rpc.CardinalityResult_) index.CardinalityResult {
+	res := index.CardinalityResult{
+		NumSeries:                   r.NumSeries,
+		SeriesCountByMetricName:     fromRPCCardinalityStats(r.SeriesCountByMetricName),
+		LabelValueCountByLabelName:  fromRPCCardinalityStats(r.LabelValueCountByLabelName),
+		SeriesCountByLabelValuePair: fromRPCCardinalityStats(r.SeriesCountByLabelValuePair),
+		Blocks:                      make([]index.BlockCardinality, 0, len(r.Blocks)),
+	}
+	for _, b := range r.Blocks {
+		res.Blocks = append(res.Blocks, index.BlockCardinality{
+			BlockStart:    xtime.UnixNano(b.BlockStart),
+			NumSeries:     b.NumSeries,
+			NewSeries:     b.NewSeries,
+			RemovedSeries: b.RemovedSeries,
+		})
+	}
+	return res
+}
+
+func toRPCCardinalityStats(stats []index.CardinalityStat) []*rpc.CardinalityStat {
+	res := make([]*rpc.CardinalityStat, 0, len(stats))
+	for _, stat := range stats {
+		res = append(res, &rpc.CardinalityStat{Name: stat.Name, Value: stat.Value})
+	}
+	return res
+}
+
+func fromRPCCardinalityStats(stats []*rpc.CardinalityStat) []index.CardinalityStat {
+	res := make([]index.CardinalityStat, 0, len(stats))
+	for _, stat := range stats {
+		res = append(res, index.CardinalityStat{Name: stat.Name, Value: stat.Value})
+	}
+	return res
+}
+
 // FromRPCAggregateQueryRequest converts the rpc request type for AggregateRawQueryRequest into corresponding Go API types.
 func FromRPCAggregateQueryRequest(
 	req *rpc.AggregateQueryRequest,
@@ -31,6 +31,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
 	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/convert"
 	tterrors "github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/errors"
@@ -180,6 +181,136 @@ func TestConvertFetchTaggedRequest(t *testing.T) {
 	}
 }
 
+func TestConvertFetchTaggedRequestDownsample(t *testing.T) {
+	ns := ident.StringID("abc")
+	q, _ := termQueryTestCase(t)
+	opts := index.QueryOptions{
+		StartInclusive: xtime.Now().Add(-time.Hour),
+		EndExclusive:   xtime.Now(),
+		DownsampleStep: time.Minute,
+		DownsampleType: encoding.DownsampleMax,
+	}
+
+	req, err := convert.ToRPCFetchTaggedRequest(ns, index.Query{Query: q}, opts, true)
+	require.NoError(t, err)
+	require.True(t, req.IsSetDownsampleStepNanos())
+	require.Equal(t, int64(time.Minute), req.GetDownsampleStepNanos())
+	require.Equal(t, rpc.DownsampleType_MAX, req.GetDownsampleType())
+
+	_, _, observedOpts, _, err := convert.FromRPCFetchTaggedRequest(&req, nil)
+	require.NoError(t, err)
+	require.Equal(t, time.Minute, observedOpts.DownsampleStep)
+	require.Equal(t, encoding.DownsampleMax, observedOpts.DownsampleType)
+
+	opts.DownsampleStep = 0
+	req, err = convert.ToRPCFetchTaggedRequest(ns, index.Query{Query: q}, opts, true)
+	require.NoError(t, err)
+	require.False(t, req.IsSetDownsampleStepNanos())
+	require.False(t, req.IsSetDownsampleType())
+}
+
+func TestConvertDeleteSeriesRequest(t *testing.T) {
+	var (
+		ns    = ident.StringID("abc")
+		start = xtime.Now().Add(-900 * time.Hour)
+		end   = xtime.Now()
+	)
+	q, rpcQ := termQueryTestCase(t)
+	expectedReq := rpc.DeleteSeriesRequest{
+		NameSpace:     ns.Bytes(),
+		Query:         rpcQ,
+		RangeStart:    mustToRPCTime(t, start),
+		RangeEnd:      mustToRPCTime(t, end),
+		RangeTimeType: rpc.TimeType_UNIX_NANOSECONDS,
+	}
+
+	observedReq, err := convert.ToRPCDeleteSeriesRequest(ns, index.Query{Query: q}, start, end)
+	require.NoError(t, err)
+	require.Equal(t, expectedReq, observedReq)
+
+	id, observedQuery, observedStart, observedEnd, err := convert.FromRPCDeleteSeriesRequest(&observedReq)
+	require.NoError(t, err)
+	require.Equal(t, ns.String(), id.String())
+	require.True(t, index.NewQueryMatcher(index.Query{Query: q}).Matches(observedQuery))
+	require.Equal(t, start, observedStart)
+	require.Equal(t, end, observedEnd)
+
+	// Ranges default to seconds.
+	secondsReq := &rpc.DeleteSeriesRequest{
+		NameSpace:  ns.Bytes(),
+		Query:      rpcQ,
+		RangeStart: 1,
+		RangeEnd:   2,
+	}
+	_, _, observedStart, observedEnd, err = convert.FromRPCDeleteSeriesRequest(secondsReq)
+	require.NoError(t, err)
+	require.Equal(t, xtime.UnixNano(time.Second), observedStart)
+	require.Equal(t, xtime.UnixNano(2*time.Second), observedEnd)
+}
+
+func TestConvertCardinalityRequest(t *testing.T) {
+	var (
+		ns    = ident.StringID("abc")
+		start = xtime.Now().Add(-900 * time.Hour)
+		end   = xtime.Now()
+		limit = int64(5)
+		opts  = index.CardinalityOptions{
+			StartInclusive: start,
+			EndExclusive:   end,
+			Limit:          int(limit),
+			NameField:      []byte("__name__"),
+		}
+	)
+	expectedReq := rpc.CardinalityRequest{
+		NameSpace:     ns.Bytes(),
+		RangeStart:    mustToRPCTime(t, start),
+		RangeEnd:      mustToRPCTime(t, end),
+		RangeTimeType: rpc.TimeType_UNIX_NANOSECONDS,
+		Limit:         &limit,
+		NameTag:       []byte("__name__"),
+	}
+
+	observedReq, err := convert.ToRPCCardinalityRequest(ns, opts)
+	require.NoError(t, err)
+	require.Equal(t, expectedReq, observedReq)
+
+	id, observedOpts, err := convert.FromRPCCardinalityRequest(&observedReq)
+	require.NoError(t, err)
+	require.Equal(t, ns.String(), id.String())
+	require.Equal(t, opts, observedOpts)
+
+	// A query restricting the series round trips.
+	opts.Query = index.Query{Query: idx.NewTermQuery([]byte("tenant"), []byte("a"))}
+	observedReq, err = convert.ToRPCCardinalityRequest(ns, opts)
+	require.NoError(t, err)
+	require.NotNil(t, observedReq.Query)
+
+	_, observedOpts, err = convert.FromRPCCardinalityRequest(&observedReq)
+	require.NoError(t, err)
+	require.True(t, opts.Query.Equal(observedOpts.Query.Query))
+}
+
+func TestConvertCardinalityResult(t *testing.T) {
+	result := index.CardinalityResult{
+		NumSeries: 3,
+		SeriesCountByMetricName: []index.CardinalityStat{
+			{Name: []byte("foo"), Value: 2},
+			{Name: []byte("bar"), Value: 1},
+		},
+		LabelValueCountByLabelName: []index.CardinalityStat{
+			{Name: []byte("__name__"), Value: 2},
+		},
+		SeriesCountByLabelValuePair: []index.CardinalityStat{
+			{Name: []byte("__name__=foo"), Value: 2},
+		},
+		Blocks: []index.BlockCardinality{
+			{BlockStart: xtime.Now().Truncate(time.Hour), NumSeries: 3, NewSeries: 1, RemovedSeries: 2},
+		},
+	}
+
+	require.Equal(t, result, convert.FromRPCCardinalityResult(convert.ToRPCCardinalityResult(result)))
+}
+
 func TestConvertAggregateRawQueryRequest(t *testing.T) {
 	var (
 		seriesLimit       int64 = 10
@@ -277,6 +408,9 @@ func TestToRPCError(t *testing.T) {
 		convert.ToRPCError(xerrors.Wrap(limitErr, "wrap")),
 	)
 
+	seriesLimitErr := limits.NewSeriesLimitExceededError("series limit")
+	require.Equal(t, tterrors.NewResourceExhaustedError(seriesLimitErr), convert.ToRPCError(seriesLimitErr))
+
 	require.Equal(t, tterrors.NewBadRequestError(invalidParamsErr), convert.ToRPCError(invalidParamsErr))
 	require.Equal(
 		t,
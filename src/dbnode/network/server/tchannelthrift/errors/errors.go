@@ -82,6 +82,14 @@ func NewWriteBatchRawError(index int, err error) *rpc.WriteBatchRawError {
 	return batchErr
 }
 
+// NewResourceExhaustedWriteBatchRawError creates a new resource exhausted write batch error.
+func NewResourceExhaustedWriteBatchRawError(index int, err error) *rpc.WriteBatchRawError {
+	batchErr := rpc.NewWriteBatchRawError()
+	batchErr.Index = int64(index)
+	batchErr.Err = NewResourceExhaustedError(err)
+	return batchErr
+}
+
 // NewBadRequestWriteBatchRawError creates a new bad request write batch error
 func NewBadRequestWriteBatchRawError(index int, err error) *rpc.WriteBatchRawError {
 	batchErr := rpc.NewWriteBatchRawError()
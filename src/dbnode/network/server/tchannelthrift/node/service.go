@@ -35,6 +35,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
 	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift"
@@ -114,6 +115,8 @@ type serviceMetrics struct {
 	fetchBlocksMetadata     instrument.MethodMetrics
 	repair                  instrument.MethodMetrics
 	truncate                instrument.MethodMetrics
+	deleteSeries            instrument.MethodMetrics
+	cardinality             instrument.MethodMetrics
 	fetchBatchRawRPCS       tally.Counter
 	fetchBatchRaw           instrument.BatchMethodMetrics
 	writeBatchRawRPCs       tally.Counter
@@ -138,6 +141,8 @@ func newServiceMetrics(scope tally.Scope, opts instrument.TimerOptions) serviceM
 		fetchBlocksMetadata:     instrument.NewMethodMetrics(scope, "fetchBlocksMetadata", opts),
 		repair:                  instrument.NewMethodMetrics(scope, "repair", opts),
 		truncate:                instrument.NewMethodMetrics(scope, "truncate", opts),
+		deleteSeries:            instrument.NewMethodMetrics(scope, "deleteSeries", opts),
+		cardinality:             instrument.NewMethodMetrics(scope, "cardinality", opts),
 		fetchBatchRawRPCS:       scope.Counter("fetchBatchRaw-rpcs"),
 		fetchBatchRaw:           instrument.NewBatchMethodMetrics(scope, "fetchBatchRaw", opts),
 		writeBatchRawRPCs:       scope.Counter("writeBatchRaw-rpcs"),
@@ -848,6 +853,21 @@ func (s *service) fetchTaggedIter(
 	tagEncoder := s.pools.tagEncoder.Get()
 	ctx.RegisterFinalizer(tagEncoder)
 
+	var downsampler *resultDownsampler
+	if fetchData && opts.DownsampleStep > 0 {
+		// NB: the datapoints of schema namespaces are protobuf messages which
+		// cannot be consolidated so they are always returned as is.
+		nsCtx := namespace.NewContextFor(ns, db.Options().SchemaRegistry())
+		if nsCtx.Schema == nil {
+			downsampler = &resultDownsampler{
+				step:           opts.DownsampleStep,
+				downsampleType: opts.DownsampleType,
+				iterPool:       db.Options().MultiReaderIteratorPool(),
+				encoderPool:    db.Options().EncoderPool(),
+			}
+		}
+	}
+
 	return newFetchTaggedResultsIter(fetchTaggedResultsIterOpts{
 		queryResult:     queryResult,
 		queryOpts:       opts,
@@ -861,6 +881,7 @@ func (s *service) fetchTaggedIter(
 		blockPermits:    permits,
 		requireNoWait:   req.RequireNoWait,
 		indexWaited:     queryResult.Waited,
+		downsampler:     downsampler,
 	}), nil
 }
 
@@ -925,6 +946,7 @@ type fetchTaggedResultsIterOpts struct {
 	blockPermits    permits.Permits
 	requireNoWait   bool
 	indexWaited     int
+	downsampler     *resultDownsampler
 }
 
 func newFetchTaggedResultsIter(opts fetchTaggedResultsIterOpts) FetchTaggedResultsIter { //nolint: gocritic
@@ -964,6 +986,7 @@ func (i *fetchTaggedResultsIter) Next(ctx context.Context) bool {
 				docReader:   i.docReader,
 				tagEncoder:  i.tagEncoder,
 				iOpts:       i.iOpts,
+				downsampler: i.downsampler,
 			}
 			if i.fetchData {
 				// NB(r): Use a bytes ID here so that this ID doesn't need to be
@@ -1124,6 +1147,7 @@ type idResult struct {
 	blockReaders     [][]xio.BlockReader
 	quotaUsed        int64
 	iOpts            instrument.Options
+	downsampler      *resultDownsampler
 }
 
 func (i *idResult) ID() []byte {
@@ -1147,6 +1171,16 @@ func (i *idResult) WriteTags(dst []byte) ([]byte, error) {
 
 func (i *idResult) WriteSegments(ctx context.Context, dst []*rpc.Segments) ([]*rpc.Segments, error) {
 	dst = dst[:0]
+	if i.downsampler != nil {
+		segments, err := i.downsampler.readSegments(ctx, i.blockReaders)
+		if err != nil {
+			return nil, err
+		}
+		if segments != nil {
+			dst = append(dst, segments)
+		}
+		return dst, nil
+	}
 	for _, blockReaders := range i.blockReaders {
 		segments, err := readEncodedResultSegment(ctx, blockReaders)
 		if err != nil {
@@ -1159,6 +1193,71 @@ func (i *idResult) WriteSegments(ctx context.Context, dst []*rpc.Segments) ([]*r
 	return dst, nil
 }
 
+// resultDownsampler consolidates the datapoints of each step window of the
+// series read by a fetchTagged request that asks for downsampled data.
+type resultDownsampler struct {
+	step           time.Duration
+	downsampleType encoding.DownsampleType
+	iterPool       encoding.MultiReaderIteratorPool
+	encoderPool    encoding.EncoderPool
+}
+
+// readSegments decodes the blocks read for a series and returns the
+// downsampled datapoints re-encoded as a single merged segment spanning all
+// of the blocks, the wire format is unchanged so clients decode it as usual.
+func (d *resultDownsampler) readSegments(
+	ctx context.Context,
+	blockReaders [][]xio.BlockReader,
+) (*rpc.Segments, error) {
+	blockReaders, err := xio.FilterEmptyBlockReadersSliceOfSlicesInPlace(blockReaders)
+	if err != nil {
+		return nil, convert.ToRPCError(err)
+	}
+	if len(blockReaders) == 0 {
+		return nil, nil
+	}
+
+	var (
+		first = blockReaders[0][0]
+		last  = blockReaders[len(blockReaders)-1][0]
+		start = first.Start
+		end   = last.Start.Add(last.BlockSize)
+	)
+	multiIter := d.iterPool.Get()
+	multiIter.ResetSliceOfSlices(
+		xio.NewReaderSliceOfSlicesFromBlockReadersIterator(blockReaders), nil)
+	iter := encoding.NewDownsampleIterator(multiIter, d.step, d.downsampleType)
+	defer iter.Close()
+
+	encoder := d.encoderPool.Get()
+	encoder.Reset(start, 0, nil)
+	defer encoder.Close()
+
+	for iter.Next() {
+		dp, unit, annotation := iter.Current()
+		if err := encoder.Encode(dp, unit, annotation); err != nil {
+			return nil, convert.ToRPCError(err)
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return nil, convert.ToRPCError(err)
+	}
+
+	stream, ok := encoder.Stream(ctx)
+	if !ok {
+		return nil, nil
+	}
+	segments, rpcErr := readEncodedResultSegment(ctx, []xio.BlockReader{{
+		SegmentReader: stream,
+		Start:         start,
+		BlockSize:     end.Sub(start),
+	}})
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	return segments, nil
+}
+
 func (s *service) Aggregate(tctx thrift.Context, req *rpc.AggregateQueryRequest) (*rpc.AggregateQueryResult_, error) {
 	db, err := s.startReadRPCWithDB()
 	if err != nil {
@@ -2211,6 +2310,61 @@ func (s *service) Truncate(tctx thrift.Context, req *rpc.TruncateRequest) (r *rp
 	return res, nil
 }
 
+func (s *service) DeleteSeries(tctx thrift.Context, req *rpc.DeleteSeriesRequest) (*rpc.DeleteSeriesResult_, error) {
+	db, err := s.startRPCWithDB()
+	if err != nil {
+		return nil, err
+	}
+
+	callStart := s.nowFn()
+	ctx := tchannelthrift.Context(tctx)
+	ns, query, start, end, err := convert.FromRPCDeleteSeriesRequest(req)
+	if err != nil {
+		s.metrics.deleteSeries.ReportError(s.nowFn().Sub(callStart))
+		return nil, tterrors.NewBadRequestError(err)
+	}
+
+	deleted, err := db.DeleteSeries(ctx, ns, query, start, end)
+	if err != nil {
+		s.metrics.deleteSeries.ReportError(s.nowFn().Sub(callStart))
+		return nil, convert.ToRPCError(err)
+	}
+
+	res := rpc.NewDeleteSeriesResult_()
+	res.NumSeries = deleted
+
+	s.metrics.deleteSeries.ReportSuccess(s.nowFn().Sub(callStart))
+
+	return res, nil
+}
+
+func (s *service) Cardinality(tctx thrift.Context, req *rpc.CardinalityRequest) (*rpc.CardinalityResult_, error) {
+	db, err := s.startRPCWithDB()
+	if err != nil {
+		return nil, err
+	}
+
+	callStart := s.nowFn()
+	ctx := tchannelthrift.Context(tctx)
+	ns, opts, err := convert.FromRPCCardinalityRequest(req)
+	if err != nil {
+		s.metrics.cardinality.ReportError(s.nowFn().Sub(callStart))
+		return nil, tterrors.NewBadRequestError(err)
+	}
+
+	result, err := db.Cardinality(ctx, ns, opts)
+	if err != nil {
+		s.metrics.cardinality.ReportError(s.nowFn().Sub(callStart))
+		return nil, convert.ToRPCError(err)
+	}
+
+	res := convert.ToRPCCardinalityResult(result)
+
+	s.metrics.cardinality.ReportSuccess(s.nowFn().Sub(callStart))
+
+	return res, nil
+}
+
 func (s *service) GetPersistRateLimit(
 	ctx thrift.Context,
 ) (*rpc.NodePersistRateLimitResult_, error) {
@@ -2794,6 +2948,14 @@ func (r *writeBatchPooledReq) HandleError(index int, err error) {
 		return
 	}
 
+	if limits.IsSeriesLimitExceededError(err) {
+		r.nonRetryableErrors++
+		r.errs = append(
+			r.errs,
+			tterrors.NewResourceExhaustedWriteBatchRawError(index, err))
+		return
+	}
+
 	if xerrors.IsInvalidParams(err) {
 		r.nonRetryableErrors++
 		r.errs = append(
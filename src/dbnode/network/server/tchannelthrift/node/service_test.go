@@ -37,6 +37,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/uber/tchannel-go/thrift"
 
+	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
 	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift"
@@ -2017,6 +2018,112 @@ func TestServiceFetchTaggedNoData(t *testing.T) {
 	}
 }
 
+func TestServiceFetchTaggedDownsample(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockDB.EXPECT().IsOverloaded().Return(false)
+
+	service := NewService(mockDB, testTChannelThriftOptions).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	start := xtime.Now().Truncate(time.Hour).Add(-2 * time.Hour)
+	end := start.Add(2 * time.Hour)
+	nsID := "metrics"
+
+	enc := testStorageOpts.EncoderPool().Get()
+	enc.Reset(start, 0, nil)
+	for _, dp := range []ts.Datapoint{
+		{TimestampNanos: start.Add(10 * time.Second), Value: 1},
+		{TimestampNanos: start.Add(time.Minute), Value: 2},
+		{TimestampNanos: start.Add(70 * time.Second), Value: 3},
+		{TimestampNanos: start.Add(100 * time.Second), Value: 4},
+	} {
+		require.NoError(t, enc.Encode(dp, xtime.Second, nil))
+	}
+	stream, _ := enc.Stream(ctx)
+	mockDB.EXPECT().
+		ReadEncoded(gomock.Any(), ident.NewIDMatcher(nsID), ident.NewIDMatcher("foo"), start, end).
+		Return(&series.FakeBlockReaderIter{
+			Readers: [][]xio.BlockReader{{
+				xio.BlockReader{
+					SegmentReader: stream,
+					Start:         start,
+					BlockSize:     2 * time.Hour,
+				},
+			}},
+		}, nil)
+
+	req := idx.NewTermQuery([]byte("foo"), []byte("bar"))
+	qry := index.Query{Query: req}
+
+	md := doc.Metadata{
+		ID:     ident.BytesID("foo"),
+		Fields: []doc.Field{{Name: []byte("foo"), Value: []byte("bar")}},
+	}
+	resMap := index.NewQueryResults(ident.StringID(nsID),
+		index.QueryResultsOptions{}, testIndexOptions)
+	resMap.Map().Set(md.ID, doc.NewDocumentFromMetadata(md))
+	mockDB.EXPECT().QueryIDs(
+		gomock.Any(),
+		ident.NewIDMatcher(nsID),
+		index.NewQueryMatcher(qry),
+		index.QueryOptions{
+			StartInclusive: start,
+			EndExclusive:   end,
+			DownsampleStep: time.Minute,
+			DownsampleType: encoding.DownsampleLast,
+		}).Return(index.QueryResult{Results: resMap, Exhaustive: true}, nil)
+
+	startNanos, err := convert.ToValue(start, rpc.TimeType_UNIX_NANOSECONDS)
+	require.NoError(t, err)
+	endNanos, err := convert.ToValue(end, rpc.TimeType_UNIX_NANOSECONDS)
+	require.NoError(t, err)
+
+	data, err := idx.Marshal(req)
+	require.NoError(t, err)
+	step := int64(time.Minute)
+	r, err := service.FetchTagged(tctx, &rpc.FetchTaggedRequest{
+		NameSpace:           []byte(nsID),
+		Query:               data,
+		RangeStart:          startNanos,
+		RangeEnd:            endNanos,
+		FetchData:           true,
+		DownsampleStepNanos: &step,
+		DownsampleType:      rpc.DownsampleTypePtr(rpc.DownsampleType_LAST),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(r.Elements))
+	require.Equal(t, 1, len(r.Elements[0].Segments))
+
+	merged := r.Elements[0].Segments[0].Merged
+	require.NotNil(t, merged)
+	require.Equal(t, int64(start), merged.GetStartTime())
+	require.Equal(t, int64(2*time.Hour), merged.GetBlockSize())
+
+	segment := ts.NewSegment(checked.NewBytes(merged.Head, nil),
+		checked.NewBytes(merged.Tail, nil), 0, ts.FinalizeNone)
+	iter := testStorageOpts.ReaderIteratorPool().Get()
+	iter.Reset(xio.NewSegmentReader(segment), nil)
+	defer iter.Close()
+
+	var actual []ts.Datapoint
+	for iter.Next() {
+		dp, _, _ := iter.Current()
+		actual = append(actual, dp)
+	}
+	require.NoError(t, iter.Err())
+	require.Equal(t, []ts.Datapoint{
+		{TimestampNanos: start.Add(time.Minute), Value: 2},
+		{TimestampNanos: start.Add(100 * time.Second), Value: 4},
+	}, actual)
+}
+
 func TestServiceFetchTaggedErrs(t *testing.T) {
 	ctrl := xtest.NewController(t)
 	defer ctrl.Finish()
@@ -3125,6 +3232,78 @@ func TestServiceWriteTaggedBatchRawUnknownError(t *testing.T) {
 	require.Equal(t, convert.ToRPCError(unknownErr), err)
 }
 
+func TestServiceWriteTaggedBatchRawSeriesLimitExceeded(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+
+	opts := tchannelthrift.NewOptions()
+
+	service := NewService(mockDB, opts).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	nsID := "metrics"
+	limitErr := limits.NewSeriesLimitExceededError("series limit exceeded")
+	values := []struct {
+		id        string
+		tagEncode string
+		t         time.Time
+		v         float64
+	}{
+		{"foo", "a|b", time.Now().Truncate(time.Second), 12.34},
+		{"bar", "c|dd", time.Now().Truncate(time.Second), 42.42},
+	}
+
+	writeBatch := writes.NewWriteBatch(len(values), ident.StringID(nsID), nil)
+	mockDB.EXPECT().
+		BatchWriter(ident.NewIDMatcher(nsID), len(values)).
+		Return(writeBatch, nil)
+
+	mockDB.EXPECT().
+		WriteTaggedBatch(ctx, ident.NewIDMatcher(nsID), writeBatch, gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			_ ident.ID,
+			_ writes.BatchWriter,
+			errHandler storage.IndexedErrorHandler,
+		) error {
+			errHandler.HandleError(1, limitErr)
+			return nil
+		})
+
+	var elements []*rpc.WriteTaggedBatchRawRequestElement
+	for _, w := range values {
+		elem := &rpc.WriteTaggedBatchRawRequestElement{
+			ID:          []byte(w.id),
+			EncodedTags: []byte(w.tagEncode),
+			Datapoint: &rpc.Datapoint{
+				Timestamp:         w.t.Unix(),
+				TimestampTimeType: rpc.TimeType_UNIX_SECONDS,
+				Value:             w.v,
+			},
+		}
+		elements = append(elements, elem)
+	}
+
+	mockDB.EXPECT().IsOverloaded().Return(false)
+	err := service.WriteTaggedBatchRaw(tctx, &rpc.WriteTaggedBatchRawRequest{
+		NameSpace: []byte(nsID),
+		Elements:  elements,
+	})
+	require.Error(t, err)
+
+	batchErrs, ok := err.(*rpc.WriteBatchRawErrors)
+	require.True(t, ok)
+	require.Equal(t, []*rpc.WriteBatchRawError{
+		tterrors.NewResourceExhaustedWriteBatchRawError(1, limitErr),
+	}, batchErrs.Errors)
+}
+
 func TestServiceRepair(t *testing.T) {
 	ctrl := xtest.NewController(t)
 	defer ctrl.Finish()
@@ -3170,6 +3349,101 @@ func TestServiceTruncate(t *testing.T) {
 	assert.Equal(t, truncated, r.NumSeries)
 }
 
+func TestServiceDeleteSeries(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockDB.EXPECT().IsOverloaded().Return(false)
+
+	service := NewService(mockDB, testTChannelThriftOptions).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	var (
+		nsID    = "metrics"
+		start   = xtime.Now().Add(-2 * time.Hour).Truncate(time.Second)
+		end     = start.Add(time.Hour)
+		deleted = int64(3)
+	)
+
+	req, err := idx.NewRegexpQuery([]byte("foo"), []byte("b.*"))
+	require.NoError(t, err)
+	data, err := idx.Marshal(req)
+	require.NoError(t, err)
+
+	mockDB.EXPECT().DeleteSeries(gomock.Any(), ident.NewIDMatcher(nsID),
+		index.NewQueryMatcher(index.Query{Query: req}), start, end).
+		Return(deleted, nil)
+
+	r, err := service.DeleteSeries(tctx, &rpc.DeleteSeriesRequest{
+		NameSpace:  []byte(nsID),
+		Query:      data,
+		RangeStart: start.Seconds(),
+		RangeEnd:   end.Seconds(),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, deleted, r.NumSeries)
+}
+
+func TestServiceCardinality(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockDB.EXPECT().IsOverloaded().Return(false)
+
+	service := NewService(mockDB, testTChannelThriftOptions).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	var (
+		nsID   = "metrics"
+		start  = xtime.Now().Add(-2 * time.Hour).Truncate(time.Second)
+		end    = start.Add(time.Hour)
+		limit  = int64(3)
+		result = index.CardinalityResult{
+			NumSeries: 2,
+			SeriesCountByMetricName: []index.CardinalityStat{
+				{Name: []byte("foo"), Value: 2},
+			},
+			Blocks: []index.BlockCardinality{
+				{BlockStart: start.Truncate(time.Hour), NumSeries: 2, NewSeries: 2},
+			},
+		}
+	)
+
+	mockDB.EXPECT().Cardinality(gomock.Any(), ident.NewIDMatcher(nsID),
+		index.CardinalityOptions{
+			StartInclusive: start,
+			EndExclusive:   end,
+			Limit:          int(limit),
+			NameField:      []byte("__name__"),
+		}).
+		Return(result, nil)
+
+	r, err := service.Cardinality(tctx, &rpc.CardinalityRequest{
+		NameSpace:  []byte(nsID),
+		RangeStart: start.Seconds(),
+		RangeEnd:   end.Seconds(),
+		Limit:      &limit,
+		NameTag:    []byte("__name__"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), r.NumSeries)
+	require.Len(t, r.SeriesCountByMetricName, 1)
+	assert.Equal(t, []byte("foo"), r.SeriesCountByMetricName[0].Name)
+	assert.Equal(t, int64(2), r.SeriesCountByMetricName[0].Value)
+	require.Len(t, r.Blocks, 1)
+	assert.Equal(t, int64(2), r.Blocks[0].NewSeries)
+}
+
 func TestServiceSetPersistRateLimit(t *testing.T) {
 	ctrl := xtest.NewController(t)
 	defer ctrl.Finish()
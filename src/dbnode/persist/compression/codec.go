@@ -0,0 +1,108 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compression
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+var (
+	zstdCodecOnce sync.Once
+	zstdCodecErr  error
+	zstdCodecInst *zstdCodec
+)
+
+// NewCodec returns the codec for a compression type, NoneType has no codec
+// and nil is returned for it.
+func NewCodec(t Type) (Codec, error) {
+	switch t {
+	case NoneType:
+		return nil, nil
+	case SnappyType:
+		return snappyCodec{}, nil
+	case ZstdType:
+		// NB: zstd encoders and decoders hold onto large buffers and are
+		// safe for concurrent use so a single instance is shared.
+		zstdCodecOnce.Do(func() {
+			zstdCodecInst, zstdCodecErr = newZstdCodec()
+		})
+		if zstdCodecErr != nil {
+			return nil, zstdCodecErr
+		}
+		return zstdCodecInst, nil
+	default:
+		return nil, fmt.Errorf("unknown compression type: %d", t)
+	}
+}
+
+type snappyCodec struct{}
+
+func (c snappyCodec) Type() Type {
+	return SnappyType
+}
+
+func (c snappyCodec) Compress(dst, src []byte) []byte {
+	return snappy.Encode(dst[:cap(dst)], src)
+}
+
+func (c snappyCodec) Decompress(dst, src []byte) ([]byte, error) {
+	return snappy.Decode(dst[:cap(dst)], src)
+}
+
+type zstdCodec struct {
+	encoder *zstd.Encoder
+	decoder *zstd.Decoder
+}
+
+func newZstdCodec() (*zstdCodec, error) {
+	// NB: the fileset index entries already checksum the data so there is
+	// no need for the per frame checksums.
+	encoder, err := zstd.NewWriter(nil, zstd.WithEncoderCRC(false))
+	if err != nil {
+		return nil, err
+	}
+
+	// NB: the decoder is shared for the lifetime of the process, it is
+	// only used with DecodeAll which is safe for concurrent use.
+	decoder, err := zstd.NewReader(nil,
+		zstd.WithDecoderConcurrency(1),
+		zstd.WithDecoderLowmem(true))
+	if err != nil {
+		return nil, err
+	}
+	return &zstdCodec{encoder: encoder, decoder: decoder}, nil
+}
+
+func (c *zstdCodec) Type() Type {
+	return ZstdType
+}
+
+func (c *zstdCodec) Compress(dst, src []byte) []byte {
+	return c.encoder.EncodeAll(src, dst[:0])
+}
+
+func (c *zstdCodec) Decompress(dst, src []byte) ([]byte, error) {
+	return c.decoder.DecodeAll(src, dst[:0])
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compression
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestCodecRoundTrip(t *testing.T) {
+	src := bytes.Repeat([]byte("fileset compression "), 100)
+	for _, typ := range []Type{SnappyType, ZstdType} {
+		t.Run(typ.String(), func(t *testing.T) {
+			codec, err := NewCodec(typ)
+			require.NoError(t, err)
+			require.Equal(t, typ, codec.Type())
+
+			compressed := codec.Compress(nil, src)
+			assert.True(t, len(compressed) < len(src))
+
+			decompressed, err := codec.Decompress(nil, compressed)
+			require.NoError(t, err)
+			assert.Equal(t, src, decompressed)
+
+			// Reuse buffers that are large enough.
+			buf := make([]byte, 0, 2*len(src))
+			decompressed, err = codec.Decompress(buf, compressed)
+			require.NoError(t, err)
+			assert.Equal(t, src, decompressed)
+			assert.Equal(t, &buf[:1][0], &decompressed[0])
+
+			_, err = codec.Decompress(nil, src)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNewCodecNoneType(t *testing.T) {
+	codec, err := NewCodec(NoneType)
+	require.NoError(t, err)
+	assert.Nil(t, codec)
+
+	_, err = NewCodec(Type(100))
+	assert.Error(t, err)
+}
+
+func TestTypeValidate(t *testing.T) {
+	for _, typ := range ValidTypes() {
+		assert.NoError(t, typ.Validate())
+	}
+	assert.Error(t, Type(100).Validate())
+}
+
+func TestTypeYAML(t *testing.T) {
+	var cfg struct {
+		Compression Type `yaml:"compression"`
+	}
+	require.NoError(t, yaml.Unmarshal([]byte("compression: zstd\n"), &cfg))
+	assert.Equal(t, ZstdType, cfg.Compression)
+
+	out, err := yaml.Marshal(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "compression: zstd\n", string(out))
+
+	require.NoError(t, yaml.Unmarshal([]byte("compression: \"\"\n"), &cfg))
+	assert.Equal(t, NoneType, cfg.Compression)
+
+	assert.Error(t, yaml.Unmarshal([]byte("compression: lz4\n"), &cfg))
+}
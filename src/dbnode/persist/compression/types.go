@@ -0,0 +1,122 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package compression provides the codecs used to compress the contents of
+// data filesets.
+package compression
+
+import (
+	"fmt"
+)
+
+// Type is the type of compression applied to the contents of a data fileset.
+type Type uint8
+
+const (
+	// NoneType indicates the contents of a fileset are not compressed.
+	NoneType Type = iota
+	// SnappyType indicates the contents of a fileset are compressed with snappy.
+	SnappyType
+	// ZstdType indicates the contents of a fileset are compressed with zstd.
+	ZstdType
+)
+
+var validTypes = []Type{
+	NoneType,
+	SnappyType,
+	ZstdType,
+}
+
+// ValidTypes returns the valid compression types.
+func ValidTypes() []Type {
+	return validTypes
+}
+
+func (t Type) String() string {
+	switch t {
+	case NoneType:
+		return "none"
+	case SnappyType:
+		return "snappy"
+	case ZstdType:
+		return "zstd"
+	default:
+		return "unknown"
+	}
+}
+
+// Validate validates the compression type.
+func (t Type) Validate() error {
+	for _, valid := range validTypes {
+		if valid == t {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid compression type '%d': should be one of %v",
+		t, validTypes)
+}
+
+// ParseType parses a string for a compression type.
+func ParseType(str string) (Type, error) {
+	for _, valid := range validTypes {
+		if str == valid.String() {
+			return valid, nil
+		}
+	}
+	return 0, fmt.Errorf("unrecognized compression type: %v", str)
+}
+
+// MarshalYAML marshals a compression type.
+func (t Type) MarshalYAML() (interface{}, error) {
+	return t.String(), nil
+}
+
+// UnmarshalYAML unmarshals a compression type.
+func (t *Type) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+	if str == "" {
+		*t = NoneType
+		return nil
+	}
+	value, err := ParseType(str)
+	if err != nil {
+		return fmt.Errorf("invalid compression type '%s' valid types are: %v",
+			str, validTypes)
+	}
+	*t = value
+	return nil
+}
+
+// Codec compresses and decompresses byte slices, it is safe for concurrent use.
+type Codec interface {
+	// Type returns the compression type of the codec.
+	Type() Type
+
+	// Compress compresses src reusing the capacity of dst when possible and
+	// returns the compressed bytes.
+	Compress(dst, src []byte) []byte
+
+	// Decompress decompresses src reusing the capacity of dst when possible
+	// and returns the decompressed bytes.
+	Decompress(dst, src []byte) ([]byte, error)
+}
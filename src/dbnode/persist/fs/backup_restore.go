@@ -0,0 +1,82 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"time"
+)
+
+const (
+	backupRestoreFileName     = "backup_restore.json"
+	backupRestoreTempFileName = backupRestoreFileName + ".tmp"
+)
+
+// BackupRestore records that the initial bootstrap of the data directory
+// restored a backup, or found none to restore.
+type BackupRestore struct {
+	// HostID is the ID of the host the backup was taken from.
+	HostID string `json:"hostID"`
+	// BackupID is the ID of the backup restored, empty if there was none.
+	BackupID string `json:"backupID,omitempty"`
+	// RestoredAt is the time the backup was restored.
+	RestoredAt time.Time `json:"restoredAt"`
+}
+
+// BackupRestoreFilePath returns the path to the file recording the backup
+// restored into the data directory.
+func BackupRestoreFilePath(prefix string) string {
+	return path.Join(prefix, backupRestoreFileName)
+}
+
+// ReadBackupRestore reads the backup restored into the data directory, it
+// returns false if no backup restore has been recorded.
+func ReadBackupRestore(opts Options) (BackupRestore, bool, error) {
+	var restore BackupRestore
+	data, err := ioutil.ReadFile(BackupRestoreFilePath(opts.FilePathPrefix()))
+	if os.IsNotExist(err) {
+		return restore, false, nil
+	}
+	if err != nil {
+		return restore, false, err
+	}
+
+	if err := json.Unmarshal(data, &restore); err != nil {
+		return restore, false, fmt.Errorf("invalid backup restore file: %w", err)
+	}
+	return restore, true, nil
+}
+
+// WriteBackupRestore records the backup restored into the data directory.
+func WriteBackupRestore(opts Options, restore BackupRestore) error {
+	data, err := json.Marshal(restore)
+	if err != nil {
+		return err
+	}
+
+	prefix := opts.FilePathPrefix()
+	return writeFileAtomically(opts, prefix, BackupRestoreFilePath(prefix),
+		path.Join(prefix, backupRestoreTempFileName), data)
+}
@@ -77,6 +77,7 @@ func (c *cloner) Clone(src FileSetID, dest FileSetID, destBlocksize time.Duratio
 			Shard:      dest.Shard,
 			BlockStart: dest.Blockstart,
 		},
+		Compression: reader.Status().Compression,
 	}
 	if err := writer.Open(writerOpts); err != nil {
 		return fmt.Errorf("unable to open fileset writer: %v", err)
@@ -0,0 +1,603 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package fs
+
+import (
+	stdctx "context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/x/clock"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/ident"
+	xresource "github.com/m3db/m3/src/x/resource"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+const (
+	coldTierFileSuffix      = "coldtier"
+	coldTierKeyPrefix       = "coldtier"
+	coldTierTempFilePattern = ".coldtier-*"
+	defaultColdTierCacheTTL = time.Hour
+
+	defaultColdTierOrphanGracePeriod = 24 * time.Hour
+)
+
+// coldTierOffloadedFileSuffixes are the suffixes of the files of a data
+// fileset that are uploaded to the blob store and removed from disk when the
+// data fileset is offloaded.
+var coldTierOffloadedFileSuffixes = []string{
+	indexFileSuffix,
+	summariesFileSuffix,
+	bloomFilterFileSuffix,
+	dataFileSuffix,
+}
+
+var (
+	errColdTierStoreNotSet       = errors.New("cold tier store is not set")
+	errColdTierHostIDNotSet      = errors.New("cold tier host ID is not set")
+	errColdTierCacheTTLNegative  = errors.New("cold tier cache TTL must not be negative")
+	errColdTierGraceNegative     = errors.New("cold tier orphan grace period must not be negative")
+	errColdTierFileSetIncomplete = errors.New("cold tier cannot offload an incomplete data fileset")
+)
+
+// ColdTierOptions are the options of a cold tier.
+type ColdTierOptions struct {
+	// Store is the blob store offloaded files are uploaded to.
+	Store ColdTierStore
+	// HostID prefixes the keys of the offloaded files so that hosts can share
+	// a blob store.
+	HostID string
+	// CacheTTL is how long fetched files are cached on disk, defaults to an
+	// hour when zero.
+	CacheTTL time.Duration
+	// OrphanGracePeriod is how long files in the blob store must have been
+	// orphaned before they are deleted, defaults to a day when zero.
+	OrphanGracePeriod time.Duration
+	// FilesystemOptions are the filesystem options of the offloaded filesets.
+	FilesystemOptions Options
+}
+
+// ColdTierBoundary returns the block start before which the blocks of a
+// namespace are in the cold tier, false if the namespace has no cold tier.
+func ColdTierBoundary(opts namespace.Options, now xtime.UnixNano) (xtime.UnixNano, bool) {
+	age := opts.ColdTierAge()
+	if age <= 0 {
+		return 0, false
+	}
+	return now.Add(-age).Truncate(opts.RetentionOptions().BlockSize()), true
+}
+
+type coldTierMetrics struct {
+	offloaded      tally.Counter
+	fetched        tally.Counter
+	fetchErrors    tally.Counter
+	evicted        tally.Counter
+	orphansDeleted tally.Counter
+}
+
+func newColdTierMetrics(scope tally.Scope) coldTierMetrics {
+	return coldTierMetrics{
+		offloaded:      scope.Counter("offloaded-filesets"),
+		fetched:        scope.Counter("fetched-filesets"),
+		fetchErrors:    scope.Counter("fetch-errors"),
+		evicted:        scope.Counter("evicted-filesets"),
+		orphansDeleted: scope.Counter("orphans-deleted"),
+	}
+}
+
+type coldTier struct {
+	sync.Mutex
+
+	store             ColdTierStore
+	hostID            string
+	cacheTTL          time.Duration
+	orphanGracePeriod time.Duration
+	filePathPrefix    string
+	newFileMode       os.FileMode
+	newDirectoryMode  os.FileMode
+	nowFn             clock.NowFn
+	logger            *zap.Logger
+	metrics           coldTierMetrics
+
+	// fetching holds the data filesets being fetched by their marker file.
+	fetching map[string]*sync.WaitGroup
+	// refs counts the fetches of the data filesets by their marker file whose
+	// files have not been opened yet, they are not evicted meanwhile.
+	refs map[string]int
+	// orphanedAt holds the time the files in the blob store were first found
+	// orphaned at by their key.
+	orphanedAt map[string]time.Time
+}
+
+// NewColdTier returns a new cold tier.
+func NewColdTier(opts ColdTierOptions) (ColdTier, error) {
+	if opts.Store == nil {
+		return nil, errColdTierStoreNotSet
+	}
+	if opts.HostID == "" {
+		return nil, errColdTierHostIDNotSet
+	}
+	if opts.CacheTTL < 0 {
+		return nil, errColdTierCacheTTLNegative
+	}
+	if opts.OrphanGracePeriod < 0 {
+		return nil, errColdTierGraceNegative
+	}
+	cacheTTL := opts.CacheTTL
+	if cacheTTL == 0 {
+		cacheTTL = defaultColdTierCacheTTL
+	}
+	orphanGracePeriod := opts.OrphanGracePeriod
+	if orphanGracePeriod == 0 {
+		orphanGracePeriod = defaultColdTierOrphanGracePeriod
+	}
+	fsOpts := opts.FilesystemOptions
+	if fsOpts == nil {
+		fsOpts = NewOptions()
+	}
+	iOpts := fsOpts.InstrumentOptions()
+	return &coldTier{
+		store:             opts.Store,
+		hostID:            opts.HostID,
+		cacheTTL:          cacheTTL,
+		orphanGracePeriod: orphanGracePeriod,
+		filePathPrefix:    fsOpts.FilePathPrefix(),
+		newFileMode:       fsOpts.NewFileMode(),
+		newDirectoryMode:  fsOpts.NewDirectoryMode(),
+		nowFn:             fsOpts.ClockOptions().NowFn(),
+		logger:            iOpts.Logger(),
+		metrics:           newColdTierMetrics(iOpts.MetricsScope().SubScope("cold-tier")),
+		fetching:          make(map[string]*sync.WaitGroup),
+		refs:              make(map[string]int),
+		orphanedAt:        make(map[string]time.Time),
+	}, nil
+}
+
+func (c *coldTier) CacheTTL() time.Duration {
+	return c.cacheTTL
+}
+
+func (c *coldTier) Offload(
+	namespace ident.ID,
+	shard uint32,
+	before xtime.UnixNano,
+) (int, error) {
+	filesets, err := DataFiles(c.filePathPrefix, namespace, shard)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		offloaded int
+		seen      = make(map[xtime.UnixNano]struct{})
+		multiErr  = xerrors.NewMultiError()
+	)
+	for _, fileset := range filesets {
+		blockStart := fileset.ID.BlockStart
+		if !blockStart.Before(before) {
+			continue
+		}
+		if _, ok := seen[blockStart]; ok {
+			continue
+		}
+		seen[blockStart] = struct{}{}
+
+		latest, ok := filesets.LatestVolumeForBlock(blockStart)
+		if !ok || isColdTierOffloaded(latest) {
+			continue
+		}
+		if err := c.offload(latest.ID); err != nil {
+			multiErr = multiErr.Add(fmt.Errorf(
+				"error offloading data fileset of namespace %s shard %d block %s volume %d: %w",
+				namespace.String(), shard, blockStart.String(), latest.ID.VolumeIndex, err))
+			continue
+		}
+		offloaded++
+		c.metrics.offloaded.Inc(1)
+	}
+	return offloaded, multiErr.FinalError()
+}
+
+func (c *coldTier) offload(id FileSetFileIdentifier) error {
+	fileSet, ok, err := c.fileSet(id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errColdTierFileSetIncomplete
+	}
+	complete, err := CompleteCheckpointFileExists(fileSet.checkpoint)
+	if err != nil {
+		return err
+	}
+	if !complete {
+		return errColdTierFileSetIncomplete
+	}
+
+	for _, p := range fileSet.offloaded {
+		if err := c.upload(p); err != nil {
+			return err
+		}
+	}
+
+	// The marker is written once all the files are in the blob store, the
+	// files are only removed from disk after that.
+	if err := ioutil.WriteFile(fileSet.marker, nil, c.newFileMode); err != nil {
+		return err
+	}
+	return DeleteFiles(fileSet.offloaded)
+}
+
+func (c *coldTier) upload(p string) error {
+	key, err := c.key(p)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(p) //nolint:gosec
+	if err != nil {
+		return err
+	}
+	defer f.Close() //nolint:errcheck
+	return c.store.Put(stdctx.Background(), key, f)
+}
+
+func (c *coldTier) Fetch(id FileSetFileIdentifier) (xresource.SimpleCloser, error) {
+	fileSet, ok, err := c.fileSet(id)
+	if err != nil || !ok {
+		return noopColdTierRelease, err
+	}
+
+	// Hold a reference before checking the files so that they are not evicted
+	// until the caller has opened them.
+	c.Lock()
+	c.refs[fileSet.marker]++
+	c.Unlock()
+	release := xresource.SimpleCloserFn(func() {
+		c.Lock()
+		if c.refs[fileSet.marker]--; c.refs[fileSet.marker] <= 0 {
+			delete(c.refs, fileSet.marker)
+		}
+		c.Unlock()
+	})
+
+	for {
+		offloaded, err := FileExists(fileSet.marker)
+		if err != nil {
+			release()
+			return noopColdTierRelease, err
+		}
+		if !offloaded {
+			return release, nil
+		}
+
+		c.Lock()
+		if wg, ok := c.fetching[fileSet.marker]; ok {
+			// Wait for the concurrent fetch and check the files again, they
+			// are fetched by this call if the concurrent fetch failed.
+			c.Unlock()
+			wg.Wait()
+			continue
+		}
+		wg := &sync.WaitGroup{}
+		wg.Add(1)
+		c.fetching[fileSet.marker] = wg
+		c.Unlock()
+
+		err = c.fetch(fileSet)
+
+		c.Lock()
+		delete(c.fetching, fileSet.marker)
+		c.Unlock()
+		wg.Done()
+
+		if err != nil {
+			c.metrics.fetchErrors.Inc(1)
+			release()
+			return noopColdTierRelease, err
+		}
+		return release, nil
+	}
+}
+
+func (c *coldTier) fetch(fileSet coldTierFileSet) error {
+	fetched := false
+	for _, p := range fileSet.offloaded {
+		exists, err := FileExists(p)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+		if err := c.download(p); err != nil {
+			return err
+		}
+		fetched = true
+	}
+	if !fetched {
+		return nil
+	}
+
+	// The modification time of the marker is the time the files were fetched
+	// at, cached files are evicted based on it.
+	now := c.nowFn()
+	if err := os.Chtimes(fileSet.marker, now, now); err != nil {
+		return err
+	}
+	c.metrics.fetched.Inc(1)
+	return nil
+}
+
+func (c *coldTier) download(p string) error {
+	key, err := c.key(p)
+	if err != nil {
+		return err
+	}
+	r, err := c.store.Get(stdctx.Background(), key)
+	if err != nil {
+		return fmt.Errorf("error fetching %s from cold tier: %w", key, err)
+	}
+	defer r.Close() //nolint:errcheck
+
+	f, err := ioutil.TempFile(filepath.Dir(p), coldTierTempFilePattern)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()           //nolint:errcheck
+		os.Remove(f.Name()) //nolint:errcheck
+		return err
+	}
+	if err := f.Chmod(c.newFileMode); err != nil {
+		f.Close()           //nolint:errcheck
+		os.Remove(f.Name()) //nolint:errcheck
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name()) //nolint:errcheck
+		return err
+	}
+	return os.Rename(f.Name(), p)
+}
+
+func (c *coldTier) Evict(namespace ident.ID, shard uint32) (int, error) {
+	filesets, err := DataFiles(c.filePathPrefix, namespace, shard)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		evicted  int
+		expired  = c.nowFn().Add(-c.cacheTTL)
+		multiErr = xerrors.NewMultiError()
+	)
+	for _, fileset := range filesets {
+		var (
+			marker string
+			cached []string
+		)
+		for _, p := range fileset.AbsoluteFilePaths {
+			if hasFileSetFileSuffix(p, coldTierFileSuffix) {
+				marker = p
+				continue
+			}
+			if _, ok := coldTierOffloadedFileSuffix(p); ok {
+				cached = append(cached, p)
+			}
+		}
+		if marker == "" || len(cached) == 0 {
+			continue
+		}
+
+		ok, err := c.evict(marker, cached, expired)
+		if err != nil {
+			multiErr = multiErr.Add(err)
+			continue
+		}
+		if ok {
+			evicted++
+			c.metrics.evicted.Inc(1)
+		}
+	}
+	return evicted, multiErr.FinalError()
+}
+
+// evict removes the cached files of an offloaded data fileset fetched before
+// the expiry time, unless they are being fetched or have not been opened yet.
+func (c *coldTier) evict(marker string, cached []string, expired time.Time) (bool, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	if _, ok := c.fetching[marker]; ok || c.refs[marker] > 0 {
+		return false, nil
+	}
+	info, err := os.Stat(marker)
+	if err != nil {
+		return false, err
+	}
+	if info.ModTime().After(expired) {
+		return false, nil
+	}
+	if err := DeleteFiles(cached); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (c *coldTier) DeleteOrphans() (int, error) {
+	ctx := stdctx.Background()
+	keyPrefix := c.keyPrefix()
+	keys, err := c.store.List(ctx, keyPrefix)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		deleted    int
+		now        = c.nowFn()
+		orphanedAt = make(map[string]time.Time, len(c.orphanedAt))
+		orphaned   = make(map[string]bool)
+		multiErr   = xerrors.NewMultiError()
+	)
+	for _, key := range keys {
+		p := filepath.Join(c.filePathPrefix, filepath.FromSlash(strings.TrimPrefix(key, keyPrefix)))
+		suffix, ok := coldTierOffloadedFileSuffix(p)
+		if !ok {
+			continue
+		}
+
+		filePathPrefix := strings.TrimSuffix(p, separator+suffix+fileSuffix)
+		isOrphaned, ok := orphaned[filePathPrefix]
+		if !ok {
+			isOrphaned, err = isColdTierOrphaned(filePathPrefix)
+			if err != nil {
+				multiErr = multiErr.Add(err)
+				continue
+			}
+			orphaned[filePathPrefix] = isOrphaned
+		}
+		if !isOrphaned {
+			continue
+		}
+
+		// Files are only deleted once they have been orphaned for the grace
+		// period, so that the files of a data directory being restored are
+		// not deleted right away.
+		firstOrphanedAt, ok := c.orphanedAt[key]
+		if !ok {
+			firstOrphanedAt = now
+		}
+		if now.Sub(firstOrphanedAt) < c.orphanGracePeriod {
+			orphanedAt[key] = firstOrphanedAt
+			continue
+		}
+
+		if err := c.store.Delete(ctx, key); err != nil {
+			orphanedAt[key] = firstOrphanedAt
+			multiErr = multiErr.Add(err)
+			continue
+		}
+		deleted++
+		c.metrics.orphansDeleted.Inc(1)
+	}
+	c.orphanedAt = orphanedAt
+	return deleted, multiErr.FinalError()
+}
+
+// isColdTierOrphaned returns true if neither the marker nor the checkpoint
+// file of a data fileset are on disk, which means the data fileset has been
+// removed rather than being offloaded.
+func isColdTierOrphaned(filePathPrefix string) (bool, error) {
+	exists, err := FileExists(filePathPrefix + separator + coldTierFileSuffix + fileSuffix)
+	if err != nil || exists {
+		return false, err
+	}
+	exists, err = CompleteCheckpointFileExists(filePathPrefix + separator + CheckpointFileSuffix + fileSuffix)
+	if err != nil || exists {
+		return false, err
+	}
+	return true, nil
+}
+
+func (c *coldTier) keyPrefix() string {
+	return c.hostID + "/" + coldTierKeyPrefix + "/"
+}
+
+func (c *coldTier) key(p string) (string, error) {
+	rel, err := filepath.Rel(c.filePathPrefix, p)
+	if err != nil {
+		return "", err
+	}
+	return c.keyPrefix() + filepath.ToSlash(rel), nil
+}
+
+// coldTierFileSet are the paths of the files of a data fileset that are
+// relevant to the cold tier.
+type coldTierFileSet struct {
+	marker     string
+	checkpoint string
+	offloaded  []string
+}
+
+// fileSet returns the paths of the files of a data fileset, false if the
+// data fileset does not exist.
+func (c *coldTier) fileSet(id FileSetFileIdentifier) (coldTierFileSet, bool, error) {
+	shardDir := ShardDataDirPath(c.filePathPrefix, id.Namespace, id.Shard)
+	isLegacy := false
+	if id.VolumeIndex == 0 {
+		var err error
+		isLegacy, err = isFirstVolumeLegacy(shardDir, id.BlockStart, CheckpointFileSuffix)
+		if err == ErrCheckpointFileNotFound {
+			return coldTierFileSet{}, false, nil
+		}
+		if err != nil {
+			return coldTierFileSet{}, false, err
+		}
+	}
+
+	filePath := func(suffix string) string {
+		return dataFilesetPathFromTimeAndIndex(shardDir, id.BlockStart, id.VolumeIndex, suffix, isLegacy)
+	}
+	fileSet := coldTierFileSet{
+		marker:     filePath(coldTierFileSuffix),
+		checkpoint: filePath(CheckpointFileSuffix),
+		offloaded:  make([]string, 0, len(coldTierOffloadedFileSuffixes)),
+	}
+	for _, suffix := range coldTierOffloadedFileSuffixes {
+		fileSet.offloaded = append(fileSet.offloaded, filePath(suffix))
+	}
+	return fileSet, true, nil
+}
+
+func isColdTierOffloaded(fileset FileSetFile) bool {
+	for _, p := range fileset.AbsoluteFilePaths {
+		if hasFileSetFileSuffix(p, coldTierFileSuffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func coldTierOffloadedFileSuffix(p string) (string, bool) {
+	for _, suffix := range coldTierOffloadedFileSuffixes {
+		if hasFileSetFileSuffix(p, suffix) {
+			return suffix, true
+		}
+	}
+	return "", false
+}
+
+func hasFileSetFileSuffix(p string, suffix string) bool {
+	return strings.HasSuffix(p, separator+suffix+fileSuffix)
+}
+
+var noopColdTierRelease = xresource.SimpleCloserFn(func() {})
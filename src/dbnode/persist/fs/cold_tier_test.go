@@ -0,0 +1,273 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package fs
+
+import (
+	"bytes"
+	stdctx "context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/retention"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+type testColdTierStore struct {
+	sync.Mutex
+	objects map[string][]byte
+}
+
+func newTestColdTierStore() *testColdTierStore {
+	return &testColdTierStore{objects: make(map[string][]byte)}
+}
+
+func (s *testColdTierStore) Put(_ stdctx.Context, key string, r io.ReadSeeker) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	s.Lock()
+	s.objects[key] = data
+	s.Unlock()
+	return nil
+}
+
+func (s *testColdTierStore) Get(_ stdctx.Context, key string) (io.ReadCloser, error) {
+	s.Lock()
+	defer s.Unlock()
+	data, ok := s.objects[key]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return ioutil.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *testColdTierStore) List(_ stdctx.Context, prefix string) ([]string, error) {
+	s.Lock()
+	defer s.Unlock()
+	var keys []string
+	for key := range s.objects {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (s *testColdTierStore) Delete(_ stdctx.Context, key string) error {
+	s.Lock()
+	delete(s.objects, key)
+	s.Unlock()
+	return nil
+}
+
+func TestColdTierOffloadFetchEvict(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	entries := []testEntry{
+		{"foo", nil, []byte{1, 2, 3}},
+		{"bar", map[string]string{"baz": "qux"}, []byte{4, 5, 6}},
+	}
+	w := newTestWriter(t, dir)
+	writeTestData(t, w, 0, testWriterStart, entries, persist.FileSetFlushType)
+
+	var (
+		now   = time.Now()
+		store = newTestColdTierStore()
+		opts  = testDefaultOpts.
+			SetFilePathPrefix(dir).
+			SetClockOptions(testDefaultOpts.ClockOptions().SetNowFn(func() time.Time {
+				return now
+			}))
+	)
+	coldTier, err := NewColdTier(ColdTierOptions{
+		Store:             store,
+		HostID:            "host",
+		CacheTTL:          time.Hour,
+		FilesystemOptions: opts,
+	})
+	require.NoError(t, err)
+
+	// Blocks starting at or after the boundary are not offloaded.
+	offloaded, err := coldTier.Offload(testNs1ID, 0, testWriterStart)
+	require.NoError(t, err)
+	require.Equal(t, 0, offloaded)
+
+	offloaded, err = coldTier.Offload(testNs1ID, 0, testWriterStart.Add(testBlockSize))
+	require.NoError(t, err)
+	require.Equal(t, 1, offloaded)
+
+	keys, err := store.List(stdctx.Background(), "host/coldtier/data/testNs/0/")
+	require.NoError(t, err)
+	require.Len(t, keys, len(coldTierOffloadedFileSuffixes))
+
+	shardDir := ShardDataDirPath(dir, testNs1ID, 0)
+	dataPath := FilesetPathFromTimeAndIndex(shardDir, testWriterStart, 0, dataFileSuffix)
+	requireFileExists := func(p string, expected bool) {
+		exists, err := FileExists(p)
+		require.NoError(t, err)
+		require.Equal(t, expected, exists)
+	}
+	requireFileExists(dataPath, false)
+	requireFileExists(FilesetPathFromTimeAndIndex(shardDir, testWriterStart, 0, coldTierFileSuffix), true)
+	exists, err := DataFileSetExists(dir, testNs1ID, 0, testWriterStart, 0)
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	// Offloading is a no-op for offloaded data filesets.
+	offloaded, err = coldTier.Offload(testNs1ID, 0, testWriterStart.Add(testBlockSize))
+	require.NoError(t, err)
+	require.Equal(t, 0, offloaded)
+
+	// Reading fetches the offloaded files.
+	r, err := NewReader(nil, opts.SetColdTier(coldTier))
+	require.NoError(t, err)
+	readTestData(t, r, 0, testWriterStart, entries)
+	requireFileExists(dataPath, true)
+
+	// Cached files are only evicted after the cache TTL.
+	evicted, err := coldTier.Evict(testNs1ID, 0)
+	require.NoError(t, err)
+	require.Equal(t, 0, evicted)
+
+	// Nor while the fetched files have not been opened.
+	release, err := coldTier.Fetch(FileSetFileIdentifier{
+		Namespace:  testNs1ID,
+		Shard:      0,
+		BlockStart: testWriterStart,
+	})
+	require.NoError(t, err)
+	now = now.Add(2 * time.Hour)
+	evicted, err = coldTier.Evict(testNs1ID, 0)
+	require.NoError(t, err)
+	require.Equal(t, 0, evicted)
+	requireFileExists(dataPath, true)
+
+	release.Close()
+	evicted, err = coldTier.Evict(testNs1ID, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, evicted)
+	requireFileExists(dataPath, false)
+
+	// Files in the cold tier are kept as long as the data fileset is on disk.
+	deleted, err := coldTier.DeleteOrphans()
+	require.NoError(t, err)
+	require.Equal(t, 0, deleted)
+
+	// Nor if only the marker is missing.
+	marker := FilesetPathFromTimeAndIndex(shardDir, testWriterStart, 0, coldTierFileSuffix)
+	require.NoError(t, os.Rename(marker, marker+".bak"))
+	deleted, err = coldTier.DeleteOrphans()
+	require.NoError(t, err)
+	require.Equal(t, 0, deleted)
+	require.NoError(t, os.Rename(marker+".bak", marker))
+
+	filesets, err := DataFiles(dir, testNs1ID, 0)
+	require.NoError(t, err)
+	require.NoError(t, DeleteFiles(filesets.Filepaths()))
+
+	// Orphaned files are only deleted after the grace period.
+	deleted, err = coldTier.DeleteOrphans()
+	require.NoError(t, err)
+	require.Equal(t, 0, deleted)
+	require.Len(t, store.objects, len(coldTierOffloadedFileSuffixes))
+
+	now = now.Add(defaultColdTierOrphanGracePeriod)
+	deleted, err = coldTier.DeleteOrphans()
+	require.NoError(t, err)
+	require.Equal(t, len(coldTierOffloadedFileSuffixes), deleted)
+	require.Empty(t, store.objects)
+}
+
+func TestColdTierFetchNotOffloaded(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	w := newTestWriter(t, dir)
+	writeTestData(t, w, 0, testWriterStart, nil, persist.FileSetFlushType)
+
+	store := newTestColdTierStore()
+	coldTier, err := NewColdTier(ColdTierOptions{
+		Store:             store,
+		HostID:            "host",
+		FilesystemOptions: testDefaultOpts.SetFilePathPrefix(dir),
+	})
+	require.NoError(t, err)
+	require.Equal(t, defaultColdTierCacheTTL, coldTier.CacheTTL())
+
+	for _, blockStart := range []xtime.UnixNano{testWriterStart, testWriterStart.Add(testBlockSize)} {
+		release, err := coldTier.Fetch(FileSetFileIdentifier{
+			Namespace:  testNs1ID,
+			Shard:      0,
+			BlockStart: blockStart,
+		})
+		require.NoError(t, err)
+		release.Close()
+	}
+	require.Empty(t, store.objects)
+}
+
+func TestNewColdTierValidation(t *testing.T) {
+	_, err := NewColdTier(ColdTierOptions{HostID: "host"})
+	require.Equal(t, errColdTierStoreNotSet, err)
+
+	_, err = NewColdTier(ColdTierOptions{Store: newTestColdTierStore()})
+	require.Equal(t, errColdTierHostIDNotSet, err)
+
+	_, err = NewColdTier(ColdTierOptions{
+		Store:    newTestColdTierStore(),
+		HostID:   "host",
+		CacheTTL: -time.Second,
+	})
+	require.Equal(t, errColdTierCacheTTLNegative, err)
+
+	_, err = NewColdTier(ColdTierOptions{
+		Store:             newTestColdTierStore(),
+		HostID:            "host",
+		OrphanGracePeriod: -time.Second,
+	})
+	require.Equal(t, errColdTierGraceNegative, err)
+}
+
+func TestColdTierBoundary(t *testing.T) {
+	opts := namespace.NewOptions().
+		SetRetentionOptions(retention.NewOptions().SetBlockSize(2 * time.Hour))
+	now := xtime.UnixNano(0).Add(100 * time.Hour).Add(30 * time.Minute)
+
+	_, ok := ColdTierBoundary(opts, now)
+	require.False(t, ok)
+
+	boundary, ok := ColdTierBoundary(opts.SetColdTierAge(24*time.Hour), now)
+	require.True(t, ok)
+	require.Equal(t, xtime.UnixNano(0).Add(76*time.Hour), boundary)
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package fs
+
+import (
+	"github.com/m3db/m3/src/dbnode/persist/compression"
+	"github.com/m3db/m3/src/dbnode/persist/schema"
+)
+
+// fileSetCodec returns the codec the data and index files of a fileset were
+// compressed with, or nil if they were not compressed.
+func fileSetCodec(info schema.IndexInfo) (compression.Codec, error) {
+	versionChecker := schema.NewVersionChecker(int(info.MajorVersion), int(info.MinorVersion))
+	if !versionChecker.FileSetCompressionEnabled() {
+		return nil, nil
+	}
+	return compression.NewCodec(info.Compression)
+}
+
+// decompressEntryData decompresses the on disk data of an index entry into
+// dst, which is reused if it has enough capacity, and verifies that the
+// result has the uncompressed size recorded in the index entry.
+func decompressEntryData(
+	codec compression.Codec,
+	dst []byte,
+	src []byte,
+	size int64,
+) ([]byte, error) {
+	data, err := codec.Decompress(dst, src)
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) != size {
+		return nil, errReadNotExpectedSize
+	}
+	return data, nil
+}
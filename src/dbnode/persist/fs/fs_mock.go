@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/m3db/m3/src/dbnode/persist/fs (interfaces: DataFileSetWriter,DataFileSetReader,DataFileSetSeeker,IndexFileSetWriter,IndexFileSetReader,IndexSegmentFileSetWriter,IndexSegmentFileSet,IndexSegmentFile,SnapshotMetadataFileWriter,DataFileSetSeekerManager,ConcurrentDataFileSetSeeker,MergeWith,StreamingWriter)
+// Source: github.com/m3db/m3/src/dbnode/persist/fs (interfaces: DataFileSetWriter,DataFileSetReader,DataFileSetSeeker,IndexFileSetWriter,IndexFileSetReader,IndexSegmentFileSetWriter,IndexSegmentFileSet,IndexSegmentFile,SnapshotMetadataFileWriter,DataFileSetSeekerManager,ConcurrentDataFileSetSeeker,MergeWith,StreamingWriter,ColdTier)
 
 // Copyright (c) 2022 Uber Technologies, Inc.
 //
@@ -27,6 +27,7 @@ package fs
 import (
 	"io"
 	"reflect"
+	time0 "time"
 
 	"github.com/m3db/m3/src/dbnode/namespace"
 	persist "github.com/m3db/m3/src/dbnode/persist"
@@ -37,6 +38,7 @@ import (
 	"github.com/m3db/m3/src/x/checked"
 	"github.com/m3db/m3/src/x/context"
 	"github.com/m3db/m3/src/x/ident"
+	"github.com/m3db/m3/src/x/resource"
 	"github.com/m3db/m3/src/x/time"
 
 	"github.com/golang/mock/gomock"
@@ -1277,6 +1279,20 @@ func (m *MockMergeWith) EXPECT() *MockMergeWithMockRecorder {
 	return m.recorder
 }
 
+// DeletedRanges mocks base method.
+func (m *MockMergeWith) DeletedRanges(arg0 ident.ID, arg1 time.UnixNano) time.Ranges {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletedRanges", arg0, arg1)
+	ret0, _ := ret[0].(time.Ranges)
+	return ret0
+}
+
+// DeletedRanges indicates an expected call of DeletedRanges.
+func (mr *MockMergeWithMockRecorder) DeletedRanges(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletedRanges", reflect.TypeOf((*MockMergeWith)(nil).DeletedRanges), arg0, arg1)
+}
+
 // ForEachRemaining mocks base method.
 func (m *MockMergeWith) ForEachRemaining(arg0 context.Context, arg1 time.UnixNano, arg2 ForEachRemainingFn, arg3 namespace.Context) error {
 	m.ctrl.T.Helper()
@@ -1385,3 +1401,100 @@ func (mr *MockStreamingWriterMockRecorder) WriteAll(arg0, arg1, arg2, arg3 inter
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteAll", reflect.TypeOf((*MockStreamingWriter)(nil).WriteAll), arg0, arg1, arg2, arg3)
 }
+
+// MockColdTier is a mock of ColdTier interface.
+type MockColdTier struct {
+	ctrl     *gomock.Controller
+	recorder *MockColdTierMockRecorder
+}
+
+// MockColdTierMockRecorder is the mock recorder for MockColdTier.
+type MockColdTierMockRecorder struct {
+	mock *MockColdTier
+}
+
+// NewMockColdTier creates a new mock instance.
+func NewMockColdTier(ctrl *gomock.Controller) *MockColdTier {
+	mock := &MockColdTier{ctrl: ctrl}
+	mock.recorder = &MockColdTierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockColdTier) EXPECT() *MockColdTierMockRecorder {
+	return m.recorder
+}
+
+// CacheTTL mocks base method.
+func (m *MockColdTier) CacheTTL() time0.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CacheTTL")
+	ret0, _ := ret[0].(time0.Duration)
+	return ret0
+}
+
+// CacheTTL indicates an expected call of CacheTTL.
+func (mr *MockColdTierMockRecorder) CacheTTL() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CacheTTL", reflect.TypeOf((*MockColdTier)(nil).CacheTTL))
+}
+
+// DeleteOrphans mocks base method.
+func (m *MockColdTier) DeleteOrphans() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrphans")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteOrphans indicates an expected call of DeleteOrphans.
+func (mr *MockColdTierMockRecorder) DeleteOrphans() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrphans", reflect.TypeOf((*MockColdTier)(nil).DeleteOrphans))
+}
+
+// Evict mocks base method.
+func (m *MockColdTier) Evict(arg0 ident.ID, arg1 uint32) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Evict", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Evict indicates an expected call of Evict.
+func (mr *MockColdTierMockRecorder) Evict(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Evict", reflect.TypeOf((*MockColdTier)(nil).Evict), arg0, arg1)
+}
+
+// Fetch mocks base method.
+func (m *MockColdTier) Fetch(arg0 FileSetFileIdentifier) (resource.SimpleCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fetch", arg0)
+	ret0, _ := ret[0].(resource.SimpleCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Fetch indicates an expected call of Fetch.
+func (mr *MockColdTierMockRecorder) Fetch(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fetch", reflect.TypeOf((*MockColdTier)(nil).Fetch), arg0)
+}
+
+// Offload mocks base method.
+func (m *MockColdTier) Offload(arg0 ident.ID, arg1 uint32, arg2 time.UnixNano) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Offload", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Offload indicates an expected call of Offload.
+func (mr *MockColdTierMockRecorder) Offload(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Offload", reflect.TypeOf((*MockColdTier)(nil).Offload), arg0, arg1, arg2)
+}
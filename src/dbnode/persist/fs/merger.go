@@ -208,8 +208,13 @@ func (m *merger) Merge(
 
 		// In the special (but common) case that we're just copying the series data from the old file
 		// into the new one without merging or adding any additional data we can avoid recalculating
-		// the checksum.
-		if len(segmentReaders) == 1 && hasInMemoryData == false {
+		// the checksum. Series with deleted datapoints are always re-encoded without them.
+		if deleted := mergeWith.DeletedRanges(id, blockStart); deleted != nil {
+			if _, err := persistIterWithoutDeleted(metadata, segmentReaders, deleted,
+				iterResources, prepared.Persist); err != nil {
+				return closer, err
+			}
+		} else if len(segmentReaders) == 1 && hasInMemoryData == false {
 			segment, err := segmentReaders[0].Segment()
 			if err != nil {
 				return closer, err
@@ -239,9 +244,18 @@ func (m *merger) Merge(
 			segmentReaders = appendBlockReadersToSegmentReaders(segmentReaders, mergeWithData.Blocks)
 
 			metadata := persist.NewMetadata(seriesMetadata)
-			err := persistSegmentReaders(metadata, segmentReaders, iterResources, prepared.Persist)
+			var (
+				persisted = true
+				err       error
+			)
+			if deleted := mergeWith.DeletedRanges(ident.BytesID(seriesMetadata.ID), blockStart); deleted != nil {
+				persisted, err = persistIterWithoutDeleted(metadata, segmentReaders, deleted,
+					iterResources, prepared.Persist)
+			} else {
+				err = persistSegmentReaders(metadata, segmentReaders, iterResources, prepared.Persist)
+			}
 
-			if err == nil {
+			if err == nil && persisted {
 				err = onFlush.OnFlushNewSeries(persist.OnFlushNewSeriesEvent{
 					Shard:      shard,
 					BlockStart: startTime,
@@ -336,23 +350,51 @@ func persistIter(
 	ir iterResources,
 	persistFn persist.DataFn,
 ) error {
+	_, err := persistIterWithoutDeleted(metadata, segReaders, nil, ir, persistFn)
+	return err
+}
+
+// persistIterWithoutDeleted persists the merged datapoints of the segment
+// readers, except for the datapoints within the deleted ranges. It returns
+// false if all datapoints are deleted, in which case nothing is persisted and
+// the metadata is finalized since the writer never takes ownership of it.
+func persistIterWithoutDeleted(
+	metadata persist.Metadata,
+	segReaders []xio.SegmentReader,
+	deleted xtime.Ranges,
+	ir iterResources,
+	persistFn persist.DataFn,
+) (bool, error) {
 	it := ir.multiIter
 	it.Reset(segReaders, ir.blockStart, ir.blockSize, ir.schema)
 	encoder := ir.encoderPool.Get()
 	encoder.Reset(ir.blockStart, ir.blockAllocSize, ir.schema)
 	for it.Next() {
-		if err := encoder.Encode(it.Current()); err != nil {
+		dp, unit, annotation := it.Current()
+		if deleted != nil && deleted.Overlaps(xtime.Range{
+			Start: dp.TimestampNanos,
+			End:   dp.TimestampNanos + 1,
+		}) {
+			continue
+		}
+		if err := encoder.Encode(dp, unit, annotation); err != nil {
 			encoder.Close()
-			return err
+			return false, err
 		}
 	}
 	if err := it.Err(); err != nil {
 		encoder.Close()
-		return err
+		return false, err
+	}
+
+	if deleted != nil && encoder.NumEncoded() == 0 {
+		encoder.Close()
+		metadata.Finalize()
+		return false, nil
 	}
 
 	segment := encoder.Discard()
-	return persistSegment(metadata, segment, persistFn)
+	return true, persistSegment(metadata, segment, persistFn)
 }
 
 func persistSegmentReader(
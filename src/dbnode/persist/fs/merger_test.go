@@ -205,6 +205,91 @@ func TestMergeWithIntersection(t *testing.T) {
 	testMergeWith(t, diskData, mergeTargetData, expected)
 }
 
+func TestMergeWithDeletedRanges(t *testing.T) {
+	// This test scenario is when datapoints on disk and in the merge target
+	// are deleted.
+
+	diskData := newCheckedBytesByIDMap(newCheckedBytesByIDMapOptions{})
+	diskData.Set(id0, datapointsToCheckedBytes(t, []ts.Datapoint{
+		{TimestampNanos: startTime.Add(0 * time.Second), Value: 0},
+		{TimestampNanos: startTime.Add(1 * time.Second), Value: 1},
+		{TimestampNanos: startTime.Add(2 * time.Second), Value: 2},
+		{TimestampNanos: startTime.Add(3 * time.Second), Value: 3},
+	}))
+	diskData.Set(id1, datapointsToCheckedBytes(t, []ts.Datapoint{
+		{TimestampNanos: startTime.Add(1 * time.Second), Value: 4},
+	}))
+	diskData.Set(id2, datapointsToCheckedBytes(t, []ts.Datapoint{
+		{TimestampNanos: startTime.Add(1 * time.Second), Value: 5},
+	}))
+
+	mergeTargetData := newCheckedBytesByIDMap(newCheckedBytesByIDMapOptions{})
+	mergeTargetData.Set(id0, datapointsToCheckedBytes(t, []ts.Datapoint{
+		{TimestampNanos: startTime.Add(4 * time.Second), Value: 6},
+	}))
+	mergeTargetData.Set(id3, datapointsToCheckedBytes(t, []ts.Datapoint{
+		{TimestampNanos: startTime.Add(1 * time.Second), Value: 7},
+		{TimestampNanos: startTime.Add(5 * time.Second), Value: 8},
+	}))
+
+	deleted := map[string]xtime.Ranges{
+		id0.String(): xtime.NewRanges(
+			xtime.Range{Start: startTime.Add(1 * time.Second), End: startTime.Add(3 * time.Second)},
+			xtime.Range{Start: startTime.Add(4 * time.Second), End: startTime.Add(5 * time.Second)},
+		),
+		id1.String(): xtime.NewRanges(xtime.Range{Start: startTime, End: startTime.Add(blockSize)}),
+		id3.String(): xtime.NewRanges(xtime.Range{Start: startTime, End: startTime.Add(2 * time.Second)}),
+	}
+
+	expected := newCheckedBytesByIDMap(newCheckedBytesByIDMapOptions{})
+	expected.Set(id0, datapointsToCheckedBytes(t, []ts.Datapoint{
+		{TimestampNanos: startTime.Add(0 * time.Second), Value: 0},
+		{TimestampNanos: startTime.Add(3 * time.Second), Value: 3},
+	}))
+	expected.Set(id2, datapointsToCheckedBytes(t, []ts.Datapoint{
+		{TimestampNanos: startTime.Add(1 * time.Second), Value: 5},
+	}))
+	expected.Set(id3, datapointsToCheckedBytes(t, []ts.Datapoint{
+		{TimestampNanos: startTime.Add(5 * time.Second), Value: 8},
+	}))
+
+	testMergeWithDeletedRanges(t, diskData, mergeTargetData, deleted, expected)
+}
+
+func TestPersistIterWithoutDeletedFinalizesFullyDeletedSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	id := ident.NewMockID(ctrl)
+	id.EXPECT().Finalize()
+	tagsIter := ident.NewMockTagIterator(ctrl)
+	tagsIter.EXPECT().Close()
+	metadata := persist.NewMetadataFromIDAndTagIterator(id, tagsIter,
+		persist.MetadataOptions{
+			FinalizeID:          true,
+			FinalizeTagIterator: true,
+		})
+
+	segReader := srPool.Get()
+	segReader.Reset(ts.NewSegment(datapointsToCheckedBytes(t, []ts.Datapoint{
+		{TimestampNanos: startTime.Add(1 * time.Second), Value: 1},
+	}), nil, 0, ts.FinalizeHead))
+	defer segReader.Finalize()
+
+	multiIter := multiIterPool.Get()
+	defer multiIter.Close()
+
+	persisted, err := persistIterWithoutDeleted(metadata, []xio.SegmentReader{segReader},
+		xtime.NewRanges(xtime.Range{Start: startTime, End: startTime.Add(blockSize)}),
+		newIterResources(multiIter, startTime, blockSize, 0, nil, encoderPool),
+		func(persist.Metadata, ts.Segment, uint32) error {
+			require.FailNow(t, "fully deleted series should not be persisted")
+			return nil
+		})
+	require.NoError(t, err)
+	require.False(t, persisted)
+}
+
 func TestMergeWithFullIntersection(t *testing.T) {
 	// This test scenario is when the merge target contains only and all data
 	// from disk.
@@ -532,6 +617,16 @@ func testMergeWith(
 	diskData *checkedBytesMap,
 	mergeTargetData *checkedBytesMap,
 	expectedData *checkedBytesMap,
+) {
+	testMergeWithDeletedRanges(t, diskData, mergeTargetData, nil, expectedData)
+}
+
+func testMergeWithDeletedRanges(
+	t *testing.T,
+	diskData *checkedBytesMap,
+	mergeTargetData *checkedBytesMap,
+	deleted map[string]xtime.Ranges,
+	expectedData *checkedBytesMap,
 ) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -570,7 +665,7 @@ func testMergeWith(
 		Shard:      uint32(8),
 		BlockStart: startTime,
 	}
-	mergeWith := mockMergeWithFromData(t, ctrl, diskData, mergeTargetData)
+	mergeWith := mockMergeWithFromData(t, ctrl, diskData, mergeTargetData, deleted)
 	close, err := merger.Merge(fsID, mergeWith, 1, preparer, nsCtx, &persist.NoOpColdFlushNamespace{})
 	require.NoError(t, err)
 	require.False(t, deferClosed)
@@ -655,8 +750,13 @@ func mockMergeWithFromData(
 	ctrl *gomock.Controller,
 	diskData *checkedBytesMap,
 	mergeTargetData *checkedBytesMap,
+	deleted map[string]xtime.Ranges,
 ) *MockMergeWith {
 	mergeWith := NewMockMergeWith(ctrl)
+	mergeWith.EXPECT().DeletedRanges(gomock.Any(), startTime).DoAndReturn(
+		func(id ident.ID, _ xtime.UnixNano) xtime.Ranges {
+			return deleted[id.String()]
+		}).AnyTimes()
 
 	// Get the series IDs in the merge target that does not exist in disk data.
 	// This logic is not tested here because it should be part of tests of the
@@ -0,0 +1,524 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package migration
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/persist/schema"
+	"github.com/m3db/m3/src/dbnode/storage"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+// toBlockSizeTask is an object responsible for re-blocking a fileset written with
+// a previous block size of its namespace into filesets of the current block size.
+type toBlockSizeTask struct {
+	opts TaskOptions
+}
+
+// MigrationTaskToBlockSize returns true or false if a fileset should be re-blocked, which
+// is the case for filesets written with a block size other than the current block size of
+// their namespace. If true, also returns a function that can be used to create a new
+// migration task.
+func MigrationTaskToBlockSize(md namespace.Metadata, info fs.ReadInfoFileResult) (NewTaskFn, bool) {
+	blockSize := md.Options().RetentionOptions().BlockSize()
+	if info.Info.BlockSize == 0 || time.Duration(info.Info.BlockSize) == blockSize {
+		return nil, false
+	}
+	return NewToBlockSizeTask, true
+}
+
+// NewToBlockSizeTask creates a task for re-blocking a fileset into the current block size
+// of its namespace.
+func NewToBlockSizeTask(opts TaskOptions) (Task, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	return &toBlockSizeTask{
+		opts: opts,
+	}, nil
+}
+
+// Run re-blocks the fileset along with the filesets of a previous block size that share
+// blocks of the current block size with it. The re-blocked filesets are removed once all of
+// their data has been written to filesets of the current block size.
+//
+// NB: re-blocking changes the block starts of the filesets of the shard so the returned
+// result is the unchanged info file and callers are expected to re-read the info files.
+func (v *toBlockSizeTask) Run() (fs.ReadInfoFileResult, error) {
+	var (
+		fsOpts         = v.opts.FilesystemOptions()
+		nsMd           = v.opts.NamespaceMetadata()
+		infoFileResult = v.opts.InfoFileResult()
+		retentionOpts  = nsMd.Options().RetentionOptions()
+		blockSize      = retentionOpts.BlockSize()
+		start          = xtime.UnixNano(infoFileResult.Info.BlockStart)
+		end            = start.Add(time.Duration(infoFileResult.Info.BlockSize))
+		now            = xtime.ToUnixNano(fsOpts.ClockOptions().NowFn()())
+	)
+
+	filesets := v.readShardFileSets()
+	if !filesets.hasPrevious(start, infoFileResult.Info.VolumeIndex) {
+		// Already re-blocked along with a neighbouring fileset.
+		return infoFileResult, nil
+	}
+
+	reblocked := xtime.NewRanges()
+	for target := start.Truncate(blockSize); target.Before(end); target = target.Add(blockSize) {
+		// NB: a flushed fileset for a block that can still be written to would prevent
+		// the block from being warm flushed, so such blocks are re-blocked by the shard
+		// once they have been warm flushed, see NewFileSetReblocker.
+		if target.Add(blockSize).Add(retentionOpts.BufferPast()).After(now) {
+			continue
+		}
+		if _, _, err := v.reblock(filesets, target); err != nil {
+			return infoFileResult, err
+		}
+		reblocked.AddRange(xtime.Range{Start: target, End: target.Add(blockSize)})
+	}
+
+	for _, info := range filesets.latestPrevious {
+		remaining := xtime.NewRanges(filesetRange(info))
+		remaining.RemoveRanges(reblocked)
+		if !remaining.IsEmpty() {
+			continue
+		}
+		if err := v.removePrevious(filesets, xtime.UnixNano(info.BlockStart)); err != nil {
+			return infoFileResult, err
+		}
+	}
+
+	return infoFileResult, nil
+}
+
+// fileSetReblocker re-blocks the filesets of a previous block size within blocks of the
+// current block size as they are warm flushed by a live node.
+type fileSetReblocker struct{}
+
+// NewFileSetReblocker returns a storage.FileSetReblocker that re-blocks the filesets of a
+// previous block size that lie within blocks which could not be re-blocked while
+// bootstrapping since they could still be written to.
+//
+// NB: the filesets of a previous block size are left in place, the shard serves them
+// until the re-blocked volume is retrievable and removes them after.
+func NewFileSetReblocker() storage.FileSetReblocker {
+	return fileSetReblocker{}
+}
+
+func (r fileSetReblocker) Reblock(
+	opts storage.Options,
+	md namespace.Metadata,
+	shard uint32,
+	blockStart xtime.UnixNano,
+	volume int,
+) (int, error) {
+	task := &toBlockSizeTask{
+		opts: NewTaskOptions().
+			SetStorageOptions(opts).
+			SetFilesystemOptions(opts.CommitLogOptions().FilesystemOptions()).
+			SetNamespaceMetadata(md).
+			SetShard(shard),
+	}
+
+	reblocked, ok, err := task.reblock(task.readShardFileSets(), blockStart)
+	if err != nil || !ok {
+		return volume, err
+	}
+	return reblocked, nil
+}
+
+// shardFileSets are the info files of the filesets of a shard.
+type shardFileSets struct {
+	// blockSize is the current block size of the namespace.
+	blockSize time.Duration
+	// infos are the info files of all volumes of all filesets.
+	infos []schema.IndexInfo
+	// latestPrevious are the latest volumes of a previous block size of each block start.
+	latestPrevious []schema.IndexInfo
+}
+
+func (v *toBlockSizeTask) readShardFileSets() shardFileSets {
+	var (
+		fsOpts    = v.opts.FilesystemOptions()
+		nsMd      = v.opts.NamespaceMetadata()
+		blockSize = nsMd.Options().RetentionOptions().BlockSize()
+		results   = fs.ReadInfoFiles(fsOpts.FilePathPrefix(), nsMd.ID(), v.opts.Shard(),
+			fsOpts.InfoReaderBufferSize(), fsOpts.DecodingOptions(), persist.FileSetFlushType)
+		filesets = shardFileSets{blockSize: blockSize}
+		latest   = make(map[int64]schema.IndexInfo, len(results))
+	)
+	for _, result := range results {
+		if result.Err.Error() != nil {
+			continue
+		}
+		info := result.Info
+		filesets.infos = append(filesets.infos, info)
+		if time.Duration(info.BlockSize) == blockSize {
+			continue
+		}
+		if curr, ok := latest[info.BlockStart]; !ok || info.VolumeIndex > curr.VolumeIndex {
+			latest[info.BlockStart] = info
+		}
+	}
+	for _, info := range latest {
+		filesets.latestPrevious = append(filesets.latestPrevious, info)
+	}
+	sort.Slice(filesets.latestPrevious, func(i, j int) bool {
+		return filesets.latestPrevious[i].BlockStart < filesets.latestPrevious[j].BlockStart
+	})
+	return filesets
+}
+
+func (f shardFileSets) hasPrevious(blockStart xtime.UnixNano, volume int) bool {
+	for _, info := range f.infos {
+		if info.BlockStart == int64(blockStart) && info.VolumeIndex == volume &&
+			time.Duration(info.BlockSize) != f.blockSize {
+			return true
+		}
+	}
+	return false
+}
+
+// nextVolume returns the volume index for a new volume of a block.
+func (f shardFileSets) nextVolume(blockStart xtime.UnixNano) int {
+	next := 0
+	for _, info := range f.infos {
+		if info.BlockStart == int64(blockStart) && info.VolumeIndex >= next {
+			next = info.VolumeIndex + 1
+		}
+	}
+	return next
+}
+
+// current returns the latest volume of a block if it is of the current block size.
+func (f shardFileSets) current(blockStart xtime.UnixNano) (schema.IndexInfo, bool) {
+	var (
+		latest schema.IndexInfo
+		found  bool
+	)
+	for _, info := range f.infos {
+		if info.BlockStart == int64(blockStart) && (!found || info.VolumeIndex > latest.VolumeIndex) {
+			latest, found = info, true
+		}
+	}
+	return latest, found && time.Duration(latest.BlockSize) == f.blockSize
+}
+
+func filesetRange(info schema.IndexInfo) xtime.Range {
+	start := xtime.UnixNano(info.BlockStart)
+	return xtime.Range{Start: start, End: start.Add(time.Duration(info.BlockSize))}
+}
+
+// reblock writes a new volume of the target block with the data of the filesets of a
+// previous block size that overlap with it, merged with the latest volume of the target
+// block if it already exists. Returns the volume written and whether there was any
+// fileset of a previous block size to re-block.
+func (v *toBlockSizeTask) reblock(
+	filesets shardFileSets,
+	target xtime.UnixNano,
+) (int, bool, error) {
+	var (
+		sOpts       = v.opts.StorageOptions()
+		fsOpts      = v.opts.FilesystemOptions()
+		nsMd        = v.opts.NamespaceMetadata()
+		shard       = v.opts.Shard()
+		targetRange = xtime.Range{Start: target, End: target.Add(filesets.blockSize)}
+		sources     []*blockSizeSource
+	)
+	defer func() {
+		for _, source := range sources {
+			_ = source.reader.Close()
+		}
+	}()
+
+	openSource := func(info schema.IndexInfo) error {
+		reader, err := fs.NewReader(sOpts.BytesPool(), fsOpts)
+		if err != nil {
+			return err
+		}
+		if err := reader.Open(fs.DataReaderOpenOptions{
+			Identifier: fs.FileSetFileIdentifier{
+				Namespace:   nsMd.ID(),
+				Shard:       shard,
+				BlockStart:  xtime.UnixNano(info.BlockStart),
+				VolumeIndex: info.VolumeIndex,
+			},
+			FileSetType:      persist.FileSetFlushType,
+			StreamingEnabled: true,
+		}); err != nil {
+			return fmt.Errorf("could not open fileset of namespace %s shard %d block %d: %w",
+				nsMd.ID(), shard, info.BlockStart, err)
+		}
+		sources = append(sources, &blockSizeSource{reader: reader})
+		return nil
+	}
+
+	for _, info := range filesets.latestPrevious {
+		if filesetRange(info).Overlaps(targetRange) {
+			if err := openSource(info); err != nil {
+				return 0, false, err
+			}
+		}
+	}
+	if len(sources) == 0 {
+		return 0, false, nil
+	}
+
+	// NB: the current volume of the target block, which exists if a previous re-blocking
+	// did not complete or if the block was warm flushed by a live node, is merged last so
+	// that its datapoints take precedence.
+	if info, ok := filesets.current(target); ok {
+		if err := openSource(info); err != nil {
+			return 0, false, err
+		}
+	}
+
+	plannedRecordsCount := 1
+	for _, source := range sources {
+		plannedRecordsCount += source.reader.Entries()
+	}
+
+	writer, err := fs.NewStreamingWriter(fsOpts)
+	if err != nil {
+		return 0, false, err
+	}
+	volume := filesets.nextVolume(target)
+	if err := writer.Open(fs.StreamingWriterOpenOptions{
+		NamespaceID:         nsMd.ID(),
+		ShardID:             shard,
+		BlockStart:          target,
+		BlockSize:           filesets.blockSize,
+		VolumeIndex:         volume,
+		Compression:         nsMd.Options().FileSetCompression(),
+		PlannedRecordsCount: uint(plannedRecordsCount),
+	}); err != nil {
+		return 0, false, err
+	}
+
+	if err := v.mergeSources(sources, targetRange, writer); err != nil {
+		_ = writer.Abort()
+		return 0, false, err
+	}
+
+	if err := writer.Close(); err != nil {
+		return 0, false, err
+	}
+	return volume, true, nil
+}
+
+// mergeSources writes the datapoints of the sources within the target range, series are
+// merged by ID since the sources are ordered by ID.
+func (v *toBlockSizeTask) mergeSources(
+	sources []*blockSizeSource,
+	targetRange xtime.Range,
+	writer fs.StreamingWriter,
+) error {
+	for _, source := range sources {
+		if err := source.next(); err != nil {
+			return err
+		}
+	}
+
+	var (
+		sOpts      = v.opts.StorageOptions()
+		schema     = namespace.NewContextFrom(v.opts.NamespaceMetadata()).Schema
+		datapoints []blockSizeDatapoint
+		series     []*blockSizeSource
+	)
+	for {
+		var id []byte
+		for _, source := range sources {
+			if source.done {
+				continue
+			}
+			if id == nil || bytes.Compare(source.entry.ID, id) < 0 {
+				id = source.entry.ID
+			}
+		}
+		if id == nil {
+			return nil
+		}
+		id = append([]byte(nil), id...)
+
+		series = series[:0]
+		for _, source := range sources {
+			if !source.done && bytes.Equal(source.entry.ID, id) {
+				series = append(series, source)
+			}
+		}
+
+		encodedTags := append([]byte(nil), series[0].entry.EncodedTags...)
+		datapoints = datapoints[:0]
+		for _, source := range series {
+			var err error
+			datapoints, err = decodeDatapoints(sOpts, schema, source.entry.Data, targetRange, datapoints)
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := writeDatapoints(sOpts, schema, targetRange.Start, id, encodedTags,
+			datapoints, writer); err != nil {
+			return err
+		}
+
+		for _, source := range series {
+			if err := source.next(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// removePrevious removes all volumes of a block that were written with a previous block size.
+func (v *toBlockSizeTask) removePrevious(filesets shardFileSets, blockStart xtime.UnixNano) error {
+	var (
+		fsOpts = v.opts.FilesystemOptions()
+		nsMd   = v.opts.NamespaceMetadata()
+	)
+	for _, info := range filesets.infos {
+		if info.BlockStart != int64(blockStart) || time.Duration(info.BlockSize) == filesets.blockSize {
+			continue
+		}
+		if err := fs.DeleteFileSetAt(fsOpts.FilePathPrefix(), nsMd.ID(), v.opts.Shard(),
+			blockStart, info.VolumeIndex); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// blockSizeSource is a fileset read in ID order while re-blocking.
+type blockSizeSource struct {
+	reader fs.DataFileSetReader
+	entry  fs.StreamedDataEntry
+	done   bool
+}
+
+func (s *blockSizeSource) next() error {
+	if s.done {
+		return nil
+	}
+
+	entry, err := s.reader.StreamingRead()
+	if err == io.EOF {
+		s.done = true
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	s.entry = entry
+	return nil
+}
+
+type blockSizeDatapoint struct {
+	ts.Datapoint
+	unit       xtime.Unit
+	annotation ts.Annotation
+}
+
+// decodeDatapoints appends the datapoints of the data within the range, annotations are
+// copied since they are only valid until the next datapoint is read.
+func decodeDatapoints(
+	opts storage.Options,
+	schema namespace.SchemaDescr,
+	data []byte,
+	r xtime.Range,
+	datapoints []blockSizeDatapoint,
+) ([]blockSizeDatapoint, error) {
+	iter := opts.ReaderIteratorPool().Get()
+	defer iter.Close()
+
+	iter.Reset(xio.NewBytesReader64(data), schema)
+	for iter.Next() {
+		dp, unit, ann := iter.Current()
+		if dp.TimestampNanos.Before(r.Start) || !dp.TimestampNanos.Before(r.End) {
+			continue
+		}
+		var annotationCopy ts.Annotation
+		if len(ann) > 0 {
+			annotationCopy = append(annotationCopy, ann...)
+		}
+		datapoints = append(datapoints, blockSizeDatapoint{
+			Datapoint:  dp,
+			unit:       unit,
+			annotation: annotationCopy,
+		})
+	}
+
+	return datapoints, iter.Err()
+}
+
+// writeDatapoints encodes and writes the datapoints of a series, of datapoints with the
+// same timestamp the one decoded last is written.
+func writeDatapoints(
+	opts storage.Options,
+	schema namespace.SchemaDescr,
+	blockStart xtime.UnixNano,
+	id, encodedTags []byte,
+	datapoints []blockSizeDatapoint,
+	writer fs.StreamingWriter,
+) error {
+	sort.SliceStable(datapoints, func(i, j int) bool {
+		return datapoints[i].TimestampNanos < datapoints[j].TimestampNanos
+	})
+
+	encoder := opts.EncoderPool().Get()
+	encoder.Reset(blockStart, len(datapoints), schema)
+	for i, dp := range datapoints {
+		if i+1 < len(datapoints) && datapoints[i+1].TimestampNanos == dp.TimestampNanos {
+			continue
+		}
+		if err := encoder.Encode(dp.Datapoint, dp.unit, dp.annotation); err != nil {
+			encoder.Close()
+			return err
+		}
+	}
+
+	if encoder.NumEncoded() == 0 {
+		encoder.Close()
+		return nil
+	}
+
+	segment := encoder.Discard()
+	defer segment.Finalize()
+
+	var data [][]byte
+	if segment.Head != nil {
+		data = append(data, segment.Head.Bytes())
+	}
+	if segment.Tail != nil {
+		data = append(data, segment.Tail.Bytes())
+	}
+
+	return writer.WriteAll(ident.BytesID(id), encodedTags, data, segment.CalculateChecksum())
+}
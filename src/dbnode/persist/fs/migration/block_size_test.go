@@ -0,0 +1,400 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package migration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/retention"
+	"github.com/m3db/m3/src/dbnode/storage"
+	"github.com/m3db/m3/src/dbnode/storage/block"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/ident"
+	"github.com/m3db/m3/src/x/instrument"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+func TestToBlockSizeRun(t *testing.T) {
+	for _, test := range []struct {
+		name         string
+		oldBlockSize time.Duration
+		newBlockSize time.Duration
+	}{
+		{
+			name:         "larger",
+			oldBlockSize: time.Hour,
+			newBlockSize: 4 * time.Hour,
+		},
+		{
+			name:         "smaller",
+			oldBlockSize: 4 * time.Hour,
+			newBlockSize: time.Hour,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			testToBlockSizeRun(t, test.oldBlockSize, test.newBlockSize)
+		})
+	}
+}
+
+func testToBlockSizeRun(t *testing.T, oldBlockSize, newBlockSize time.Duration) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	var (
+		shard  uint32 = 1
+		nsID          = ident.StringID("foo")
+		now           = xtime.Now().Truncate(24 * time.Hour)
+		start         = now.Add(-48 * time.Hour)
+		end           = start.Add(8 * time.Hour)
+		fsOpts        = fs.NewOptions().
+			SetFilePathPrefix(filepath.Join(dir, "")).
+			SetClockOptions(clock.NewOptions().SetNowFn(now.ToTime))
+		sOpts = storage.DefaultTestOptions()
+	)
+
+	// Write a datapoint every 30 minutes for two series in the previous block size.
+	expected := make(map[string][]ts.Datapoint)
+	for blockStart := start; blockStart.Before(end); blockStart = blockStart.Add(oldBlockSize) {
+		series := make(map[string][]ts.Datapoint)
+		for _, id := range []string{"bar", "baz"} {
+			for at := blockStart; at.Before(blockStart.Add(oldBlockSize)); at = at.Add(30 * time.Minute) {
+				dp := ts.Datapoint{TimestampNanos: at, Value: float64(at.Seconds())}
+				series[id] = append(series[id], dp)
+				expected[id] = append(expected[id], dp)
+			}
+		}
+		writeBlockSizeFileSet(t, fsOpts, sOpts, nsID, shard, blockStart, oldBlockSize, 0, series)
+	}
+
+	md, err := namespace.NewMetadata(nsID, namespace.NewOptions().
+		SetRetentionOptions(retention.NewOptions().
+			SetRetentionPeriod(7*24*time.Hour).
+			SetBlockSize(newBlockSize)))
+	require.NoError(t, err)
+
+	opts, closer := newBlockSizeTaskOptions(t, fsOpts, sOpts, md, shard)
+	defer closer()
+
+	results := fs.ReadInfoFiles(fsOpts.FilePathPrefix(), nsID, shard,
+		fsOpts.InfoReaderBufferSize(), fsOpts.DecodingOptions(), persist.FileSetFlushType)
+	require.Equal(t, int(end.Sub(start)/oldBlockSize), len(results))
+	for _, result := range results {
+		newTaskFn, ok := MigrationTaskToBlockSize(md, result)
+		require.True(t, ok)
+
+		task, err := newTaskFn(opts.SetInfoFileResult(result))
+		require.NoError(t, err)
+		_, err = task.Run()
+		require.NoError(t, err)
+	}
+
+	// Only filesets of the new block size remain and hold all datapoints.
+	results = fs.ReadInfoFiles(fsOpts.FilePathPrefix(), nsID, shard,
+		fsOpts.InfoReaderBufferSize(), fsOpts.DecodingOptions(), persist.FileSetFlushType)
+	require.Equal(t, int(end.Sub(start)/newBlockSize), len(results))
+
+	actual := make(map[string][]ts.Datapoint)
+	for _, result := range results {
+		require.NoError(t, result.Err.Error())
+		require.Equal(t, int64(newBlockSize), result.Info.BlockSize)
+
+		_, ok := MigrationTaskToBlockSize(md, result)
+		require.False(t, ok)
+
+		blockStart := xtime.UnixNano(result.Info.BlockStart)
+		reader, err := fs.NewReader(nil, fsOpts)
+		require.NoError(t, err)
+		require.NoError(t, reader.Open(fs.DataReaderOpenOptions{
+			Identifier: fs.FileSetFileIdentifier{
+				Namespace:   nsID,
+				Shard:       shard,
+				BlockStart:  blockStart,
+				VolumeIndex: result.Info.VolumeIndex,
+			},
+			FileSetType: persist.FileSetFlushType,
+		}))
+		for i := 0; i < reader.Entries(); i++ {
+			id, _, data, _, err := reader.Read()
+			require.NoError(t, err)
+			data.IncRef()
+			datapoints, err := decodeDatapoints(sOpts, nil, data.Bytes(),
+				xtime.Range{Start: start, End: end}, nil)
+			data.DecRef()
+			require.NoError(t, err)
+			for _, dp := range datapoints {
+				require.False(t, dp.TimestampNanos.Before(blockStart))
+				require.True(t, dp.TimestampNanos.Before(blockStart.Add(newBlockSize)))
+				actual[id.String()] = append(actual[id.String()], dp.Datapoint)
+			}
+		}
+		require.NoError(t, reader.Close())
+	}
+	require.Equal(t, expected, actual)
+}
+
+func TestToBlockSizeRunSkipsOpenBlocks(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	var (
+		shard  uint32 = 1
+		nsID          = ident.StringID("foo")
+		now           = xtime.Now().Truncate(24 * time.Hour).Add(time.Hour)
+		fsOpts        = fs.NewOptions().
+			SetFilePathPrefix(filepath.Join(dir, "")).
+			SetClockOptions(clock.NewOptions().SetNowFn(now.ToTime))
+		sOpts      = storage.DefaultTestOptions()
+		blockStart = now.Add(-time.Hour)
+	)
+
+	// The block of the new block size that holds the fileset is still open.
+	writeBlockSizeFileSet(t, fsOpts, sOpts, nsID, shard, blockStart, 30*time.Minute, 0,
+		map[string][]ts.Datapoint{"bar": {{TimestampNanos: blockStart, Value: 1}}})
+
+	md, err := namespace.NewMetadata(nsID, namespace.NewOptions().
+		SetRetentionOptions(retention.NewOptions().
+			SetBlockSize(2*time.Hour)))
+	require.NoError(t, err)
+
+	results := fs.ReadInfoFiles(fsOpts.FilePathPrefix(), nsID, shard,
+		fsOpts.InfoReaderBufferSize(), fsOpts.DecodingOptions(), persist.FileSetFlushType)
+	require.Equal(t, 1, len(results))
+
+	opts, closer := newBlockSizeTaskOptions(t, fsOpts, sOpts, md, shard)
+	defer closer()
+
+	task, err := NewToBlockSizeTask(opts.SetInfoFileResult(results[0]))
+	require.NoError(t, err)
+	_, err = task.Run()
+	require.NoError(t, err)
+
+	after := fs.ReadInfoFiles(fsOpts.FilePathPrefix(), nsID, shard,
+		fsOpts.InfoReaderBufferSize(), fsOpts.DecodingOptions(), persist.FileSetFlushType)
+	require.Equal(t, results[0].Info, after[0].Info)
+	require.Equal(t, 1, len(after))
+}
+
+func TestFileSetReblockerReblock(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	var (
+		shard  uint32 = 1
+		nsID          = ident.StringID("foo")
+		now           = xtime.Now().Truncate(24 * time.Hour).Add(3 * time.Hour)
+		fsOpts        = fs.NewOptions().
+			SetFilePathPrefix(filepath.Join(dir, "")).
+			SetClockOptions(clock.NewOptions().SetNowFn(now.ToTime))
+		sOpts      = storage.DefaultTestOptions()
+		blockStart = now.Add(-3 * time.Hour)
+		halfHour   = blockStart.Add(30 * time.Minute)
+		hour       = blockStart.Add(time.Hour)
+	)
+
+	md, err := namespace.NewMetadata(nsID, namespace.NewOptions().
+		SetRetentionOptions(retention.NewOptions().
+			SetBlockSize(2*time.Hour)))
+	require.NoError(t, err)
+
+	opts, closer := newBlockSizeTaskOptions(t, fsOpts, sOpts, md, shard)
+	defer closer()
+	sOpts = opts.StorageOptions().
+		SetCommitLogOptions(sOpts.CommitLogOptions().SetFilesystemOptions(fsOpts))
+
+	// Filesets of the previous block size within the block and the volume of the block
+	// written by a warm flush of the live node.
+	writeBlockSizeFileSet(t, fsOpts, sOpts, nsID, shard, blockStart, 30*time.Minute, 0,
+		map[string][]ts.Datapoint{"bar": {{TimestampNanos: blockStart, Value: 1}}})
+	writeBlockSizeFileSet(t, fsOpts, sOpts, nsID, shard, halfHour, 30*time.Minute, 0,
+		map[string][]ts.Datapoint{
+			"bar": {{TimestampNanos: halfHour, Value: 2}},
+			"baz": {{TimestampNanos: halfHour, Value: 3}},
+		})
+	writeBlockSizeFileSet(t, fsOpts, sOpts, nsID, shard, blockStart, 2*time.Hour, 1,
+		map[string][]ts.Datapoint{"bar": {
+			{TimestampNanos: blockStart, Value: 10},
+			{TimestampNanos: hour, Value: 11},
+		}})
+
+	reblocker := NewFileSetReblocker()
+	volume, err := reblocker.Reblock(sOpts, md, shard, blockStart, 1)
+	require.NoError(t, err)
+	require.Equal(t, 2, volume)
+
+	// The datapoints of the warm flush take precedence.
+	require.Equal(t, map[string][]ts.Datapoint{
+		"bar": {
+			{TimestampNanos: blockStart, Value: 10},
+			{TimestampNanos: halfHour, Value: 2},
+			{TimestampNanos: hour, Value: 11},
+		},
+		"baz": {{TimestampNanos: halfHour, Value: 3}},
+	}, readBlockSizeFileSet(t, fsOpts, sOpts, nsID, shard, blockStart, volume))
+
+	// The filesets of the previous block size are left for the shard to remove.
+	results := fs.ReadInfoFiles(fsOpts.FilePathPrefix(), nsID, shard,
+		fsOpts.InfoReaderBufferSize(), fsOpts.DecodingOptions(), persist.FileSetFlushType)
+	require.Equal(t, 4, len(results))
+
+	// Blocks without filesets of a previous block size are left as is.
+	volume, err = reblocker.Reblock(sOpts, md, shard, blockStart.Add(-2*time.Hour), 0)
+	require.NoError(t, err)
+	require.Equal(t, 0, volume)
+}
+
+func TestMigrationTaskToBlockSize(t *testing.T) {
+	md, err := namespace.NewMetadata(ident.StringID("foo"), namespace.NewOptions().
+		SetRetentionOptions(retention.NewOptions().SetBlockSize(2*time.Hour)))
+	require.NoError(t, err)
+
+	info := fs.ReadInfoFileResult{}
+	info.Info.BlockSize = int64(2 * time.Hour)
+	_, ok := MigrationTaskToBlockSize(md, info)
+	require.False(t, ok)
+
+	info.Info.BlockSize = int64(time.Hour)
+	_, ok = MigrationTaskToBlockSize(md, info)
+	require.True(t, ok)
+}
+
+func newBlockSizeTaskOptions(
+	t *testing.T,
+	fsOpts fs.Options,
+	sOpts storage.Options,
+	md namespace.Metadata,
+	shard uint32,
+) (TaskOptions, func()) {
+	pm, err := fs.NewPersistManager(fsOpts)
+	require.NoError(t, err)
+	fs.ResetIndexClaimsManagersUnsafe()
+	icm, err := fs.NewIndexClaimsManager(fsOpts)
+	require.NoError(t, err)
+
+	plCache, err := index.NewPostingsListCache(1, index.PostingsListCacheOptions{
+		InstrumentOptions: instrument.NewOptions(),
+	})
+	require.NoError(t, err)
+	stop := plCache.Start()
+
+	opts := NewTaskOptions().
+		SetPersistManager(pm).
+		SetNamespaceMetadata(md).
+		SetStorageOptions(sOpts.
+			SetPersistManager(pm).
+			SetIndexClaimsManager(icm).
+			SetNamespaceInitializer(namespace.NewStaticInitializer([]namespace.Metadata{md})).
+			SetRepairEnabled(false).
+			SetIndexOptions(index.NewOptions().
+				SetPostingsListCache(plCache)).
+			SetBlockLeaseManager(block.NewLeaseManager(nil))).
+		SetShard(shard).
+		SetFilesystemOptions(fsOpts)
+
+	return opts, func() {
+		stop()
+		fs.ResetIndexClaimsManagersUnsafe()
+	}
+}
+
+func writeBlockSizeFileSet(
+	t *testing.T,
+	fsOpts fs.Options,
+	sOpts storage.Options,
+	nsID ident.ID,
+	shard uint32,
+	blockStart xtime.UnixNano,
+	blockSize time.Duration,
+	volume int,
+	series map[string][]ts.Datapoint,
+) {
+	w, err := fs.NewStreamingWriter(fsOpts)
+	require.NoError(t, err)
+	require.NoError(t, w.Open(fs.StreamingWriterOpenOptions{
+		NamespaceID:         nsID,
+		ShardID:             shard,
+		BlockStart:          blockStart,
+		BlockSize:           blockSize,
+		VolumeIndex:         volume,
+		PlannedRecordsCount: uint(len(series)),
+	}))
+
+	// NB: series are written in ID order.
+	for _, id := range []string{"bar", "baz"} {
+		datapoints, ok := series[id]
+		if !ok {
+			continue
+		}
+		encoded := make([]blockSizeDatapoint, 0, len(datapoints))
+		for _, dp := range datapoints {
+			encoded = append(encoded, blockSizeDatapoint{Datapoint: dp, unit: xtime.Second})
+		}
+		require.NoError(t, writeDatapoints(sOpts, nil, blockStart, []byte(id), nil, encoded, w))
+	}
+	require.NoError(t, w.Close())
+}
+
+func readBlockSizeFileSet(
+	t *testing.T,
+	fsOpts fs.Options,
+	sOpts storage.Options,
+	nsID ident.ID,
+	shard uint32,
+	blockStart xtime.UnixNano,
+	volume int,
+) map[string][]ts.Datapoint {
+	reader, err := fs.NewReader(nil, fsOpts)
+	require.NoError(t, err)
+	require.NoError(t, reader.Open(fs.DataReaderOpenOptions{
+		Identifier: fs.FileSetFileIdentifier{
+			Namespace:   nsID,
+			Shard:       shard,
+			BlockStart:  blockStart,
+			VolumeIndex: volume,
+		},
+		FileSetType: persist.FileSetFlushType,
+	}))
+	defer reader.Close()
+
+	series := make(map[string][]ts.Datapoint)
+	for i := 0; i < reader.Entries(); i++ {
+		id, _, data, _, err := reader.Read()
+		require.NoError(t, err)
+		data.IncRef()
+		datapoints, err := decodeDatapoints(sOpts, nil, data.Bytes(),
+			xtime.Range{Start: blockStart, End: blockStart.Add(reader.Status().BlockSize)}, nil)
+		data.DecRef()
+		require.NoError(t, err)
+		for _, dp := range datapoints {
+			series[id.String()] = append(series[id.String()], dp.Datapoint)
+		}
+	}
+	return series
+}
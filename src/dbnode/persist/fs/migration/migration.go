@@ -24,6 +24,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/persist/schema"
 	xtime "github.com/m3db/m3/src/x/time"
 )
 
@@ -44,15 +45,38 @@ type toVersion1_1Task struct {
 	opts TaskOptions
 }
 
+// toVersion1_2Task is an object responsible for migrating a fileset to version 1.2
+// and the fileset compression of its namespace.
+type toVersion1_2Task struct {
+	opts TaskOptions
+}
+
 // MigrationTask returns true or false if a fileset should be migrated. If true, also returns
 // a function that can be used to create a new migration task.
-func MigrationTask(info fs.ReadInfoFileResult) (NewTaskFn, bool) {
+func MigrationTask(_ namespace.Metadata, info fs.ReadInfoFileResult) (NewTaskFn, bool) {
 	if info.Info.MajorVersion == 1 && info.Info.MinorVersion == 0 {
 		return NewToVersion1_1Task, true
 	}
 	return nil, false
 }
 
+// MigrationTaskToVersion1_2 returns true or false if a fileset should be migrated to version 1.2,
+// which is the case for filesets older than version 1.1 or not compressed with the fileset
+// compression of their namespace. If true, also returns a function that can be used to create
+// a new migration task.
+func MigrationTaskToVersion1_2(md namespace.Metadata, info fs.ReadInfoFileResult) (NewTaskFn, bool) {
+	if info.Info.MajorVersion != 1 {
+		return nil, false
+	}
+	// NB: filesets older than version 1.2 do not record a compression and
+	// decode as uncompressed.
+	if info.Info.MinorVersion == 0 ||
+		info.Info.Compression != md.Options().FileSetCompression() {
+		return NewToVersion1_2Task, true
+	}
+	return nil, false
+}
+
 // NewToVersion1_1Task creates a task for migrating a fileset to version 1.1.
 func NewToVersion1_1Task(opts TaskOptions) (Task, error) {
 	if err := opts.Validate(); err != nil {
@@ -65,14 +89,37 @@ func NewToVersion1_1Task(opts TaskOptions) (Task, error) {
 
 // Run executes the steps to bring a fileset to Version 1.1.
 func (v *toVersion1_1Task) Run() (fs.ReadInfoFileResult, error) {
+	return rewriteFileSet(v.opts)
+}
+
+// NewToVersion1_2Task creates a task for migrating a fileset to version 1.2.
+func NewToVersion1_2Task(opts TaskOptions) (Task, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	return &toVersion1_2Task{
+		opts: opts,
+	}, nil
+}
+
+// Run executes the steps to bring a fileset to Version 1.2 and the fileset
+// compression of its namespace.
+func (v *toVersion1_2Task) Run() (fs.ReadInfoFileResult, error) {
+	return rewriteFileSet(v.opts)
+}
+
+// rewriteFileSet rewrites a fileset into a new volume with the current encoder, which
+// generates index files with the entry level checksums at the current version and
+// compresses the fileset with the fileset compression of its namespace.
+func rewriteFileSet(opts TaskOptions) (fs.ReadInfoFileResult, error) {
 	var (
-		sOpts          = v.opts.StorageOptions()
-		fsOpts         = v.opts.FilesystemOptions()
-		newMergerFn    = v.opts.NewMergerFn()
-		nsMd           = v.opts.NamespaceMetadata()
-		infoFileResult = v.opts.InfoFileResult()
-		shard          = v.opts.Shard()
-		persistManager = v.opts.PersistManager()
+		sOpts          = opts.StorageOptions()
+		fsOpts         = opts.FilesystemOptions()
+		newMergerFn    = opts.NewMergerFn()
+		nsMd           = opts.NamespaceMetadata()
+		infoFileResult = opts.InfoFileResult()
+		shard          = opts.Shard()
+		persistManager = opts.PersistManager()
 	)
 	reader, err := fs.NewReader(sOpts.BytesPool(), fsOpts)
 	if err != nil {
@@ -112,7 +159,8 @@ func (v *toVersion1_1Task) Run() (fs.ReadInfoFileResult, error) {
 	}
 
 	infoFileResult.Info.VolumeIndex = newIndex
-	infoFileResult.Info.MinorVersion = 1
+	infoFileResult.Info.MinorVersion = schema.MinorVersion
+	infoFileResult.Info.Compression = nsMd.Options().FileSetCompression()
 
 	return infoFileResult, nil
 }
@@ -34,6 +34,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/digest"
 	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/persist/fs/msgpack"
 	"github.com/m3db/m3/src/dbnode/storage"
@@ -131,6 +132,133 @@ func TestToVersion1_1Run(t *testing.T) {
 	require.Contains(t, err.Error(), "checksum mismatch")
 }
 
+func TestToVersion1_2Run(t *testing.T) {
+	dir := createTempDir(t)
+	filePathPrefix := filepath.Join(dir, "")
+	defer os.RemoveAll(dir)
+
+	var shard uint32 = 1
+	nsID := ident.StringID("foo")
+
+	// Write uncompressed fileset to disk
+	fsOpts := writeUnmigratedData(t, filePathPrefix, nsID, shard)
+
+	results := fs.ReadInfoFiles(filePathPrefix, nsID, shard,
+		fsOpts.InfoReaderBufferSize(), fsOpts.DecodingOptions(), persist.FileSetFlushType)
+	require.Equal(t, 1, len(results))
+	infoFileResult := results[0]
+	require.Equal(t, compression.NoneType, infoFileResult.Info.Compression)
+
+	pm, err := fs.NewPersistManager(
+		fsOpts.SetEncodingOptions(msgpack.DefaultLegacyEncodingOptions))
+	require.NoError(t, err)
+	fs.ResetIndexClaimsManagersUnsafe()
+	icm, err := fs.NewIndexClaimsManager(fsOpts)
+	require.NoError(t, err)
+
+	md, err := namespace.NewMetadata(nsID, namespace.NewOptions().
+		SetFileSetCompression(compression.ZstdType))
+	require.NoError(t, err)
+
+	newTaskFn, ok := MigrationTaskToVersion1_2(md, infoFileResult)
+	require.True(t, ok)
+
+	plCache, err := index.NewPostingsListCache(1, index.PostingsListCacheOptions{
+		InstrumentOptions: instrument.NewOptions(),
+	})
+	require.NoError(t, err)
+	defer plCache.Start()()
+
+	opts := NewTaskOptions().
+		SetNewMergerFn(fs.NewMerger).
+		SetPersistManager(pm).
+		SetNamespaceMetadata(md).
+		SetStorageOptions(storage.DefaultTestOptions().
+			SetPersistManager(pm).
+			SetIndexClaimsManager(icm).
+			SetNamespaceInitializer(namespace.NewStaticInitializer([]namespace.Metadata{md})).
+			SetRepairEnabled(false).
+			SetIndexOptions(index.NewOptions().
+				SetPostingsListCache(plCache)).
+			SetBlockLeaseManager(block.NewLeaseManager(nil))).
+		SetShard(shard).
+		SetInfoFileResult(infoFileResult).
+		SetFilesystemOptions(fsOpts)
+
+	task, err := newTaskFn(opts)
+	require.NoError(t, err)
+
+	updatedInfoFile, err := task.Run()
+	require.NoError(t, err)
+	require.Equal(t, compression.ZstdType, updatedInfoFile.Info.Compression)
+
+	// Read new info file and make sure it matches results returned by task
+	newInfoFd := openFile(t, fsOpts, nsID, shard, updatedInfoFile, "info")
+	newInfoBytes, err := ioutil.ReadAll(newInfoFd)
+	require.NoError(t, err)
+
+	decoder := msgpack.NewDecoder(nil)
+	decoder.Reset(msgpack.NewByteDecoderStream(newInfoBytes))
+	info, err := decoder.DecodeIndexInfo()
+	require.NoError(t, err)
+	require.Equal(t, updatedInfoFile.Info, info)
+
+	// The migrated fileset no longer needs migrating.
+	_, ok = MigrationTaskToVersion1_2(md, updatedInfoFile)
+	require.False(t, ok)
+
+	// Read back the compressed data of the new volume.
+	reader, err := fs.NewReader(nil, fsOpts)
+	require.NoError(t, err)
+	require.NoError(t, reader.Open(fs.DataReaderOpenOptions{
+		Identifier: fs.FileSetFileIdentifier{
+			Namespace:   nsID,
+			Shard:       shard,
+			BlockStart:  xtime.UnixNano(updatedInfoFile.Info.BlockStart),
+			VolumeIndex: updatedInfoFile.Info.VolumeIndex,
+		},
+		FileSetType: persist.FileSetFlushType,
+	}))
+	defer reader.Close()
+
+	id, _, data, checksum, err := reader.Read()
+	require.NoError(t, err)
+	require.Equal(t, "foo", id.String())
+	data.IncRef()
+	require.Equal(t, []byte{1, 2, 3}, data.Bytes())
+	require.Equal(t, digest.Checksum(data.Bytes()), checksum)
+	data.DecRef()
+	require.NoError(t, reader.Validate())
+}
+
+func TestMigrationTaskToVersion1_2(t *testing.T) {
+	md, err := namespace.NewMetadata(ident.StringID("foo"), namespace.NewOptions().
+		SetFileSetCompression(compression.SnappyType))
+	require.NoError(t, err)
+
+	info := fs.ReadInfoFileResult{}
+	info.Info.MajorVersion = 1
+	info.Info.MinorVersion = 2
+	info.Info.Compression = compression.SnappyType
+	_, ok := MigrationTaskToVersion1_2(md, info)
+	require.False(t, ok)
+
+	info.Info.Compression = compression.NoneType
+	_, ok = MigrationTaskToVersion1_2(md, info)
+	require.True(t, ok)
+
+	info.Info.MinorVersion = 0
+	info.Info.Compression = compression.SnappyType
+	_, ok = MigrationTaskToVersion1_2(md, info)
+	require.True(t, ok)
+
+	// Version 1.1 migrations do not consider compression.
+	info.Info.MinorVersion = 1
+	info.Info.Compression = compression.NoneType
+	_, ok = MigrationTask(md, info)
+	require.False(t, ok)
+}
+
 func openFile(
 	t *testing.T,
 	fsOpts fs.Options,
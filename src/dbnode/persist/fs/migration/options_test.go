@@ -46,6 +46,6 @@ func TestOptionsValidate(t *testing.T) {
 	opts := NewOptions()
 	require.NoError(t, opts.Validate())
 
-	require.Error(t, opts.SetTargetMigrationVersion(2).Validate())
+	require.Error(t, opts.SetTargetMigrationVersion(3).Validate())
 	require.Error(t, opts.SetConcurrency(0).Validate())
 }
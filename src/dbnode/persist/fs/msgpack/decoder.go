@@ -28,6 +28,7 @@ import (
 	"gopkg.in/vmihailenco/msgpack.v2"
 
 	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/persist/schema"
 	"github.com/m3db/m3/src/x/pool"
 )
@@ -273,6 +274,11 @@ func (dec *Decoder) decodeIndexInfo() schema.IndexInfo {
 		opts.override = true
 		opts.numExpectedMinFields = 6
 		opts.numExpectedCurrFields = 10
+	case LegacyEncodingIndexVersionV5:
+		// V5 had 11 fields.
+		opts.override = true
+		opts.numExpectedMinFields = 6
+		opts.numExpectedCurrFields = 11
 	}
 
 	numFieldsToSkip, actual, ok := dec.checkNumFieldsFor(indexInfoType, opts)
@@ -325,6 +331,15 @@ func (dec *Decoder) decodeIndexInfo() schema.IndexInfo {
 	// Decode fields added in V5.
 	indexInfo.MinorVersion = dec.decodeVarint()
 
+	// At this point if its a V5 file we've decoded all the available fields.
+	if dec.legacy.DecodeLegacyIndexInfoVersion == LegacyEncodingIndexVersionV5 || actual < 12 {
+		dec.skip(numFieldsToSkip)
+		return indexInfo
+	}
+
+	// Decode fields added in V6.
+	indexInfo.Compression = compression.Type(dec.decodeVarint())
+
 	dec.skip(numFieldsToSkip)
 	return indexInfo
 }
@@ -371,8 +386,13 @@ func (dec *Decoder) checkNumIndexEntryFields() (int, int, bool) {
 		opts.override = true
 		opts.numExpectedMinFields = 5
 		opts.numExpectedCurrFields = 6
+	case LegacyEncodingIndexEntryVersionV3:
+		// V3 had 7 fields.
+		opts.override = true
+		opts.numExpectedMinFields = 5
+		opts.numExpectedCurrFields = 7
 	case LegacyEncodingIndexEntryVersionCurrent:
-		// V3 is current version, no overrides needed
+		// V4 is current version, no overrides needed
 		break
 	default:
 		dec.err = fmt.Errorf("invalid legacyEncodingIndexEntryVersion provided: %v",
@@ -423,6 +443,11 @@ func (dec *Decoder) decodeIndexEntry(bytesPool pool.BytesPool) schema.IndexEntry
 
 	// NB(nate): Any new fields should be parsed here.
 
+	// Decode fields added in V4, a V3 file only has the checksum left.
+	if dec.legacy.DecodeLegacyIndexEntryVersion != LegacyEncodingIndexEntryVersionV3 && actual >= 8 {
+		indexEntry.CompressedSize = dec.decodeVarint()
+	}
+
 	// Intentionally skip any extra fields here as we've stipulated that from V3 onward, IndexEntryChecksum will be the
 	// final field on index entries
 	dec.skip(numFieldsToSkip)
@@ -58,22 +58,24 @@ type Encoder struct {
 type LegacyEncodingIndexInfoVersion int
 
 const (
-	LegacyEncodingIndexVersionCurrent                                = LegacyEncodingIndexVersionV5
+	LegacyEncodingIndexVersionCurrent                                = LegacyEncodingIndexVersionV6
 	LegacyEncodingIndexVersionV1      LegacyEncodingIndexInfoVersion = iota
 	LegacyEncodingIndexVersionV2
 	LegacyEncodingIndexVersionV3
 	LegacyEncodingIndexVersionV4
 	LegacyEncodingIndexVersionV5
+	LegacyEncodingIndexVersionV6
 )
 
 // LegacyEncodingIndexEntryVersion is the encoding/decoding version to use when processing index entries
 type LegacyEncodingIndexEntryVersion int
 
 const (
-	LegacyEncodingIndexEntryVersionCurrent                                 = LegacyEncodingIndexEntryVersionV3
+	LegacyEncodingIndexEntryVersionCurrent                                 = LegacyEncodingIndexEntryVersionV4
 	LegacyEncodingIndexEntryVersionV1      LegacyEncodingIndexEntryVersion = iota
 	LegacyEncodingIndexEntryVersionV2
 	LegacyEncodingIndexEntryVersionV3
+	LegacyEncodingIndexEntryVersionV4
 )
 
 // LegacyEncodingOptions allows you to specify the version to use when encoding/decoding
@@ -151,8 +153,10 @@ func (enc *Encoder) EncodeIndexInfo(info schema.IndexInfo) error {
 		enc.encodeIndexInfoV3(info)
 	case LegacyEncodingIndexVersionV4:
 		enc.encodeIndexInfoV4(info)
-	default:
+	case LegacyEncodingIndexVersionV5:
 		enc.encodeIndexInfoV5(info)
+	default:
+		enc.encodeIndexInfoV6(info)
 	}
 	return enc.err
 }
@@ -173,8 +177,10 @@ func (enc *Encoder) EncodeIndexEntry(entry schema.IndexEntry) error {
 		enc.encodeIndexEntryV1(entry)
 	case LegacyEncodingIndexEntryVersionV2:
 		enc.encodeIndexEntryV2(entry)
-	default:
+	case LegacyEncodingIndexEntryVersionV3:
 		enc.encodeIndexEntryV3(entry, checksumStart)
+	default:
+		enc.encodeIndexEntryV4(entry, checksumStart)
 	}
 	return enc.err
 }
@@ -278,6 +284,21 @@ func (enc *Encoder) encodeIndexInfoV4(info schema.IndexInfo) {
 }
 
 func (enc *Encoder) encodeIndexInfoV5(info schema.IndexInfo) {
+	enc.encodeArrayLenFn(11) // V5 had 11 fields.
+	enc.encodeVarintFn(info.BlockStart)
+	enc.encodeVarintFn(info.BlockSize)
+	enc.encodeVarintFn(info.Entries)
+	enc.encodeVarintFn(info.MajorVersion)
+	enc.encodeIndexSummariesInfo(info.Summaries)
+	enc.encodeIndexBloomFilterInfo(info.BloomFilter)
+	enc.encodeVarintFn(info.SnapshotTime)
+	enc.encodeVarintFn(int64(info.FileType))
+	enc.encodeBytesFn(info.SnapshotID)
+	enc.encodeVarintFn(int64(info.VolumeIndex))
+	enc.encodeVarintFn(info.MinorVersion)
+}
+
+func (enc *Encoder) encodeIndexInfoV6(info schema.IndexInfo) {
 	enc.encodeNumObjectFieldsForFn(indexInfoType)
 	enc.encodeVarintFn(info.BlockStart)
 	enc.encodeVarintFn(info.BlockSize)
@@ -290,6 +311,7 @@ func (enc *Encoder) encodeIndexInfoV5(info schema.IndexInfo) {
 	enc.encodeBytesFn(info.SnapshotID)
 	enc.encodeVarintFn(int64(info.VolumeIndex))
 	enc.encodeVarintFn(info.MinorVersion)
+	enc.encodeVarintFn(int64(info.Compression))
 }
 
 func (enc *Encoder) encodeIndexSummariesInfo(info schema.IndexSummariesInfo) {
@@ -326,6 +348,19 @@ func (enc *Encoder) encodeIndexEntryV2(entry schema.IndexEntry) {
 }
 
 func (enc *Encoder) encodeIndexEntryV3(entry schema.IndexEntry, checksumStart int) {
+	enc.encodeArrayLenFn(7) // V3 had 7 fields.
+	enc.encodeVarintFn(entry.Index)
+	enc.encodeBytesFn(entry.ID)
+	enc.encodeVarintFn(entry.Size)
+	enc.encodeVarintFn(entry.Offset)
+	enc.encodeVarintFn(entry.DataChecksum)
+	enc.encodeBytesFn(entry.EncodedTags)
+
+	checksum := digest.Checksum(enc.Bytes()[checksumStart:])
+	enc.encodeVarintFn(int64(checksum))
+}
+
+func (enc *Encoder) encodeIndexEntryV4(entry schema.IndexEntry, checksumStart int) {
 	enc.encodeNumObjectFieldsForFn(indexEntryType)
 	enc.encodeVarintFn(entry.Index)
 	enc.encodeBytesFn(entry.ID)
@@ -333,6 +368,7 @@ func (enc *Encoder) encodeIndexEntryV3(entry schema.IndexEntry, checksumStart in
 	enc.encodeVarintFn(entry.Offset)
 	enc.encodeVarintFn(entry.DataChecksum)
 	enc.encodeBytesFn(entry.EncodedTags)
+	enc.encodeVarintFn(entry.CompressedSize)
 
 	checksum := digest.Checksum(enc.Bytes()[checksumStart:])
 	enc.encodeVarintFn(int64(checksum))
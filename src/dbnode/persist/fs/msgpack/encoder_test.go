@@ -97,6 +97,7 @@ func testExpectedResultForIndexInfo(t *testing.T, indexInfo schema.IndexInfo) []
 		indexInfo.SnapshotID,
 		int64(indexInfo.VolumeIndex),
 		indexInfo.MinorVersion,
+		int64(indexInfo.Compression),
 	}
 }
 
@@ -114,6 +115,7 @@ func testExpectedResultForIndexEntry(t *testing.T, indexEntry schema.IndexEntry)
 		indexEntry.Offset,
 		indexEntry.DataChecksum,
 		indexEntry.EncodedTags,
+		indexEntry.CompressedSize,
 		int64(testIndexEntryChecksum), // Checksum auto-added to the end of the index entry
 	}
 }
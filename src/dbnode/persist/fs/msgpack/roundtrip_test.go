@@ -27,6 +27,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/persist/schema"
 	xtest "github.com/m3db/m3/src/x/test"
 	xhash "github.com/m3db/m3/src/x/test/hash"
@@ -50,17 +51,20 @@ var (
 		SnapshotID:   []byte("some_bytes"),
 		VolumeIndex:  1,
 		MinorVersion: schema.MinorVersion,
+		Compression:  compression.ZstdType,
 	}
 
-	testIndexEntryChecksum = int64(2611877657)
-	testIndexEntry         = schema.IndexEntry{
-		Index:         234,
-		ID:            []byte("testIndexEntry"),
-		Size:          5456,
-		Offset:        2390423,
-		DataChecksum:  134245634534,
-		IndexChecksum: testIndexEntryChecksum,
-		EncodedTags:   []byte("testEncodedTags"),
+	testIndexEntryChecksum   = int64(3824490552)
+	testIndexEntryV3Checksum = int64(2611877657)
+	testIndexEntry           = schema.IndexEntry{
+		Index:          234,
+		ID:             []byte("testIndexEntry"),
+		Size:           5456,
+		Offset:         2390423,
+		DataChecksum:   134245634534,
+		IndexChecksum:  testIndexEntryChecksum,
+		EncodedTags:    []byte("testEncodedTags"),
+		CompressedSize: 3141,
 	}
 
 	testIndexSummary = schema.IndexSummary{
@@ -103,7 +107,7 @@ func TestIndexInfoRoundtrip(t *testing.T) {
 	require.Equal(t, testIndexInfo, res)
 }
 
-// Make sure the V5 decoding code can handle the V1 file format.
+// Make sure the V6 decoding code can handle the V1 file format.
 func TestIndexInfoRoundTripBackwardsCompatibilityV1(t *testing.T) {
 	var (
 		opts = LegacyEncodingOptions{EncodeLegacyIndexInfoVersion: LegacyEncodingIndexVersionV1}
@@ -122,18 +126,21 @@ func TestIndexInfoRoundTripBackwardsCompatibilityV1(t *testing.T) {
 		currSnapshotID   = testIndexInfo.SnapshotID
 		currVolumeIndex  = testIndexInfo.VolumeIndex
 		currMinorVersion = testIndexInfo.MinorVersion
+		currCompression  = testIndexInfo.Compression
 	)
 	testIndexInfo.SnapshotTime = 0
 	testIndexInfo.FileType = 0
 	testIndexInfo.SnapshotID = nil
 	testIndexInfo.VolumeIndex = 0
 	testIndexInfo.MinorVersion = 0
+	testIndexInfo.Compression = 0
 	defer func() {
 		testIndexInfo.SnapshotTime = currSnapshotTime
 		testIndexInfo.FileType = currFileType
 		testIndexInfo.SnapshotID = currSnapshotID
 		testIndexInfo.VolumeIndex = currVolumeIndex
 		testIndexInfo.MinorVersion = currMinorVersion
+		testIndexInfo.Compression = currCompression
 	}()
 
 	enc.EncodeIndexInfo(testIndexInfo)
@@ -143,7 +150,7 @@ func TestIndexInfoRoundTripBackwardsCompatibilityV1(t *testing.T) {
 	require.Equal(t, testIndexInfo, res)
 }
 
-// Make sure the V1 decoder code can handle the V5 file format.
+// Make sure the V1 decoder code can handle the V6 file format.
 func TestIndexInfoRoundTripForwardsCompatibilityV1(t *testing.T) {
 	var (
 		opts = LegacyEncodingOptions{DecodeLegacyIndexInfoVersion: LegacyEncodingIndexVersionV1}
@@ -160,6 +167,7 @@ func TestIndexInfoRoundTripForwardsCompatibilityV1(t *testing.T) {
 		currSnapshotID   = testIndexInfo.SnapshotID
 		currVolumeIndex  = testIndexInfo.VolumeIndex
 		currMinorVersion = testIndexInfo.MinorVersion
+		currCompression  = testIndexInfo.Compression
 	)
 
 	enc.EncodeIndexInfo(testIndexInfo)
@@ -171,12 +179,14 @@ func TestIndexInfoRoundTripForwardsCompatibilityV1(t *testing.T) {
 	testIndexInfo.SnapshotID = nil
 	testIndexInfo.VolumeIndex = 0
 	testIndexInfo.MinorVersion = 0
+	testIndexInfo.Compression = 0
 	defer func() {
 		testIndexInfo.SnapshotTime = currSnapshotTime
 		testIndexInfo.FileType = currFileType
 		testIndexInfo.SnapshotID = currSnapshotID
 		testIndexInfo.VolumeIndex = currVolumeIndex
 		testIndexInfo.MinorVersion = currMinorVersion
+		testIndexInfo.Compression = currCompression
 	}()
 
 	dec.Reset(NewByteDecoderStream(enc.Bytes()))
@@ -185,7 +195,7 @@ func TestIndexInfoRoundTripForwardsCompatibilityV1(t *testing.T) {
 	require.Equal(t, testIndexInfo, res)
 }
 
-// Make sure the V5 decoding code can handle the V2 file format.
+// Make sure the V6 decoding code can handle the V2 file format.
 func TestIndexInfoRoundTripBackwardsCompatibilityV2(t *testing.T) {
 	var (
 		opts = LegacyEncodingOptions{EncodeLegacyIndexInfoVersion: LegacyEncodingIndexVersionV2}
@@ -203,18 +213,21 @@ func TestIndexInfoRoundTripBackwardsCompatibilityV2(t *testing.T) {
 		currSnapshotID   = testIndexInfo.SnapshotID
 		currVolumeIndex  = testIndexInfo.VolumeIndex
 		currMinorVersion = testIndexInfo.MinorVersion
+		currCompression  = testIndexInfo.Compression
 	)
 	testIndexInfo.SnapshotTime = 0
 	testIndexInfo.FileType = 0
 	testIndexInfo.SnapshotID = nil
 	testIndexInfo.VolumeIndex = 0
 	testIndexInfo.MinorVersion = 0
+	testIndexInfo.Compression = 0
 	defer func() {
 		testIndexInfo.SnapshotTime = currSnapshotTime
 		testIndexInfo.FileType = currFileType
 		testIndexInfo.SnapshotID = currSnapshotID
 		testIndexInfo.VolumeIndex = currVolumeIndex
 		testIndexInfo.MinorVersion = currMinorVersion
+		testIndexInfo.Compression = currCompression
 	}()
 
 	enc.EncodeIndexInfo(testIndexInfo)
@@ -224,7 +237,7 @@ func TestIndexInfoRoundTripBackwardsCompatibilityV2(t *testing.T) {
 	require.Equal(t, testIndexInfo, res)
 }
 
-// Make sure the V2 decoder code can handle the V5 file format.
+// Make sure the V2 decoder code can handle the V6 file format.
 func TestIndexInfoRoundTripForwardsCompatibilityV2(t *testing.T) {
 	var (
 		opts = LegacyEncodingOptions{DecodeLegacyIndexInfoVersion: LegacyEncodingIndexVersionV2}
@@ -238,6 +251,7 @@ func TestIndexInfoRoundTripForwardsCompatibilityV2(t *testing.T) {
 	currSnapshotID := testIndexInfo.SnapshotID
 	currVolumeIndex := testIndexInfo.VolumeIndex
 	currMinorVersion := testIndexInfo.MinorVersion
+	currCompression := testIndexInfo.Compression
 
 	enc.EncodeIndexInfo(testIndexInfo)
 
@@ -246,10 +260,12 @@ func TestIndexInfoRoundTripForwardsCompatibilityV2(t *testing.T) {
 	testIndexInfo.SnapshotID = nil
 	testIndexInfo.VolumeIndex = 0
 	testIndexInfo.MinorVersion = 0
+	testIndexInfo.Compression = 0
 	defer func() {
 		testIndexInfo.SnapshotID = currSnapshotID
 		testIndexInfo.VolumeIndex = currVolumeIndex
 		testIndexInfo.MinorVersion = currMinorVersion
+		testIndexInfo.Compression = currCompression
 	}()
 
 	dec.Reset(NewByteDecoderStream(enc.Bytes()))
@@ -258,7 +274,7 @@ func TestIndexInfoRoundTripForwardsCompatibilityV2(t *testing.T) {
 	require.Equal(t, testIndexInfo, res)
 }
 
-// Make sure the V5 decoding code can handle the V3 file format.
+// Make sure the V6 decoding code can handle the V3 file format.
 func TestIndexInfoRoundTripBackwardsCompatibilityV3(t *testing.T) {
 	var (
 		opts = LegacyEncodingOptions{EncodeLegacyIndexInfoVersion: LegacyEncodingIndexVersionV3}
@@ -273,12 +289,15 @@ func TestIndexInfoRoundTripBackwardsCompatibilityV3(t *testing.T) {
 	var (
 		currVolumeIndex  = testIndexInfo.VolumeIndex
 		currMinorVersion = testIndexInfo.MinorVersion
+		currCompression  = testIndexInfo.Compression
 	)
 	testIndexInfo.VolumeIndex = 0
 	testIndexInfo.MinorVersion = 0
+	testIndexInfo.Compression = 0
 	defer func() {
 		testIndexInfo.VolumeIndex = currVolumeIndex
 		testIndexInfo.MinorVersion = currMinorVersion
+		testIndexInfo.Compression = currCompression
 	}()
 
 	enc.EncodeIndexInfo(testIndexInfo)
@@ -288,7 +307,7 @@ func TestIndexInfoRoundTripBackwardsCompatibilityV3(t *testing.T) {
 	require.Equal(t, testIndexInfo, res)
 }
 
-// Make sure the V3 decoder code can handle the V5 file format.
+// Make sure the V3 decoder code can handle the V6 file format.
 func TestIndexInfoRoundTripForwardsCompatibilityV3(t *testing.T) {
 	var (
 		opts = LegacyEncodingOptions{DecodeLegacyIndexInfoVersion: LegacyEncodingIndexVersionV3}
@@ -301,6 +320,7 @@ func TestIndexInfoRoundTripForwardsCompatibilityV3(t *testing.T) {
 	// because the old decoder won't read the new fields.
 	currVolumeIndex := testIndexInfo.VolumeIndex
 	currMinorVersion := testIndexInfo.MinorVersion
+	currCompression := testIndexInfo.Compression
 
 	enc.EncodeIndexInfo(testIndexInfo)
 
@@ -308,9 +328,11 @@ func TestIndexInfoRoundTripForwardsCompatibilityV3(t *testing.T) {
 	// encoded the data.
 	testIndexInfo.VolumeIndex = 0
 	testIndexInfo.MinorVersion = 0
+	testIndexInfo.Compression = 0
 	defer func() {
 		testIndexInfo.VolumeIndex = currVolumeIndex
 		testIndexInfo.MinorVersion = currMinorVersion
+		testIndexInfo.Compression = currCompression
 	}()
 
 	dec.Reset(NewByteDecoderStream(enc.Bytes()))
@@ -319,7 +341,7 @@ func TestIndexInfoRoundTripForwardsCompatibilityV3(t *testing.T) {
 	require.Equal(t, testIndexInfo, res)
 }
 
-// Make sure the V5 decoding code can handle the V4 file format.
+// Make sure the V6 decoding code can handle the V4 file format.
 func TestIndexInfoRoundTripBackwardsCompatibilityV4(t *testing.T) {
 	var (
 		opts = LegacyEncodingOptions{EncodeLegacyIndexInfoVersion: LegacyEncodingIndexVersionV4}
@@ -332,10 +354,13 @@ func TestIndexInfoRoundTripBackwardsCompatibilityV4(t *testing.T) {
 	// because the new decoder won't try and read the new fields from
 	// the old file format.
 	currMinorVersion := testIndexInfo.MinorVersion
+	currCompression := testIndexInfo.Compression
 
 	testIndexInfo.MinorVersion = 0
+	testIndexInfo.Compression = 0
 	defer func() {
 		testIndexInfo.MinorVersion = currMinorVersion
+		testIndexInfo.Compression = currCompression
 	}()
 
 	enc.EncodeIndexInfo(testIndexInfo)
@@ -345,7 +370,7 @@ func TestIndexInfoRoundTripBackwardsCompatibilityV4(t *testing.T) {
 	require.Equal(t, testIndexInfo, res)
 }
 
-// Make sure the V4 decoder code can handle the V5 file format.
+// Make sure the V4 decoder code can handle the V6 file format.
 func TestIndexInfoRoundTripForwardsCompatibilityV4(t *testing.T) {
 	var (
 		opts = LegacyEncodingOptions{DecodeLegacyIndexInfoVersion: LegacyEncodingIndexVersionV4}
@@ -357,14 +382,71 @@ func TestIndexInfoRoundTripForwardsCompatibilityV4(t *testing.T) {
 	// and then restore them at the end of the test - This is required
 	// because the old decoder won't read the new fields.
 	currMinorVersion := testIndexInfo.MinorVersion
+	currCompression := testIndexInfo.Compression
 
 	enc.EncodeIndexInfo(testIndexInfo)
 
 	// Make sure to zero them before we compare, but after we have
 	// encoded the data.
 	testIndexInfo.MinorVersion = 0
+	testIndexInfo.Compression = 0
 	defer func() {
 		testIndexInfo.MinorVersion = currMinorVersion
+		testIndexInfo.Compression = currCompression
+	}()
+
+	dec.Reset(NewByteDecoderStream(enc.Bytes()))
+	res, err := dec.DecodeIndexInfo()
+	require.NoError(t, err)
+	require.Equal(t, testIndexInfo, res)
+}
+
+// Make sure the V6 decoding code can handle the V5 file format.
+func TestIndexInfoRoundTripBackwardsCompatibilityV5(t *testing.T) {
+	var (
+		opts = LegacyEncodingOptions{EncodeLegacyIndexInfoVersion: LegacyEncodingIndexVersionV5}
+		enc  = newEncoder(opts)
+		dec  = newDecoder(opts, nil)
+	)
+
+	// Set the default values on the fields that did not exist in V5,
+	// and then restore them at the end of the test - This is required
+	// because the new decoder won't try and read the new fields from
+	// the old file format.
+	currCompression := testIndexInfo.Compression
+
+	testIndexInfo.Compression = 0
+	defer func() {
+		testIndexInfo.Compression = currCompression
+	}()
+
+	enc.EncodeIndexInfo(testIndexInfo)
+	dec.Reset(NewByteDecoderStream(enc.Bytes()))
+	res, err := dec.DecodeIndexInfo()
+	require.NoError(t, err)
+	require.Equal(t, testIndexInfo, res)
+}
+
+// Make sure the V5 decoder code can handle the V6 file format.
+func TestIndexInfoRoundTripForwardsCompatibilityV5(t *testing.T) {
+	var (
+		opts = LegacyEncodingOptions{DecodeLegacyIndexInfoVersion: LegacyEncodingIndexVersionV5}
+		enc  = newEncoder(opts)
+		dec  = newDecoder(opts, nil)
+	)
+
+	// Set the default values on the fields that did not exist in V5
+	// and then restore them at the end of the test - This is required
+	// because the old decoder won't read the new fields.
+	currCompression := testIndexInfo.Compression
+
+	enc.EncodeIndexInfo(testIndexInfo)
+
+	// Make sure to zero them before we compare, but after we have
+	// encoded the data.
+	testIndexInfo.Compression = 0
+	defer func() {
+		testIndexInfo.Compression = currCompression
 	}()
 
 	dec.Reset(NewByteDecoderStream(enc.Bytes()))
@@ -385,7 +467,7 @@ func TestIndexEntryRoundtrip(t *testing.T) {
 	require.Equal(t, testIndexEntry, res)
 }
 
-// Make sure the V3 decoding code can handle the V1 file format.
+// Make sure the V4 decoding code can handle the V1 file format.
 func TestIndexEntryRoundTripBackwardsCompatibilityV1(t *testing.T) {
 	ctrl := xtest.NewController(t)
 	defer ctrl.Finish()
@@ -419,10 +501,11 @@ func TestIndexEntryRoundTripBackwardsCompatibilityV1(t *testing.T) {
 	require.NoError(t, err)
 	expected := testIndexEntry
 	expected.IndexChecksum = 0
+	expected.CompressedSize = 0
 	require.Equal(t, expected, res)
 }
 
-// Make sure the V1 decoder code can handle the V3 file format.
+// Make sure the V1 decoder code can handle the V4 file format.
 func TestIndexEntryRoundTripForwardsCompatibilityV1(t *testing.T) {
 	ctrl := xtest.NewController(t)
 	defer ctrl.Finish()
@@ -455,10 +538,11 @@ func TestIndexEntryRoundTripForwardsCompatibilityV1(t *testing.T) {
 	require.NoError(t, err)
 
 	expected.IndexChecksum = 0
+	expected.CompressedSize = 0
 	require.Equal(t, expected, res)
 }
 
-// Make sure the V3 decoding code can handle the V2 file format.
+// Make sure the V4 decoding code can handle the V2 file format.
 func TestIndexEntryRoundTripBackwardsCompatibilityV2(t *testing.T) {
 	var (
 		opts = LegacyEncodingOptions{EncodeLegacyIndexEntryVersion: LegacyEncodingIndexEntryVersionV2,
@@ -478,10 +562,11 @@ func TestIndexEntryRoundTripBackwardsCompatibilityV2(t *testing.T) {
 	require.NoError(t, err)
 	expected := testIndexEntry
 	expected.IndexChecksum = 0
+	expected.CompressedSize = 0
 	require.Equal(t, expected, res)
 }
 
-// Make sure the V2 decoder code can handle the V3 file format.
+// Make sure the V2 decoder code can handle the V4 file format.
 func TestIndexEntryRoundTripForwardsCompatibilityV2(t *testing.T) {
 	var (
 		opts = LegacyEncodingOptions{DecodeLegacyIndexEntryVersion: LegacyEncodingIndexEntryVersionV2}
@@ -500,6 +585,50 @@ func TestIndexEntryRoundTripForwardsCompatibilityV2(t *testing.T) {
 	require.NoError(t, err)
 	expected := testIndexEntry
 	expected.IndexChecksum = 0
+	expected.CompressedSize = 0
+	require.Equal(t, expected, res)
+}
+
+// Make sure the V4 decoding code can handle the V3 file format.
+func TestIndexEntryRoundTripBackwardsCompatibilityV3(t *testing.T) {
+	var (
+		opts = LegacyEncodingOptions{EncodeLegacyIndexEntryVersion: LegacyEncodingIndexEntryVersionV3,
+			DecodeLegacyIndexEntryVersion: LegacyEncodingIndexEntryVersionCurrent}
+		enc = newEncoder(opts)
+		dec = newDecoder(opts, NewDecodingOptions().SetIndexEntryHasher(xhash.NewParsedIndexHasher(t)))
+	)
+
+	// The field added in V4 is not encoded by V3 so the decoded entry has no
+	// compressed size, the checksum of the V3 entry is validated as usual.
+	err := enc.EncodeIndexEntry(testIndexEntry)
+	require.NoError(t, err)
+	dec.Reset(NewByteDecoderStream(enc.Bytes()))
+	res, err := dec.DecodeIndexEntry(nil)
+	require.NoError(t, err)
+	expected := testIndexEntry
+	expected.CompressedSize = 0
+	expected.IndexChecksum = testIndexEntryV3Checksum
+	require.Equal(t, expected, res)
+}
+
+// Make sure the V3 decoder code can handle the V4 file format.
+func TestIndexEntryRoundTripForwardsCompatibilityV3(t *testing.T) {
+	var (
+		opts = LegacyEncodingOptions{EncodeLegacyIndexEntryVersion: LegacyEncodingIndexEntryVersionCurrent,
+			DecodeLegacyIndexEntryVersion: LegacyEncodingIndexEntryVersionV3}
+		enc = newEncoder(opts)
+		dec = newDecoder(opts, NewDecodingOptions().SetIndexEntryHasher(xhash.NewParsedIndexHasher(t)))
+	)
+
+	// The V3 decoder skips the compressed size added in V4 and still
+	// validates the checksum which is always the last field.
+	err := enc.EncodeIndexEntry(testIndexEntry)
+	require.NoError(t, err)
+	dec.Reset(NewByteDecoderStream(enc.Bytes()))
+	res, err := dec.DecodeIndexEntry(nil)
+	require.NoError(t, err)
+	expected := testIndexEntry
+	expected.CompressedSize = 0
 	require.Equal(t, expected, res)
 }
 
@@ -99,10 +99,10 @@ const (
 	// correct number of fields is encoded into the files. These values need
 	// to be incremented whenever we add new fields to an object.
 	currNumRootObjectFields           = 2
-	currNumIndexInfoFields            = 11
+	currNumIndexInfoFields            = 12
 	currNumIndexSummariesInfoFields   = 1
 	currNumIndexBloomFilterInfoFields = 2
-	currNumIndexEntryFields           = 7
+	currNumIndexEntryFields           = 8
 	currNumIndexSummaryFields         = 3
 	currNumLogInfoFields              = 3
 	currNumLogEntryFields             = 7
@@ -109,6 +109,7 @@ type options struct {
 	mmapReporter                         mmap.Reporter
 	indexReaderAutovalidateIndexSegments bool
 	encodingOptions                      msgpack.LegacyEncodingOptions
+	coldTier                             ColdTier
 }
 
 type optionsInput struct {
@@ -469,3 +470,13 @@ func (o *options) SetEncodingOptions(value msgpack.LegacyEncodingOptions) Option
 func (o *options) EncodingOptions() msgpack.LegacyEncodingOptions {
 	return o.encodingOptions
 }
+
+func (o *options) SetColdTier(value ColdTier) Options {
+	opts := *o
+	opts.coldTier = value
+	return &opts
+}
+
+func (o *options) ColdTier() ColdTier {
+	return o.coldTier
+}
@@ -509,6 +509,11 @@ func (pm *persistManager) PrepareData(opts persist.DataPrepareOptions) (persist.
 			VolumeIndex: volumeIndex,
 		},
 	}
+	if opts.FileSetType == persist.FileSetFlushType {
+		// NB: snapshots are short lived so they are always written uncompressed
+		// rather than spending CPU compressing them.
+		dataWriterOpts.Compression = nsMetadata.Options().FileSetCompression()
+	}
 	if err := pm.dataPM.writer.Open(dataWriterOpts); err != nil {
 		return prepared, err
 	}
@@ -33,6 +33,7 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/digest"
 	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/persist/fs/msgpack"
 	"github.com/m3db/m3/src/dbnode/persist/schema"
 	"github.com/m3db/m3/src/x/checked"
@@ -69,8 +70,10 @@ type reader struct {
 	filePathPrefix string
 	namespace      ident.ID
 
-	start     xtime.UnixNano
-	blockSize time.Duration
+	start       xtime.UnixNano
+	blockSize   time.Duration
+	compression compression.Type
+	codec       compression.Codec
 
 	infoFdWithDigest           digest.FdWithDigestReader
 	bloomFilterWithDigest      digest.FdWithDigestReader
@@ -100,6 +103,10 @@ type reader struct {
 	streamingTags []byte
 	streamingData []byte
 
+	// Buffers reused across entries when reading compressed filesets.
+	compressedBuf []byte
+	tagsBuf       []byte
+
 	expectedInfoDigest        uint32
 	expectedIndexDigest       uint32
 	expectedDataDigest        uint32
@@ -175,6 +182,15 @@ func (r *reader) Open(opts DataReaderOpenOptions) error {
 	case persist.FileSetFlushType:
 		shardDir = ShardDataDirPath(r.filePathPrefix, namespace, shard)
 
+		if coldTier := r.opts.ColdTier(); coldTier != nil {
+			release, err := coldTier.Fetch(opts.Identifier)
+			if err != nil {
+				return err
+			}
+			// The fetched files can be evicted once the reader has opened them.
+			defer release.Close()
+		}
+
 		isLegacy := false
 		if volumeIndex == 0 {
 			isLegacy, err = isFirstVolumeLegacy(shardDir, blockStart, CheckpointFileSuffix)
@@ -298,12 +314,13 @@ func (r *reader) Open(opts DataReaderOpenOptions) error {
 
 func (r *reader) Status() DataFileSetReaderStatus {
 	return DataFileSetReaderStatus{
-		Open:       r.open,
-		Namespace:  r.namespace,
-		Shard:      r.shard,
-		Volume:     r.volume,
-		BlockStart: r.start,
-		BlockSize:  r.blockSize,
+		Open:        r.open,
+		Namespace:   r.namespace,
+		Shard:       r.shard,
+		Volume:      r.volume,
+		BlockStart:  r.start,
+		BlockSize:   r.blockSize,
+		Compression: r.compression,
 	}
 }
 
@@ -339,6 +356,12 @@ func (r *reader) readInfo(size int) error {
 	if err != nil {
 		return err
 	}
+	codec, err := fileSetCodec(info)
+	if err != nil {
+		return err
+	}
+	r.compression = info.Compression
+	r.codec = codec
 	r.start = xtime.UnixNano(info.BlockStart)
 	r.volume = info.VolumeIndex
 	r.blockSize = time.Duration(info.BlockSize)
@@ -383,12 +406,22 @@ func (r *reader) StreamingRead() (StreamedDataEntry, error) {
 		return StreamedDataEntry{}, err
 	}
 
-	if entry.Offset+entry.Size > int64(len(r.dataMmap.Bytes)) {
+	size := entry.Size
+	if r.codec != nil {
+		size = entry.CompressedSize
+	}
+	if entry.Offset+size > int64(len(r.dataMmap.Bytes)) {
 		return StreamedDataEntry{}, fmt.Errorf(
 			"attempt to read beyond data file size (offset=%d, size=%d, file size=%d)",
-			entry.Offset, entry.Size, len(r.dataMmap.Bytes))
+			entry.Offset, size, len(r.dataMmap.Bytes))
+	}
+	data := r.dataMmap.Bytes[entry.Offset : entry.Offset+size]
+	if r.codec != nil {
+		data, err = decompressEntryData(r.codec, r.streamingData, data, entry.Size)
+		if err != nil {
+			return StreamedDataEntry{}, err
+		}
 	}
-	data := r.dataMmap.Bytes[entry.Offset : entry.Offset+entry.Size]
 
 	// NB(r): _must_ check the checksum against known checksum as the data
 	// file might not have been verified if we haven't read through the file yet.
@@ -396,9 +429,19 @@ func (r *reader) StreamingRead() (StreamedDataEntry, error) {
 		return StreamedDataEntry{}, errSeekChecksumMismatch
 	}
 
-	r.streamingData = append(r.streamingData[:0], data...)
+	encodedTags, err := r.entryEncodedTags(entry.EncodedTags)
+	if err != nil {
+		return StreamedDataEntry{}, err
+	}
+
+	if r.codec != nil {
+		// Already decompressed into the streaming data buffer.
+		r.streamingData = data
+	} else {
+		r.streamingData = append(r.streamingData[:0], data...)
+	}
 	r.streamingID = append(r.streamingID[:0], entry.ID...)
-	r.streamingTags = append(r.streamingTags[:0], entry.EncodedTags...)
+	r.streamingTags = append(r.streamingTags[:0], encodedTags...)
 
 	r.entriesRead++
 
@@ -441,16 +484,27 @@ func (r *reader) Read() (ident.ID, ident.TagIterator, checked.Bytes, uint32, err
 		defer data.DecRef()
 	}
 
-	n, err := r.dataReader.Read(data.Bytes())
+	if r.codec != nil {
+		if err := r.readCompressedData(entry, data.Bytes()); err != nil {
+			return nil, nil, nil, 0, err
+		}
+	} else {
+		n, err := r.dataReader.Read(data.Bytes())
+		if err != nil {
+			return nil, nil, nil, 0, err
+		}
+		if n != int(entry.Size) {
+			return nil, nil, nil, 0, errReadNotExpectedSize
+		}
+	}
+
+	encodedTags, err := r.entryEncodedTags(entry.EncodedTags)
 	if err != nil {
 		return nil, nil, nil, 0, err
 	}
-	if n != int(entry.Size) {
-		return nil, nil, nil, 0, errReadNotExpectedSize
-	}
 
 	id := r.entryClonedID(entry.ID)
-	tags := r.entryClonedEncodedTagsIter(entry.EncodedTags)
+	tags := r.entryClonedEncodedTagsIter(encodedTags)
 
 	r.entriesRead++
 	return id, tags, data, uint32(entry.DataChecksum), nil
@@ -470,8 +524,13 @@ func (r *reader) StreamingReadMetadata() (StreamedMetadataEntry, error) {
 		return StreamedMetadataEntry{}, err
 	}
 
+	encodedTags, err := r.entryEncodedTags(entry.EncodedTags)
+	if err != nil {
+		return StreamedMetadataEntry{}, err
+	}
+
 	r.streamingID = append(r.streamingID[:0], entry.ID...)
-	r.streamingTags = append(r.streamingTags[:0], entry.EncodedTags...)
+	r.streamingTags = append(r.streamingTags[:0], encodedTags...)
 
 	r.metadataRead++
 
@@ -493,8 +552,12 @@ func (r *reader) ReadMetadata() (ident.ID, ident.TagIterator, int, uint32, error
 	}
 
 	entry := r.indexEntriesByOffsetAsc[r.metadataRead]
+	encodedTags, err := r.entryEncodedTags(entry.EncodedTags)
+	if err != nil {
+		return nil, nil, 0, 0, err
+	}
 	id := r.entryClonedID(entry.ID)
-	tags := r.entryClonedEncodedTagsIter(entry.EncodedTags)
+	tags := r.entryClonedEncodedTagsIter(encodedTags)
 	length := int(entry.Size)
 	checksum := uint32(entry.DataChecksum)
 
@@ -516,6 +579,45 @@ func (r *reader) ReadBloomFilter() (*ManagedConcurrentBloomFilter, error) {
 	)
 }
 
+// readCompressedData reads the compressed data of an entry from the data file
+// and decompresses it into data, which must have the uncompressed size.
+func (r *reader) readCompressedData(entry schema.IndexEntry, data []byte) error {
+	if cap(r.compressedBuf) < int(entry.CompressedSize) {
+		r.compressedBuf = make([]byte, entry.CompressedSize)
+	}
+	compressed := r.compressedBuf[:entry.CompressedSize]
+	n, err := r.dataReader.Read(compressed)
+	if err != nil {
+		return err
+	}
+	if n != len(compressed) {
+		return errReadNotExpectedSize
+	}
+
+	decompressed, err := decompressEntryData(r.codec, data[:0], compressed, entry.Size)
+	if err != nil {
+		return err
+	}
+	// NB: decompressed shares data unless the codec had to allocate, in which
+	// case the copy moves it into the caller's buffer.
+	copy(data, decompressed)
+	return nil
+}
+
+// entryEncodedTags returns the uncompressed encoded tags of an entry, the
+// result is only valid until the next call.
+func (r *reader) entryEncodedTags(encodedTags []byte) ([]byte, error) {
+	if r.codec == nil || len(encodedTags) == 0 {
+		return encodedTags, nil
+	}
+	tags, err := r.codec.Decompress(r.tagsBuf, encodedTags)
+	if err != nil {
+		return nil, err
+	}
+	r.tagsBuf = tags
+	return tags, nil
+}
+
 func (r *reader) entryClonedBytes(bytes []byte) checked.Bytes {
 	var bytesClone checked.Bytes
 	if r.bytesPool != nil {
@@ -621,6 +723,8 @@ func (r *reader) Close() error {
 	bytesPool := r.bytesPool
 	tagDecoderPool := r.tagDecoderPool
 	indexEntriesByOffsetAsc := r.indexEntriesByOffsetAsc
+	compressedBuf := r.compressedBuf
+	tagsBuf := r.tagsBuf
 
 	// Reset struct
 	*r = reader{}
@@ -639,6 +743,8 @@ func (r *reader) Close() error {
 	r.bytesPool = bytesPool
 	r.tagDecoderPool = tagDecoderPool
 	r.indexEntriesByOffsetAsc = indexEntriesByOffsetAsc
+	r.compressedBuf = compressedBuf[:0]
+	r.tagsBuf = tagsBuf[:0]
 
 	return multiErr.FinalError()
 }
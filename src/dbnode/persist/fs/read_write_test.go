@@ -36,6 +36,7 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/digest"
 	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/x/checked"
 	"github.com/m3db/m3/src/x/ident"
@@ -312,6 +313,65 @@ func TestSimpleReadWrite(t *testing.T) {
 	readTestData(t, r, 0, testWriterStart, entries)
 }
 
+func TestCompressedReadWrite(t *testing.T) {
+	for _, compressionType := range compression.ValidTypes() {
+		t.Run(compressionType.String(), func(t *testing.T) {
+			dir := createTempDir(t)
+			filePathPrefix := filepath.Join(dir, "")
+			defer os.RemoveAll(dir)
+
+			entries := []testEntry{
+				{"foo", nil, []byte{1, 2, 3}},
+				{"bar", nil, []byte{4, 5, 6}},
+				{"baz", nil, make([]byte, 65536)},
+				{"foo+bar=baz,qux=qaz", map[string]string{
+					"bar": "baz",
+					"qux": "qaz",
+				}, []byte{7, 8, 9}},
+			}
+
+			// Write a compressed fileset for shard 0 and an uncompressed one
+			// for shard 1 to verify the reader handles both when reused.
+			w := newTestWriter(t, filePathPrefix)
+			for shard, fileSetCompression := range []compression.Type{
+				compressionType, compression.NoneType,
+			} {
+				require.NoError(t, w.Open(DataWriterOpenOptions{
+					Identifier: FileSetFileIdentifier{
+						Namespace:  testNs1ID,
+						Shard:      uint32(shard),
+						BlockStart: testWriterStart,
+					},
+					BlockSize:   testBlockSize,
+					FileSetType: persist.FileSetFlushType,
+					Compression: fileSetCompression,
+				}))
+				for _, entry := range entries {
+					metadata := persist.NewMetadataFromIDAndTags(entry.ID(),
+						entry.Tags(), persist.MetadataOptions{})
+					require.NoError(t, w.Write(metadata,
+						bytesRefd(entry.data), digest.Checksum(entry.data)))
+				}
+				require.NoError(t, w.Close())
+			}
+
+			r := newTestReader(t, filePathPrefix)
+			readTestData(t, r, 0, testWriterStart, entries)
+			readTestData(t, r, 1, testWriterStart, entries)
+
+			require.NoError(t, r.Open(DataReaderOpenOptions{
+				Identifier: FileSetFileIdentifier{
+					Namespace:  testNs1ID,
+					Shard:      0,
+					BlockStart: testWriterStart,
+				},
+			}))
+			require.Equal(t, compressionType, r.Status().Compression)
+			require.NoError(t, r.Close())
+		})
+	}
+}
+
 func TestCheckpointFileSizeBytesSize(t *testing.T) {
 	// These values need to match so that the logic for determining whether
 	// a checkpoint file is complete or not remains correct.
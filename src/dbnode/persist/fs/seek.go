@@ -32,6 +32,7 @@ import (
 	"gopkg.in/vmihailenco/msgpack.v2"
 
 	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	xmsgpack "github.com/m3db/m3/src/dbnode/persist/fs/msgpack"
 	"github.com/m3db/m3/src/dbnode/persist/schema"
 	"github.com/m3db/m3/src/x/checked"
@@ -71,6 +72,7 @@ type seeker struct {
 	start          xtime.UnixNano
 	blockSize      time.Duration
 	versionChecker schema.VersionChecker
+	codec          compression.Codec
 
 	dataFd        *os.File
 	indexFd       *os.File
@@ -93,6 +95,9 @@ type IndexEntry struct {
 	DataChecksum uint32
 	Offset       int64
 	EncodedTags  checked.Bytes
+	// CompressedSize is the size of the data on disk if the fileset
+	// is compressed, Size is always the uncompressed size.
+	CompressedSize uint32
 }
 
 // NewSeeker returns a new seeker.
@@ -229,6 +234,11 @@ func (s *seeker) Open(
 	s.start = xtime.UnixNano(info.BlockStart)
 	s.blockSize = time.Duration(info.BlockSize)
 	s.versionChecker = schema.NewVersionChecker(int(info.MajorVersion), int(info.MinorVersion))
+	s.codec, err = fileSetCodec(info)
+	if err != nil {
+		s.Close()
+		return err
+	}
 
 	err = s.validateIndexFileDigest(
 		indexFdWithDigest, expectedDigests.indexDigest)
@@ -355,15 +365,21 @@ func (s *seeker) SeekByIndexEntry(
 
 	// Copy the actual data into the underlying buffer.
 	underlyingBuf := buffer.Bytes()
-	n, err := io.ReadFull(resources.offsetFileReader, underlyingBuf)
-	if err != nil {
-		return nil, err
-	}
-	if n != int(entry.Size) {
-		// This check is redundant because io.ReadFull will return an error if
-		// its not able to read the specified number of bytes, but we keep it
-		// in for posterity.
-		return nil, fmt.Errorf("tried to read: %d bytes but read: %d", entry.Size, n)
+	if s.codec != nil {
+		if err := s.readCompressedData(entry, underlyingBuf, resources); err != nil {
+			return nil, err
+		}
+	} else {
+		n, err := io.ReadFull(resources.offsetFileReader, underlyingBuf)
+		if err != nil {
+			return nil, err
+		}
+		if n != int(entry.Size) {
+			// This check is redundant because io.ReadFull will return an error if
+			// its not able to read the specified number of bytes, but we keep it
+			// in for posterity.
+			return nil, fmt.Errorf("tried to read: %d bytes but read: %d", entry.Size, n)
+		}
 	}
 
 	// NB(r): _must_ check the checksum against known checksum as the data
@@ -375,6 +391,31 @@ func (s *seeker) SeekByIndexEntry(
 	return buffer, nil
 }
 
+// readCompressedData reads the compressed data of an entry from the data file
+// and decompresses it into data, which must have the uncompressed size.
+func (s *seeker) readCompressedData(
+	entry IndexEntry,
+	data []byte,
+	resources ReusableSeekerResources,
+) error {
+	compressed := resources.decodeIndexEntryBytesPool.Get(int(entry.CompressedSize))
+	defer resources.decodeIndexEntryBytesPool.Put(compressed)
+
+	compressed = compressed[:entry.CompressedSize]
+	if _, err := io.ReadFull(resources.offsetFileReader, compressed); err != nil {
+		return err
+	}
+
+	decompressed, err := decompressEntryData(s.codec, data[:0], compressed, int64(entry.Size))
+	if err != nil {
+		return err
+	}
+	// NB: decompressed shares data unless the codec had to allocate, in which
+	// case the copy moves it into the caller's buffer.
+	copy(data, decompressed)
+	return nil
+}
+
 // SeekIndexEntry performs the following steps:
 //
 //  1. Go to the indexLookup and it will give us an offset that is a good starting
@@ -430,18 +471,30 @@ func (s *seeker) SeekIndexEntry(
 			// If it's a match, we need to copy the tags into a checked bytes
 			// so they can be passed along. We use the "real" bytes pool here
 			// because we're passing ownership of the bytes to the entry / caller.
+			encodedTags := entry.EncodedTags
+			if s.codec != nil && len(encodedTags) > 0 {
+				encodedTags, err = s.codec.Decompress(
+					resources.decodeIndexEntryBytesPool.Get(len(entry.EncodedTags)),
+					entry.EncodedTags)
+				if err != nil {
+					return IndexEntry{}, err
+				}
+				defer resources.decodeIndexEntryBytesPool.Put(encodedTags)
+			}
+
 			var checkedEncodedTags checked.Bytes
-			if len(entry.EncodedTags) > 0 {
-				checkedEncodedTags = s.opts.bytesPool.Get(len(entry.EncodedTags))
+			if len(encodedTags) > 0 {
+				checkedEncodedTags = s.opts.bytesPool.Get(len(encodedTags))
 				checkedEncodedTags.IncRef()
-				checkedEncodedTags.AppendAll(entry.EncodedTags)
+				checkedEncodedTags.AppendAll(encodedTags)
 			}
 
 			indexEntry := IndexEntry{
-				Size:         uint32(entry.Size),
-				DataChecksum: uint32(entry.DataChecksum),
-				Offset:       entry.Offset,
-				EncodedTags:  checkedEncodedTags,
+				Size:           uint32(entry.Size),
+				DataChecksum:   uint32(entry.DataChecksum),
+				Offset:         entry.Offset,
+				EncodedTags:    checkedEncodedTags,
+				CompressedSize: uint32(entry.CompressedSize),
 			}
 
 			// Safe to return resources to the pool because ID will not be
@@ -516,6 +569,7 @@ func (s *seeker) ConcurrentClone() (ConcurrentDataFileSetSeeker, error) {
 		dataFd:  s.dataFd,
 
 		versionChecker: s.versionChecker,
+		codec:          s.codec,
 	}
 
 	return seeker, nil
@@ -193,11 +193,15 @@ type seekersByTime struct {
 type rotatableSeekers struct {
 	active   seekersAndBloom
 	inactive seekersAndBloom
+	// lastBorrowed is only tracked when the cold tier is enabled, it is used
+	// to close the seekers of blocks in the cold tier once they are idle.
+	lastBorrowed xtime.UnixNano
 }
 
 type seekerManagerPendingClose struct {
 	shard      uint32
 	blockStart xtime.UnixNano
+	idle       bool
 }
 
 // NewSeekerManager returns a new TSDB file set seeker manager.
@@ -343,6 +347,12 @@ func (m *seekerManager) Test(
 		return seekers.active.bloomFilter.Test(id.Bytes()), nil
 	}
 
+	if coldTierBoundary, ok := m.coldTierBoundary(); ok && start.Before(coldTierBoundary) {
+		// Opening the seekers of a block in the cold tier can require fetching
+		// its files, so only do so when the series is actually seeked.
+		return true, nil
+	}
+
 	openSeekersAndBloom, err := m.getOrOpenSeekersWithLock(start, byTime)
 	if err != nil {
 		return false, err
@@ -372,6 +382,13 @@ func (m *seekerManager) Borrow(
 		return nil, err
 	}
 
+	if m.opts.ColdTier() != nil {
+		if rotatable, ok := byTime.seekers[start]; ok {
+			rotatable.lastBorrowed = xtime.ToUnixNano(m.opts.ClockOptions().NowFn()())
+			byTime.seekers[start] = rotatable
+		}
+	}
+
 	seekers := openSeekersAndBloom.seekers
 	availableSeekerIdx := -1
 	availableSeeker := borrowableSeeker{}
@@ -805,6 +822,11 @@ func (m *seekerManager) openAnyUnopenSeekers(byTime *seekersByTime) error {
 	blockSize := m.namespaceMetadata.Options().RetentionOptions().BlockSize()
 	multiErr := xerrors.NewMultiError()
 
+	// Seekers of blocks in the cold tier are only opened on demand.
+	if coldTierBoundary, ok := m.coldTierBoundary(); ok && coldTierBoundary.After(start) {
+		start = coldTierBoundary
+	}
+
 	for t := start; !t.After(end); t = t.Add(blockSize) {
 		byTime.Lock()
 		_, err := m.getOrOpenSeekersWithLock(t, byTime)
@@ -831,6 +853,22 @@ func (m *seekerManager) newOpenSeeker(
 		return nil, errSeekerManagerFileSetNotFound
 	}
 
+	// Fetch the files of offloaded filesets before taking the lock on the
+	// unread buffer since it can take a while.
+	if coldTier := m.opts.ColdTier(); coldTier != nil {
+		release, err := coldTier.Fetch(FileSetFileIdentifier{
+			Namespace:   m.namespace,
+			Shard:       shard,
+			BlockStart:  blockStart,
+			VolumeIndex: volume,
+		})
+		if err != nil {
+			return nil, err
+		}
+		// The fetched files can be evicted once the seeker has opened them.
+		defer release.Close()
+	}
+
 	// NB(r): Use a lock on the unread buffer to avoid multiple
 	// goroutines reusing the unread buffer that we share between the seekers
 	// when we open each seeker.
@@ -971,6 +1009,22 @@ func (m *seekerManager) latestSeekableBlockStart() xtime.UnixNano {
 	return now.Truncate(ropts.BlockSize())
 }
 
+// coldTierBoundary returns the block start before which blocks are in the
+// cold tier, false if the cold tier is disabled.
+func (m *seekerManager) coldTierBoundary() (xtime.UnixNano, bool) {
+	if m.opts.ColdTier() == nil {
+		return 0, false
+	}
+	nowFn := m.opts.ClockOptions().NowFn()
+	return ColdTierBoundary(m.namespaceMetadata.Options(), xtime.ToUnixNano(nowFn()))
+}
+
+// coldTierIdleWithLock returns whether the seekers of a block in the cold
+// tier have not been borrowed for longer than the cold tier cache TTL.
+func (m *seekerManager) coldTierIdleWithLock(seekers rotatableSeekers, idleBefore xtime.UnixNano) bool {
+	return seekers.active.wg == nil && seekers.lastBorrowed.Before(idleBefore)
+}
+
 // openCloseLoop ensures to keep seekers open for those times where they are
 // available and closes them when they fall out of retention and expire.
 func (m *seekerManager) openCloseLoop() {
@@ -996,6 +1050,12 @@ func (m *seekerManager) openCloseLoop() {
 
 	for {
 		earliestSeekableBlockStart := m.earliestSeekableBlockStart()
+		coldTierBoundary, coldTierEnabled := m.coldTierBoundary()
+		var coldTierIdleBefore xtime.UnixNano
+		if coldTierEnabled {
+			nowFn := m.opts.ClockOptions().NowFn()
+			coldTierIdleBefore = xtime.ToUnixNano(nowFn()).Add(-m.opts.ColdTier().CacheTTL())
+		}
 
 		m.RLock()
 		if m.status != seekerManagerOpen {
@@ -1022,7 +1082,7 @@ func (m *seekerManager) openCloseLoop() {
 		m.RLock()
 		for shard, byTime := range m.seekersByShardIdx {
 			byTime.RLock()
-			for blockStart := range byTime.seekers {
+			for blockStart, seekers := range byTime.seekers {
 				if blockStart.Before(earliestSeekableBlockStart) ||
 					// Close seekers for shards that are no longer available. This
 					// ensure that seekers are eventually consistent w/ shard state.
@@ -1031,6 +1091,17 @@ func (m *seekerManager) openCloseLoop() {
 						shard:      uint32(shard),
 						blockStart: blockStart,
 					})
+					continue
+				}
+				// Close idle seekers of blocks in the cold tier so that their
+				// cached files are not kept open once they are evicted.
+				if coldTierEnabled && blockStart.Before(coldTierBoundary) &&
+					m.coldTierIdleWithLock(seekers, coldTierIdleBefore) {
+					shouldClose = append(shouldClose, seekerManagerPendingClose{
+						shard:      uint32(shard),
+						blockStart: blockStart,
+						idle:       true,
+					})
 				}
 			}
 			byTime.RUnlock()
@@ -1040,7 +1111,12 @@ func (m *seekerManager) openCloseLoop() {
 			for _, elem := range shouldClose {
 				byTime := m.seekersByShardIdx[elem.shard]
 				byTime.Lock()
-				seekers := byTime.seekers[elem.blockStart]
+				seekers, ok := byTime.seekers[elem.blockStart]
+				if !ok || (elem.idle && !m.coldTierIdleWithLock(seekers, coldTierIdleBefore)) {
+					// The seekers were closed or borrowed since they were checked.
+					byTime.Unlock()
+					continue
+				}
 				allSeekersAreReturned := true
 
 				// Ensure no active seekers are still borrowed.
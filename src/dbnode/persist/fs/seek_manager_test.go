@@ -30,6 +30,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/m3db/m3/src/cluster/shard"
+	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/sharding"
 	"github.com/m3db/m3/src/dbnode/storage/block"
@@ -733,3 +734,81 @@ func TestSeekerManagerDoNotOpenSeekersForOutOfRetentionBlocks(t *testing.T) {
 	require.NotContains(t, openSeekers, earliestBlockStart.Add(-blockSize))
 	require.NotContains(t, openSeekers, earliestBlockStart.Add(-2*blockSize))
 }
+
+func TestSeekerManagerColdTierSeekersOpenedOnDemand(t *testing.T) {
+	defer leaktest.CheckTimeout(t, 1*time.Minute)()
+	var (
+		ctrl        = xtest.NewController(t)
+		shards      = []uint32{0}
+		metadata    = testNs1Metadata(t)
+		blockSize   = metadata.Options().RetentionOptions().BlockSize()
+		signal      = make(chan struct{})
+		openSeekers = make(map[xtime.UnixNano]struct{})
+		now         = time.Now()
+		coldTier    = NewMockColdTier(ctrl)
+		opts        = NewOptions().SetColdTier(coldTier)
+	)
+	metadata, err := namespace.NewMetadata(metadata.ID(),
+		metadata.Options().SetColdTierAge(4*blockSize))
+	require.NoError(t, err)
+	shardSet, err := sharding.NewShardSet(
+		sharding.NewShards(shards, shard.Available),
+		sharding.DefaultHashFn(1),
+	)
+	require.NoError(t, err)
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(func() time.Time {
+		return now
+	}))
+	coldTier.EXPECT().CacheTTL().Return(time.Hour).AnyTimes()
+
+	m := NewSeekerManager(nil, opts, defaultTestBlockRetrieverOptions).(*seekerManager)
+	m.sleepFn = func(_ time.Duration) {
+		signal <- struct{}{} // signal once to indicate that openCloseLoop completed.
+		m.sleepFn = time.Sleep
+	}
+	require.NoError(t, m.Open(metadata, shardSet))
+	defer func() {
+		require.NoError(t, m.Close())
+	}()
+
+	var openSeekersLock sync.Mutex
+	m.newOpenSeekerFn = func(shard uint32, blockStart xtime.UnixNano, volume int) (DataFileSetSeeker, error) {
+		openSeekersLock.Lock()
+		openSeekers[blockStart] = struct{}{}
+		openSeekersLock.Unlock()
+		mockSeeker := NewMockDataFileSetSeeker(ctrl)
+		mockConcurrentDataFileSetSeeker := NewMockConcurrentDataFileSetSeeker(ctrl)
+		mockConcurrentDataFileSetSeeker.EXPECT().Close().Return(nil)
+		mockSeeker.EXPECT().ConcurrentClone().Return(mockConcurrentDataFileSetSeeker, nil)
+		mockSeeker.EXPECT().ConcurrentIDBloomFilter().Return(nil)
+		mockSeeker.EXPECT().Close().Return(nil)
+		return mockSeeker, nil
+	}
+
+	coldTierBoundary, ok := ColdTierBoundary(metadata.Options(), xtime.ToUnixNano(now))
+	require.True(t, ok)
+	require.NoError(t, m.CacheShardIndices(shards))
+
+	<-signal
+	openSeekersLock.Lock()
+	require.Contains(t, openSeekers, coldTierBoundary)
+	require.NotContains(t, openSeekers, coldTierBoundary.Add(-blockSize))
+	openSeekersLock.Unlock()
+
+	// Testing a block in the cold tier does not open its seekers.
+	coldBlockStart := coldTierBoundary.Add(-blockSize)
+	exists, err := m.Test(ident.StringID("foo"), 0, coldBlockStart)
+	require.NoError(t, err)
+	require.True(t, exists)
+	openSeekersLock.Lock()
+	require.NotContains(t, openSeekers, coldBlockStart)
+	openSeekersLock.Unlock()
+
+	// Borrowing opens them.
+	seeker, err := m.Borrow(0, coldBlockStart)
+	require.NoError(t, err)
+	require.NoError(t, m.Return(0, coldBlockStart, seeker))
+	openSeekersLock.Lock()
+	require.Contains(t, openSeekers, coldBlockStart)
+	openSeekersLock.Unlock()
+}
@@ -21,10 +21,13 @@
 package fs
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
 	"testing"
 	"time"
 
@@ -33,6 +36,7 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/digest"
 	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/persist/schema"
 	"github.com/m3db/m3/src/x/ident"
 )
@@ -238,6 +242,66 @@ func TestSeek(t *testing.T) {
 	assert.NoError(t, s.Close())
 }
 
+func TestSeekCompressed(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testdb")
+	require.NoError(t, err)
+	filePathPrefix := filepath.Join(dir, "")
+	defer os.RemoveAll(dir)
+
+	w := newTestWriter(t, filePathPrefix)
+	require.NoError(t, w.Open(DataWriterOpenOptions{
+		BlockSize: testBlockSize,
+		Identifier: FileSetFileIdentifier{
+			Namespace:  testNs1ID,
+			Shard:      0,
+			BlockStart: testWriterStart,
+		},
+		Compression: compression.SnappyType,
+	}))
+	for i := 1; i <= 3; i++ {
+		data := bytes.Repeat([]byte{1, 2, byte(i)}, 100)
+		require.NoError(t, w.Write(
+			persist.NewMetadataFromIDAndTags(
+				ident.StringID(fmt.Sprintf("foo%d", i)),
+				ident.NewTags(ident.StringTag("num", strconv.Itoa(i))),
+				persist.MetadataOptions{}),
+			bytesRefd(data),
+			digest.Checksum(data)))
+	}
+	require.NoError(t, w.Close())
+
+	resources := newTestReusableSeekerResources()
+	s := newTestSeeker(filePathPrefix)
+	require.NoError(t, s.Open(testNs1ID, 0, testWriterStart, 0, resources))
+
+	entry, err := s.SeekIndexEntry(ident.StringID("foo2"), resources)
+	require.NoError(t, err)
+	require.Equal(t, uint32(300), entry.Size)
+	require.True(t, entry.CompressedSize > 0 && entry.CompressedSize < entry.Size)
+
+	tags := decodeTags(t, entry.EncodedTags.Bytes())
+	require.True(t, ident.NewTagIterMatcher(
+		ident.MustNewTagStringsIterator("num", "2")).Matches(tags))
+	tags.Close()
+
+	data, err := s.SeekByIndexEntry(entry, resources)
+	require.NoError(t, err)
+	data.IncRef()
+	defer data.DecRef()
+	require.Equal(t, bytes.Repeat([]byte{1, 2, 2}, 100), data.Bytes())
+
+	clone, err := s.ConcurrentClone()
+	require.NoError(t, err)
+	cloneData, err := clone.SeekByID(ident.StringID("foo3"), resources)
+	require.NoError(t, err)
+	cloneData.IncRef()
+	defer cloneData.DecRef()
+	require.Equal(t, bytes.Repeat([]byte{1, 2, 3}, 100), cloneData.Bytes())
+
+	require.NoError(t, clone.Close())
+	require.NoError(t, s.Close())
+}
+
 // TestSeekIDNotExists is similar to TestSeek, but it covers more edge cases
 // around IDs not existing.
 func TestSeekIDNotExists(t *testing.T) {
@@ -30,6 +30,7 @@ import (
 	"github.com/m3db/bloom/v4"
 
 	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/x/ident"
 	xtime "github.com/m3db/m3/src/x/time"
@@ -58,6 +59,8 @@ type StreamingWriterOpenOptions struct {
 	BlockStart  xtime.UnixNano
 	BlockSize   time.Duration
 	VolumeIndex int
+	// Compression is the codec the data and index files are compressed with.
+	Compression compression.Type
 
 	// PlannedRecordsCount is an estimate of the number of series to be written.
 	// Must be greater than 0.
@@ -101,6 +104,7 @@ func (w *streamingWriter) Open(opts StreamingWriterOpenOptions) error {
 			VolumeIndex: opts.VolumeIndex,
 		},
 		FileSetType: persist.FileSetFlushType,
+		Compression: opts.Compression,
 	}
 
 	plannedRecordsCount := opts.PlannedRecordsCount
@@ -174,10 +178,23 @@ func (w *streamingWriter) writeData(
 		size:           uint32(size),
 		dataChecksum:   dataChecksum,
 	}
-	for _, d := range data {
-		if err := w.writer.writeData(d); err != nil {
+	if w.writer.codec != nil {
+		buf := w.writer.uncompressedBuf[:0]
+		for _, d := range data {
+			buf = append(buf, d...)
+		}
+		w.writer.uncompressedBuf = buf
+		compressedSize, err := w.writer.writeCompressedData(buf)
+		if err != nil {
 			return indexEntry{}, false, err
 		}
+		entry.compressedSize = compressedSize
+	} else {
+		for _, d := range data {
+			if err := w.writer.writeData(d); err != nil {
+				return indexEntry{}, false, err
+			}
+		}
 	}
 
 	w.currIdx++
@@ -33,6 +33,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
 	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/x/context"
 	"github.com/m3db/m3/src/x/ident"
@@ -143,6 +144,39 @@ func TestSimpleReadStreamingWrite(t *testing.T) {
 	verifyInfoFile(t, filePathPrefix, testNs1ID, 0, len(entries))
 }
 
+func TestCompressedReadStreamingWrite(t *testing.T) {
+	dir := createTempDir(t)
+	filePathPrefix := filepath.Join(dir, "")
+	defer os.RemoveAll(dir)
+
+	entries := []testStreamingEntry{
+		{testEntry{"bar", nil, nil}, []float64{4.8, 5.2, 6}},
+		{testEntry{"foo", nil, nil}, []float64{1, 2, 3}},
+		{testEntry{"foo+bar=baz,qux=qaz", map[string]string{
+			"bar": "baz",
+			"qux": "qaz",
+		}, nil}, []float64{7, 8, 9}},
+	}
+
+	w := newTestStreamingWriter(t, filePathPrefix)
+	err := w.Open(StreamingWriterOpenOptions{
+		NamespaceID:         testNs1ID,
+		BlockStart:          testWriterStart,
+		BlockSize:           testBlockSize,
+		Compression:         compression.ZstdType,
+		PlannedRecordsCount: uint(len(entries)),
+	})
+	require.NoError(t, err)
+	err = streamingWriteTestData(t, w, testWriterStart, entries)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	r := newTestReader(t, filePathPrefix)
+	readTestData(t, r, 0, testWriterStart, toTestEntries(entries))
+
+	verifyInfoFile(t, filePathPrefix, testNs1ID, 0, len(entries))
+}
+
 func TestReuseStreamingWriter(t *testing.T) {
 	dir := createTempDir(t)
 	filePathPrefix := filepath.Join(dir, "")
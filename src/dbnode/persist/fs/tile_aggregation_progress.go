@@ -0,0 +1,105 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package fs
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"time"
+
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+const (
+	tileAggregationDirName        = "tile_aggregation"
+	tileAggregationFileSuffix     = ".json"
+	tileAggregationTempFileSuffix = ".tmp"
+)
+
+// TileAggregationProgress is the progress of the tile aggregation of a source
+// namespace into a target namespace.
+type TileAggregationProgress struct {
+	// AggregatedUntil is the end of the last target block aggregated, all the
+	// target blocks before it have been aggregated.
+	AggregatedUntil xtime.UnixNano `json:"aggregatedUntil"`
+}
+
+// TileAggregationDirPath returns the path to the tile aggregation directory.
+func TileAggregationDirPath(prefix string) string {
+	return path.Join(prefix, tileAggregationDirName)
+}
+
+// TileAggregationProgressFilePath returns the path to the file holding the
+// progress of the tile aggregation of a source namespace into tiles of the
+// given step of a target namespace.
+func TileAggregationProgressFilePath(
+	prefix string,
+	sourceNamespace, targetNamespace ident.ID,
+	step time.Duration,
+) string {
+	return path.Join(TileAggregationDirPath(prefix), sourceNamespace.String(),
+		fmt.Sprintf("%s-%s%s", targetNamespace.String(), step.String(), tileAggregationFileSuffix))
+}
+
+// ReadTileAggregationProgress reads the progress of a tile aggregation, an
+// empty progress is returned if nothing has been aggregated yet.
+func ReadTileAggregationProgress(
+	opts Options,
+	sourceNamespace, targetNamespace ident.ID,
+	step time.Duration,
+) (TileAggregationProgress, error) {
+	var progress TileAggregationProgress
+	data, err := ioutil.ReadFile(TileAggregationProgressFilePath(opts.FilePathPrefix(),
+		sourceNamespace, targetNamespace, step))
+	if os.IsNotExist(err) {
+		return progress, nil
+	}
+	if err != nil {
+		return progress, err
+	}
+
+	if err := json.Unmarshal(data, &progress); err != nil {
+		return progress, fmt.Errorf("invalid tile aggregation progress file of namespace %s into %s: %w",
+			sourceNamespace, targetNamespace, err)
+	}
+	return progress, nil
+}
+
+// WriteTileAggregationProgress replaces the progress of a tile aggregation.
+func WriteTileAggregationProgress(
+	opts Options,
+	sourceNamespace, targetNamespace ident.ID,
+	step time.Duration,
+	progress TileAggregationProgress,
+) error {
+	data, err := json.Marshal(progress)
+	if err != nil {
+		return err
+	}
+
+	filePath := TileAggregationProgressFilePath(opts.FilePathPrefix(),
+		sourceNamespace, targetNamespace, step)
+	return writeFileAtomically(opts, path.Dir(filePath), filePath,
+		filePath+tileAggregationTempFileSuffix, data)
+}
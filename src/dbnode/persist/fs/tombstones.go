@@ -0,0 +1,228 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+const (
+	tombstonesDirName        = "tombstones"
+	tombstonesFileSuffix     = ".json"
+	tombstonesLogFileSuffix  = ".log"
+	tombstonesTempFileSuffix = ".tmp"
+)
+
+// ShardTombstones are the time ranges of the datapoints deleted from the
+// series of a shard.
+type ShardTombstones struct {
+	Series []SeriesTombstones `json:"series"`
+	Blocks []BlockTombstones  `json:"blocks,omitempty"`
+}
+
+// SeriesTombstones are the time ranges of the datapoints deleted from a series.
+type SeriesTombstones struct {
+	ID     []byte           `json:"id"`
+	Ranges []TombstoneRange `json:"ranges"`
+}
+
+// TombstoneRange is a deleted time range, the start is inclusive and the end
+// is exclusive.
+type TombstoneRange struct {
+	Start xtime.UnixNano `json:"start"`
+	End   xtime.UnixNano `json:"end"`
+}
+
+// BlockTombstones are the times datapoints of a block were last deleted and
+// last removed from the filesets of the block.
+type BlockTombstones struct {
+	BlockStart xtime.UnixNano `json:"blockStart"`
+	DeletedAt  xtime.UnixNano `json:"deletedAt"`
+	PurgedAt   xtime.UnixNano `json:"purgedAt,omitempty"`
+}
+
+// TombstonesLogEntry is a change to the tombstones of a shard appended to its
+// tombstones log, either the deletion of a time range of series or the
+// restoration of a time range of series written after it was deleted.
+type TombstonesLogEntry struct {
+	IDs   [][]byte       `json:"ids"`
+	Range TombstoneRange `json:"range"`
+	// DeletedAt is the wall clock time of the deletion.
+	DeletedAt xtime.UnixNano `json:"deletedAt,omitempty"`
+	// Restored is set if the range was written after it was deleted.
+	Restored bool `json:"restored,omitempty"`
+}
+
+// TombstonesDirPath returns the path to the tombstones directory.
+func TombstonesDirPath(prefix string) string {
+	return path.Join(prefix, tombstonesDirName)
+}
+
+// NamespaceTombstonesDirPath returns the path to the tombstones directory for
+// a given namespace.
+func NamespaceTombstonesDirPath(prefix string, namespace ident.ID) string {
+	return path.Join(TombstonesDirPath(prefix), namespace.String())
+}
+
+// ShardTombstonesFilePath returns the path to the tombstones file of a shard.
+func ShardTombstonesFilePath(prefix string, namespace ident.ID, shard uint32) string {
+	return path.Join(NamespaceTombstonesDirPath(prefix, namespace),
+		fmt.Sprintf("%d%s", shard, tombstonesFileSuffix))
+}
+
+// ShardTombstonesLogFilePath returns the path to the tombstones log of a shard.
+func ShardTombstonesLogFilePath(prefix string, namespace ident.ID, shard uint32) string {
+	return path.Join(NamespaceTombstonesDirPath(prefix, namespace),
+		fmt.Sprintf("%d%s", shard, tombstonesLogFileSuffix))
+}
+
+// ReadShardTombstones reads the tombstones of a shard and the entries of its
+// tombstones log appended since they were written, which are to be applied in
+// order on top of the tombstones. No tombstones are returned if the shard has
+// no tombstones file.
+func ReadShardTombstones(
+	opts Options,
+	namespace ident.ID,
+	shard uint32,
+) (ShardTombstones, []TombstonesLogEntry, error) {
+	var tombstones ShardTombstones
+	data, err := ioutil.ReadFile(ShardTombstonesFilePath(opts.FilePathPrefix(), namespace, shard))
+	if err != nil && !os.IsNotExist(err) {
+		return tombstones, nil, err
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, &tombstones); err != nil {
+			return tombstones, nil, fmt.Errorf("invalid tombstones file of namespace %s shard %d: %w",
+				namespace, shard, err)
+		}
+	}
+
+	data, err = ioutil.ReadFile(ShardTombstonesLogFilePath(opts.FilePathPrefix(), namespace, shard))
+	if os.IsNotExist(err) {
+		return tombstones, nil, nil
+	}
+	if err != nil {
+		return tombstones, nil, err
+	}
+
+	var (
+		entries []TombstonesLogEntry
+		lines   = bytes.Split(data, []byte("\n"))
+	)
+	for i, line := range lines {
+		if len(line) == 0 {
+			continue
+		}
+
+		var entry TombstonesLogEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			// NB: the last entry is partially written if the process stopped
+			// while appending it, in which case the append never succeeded
+			// and the entry is ignored.
+			if i == len(lines)-1 {
+				break
+			}
+			return tombstones, nil, fmt.Errorf("invalid tombstones log of namespace %s shard %d: %w",
+				namespace, shard, err)
+		}
+		entries = append(entries, entry)
+	}
+	return tombstones, entries, nil
+}
+
+// AppendShardTombstonesLog appends an entry to the tombstones log of a shard,
+// the entry is synced to disk before returning.
+func AppendShardTombstonesLog(
+	opts Options,
+	namespace ident.ID,
+	shard uint32,
+	entry TombstonesLogEntry,
+) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	if err := os.MkdirAll(NamespaceTombstonesDirPath(opts.FilePathPrefix(), namespace),
+		opts.NewDirectoryMode()); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(ShardTombstonesLogFilePath(opts.FilePathPrefix(), namespace, shard),
+		os.O_WRONLY|os.O_CREATE|os.O_APPEND, opts.NewFileMode())
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// WriteShardTombstones replaces the tombstones file of a shard and removes
+// its tombstones log, the file is removed if there are no tombstones.
+func WriteShardTombstones(
+	opts Options,
+	namespace ident.ID,
+	shard uint32,
+	tombstones ShardTombstones,
+) error {
+	var (
+		dirPath     = NamespaceTombstonesDirPath(opts.FilePathPrefix(), namespace)
+		filePath    = ShardTombstonesFilePath(opts.FilePathPrefix(), namespace, shard)
+		logFilePath = ShardTombstonesLogFilePath(opts.FilePathPrefix(), namespace, shard)
+	)
+	if len(tombstones.Series) == 0 && len(tombstones.Blocks) == 0 {
+		if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	} else {
+		data, err := json.Marshal(tombstones)
+		if err != nil {
+			return err
+		}
+		if err := writeFileAtomically(opts, dirPath, filePath,
+			filePath+tombstonesTempFileSuffix, data); err != nil {
+			return err
+		}
+	}
+
+	// NB: the log is removed once its entries are part of the tombstones file,
+	// entries replayed again if removing it fails have no further effect.
+	if err := os.Remove(logFilePath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/x/ident"
+)
+
+func TestWriteReadShardTombstones(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tombstones")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var (
+		opts = NewOptions().SetFilePathPrefix(dir)
+		ns   = ident.StringID("testns")
+	)
+
+	// Reading a shard without tombstones returns no tombstones.
+	tombstones, entries, err := ReadShardTombstones(opts, ns, 3)
+	require.NoError(t, err)
+	assert.Empty(t, tombstones.Series)
+	assert.Empty(t, entries)
+
+	expected := ShardTombstones{
+		Series: []SeriesTombstones{
+			{
+				ID: []byte("foo"),
+				Ranges: []TombstoneRange{
+					{Start: 10, End: 20},
+					{Start: 30, End: 40},
+				},
+			},
+			{
+				ID:     []byte("bar"),
+				Ranges: []TombstoneRange{{Start: 0, End: 100}},
+			},
+		},
+		Blocks: []BlockTombstones{{BlockStart: 0, DeletedAt: 150, PurgedAt: 120}},
+	}
+	require.NoError(t, WriteShardTombstones(opts, ns, 3, expected))
+
+	tombstones, entries, err = ReadShardTombstones(opts, ns, 3)
+	require.NoError(t, err)
+	assert.Equal(t, expected, tombstones)
+	assert.Empty(t, entries)
+
+	// Log entries are read in order on top of the tombstones.
+	expectedEntries := []TombstonesLogEntry{
+		{IDs: [][]byte{[]byte("baz")}, Range: TombstoneRange{Start: 0, End: 50}, DeletedAt: 200},
+		{IDs: [][]byte{[]byte("qux")}, Range: TombstoneRange{Start: 10, End: 60}, DeletedAt: 210},
+		{IDs: [][]byte{[]byte("baz")}, Range: TombstoneRange{Start: 10, End: 11}, Restored: true},
+	}
+	for _, entry := range expectedEntries {
+		require.NoError(t, AppendShardTombstonesLog(opts, ns, 3, entry))
+	}
+
+	tombstones, entries, err = ReadShardTombstones(opts, ns, 3)
+	require.NoError(t, err)
+	assert.Equal(t, expected, tombstones)
+	assert.Equal(t, expectedEntries, entries)
+
+	// A partially appended last entry is ignored.
+	logFile, err := os.OpenFile(ShardTombstonesLogFilePath(dir, ns, 3), os.O_WRONLY|os.O_APPEND, 0)
+	require.NoError(t, err)
+	_, err = logFile.Write([]byte(`{"ids":["`))
+	require.NoError(t, err)
+	require.NoError(t, logFile.Close())
+
+	_, entries, err = ReadShardTombstones(opts, ns, 3)
+	require.NoError(t, err)
+	assert.Equal(t, expectedEntries, entries)
+
+	// Writing no tombstones removes the file and the log.
+	require.NoError(t, WriteShardTombstones(opts, ns, 3, ShardTombstones{}))
+	_, err = os.Stat(ShardTombstonesFilePath(dir, ns, 3))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(ShardTombstonesLogFilePath(dir, ns, 3))
+	assert.True(t, os.IsNotExist(err))
+}
@@ -21,6 +21,7 @@
 package fs
 
 import (
+	stdctx "context"
 	"errors"
 	"io"
 	"os"
@@ -29,6 +30,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/persist/fs/msgpack"
 	"github.com/m3db/m3/src/dbnode/runtime"
 	"github.com/m3db/m3/src/dbnode/sharding"
@@ -47,6 +49,7 @@ import (
 	"github.com/m3db/m3/src/x/instrument"
 	"github.com/m3db/m3/src/x/mmap"
 	"github.com/m3db/m3/src/x/pool"
+	xresource "github.com/m3db/m3/src/x/resource"
 	"github.com/m3db/m3/src/x/serialize"
 	xtime "github.com/m3db/m3/src/x/time"
 )
@@ -71,6 +74,8 @@ type DataWriterOpenOptions struct {
 	FileSetContentType persist.FileSetContentType
 	Identifier         FileSetFileIdentifier
 	BlockSize          time.Duration
+	// Compression is the codec the data and index files are compressed with.
+	Compression compression.Type
 	// Only used when writing snapshot files
 	Snapshot DataWriterSnapshotOptions
 }
@@ -116,12 +121,13 @@ type SnapshotMetadataFileReader interface {
 
 // DataFileSetReaderStatus describes the status of a file set reader.
 type DataFileSetReaderStatus struct {
-	Namespace  ident.ID
-	BlockStart xtime.UnixNano
-	Shard      uint32
-	Volume     int
-	Open       bool
-	BlockSize  time.Duration
+	Namespace   ident.ID
+	BlockStart  xtime.UnixNano
+	Shard       uint32
+	Volume      int
+	Open        bool
+	BlockSize   time.Duration
+	Compression compression.Type
 }
 
 // DataReaderOpenOptions is options struct for the reader open method.
@@ -540,6 +546,14 @@ type Options interface {
 
 	// EncodingOptions returns the encoder options used by the encoder.
 	EncodingOptions() msgpack.LegacyEncodingOptions
+
+	// SetColdTier sets the cold tier data filesets are offloaded to, nil
+	// disables the cold tier.
+	SetColdTier(value ColdTier) Options
+
+	// ColdTier returns the cold tier data filesets are offloaded to, nil
+	// if the cold tier is disabled.
+	ColdTier() ColdTier
 }
 
 // BlockRetrieverOptions represents the options for block retrieval.
@@ -613,6 +627,10 @@ type MergeWith interface {
 		fn ForEachRemainingFn,
 		nsCtx namespace.Context,
 	) error
+
+	// DeletedRanges returns the time ranges of the block of the given series
+	// with deleted datapoints that should not be persisted, or nil if none.
+	DeletedRanges(seriesID ident.ID, blockStart xtime.UnixNano) xtime.Ranges
 }
 
 // Merger is in charge of merging filesets with some target MergeWith interface.
@@ -693,3 +711,52 @@ type StreamedMetadataEntry struct {
 
 // NewReaderFn creates a new DataFileSetReader.
 type NewReaderFn func(bytesPool pool.CheckedBytesPool, opts Options) (DataFileSetReader, error)
+
+// ColdTierStore is a blob store that offloaded data files are uploaded to,
+// keys are slash separated paths.
+type ColdTierStore interface {
+	// Put uploads the content of a reader to a key.
+	Put(ctx stdctx.Context, key string, r io.ReadSeeker) error
+
+	// Get returns a reader of the content of a key.
+	Get(ctx stdctx.Context, key string) (io.ReadCloser, error)
+
+	// List returns the keys with a prefix.
+	List(ctx stdctx.Context, prefix string) ([]string, error)
+
+	// Delete deletes a key.
+	Delete(ctx stdctx.Context, key string) error
+}
+
+// ColdTier offloads the data filesets of blocks older than the cold tier age
+// of their namespace to a blob store and fetches them back when they are read.
+// An offloaded data fileset keeps its info, digest and checkpoint files on
+// disk along with a marker file, its other files are only on disk while they
+// are cached after being fetched.
+type ColdTier interface {
+	// CacheTTL returns how long fetched data files are cached on disk.
+	CacheTTL() time.Duration
+
+	// Offload uploads the latest volume of the complete data filesets of a
+	// shard with a block start before the provided time to the blob store and
+	// removes their offloaded files from disk, returning the number of data
+	// filesets offloaded.
+	Offload(namespace ident.ID, shard uint32, before xtime.UnixNano) (int, error)
+
+	// Fetch downloads the offloaded files of a data fileset that are not on
+	// disk, it is a no-op for data filesets that have not been offloaded. The
+	// files are not evicted until the returned closer is closed, which must be
+	// done once they have been opened.
+	Fetch(id FileSetFileIdentifier) (xresource.SimpleCloser, error)
+
+	// Evict removes the cached files of the offloaded data filesets of a shard
+	// that were fetched longer than the cache TTL ago and are not being
+	// opened, returning the number of data filesets evicted.
+	Evict(namespace ident.ID, shard uint32) (int, error)
+
+	// DeleteOrphans deletes the files in the blob store of the offloaded data
+	// filesets that have been removed from disk for longer than the orphan
+	// grace period, returning the number of files deleted. It must not be
+	// called concurrently with Offload.
+	DeleteOrphans() (int, error)
+}
@@ -34,6 +34,7 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/digest"
 	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/persist/fs/msgpack"
 	"github.com/m3db/m3/src/dbnode/persist/schema"
 	"github.com/m3db/m3/src/dbnode/ts"
@@ -75,6 +76,13 @@ type writer struct {
 	volumeIndex  int
 	snapshotTime xtime.UnixNano
 	snapshotID   uuid.UUID
+	compression  compression.Type
+	codec        compression.Codec
+
+	// Buffers reused across series when writing compressed filesets.
+	uncompressedBuf []byte
+	compressedBuf   []byte
+	compressedTags  []byte
 
 	currIdx            int64
 	currOffset         int64
@@ -91,6 +99,7 @@ type indexEntry struct {
 	dataFileOffset  int64
 	indexFileOffset int64
 	size            uint32
+	compressedSize  uint32
 	dataChecksum    uint32
 }
 
@@ -163,7 +172,11 @@ func (w *writer) Open(opts DataWriterOpenOptions) error {
 		blockStart  = opts.Identifier.BlockStart
 		volumeIndex = opts.Identifier.VolumeIndex
 	)
-	w.reset(opts)
+	codec, err := compression.NewCodec(opts.Compression)
+	if err != nil {
+		return err
+	}
+	w.reset(opts, codec)
 
 	var (
 		shardDir            string
@@ -232,8 +245,10 @@ func (w *writer) Open(opts DataWriterOpenOptions) error {
 	return nil
 }
 
-func (w *writer) reset(opts DataWriterOpenOptions) {
+func (w *writer) reset(opts DataWriterOpenOptions, codec compression.Codec) {
 	w.blockSize = opts.BlockSize
+	w.compression = opts.Compression
+	w.codec = codec
 	w.start = opts.Identifier.BlockStart
 	w.volumeIndex = opts.Identifier.VolumeIndex
 	w.snapshotTime = opts.Snapshot.SnapshotTime
@@ -260,6 +275,16 @@ func (w *writer) writeData(data []byte) error {
 	return nil
 }
 
+// writeCompressedData compresses the data of a series as a single block and
+// writes it to the data file, returning the compressed size.
+func (w *writer) writeCompressedData(data []byte) (uint32, error) {
+	w.compressedBuf = w.codec.Compress(w.compressedBuf, data)
+	if err := w.writeData(w.compressedBuf); err != nil {
+		return 0, err
+	}
+	return uint32(len(w.compressedBuf)), nil
+}
+
 func (w *writer) Write(
 	metadata persist.Metadata,
 	data checked.Bytes,
@@ -310,13 +335,29 @@ func (w *writer) writeAll(
 		},
 		metadata: metadata,
 	}
-	for _, d := range data {
-		if d == nil {
-			continue
+	if w.codec != nil {
+		buf := w.uncompressedBuf[:0]
+		for _, d := range data {
+			if d == nil {
+				continue
+			}
+			buf = append(buf, d.Bytes()...)
 		}
-		if err := w.writeData(d.Bytes()); err != nil {
+		w.uncompressedBuf = buf
+		compressedSize, err := w.writeCompressedData(buf)
+		if err != nil {
 			return err
 		}
+		entry.entry.compressedSize = compressedSize
+	} else {
+		for _, d := range data {
+			if d == nil {
+				continue
+			}
+			if err := w.writeData(d.Bytes()); err != nil {
+				return err
+			}
+		}
 	}
 
 	w.indexEntries = append(w.indexEntries, entry)
@@ -529,13 +570,19 @@ func (w *writer) writeIndexWithEncodedTags(
 	encodedTags ts.EncodedTags,
 	entry indexEntry,
 ) (int64, error) {
+	if w.codec != nil && len(encodedTags) > 0 {
+		w.compressedTags = w.codec.Compress(w.compressedTags, encodedTags)
+		encodedTags = w.compressedTags
+	}
+
 	e := schema.IndexEntry{
-		Index:        entry.index,
-		ID:           id,
-		Size:         int64(entry.size),
-		Offset:       entry.dataFileOffset,
-		DataChecksum: int64(entry.dataChecksum),
-		EncodedTags:  encodedTags,
+		Index:          entry.index,
+		ID:             id,
+		Size:           int64(entry.size),
+		Offset:         entry.dataFileOffset,
+		DataChecksum:   int64(entry.dataChecksum),
+		EncodedTags:    encodedTags,
+		CompressedSize: int64(entry.compressedSize),
 	}
 
 	w.encoder.Reset()
@@ -617,6 +664,7 @@ func (w *writer) writeInfoFileContents(
 		Entries:      entriesCount,
 		MajorVersion: schema.MajorVersion,
 		MinorVersion: schema.MinorVersion,
+		Compression:  w.compression,
 		Summaries: schema.IndexSummariesInfo{
 			Summaries: int64(summaries),
 		},
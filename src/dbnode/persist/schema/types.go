@@ -22,6 +22,7 @@ package schema
 
 import (
 	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/compression"
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/x/ident"
 )
@@ -34,7 +35,7 @@ const MajorVersion = 1
 // MinorVersion is the minor schema version for a set of fileset files.
 // This is only incremented when *non-breaking* changes are introduced that
 // we want to have some level of control around how they're rolled out.
-const MinorVersion = 1
+const MinorVersion = 2
 
 // IndexInfo stores metadata information about block filesets.
 type IndexInfo struct {
@@ -49,6 +50,7 @@ type IndexInfo struct {
 	SnapshotID   []byte
 	VolumeIndex  int
 	MinorVersion int64
+	Compression  compression.Type
 }
 
 // IndexSummariesInfo stores metadata about the summaries.
@@ -67,14 +69,19 @@ type IndexBloomFilterInfo struct {
 // When serialized to disk, the encoder will automatically add the IndexEntryChecksum, a checksum to validate
 // the index entry itself, to the end of the entry. That field is not exposed on this struct as this is handled
 // transparently by the encoder and decoder. Appending of checksum starts in V3.
+//
+// When the fileset is compressed the Size and DataChecksum refer to the
+// uncompressed data while CompressedSize is the size of the data in the data
+// file, the EncodedTags are stored compressed. Compressed size starts in V4.
 type IndexEntry struct {
-	Index         int64
-	ID            []byte
-	Size          int64
-	Offset        int64
-	DataChecksum  int64
-	EncodedTags   []byte
-	IndexChecksum int64
+	Index          int64
+	ID             []byte
+	Size           int64
+	Offset         int64
+	DataChecksum   int64
+	EncodedTags    []byte
+	CompressedSize int64
+	IndexChecksum  int64
 }
 
 // IndexEntryHasher hashes an index entry.
@@ -41,3 +41,9 @@ func NewVersionChecker(majorVersion int, minorVersion int) VersionChecker {
 func (v *VersionChecker) IndexEntryValidationEnabled() bool {
 	return v.majorVersion >= 2 || v.majorVersion == 1 && v.minorVersion >= 1
 }
+
+// FileSetCompressionEnabled checks the version to determine if fileset files
+// of the specified version record the compression of their contents.
+func (v *VersionChecker) FileSetCompressionEnabled() bool {
+	return v.majorVersion >= 2 || v.majorVersion == 1 && v.minorVersion >= 2
+}
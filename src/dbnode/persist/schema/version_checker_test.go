@@ -44,3 +44,19 @@ func TestIndexEntryValidationDisabled(t *testing.T) {
 	checker := NewVersionChecker(1, 0)
 	require.False(t, checker.IndexEntryValidationEnabled())
 }
+
+func TestFileSetCompressionEnabled(t *testing.T) {
+	checker := NewVersionChecker(1, 2)
+	require.True(t, checker.FileSetCompressionEnabled())
+
+	checker = NewVersionChecker(2, 0)
+	require.True(t, checker.FileSetCompressionEnabled())
+}
+
+func TestFileSetCompressionDisabled(t *testing.T) {
+	checker := NewVersionChecker(1, 0)
+	require.False(t, checker.FileSetCompressionEnabled())
+
+	checker = NewVersionChecker(1, 1)
+	require.False(t, checker.FileSetCompressionEnabled())
+}
@@ -29,6 +29,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/ratelimit"
+	"github.com/m3db/m3/src/dbnode/storage/limits"
 	"github.com/m3db/m3/src/dbnode/topology"
 	"github.com/m3db/m3/src/x/resource"
 
@@ -142,6 +143,20 @@ func (mr *MockOptionsMockRecorder) PersistRateLimitOptions() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PersistRateLimitOptions", reflect.TypeOf((*MockOptions)(nil).PersistRateLimitOptions))
 }
 
+// SeriesLimitsPerShard mocks base method.
+func (m *MockOptions) SeriesLimitsPerShard() []limits.SeriesLimit {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SeriesLimitsPerShard")
+	ret0, _ := ret[0].([]limits.SeriesLimit)
+	return ret0
+}
+
+// SeriesLimitsPerShard indicates an expected call of SeriesLimitsPerShard.
+func (mr *MockOptionsMockRecorder) SeriesLimitsPerShard() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SeriesLimitsPerShard", reflect.TypeOf((*MockOptions)(nil).SeriesLimitsPerShard))
+}
+
 // SetClientBootstrapConsistencyLevel mocks base method.
 func (m *MockOptions) SetClientBootstrapConsistencyLevel(value topology.ReadConsistencyLevel) Options {
 	m.ctrl.T.Helper()
@@ -226,6 +241,20 @@ func (mr *MockOptionsMockRecorder) SetPersistRateLimitOptions(value interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPersistRateLimitOptions", reflect.TypeOf((*MockOptions)(nil).SetPersistRateLimitOptions), value)
 }
 
+// SetSeriesLimitsPerShard mocks base method.
+func (m *MockOptions) SetSeriesLimitsPerShard(value []limits.SeriesLimit) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSeriesLimitsPerShard", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetSeriesLimitsPerShard indicates an expected call of SetSeriesLimitsPerShard.
+func (mr *MockOptionsMockRecorder) SetSeriesLimitsPerShard(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSeriesLimitsPerShard", reflect.TypeOf((*MockOptions)(nil).SetSeriesLimitsPerShard), value)
+}
+
 // SetTickCancellationCheckInterval mocks base method.
 func (m *MockOptions) SetTickCancellationCheckInterval(value time.Duration) Options {
 	m.ctrl.T.Helper()
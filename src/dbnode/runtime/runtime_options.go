@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/ratelimit"
+	"github.com/m3db/m3/src/dbnode/storage/limits"
 	"github.com/m3db/m3/src/dbnode/topology"
 )
 
@@ -65,6 +66,7 @@ type options struct {
 	writeNewSeriesBackoffDuration        time.Duration
 	writeNewSeriesLimitPerShardPerSecond int
 	encodersPerBlockLimit                int
+	seriesLimitsPerShard                 []limits.SeriesLimit
 	tickSeriesBatchSize                  int
 	tickPerSeriesSleepDuration           time.Duration
 	tickMinimumInterval                  time.Duration
@@ -168,6 +170,16 @@ func (o *options) EncodersPerBlockLimit() int {
 	return o.encodersPerBlockLimit
 }
 
+func (o *options) SetSeriesLimitsPerShard(value []limits.SeriesLimit) Options {
+	opts := *o
+	opts.seriesLimitsPerShard = value
+	return &opts
+}
+
+func (o *options) SeriesLimitsPerShard() []limits.SeriesLimit {
+	return o.seriesLimitsPerShard
+}
+
 func (o *options) SetTickSeriesBatchSize(value int) Options {
 	opts := *o
 	opts.tickSeriesBatchSize = value
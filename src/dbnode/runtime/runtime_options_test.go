@@ -27,6 +27,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/m3db/m3/src/dbnode/ratelimit"
+	"github.com/m3db/m3/src/dbnode/storage/limits"
 	"github.com/m3db/m3/src/dbnode/topology"
 )
 
@@ -108,6 +109,10 @@ func TestRuntimeOptions(t *testing.T) {
 	opts = opts.SetEncodersPerBlockLimit(50)
 	assert.Equal(t, 50, opts.EncodersPerBlockLimit())
 
+	seriesLimits := []limits.SeriesLimit{{TagName: "tenant", MaxActiveSeries: 100}}
+	opts = opts.SetSeriesLimitsPerShard(seriesLimits)
+	assert.Equal(t, seriesLimits, opts.SeriesLimitsPerShard())
+
 	opts = opts.SetTickSeriesBatchSize(100)
 	assert.Equal(t, 100, opts.TickSeriesBatchSize())
 
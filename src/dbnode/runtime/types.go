@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/ratelimit"
+	"github.com/m3db/m3/src/dbnode/storage/limits"
 	"github.com/m3db/m3/src/dbnode/topology"
 	xresource "github.com/m3db/m3/src/x/resource"
 )
@@ -91,6 +92,14 @@ type Options interface {
 	// causing a large burst in CPU load when trying to merge them.
 	EncodersPerBlockLimit() int
 
+	// SetSeriesLimitsPerShard sets the active series limits keyed on a tag
+	// enforced by each shard when inserting new series.
+	SetSeriesLimitsPerShard(value []limits.SeriesLimit) Options
+
+	// SeriesLimitsPerShard returns the active series limits keyed on a tag
+	// enforced by each shard when inserting new series.
+	SeriesLimitsPerShard() []limits.SeriesLimit
+
 	// SetTickSeriesBatchSize sets the batch size to process series together
 	// during a tick before yielding and sleeping the per series duration
 	// multiplied by the batch size.
@@ -54,8 +54,10 @@ import (
 	"github.com/m3db/m3/src/cluster/placementhandler"
 	"github.com/m3db/m3/src/cluster/placementhandler/handleroptions"
 	"github.com/m3db/m3/src/cmd/services/m3dbnode/config"
+	"github.com/m3db/m3/src/dbnode/backup"
 	"github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/histogram"
 	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
 	"github.com/m3db/m3/src/dbnode/encoding/proto"
 	"github.com/m3db/m3/src/dbnode/environment"
@@ -68,6 +70,7 @@ import (
 	ttnode "github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/node"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
+	"github.com/m3db/m3/src/dbnode/persist/fs/migration"
 	"github.com/m3db/m3/src/dbnode/ratelimit"
 	"github.com/m3db/m3/src/dbnode/retention"
 	m3dbruntime "github.com/m3db/m3/src/dbnode/runtime"
@@ -640,6 +643,24 @@ func Run(runOpts RunOptions) {
 		SetIndexBloomFilterFalsePositivePercent(cfg.Filesystem.BloomFilterFalsePositivePercentOrDefault()).
 		SetMmapReporter(mmapReporter)
 
+	if coldTierCfg := cfg.ColdTier; coldTierCfg != nil {
+		store, err := coldTierCfg.Store.NewStore()
+		if err != nil {
+			logger.Fatal("could not create cold tier store", zap.Error(err))
+		}
+		coldTier, err := fs.NewColdTier(fs.ColdTierOptions{
+			Store:             store,
+			HostID:            hostID,
+			CacheTTL:          coldTierCfg.CacheTTL,
+			OrphanGracePeriod: coldTierCfg.OrphanGracePeriod,
+			FilesystemOptions: fsopts,
+		})
+		if err != nil {
+			logger.Fatal("could not create cold tier", zap.Error(err))
+		}
+		fsopts = fsopts.SetColdTier(coldTier)
+	}
+
 	var commitLogQueueSize int
 	cfgCommitLog := cfg.CommitLogOrDefault()
 	specified := cfgCommitLog.Queue.Size
@@ -1006,6 +1027,45 @@ func Run(runOpts RunOptions) {
 		opts = opts.SetRepairEnabled(false)
 	}
 
+	if tileCfg := cfg.TileAggregation; tileCfg != nil && tileCfg.Enabled {
+		newTileAggregatorFn := storage.NewTileAggregator
+		if fn := runOpts.StorageOptions.NewTileAggregatorFn; fn != nil {
+			newTileAggregatorFn = fn
+		}
+		opts = opts.SetTileAggregator(newTileAggregatorFn(iOpts))
+
+		if len(tileCfg.Rules) > 0 {
+			rules, err := tileCfg.TileAggregationRules()
+			if err != nil {
+				logger.Fatal("could not parse tile aggregation rules", zap.Error(err))
+			}
+			opts = opts.SetBackgroundProcessFns(append(opts.BackgroundProcessFns(),
+				storage.NewTileAggregationSchedulerFn(rules, tileCfg.CheckInterval)))
+		}
+	}
+
+	// Re-block the filesets of a previous block size of a namespace that could not be
+	// re-blocked while bootstrapping as their blocks are warm flushed.
+	opts = opts.SetFileSetReblocker(migration.NewFileSetReblocker())
+
+	if backupCfg := cfg.Backup; backupCfg != nil {
+		store, err := backupCfg.Store.NewStore()
+		if err != nil {
+			logger.Fatal("could not create backup store", zap.Error(err))
+		}
+		backupManager, err := backup.NewManager(backup.NewOptions().
+			SetStore(store).
+			SetHostID(hostID).
+			SetFilesystemOptions(fsopts).
+			SetInstrumentOptions(iOpts))
+		if err != nil {
+			logger.Fatal("could not create backup manager", zap.Error(err))
+		}
+		opts = opts.SetBackgroundProcessFns(append(opts.BackgroundProcessFns(),
+			storage.NewBackupSchedulerFn(backupManager, backupCfg.NamespaceIDs(),
+				backupCfg.Interval, backupCfg.Retain)))
+	}
+
 	// Set bootstrap options - We need to create a topology map provider from the
 	// same topology that will be passed to the cluster so that when we make
 	// bootstrapping decisions they are in sync with the clustered database
@@ -1089,6 +1149,8 @@ func Run(runOpts RunOptions) {
 			runtimeOptsMgr, cfg.Limits.WriteNewSeriesPerSecond)
 		kvWatchEncodersPerBlockLimit(syncCfg.KVStore, logger,
 			runtimeOptsMgr, cfg.Limits.MaxEncodersPerBlock)
+		kvWatchSeriesLimits(syncCfg.KVStore, logger, topo, runtimeOptsMgr,
+			cfg.Limits.SeriesLimitsShardSlackOrDefault())
 		kvWatchQueryLimit(syncCfg.KVStore, logger,
 			queryLimits.FetchDocsLimit(),
 			queryLimits.BytesReadLimit(),
@@ -1308,6 +1370,54 @@ func kvWatchEncodersPerBlockLimit(
 	}()
 }
 
+func kvWatchSeriesLimits(
+	store kv.Store,
+	logger *zap.Logger,
+	topo topology.Topology,
+	runtimeOptsMgr m3dbruntime.OptionsManager,
+	shardSlack float64,
+) {
+	value, err := store.Get(kvconfig.SeriesLimits)
+	if err == nil {
+		clusterLimits := &kvpb.SeriesLimits{}
+		err = value.Unmarshal(clusterLimits)
+		if err == nil {
+			err = setSeriesLimitsPerShardOnChange(topo, runtimeOptsMgr,
+				clusterLimits, shardSlack)
+		}
+		if err != nil {
+			logger.Warn("unable to set series limits", zap.Error(err))
+		}
+	} else if !errors.Is(err, kv.ErrNotFound) {
+		logger.Warn("error resolving series limits", zap.Error(err))
+	}
+
+	watch, err := store.Watch(kvconfig.SeriesLimits)
+	if err != nil {
+		logger.Error("could not watch series limits", zap.Error(err))
+		return
+	}
+
+	go func() {
+		for range watch.C() {
+			clusterLimits := &kvpb.SeriesLimits{}
+			if newValue := watch.Get(); newValue != nil {
+				if err := newValue.Unmarshal(clusterLimits); err != nil {
+					logger.Warn("unable to parse new series limits", zap.Error(err))
+					continue
+				}
+			}
+
+			err := setSeriesLimitsPerShardOnChange(topo, runtimeOptsMgr,
+				clusterLimits, shardSlack)
+			if err != nil {
+				logger.Warn("unable to set series limits", zap.Error(err))
+				continue
+			}
+		}
+	}()
+}
+
 func kvWatchQueryLimit(
 	store kv.Store,
 	logger *zap.Logger,
@@ -1566,6 +1676,48 @@ func setNewSeriesLimitPerShardOnChange(
 	return runtimeOptsMgr.Update(newRuntimeOpts)
 }
 
+func setSeriesLimitsPerShardOnChange(
+	topo topology.Topology,
+	runtimeOptsMgr m3dbruntime.OptionsManager,
+	clusterLimits *kvpb.SeriesLimits,
+	shardSlack float64,
+) error {
+	var (
+		numShards    = len(topo.Get().ShardSet().AllIDs())
+		seriesLimits = make([]limits.SeriesLimit, 0, len(clusterLimits.Limits))
+	)
+	for _, clusterLimit := range clusterLimits.Limits {
+		if clusterLimit.TagName == "" {
+			return fmt.Errorf("series limit tag name must be set: %v", clusterLimit)
+		}
+		if clusterLimit.MaxActiveSeries < 1 || numShards < 1 {
+			continue
+		}
+		// NB: series are spread across the shards and each replica of a
+		// shard holds the same series, so the cluster wide limit of unique
+		// series is split across the shards only. The series of a tag value
+		// are not spread perfectly evenly, so each shard accepts its part
+		// of the limit plus some slack to avoid rejecting writes to the
+		// shards with more series before the cluster wide limit is reached.
+		seriesLimits = append(seriesLimits, limits.SeriesLimit{
+			TagName:  clusterLimit.TagName,
+			TagValue: clusterLimit.TagValue,
+			MaxActiveSeries: int64(math.Ceil(
+				float64(clusterLimit.MaxActiveSeries) * (1 + shardSlack) / float64(numShards))),
+		})
+	}
+
+	runtimeOpts := runtimeOptsMgr.Get()
+	if limits.SeriesLimitsEqual(runtimeOpts.SeriesLimitsPerShard(), seriesLimits) {
+		// Not changed, no need to set the value and trigger a runtime options update
+		return nil
+	}
+
+	newRuntimeOpts := runtimeOpts.
+		SetSeriesLimitsPerShard(seriesLimits)
+	return runtimeOptsMgr.Update(newRuntimeOpts)
+}
+
 func clusterLimitToPlacedShardLimit(topo topology.Topology, clusterLimit int) int {
 	if clusterLimit < 1 {
 		return 0
@@ -1782,6 +1934,12 @@ func withEncodingAndPoolingOptions(
 			enc := proto.NewEncoder(0, encodingOpts)
 			return enc
 		}
+		if cfg.NativeHistograms.EnabledOrDefault() {
+			// NB: series without histograms are still written as plain m3tsz
+			// streams, the streams of series with histograms can only be read
+			// by nodes and coordinators that support the histogram scheme.
+			return histogram.NewEncoder(0, m3tsz.DefaultIntOptimizationEnabled, encodingOpts)
+		}
 
 		return m3tsz.NewEncoder(0, nil, m3tsz.DefaultIntOptimizationEnabled, encodingOpts)
 	})
@@ -1790,7 +1948,9 @@ func withEncodingAndPoolingOptions(
 		if cfg.Proto != nil && cfg.Proto.Enabled {
 			return proto.NewIterator(r, descr, encodingOpts)
 		}
-		return m3tsz.NewReaderIterator(r, m3tsz.DefaultIntOptimizationEnabled, encodingOpts)
+		// NB: the histogram iterator reads plain m3tsz streams too, so data
+		// written before native histograms were enabled remains readable.
+		return histogram.NewReaderIterator(r, m3tsz.DefaultIntOptimizationEnabled, encodingOpts)
 	})
 
 	multiIteratorPool.Init(func(r xio.Reader64, descr namespace.SchemaDescr) encoding.ReaderIterator {
@@ -33,6 +33,7 @@ import (
 	"github.com/uber/tchannel-go"
 	"go.uber.org/zap"
 
+	"github.com/m3db/m3/src/cluster/generated/proto/kvpb"
 	"github.com/m3db/m3/src/cluster/kv"
 	"github.com/m3db/m3/src/cmd/services/m3dbnode/config"
 	"github.com/m3db/m3/src/dbnode/client"
@@ -147,6 +148,26 @@ func TestKvWatchQueryLimit(t *testing.T) {
 		mockDiskSeriesReadLimit, mockAggregateDocsLimit, mockDefaultOpts)
 }
 
+func TestKvWatchSeriesLimits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := kv.NewMockStore(ctrl)
+	mockTopo := topology.NewMockTopology(ctrl)
+	mockRuntimeOptsMgr := runtime.NewMockOptionsManager(ctrl)
+	mockWatch := kv.NewMockValueWatch(ctrl)
+	notifyChannel := make(chan struct{})
+	logger := zap.NewNop()
+
+	mockStore.EXPECT().Get("m3db.series.limits").Return(nil, kv.ErrNotFound).AnyTimes()
+	mockStore.EXPECT().Watch("m3db.series.limits").Return(mockWatch, nil)
+	mockWatch.EXPECT().C().Return(notifyChannel).AnyTimes()
+	kvWatchSeriesLimits(mockStore, logger, mockTopo, mockRuntimeOptsMgr, 0)
+
+	mockStore.EXPECT().Watch("m3db.series.limits").Return(nil, errors.New("watch error"))
+	kvWatchSeriesLimits(mockStore, logger, mockTopo, mockRuntimeOptsMgr, 0)
+}
+
 func TestKvWatchClientConsistencyLevels(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -214,6 +235,64 @@ func TestSetNewSeriesLimitPerShardOnChange(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestSetSeriesLimitsPerShardOnChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTopo := topology.NewMockTopology(ctrl)
+	mockTopoMap := topology.NewMockMap(ctrl)
+	mockRuntimeOptsMgr := runtime.NewMockOptionsManager(ctrl)
+	mockShardSet := sharding.NewMockShardSet(ctrl)
+	clusterLimits := &kvpb.SeriesLimits{
+		Limits: []*kvpb.SeriesLimit{
+			{TagName: "tenant", MaxActiveSeries: 10},
+			{TagName: "__name__", TagValue: "foo", MaxActiveSeries: 4},
+			{TagName: "host"},
+		},
+	}
+
+	mockTopo.EXPECT().Get().Return(mockTopoMap).AnyTimes()
+	mockTopoMap.EXPECT().ShardSet().Return(mockShardSet).AnyTimes()
+	mockShardSet.EXPECT().AllIDs().Return([]uint32{0, 1, 2, 3}).AnyTimes()
+
+	mockRuntimeOptsMgr.EXPECT().Get().Return(runtime.NewOptions())
+	mockRuntimeOptsMgr.EXPECT().Update(gomock.Any()).DoAndReturn(func(opts runtime.Options) error {
+		require.Equal(t, []limits.SeriesLimit{
+			{TagName: "tenant", MaxActiveSeries: 3},
+			{TagName: "__name__", TagValue: "foo", MaxActiveSeries: 1},
+		}, opts.SeriesLimitsPerShard())
+		return nil
+	})
+	err := setSeriesLimitsPerShardOnChange(mockTopo, mockRuntimeOptsMgr, clusterLimits, 0)
+	require.NoError(t, err)
+
+	// Not updated when unchanged.
+	mockRuntimeOptsMgr.EXPECT().Get().Return(runtime.NewOptions().SetSeriesLimitsPerShard(
+		[]limits.SeriesLimit{
+			{TagName: "tenant", MaxActiveSeries: 3},
+			{TagName: "__name__", TagValue: "foo", MaxActiveSeries: 1},
+		}))
+	err = setSeriesLimitsPerShardOnChange(mockTopo, mockRuntimeOptsMgr, clusterLimits, 0)
+	require.NoError(t, err)
+
+	// Each shard accepts its part of the limit plus the slack.
+	mockRuntimeOptsMgr.EXPECT().Get().Return(runtime.NewOptions())
+	mockRuntimeOptsMgr.EXPECT().Update(gomock.Any()).DoAndReturn(func(opts runtime.Options) error {
+		require.Equal(t, []limits.SeriesLimit{
+			{TagName: "tenant", MaxActiveSeries: 4},
+			{TagName: "__name__", TagValue: "foo", MaxActiveSeries: 2},
+		}, opts.SeriesLimitsPerShard())
+		return nil
+	})
+	err = setSeriesLimitsPerShardOnChange(mockTopo, mockRuntimeOptsMgr, clusterLimits, 0.6)
+	require.NoError(t, err)
+
+	err = setSeriesLimitsPerShardOnChange(mockTopo, mockRuntimeOptsMgr, &kvpb.SeriesLimits{
+		Limits: []*kvpb.SeriesLimit{{MaxActiveSeries: 10}},
+	}, 0)
+	require.Error(t, err)
+}
+
 func TestSetEncodersPerBlockLimitOnChange(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
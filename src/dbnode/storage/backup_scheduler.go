@@ -0,0 +1,171 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	stdctx "context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+
+	"github.com/m3db/m3/src/dbnode/backup"
+	"github.com/m3db/m3/src/x/ident"
+)
+
+const defaultBackupInterval = 24 * time.Hour
+
+var (
+	errBackupNoManager  = errors.New("backup manager not set")
+	errBackupInProgress = errors.New("backup already in progress")
+)
+
+type backupScheduler struct {
+	database   Database
+	manager    backup.Manager
+	namespaces []ident.ID
+	interval   time.Duration
+	retain     int
+	logger     *zap.Logger
+
+	running   int32
+	closeCh   chan struct{}
+	closeOnce sync.Once
+
+	status tally.Gauge
+	errors tally.Counter
+}
+
+// NewBackupSchedulerFn returns a NewBackgroundProcessFn creating a process
+// that backs up the namespaces every interval and deletes all but the latest
+// retain backups. All namespaces are backed up if none are given, an
+// interval of zero uses the default interval and a retain of zero keeps all
+// the backups.
+func NewBackupSchedulerFn(
+	manager backup.Manager,
+	namespaces []ident.ID,
+	interval time.Duration,
+	retain int,
+) NewBackgroundProcessFn {
+	return func(database Database, opts Options) (BackgroundProcess, error) {
+		return newBackupScheduler(database, opts, manager, namespaces, interval, retain)
+	}
+}
+
+func newBackupScheduler(
+	database Database,
+	opts Options,
+	manager backup.Manager,
+	namespaces []ident.ID,
+	interval time.Duration,
+	retain int,
+) (*backupScheduler, error) {
+	if manager == nil {
+		return nil, errBackupNoManager
+	}
+	if interval <= 0 {
+		interval = defaultBackupInterval
+	}
+
+	iOpts := opts.InstrumentOptions()
+	scope := iOpts.MetricsScope().SubScope("backup-scheduler")
+	return &backupScheduler{
+		database:   database,
+		manager:    manager,
+		namespaces: namespaces,
+		interval:   interval,
+		retain:     retain,
+		logger:     iOpts.Logger(),
+		closeCh:    make(chan struct{}),
+		status:     scope.Gauge("running"),
+		errors:     scope.Counter("errors"),
+	}, nil
+}
+
+func (s *backupScheduler) Start() {
+	go s.run()
+}
+
+func (s *backupScheduler) Stop() {
+	s.closeOnce.Do(func() { close(s.closeCh) })
+}
+
+func (s *backupScheduler) Report() {
+	if atomic.LoadInt32(&s.running) == 1 {
+		s.status.Update(1)
+	} else {
+		s.status.Update(0)
+	}
+}
+
+func (s *backupScheduler) run() {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.closeCh:
+			return
+		case <-ticker.C:
+			if err := s.Backup(); err != nil {
+				s.errors.Inc(1)
+				s.logger.Error("error backing up namespaces", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Backup backs up the namespaces and prunes the backups exceeding the number
+// of backups retained.
+func (s *backupScheduler) Backup() error {
+	if !s.database.IsBootstrapped() {
+		return nil
+	}
+
+	if !atomic.CompareAndSwapInt32(&s.running, 0, 1) {
+		return errBackupInProgress
+	}
+	defer atomic.StoreInt32(&s.running, 0)
+
+	namespaces := s.namespaces
+	if len(namespaces) == 0 {
+		for _, ns := range s.database.Namespaces() {
+			namespaces = append(namespaces, ns.ID())
+		}
+	}
+
+	ctx := stdctx.Background()
+	manifest, err := s.manager.Backup(ctx, namespaces)
+	if err != nil {
+		return err
+	}
+	s.logger.Info("backed up namespaces",
+		zap.String("backupID", manifest.ID),
+		zap.Int("namespaces", len(manifest.Namespaces)))
+
+	if s.retain > 0 {
+		return s.manager.Prune(ctx, manifest.HostID, s.retain)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	stdctx "context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/dbnode/backup"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/x/ident"
+	xtest "github.com/m3db/m3/src/x/test"
+)
+
+func TestBackupSchedulerBacksUpAndPrunes(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	dir, err := ioutil.TempDir("", "backup-scheduler")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	store, err := backup.NewLocalStore(filepath.Join(dir, "store"))
+	require.NoError(t, err)
+	manager, err := backup.NewManager(backup.NewOptions().
+		SetStore(store).
+		SetHostID("host0").
+		SetFilesystemOptions(fs.NewOptions().SetFilePathPrefix(filepath.Join(dir, "data"))))
+	require.NoError(t, err)
+
+	ns := NewMockNamespace(ctrl)
+	ns.EXPECT().ID().Return(ident.StringID("metrics")).AnyTimes()
+	db := NewMockDatabase(ctrl)
+	db.EXPECT().IsBootstrapped().Return(false)
+	db.EXPECT().IsBootstrapped().Return(true).Times(3)
+	db.EXPECT().Namespaces().Return([]Namespace{ns}).Times(3)
+
+	process, err := NewBackupSchedulerFn(manager, nil, 0, 2)(db, DefaultTestOptions())
+	require.NoError(t, err)
+	scheduler := process.(*backupScheduler)
+
+	// Nothing is backed up before the database is bootstrapped.
+	require.NoError(t, scheduler.Backup())
+	ids, err := manager.Backups(stdctx.Background(), "host0")
+	require.NoError(t, err)
+	require.Empty(t, ids)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, scheduler.Backup())
+	}
+	ids, err = manager.Backups(stdctx.Background(), "host0")
+	require.NoError(t, err)
+	require.Len(t, ids, 2)
+
+	manifest, err := manager.Manifest(stdctx.Background(), "host0", ids[1])
+	require.NoError(t, err)
+	_, ok := manifest.Namespace(ident.StringID("metrics"))
+	require.True(t, ok)
+}
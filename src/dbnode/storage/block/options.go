@@ -22,6 +22,7 @@ package block
 
 import (
 	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/histogram"
 	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
 	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/dbnode/ts"
@@ -94,7 +95,7 @@ func NewOptions() Options {
 	o.encoderPool.Init(func() encoding.Encoder {
 		return m3tsz.NewEncoder(timeZero, nil, m3tsz.DefaultIntOptimizationEnabled, encodingOpts)
 	})
-	o.readerIteratorPool.Init(m3tsz.DefaultReaderIteratorAllocFn(encodingOpts))
+	o.readerIteratorPool.Init(histogram.DefaultReaderIteratorAllocFn(encodingOpts))
 	o.multiReaderIteratorPool.Init(
 		func(r xio.Reader64, descr namespace.SchemaDescr) encoding.ReaderIterator {
 			it := o.readerIteratorPool.Get()
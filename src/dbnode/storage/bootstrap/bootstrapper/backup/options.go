@@ -0,0 +1,102 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package backup
+
+import (
+	"errors"
+
+	"github.com/m3db/m3/src/dbnode/backup"
+	bfs "github.com/m3db/m3/src/dbnode/storage/bootstrap/bootstrapper/fs"
+)
+
+var (
+	errNoFilesystemBootstrapperOptions = errors.New("filesystem bootstrapper options not set")
+	errNoBackupManager                 = errors.New("backup manager not set")
+	errNoHostID                        = errors.New("backup host ID not set")
+)
+
+type options struct {
+	fsOpts   bfs.Options
+	manager  backup.Manager
+	hostID   string
+	backupID string
+}
+
+// NewOptions creates a new Options.
+func NewOptions() Options {
+	return &options{}
+}
+
+func (o *options) Validate() error {
+	if o.fsOpts == nil {
+		return errNoFilesystemBootstrapperOptions
+	}
+	if err := o.fsOpts.Validate(); err != nil {
+		return err
+	}
+	if o.manager == nil {
+		return errNoBackupManager
+	}
+	if o.hostID == "" {
+		return errNoHostID
+	}
+	return nil
+}
+
+func (o *options) SetFilesystemBootstrapperOptions(value bfs.Options) Options {
+	opts := *o
+	opts.fsOpts = value
+	return &opts
+}
+
+func (o *options) FilesystemBootstrapperOptions() bfs.Options {
+	return o.fsOpts
+}
+
+func (o *options) SetBackupManager(value backup.Manager) Options {
+	opts := *o
+	opts.manager = value
+	return &opts
+}
+
+func (o *options) BackupManager() backup.Manager {
+	return o.manager
+}
+
+func (o *options) SetHostID(value string) Options {
+	opts := *o
+	opts.hostID = value
+	return &opts
+}
+
+func (o *options) HostID() string {
+	return o.hostID
+}
+
+func (o *options) SetBackupID(value string) Options {
+	opts := *o
+	opts.backupID = value
+	return &opts
+}
+
+func (o *options) BackupID() string {
+	return o.backupID
+}
@@ -0,0 +1,334 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package backup
+
+import (
+	stdctx "context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/m3db/m3/src/dbnode/backup"
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/storage/bootstrap"
+	bfs "github.com/m3db/m3/src/dbnode/storage/bootstrap/bootstrapper/fs"
+	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
+	"github.com/m3db/m3/src/x/context"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+// backupSource restores the filesets of a backup that are not on disk and
+// reads them with a filesystem source.
+type backupSource struct {
+	opts     Options
+	manager  backup.Manager
+	fsOpts   fs.Options
+	fsSource bootstrap.Source
+	log      *zap.Logger
+
+	// manifest is the manifest of the backup restored, loaded once per
+	// bootstrap run.
+	manifest       backup.Manifest
+	manifestLoaded bool
+	// restoreRecorded is true if the data directory has already restored a
+	// backup, in which case the manifest is empty.
+	restoreRecorded bool
+}
+
+func newBackupSource(opts Options) (bootstrap.Source, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	bfsOpts := opts.FilesystemBootstrapperOptions()
+	iOpts := bfsOpts.InstrumentOptions()
+	iOpts = iOpts.SetMetricsScope(iOpts.MetricsScope().SubScope("backup-bootstrapper"))
+	fsSource, err := bfs.NewFileSystemSource(bfsOpts.SetInstrumentOptions(iOpts))
+	if err != nil {
+		return nil, err
+	}
+
+	return &backupSource{
+		opts:     opts,
+		manager:  opts.BackupManager(),
+		fsOpts:   bfsOpts.FilesystemOptions(),
+		fsSource: fsSource,
+		log:      iOpts.Logger().With(zap.String("bootstrapper", BackupBootstrapperName)),
+	}, nil
+}
+
+func (s *backupSource) AvailableData(
+	md namespace.Metadata,
+	shardTimeRanges result.ShardTimeRanges,
+	_ bootstrap.Cache,
+	_ bootstrap.RunOptions,
+) (result.ShardTimeRanges, error) {
+	ns, ok := s.namespaceManifest(stdctx.Background(), md)
+	if !ok {
+		return result.NewShardTimeRanges(), nil
+	}
+	blockSize := md.Options().RetentionOptions().BlockSize()
+	return availableRanges(ns.Data, blockSize, shardTimeRanges), nil
+}
+
+func (s *backupSource) AvailableIndex(
+	md namespace.Metadata,
+	shardTimeRanges result.ShardTimeRanges,
+	_ bootstrap.Cache,
+	_ bootstrap.RunOptions,
+) (result.ShardTimeRanges, error) {
+	ns, ok := s.namespaceManifest(stdctx.Background(), md)
+	if !ok {
+		return result.NewShardTimeRanges(), nil
+	}
+	// The index of blocks without index filesets is built from the data
+	// filesets by the filesystem source.
+	available := availableRanges(ns.Data,
+		md.Options().RetentionOptions().BlockSize(), shardTimeRanges)
+	available.AddRanges(availableRanges(ns.Index,
+		md.Options().IndexOptions().BlockSize(), shardTimeRanges))
+	return available, nil
+}
+
+func (s *backupSource) Read(
+	ctx context.Context,
+	namespaces bootstrap.Namespaces,
+	cache bootstrap.Cache,
+) (bootstrap.NamespaceResults, error) {
+	goCtx := ctx.GoContext()
+	if goCtx == nil {
+		goCtx = stdctx.Background()
+	}
+
+	// The manifest is loaded again by the next run so that it is not stale.
+	defer s.resetManifest()
+	s.ensureManifest(goCtx)
+
+	// Snapshots are only restored if there are none on disk so that newer
+	// snapshots are never shadowed by the ones of the backup.
+	restoreSnapshots, err := s.shouldRestoreSnapshots()
+	if err != nil {
+		return bootstrap.NamespaceResults{}, err
+	}
+
+	var filesets []backup.FileSet
+	for _, elem := range namespaces.Namespaces.Iter() {
+		ns := elem.Value()
+		nsManifest, ok := s.namespaceManifest(goCtx, ns.Metadata)
+		if !ok {
+			continue
+		}
+		opts := ns.Metadata.Options()
+		filesets = appendRequested(filesets, nsManifest.Data,
+			opts.RetentionOptions().BlockSize(), ns.DataRunOptions.ShardTimeRanges)
+		if opts.IndexOptions().Enabled() {
+			filesets = appendRequested(filesets, nsManifest.Index,
+				opts.IndexOptions().BlockSize(), ns.IndexRunOptions.ShardTimeRanges)
+		}
+		if restoreSnapshots {
+			filesets = appendShards(filesets, nsManifest.Snapshots, ns.Shards)
+		}
+	}
+	if restoreSnapshots && len(filesets) > 0 {
+		filesets = append(filesets, *s.manifest.SnapshotMetadata)
+	}
+
+	if len(filesets) > 0 {
+		restored, err := s.manager.Restore(goCtx, s.manifest, filesets)
+		if err != nil {
+			return bootstrap.NamespaceResults{}, err
+		}
+		s.log.Info("restored filesets from backup",
+			zap.String("hostID", s.manifest.HostID),
+			zap.String("backupID", s.manifest.ID),
+			zap.Int("requested", len(filesets)),
+			zap.Int("restored", restored))
+		if restored > 0 {
+			// Read the info files of the restored filesets.
+			cache.Evict()
+		}
+	}
+	if restoreSnapshots && len(filesets) > 0 {
+		// The writes after the snapshots are read from the commit logs.
+		restored, err := s.manager.RestoreCommitLogs(goCtx, s.manifest)
+		if err != nil {
+			return bootstrap.NamespaceResults{}, err
+		}
+		s.log.Info("restored commit logs from backup",
+			zap.String("hostID", s.manifest.HostID),
+			zap.String("backupID", s.manifest.ID),
+			zap.Int("restored", restored))
+	}
+
+	// Only the initial bootstrap of the data directory restores a backup, so
+	// that the later bootstraps do not restore the backups of the node itself.
+	if s.manifestLoaded && !s.restoreRecorded {
+		if err := fs.WriteBackupRestore(s.fsOpts, fs.BackupRestore{
+			HostID:     s.opts.HostID(),
+			BackupID:   s.manifest.ID,
+			RestoredAt: time.Now().UTC(),
+		}); err != nil {
+			return bootstrap.NamespaceResults{}, err
+		}
+	}
+
+	return s.fsSource.Read(ctx, namespaces, cache)
+}
+
+func (s *backupSource) shouldRestoreSnapshots() (bool, error) {
+	if s.manifest.SnapshotMetadata == nil {
+		return false, nil
+	}
+	metadatas, _, err := fs.SortedSnapshotMetadataFiles(s.fsOpts)
+	if err != nil {
+		return false, err
+	}
+	return len(metadatas) == 0, nil
+}
+
+func (s *backupSource) namespaceManifest(
+	ctx stdctx.Context,
+	md namespace.Metadata,
+) (backup.NamespaceManifest, bool) {
+	s.ensureManifest(ctx)
+	return s.manifest.Namespace(md.ID())
+}
+
+func (s *backupSource) ensureManifest(ctx stdctx.Context) {
+	if s.manifestLoaded {
+		return
+	}
+	manifest, err := s.loadManifest(ctx)
+	if err != nil {
+		// Do not fail the bootstrap, the next bootstrappers may still
+		// fulfill the ranges. The manifest is loaded again by the next call.
+		s.log.Error("could not load backup manifest", zap.Error(err))
+		return
+	}
+	s.manifest = manifest
+	s.manifestLoaded = true
+}
+
+func (s *backupSource) resetManifest() {
+	s.manifest = backup.Manifest{}
+	s.manifestLoaded = false
+	s.restoreRecorded = false
+}
+
+func (s *backupSource) loadManifest(ctx stdctx.Context) (backup.Manifest, error) {
+	var (
+		hostID   = s.opts.HostID()
+		backupID = s.opts.BackupID()
+	)
+	restore, ok, err := fs.ReadBackupRestore(s.fsOpts)
+	if err != nil {
+		return backup.Manifest{}, err
+	}
+	if ok && restore.HostID == hostID && (backupID == "" || backupID == restore.BackupID) {
+		s.restoreRecorded = true
+		return backup.Manifest{}, nil
+	}
+
+	if backupID == "" {
+		ids, err := s.manager.Backups(ctx, hostID)
+		if err != nil {
+			return backup.Manifest{}, err
+		}
+		if len(ids) == 0 {
+			s.log.Info("no backup to restore", zap.String("hostID", hostID))
+			return backup.Manifest{}, nil
+		}
+		backupID = ids[len(ids)-1]
+	}
+	return s.manager.Manifest(ctx, hostID, backupID)
+}
+
+// availableRanges returns the requested ranges covered by the blocks of the
+// filesets.
+func availableRanges(
+	filesets []backup.FileSet,
+	blockSize time.Duration,
+	requested result.ShardTimeRanges,
+) result.ShardTimeRanges {
+	available := result.NewShardTimeRanges()
+	for _, fileset := range filesets {
+		block := xtime.Range{Start: fileset.BlockStart, End: fileset.BlockStart.Add(blockSize)}
+		for _, shard := range fileset.Shards {
+			ranges, ok := requested.Get(shard)
+			if !ok {
+				continue
+			}
+			for it := ranges.Iter(); it.Next(); {
+				if r, ok := it.Value().Intersect(block); ok {
+					available.GetOrAdd(shard).AddRange(r)
+				}
+			}
+		}
+	}
+	return available
+}
+
+// appendRequested appends the filesets with a block overlapping the
+// requested ranges of one of their shards.
+func appendRequested(
+	filesets []backup.FileSet,
+	candidates []backup.FileSet,
+	blockSize time.Duration,
+	requested result.ShardTimeRanges,
+) []backup.FileSet {
+	for _, fileset := range candidates {
+		block := xtime.Range{Start: fileset.BlockStart, End: fileset.BlockStart.Add(blockSize)}
+		for _, shard := range fileset.Shards {
+			if ranges, ok := requested.Get(shard); ok && ranges.Overlaps(block) {
+				filesets = append(filesets, fileset)
+				break
+			}
+		}
+	}
+	return filesets
+}
+
+// appendShards appends the filesets of the shards.
+func appendShards(
+	filesets []backup.FileSet,
+	candidates []backup.FileSet,
+	shards []uint32,
+) []backup.FileSet {
+	for _, fileset := range candidates {
+		for _, shard := range fileset.Shards {
+			if containsShard(shards, shard) {
+				filesets = append(filesets, fileset)
+				break
+			}
+		}
+	}
+	return filesets
+}
+
+func containsShard(shards []uint32, shard uint32) bool {
+	for _, s := range shards {
+		if s == shard {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,248 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package backup
+
+import (
+	stdctx "context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/m3db/m3/src/dbnode/backup"
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/storage/bootstrap"
+	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
+	"github.com/m3db/m3/src/x/checked"
+	"github.com/m3db/m3/src/x/context"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+const testHostID = "host0"
+
+var testNamespace = ident.StringID("metrics")
+
+func newTestFsOptions(t *testing.T) fs.Options {
+	dir, err := ioutil.TempDir("", "backup-bootstrapper")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	return fs.NewOptions().SetFilePathPrefix(dir)
+}
+
+func newTestManager(t *testing.T, store backup.Store, fsOpts fs.Options) backup.Manager {
+	m, err := backup.NewManager(backup.NewOptions().
+		SetStore(store).
+		SetHostID(testHostID).
+		SetFilesystemOptions(fsOpts))
+	require.NoError(t, err)
+	return m
+}
+
+func writeTestDataFileSet(
+	t *testing.T,
+	fsOpts fs.Options,
+	shard uint32,
+	blockStart xtime.UnixNano,
+	blockSize time.Duration,
+) {
+	w, err := fs.NewWriter(fsOpts)
+	require.NoError(t, err)
+	require.NoError(t, w.Open(fs.DataWriterOpenOptions{
+		Identifier: fs.FileSetFileIdentifier{
+			Namespace:  testNamespace,
+			Shard:      shard,
+			BlockStart: blockStart,
+		},
+		BlockSize: blockSize,
+	}))
+	data := checked.NewBytes([]byte{1, 2, 3}, nil)
+	data.IncRef()
+	metadata := persist.NewMetadataFromIDAndTags(ident.StringID("foo"),
+		ident.Tags{}, persist.MetadataOptions{})
+	require.NoError(t, w.Write(metadata, data, digest.Checksum(data.Bytes())))
+	data.DecRef()
+	require.NoError(t, w.Close())
+}
+
+func TestBackupSourceRestoresAndReadsFileSets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	md, err := namespace.NewMetadata(testNamespace, namespace.NewOptions())
+	require.NoError(t, err)
+
+	var (
+		blockSize  = md.Options().RetentionOptions().BlockSize()
+		blockStart = xtime.ToUnixNano(time.Now().Truncate(blockSize)).Add(-2 * blockSize)
+		srcOpts    = newTestFsOptions(t)
+		dstOpts    = newTestFsOptions(t)
+	)
+	storeDir, err := ioutil.TempDir("", "backup-store")
+	require.NoError(t, err)
+	defer os.RemoveAll(storeDir)
+	store, err := backup.NewLocalStore(storeDir)
+	require.NoError(t, err)
+
+	writeTestDataFileSet(t, srcOpts, 0, blockStart, blockSize)
+	writeTestDataFileSet(t, srcOpts, 1, blockStart, blockSize)
+	_, err = newTestManager(t, store, srcOpts).Backup(stdctx.Background(), []ident.ID{testNamespace})
+	require.NoError(t, err)
+
+	fsSource := bootstrap.NewMockSource(ctrl)
+	src := &backupSource{
+		opts:     NewOptions().SetHostID(testHostID),
+		manager:  newTestManager(t, store, dstOpts),
+		fsOpts:   dstOpts,
+		fsSource: fsSource,
+		log:      zap.NewNop(),
+	}
+
+	// Only shard 1 is requested, and only from the middle of the block on.
+	requested := result.NewShardTimeRanges().Set(1, xtime.NewRanges(xtime.Range{
+		Start: blockStart.Add(blockSize / 2),
+		End:   blockStart.Add(2 * blockSize),
+	}))
+	available, err := src.AvailableData(md, requested, nil, bootstrap.NewRunOptions())
+	require.NoError(t, err)
+	require.True(t, available.Equal(result.NewShardTimeRanges().Set(1, xtime.NewRanges(xtime.Range{
+		Start: blockStart.Add(blockSize / 2),
+		End:   blockStart.Add(blockSize),
+	}))))
+
+	namespaces := bootstrap.Namespaces{
+		Namespaces: bootstrap.NewNamespacesMap(bootstrap.NamespacesMapOptions{}),
+	}
+	namespaces.Namespaces.Set(testNamespace, bootstrap.Namespace{
+		Metadata:        md,
+		Shards:          []uint32{1},
+		DataRunOptions:  bootstrap.NamespaceRunOptions{ShardTimeRanges: available},
+		IndexRunOptions: bootstrap.NamespaceRunOptions{ShardTimeRanges: result.NewShardTimeRanges()},
+	})
+
+	var (
+		ctx   = context.NewBackground()
+		cache = bootstrap.NewMockCache(ctrl)
+	)
+	defer ctx.Close()
+	cache.EXPECT().Evict()
+	fsSource.EXPECT().Read(ctx, namespaces, cache).Return(bootstrap.NamespaceResults{}, nil)
+
+	_, err = src.Read(ctx, namespaces, cache)
+	require.NoError(t, err)
+
+	files, err := fs.DataFiles(dstOpts.FilePathPrefix(), testNamespace, 1)
+	require.NoError(t, err)
+	_, ok := files.LatestVolumeForBlock(blockStart)
+	require.True(t, ok)
+
+	// Shards that were not requested are not restored.
+	files, err = fs.DataFiles(dstOpts.FilePathPrefix(), testNamespace, 0)
+	require.NoError(t, err)
+	require.Empty(t, files)
+
+	// The later bootstraps do not restore the backup again.
+	restore, ok, err := fs.ReadBackupRestore(dstOpts)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, testHostID, restore.HostID)
+	available, err = src.AvailableData(md, result.NewShardTimeRanges().Set(0, xtime.NewRanges(xtime.Range{
+		Start: blockStart,
+		End:   blockStart.Add(blockSize),
+	})), nil, bootstrap.NewRunOptions())
+	require.NoError(t, err)
+	require.True(t, available.IsEmpty())
+}
+
+// failingManifestManager fails to return the manifest of a backup a number of
+// times before returning it.
+type failingManifestManager struct {
+	backup.Manager
+
+	failures int
+	manifest backup.Manifest
+	calls    int
+}
+
+func (m *failingManifestManager) Manifest(
+	_ stdctx.Context,
+	_, _ string,
+) (backup.Manifest, error) {
+	m.calls++
+	if m.calls <= m.failures {
+		return backup.Manifest{}, errors.New("unavailable")
+	}
+	return m.manifest, nil
+}
+
+func TestBackupSourceRetriesManifestLoad(t *testing.T) {
+	md, err := namespace.NewMetadata(testNamespace, namespace.NewOptions())
+	require.NoError(t, err)
+
+	var (
+		fsOpts  = newTestFsOptions(t)
+		manager = &failingManifestManager{
+			failures: 1,
+			manifest: backup.Manifest{
+				ID:         "b0",
+				HostID:     testHostID,
+				Namespaces: []backup.NamespaceManifest{{ID: testNamespace.String()}},
+			},
+		}
+		src = &backupSource{
+			opts:    NewOptions().SetHostID(testHostID).SetBackupID("b0"),
+			manager: manager,
+			fsOpts:  fsOpts,
+			log:     zap.NewNop(),
+		}
+		requested = result.NewShardTimeRanges().Set(1, xtime.NewRanges(xtime.Range{
+			Start: 0,
+			End:   xtime.ToUnixNano(time.Now()),
+		}))
+	)
+
+	// A failed load is not cached.
+	available, err := src.AvailableData(md, requested, nil, bootstrap.NewRunOptions())
+	require.NoError(t, err)
+	require.True(t, available.IsEmpty())
+	require.False(t, src.manifestLoaded)
+
+	_, err = src.AvailableData(md, requested, nil, bootstrap.NewRunOptions())
+	require.NoError(t, err)
+	require.True(t, src.manifestLoaded)
+	_, err = src.AvailableData(md, requested, nil, bootstrap.NewRunOptions())
+	require.NoError(t, err)
+	require.Equal(t, 2, manager.calls)
+
+	// The manifest is loaded again by the next run.
+	src.resetManifest()
+	_, err = src.AvailableData(md, requested, nil, bootstrap.NewRunOptions())
+	require.NoError(t, err)
+	require.Equal(t, 3, manager.calls)
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package backup
+
+import (
+	"github.com/m3db/m3/src/dbnode/backup"
+	bfs "github.com/m3db/m3/src/dbnode/storage/bootstrap/bootstrapper/fs"
+)
+
+// Options is the options interface for the backup source.
+type Options interface {
+	// Validate validates the options.
+	Validate() error
+
+	// SetFilesystemBootstrapperOptions sets the options of the filesystem
+	// source reading the restored filesets.
+	SetFilesystemBootstrapperOptions(value bfs.Options) Options
+
+	// FilesystemBootstrapperOptions returns the options of the filesystem
+	// source reading the restored filesets.
+	FilesystemBootstrapperOptions() bfs.Options
+
+	// SetBackupManager sets the backup manager.
+	SetBackupManager(value backup.Manager) Options
+
+	// BackupManager returns the backup manager.
+	BackupManager() backup.Manager
+
+	// SetHostID sets the ID of the host whose backup is restored.
+	SetHostID(value string) Options
+
+	// HostID returns the ID of the host whose backup is restored.
+	HostID() string
+
+	// SetBackupID sets the ID of the backup restored, the latest backup of
+	// the host is restored if empty.
+	SetBackupID(value string) Options
+
+	// BackupID returns the ID of the backup restored, the latest backup of
+	// the host is restored if empty.
+	BackupID() string
+}
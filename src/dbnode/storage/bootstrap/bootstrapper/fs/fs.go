@@ -72,6 +72,12 @@ func (p fileSystemBootstrapperProvider) Provide() (bootstrap.Bootstrapper, error
 		src, p.opts.ResultOptions(), next)
 }
 
+// NewFileSystemSource creates a new bootstrap source reading from on-disk
+// files, for bootstrappers that place filesets on disk before reading them.
+func NewFileSystemSource(opts Options) (bootstrap.Source, error) {
+	return newFileSystemSource(opts)
+}
+
 func (p fileSystemBootstrapperProvider) String() string {
 	return FileSystemBootstrapperName
 }
@@ -78,6 +78,10 @@ type migrationCandidate struct {
 	shard          uint32
 }
 
+func (c migrationCandidate) sameShard(other migrationCandidate) bool {
+	return c.metadata == other.metadata && c.shard == other.shard
+}
+
 // mergeKey is the unique set of data that identifies an ReadInfoFileResult.
 type mergeKey struct {
 	metadata   namespace.Metadata
@@ -143,9 +147,15 @@ func (m *Migrator) Run(ctx context.Context) error {
 	)
 	for i, worker := range workers {
 		endIdx := candidateIdx + candidatesPerWorker
-		if i == len(workers)-1 {
+		if i == len(workers)-1 || endIdx > len(candidates) {
 			endIdx = len(candidates)
 		}
+		// NB: candidates of the same shard are kept on the same worker since re-blocking
+		// a fileset reads and removes the filesets of neighbouring blocks.
+		for endIdx > candidateIdx && endIdx < len(candidates) &&
+			candidates[endIdx].sameShard(candidates[endIdx-1]) {
+			endIdx++
+		}
 
 		worker := worker
 		startIdx := candidateIdx // Capture current candidateIdx value for goroutine
@@ -190,7 +200,7 @@ func (m *Migrator) findMigrationCandidates() []migrationCandidate {
 	for md, resultsByShard := range m.infoFilesByNamespace {
 		for shard, results := range resultsByShard {
 			for _, info := range results {
-				newTaskFn, shouldMigrate := m.migrationTaskFn(info)
+				newTaskFn, shouldMigrate := m.migrationTaskFn(md, info)
 				if shouldMigrate {
 					candidates = append(candidates, migrationCandidate{
 						newTaskFn:      newTaskFn,
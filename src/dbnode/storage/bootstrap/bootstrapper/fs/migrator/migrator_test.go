@@ -21,12 +21,14 @@
 package migrator
 
 import (
+	"sync"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/persist/fs/migration"
 	"github.com/m3db/m3/src/dbnode/persist/schema"
@@ -63,7 +65,7 @@ func TestMigratorRun(t *testing.T) {
 	}
 
 	opts = opts.
-		SetMigrationTaskFn(func(result fs.ReadInfoFileResult) (migration.NewTaskFn, bool) {
+		SetMigrationTaskFn(func(_ namespace.Metadata, result fs.ReadInfoFileResult) (migration.NewTaskFn, bool) {
 			return newTestTask, result.Info.VolumeIndex == 0
 		}).
 		SetInfoFilesByNamespace(infoFilesByNamespace).
@@ -85,6 +87,50 @@ func TestMigratorRun(t *testing.T) {
 	}
 }
 
+func TestMigratorRunKeepsShardsOnWorker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	md, err := namespace.NewMetadata(ident.StringID("foo"), namespace.NewOptions())
+	require.NoError(t, err)
+
+	infoFilesByNamespace := bootstrap.InfoFilesByNamespace{
+		md: {
+			1: {testInfoFileWithVolumeIndex(0), testInfoFileWithVolumeIndex(0), testInfoFileWithVolumeIndex(0)},
+			2: {testInfoFileWithVolumeIndex(0), testInfoFileWithVolumeIndex(0), testInfoFileWithVolumeIndex(0)},
+		},
+	}
+
+	var (
+		lock            sync.Mutex
+		persistManagers = make(map[uint32]map[persist.Manager]struct{})
+	)
+	opts := testMigratorOptions(ctrl).
+		SetMigrationTaskFn(func(_ namespace.Metadata, _ fs.ReadInfoFileResult) (migration.NewTaskFn, bool) {
+			return func(opts migration.TaskOptions) (migration.Task, error) {
+				lock.Lock()
+				defer lock.Unlock()
+				if _, ok := persistManagers[opts.Shard()]; !ok {
+					persistManagers[opts.Shard()] = make(map[persist.Manager]struct{})
+				}
+				persistManagers[opts.Shard()][opts.PersistManager()] = struct{}{}
+				return newTestTask(opts)
+			}, true
+		}).
+		SetInfoFilesByNamespace(infoFilesByNamespace).
+		SetMigrationOptions(migration.NewOptions().SetConcurrency(4))
+
+	migrator, err := NewMigrator(opts)
+	require.NoError(t, err)
+	require.NoError(t, migrator.Run(context.NewBackground()))
+
+	// Each worker has its own persist manager so a shard is migrated by a single worker.
+	require.Equal(t, 2, len(persistManagers))
+	for _, managers := range persistManagers {
+		require.Equal(t, 1, len(managers))
+	}
+}
+
 func testMigratorOptions(ctrl *gomock.Controller) Options {
 	mockOpts := storage.NewMockOptions(ctrl)
 	mockOpts.EXPECT().Validate().AnyTimes()
@@ -26,6 +26,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/persist/fs/migration"
 	"github.com/m3db/m3/src/dbnode/storage"
@@ -107,7 +108,7 @@ func newTestOptions(ctrl *gomock.Controller) Options {
 	mockOpts.EXPECT().Validate().AnyTimes()
 
 	return NewOptions().
-		SetMigrationTaskFn(func(result fs.ReadInfoFileResult) (migration.NewTaskFn, bool) {
+		SetMigrationTaskFn(func(_ namespace.Metadata, result fs.ReadInfoFileResult) (migration.NewTaskFn, bool) {
 			return nil, false
 		}).
 		SetInfoFilesByNamespace(make(bootstrap.InfoFilesByNamespace)).
@@ -21,6 +21,7 @@
 package migrator
 
 import (
+	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/persist/fs/migration"
 	"github.com/m3db/m3/src/dbnode/storage"
@@ -29,7 +30,7 @@ import (
 )
 
 // MigrationTaskFn returns a fileset migration function and a boolean indicating if migration is necessary.
-type MigrationTaskFn func(result fs.ReadInfoFileResult) (migration.NewTaskFn, bool)
+type MigrationTaskFn func(md namespace.Metadata, result fs.ReadInfoFileResult) (migration.NewTaskFn, bool)
 
 // Options represents the options for the migrator.
 type Options interface {
@@ -152,8 +152,7 @@ func (s *fileSystemSource) Read(
 	// Perform any necessary migrations but don't block bootstrap process on failure. Will update info file
 	// in-memory structures in place if migrations have written new files to disk. This saves us the need from
 	// having to re-read migrated info files.
-	infoFilesByNamespace := cache.ReadInfoFiles()
-	s.runMigrations(ctx, infoFilesByNamespace)
+	s.runMigrations(ctx, cache)
 
 	// NB(r): Perform all data bootstrapping first then index bootstrapping
 	// to more clearly deliniate which process is slower than the other.
@@ -209,14 +208,40 @@ func (s *fileSystemSource) Read(
 	return results, nil
 }
 
-func (s *fileSystemSource) runMigrations(ctx context.Context, infoFilesByNamespace bootstrap.InfoFilesByNamespace) {
-	// Only one migration for now, so just short circuit entirely if not enabled
-	if s.opts.MigrationOptions().TargetMigrationVersion() != migration.MigrationVersion_1_1 {
+func (s *fileSystemSource) runMigrations(ctx context.Context, cache bootstrap.Cache) {
+	var versionTaskFn migrator.MigrationTaskFn
+	switch s.opts.MigrationOptions().TargetMigrationVersion() {
+	case migration.MigrationVersion_1_1:
+		versionTaskFn = migration.MigrationTask
+	case migration.MigrationVersion_1_2:
+		versionTaskFn = migration.MigrationTaskToVersion1_2
+	}
+
+	infoFilesByNamespace := cache.ReadInfoFiles()
+	reblock := needsReblock(infoFilesByNamespace)
+	if versionTaskFn == nil && !reblock {
 		return
 	}
+	if reblock {
+		// NB: re-blocking writes filesets at different block starts than the info files
+		// they were migrated from so the info files are re-read after the migrations.
+		defer cache.Evict()
+	}
+
+	migrationTaskFn := func(md namespace.Metadata, info fs.ReadInfoFileResult) (migration.NewTaskFn, bool) {
+		// NB: re-blocked filesets are written with the current version and compression
+		// so re-blocking takes precedence over version migrations.
+		if newTaskFn, ok := migration.MigrationTaskToBlockSize(md, info); ok {
+			return newTaskFn, true
+		}
+		if versionTaskFn == nil {
+			return nil, false
+		}
+		return versionTaskFn(md, info)
+	}
 
 	migrator, err := migrator.NewMigrator(migrator.NewOptions().
-		SetMigrationTaskFn(migration.MigrationTask).
+		SetMigrationTaskFn(migrationTaskFn).
 		SetInfoFilesByNamespace(infoFilesByNamespace).
 		SetMigrationOptions(s.opts.MigrationOptions()).
 		SetFilesystemOptions(s.fsopts).
@@ -240,6 +265,21 @@ func (s *fileSystemSource) runMigrations(ctx context.Context, infoFilesByNamespa
 	}
 }
 
+// needsReblock returns whether any fileset was written with a block size other than the
+// current block size of its namespace.
+func needsReblock(infoFilesByNamespace bootstrap.InfoFilesByNamespace) bool {
+	for md, resultsByShard := range infoFilesByNamespace {
+		for _, results := range resultsByShard {
+			for _, info := range results {
+				if _, ok := migration.MigrationTaskToBlockSize(md, info); ok {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
 func (s *fileSystemSource) availability(
 	md namespace.Metadata,
 	shardTimeRanges result.ShardTimeRanges,
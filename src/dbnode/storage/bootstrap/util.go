@@ -33,7 +33,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/m3db/m3/src/dbnode/encoding"
-	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/encoding/histogram"
 	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/storage/block"
@@ -329,7 +329,7 @@ type NamespacesTester struct {
 
 func buildDefaultIterPool() encoding.MultiReaderIteratorPool {
 	iterPool := encoding.NewMultiReaderIteratorPool(pool.NewObjectPoolOptions())
-	iterPool.Init(m3tsz.DefaultReaderIteratorAllocFn(encoding.NewOptions()))
+	iterPool.Init(histogram.DefaultReaderIteratorAllocFn(encoding.NewOptions()))
 	return iterPool
 }
 
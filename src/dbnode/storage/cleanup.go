@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/pborman/uuid"
 	"github.com/uber-go/tally"
@@ -56,6 +57,11 @@ type deleteFilesFn func(files []string) error
 
 type deleteInactiveDirectoriesFn func(parentDirPath string, activeDirNames []string) error
 
+// coldTierOrphansCleanupInterval is the minimum interval between deletions of
+// the files in the cold tier of data filesets that are no longer on disk,
+// since that requires listing all the files of the host in the cold tier.
+const coldTierOrphansCleanupInterval = time.Hour
+
 // Narrow interface so as not to expose all the functionality of the commitlog
 // to the cleanup manager.
 type activeCommitlogs interface {
@@ -78,6 +84,8 @@ type cleanupManager struct {
 
 	deleteFilesFn               deleteFilesFn
 	deleteInactiveDirectoriesFn deleteInactiveDirectoriesFn
+	coldTier                    fs.ColdTier
+	lastColdTierOrphansCleanup  xtime.UnixNano
 	warmFlushCleanupInProgress  bool
 	coldFlushCleanupInProgress  bool
 	metrics                     cleanupManagerMetrics
@@ -130,6 +138,7 @@ func newCleanupManager(
 		snapshotFilesFn:             fs.SnapshotFiles,
 		deleteFilesFn:               fs.DeleteFiles,
 		deleteInactiveDirectoriesFn: fs.DeleteInactiveDirectories,
+		coldTier:                    opts.CommitLogOptions().FilesystemOptions().ColdTier(),
 		metrics:                     newCleanupManagerMetrics(scope),
 		logger:                      opts.InstrumentOptions().Logger(),
 	}
@@ -212,6 +221,11 @@ func (m *cleanupManager) ColdFlushCleanup(t xtime.UnixNano) error {
 			"encountered errors when deleting inactive data files for %v: %v", t, err))
 	}
 
+	if err := m.cleanupColdTierDataFiles(t, namespaces); err != nil {
+		multiErr = multiErr.Add(fmt.Errorf(
+			"encountered errors when cleaning up cold tier data files for %v: %v", t, err))
+	}
+
 	return multiErr.FinalError()
 }
 
@@ -292,6 +306,46 @@ func (m *cleanupManager) cleanupDataFiles(t xtime.UnixNano, namespaces []databas
 	return multiErr.FinalError()
 }
 
+// cleanupColdTierDataFiles offloads the data filesets of the blocks in the
+// cold tier, evicts the cached files of offloaded data filesets and deletes the
+// files in the cold tier of the data filesets that are no longer on disk. It
+// runs after the data files cleanup so that the files of expired and compacted
+// data filesets are deleted from the cold tier as well.
+func (m *cleanupManager) cleanupColdTierDataFiles(t xtime.UnixNano, namespaces []databaseNamespace) error {
+	if m.coldTier == nil {
+		return nil
+	}
+
+	multiErr := xerrors.NewMultiError()
+	for _, n := range namespaces {
+		if !n.Options().CleanupEnabled() {
+			continue
+		}
+		coldTierBoundary, coldTierEnabled := fs.ColdTierBoundary(n.Options(), t)
+		for _, shard := range n.OwnedShards() {
+			if coldTierEnabled {
+				if _, err := m.coldTier.Offload(n.ID(), shard.ID(), coldTierBoundary); err != nil {
+					multiErr = multiErr.Add(err)
+				}
+			}
+			// Evict regardless of the cold tier age of the namespace, data
+			// filesets stay offloaded if the cold tier is disabled later on.
+			if _, err := m.coldTier.Evict(n.ID(), shard.ID()); err != nil {
+				multiErr = multiErr.Add(err)
+			}
+		}
+	}
+
+	if t.Sub(m.lastColdTierOrphansCleanup) >= coldTierOrphansCleanupInterval {
+		if _, err := m.coldTier.DeleteOrphans(); err != nil {
+			multiErr = multiErr.Add(err)
+		} else {
+			m.lastColdTierOrphansCleanup = t
+		}
+	}
+	return multiErr.FinalError()
+}
+
 func (m *cleanupManager) cleanupExpiredIndexFiles(
 	t xtime.UnixNano, namespaces []databaseNamespace,
 ) error {
@@ -471,6 +471,42 @@ func TestCleanupDataAndSnapshotFileSetFiles(t *testing.T) {
 	require.NoError(t, cleanup(mgr, ts))
 }
 
+func TestCleanupColdTierDataFiles(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+	ts := timeFor()
+
+	nsOpts := namespaceOptions.SetColdTierAge(24 * time.Hour)
+	ns := NewMockdatabaseNamespace(ctrl)
+	ns.EXPECT().Options().Return(nsOpts).AnyTimes()
+	ns.EXPECT().ID().Return(ident.StringID("nsID")).AnyTimes()
+	ns.EXPECT().NeedsFlush(gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes()
+
+	shard := NewMockdatabaseShard(ctrl)
+	shard.EXPECT().ID().Return(uint32(0)).AnyTimes()
+	shard.EXPECT().IsBootstrapped().Return(true).AnyTimes()
+	shard.EXPECT().CleanupExpiredFileSets(gomock.Any()).Return(nil).Times(2)
+	shard.EXPECT().CleanupCompactedFileSets().Return(nil).Times(2)
+	ns.EXPECT().OwnedShards().Return([]databaseShard{shard}).AnyTimes()
+	namespaces := []databaseNamespace{ns}
+
+	db := newMockdatabase(ctrl, namespaces...)
+	db.EXPECT().OwnedNamespaces().Return(namespaces, nil).AnyTimes()
+	mgr := newCleanupManager(db, newNoopFakeActiveLogs(), tally.NoopScope).(*cleanupManager)
+
+	coldTier := fs.NewMockColdTier(ctrl)
+	mgr.coldTier = coldTier
+	boundary, ok := fs.ColdTierBoundary(nsOpts, ts)
+	require.True(t, ok)
+	coldTier.EXPECT().Offload(ident.StringID("nsID"), uint32(0), boundary).Return(1, nil).Times(2)
+	coldTier.EXPECT().Evict(ident.StringID("nsID"), uint32(0)).Return(0, nil).Times(2)
+	// Orphans are only deleted once per interval.
+	coldTier.EXPECT().DeleteOrphans().Return(0, nil)
+
+	require.NoError(t, mgr.ColdFlushCleanup(ts))
+	require.NoError(t, mgr.ColdFlushCleanup(ts))
+}
+
 type deleteInactiveDirectoriesCall struct {
 	parentDirPath  string
 	activeDirNames []string
@@ -1065,6 +1065,28 @@ func (d *db) AggregateQuery(
 	return n.AggregateQuery(ctx, query, aggResultOpts)
 }
 
+func (d *db) Cardinality(
+	ctx context.Context,
+	namespace ident.ID,
+	opts index.CardinalityOptions,
+) (index.CardinalityResult, error) {
+	// Cardinality reads every series of the blocks in range, so it is
+	// abandoned when the query limits are exceeded and is charged against
+	// the aggregate docs limit.
+	if err := d.queryLimits.AnyFetchExceeded(); err != nil {
+		return index.CardinalityResult{}, err
+	}
+	if opts.DocsLimit == nil {
+		opts.DocsLimit = d.queryLimits.AggregateDocsLimit()
+	}
+
+	n, err := d.namespaceFor(namespace)
+	if err != nil {
+		return index.CardinalityResult{}, err
+	}
+	return n.Cardinality(ctx, opts)
+}
+
 func (d *db) ReadEncoded(
 	ctx context.Context,
 	namespace ident.ID,
@@ -1220,6 +1242,19 @@ func (d *db) Truncate(namespace ident.ID) (int64, error) {
 	return n.Truncate()
 }
 
+func (d *db) DeleteSeries(
+	ctx context.Context,
+	namespace ident.ID,
+	query index.Query,
+	start, end xtime.UnixNano,
+) (int64, error) {
+	n, err := d.namespaceFor(namespace)
+	if err != nil {
+		return 0, err
+	}
+	return n.DeleteSeries(ctx, query, start, end)
+}
+
 func (d *db) IsOverloaded() bool {
 	queueSize := float64(d.commitLog.QueueLength())
 	queueCapacity := float64(d.opts.CommitLogOptions().BacklogQueueSize())
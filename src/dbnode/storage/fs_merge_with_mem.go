@@ -143,3 +143,10 @@ func (m *fsMergeWithMem) ForEachRemaining(
 
 	return nil
 }
+
+func (m *fsMergeWithMem) DeletedRanges(
+	seriesID ident.ID,
+	blockStart xtime.UnixNano,
+) xtime.Ranges {
+	return m.shard.DeletedRanges(seriesID, blockStart)
+}
@@ -1580,6 +1580,57 @@ func (i *nsIndex) AggregateQuery(
 	}, nil
 }
 
+func (i *nsIndex) Cardinality(
+	ctx context.Context,
+	opts index.CardinalityOptions,
+) (index.CardinalityResult, error) {
+	i.state.RLock()
+	if !i.isOpenWithRLock() {
+		i.state.RUnlock()
+		return index.CardinalityResult{}, errDbIndexUnableToQueryClosed
+	}
+
+	// Track this as an inflight query that needs to finish
+	// when the index is closed.
+	i.queriesWg.Add(1)
+	defer i.queriesWg.Done()
+
+	var (
+		firstBlockStart = opts.StartInclusive.Truncate(i.blockSize)
+		prev            = i.state.blocksByTime[firstBlockStart.Add(-i.blockSize)]
+		blocks          []index.Block
+	)
+	for blockStart, block := range i.state.blocksByTime {
+		if blockStart.Before(firstBlockStart) || !blockStart.Before(opts.EndExclusive) {
+			continue
+		}
+		blocks = append(blocks, block)
+	}
+	i.state.RUnlock()
+
+	sort.Slice(blocks, func(a, b int) bool {
+		return blocks[a].StartTime().Before(blocks[b].StartTime())
+	})
+
+	// Take a single permit for the whole computation since the blocks are
+	// read sequentially.
+	perms, err := i.permitsManager.NewPermits(ctx)
+	if err != nil {
+		return index.CardinalityResult{}, err
+	}
+	defer perms.Close()
+
+	acquireResult, err := perms.Acquire(ctx)
+	if acquireResult.Permit != nil {
+		defer perms.Release(acquireResult.Permit)
+	}
+	if err != nil {
+		return index.CardinalityResult{}, err
+	}
+
+	return index.ComputeCardinality(ctx, prev, blocks, opts)
+}
+
 type queryResult struct {
 	exhaustive bool
 	waited     int
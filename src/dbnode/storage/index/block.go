@@ -1304,6 +1304,27 @@ func (b *block) MemorySegmentsData(ctx context.Context) ([]fst.SegmentData, erro
 	return data, nil
 }
 
+func (b *block) SegmentReaders(ctx context.Context) ([]segment.Reader, error) {
+	b.RLock()
+	defer b.RUnlock()
+	if b.state == blockStateClosed {
+		return nil, ErrUnableToQueryBlockClosed
+	}
+	readers, err := b.segmentReadersWithRLock()
+	if err != nil {
+		return nil, err
+	}
+
+	// Register the readers to close when context closes so that callers can
+	// iterate the segments without holding the block lock.
+	ctx.RegisterFinalizer(xresource.FinalizerFn(func() {
+		for _, reader := range readers {
+			b.closeAsync(reader)
+		}
+	}))
+	return readers, nil
+}
+
 func (b *block) Close() error {
 	b.Lock()
 	defer b.Unlock()
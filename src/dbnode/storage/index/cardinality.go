@@ -0,0 +1,640 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package index
+
+import (
+	"bytes"
+	"container/heap"
+	"sort"
+
+	"github.com/m3db/m3/src/dbnode/storage/limits"
+	"github.com/m3db/m3/src/m3ninx/doc"
+	"github.com/m3db/m3/src/m3ninx/index/segment"
+	"github.com/m3db/m3/src/m3ninx/postings"
+	"github.com/m3db/m3/src/m3ninx/postings/roaring"
+	"github.com/m3db/m3/src/m3ninx/search"
+	"github.com/m3db/m3/src/x/context"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+// DefaultCardinalityLimit is the default number of entries of each of the
+// top lists of the cardinality of a namespace.
+const DefaultCardinalityLimit = 10
+
+// CardinalityOptions are the options to compute the cardinality of the
+// series indexed by a namespace.
+type CardinalityOptions struct {
+	// StartInclusive is the start of the range of index blocks to report on.
+	StartInclusive xtime.UnixNano
+	// EndExclusive is the exclusive end of the range of index blocks to
+	// report on.
+	EndExclusive xtime.UnixNano
+	// Limit is the number of entries of each of the top lists, defaults
+	// to 10 when not set.
+	Limit int
+	// NameField is the field that holds the metric name of the series.
+	NameField []byte
+	// DocsLimit, if set, is charged with the series of each index block as
+	// the block is read and stops the computation once it is exceeded.
+	DocsLimit limits.LookbackLimit
+	// Query, if set, restricts the cardinality to the series matching it.
+	Query Query
+}
+
+// CardinalityStat is a count for a metric name, label name or label pair.
+type CardinalityStat struct {
+	Name  []byte
+	Value int64
+}
+
+// BlockCardinality is the number of series and series churn of an index
+// block.
+type BlockCardinality struct {
+	BlockStart xtime.UnixNano
+	NumSeries  int64
+	// NewSeries is the number of series of the block that are not indexed
+	// by the previous block, all of the series of the block are new if there
+	// is no previous block.
+	NewSeries int64
+	// RemovedSeries is the number of series of the previous block that are
+	// not indexed by the block.
+	RemovedSeries int64
+}
+
+// CardinalityResult is the cardinality of the series indexed by a
+// namespace. The series counts and top lists are computed from the latest
+// index block of the range.
+type CardinalityResult struct {
+	// NumSeries is the number of series of the latest index block.
+	NumSeries int64
+	// SeriesCountByMetricName are the metric names with the most series.
+	SeriesCountByMetricName []CardinalityStat
+	// LabelValueCountByLabelName are the label names with the most distinct
+	// values.
+	LabelValueCountByLabelName []CardinalityStat
+	// SeriesCountByLabelValuePair are the label pairs, formatted as
+	// name=value, with the most series.
+	SeriesCountByLabelValuePair []CardinalityStat
+	// Blocks is the series churn of each index block of the range.
+	Blocks []BlockCardinality
+	// Approximate is set on results merged across the hosts of a cluster
+	// when the top lists or the counts may not be exact, either because the
+	// top lists of some hosts were truncated or because some hosts did not
+	// respond.
+	Approximate bool
+}
+
+// ComputeCardinality computes the cardinality of the series indexed by the
+// blocks, which must be in ascending order of block start. The previous
+// block, if not nil, is the block preceding the first block and is only used
+// to compute the series churn of the first block.
+//
+// The series of a block are counted once even if they are indexed by more
+// than one segment of the block.
+func ComputeCardinality(
+	ctx context.Context,
+	prev Block,
+	blocks []Block,
+	opts CardinalityOptions,
+) (CardinalityResult, error) {
+	var (
+		result      CardinalityResult
+		searcher    search.Searcher
+		prevReaders []segment.Reader
+		prevFilters []postings.List
+	)
+	if len(blocks) == 0 {
+		return result, nil
+	}
+	if q := opts.Query.SearchQuery(); q != nil {
+		var err error
+		searcher, err = q.Searcher()
+		if err != nil {
+			return CardinalityResult{}, err
+		}
+	}
+	if prev != nil {
+		readers, err := prev.SegmentReaders(ctx)
+		if err != nil {
+			return CardinalityResult{}, err
+		}
+		filters, err := cardinalityFilters(searcher, readers)
+		if err != nil {
+			return CardinalityResult{}, err
+		}
+		prevReaders, prevFilters = readers, filters
+	}
+
+	for _, block := range blocks {
+		readers, err := block.SegmentReaders(ctx)
+		if err != nil {
+			return CardinalityResult{}, err
+		}
+		filters, err := cardinalityFilters(searcher, readers)
+		if err != nil {
+			return CardinalityResult{}, err
+		}
+		blockResult, err := seriesChurn(readers, filters, prevReaders, prevFilters)
+		if err != nil {
+			return CardinalityResult{}, err
+		}
+		if opts.DocsLimit != nil {
+			if err := opts.DocsLimit.Inc(int(blockResult.NumSeries), nil); err != nil {
+				return CardinalityResult{}, err
+			}
+		}
+		blockResult.BlockStart = block.StartTime()
+		result.Blocks = append(result.Blocks, blockResult)
+		prevReaders, prevFilters = readers, filters
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = DefaultCardinalityLimit
+	}
+	err := topCardinality(prevReaders, prevFilters, opts.NameField, limit, &result)
+	if err != nil {
+		return CardinalityResult{}, err
+	}
+	result.NumSeries = result.Blocks[len(result.Blocks)-1].NumSeries
+	return result, nil
+}
+
+// cardinalityFilters returns the postings lists of the series of each segment
+// that match a searcher, or nil if there is no searcher.
+func cardinalityFilters(
+	searcher search.Searcher,
+	readers []segment.Reader,
+) ([]postings.List, error) {
+	if searcher == nil {
+		return nil, nil
+	}
+	filters := make([]postings.List, 0, len(readers))
+	for _, r := range readers {
+		pl, err := searcher.Search(r)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, pl)
+	}
+	return filters, nil
+}
+
+// seriesChurn merge joins the sorted series IDs of the segments of a block
+// with the ones of the previous block.
+func seriesChurn(
+	readers []segment.Reader,
+	filters []postings.List,
+	prevReaders []segment.Reader,
+	prevFilters []postings.List,
+) (BlockCardinality, error) {
+	curr, err := newTermsMerger(readers, filters, doc.IDReservedFieldName)
+	if err != nil {
+		return BlockCardinality{}, err
+	}
+	defer curr.Close()
+
+	prev, err := newTermsMerger(prevReaders, prevFilters, doc.IDReservedFieldName)
+	if err != nil {
+		return BlockCardinality{}, err
+	}
+	defer prev.Close()
+
+	var (
+		result = BlockCardinality{}
+		currOK = curr.Next()
+		prevOK = prev.Next()
+	)
+	for currOK || prevOK {
+		cmp := 0
+		switch {
+		case !prevOK:
+			cmp = -1
+		case !currOK:
+			cmp = 1
+		default:
+			cmp = bytes.Compare(curr.Current(), prev.Current())
+		}
+
+		switch {
+		case cmp < 0:
+			result.NumSeries++
+			result.NewSeries++
+			currOK = curr.Next()
+		case cmp > 0:
+			result.RemovedSeries++
+			prevOK = prev.Next()
+		default:
+			result.NumSeries++
+			currOK = curr.Next()
+			prevOK = prev.Next()
+		}
+	}
+
+	multiErr := xerrors.NewMultiError().
+		Add(curr.Err()).
+		Add(prev.Err())
+	return result, multiErr.FinalError()
+}
+
+func topCardinality(
+	readers []segment.Reader,
+	filters []postings.List,
+	nameField []byte,
+	limit int,
+	result *CardinalityResult,
+) error {
+	iters := make([]termsIterator, 0, len(readers))
+	for _, r := range readers {
+		fieldsIter, err := r.Fields()
+		if err != nil {
+			closeTermsIterators(iters)
+			return err
+		}
+		iters = append(iters, fieldsTermsIterator{FieldsIterator: fieldsIter})
+	}
+	fields := newTermsMergerFromIters(iters)
+	defer fields.Close()
+
+	var (
+		byMetricName = newTopCardinalityStats(limit)
+		byLabelName  = newTopCardinalityStats(limit)
+		byLabelPair  = newTopCardinalityStats(limit)
+		pair         []byte
+	)
+	for fields.Next() {
+		field := fields.Current()
+		if bytes.Equal(field, doc.IDReservedFieldName) {
+			continue
+		}
+
+		terms, err := newTermsMerger(readers, filters, field)
+		if err != nil {
+			return err
+		}
+
+		var (
+			isNameField = bytes.Equal(field, nameField)
+			distinct    int64
+		)
+		for terms.Next() {
+			term, count := terms.Current(), terms.Count()
+			distinct++
+			if !(isNameField && byMetricName.accepts(count)) && !byLabelPair.accepts(count) {
+				continue
+			}
+			// NB: the count of the postings is an upper bound of the number of
+			// series with the term, series are only deduplicated across the
+			// segments of the block when they could make it to a top list.
+			count, err = terms.DistinctCount()
+			if err != nil {
+				break
+			}
+			if isNameField {
+				byMetricName.add(term, count)
+			}
+			if byLabelPair.accepts(count) {
+				pair = append(pair[:0], field...)
+				pair = append(pair, '=')
+				pair = append(pair, term...)
+				byLabelPair.add(pair, count)
+			}
+		}
+		if err == nil {
+			err = terms.Err()
+		}
+		terms.Close()
+		if err != nil {
+			return err
+		}
+
+		if distinct > 0 {
+			// NB: fields of series that do not match the query have no terms.
+			byLabelName.add(field, distinct)
+		}
+	}
+	if err := fields.Err(); err != nil {
+		return err
+	}
+
+	result.SeriesCountByMetricName = byMetricName.sorted()
+	result.LabelValueCountByLabelName = byLabelName.sorted()
+	result.SeriesCountByLabelValuePair = byLabelPair.sorted()
+	return nil
+}
+
+type termsIterator interface {
+	Next() bool
+	Current() ([]byte, postings.List)
+	Err() error
+	Close() error
+}
+
+type fieldsTermsIterator struct {
+	segment.FieldsIterator
+}
+
+func (it fieldsTermsIterator) Current() ([]byte, postings.List) {
+	return it.FieldsIterator.Current(), nil
+}
+
+// filteredTermsIterator only iterates over the terms of the series of a
+// segment that are in a filter, with the postings restricted to the filter.
+type filteredTermsIterator struct {
+	termsIterator
+	filter postings.List
+	pl     postings.MutableList
+	err    error
+}
+
+func (it *filteredTermsIterator) Next() bool {
+	for it.err == nil && it.termsIterator.Next() {
+		_, pl := it.termsIterator.Current()
+		if pl == nil {
+			continue
+		}
+
+		// NB: the postings of the terms and of the filter are not
+		// necessarily of the same implementation so they are intersected
+		// by iterating over the postings of the term.
+		if it.pl == nil {
+			it.pl = roaring.NewPostingsList()
+		}
+		it.pl.Reset()
+		ids := pl.Iterator()
+		for ids.Next() {
+			if id := ids.Current(); it.filter.Contains(id) {
+				if err := it.pl.Insert(id); err != nil {
+					it.err = err
+				}
+			}
+		}
+		if err := ids.Err(); err != nil && it.err == nil {
+			it.err = err
+		}
+		ids.Close() // nolint: errcheck
+		if it.err == nil && !it.pl.IsEmpty() {
+			return true
+		}
+	}
+	return false
+}
+
+func (it *filteredTermsIterator) Current() ([]byte, postings.List) {
+	term, _ := it.termsIterator.Current()
+	return term, it.pl
+}
+
+func (it *filteredTermsIterator) Err() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.termsIterator.Err()
+}
+
+func closeTermsIterators(iters []termsIterator) {
+	for _, it := range iters {
+		it.Close() // nolint: errcheck
+	}
+}
+
+// termsMerger iterates over the distinct terms of sorted terms iterators.
+type termsMerger struct {
+	readers []segment.Reader
+	iters   []termsIterator
+	valid   []bool
+	matched []bool
+	started bool
+	term    []byte
+	count   int64
+	err     error
+}
+
+func newTermsMerger(
+	readers []segment.Reader,
+	filters []postings.List,
+	field []byte,
+) (*termsMerger, error) {
+	iters := make([]termsIterator, 0, len(readers))
+	for i, r := range readers {
+		termsIter, err := r.Terms(field)
+		if err != nil {
+			closeTermsIterators(iters)
+			return nil, err
+		}
+		if filters != nil {
+			iters = append(iters, &filteredTermsIterator{
+				termsIterator: termsIter,
+				filter:        filters[i],
+			})
+			continue
+		}
+		iters = append(iters, termsIter)
+	}
+	m := newTermsMergerFromIters(iters)
+	m.readers = readers
+	return m, nil
+}
+
+func newTermsMergerFromIters(iters []termsIterator) *termsMerger {
+	m := &termsMerger{
+		iters:   iters,
+		valid:   make([]bool, len(iters)),
+		matched: make([]bool, len(iters)),
+	}
+	for i, it := range iters {
+		m.valid[i] = it.Next()
+	}
+	return m
+}
+
+func (m *termsMerger) Next() bool {
+	if m.started {
+		for i, it := range m.iters {
+			if m.matched[i] {
+				m.valid[i] = it.Next()
+			}
+		}
+	}
+	m.started = true
+
+	m.term = nil
+	for i, it := range m.iters {
+		if !m.valid[i] {
+			continue
+		}
+		if term, _ := it.Current(); m.term == nil || bytes.Compare(term, m.term) < 0 {
+			m.term = term
+		}
+	}
+	if m.term == nil {
+		return false
+	}
+
+	m.count = 0
+	for i, it := range m.iters {
+		m.matched[i] = false
+		if !m.valid[i] {
+			continue
+		}
+		if term, pl := it.Current(); bytes.Equal(term, m.term) {
+			m.matched[i] = true
+			if pl != nil {
+				m.count += int64(pl.Len())
+			}
+		}
+	}
+	return true
+}
+
+// Current returns the current term, it is only valid until the next call
+// to Next.
+func (m *termsMerger) Current() []byte {
+	return m.term
+}
+
+// Count returns the sum of the postings of the current term, which counts a
+// series indexed by more than one segment more than once.
+func (m *termsMerger) Count() int64 {
+	return m.count
+}
+
+// DistinctCount returns the number of distinct series of the postings of the
+// current term across the segments.
+func (m *termsMerger) DistinctCount() (int64, error) {
+	var (
+		matched int
+		ids     map[string]struct{}
+	)
+	for i := range m.iters {
+		if m.matched[i] {
+			matched++
+		}
+	}
+	if matched <= 1 || m.readers == nil {
+		return m.count, nil
+	}
+
+	ids = make(map[string]struct{}, m.count)
+	for i, it := range m.iters {
+		if !m.matched[i] {
+			continue
+		}
+		_, pl := it.Current()
+		if pl == nil {
+			continue
+		}
+		docs, err := m.readers[i].MetadataIterator(pl)
+		if err != nil {
+			return 0, err
+		}
+		for docs.Next() {
+			ids[string(docs.Current().ID)] = struct{}{}
+		}
+		err = docs.Err()
+		docs.Close() // nolint: errcheck
+		if err != nil {
+			return 0, err
+		}
+	}
+	return int64(len(ids)), nil
+}
+
+func (m *termsMerger) Err() error {
+	multiErr := xerrors.NewMultiError()
+	for _, it := range m.iters {
+		multiErr = multiErr.Add(it.Err())
+	}
+	return multiErr.FinalError()
+}
+
+func (m *termsMerger) Close() {
+	closeTermsIterators(m.iters)
+}
+
+type topCardinalityStats struct {
+	limit int
+	stats cardinalityStatsHeap
+}
+
+func newTopCardinalityStats(limit int) *topCardinalityStats {
+	return &topCardinalityStats{
+		limit: limit,
+		stats: make(cardinalityStatsHeap, 0, limit),
+	}
+}
+
+// accepts returns whether a stat with the value would be kept, since the
+// names are added in order, ties are resolved in favor of the earlier name.
+func (t *topCardinalityStats) accepts(value int64) bool {
+	return len(t.stats) < t.limit || value > t.stats[0].Value
+}
+
+func (t *topCardinalityStats) add(name []byte, value int64) {
+	if !t.accepts(value) {
+		return
+	}
+	stat := CardinalityStat{
+		Name:  append([]byte(nil), name...),
+		Value: value,
+	}
+	if len(t.stats) < t.limit {
+		heap.Push(&t.stats, stat)
+		return
+	}
+	t.stats[0] = stat
+	heap.Fix(&t.stats, 0)
+}
+
+func (t *topCardinalityStats) sorted() []CardinalityStat {
+	result := make([]CardinalityStat, len(t.stats))
+	copy(result, t.stats)
+	SortCardinalityStats(result)
+	return result
+}
+
+// SortCardinalityStats sorts the stats by descending value and then by name.
+func SortCardinalityStats(stats []CardinalityStat) {
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Value != stats[j].Value {
+			return stats[i].Value > stats[j].Value
+		}
+		return bytes.Compare(stats[i].Name, stats[j].Name) < 0
+	})
+}
+
+type cardinalityStatsHeap []CardinalityStat
+
+func (h cardinalityStatsHeap) Len() int           { return len(h) }
+func (h cardinalityStatsHeap) Less(i, j int) bool { return h[i].Value < h[j].Value }
+func (h cardinalityStatsHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *cardinalityStatsHeap) Push(x interface{}) {
+	*h = append(*h, x.(CardinalityStat))
+}
+
+func (h *cardinalityStatsHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
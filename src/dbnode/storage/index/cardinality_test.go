@@ -0,0 +1,263 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package index
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/dbnode/storage/limits"
+	"github.com/m3db/m3/src/m3ninx/doc"
+	"github.com/m3db/m3/src/m3ninx/idx"
+	"github.com/m3db/m3/src/m3ninx/index/segment"
+	"github.com/m3db/m3/src/x/context"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+func testCardinalityDoc(id, name, host string) doc.Metadata {
+	return doc.Metadata{
+		ID: []byte(id),
+		Fields: []doc.Field{
+			{Name: []byte("__name__"), Value: []byte(name)},
+			{Name: []byte("host"), Value: []byte(host)},
+		},
+	}
+}
+
+func testCardinalityBlock(
+	t *testing.T,
+	ctrl *gomock.Controller,
+	start xtime.UnixNano,
+	segments ...[]doc.Metadata,
+) Block {
+	var readers []segment.Reader
+	for _, docs := range segments {
+		seg := testSegment(t, docs...)
+		require.NoError(t, seg.(segment.MutableSegment).Seal())
+		reader, err := seg.Reader()
+		require.NoError(t, err)
+		readers = append(readers, reader)
+	}
+
+	block := NewMockBlock(ctrl)
+	block.EXPECT().SegmentReaders(gomock.Any()).Return(readers, nil)
+	block.EXPECT().StartTime().Return(start).AnyTimes()
+	return block
+}
+
+func TestComputeCardinality(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.NewBackground()
+	defer ctx.Close()
+
+	var (
+		blockSize = 2 * time.Hour
+		start     = xtime.Now().Truncate(blockSize)
+		prev      = testCardinalityBlock(t, ctrl, start.Add(-blockSize),
+			[]doc.Metadata{
+				testCardinalityDoc("a1", "a", "h1"),
+				testCardinalityDoc("b1", "b", "h1"),
+			})
+		first = testCardinalityBlock(t, ctrl, start,
+			[]doc.Metadata{
+				testCardinalityDoc("a1", "a", "h1"),
+				testCardinalityDoc("a2", "a", "h2"),
+			},
+			[]doc.Metadata{
+				testCardinalityDoc("a2", "a", "h2"),
+				testCardinalityDoc("c1", "c", "h1"),
+			})
+		second = testCardinalityBlock(t, ctrl, start.Add(blockSize),
+			[]doc.Metadata{
+				testCardinalityDoc("a1", "a", "h1"),
+				testCardinalityDoc("a2", "a", "h2"),
+				testCardinalityDoc("a3", "a", "h3"),
+				testCardinalityDoc("b1", "b", "h1"),
+			})
+	)
+
+	result, err := ComputeCardinality(ctx, prev, []Block{first, second},
+		CardinalityOptions{
+			Limit:     2,
+			NameField: []byte("__name__"),
+		})
+	require.NoError(t, err)
+
+	require.Equal(t, CardinalityResult{
+		NumSeries: 4,
+		SeriesCountByMetricName: []CardinalityStat{
+			{Name: []byte("a"), Value: 3},
+			{Name: []byte("b"), Value: 1},
+		},
+		LabelValueCountByLabelName: []CardinalityStat{
+			{Name: []byte("host"), Value: 3},
+			{Name: []byte("__name__"), Value: 2},
+		},
+		SeriesCountByLabelValuePair: []CardinalityStat{
+			{Name: []byte("__name__=a"), Value: 3},
+			{Name: []byte("host=h1"), Value: 2},
+		},
+		Blocks: []BlockCardinality{
+			{BlockStart: start, NumSeries: 3, NewSeries: 2, RemovedSeries: 1},
+			{BlockStart: start.Add(blockSize), NumSeries: 4, NewSeries: 2, RemovedSeries: 1},
+		},
+	}, result)
+}
+
+func TestComputeCardinalityNoPreviousBlock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.NewBackground()
+	defer ctx.Close()
+
+	start := xtime.Now().Truncate(time.Hour)
+	block := testCardinalityBlock(t, ctrl, start,
+		[]doc.Metadata{
+			testCardinalityDoc("a1", "a", "h1"),
+			testCardinalityDoc("b1", "b", "h1"),
+		})
+
+	result, err := ComputeCardinality(ctx, nil, []Block{block},
+		CardinalityOptions{NameField: []byte("__name__")})
+	require.NoError(t, err)
+	require.Equal(t, []BlockCardinality{
+		{BlockStart: start, NumSeries: 2, NewSeries: 2},
+	}, result.Blocks)
+	require.Equal(t, int64(2), result.NumSeries)
+}
+
+func TestComputeCardinalityDeduplicatesSeriesAcrossSegments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.NewBackground()
+	defer ctx.Close()
+
+	start := xtime.Now().Truncate(time.Hour)
+	block := testCardinalityBlock(t, ctrl, start,
+		[]doc.Metadata{
+			testCardinalityDoc("a1", "a", "h1"),
+			testCardinalityDoc("a2", "a", "h2"),
+		},
+		[]doc.Metadata{
+			testCardinalityDoc("a1", "a", "h1"),
+			testCardinalityDoc("b1", "b", "h2"),
+		})
+
+	result, err := ComputeCardinality(ctx, nil, []Block{block},
+		CardinalityOptions{NameField: []byte("__name__")})
+	require.NoError(t, err)
+	require.Equal(t, []CardinalityStat{
+		{Name: []byte("a"), Value: 2},
+		{Name: []byte("b"), Value: 1},
+	}, result.SeriesCountByMetricName)
+	require.Equal(t, []CardinalityStat{
+		{Name: []byte("__name__=a"), Value: 2},
+		{Name: []byte("host=h2"), Value: 2},
+		{Name: []byte("__name__=b"), Value: 1},
+		{Name: []byte("host=h1"), Value: 1},
+	}, result.SeriesCountByLabelValuePair)
+}
+
+func TestComputeCardinalityDocsLimitExceeded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.NewBackground()
+	defer ctx.Close()
+
+	start := xtime.Now().Truncate(time.Hour)
+	block := testCardinalityBlock(t, ctrl, start,
+		[]doc.Metadata{
+			testCardinalityDoc("a1", "a", "h1"),
+			testCardinalityDoc("b1", "b", "h1"),
+		})
+
+	limitErr := limits.NewQueryLimitExceededError("docs limit exceeded")
+	docsLimit := limits.NewMockLookbackLimit(ctrl)
+	docsLimit.EXPECT().Inc(2, nil).Return(limitErr)
+
+	_, err := ComputeCardinality(ctx, nil, []Block{block},
+		CardinalityOptions{
+			NameField: []byte("__name__"),
+			DocsLimit: docsLimit,
+		})
+	require.Equal(t, limitErr, err)
+}
+
+func TestComputeCardinalityQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.NewBackground()
+	defer ctx.Close()
+
+	var (
+		blockSize = 2 * time.Hour
+		start     = xtime.Now().Truncate(blockSize)
+		prev      = testCardinalityBlock(t, ctrl, start.Add(-blockSize),
+			[]doc.Metadata{
+				testCardinalityDoc("a1", "a", "h1"),
+				testCardinalityDoc("b1", "b", "h2"),
+			})
+		block = testCardinalityBlock(t, ctrl, start,
+			[]doc.Metadata{
+				testCardinalityDoc("a1", "a", "h1"),
+				testCardinalityDoc("a2", "a", "h1"),
+			},
+			[]doc.Metadata{
+				testCardinalityDoc("b2", "b", "h2"),
+				testCardinalityDoc("c1", "c", "h1"),
+			})
+	)
+
+	// Only the series of the host h1 are counted.
+	result, err := ComputeCardinality(ctx, prev, []Block{block},
+		CardinalityOptions{
+			NameField: []byte("__name__"),
+			Query:     Query{Query: idx.NewTermQuery([]byte("host"), []byte("h1"))},
+		})
+	require.NoError(t, err)
+	require.Equal(t, CardinalityResult{
+		NumSeries: 3,
+		SeriesCountByMetricName: []CardinalityStat{
+			{Name: []byte("a"), Value: 2},
+			{Name: []byte("c"), Value: 1},
+		},
+		LabelValueCountByLabelName: []CardinalityStat{
+			{Name: []byte("__name__"), Value: 2},
+			{Name: []byte("host"), Value: 1},
+		},
+		SeriesCountByLabelValuePair: []CardinalityStat{
+			{Name: []byte("host=h1"), Value: 3},
+			{Name: []byte("__name__=a"), Value: 2},
+			{Name: []byte("__name__=c"), Value: 1},
+		},
+		Blocks: []BlockCardinality{
+			{BlockStart: start, NumSeries: 3, NewSeries: 2},
+		},
+	}, result)
+}
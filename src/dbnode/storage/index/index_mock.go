@@ -32,6 +32,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/index/compaction"
 	"github.com/m3db/m3/src/dbnode/storage/limits"
 	"github.com/m3db/m3/src/m3ninx/doc"
+	"github.com/m3db/m3/src/m3ninx/index/segment"
 	"github.com/m3db/m3/src/m3ninx/index/segment/builder"
 	"github.com/m3db/m3/src/m3ninx/index/segment/fst"
 	"github.com/m3db/m3/src/m3ninx/index/segment/mem"
@@ -1052,6 +1053,21 @@ func (mr *MockBlockMockRecorder) Seal() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Seal", reflect.TypeOf((*MockBlock)(nil).Seal))
 }
 
+// SegmentReaders mocks base method.
+func (m *MockBlock) SegmentReaders(ctx context.Context) ([]segment.Reader, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SegmentReaders", ctx)
+	ret0, _ := ret[0].([]segment.Reader)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SegmentReaders indicates an expected call of SegmentReaders.
+func (mr *MockBlockMockRecorder) SegmentReaders(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SegmentReaders", reflect.TypeOf((*MockBlock)(nil).SegmentReaders), ctx)
+}
+
 // StartTime mocks base method.
 func (m *MockBlock) StartTime() time0.UnixNano {
 	m.ctrl.T.Helper()
@@ -34,6 +34,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/topology"
 	"github.com/m3db/m3/src/m3ninx/doc"
 	"github.com/m3db/m3/src/m3ninx/idx"
+	"github.com/m3db/m3/src/m3ninx/index/segment"
 	"github.com/m3db/m3/src/m3ninx/index/segment/builder"
 	"github.com/m3db/m3/src/m3ninx/index/segment/fst"
 	"github.com/m3db/m3/src/m3ninx/index/segment/mem"
@@ -94,6 +95,12 @@ type QueryOptions struct {
 	IterateEqualTimestampStrategy *encoding.IterateEqualTimestampStrategy
 	// Source is an optional query source.
 	Source []byte
+	// DownsampleStep if set consolidates the datapoints of each step window
+	// of the matched series into a single datapoint when they are read.
+	DownsampleStep time.Duration
+	// DownsampleType is how the datapoints of each step window are
+	// consolidated when DownsampleStep is set.
+	DownsampleType encoding.DownsampleType
 }
 
 // IterationOptions enables users to specify iteration preferences.
@@ -432,6 +439,10 @@ type Block interface {
 	// MemorySegmentsData returns all in memory segments data.
 	MemorySegmentsData(ctx context.Context) ([]fst.SegmentData, error)
 
+	// SegmentReaders returns point in time readers of all the segments of the
+	// block, the readers are closed when the context closes.
+	SegmentReaders(ctx context.Context) ([]segment.Reader, error)
+
 	// BackgroundCompact background compacts eligible segments.
 	BackgroundCompact()
 
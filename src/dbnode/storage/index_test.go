@@ -43,6 +43,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/dbnode/storage/limits/permits"
 	"github.com/m3db/m3/src/m3ninx/doc"
 	"github.com/m3db/m3/src/m3ninx/idx"
 	"github.com/m3db/m3/src/m3ninx/index/segment"
@@ -703,6 +704,64 @@ func TestNamespaceIndexQueryNoMatchingBlocks(t *testing.T) {
 	assert.Equal(t, 0, aggResult.Results.Size())
 }
 
+func TestNamespaceIndexCardinality(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	test := newTestIndex(t, ctrl)
+
+	now := xtime.Now().Truncate(test.indexBlockSize)
+	idx := test.index.(*nsIndex)
+
+	defer func() {
+		require.NoError(t, idx.Close())
+	}()
+
+	// Blocks from 6 to 3 block sizes ago, only the ones 5 and 4 block sizes
+	// ago are in the range and the one 6 block sizes ago is the previous one.
+	var blockStarts []xtime.UnixNano
+	for i := 6; i >= 3; i-- {
+		blockStart := now.Add(-time.Duration(i) * test.indexBlockSize)
+		blockStarts = append(blockStarts, blockStart)
+
+		mockBlock := index.NewMockBlock(ctrl)
+		mockBlock.EXPECT().Stats(gomock.Any()).Return(nil).AnyTimes()
+		mockBlock.EXPECT().StartTime().Return(blockStart).AnyTimes()
+		mockBlock.EXPECT().EndTime().Return(blockStart.Add(test.indexBlockSize)).AnyTimes()
+		mockBlock.EXPECT().Close().Return(nil)
+		if i <= 3 {
+			// Outside of the range.
+			mockBlock.EXPECT().SegmentReaders(gomock.Any()).Times(0)
+		} else {
+			mockBlock.EXPECT().SegmentReaders(gomock.Any()).Return(nil, nil)
+		}
+		idx.state.blocksByTime[blockStart] = mockBlock
+	}
+
+	ctx := context.NewBackground()
+	defer ctx.Close()
+
+	// The computation holds a single permit.
+	permit := permits.NewMockPermit(ctrl)
+	perms := permits.NewMockPermits(ctrl)
+	perms.EXPECT().Acquire(ctx).Return(permits.AcquireResult{Permit: permit}, nil)
+	perms.EXPECT().Release(permit)
+	perms.EXPECT().Close()
+	permitsManager := permits.NewMockManager(ctrl)
+	permitsManager.EXPECT().NewPermits(ctx).Return(perms, nil)
+	idx.permitsManager = permitsManager
+
+	result, err := idx.Cardinality(ctx, index.CardinalityOptions{
+		StartInclusive: blockStarts[1].Add(time.Minute),
+		EndExclusive:   blockStarts[3],
+	})
+	require.NoError(t, err)
+	require.Equal(t, []index.BlockCardinality{
+		{BlockStart: blockStarts[1]},
+		{BlockStart: blockStarts[2]},
+	}, result.Blocks)
+}
+
 func TestNamespaceIndexQueryTimeout(t *testing.T) {
 	ctrl := xtest.NewController(t)
 	defer ctrl.Finish()
@@ -55,3 +55,30 @@ func IsQueryLimitExceededError(err error) bool {
 	}
 	return false
 }
+
+type seriesLimitExceededError struct {
+	msg string
+}
+
+// NewSeriesLimitExceededError creates a series limit exceeded error.
+func NewSeriesLimitExceededError(msg string) error {
+	return &seriesLimitExceededError{
+		msg: msg,
+	}
+}
+
+func (err *seriesLimitExceededError) Error() string {
+	return err.msg
+}
+
+// IsSeriesLimitExceededError returns true if the error is a series limit exceeded error.
+func IsSeriesLimitExceededError(err error) bool {
+	//nolint:errorlint
+	for err != nil {
+		if _, ok := err.(*seriesLimitExceededError); ok {
+			return true
+		}
+		err = xerrors.InnerError(err)
+	}
+	return false
+}
@@ -94,3 +94,12 @@ func multiError(errs ...error) error {
 	}
 	return multiErr.FinalError()
 }
+
+func TestIsSeriesLimitExceededError(t *testing.T) {
+	limitExceededErr := NewSeriesLimitExceededError("series limit exceeded")
+
+	assert.True(t, IsSeriesLimitExceededError(limitExceededErr))
+	assert.True(t, IsSeriesLimitExceededError(xerrors.NewInvalidParamsError(limitExceededErr)))
+	assert.False(t, IsSeriesLimitExceededError(errors.New("random error")))
+	assert.False(t, IsSeriesLimitExceededError(NewQueryLimitExceededError("query limit exceeded")))
+}
@@ -27,6 +27,7 @@ package limits
 import (
 	"reflect"
 
+	"github.com/m3db/m3/src/m3ninx/doc"
 	"github.com/m3db/m3/src/x/instrument"
 
 	"github.com/golang/mock/gomock"
@@ -224,6 +225,107 @@ func (mr *MockLookbackLimitMockRecorder) Update(opts interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockLookbackLimit)(nil).Update), opts)
 }
 
+// MockActiveSeriesLimits is a mock of ActiveSeriesLimits interface.
+type MockActiveSeriesLimits struct {
+	ctrl     *gomock.Controller
+	recorder *MockActiveSeriesLimitsMockRecorder
+}
+
+// MockActiveSeriesLimitsMockRecorder is the mock recorder for MockActiveSeriesLimits.
+type MockActiveSeriesLimitsMockRecorder struct {
+	mock *MockActiveSeriesLimits
+}
+
+// NewMockActiveSeriesLimits creates a new mock instance.
+func NewMockActiveSeriesLimits(ctrl *gomock.Controller) *MockActiveSeriesLimits {
+	mock := &MockActiveSeriesLimits{ctrl: ctrl}
+	mock.recorder = &MockActiveSeriesLimitsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockActiveSeriesLimits) EXPECT() *MockActiveSeriesLimitsMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockActiveSeriesLimits) Add(metadata doc.Metadata) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Add", metadata)
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockActiveSeriesLimitsMockRecorder) Add(metadata interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockActiveSeriesLimits)(nil).Add), metadata)
+}
+
+// Check mocks base method.
+func (m *MockActiveSeriesLimits) Check(metadata doc.Metadata) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", metadata)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockActiveSeriesLimitsMockRecorder) Check(metadata interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockActiveSeriesLimits)(nil).Check), metadata)
+}
+
+// Enabled mocks base method.
+func (m *MockActiveSeriesLimits) Enabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Enabled indicates an expected call of Enabled.
+func (mr *MockActiveSeriesLimitsMockRecorder) Enabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enabled", reflect.TypeOf((*MockActiveSeriesLimits)(nil).Enabled))
+}
+
+// Limits mocks base method.
+func (m *MockActiveSeriesLimits) Limits() []SeriesLimit {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Limits")
+	ret0, _ := ret[0].([]SeriesLimit)
+	return ret0
+}
+
+// Limits indicates an expected call of Limits.
+func (mr *MockActiveSeriesLimitsMockRecorder) Limits() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Limits", reflect.TypeOf((*MockActiveSeriesLimits)(nil).Limits))
+}
+
+// Remove mocks base method.
+func (m *MockActiveSeriesLimits) Remove(metadata doc.Metadata) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Remove", metadata)
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockActiveSeriesLimitsMockRecorder) Remove(metadata interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockActiveSeriesLimits)(nil).Remove), metadata)
+}
+
+// Reset mocks base method.
+func (m *MockActiveSeriesLimits) Reset(limits []SeriesLimit) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Reset", limits)
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockActiveSeriesLimitsMockRecorder) Reset(limits interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockActiveSeriesLimits)(nil).Reset), limits)
+}
+
 // MockSourceLoggerBuilder is a mock of SourceLoggerBuilder interface.
 type MockSourceLoggerBuilder struct {
 	ctrl     *gomock.Controller
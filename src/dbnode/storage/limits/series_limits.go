@@ -0,0 +1,187 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package limits
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/atomic"
+
+	"github.com/m3db/m3/src/m3ninx/doc"
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+type activeSeriesLimits struct {
+	sync.Mutex
+
+	enabled *atomic.Bool
+	limits  []SeriesLimit
+	states  []*seriesLimitState
+	scope   tally.Scope
+}
+
+type seriesLimitState struct {
+	limit SeriesLimit
+	// overridden is the set of values of the tag with a limit of their own,
+	// only set for limits that apply to each value of the tag.
+	overridden map[string]struct{}
+	active     map[string]int64
+	exceeded   tally.Counter
+}
+
+var _ ActiveSeriesLimits = (*activeSeriesLimits)(nil)
+
+// NewActiveSeriesLimits returns new active series limits that enforce no
+// limits until reset with some.
+func NewActiveSeriesLimits(instrumentOpts instrument.Options) ActiveSeriesLimits {
+	return &activeSeriesLimits{
+		enabled: atomic.NewBool(false),
+		scope:   instrumentOpts.MetricsScope().SubScope("series-limits"),
+	}
+}
+
+// SeriesLimitsEqual returns whether two sets of series limits are equal.
+func SeriesLimitsEqual(a, b []SeriesLimit) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (l *activeSeriesLimits) Enabled() bool {
+	return l.enabled.Load()
+}
+
+func (l *activeSeriesLimits) Limits() []SeriesLimit {
+	l.Lock()
+	defer l.Unlock()
+	return l.limits
+}
+
+func (l *activeSeriesLimits) Reset(limits []SeriesLimit) {
+	states := make([]*seriesLimitState, 0, len(limits))
+	for _, limit := range limits {
+		if limit.MaxActiveSeries <= 0 {
+			continue
+		}
+		tags := map[string]string{"tag": limit.TagName}
+		if limit.TagValue != "" {
+			tags["tag_value"] = limit.TagValue
+		}
+		states = append(states, &seriesLimitState{
+			limit:    limit,
+			active:   make(map[string]int64),
+			exceeded: l.scope.Tagged(tags).Counter("exceeded"),
+		})
+	}
+	for _, state := range states {
+		if state.limit.TagValue != "" {
+			continue
+		}
+		for _, other := range states {
+			if other.limit.TagName != state.limit.TagName || other.limit.TagValue == "" {
+				continue
+			}
+			if state.overridden == nil {
+				state.overridden = make(map[string]struct{})
+			}
+			state.overridden[other.limit.TagValue] = struct{}{}
+		}
+	}
+
+	l.Lock()
+	l.limits = append([]SeriesLimit(nil), limits...)
+	l.states = states
+	l.enabled.Store(len(states) > 0)
+	l.Unlock()
+}
+
+func (l *activeSeriesLimits) Check(metadata doc.Metadata) error {
+	l.Lock()
+	defer l.Unlock()
+	for _, state := range l.states {
+		value, ok := state.match(metadata.Fields)
+		if !ok {
+			continue
+		}
+		if state.active[string(value)] < state.limit.MaxActiveSeries {
+			continue
+		}
+		state.exceeded.Inc(1)
+		return NewSeriesLimitExceededError(fmt.Sprintf(
+			"active series limit exceeded: tag=%s, value=%s, limit=%d",
+			state.limit.TagName, value, state.limit.MaxActiveSeries))
+	}
+	return nil
+}
+
+func (l *activeSeriesLimits) Add(metadata doc.Metadata) {
+	l.Lock()
+	defer l.Unlock()
+	for _, state := range l.states {
+		if value, ok := state.match(metadata.Fields); ok {
+			state.active[string(value)]++
+		}
+	}
+}
+
+func (l *activeSeriesLimits) Remove(metadata doc.Metadata) {
+	l.Lock()
+	defer l.Unlock()
+	for _, state := range l.states {
+		value, ok := state.match(metadata.Fields)
+		if !ok {
+			continue
+		}
+		// NB: series added before the last reset and not added back are not
+		// tracked, make sure not to go negative when they are removed.
+		if curr, ok := state.active[string(value)]; ok {
+			if curr <= 1 {
+				delete(state.active, string(value))
+			} else {
+				state.active[string(value)] = curr - 1
+			}
+		}
+	}
+}
+
+func (s *seriesLimitState) match(fields []doc.Field) ([]byte, bool) {
+	for _, field := range fields {
+		if string(field.Name) != s.limit.TagName {
+			continue
+		}
+		if s.limit.TagValue != "" {
+			return field.Value, string(field.Value) == s.limit.TagValue
+		}
+		if _, ok := s.overridden[string(field.Value)]; ok {
+			return nil, false
+		}
+		return field.Value, true
+	}
+	return nil, false
+}
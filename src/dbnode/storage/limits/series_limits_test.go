@@ -0,0 +1,107 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package limits
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+
+	"github.com/m3db/m3/src/m3ninx/doc"
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+func seriesWithTags(tags ...string) doc.Metadata {
+	var fields []doc.Field
+	for i := 0; i < len(tags); i += 2 {
+		fields = append(fields, doc.Field{
+			Name:  []byte(tags[i]),
+			Value: []byte(tags[i+1]),
+		})
+	}
+	return doc.Metadata{ID: []byte("foo"), Fields: fields}
+}
+
+func TestActiveSeriesLimits(t *testing.T) {
+	scope := tally.NewTestScope("", nil)
+	iOpts := instrument.NewOptions().SetMetricsScope(scope)
+	l := NewActiveSeriesLimits(iOpts)
+
+	// No limits.
+	require.False(t, l.Enabled())
+	require.NoError(t, l.Check(seriesWithTags("tenant", "a")))
+
+	limits := []SeriesLimit{
+		{TagName: "tenant", MaxActiveSeries: 2},
+		{TagName: "tenant", TagValue: "big", MaxActiveSeries: 3},
+		{TagName: "__name__", TagValue: "disabled"},
+	}
+	l.Reset(limits)
+	require.True(t, l.Enabled())
+	require.True(t, SeriesLimitsEqual(limits, l.Limits()))
+
+	for i := 0; i < 2; i++ {
+		series := seriesWithTags("__name__", "disabled", "tenant", "a")
+		require.NoError(t, l.Check(series))
+		l.Add(series)
+	}
+	err := l.Check(seriesWithTags("tenant", "a"))
+	require.Error(t, err)
+	require.True(t, IsSeriesLimitExceededError(err))
+	require.Equal(t, "active series limit exceeded: tag=tenant, value=a, limit=2", err.Error())
+
+	// Other values and series without the tag are not limited by the others.
+	require.NoError(t, l.Check(seriesWithTags("tenant", "b")))
+	require.NoError(t, l.Check(seriesWithTags("host", "a")))
+
+	// Values with a limit of their own are only limited by it.
+	for i := 0; i < 3; i++ {
+		series := seriesWithTags("tenant", "big")
+		require.NoError(t, l.Check(series))
+		l.Add(series)
+	}
+	require.Error(t, l.Check(seriesWithTags("tenant", "big")))
+
+	// Removing series makes room for new ones.
+	l.Remove(seriesWithTags("tenant", "a"))
+	require.NoError(t, l.Check(seriesWithTags("tenant", "a")))
+
+	// Removing series not tracked does not go negative.
+	l.Remove(seriesWithTags("tenant", "c"))
+	l.Remove(seriesWithTags("tenant", "a"))
+	l.Remove(seriesWithTags("tenant", "a"))
+	l.Add(seriesWithTags("tenant", "a"))
+	l.Add(seriesWithTags("tenant", "a"))
+	require.Error(t, l.Check(seriesWithTags("tenant", "a")))
+
+	counters := scope.Snapshot().Counters()
+	require.Equal(t, int64(2),
+		counters["series-limits.exceeded+tag=tenant"].Value())
+	require.Equal(t, int64(1),
+		counters["series-limits.exceeded+tag=tenant,tag_value=big"].Value())
+
+	// Reset clears the tracked series.
+	l.Reset(nil)
+	require.False(t, l.Enabled())
+	require.Empty(t, l.Limits())
+	require.NoError(t, l.Check(seriesWithTags("tenant", "a")))
+}
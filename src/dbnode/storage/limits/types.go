@@ -18,12 +18,14 @@
 // OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
 // THE SOFTWARE.
 
-// Package limits contains paths to enforce read query limits.
+// Package limits contains paths to enforce read query limits and write
+// series limits.
 package limits
 
 import (
 	"time"
 
+	"github.com/m3db/m3/src/m3ninx/doc"
 	"github.com/m3db/m3/src/x/instrument"
 )
 
@@ -81,6 +83,39 @@ type LookbackLimitOptions struct {
 	ForceWaited bool
 }
 
+// SeriesLimit is a limit on the number of active series with a given tag.
+type SeriesLimit struct {
+	// TagName is the name of the tag the limit is keyed on, e.g. "tenant" or
+	// "__name__" to limit the series per metric name.
+	TagName string
+	// TagValue restricts the limit to the series with this value of the tag,
+	// if empty the limit applies to each value of the tag separately. A limit
+	// for a specific value takes precedence over a limit for each value.
+	TagValue string
+	// MaxActiveSeries is the max number of active series with a value of
+	// the tag past which the inserts of new series are rejected.
+	// Zero disables the limit.
+	MaxActiveSeries int64
+}
+
+// ActiveSeriesLimits tracks the number of active series per value of the
+// tags of a set of series limits and enforces the limits.
+type ActiveSeriesLimits interface {
+	// Enabled returns whether any series limits are enforced.
+	Enabled() bool
+	// Limits returns the series limits enforced.
+	Limits() []SeriesLimit
+	// Reset changes the series limits enforced and clears the active series
+	// tracked, active series need to be added back once reset.
+	Reset(limits []SeriesLimit)
+	// Check returns an error if adding the series would exceed a limit.
+	Check(metadata doc.Metadata) error
+	// Add tracks a new active series.
+	Add(metadata doc.Metadata)
+	// Remove stops tracking an active series.
+	Remove(metadata doc.Metadata)
+}
+
 // SourceLoggerBuilder builds a SourceLogger given instrument options.
 type SourceLoggerBuilder interface {
 	// NewSourceLogger builds a source logger.
@@ -36,6 +36,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/sharding"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap"
@@ -48,6 +49,8 @@ import (
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/dbnode/ts/writes"
 	"github.com/m3db/m3/src/m3ninx/doc"
+	"github.com/m3db/m3/src/m3ninx/idx"
+	"github.com/m3db/m3/src/m3ninx/index/segment/fst/encoding/docs"
 	idxpersist "github.com/m3db/m3/src/m3ninx/persist"
 	"github.com/m3db/m3/src/x/clock"
 	"github.com/m3db/m3/src/x/context"
@@ -64,6 +67,7 @@ var (
 	errNamespaceAlreadyClosed    = errors.New("namespace already closed")
 	errNamespaceIndexingDisabled = errors.New("namespace indexing is disabled")
 	errNamespaceReadOnly         = errors.New("cannot write to a read only namespace")
+	errDeleteSeriesNotExhaustive = errors.New("delete series query matched too many series to delete at once")
 )
 
 type commitLogWriter interface {
@@ -174,6 +178,7 @@ type databaseNamespaceMetrics struct {
 	fetchBlocksMetadata instrument.MethodMetrics
 	queryIDs            instrument.MethodMetrics
 	aggregateQuery      instrument.MethodMetrics
+	deleteSeries        instrument.MethodMetrics
 
 	unfulfilled             tally.Counter
 	bootstrapStart          tally.Counter
@@ -262,6 +267,7 @@ func newDatabaseNamespaceMetrics(
 		fetchBlocks:         instrument.NewMethodMetrics(scope, "fetchBlocks", opts),
 		fetchBlocksMetadata: instrument.NewMethodMetrics(scope, "fetchBlocksMetadata", opts),
 		queryIDs:            instrument.NewMethodMetrics(scope, "queryIDs", opts),
+		deleteSeries:        instrument.NewMethodMetrics(scope, "deleteSeries", opts),
 		aggregateQuery:      instrument.NewMethodMetrics(scope, "aggregateQuery", opts),
 
 		unfulfilled:             bootstrapScope.Counter("unfulfilled"),
@@ -835,11 +841,121 @@ func (n *dbNamespace) QueryIDs(
 	res, err := n.reverseIndex.Query(ctx, query, opts)
 	if err != nil {
 		sp.LogFields(opentracinglog.Error(err))
+	} else {
+		n.removeDeletedSeries(res.Results, xtime.Range{
+			Start: opts.StartInclusive,
+			End:   opts.EndExclusive,
+		})
 	}
 	n.metrics.queryIDs.ReportSuccessOrError(err, n.nowFn().Sub(callStart))
 	return res, err
 }
 
+// removeDeletedSeries removes the series with all their datapoints within the
+// range deleted from the results.
+func (n *dbNamespace) removeDeletedSeries(results index.QueryResults, r xtime.Range) {
+	if results == nil {
+		return
+	}
+
+	var (
+		blockSize  = n.nopts.RetentionOptions().BlockSize()
+		resultsMap = results.Map()
+		deleted    [][]byte
+	)
+	for _, entry := range resultsMap.Iter() {
+		id := ident.BytesID(entry.Key())
+		shard, _, err := n.shardFor(id)
+		if err != nil || !shard.HasDeletedSeries() {
+			continue
+		}
+
+		covered := true
+		for blockStart := r.Start.Truncate(blockSize); covered && blockStart.Before(r.End); blockStart = blockStart.Add(blockSize) {
+			blockRange := xtime.Range{Start: blockStart, End: blockStart.Add(blockSize)}
+			if intersection, ok := blockRange.Intersect(r); ok {
+				covered = rangesCover(shard.DeletedRanges(id, blockStart), intersection)
+			}
+		}
+		if covered {
+			deleted = append(deleted, entry.Key())
+		}
+	}
+	for _, id := range deleted {
+		resultsMap.Delete(id)
+	}
+}
+
+func (n *dbNamespace) DeleteSeries(
+	ctx context.Context,
+	query index.Query,
+	start, end xtime.UnixNano,
+) (int64, error) {
+	callStart := n.nowFn()
+	if n.reverseIndex == nil {
+		n.metrics.deleteSeries.ReportError(n.nowFn().Sub(callStart))
+		return 0, errNamespaceIndexingDisabled
+	}
+
+	if !n.reverseIndex.Bootstrapped() {
+		n.metrics.deleteSeries.ReportError(n.nowFn().Sub(callStart))
+		return 0, xerrors.NewRetryableError(errIndexNotBootstrappedToRead)
+	}
+
+	// Only datapoints within retention that could have been written already
+	// can be deleted, datapoints written later are not deleted.
+	var (
+		ropts = n.nopts.RetentionOptions()
+		now   = xtime.ToUnixNano(n.nowFn())
+	)
+	if earliest := retention.FlushTimeStart(ropts, now); start.Before(earliest) {
+		start = earliest
+	}
+	if latest := now.Add(ropts.BufferFuture()); end.After(latest) {
+		end = latest
+	}
+	if !start.Before(end) {
+		n.metrics.deleteSeries.ReportSuccess(n.nowFn().Sub(callStart))
+		return 0, nil
+	}
+
+	res, err := n.reverseIndex.Query(ctx, query, index.QueryOptions{
+		StartInclusive: start,
+		EndExclusive:   end,
+	})
+	if err != nil {
+		n.metrics.deleteSeries.ReportError(n.nowFn().Sub(callStart))
+		return 0, err
+	}
+	if !res.Exhaustive {
+		n.metrics.deleteSeries.ReportError(n.nowFn().Sub(callStart))
+		return 0, errDeleteSeriesNotExhaustive
+	}
+
+	idsByShard := make(map[databaseShard][]ident.ID)
+	for _, entry := range res.Results.Map().Iter() {
+		id := ident.BytesID(append([]byte(nil), entry.Key()...))
+		shard, _, err := n.readableShardFor(id)
+		if err != nil {
+			n.metrics.deleteSeries.ReportError(n.nowFn().Sub(callStart))
+			return 0, err
+		}
+		idsByShard[shard] = append(idsByShard[shard], id)
+	}
+
+	var deleted int64
+	for shard, ids := range idsByShard {
+		if err := shard.DeleteSeries(ids, start, end); err != nil {
+			n.metrics.deleteSeries.ReportError(n.nowFn().Sub(callStart))
+			return deleted, err
+		}
+		deleted += int64(len(ids))
+	}
+
+	n.metrics.deleteSeries.ReportSuccess(n.nowFn().Sub(callStart))
+	return deleted, nil
+}
+
 func (n *dbNamespace) AggregateQuery(
 	ctx context.Context,
 	query index.Query,
@@ -858,11 +974,103 @@ func (n *dbNamespace) AggregateQuery(
 			xerrors.NewRetryableError(errIndexNotBootstrappedToRead)
 	}
 
-	res, err := n.reverseIndex.AggregateQuery(ctx, query, opts)
+	var (
+		res index.AggregateQueryResult
+		err error
+	)
+	if n.hasDeletedSeries() {
+		res, err = n.aggregateQueryWithoutDeleted(ctx, query, opts)
+	} else {
+		res, err = n.reverseIndex.AggregateQuery(ctx, query, opts)
+	}
 	n.metrics.aggregateQuery.ReportSuccessOrError(err, n.nowFn().Sub(callStart))
 	return res, err
 }
 
+// aggregateQueryWithoutDeleted aggregates the tags of the series matching the
+// query that have not been deleted. Deleted series are only removed from the
+// index when their index blocks expire, so the tags are aggregated from the
+// documents of the series instead of the terms of the index.
+func (n *dbNamespace) aggregateQueryWithoutDeleted(
+	ctx context.Context,
+	query index.Query,
+	opts index.AggregationOptions,
+) (index.AggregateQueryResult, error) {
+	queryRes, err := n.reverseIndex.Query(ctx, query, opts.QueryOptions)
+	if err != nil {
+		return index.AggregateQueryResult{}, err
+	}
+	n.removeDeletedSeries(queryRes.Results, xtime.Range{
+		Start: opts.StartInclusive,
+		End:   opts.EndExclusive,
+	})
+
+	fieldFilter := opts.FieldFilter
+	if field, isFieldQuery := idx.FieldQuery(query.Query); isFieldQuery {
+		fieldFilter = fieldFilter.AddIfMissing(field)
+	}
+	aopts := index.AggregateResultsOptions{
+		SizeLimit:   opts.SeriesLimit,
+		DocsLimit:   opts.DocsLimit,
+		FieldFilter: fieldFilter.SortAndDedupe(),
+		Type:        opts.Type,
+	}
+	results := index.NewAggregateResults(n.ID(), aopts, n.opts.IndexOptions())
+
+	var (
+		reader = docs.NewEncodedDocumentReader()
+		size   int
+		count  int
+	)
+	for _, entry := range queryRes.Results.Map().Iter() {
+		metadata, err := docs.MetadataFromDocument(entry.Value(), reader)
+		if err != nil {
+			return index.AggregateQueryResult{}, err
+		}
+
+		batch := make([]index.AggregateResultsEntry, 0, len(metadata.Fields))
+		for _, field := range metadata.Fields {
+			if !aopts.FieldFilter.Allow(field.Name) {
+				continue
+			}
+			aggEntry := index.AggregateResultsEntry{
+				Field: ident.BytesID(append([]byte(nil), field.Name...)),
+			}
+			if opts.Type == index.AggregateTagNamesAndValues {
+				aggEntry.Terms = []ident.ID{ident.BytesID(append([]byte(nil), field.Value...))}
+			}
+			batch = append(batch, aggEntry)
+		}
+		size, count = results.AddFields(batch)
+	}
+
+	exhaustive := queryRes.Exhaustive &&
+		(opts.SeriesLimit == 0 || size < opts.SeriesLimit) &&
+		(opts.DocsLimit == 0 || count < opts.DocsLimit)
+	return index.AggregateQueryResult{
+		Results:    results,
+		Exhaustive: exhaustive,
+		Waited:     queryRes.Waited,
+	}, nil
+}
+
+func (n *dbNamespace) Cardinality(
+	ctx context.Context,
+	opts index.CardinalityOptions,
+) (index.CardinalityResult, error) {
+	if n.reverseIndex == nil {
+		return index.CardinalityResult{}, errNamespaceIndexingDisabled
+	}
+
+	if !n.reverseIndex.Bootstrapped() {
+		// Similar to reading shard data, return not bootstrapped
+		return index.CardinalityResult{},
+			xerrors.NewRetryableError(errIndexNotBootstrappedToRead)
+	}
+
+	return n.reverseIndex.Cardinality(ctx, opts)
+}
+
 func (n *dbNamespace) PrepareBootstrap(ctx context.Context) ([]databaseShard, error) {
 	ctx, span, sampled := ctx.StartSampledTraceSpan(tracepoint.NSPrepareBootstrap)
 	defer span.Finish()
@@ -1243,9 +1451,10 @@ func (n *dbNamespace) ColdFlush(flushPersist persist.FlushPreparer) error {
 	repairsAny := n.repairsAny
 	n.RUnlock()
 
-	// If repair has run we still need cold flush regardless of whether cold writes is
-	// enabled since repairs are dependent on the cold flushing logic.
-	enabled := n.nopts.ColdWritesEnabled() || repairsAny
+	// If repair has run or series were deleted we still need cold flush regardless of
+	// whether cold writes is enabled since repairs and deletes are dependent on the
+	// cold flushing logic.
+	enabled := n.nopts.ColdWritesEnabled() || repairsAny || n.hasDeletedSeries()
 	if n.ReadOnly() || !enabled {
 		n.metrics.flushColdData.ReportSuccess(n.nowFn().Sub(callStart))
 		return nil
@@ -1471,6 +1680,15 @@ func (n *dbNamespace) Truncate() (int64, error) {
 	return totalNumSeries, nil
 }
 
+func (n *dbNamespace) hasDeletedSeries() bool {
+	for _, shard := range n.OwnedShards() {
+		if shard.HasDeletedSeries() {
+			return true
+		}
+	}
+	return false
+}
+
 func (n *dbNamespace) Repair(
 	repairer databaseShardRepairer,
 	tr xtime.Range,
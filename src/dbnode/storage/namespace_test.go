@@ -669,6 +669,7 @@ func TestNamespaceFlushSkipShardNotBootstrapped(t *testing.T) {
 	shard := NewMockdatabaseShard(ctrl)
 	shard.EXPECT().ID().Return(testShardIDs[0].ID()).AnyTimes()
 	shard.EXPECT().IsBootstrapped().Return(false)
+	shard.EXPECT().HasDeletedSeries().Return(false)
 	ns.shards[testShardIDs[0].ID()] = shard
 
 	err := ns.WarmFlush(blockStart, nil)
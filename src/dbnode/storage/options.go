@@ -27,6 +27,7 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/histogram"
 	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
 	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/dbnode/persist"
@@ -173,6 +174,7 @@ type options struct {
 	newBackgroundProcessFns         []NewBackgroundProcessFn
 	namespaceHooks                  NamespaceHooks
 	tileAggregator                  TileAggregator
+	fileSetReblocker                FileSetReblocker
 	permitsOptions                  permits.Options
 	limitsOptions                   limits.Options
 	coreFn                          xsync.CoreFn
@@ -501,12 +503,12 @@ func (o *options) SetEncodingM3TSZPooled() Options {
 	opts.encoderPool = encoderPool
 
 	// initialize single reader iterator pool
-	readerIteratorPool.Init(m3tsz.DefaultReaderIteratorAllocFn(encodingOpts))
+	readerIteratorPool.Init(histogram.DefaultReaderIteratorAllocFn(encodingOpts))
 	opts.readerIteratorPool = readerIteratorPool
 
 	// initialize multi reader iterator pool
 	multiReaderIteratorPool := encoding.NewMultiReaderIteratorPool(opts.poolOpts)
-	multiReaderIteratorPool.Init(m3tsz.DefaultReaderIteratorAllocFn(encodingOpts))
+	multiReaderIteratorPool.Init(histogram.DefaultReaderIteratorAllocFn(encodingOpts))
 	opts.multiReaderIteratorPool = multiReaderIteratorPool
 
 	opts.blockOpts = opts.blockOpts.
@@ -924,6 +926,16 @@ func (o *options) TileAggregator() TileAggregator {
 	return o.tileAggregator
 }
 
+func (o *options) SetFileSetReblocker(value FileSetReblocker) Options {
+	opts := *o
+	opts.fileSetReblocker = value
+	return &opts
+}
+
+func (o *options) FileSetReblocker() FileSetReblocker {
+	return o.fileSetReblocker
+}
+
 func (o *options) CoreFn() xsync.CoreFn {
 	return o.coreFn
 }
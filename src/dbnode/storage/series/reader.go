@@ -207,6 +207,13 @@ func (i *blockReaderIter) Next(ctx context.Context) bool {
 				i.curr = append(i.curr, blockReader)
 			}
 		}
+
+		previousReaders, err := i.reader.streamPreviousBlockSize(ctx, i.blockAt, i.nsCtx)
+		if err != nil {
+			i.err = err
+			return false
+		}
+		i.curr = append(i.curr, previousReaders...)
 		i.blockAt = i.blockAt.Add(i.blockSize)
 	}
 	return len(i.curr) != 0
@@ -409,6 +416,16 @@ func (r *Reader) resolveBlockResults(
 			blockReaders = append(blockReaders, blockReader)
 		}
 
+		previousReaders, err := r.streamPreviousBlockSize(ctx, start, nsCtx)
+		if err != nil {
+			r := block.NewFetchBlockResult(start, nil,
+				fmt.Errorf("unable to retrieve block stream for series %s time %v: %w",
+					r.id.String(), start, err))
+			res = append(res, r)
+			continue
+		}
+		blockReaders = append(blockReaders, previousReaders...)
+
 		if len(blockReaders) > 0 {
 			res = append(res, block.NewFetchBlockResult(start, blockReaders, nil))
 		}
@@ -470,3 +487,35 @@ func (r *Reader) streamBlock(
 
 	return xio.BlockReader{}, false, nil
 }
+
+// streamPreviousBlockSize streams the series from the filesets written with a
+// previous block size within a block that are yet to be re-blocked, the
+// readers are returned as readers of the block since their data lies within it.
+func (r *Reader) streamPreviousBlockSize(
+	ctx context.Context,
+	start xtime.UnixNano,
+	nsCtx namespace.Context,
+) ([]xio.BlockReader, error) {
+	retriever, ok := r.retriever.(PreviousBlockSizeRetriever)
+	if !ok || r.opts.CachePolicy() == CacheAll {
+		return nil, nil
+	}
+
+	var (
+		blockSize = r.opts.RetentionOptions().BlockSize()
+		readers   []xio.BlockReader
+	)
+	for _, previousStart := range retriever.PreviousBlockSizeBlockStarts(start) {
+		streamedBlock, err := r.retriever.Stream(ctx, r.id, previousStart, nil, nsCtx)
+		if err != nil {
+			return nil, err
+		}
+		if streamedBlock.IsEmpty() {
+			continue
+		}
+		streamedBlock.Start = start
+		streamedBlock.BlockSize = blockSize
+		readers = append(readers, streamedBlock)
+	}
+	return readers, nil
+}
@@ -94,6 +94,69 @@ func TestReaderUsingRetrieverReadEncoded(t *testing.T) {
 	require.Equal(t, 2, count)
 }
 
+type previousBlockSizeRetriever struct {
+	*MockQueryableBlockRetriever
+
+	previousStarts map[xtime.UnixNano][]xtime.UnixNano
+}
+
+func (r previousBlockSizeRetriever) PreviousBlockSizeBlockStarts(
+	blockStart xtime.UnixNano,
+) []xtime.UnixNano {
+	return r.previousStarts[blockStart]
+}
+
+func TestReaderUsingRetrieverReadEncodedPreviousBlockSize(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newSeriesTestOptions()
+	ropts := opts.RetentionOptions()
+
+	start := xtime.ToUnixNano(opts.ClockOptions().NowFn()().Truncate(ropts.BlockSize())).
+		Add(-ropts.BlockSize())
+	previousStart := start.Add(ropts.BlockSize() / 2)
+
+	mockRetriever := NewMockQueryableBlockRetriever(ctrl)
+	retriever := previousBlockSizeRetriever{
+		MockQueryableBlockRetriever: mockRetriever,
+		previousStarts: map[xtime.UnixNano][]xtime.UnixNano{
+			start: {start, previousStart},
+		},
+	}
+
+	ctx := opts.ContextPool().Get()
+	defer ctx.Close()
+
+	// The block is yet to be warm flushed so only the filesets of the previous
+	// block size within it are read, as readers of the block.
+	mockRetriever.EXPECT().IsBlockRetrievable(start).Return(false, nil)
+	segReader := xio.NewMockSegmentReader(ctrl)
+	mockRetriever.EXPECT().
+		Stream(ctx, ident.NewIDMatcher("foo"), start, nil, gomock.Any()).
+		Return(xio.EmptyBlockReader, nil)
+	mockRetriever.EXPECT().
+		Stream(ctx, ident.NewIDMatcher("foo"), previousStart, nil, gomock.Any()).
+		Return(xio.BlockReader{
+			SegmentReader: segReader,
+			Start:         previousStart,
+			BlockSize:     ropts.BlockSize() / 2,
+		}, nil)
+
+	reader := NewReaderUsingRetriever(ident.StringID("foo"), retriever, nil, nil, opts)
+	iter, err := reader.ReadEncoded(ctx, start, start.Add(ropts.BlockSize()), namespace.Context{})
+	require.NoError(t, err)
+
+	require.True(t, iter.Next(ctx))
+	require.Equal(t, []xio.BlockReader{{
+		SegmentReader: segReader,
+		Start:         start,
+		BlockSize:     ropts.BlockSize(),
+	}}, iter.Current())
+	require.False(t, iter.Next(ctx))
+	require.NoError(t, iter.Err())
+}
+
 type readTestCase struct {
 	title           string
 	times           []xtime.UnixNano
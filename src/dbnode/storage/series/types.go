@@ -206,6 +206,15 @@ type QueryableBlockRetriever interface {
 	BlockStatesSnapshot() ShardBlockStateSnapshot
 }
 
+// PreviousBlockSizeRetriever is implemented by QueryableBlockRetrievers that
+// serve the filesets written with a previous block size of the namespace
+// until they are re-blocked into filesets of the current block size.
+type PreviousBlockSizeRetriever interface {
+	// PreviousBlockSizeBlockStarts returns the block starts of the filesets of
+	// a previous block size within a block that are yet to be re-blocked.
+	PreviousBlockSizeBlockStarts(blockStart xtime.UnixNano) []xtime.UnixNano
+}
+
 // ShardBlockStateSnapshot represents a snapshot of a shard's block state at
 // a moment in time.
 type ShardBlockStateSnapshot struct {
@@ -45,6 +45,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/storage/index/convert"
+	"github.com/m3db/m3/src/dbnode/storage/limits"
 	"github.com/m3db/m3/src/dbnode/storage/repair"
 	"github.com/m3db/m3/src/dbnode/storage/series"
 	"github.com/m3db/m3/src/dbnode/tracepoint"
@@ -77,6 +78,7 @@ var (
 	errShardAlreadyBootstrapped   = errors.New("shard is already bootstrapped")
 	errFlushStateIsNotInitialized = errors.New("shard flush state is not initialized")
 	errTriedToLoadNilSeries       = errors.New("tried to load nil series into shard")
+	errFileSetReblockerNotSet     = errors.New("shard has filesets of a previous block size but no fileset reblocker")
 
 	// ErrDatabaseLoadLimitHit is the error returned when the database load limit
 	// is hit or exceeded.
@@ -126,6 +128,7 @@ type dbShard struct {
 	seriesPool            series.DatabaseSeriesPool
 	reverseIndex          NamespaceIndex
 	insertQueue           *dbShardInsertQueue
+	seriesLimits          limits.ActiveSeriesLimits
 
 	// protected by dbShard lock.
 	lastEntryIndex uint64
@@ -151,6 +154,7 @@ type dbShard struct {
 	logger                   *zap.Logger
 	metrics                  dbShardMetrics
 	tileAggregator           TileAggregator
+	tombstones               *shardTombstones
 	ticking                  bool
 	shard                    uint32
 	coldWritesEnabled        bool
@@ -239,6 +243,10 @@ type shardFlushState struct {
 	sync.RWMutex
 	statesByTime map[xtime.UnixNano]fileOpState
 	initialized  bool
+	// previousBlockSizeStarts are the block starts of the filesets written with
+	// a previous, smaller block size of the namespace that are yet to be
+	// re-blocked into the block of the current block size they lie within.
+	previousBlockSizeStarts map[xtime.UnixNano]struct{}
 }
 
 func newShardFlushState() shardFlushState {
@@ -260,6 +268,8 @@ func newDatabaseShard(
 ) databaseShard {
 	scope := opts.InstrumentOptions().MetricsScope().
 		SubScope("dbshard")
+	tombstones := newShardTombstones(opts.CommitLogOptions().FilesystemOptions(),
+		namespaceMetadata.ID(), shard, namespaceMetadata.Options().RetentionOptions().BlockSize())
 
 	s := &dbShard{
 		opts:                 opts,
@@ -291,8 +301,11 @@ func newDatabaseShard(
 		logger:               opts.InstrumentOptions().Logger(),
 		metrics:              newDatabaseShardMetrics(shard, scope),
 		tileAggregator:       opts.TileAggregator(),
+		tombstones:           tombstones,
 		entryMetrics:         NewEntryMetrics(scope.SubScope("entries")),
 	}
+	s.seriesLimits = limits.NewActiveSeriesLimits(
+		opts.InstrumentOptions().SetMetricsScope(scope))
 	s.insertQueue = newDatabaseShardInsertQueue(s.insertSeriesBatch,
 		s.nowFn, opts.CoreFn(), scope, opts.InstrumentOptions().Logger())
 
@@ -309,6 +322,10 @@ func newDatabaseShard(
 
 	if !needsBootstrap {
 		s.bootstrapState = Bootstrapped
+		if err := s.tombstones.load(); err != nil {
+			s.logger.Error("could not load shard tombstones",
+				zap.Uint32("shard", shard), zap.Error(err))
+		}
 	}
 
 	if blockRetriever != nil {
@@ -337,6 +354,26 @@ func (s *dbShard) SetRuntimeOptions(value runtime.Options) {
 		tickSleepPerSeries:       value.TickPerSeriesSleepDuration(),
 	}
 	s.Unlock()
+
+	if seriesLimits := value.SeriesLimitsPerShard(); !limits.SeriesLimitsEqual(
+		seriesLimits, s.seriesLimits.Limits()) {
+		s.resetSeriesLimits(seriesLimits)
+	}
+}
+
+func (s *dbShard) resetSeriesLimits(seriesLimits []limits.SeriesLimit) {
+	// NB: hold the read lock while the active series are added back to
+	// ensure no series are inserted or purged in the meantime.
+	s.RLock()
+	defer s.RUnlock()
+
+	s.seriesLimits.Reset(seriesLimits)
+	if !s.seriesLimits.Enabled() {
+		return
+	}
+	for elem := s.list.Front(); elem != nil; elem = elem.Next() {
+		s.seriesLimits.Add(elem.Value.(*Entry).Series.Metadata())
+	}
 }
 
 func (s *dbShard) ID() uint32 {
@@ -362,6 +399,26 @@ func (s *dbShard) Stream(
 		blockStart, onRetrieve, nsCtx)
 }
 
+// PreviousBlockSizeBlockStarts implements series.PreviousBlockSizeRetriever
+func (s *dbShard) PreviousBlockSizeBlockStarts(blockStart xtime.UnixNano) []xtime.UnixNano {
+	s.flushState.RLock()
+	defer s.flushState.RUnlock()
+	if len(s.flushState.previousBlockSizeStarts) == 0 {
+		return nil
+	}
+
+	var (
+		blockEnd = blockStart.Add(s.namespace.Options().RetentionOptions().BlockSize())
+		starts   []xtime.UnixNano
+	)
+	for start := range s.flushState.previousBlockSizeStarts {
+		if !start.Before(blockStart) && start.Before(blockEnd) {
+			starts = append(starts, start)
+		}
+	}
+	return starts
+}
+
 // IsBlockRetrievable implements series.QueryableBlockRetriever
 func (s *dbShard) IsBlockRetrievable(blockStart xtime.UnixNano) (bool, error) {
 	return s.hasWarmFlushed(blockStart)
@@ -656,7 +713,7 @@ func (s *dbShard) Close() error {
 	// should be increased.
 	cancellable := context.NewNoOpCanncellable()
 	_, err := s.tickAndExpire(cancellable, tickPolicyCloseShard, namespace.Context{})
-	return err
+	return xerrors.FirstError(err, s.tombstones.persistRestored())
 }
 
 func (s *dbShard) Closed() bool {
@@ -859,6 +916,9 @@ func (s *dbShard) purgeExpiredSeries(expiredEntries []*Entry) {
 		// NB(xichen): if we get here, we are guaranteed that there can be
 		// no more reads/writes to this series while the lock is held, so it's
 		// safe to remove it.
+		if s.seriesLimits.Enabled() {
+			s.seriesLimits.Remove(series.Metadata())
+		}
 		series.Close()
 		s.list.Remove(elem)
 		s.lookup.Delete(id)
@@ -1020,6 +1080,11 @@ func (s *dbShard) writeAndIndex(
 		commitLogSeriesUniqueIndex = result.entry.Index
 	}
 
+	// Datapoints written after they were deleted are no longer deleted.
+	if wasWritten {
+		s.tombstones.restoreWritten(id, timestamp)
+	}
+
 	// Return metadata useful for writing to commit log and indexing.
 	return SeriesWrite{
 		Series: ts.Series{
@@ -1108,15 +1173,43 @@ func (s *dbShard) ReadEncoded(
 		return nil, err
 	}
 
+	var iter series.BlockReaderIter
 	if entry != nil {
-		return entry.Series.ReadEncoded(ctx, start, end, nsCtx)
+		iter, err = entry.Series.ReadEncoded(ctx, start, end, nsCtx)
+	} else {
+		retriever := s.seriesBlockRetriever
+		onRetrieve := s.seriesOnRetrieveBlock
+		opts := s.seriesOpts
+		reader := series.NewReaderUsingRetriever(id, retriever, onRetrieve, nil, opts)
+		iter, err = reader.ReadEncoded(ctx, start, end, nsCtx)
+	}
+	if err != nil || iter == nil {
+		return iter, err
+	}
+
+	// Hide the deleted datapoints that have not been removed from disk yet.
+	blockSize := s.namespace.Options().RetentionOptions().BlockSize()
+	deleted := s.tombstones.deletedRanges(id, xtime.Range{
+		Start: start.Truncate(blockSize),
+		End:   end.Truncate(blockSize).Add(blockSize),
+	})
+	if deleted == nil {
+		return iter, nil
 	}
+	return newDeletedBlockReaderIter(iter, deleted, blockSize, s.opts, nsCtx), nil
+}
+
+func (s *dbShard) DeleteSeries(ids []ident.ID, start, end xtime.UnixNano) error {
+	return s.tombstones.add(ids, xtime.Range{Start: start, End: end}, xtime.ToUnixNano(s.nowFn()))
+}
 
-	retriever := s.seriesBlockRetriever
-	onRetrieve := s.seriesOnRetrieveBlock
-	opts := s.seriesOpts
-	reader := series.NewReaderUsingRetriever(id, retriever, onRetrieve, nil, opts)
-	return reader.ReadEncoded(ctx, start, end, nsCtx)
+func (s *dbShard) DeletedRanges(id ident.ID, blockStart xtime.UnixNano) xtime.Ranges {
+	blockSize := s.namespace.Options().RetentionOptions().BlockSize()
+	return s.tombstones.deletedRanges(id, xtime.Range{Start: blockStart, End: blockStart.Add(blockSize)})
+}
+
+func (s *dbShard) HasDeletedSeries() bool {
+	return !s.tombstones.isEmpty()
 }
 
 // lookupEntryWithLock returns the entry for a given id while holding a read lock or a write lock.
@@ -1318,6 +1411,15 @@ func (s *dbShard) insertSeriesAsyncBatched(
 		return insertAsyncResult{}, err
 	}
 
+	// NB: the series limits are checked before enqueueing the insert so
+	// that writes of new series past the limits are rejected, series being
+	// inserted concurrently are not accounted for until they are inserted.
+	if s.seriesLimits.Enabled() {
+		if err := s.seriesLimits.Check(entry.Series.Metadata()); err != nil {
+			return insertAsyncResult{}, err
+		}
+	}
+
 	wg, err := s.insertQueue.Insert(dbShardInsert{
 		entry: entry,
 		opts:  opts,
@@ -1427,6 +1529,9 @@ func (s *dbShard) insertNewShardEntryWithLock(entry *Entry) {
 		NoCopyKey:     true,
 		NoFinalizeKey: true,
 	})
+	if s.seriesLimits.Enabled() {
+		s.seriesLimits.Add(entry.Series.Metadata())
+	}
 	entry.SetInsertTime(s.nowFn())
 }
 
@@ -1613,18 +1718,39 @@ func (s *dbShard) FetchBlocks(
 		return nil, err
 	}
 
+	var results []block.FetchBlockResult
 	if entry != nil {
-		return entry.Series.FetchBlocks(ctx, starts, nsCtx)
+		results, err = entry.Series.FetchBlocks(ctx, starts, nsCtx)
+	} else {
+		retriever := s.seriesBlockRetriever
+		onRetrieve := s.seriesOnRetrieveBlock
+		opts := s.seriesOpts
+		// Nil for onRead callback because we don't want peer bootstrapping to impact
+		// the behavior of the LRU
+		var onReadCb block.OnReadBlock
+		reader := series.NewReaderUsingRetriever(id, retriever, onRetrieve, onReadCb, opts)
+		results, err = reader.FetchBlocks(ctx, starts, nsCtx)
+	}
+	if err != nil || len(starts) == 0 || s.tombstones.isEmpty() {
+		return results, err
 	}
 
-	retriever := s.seriesBlockRetriever
-	onRetrieve := s.seriesOnRetrieveBlock
-	opts := s.seriesOpts
-	// Nil for onRead callback because we don't want peer bootstrapping to impact
-	// the behavior of the LRU
-	var onReadCb block.OnReadBlock
-	reader := series.NewReaderUsingRetriever(id, retriever, onRetrieve, onReadCb, opts)
-	return reader.FetchBlocks(ctx, starts, nsCtx)
+	// Hide the deleted datapoints that have not been removed from disk yet so
+	// that they are not streamed to peers.
+	blockSize := s.namespace.Options().RetentionOptions().BlockSize()
+	minStart, maxStart := starts[0], starts[0]
+	for _, start := range starts {
+		minStart = xtime.MinUnixNano(minStart, start)
+		maxStart = xtime.MaxUnixNano(maxStart, start)
+	}
+	deleted := s.tombstones.deletedRanges(id, xtime.Range{
+		Start: minStart.Truncate(blockSize),
+		End:   maxStart.Truncate(blockSize).Add(blockSize),
+	})
+	if deleted == nil {
+		return results, nil
+	}
+	return fetchBlockResultsWithoutDeleted(ctx, results, deleted, blockSize, s.opts, nsCtx), nil
 }
 
 func (s *dbShard) FetchBlocksForColdFlush(
@@ -1955,6 +2081,16 @@ func (s *dbShard) UpdateFlushStates() {
 	readInfoFilesResults := fs.ReadInfoFiles(fsOpts.FilePathPrefix(), s.namespace.ID(), s.shard,
 		fsOpts.InfoReaderBufferSize(), fsOpts.DecodingOptions(), persist.FileSetFlushType)
 
+	var (
+		blockSize               = s.namespace.Options().RetentionOptions().BlockSize()
+		previousBlockSizeStarts = make(map[xtime.UnixNano]struct{})
+	)
+	defer func() {
+		s.flushState.Lock()
+		s.flushState.previousBlockSizeStarts = previousBlockSizeStarts
+		s.flushState.Unlock()
+	}()
+
 	for _, result := range readInfoFilesResults {
 		if err := result.Err.Error(); err != nil {
 			s.logger.Error("unable to read info files in shard bootstrap",
@@ -1969,7 +2105,15 @@ func (s *dbShard) UpdateFlushStates() {
 		at := xtime.UnixNano(info.BlockStart)
 		currState := s.flushStateNoBootstrapCheck(at)
 
-		if currState.WarmStatus.DataFlushed != fileOpSuccess {
+		// Filesets of a previous, smaller block size lie within a block of the
+		// current block size that is yet to be warm flushed. They are served
+		// along with the block and re-blocked once it has been warm flushed.
+		previousBlockSize := info.BlockSize != 0 && time.Duration(info.BlockSize) < blockSize
+		if previousBlockSize {
+			previousBlockSizeStarts[at] = struct{}{}
+		}
+
+		if !previousBlockSize && currState.WarmStatus.DataFlushed != fileOpSuccess {
 			s.markWarmDataFlushStateSuccess(at)
 		}
 
@@ -1991,7 +2135,6 @@ func (s *dbShard) UpdateFlushStates() {
 		return
 	}
 
-	blockSize := s.namespace.Options().RetentionOptions().BlockSize()
 	indexBlockSize := s.namespace.Options().IndexOptions().BlockSize()
 
 	indexFlushedBlockStarts := s.reverseIndex.WarmFlushBlockStarts()
@@ -2038,6 +2181,10 @@ func (s *dbShard) Bootstrap(
 		multiErr = multiErr.Add(err)
 	}
 
+	if err := s.tombstones.load(); err != nil {
+		multiErr = multiErr.Add(err)
+	}
+
 	// Now that this shard has finished bootstrapping, attempt to cache all of its seekers. Cannot call
 	// this earlier as block lease verification will fail due to the shards not being bootstrapped
 	// (and as a result no leases can be verified since the flush state is not yet known).
@@ -2220,13 +2367,27 @@ func (s *dbShard) WarmFlush(
 	}
 	s.RUnlock()
 
+	// Volume index is always 0 for warm flushes because a warm flush must
+	// happen first before cold flushes happen, unless filesets of a previous
+	// block size exist at the block start in which case the warm flush follows
+	// them and is re-blocked with them once written.
+	var (
+		previousBlockSizeStarts = s.PreviousBlockSizeBlockStarts(blockStart)
+		volume                  = 0
+	)
+	if len(previousBlockSizeStarts) > 0 {
+		var err error
+		volume, err = s.nextVolume(blockStart)
+		if err != nil {
+			return err
+		}
+	}
+
 	prepareOpts := persist.DataPrepareOptions{
 		NamespaceMetadata: s.namespace,
 		Shard:             s.ID(),
 		BlockStart:        blockStart,
-		// Volume index is always 0 for warm flushes because a warm flush must
-		// happen first before cold flushes happen.
-		VolumeIndex: 0,
+		VolumeIndex:       volume,
 		// We explicitly set delete if exists to false here as we track which
 		// filesets exist at bootstrap time so we should never encounter a time
 		// where a fileset already exists when we attempt to flush unless there
@@ -2270,7 +2431,78 @@ func (s *dbShard) WarmFlush(
 		multiErr = multiErr.Add(err)
 	}
 
-	return s.markWarmDataFlushStateSuccessOrError(blockStart, multiErr.FinalError())
+	if err := multiErr.FinalError(); err != nil || len(previousBlockSizeStarts) == 0 {
+		return s.markWarmDataFlushStateSuccessOrError(blockStart, err)
+	}
+
+	return s.reblockPreviousBlockSize(blockStart, volume, previousBlockSizeStarts)
+}
+
+// nextVolume returns the volume following all volumes of a block start.
+func (s *dbShard) nextVolume(blockStart xtime.UnixNano) (int, error) {
+	filePathPrefix := s.opts.CommitLogOptions().FilesystemOptions().FilePathPrefix()
+	filesets, err := s.filesetsFn(filePathPrefix, s.namespace.ID(), s.ID())
+	if err != nil {
+		return 0, err
+	}
+
+	next := 0
+	for _, fileset := range filesets {
+		if fileset.ID.BlockStart.Equal(blockStart) && fileset.ID.VolumeIndex >= next {
+			next = fileset.ID.VolumeIndex + 1
+		}
+	}
+	return next, nil
+}
+
+// reblockPreviousBlockSize re-blocks the filesets of a previous block size
+// within a block that was just warm flushed to the given volume, the filesets
+// are served until the re-blocked volume is retrievable and removed after.
+func (s *dbShard) reblockPreviousBlockSize(
+	blockStart xtime.UnixNano,
+	warmVolume int,
+	previousBlockSizeStarts []xtime.UnixNano,
+) error {
+	reblocker := s.opts.FileSetReblocker()
+	if reblocker == nil {
+		return s.markWarmDataFlushStateSuccessOrError(blockStart, errFileSetReblockerNotSet)
+	}
+
+	volume, err := reblocker.Reblock(s.opts, s.namespace, s.ID(), blockStart, warmVolume)
+	if err != nil {
+		return s.markWarmDataFlushStateSuccessOrError(blockStart, err)
+	}
+
+	if err := s.finishWriting(blockStart, volume, false); err != nil {
+		return s.markWarmDataFlushStateSuccessOrError(blockStart, err)
+	}
+	s.markWarmDataFlushStateSuccess(blockStart)
+
+	s.flushState.Lock()
+	for _, start := range previousBlockSizeStarts {
+		delete(s.flushState.previousBlockSizeStarts, start)
+		if !start.Equal(blockStart) {
+			delete(s.flushState.statesByTime, start)
+		}
+	}
+	s.flushState.Unlock()
+
+	// NB: the volumes at the block start that precede the re-blocked volume are
+	// removed along with other compacted filesets.
+	filePathPrefix := s.opts.CommitLogOptions().FilesystemOptions().FilePathPrefix()
+	filesets, err := s.filesetsFn(filePathPrefix, s.namespace.ID(), s.ID())
+	if err != nil {
+		return err
+	}
+	toDelete := fs.FileSetFilesSlice(make([]fs.FileSetFile, 0, len(previousBlockSizeStarts)))
+	for _, fileset := range filesets {
+		for _, start := range previousBlockSizeStarts {
+			if !start.Equal(blockStart) && fileset.ID.BlockStart.Equal(start) {
+				toDelete = append(toDelete, fileset)
+			}
+		}
+	}
+	return s.deleteFilesFn(toDelete.Filepaths())
 }
 
 func (s *dbShard) ColdFlush(
@@ -2347,7 +2579,33 @@ func (s *dbShard) ColdFlush(
 		return shardColdFlush{}, loopErr
 	}
 
-	if dirtySeries.Len() == 0 {
+	// Blocks with deleted datapoints are merged even without cold writes so
+	// that the deleted datapoints are removed from disk.
+	ropts := s.namespace.Options().RetentionOptions()
+	if err := s.tombstones.removeBefore(retention.FlushTimeStart(ropts, xtime.ToUnixNano(s.nowFn()))); err != nil {
+		return shardColdFlush{}, err
+	}
+	var (
+		// NB: datapoints deleted after the merge starts may not be removed
+		// from disk by it, so blocks are only marked as purged as of now.
+		purgedAt         = xtime.ToUnixNano(s.nowFn())
+		hasDeletedBlocks bool
+	)
+	for _, blockStart := range s.tombstones.blocksToPurge() {
+		hasWarmFlushed, err := s.hasWarmFlushed(blockStart)
+		if err != nil {
+			return shardColdFlush{}, err
+		}
+		if !hasWarmFlushed {
+			continue
+		}
+		if dirtySeriesToWrite[blockStart] == nil {
+			dirtySeriesToWrite[blockStart] = newIDList(idElementPool)
+		}
+		hasDeletedBlocks = true
+	}
+
+	if dirtySeries.Len() == 0 && !hasDeletedBlocks {
 		// Early exit if there is nothing dirty to merge. dirtySeriesToWrite
 		// may be non-empty when dirtySeries is empty because we purposely
 		// leave empty seriesLists in the dirtySeriesToWrite map to avoid having
@@ -2356,8 +2614,9 @@ func (s *dbShard) ColdFlush(
 	}
 
 	flush := shardColdFlush{
-		shard:   s,
-		doneFns: make([]shardColdFlushDone, 0, len(dirtySeriesToWrite)),
+		shard:    s,
+		doneFns:  make([]shardColdFlushDone, 0, len(dirtySeriesToWrite)),
+		purgedAt: purgedAt,
 	}
 	merger := s.newMergerFn(resources.fsReader, s.opts.DatabaseBlockOptions().DatabaseBlockAllocSize(),
 		s.opts.SegmentReaderPool(), s.opts.MultiReaderIteratorPool(),
@@ -2507,6 +2766,11 @@ func (s *dbShard) Snapshot(
 	multiErr = multiErr.Add(prepared.Close())
 	closeTimer.Stop()
 
+	// NB: the restored datapoints that could not be appended to the
+	// tombstones log are persisted along with the snapshot so that they are
+	// not deleted again once the commit logs are cleaned up.
+	multiErr = multiErr.Add(s.tombstones.persistRestored())
+
 	if err := multiErr.FinalError(); err != nil {
 		return ShardSnapshotResult{}, err
 	}
@@ -2626,6 +2890,11 @@ func (s *dbShard) removeAnyFlushStatesTooEarly(startTime xtime.UnixNano) {
 			delete(s.flushState.statesByTime, t)
 		}
 	}
+	for t := range s.flushState.previousBlockSizeStarts {
+		if t.Before(earliestFlush) {
+			delete(s.flushState.previousBlockSizeStarts, t)
+		}
+	}
 	s.flushState.Unlock()
 }
 
@@ -2700,7 +2969,8 @@ func (s *dbShard) AggregateTiles(
 		// NB: markWarmFlushStateSuccess=true because there are no flushes happening in this
 		// flow, and we need to set WarmStatus to fileOpSuccess explicitly in order to make
 		// the new blocks readable.
-		if err = s.finishWriting(opts.Start, nextVolume, true); err != nil {
+		targetBlockStart := opts.Start.Truncate(s.namespace.Options().RetentionOptions().BlockSize())
+		if err = s.finishWriting(targetBlockStart, nextVolume, true); err != nil {
 			multiErr = multiErr.Add(err)
 		}
 	}
@@ -2838,10 +3108,16 @@ type shardColdFlushDone struct {
 type shardColdFlush struct {
 	shard   *dbShard
 	doneFns []shardColdFlushDone
+	// purgedAt is the time before which the datapoints deleted from the
+	// merged blocks are removed from disk.
+	purgedAt xtime.UnixNano
 }
 
 func (s shardColdFlush) Done() error {
-	multiErr := xerrors.NewMultiError()
+	var (
+		multiErr = xerrors.NewMultiError()
+		merged   = make([]xtime.UnixNano, 0, len(s.doneFns))
+	)
 	for _, done := range s.doneFns {
 		startTime := done.startTime
 		nextVersion := done.nextVersion
@@ -2854,7 +3130,14 @@ func (s shardColdFlush) Done() error {
 		err := s.shard.finishWriting(startTime, nextVersion, false)
 		if err != nil {
 			multiErr = multiErr.Add(err)
+			continue
 		}
+		merged = append(merged, startTime)
+	}
+
+	// The deleted datapoints of the merged blocks are no longer on disk.
+	if s.shard != nil {
+		multiErr = multiErr.Add(s.shard.tombstones.markPurged(merged, s.purgedAt))
 	}
 	return multiErr.FinalError()
 }
@@ -34,6 +34,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/runtime"
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/storage/index/convert"
+	"github.com/m3db/m3/src/dbnode/storage/limits"
 	"github.com/m3db/m3/src/dbnode/storage/series"
 	"github.com/m3db/m3/src/m3ninx/doc"
 	"github.com/m3db/m3/src/x/context"
@@ -104,6 +105,63 @@ func TestShardInsertNamespaceIndex(t *testing.T) {
 	require.Equal(t, []byte("value"), indexWrites[0].Fields[0].Value)
 }
 
+func TestShardInsertSeriesLimits(t *testing.T) {
+	defer leaktest.CheckTimeout(t, 2*time.Second)()
+	opts := DefaultTestOptions()
+
+	now := xtime.Now()
+	blockSize := namespace.NewIndexOptions().BlockSize()
+	blockStart := now.Truncate(blockSize)
+
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+	idx := NewMockNamespaceIndex(ctrl)
+	idx.EXPECT().BlockStartForWriteTime(gomock.Any()).Return(blockStart).AnyTimes()
+	idx.EXPECT().WriteBatch(gomock.Any()).Do(
+		func(batch *index.WriteBatch) {
+			for i, e := range batch.PendingEntries() {
+				e.OnIndexSeries.OnIndexSuccess(blockStart)
+				e.OnIndexSeries.OnIndexFinalize(blockStart)
+				batch.PendingEntries()[i].OnIndexSeries = nil
+			}
+		}).Return(nil).AnyTimes()
+
+	shard := testDatabaseShardWithIndexFn(t, opts, idx, false)
+	runtimeOpts := runtime.NewOptions().SetWriteNewSeriesAsync(false)
+	shard.SetRuntimeOptions(runtimeOpts)
+	defer shard.Close()
+
+	ctx := context.NewBackground()
+	defer ctx.Close()
+
+	write := func(id, tenant string) error {
+		tags := ident.NewTags(ident.StringTag("tenant", tenant))
+		_, err := shard.WriteTagged(ctx, ident.StringID(id),
+			convert.NewTagsIterMetadataResolver(ident.NewTagsIterator(tags)),
+			now, 1.0, xtime.Second, nil, series.WriteOptions{})
+		return err
+	}
+
+	// Series inserted before the limits are set are accounted for.
+	require.NoError(t, write("a1", "a"))
+
+	shard.SetRuntimeOptions(runtimeOpts.SetSeriesLimitsPerShard([]limits.SeriesLimit{
+		{TagName: "tenant", MaxActiveSeries: 2},
+	}))
+
+	require.NoError(t, write("a2", "a"))
+	err := write("a3", "a")
+	require.Error(t, err)
+	require.True(t, limits.IsSeriesLimitExceededError(err))
+
+	// Writes to existing series and other values of the tag are not limited.
+	require.NoError(t, write("a1", "a"))
+	require.NoError(t, write("b1", "b"))
+
+	shard.SetRuntimeOptions(runtimeOpts)
+	require.NoError(t, write("a3", "a"))
+}
+
 func TestShardAsyncInsertMarkIndexedForBlockStart(t *testing.T) {
 	ctrl := xtest.NewController(t)
 	defer ctrl.Finish()
@@ -575,6 +575,89 @@ func TestShardFlushSeriesFlushSuccess(t *testing.T) {
 	}, flushState)
 }
 
+func TestShardWarmFlushReblocksPreviousBlockSize(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testdir")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		reblocker = NewMockFileSetReblocker(ctrl)
+		opts      = DefaultTestOptions().SetFileSetReblocker(reblocker)
+		fsOpts    = opts.CommitLogOptions().FilesystemOptions().SetFilePathPrefix(dir)
+	)
+	opts = opts.SetCommitLogOptions(opts.CommitLogOptions().SetFilesystemOptions(fsOpts))
+
+	s := testDatabaseShard(t, opts)
+	defer s.Close()
+
+	var (
+		blockSize         = s.namespace.Options().RetentionOptions().BlockSize()
+		previousBlockSize = blockSize / 2
+		blockStart        = xtime.Now().Truncate(blockSize).Add(-2 * blockSize)
+		previousStarts    = []xtime.UnixNano{blockStart, blockStart.Add(previousBlockSize)}
+	)
+
+	// Filesets of a previous, smaller block size within a block.
+	writer, err := fs.NewWriter(fsOpts)
+	require.NoError(t, err)
+	for _, start := range previousStarts {
+		require.NoError(t, writer.Open(fs.DataWriterOpenOptions{
+			FileSetType: persist.FileSetFlushType,
+			Identifier: fs.FileSetFileIdentifier{
+				Namespace:  s.namespace.ID(),
+				Shard:      s.ID(),
+				BlockStart: start,
+			},
+			BlockSize: previousBlockSize,
+		}))
+		require.NoError(t, writer.Close())
+	}
+
+	ctx := context.NewBackground()
+	defer ctx.Close()
+	require.NoError(t, s.Bootstrap(ctx, namespace.Context{ID: ident.StringID("foo")}))
+
+	// The filesets are served along with the block which is yet to be warm flushed.
+	require.ElementsMatch(t, previousStarts, s.PreviousBlockSizeBlockStarts(blockStart))
+	retrievable, err := s.IsBlockRetrievable(blockStart)
+	require.NoError(t, err)
+	require.False(t, retrievable)
+
+	// The warm flush follows the volume of the previous block size at the block start
+	// and is then re-blocked with the filesets of the previous block size.
+	flush := persist.NewMockFlushPreparer(ctrl)
+	flush.EXPECT().PrepareData(xtest.CmpMatcher(persist.DataPrepareOptions{
+		NamespaceMetadata: s.namespace,
+		Shard:             s.shard,
+		BlockStart:        blockStart,
+		VolumeIndex:       1,
+		FileSetType:       persist.FileSetFlushType,
+	})).Return(persist.PreparedDataPersist{
+		Persist: func(persist.Metadata, ts.Segment, uint32) error { return nil },
+		Close:   func() error { return nil },
+	}, nil)
+	reblocker.EXPECT().Reblock(opts, s.namespace, s.ID(), blockStart, 1).Return(2, nil)
+
+	require.NoError(t, s.WarmFlush(blockStart, flush, namespace.Context{}))
+
+	flushState, err := s.FlushState(blockStart)
+	require.NoError(t, err)
+	require.Equal(t, fileOpSuccess, flushState.WarmStatus.DataFlushed)
+	require.Equal(t, 2, flushState.ColdVersionFlushed)
+	require.Equal(t, 2, flushState.ColdVersionRetrievable)
+	require.Empty(t, s.PreviousBlockSizeBlockStarts(blockStart))
+
+	// The fileset of the previous block size at the block start is removed along
+	// with compacted filesets, others right away.
+	filesets, err := fs.DataFiles(dir, s.namespace.ID(), s.ID())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(filesets))
+	require.Equal(t, blockStart, filesets[0].ID.BlockStart)
+}
+
 type testDirtySeries struct {
 	id         ident.ID
 	dirtyTimes []xtime.UnixNano
@@ -1635,6 +1718,106 @@ func TestShardRegisterRuntimeOptionsListeners(t *testing.T) {
 	assert.Equal(t, 2, closer.called)
 }
 
+func TestShardDeleteSeriesHidesDeletedDatapoints(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testdir")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var (
+		id        = ident.StringID("foo")
+		blockSize = defaultTestNs1Opts.RetentionOptions().BlockSize()
+		start     = xtime.Now().Truncate(blockSize)
+		now       = start.Add(time.Minute)
+	)
+
+	opts := DefaultTestOptions()
+	opts = opts.
+		SetClockOptions(opts.ClockOptions().SetNowFn(now.ToTime)).
+		SetCommitLogOptions(opts.CommitLogOptions().
+			SetFilesystemOptions(opts.CommitLogOptions().FilesystemOptions().
+				SetFilePathPrefix(dir)))
+	shard := testDatabaseShard(t, opts)
+	defer shard.Close()
+
+	ctx := context.NewBackground()
+	defer ctx.Close()
+
+	for i := 0; i < 3; i++ {
+		_, err := shard.Write(ctx, id, start.Add(time.Duration(i)*time.Second),
+			float64(i), xtime.Second, nil, series.WriteOptions{})
+		require.NoError(t, err)
+	}
+
+	require.False(t, shard.HasDeletedSeries())
+	require.NoError(t, shard.DeleteSeries([]ident.ID{id},
+		start.Add(time.Second), start.Add(2*time.Second)))
+	require.True(t, shard.HasDeletedSeries())
+	require.Nil(t, shard.DeletedRanges(ident.StringID("bar"), start))
+	require.NotNil(t, shard.DeletedRanges(id, start))
+
+	values := func(blocks []xio.BlockReader) []float64 {
+		readers := make([]xio.SegmentReader, 0, len(blocks))
+		for _, b := range blocks {
+			readers = append(readers, b.SegmentReader)
+		}
+		multiIter := opts.MultiReaderIteratorPool().Get()
+		multiIter.Reset(readers, start, blockSize, nil)
+		defer multiIter.Close()
+
+		var values []float64
+		for multiIter.Next() {
+			dp, _, _ := multiIter.Current()
+			values = append(values, dp.Value)
+		}
+		require.NoError(t, multiIter.Err())
+		return values
+	}
+
+	iter, err := shard.ReadEncoded(ctx, id, start, start.Add(blockSize), namespace.Context{})
+	require.NoError(t, err)
+	blocks, err := iter.ToSlices(ctx)
+	require.NoError(t, err)
+	require.Len(t, blocks, 1)
+	require.Equal(t, []float64{0, 2}, values(blocks[0]))
+
+	fetched, err := shard.FetchBlocks(ctx, id, []xtime.UnixNano{start}, namespace.Context{})
+	require.NoError(t, err)
+	require.Len(t, fetched, 1)
+	require.Equal(t, []float64{0, 2}, values(fetched[0].Blocks))
+
+	// Datapoints written after the deletion are not deleted.
+	_, err = shard.Write(ctx, id, start.Add(time.Second), 3, xtime.Second, nil, series.WriteOptions{})
+	require.NoError(t, err)
+	require.False(t, shard.DeletedRanges(id, start).Overlaps(xtime.Range{
+		Start: start.Add(time.Second),
+		End:   start.Add(time.Second) + 1,
+	}))
+
+	iter, err = shard.ReadEncoded(ctx, id, start, start.Add(blockSize), namespace.Context{})
+	require.NoError(t, err)
+	blocks, err = iter.ToSlices(ctx)
+	require.NoError(t, err)
+	require.Len(t, blocks, 1)
+	require.Equal(t, []float64{0, 3, 2}, values(blocks[0]))
+
+	// Datapoints written after the deletion stay restored after a restart
+	// without a snapshot, where the commit log bootstrap loads the datapoints
+	// without restoring them before the tombstones are loaded.
+	restarted := testDatabaseShard(t, opts)
+	defer restarted.Close()
+	_, err = restarted.Write(ctx, id, start.Add(time.Second), 3, xtime.Second, nil, series.WriteOptions{})
+	require.NoError(t, err)
+	require.NoError(t, restarted.tombstones.load())
+	require.True(t, restarted.HasDeletedSeries())
+
+	iter, err = restarted.ReadEncoded(ctx, id, start, start.Add(blockSize), namespace.Context{})
+	require.NoError(t, err)
+	blocks, err = iter.ToSlices(ctx)
+	require.NoError(t, err)
+	require.Len(t, blocks, 1)
+	require.Equal(t, []float64{3}, values(blocks[0]))
+}
+
 func TestShardReadEncodedCachesSeriesWithRecentlyReadPolicy(t *testing.T) {
 	dir, err := ioutil.TempDir("", "testdir")
 	require.NoError(t, err)
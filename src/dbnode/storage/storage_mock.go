@@ -205,6 +205,21 @@ func (mr *MockDatabaseMockRecorder) BootstrapState() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrapState", reflect.TypeOf((*MockDatabase)(nil).BootstrapState))
 }
 
+// Cardinality mocks base method.
+func (m *MockDatabase) Cardinality(ctx context.Context, namespace ident.ID, opts index.CardinalityOptions) (index.CardinalityResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cardinality", ctx, namespace, opts)
+	ret0, _ := ret[0].(index.CardinalityResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cardinality indicates an expected call of Cardinality.
+func (mr *MockDatabaseMockRecorder) Cardinality(ctx, namespace, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cardinality", reflect.TypeOf((*MockDatabase)(nil).Cardinality), ctx, namespace, opts)
+}
+
 // Close mocks base method.
 func (m *MockDatabase) Close() error {
 	m.ctrl.T.Helper()
@@ -219,6 +234,21 @@ func (mr *MockDatabaseMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockDatabase)(nil).Close))
 }
 
+// DeleteSeries indicates an expected call of DeleteSeries.
+func (mr *MockDatabaseMockRecorder) DeleteSeries(ctx, namespace, query, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSeries", reflect.TypeOf((*MockDatabase)(nil).DeleteSeries), ctx, namespace, query, start, end)
+}
+
+// DeleteSeries mocks base method.
+func (m *MockDatabase) DeleteSeries(ctx context.Context, namespace ident.ID, query index.Query, start, end time0.UnixNano) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSeries", ctx, namespace, query, start, end)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // FetchBlocks mocks base method.
 func (m *MockDatabase) FetchBlocks(ctx context.Context, namespace ident.ID, shard uint32, id ident.ID, starts []time0.UnixNano) ([]block.FetchBlockResult, error) {
 	m.ctrl.T.Helper()
@@ -615,6 +645,21 @@ func (mr *MockdatabaseMockRecorder) BootstrapState() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrapState", reflect.TypeOf((*Mockdatabase)(nil).BootstrapState))
 }
 
+// Cardinality mocks base method.
+func (m *Mockdatabase) Cardinality(ctx context.Context, namespace ident.ID, opts index.CardinalityOptions) (index.CardinalityResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cardinality", ctx, namespace, opts)
+	ret0, _ := ret[0].(index.CardinalityResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cardinality indicates an expected call of Cardinality.
+func (mr *MockdatabaseMockRecorder) Cardinality(ctx, namespace, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cardinality", reflect.TypeOf((*Mockdatabase)(nil).Cardinality), ctx, namespace, opts)
+}
+
 // Close mocks base method.
 func (m *Mockdatabase) Close() error {
 	m.ctrl.T.Helper()
@@ -629,6 +674,21 @@ func (mr *MockdatabaseMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*Mockdatabase)(nil).Close))
 }
 
+// DeleteSeries indicates an expected call of DeleteSeries.
+func (mr *MockdatabaseMockRecorder) DeleteSeries(ctx, namespace, query, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSeries", reflect.TypeOf((*Mockdatabase)(nil).DeleteSeries), ctx, namespace, query, start, end)
+}
+
+// DeleteSeries mocks base method.
+func (m *Mockdatabase) DeleteSeries(ctx context.Context, namespace ident.ID, query index.Query, start, end time0.UnixNano) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSeries", ctx, namespace, query, start, end)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // FetchBlocks mocks base method.
 func (m *Mockdatabase) FetchBlocks(ctx context.Context, namespace ident.ID, shard uint32, id ident.ID, starts []time0.UnixNano) ([]block.FetchBlockResult, error) {
 	m.ctrl.T.Helper()
@@ -1247,6 +1307,21 @@ func (mr *MockdatabaseNamespaceMockRecorder) BootstrapState() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrapState", reflect.TypeOf((*MockdatabaseNamespace)(nil).BootstrapState))
 }
 
+// Cardinality mocks base method.
+func (m *MockdatabaseNamespace) Cardinality(ctx context.Context, opts index.CardinalityOptions) (index.CardinalityResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cardinality", ctx, opts)
+	ret0, _ := ret[0].(index.CardinalityResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cardinality indicates an expected call of Cardinality.
+func (mr *MockdatabaseNamespaceMockRecorder) Cardinality(ctx, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cardinality", reflect.TypeOf((*MockdatabaseNamespace)(nil).Cardinality), ctx, opts)
+}
+
 // Close mocks base method.
 func (m *MockdatabaseNamespace) Close() error {
 	m.ctrl.T.Helper()
@@ -1275,6 +1350,21 @@ func (mr *MockdatabaseNamespaceMockRecorder) ColdFlush(flush interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ColdFlush", reflect.TypeOf((*MockdatabaseNamespace)(nil).ColdFlush), flush)
 }
 
+// DeleteSeries indicates an expected call of DeleteSeries.
+func (mr *MockdatabaseNamespaceMockRecorder) DeleteSeries(ctx, query, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSeries", reflect.TypeOf((*MockdatabaseNamespace)(nil).DeleteSeries), ctx, query, start, end)
+}
+
+// DeleteSeries mocks base method.
+func (m *MockdatabaseNamespace) DeleteSeries(ctx context.Context, query index.Query, start, end time0.UnixNano) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSeries", ctx, query, start, end)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // DocRef mocks base method.
 func (m *MockdatabaseNamespace) DocRef(id ident.ID) (doc.Metadata, bool, error) {
 	m.ctrl.T.Helper()
@@ -2014,6 +2104,34 @@ func (mr *MockdatabaseShardMockRecorder) ColdFlush(flush, resources, nsCtx, onFl
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ColdFlush", reflect.TypeOf((*MockdatabaseShard)(nil).ColdFlush), flush, resources, nsCtx, onFlush)
 }
 
+// DeletedRanges indicates an expected call of DeletedRanges.
+func (mr *MockdatabaseShardMockRecorder) DeletedRanges(id, blockStart interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletedRanges", reflect.TypeOf((*MockdatabaseShard)(nil).DeletedRanges), id, blockStart)
+}
+
+// DeletedRanges mocks base method.
+func (m *MockdatabaseShard) DeletedRanges(id ident.ID, blockStart time0.UnixNano) time0.Ranges {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletedRanges", id, blockStart)
+	ret0, _ := ret[0].(time0.Ranges)
+	return ret0
+}
+
+// DeleteSeries indicates an expected call of DeleteSeries.
+func (mr *MockdatabaseShardMockRecorder) DeleteSeries(ids, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSeries", reflect.TypeOf((*MockdatabaseShard)(nil).DeleteSeries), ids, start, end)
+}
+
+// DeleteSeries mocks base method.
+func (m *MockdatabaseShard) DeleteSeries(ids []ident.ID, start, end time0.UnixNano) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSeries", ids, start, end)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
 // DocRef mocks base method.
 func (m *MockdatabaseShard) DocRef(id ident.ID) (doc.Metadata, bool, error) {
 	m.ctrl.T.Helper()
@@ -2105,6 +2223,20 @@ func (mr *MockdatabaseShardMockRecorder) FlushState(blockStart interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlushState", reflect.TypeOf((*MockdatabaseShard)(nil).FlushState), blockStart)
 }
 
+// HasDeletedSeries indicates an expected call of HasDeletedSeries.
+func (mr *MockdatabaseShardMockRecorder) HasDeletedSeries() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasDeletedSeries", reflect.TypeOf((*MockdatabaseShard)(nil).HasDeletedSeries))
+}
+
+// HasDeletedSeries mocks base method.
+func (m *MockdatabaseShard) HasDeletedSeries() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasDeletedSeries")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
 // ID mocks base method.
 func (m *MockdatabaseShard) ID() uint32 {
 	m.ctrl.T.Helper()
@@ -2532,6 +2664,21 @@ func (mr *MockNamespaceIndexMockRecorder) Bootstrapped() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bootstrapped", reflect.TypeOf((*MockNamespaceIndex)(nil).Bootstrapped))
 }
 
+// Cardinality mocks base method.
+func (m *MockNamespaceIndex) Cardinality(ctx context.Context, opts index.CardinalityOptions) (index.CardinalityResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cardinality", ctx, opts)
+	ret0, _ := ret[0].(index.CardinalityResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cardinality indicates an expected call of Cardinality.
+func (mr *MockNamespaceIndexMockRecorder) Cardinality(ctx, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cardinality", reflect.TypeOf((*MockNamespaceIndex)(nil).Cardinality), ctx, opts)
+}
+
 // CleanupCorruptedFileSets mocks base method.
 func (m *MockNamespaceIndex) CleanupCorruptedFileSets() error {
 	m.ctrl.T.Helper()
@@ -4168,6 +4315,20 @@ func (mr *MockOptionsMockRecorder) FetchBlocksMetadataResultsPool() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBlocksMetadataResultsPool", reflect.TypeOf((*MockOptions)(nil).FetchBlocksMetadataResultsPool))
 }
 
+// FileSetReblocker mocks base method.
+func (m *MockOptions) FileSetReblocker() FileSetReblocker {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FileSetReblocker")
+	ret0, _ := ret[0].(FileSetReblocker)
+	return ret0
+}
+
+// FileSetReblocker indicates an expected call of FileSetReblocker.
+func (mr *MockOptionsMockRecorder) FileSetReblocker() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FileSetReblocker", reflect.TypeOf((*MockOptions)(nil).FileSetReblocker))
+}
+
 // ForceColdWritesEnabled mocks base method.
 func (m *MockOptions) ForceColdWritesEnabled() bool {
 	m.ctrl.T.Helper()
@@ -4840,6 +5001,20 @@ func (mr *MockOptionsMockRecorder) SetFetchBlocksMetadataResultsPool(value inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFetchBlocksMetadataResultsPool", reflect.TypeOf((*MockOptions)(nil).SetFetchBlocksMetadataResultsPool), value)
 }
 
+// SetFileSetReblocker mocks base method.
+func (m *MockOptions) SetFileSetReblocker(value FileSetReblocker) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetFileSetReblocker", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetFileSetReblocker indicates an expected call of SetFileSetReblocker.
+func (mr *MockOptionsMockRecorder) SetFileSetReblocker(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFileSetReblocker", reflect.TypeOf((*MockOptions)(nil).SetFileSetReblocker), value)
+}
+
 // SetForceColdWritesEnabled mocks base method.
 func (m *MockOptions) SetForceColdWritesEnabled(value bool) Options {
 	m.ctrl.T.Helper()
@@ -5484,6 +5659,44 @@ func (mr *MockTileAggregatorMockRecorder) AggregateTiles(ctx, sourceNs, targetNs
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AggregateTiles", reflect.TypeOf((*MockTileAggregator)(nil).AggregateTiles), ctx, sourceNs, targetNs, shardID, onFlushSeries, opts)
 }
 
+// MockFileSetReblocker is a mock of FileSetReblocker interface.
+type MockFileSetReblocker struct {
+	ctrl     *gomock.Controller
+	recorder *MockFileSetReblockerMockRecorder
+}
+
+// MockFileSetReblockerMockRecorder is the mock recorder for MockFileSetReblocker.
+type MockFileSetReblockerMockRecorder struct {
+	mock *MockFileSetReblocker
+}
+
+// NewMockFileSetReblocker creates a new mock instance.
+func NewMockFileSetReblocker(ctrl *gomock.Controller) *MockFileSetReblocker {
+	mock := &MockFileSetReblocker{ctrl: ctrl}
+	mock.recorder = &MockFileSetReblockerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFileSetReblocker) EXPECT() *MockFileSetReblockerMockRecorder {
+	return m.recorder
+}
+
+// Reblock mocks base method.
+func (m *MockFileSetReblocker) Reblock(opts Options, md namespace.Metadata, shard uint32, blockStart time0.UnixNano, volume int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reblock", opts, md, shard, blockStart, volume)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reblock indicates an expected call of Reblock.
+func (mr *MockFileSetReblockerMockRecorder) Reblock(opts, md, shard, blockStart, volume interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reblock", reflect.TypeOf((*MockFileSetReblocker)(nil).Reblock), opts, md, shard, blockStart, volume)
+}
+
 // MockNamespaceHooks is a mock of NamespaceHooks interface.
 type MockNamespaceHooks struct {
 	ctrl     *gomock.Controller
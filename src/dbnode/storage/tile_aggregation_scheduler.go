@@ -0,0 +1,381 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/retention"
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/context"
+	"github.com/m3db/m3/src/x/ident"
+	"github.com/m3db/m3/src/x/instrument"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+const defaultTileAggregationCheckInterval = time.Minute
+
+var (
+	errTileAggregationNoRules           = errors.New("tile aggregation requires at least one rule")
+	errTileAggregationSameNamespace     = errors.New("tile aggregation source and target namespaces must differ")
+	errTileAggregationInProgress        = errors.New("tile aggregation already in progress")
+	errTileAggregationNamespaceNotFound = errors.New("tile aggregation namespace not found")
+)
+
+// TileAggregationRule describes tiles aggregated automatically from the
+// blocks of a source namespace into a target namespace once they are flushed.
+type TileAggregationRule struct {
+	// SourceNamespace is the namespace the datapoints are read from.
+	SourceNamespace ident.ID
+	// TargetNamespace is the namespace the tiles are written to.
+	TargetNamespace ident.ID
+	// Step is the size of the tiles.
+	Step time.Duration
+	// Aggregation is the aggregation applied to the datapoints of each tile.
+	Aggregation TileAggregation
+}
+
+// Validate validates the rule.
+func (r TileAggregationRule) Validate() error {
+	if r.SourceNamespace == nil || r.TargetNamespace == nil {
+		return errors.New("tile aggregation source and target namespaces must be set")
+	}
+	if r.SourceNamespace.Equal(r.TargetNamespace) {
+		return errTileAggregationSameNamespace
+	}
+	if r.Step <= 0 {
+		return errTileAggregationStepNotPositive
+	}
+	return nil
+}
+
+type tileAggregationScheduler struct {
+	database      Database
+	rules         []TileAggregationRule
+	checkInterval time.Duration
+	nowFn         clock.NowFn
+	fsOpts        fs.Options
+	iOpts         instrument.Options
+	logger        *zap.Logger
+
+	// aggregatedUntil holds for each rule the end of the last target block
+	// aggregated, it is loaded from the persisted progress on first use.
+	aggregatedUntil []xtime.UnixNano
+	progressLoaded  []bool
+	running         int32
+	closeCh         chan struct{}
+	closeOnce       sync.Once
+
+	status           tally.Gauge
+	aggregatedBlocks tally.Counter
+	errors           tally.Counter
+}
+
+// NewTileAggregationSchedulerFn returns a NewBackgroundProcessFn creating a
+// process that aggregates the tiles of each rule as soon as the blocks of the
+// source namespace are flushed by all shards. A check interval of zero uses
+// the default interval.
+func NewTileAggregationSchedulerFn(
+	rules []TileAggregationRule,
+	checkInterval time.Duration,
+) NewBackgroundProcessFn {
+	return func(database Database, opts Options) (BackgroundProcess, error) {
+		return newTileAggregationScheduler(database, opts, rules, checkInterval)
+	}
+}
+
+func newTileAggregationScheduler(
+	database Database,
+	opts Options,
+	rules []TileAggregationRule,
+	checkInterval time.Duration,
+) (*tileAggregationScheduler, error) {
+	if len(rules) == 0 {
+		return nil, errTileAggregationNoRules
+	}
+	for _, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if checkInterval <= 0 {
+		checkInterval = defaultTileAggregationCheckInterval
+	}
+
+	iOpts := opts.InstrumentOptions()
+	scope := iOpts.MetricsScope().SubScope("tile-aggregation-scheduler")
+	return &tileAggregationScheduler{
+		database:         database,
+		rules:            rules,
+		checkInterval:    checkInterval,
+		nowFn:            opts.ClockOptions().NowFn(),
+		fsOpts:           opts.CommitLogOptions().FilesystemOptions(),
+		iOpts:            iOpts,
+		logger:           iOpts.Logger(),
+		aggregatedUntil:  make([]xtime.UnixNano, len(rules)),
+		progressLoaded:   make([]bool, len(rules)),
+		closeCh:          make(chan struct{}),
+		status:           scope.Gauge("running"),
+		aggregatedBlocks: scope.Counter("aggregated-blocks"),
+		errors:           scope.Counter("errors"),
+	}, nil
+}
+
+func (s *tileAggregationScheduler) Start() {
+	go s.run()
+}
+
+func (s *tileAggregationScheduler) Stop() {
+	s.closeOnce.Do(func() { close(s.closeCh) })
+}
+
+func (s *tileAggregationScheduler) Report() {
+	if atomic.LoadInt32(&s.running) == 1 {
+		s.status.Update(1)
+	} else {
+		s.status.Update(0)
+	}
+}
+
+func (s *tileAggregationScheduler) run() {
+	ticker := time.NewTicker(s.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.closeCh:
+			return
+		case <-ticker.C:
+			if err := s.AggregateTiles(); err != nil {
+				s.logger.Error("error aggregating tiles", zap.Error(err))
+			}
+		}
+	}
+}
+
+// AggregateTiles aggregates the tiles of the target blocks of all rules whose
+// source blocks have all been flushed and that have not been aggregated yet.
+func (s *tileAggregationScheduler) AggregateTiles() error {
+	if !s.database.IsBootstrapped() {
+		return nil
+	}
+
+	if !atomic.CompareAndSwapInt32(&s.running, 0, 1) {
+		return errTileAggregationInProgress
+	}
+	defer atomic.StoreInt32(&s.running, 0)
+
+	now := xtime.ToUnixNano(s.nowFn())
+	var lastErr error
+	for idx, rule := range s.rules {
+		if err := s.aggregateRule(idx, rule, now); err != nil {
+			s.errors.Inc(1)
+			s.logger.Error("error aggregating tiles of rule",
+				zap.Stringer("sourceNs", rule.SourceNamespace),
+				zap.Stringer("targetNs", rule.TargetNamespace),
+				zap.Error(err))
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+func (s *tileAggregationScheduler) aggregateRule(
+	idx int,
+	rule TileAggregationRule,
+	now xtime.UnixNano,
+) error {
+	sourceNs, ok := s.database.Namespace(rule.SourceNamespace)
+	if !ok {
+		return fmt.Errorf("%w: %s", errTileAggregationNamespaceNotFound, rule.SourceNamespace)
+	}
+	targetNs, ok := s.database.Namespace(rule.TargetNamespace)
+	if !ok {
+		return fmt.Errorf("%w: %s", errTileAggregationNamespaceNotFound, rule.TargetNamespace)
+	}
+
+	var (
+		sourceRopts     = sourceNs.Options().RetentionOptions()
+		sourceBlockSize = sourceRopts.BlockSize()
+		targetRopts     = targetNs.Options().RetentionOptions()
+		targetBlockSize = targetRopts.BlockSize()
+	)
+	if targetBlockSize%sourceBlockSize != 0 {
+		return fmt.Errorf("target namespace block size %s must be a multiple of source namespace block size %s",
+			targetBlockSize, sourceBlockSize)
+	}
+	if targetBlockSize%rule.Step != 0 {
+		return fmt.Errorf("target namespace block size %s must be a multiple of tile step %s",
+			targetBlockSize, rule.Step)
+	}
+
+	aggregatedUntil, err := s.progress(idx, rule)
+	if err != nil {
+		return err
+	}
+
+	// Target blocks are aggregated once and in order, starting after the
+	// persisted progress, or from the previous target block if nothing has
+	// been aggregated yet, and no earlier than both retentions allow.
+	earliest := aggregatedUntil
+	if earliest == 0 {
+		earliest = now.Truncate(targetBlockSize).Add(-targetBlockSize)
+	}
+	for _, ropts := range []retention.Options{sourceRopts, targetRopts} {
+		if start := retention.FlushTimeStart(ropts, now); earliest.Before(start) {
+			earliest = start
+		}
+	}
+	if aligned := earliest.Truncate(targetBlockSize); aligned != earliest {
+		earliest = aligned.Add(targetBlockSize)
+	}
+	latest := retention.FlushTimeEndForBlockSize(sourceBlockSize, now.Add(-sourceRopts.BufferPast()))
+
+	for blockStart := earliest; ; blockStart = blockStart.Add(targetBlockSize) {
+		blockEnd := blockStart.Add(targetBlockSize)
+		if blockEnd.Add(-sourceBlockSize).After(latest) {
+			return nil
+		}
+
+		flushed, err := s.sourceBlocksFlushed(sourceNs, blockStart, blockEnd, sourceBlockSize)
+		if err != nil {
+			return err
+		}
+		if !flushed {
+			return nil
+		}
+
+		if err := s.aggregateBlock(rule, blockStart, blockEnd); err != nil {
+			return err
+		}
+		if err := s.setProgress(idx, rule, blockEnd); err != nil {
+			return err
+		}
+	}
+}
+
+// progress returns the end of the last target block aggregated by the rule.
+func (s *tileAggregationScheduler) progress(idx int, rule TileAggregationRule) (xtime.UnixNano, error) {
+	if !s.progressLoaded[idx] {
+		progress, err := fs.ReadTileAggregationProgress(s.fsOpts,
+			rule.SourceNamespace, rule.TargetNamespace, rule.Step)
+		if err != nil {
+			return 0, err
+		}
+		s.aggregatedUntil[idx] = progress.AggregatedUntil
+		s.progressLoaded[idx] = true
+	}
+	return s.aggregatedUntil[idx], nil
+}
+
+func (s *tileAggregationScheduler) setProgress(
+	idx int,
+	rule TileAggregationRule,
+	aggregatedUntil xtime.UnixNano,
+) error {
+	if err := fs.WriteTileAggregationProgress(s.fsOpts, rule.SourceNamespace, rule.TargetNamespace,
+		rule.Step, fs.TileAggregationProgress{AggregatedUntil: aggregatedUntil}); err != nil {
+		return err
+	}
+	s.aggregatedUntil[idx] = aggregatedUntil
+	return nil
+}
+
+func (s *tileAggregationScheduler) aggregateBlock(
+	rule TileAggregationRule,
+	blockStart, blockEnd xtime.UnixNano,
+) error {
+	opts, err := NewAggregateTilesOptions(blockStart, blockEnd, rule.Step,
+		rule.TargetNamespace, AggregateTilesRegular, false, false, nil, s.iOpts)
+	if err != nil {
+		return err
+	}
+	opts.Aggregation = rule.Aggregation
+
+	ctx := context.NewBackground()
+	defer ctx.Close()
+
+	processedTileCount, err := s.database.AggregateTiles(ctx, rule.SourceNamespace, rule.TargetNamespace, opts)
+	if err != nil {
+		return err
+	}
+
+	s.aggregatedBlocks.Inc(1)
+	s.logger.Info("aggregated tiles of flushed blocks",
+		zap.Stringer("sourceNs", rule.SourceNamespace),
+		zap.Stringer("targetNs", rule.TargetNamespace),
+		zap.Time("blockStart", blockStart.ToTime()),
+		zap.Int64("processedTiles", processedTileCount))
+	return nil
+}
+
+// sourceBlocksFlushed returns true if all source blocks between start and end
+// have been flushed.
+func (s *tileAggregationScheduler) sourceBlocksFlushed(
+	ns Namespace,
+	start, end xtime.UnixNano,
+	blockSize time.Duration,
+) (bool, error) {
+	for blockStart := start; blockStart.Before(end); blockStart = blockStart.Add(blockSize) {
+		flushed, err := s.sourceBlockFlushed(ns, blockStart)
+		if err != nil || !flushed {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// sourceBlockFlushed returns true if all shards of the namespace are
+// bootstrapped and have flushed the block.
+func (s *tileAggregationScheduler) sourceBlockFlushed(ns Namespace, blockStart xtime.UnixNano) (bool, error) {
+	shards := ns.Shards()
+	if len(shards) == 0 {
+		return false, nil
+	}
+
+	for _, shard := range shards {
+		if !shard.IsBootstrapped() {
+			return false, nil
+		}
+
+		readable, _, err := ns.ReadableShardAt(shard.ID())
+		if err != nil {
+			return false, err
+		}
+		state, err := readable.FlushState(blockStart)
+		if err != nil {
+			return false, err
+		}
+		if state.WarmStatus.DataFlushed != fileOpSuccess {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/retention"
+	"github.com/m3db/m3/src/x/ident"
+	xtest "github.com/m3db/m3/src/x/test"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+func TestTileAggregationSchedulerInvalidRules(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	db := NewMockDatabase(ctrl)
+	opts := DefaultTestOptions()
+
+	_, err := NewTileAggregationSchedulerFn(nil, 0)(db, opts)
+	require.Equal(t, errTileAggregationNoRules, err)
+
+	_, err = NewTileAggregationSchedulerFn([]TileAggregationRule{{
+		SourceNamespace: ident.StringID("foo"),
+		TargetNamespace: ident.StringID("foo"),
+		Step:            time.Minute,
+	}}, 0)(db, opts)
+	require.Equal(t, errTileAggregationSameNamespace, err)
+
+	_, err = NewTileAggregationSchedulerFn([]TileAggregationRule{{
+		SourceNamespace: ident.StringID("foo"),
+		TargetNamespace: ident.StringID("bar"),
+	}}, 0)(db, opts)
+	require.Equal(t, errTileAggregationStepNotPositive, err)
+}
+
+func TestTileAggregationSchedulerAggregatesFlushedBlocks(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	dir, err := ioutil.TempDir("", "tile-aggregation-scheduler")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var (
+		sourceNsID = ident.StringID("source")
+		targetNsID = ident.StringID("target")
+		start      = xtime.Now().Truncate(2 * time.Hour).Add(-4 * time.Hour)
+		now        = start.Add(2*time.Hour + 30*time.Minute)
+		flushed    = map[xtime.UnixNano]bool{start: true}
+	)
+
+	sourceNs := NewMockNamespace(ctrl)
+	sourceNs.EXPECT().Options().Return(namespace.NewOptions().SetRetentionOptions(
+		retention.NewOptions().SetBlockSize(time.Hour).SetBufferPast(10 * time.Minute))).AnyTimes()
+	targetNs := NewMockNamespace(ctrl)
+	targetNs.EXPECT().Options().Return(namespace.NewOptions().SetRetentionOptions(
+		retention.NewOptions().SetBlockSize(2 * time.Hour))).AnyTimes()
+
+	shard := NewMockShard(ctrl)
+	shard.EXPECT().ID().Return(uint32(0)).AnyTimes()
+	shard.EXPECT().IsBootstrapped().Return(true).AnyTimes()
+	sourceNs.EXPECT().Shards().Return([]Shard{shard}).AnyTimes()
+
+	readableShard := NewMockdatabaseShard(ctrl)
+	readableShard.EXPECT().FlushState(gomock.Any()).DoAndReturn(
+		func(blockStart xtime.UnixNano) (fileOpState, error) {
+			var state fileOpState
+			if flushed[blockStart] {
+				state.WarmStatus.DataFlushed = fileOpSuccess
+			}
+			return state, nil
+		}).AnyTimes()
+	sourceNs.EXPECT().ReadableShardAt(uint32(0)).
+		Return(readableShard, namespace.Context{}, nil).AnyTimes()
+
+	db := NewMockDatabase(ctrl)
+	db.EXPECT().IsBootstrapped().Return(true).AnyTimes()
+	db.EXPECT().Namespace(sourceNsID).Return(sourceNs, true).AnyTimes()
+	db.EXPECT().Namespace(targetNsID).Return(targetNs, true).AnyTimes()
+
+	opts := DefaultTestOptions()
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(func() time.Time {
+		return now.ToTime()
+	}))
+	opts = opts.SetCommitLogOptions(opts.CommitLogOptions().SetFilesystemOptions(
+		opts.CommitLogOptions().FilesystemOptions().SetFilePathPrefix(dir)))
+
+	rules := []TileAggregationRule{{
+		SourceNamespace: sourceNsID,
+		TargetNamespace: targetNsID,
+		Step:            time.Minute,
+		Aggregation:     TileAggregationMax,
+	}}
+	process, err := NewTileAggregationSchedulerFn(rules, 0)(db, opts)
+	require.NoError(t, err)
+	scheduler := process.(*tileAggregationScheduler)
+
+	var aggregated []xtime.UnixNano
+	db.EXPECT().AggregateTiles(gomock.Any(), sourceNsID, targetNsID, gomock.Any()).DoAndReturn(
+		func(_ interface{}, _, _ ident.ID, opts AggregateTilesOptions) (int64, error) {
+			assert.Equal(t, opts.Start.Add(2*time.Hour), opts.End)
+			assert.Equal(t, time.Minute, opts.Step)
+			assert.Equal(t, TileAggregationMax, opts.Aggregation)
+			aggregated = append(aggregated, opts.Start)
+			return 1, nil
+		}).Times(1)
+
+	// The target block is only aggregated once all its source blocks are
+	// flushed, and only once.
+	require.NoError(t, scheduler.AggregateTiles())
+	assert.Empty(t, aggregated)
+
+	flushed[start.Add(time.Hour)] = true
+	require.NoError(t, scheduler.AggregateTiles())
+	assert.Equal(t, []xtime.UnixNano{start}, aggregated)
+
+	require.NoError(t, scheduler.AggregateTiles())
+	assert.Equal(t, []xtime.UnixNano{start}, aggregated)
+
+	// The progress is persisted so that target blocks are not aggregated
+	// again after a restart.
+	progress, err := fs.ReadTileAggregationProgress(opts.CommitLogOptions().FilesystemOptions(),
+		sourceNsID, targetNsID, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, start.Add(2*time.Hour), progress.AggregatedUntil)
+
+	process, err = NewTileAggregationSchedulerFn(rules, 0)(db, opts)
+	require.NoError(t, err)
+	require.NoError(t, process.(*tileAggregationScheduler).AggregateTiles())
+	assert.Equal(t, []xtime.UnixNano{start}, aggregated)
+}
@@ -0,0 +1,571 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/m3db/m3/src/dbnode/generated/proto/annotation"
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3/src/x/context"
+	"github.com/m3db/m3/src/x/ident"
+	"github.com/m3db/m3/src/x/instrument"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+var errTileAggregationStepNotPositive = errors.New("tile aggregation step must be positive")
+
+type tileAggregator struct {
+	iOpts instrument.Options
+}
+
+// NewTileAggregator creates a TileAggregator that reads the flushed filesets
+// of the source namespace and writes their datapoints aggregated into tiles
+// to a new volume of the target namespace block. The aggregation window is
+// clamped to the target block that contains its start, and datapoints of the
+// previous volume of the target block outside of the window are kept.
+func NewTileAggregator(iOpts instrument.Options) TileAggregator {
+	return &tileAggregator{iOpts: iOpts}
+}
+
+func (a *tileAggregator) AggregateTiles(
+	ctx context.Context,
+	sourceNs, targetNs Namespace,
+	shardID uint32,
+	onFlushSeries persist.OnFlushSeries,
+	opts AggregateTilesOptions,
+) (int64, int, error) {
+	if opts.Step <= 0 {
+		return 0, 0, errTileAggregationStepNotPositive
+	}
+
+	sourceShard, _, err := sourceNs.ReadableShardAt(shardID)
+	if err != nil {
+		return 0, 0, err
+	}
+	targetShard, _, err := targetNs.ReadableShardAt(shardID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var (
+		sourceBlockSize  = sourceNs.Options().RetentionOptions().BlockSize()
+		targetBlockSize  = targetNs.Options().RetentionOptions().BlockSize()
+		targetBlockStart = opts.Start.Truncate(targetBlockSize)
+		targetBlockEnd   = targetBlockStart.Add(targetBlockSize)
+		sourceFsOpts     = sourceNs.StorageOptions().CommitLogOptions().FilesystemOptions()
+		targetFsOpts     = targetNs.StorageOptions().CommitLogOptions().FilesystemOptions()
+		sources          []*tileSource
+	)
+	defer func() {
+		for _, source := range sources {
+			_ = source.reader.Close()
+		}
+	}()
+
+	// NB: tiles are only ever written to the target block, so datapoints past
+	// its end are left for the aggregation of the next target block.
+	if opts.End.After(targetBlockEnd) {
+		opts.End = targetBlockEnd
+	}
+
+	for blockStart := opts.Start.Truncate(sourceBlockSize); blockStart.Before(opts.End); blockStart = blockStart.Add(sourceBlockSize) {
+		source, ok, err := openTileSource(sourceShard, sourceNs.ID(), sourceFsOpts, blockStart)
+		if err != nil {
+			return 0, 0, err
+		}
+		if !ok {
+			a.iOpts.Logger().Debug("no fileset to aggregate tiles from",
+				zap.Stringer("sourceNs", sourceNs.ID()),
+				zap.Uint32("shard", shardID),
+				zap.Time("blockStart", blockStart.ToTime()))
+			continue
+		}
+		sources = append(sources, source)
+	}
+
+	// The previous volume of the target block is merged into the new volume so
+	// that tiles outside of the aggregation window are not lost.
+	nextVolume, err := targetShard.LatestVolume(targetBlockStart)
+	if err != nil {
+		return 0, 0, err
+	}
+	previous, ok, err := openTileSource(targetShard, targetNs.ID(), targetFsOpts, targetBlockStart)
+	if err != nil {
+		return 0, 0, err
+	}
+	if ok {
+		previous.previousVolume = true
+		sources = append(sources, previous)
+		nextVolume++
+	}
+
+	// NB: an empty volume is written if there is nothing to aggregate so that
+	// the target block is still readable.
+	plannedRecordsCount := 1
+	for _, source := range sources {
+		plannedRecordsCount += source.reader.Entries()
+	}
+
+	writer, err := fs.NewStreamingWriter(targetFsOpts)
+	if err != nil {
+		return 0, 0, err
+	}
+	if err := writer.Open(fs.StreamingWriterOpenOptions{
+		NamespaceID:         targetNs.ID(),
+		ShardID:             shardID,
+		BlockStart:          targetBlockStart,
+		BlockSize:           targetBlockSize,
+		VolumeIndex:         nextVolume,
+		Compression:         targetNs.Options().FileSetCompression(),
+		PlannedRecordsCount: uint(plannedRecordsCount),
+	}); err != nil {
+		return 0, 0, err
+	}
+
+	processedTileCount, err := a.aggregateSources(sources, sourceNs, targetNs,
+		shardID, targetBlockStart, writer, onFlushSeries, opts)
+	if err != nil {
+		_ = writer.Abort()
+		return 0, 0, err
+	}
+
+	if err := writer.Close(); err != nil {
+		return 0, 0, err
+	}
+
+	return processedTileCount, nextVolume, nil
+}
+
+func (a *tileAggregator) aggregateSources(
+	sources []*tileSource,
+	sourceNs, targetNs Namespace,
+	shardID uint32,
+	targetBlockStart xtime.UnixNano,
+	writer fs.StreamingWriter,
+	onFlushSeries persist.OnFlushSeries,
+	opts AggregateTilesOptions,
+) (int64, error) {
+	for _, source := range sources {
+		if err := source.next(); err != nil {
+			return 0, err
+		}
+	}
+
+	var (
+		scope         = opts.InsOptions.MetricsScope()
+		writtenSeries = scope.Counter("tile-aggregator-written-series")
+
+		sourceOpts  = sourceNs.StorageOptions()
+		targetOpts  = targetNs.StorageOptions()
+		decoder     = newTileDecoder(sourceOpts, sourceNs.Schema())
+		prevDecoder = newTileDecoder(targetOpts, targetNs.Schema())
+		unit        = tileUnit(opts.Step)
+
+		processedTileCount int64
+		series             []*tileSource
+		datapoints         []tileDatapoint
+		previous           []tileDatapoint
+	)
+
+	for {
+		// Sources are ordered by ID, so series are merged by picking the
+		// smallest ID among the current entries of all sources.
+		var id []byte
+		for _, source := range sources {
+			if source.done {
+				continue
+			}
+			if id == nil || bytes.Compare(source.entry.ID, id) < 0 {
+				id = source.entry.ID
+			}
+		}
+		if id == nil {
+			return processedTileCount, nil
+		}
+		id = append([]byte(nil), id...)
+
+		series = series[:0]
+		for _, source := range sources {
+			if !source.done && bytes.Equal(source.entry.ID, id) {
+				series = append(series, source)
+			}
+		}
+
+		var (
+			encodedTags = append([]byte(nil), series[0].entry.EncodedTags...)
+			acc         = newTileAccumulator(opts.Start, opts.Step, opts.Aggregation)
+		)
+		datapoints, previous = datapoints[:0], previous[:0]
+		for _, source := range series {
+			var err error
+			if source.previousVolume {
+				previous, err = prevDecoder.decode(source.entry.Data, previous)
+			} else {
+				datapoints, err = decoder.decode(source.entry.Data, datapoints)
+			}
+			if err != nil {
+				return 0, err
+			}
+		}
+
+		payload := seriesPayload(datapoints)
+		seriesName := string(id)
+		if payload == nil && opts.BackfillMetricTypes {
+			if memorized, ok := opts.MetricTypeByName[seriesName]; ok {
+				payload = &memorized
+			}
+		}
+		if payload != nil && opts.MemorizeMetricTypes {
+			opts.MetricTypeByName[seriesName] = *payload
+		}
+		acc.counter = payload != nil &&
+			payload.SourceFormat == annotation.SourceFormat_OPEN_METRICS &&
+			payload.OpenMetricsFamilyType == annotation.OpenMetricsFamilyType_COUNTER
+
+		for _, dp := range datapoints {
+			if dp.TimestampNanos.Before(opts.Start) || !dp.TimestampNanos.Before(opts.End) {
+				continue
+			}
+			acc.add(dp.Datapoint)
+		}
+		tiles := acc.finish()
+		processedTileCount += int64(len(tiles))
+
+		var annotationBytes ts.Annotation
+		if payload != nil && len(tiles) > 0 {
+			var err error
+			if annotationBytes, err = payload.Marshal(); err != nil {
+				return 0, err
+			}
+		}
+
+		written, err := a.writeSeries(targetOpts, targetNs.Schema(), targetBlockStart,
+			id, encodedTags, previous, tiles, unit, annotationBytes, opts, writer)
+		if err != nil {
+			return 0, err
+		}
+
+		if written {
+			writtenSeries.Inc(1)
+			if err := onFlushSeries.OnFlushNewSeries(persist.OnFlushNewSeriesEvent{
+				Shard:      shardID,
+				BlockStart: targetBlockStart,
+				FirstWrite: targetBlockStart,
+				SeriesMetadata: persist.SeriesMetadata{
+					Type: persist.SeriesIDAndEncodedTagsType,
+					IDAndEncodedTags: persist.IDAndEncodedTags{
+						ID:          id,
+						EncodedTags: encodedTags,
+					},
+					LifeTime: persist.SeriesLifeTimeShort,
+				},
+			}); err != nil {
+				return 0, err
+			}
+		}
+
+		for _, source := range series {
+			if err := source.next(); err != nil {
+				return 0, err
+			}
+		}
+	}
+}
+
+// writeSeries writes the datapoints of the previous volume outside of the
+// aggregation window and the tiles, and returns false if there was nothing
+// to write.
+func (a *tileAggregator) writeSeries(
+	targetOpts Options,
+	schema namespace.SchemaDescr,
+	blockStart xtime.UnixNano,
+	id, encodedTags []byte,
+	previous []tileDatapoint,
+	tiles []ts.Datapoint,
+	unit xtime.Unit,
+	annotationBytes ts.Annotation,
+	opts AggregateTilesOptions,
+	writer fs.StreamingWriter,
+) (bool, error) {
+	encoder := targetOpts.EncoderPool().Get()
+	encoder.Reset(blockStart, len(previous)+len(tiles), schema)
+
+	var (
+		tileIdx int
+		err     error
+	)
+	encodeTiles := func(before xtime.UnixNano) {
+		for ; err == nil && tileIdx < len(tiles) && tiles[tileIdx].TimestampNanos.Before(before); tileIdx++ {
+			var ann ts.Annotation
+			if tileIdx == 0 {
+				ann = annotationBytes
+			}
+			err = encoder.Encode(tiles[tileIdx], unit, ann)
+		}
+	}
+	for _, dp := range previous {
+		if !dp.TimestampNanos.Before(opts.Start) && dp.TimestampNanos.Before(opts.End) {
+			// Replaced by the tiles of the aggregation window.
+			continue
+		}
+		encodeTiles(dp.TimestampNanos)
+		if err == nil {
+			err = encoder.Encode(dp.Datapoint, dp.unit, dp.annotation)
+		}
+	}
+	encodeTiles(math.MaxInt64)
+	if err != nil {
+		encoder.Close()
+		return false, err
+	}
+
+	if encoder.NumEncoded() == 0 {
+		encoder.Close()
+		return false, nil
+	}
+
+	segment := encoder.Discard()
+	defer segment.Finalize()
+
+	var data [][]byte
+	if segment.Head != nil {
+		data = append(data, segment.Head.Bytes())
+	}
+	if segment.Tail != nil {
+		data = append(data, segment.Tail.Bytes())
+	}
+
+	if err := writer.WriteAll(ident.BytesID(id), encodedTags, data, segment.CalculateChecksum()); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func openTileSource(
+	shard databaseShard,
+	nsID ident.ID,
+	fsOpts fs.Options,
+	blockStart xtime.UnixNano,
+) (*tileSource, bool, error) {
+	volume, err := shard.LatestVolume(blockStart)
+	if err != nil {
+		return nil, false, err
+	}
+
+	exists, err := fs.DataFileSetExists(fsOpts.FilePathPrefix(), nsID, shard.ID(), blockStart, volume)
+	if err != nil || !exists {
+		return nil, false, err
+	}
+
+	reader, err := shard.OpenStreamingReader(blockStart)
+	if err != nil {
+		return nil, false, fmt.Errorf("could not open fileset of namespace %s shard %d block %s: %w",
+			nsID, shard.ID(), blockStart, err)
+	}
+
+	return &tileSource{reader: reader}, true, nil
+}
+
+// tileSource is a fileset read in ID order by the tile aggregator.
+type tileSource struct {
+	reader         fs.DataFileSetReader
+	entry          fs.StreamedDataEntry
+	done           bool
+	previousVolume bool
+}
+
+func (s *tileSource) next() error {
+	if s.done {
+		return nil
+	}
+
+	entry, err := s.reader.StreamingRead()
+	if err == io.EOF {
+		s.done = true
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	s.entry = entry
+	return nil
+}
+
+type tileDatapoint struct {
+	ts.Datapoint
+	unit       xtime.Unit
+	annotation ts.Annotation
+}
+
+type tileDecoder struct {
+	opts   Options
+	schema namespace.SchemaDescr
+}
+
+func newTileDecoder(opts Options, schema namespace.SchemaDescr) tileDecoder {
+	return tileDecoder{opts: opts, schema: schema}
+}
+
+// decode appends the datapoints of the data, annotations are copied since
+// they are only valid until the next datapoint is read.
+func (d tileDecoder) decode(data []byte, datapoints []tileDatapoint) ([]tileDatapoint, error) {
+	iter := d.opts.ReaderIteratorPool().Get()
+	defer iter.Close()
+
+	iter.Reset(xio.NewBytesReader64(data), d.schema)
+	for iter.Next() {
+		dp, unit, ann := iter.Current()
+		var annotationCopy ts.Annotation
+		if len(ann) > 0 {
+			annotationCopy = append(annotationCopy, ann...)
+		}
+		datapoints = append(datapoints, tileDatapoint{
+			Datapoint:  dp,
+			unit:       unit,
+			annotation: annotationCopy,
+		})
+	}
+
+	return datapoints, iter.Err()
+}
+
+// seriesPayload returns the annotation payload of the first annotated
+// datapoint, or nil if no datapoint has a valid annotation.
+func seriesPayload(datapoints []tileDatapoint) *annotation.Payload {
+	for _, dp := range datapoints {
+		if len(dp.annotation) == 0 {
+			continue
+		}
+		var payload annotation.Payload
+		if err := payload.Unmarshal(dp.annotation); err != nil {
+			return nil
+		}
+		return &payload
+	}
+	return nil
+}
+
+func tileUnit(step time.Duration) xtime.Unit {
+	if step%time.Second == 0 {
+		return xtime.Second
+	}
+	return xtime.Millisecond
+}
+
+// tileAccumulator aggregates datapoints in time order into tiles that start
+// at multiples of the step, each tile is written at its start or at the start
+// of the aggregation window if later.
+type tileAccumulator struct {
+	start       xtime.UnixNano
+	step        time.Duration
+	aggregation TileAggregation
+	// counter keeps the last datapoint of each tile, adjusted for counter
+	// resets within the aggregation window so that the increase before a
+	// reset is not lost.
+	counter bool
+
+	tiles       []ts.Datapoint
+	tileStart   xtime.UnixNano
+	count       int
+	value       float64
+	lastRaw     float64
+	hasLastRaw  bool
+	resetOffset float64
+}
+
+func newTileAccumulator(
+	start xtime.UnixNano,
+	step time.Duration,
+	aggregation TileAggregation,
+) *tileAccumulator {
+	return &tileAccumulator{start: start, step: step, aggregation: aggregation}
+}
+
+func (a *tileAccumulator) add(dp ts.Datapoint) {
+	if math.IsNaN(dp.Value) {
+		return
+	}
+
+	tileStart := dp.TimestampNanos.Truncate(a.step)
+	if tileStart.Before(a.start) {
+		tileStart = a.start
+	}
+	if a.count > 0 && tileStart != a.tileStart {
+		a.flush()
+	}
+	a.tileStart = tileStart
+	a.count++
+
+	if a.counter {
+		if a.hasLastRaw && dp.Value < a.lastRaw {
+			a.resetOffset += a.lastRaw
+		}
+		a.lastRaw, a.hasLastRaw = dp.Value, true
+		a.value = dp.Value + a.resetOffset
+		return
+	}
+
+	switch a.aggregation {
+	case TileAggregationSum:
+		if a.count == 1 {
+			a.value = 0
+		}
+		a.value += dp.Value
+	case TileAggregationMin:
+		if a.count == 1 || dp.Value < a.value {
+			a.value = dp.Value
+		}
+	case TileAggregationMax:
+		if a.count == 1 || dp.Value > a.value {
+			a.value = dp.Value
+		}
+	case TileAggregationCount:
+		a.value = float64(a.count)
+	default:
+		a.value = dp.Value
+	}
+}
+
+func (a *tileAccumulator) flush() {
+	a.tiles = append(a.tiles, ts.Datapoint{TimestampNanos: a.tileStart, Value: a.value})
+	a.count = 0
+}
+
+// finish returns the tiles of all the datapoints added.
+func (a *tileAccumulator) finish() []ts.Datapoint {
+	if a.count > 0 {
+		a.flush()
+	}
+	return a.tiles
+}
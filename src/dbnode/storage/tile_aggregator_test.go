@@ -0,0 +1,305 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/dbnode/generated/proto/annotation"
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/retention"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3/src/x/context"
+	"github.com/m3db/m3/src/x/ident"
+	"github.com/m3db/m3/src/x/instrument"
+	xtest "github.com/m3db/m3/src/x/test"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+func TestTileAccumulator(t *testing.T) {
+	var (
+		start = xtime.UnixNano(0).Add(time.Hour)
+		input = []ts.Datapoint{
+			{TimestampNanos: start.Add(10 * time.Second), Value: 3},
+			{TimestampNanos: start.Add(20 * time.Second), Value: 1},
+			{TimestampNanos: start.Add(30 * time.Second), Value: 2},
+			{TimestampNanos: start.Add(time.Minute), Value: 5},
+			{TimestampNanos: start.Add(80 * time.Second), Value: 4},
+		}
+	)
+
+	tests := []struct {
+		aggregation TileAggregation
+		counter     bool
+		expected    []float64
+	}{
+		{aggregation: TileAggregationLast, expected: []float64{2, 4}},
+		{aggregation: TileAggregationSum, expected: []float64{6, 9}},
+		{aggregation: TileAggregationMin, expected: []float64{1, 4}},
+		{aggregation: TileAggregationMax, expected: []float64{3, 5}},
+		{aggregation: TileAggregationCount, expected: []float64{3, 2}},
+		// Resets after 3 and 5 are added to the values that follow.
+		{aggregation: TileAggregationSum, counter: true, expected: []float64{5, 12}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.aggregation.String(), func(t *testing.T) {
+			acc := newTileAccumulator(start, time.Minute, tt.aggregation)
+			acc.counter = tt.counter
+			for _, dp := range input {
+				acc.add(dp)
+			}
+
+			tiles := acc.finish()
+			require.Len(t, tiles, len(tt.expected))
+			for i, tile := range tiles {
+				assert.Equal(t, start.Add(time.Duration(i)*time.Minute), tile.TimestampNanos)
+				assert.Equal(t, tt.expected[i], tile.Value)
+			}
+		})
+	}
+}
+
+func TestTileAccumulatorClampsTilesToStart(t *testing.T) {
+	start := xtime.UnixNano(0).Add(time.Hour + 30*time.Second)
+	acc := newTileAccumulator(start, time.Minute, TileAggregationLast)
+	acc.add(ts.Datapoint{TimestampNanos: start.Add(10 * time.Second), Value: 1})
+	acc.add(ts.Datapoint{TimestampNanos: start.Add(40 * time.Second), Value: 2})
+
+	tiles := acc.finish()
+	require.Len(t, tiles, 2)
+	assert.Equal(t, start, tiles[0].TimestampNanos)
+	assert.Equal(t, start.Add(30*time.Second), tiles[1].TimestampNanos)
+}
+
+func TestParseTileAggregation(t *testing.T) {
+	for _, aggregation := range TileAggregations {
+		parsed, err := ParseTileAggregation(aggregation.String())
+		require.NoError(t, err)
+		assert.Equal(t, aggregation, parsed)
+	}
+
+	_, err := ParseTileAggregation("p99")
+	require.Error(t, err)
+}
+
+func TestTileAggregatorAggregateTiles(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	dir, err := ioutil.TempDir("", "tile-aggregator")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var (
+		opts   = DefaultTestOptions()
+		fsOpts = opts.CommitLogOptions().FilesystemOptions().SetFilePathPrefix(dir)
+
+		sourceNsID = ident.StringID("source")
+		targetNsID = ident.StringID("target")
+		sourceBS   = time.Hour
+		targetBS   = 2 * time.Hour
+		start      = xtime.Now().Truncate(targetBS).Add(-targetBS)
+	)
+	opts = opts.SetCommitLogOptions(opts.CommitLogOptions().SetFilesystemOptions(fsOpts))
+
+	counterPayload := annotation.Payload{
+		SourceFormat:          annotation.SourceFormat_OPEN_METRICS,
+		OpenMetricsFamilyType: annotation.OpenMetricsFamilyType_COUNTER,
+	}
+	counterAnnotation, err := counterPayload.Marshal()
+	require.NoError(t, err)
+
+	writeTestSourceFileSet(t, opts, sourceNsID, start, sourceBS, map[string][]ts.Datapoint{
+		"counter": {
+			{TimestampNanos: start, Value: 5},
+			{TimestampNanos: start.Add(30 * time.Second), Value: 8},
+			{TimestampNanos: start.Add(40 * time.Second), Value: 2},
+			{TimestampNanos: start.Add(70 * time.Second), Value: 4},
+		},
+		"gauge": {
+			{TimestampNanos: start.Add(10 * time.Second), Value: 1},
+			{TimestampNanos: start.Add(20 * time.Second), Value: 3},
+			{TimestampNanos: start.Add(70 * time.Second), Value: 5},
+		},
+	}, map[string]ts.Annotation{"counter": counterAnnotation})
+
+	sourceShard := NewMockdatabaseShard(ctrl)
+	sourceShard.EXPECT().ID().Return(uint32(0)).AnyTimes()
+	sourceShard.EXPECT().LatestVolume(gomock.Any()).Return(0, nil).AnyTimes()
+	sourceShard.EXPECT().OpenStreamingReader(start).DoAndReturn(
+		func(blockStart xtime.UnixNano) (fs.DataFileSetReader, error) {
+			reader, err := fs.NewReader(opts.BytesPool(), fsOpts)
+			if err != nil {
+				return nil, err
+			}
+			return reader, reader.Open(fs.DataReaderOpenOptions{
+				Identifier: fs.FileSetFileIdentifier{
+					Namespace:  sourceNsID,
+					BlockStart: blockStart,
+				},
+				FileSetType:      persist.FileSetFlushType,
+				StreamingEnabled: true,
+			})
+		})
+
+	targetShard := NewMockdatabaseShard(ctrl)
+	targetShard.EXPECT().ID().Return(uint32(0)).AnyTimes()
+	targetShard.EXPECT().LatestVolume(start).Return(0, nil).AnyTimes()
+
+	sourceNs := newTileAggregatorTestNamespace(ctrl, opts, sourceNsID, sourceBS, sourceShard)
+	targetNs := newTileAggregatorTestNamespace(ctrl, opts, targetNsID, targetBS, targetShard)
+
+	onFlushSeries := persist.NewMockOnFlushSeries(ctrl)
+	onFlushSeries.EXPECT().OnFlushNewSeries(gomock.Any()).Return(nil).Times(2)
+
+	ctx := context.NewBackground()
+	defer ctx.Close()
+
+	processedTileCount, nextVolume, err := NewTileAggregator(instrument.NewOptions()).
+		AggregateTiles(ctx, sourceNs, targetNs, 0, onFlushSeries, AggregateTilesOptions{
+			Start:       start,
+			End:         start.Add(sourceBS),
+			Step:        time.Minute,
+			Aggregation: TileAggregationSum,
+			InsOptions:  instrument.NewOptions(),
+		})
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), processedTileCount)
+	assert.Equal(t, 0, nextVolume)
+
+	reader, err := fs.NewReader(opts.BytesPool(), fsOpts)
+	require.NoError(t, err)
+	require.NoError(t, reader.Open(fs.DataReaderOpenOptions{
+		Identifier: fs.FileSetFileIdentifier{
+			Namespace:  targetNsID,
+			BlockStart: start,
+		},
+		FileSetType: persist.FileSetFlushType,
+	}))
+	defer reader.Close()
+
+	actual := make(map[string][]ts.Datapoint)
+	for {
+		id, tags, data, _, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		iter := opts.ReaderIteratorPool().Get()
+		data.IncRef()
+		iter.Reset(xio.NewBytesReader64(data.Bytes()), nil)
+		for iter.Next() {
+			dp, _, _ := iter.Current()
+			actual[id.String()] = append(actual[id.String()], dp)
+		}
+		require.NoError(t, iter.Err())
+		iter.Close()
+		data.DecRef()
+		data.Finalize()
+		id.Finalize()
+		tags.Close()
+	}
+
+	assert.Equal(t, map[string][]ts.Datapoint{
+		"counter": {
+			{TimestampNanos: start, Value: 10},
+			{TimestampNanos: start.Add(time.Minute), Value: 12},
+		},
+		"gauge": {
+			{TimestampNanos: start, Value: 4},
+			{TimestampNanos: start.Add(time.Minute), Value: 5},
+		},
+	}, actual)
+}
+
+func newTileAggregatorTestNamespace(
+	ctrl *gomock.Controller,
+	opts Options,
+	id ident.ID,
+	blockSize time.Duration,
+	shard databaseShard,
+) *MockNamespace {
+	nsOpts := namespace.NewOptions().
+		SetRetentionOptions(retention.NewOptions().SetBlockSize(blockSize))
+
+	ns := NewMockNamespace(ctrl)
+	ns.EXPECT().ID().Return(id).AnyTimes()
+	ns.EXPECT().Options().Return(nsOpts).AnyTimes()
+	ns.EXPECT().StorageOptions().Return(opts).AnyTimes()
+	ns.EXPECT().Schema().Return(nil).AnyTimes()
+	ns.EXPECT().ReadableShardAt(uint32(0)).Return(shard, namespace.Context{}, nil)
+	return ns
+}
+
+func writeTestSourceFileSet(
+	t *testing.T,
+	opts Options,
+	nsID ident.ID,
+	blockStart xtime.UnixNano,
+	blockSize time.Duration,
+	series map[string][]ts.Datapoint,
+	annotations map[string]ts.Annotation,
+) {
+	writer, err := fs.NewStreamingWriter(opts.CommitLogOptions().FilesystemOptions())
+	require.NoError(t, err)
+	require.NoError(t, writer.Open(fs.StreamingWriterOpenOptions{
+		NamespaceID:         nsID,
+		BlockStart:          blockStart,
+		BlockSize:           blockSize,
+		PlannedRecordsCount: uint(len(series)),
+	}))
+
+	// NB: series are written in ID order.
+	for _, id := range []string{"counter", "gauge"} {
+		encoder := opts.EncoderPool().Get()
+		encoder.Reset(blockStart, len(series[id]), nil)
+		for i, dp := range series[id] {
+			var ann ts.Annotation
+			if i == 0 {
+				ann = annotations[id]
+			}
+			require.NoError(t, encoder.Encode(dp, xtime.Second, ann))
+		}
+
+		segment := encoder.Discard()
+		data := [][]byte{segment.Head.Bytes()}
+		if segment.Tail != nil {
+			data = append(data, segment.Tail.Bytes())
+		}
+		require.NoError(t, writer.WriteAll(ident.BytesID(id), nil, data, segment.CalculateChecksum()))
+		segment.Finalize()
+	}
+
+	require.NoError(t, writer.Close())
+}
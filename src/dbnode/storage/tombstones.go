@@ -0,0 +1,608 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/storage/block"
+	"github.com/m3db/m3/src/dbnode/storage/series"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3/src/x/context"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+// shardTombstones are the time ranges of the datapoints deleted from the
+// series of a shard. Deleted datapoints are hidden from reads and their series
+// from index queries until the datapoints are out of retention. Datapoints
+// written after they were deleted are restored. Deletes and restores are
+// appended to the tombstones log of the shard, which is compacted into its
+// tombstones file when blocks are purged or expire.
+type shardTombstones struct {
+	sync.RWMutex
+
+	fsOpts    fs.Options
+	logger    *zap.Logger
+	namespace ident.ID
+	shard     uint32
+	blockSize time.Duration
+
+	series map[string]xtime.Ranges
+	blocks map[xtime.UnixNano]blockTombstones
+	// restored are the series of each block with datapoints written after
+	// they were deleted while the block was not purged.
+	restored map[xtime.UnixNano]map[string]struct{}
+	// hasRestored is set if datapoints have been restored without being
+	// appended to the tombstones log since the tombstones were last persisted.
+	hasRestored bool
+	// numSeries is the number of series with tombstones, which is read
+	// without holding the lock on the write path.
+	numSeries atomic.Int64
+}
+
+// blockTombstones are the times datapoints of a block were last deleted and
+// last removed from the filesets of the block by a cold flush.
+type blockTombstones struct {
+	deletedAt xtime.UnixNano
+	purgedAt  xtime.UnixNano
+}
+
+func newShardTombstones(
+	fsOpts fs.Options,
+	namespace ident.ID,
+	shard uint32,
+	blockSize time.Duration,
+) *shardTombstones {
+	return &shardTombstones{
+		fsOpts:    fsOpts,
+		logger:    fsOpts.InstrumentOptions().Logger(),
+		namespace: namespace,
+		shard:     shard,
+		blockSize: blockSize,
+		series:    make(map[string]xtime.Ranges),
+		blocks:    make(map[xtime.UnixNano]blockTombstones),
+		restored:  make(map[xtime.UnixNano]map[string]struct{}),
+	}
+}
+
+// load replaces the tombstones with the persisted tombstones of the shard.
+func (t *shardTombstones) load() error {
+	persisted, entries, err := fs.ReadShardTombstones(t.fsOpts, t.namespace, t.shard)
+	if err != nil {
+		return err
+	}
+
+	t.Lock()
+	defer t.Unlock()
+
+	t.series = make(map[string]xtime.Ranges, len(persisted.Series))
+	for _, s := range persisted.Series {
+		ranges := xtime.NewRanges()
+		for _, r := range s.Ranges {
+			ranges.AddRange(xtime.Range{Start: r.Start, End: r.End})
+		}
+		if !ranges.IsEmpty() {
+			t.series[string(s.ID)] = ranges
+		}
+	}
+	t.blocks = make(map[xtime.UnixNano]blockTombstones, len(persisted.Blocks))
+	t.restored = make(map[xtime.UnixNano]map[string]struct{})
+	for _, b := range persisted.Blocks {
+		t.blocks[b.BlockStart] = blockTombstones{deletedAt: b.DeletedAt, purgedAt: b.PurgedAt}
+	}
+	for _, entry := range entries {
+		t.applyWithLock(entry)
+	}
+	t.numSeries.Store(int64(len(t.series)))
+
+	if len(entries) == 0 {
+		return nil
+	}
+	return t.persistWithLock()
+}
+
+// add deletes the datapoints of the series within the range written before
+// the deletion time.
+func (t *shardTombstones) add(ids []ident.ID, r xtime.Range, deletedAt xtime.UnixNano) error {
+	if len(ids) == 0 || r.IsEmpty() {
+		return nil
+	}
+
+	entry := fs.TombstonesLogEntry{
+		IDs:       make([][]byte, 0, len(ids)),
+		Range:     fs.TombstoneRange{Start: r.Start, End: r.End},
+		DeletedAt: deletedAt,
+	}
+	for _, id := range ids {
+		entry.IDs = append(entry.IDs, append([]byte(nil), id.Bytes()...))
+	}
+
+	t.Lock()
+	defer t.Unlock()
+
+	if err := fs.AppendShardTombstonesLog(t.fsOpts, t.namespace, t.shard, entry); err != nil {
+		return err
+	}
+	t.applyWithLock(entry)
+	t.numSeries.Store(int64(len(t.series)))
+	return nil
+}
+
+// restoreWritten restores the deleted datapoints of the series at the given
+// time since it has been written after the deletion. The deleted datapoints
+// of the series are restored for the whole block of the write once the block
+// has been purged, before then only the written datapoints are restored so
+// that the deleted datapoints still on disk stay hidden. Restores are
+// appended to the tombstones log like deletes, a restore that cannot be
+// appended does not fail the write and is persisted with the tombstones file
+// by persistRestored instead.
+func (t *shardTombstones) restoreWritten(id ident.ID, timestamp xtime.UnixNano) {
+	if t.numSeries.Load() == 0 {
+		return
+	}
+
+	written := xtime.Range{Start: timestamp, End: timestamp + 1}
+	t.RLock()
+	ranges, ok := t.series[string(id.Bytes())]
+	deleted := ok && ranges.Overlaps(written)
+	t.RUnlock()
+	if !deleted {
+		return
+	}
+
+	t.Lock()
+	defer t.Unlock()
+
+	// NB: re-check since the tombstones may have changed without the lock.
+	ranges, ok = t.series[string(id.Bytes())]
+	if !ok || !ranges.Overlaps(written) {
+		return
+	}
+
+	blockStart := timestamp.Truncate(t.blockSize)
+	if block := t.blocks[blockStart]; !block.deletedAt.After(block.purgedAt) {
+		written = xtime.Range{Start: blockStart, End: blockStart.Add(t.blockSize)}
+	}
+	entry := fs.TombstonesLogEntry{
+		IDs:      [][]byte{append([]byte(nil), id.Bytes()...)},
+		Range:    fs.TombstoneRange{Start: written.Start, End: written.End},
+		Restored: true,
+	}
+	if err := fs.AppendShardTombstonesLog(t.fsOpts, t.namespace, t.shard, entry); err != nil {
+		t.logger.Error("could not append restore to shard tombstones log",
+			zap.Stringer("namespace", t.namespace), zap.Uint32("shard", t.shard), zap.Error(err))
+		t.hasRestored = true
+	}
+	t.applyWithLock(entry)
+	t.numSeries.Store(int64(len(t.series)))
+}
+
+// persistRestored persists the tombstones if datapoints have been restored
+// without being appended to the tombstones log since they were last
+// persisted.
+func (t *shardTombstones) persistRestored() error {
+	t.Lock()
+	defer t.Unlock()
+
+	if !t.hasRestored {
+		return nil
+	}
+	return t.persistWithLock()
+}
+
+func (t *shardTombstones) applyWithLock(entry fs.TombstonesLogEntry) {
+	r := xtime.Range{Start: entry.Range.Start, End: entry.Range.End}
+	if entry.Restored {
+		for _, id := range entry.IDs {
+			t.restoreWithLock(string(id), r)
+		}
+		return
+	}
+
+	for _, id := range entry.IDs {
+		key := string(id)
+		ranges, ok := t.series[key]
+		if !ok {
+			ranges = xtime.NewRanges()
+			t.series[key] = ranges
+		}
+		ranges.AddRange(r)
+	}
+
+	for blockStart := r.Start.Truncate(t.blockSize); blockStart.Before(r.End); blockStart = blockStart.Add(t.blockSize) {
+		block := t.blocks[blockStart]
+		if entry.DeletedAt.After(block.deletedAt) {
+			block.deletedAt = entry.DeletedAt
+		}
+		t.blocks[blockStart] = block
+	}
+}
+
+// restoreWithLock removes the restored range from the tombstones of the
+// series, the series is tracked until its block is purged if the block still
+// has deleted datapoints on disk.
+func (t *shardTombstones) restoreWithLock(key string, r xtime.Range) {
+	ranges, ok := t.series[key]
+	if !ok {
+		return
+	}
+	ranges.RemoveRange(r)
+	if ranges.IsEmpty() {
+		delete(t.series, key)
+	}
+
+	blockStart := r.Start.Truncate(t.blockSize)
+	if block := t.blocks[blockStart]; block.deletedAt.After(block.purgedAt) {
+		restored, ok := t.restored[blockStart]
+		if !ok {
+			restored = make(map[string]struct{})
+			t.restored[blockStart] = restored
+		}
+		restored[key] = struct{}{}
+	}
+}
+
+// deletedRanges returns the deleted ranges of the series within the range, or
+// nil if no datapoint of the series within the range is deleted.
+func (t *shardTombstones) deletedRanges(id ident.ID, r xtime.Range) xtime.Ranges {
+	if t.numSeries.Load() == 0 {
+		return nil
+	}
+
+	t.RLock()
+	defer t.RUnlock()
+
+	ranges, ok := t.series[string(id.Bytes())]
+	if !ok || !ranges.Overlaps(r) {
+		return nil
+	}
+
+	deleted := xtime.NewRanges()
+	for it := ranges.Iter(); it.Next(); {
+		if intersection, ok := it.Value().Intersect(r); ok {
+			deleted.AddRange(intersection)
+		}
+	}
+	return deleted
+}
+
+// isEmpty returns true if there are no tombstones.
+func (t *shardTombstones) isEmpty() bool {
+	return t.numSeries.Load() == 0
+}
+
+// blocksToPurge returns the block starts with datapoints deleted since the
+// block was last purged.
+func (t *shardTombstones) blocksToPurge() []xtime.UnixNano {
+	t.RLock()
+	defer t.RUnlock()
+
+	var blockStarts []xtime.UnixNano
+	for blockStart, block := range t.blocks {
+		if block.deletedAt.After(block.purgedAt) {
+			blockStarts = append(blockStarts, blockStart)
+		}
+	}
+	return blockStarts
+}
+
+// markPurged records that the datapoints of the blocks deleted before the
+// given time have been removed from disk. The tombstones are kept so that the
+// series stay hidden from index queries.
+func (t *shardTombstones) markPurged(blockStarts []xtime.UnixNano, purgedAt xtime.UnixNano) error {
+	if len(blockStarts) == 0 {
+		return nil
+	}
+
+	t.Lock()
+	defer t.Unlock()
+
+	var changed bool
+	for _, blockStart := range blockStarts {
+		block, ok := t.blocks[blockStart]
+		if !ok || !purgedAt.After(block.purgedAt) {
+			continue
+		}
+		block.purgedAt = purgedAt
+		t.blocks[blockStart] = block
+		changed = true
+
+		// The datapoints of the series restored in the block that were
+		// deleted are no longer on disk, so the whole block is restored.
+		if block.deletedAt.After(block.purgedAt) {
+			continue
+		}
+		blockRange := xtime.Range{Start: blockStart, End: blockStart.Add(t.blockSize)}
+		for key := range t.restored[blockStart] {
+			if ranges, ok := t.series[key]; ok {
+				ranges.RemoveRange(blockRange)
+				if ranges.IsEmpty() {
+					delete(t.series, key)
+				}
+			}
+		}
+		delete(t.restored, blockStart)
+	}
+	if !changed {
+		return nil
+	}
+	t.numSeries.Store(int64(len(t.series)))
+	return t.persistWithLock()
+}
+
+// removeBefore drops the tombstones of the datapoints before the given time,
+// which is used to drop the tombstones of blocks out of retention.
+func (t *shardTombstones) removeBefore(end xtime.UnixNano) error {
+	t.Lock()
+	defer t.Unlock()
+
+	expired := xtime.Range{End: end}
+	var changed bool
+	for key, ranges := range t.series {
+		if !ranges.Overlaps(expired) {
+			continue
+		}
+		ranges.RemoveRange(expired)
+		if ranges.IsEmpty() {
+			delete(t.series, key)
+		}
+		changed = true
+	}
+	for blockStart := range t.blocks {
+		if !blockStart.Add(t.blockSize).After(end) {
+			delete(t.blocks, blockStart)
+			delete(t.restored, blockStart)
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	t.numSeries.Store(int64(len(t.series)))
+	return t.persistWithLock()
+}
+
+// persistWithLock compacts the tombstones log into the tombstones file.
+func (t *shardTombstones) persistWithLock() error {
+	persisted := fs.ShardTombstones{
+		Series: make([]fs.SeriesTombstones, 0, len(t.series)),
+		Blocks: make([]fs.BlockTombstones, 0, len(t.blocks)),
+	}
+	for key, ranges := range t.series {
+		series := fs.SeriesTombstones{
+			ID:     []byte(key),
+			Ranges: make([]fs.TombstoneRange, 0, ranges.Len()),
+		}
+		for it := ranges.Iter(); it.Next(); {
+			r := it.Value()
+			series.Ranges = append(series.Ranges, fs.TombstoneRange{Start: r.Start, End: r.End})
+		}
+		persisted.Series = append(persisted.Series, series)
+	}
+	for blockStart, block := range t.blocks {
+		persisted.Blocks = append(persisted.Blocks, fs.BlockTombstones{
+			BlockStart: blockStart,
+			DeletedAt:  block.deletedAt,
+			PurgedAt:   block.purgedAt,
+		})
+	}
+	if err := fs.WriteShardTombstones(t.fsOpts, t.namespace, t.shard, persisted); err != nil {
+		return err
+	}
+	t.hasRestored = false
+	return nil
+}
+
+// rangesCover returns true if the ranges cover the whole range.
+func rangesCover(ranges xtime.Ranges, r xtime.Range) bool {
+	if ranges == nil {
+		return false
+	}
+	remaining := xtime.NewRanges(r)
+	remaining.RemoveRanges(ranges)
+	return remaining.IsEmpty()
+}
+
+// deletedBlockReaderIter hides the deleted datapoints of the blocks read from
+// a series, blocks with deleted datapoints are re-encoded without them.
+type deletedBlockReaderIter struct {
+	iter      series.BlockReaderIter
+	deleted   xtime.Ranges
+	blockSize time.Duration
+	opts      Options
+	nsCtx     namespace.Context
+
+	curr []xio.BlockReader
+	err  error
+}
+
+func newDeletedBlockReaderIter(
+	iter series.BlockReaderIter,
+	deleted xtime.Ranges,
+	blockSize time.Duration,
+	opts Options,
+	nsCtx namespace.Context,
+) series.BlockReaderIter {
+	return &deletedBlockReaderIter{
+		iter:      iter,
+		deleted:   deleted,
+		blockSize: blockSize,
+		opts:      opts,
+		nsCtx:     nsCtx,
+	}
+}
+
+func (i *deletedBlockReaderIter) Next(ctx context.Context) bool {
+	if i.err != nil {
+		return false
+	}
+
+	for i.iter.Next(ctx) {
+		blocks := i.iter.Current()
+		blockStart := blocks[0].Start
+		blockRange := xtime.Range{Start: blockStart, End: blockStart.Add(i.blockSize)}
+		if !i.deleted.Overlaps(blockRange) {
+			i.curr = blocks
+			return true
+		}
+		if rangesCover(i.deleted, blockRange) {
+			continue
+		}
+
+		block, ok, err := i.withoutDeleted(ctx, blockStart, blocks)
+		if err != nil {
+			i.err = err
+			return false
+		}
+		if ok {
+			i.curr = []xio.BlockReader{block}
+			return true
+		}
+	}
+	return false
+}
+
+func (i *deletedBlockReaderIter) withoutDeleted(
+	ctx context.Context,
+	blockStart xtime.UnixNano,
+	blocks []xio.BlockReader,
+) (xio.BlockReader, bool, error) {
+	return blockWithoutDeleted(ctx, blockStart, i.blockSize, blocks, i.deleted, i.opts, i.nsCtx)
+}
+
+// blockWithoutDeleted re-encodes the blocks of a series without the deleted
+// datapoints, it returns false if all the datapoints are deleted.
+func blockWithoutDeleted(
+	ctx context.Context,
+	blockStart xtime.UnixNano,
+	blockSize time.Duration,
+	blocks []xio.BlockReader,
+	deleted xtime.Ranges,
+	opts Options,
+	nsCtx namespace.Context,
+) (xio.BlockReader, bool, error) {
+	readers := make([]xio.SegmentReader, 0, len(blocks))
+	for _, block := range blocks {
+		readers = append(readers, block.SegmentReader)
+	}
+
+	iter := opts.MultiReaderIteratorPool().Get()
+	iter.Reset(readers, blockStart, blockSize, nsCtx.Schema)
+	defer iter.Close()
+
+	encoder := opts.EncoderPool().Get()
+	encoder.Reset(blockStart, 0, nsCtx.Schema)
+	for iter.Next() {
+		dp, unit, annotation := iter.Current()
+		if deleted.Overlaps(xtime.Range{Start: dp.TimestampNanos, End: dp.TimestampNanos + 1}) {
+			continue
+		}
+		if err := encoder.Encode(dp, unit, annotation); err != nil {
+			encoder.Close()
+			return xio.BlockReader{}, false, err
+		}
+	}
+	if err := iter.Err(); err != nil {
+		encoder.Close()
+		return xio.BlockReader{}, false, err
+	}
+	if encoder.NumEncoded() == 0 {
+		encoder.Close()
+		return xio.BlockReader{}, false, nil
+	}
+
+	reader := xio.NewSegmentReader(encoder.Discard())
+	ctx.RegisterFinalizer(reader)
+	return xio.BlockReader{
+		SegmentReader: reader,
+		Start:         blockStart,
+		BlockSize:     blockSize,
+	}, true, nil
+}
+
+// fetchBlockResultsWithoutDeleted hides the deleted datapoints of fetched
+// blocks, blocks with deleted datapoints are re-encoded without them.
+func fetchBlockResultsWithoutDeleted(
+	ctx context.Context,
+	results []block.FetchBlockResult,
+	deleted xtime.Ranges,
+	blockSize time.Duration,
+	opts Options,
+	nsCtx namespace.Context,
+) []block.FetchBlockResult {
+	for idx := range results {
+		result := &results[idx]
+		if result.Err != nil || len(result.Blocks) == 0 {
+			continue
+		}
+		blockRange := xtime.Range{Start: result.Start, End: result.Start.Add(blockSize)}
+		if !deleted.Overlaps(blockRange) {
+			continue
+		}
+		if rangesCover(deleted, blockRange) {
+			result.Blocks = nil
+			continue
+		}
+
+		reader, ok, err := blockWithoutDeleted(ctx, result.Start, blockSize,
+			result.Blocks, deleted, opts, nsCtx)
+		switch {
+		case err != nil:
+			result.Blocks = nil
+			result.Err = err
+		case !ok:
+			result.Blocks = nil
+		default:
+			result.Blocks = []xio.BlockReader{reader}
+		}
+	}
+	return results
+}
+
+func (i *deletedBlockReaderIter) Current() []xio.BlockReader {
+	return i.curr
+}
+
+func (i *deletedBlockReaderIter) Err() error {
+	if i.err != nil {
+		return i.err
+	}
+	return i.iter.Err()
+}
+
+func (i *deletedBlockReaderIter) ToSlices(ctx context.Context) ([][]xio.BlockReader, error) {
+	var results [][]xio.BlockReader
+	for i.Next(ctx) {
+		results = append(results, i.Current())
+	}
+	if err := i.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/storage/series"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3/src/x/context"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShardTombstonesAddRemove(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testdir")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var (
+		fsOpts    = fs.NewOptions().SetFilePathPrefix(dir)
+		nsID      = ident.StringID("ns")
+		blockSize = 2 * time.Hour
+		start     = xtime.Now().Truncate(blockSize)
+		foo       = ident.StringID("foo")
+		bar       = ident.StringID("bar")
+		deleted   = xtime.Range{Start: start.Add(time.Hour), End: start.Add(3 * time.Hour)}
+	)
+
+	tombstones := newShardTombstones(fsOpts, nsID, 0, blockSize)
+	require.True(t, tombstones.isEmpty())
+	deletedAt := start.Add(4 * time.Hour)
+	require.NoError(t, tombstones.add([]ident.ID{foo}, deleted, deletedAt))
+	require.False(t, tombstones.isEmpty())
+
+	firstBlock := xtime.Range{Start: start, End: start.Add(blockSize)}
+	require.Nil(t, tombstones.deletedRanges(bar, firstBlock))
+	ranges := tombstones.deletedRanges(foo, firstBlock)
+	require.NotNil(t, ranges)
+	require.True(t, rangesCover(ranges, xtime.Range{Start: start.Add(time.Hour), End: start.Add(blockSize)}))
+	require.False(t, rangesCover(ranges, firstBlock))
+	require.ElementsMatch(t, []xtime.UnixNano{start, start.Add(blockSize)}, tombstones.blocksToPurge())
+
+	// Datapoints written after they were deleted are restored, only the
+	// written datapoints until the deleted datapoints are purged.
+	written := start.Add(90 * time.Minute)
+	tombstones.restoreWritten(foo, written)
+	ranges = tombstones.deletedRanges(foo, firstBlock)
+	require.False(t, ranges.Overlaps(xtime.Range{Start: written, End: written + 1}))
+	require.True(t, ranges.Overlaps(xtime.Range{Start: start.Add(time.Hour), End: written}))
+
+	// Deletes and restores are appended to the log and can be loaded.
+	loaded := newShardTombstones(fsOpts, nsID, 0, blockSize)
+	require.NoError(t, loaded.load())
+	require.Equal(t, ranges.String(), loaded.deletedRanges(foo, firstBlock).String())
+	require.ElementsMatch(t, tombstones.blocksToPurge(), loaded.blocksToPurge())
+
+	// Purged blocks keep their tombstones until datapoints are deleted again,
+	// except for the series restored in the block which are restored for the
+	// whole block.
+	require.NoError(t, tombstones.markPurged([]xtime.UnixNano{start}, deletedAt.Add(time.Minute)))
+	require.Equal(t, []xtime.UnixNano{start.Add(blockSize)}, tombstones.blocksToPurge())
+	require.Nil(t, tombstones.deletedRanges(foo, firstBlock))
+	secondBlock := xtime.Range{Start: start.Add(blockSize), End: start.Add(2 * blockSize)}
+	require.NotNil(t, tombstones.deletedRanges(foo, secondBlock))
+
+	// Writes into purged blocks restore the whole block of the series at once.
+	require.NoError(t, tombstones.markPurged([]xtime.UnixNano{start.Add(blockSize)}, deletedAt.Add(time.Minute)))
+	tombstones.restoreWritten(foo, start.Add(blockSize+time.Minute))
+	require.Nil(t, tombstones.deletedRanges(foo, secondBlock))
+	require.NoError(t, tombstones.add([]ident.ID{foo}, secondBlock, deletedAt.Add(2*time.Minute)))
+	require.NotNil(t, tombstones.deletedRanges(foo, secondBlock))
+	require.NoError(t, tombstones.add([]ident.ID{bar}, firstBlock, deletedAt.Add(time.Hour)))
+	require.ElementsMatch(t, []xtime.UnixNano{start, start.Add(blockSize)}, tombstones.blocksToPurge())
+
+	loaded = newShardTombstones(fsOpts, nsID, 0, blockSize)
+	require.NoError(t, loaded.load())
+	require.ElementsMatch(t, tombstones.blocksToPurge(), loaded.blocksToPurge())
+	require.NotNil(t, loaded.deletedRanges(bar, firstBlock))
+
+	require.NoError(t, tombstones.removeBefore(start.Add(2*blockSize)))
+	require.True(t, tombstones.isEmpty())
+	require.Empty(t, tombstones.blocksToPurge())
+
+	// Removing all the tombstones removes the persisted tombstones.
+	loaded = newShardTombstones(fsOpts, nsID, 0, blockSize)
+	require.NoError(t, loaded.load())
+	require.True(t, loaded.isEmpty())
+}
+
+func TestDeletedBlockReaderIter(t *testing.T) {
+	var (
+		opts      = DefaultTestOptions()
+		blockSize = time.Hour
+		start     = xtime.Now().Truncate(blockSize)
+		ctx       = context.NewBackground()
+	)
+	defer ctx.Close()
+
+	block := func(blockStart xtime.UnixNano) []xio.BlockReader {
+		encoder := opts.EncoderPool().Get()
+		encoder.Reset(blockStart, 0, nil)
+		for i := 0; i < 6; i++ {
+			dp := ts.Datapoint{
+				TimestampNanos: blockStart.Add(time.Duration(i) * 10 * time.Minute),
+				Value:          float64(i),
+			}
+			require.NoError(t, encoder.Encode(dp, xtime.Second, nil))
+		}
+		return []xio.BlockReader{{
+			SegmentReader: xio.NewSegmentReader(encoder.Discard()),
+			Start:         blockStart,
+			BlockSize:     blockSize,
+		}}
+	}
+
+	// The second block is fully deleted and the third block partially.
+	deleted := xtime.NewRanges(xtime.Range{
+		Start: start.Add(blockSize),
+		End:   start.Add(2*blockSize + 30*time.Minute),
+	})
+	iter := newDeletedBlockReaderIter(&series.FakeBlockReaderIter{
+		Readers: [][]xio.BlockReader{
+			block(start),
+			block(start.Add(blockSize)),
+			block(start.Add(2 * blockSize)),
+		},
+	}, deleted, blockSize, opts, namespace.Context{})
+
+	results, err := iter.ToSlices(ctx)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	values := func(blocks []xio.BlockReader) []float64 {
+		readers := make([]xio.SegmentReader, 0, len(blocks))
+		for _, b := range blocks {
+			readers = append(readers, b.SegmentReader)
+		}
+		it := opts.MultiReaderIteratorPool().Get()
+		it.Reset(readers, blocks[0].Start, blockSize, nil)
+		defer it.Close()
+
+		var values []float64
+		for it.Next() {
+			dp, _, _ := it.Current()
+			values = append(values, dp.Value)
+		}
+		require.NoError(t, it.Err())
+		return values
+	}
+
+	require.Equal(t, start, results[0][0].Start)
+	require.Equal(t, []float64{0, 1, 2, 3, 4, 5}, values(results[0]))
+	require.Equal(t, start.Add(2*blockSize), results[1][0].Start)
+	require.Equal(t, []float64{3, 4, 5}, values(results[1]))
+}
@@ -167,6 +167,14 @@ type Database interface {
 		opts index.AggregationOptions,
 	) (index.AggregateQueryResult, error)
 
+	// Cardinality computes the cardinality of the series indexed by the
+	// namespace.
+	Cardinality(
+		ctx context.Context,
+		namespace ident.ID,
+		opts index.CardinalityOptions,
+	) (index.CardinalityResult, error)
+
 	// ReadEncoded retrieves encoded segments for an ID.
 	ReadEncoded(
 		ctx context.Context,
@@ -218,6 +226,15 @@ type Database interface {
 	// Truncate truncates data for the given namespace.
 	Truncate(namespace ident.ID) (int64, error)
 
+	// DeleteSeries deletes the datapoints within [start, end) of the series
+	// matching the query, and returns the number of series deleted from.
+	DeleteSeries(
+		ctx context.Context,
+		namespace ident.ID,
+		query index.Query,
+		start, end xtime.UnixNano,
+	) (int64, error)
+
 	// BootstrapState captures and returns a snapshot of the databases'
 	// bootstrap state.
 	BootstrapState() DatabaseBootstrapState
@@ -349,6 +366,13 @@ type databaseNamespace interface {
 		opts index.AggregationOptions,
 	) (index.AggregateQueryResult, error)
 
+	// Cardinality computes the cardinality of the series indexed by the
+	// namespace.
+	Cardinality(
+		ctx context.Context,
+		opts index.CardinalityOptions,
+	) (index.CardinalityResult, error)
+
 	// ReadEncoded reads data for given id within [start, end).
 	ReadEncoded(
 		ctx context.Context,
@@ -403,6 +427,14 @@ type databaseNamespace interface {
 	// Truncate truncates the in-memory data for this namespace.
 	Truncate() (int64, error)
 
+	// DeleteSeries deletes the datapoints within [start, end) of the series
+	// matching the query, and returns the number of series deleted from.
+	DeleteSeries(
+		ctx context.Context,
+		query index.Query,
+		start, end xtime.UnixNano,
+	) (int64, error)
+
 	// Repair repairs the namespace data for a given time range.
 	Repair(repairer databaseShardRepairer, tr xtime.Range, opts NamespaceRepairOptions) error
 
@@ -520,6 +552,17 @@ type databaseShard interface {
 		nsCtx namespace.Context,
 	) ([]block.FetchBlockResult, error)
 
+	// DeleteSeries deletes the datapoints within [start, end) of the series.
+	DeleteSeries(ids []ident.ID, start, end xtime.UnixNano) error
+
+	// DeletedRanges returns the time ranges of the block of the series with
+	// deleted datapoints, or nil if none.
+	DeletedRanges(id ident.ID, blockStart xtime.UnixNano) xtime.Ranges
+
+	// HasDeletedSeries returns true if the shard has deleted datapoints that
+	// have not been removed from disk yet.
+	HasDeletedSeries() bool
+
 	// FetchBlocksForColdFlush fetches blocks for a cold flush. This function
 	// informs the series and the buffer that a cold flush for the specified
 	// block start is occurring so that it knows to update bucket versions.
@@ -683,6 +726,13 @@ type NamespaceIndex interface {
 		opts index.AggregationOptions,
 	) (index.AggregateQueryResult, error)
 
+	// Cardinality computes the cardinality of the series indexed by the
+	// index blocks in the range of the options.
+	Cardinality(
+		ctx context.Context,
+		opts index.CardinalityOptions,
+	) (index.CardinalityResult, error)
+
 	// Bootstrap bootstraps the index with the provided segments.
 	Bootstrap(
 		bootstrapResults result.IndexResults,
@@ -1327,6 +1377,12 @@ type Options interface {
 	// TileAggregator returns the TileAggregator.
 	TileAggregator() TileAggregator
 
+	// SetFileSetReblocker sets the FileSetReblocker.
+	SetFileSetReblocker(value FileSetReblocker) Options
+
+	// FileSetReblocker returns the FileSetReblocker.
+	FileSetReblocker() FileSetReblocker
+
 	// PermitsOptions returns the permits options.
 	PermitsOptions() permits.Options
 
@@ -1440,6 +1496,64 @@ func (p AggregateTilesProcess) String() string {
 	}
 }
 
+// TileAggregation is the aggregation applied to the datapoints of each tile.
+// Series of Prometheus counters always keep the last datapoint of each tile,
+// adjusted for counter resets.
+type TileAggregation uint8
+
+const (
+	// TileAggregationLast keeps the last datapoint of each tile.
+	TileAggregationLast TileAggregation = iota
+
+	// TileAggregationSum sums the datapoints of each tile.
+	TileAggregationSum
+
+	// TileAggregationMin keeps the minimum datapoint of each tile.
+	TileAggregationMin
+
+	// TileAggregationMax keeps the maximum datapoint of each tile.
+	TileAggregationMax
+
+	// TileAggregationCount counts the datapoints of each tile.
+	TileAggregationCount
+)
+
+// TileAggregations is a list of available TileAggregation values.
+var TileAggregations = []TileAggregation{
+	TileAggregationLast,
+	TileAggregationSum,
+	TileAggregationMin,
+	TileAggregationMax,
+	TileAggregationCount,
+}
+
+func (a TileAggregation) String() string {
+	switch a {
+	case TileAggregationLast:
+		return "last"
+	case TileAggregationSum:
+		return "sum"
+	case TileAggregationMin:
+		return "min"
+	case TileAggregationMax:
+		return "max"
+	case TileAggregationCount:
+		return "count"
+	default:
+		return fmt.Sprintf("unknown (%d)", a)
+	}
+}
+
+// ParseTileAggregation parses a TileAggregation from its string representation.
+func ParseTileAggregation(str string) (TileAggregation, error) {
+	for _, a := range TileAggregations {
+		if a.String() == str {
+			return a, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid tile aggregation '%s', valid values are %v", str, TileAggregations)
+}
+
 // AggregateTilesOptions is the options for large tile aggregation.
 type AggregateTilesOptions struct {
 	// Start and End specify the aggregation window.
@@ -1448,6 +1562,8 @@ type AggregateTilesOptions struct {
 	Step       time.Duration
 	InsOptions instrument.Options
 	Process    AggregateTilesProcess
+	// Aggregation is the aggregation applied to the datapoints of each tile.
+	Aggregation TileAggregation
 
 	// MemorizeMetricTypes enables storing data into MetricTypeByName map.
 	MemorizeMetricTypes bool
@@ -1473,6 +1589,21 @@ type TileAggregator interface {
 // NewTileAggregatorFn creates a new TileAggregator.
 type NewTileAggregatorFn func(iOpts instrument.Options) TileAggregator
 
+// FileSetReblocker re-blocks the filesets of a shard that were written with a
+// previous block size of their namespace while the node is running.
+type FileSetReblocker interface {
+	// Reblock merges the filesets of a previous block size that overlap with a
+	// block into a new volume of the block, along with the latest volume of the
+	// block, and returns the latest volume of the block.
+	Reblock(
+		opts Options,
+		md namespace.Metadata,
+		shard uint32,
+		blockStart xtime.UnixNano,
+		volume int,
+	) (int, error)
+}
+
 // NamespaceHooks allows dynamic plugging into the namespace lifecycle.
 type NamespaceHooks interface {
 	// OnCreatedNamespace gets invoked after each namespace is initialized.
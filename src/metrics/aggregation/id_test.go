@@ -116,7 +116,7 @@ func TestIDYAMLMarshaling(t *testing.T) {
 	})
 
 	t.Run("marshal_error", func(t *testing.T) {
-		_, err := yaml.Marshal(ID{40559696})
+		_, err := yaml.Marshal(ID{1 << 26})
 		assert.Error(t, err)
 	})
 
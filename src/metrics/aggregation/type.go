@@ -55,6 +55,7 @@ const (
 	P9999
 	P25
 	P75
+	Histogram
 
 	nextTypeID = iota
 )
@@ -78,59 +79,61 @@ var (
 
 	// ValidTypes is the list of all the valid aggregation types.
 	ValidTypes = map[Type]struct{}{
-		Last:   emptyStruct,
-		Min:    emptyStruct,
-		Max:    emptyStruct,
-		Mean:   emptyStruct,
-		Median: emptyStruct,
-		Count:  emptyStruct,
-		Sum:    emptyStruct,
-		SumSq:  emptyStruct,
-		Stdev:  emptyStruct,
-		P10:    emptyStruct,
-		P20:    emptyStruct,
-		P25:    emptyStruct,
-		P30:    emptyStruct,
-		P40:    emptyStruct,
-		P50:    emptyStruct,
-		P60:    emptyStruct,
-		P70:    emptyStruct,
-		P75:    emptyStruct,
-		P80:    emptyStruct,
-		P90:    emptyStruct,
-		P95:    emptyStruct,
-		P99:    emptyStruct,
-		P999:   emptyStruct,
-		P9999:  emptyStruct,
+		Last:      emptyStruct,
+		Min:       emptyStruct,
+		Max:       emptyStruct,
+		Mean:      emptyStruct,
+		Median:    emptyStruct,
+		Count:     emptyStruct,
+		Sum:       emptyStruct,
+		SumSq:     emptyStruct,
+		Stdev:     emptyStruct,
+		P10:       emptyStruct,
+		P20:       emptyStruct,
+		P25:       emptyStruct,
+		P30:       emptyStruct,
+		P40:       emptyStruct,
+		P50:       emptyStruct,
+		P60:       emptyStruct,
+		P70:       emptyStruct,
+		P75:       emptyStruct,
+		P80:       emptyStruct,
+		P90:       emptyStruct,
+		P95:       emptyStruct,
+		P99:       emptyStruct,
+		P999:      emptyStruct,
+		P9999:     emptyStruct,
+		Histogram: emptyStruct,
 	}
 
 	typeStringMap map[string]Type
 
 	typeStringNames = map[Type][]byte{
-		Last:   []byte("last"),
-		Min:    []byte("lower"),
-		Max:    []byte("upper"),
-		Mean:   []byte("mean"),
-		Median: []byte("median"),
-		Count:  []byte("count"),
-		Sum:    []byte("sum"),
-		SumSq:  []byte("sum_sq"),
-		Stdev:  []byte("stdev"),
-		P10:    []byte("p10"),
-		P20:    []byte("p20"),
-		P25:    []byte("p25"),
-		P30:    []byte("p30"),
-		P40:    []byte("p40"),
-		P50:    []byte("p50"),
-		P60:    []byte("p60"),
-		P70:    []byte("p70"),
-		P75:    []byte("p75"),
-		P80:    []byte("p80"),
-		P90:    []byte("p90"),
-		P95:    []byte("p95"),
-		P99:    []byte("p99"),
-		P999:   []byte("p999"),
-		P9999:  []byte("p9999"),
+		Last:      []byte("last"),
+		Min:       []byte("lower"),
+		Max:       []byte("upper"),
+		Mean:      []byte("mean"),
+		Median:    []byte("median"),
+		Count:     []byte("count"),
+		Sum:       []byte("sum"),
+		SumSq:     []byte("sum_sq"),
+		Stdev:     []byte("stdev"),
+		P10:       []byte("p10"),
+		P20:       []byte("p20"),
+		P25:       []byte("p25"),
+		P30:       []byte("p30"),
+		P40:       []byte("p40"),
+		P50:       []byte("p50"),
+		P60:       []byte("p60"),
+		P70:       []byte("p70"),
+		P75:       []byte("p75"),
+		P80:       []byte("p80"),
+		P90:       []byte("p90"),
+		P95:       []byte("p95"),
+		P99:       []byte("p99"),
+		P999:      []byte("p999"),
+		P9999:     []byte("p9999"),
+		Histogram: []byte("histogram"),
 	}
 
 	typeQuantileBytes = map[Type][]byte{
@@ -38,6 +38,9 @@ type TypesConfiguration struct {
 	// Default aggregation types for gauge metrics.
 	DefaultGaugeAggregationTypes *Types `yaml:"defaultGaugeAggregationTypes"`
 
+	// Default aggregation types for histogram metrics.
+	DefaultHistogramAggregationTypes *Types `yaml:"defaultHistogramAggregationTypes"`
+
 	// CounterTransformFnType configures the type string transformation function for counters.
 	CounterTransformFnType *TransformFnType `yaml:"counterTransformFnType"`
 
@@ -66,6 +69,9 @@ func (c TypesConfiguration) NewOptions(instrumentOpts instrument.Options) (Types
 	if c.DefaultTimerAggregationTypes != nil {
 		opts = opts.SetDefaultTimerAggregationTypes(*c.DefaultTimerAggregationTypes)
 	}
+	if c.DefaultHistogramAggregationTypes != nil {
+		opts = opts.SetDefaultHistogramAggregationTypes(*c.DefaultHistogramAggregationTypes)
+	}
 	if c.CounterTransformFnType != nil {
 		fn, err := c.CounterTransformFnType.TransformFn()
 		if err != nil {
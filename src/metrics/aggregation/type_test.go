@@ -33,13 +33,13 @@ import (
 )
 
 func TestTypeIsValid(t *testing.T) {
-	require.True(t, P75.IsValid())
-	require.False(t, Type(int(P75)+1).IsValid())
+	require.True(t, Histogram.IsValid())
+	require.False(t, Type(int(Histogram)+1).IsValid())
 }
 
 func TestTypeMaxID(t *testing.T) {
-	require.Equal(t, maxTypeID, P75.ID())
-	require.Equal(t, P75, Type(maxTypeID))
+	require.Equal(t, maxTypeID, Histogram.ID())
+	require.Equal(t, Histogram, Type(maxTypeID))
 	require.Equal(t, maxTypeID, len(ValidTypes))
 }
 
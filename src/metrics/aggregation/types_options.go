@@ -57,6 +57,12 @@ type TypesOptions interface {
 	// DefaultGaugeAggregationTypes returns the default aggregation types for gauges.
 	DefaultGaugeAggregationTypes() Types
 
+	// SetDefaultHistogramAggregationTypes sets the default aggregation types for histograms.
+	SetDefaultHistogramAggregationTypes(value Types) TypesOptions
+
+	// DefaultHistogramAggregationTypes returns the default aggregation types for histograms.
+	DefaultHistogramAggregationTypes() Types
+
 	// SetQuantileTypeStringFn sets the quantile type string function for timers.
 	SetQuantileTypeStringFn(value QuantileTypeStringFn) TypesOptions
 
@@ -141,29 +147,34 @@ var (
 	defaultDefaultGaugeAggregationTypes = Types{
 		Last,
 	}
+	defaultDefaultHistogramAggregationTypes = Types{
+		Histogram,
+	}
 	defaultTypeStringsMap = map[Type][]byte{
-		Last:   []byte("last"),
-		Sum:    []byte("sum"),
-		SumSq:  []byte("sum_sq"),
-		Mean:   []byte("mean"),
-		Min:    []byte("lower"),
-		Max:    []byte("upper"),
-		Count:  []byte("count"),
-		Stdev:  []byte("stdev"),
-		Median: []byte("median"),
+		Last:      []byte("last"),
+		Sum:       []byte("sum"),
+		SumSq:     []byte("sum_sq"),
+		Mean:      []byte("mean"),
+		Min:       []byte("lower"),
+		Max:       []byte("upper"),
+		Count:     []byte("count"),
+		Stdev:     []byte("stdev"),
+		Median:    []byte("median"),
+		Histogram: []byte("histogram"),
 	}
 )
 
 type options struct {
-	defaultCounterAggregationTypes Types
-	defaultTimerAggregationTypes   Types
-	defaultGaugeAggregationTypes   Types
-	quantileTypeStringFn           QuantileTypeStringFn
-	counterTypeStringTransformFn   TypeStringTransformFn
-	timerTypeStringTransformFn     TypeStringTransformFn
-	gaugeTypeStringTransformFn     TypeStringTransformFn
-	aggTypesPool                   TypesPool
-	quantilesPool                  pool.FloatsPool
+	defaultCounterAggregationTypes   Types
+	defaultTimerAggregationTypes     Types
+	defaultGaugeAggregationTypes     Types
+	defaultHistogramAggregationTypes Types
+	quantileTypeStringFn             QuantileTypeStringFn
+	counterTypeStringTransformFn     TypeStringTransformFn
+	timerTypeStringTransformFn       TypeStringTransformFn
+	gaugeTypeStringTransformFn       TypeStringTransformFn
+	aggTypesPool                     TypesPool
+	quantilesPool                    pool.FloatsPool
 
 	counterTypeStrings [][]byte
 	timerTypeStrings   [][]byte
@@ -174,13 +185,14 @@ type options struct {
 // NewTypesOptions returns a default TypesOptions.
 func NewTypesOptions() TypesOptions {
 	o := &options{
-		defaultCounterAggregationTypes: defaultDefaultCounterAggregationTypes,
-		defaultGaugeAggregationTypes:   defaultDefaultGaugeAggregationTypes,
-		defaultTimerAggregationTypes:   defaultDefaultTimerAggregationTypes,
-		quantileTypeStringFn:           defaultQuantileTypeStringFn,
-		counterTypeStringTransformFn:   NoOpTransform,
-		timerTypeStringTransformFn:     NoOpTransform,
-		gaugeTypeStringTransformFn:     NoOpTransform,
+		defaultCounterAggregationTypes:   defaultDefaultCounterAggregationTypes,
+		defaultGaugeAggregationTypes:     defaultDefaultGaugeAggregationTypes,
+		defaultTimerAggregationTypes:     defaultDefaultTimerAggregationTypes,
+		defaultHistogramAggregationTypes: defaultDefaultHistogramAggregationTypes,
+		quantileTypeStringFn:             defaultQuantileTypeStringFn,
+		counterTypeStringTransformFn:     NoOpTransform,
+		timerTypeStringTransformFn:       NoOpTransform,
+		gaugeTypeStringTransformFn:       NoOpTransform,
 	}
 	o.initPools()
 	o.computeAllDerived()
@@ -230,6 +242,16 @@ func (o *options) DefaultGaugeAggregationTypes() Types {
 	return o.defaultGaugeAggregationTypes
 }
 
+func (o *options) SetDefaultHistogramAggregationTypes(aggTypes Types) TypesOptions {
+	opts := *o
+	opts.defaultHistogramAggregationTypes = aggTypes
+	return &opts
+}
+
+func (o *options) DefaultHistogramAggregationTypes() Types {
+	return o.defaultHistogramAggregationTypes
+}
+
 func (o *options) SetQuantileTypeStringFn(value QuantileTypeStringFn) TypesOptions {
 	opts := *o
 	opts.quantileTypeStringFn = value
@@ -331,6 +353,8 @@ func (o *options) IsContainedInDefaultAggregationTypes(at Type, mt metric.Type)
 		aggTypes = o.DefaultGaugeAggregationTypes()
 	case metric.TimerType:
 		aggTypes = o.DefaultTimerAggregationTypes()
+	case metric.HistogramType:
+		aggTypes = o.DefaultHistogramAggregationTypes()
 	}
 	return aggTypes.Contains(at)
 }
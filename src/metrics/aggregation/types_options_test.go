@@ -36,6 +36,7 @@ func TestTypesOptionsValidateDefault(t *testing.T) {
 	require.Equal(t, defaultDefaultCounterAggregationTypes, o.DefaultCounterAggregationTypes())
 	require.Equal(t, defaultDefaultTimerAggregationTypes, o.DefaultTimerAggregationTypes())
 	require.Equal(t, defaultDefaultGaugeAggregationTypes, o.DefaultGaugeAggregationTypes())
+	require.Equal(t, defaultDefaultHistogramAggregationTypes, o.DefaultHistogramAggregationTypes())
 	require.NotNil(t, o.QuantileTypeStringFn())
 	require.NotNil(t, o.CounterTypeStringTransformFn())
 	require.NotNil(t, o.TimerTypeStringTransformFn())
@@ -71,6 +72,12 @@ func TestOptionsSetDefaultGaugeAggregationTypes(t *testing.T) {
 	require.Equal(t, typeStrings(nil), o.(*options).gaugeTypeStrings)
 }
 
+func TestOptionsSetDefaultHistogramAggregationTypes(t *testing.T) {
+	aggTypes := Types{Histogram, P99}
+	o := NewTypesOptions().SetDefaultHistogramAggregationTypes(aggTypes)
+	require.Equal(t, aggTypes, o.DefaultHistogramAggregationTypes())
+}
+
 func TestOptionsSetTimerQuantileTypeStringFn(t *testing.T) {
 	fn := func(q float64) []byte { return []byte(fmt.Sprintf("%1.2f", q)) }
 	o := NewTypesOptions().SetQuantileTypeStringFn(fn)
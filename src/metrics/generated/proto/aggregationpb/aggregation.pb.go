@@ -22,13 +22,13 @@
 // THE SOFTWARE.
 
 /*
-Package aggregationpb is a generated protocol buffer package.
+	Package aggregationpb is a generated protocol buffer package.
 
-It is generated from these files:
-	github.com/m3db/m3/src/metrics/generated/proto/aggregationpb/aggregation.proto
+	It is generated from these files:
+		github.com/m3db/m3/src/metrics/generated/proto/aggregationpb/aggregation.proto
 
-It has these top-level messages:
-	AggregationID
+	It has these top-level messages:
+		AggregationID
 */
 package aggregationpb
 
@@ -52,31 +52,32 @@ const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 type AggregationType int32
 
 const (
-	AggregationType_UNKNOWN AggregationType = 0
-	AggregationType_LAST    AggregationType = 1
-	AggregationType_MIN     AggregationType = 2
-	AggregationType_MAX     AggregationType = 3
-	AggregationType_MEAN    AggregationType = 4
-	AggregationType_MEDIAN  AggregationType = 5
-	AggregationType_COUNT   AggregationType = 6
-	AggregationType_SUM     AggregationType = 7
-	AggregationType_SUMSQ   AggregationType = 8
-	AggregationType_STDEV   AggregationType = 9
-	AggregationType_P10     AggregationType = 10
-	AggregationType_P20     AggregationType = 11
-	AggregationType_P30     AggregationType = 12
-	AggregationType_P40     AggregationType = 13
-	AggregationType_P50     AggregationType = 14
-	AggregationType_P60     AggregationType = 15
-	AggregationType_P70     AggregationType = 16
-	AggregationType_P80     AggregationType = 17
-	AggregationType_P90     AggregationType = 18
-	AggregationType_P95     AggregationType = 19
-	AggregationType_P99     AggregationType = 20
-	AggregationType_P999    AggregationType = 21
-	AggregationType_P9999   AggregationType = 22
-	AggregationType_P25     AggregationType = 23
-	AggregationType_P75     AggregationType = 24
+	AggregationType_UNKNOWN   AggregationType = 0
+	AggregationType_LAST      AggregationType = 1
+	AggregationType_MIN       AggregationType = 2
+	AggregationType_MAX       AggregationType = 3
+	AggregationType_MEAN      AggregationType = 4
+	AggregationType_MEDIAN    AggregationType = 5
+	AggregationType_COUNT     AggregationType = 6
+	AggregationType_SUM       AggregationType = 7
+	AggregationType_SUMSQ     AggregationType = 8
+	AggregationType_STDEV     AggregationType = 9
+	AggregationType_P10       AggregationType = 10
+	AggregationType_P20       AggregationType = 11
+	AggregationType_P30       AggregationType = 12
+	AggregationType_P40       AggregationType = 13
+	AggregationType_P50       AggregationType = 14
+	AggregationType_P60       AggregationType = 15
+	AggregationType_P70       AggregationType = 16
+	AggregationType_P80       AggregationType = 17
+	AggregationType_P90       AggregationType = 18
+	AggregationType_P95       AggregationType = 19
+	AggregationType_P99       AggregationType = 20
+	AggregationType_P999      AggregationType = 21
+	AggregationType_P9999     AggregationType = 22
+	AggregationType_P25       AggregationType = 23
+	AggregationType_P75       AggregationType = 24
+	AggregationType_HISTOGRAM AggregationType = 25
 )
 
 var AggregationType_name = map[int32]string{
@@ -105,33 +106,35 @@ var AggregationType_name = map[int32]string{
 	22: "P9999",
 	23: "P25",
 	24: "P75",
+	25: "HISTOGRAM",
 }
 var AggregationType_value = map[string]int32{
-	"UNKNOWN": 0,
-	"LAST":    1,
-	"MIN":     2,
-	"MAX":     3,
-	"MEAN":    4,
-	"MEDIAN":  5,
-	"COUNT":   6,
-	"SUM":     7,
-	"SUMSQ":   8,
-	"STDEV":   9,
-	"P10":     10,
-	"P20":     11,
-	"P30":     12,
-	"P40":     13,
-	"P50":     14,
-	"P60":     15,
-	"P70":     16,
-	"P80":     17,
-	"P90":     18,
-	"P95":     19,
-	"P99":     20,
-	"P999":    21,
-	"P9999":   22,
-	"P25":     23,
-	"P75":     24,
+	"UNKNOWN":   0,
+	"LAST":      1,
+	"MIN":       2,
+	"MAX":       3,
+	"MEAN":      4,
+	"MEDIAN":    5,
+	"COUNT":     6,
+	"SUM":       7,
+	"SUMSQ":     8,
+	"STDEV":     9,
+	"P10":       10,
+	"P20":       11,
+	"P30":       12,
+	"P40":       13,
+	"P50":       14,
+	"P60":       15,
+	"P70":       16,
+	"P80":       17,
+	"P90":       18,
+	"P95":       19,
+	"P99":       20,
+	"P999":      21,
+	"P9999":     22,
+	"P25":       23,
+	"P75":       24,
+	"HISTOGRAM": 25,
 }
 
 func (x AggregationType) String() string {
@@ -394,26 +397,26 @@ func init() {
 }
 
 var fileDescriptorAggregation = []byte{
-	// 328 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0xd1, 0xbb, 0x4e, 0xc3, 0x30,
-	0x14, 0x06, 0xe0, 0x26, 0xbd, 0xbb, 0xb4, 0x3d, 0x98, 0x5b, 0xa7, 0x80, 0x98, 0x10, 0x43, 0x6d,
-	0x08, 0x01, 0x22, 0xb1, 0x04, 0xda, 0xa1, 0x82, 0xb8, 0x85, 0x24, 0x80, 0xd8, 0x9a, 0xc6, 0x0a,
-	0x19, 0xd2, 0x54, 0x69, 0x18, 0xd8, 0x79, 0x00, 0x1e, 0x8b, 0x91, 0x47, 0x40, 0xe1, 0x45, 0x90,
-	0x9d, 0x81, 0x32, 0xb3, 0x7d, 0x3e, 0xff, 0x2f, 0x1f, 0x4b, 0x46, 0x2c, 0x8c, 0xb2, 0xe7, 0x17,
-	0xbf, 0x3f, 0x4b, 0x62, 0x12, 0xeb, 0x81, 0x4f, 0x62, 0x9d, 0x2c, 0xd3, 0x19, 0x89, 0x79, 0x96,
-	0x46, 0xb3, 0x25, 0x09, 0xf9, 0x9c, 0xa7, 0xd3, 0x8c, 0x07, 0x64, 0x91, 0x26, 0x59, 0x42, 0xa6,
-	0x61, 0x98, 0xf2, 0x70, 0x9a, 0x45, 0xc9, 0x7c, 0xe1, 0xaf, 0x9e, 0xfa, 0x32, 0xc7, 0xed, 0x3f,
-	0x85, 0xfd, 0x5d, 0xd4, 0xb6, 0x7e, 0x07, 0xa3, 0x01, 0xee, 0x20, 0x35, 0x0a, 0x7a, 0xca, 0x9e,
-	0x72, 0x50, 0xb9, 0x53, 0xa3, 0xe0, 0xf0, 0x4d, 0x45, 0xdd, 0x95, 0x86, 0xfb, 0xba, 0xe0, 0xb8,
-	0x85, 0xea, 0x1e, 0xbb, 0x66, 0xe3, 0x07, 0x06, 0x25, 0xdc, 0x40, 0x95, 0x1b, 0xcb, 0x71, 0x41,
-	0xc1, 0x75, 0x54, 0xb6, 0x47, 0x0c, 0x54, 0x09, 0xeb, 0x11, 0xca, 0x22, 0xb3, 0x87, 0x16, 0x83,
-	0x0a, 0x46, 0xa8, 0x66, 0x0f, 0x07, 0x23, 0x8b, 0x41, 0x15, 0x37, 0x51, 0xf5, 0x6a, 0xec, 0x31,
-	0x17, 0x6a, 0xa2, 0xe9, 0x78, 0x36, 0xd4, 0xc5, 0xcc, 0xf1, 0x6c, 0xe7, 0x16, 0x1a, 0x92, 0xee,
-	0x60, 0x78, 0x0f, 0x4d, 0x11, 0x4f, 0x8e, 0x28, 0x20, 0x89, 0x63, 0x0a, 0x2d, 0x09, 0x9d, 0xc2,
-	0x9a, 0xc4, 0x09, 0x85, 0xb6, 0x84, 0x41, 0xa1, 0x23, 0x71, 0x4a, 0xa1, 0x2b, 0x71, 0x46, 0x01,
-	0x24, 0xce, 0x29, 0xac, 0x4b, 0x98, 0x14, 0x70, 0x01, 0x03, 0x36, 0x0a, 0x98, 0xb0, 0x29, 0x9e,
-	0x38, 0x31, 0x4d, 0x13, 0xb6, 0xc4, 0x5e, 0x21, 0x13, 0xb6, 0x8b, 0x75, 0x06, 0xec, 0x14, 0x57,
-	0x19, 0xd0, 0xbb, 0x64, 0x1f, 0xb9, 0xa6, 0x7c, 0xe6, 0x9a, 0xf2, 0x95, 0x6b, 0xca, 0xfb, 0xb7,
-	0x56, 0x7a, 0xba, 0xf8, 0xcf, 0xc7, 0xf8, 0x35, 0x39, 0xd4, 0x7f, 0x02, 0x00, 0x00, 0xff, 0xff,
-	0xe6, 0x7e, 0xf0, 0xe7, 0xdf, 0x01, 0x00, 0x00,
+	// 333 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0xd1, 0xcb, 0x4e, 0x83, 0x40,
+	0x14, 0x06, 0x60, 0xa1, 0xf7, 0xa9, 0xb4, 0xc7, 0xf1, 0x56, 0x37, 0xd5, 0xb8, 0x32, 0x2e, 0x3a,
+	0xa3, 0x88, 0x4a, 0xe2, 0x06, 0x6d, 0xa3, 0xa8, 0x4c, 0x6b, 0x01, 0x35, 0xee, 0x4a, 0x21, 0xc8,
+	0x82, 0xd2, 0x50, 0x5c, 0xf8, 0x1c, 0xbe, 0x94, 0x4b, 0x1f, 0xc1, 0xf0, 0x24, 0x66, 0x86, 0x85,
+	0x75, 0xed, 0xee, 0x9b, 0xf3, 0xff, 0x99, 0x33, 0xc9, 0x20, 0x16, 0x46, 0xd9, 0xeb, 0x9b, 0xd7,
+	0x9b, 0x26, 0x31, 0x89, 0x55, 0xdf, 0x23, 0xb1, 0x4a, 0x16, 0xe9, 0x94, 0xc4, 0x41, 0x96, 0x46,
+	0xd3, 0x05, 0x09, 0x83, 0x59, 0x90, 0x4e, 0xb2, 0xc0, 0x27, 0xf3, 0x34, 0xc9, 0x12, 0x32, 0x09,
+	0xc3, 0x34, 0x08, 0x27, 0x59, 0x94, 0xcc, 0xe6, 0xde, 0xf2, 0xa9, 0x27, 0x72, 0xac, 0xfc, 0x29,
+	0xec, 0xef, 0x22, 0xc5, 0xf8, 0x1d, 0x98, 0x7d, 0xdc, 0x42, 0x72, 0xe4, 0x77, 0xa4, 0x3d, 0xe9,
+	0xa0, 0x3c, 0x96, 0x23, 0xff, 0xf0, 0x43, 0x46, 0xed, 0xa5, 0x86, 0xf3, 0x3e, 0x0f, 0x70, 0x13,
+	0xd5, 0x5c, 0x76, 0xc7, 0x86, 0x4f, 0x0c, 0x56, 0x70, 0x1d, 0x95, 0xef, 0x0d, 0xdb, 0x01, 0x09,
+	0xd7, 0x50, 0xc9, 0x32, 0x19, 0xc8, 0x02, 0xc6, 0x33, 0x94, 0x78, 0x66, 0x0d, 0x0c, 0x06, 0x65,
+	0x8c, 0x50, 0xd5, 0x1a, 0xf4, 0x4d, 0x83, 0x41, 0x05, 0x37, 0x50, 0xe5, 0x6a, 0xe8, 0x32, 0x07,
+	0xaa, 0xbc, 0x69, 0xbb, 0x16, 0xd4, 0xf8, 0xcc, 0x76, 0x2d, 0xfb, 0x01, 0xea, 0x82, 0x4e, 0x7f,
+	0xf0, 0x08, 0x0d, 0x1e, 0x8f, 0x8e, 0x28, 0x20, 0x81, 0x63, 0x0a, 0x4d, 0x01, 0x95, 0xc2, 0xaa,
+	0xc0, 0x09, 0x05, 0x45, 0x40, 0xa3, 0xd0, 0x12, 0x38, 0xa5, 0xd0, 0x16, 0x38, 0xa3, 0x00, 0x02,
+	0xe7, 0x14, 0xd6, 0x04, 0x74, 0x0a, 0xb8, 0x80, 0x06, 0xeb, 0x05, 0x74, 0xd8, 0xe0, 0x4f, 0x1c,
+	0xe9, 0xba, 0x0e, 0x9b, 0x7c, 0x2f, 0x97, 0x0e, 0x5b, 0xc5, 0x3a, 0x0d, 0xb6, 0x8b, 0xab, 0x34,
+	0xe8, 0x60, 0x05, 0x35, 0x6e, 0x4c, 0xdb, 0x19, 0x5e, 0x8f, 0x0d, 0x0b, 0x76, 0x2e, 0x6f, 0x3f,
+	0xf3, 0xae, 0xf4, 0x95, 0x77, 0xa5, 0xef, 0xbc, 0x2b, 0xbd, 0x5c, 0xfc, 0xe7, 0x8f, 0xbc, 0xaa,
+	0x18, 0xaa, 0x3f, 0x03, 0x00, 0x2b, 0x8c, 0x92, 0x30, 0xea, 0x01, 0x00, 0x00,
 }
@@ -39,10 +39,11 @@ var _ = math.Inf
 type MetricType int32
 
 const (
-	MetricType_UNKNOWN MetricType = 0
-	MetricType_COUNTER MetricType = 1
-	MetricType_TIMER   MetricType = 2
-	MetricType_GAUGE   MetricType = 3
+	MetricType_UNKNOWN   MetricType = 0
+	MetricType_COUNTER   MetricType = 1
+	MetricType_TIMER     MetricType = 2
+	MetricType_GAUGE     MetricType = 3
+	MetricType_HISTOGRAM MetricType = 4
 )
 
 var MetricType_name = map[int32]string{
@@ -50,12 +51,14 @@ var MetricType_name = map[int32]string{
 	1: "COUNTER",
 	2: "TIMER",
 	3: "GAUGE",
+	4: "HISTOGRAM",
 }
 var MetricType_value = map[string]int32{
-	"UNKNOWN": 0,
-	"COUNTER": 1,
-	"TIMER":   2,
-	"GAUGE":   3,
+	"UNKNOWN":   0,
+	"COUNTER":   1,
+	"TIMER":     2,
+	"GAUGE":     3,
+	"HISTOGRAM": 4,
 }
 
 func (x MetricType) String() string {
@@ -1858,33 +1861,33 @@ func init() {
 }
 
 var fileDescriptorMetric = []byte{
-	// 444 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x53, 0xc1, 0x6e, 0xd3, 0x40,
-	0x14, 0xec, 0xda, 0x4e, 0x42, 0x5f, 0x4a, 0x1b, 0x56, 0x15, 0xf2, 0x05, 0x13, 0xe5, 0x64, 0xf5,
-	0x60, 0x4b, 0xe4, 0xc0, 0x85, 0x0b, 0x2d, 0x21, 0xaa, 0x50, 0x5d, 0xc9, 0x72, 0x40, 0xe2, 0x12,
-	0xad, 0xed, 0x95, 0xbb, 0x52, 0xbd, 0x6b, 0xad, 0xd7, 0x41, 0x11, 0x17, 0x3e, 0x81, 0x0f, 0xe0,
-	0x83, 0x38, 0xf2, 0x03, 0x48, 0x28, 0xfc, 0x08, 0xf2, 0xda, 0x26, 0x85, 0x8a, 0x1e, 0x50, 0x73,
-	0x7b, 0x33, 0xbb, 0x6f, 0x67, 0xc6, 0x23, 0xc3, 0xab, 0x8c, 0xa9, 0xab, 0x2a, 0xf6, 0x12, 0x91,
-	0xfb, 0xf9, 0x34, 0x8d, 0xfd, 0x7c, 0xea, 0x97, 0x32, 0xf1, 0x73, 0xaa, 0x24, 0x4b, 0x4a, 0x3f,
-	0xa3, 0x9c, 0x4a, 0xa2, 0x68, 0xea, 0x17, 0x52, 0x28, 0xd1, 0xf2, 0x45, 0xdc, 0x0e, 0x9e, 0x66,
-	0xf1, 0x83, 0x8e, 0x9e, 0x7c, 0x84, 0xc1, 0x99, 0xa8, 0xb8, 0xa2, 0x12, 0x1f, 0x82, 0xc1, 0x52,
-	0x1b, 0x8d, 0x91, 0x7b, 0x10, 0x1a, 0x2c, 0xc5, 0xc7, 0xd0, 0x5b, 0x91, 0xeb, 0x8a, 0xda, 0xc6,
-	0x18, 0xb9, 0x66, 0xd8, 0x00, 0xec, 0x00, 0x10, 0xce, 0x85, 0x22, 0x8a, 0x09, 0x6e, 0x9b, 0xfa,
-	0xf6, 0x0d, 0x06, 0x9f, 0xc0, 0xa3, 0xe4, 0x9a, 0x51, 0xae, 0x96, 0x8a, 0xe5, 0x74, 0xc9, 0x09,
-	0x17, 0xa5, 0x6d, 0xe9, 0x17, 0x8e, 0x9a, 0x83, 0x88, 0xe5, 0x34, 0xa8, 0xe9, 0xc9, 0x27, 0x04,
-	0x70, 0x4a, 0x54, 0x72, 0x55, 0x53, 0xb7, 0x0d, 0x3c, 0x86, 0xbe, 0xd6, 0x2c, 0x6d, 0x63, 0x6c,
-	0xba, 0x28, 0x6c, 0xd1, 0xbd, 0x5a, 0x58, 0x43, 0x6f, 0x4e, 0xaa, 0x8c, 0xde, 0x9d, 0x1e, 0xed,
-	0x22, 0xfd, 0x17, 0x04, 0xc3, 0x1a, 0xa5, 0x17, 0xba, 0x0c, 0xec, 0x82, 0xa5, 0xd6, 0x05, 0xd5,
-	0x1e, 0x0e, 0x9f, 0x1d, 0x7b, 0x5d, 0x47, 0x5e, 0x73, 0x1e, 0xad, 0x0b, 0x1a, 0xea, 0x1b, 0xad,
-	0x57, 0xe3, 0xb7, 0xd7, 0x27, 0x00, 0x37, 0xe4, 0x4c, 0x2d, 0xb7, 0xaf, 0x3a, 0xa1, 0x6d, 0x14,
-	0xeb, 0xdf, 0x51, 0x7a, 0x7f, 0x47, 0x99, 0x7c, 0x47, 0x70, 0xf4, 0x5a, 0xc8, 0x0f, 0x44, 0xa6,
-	0xbb, 0xb7, 0xb8, 0xad, 0xda, 0xba, 0xa3, 0xea, 0x5b, 0x26, 0xf1, 0x53, 0x18, 0x16, 0x92, 0xae,
-	0x96, 0xed, 0x72, 0x5f, 0x2f, 0x43, 0x4d, 0xbd, 0x6d, 0x1e, 0xb0, 0x61, 0xb0, 0xa2, 0xb2, 0xac,
-	0xb7, 0x07, 0x63, 0xe4, 0x3e, 0x0c, 0x3b, 0x38, 0xf1, 0xc1, 0x8c, 0x48, 0x86, 0x31, 0x58, 0x9c,
-	0xe4, 0xb4, 0x6d, 0x5e, 0xcf, 0x7f, 0x76, 0x7f, 0xd0, 0x7e, 0xb0, 0x93, 0x17, 0x00, 0xdb, 0x98,
-	0x78, 0x08, 0x83, 0x45, 0xf0, 0x26, 0xb8, 0x7c, 0x17, 0x8c, 0xf6, 0x6a, 0x70, 0x76, 0xb9, 0x08,
-	0xa2, 0x59, 0x38, 0x42, 0x78, 0x1f, 0x7a, 0xd1, 0xf9, 0xc5, 0x2c, 0x1c, 0x19, 0xf5, 0x38, 0x7f,
-	0xb9, 0x98, 0xcf, 0x46, 0xe6, 0xe9, 0xf9, 0xd7, 0x8d, 0x83, 0xbe, 0x6d, 0x1c, 0xf4, 0x63, 0xe3,
-	0xa0, 0xcf, 0x3f, 0x9d, 0xbd, 0xf7, 0xcf, 0xff, 0xf3, 0x57, 0x8e, 0xfb, 0x1a, 0x4f, 0x7f, 0x05,
-	0x00, 0x00, 0xff, 0xff, 0x42, 0xaf, 0x37, 0x39, 0x0c, 0x04, 0x00, 0x00,
+	// 447 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x53, 0x4f, 0x6f, 0xd3, 0x30,
+	0x1c, 0xc5, 0x49, 0xda, 0xd2, 0x5f, 0xf7, 0x27, 0x58, 0x13, 0xca, 0x85, 0x52, 0xf5, 0x14, 0xed,
+	0xd0, 0x48, 0xf4, 0xc0, 0x79, 0x1b, 0xa5, 0x4c, 0x53, 0x53, 0xc9, 0xa4, 0x20, 0x71, 0xa9, 0xdc,
+	0xc4, 0xea, 0x2c, 0x2d, 0x76, 0xe4, 0x38, 0x45, 0x15, 0x17, 0x3e, 0x08, 0x1f, 0x88, 0x23, 0x5f,
+	0x00, 0x09, 0xf5, 0x93, 0xa0, 0x38, 0x09, 0x1d, 0x4c, 0xec, 0x80, 0xd8, 0xed, 0xf7, 0x9e, 0xfd,
+	0xf3, 0x7b, 0x2f, 0x4f, 0x81, 0x57, 0x6b, 0xae, 0xaf, 0x8b, 0xd5, 0x28, 0x96, 0x69, 0x90, 0x8e,
+	0x93, 0x55, 0x90, 0x8e, 0x83, 0x5c, 0xc5, 0x41, 0xca, 0xb4, 0xe2, 0x71, 0x1e, 0xac, 0x99, 0x60,
+	0x8a, 0x6a, 0x96, 0x04, 0x99, 0x92, 0x5a, 0xd6, 0x7c, 0xb6, 0xaa, 0x87, 0x91, 0x61, 0xf1, 0xe3,
+	0x86, 0x1e, 0x7e, 0x82, 0xce, 0x85, 0x2c, 0x84, 0x66, 0x0a, 0x1f, 0x81, 0xc5, 0x13, 0x0f, 0x0d,
+	0x90, 0x7f, 0x40, 0x2c, 0x9e, 0xe0, 0x13, 0x68, 0x6d, 0xe8, 0x4d, 0xc1, 0x3c, 0x6b, 0x80, 0x7c,
+	0x9b, 0x54, 0x00, 0xf7, 0x01, 0xa8, 0x10, 0x52, 0x53, 0xcd, 0xa5, 0xf0, 0x6c, 0x73, 0xfb, 0x16,
+	0x83, 0x4f, 0xe1, 0x49, 0x7c, 0xc3, 0x99, 0xd0, 0x4b, 0xcd, 0x53, 0xb6, 0x14, 0x54, 0xc8, 0xdc,
+	0x73, 0xcc, 0x0b, 0xc7, 0xd5, 0x41, 0xc4, 0x53, 0x16, 0x96, 0xf4, 0xf0, 0x33, 0x02, 0x38, 0xa7,
+	0x3a, 0xbe, 0x2e, 0xa9, 0xbb, 0x06, 0x9e, 0x42, 0xdb, 0x68, 0xe6, 0x9e, 0x35, 0xb0, 0x7d, 0x44,
+	0x6a, 0xf4, 0x5f, 0x2d, 0x6c, 0xa1, 0x35, 0xa5, 0xc5, 0x9a, 0xdd, 0x9f, 0x1e, 0x3d, 0x44, 0xfa,
+	0x2f, 0x08, 0x7a, 0x25, 0x4a, 0x66, 0xa6, 0x0c, 0xec, 0x83, 0xa3, 0xb7, 0x19, 0x33, 0x1e, 0x8e,
+	0x5e, 0x9c, 0x8c, 0x9a, 0x8e, 0x46, 0xd5, 0x79, 0xb4, 0xcd, 0x18, 0x31, 0x37, 0x6a, 0xaf, 0xd6,
+	0x2f, 0xaf, 0xcf, 0x00, 0x6e, 0xc9, 0xd9, 0x46, 0xae, 0xab, 0x1b, 0xa1, 0x7d, 0x14, 0xe7, 0xef,
+	0x51, 0x5a, 0x7f, 0x46, 0x19, 0x7e, 0x47, 0x70, 0xfc, 0x5a, 0xaa, 0x8f, 0x54, 0x25, 0x0f, 0x6f,
+	0x71, 0x5f, 0xb5, 0x73, 0x4f, 0xd5, 0x77, 0x4c, 0xe2, 0xe7, 0xd0, 0xcb, 0x14, 0xdb, 0x2c, 0xeb,
+	0xe5, 0xb6, 0x59, 0x86, 0x92, 0x7a, 0x57, 0x3d, 0xe0, 0x41, 0x67, 0xc3, 0x54, 0x5e, 0x6e, 0x77,
+	0x06, 0xc8, 0x3f, 0x24, 0x0d, 0x1c, 0x06, 0x60, 0x47, 0x74, 0x8d, 0x31, 0x38, 0x82, 0xa6, 0xac,
+	0x6e, 0xde, 0xcc, 0xbf, 0x77, 0x7f, 0x50, 0x7f, 0xb0, 0xd3, 0x2b, 0x80, 0x7d, 0x4c, 0xdc, 0x83,
+	0xce, 0x22, 0xbc, 0x0a, 0xe7, 0xef, 0x43, 0xf7, 0x51, 0x09, 0x2e, 0xe6, 0x8b, 0x30, 0x9a, 0x10,
+	0x17, 0xe1, 0x2e, 0xb4, 0xa2, 0xcb, 0xd9, 0x84, 0xb8, 0x56, 0x39, 0x4e, 0xcf, 0x16, 0xd3, 0x89,
+	0x6b, 0xe3, 0x43, 0xe8, 0xbe, 0xb9, 0x7c, 0x1b, 0xcd, 0xa7, 0xe4, 0x6c, 0xe6, 0x3a, 0xe7, 0x93,
+	0xaf, 0xbb, 0x3e, 0xfa, 0xb6, 0xeb, 0xa3, 0x1f, 0xbb, 0x3e, 0xfa, 0xf0, 0xf2, 0x1f, 0xff, 0xea,
+	0x55, 0xdb, 0xe0, 0xf1, 0xcf, 0x01, 0x00, 0xc4, 0xf5, 0x18, 0x08, 0x17, 0x04, 0x00, 0x00,
 }
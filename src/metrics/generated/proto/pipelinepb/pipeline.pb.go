@@ -31,6 +31,7 @@
 		AggregationOp
 		TransformationOp
 		RollupOp
+		RelabelOp
 		PipelineOp
 		Pipeline
 		AppliedRollupOp
@@ -80,6 +81,39 @@ func (x RollupOp_Type) String() string {
 }
 func (RollupOp_Type) EnumDescriptor() ([]byte, []int) { return fileDescriptorPipeline, []int{2, 0} }
 
+type RelabelOp_Action int32
+
+const (
+	RelabelOp_UNKNOWN    RelabelOp_Action = 0
+	RelabelOp_REPLACE    RelabelOp_Action = 1
+	RelabelOp_LABEL_MAP  RelabelOp_Action = 2
+	RelabelOp_LABEL_DROP RelabelOp_Action = 3
+	RelabelOp_LABEL_KEEP RelabelOp_Action = 4
+	RelabelOp_HASH_MOD   RelabelOp_Action = 5
+)
+
+var RelabelOp_Action_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "REPLACE",
+	2: "LABEL_MAP",
+	3: "LABEL_DROP",
+	4: "LABEL_KEEP",
+	5: "HASH_MOD",
+}
+var RelabelOp_Action_value = map[string]int32{
+	"UNKNOWN":    0,
+	"REPLACE":    1,
+	"LABEL_MAP":  2,
+	"LABEL_DROP": 3,
+	"LABEL_KEEP": 4,
+	"HASH_MOD":   5,
+}
+
+func (x RelabelOp_Action) String() string {
+	return proto.EnumName(RelabelOp_Action_name, int32(x))
+}
+func (RelabelOp_Action) EnumDescriptor() ([]byte, []int) { return fileDescriptorPipeline, []int{3, 0} }
+
 type PipelineOp_Type int32
 
 const (
@@ -87,6 +121,7 @@ const (
 	PipelineOp_AGGREGATION    PipelineOp_Type = 1
 	PipelineOp_TRANSFORMATION PipelineOp_Type = 2
 	PipelineOp_ROLLUP         PipelineOp_Type = 3
+	PipelineOp_RELABEL        PipelineOp_Type = 4
 )
 
 var PipelineOp_Type_name = map[int32]string{
@@ -94,18 +129,20 @@ var PipelineOp_Type_name = map[int32]string{
 	1: "AGGREGATION",
 	2: "TRANSFORMATION",
 	3: "ROLLUP",
+	4: "RELABEL",
 }
 var PipelineOp_Type_value = map[string]int32{
 	"UNKNOWN":        0,
 	"AGGREGATION":    1,
 	"TRANSFORMATION": 2,
 	"ROLLUP":         3,
+	"RELABEL":        4,
 }
 
 func (x PipelineOp_Type) String() string {
 	return proto.EnumName(PipelineOp_Type_name, int32(x))
 }
-func (PipelineOp_Type) EnumDescriptor() ([]byte, []int) { return fileDescriptorPipeline, []int{3, 0} }
+func (PipelineOp_Type) EnumDescriptor() ([]byte, []int) { return fileDescriptorPipeline, []int{4, 0} }
 
 type AppliedPipelineOp_Type int32
 
@@ -130,7 +167,7 @@ func (x AppliedPipelineOp_Type) String() string {
 	return proto.EnumName(AppliedPipelineOp_Type_name, int32(x))
 }
 func (AppliedPipelineOp_Type) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptorPipeline, []int{6, 0}
+	return fileDescriptorPipeline, []int{7, 0}
 }
 
 type AggregationOp struct {
@@ -205,17 +242,84 @@ func (m *RollupOp) GetType() RollupOp_Type {
 	return RollupOp_GROUP_BY
 }
 
+// RelabelOp rewrites the tags of a metric following the semantics of
+// Prometheus relabeling.
+type RelabelOp struct {
+	Action      RelabelOp_Action `protobuf:"varint,1,opt,name=action,proto3,enum=pipelinepb.RelabelOp_Action" json:"action,omitempty"`
+	SourceTags  []string         `protobuf:"bytes,2,rep,name=source_tags,json=sourceTags" json:"source_tags,omitempty"`
+	Separator   string           `protobuf:"bytes,3,opt,name=separator,proto3" json:"separator,omitempty"`
+	Regex       string           `protobuf:"bytes,4,opt,name=regex,proto3" json:"regex,omitempty"`
+	TargetTag   string           `protobuf:"bytes,5,opt,name=target_tag,json=targetTag,proto3" json:"target_tag,omitempty"`
+	Replacement string           `protobuf:"bytes,6,opt,name=replacement,proto3" json:"replacement,omitempty"`
+	Modulus     uint64           `protobuf:"varint,7,opt,name=modulus,proto3" json:"modulus,omitempty"`
+}
+
+func (m *RelabelOp) Reset()                    { *m = RelabelOp{} }
+func (m *RelabelOp) String() string            { return proto.CompactTextString(m) }
+func (*RelabelOp) ProtoMessage()               {}
+func (*RelabelOp) Descriptor() ([]byte, []int) { return fileDescriptorPipeline, []int{3} }
+
+func (m *RelabelOp) GetAction() RelabelOp_Action {
+	if m != nil {
+		return m.Action
+	}
+	return RelabelOp_UNKNOWN
+}
+
+func (m *RelabelOp) GetSourceTags() []string {
+	if m != nil {
+		return m.SourceTags
+	}
+	return nil
+}
+
+func (m *RelabelOp) GetSeparator() string {
+	if m != nil {
+		return m.Separator
+	}
+	return ""
+}
+
+func (m *RelabelOp) GetRegex() string {
+	if m != nil {
+		return m.Regex
+	}
+	return ""
+}
+
+func (m *RelabelOp) GetTargetTag() string {
+	if m != nil {
+		return m.TargetTag
+	}
+	return ""
+}
+
+func (m *RelabelOp) GetReplacement() string {
+	if m != nil {
+		return m.Replacement
+	}
+	return ""
+}
+
+func (m *RelabelOp) GetModulus() uint64 {
+	if m != nil {
+		return m.Modulus
+	}
+	return 0
+}
+
 type PipelineOp struct {
 	Type           PipelineOp_Type   `protobuf:"varint,1,opt,name=type,proto3,enum=pipelinepb.PipelineOp_Type" json:"type,omitempty"`
 	Aggregation    *AggregationOp    `protobuf:"bytes,2,opt,name=aggregation" json:"aggregation,omitempty"`
 	Transformation *TransformationOp `protobuf:"bytes,3,opt,name=transformation" json:"transformation,omitempty"`
 	Rollup         *RollupOp         `protobuf:"bytes,4,opt,name=rollup" json:"rollup,omitempty"`
+	Relabel        *RelabelOp        `protobuf:"bytes,5,opt,name=relabel" json:"relabel,omitempty"`
 }
 
 func (m *PipelineOp) Reset()                    { *m = PipelineOp{} }
 func (m *PipelineOp) String() string            { return proto.CompactTextString(m) }
 func (*PipelineOp) ProtoMessage()               {}
-func (*PipelineOp) Descriptor() ([]byte, []int) { return fileDescriptorPipeline, []int{3} }
+func (*PipelineOp) Descriptor() ([]byte, []int) { return fileDescriptorPipeline, []int{4} }
 
 func (m *PipelineOp) GetType() PipelineOp_Type {
 	if m != nil {
@@ -245,6 +349,13 @@ func (m *PipelineOp) GetRollup() *RollupOp {
 	return nil
 }
 
+func (m *PipelineOp) GetRelabel() *RelabelOp {
+	if m != nil {
+		return m.Relabel
+	}
+	return nil
+}
+
 type Pipeline struct {
 	Ops []PipelineOp `protobuf:"bytes,1,rep,name=ops" json:"ops"`
 }
@@ -252,7 +363,7 @@ type Pipeline struct {
 func (m *Pipeline) Reset()                    { *m = Pipeline{} }
 func (m *Pipeline) String() string            { return proto.CompactTextString(m) }
 func (*Pipeline) ProtoMessage()               {}
-func (*Pipeline) Descriptor() ([]byte, []int) { return fileDescriptorPipeline, []int{4} }
+func (*Pipeline) Descriptor() ([]byte, []int) { return fileDescriptorPipeline, []int{5} }
 
 func (m *Pipeline) GetOps() []PipelineOp {
 	if m != nil {
@@ -271,7 +382,7 @@ type AppliedRollupOp struct {
 func (m *AppliedRollupOp) Reset()                    { *m = AppliedRollupOp{} }
 func (m *AppliedRollupOp) String() string            { return proto.CompactTextString(m) }
 func (*AppliedRollupOp) ProtoMessage()               {}
-func (*AppliedRollupOp) Descriptor() ([]byte, []int) { return fileDescriptorPipeline, []int{5} }
+func (*AppliedRollupOp) Descriptor() ([]byte, []int) { return fileDescriptorPipeline, []int{6} }
 
 func (m *AppliedRollupOp) GetId() []byte {
 	if m != nil {
@@ -298,7 +409,7 @@ type AppliedPipelineOp struct {
 func (m *AppliedPipelineOp) Reset()                    { *m = AppliedPipelineOp{} }
 func (m *AppliedPipelineOp) String() string            { return proto.CompactTextString(m) }
 func (*AppliedPipelineOp) ProtoMessage()               {}
-func (*AppliedPipelineOp) Descriptor() ([]byte, []int) { return fileDescriptorPipeline, []int{6} }
+func (*AppliedPipelineOp) Descriptor() ([]byte, []int) { return fileDescriptorPipeline, []int{7} }
 
 func (m *AppliedPipelineOp) GetType() AppliedPipelineOp_Type {
 	if m != nil {
@@ -330,7 +441,7 @@ type AppliedPipeline struct {
 func (m *AppliedPipeline) Reset()                    { *m = AppliedPipeline{} }
 func (m *AppliedPipeline) String() string            { return proto.CompactTextString(m) }
 func (*AppliedPipeline) ProtoMessage()               {}
-func (*AppliedPipeline) Descriptor() ([]byte, []int) { return fileDescriptorPipeline, []int{7} }
+func (*AppliedPipeline) Descriptor() ([]byte, []int) { return fileDescriptorPipeline, []int{8} }
 
 func (m *AppliedPipeline) GetOps() []AppliedPipelineOp {
 	if m != nil {
@@ -343,12 +454,14 @@ func init() {
 	proto.RegisterType((*AggregationOp)(nil), "pipelinepb.AggregationOp")
 	proto.RegisterType((*TransformationOp)(nil), "pipelinepb.TransformationOp")
 	proto.RegisterType((*RollupOp)(nil), "pipelinepb.RollupOp")
+	proto.RegisterType((*RelabelOp)(nil), "pipelinepb.RelabelOp")
 	proto.RegisterType((*PipelineOp)(nil), "pipelinepb.PipelineOp")
 	proto.RegisterType((*Pipeline)(nil), "pipelinepb.Pipeline")
 	proto.RegisterType((*AppliedRollupOp)(nil), "pipelinepb.AppliedRollupOp")
 	proto.RegisterType((*AppliedPipelineOp)(nil), "pipelinepb.AppliedPipelineOp")
 	proto.RegisterType((*AppliedPipeline)(nil), "pipelinepb.AppliedPipeline")
 	proto.RegisterEnum("pipelinepb.RollupOp_Type", RollupOp_Type_name, RollupOp_Type_value)
+	proto.RegisterEnum("pipelinepb.RelabelOp_Action", RelabelOp_Action_name, RelabelOp_Action_value)
 	proto.RegisterEnum("pipelinepb.PipelineOp_Type", PipelineOp_Type_name, PipelineOp_Type_value)
 	proto.RegisterEnum("pipelinepb.AppliedPipelineOp_Type", AppliedPipelineOp_Type_name, AppliedPipelineOp_Type_value)
 }
@@ -459,6 +572,73 @@ func (m *RollupOp) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *RelabelOp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RelabelOp) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Action != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintPipeline(dAtA, i, uint64(m.Action))
+	}
+	if len(m.SourceTags) > 0 {
+		for _, s := range m.SourceTags {
+			dAtA[i] = 0x12
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.Separator) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPipeline(dAtA, i, uint64(len(m.Separator)))
+		i += copy(dAtA[i:], m.Separator)
+	}
+	if len(m.Regex) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintPipeline(dAtA, i, uint64(len(m.Regex)))
+		i += copy(dAtA[i:], m.Regex)
+	}
+	if len(m.TargetTag) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintPipeline(dAtA, i, uint64(len(m.TargetTag)))
+		i += copy(dAtA[i:], m.TargetTag)
+	}
+	if len(m.Replacement) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintPipeline(dAtA, i, uint64(len(m.Replacement)))
+		i += copy(dAtA[i:], m.Replacement)
+	}
+	if m.Modulus != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintPipeline(dAtA, i, uint64(m.Modulus))
+	}
+	return i, nil
+}
+
 func (m *PipelineOp) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -509,6 +689,16 @@ func (m *PipelineOp) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n5
 	}
+	if m.Relabel != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintPipeline(dAtA, i, uint64(m.Relabel.Size()))
+		n6, err := m.Relabel.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n6
+	}
 	return i, nil
 }
 
@@ -566,11 +756,11 @@ func (m *AppliedRollupOp) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x12
 	i++
 	i = encodeVarintPipeline(dAtA, i, uint64(m.AggregationId.Size()))
-	n6, err := m.AggregationId.MarshalTo(dAtA[i:])
+	n7, err := m.AggregationId.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n6
+	i += n7
 	return i, nil
 }
 
@@ -597,19 +787,19 @@ func (m *AppliedPipelineOp) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x12
 	i++
 	i = encodeVarintPipeline(dAtA, i, uint64(m.Transformation.Size()))
-	n7, err := m.Transformation.MarshalTo(dAtA[i:])
+	n8, err := m.Transformation.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n7
+	i += n8
 	dAtA[i] = 0x1a
 	i++
 	i = encodeVarintPipeline(dAtA, i, uint64(m.Rollup.Size()))
-	n8, err := m.Rollup.MarshalTo(dAtA[i:])
+	n9, err := m.Rollup.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n8
+	i += n9
 	return i, nil
 }
 
@@ -696,6 +886,40 @@ func (m *RollupOp) Size() (n int) {
 	return n
 }
 
+func (m *RelabelOp) Size() (n int) {
+	var l int
+	_ = l
+	if m.Action != 0 {
+		n += 1 + sovPipeline(uint64(m.Action))
+	}
+	if len(m.SourceTags) > 0 {
+		for _, s := range m.SourceTags {
+			l = len(s)
+			n += 1 + l + sovPipeline(uint64(l))
+		}
+	}
+	l = len(m.Separator)
+	if l > 0 {
+		n += 1 + l + sovPipeline(uint64(l))
+	}
+	l = len(m.Regex)
+	if l > 0 {
+		n += 1 + l + sovPipeline(uint64(l))
+	}
+	l = len(m.TargetTag)
+	if l > 0 {
+		n += 1 + l + sovPipeline(uint64(l))
+	}
+	l = len(m.Replacement)
+	if l > 0 {
+		n += 1 + l + sovPipeline(uint64(l))
+	}
+	if m.Modulus != 0 {
+		n += 1 + sovPipeline(uint64(m.Modulus))
+	}
+	return n
+}
+
 func (m *PipelineOp) Size() (n int) {
 	var l int
 	_ = l
@@ -714,6 +938,10 @@ func (m *PipelineOp) Size() (n int) {
 		l = m.Rollup.Size()
 		n += 1 + l + sovPipeline(uint64(l))
 	}
+	if m.Relabel != nil {
+		l = m.Relabel.Size()
+		n += 1 + l + sovPipeline(uint64(l))
+	}
 	return n
 }
 
@@ -1106,6 +1334,239 @@ func (m *RollupOp) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *RelabelOp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPipeline
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RelabelOp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RelabelOp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Action", wireType)
+			}
+			m.Action = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPipeline
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Action |= (RelabelOp_Action(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SourceTags", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPipeline
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPipeline
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SourceTags = append(m.SourceTags, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Separator", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPipeline
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPipeline
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Separator = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Regex", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPipeline
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPipeline
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Regex = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TargetTag", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPipeline
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPipeline
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TargetTag = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Replacement", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPipeline
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPipeline
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Replacement = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Modulus", wireType)
+			}
+			m.Modulus = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPipeline
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Modulus |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPipeline(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPipeline
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *PipelineOp) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -1253,6 +1714,39 @@ func (m *PipelineOp) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Relabel", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPipeline
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPipeline
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Relabel == nil {
+				m.Relabel = &RelabelOp{}
+			}
+			if err := m.Relabel.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPipeline(dAtA[iNdEx:])
@@ -1705,46 +2199,58 @@ func init() {
 }
 
 var fileDescriptorPipeline = []byte{
-	// 641 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x94, 0xcb, 0x4e, 0xdb, 0x40,
-	0x14, 0x86, 0x63, 0x3b, 0x82, 0x70, 0x02, 0xc1, 0x8c, 0xaa, 0xca, 0x5c, 0x1a, 0x22, 0x8b, 0x45,
-	0x16, 0xc5, 0x96, 0x12, 0xb5, 0x2a, 0x74, 0x15, 0x08, 0x4d, 0x53, 0x52, 0x1b, 0x4d, 0x13, 0xf5,
-	0xb2, 0x41, 0x36, 0x1e, 0x5c, 0x4b, 0xb1, 0x3d, 0xb2, 0x8d, 0x10, 0x4f, 0xd0, 0x2d, 0xaf, 0xd0,
-	0x77, 0xe8, 0x43, 0xb0, 0xec, 0xbe, 0x52, 0x55, 0xd1, 0x17, 0xa9, 0x62, 0x3b, 0xc9, 0x4c, 0x48,
-	0x2f, 0x74, 0x37, 0x33, 0x3e, 0xe7, 0x3f, 0xff, 0xfc, 0xdf, 0xc8, 0xf0, 0xd2, 0xf5, 0x92, 0x8f,
-	0x17, 0xb6, 0x76, 0x16, 0xfa, 0xba, 0xdf, 0x74, 0x6c, 0xdd, 0x6f, 0xea, 0x71, 0x74, 0xa6, 0xfb,
-	0x24, 0x89, 0xbc, 0xb3, 0x58, 0x77, 0x49, 0x40, 0x22, 0x2b, 0x21, 0x8e, 0x4e, 0xa3, 0x30, 0x09,
-	0x75, 0xea, 0x51, 0x32, 0xf4, 0x02, 0x42, 0xed, 0xc9, 0x52, 0x4b, 0xbf, 0x20, 0x98, 0x7e, 0xda,
-	0xd8, 0x65, 0x54, 0xdd, 0xd0, 0x0d, 0xb3, 0x66, 0xfb, 0xe2, 0x3c, 0xdd, 0x65, 0x4a, 0xa3, 0x55,
-	0xd6, 0xba, 0x61, 0xdc, 0xd3, 0x84, 0xe5, 0xba, 0x11, 0x71, 0xad, 0xc4, 0x0b, 0x03, 0x6a, 0xb3,
-	0xbb, 0x5c, 0xaf, 0x7f, 0x4f, 0xbd, 0x24, 0xb2, 0x82, 0xf8, 0x3c, 0x8c, 0xfc, 0xb1, 0x24, 0x7f,
-	0x90, 0xa9, 0xaa, 0x87, 0xb0, 0xd2, 0x9a, 0x8e, 0x32, 0x29, 0x6a, 0x40, 0x31, 0xb9, 0xa2, 0x44,
-	0x11, 0x6a, 0x42, 0xbd, 0xd2, 0xa8, 0x6a, 0x9c, 0x2d, 0x8d, 0xa9, 0xed, 0x5f, 0x51, 0x82, 0xd3,
-	0x5a, 0xb5, 0x07, 0x72, 0x9f, 0x13, 0x37, 0x29, 0x7a, 0xc6, 0xe9, 0xec, 0x68, 0xb3, 0x76, 0x34,
-	0xbe, 0x83, 0x51, 0xfb, 0x26, 0x40, 0x09, 0x87, 0xc3, 0xe1, 0x05, 0x35, 0x29, 0x5a, 0x87, 0x52,
-	0x40, 0x2e, 0x4f, 0x03, 0xcb, 0xcf, 0xa4, 0x96, 0xf0, 0x62, 0x40, 0x2e, 0x0d, 0xcb, 0x27, 0x08,
-	0x41, 0x31, 0xb1, 0xdc, 0x58, 0x11, 0x6b, 0x52, 0x7d, 0x09, 0xa7, 0x6b, 0x74, 0x0c, 0x6b, 0x8c,
-	0xe1, 0xd3, 0x91, 0x5e, 0xac, 0x48, 0x35, 0xe9, 0x1f, 0xae, 0x22, 0x5b, 0xfc, 0x41, 0x8c, 0x76,
-	0xf3, 0x2b, 0x14, 0xd3, 0x2b, 0xac, 0x6b, 0xd3, 0xb7, 0xa0, 0x8d, 0xfd, 0x69, 0x8c, 0xef, 0x1d,
-	0x28, 0x8e, 0x76, 0x68, 0x19, 0x4a, 0x1d, 0x6c, 0x0e, 0x4e, 0x4e, 0x0f, 0xde, 0xcb, 0x05, 0x54,
-	0x01, 0x38, 0x7a, 0x77, 0xd8, 0x1b, 0xb4, 0x8f, 0x46, 0x7b, 0x41, 0xfd, 0x22, 0x02, 0x9c, 0xe4,
-	0x42, 0x26, 0x45, 0x3a, 0x17, 0xd3, 0x26, 0x3b, 0x63, 0x5a, 0xc5, 0x4c, 0x41, 0xcf, 0xa1, 0xcc,
-	0x18, 0x55, 0xc4, 0x9a, 0x50, 0x2f, 0xf3, 0xde, 0x38, 0x9e, 0x98, 0xad, 0x46, 0x6d, 0xa8, 0xf0,
-	0x1c, 0x14, 0x29, 0xed, 0xdf, 0x62, 0xfb, 0x67, 0x51, 0xe2, 0x99, 0x1e, 0xf4, 0x18, 0x16, 0xa2,
-	0xf4, 0xfe, 0x69, 0x32, 0xe5, 0xc6, 0x83, 0x79, 0xc9, 0xe0, 0xbc, 0x46, 0x6d, 0xe7, 0xb1, 0x94,
-	0x61, 0x71, 0x60, 0x1c, 0x1b, 0xe6, 0x5b, 0x43, 0x2e, 0xa0, 0x55, 0x28, 0xb7, 0x3a, 0x1d, 0x7c,
-	0xd4, 0x69, 0xf5, 0xbb, 0xa6, 0x21, 0x0b, 0x08, 0x41, 0xa5, 0x8f, 0x5b, 0xc6, 0x9b, 0x17, 0x26,
-	0x7e, 0x9d, 0x9d, 0x89, 0x08, 0x60, 0x01, 0x9b, 0xbd, 0xde, 0xe0, 0x44, 0x96, 0xd4, 0x7d, 0x28,
-	0x8d, 0xf3, 0x40, 0x1a, 0x48, 0x21, 0x8d, 0x15, 0xa1, 0x26, 0xd5, 0xcb, 0x8d, 0x87, 0xf3, 0x23,
-	0x3b, 0x28, 0xde, 0x7c, 0xdf, 0x2e, 0xe0, 0x51, 0xa1, 0x3a, 0x84, 0xd5, 0x16, 0xa5, 0x43, 0x8f,
-	0x38, 0x93, 0x67, 0x55, 0x01, 0xd1, 0x73, 0xd2, 0xd0, 0x97, 0xb1, 0xe8, 0x39, 0xa8, 0x0b, 0x15,
-	0xf6, 0xdd, 0x78, 0x4e, 0x1e, 0xec, 0xd6, 0xef, 0x1f, 0x4d, 0xb7, 0x9d, 0xcf, 0x58, 0x61, 0x4a,
-	0xba, 0x8e, 0xfa, 0x49, 0x84, 0xb5, 0x7c, 0x1c, 0xc3, 0xf9, 0x29, 0xc7, 0x59, 0xe5, 0x78, 0xcd,
-	0x16, 0xb3, 0xb8, 0x5f, 0xdd, 0x21, 0x26, 0xfe, 0x9d, 0x58, 0x6e, 0x6c, 0x96, 0xdb, 0xde, 0x84,
-	0x5b, 0x46, 0x7d, 0x73, 0x8e, 0x8b, 0x71, 0x42, 0xb9, 0xc4, 0x18, 0x62, 0x73, 0x1e, 0xc4, 0xbb,
-	0xcc, 0x04, 0x86, 0x99, 0xa8, 0x1a, 0x93, 0xdc, 0x27, 0xe8, 0x9e, 0xb0, 0xe8, 0x1e, 0xfd, 0x31,
-	0x05, 0x86, 0xe0, 0x7e, 0xf1, 0xfa, 0xf3, 0x76, 0xe1, 0xe0, 0xf8, 0xe6, 0xb6, 0x2a, 0x7c, 0xbd,
-	0xad, 0x0a, 0x3f, 0x6e, 0xab, 0xc2, 0xf5, 0xcf, 0x6a, 0xe1, 0xc3, 0xde, 0x7f, 0xff, 0xe8, 0xed,
-	0x85, 0xf4, 0xa4, 0xf9, 0x2b, 0x00, 0x00, 0xff, 0xff, 0x82, 0xb2, 0x32, 0x0e, 0x2c, 0x06, 0x00,
-	0x00,
+	// 839 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0xcb, 0x6e, 0xdb, 0x46,
+	0x14, 0x35, 0x29, 0x5a, 0xb2, 0x2e, 0x63, 0x99, 0x19, 0xa4, 0x05, 0x93, 0x38, 0xb6, 0x40, 0x64,
+	0xa1, 0x45, 0x43, 0x02, 0x72, 0x5b, 0x34, 0xe9, 0x8a, 0xb2, 0x58, 0x59, 0xb5, 0x4c, 0xaa, 0x13,
+	0x09, 0x7d, 0x6c, 0x04, 0x4a, 0x9c, 0xb0, 0x04, 0xf8, 0x18, 0x90, 0x14, 0xd2, 0x7c, 0x41, 0xb7,
+	0xfd, 0x85, 0xfe, 0x4d, 0x96, 0x45, 0xb7, 0x05, 0x8a, 0xc2, 0x9f, 0xd0, 0x2f, 0x28, 0x38, 0xa4,
+	0xac, 0xa1, 0xa2, 0x3e, 0xd2, 0x1d, 0xef, 0x9d, 0x33, 0x67, 0xee, 0x9c, 0x73, 0x06, 0x84, 0x2b,
+	0x3f, 0xc8, 0xbf, 0x5f, 0x2f, 0xf5, 0x55, 0x12, 0x19, 0xd1, 0x85, 0xb7, 0x34, 0xa2, 0x0b, 0x23,
+	0x4b, 0x57, 0x46, 0x44, 0xf2, 0x34, 0x58, 0x65, 0x86, 0x4f, 0x62, 0x92, 0xba, 0x39, 0xf1, 0x0c,
+	0x9a, 0x26, 0x79, 0x62, 0xd0, 0x80, 0x92, 0x30, 0x88, 0x09, 0x5d, 0xde, 0x7d, 0xea, 0x6c, 0x05,
+	0xc1, 0x76, 0xe9, 0xd1, 0x33, 0x8e, 0xd5, 0x4f, 0xfc, 0xa4, 0xdc, 0xbc, 0x5c, 0xbf, 0x62, 0x55,
+	0xc9, 0x54, 0x7c, 0x95, 0x5b, 0x1f, 0xd9, 0xef, 0x39, 0x84, 0xeb, 0xfb, 0x29, 0xf1, 0xdd, 0x3c,
+	0x48, 0x62, 0xba, 0xe4, 0xab, 0x8a, 0x6f, 0xf6, 0x9e, 0x7c, 0x79, 0xea, 0xc6, 0xd9, 0xab, 0x24,
+	0x8d, 0x36, 0x94, 0xf5, 0x46, 0xc9, 0xaa, 0x5d, 0xc2, 0xb1, 0xb9, 0x3d, 0xca, 0xa1, 0xa8, 0x0f,
+	0x52, 0xfe, 0x86, 0x12, 0x55, 0xe8, 0x0a, 0xbd, 0x4e, 0xff, 0x4c, 0xaf, 0x8d, 0xa5, 0x73, 0xd8,
+	0xd9, 0x1b, 0x4a, 0x30, 0xc3, 0x6a, 0x13, 0x50, 0x66, 0x35, 0x72, 0x87, 0xa2, 0xcf, 0x6a, 0x3c,
+	0x4f, 0xf5, 0xdd, 0x71, 0xf4, 0xfa, 0x0e, 0x8e, 0xed, 0x37, 0x01, 0x8e, 0x70, 0x12, 0x86, 0x6b,
+	0xea, 0x50, 0xf4, 0x10, 0x8e, 0x62, 0xf2, 0x7a, 0x11, 0xbb, 0x51, 0x49, 0xd5, 0xc6, 0xad, 0x98,
+	0xbc, 0xb6, 0xdd, 0x88, 0x20, 0x04, 0x52, 0xee, 0xfa, 0x99, 0x2a, 0x76, 0x1b, 0xbd, 0x36, 0x66,
+	0xdf, 0xe8, 0x1a, 0xee, 0x73, 0x03, 0x2f, 0x0a, 0xbe, 0x4c, 0x6d, 0x74, 0x1b, 0xff, 0xe1, 0x2a,
+	0x8a, 0x5b, 0x6f, 0x64, 0xe8, 0x59, 0x75, 0x05, 0x89, 0x5d, 0xe1, 0xa1, 0xbe, 0xcd, 0x82, 0xbe,
+	0x99, 0x4f, 0xe7, 0xe6, 0x7e, 0x0a, 0x52, 0x51, 0xa1, 0x7b, 0x70, 0x34, 0xc2, 0xce, 0x7c, 0xba,
+	0x18, 0x7c, 0xab, 0x1c, 0xa0, 0x0e, 0x80, 0xf5, 0xcd, 0xe5, 0x64, 0x3e, 0xb4, 0x8a, 0x5a, 0xd0,
+	0x7e, 0x15, 0xa1, 0x8d, 0x49, 0xe8, 0x2e, 0x49, 0xe8, 0x50, 0xf4, 0x31, 0x34, 0xdd, 0x55, 0x71,
+	0x62, 0xa5, 0xd3, 0x69, 0xed, 0x90, 0x0d, 0x4c, 0x37, 0x19, 0x06, 0x57, 0x58, 0x74, 0x0e, 0x72,
+	0x96, 0xac, 0xd3, 0x15, 0x59, 0x70, 0x02, 0x40, 0xd9, 0x9a, 0x15, 0x32, 0x9c, 0x42, 0x3b, 0x23,
+	0xd4, 0x4d, 0xdd, 0x3c, 0x49, 0xd5, 0x06, 0x93, 0x6d, 0xdb, 0x40, 0x0f, 0xe0, 0x30, 0x25, 0x3e,
+	0xf9, 0x81, 0x5d, 0xac, 0x8d, 0xcb, 0x02, 0x3d, 0x01, 0xc8, 0xdd, 0xd4, 0x27, 0x79, 0x41, 0xaa,
+	0x1e, 0x96, 0x9b, 0xca, 0xce, 0xcc, 0xf5, 0x51, 0x17, 0xe4, 0x94, 0xd0, 0xd0, 0x5d, 0x91, 0x88,
+	0xc4, 0xb9, 0xda, 0x64, 0xeb, 0x7c, 0x0b, 0xa9, 0xd0, 0x8a, 0x12, 0x6f, 0x1d, 0xae, 0x33, 0xb5,
+	0xd5, 0x15, 0x7a, 0x12, 0xde, 0x94, 0xda, 0x02, 0x9a, 0xe5, 0x0d, 0x90, 0x0c, 0xad, 0xb9, 0x7d,
+	0x6d, 0x3b, 0x5f, 0xdb, 0xca, 0x41, 0x51, 0x60, 0x6b, 0x3a, 0x31, 0x2f, 0x2d, 0x45, 0x40, 0xc7,
+	0xd0, 0x9e, 0x98, 0x03, 0x6b, 0xb2, 0xb8, 0x31, 0xa7, 0x8a, 0x58, 0xc8, 0x56, 0x96, 0x43, 0xec,
+	0x4c, 0x95, 0xc6, 0xb6, 0xbe, 0xb6, 0xac, 0xa9, 0x22, 0x15, 0x22, 0x5f, 0x99, 0x2f, 0xaf, 0x16,
+	0x37, 0xce, 0x50, 0x39, 0xd4, 0xfe, 0x14, 0x01, 0xa6, 0x95, 0x70, 0x0e, 0x45, 0x46, 0x2d, 0x7b,
+	0x8f, 0x79, 0x4d, 0xb7, 0x28, 0xce, 0x3a, 0xf4, 0x39, 0xc8, 0x9c, 0xfb, 0xaa, 0xd8, 0x15, 0x7a,
+	0x72, 0xdd, 0xf0, 0xda, 0x23, 0xc1, 0x3c, 0x1a, 0x0d, 0xa1, 0x53, 0x0f, 0x37, 0x53, 0x5c, 0xae,
+	0x7b, 0xb9, 0xfb, 0x3e, 0xf0, 0xce, 0x1e, 0xf4, 0x11, 0x34, 0x53, 0x16, 0x2a, 0xe6, 0x8a, 0xdc,
+	0x7f, 0xb0, 0x2f, 0x6e, 0xb8, 0xc2, 0x20, 0x03, 0x5a, 0x69, 0x99, 0x0e, 0xe6, 0x94, 0xdc, 0xff,
+	0x60, 0x6f, 0x70, 0xf0, 0x06, 0xa5, 0x7d, 0x55, 0x85, 0xb3, 0x66, 0xc0, 0x09, 0xc8, 0xe6, 0x68,
+	0x84, 0xad, 0x91, 0x39, 0x1b, 0x3b, 0xb6, 0x22, 0x20, 0x04, 0x9d, 0x19, 0x36, 0xed, 0x97, 0x5f,
+	0x38, 0xf8, 0xa6, 0xec, 0x89, 0x08, 0xa0, 0x89, 0x9d, 0xc9, 0x64, 0x5e, 0xb8, 0xc0, 0x1c, 0x63,
+	0x3e, 0x28, 0x92, 0xf6, 0x02, 0x8e, 0x36, 0x6a, 0x22, 0x1d, 0x1a, 0x09, 0xcd, 0x54, 0xa1, 0xdb,
+	0xe8, 0xc9, 0xfd, 0x0f, 0xf7, 0x0b, 0x3e, 0x90, 0xde, 0xfe, 0x7e, 0x7e, 0x80, 0x0b, 0xa0, 0x16,
+	0xc2, 0x89, 0x49, 0x69, 0x18, 0x10, 0xef, 0xee, 0xa5, 0x77, 0x40, 0x0c, 0x3c, 0x66, 0xd9, 0x3d,
+	0x2c, 0x06, 0x1e, 0x1a, 0x43, 0x87, 0x7f, 0xca, 0x81, 0x57, 0xd9, 0x72, 0xfa, 0xf7, 0xef, 0x78,
+	0x3c, 0xac, 0xce, 0x38, 0xe6, 0x20, 0x63, 0x4f, 0xfb, 0x51, 0x84, 0xfb, 0xd5, 0x71, 0x5c, 0x4a,
+	0x3e, 0xad, 0xa5, 0x44, 0xab, 0xb9, 0xbd, 0x0b, 0xe6, 0xc3, 0xf2, 0xe5, 0x3b, 0x7e, 0x8b, 0xff,
+	0xee, 0x77, 0x35, 0xd8, 0xae, 0xeb, 0xcf, 0xef, 0x5c, 0x2f, 0x33, 0xf3, 0x78, 0xcf, 0x14, 0x1b,
+	0x85, 0x2a, 0x8a, 0x6a, 0x83, 0x76, 0xb1, 0xcf, 0xd1, 0x77, 0x0d, 0x14, 0x38, 0x03, 0x45, 0xcd,
+	0x86, 0x93, 0x9d, 0xbb, 0xa1, 0x4f, 0x78, 0xeb, 0x9e, 0xfc, 0xa3, 0x0a, 0x9c, 0x83, 0x2f, 0xa4,
+	0x9f, 0x7e, 0x3e, 0x3f, 0x18, 0x8c, 0xde, 0xde, 0x9e, 0x09, 0xbf, 0xdc, 0x9e, 0x09, 0x7f, 0xdc,
+	0x9e, 0x09, 0xdf, 0x3d, 0xff, 0xdf, 0xff, 0xdd, 0x65, 0x93, 0x75, 0x2e, 0xfe, 0x1a, 0x00, 0xc7,
+	0xce, 0x7a, 0x63, 0xbb, 0x07, 0x00, 0x00,
 }
@@ -51,6 +51,7 @@ type MappingRuleSnapshot struct {
 	StoragePolicies    []*policypb.StoragePolicy       `protobuf:"bytes,9,rep,name=storage_policies,json=storagePolicies" json:"storage_policies,omitempty"`
 	DropPolicy         policypb.DropPolicy             `protobuf:"varint,10,opt,name=drop_policy,json=dropPolicy,proto3,enum=policypb.DropPolicy" json:"drop_policy,omitempty"`
 	Tags               []*metricpb.Tag                 `protobuf:"bytes,11,rep,name=tags" json:"tags,omitempty"`
+	Relabel            []*pipelinepb.RelabelOp         `protobuf:"bytes,12,rep,name=relabel" json:"relabel,omitempty"`
 }
 
 func (m *MappingRuleSnapshot) Reset()                    { *m = MappingRuleSnapshot{} }
@@ -135,6 +136,13 @@ func (m *MappingRuleSnapshot) GetTags() []*metricpb.Tag {
 	return nil
 }
 
+func (m *MappingRuleSnapshot) GetRelabel() []*pipelinepb.RelabelOp {
+	if m != nil {
+		return m.Relabel
+	}
+	return nil
+}
+
 type MappingRule struct {
 	Uuid      string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
 	Snapshots []*MappingRuleSnapshot `protobuf:"bytes,2,rep,name=snapshots" json:"snapshots,omitempty"`
@@ -539,6 +547,18 @@ func (m *MappingRuleSnapshot) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
+	if len(m.Relabel) > 0 {
+		for _, msg := range m.Relabel {
+			dAtA[i] = 0x62
+			i++
+			i = encodeVarintRule(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
 	return i, nil
 }
 
@@ -963,6 +983,12 @@ func (m *MappingRuleSnapshot) Size() (n int) {
 			n += 1 + l + sovRule(uint64(l))
 		}
 	}
+	if len(m.Relabel) > 0 {
+		for _, e := range m.Relabel {
+			l = e.Size()
+			n += 1 + l + sovRule(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -1490,6 +1516,37 @@ func (m *MappingRuleSnapshot) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Relabel", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRule
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRule
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Relabel = append(m.Relabel, &pipelinepb.RelabelOp{})
+			if err := m.Relabel[len(m.Relabel)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRule(dAtA[iNdEx:])
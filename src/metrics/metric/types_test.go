@@ -54,7 +54,7 @@ func TestTypeUnmarshalYAMLErrors(t *testing.T) {
 		var typ Type
 		err := yaml.Unmarshal([]byte(input), &typ)
 		require.Error(t, err)
-		require.Equal(t, "invalid metric type '"+input+"', valid types are: counter, timer, gauge", err.Error())
+		require.Equal(t, "invalid metric type '"+input+"', valid types are: counter, timer, gauge, histogram", err.Error())
 	}
 }
 
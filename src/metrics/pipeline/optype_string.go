@@ -24,9 +24,9 @@ package pipeline
 
 import "strconv"
 
-const _OpType_name = "UnknownOpTypeAggregationOpTypeTransformationOpTypeRollupOpType"
+const _OpType_name = "UnknownOpTypeAggregationOpTypeTransformationOpTypeRollupOpTypeRelabelOpType"
 
-var _OpType_index = [...]uint8{0, 13, 30, 50, 62}
+var _OpType_index = [...]uint8{0, 13, 30, 50, 62, 75}
 
 func (i OpType) String() string {
 	if i < 0 || i >= OpType(len(_OpType_index)-1) {
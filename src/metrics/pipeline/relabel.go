@@ -0,0 +1,484 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package pipeline
+
+import (
+	"bytes"
+	"crypto/md5" // nolint: gosec
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/m3db/m3/src/metrics/generated/proto/pipelinepb"
+	"github.com/m3db/m3/src/metrics/metric/id"
+	xbytes "github.com/m3db/m3/src/metrics/x/bytes"
+)
+
+const (
+	defaultRelabelSeparator   = ";"
+	defaultRelabelRegex       = "(.*)"
+	defaultRelabelReplacement = "$1"
+)
+
+var (
+	errNilRelabelOpProto        = errors.New("nil relabel op proto message")
+	errRelabelNoTargetTag       = errors.New("relabel operation requires a target tag")
+	errRelabelNoSourceTags      = errors.New("relabel operation requires source tags")
+	errRelabelNoModulus         = errors.New("relabel operation requires a non-zero modulus")
+	errRelabelInvalidTargetTag  = errors.New("relabel operation target tag is not a valid tag name")
+	errRelabelInvalidSourceTags = errors.New("relabel operation source tags are only used by replace and hashmod actions")
+)
+
+// RelabelAction is the action of a relabel operation.
+// Note: Must match the protobuf enum definition since this is a direct cast.
+type RelabelAction int
+
+const (
+	// UnknownRelabelAction is an unknown relabel action.
+	UnknownRelabelAction RelabelAction = iota
+	// ReplaceRelabelAction sets the target tag to the replacement expanded
+	// with the regex matches of the concatenated source tag values.
+	ReplaceRelabelAction
+	// LabelMapRelabelAction copies the values of the tags whose names match
+	// the regex to the tags named by the replacement expanded with the matches.
+	LabelMapRelabelAction
+	// LabelDropRelabelAction removes the tags whose names match the regex.
+	LabelDropRelabelAction
+	// LabelKeepRelabelAction removes the tags whose names do not match the regex.
+	LabelKeepRelabelAction
+	// HashModRelabelAction sets the target tag to the modulus of the hash of
+	// the concatenated source tag values.
+	HashModRelabelAction
+)
+
+var relabelActionStrings = map[RelabelAction]string{
+	ReplaceRelabelAction:   "replace",
+	LabelMapRelabelAction:  "labelmap",
+	LabelDropRelabelAction: "labeldrop",
+	LabelKeepRelabelAction: "labelkeep",
+	HashModRelabelAction:   "hashmod",
+}
+
+// ParseRelabelAction parses a relabel action.
+func ParseRelabelAction(str string) (RelabelAction, error) {
+	for action, actionStr := range relabelActionStrings {
+		if actionStr == str {
+			return action, nil
+		}
+	}
+	return UnknownRelabelAction, fmt.Errorf("invalid relabel action: %s", str)
+}
+
+// IsValid checks if the relabel action is valid.
+func (a RelabelAction) IsValid() bool {
+	_, ok := relabelActionStrings[a]
+	return ok
+}
+
+func (a RelabelAction) String() string {
+	if str, ok := relabelActionStrings[a]; ok {
+		return str
+	}
+	return "RelabelAction(" + strconv.Itoa(int(a)) + ")"
+}
+
+// MarshalText serializes the relabel action to its textual representation.
+func (a RelabelAction) MarshalText() ([]byte, error) {
+	if !a.IsValid() {
+		return nil, fmt.Errorf("invalid relabel action %s", a.String())
+	}
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText extracts the relabel action from its textual representation.
+func (a *RelabelAction) UnmarshalText(text []byte) error {
+	parsed, err := ParseRelabelAction(string(text))
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// RelabelOp is a relabel operation that rewrites the tags of a metric
+// following the semantics of Prometheus relabeling. The tags are rewritten
+// before they are grouped by the rollup operations that follow it. Relabel
+// operations must be created with NewRelabelOp so that their regex is compiled.
+type RelabelOp struct {
+	// Action is the relabel action.
+	Action RelabelAction
+	// SourceTags are the tags whose values are concatenated with the
+	// separator for the replace and hashmod actions.
+	SourceTags [][]byte
+	// Separator separates the concatenated source tag values.
+	Separator []byte
+	// Regex is matched against the concatenated source tag values for
+	// the replace action, and against the tag names otherwise.
+	Regex string
+	// TargetTag is the tag set by the replace and hashmod actions, it
+	// may refer to the regex matches for the replace action.
+	TargetTag []byte
+	// Replacement is expanded with the regex matches for the replace and
+	// labelmap actions.
+	Replacement []byte
+	// Modulus is the modulus of the hash for the hashmod action.
+	Modulus uint64
+
+	regex *regexp.Regexp
+}
+
+// NewRelabelOpFromProto creates a new relabel op from proto.
+func NewRelabelOpFromProto(pb *pipelinepb.RelabelOp) (RelabelOp, error) {
+	if pb == nil {
+		return RelabelOp{}, errNilRelabelOpProto
+	}
+	return NewRelabelOp(
+		RelabelAction(pb.Action),
+		pb.SourceTags,
+		pb.Separator,
+		pb.Regex,
+		pb.TargetTag,
+		pb.Replacement,
+		pb.Modulus,
+	)
+}
+
+// NewRelabelOp creates a new relabel op.
+func NewRelabelOp(
+	action RelabelAction,
+	sourceTags []string,
+	separator string,
+	regex string,
+	targetTag string,
+	replacement string,
+	modulus uint64,
+) (RelabelOp, error) {
+	if !action.IsValid() {
+		return RelabelOp{}, fmt.Errorf("invalid relabel action %s", action.String())
+	}
+	re, err := regexp.Compile("^(?:" + regex + ")$")
+	if err != nil {
+		return RelabelOp{}, fmt.Errorf("invalid relabel regex %s: %v", regex, err)
+	}
+
+	switch action {
+	case ReplaceRelabelAction:
+		if targetTag == "" {
+			return RelabelOp{}, errRelabelNoTargetTag
+		}
+	case HashModRelabelAction:
+		if targetTag == "" {
+			return RelabelOp{}, errRelabelNoTargetTag
+		}
+		if !isValidTagName(targetTag) {
+			return RelabelOp{}, errRelabelInvalidTargetTag
+		}
+		if len(sourceTags) == 0 {
+			return RelabelOp{}, errRelabelNoSourceTags
+		}
+		if modulus == 0 {
+			return RelabelOp{}, errRelabelNoModulus
+		}
+	default:
+		if len(sourceTags) > 0 {
+			return RelabelOp{}, errRelabelInvalidSourceTags
+		}
+	}
+
+	return RelabelOp{
+		Action:      action,
+		SourceTags:  xbytes.ArraysFromStringArray(sourceTags),
+		Separator:   []byte(separator),
+		Regex:       regex,
+		TargetTag:   []byte(targetTag),
+		Replacement: []byte(replacement),
+		Modulus:     modulus,
+		regex:       re,
+	}, nil
+}
+
+// Apply applies the relabel operation to a list of tag pairs sorted by
+// name and returns the relabeled tag pairs, also sorted by name. The
+// given tag pairs are not modified.
+func (op RelabelOp) Apply(tags []id.TagPair) []id.TagPair {
+	switch op.Action {
+	case ReplaceRelabelAction:
+		value := op.sourceValue(tags)
+		indexes := op.regex.FindSubmatchIndex(value)
+		if indexes == nil {
+			return tags
+		}
+		target := op.regex.Expand(nil, op.TargetTag, value, indexes)
+		if !isValidTagName(string(target)) {
+			return tags
+		}
+		replaced := op.regex.Expand(nil, op.Replacement, value, indexes)
+		return setTag(tags, target, replaced)
+	case HashModRelabelAction:
+		sum := md5.Sum(op.sourceValue(tags)) // nolint: gosec
+		mod := binary.BigEndian.Uint64(sum[md5.Size-8:]) % op.Modulus
+		return setTag(tags, op.TargetTag, []byte(strconv.FormatUint(mod, 10)))
+	case LabelMapRelabelAction:
+		relabeled := tags
+		for _, tag := range tags {
+			indexes := op.regex.FindSubmatchIndex(tag.Name)
+			if indexes == nil {
+				continue
+			}
+			name := op.regex.Expand(nil, op.Replacement, tag.Name, indexes)
+			relabeled = setTag(relabeled, name, tag.Value)
+		}
+		return relabeled
+	case LabelDropRelabelAction, LabelKeepRelabelAction:
+		keep := op.Action == LabelKeepRelabelAction
+		relabeled := make([]id.TagPair, 0, len(tags))
+		for _, tag := range tags {
+			if op.regex.Match(tag.Name) == keep {
+				relabeled = append(relabeled, tag)
+			}
+		}
+		return relabeled
+	}
+	return tags
+}
+
+// RemovesTag returns true if the relabel operation always removes the tag
+// from the tags it rewrites.
+func (op RelabelOp) RemovesTag(name []byte) bool {
+	switch op.Action {
+	case LabelDropRelabelAction:
+		return op.regex.Match(name)
+	case LabelKeepRelabelAction:
+		return !op.regex.Match(name)
+	}
+	return false
+}
+
+// MaySetTag returns true if the relabel operation may set the tag in the
+// tags it rewrites.
+func (op RelabelOp) MaySetTag(name []byte) bool {
+	switch op.Action {
+	case ReplaceRelabelAction:
+		// The target tag may refer to the regex matches.
+		return bytes.IndexByte(op.TargetTag, '$') != -1 || bytes.Equal(op.TargetTag, name)
+	case HashModRelabelAction:
+		return bytes.Equal(op.TargetTag, name)
+	case LabelMapRelabelAction:
+		// The tag names are expanded from the names of the matching tags.
+		return true
+	}
+	return false
+}
+
+func (op RelabelOp) sourceValue(tags []id.TagPair) []byte {
+	var value []byte
+	for i, sourceTag := range op.SourceTags {
+		if i > 0 {
+			value = append(value, op.Separator...)
+		}
+		idx := sort.Search(len(tags), func(j int) bool {
+			return bytes.Compare(tags[j].Name, sourceTag) >= 0
+		})
+		if idx < len(tags) && bytes.Equal(tags[idx].Name, sourceTag) {
+			value = append(value, tags[idx].Value...)
+		}
+	}
+	return value
+}
+
+// setTag returns a copy of the tag pairs sorted by name with the tag set to
+// the value, or removed if the value is empty.
+func setTag(tags []id.TagPair, name, value []byte) []id.TagPair {
+	idx := sort.Search(len(tags), func(i int) bool {
+		return bytes.Compare(tags[i].Name, name) >= 0
+	})
+	exists := idx < len(tags) && bytes.Equal(tags[idx].Name, name)
+
+	relabeled := make([]id.TagPair, 0, len(tags)+1)
+	relabeled = append(relabeled, tags[:idx]...)
+	if len(value) > 0 {
+		relabeled = append(relabeled, id.TagPair{Name: name, Value: value})
+	}
+	if exists {
+		idx++
+	}
+	return append(relabeled, tags[idx:]...)
+}
+
+func isValidTagName(name string) bool {
+	return len(name) > 0 && !bytes.ContainsAny([]byte(name), "{}$")
+}
+
+// Equal returns true if two relabel operations are equal.
+func (op RelabelOp) Equal(other RelabelOp) bool {
+	if op.Action != other.Action || op.Modulus != other.Modulus || op.Regex != other.Regex {
+		return false
+	}
+	if !bytes.Equal(op.Separator, other.Separator) ||
+		!bytes.Equal(op.TargetTag, other.TargetTag) ||
+		!bytes.Equal(op.Replacement, other.Replacement) {
+		return false
+	}
+	if len(op.SourceTags) != len(other.SourceTags) {
+		return false
+	}
+	for i := range op.SourceTags {
+		if !bytes.Equal(op.SourceTags[i], other.SourceTags[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Clone clones the relabel operation.
+func (op RelabelOp) Clone() RelabelOp {
+	return RelabelOp{
+		Action:      op.Action,
+		SourceTags:  xbytes.ArrayCopy(op.SourceTags),
+		Separator:   append([]byte(nil), op.Separator...),
+		Regex:       op.Regex,
+		TargetTag:   append([]byte(nil), op.TargetTag...),
+		Replacement: append([]byte(nil), op.Replacement...),
+		Modulus:     op.Modulus,
+		regex:       op.regex,
+	}
+}
+
+// Proto returns the proto message for the given relabel op.
+func (op RelabelOp) Proto() (*pipelinepb.RelabelOp, error) {
+	if !op.Action.IsValid() {
+		return nil, fmt.Errorf("invalid relabel action %s", op.Action.String())
+	}
+	return &pipelinepb.RelabelOp{
+		Action:      pipelinepb.RelabelOp_Action(op.Action),
+		SourceTags:  xbytes.ArraysToStringArray(op.SourceTags),
+		Separator:   string(op.Separator),
+		Regex:       op.Regex,
+		TargetTag:   string(op.TargetTag),
+		Replacement: string(op.Replacement),
+		Modulus:     op.Modulus,
+	}, nil
+}
+
+func (op RelabelOp) String() string {
+	var b bytes.Buffer
+	b.WriteString("{")
+	fmt.Fprintf(&b, "action: %v, ", op.Action)
+	b.WriteString("sourceTags: [")
+	for i, t := range op.SourceTags {
+		fmt.Fprintf(&b, "%s", t)
+		if i < len(op.SourceTags)-1 {
+			b.WriteString(", ")
+		}
+	}
+	b.WriteString("], ")
+	fmt.Fprintf(&b, "separator: %s, ", op.Separator)
+	fmt.Fprintf(&b, "regex: %s, ", op.Regex)
+	fmt.Fprintf(&b, "targetTag: %s, ", op.TargetTag)
+	fmt.Fprintf(&b, "replacement: %s, ", op.Replacement)
+	fmt.Fprintf(&b, "modulus: %d", op.Modulus)
+	b.WriteString("}")
+	return b.String()
+}
+
+// MarshalJSON returns the JSON encoding of a relabel operation.
+func (op RelabelOp) MarshalJSON() ([]byte, error) {
+	return json.Marshal(newRelabelMarshaler(op))
+}
+
+// UnmarshalJSON unmarshals JSON-encoded data into a relabel operation.
+func (op *RelabelOp) UnmarshalJSON(data []byte) error {
+	var converted relabelMarshaler
+	err := json.Unmarshal(data, &converted)
+	if err != nil {
+		return err
+	}
+	*op, err = converted.RelabelOp()
+	return err
+}
+
+// MarshalYAML returns the YAML representation of this type.
+func (op RelabelOp) MarshalYAML() (interface{}, error) {
+	return newRelabelMarshaler(op), nil
+}
+
+// UnmarshalYAML unmarshals YAML-encoded data into a relabel operation.
+func (op *RelabelOp) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var converted relabelMarshaler
+	err := unmarshal(&converted)
+	if err != nil {
+		return err
+	}
+	*op, err = converted.RelabelOp()
+	return err
+}
+
+// relabelMarshaler is a helper type to facilitate marshaling and unmarshaling
+// relabel operations, the separator, regex and replacement default to those
+// of Prometheus relabeling when unset.
+type relabelMarshaler struct {
+	Action      RelabelAction `json:"action" yaml:"action"`
+	SourceTags  []string      `json:"sourceTags,omitempty" yaml:"sourceTags"`
+	Separator   *string       `json:"separator,omitempty" yaml:"separator"`
+	Regex       *string       `json:"regex,omitempty" yaml:"regex"`
+	TargetTag   string        `json:"targetTag,omitempty" yaml:"targetTag"`
+	Replacement *string       `json:"replacement,omitempty" yaml:"replacement"`
+	Modulus     uint64        `json:"modulus,omitempty" yaml:"modulus"`
+}
+
+func newRelabelMarshaler(op RelabelOp) relabelMarshaler {
+	var (
+		separator   = string(op.Separator)
+		regex       = op.Regex
+		replacement = string(op.Replacement)
+	)
+	return relabelMarshaler{
+		Action:      op.Action,
+		SourceTags:  xbytes.ArraysToStringArray(op.SourceTags),
+		Separator:   &separator,
+		Regex:       &regex,
+		TargetTag:   string(op.TargetTag),
+		Replacement: &replacement,
+		Modulus:     op.Modulus,
+	}
+}
+
+func (m relabelMarshaler) RelabelOp() (RelabelOp, error) {
+	var (
+		separator   = defaultRelabelSeparator
+		regex       = defaultRelabelRegex
+		replacement = defaultRelabelReplacement
+	)
+	if m.Separator != nil {
+		separator = *m.Separator
+	}
+	if m.Regex != nil {
+		regex = *m.Regex
+	}
+	if m.Replacement != nil {
+		replacement = *m.Replacement
+	}
+	return NewRelabelOp(m.Action, m.SourceTags, separator, regex, m.TargetTag, replacement, m.Modulus)
+}
@@ -0,0 +1,346 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package pipeline
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+
+	"github.com/m3db/m3/src/metrics/generated/proto/pipelinepb"
+	"github.com/m3db/m3/src/metrics/metric/id"
+)
+
+func TestRelabelActionMarshalText(t *testing.T) {
+	for _, action := range []RelabelAction{
+		ReplaceRelabelAction,
+		LabelMapRelabelAction,
+		LabelDropRelabelAction,
+		LabelKeepRelabelAction,
+		HashModRelabelAction,
+	} {
+		text, err := action.MarshalText()
+		require.NoError(t, err)
+
+		var parsed RelabelAction
+		require.NoError(t, parsed.UnmarshalText(text))
+		require.Equal(t, action, parsed)
+	}
+
+	_, err := UnknownRelabelAction.MarshalText()
+	require.Error(t, err)
+	_, err = ParseRelabelAction("keep")
+	require.Error(t, err)
+}
+
+func TestNewRelabelOpValidation(t *testing.T) {
+	inputs := []struct {
+		name        string
+		action      RelabelAction
+		sourceTags  []string
+		regex       string
+		targetTag   string
+		modulus     uint64
+		expectedErr bool
+	}{
+		{name: "replace", action: ReplaceRelabelAction, sourceTags: []string{"a"}, regex: "(.*)", targetTag: "b"},
+		{name: "unknown action", action: UnknownRelabelAction, regex: "(.*)", expectedErr: true},
+		{name: "invalid regex", action: LabelDropRelabelAction, regex: "(", expectedErr: true},
+		{name: "replace without target", action: ReplaceRelabelAction, sourceTags: []string{"a"}, regex: "(.*)", expectedErr: true},
+		{name: "hashmod", action: HashModRelabelAction, sourceTags: []string{"a"}, regex: "(.*)", targetTag: "shard", modulus: 4},
+		{name: "hashmod without modulus", action: HashModRelabelAction, sourceTags: []string{"a"}, regex: "(.*)", targetTag: "shard", expectedErr: true},
+		{name: "hashmod without source tags", action: HashModRelabelAction, regex: "(.*)", targetTag: "shard", modulus: 4, expectedErr: true},
+		{name: "hashmod with templated target", action: HashModRelabelAction, sourceTags: []string{"a"}, regex: "(.*)", targetTag: "${1}", modulus: 4, expectedErr: true},
+		{name: "labeldrop with source tags", action: LabelDropRelabelAction, sourceTags: []string{"a"}, regex: "a", expectedErr: true},
+	}
+
+	for _, input := range inputs {
+		t.Run(input.name, func(t *testing.T) {
+			_, err := NewRelabelOp(input.action, input.sourceTags, ";", input.regex, input.targetTag, "$1", input.modulus)
+			if input.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestRelabelOpApply(t *testing.T) {
+	tags := []id.TagPair{
+		{Name: []byte("__meta_env"), Value: []byte("prod")},
+		{Name: []byte("host"), Value: []byte("host-1.dc1")},
+		{Name: []byte("service"), Value: []byte("api")},
+	}
+
+	inputs := []struct {
+		name         string
+		op           RelabelOp
+		expectedTags []id.TagPair
+	}{
+		{
+			name: "replace",
+			op:   mustNewRelabelOp(t, ReplaceRelabelAction, []string{"host"}, ";", "[^.]+\\.(.*)", "dc", "$1", 0),
+			expectedTags: []id.TagPair{
+				{Name: []byte("__meta_env"), Value: []byte("prod")},
+				{Name: []byte("dc"), Value: []byte("dc1")},
+				{Name: []byte("host"), Value: []byte("host-1.dc1")},
+				{Name: []byte("service"), Value: []byte("api")},
+			},
+		},
+		{
+			name: "replace concatenates source tags",
+			op:   mustNewRelabelOp(t, ReplaceRelabelAction, []string{"service", "missing", "__meta_env"}, "/", "(.*)", "job", "$1", 0),
+			expectedTags: []id.TagPair{
+				{Name: []byte("__meta_env"), Value: []byte("prod")},
+				{Name: []byte("host"), Value: []byte("host-1.dc1")},
+				{Name: []byte("job"), Value: []byte("api//prod")},
+				{Name: []byte("service"), Value: []byte("api")},
+			},
+		},
+		{
+			name:         "replace without match",
+			op:           mustNewRelabelOp(t, ReplaceRelabelAction, []string{"service"}, ";", "web", "service", "frontend", 0),
+			expectedTags: tags,
+		},
+		{
+			name: "replace with empty value removes tag",
+			op:   mustNewRelabelOp(t, ReplaceRelabelAction, []string{"service"}, ";", "api", "host", "", 0),
+			expectedTags: []id.TagPair{
+				{Name: []byte("__meta_env"), Value: []byte("prod")},
+				{Name: []byte("service"), Value: []byte("api")},
+			},
+		},
+		{
+			name: "labelmap",
+			op:   mustNewRelabelOp(t, LabelMapRelabelAction, nil, ";", "__meta_(.+)", "", "$1", 0),
+			expectedTags: []id.TagPair{
+				{Name: []byte("__meta_env"), Value: []byte("prod")},
+				{Name: []byte("env"), Value: []byte("prod")},
+				{Name: []byte("host"), Value: []byte("host-1.dc1")},
+				{Name: []byte("service"), Value: []byte("api")},
+			},
+		},
+		{
+			name: "labeldrop",
+			op:   mustNewRelabelOp(t, LabelDropRelabelAction, nil, ";", "__meta_.*|host", "", "$1", 0),
+			expectedTags: []id.TagPair{
+				{Name: []byte("service"), Value: []byte("api")},
+			},
+		},
+		{
+			name: "labelkeep",
+			op:   mustNewRelabelOp(t, LabelKeepRelabelAction, nil, ";", "host|service", "", "$1", 0),
+			expectedTags: []id.TagPair{
+				{Name: []byte("host"), Value: []byte("host-1.dc1")},
+				{Name: []byte("service"), Value: []byte("api")},
+			},
+		},
+		{
+			name: "hashmod",
+			op:   mustNewRelabelOp(t, HashModRelabelAction, []string{"host"}, ";", "(.*)", "shard", "$1", 1),
+			expectedTags: []id.TagPair{
+				{Name: []byte("__meta_env"), Value: []byte("prod")},
+				{Name: []byte("host"), Value: []byte("host-1.dc1")},
+				{Name: []byte("service"), Value: []byte("api")},
+				{Name: []byte("shard"), Value: []byte("0")},
+			},
+		},
+	}
+
+	for _, input := range inputs {
+		t.Run(input.name, func(t *testing.T) {
+			original := cloneTagPairs(tags)
+			require.Equal(t, input.expectedTags, input.op.Apply(tags))
+			require.Equal(t, original, tags)
+		})
+	}
+}
+
+func TestRelabelOpHashModIsStable(t *testing.T) {
+	op := mustNewRelabelOp(t, HashModRelabelAction, []string{"host"}, ";", "(.*)", "shard", "$1", 16)
+	tags := []id.TagPair{{Name: []byte("host"), Value: []byte("host-1")}}
+
+	first := op.Apply(tags)
+	require.Len(t, first, 2)
+	require.Equal(t, []byte("shard"), first[1].Name)
+	require.Equal(t, first, op.Apply(tags))
+}
+
+func TestRelabelOpRemovesAndMaySetTag(t *testing.T) {
+	inputs := []struct {
+		name       string
+		op         RelabelOp
+		tag        string
+		removesTag bool
+		maySetTag  bool
+	}{
+		{
+			name:      "replace sets target tag",
+			op:        mustNewRelabelOp(t, ReplaceRelabelAction, []string{"host"}, ";", "[^.]+\\.(.*)", "dc", "$1", 0),
+			tag:       "dc",
+			maySetTag: true,
+		},
+		{
+			name: "replace does not set other tags",
+			op:   mustNewRelabelOp(t, ReplaceRelabelAction, []string{"host"}, ";", "[^.]+\\.(.*)", "dc", "$1", 0),
+			tag:  "host",
+		},
+		{
+			name:      "replace may set templated target tag",
+			op:        mustNewRelabelOp(t, ReplaceRelabelAction, []string{"kind"}, ";", "(.*)", "${1}_total", "1", 0),
+			tag:       "requests_total",
+			maySetTag: true,
+		},
+		{
+			name:      "labelmap may set any tag",
+			op:        mustNewRelabelOp(t, LabelMapRelabelAction, nil, ";", "__meta_(.+)", "", "$1", 0),
+			tag:       "env",
+			maySetTag: true,
+		},
+		{
+			name:      "hashmod sets target tag",
+			op:        mustNewRelabelOp(t, HashModRelabelAction, []string{"host"}, ";", "(.*)", "shard", "$1", 16),
+			tag:       "shard",
+			maySetTag: true,
+		},
+		{
+			name:       "labeldrop removes matching tag",
+			op:         mustNewRelabelOp(t, LabelDropRelabelAction, nil, ";", "host|dc", "", "$1", 0),
+			tag:        "dc",
+			removesTag: true,
+		},
+		{
+			name: "labeldrop keeps other tags",
+			op:   mustNewRelabelOp(t, LabelDropRelabelAction, nil, ";", "host|dc", "", "$1", 0),
+			tag:  "service",
+		},
+		{
+			name:       "labelkeep removes other tags",
+			op:         mustNewRelabelOp(t, LabelKeepRelabelAction, nil, ";", "service", "", "$1", 0),
+			tag:        "dc",
+			removesTag: true,
+		},
+		{
+			name: "labelkeep keeps matching tag",
+			op:   mustNewRelabelOp(t, LabelKeepRelabelAction, nil, ";", "service", "", "$1", 0),
+			tag:  "service",
+		},
+	}
+
+	for _, input := range inputs {
+		t.Run(input.name, func(t *testing.T) {
+			require.Equal(t, input.removesTag, input.op.RemovesTag([]byte(input.tag)))
+			require.Equal(t, input.maySetTag, input.op.MaySetTag([]byte(input.tag)))
+		})
+	}
+}
+
+func TestRelabelOpProtoRoundTrip(t *testing.T) {
+	op := mustNewRelabelOp(t, HashModRelabelAction, []string{"host", "service"}, ";", "(.*)", "shard", "$1", 16)
+
+	pb, err := op.Proto()
+	require.NoError(t, err)
+	require.Equal(t, pipelinepb.RelabelOp_HASH_MOD, pb.Action)
+
+	res, err := NewRelabelOpFromProto(pb)
+	require.NoError(t, err)
+	require.True(t, op.Equal(res))
+
+	_, err = NewRelabelOpFromProto(nil)
+	require.Error(t, err)
+
+	union := OpUnion{Type: RelabelOpType, Relabel: op}
+	pbUnion, err := union.Proto()
+	require.NoError(t, err)
+	require.Equal(t, pipelinepb.PipelineOp_RELABEL, pbUnion.Type)
+
+	resUnion, err := NewOpUnionFromProto(*pbUnion)
+	require.NoError(t, err)
+	require.True(t, union.Equal(resUnion))
+}
+
+func TestRelabelOpUnmarshalDefaults(t *testing.T) {
+	var op OpUnion
+	require.NoError(t, json.Unmarshal([]byte(`{"relabel":{"action":"replace","sourceTags":["host"],"targetTag":"instance"}}`), &op))
+	require.Equal(t, RelabelOpType, op.Type)
+	require.True(t, op.Relabel.Equal(
+		mustNewRelabelOp(t, ReplaceRelabelAction, []string{"host"}, ";", "(.*)", "instance", "$1", 0)))
+
+	input := `
+relabel:
+  action: labeldrop
+  regex: "__meta_.*"
+`
+	require.NoError(t, yaml.Unmarshal([]byte(input), &op))
+	require.Equal(t, RelabelOpType, op.Type)
+	require.True(t, op.Relabel.Equal(
+		mustNewRelabelOp(t, LabelDropRelabelAction, nil, ";", "__meta_.*", "", "$1", 0)))
+
+	require.Error(t, json.Unmarshal([]byte(`{"relabel":{"action":"replace","sourceTags":["host"]}}`), &op))
+}
+
+func TestRelabelOpMarshalRoundTrip(t *testing.T) {
+	op := OpUnion{
+		Type:    RelabelOpType,
+		Relabel: mustNewRelabelOp(t, ReplaceRelabelAction, []string{"host"}, "", "", "host", "", 0),
+	}
+
+	b, err := json.Marshal(op)
+	require.NoError(t, err)
+	var jsonRes OpUnion
+	require.NoError(t, json.Unmarshal(b, &jsonRes))
+	require.True(t, op.Equal(jsonRes))
+
+	b, err = yaml.Marshal(op)
+	require.NoError(t, err)
+	var yamlRes OpUnion
+	require.NoError(t, yaml.Unmarshal(b, &yamlRes))
+	require.True(t, op.Equal(yamlRes))
+}
+
+func mustNewRelabelOp(
+	t *testing.T,
+	action RelabelAction,
+	sourceTags []string,
+	separator string,
+	regex string,
+	targetTag string,
+	replacement string,
+	modulus uint64,
+) RelabelOp {
+	op, err := NewRelabelOp(action, sourceTags, separator, regex, targetTag, replacement, modulus)
+	require.NoError(t, err)
+	return op
+}
+
+func cloneTagPairs(tags []id.TagPair) []id.TagPair {
+	cloned := make([]id.TagPair, 0, len(tags))
+	for _, tag := range tags {
+		cloned = append(cloned, id.TagPair{
+			Name:  append([]byte(nil), tag.Name...),
+			Value: append([]byte(nil), tag.Value...),
+		})
+	}
+	return cloned
+}
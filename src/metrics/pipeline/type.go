@@ -67,6 +67,7 @@ const (
 	AggregationOpType
 	TransformationOpType
 	RollupOpType
+	RelabelOpType
 )
 
 // AggregationOp is an aggregation operation.
@@ -429,6 +430,7 @@ type OpUnion struct {
 	Type           OpType
 	Aggregation    AggregationOp
 	Transformation TransformationOp
+	Relabel        RelabelOp
 }
 
 // NewOpUnionFromProto creates a new operation union from proto.
@@ -447,6 +449,9 @@ func NewOpUnionFromProto(pb pipelinepb.PipelineOp) (OpUnion, error) {
 	case pipelinepb.PipelineOp_ROLLUP:
 		u.Type = RollupOpType
 		u.Rollup, err = NewRollupOpFromProto(pb.Rollup)
+	case pipelinepb.PipelineOp_RELABEL:
+		u.Type = RelabelOpType
+		u.Relabel, err = NewRelabelOpFromProto(pb.Relabel)
 	default:
 		err = fmt.Errorf("unknown op type in proto: %v", pb.Type)
 	}
@@ -465,6 +470,8 @@ func (u OpUnion) Equal(other OpUnion) bool {
 		return u.Transformation.Equal(other.Transformation)
 	case RollupOpType:
 		return u.Rollup.Equal(other.Rollup)
+	case RelabelOpType:
+		return u.Relabel.Equal(other.Relabel)
 	}
 	return true
 }
@@ -479,6 +486,8 @@ func (u OpUnion) Clone() OpUnion {
 		clone.Transformation = u.Transformation.Clone()
 	case RollupOpType:
 		clone.Rollup = u.Rollup.Clone()
+	case RelabelOpType:
+		clone.Relabel = u.Relabel.Clone()
 	}
 	return clone
 }
@@ -499,6 +508,9 @@ func (u OpUnion) Proto() (*pipelinepb.PipelineOp, error) {
 	case RollupOpType:
 		pbOp.Type = pipelinepb.PipelineOp_ROLLUP
 		pbOp.Rollup, err = u.Rollup.Proto()
+	case RelabelOpType:
+		pbOp.Type = pipelinepb.PipelineOp_RELABEL
+		pbOp.Relabel, err = u.Relabel.Proto()
 	default:
 		err = fmt.Errorf("unknown op type: %v", u.Type)
 	}
@@ -515,6 +527,8 @@ func (u OpUnion) String() string {
 		fmt.Fprintf(&b, "transformation: %s", u.Transformation.String())
 	case RollupOpType:
 		fmt.Fprintf(&b, "rollup: %s", u.Rollup.String())
+	case RelabelOpType:
+		fmt.Fprintf(&b, "relabel: %s", u.Relabel.String())
 	default:
 		fmt.Fprintf(&b, "unknown op type: %v", u.Type)
 	}
@@ -569,6 +583,7 @@ type unionMarshaler struct {
 	Aggregation    *AggregationOp    `json:"aggregation,omitempty" yaml:"aggregation"`
 	Transformation *TransformationOp `json:"transformation,omitempty" yaml:"transformation"`
 	Rollup         *RollupOp         `json:"rollup,omitempty" yaml:"rollup"`
+	Relabel        *RelabelOp        `json:"relabel,omitempty" yaml:"relabel"`
 }
 
 func newUnionMarshaler(u OpUnion) (unionMarshaler, error) {
@@ -580,6 +595,8 @@ func newUnionMarshaler(u OpUnion) (unionMarshaler, error) {
 		converted.Transformation = &u.Transformation
 	case RollupOpType:
 		converted.Rollup = &u.Rollup
+	case RelabelOpType:
+		converted.Relabel = &u.Relabel
 	default:
 		return unionMarshaler{}, fmt.Errorf("unknown op type: %v", u.Type)
 	}
@@ -596,6 +613,9 @@ func (m unionMarshaler) OpUnion() (OpUnion, error) {
 	if m.Rollup != nil {
 		return OpUnion{Type: RollupOpType, Rollup: *m.Rollup}, nil
 	}
+	if m.Relabel != nil {
+		return OpUnion{Type: RelabelOpType, Relabel: *m.Relabel}, nil
+	}
 	return OpUnion{}, errNoOpInUnionMarshaler
 }
 
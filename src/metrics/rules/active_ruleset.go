@@ -243,12 +243,24 @@ func (as *activeRuleSet) forwardMatchAt(
 	if err != nil {
 		return forwardMatchResult{}, err
 	}
+	// The mapping rules with relabel operations aggregate the metric under the
+	// relabeled ID, which is generated the same way as the new rollup IDs.
+	// NB: could log the matching error here if needed.
+	relabelResults, _ := as.toRollupResults(
+		id,
+		mappingResults.forExistingID.cutoverNanos,
+		mappingResults.relabelTargets,
+		false,
+		mappingResults.relabelTags,
+		matchOpts,
+	)
 	forExistingID := mappingResults.forExistingID.
 		merge(rollupResults.forExistingID).
 		unique().
 		toStagedMetadata()
-	forNewRollupIDs := make([]IDWithMetadatas, 0, len(rollupResults.forNewRollupIDs))
-	for _, idWithMatchResult := range rollupResults.forNewRollupIDs {
+	newRollupIDResults := append(rollupResults.forNewRollupIDs, relabelResults.forNewRollupIDs...)
+	forNewRollupIDs := make([]IDWithMetadatas, 0, len(newRollupIDResults))
+	for _, idWithMatchResult := range newRollupIDResults {
 		stagedMetadata := idWithMatchResult.matchResults.unique().toStagedMetadata()
 		newIDWithMetadatas := IDWithMetadatas{
 			ID:        idWithMatchResult.id,
@@ -270,8 +282,10 @@ func (as *activeRuleSet) mappingsForNonRollupID(
 	matchOpts MatchOptions,
 ) (mappingResults, error) {
 	var (
-		cutoverNanos int64
-		pipelines    []metadata.PipelineMetadata
+		cutoverNanos   int64
+		pipelines      []metadata.PipelineMetadata
+		relabelTargets []rollupTarget
+		relabelTags    [][]models.Tag
 	)
 	for _, mappingRule := range as.mappingRules {
 		snapshot := mappingRule.activeSnapshot(timeNanos)
@@ -298,6 +312,17 @@ func (as *activeRuleSet) mappingsForNonRollupID(
 		if snapshot.tombstoned {
 			continue
 		}
+		// If the mapping rule snapshot has relabel operations, the metric is aggregated
+		// and retained under the relabeled ID instead of the existing ID.
+		if len(snapshot.relabel) > 0 {
+			target, err := snapshot.relabelTarget()
+			if err != nil {
+				return mappingResults{}, err
+			}
+			relabelTargets = append(relabelTargets, target)
+			relabelTags = append(relabelTags, snapshot.tags)
+			continue
+		}
 		pipeline := metadata.PipelineMetadata{
 			AggregationID:   snapshot.aggregationID,
 			StoragePolicies: snapshot.storagePolicies.Clone(),
@@ -315,7 +340,9 @@ func (as *activeRuleSet) mappingsForNonRollupID(
 		pipelines = metadata.DefaultPipelineMetadatas.Clone()
 	}
 	return mappingResults{
-		forExistingID: ruleMatchResults{cutoverNanos: cutoverNanos, pipelines: pipelines},
+		forExistingID:  ruleMatchResults{cutoverNanos: cutoverNanos, pipelines: pipelines},
+		relabelTargets: relabelTargets,
+		relabelTags:    relabelTags,
 	}, nil
 }
 
@@ -395,7 +422,8 @@ func (as *activeRuleSet) rollupResultsFor(id []byte, timeNanos int64, matchOpts
 // ID. It additionally distinguishes rollup pipelines whose first operation is a rollup
 // operation from those that aren't since the former pipelines are applied against the
 // original metric ID and the latter are applied against new rollup IDs due to the
-// application of the rollup operation.
+// application of the rollup operation. The relabel operations leading the pipelines
+// rewrite the tags the rollup operations are applied against.
 // nolint: unparam
 func (as *activeRuleSet) toRollupResults(
 	id []byte,
@@ -411,7 +439,7 @@ func (as *activeRuleSet) toRollupResults(
 
 	// If we cannot extract tags from the id, this is likely an invalid
 	// metric and we bail early.
-	_, sortedTagPairBytes, err := matchOpts.NameAndTagsFn(id)
+	name, sortedTagPairBytes, err := matchOpts.NameAndTagsFn(id)
 	if err != nil {
 		return rollupResults{}, err
 	}
@@ -435,32 +463,49 @@ func (as *activeRuleSet) toRollupResults(
 		var (
 			aggregationID aggregation.ID
 			rollupID      []byte
+			relabeled     []metricid.TagPair
+			firstOpIdx    = 0
 			numSteps      = pipeline.Len()
-			firstOp       = pipeline.At(0)
 			toApply       mpipeline.Pipeline
 		)
+		for ; firstOpIdx < numSteps && pipeline.At(firstOpIdx).Type == mpipeline.RelabelOpType; firstOpIdx++ {
+			relabeled, err = relabelTags(sortedTagPairBytes, relabeled, pipeline.At(firstOpIdx).Relabel, matchOpts)
+			if err != nil {
+				break
+			}
+		}
+		if err != nil {
+			multiErr = multiErr.Add(err)
+			continue
+		}
+		if firstOpIdx == numSteps {
+			err = fmt.Errorf("target %v has no operation after relabel operations", target)
+			multiErr = multiErr.Add(err)
+			continue
+		}
+		firstOp := pipeline.At(firstOpIdx)
 		switch firstOp.Type {
 		case mpipeline.AggregationOpType:
 			aggregationID, err = aggregation.CompressTypes(firstOp.Aggregation.Type)
 			if err != nil {
-				err = fmt.Errorf("target %v operation 0 aggregation type compression error: %v", target, err)
+				err = fmt.Errorf("target %v operation %d aggregation type compression error: %v",
+					target, firstOpIdx, err)
 				multiErr = multiErr.Add(err)
 				continue
 			}
-			toApply = pipeline.SubPipeline(1, numSteps)
+			toApply = pipeline.SubPipeline(firstOpIdx+1, numSteps)
 		case mpipeline.TransformationOpType:
 			aggregationID = aggregation.DefaultID
-			toApply = pipeline
+			toApply = pipeline.SubPipeline(firstOpIdx, numSteps)
 		case mpipeline.RollupOpType:
 			tagPairs = tagPairs[:0]
 			var matched bool
 			rollupID, matched, err = as.matchRollupTarget(
-				sortedTagPairBytes,
+				newSortedTagIterator(sortedTagPairBytes, relabeled, matchOpts),
 				firstOp.Rollup,
 				tagPairs,
 				tags[idx],
-				matchRollupTargetOptions{generateRollupID: true},
-				matchOpts)
+				matchRollupTargetOptions{generateRollupID: true, name: name})
 			if err != nil {
 				multiErr = multiErr.Add(err)
 				continue
@@ -470,14 +515,14 @@ func (as *activeRuleSet) toRollupResults(
 				continue
 			}
 			aggregationID = firstOp.Rollup.AggregationID
-			toApply = pipeline.SubPipeline(1, numSteps)
+			toApply = pipeline.SubPipeline(firstOpIdx+1, numSteps)
 		default:
-			err = fmt.Errorf("target %v operation 0 has unknown type: %v", target, firstOp.Type)
+			err = fmt.Errorf("target %v operation %d has unknown type: %v", target, firstOpIdx, firstOp.Type)
 			multiErr = multiErr.Add(err)
 			continue
 		}
 		tagPairs = tagPairs[:0]
-		applied, err := as.applyIDToPipeline(sortedTagPairBytes, toApply, tagPairs, tags[idx], matchOpts)
+		applied, err := as.applyIDToPipeline(name, sortedTagPairBytes, relabeled, toApply, tagPairs, tags[idx], matchOpts)
 		if err != nil {
 			err = fmt.Errorf("failed to apply id %s to pipeline %v: %v", id, toApply, err)
 			multiErr = multiErr.Add(err)
@@ -517,12 +562,11 @@ func (as *activeRuleSet) toRollupResults(
 // returns the new rollup ID if the metric ID contains the full list of rollup
 // tags, and nil otherwise.
 func (as *activeRuleSet) matchRollupTarget(
-	sortedTagPairBytes []byte,
+	sortedTagIter metricid.SortedTagIterator,
 	rollupOp mpipeline.RollupOp,
 	tagPairs []metricid.TagPair, // buffer for reuse to generate rollup ID across calls
 	tags []models.Tag,
 	targetOpts matchRollupTargetOptions,
-	matchOpts MatchOptions,
 ) ([]byte, bool, error) {
 	if rollupOp.Type == mpipeline.ExcludeByRollupType && !targetOpts.generateRollupID {
 		// Exclude by tag always matches, if not generating rollup ID
@@ -537,7 +581,6 @@ func (as *activeRuleSet) matchRollupTarget(
 		nameTagValue    []byte
 		includeTagNames = as.includeTagKeys
 	)
-	sortedTagIter := matchOpts.SortedTagIteratorFn(sortedTagPairBytes)
 
 	switch rollupOp.Type {
 	case mpipeline.GroupByRollupType:
@@ -648,12 +691,19 @@ func (as *activeRuleSet) matchRollupTarget(
 		})
 	}
 
+	// The name of the metric is used if the tags do not contain the name tag,
+	// e.g. for metric IDs whose name is not encoded as a tag.
+	if nameTagValue == nil {
+		nameTagValue = targetOpts.name
+	}
 	newName := rollupOp.NewName(nameTagValue)
 	return as.newRollupIDFn(newName, tagPairs), true, nil
 }
 
 func (as *activeRuleSet) applyIDToPipeline(
+	name []byte,
 	sortedTagPairBytes []byte,
+	relabeled []metricid.TagPair, // tags rewritten by the preceding relabel operations if any
 	pipeline mpipeline.Pipeline,
 	tagPairs []metricid.TagPair, // buffer for reuse across calls
 	tags []models.Tag,
@@ -669,16 +719,24 @@ func (as *activeRuleSet) applyIDToPipeline(
 				Type:           mpipeline.TransformationOpType,
 				Transformation: pipelineOp.Transformation,
 			}
+		case mpipeline.RelabelOpType:
+			// Relabel operations only rewrite the tags the following rollup
+			// operations are applied against and are not part of the applied pipeline.
+			var err error
+			relabeled, err = relabelTags(sortedTagPairBytes, relabeled, pipelineOp.Relabel, matchOpts)
+			if err != nil {
+				return applied.Pipeline{}, err
+			}
+			continue
 		case mpipeline.RollupOpType:
 			rollupOp := pipelineOp.Rollup
 			var matched bool
 			rollupID, matched, err := as.matchRollupTarget(
-				sortedTagPairBytes,
+				newSortedTagIterator(sortedTagPairBytes, relabeled, matchOpts),
 				rollupOp,
 				tagPairs,
 				tags,
-				matchRollupTargetOptions{generateRollupID: true},
-				matchOpts)
+				matchRollupTargetOptions{generateRollupID: true, name: name})
 			if err != nil {
 				return applied.Pipeline{}, err
 			}
@@ -712,6 +770,7 @@ func (as *activeRuleSet) reverseMappingsFor(
 		return as.reverseMappingsForNonRollupID(id, timeNanos, mt, at, aggTypesOpts, matchOpts)
 	}
 	return as.reverseMappingsForRollupID(
+		id,
 		name,
 		tags,
 		timeNanos,
@@ -774,7 +833,13 @@ func (as *activeRuleSet) reverseMappingsForNonRollupID(
 // and each aggregation type would generate a new id. So when doing reverse mapping, not only do
 // we need to match the roll up tags, we also need to check the aggregation type against
 // each rollup pipeline to see if the aggregation type was actually contained in the pipeline.
+// The rollup ID contains the tags rewritten by the relabel operations preceding the rollup
+// operation, and as such a rollup target whose relabel operations always remove one of its
+// rollup tags cannot produce the rollup ID. The relabeled IDs of the mapping rules with relabel
+// operations keep all of their tags, and are matched against the mapping rules whose filters
+// they match, under the same assumption that at most one such mapping rule may match them.
 func (as *activeRuleSet) reverseMappingsForRollupID(
+	id []byte,
 	name []byte,
 	sortedTagPairBytes []byte,
 	timeNanos int64,
@@ -791,8 +856,13 @@ func (as *activeRuleSet) reverseMappingsForRollupID(
 		}
 
 		for _, target := range snapshot.targets {
+			var relabelOps []mpipeline.RelabelOp
 			for i := 0; i < target.Pipeline.Len(); i++ {
 				pipelineOp := target.Pipeline.At(i)
+				if pipelineOp.Type == mpipeline.RelabelOpType {
+					relabelOps = append(relabelOps, pipelineOp.Relabel)
+					continue
+				}
 				if pipelineOp.Type != mpipeline.RollupOpType {
 					continue
 				}
@@ -800,13 +870,15 @@ func (as *activeRuleSet) reverseMappingsForRollupID(
 				if !bytes.Equal(rollupOp.NewName(name), name) {
 					continue
 				}
+				if !relabeledTagsMayContain(relabelOps, rollupOp.Tags) {
+					continue
+				}
 				_, matched, err := as.matchRollupTarget(
-					sortedTagPairBytes,
+					matchOpts.SortedTagIteratorFn(sortedTagPairBytes),
 					rollupOp,
 					nil,
 					nil,
 					matchRollupTargetOptions{generateRollupID: false},
-					matchOpts,
 				)
 				if err != nil {
 					return reverseMatchResult{}, false, err
@@ -819,38 +891,94 @@ func (as *activeRuleSet) reverseMappingsForRollupID(
 					AggregationID:   rollupOp.AggregationID,
 					StoragePolicies: target.StoragePolicies.Clone(),
 				}
-				// Only further filter the pipelines with aggregation types if the given metric type
-				// supports multiple aggregation types. This is because if a metric type only supports
-				// a single aggregation type, this is the only pipline that could possibly produce this
-				// rollup metric and as such is chosen. The aggregation type passed in is not used because
-				// it maybe not be accurate because it may not be possible to infer the actual aggregation
-				// type only from the metric ID.
-				filteredPipelines := []metadata.PipelineMetadata{pipeline}
-				if isMultiAggregationTypesAllowed {
-					filteredPipelines = filteredPipelinesWithAggregationType(filteredPipelines, mt, at, aggTypesOpts)
-				}
-				if len(filteredPipelines) == 0 {
-					return reverseMatchResult{
-						metadata: metadata.DefaultStagedMetadata,
-					}, false, nil
-				}
-
-				return reverseMatchResult{
-					metadata: metadata.StagedMetadata{
-						CutoverNanos: snapshot.cutoverNanos,
-						Tombstoned:   false,
-						Metadata:     metadata.Metadata{Pipelines: filteredPipelines},
-					},
-					keepOriginal: snapshot.keepOriginal,
-				}, true, nil
+				res, matched := reverseMatchResultFor(
+					pipeline,
+					snapshot.cutoverNanos,
+					snapshot.keepOriginal,
+					mt,
+					at,
+					isMultiAggregationTypesAllowed,
+					aggTypesOpts,
+				)
+				return res, matched, nil
 			}
 		}
 	}
+
+	for _, mappingRule := range as.mappingRules {
+		snapshot := mappingRule.activeSnapshot(timeNanos)
+		if snapshot == nil || snapshot.tombstoned || len(snapshot.relabel) == 0 {
+			continue
+		}
+		matches, err := snapshot.filter.Matches(id, filters.TagMatchOptions{
+			SortedTagIteratorFn: matchOpts.SortedTagIteratorFn,
+			NameAndTagsFn:       matchOpts.NameAndTagsFn,
+		})
+		if err != nil {
+			return reverseMatchResult{}, false, err
+		}
+		if !matches {
+			continue
+		}
+		pipeline := metadata.PipelineMetadata{
+			AggregationID:   snapshot.aggregationID,
+			StoragePolicies: snapshot.storagePolicies.Clone(),
+		}
+		res, matched := reverseMatchResultFor(
+			pipeline,
+			snapshot.cutoverNanos,
+			false,
+			mt,
+			at,
+			isMultiAggregationTypesAllowed,
+			aggTypesOpts,
+		)
+		return res, matched, nil
+	}
+
 	return reverseMatchResult{
 		metadata: metadata.DefaultStagedMetadata,
 	}, false, nil
 }
 
+// reverseMatchResultFor returns the reverse match result for the pipeline that produced
+// a rollup ID, and true if the pipeline is retained after being filtered with the
+// aggregation type, and false otherwise.
+func reverseMatchResultFor(
+	pipeline metadata.PipelineMetadata,
+	cutoverNanos int64,
+	keepOriginal bool,
+	mt metric.Type,
+	at aggregation.Type,
+	isMultiAggregationTypesAllowed bool,
+	aggTypesOpts aggregation.TypesOptions,
+) (reverseMatchResult, bool) {
+	// Only further filter the pipelines with aggregation types if the given metric type
+	// supports multiple aggregation types. This is because if a metric type only supports
+	// a single aggregation type, this is the only pipline that could possibly produce this
+	// rollup metric and as such is chosen. The aggregation type passed in is not used because
+	// it maybe not be accurate because it may not be possible to infer the actual aggregation
+	// type only from the metric ID.
+	filteredPipelines := []metadata.PipelineMetadata{pipeline}
+	if isMultiAggregationTypesAllowed {
+		filteredPipelines = filteredPipelinesWithAggregationType(filteredPipelines, mt, at, aggTypesOpts)
+	}
+	if len(filteredPipelines) == 0 {
+		return reverseMatchResult{
+			metadata: metadata.DefaultStagedMetadata,
+		}, false
+	}
+
+	return reverseMatchResult{
+		metadata: metadata.StagedMetadata{
+			CutoverNanos: cutoverNanos,
+			Tombstoned:   false,
+			Metadata:     metadata.Metadata{Pipelines: filteredPipelines},
+		},
+		keepOriginal: keepOriginal,
+	}, true
+}
+
 // nextCutoverIdx returns the next snapshot index whose cutover time is after t.
 // NB(xichen): not using sort.Search to avoid a lambda capture.
 func (as *activeRuleSet) nextCutoverIdx(t int64) int {
@@ -985,6 +1113,8 @@ func (a int64Asc) Less(i, j int) bool { return a[i] < a[j] }
 
 type matchRollupTargetOptions struct {
 	generateRollupID bool
+	// name is the name of the metric the rollup target is matched against.
+	name []byte
 }
 
 type ruleMatchResults struct {
@@ -1055,6 +1185,12 @@ type mappingResults struct {
 	// This represent the match result that should be applied against the
 	// incoming metric ID the mapping rules were matched against.
 	forExistingID ruleMatchResults
+
+	// This represents the rollup targets generating the relabeled IDs of the
+	// matched mapping rules with relabel operations, along with the tags of
+	// the mapping rules added to the relabeled IDs.
+	relabelTargets []rollupTarget
+	relabelTags    [][]models.Tag
 }
 
 type rollupResults struct {
@@ -0,0 +1,143 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/m3db/m3/src/metrics/filters"
+	"github.com/m3db/m3/src/metrics/metric"
+	"github.com/m3db/m3/src/metrics/metric/id"
+	"github.com/m3db/m3/src/x/ident"
+	"github.com/m3db/m3/src/x/pool"
+	"github.com/m3db/m3/src/x/serialize"
+)
+
+const (
+	ruleSetOptionsPoolSize = 16
+)
+
+// encodedTagsFilterOutTagPrefixes are the prefixes of the tags that are only
+// used for matching and are not included in rollup IDs.
+var encodedTagsFilterOutTagPrefixes = [][]byte{
+	metric.M3MetricsPrefix,
+}
+
+// NewRuleSetOptions returns the options of the rulesets matching metrics
+// whose ID is their serialized tags, with the name of the metric under the
+// name tag, as the coordinator downsampler does.
+func NewRuleSetOptions(nameTag []byte) Options {
+	// NB: the pools are small as these options are meant to match a few
+	// metrics outside of the ingestion path.
+	poolOpts := pool.NewObjectPoolOptions().SetSize(ruleSetOptionsPoolSize)
+
+	tagEncoderPool := serialize.NewTagEncoderPool(serialize.NewTagEncoderOptions(), poolOpts)
+	tagEncoderPool.Init()
+
+	tagDecoderPool := serialize.NewTagDecoderPool(
+		serialize.NewTagDecoderOptions(serialize.TagDecoderOptionsConfig{}), poolOpts)
+	tagDecoderPool.Init()
+
+	metricTagsIteratorPool := serialize.NewMetricTagsIteratorPool(tagDecoderPool, poolOpts)
+	metricTagsIteratorPool.Init()
+
+	return NewRuleSetOptionsWithPools(nameTag, tagEncoderPool, poolOpts, metricTagsIteratorPool)
+}
+
+// NewRuleSetOptionsWithPools returns the options of NewRuleSetOptions using
+// the given pools.
+func NewRuleSetOptionsWithPools(
+	nameTag []byte,
+	tagEncoderPool serialize.TagEncoderPool,
+	tagEncoderPoolOpts pool.ObjectPoolOptions,
+	metricTagsIteratorPool serialize.MetricTagsIteratorPool,
+) Options {
+	tagsFilterOpts := filters.TagsFilterOptions{
+		NameTagKey: nameTag,
+	}
+
+	isRollupIDFn := func(name []byte, tags []byte) bool {
+		return isRollupID(tags, metricTagsIteratorPool)
+	}
+
+	newRollupIDProviderPool := newRollupIDProviderPool(tagEncoderPool,
+		tagEncoderPoolOpts, ident.BytesID(nameTag))
+	newRollupIDProviderPool.Init()
+
+	newRollupIDFn := func(newName []byte, tagPairs []id.TagPair) []byte {
+		// First filter out any tags that have a prefix that
+		// are not included in output metric IDs (such as metric
+		// type tags that are just used for filtering like __m3_type__).
+		filtered := tagPairs[:0]
+	TagPairsFilterLoop:
+		for i := range tagPairs {
+			for _, filter := range encodedTagsFilterOutTagPrefixes {
+				if bytes.HasPrefix(tagPairs[i].Name, filter) {
+					continue TagPairsFilterLoop
+				}
+			}
+			filtered = append(filtered, tagPairs[i])
+		}
+
+		// Create the rollup using filtered tag pairs.
+		rollupIDProvider := newRollupIDProviderPool.Get()
+		id, err := rollupIDProvider.provide(newName, filtered)
+		if err != nil {
+			panic(err) // Encoding should never fail
+		}
+		rollupIDProvider.finalize()
+		return id
+	}
+
+	return NewOptions().
+		SetTagsFilterOptions(tagsFilterOpts).
+		SetNewRollupIDFn(newRollupIDFn).
+		SetIsRollupIDFn(isRollupIDFn)
+}
+
+// NewRuleSetMatchOptions returns the options to match the metric IDs
+// described by NewRuleSetOptions with.
+func NewRuleSetMatchOptions(nameTag []byte) MatchOptions {
+	limits := serialize.NewTagSerializationLimits()
+	return MatchOptions{
+		NameAndTagsFn: NewEncodedTagsNameAndTagsFn(nameTag),
+		SortedTagIteratorFn: func(tagPairs []byte) id.SortedTagIterator {
+			iter := serialize.NewUncheckedMetricTagsIterator(limits)
+			iter.Reset(tagPairs)
+			return iter
+		},
+	}
+}
+
+// NewEncodedTagsNameAndTagsFn returns the function that resolves the name
+// and tags of the metric IDs described by NewRuleSetOptions.
+func NewEncodedTagsNameAndTagsFn(nameTag []byte) id.NameAndTagsFn {
+	return func(id []byte) ([]byte, []byte, error) {
+		name, err := resolveEncodedTagsNameTag(id, nameTag)
+		if err != nil && !errors.Is(err, errNoMetricNameTag) {
+			return nil, nil, err
+		}
+		// ID is always the encoded tags for IDs in the downsampler
+		tags := id
+		return name, tags, nil
+	}
+}
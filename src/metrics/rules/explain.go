@@ -0,0 +1,203 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"errors"
+
+	"github.com/m3db/m3/src/metrics/filters"
+	metricid "github.com/m3db/m3/src/metrics/metric/id"
+	mpipeline "github.com/m3db/m3/src/metrics/pipeline"
+	"github.com/m3db/m3/src/metrics/rules/view"
+)
+
+// Explanation explains how an id is matched against the rules of a ruleset.
+type Explanation struct {
+	// MappingRules are the active mapping rules whose filters match the id.
+	MappingRules []view.MappingRule
+
+	// RollupRules are the active rollup rules whose filters match the id.
+	RollupRules []view.RollupRule
+
+	// RollupTargets are the rollup ids the targets of the matched rollup
+	// rules produce for the id.
+	RollupTargets []ExplainedRollupTarget
+
+	// MatchResult is the result of forward matching the id.
+	MatchResult MatchResult
+}
+
+// ExplainedRollupTarget is the rollup id a target of a matched rollup rule
+// produces for an id.
+type ExplainedRollupTarget struct {
+	// RuleName is the name of the rollup rule.
+	RuleName string
+
+	// TargetIndex is the index of the target within the rollup rule.
+	TargetIndex int
+
+	// RollupID is the rollup id the target produces.
+	RollupID []byte
+}
+
+var errExplainUnsupportedRuleSet = errors.New("ruleset cannot be explained")
+
+// Explain matches an id against the rules of a ruleset active at a given
+// time and returns the matching rules along with the forward match result.
+func Explain(
+	rs RuleSet,
+	id metricid.ID,
+	timeNanos int64,
+	opts MatchOptions,
+) (Explanation, error) {
+	r, ok := rs.(*ruleSet)
+	if !ok {
+		return Explanation{}, errExplainUnsupportedRuleSet
+	}
+	return r.explain(id, timeNanos, opts)
+}
+
+func (rs *ruleSet) explain(
+	id metricid.ID,
+	timeNanos int64,
+	opts MatchOptions,
+) (Explanation, error) {
+	as := rs.activeSet(timeNanos)
+	matchResult, err := as.ForwardMatch(id, timeNanos, timeNanos+1, opts)
+	if err != nil {
+		return Explanation{}, err
+	}
+
+	tagMatchOpts := filters.TagMatchOptions{
+		NameAndTagsFn:       opts.NameAndTagsFn,
+		SortedTagIteratorFn: opts.SortedTagIteratorFn,
+	}
+	var mappingRules []view.MappingRule
+	for _, mappingRule := range as.mappingRules {
+		idx := mappingRule.activeIndex(timeNanos)
+		if idx < 0 || mappingRule.snapshots[idx].tombstoned {
+			continue
+		}
+		matches, err := mappingRule.snapshots[idx].filter.Matches(id.Bytes(), tagMatchOpts)
+		if err != nil {
+			return Explanation{}, err
+		}
+		if !matches {
+			continue
+		}
+		mrv, err := mappingRule.mappingRuleView(idx)
+		if err != nil {
+			return Explanation{}, err
+		}
+		mappingRules = append(mappingRules, mrv)
+	}
+
+	var (
+		rollupRules   []view.RollupRule
+		rollupTargets []ExplainedRollupTarget
+	)
+	for _, rollupRule := range as.rollupRules {
+		idx := rollupRule.activeIndex(timeNanos)
+		if idx < 0 || rollupRule.snapshots[idx].tombstoned {
+			continue
+		}
+		snapshot := rollupRule.snapshots[idx]
+		matches, err := snapshot.filter.Matches(id.Bytes(), tagMatchOpts)
+		if err != nil {
+			return Explanation{}, err
+		}
+		if !matches {
+			continue
+		}
+		rrv, err := rollupRule.rollupRuleView(idx)
+		if err != nil {
+			return Explanation{}, err
+		}
+		rollupRules = append(rollupRules, rrv)
+
+		targets, err := as.explainRollupTargets(id.Bytes(), snapshot, opts)
+		if err != nil {
+			return Explanation{}, err
+		}
+		rollupTargets = append(rollupTargets, targets...)
+	}
+
+	return Explanation{
+		MappingRules:  mappingRules,
+		RollupRules:   rollupRules,
+		RollupTargets: rollupTargets,
+		MatchResult:   matchResult,
+	}, nil
+}
+
+// explainRollupTargets returns the rollup ids produced for an id by the
+// first rollup operation of each target of a rollup rule snapshot, after the
+// relabel operations preceding it, skipping the targets without a rollup
+// operation or whose rollup tags the id lacks.
+func (as *activeRuleSet) explainRollupTargets(
+	id []byte,
+	snapshot *rollupRuleSnapshot,
+	opts MatchOptions,
+) ([]ExplainedRollupTarget, error) {
+	name, sortedTagPairBytes, err := opts.NameAndTagsFn(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		targets  []ExplainedRollupTarget
+		tagPairs []metricid.TagPair
+	)
+	for targetIdx, target := range snapshot.targets {
+		var relabeled []metricid.TagPair
+		for i := 0; i < target.Pipeline.Len(); i++ {
+			op := target.Pipeline.At(i)
+			if op.Type == mpipeline.RelabelOpType {
+				relabeled, err = relabelTags(sortedTagPairBytes, relabeled, op.Relabel, opts)
+				if err != nil {
+					return nil, err
+				}
+				continue
+			}
+			if op.Type != mpipeline.RollupOpType {
+				continue
+			}
+			rollupID, matched, err := as.matchRollupTarget(
+				newSortedTagIterator(sortedTagPairBytes, relabeled, opts),
+				op.Rollup,
+				tagPairs[:0],
+				snapshot.tags,
+				matchRollupTargetOptions{generateRollupID: true, name: name})
+			if err != nil {
+				return nil, err
+			}
+			if matched {
+				targets = append(targets, ExplainedRollupTarget{
+					RuleName:    snapshot.name,
+					TargetIndex: targetIdx,
+					RollupID:    rollupID,
+				})
+			}
+			break
+		}
+	}
+	return targets, nil
+}
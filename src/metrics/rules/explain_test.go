@@ -0,0 +1,147 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/metrics/aggregation"
+	"github.com/m3db/m3/src/metrics/matcher/namespace"
+	"github.com/m3db/m3/src/metrics/pipeline"
+	"github.com/m3db/m3/src/metrics/policy"
+	"github.com/m3db/m3/src/metrics/rules/view"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+func TestRuleSetExplain(t *testing.T) {
+	rollupOp, err := pipeline.NewRollupOp(
+		pipeline.GroupByRollupType,
+		"rollupName",
+		[]string{"service"},
+		aggregation.DefaultID,
+	)
+	require.NoError(t, err)
+
+	rsv := view.RuleSet{
+		Namespace: "ns",
+		Version:   2,
+		MappingRules: []view.MappingRule{
+			{
+				ID:     "mappingRuleID",
+				Name:   "mappingRule",
+				Filter: "name:foo env:prod",
+				StoragePolicies: policy.StoragePolicies{
+					policy.NewStoragePolicy(10*time.Second, xtime.Second, 24*time.Hour),
+				},
+			},
+			{
+				Name:       "dropRule",
+				Filter:     "name:bar",
+				DropPolicy: policy.DropMust,
+			},
+			{
+				Name:       "tombstonedRule",
+				Filter:     "name:foo",
+				Tombstoned: true,
+			},
+		},
+		RollupRules: []view.RollupRule{
+			{
+				Name:   "rollupRule",
+				Filter: "name:foo",
+				Targets: []view.RollupTarget{
+					{
+						Pipeline: pipeline.NewPipeline([]pipeline.OpUnion{
+							{
+								Type:   pipeline.RollupOpType,
+								Rollup: rollupOp,
+							},
+						}),
+						StoragePolicies: policy.StoragePolicies{
+							policy.NewStoragePolicy(time.Minute, xtime.Minute, 48*time.Hour),
+						},
+					},
+				},
+			},
+		},
+	}
+	rs, err := NewRuleSetFromView(rsv, 0, testRuleSetOptions())
+	require.NoError(t, err)
+	require.Equal(t, 2, rs.Version())
+
+	nowNanos := time.Now().UnixNano()
+	explanation, err := Explain(
+		rs,
+		namespace.NewTestID("foo|env=prod,service=api", "ns"),
+		nowNanos,
+		testMatchOptions(),
+	)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(explanation.MappingRules))
+	require.Equal(t, "mappingRuleID", explanation.MappingRules[0].ID)
+	require.Equal(t, "mappingRule", explanation.MappingRules[0].Name)
+	require.Equal(t, 1, len(explanation.RollupRules))
+	require.Equal(t, "rollupRule", explanation.RollupRules[0].Name)
+	require.Equal(t, []ExplainedRollupTarget{
+		{
+			RuleName:    "rollupRule",
+			TargetIndex: 0,
+			RollupID:    []byte("rollupName|service=api"),
+		},
+	}, explanation.RollupTargets)
+
+	res := explanation.MatchResult
+	forExistingID := res.ForExistingIDAt(nowNanos)
+	require.Equal(t, 1, len(forExistingID))
+	require.Equal(t, 1, len(forExistingID[0].Pipelines))
+	require.Equal(t, rsv.MappingRules[0].StoragePolicies, forExistingID[0].Pipelines[0].StoragePolicies)
+	require.Equal(t, 1, res.NumNewRollupIDs())
+	rollupID := res.ForNewRollupIDsAt(0, nowNanos)
+	require.Equal(t, "rollupName|service=api", string(rollupID.ID))
+
+	explanation, err = Explain(rs, namespace.NewTestID("bar|env=prod", "ns"), nowNanos, testMatchOptions())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(explanation.MappingRules))
+	require.Equal(t, "dropRule", explanation.MappingRules[0].Name)
+	require.Empty(t, explanation.RollupRules)
+	require.Empty(t, explanation.RollupTargets)
+	require.True(t, explanation.MatchResult.ForExistingIDAt(nowNanos).IsDropPolicySet())
+
+	explanation, err = Explain(rs, namespace.NewTestID("baz|env=prod", "ns"), nowNanos, testMatchOptions())
+	require.NoError(t, err)
+	require.Empty(t, explanation.MappingRules)
+	require.Empty(t, explanation.RollupRules)
+	require.Equal(t, 0, explanation.MatchResult.NumNewRollupIDs())
+}
+
+func TestNewRuleSetFromViewInvalidRule(t *testing.T) {
+	_, err := NewRuleSetFromView(view.RuleSet{
+		Namespace: "ns",
+		MappingRules: []view.MappingRule{
+			{Name: "rule", Filter: "name:foo"},
+			{Name: "rule", Filter: "name:bar"},
+		},
+	}, 0, testRuleSetOptions())
+	require.Error(t, err)
+}
@@ -32,9 +32,11 @@ import (
 	merrors "github.com/m3db/m3/src/metrics/errors"
 	"github.com/m3db/m3/src/metrics/filters"
 	"github.com/m3db/m3/src/metrics/generated/proto/metricpb"
+	"github.com/m3db/m3/src/metrics/generated/proto/pipelinepb"
 	"github.com/m3db/m3/src/metrics/generated/proto/policypb"
 	"github.com/m3db/m3/src/metrics/generated/proto/rulepb"
 	"github.com/m3db/m3/src/metrics/metric"
+	mpipeline "github.com/m3db/m3/src/metrics/pipeline"
 	"github.com/m3db/m3/src/metrics/policy"
 	"github.com/m3db/m3/src/metrics/rules/view"
 	"github.com/m3db/m3/src/query/models"
@@ -42,6 +44,10 @@ import (
 
 const (
 	nanosPerMilli = int64(time.Millisecond / time.Nanosecond)
+
+	// relabelRollupNewName keeps the name of the metrics relabeled by the
+	// mapping rules.
+	relabelRollupNewName = "{{ .MetricName }}"
 )
 
 var (
@@ -51,12 +57,15 @@ var (
 	errMappingRuleSnapshotIndexOutOfRange                  = errors.New("mapping rule snapshot index out of range")
 	errNilMappingRuleSnapshotProto                         = errors.New("nil mapping rule snapshot proto")
 	errNilMappingRuleProto                                 = errors.New("nil mapping rule proto")
+	errRelabelAndDropPolicyInMappingRuleSnapshot           = errors.New("relabel operations and a drop policy specified in mapping rule snapshot")
 
 	pathSeparator = []byte(".")
 )
 
 // mappingRuleSnapshot defines a rule snapshot such that if a metric matches the
 // provided filters, it is aggregated and retained under the provided set of policies.
+// If the snapshot has relabel operations, the metric is instead aggregated and
+// retained under the ID with the tags rewritten by the relabel operations.
 type mappingRuleSnapshot struct {
 	name               string
 	tombstoned         bool
@@ -67,6 +76,7 @@ type mappingRuleSnapshot struct {
 	storagePolicies    policy.StoragePolicies
 	dropPolicy         policy.DropPolicy
 	tags               []models.Tag
+	relabel            []mpipeline.RelabelOp
 	graphitePrefix     [][]byte
 	lastUpdatedAtNanos int64
 	lastUpdatedBy      string
@@ -118,6 +128,22 @@ func newMappingRuleSnapshotFromProto(
 		return nil, errStoragePoliciesAndDropPolicyInMappingRuleSnapshot
 	}
 
+	if len(r.Relabel) > 0 && dropPolicy != policy.DropNone {
+		return nil, errRelabelAndDropPolicyInMappingRuleSnapshot
+	}
+
+	var relabel []mpipeline.RelabelOp
+	if len(r.Relabel) > 0 {
+		relabel = make([]mpipeline.RelabelOp, 0, len(r.Relabel))
+		for _, pb := range r.Relabel {
+			op, err := mpipeline.NewRelabelOpFromProto(pb)
+			if err != nil {
+				return nil, err
+			}
+			relabel = append(relabel, op)
+		}
+	}
+
 	filterValues, err := filters.ParseTagFilterValueMap(r.Filter)
 	if err != nil {
 		return nil, err
@@ -137,6 +163,7 @@ func newMappingRuleSnapshotFromProto(
 		storagePolicies,
 		policy.DropPolicy(r.DropPolicy),
 		models.TagsFromProto(r.Tags),
+		relabel,
 		r.LastUpdatedAtNanos,
 		r.LastUpdatedBy,
 	), nil
@@ -151,6 +178,7 @@ func newMappingRuleSnapshotFromFields(
 	storagePolicies policy.StoragePolicies,
 	dropPolicy policy.DropPolicy,
 	tags []models.Tag,
+	relabel []mpipeline.RelabelOp,
 	lastUpdatedAtNanos int64,
 	lastUpdatedBy string,
 ) (*mappingRuleSnapshot, error) {
@@ -167,6 +195,7 @@ func newMappingRuleSnapshotFromFields(
 		storagePolicies,
 		dropPolicy,
 		tags,
+		relabel,
 		lastUpdatedAtNanos,
 		lastUpdatedBy,
 	), nil
@@ -184,6 +213,7 @@ func newMappingRuleSnapshotFromFieldsInternal(
 	storagePolicies policy.StoragePolicies,
 	dropPolicy policy.DropPolicy,
 	tags []models.Tag,
+	relabel []mpipeline.RelabelOp,
 	lastUpdatedAtNanos int64,
 	lastUpdatedBy string,
 ) *mappingRuleSnapshot {
@@ -206,6 +236,7 @@ func newMappingRuleSnapshotFromFieldsInternal(
 		storagePolicies:    storagePolicies,
 		dropPolicy:         dropPolicy,
 		tags:               tags,
+		relabel:            relabel,
 		graphitePrefix:     graphitePrefix,
 		lastUpdatedAtNanos: lastUpdatedAtNanos,
 		lastUpdatedBy:      lastUpdatedBy,
@@ -215,6 +246,13 @@ func newMappingRuleSnapshotFromFieldsInternal(
 func (mrs *mappingRuleSnapshot) clone() mappingRuleSnapshot {
 	tags := make([]models.Tag, len(mrs.tags))
 	copy(tags, mrs.tags)
+	var relabel []mpipeline.RelabelOp
+	if len(mrs.relabel) > 0 {
+		relabel = make([]mpipeline.RelabelOp, 0, len(mrs.relabel))
+		for _, op := range mrs.relabel {
+			relabel = append(relabel, op.Clone())
+		}
+	}
 	return mappingRuleSnapshot{
 		name:               mrs.name,
 		tombstoned:         mrs.tombstoned,
@@ -225,11 +263,42 @@ func (mrs *mappingRuleSnapshot) clone() mappingRuleSnapshot {
 		storagePolicies:    mrs.storagePolicies.Clone(),
 		dropPolicy:         mrs.dropPolicy,
 		tags:               mrs.tags,
+		relabel:            relabel,
 		lastUpdatedAtNanos: mrs.lastUpdatedAtNanos,
 		lastUpdatedBy:      mrs.lastUpdatedBy,
 	}
 }
 
+// relabelTarget returns the rollup target aggregating the metrics matching the
+// snapshot under their relabeled IDs, which keep the name and all the tags
+// rewritten by the relabel operations of the snapshot.
+func (mrs *mappingRuleSnapshot) relabelTarget() (rollupTarget, error) {
+	rollupOp, err := mpipeline.NewRollupOp(
+		mpipeline.ExcludeByRollupType,
+		relabelRollupNewName,
+		nil,
+		mrs.aggregationID,
+	)
+	if err != nil {
+		return emptyRollupTarget, err
+	}
+	ops := make([]mpipeline.OpUnion, 0, len(mrs.relabel)+1)
+	for _, relabelOp := range mrs.relabel {
+		ops = append(ops, mpipeline.OpUnion{
+			Type:    mpipeline.RelabelOpType,
+			Relabel: relabelOp,
+		})
+	}
+	ops = append(ops, mpipeline.OpUnion{
+		Type:   mpipeline.RollupOpType,
+		Rollup: rollupOp,
+	})
+	return rollupTarget{
+		Pipeline:        mpipeline.NewPipeline(ops),
+		StoragePolicies: mrs.storagePolicies.Clone(),
+	}, nil
+}
+
 // proto returns the given MappingRuleSnapshot in protobuf form.
 func (mrs *mappingRuleSnapshot) proto() (*rulepb.MappingRuleSnapshot, error) {
 	aggTypes, err := mrs.aggregationID.Types()
@@ -248,6 +317,17 @@ func (mrs *mappingRuleSnapshot) proto() (*rulepb.MappingRuleSnapshot, error) {
 	for _, tag := range mrs.tags {
 		tags = append(tags, tag.ToProto())
 	}
+	var relabel []*pipelinepb.RelabelOp
+	if len(mrs.relabel) > 0 {
+		relabel = make([]*pipelinepb.RelabelOp, 0, len(mrs.relabel))
+		for _, op := range mrs.relabel {
+			pb, err := op.Proto()
+			if err != nil {
+				return nil, err
+			}
+			relabel = append(relabel, pb)
+		}
+	}
 	return &rulepb.MappingRuleSnapshot{
 		Name:               mrs.name,
 		Tombstoned:         mrs.tombstoned,
@@ -259,6 +339,7 @@ func (mrs *mappingRuleSnapshot) proto() (*rulepb.MappingRuleSnapshot, error) {
 		StoragePolicies:    storagePolicies,
 		DropPolicy:         policypb.DropPolicy(mrs.dropPolicy),
 		Tags:               tags,
+		Relabel:            relabel,
 	}, nil
 }
 
@@ -370,6 +451,7 @@ func (mc *mappingRule) addSnapshot(
 	storagePolicies policy.StoragePolicies,
 	dropPolicy policy.DropPolicy,
 	tags []models.Tag,
+	relabel []mpipeline.RelabelOp,
 	meta UpdateMetadata,
 ) error {
 	snapshot, err := newMappingRuleSnapshotFromFields(
@@ -381,6 +463,7 @@ func (mc *mappingRule) addSnapshot(
 		storagePolicies,
 		dropPolicy,
 		tags,
+		relabel,
 		meta.updatedAtNanos,
 		meta.updatedBy,
 	)
@@ -411,6 +494,7 @@ func (mc *mappingRule) markTombstoned(meta UpdateMetadata) error {
 	snapshot.aggregationID = aggregation.DefaultID
 	snapshot.storagePolicies = nil
 	snapshot.dropPolicy = 0
+	snapshot.relabel = nil
 	mc.snapshots = append(mc.snapshots, &snapshot)
 	return nil
 }
@@ -422,6 +506,7 @@ func (mc *mappingRule) revive(
 	storagePolicies policy.StoragePolicies,
 	dropPolicy policy.DropPolicy,
 	tags []models.Tag,
+	relabel []mpipeline.RelabelOp,
 	meta UpdateMetadata,
 ) error {
 	n, err := mc.name()
@@ -432,7 +517,7 @@ func (mc *mappingRule) revive(
 		return merrors.NewInvalidInputError(fmt.Sprintf("%s is not tombstoned", n))
 	}
 	return mc.addSnapshot(name, rawFilter, aggregationID, storagePolicies,
-		dropPolicy, tags, meta)
+		dropPolicy, tags, relabel, meta)
 }
 
 func (mc *mappingRule) activeIndex(timeNanos int64) int {
@@ -475,5 +560,6 @@ func (mc *mappingRule) mappingRuleView(snapshotIdx int) (view.MappingRule, error
 		LastUpdatedBy:       mrs.lastUpdatedBy,
 		LastUpdatedAtMillis: mrs.lastUpdatedAtNanos / nanosPerMilli,
 		Tags:                mrs.tags,
+		Relabel:             mrs.relabel,
 	}, nil
 }
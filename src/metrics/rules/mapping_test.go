@@ -34,8 +34,10 @@ import (
 	"github.com/m3db/m3/src/metrics/filters"
 	"github.com/m3db/m3/src/metrics/generated/proto/aggregationpb"
 	"github.com/m3db/m3/src/metrics/generated/proto/metricpb"
+	"github.com/m3db/m3/src/metrics/generated/proto/pipelinepb"
 	"github.com/m3db/m3/src/metrics/generated/proto/policypb"
 	"github.com/m3db/m3/src/metrics/generated/proto/rulepb"
+	"github.com/m3db/m3/src/metrics/pipeline"
 	"github.com/m3db/m3/src/metrics/policy"
 	"github.com/m3db/m3/src/metrics/rules/view"
 	"github.com/m3db/m3/src/query/models"
@@ -445,6 +447,65 @@ func TestNewMappingRuleSnapshotStoragePoliciesAndDropPolicy(t *testing.T) {
 	require.Equal(t, errStoragePoliciesAndDropPolicyInMappingRuleSnapshot, err)
 }
 
+func TestNewMappingRuleSnapshotRelabelAndDropPolicy(t *testing.T) {
+	proto := &rulepb.MappingRuleSnapshot{
+		DropPolicy: policypb.DropPolicy_DROP_MUST,
+		Relabel: []*pipelinepb.RelabelOp{
+			{
+				Action:    pipelinepb.RelabelOp_LABEL_DROP,
+				Separator: ";",
+				Regex:     "tag2",
+			},
+		},
+	}
+	_, err := newMappingRuleSnapshotFromProto(proto, testTagsFilterOptions())
+	require.Equal(t, errRelabelAndDropPolicyInMappingRuleSnapshot, err)
+}
+
+func TestMappingRuleSnapshotRelabelProtoRoundtrip(t *testing.T) {
+	relabelOp, err := pipeline.NewRelabelOp(
+		pipeline.ReplaceRelabelAction,
+		[]string{"host"},
+		";",
+		"[^.]+\\.(.*)",
+		"dc",
+		"$1",
+		0,
+	)
+	require.NoError(t, err)
+	snapshot, err := newMappingRuleSnapshotFromFields(
+		"foo",
+		12345000000,
+		nil,
+		"tag1:value1",
+		aggregation.DefaultID,
+		policy.StoragePolicies{
+			policy.NewStoragePolicy(10*time.Second, xtime.Second, 24*time.Hour),
+		},
+		policy.DropNone,
+		[]models.Tag{{Name: []byte("name"), Value: []byte("value")}},
+		[]pipeline.RelabelOp{relabelOp},
+		12345000000,
+		"someone",
+	)
+	require.NoError(t, err)
+
+	pb, err := snapshot.proto()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(pb.Relabel))
+
+	data, err := pb.Marshal()
+	require.NoError(t, err)
+	var unmarshaled rulepb.MappingRuleSnapshot
+	require.NoError(t, unmarshaled.Unmarshal(data))
+	require.Equal(t, pb, &unmarshaled)
+
+	res, err := newMappingRuleSnapshotFromProto(&unmarshaled, testTagsFilterOptions())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(res.relabel))
+	require.True(t, relabelOp.Equal(res.relabel[0]))
+}
+
 func TestNewMappingRuleSnapshotInvalidDropPolicy(t *testing.T) {
 	proto := &rulepb.MappingRuleSnapshot{
 		DropPolicy: policypb.DropPolicy(-1),
@@ -463,6 +524,7 @@ func TestNewMappingRuleSnapshotFromFields(t *testing.T) {
 		testMappingRuleSnapshot3.storagePolicies,
 		testMappingRuleSnapshot3.dropPolicy,
 		testMappingRuleSnapshot3.tags,
+		testMappingRuleSnapshot3.relabel,
 		testMappingRuleSnapshot3.lastUpdatedAtNanos,
 		testMappingRuleSnapshot3.lastUpdatedBy,
 	)
@@ -487,6 +549,7 @@ func TestNewMappingRuleSnapshotFromFieldsValidationError(t *testing.T) {
 			nil,
 			policy.DropNone,
 			nil,
+			nil,
 			1234,
 			"test_user",
 		)
@@ -0,0 +1,108 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	metricid "github.com/m3db/m3/src/metrics/metric/id"
+	mpipeline "github.com/m3db/m3/src/metrics/pipeline"
+)
+
+// relabelTags applies a relabel operation to the tags matched against the
+// rollup operations of a pipeline. The relabeled tags are nil until the
+// first relabel operation of a pipeline, in which case they are decoded
+// from the sorted tag pairs of the incoming metric ID.
+func relabelTags(
+	sortedTagPairBytes []byte,
+	relabeled []metricid.TagPair,
+	relabelOp mpipeline.RelabelOp,
+	matchOpts MatchOptions,
+) ([]metricid.TagPair, error) {
+	if relabeled == nil {
+		sortedTagIter := matchOpts.SortedTagIteratorFn(sortedTagPairBytes)
+		relabeled = make([]metricid.TagPair, 0, 8)
+		for sortedTagIter.Next() {
+			tagName, tagVal := sortedTagIter.Current()
+			relabeled = append(relabeled, metricid.TagPair{Name: tagName, Value: tagVal})
+		}
+		if err := sortedTagIter.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return relabelOp.Apply(relabeled), nil
+}
+
+// relabeledTagsMayContain returns false if the relabel operations always remove
+// one of the tags, in which case the tags rewritten by the relabel operations can
+// never contain all of the tags, and true otherwise.
+func relabeledTagsMayContain(relabelOps []mpipeline.RelabelOp, tagNames [][]byte) bool {
+	for _, tagName := range tagNames {
+		mayContain := true
+		for _, relabelOp := range relabelOps {
+			if relabelOp.RemovesTag(tagName) {
+				mayContain = false
+			} else if relabelOp.MaySetTag(tagName) {
+				mayContain = true
+			}
+		}
+		if !mayContain {
+			return false
+		}
+	}
+	return true
+}
+
+// newSortedTagIterator returns an iterator over the relabeled tags if the
+// tags have been relabeled, and over the sorted tag pairs of the incoming
+// metric ID otherwise.
+func newSortedTagIterator(
+	sortedTagPairBytes []byte,
+	relabeled []metricid.TagPair,
+	matchOpts MatchOptions,
+) metricid.SortedTagIterator {
+	if relabeled == nil {
+		return matchOpts.SortedTagIteratorFn(sortedTagPairBytes)
+	}
+	return &tagPairsIterator{tagPairs: relabeled, idx: -1}
+}
+
+// tagPairsIterator iterates over a list of tag pairs sorted by name.
+type tagPairsIterator struct {
+	tagPairs []metricid.TagPair
+	idx      int
+}
+
+func (it *tagPairsIterator) Reset(_ []byte) { it.idx = -1 }
+
+func (it *tagPairsIterator) Next() bool {
+	if it.idx >= len(it.tagPairs) {
+		return false
+	}
+	it.idx++
+	return it.idx < len(it.tagPairs)
+}
+
+func (it *tagPairsIterator) Current() ([]byte, []byte) {
+	return it.tagPairs[it.idx].Name, it.tagPairs[it.idx].Value
+}
+
+func (it *tagPairsIterator) Err() error { return nil }
+
+func (it *tagPairsIterator) Close() {}
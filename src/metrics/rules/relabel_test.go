@@ -0,0 +1,432 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/metrics/aggregation"
+	"github.com/m3db/m3/src/metrics/matcher/namespace"
+	"github.com/m3db/m3/src/metrics/metric"
+	"github.com/m3db/m3/src/metrics/pipeline"
+	"github.com/m3db/m3/src/metrics/pipeline/applied"
+	"github.com/m3db/m3/src/metrics/policy"
+	"github.com/m3db/m3/src/metrics/rules/view"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+func TestRuleSetForwardMatchRelabelBeforeRollup(t *testing.T) {
+	replaceOp, err := pipeline.NewRelabelOp(
+		pipeline.ReplaceRelabelAction,
+		[]string{"host"},
+		";",
+		"[^.]+\\.(.*)",
+		"dc",
+		"$1",
+		0,
+	)
+	require.NoError(t, err)
+	labelMapOp, err := pipeline.NewRelabelOp(
+		pipeline.LabelMapRelabelAction,
+		nil,
+		";",
+		"svc",
+		"",
+		"service",
+		0,
+	)
+	require.NoError(t, err)
+	rollupOp, err := pipeline.NewRollupOp(
+		pipeline.GroupByRollupType,
+		"rollupName",
+		[]string{"dc", "service"},
+		aggregation.DefaultID,
+	)
+	require.NoError(t, err)
+
+	rsv := view.RuleSet{
+		Namespace: "ns",
+		RollupRules: []view.RollupRule{
+			{
+				Name:   "rollupRule",
+				Filter: "name:foo",
+				Targets: []view.RollupTarget{
+					{
+						Pipeline: pipeline.NewPipeline([]pipeline.OpUnion{
+							{
+								Type:    pipeline.RelabelOpType,
+								Relabel: replaceOp,
+							},
+							{
+								Type:    pipeline.RelabelOpType,
+								Relabel: labelMapOp,
+							},
+							{
+								Type:   pipeline.RollupOpType,
+								Rollup: rollupOp,
+							},
+						}),
+						StoragePolicies: policy.StoragePolicies{
+							policy.NewStoragePolicy(time.Minute, xtime.Minute, 48*time.Hour),
+						},
+					},
+				},
+			},
+		},
+	}
+	rs, err := NewRuleSetFromView(rsv, 0, testRuleSetOptions())
+	require.NoError(t, err)
+
+	nowNanos := time.Now().UnixNano()
+	explanation, err := Explain(
+		rs,
+		namespace.NewTestID("foo|host=host-1.dc1,svc=api", "ns"),
+		nowNanos,
+		testMatchOptions(),
+	)
+	require.NoError(t, err)
+	require.Equal(t, []ExplainedRollupTarget{
+		{
+			RuleName:    "rollupRule",
+			TargetIndex: 0,
+			RollupID:    []byte("rollupName|dc=dc1,service=api"),
+		},
+	}, explanation.RollupTargets)
+
+	res := explanation.MatchResult
+	require.Equal(t, 1, res.NumNewRollupIDs())
+	rollupID := res.ForNewRollupIDsAt(0, nowNanos)
+	require.Equal(t, "rollupName|dc=dc1,service=api", string(rollupID.ID))
+	require.Equal(t, 1, len(rollupID.Metadatas))
+	require.Equal(t, 1, len(rollupID.Metadatas[0].Pipelines))
+	require.True(t, rollupID.Metadatas[0].Pipelines[0].Pipeline.IsEmpty())
+
+	// The host tag is not relabeled into a dc tag, the id is not rolled up.
+	explanation, err = Explain(
+		rs,
+		namespace.NewTestID("foo|host=host-1,svc=api", "ns"),
+		nowNanos,
+		testMatchOptions(),
+	)
+	require.NoError(t, err)
+	require.Empty(t, explanation.RollupTargets)
+	require.Equal(t, 0, explanation.MatchResult.NumNewRollupIDs())
+}
+
+func TestRuleSetForwardMatchRelabelBeforeAggregation(t *testing.T) {
+	replaceOp, err := pipeline.NewRelabelOp(
+		pipeline.ReplaceRelabelAction,
+		[]string{"host"},
+		";",
+		"[^.]+\\.(.*)",
+		"dc",
+		"$1",
+		0,
+	)
+	require.NoError(t, err)
+	rollupOp, err := pipeline.NewRollupOp(
+		pipeline.GroupByRollupType,
+		"rollupName",
+		[]string{"dc"},
+		aggregation.DefaultID,
+	)
+	require.NoError(t, err)
+
+	rsv := view.RuleSet{
+		Namespace: "ns",
+		RollupRules: []view.RollupRule{
+			{
+				Name:   "rollupRule",
+				Filter: "name:foo",
+				Targets: []view.RollupTarget{
+					{
+						Pipeline: pipeline.NewPipeline([]pipeline.OpUnion{
+							{
+								Type:    pipeline.RelabelOpType,
+								Relabel: replaceOp,
+							},
+							{
+								Type:        pipeline.AggregationOpType,
+								Aggregation: pipeline.AggregationOp{Type: aggregation.Sum},
+							},
+							{
+								Type:   pipeline.RollupOpType,
+								Rollup: rollupOp,
+							},
+						}),
+						StoragePolicies: policy.StoragePolicies{
+							policy.NewStoragePolicy(time.Minute, xtime.Minute, 48*time.Hour),
+						},
+					},
+				},
+			},
+		},
+	}
+	rs, err := NewRuleSetFromView(rsv, 0, testRuleSetOptions())
+	require.NoError(t, err)
+
+	nowNanos := time.Now().UnixNano()
+	res, err := rs.ActiveSet(nowNanos).ForwardMatch(
+		namespace.NewTestID("foo|host=host-1.dc1,svc=api", "ns"),
+		nowNanos,
+		nowNanos+1,
+		testMatchOptions(),
+	)
+	require.NoError(t, err)
+	require.Equal(t, 0, res.NumNewRollupIDs())
+
+	// The existing id is aggregated before being rolled up by the relabeled tags.
+	forExistingID := res.ForExistingIDAt(nowNanos)
+	require.Equal(t, 1, len(forExistingID))
+	var found bool
+	for _, p := range forExistingID[0].Pipelines {
+		if !p.AggregationID.Equal(aggregation.MustCompressTypes(aggregation.Sum)) {
+			continue
+		}
+		found = true
+		require.Equal(t, applied.NewPipeline([]applied.OpUnion{
+			{
+				Type: pipeline.RollupOpType,
+				Rollup: applied.RollupOp{
+					ID:            []byte("rollupName|dc=dc1"),
+					AggregationID: aggregation.DefaultID,
+				},
+			},
+		}), p.Pipeline)
+	}
+	require.True(t, found)
+}
+
+func TestRuleSetForwardMatchMappingRuleRelabel(t *testing.T) {
+	replaceOp, err := pipeline.NewRelabelOp(
+		pipeline.ReplaceRelabelAction,
+		[]string{"host"},
+		";",
+		"[^.]+\\.(.*)",
+		"dc",
+		"$1",
+		0,
+	)
+	require.NoError(t, err)
+	labelDropOp, err := pipeline.NewRelabelOp(
+		pipeline.LabelDropRelabelAction,
+		nil,
+		";",
+		"host",
+		"",
+		"$1",
+		0,
+	)
+	require.NoError(t, err)
+
+	storagePolicies := policy.StoragePolicies{
+		policy.NewStoragePolicy(time.Minute, xtime.Minute, 48*time.Hour),
+	}
+	rsv := view.RuleSet{
+		Namespace: "ns",
+		MappingRules: []view.MappingRule{
+			{
+				Name:            "mappingRule",
+				Filter:          "name:foo",
+				AggregationID:   aggregation.MustCompressTypes(aggregation.Sum),
+				StoragePolicies: storagePolicies,
+				Relabel:         []pipeline.RelabelOp{replaceOp, labelDropOp},
+			},
+		},
+	}
+	rs, err := NewRuleSetFromView(rsv, 0, testRuleSetOptions())
+	require.NoError(t, err)
+
+	nowNanos := time.Now().UnixNano()
+	res, err := rs.ActiveSet(nowNanos).ForwardMatch(
+		namespace.NewTestID("foo|host=host-1.dc1,svc=api", "ns"),
+		nowNanos,
+		nowNanos+1,
+		testMatchOptions(),
+	)
+	require.NoError(t, err)
+
+	// The existing id is not matched by any other mapping rule.
+	forExistingID := res.ForExistingIDAt(nowNanos)
+	require.Equal(t, 1, len(forExistingID))
+	require.True(t, forExistingID[0].IsDefault())
+
+	// The metric is aggregated and retained under the relabeled id instead.
+	require.Equal(t, 1, res.NumNewRollupIDs())
+	relabeled := res.ForNewRollupIDsAt(0, nowNanos)
+	require.Equal(t, "foo|dc=dc1,svc=api", string(relabeled.ID))
+	require.Equal(t, 1, len(relabeled.Metadatas))
+	require.Equal(t, 1, len(relabeled.Metadatas[0].Pipelines))
+	relabeledPipeline := relabeled.Metadatas[0].Pipelines[0]
+	require.Equal(t, aggregation.MustCompressTypes(aggregation.Sum), relabeledPipeline.AggregationID)
+	require.Equal(t, storagePolicies, relabeledPipeline.StoragePolicies)
+	require.True(t, relabeledPipeline.Pipeline.IsEmpty())
+
+	// The metrics not matching the mapping rule are not relabeled.
+	res, err = rs.ActiveSet(nowNanos).ForwardMatch(
+		namespace.NewTestID("bar|host=host-1.dc1,svc=api", "ns"),
+		nowNanos,
+		nowNanos+1,
+		testMatchOptions(),
+	)
+	require.NoError(t, err)
+	require.Equal(t, 0, res.NumNewRollupIDs())
+}
+
+func TestRuleSetReverseMatchRelabel(t *testing.T) {
+	labelDropOp, err := pipeline.NewRelabelOp(
+		pipeline.LabelDropRelabelAction,
+		nil,
+		";",
+		"dc",
+		"",
+		"$1",
+		0,
+	)
+	require.NoError(t, err)
+	replaceOp, err := pipeline.NewRelabelOp(
+		pipeline.ReplaceRelabelAction,
+		[]string{"host"},
+		";",
+		"[^.]+\\.(.*)",
+		"dc",
+		"$1",
+		0,
+	)
+	require.NoError(t, err)
+	rollupOp, err := pipeline.NewRollupOp(
+		pipeline.GroupByRollupType,
+		"rollupName",
+		[]string{"dc", "service"},
+		aggregation.DefaultID,
+	)
+	require.NoError(t, err)
+
+	var (
+		droppedStoragePolicies = policy.StoragePolicies{
+			policy.NewStoragePolicy(time.Minute, xtime.Minute, 48*time.Hour),
+		}
+		rollupStoragePolicies = policy.StoragePolicies{
+			policy.NewStoragePolicy(10*time.Second, xtime.Second, 48*time.Hour),
+		}
+		mappingStoragePolicies = policy.StoragePolicies{
+			policy.NewStoragePolicy(time.Minute, xtime.Minute, 24*time.Hour),
+		}
+	)
+	rsv := view.RuleSet{
+		Namespace: "ns",
+		MappingRules: []view.MappingRule{
+			{
+				Name:            "mappingRule",
+				Filter:          "name:foo",
+				StoragePolicies: mappingStoragePolicies,
+				Relabel:         []pipeline.RelabelOp{replaceOp},
+			},
+		},
+		RollupRules: []view.RollupRule{
+			{
+				// The dc tag is always dropped before the rollup operation,
+				// the target never produces rollup ids with a dc tag.
+				Name:   "droppedRollupRule",
+				Filter: "name:bar",
+				Targets: []view.RollupTarget{
+					{
+						Pipeline: pipeline.NewPipeline([]pipeline.OpUnion{
+							{
+								Type:    pipeline.RelabelOpType,
+								Relabel: labelDropOp,
+							},
+							{
+								Type:   pipeline.RollupOpType,
+								Rollup: rollupOp,
+							},
+						}),
+						StoragePolicies: droppedStoragePolicies,
+					},
+				},
+			},
+			{
+				Name:   "rollupRule",
+				Filter: "name:baz",
+				Targets: []view.RollupTarget{
+					{
+						Pipeline: pipeline.NewPipeline([]pipeline.OpUnion{
+							{
+								Type:    pipeline.RelabelOpType,
+								Relabel: labelDropOp,
+							},
+							{
+								Type:    pipeline.RelabelOpType,
+								Relabel: replaceOp,
+							},
+							{
+								Type:   pipeline.RollupOpType,
+								Rollup: rollupOp,
+							},
+						}),
+						StoragePolicies: rollupStoragePolicies,
+					},
+				},
+			},
+		},
+	}
+	opts := testRuleSetOptions().SetIsRollupIDFn(func([]byte, []byte) bool { return true })
+	rs, err := NewRuleSetFromView(rsv, 0, opts)
+	require.NoError(t, err)
+
+	inputs := []struct {
+		id                      string
+		expectedStoragePolicies policy.StoragePolicies
+	}{
+		{
+			id:                      "rollupName|dc=dc1,service=api",
+			expectedStoragePolicies: rollupStoragePolicies,
+		},
+		{
+			id:                      "foo|dc=dc1,host=host-1.dc1,svc=api",
+			expectedStoragePolicies: mappingStoragePolicies,
+		},
+	}
+
+	nowNanos := time.Now().UnixNano()
+	for _, input := range inputs {
+		t.Run(input.id, func(t *testing.T) {
+			res, err := rs.ActiveSet(nowNanos).ReverseMatch(
+				namespace.NewTestID(input.id, "ns"),
+				nowNanos,
+				nowNanos+1,
+				metric.CounterType,
+				aggregation.Sum,
+				false,
+				aggregation.NewTypesOptions(),
+				testMatchOptions(),
+			)
+			require.NoError(t, err)
+			forExistingID := res.ForExistingIDAt(nowNanos)
+			require.Equal(t, 1, len(forExistingID))
+			require.Equal(t, 1, len(forExistingID[0].Pipelines))
+			require.Equal(t, input.expectedStoragePolicies, forExistingID[0].Pipelines[0].StoragePolicies)
+		})
+	}
+}
@@ -18,7 +18,7 @@
 // OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
 // THE SOFTWARE.
 
-package downsample
+package rules
 
 import (
 	"testing"
@@ -306,7 +306,7 @@ func TestRollupIdProvider(t *testing.T) {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
 			if tc.nameTag == "" {
-				tc.nameTag = nameTag
+				tc.nameTag = "__name__"
 			}
 			encoder := &serialize.FakeTagEncoder{}
 			p := newRollupIDProvider(encoder, nil, ident.BytesID(tc.nameTag))
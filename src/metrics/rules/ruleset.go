@@ -239,6 +239,58 @@ func NewEmptyRuleSet(namespaceName string, meta UpdateMetadata) MutableRuleSet {
 	return rs
 }
 
+// NewRuleSetFromView creates a new RuleSet from a view of its latest rules,
+// with all of the rules in effect from the given cutover time. The rule IDs
+// of the view are retained if set.
+func NewRuleSetFromView(rsv view.RuleSet, cutoverNanos int64, opts Options) (RuleSet, error) {
+	meta := UpdateMetadata{cutoverNanos: cutoverNanos, updatedAtNanos: cutoverNanos}
+	mutable := NewEmptyRuleSet(rsv.Namespace, meta)
+	mappingRuleIDs := make(map[string]string, len(rsv.MappingRules))
+	for _, mrv := range rsv.MappingRules {
+		if mrv.Tombstoned {
+			continue
+		}
+		id, err := mutable.AddMappingRule(mrv, meta)
+		if err != nil {
+			return nil, err
+		}
+		if mrv.ID != "" {
+			mappingRuleIDs[id] = mrv.ID
+		}
+	}
+	rollupRuleIDs := make(map[string]string, len(rsv.RollupRules))
+	for _, rrv := range rsv.RollupRules {
+		if rrv.Tombstoned {
+			continue
+		}
+		id, err := mutable.AddRollupRule(rrv, meta)
+		if err != nil {
+			return nil, err
+		}
+		if rrv.ID != "" {
+			rollupRuleIDs[id] = rrv.ID
+		}
+	}
+
+	// NB: the rule filters are only parsed when the rules are created from
+	// their protobuf representation.
+	pb, err := mutable.Proto()
+	if err != nil {
+		return nil, err
+	}
+	for _, mr := range pb.MappingRules {
+		if id, ok := mappingRuleIDs[mr.Uuid]; ok {
+			mr.Uuid = id
+		}
+	}
+	for _, rr := range pb.RollupRules {
+		if id, ok := rollupRuleIDs[rr.Uuid]; ok {
+			rr.Uuid = id
+		}
+	}
+	return NewRuleSetFromProto(rsv.Version, pb, opts)
+}
+
 func (rs *ruleSet) Namespace() []byte                { return rs.namespace }
 func (rs *ruleSet) Version() int                     { return rs.version }
 func (rs *ruleSet) CutoverNanos() int64              { return rs.cutoverNanos }
@@ -248,6 +300,10 @@ func (rs *ruleSet) CreatedAtNanos() int64            { return rs.createdAtNanos
 func (rs *ruleSet) ToMutableRuleSet() MutableRuleSet { return rs }
 
 func (rs *ruleSet) ActiveSet(timeNanos int64) ActiveSet {
+	return rs.activeSet(timeNanos)
+}
+
+func (rs *ruleSet) activeSet(timeNanos int64) *activeRuleSet {
 	mappingRules := make([]*mappingRule, 0, len(rs.mappingRules))
 	for _, mappingRule := range rs.mappingRules {
 		activeRule := mappingRule.activeRule(timeNanos)
@@ -395,6 +451,7 @@ func (rs *ruleSet) AddMappingRule(mrv view.MappingRule, meta UpdateMetadata) (st
 			mrv.StoragePolicies,
 			mrv.DropPolicy,
 			mrv.Tags,
+			mrv.Relabel,
 			meta,
 		); err != nil {
 			return "", xerrors.Wrap(err, fmt.Sprintf(ruleActionErrorFmt, "add", mrv.Name))
@@ -408,6 +465,7 @@ func (rs *ruleSet) AddMappingRule(mrv view.MappingRule, meta UpdateMetadata) (st
 			mrv.StoragePolicies,
 			mrv.DropPolicy,
 			mrv.Tags,
+			mrv.Relabel,
 			meta,
 		); err != nil {
 			return "", xerrors.Wrap(err, fmt.Sprintf(ruleActionErrorFmt, "revive", mrv.Name))
@@ -429,6 +487,7 @@ func (rs *ruleSet) UpdateMappingRule(mrv view.MappingRule, meta UpdateMetadata)
 		mrv.StoragePolicies,
 		mrv.DropPolicy,
 		mrv.Tags,
+		mrv.Relabel,
 		meta,
 	); err != nil {
 		return xerrors.Wrap(err, fmt.Sprintf(ruleActionErrorFmt, "update", mrv.Name))
@@ -42,6 +42,7 @@ var (
 	errMoreThanOneAggregationOpInPipeline = errors.New("more than one aggregation operation in pipeline")
 	errAggregationOpNotFirstInPipeline    = errors.New("aggregation operation is not the first operation in pipeline")
 	errNoRollupOpInPipeline               = errors.New("no rollup operation in pipeline")
+	errRelabelOpAfterRollupOpInPipeline   = errors.New("relabel operation is after a rollup operation in pipeline")
 )
 
 type validator struct {
@@ -137,13 +138,17 @@ func (v *validator) validateMappingRules(mrv []view.MappingRule) error {
 				return fmt.Errorf("mapping rule '%s' has invalid storage policies in %v: %v", rule.Name, rule.StoragePolicies, err)
 			}
 		} else {
-			// Drop policy is set, ensure default aggregation ID and no storage policies set.
+			// Drop policy is set, ensure default aggregation ID, no storage policies and
+			// no relabel operations set.
 			if !rule.AggregationID.IsDefault() {
 				return fmt.Errorf("mapping rule '%s' has a drop policy error: must use default aggregation ID", rule.Name)
 			}
 			if len(rule.StoragePolicies) != 0 {
 				return fmt.Errorf("mapping rule '%s' has a drop policy error: cannot specify storage policies", rule.Name)
 			}
+			if len(rule.Relabel) != 0 {
+				return fmt.Errorf("mapping rule '%s' has a drop policy error: cannot specify relabel operations", rule.Name)
+			}
 		}
 	}
 	return nil
@@ -279,12 +284,14 @@ func (v *validator) validateStoragePolicies(
 // validatePipeline validates the rollup pipeline as follows:
 //   - The pipeline must contain at least one operation.
 //   - The pipeline can contain at most one aggregation operation, and if there is one,
-//     it must be the first operation.
+//     it must be the first operation other than the relabel operations leading the pipeline.
 //   - The pipeline can contain arbitrary number of transformation operations. However,
 //     the transformation derivative order computed from the list of transformations must
 //     be no more than the maximum transformation derivative order that is supported.
 //   - The pipeline must contain at least one rollup operation and at most `n` rollup operations,
 //     where `n` is the maximum supported number of rollup levels.
+//   - The pipeline can contain arbitrary number of relabel operations, all of which must precede
+//     the first rollup operation.
 func (v *validator) validatePipeline(pipeline mpipeline.Pipeline, types []metric.Type) error {
 	if pipeline.IsEmpty() {
 		return errEmptyPipeline
@@ -293,18 +300,24 @@ func (v *validator) validatePipeline(pipeline mpipeline.Pipeline, types []metric
 		numAggregationOps             int
 		transformationDerivativeOrder int
 		numRollupOps                  int
+		numNonRelabelOps              int
 		previousRollupTags            map[string]struct{}
 		numPipelineOps                = pipeline.Len()
 	)
 	for i := 0; i < numPipelineOps; i++ {
 		pipelineOp := pipeline.At(i)
+		if pipelineOp.Type != mpipeline.RelabelOpType {
+			numNonRelabelOps++
+		}
 		switch pipelineOp.Type {
 		case mpipeline.AggregationOpType:
 			numAggregationOps++
 			if numAggregationOps > 1 {
 				return errMoreThanOneAggregationOpInPipeline
 			}
-			if i != 0 {
+			// Relabel operations only rewrite the tags the rollup operations are
+			// applied against and as such may precede the aggregation operation.
+			if numNonRelabelOps != 1 {
 				return errAggregationOpNotFirstInPipeline
 			}
 			if err := v.validateAggregationOp(pipelineOp.Aggregation, types); err != nil {
@@ -330,13 +343,18 @@ func (v *validator) validatePipeline(pipeline mpipeline.Pipeline, types []metric
 			if numRollupOps > v.opts.MaxRollupLevels() {
 				return fmt.Errorf("number of rollup levels is %d higher than supported %d", numRollupOps, v.opts.MaxRollupLevels())
 			}
-			if err := v.validateRollupOp(pipelineOp.Rollup, i, types, previousRollupTags); err != nil {
+			firstLevel := numNonRelabelOps == 1
+			if err := v.validateRollupOp(pipelineOp.Rollup, firstLevel, types, previousRollupTags); err != nil {
 				return fmt.Errorf("invalid rollup operation at index %d: %v", i, err)
 			}
 			previousRollupTags = make(map[string]struct{}, len(pipelineOp.Rollup.Tags))
 			for _, tag := range pipelineOp.Rollup.Tags {
 				previousRollupTags[string(tag)] = struct{}{}
 			}
+		case mpipeline.RelabelOpType:
+			if numRollupOps > 0 {
+				return errRelabelOpAfterRollupOpInPipeline
+			}
 		default:
 			return fmt.Errorf("operation at index %d has invalid type: %v", i, pipelineOp.Type)
 		}
@@ -367,7 +385,7 @@ func validateTransformationOp(transformationOp mpipeline.TransformationOp) error
 
 func (v *validator) validateRollupOp(
 	rollupOp mpipeline.RollupOp,
-	opIdxInPipeline int,
+	firstLevel bool,
 	types []metric.Type,
 	previousRollupTags map[string]struct{},
 ) error {
@@ -384,7 +402,7 @@ func (v *validator) validateRollupOp(
 
 	// Validate that the aggregation ID is valid.
 	aggType := firstLevelAggregationType
-	if opIdxInPipeline > 0 {
+	if !firstLevel {
 		aggType = nonFirstLevelAggregationType
 	}
 	if err := v.validateAggregationID(rollupOp.AggregationID, aggType, types); err != nil {
@@ -959,6 +959,221 @@ func TestValidatorValidateRollupRulePipelineMultiLevelRollup(t *testing.T) {
 	require.NoError(t, validator.ValidateSnapshot(view))
 }
 
+func TestValidatorValidateRollupRulePipelineRelabelOpBeforeRollupOp(t *testing.T) {
+	relabelOp, err := pipeline.NewRelabelOp(
+		pipeline.ReplaceRelabelAction,
+		[]string{"host"},
+		";",
+		"[^.]+\\.(.*)",
+		"rtagName1",
+		"$1",
+		0,
+	)
+	require.NoError(t, err)
+	rr1, err := pipeline.NewRollupOp(
+		pipeline.GroupByRollupType,
+		"rName1",
+		[]string{"rtagName1"},
+		aggregation.DefaultID,
+	)
+	require.NoError(t, err)
+
+	view := view.RuleSet{
+		RollupRules: []view.RollupRule{
+			{
+				Name:   "snapshot1",
+				Filter: testTypeTag + ":" + testCounterType,
+				Targets: []view.RollupTarget{
+					{
+						Pipeline: pipeline.NewPipeline([]pipeline.OpUnion{
+							{
+								Type:    pipeline.RelabelOpType,
+								Relabel: relabelOp,
+							},
+							{
+								Type:   pipeline.RollupOpType,
+								Rollup: rr1,
+							},
+						}),
+						StoragePolicies: testStoragePolicies(),
+					},
+				},
+			},
+		},
+	}
+	validator := NewValidator(testValidatorOptions())
+	require.NoError(t, validator.ValidateSnapshot(view))
+}
+
+func TestValidatorValidateRollupRulePipelineRelabelOpBeforeAggregationOp(t *testing.T) {
+	relabelOp, err := pipeline.NewRelabelOp(
+		pipeline.ReplaceRelabelAction,
+		[]string{"host"},
+		";",
+		"[^.]+\\.(.*)",
+		"rtagName1",
+		"$1",
+		0,
+	)
+	require.NoError(t, err)
+	rr1, err := pipeline.NewRollupOp(
+		pipeline.GroupByRollupType,
+		"rName1",
+		[]string{"rtagName1"},
+		aggregation.MustCompressTypes(aggregation.Count, aggregation.Max),
+	)
+	require.NoError(t, err)
+
+	view := view.RuleSet{
+		RollupRules: []view.RollupRule{
+			{
+				Name:   "snapshot1",
+				Filter: testTypeTag + ":" + testTimerType,
+				Targets: []view.RollupTarget{
+					{
+						Pipeline: pipeline.NewPipeline([]pipeline.OpUnion{
+							{
+								Type:    pipeline.RelabelOpType,
+								Relabel: relabelOp,
+							},
+							{
+								Type:        pipeline.AggregationOpType,
+								Aggregation: pipeline.AggregationOp{Type: aggregation.Sum},
+							},
+							{
+								Type:   pipeline.RollupOpType,
+								Rollup: rr1,
+							},
+						}),
+						StoragePolicies: testStoragePolicies(),
+					},
+				},
+			},
+		},
+	}
+	allowedAggregationTypes := aggregation.Types{aggregation.Sum}
+	opts := testValidatorOptions().
+		SetAllowedFirstLevelAggregationTypesFor(metric.TimerType, allowedAggregationTypes).
+		SetAllowedNonFirstLevelAggregationTypesFor(metric.TimerType, aggregation.Types{aggregation.Count, aggregation.Max})
+	validator := NewValidator(opts)
+	require.NoError(t, validator.ValidateSnapshot(view))
+
+	// The rollup operation following the relabel and aggregation operations is not a first level
+	// rollup and as such cannot use the first level aggregation types only.
+	opts = testValidatorOptions().
+		SetAllowedFirstLevelAggregationTypesFor(metric.TimerType, aggregation.Types{aggregation.Sum, aggregation.Count, aggregation.Max})
+	validator = NewValidator(opts)
+	require.Error(t, validator.ValidateSnapshot(view))
+}
+
+func TestValidatorValidateRollupRulePipelineRelabelOpBeforeFirstLevelRollupOp(t *testing.T) {
+	relabelOp, err := pipeline.NewRelabelOp(
+		pipeline.ReplaceRelabelAction,
+		[]string{"host"},
+		";",
+		"[^.]+\\.(.*)",
+		"rtagName1",
+		"$1",
+		0,
+	)
+	require.NoError(t, err)
+	rr1, err := pipeline.NewRollupOp(
+		pipeline.GroupByRollupType,
+		"rName1",
+		[]string{"rtagName1"},
+		aggregation.MustCompressTypes(aggregation.Count, aggregation.Max),
+	)
+	require.NoError(t, err)
+
+	view := view.RuleSet{
+		RollupRules: []view.RollupRule{
+			{
+				Name:   "snapshot1",
+				Filter: testTypeTag + ":" + testTimerType,
+				Targets: []view.RollupTarget{
+					{
+						Pipeline: pipeline.NewPipeline([]pipeline.OpUnion{
+							{
+								Type:    pipeline.RelabelOpType,
+								Relabel: relabelOp,
+							},
+							{
+								Type:   pipeline.RollupOpType,
+								Rollup: rr1,
+							},
+						}),
+						StoragePolicies: testStoragePolicies(),
+					},
+				},
+			},
+		},
+	}
+	// The rollup operation following the relabel operation is a first level rollup.
+	opts := testValidatorOptions().
+		SetAllowedFirstLevelAggregationTypesFor(metric.TimerType, aggregation.Types{aggregation.Count, aggregation.Max})
+	validator := NewValidator(opts)
+	require.NoError(t, validator.ValidateSnapshot(view))
+}
+
+func TestValidatorValidateRollupRulePipelineRelabelOpAfterRollupOp(t *testing.T) {
+	relabelOp, err := pipeline.NewRelabelOp(
+		pipeline.LabelDropRelabelAction,
+		nil,
+		";",
+		"rtagName2",
+		"",
+		"$1",
+		0,
+	)
+	require.NoError(t, err)
+	rr1, err := pipeline.NewRollupOp(
+		pipeline.GroupByRollupType,
+		"rName1",
+		[]string{"rtagName1", "rtagName2"},
+		aggregation.DefaultID,
+	)
+	require.NoError(t, err)
+	rr2, err := pipeline.NewRollupOp(
+		pipeline.GroupByRollupType,
+		"rName2",
+		[]string{"rtagName1"},
+		aggregation.DefaultID,
+	)
+	require.NoError(t, err)
+
+	view := view.RuleSet{
+		RollupRules: []view.RollupRule{
+			{
+				Name:   "snapshot1",
+				Filter: testTypeTag + ":" + testCounterType,
+				Targets: []view.RollupTarget{
+					{
+						Pipeline: pipeline.NewPipeline([]pipeline.OpUnion{
+							{
+								Type:   pipeline.RollupOpType,
+								Rollup: rr1,
+							},
+							{
+								Type:    pipeline.RelabelOpType,
+								Relabel: relabelOp,
+							},
+							{
+								Type:   pipeline.RollupOpType,
+								Rollup: rr2,
+							},
+						}),
+						StoragePolicies: testStoragePolicies(),
+					},
+				},
+			},
+		},
+	}
+	validator := NewValidator(testValidatorOptions().SetMaxRollupLevels(2))
+	err = validator.ValidateSnapshot(view)
+	require.Error(t, err)
+	require.True(t, strings.Contains(err.Error(), "relabel operation is after a rollup operation in pipeline"))
+}
+
 func TestValidatorValidateRollupRuleRollupOpDuplicateRollupTag(t *testing.T) {
 	rr1, err := pipeline.NewRollupOp(
 		pipeline.GroupByRollupType,
@@ -1711,6 +1926,17 @@ func TestValidatorValidateMappingRuleInvalidDropPolicy(t *testing.T) {
 		view view.RuleSet
 	}
 
+	relabelOp, err := pipeline.NewRelabelOp(
+		pipeline.LabelDropRelabelAction,
+		nil,
+		";",
+		"tag2",
+		"",
+		"$1",
+		0,
+	)
+	require.NoError(t, err)
+
 	tests := []invalidDropPolicyTest{
 		{
 			name: "invalid drop policy",
@@ -1760,6 +1986,19 @@ func TestValidatorValidateMappingRuleInvalidDropPolicy(t *testing.T) {
 					},
 				},
 			},
+			{
+				name: dropPolicy.String() + " policy with relabel operations",
+				view: view.RuleSet{
+					MappingRules: []view.MappingRule{
+						{
+							Name:       "snapshot1",
+							Filter:     "tag1:value1",
+							DropPolicy: policy.DropMust,
+							Relabel:    []pipeline.RelabelOp{relabelOp},
+						},
+					},
+				},
+			},
 		}...)
 	}
 
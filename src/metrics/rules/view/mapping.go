@@ -22,6 +22,7 @@ package view
 
 import (
 	"github.com/m3db/m3/src/metrics/aggregation"
+	"github.com/m3db/m3/src/metrics/pipeline"
 	"github.com/m3db/m3/src/metrics/policy"
 	"github.com/m3db/m3/src/query/models"
 )
@@ -37,6 +38,7 @@ type MappingRule struct {
 	StoragePolicies     policy.StoragePolicies `json:"storagePolicies"`
 	DropPolicy          policy.DropPolicy      `json:"dropPolicy"`
 	Tags                []models.Tag           `json:"tags"`
+	Relabel             []pipeline.RelabelOp   `json:"relabel,omitempty"`
 	LastUpdatedBy       string                 `json:"lastUpdatedBy"`
 	LastUpdatedAtMillis int64                  `json:"lastUpdatedAtMillis"`
 }
@@ -59,6 +61,16 @@ func (m *MappingRule) Equal(other *MappingRule) bool {
 		}
 	}
 
+	if len(m.Relabel) != len(other.Relabel) {
+		return false
+	}
+
+	for i := 0; i < len(m.Relabel); i++ {
+		if !m.Relabel[i].Equal(other.Relabel[i]) {
+			return false
+		}
+	}
+
 	return m.ID == other.ID &&
 		m.Name == other.Name &&
 		m.Filter == other.Filter &&
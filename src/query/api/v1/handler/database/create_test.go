@@ -171,6 +171,7 @@ func testLocalType(t *testing.T, providedType string, placementExists bool) {
 						},
 						"bootstrapEnabled": true,
 						"cacheBlocksOnRetrieve": false,
+						"filesetCompression": "NONE",
 						"flushEnabled": true,
 						"writesToCommitLog": true,
 						"cleanupEnabled": true,
@@ -191,6 +192,7 @@ func testLocalType(t *testing.T, providedType string, placementExists bool) {
 						},
 						"runtimeOptions": null,
 						"schemaOptions": null,
+						"coldTierAgeNanos": "0",
 						"coldWritesEnabled": false,
 						"extendedOptions": null,
 						"stagingState": {
@@ -350,6 +352,7 @@ func TestLocalTypeWithNumShards(t *testing.T) {
 						},
 						"bootstrapEnabled": true,
 						"cacheBlocksOnRetrieve": false,
+						"filesetCompression": "NONE",
 						"flushEnabled": true,
 						"writesToCommitLog": true,
 						"cleanupEnabled": true,
@@ -370,6 +373,7 @@ func TestLocalTypeWithNumShards(t *testing.T) {
 						},
 						"runtimeOptions": null,
 						"schemaOptions": null,
+						"coldTierAgeNanos": "0",
 						"coldWritesEnabled": false,
 						"extendedOptions": null,
 						"stagingState": {
@@ -482,6 +486,7 @@ func TestLocalWithBlockSizeNanos(t *testing.T) {
 						},
 						"bootstrapEnabled": true,
 						"cacheBlocksOnRetrieve": false,
+						"filesetCompression": "NONE",
 						"flushEnabled": true,
 						"writesToCommitLog": true,
 						"cleanupEnabled": true,
@@ -502,6 +507,7 @@ func TestLocalWithBlockSizeNanos(t *testing.T) {
 						},
 						"runtimeOptions": null,
 						"schemaOptions": null,
+						"coldTierAgeNanos": "0",
 						"coldWritesEnabled": false,
 						"extendedOptions": null,
 						"stagingState": {
@@ -619,6 +625,7 @@ func TestLocalWithBlockSizeExpectedSeriesDatapointsPerHour(t *testing.T) {
 						},
 						"bootstrapEnabled": true,
 						"cacheBlocksOnRetrieve": false,
+						"filesetCompression": "NONE",
 						"flushEnabled": true,
 						"writesToCommitLog": true,
 						"cleanupEnabled": true,
@@ -639,6 +646,7 @@ func TestLocalWithBlockSizeExpectedSeriesDatapointsPerHour(t *testing.T) {
 						},
 						"runtimeOptions": null,
 						"schemaOptions": null,
+						"coldTierAgeNanos": "0",
 						"coldWritesEnabled": false,
 						"extendedOptions": null,
 						"stagingState": {
@@ -886,6 +894,7 @@ func testClusterTypeHosts(t *testing.T, placementExists bool) {
 						},
 						"bootstrapEnabled": true,
 						"cacheBlocksOnRetrieve": false,
+						"filesetCompression": "NONE",
 						"flushEnabled": true,
 						"writesToCommitLog": true,
 						"cleanupEnabled": true,
@@ -906,6 +915,7 @@ func testClusterTypeHosts(t *testing.T, placementExists bool) {
 						},
 						"runtimeOptions": null,
 						"schemaOptions": null,
+						"coldTierAgeNanos": "0",
 						"coldWritesEnabled": false,
 						"extendedOptions": null,
 						"stagingState": {
@@ -1047,6 +1057,7 @@ func TestClusterTypeHostsWithIsolationGroup(t *testing.T) {
 						},
 						"bootstrapEnabled": true,
 						"cacheBlocksOnRetrieve": false,
+						"filesetCompression": "NONE",
 						"flushEnabled": true,
 						"writesToCommitLog": true,
 						"cleanupEnabled": true,
@@ -1067,6 +1078,7 @@ func TestClusterTypeHostsWithIsolationGroup(t *testing.T) {
 						},
 						"runtimeOptions": null,
 						"schemaOptions": null,
+						"coldTierAgeNanos": "0",
 						"coldWritesEnabled": false,
 						"extendedOptions": null,
 						"stagingState": {
@@ -1271,6 +1283,7 @@ func TestLocalTypeWithAggregatedNamespace(t *testing.T) {
 						},
 						"bootstrapEnabled": true,
 						"cacheBlocksOnRetrieve": false,
+						"filesetCompression": "NONE",
 						"flushEnabled": true,
 						"writesToCommitLog": true,
 						"cleanupEnabled": true,
@@ -1291,6 +1304,7 @@ func TestLocalTypeWithAggregatedNamespace(t *testing.T) {
 						},
 						"runtimeOptions": null,
 						"schemaOptions": null,
+						"coldTierAgeNanos": "0",
 						"coldWritesEnabled": false,
 						"extendedOptions": null,
 						"stagingState": {
@@ -1313,6 +1327,7 @@ func TestLocalTypeWithAggregatedNamespace(t *testing.T) {
 						},
 						"bootstrapEnabled": true,
 						"cacheBlocksOnRetrieve": false,
+						"filesetCompression": "NONE",
 						"flushEnabled": true,
 						"writesToCommitLog": true,
 						"cleanupEnabled": true,
@@ -1333,6 +1348,7 @@ func TestLocalTypeWithAggregatedNamespace(t *testing.T) {
 						},
 						"runtimeOptions": null,
 						"schemaOptions": null,
+						"coldTierAgeNanos": "0",
 						"coldWritesEnabled": false,
 						"extendedOptions": null,
 						"stagingState": {
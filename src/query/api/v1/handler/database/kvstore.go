@@ -216,6 +216,8 @@ func (h *KeyValueStoreHandler) newKVProtoMessage(key string) (protoiface.Message
 		return &commonpb.StringProto{}, nil
 	case kvconfig.QueryLimits:
 		return &kvpb.QueryLimits{}, nil
+	case kvconfig.SeriesLimits:
+		return &kvpb.SeriesLimits{}, nil
 	}
 	return nil, fmt.Errorf("unsupported kvstore key %s", key)
 }
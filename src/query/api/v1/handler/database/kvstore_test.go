@@ -206,6 +206,38 @@ func TestUpdateQueryLimits(t *testing.T) {
 	}
 }
 
+func TestUpdateSeriesLimits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	update := &KeyValueUpdate{
+		Key: kvconfig.SeriesLimits,
+		Value: json.RawMessage(`{"limits":[` +
+			`{"tagName":"tenant","maxActiveSeries":"1000"},` +
+			`{"tagName":"tenant","tagValue":"foo","maxActiveSeries":"5000"}]}`),
+		Commit: true,
+	}
+
+	storeMock := kv.NewMockStore(ctrl)
+	storeMock.EXPECT().Get(kvconfig.SeriesLimits).Return(nil, kv.ErrNotFound)
+	storeMock.EXPECT().Set(kvconfig.SeriesLimits, gomock.Any()).
+		DoAndReturn(func(_ string, v *kvpb.SeriesLimits) (int, error) {
+			require.Equal(t, []*kvpb.SeriesLimit{
+				{TagName: "tenant", MaxActiveSeries: 1000},
+				{TagName: "tenant", TagValue: "foo", MaxActiveSeries: 5000},
+			}, v.Limits)
+			return 1, nil
+		})
+
+	handler := &KeyValueStoreHandler{}
+	r, err := handler.update(zap.NewNop(), storeMock, update)
+	require.NoError(t, err)
+	require.Equal(t, kvconfig.SeriesLimits, r.Key)
+	require.Equal(t, json.RawMessage("{}"), r.Old)
+	require.Equal(t, update.Value, r.New)
+	require.Equal(t, 1, r.Version)
+}
+
 func TestProtoParser(t *testing.T) {
 	handler := &KeyValueStoreHandler{
 		kvStoreProtoParser: func(k string) (protoiface.MessageV1, error) {
@@ -69,34 +69,9 @@ func parseFindParamsToQueries(r *http.Request) (
 			xerrors.NewInvalidParamsError(errors.ErrNoQueryFound)
 	}
 
-	now := time.Now()
-	fromString, untilString := r.FormValue("from"), r.FormValue("until")
-	if len(fromString) == 0 {
-		fromString = "0"
-	}
-
-	if len(untilString) == 0 {
-		untilString = "now"
-	}
-
-	from, err := graphite.ParseTime(
-		fromString,
-		now,
-		tzOffsetForAbsoluteTime,
-	)
+	from, until, err := parseFromUntil(r)
 	if err != nil {
-		return nil, nil, "",
-			xerrors.NewInvalidParamsError(fmt.Errorf("invalid 'from': %s", fromString))
-	}
-
-	until, err := graphite.ParseTime(
-		untilString,
-		now,
-		tzOffsetForAbsoluteTime,
-	)
-	if err != nil {
-		return nil, nil, "",
-			xerrors.NewInvalidParamsError(fmt.Errorf("invalid 'until': %s", untilString))
+		return nil, nil, "", err
 	}
 
 	matchers, queryType, err := graphitestorage.TranslateQueryToMatchersWithTerminator(query)
@@ -172,6 +147,42 @@ func parseFindParamsToQueries(r *http.Request) (
 	return terminatedQuery, childQuery, query, nil
 }
 
+// parseFromUntil parses the time range of a find or tags request, which
+// defaults to all time until now.
+func parseFromUntil(r *http.Request) (time.Time, time.Time, error) {
+	now := time.Now()
+	fromString, untilString := r.FormValue("from"), r.FormValue("until")
+	if len(fromString) == 0 {
+		fromString = "0"
+	}
+
+	if len(untilString) == 0 {
+		untilString = "now"
+	}
+
+	from, err := graphite.ParseTime(
+		fromString,
+		now,
+		tzOffsetForAbsoluteTime,
+	)
+	if err != nil {
+		return time.Time{}, time.Time{},
+			xerrors.NewInvalidParamsError(fmt.Errorf("invalid 'from': %s", fromString))
+	}
+
+	until, err := graphite.ParseTime(
+		untilString,
+		now,
+		tzOffsetForAbsoluteTime,
+	)
+	if err != nil {
+		return time.Time{}, time.Time{},
+			xerrors.NewInvalidParamsError(fmt.Errorf("invalid 'until': %s", untilString))
+	}
+
+	return from, until, nil
+}
+
 type findResultsOptions struct {
 	includeBothExpandableAndLeaf bool
 }
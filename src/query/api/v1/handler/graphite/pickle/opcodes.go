@@ -34,3 +34,44 @@ const (
 	opSetItems   = 0x75
 	opProto      = 0x80
 )
+
+// list of additional opcodes supported when unpickling, i.e. carbon pickle
+// protocol messages which may be pickled with any protocol.
+const (
+	opPop             = 0x30 // '0'
+	opPopMark         = 0x31 // '1'
+	opDup             = 0x32 // '2'
+	opFloat           = 0x46 // 'F'
+	opInt             = 0x49 // 'I'
+	opBinInt1         = 0x4b // 'K'
+	opLong            = 0x4c // 'L'
+	opBinInt2         = 0x4d // 'M'
+	opString          = 0x53 // 'S'
+	opBinString       = 0x54 // 'T'
+	opShortBinString  = 0x55 // 'U'
+	opUnicode         = 0x56 // 'V'
+	opAppend          = 0x61 // 'a'
+	opGet             = 0x67 // 'g'
+	opBinGet          = 0x68 // 'h'
+	opLongBinGet      = 0x6a // 'j'
+	opList            = 0x6c // 'l'
+	opPut             = 0x70 // 'p'
+	opBinPut          = 0x71 // 'q'
+	opLongBinPut      = 0x72 // 'r'
+	opTuple           = 0x74 // 't'
+	opEmptyTuple      = 0x29 // ')'
+	opBinBytes        = 0x42 // 'B'
+	opShortBinBytes   = 0x43 // 'C'
+	opTuple1          = 0x85
+	opTuple2          = 0x86
+	opTuple3          = 0x87
+	opNewTrue         = 0x88
+	opNewFalse        = 0x89
+	opLong1           = 0x8a
+	opLong4           = 0x8b
+	opShortBinUnicode = 0x8c
+	opBinUnicode8     = 0x8d
+	opBinBytes8       = 0x8e
+	opMemoize         = 0x94
+	opFrame           = 0x95
+)
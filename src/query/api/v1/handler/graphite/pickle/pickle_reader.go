@@ -0,0 +1,588 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package pickle
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"unicode/utf8"
+)
+
+// Limits of the value of a pickle program. Memoized values can be referenced
+// any number of times, so a small program can describe a value many times
+// larger than itself.
+const (
+	maxValueDepth  = 64
+	maxValues      = 1 << 21
+	maxStringBytes = 1 << 24
+)
+
+var (
+	errUnexpectedEnd  = errors.New("unexpected end of pickle program")
+	errStackEmpty     = errors.New("pickle stack is empty")
+	errNoMark         = errors.New("pickle mark not found")
+	errMarkPastStack  = errors.New("pickle mark is past the top of the stack")
+	errNotList        = errors.New("pickle append target is not a list")
+	errRecursiveValue = errors.New("pickle value contains itself")
+	errValueTooDeep   = fmt.Errorf("pickle value is nested more than %d levels deep", maxValueDepth)
+	errValueTooLarge  = fmt.Errorf("pickle value has more than %d elements or %d string bytes",
+		maxValues, maxStringBytes)
+)
+
+// Unpickle reads the value of a pickle program. Note that this is a very
+// limited implementation of unpickling; just enough for us to read the
+// lists, tuples, strings and numbers of carbon pickle protocol messages,
+// which may be pickled with any protocol. Lists and tuples are read as
+// []interface{}, integers as int64, floats as float64, strings and bytes as
+// string, booleans as bool and None as nil. Values that contain themselves or
+// exceed the value limits are rejected.
+func Unpickle(b []byte) (interface{}, error) {
+	u := unpickler{data: b, memo: make(map[int]interface{})}
+	return u.run()
+}
+
+// list is a python list, which unlike tuples can be appended to after it
+// has been memoized.
+type list struct {
+	items []interface{}
+}
+
+type unpickler struct {
+	data  []byte
+	pos   int
+	stack []interface{}
+	marks []int
+	memo  map[int]interface{}
+}
+
+// nolint: gocyclo
+func (u *unpickler) run() (interface{}, error) {
+	for {
+		op, err := u.readByte()
+		if err != nil {
+			return nil, err
+		}
+
+		switch op {
+		case opStop:
+			v, err := u.pop()
+			if err != nil {
+				return nil, err
+			}
+			b := valueBuilder{visiting: make(map[*list]struct{})}
+			return b.toValue(v, 0)
+		case opProto:
+			_, err = u.readBytes(1)
+		case opFrame:
+			_, err = u.readBytes(8)
+		case opMark:
+			u.marks = append(u.marks, len(u.stack))
+		case opPop:
+			_, err = u.pop()
+		case opPopMark:
+			_, err = u.popMark()
+		case opDup:
+			var v interface{}
+			if v, err = u.top(); err == nil {
+				u.push(v)
+			}
+		case opNone:
+			u.push(nil)
+		case opNewTrue:
+			u.push(true)
+		case opNewFalse:
+			u.push(false)
+		case opInt:
+			err = u.readInt()
+		case opLong:
+			err = u.readLong()
+		case opBinInt:
+			var b []byte
+			if b, err = u.readBytes(4); err == nil {
+				u.push(int64(int32(binary.LittleEndian.Uint32(b))))
+			}
+		case opBinInt1:
+			var b []byte
+			if b, err = u.readBytes(1); err == nil {
+				u.push(int64(b[0]))
+			}
+		case opBinInt2:
+			var b []byte
+			if b, err = u.readBytes(2); err == nil {
+				u.push(int64(binary.LittleEndian.Uint16(b)))
+			}
+		case opLong1:
+			var n int
+			if n, err = u.readSize(1); err == nil {
+				err = u.readLongBytes(n)
+			}
+		case opLong4:
+			var n int
+			if n, err = u.readSize(4); err == nil {
+				err = u.readLongBytes(n)
+			}
+		case opFloat:
+			var line []byte
+			if line, err = u.readLine(); err == nil {
+				var v float64
+				if v, err = strconv.ParseFloat(string(line), 64); err == nil {
+					u.push(v)
+				}
+			}
+		case opBinFloat:
+			var b []byte
+			if b, err = u.readBytes(8); err == nil {
+				u.push(math.Float64frombits(binary.BigEndian.Uint64(b)))
+			}
+		case opString:
+			var line []byte
+			if line, err = u.readLine(); err == nil {
+				var v string
+				if v, err = unquoteString(line); err == nil {
+					u.push(v)
+				}
+			}
+		case opUnicode:
+			var line []byte
+			if line, err = u.readLine(); err == nil {
+				var v string
+				if v, err = decodeRawUnicodeEscape(line); err == nil {
+					u.push(v)
+				}
+			}
+		case opShortBinString, opShortBinBytes, opShortBinUnicode:
+			err = u.readString(1)
+		case opBinString, opBinBytes, opBinUnicode:
+			err = u.readString(4)
+		case opBinUnicode8, opBinBytes8:
+			err = u.readString(8)
+		case opEmptyList:
+			u.push(&list{})
+		case opList:
+			var items []interface{}
+			if items, err = u.popMark(); err == nil {
+				u.push(&list{items: items})
+			}
+		case opAppend:
+			var v interface{}
+			if v, err = u.pop(); err == nil {
+				err = u.appendToList(v)
+			}
+		case opAppends:
+			var items []interface{}
+			if items, err = u.popMark(); err == nil {
+				err = u.appendToList(items...)
+			}
+		case opEmptyTuple:
+			u.push([]interface{}{})
+		case opTuple:
+			var items []interface{}
+			if items, err = u.popMark(); err == nil {
+				u.push(items)
+			}
+		case opTuple1, opTuple2, opTuple3:
+			err = u.readTuple(int(op-opTuple1) + 1)
+		case opPut:
+			var n int
+			if n, err = u.readLineSize(); err == nil {
+				err = u.put(n)
+			}
+		case opBinPut:
+			var n int
+			if n, err = u.readSize(1); err == nil {
+				err = u.put(n)
+			}
+		case opLongBinPut:
+			var n int
+			if n, err = u.readSize(4); err == nil {
+				err = u.put(n)
+			}
+		case opMemoize:
+			err = u.put(len(u.memo))
+		case opGet:
+			var n int
+			if n, err = u.readLineSize(); err == nil {
+				err = u.get(n)
+			}
+		case opBinGet:
+			var n int
+			if n, err = u.readSize(1); err == nil {
+				err = u.get(n)
+			}
+		case opLongBinGet:
+			var n int
+			if n, err = u.readSize(4); err == nil {
+				err = u.get(n)
+			}
+		default:
+			err = fmt.Errorf("unsupported pickle opcode 0x%x at %d", op, u.pos-1)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (u *unpickler) push(v interface{}) {
+	u.stack = append(u.stack, v)
+}
+
+func (u *unpickler) top() (interface{}, error) {
+	if len(u.stack) == 0 {
+		return nil, errStackEmpty
+	}
+	return u.stack[len(u.stack)-1], nil
+}
+
+func (u *unpickler) pop() (interface{}, error) {
+	v, err := u.top()
+	if err != nil {
+		return nil, err
+	}
+	u.stack = u.stack[:len(u.stack)-1]
+	return v, nil
+}
+
+func (u *unpickler) popMark() ([]interface{}, error) {
+	if len(u.marks) == 0 {
+		return nil, errNoMark
+	}
+
+	mark := u.marks[len(u.marks)-1]
+	u.marks = u.marks[:len(u.marks)-1]
+	if mark > len(u.stack) {
+		// Values below the mark were popped since it was set.
+		return nil, errMarkPastStack
+	}
+	items := make([]interface{}, len(u.stack)-mark)
+	copy(items, u.stack[mark:])
+	u.stack = u.stack[:mark]
+	return items, nil
+}
+
+func (u *unpickler) appendToList(items ...interface{}) error {
+	v, err := u.top()
+	if err != nil {
+		return err
+	}
+
+	l, ok := v.(*list)
+	if !ok {
+		return errNotList
+	}
+
+	l.items = append(l.items, items...)
+	return nil
+}
+
+func (u *unpickler) readTuple(n int) error {
+	if len(u.stack) < n {
+		return errStackEmpty
+	}
+
+	items := make([]interface{}, n)
+	copy(items, u.stack[len(u.stack)-n:])
+	u.stack = u.stack[:len(u.stack)-n]
+	u.push(items)
+	return nil
+}
+
+func (u *unpickler) put(n int) error {
+	v, err := u.top()
+	if err != nil {
+		return err
+	}
+	u.memo[n] = v
+	return nil
+}
+
+func (u *unpickler) get(n int) error {
+	v, ok := u.memo[n]
+	if !ok {
+		return fmt.Errorf("pickle memo %d not found", n)
+	}
+	u.push(v)
+	return nil
+}
+
+func (u *unpickler) readByte() (byte, error) {
+	if u.pos >= len(u.data) {
+		return 0, errUnexpectedEnd
+	}
+	b := u.data[u.pos]
+	u.pos++
+	return b, nil
+}
+
+func (u *unpickler) readBytes(n int) ([]byte, error) {
+	if n < 0 || n > len(u.data)-u.pos {
+		return nil, errUnexpectedEnd
+	}
+	b := u.data[u.pos : u.pos+n]
+	u.pos += n
+	return b, nil
+}
+
+func (u *unpickler) readLine() ([]byte, error) {
+	idx := bytes.IndexByte(u.data[u.pos:], '\n')
+	if idx < 0 {
+		return nil, errUnexpectedEnd
+	}
+	line := u.data[u.pos : u.pos+idx]
+	u.pos += idx + 1
+	return line, nil
+}
+
+// readSize reads an unsigned little endian size of 1, 4 or 8 bytes.
+func (u *unpickler) readSize(n int) (int, error) {
+	b, err := u.readBytes(n)
+	if err != nil {
+		return 0, err
+	}
+
+	var size uint64
+	switch n {
+	case 1:
+		size = uint64(b[0])
+	case 4:
+		size = uint64(binary.LittleEndian.Uint32(b))
+	default:
+		size = binary.LittleEndian.Uint64(b)
+	}
+
+	if size > uint64(len(u.data)) {
+		// NB: sizes can never be larger than the program itself.
+		return 0, errUnexpectedEnd
+	}
+	return int(size), nil
+}
+
+func (u *unpickler) readLineSize() (int, error) {
+	line, err := u.readLine()
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(string(line))
+}
+
+func (u *unpickler) readString(sizeLen int) error {
+	n, err := u.readSize(sizeLen)
+	if err != nil {
+		return err
+	}
+
+	b, err := u.readBytes(n)
+	if err != nil {
+		return err
+	}
+
+	u.push(string(b))
+	return nil
+}
+
+func (u *unpickler) readInt() error {
+	line, err := u.readLine()
+	if err != nil {
+		return err
+	}
+
+	// NB: protocol 0 and 1 pickle booleans as the 00 and 01 integers.
+	switch string(line) {
+	case "00":
+		u.push(false)
+		return nil
+	case "01":
+		u.push(true)
+		return nil
+	}
+
+	v, err := strconv.ParseInt(string(line), 10, 64)
+	if err != nil {
+		return err
+	}
+	u.push(v)
+	return nil
+}
+
+func (u *unpickler) readLong() error {
+	line, err := u.readLine()
+	if err != nil {
+		return err
+	}
+
+	v, err := strconv.ParseInt(string(bytes.TrimSuffix(line, []byte("L"))), 10, 64)
+	if err != nil {
+		return err
+	}
+	u.push(v)
+	return nil
+}
+
+// readLongBytes reads a little endian two's complement integer of n bytes.
+func (u *unpickler) readLongBytes(n int) error {
+	if n > 8 {
+		return fmt.Errorf("pickle long of %d bytes is too large", n)
+	}
+
+	b, err := u.readBytes(n)
+	if err != nil {
+		return err
+	}
+
+	var v int64
+	for i := n - 1; i >= 0; i-- {
+		v = v<<8 | int64(b[i])
+	}
+	if n > 0 && n < 8 && b[n-1]&0x80 != 0 {
+		// Sign extend negative values.
+		v -= 1 << (8 * uint(n))
+	}
+	u.push(v)
+	return nil
+}
+
+// unquoteString unquotes the python representation of a string, i.e. 'a\'b'.
+func unquoteString(b []byte) (string, error) {
+	if len(b) < 2 || (b[0] != '\'' && b[0] != '"') || b[len(b)-1] != b[0] {
+		return "", fmt.Errorf("invalid pickle string: %s", b)
+	}
+
+	b = b[1 : len(b)-1]
+	out := make([]byte, 0, len(b))
+	for i := 0; i < len(b); i++ {
+		if b[i] != '\\' || i == len(b)-1 {
+			out = append(out, b[i])
+			continue
+		}
+
+		i++
+		switch c := b[i]; c {
+		case 'n':
+			out = append(out, '\n')
+		case 'r':
+			out = append(out, '\r')
+		case 't':
+			out = append(out, '\t')
+		case 'x':
+			if i+2 >= len(b) {
+				return "", fmt.Errorf("invalid pickle string escape: %s", b)
+			}
+			v, err := strconv.ParseUint(string(b[i+1:i+3]), 16, 8)
+			if err != nil {
+				return "", fmt.Errorf("invalid pickle string escape: %s", b)
+			}
+			out = append(out, byte(v))
+			i += 2
+		default:
+			// Quotes and backslashes.
+			out = append(out, c)
+		}
+	}
+
+	return string(out), nil
+}
+
+// decodeRawUnicodeEscape decodes the raw-unicode-escape encoding of a string,
+// in which characters up to \xff are latin-1 bytes and others are escaped as
+// \uXXXX or \UXXXXXXXX.
+func decodeRawUnicodeEscape(b []byte) (string, error) {
+	out := make([]byte, 0, len(b))
+	for i := 0; i < len(b); i++ {
+		size := 0
+		if b[i] == '\\' && i+1 < len(b) {
+			switch b[i+1] {
+			case 'u':
+				size = 4
+			case 'U':
+				size = 8
+			}
+		}
+
+		if size == 0 || i+2+size > len(b) {
+			out = utf8.AppendRune(out, rune(b[i]))
+			continue
+		}
+
+		v, err := strconv.ParseUint(string(b[i+2:i+2+size]), 16, 32)
+		if err != nil {
+			return "", fmt.Errorf("invalid pickle unicode escape: %s", b)
+		}
+		out = utf8.AppendRune(out, rune(v))
+		i += 1 + size
+	}
+
+	return string(out), nil
+}
+
+// valueBuilder converts the lists of a value to slices, enforcing the value
+// limits as memoized values are expanded.
+type valueBuilder struct {
+	numValues      int
+	numStringBytes int
+	visiting       map[*list]struct{}
+}
+
+func (b *valueBuilder) toValue(v interface{}, depth int) (interface{}, error) {
+	if depth > maxValueDepth {
+		return nil, errValueTooDeep
+	}
+	if b.numValues++; b.numValues > maxValues {
+		return nil, errValueTooLarge
+	}
+
+	switch v := v.(type) {
+	case *list:
+		// NB: a memoized list can be appended to itself.
+		if _, ok := b.visiting[v]; ok {
+			return nil, errRecursiveValue
+		}
+		b.visiting[v] = struct{}{}
+		values, err := b.toValues(v.items, depth)
+		delete(b.visiting, v)
+		return values, err
+	case []interface{}:
+		return b.toValues(v, depth)
+	case string:
+		if b.numStringBytes += len(v); b.numStringBytes > maxStringBytes {
+			return nil, errValueTooLarge
+		}
+		return v, nil
+	default:
+		return v, nil
+	}
+}
+
+func (b *valueBuilder) toValues(items []interface{}, depth int) ([]interface{}, error) {
+	values := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		value, err := b.toValue(item, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
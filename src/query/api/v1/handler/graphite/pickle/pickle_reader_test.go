@@ -0,0 +1,212 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package pickle
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnpickleProtocols(t *testing.T) {
+	// Pickled by python of [('foo.bar', (1600000000, 1.5)),
+	// ('baz', ('1600000010', '-2'))] with each protocol.
+	tests := []struct {
+		protocol int
+		data     string
+	}{
+		{
+			protocol: 0,
+			data: "(lp0\n(Vfoo.bar\np1\n(I1600000000\nF1.5\ntp2\ntp3\na" +
+				"(Vbaz\np4\n(V1600000010\np5\nV-2\np6\ntp7\ntp8\na.",
+		},
+		{
+			protocol: 1,
+			data: "]q\x00((X\x07\x00\x00\x00foo.barq\x01(J\x00\x10^_" +
+				"G?\xf8\x00\x00\x00\x00\x00\x00tq\x02tq\x03(X\x03\x00\x00\x00bazq\x04" +
+				"(X\n\x00\x00\x001600000010q\x05X\x02\x00\x00\x00-2q\x06tq\x07tq\x08e.",
+		},
+		{
+			protocol: 2,
+			data: "\x80\x02]q\x00(X\x07\x00\x00\x00foo.barq\x01J\x00\x10^_" +
+				"G?\xf8\x00\x00\x00\x00\x00\x00\x86q\x02\x86q\x03X\x03\x00\x00\x00bazq\x04" +
+				"X\n\x00\x00\x001600000010q\x05X\x02\x00\x00\x00-2q\x06\x86q\x07\x86q\x08e.",
+		},
+		{
+			protocol: 4,
+			data: "\x80\x04\x95=\x00\x00\x00\x00\x00\x00\x00]\x94(\x8c\x07foo.bar\x94" +
+				"J\x00\x10^_G?\xf8\x00\x00\x00\x00\x00\x00\x86\x94\x86\x94\x8c\x03baz\x94" +
+				"\x8c\n1600000010\x94\x8c\x02-2\x94\x86\x94\x86\x94e.",
+		},
+	}
+
+	expected := []interface{}{
+		[]interface{}{"foo.bar", []interface{}{int64(1600000000), 1.5}},
+		[]interface{}{"baz", []interface{}{"1600000010", "-2"}},
+	}
+
+	for _, tt := range tests {
+		v, err := Unpickle([]byte(tt.data))
+		require.NoError(t, err, "protocol %d", tt.protocol)
+		assert.Equal(t, expected, v, "protocol %d", tt.protocol)
+	}
+}
+
+func TestUnpickleValues(t *testing.T) {
+	expected := []interface{}{
+		"café", int64(-1), int64(300), int64(-70000),
+		int64(1 << 40), int64(-1 << 40), true, nil,
+	}
+
+	// Protocol 0.
+	v, err := Unpickle([]byte("(lp0\nVcaf\xe9\np1\naI-1\naI300\naI-70000\n" +
+		"aL1099511627776L\naL-1099511627776L\naI01\naNa."))
+	require.NoError(t, err)
+	assert.Equal(t, expected, v)
+
+	// Protocol 2.
+	v, err = Unpickle([]byte("\x80\x02]q\x00(X\x05\x00\x00\x00caf\xc3\xa9q\x01" +
+		"J\xff\xff\xff\xffM,\x01J\x90\xee\xfe\xff\x8a\x06\x00\x00\x00\x00\x00\x01" +
+		"\x8a\x06\x00\x00\x00\x00\x00\xff\x88Ne."))
+	require.NoError(t, err)
+	assert.Equal(t, expected, v)
+}
+
+func TestUnpickleStrings(t *testing.T) {
+	v, err := Unpickle([]byte("(S'a\\'b\\n\\x41'\nVx\\u20acy\\U0001f600\nt."))
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"a'b\nA", "x€y😀"}, v)
+}
+
+func TestUnpickleMemoizedList(t *testing.T) {
+	// A list memoized before it is appended to and referenced twice.
+	v, err := Unpickle([]byte("]q\x00K\x01ah\x00\x86."))
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		[]interface{}{int64(1)}, []interface{}{int64(1)},
+	}, v)
+}
+
+func TestUnpickleWriterOutput(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.BeginList()
+	w.WriteString("hello world")
+	w.WriteInt(-9459450)
+	w.WriteFloat64(349439.3494)
+	w.WriteFloat64(math.NaN())
+	w.WriteNone()
+	w.EndList()
+	require.NoError(t, w.Close())
+
+	v, err := Unpickle(buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		"hello world", int64(-9459450), 349439.3494, nil, nil,
+	}, v)
+}
+
+func TestUnpickleErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"]",
+		"X\xff\x00\x00\x00abc.",
+		"K\x01a.",
+		"e.",
+		"h\x05.",
+		"(K\x01}.",
+		"\x8a\x09\x00\x00\x00\x00\x00\x00\x00\x00\x00.",
+		// The mark is past the top of the stack after the pop.
+		"N(0l.",
+		"N(0t.",
+		"NN(00]e.",
+	}
+
+	for _, data := range tests {
+		_, err := Unpickle([]byte(data))
+		assert.Error(t, err, "data %q", data)
+	}
+}
+
+func TestUnpickleRecursiveList(t *testing.T) {
+	// A memoized list appended to itself.
+	_, err := Unpickle([]byte("]q\x00h\x00a."))
+	require.Equal(t, errRecursiveValue, err)
+
+	// A memoized list appended to itself through a tuple.
+	_, err = Unpickle([]byte("]q\x00h\x00\x85a."))
+	require.Equal(t, errRecursiveValue, err)
+}
+
+func TestUnpickleValueLimits(t *testing.T) {
+	// Each tuple references the previous one twice, so the value of the
+	// program doubles in size with every tuple.
+	var buf bytes.Buffer
+	buf.WriteString("X\x01\x00\x00\x00aq\x00")
+	for i := 0; i < 40; i++ {
+		buf.Write([]byte{'h', byte(i), 'h', byte(i), 0x86, 'q', byte(i + 1)})
+	}
+	buf.WriteString(".")
+	_, err := Unpickle(buf.Bytes())
+	require.Equal(t, errValueTooLarge, err)
+
+	// Strings referenced many times count towards the string bytes limit.
+	buf.Reset()
+	buf.WriteString("X\x00\x00\x10\x00")
+	buf.Write(make([]byte, 1<<20))
+	buf.WriteString("q\x00(")
+	for i := 0; i < 20; i++ {
+		buf.WriteString("h\x00")
+	}
+	buf.WriteString("t.")
+	_, err = Unpickle(buf.Bytes())
+	require.Equal(t, errValueTooLarge, err)
+
+	// Deeply nested tuples.
+	buf.Reset()
+	buf.WriteString("N")
+	for i := 0; i <= maxValueDepth; i++ {
+		buf.WriteByte(0x85)
+	}
+	buf.WriteString(".")
+	_, err = Unpickle(buf.Bytes())
+	require.Equal(t, errValueTooDeep, err)
+}
+
+func FuzzUnpickle(f *testing.F) {
+	for _, seed := range []string{
+		"]q\x00K\x01ah\x00\x86.",
+		"(S'a\\'b'\nVx\\u20ac\nt.",
+		"N(0l.",
+		"]q\x00h\x00a.",
+		"\x80\x02]q\x00(X\x03\x00\x00\x00bazq\x01J\x00\x10^_K\x02\x86q\x02\x86q\x03e.",
+	} {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// Unpickling must never panic, whatever the program.
+		_, _ = Unpickle(data)
+	})
+}
@@ -0,0 +1,302 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"go.uber.org/zap"
+
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/api/v1/route"
+	"github.com/m3db/m3/src/query/graphite/graphite"
+	graphitestorage "github.com/m3db/m3/src/query/graphite/storage"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/m3/consolidators"
+	"github.com/m3db/m3/src/query/util/logging"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+const (
+	// TagsURL is the url for listing the tags of tagged graphite series.
+	TagsURL = route.Prefix + "/graphite/tags"
+
+	// TagsAutoCompleteTagsURL is the url for auto completing the tags of
+	// tagged graphite series.
+	TagsAutoCompleteTagsURL = TagsURL + "/autoComplete/tags"
+
+	// TagsAutoCompleteValuesURL is the url for auto completing the values of
+	// a tag of tagged graphite series.
+	TagsAutoCompleteValuesURL = TagsURL + "/autoComplete/values"
+
+	defaultTagsLimit = 100
+)
+
+// TagsHTTPMethods are the HTTP methods for the tags handlers.
+var TagsHTTPMethods = []string{http.MethodGet, http.MethodPost}
+
+var errMissingTag = errors.New("missing 'tag'")
+
+type tagsHandlerType uint
+
+const (
+	listTagsHandlerType tagsHandlerType = iota
+	autoCompleteTagsHandlerType
+	autoCompleteValuesHandlerType
+)
+
+type graphiteTagsHandler struct {
+	handlerType         tagsHandlerType
+	storage             graphitestorage.Storage
+	fetchOptionsBuilder handleroptions.FetchOptionsBuilder
+	instrumentOpts      instrument.Options
+}
+
+// NewTagsHandler returns a new handler listing the tags of tagged series,
+// the tags can be filtered with the regular expression in the filter param.
+func NewTagsHandler(opts options.HandlerOptions) http.Handler {
+	return newTagsHandler(opts, listTagsHandlerType)
+}
+
+// NewTagsAutoCompleteTagsHandler returns a new handler auto completing the
+// tags of tagged series matching the expr params that start with the
+// tagPrefix param.
+func NewTagsAutoCompleteTagsHandler(opts options.HandlerOptions) http.Handler {
+	return newTagsHandler(opts, autoCompleteTagsHandlerType)
+}
+
+// NewTagsAutoCompleteValuesHandler returns a new handler auto completing the
+// values of the tag param of tagged series matching the expr params that
+// start with the valuePrefix param.
+func NewTagsAutoCompleteValuesHandler(opts options.HandlerOptions) http.Handler {
+	return newTagsHandler(opts, autoCompleteValuesHandlerType)
+}
+
+func newTagsHandler(opts options.HandlerOptions, handlerType tagsHandlerType) http.Handler {
+	wrappedStore := graphitestorage.NewM3WrappedStorage(opts.Storage(),
+		opts.M3DBOptions(), opts.InstrumentOpts(), opts.GraphiteStorageOptions())
+	return &graphiteTagsHandler{
+		handlerType:         handlerType,
+		storage:             wrappedStore,
+		fetchOptionsBuilder: opts.GraphiteFindFetchOptionsBuilder(),
+		instrumentOpts:      opts.InstrumentOpts(),
+	}
+}
+
+type tagsRequest struct {
+	query  *storage.CompleteTagsQuery
+	filter func(value []byte) bool
+	limit  int
+}
+
+func (h *graphiteTagsHandler) ServeHTTP(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	ctx, opts, err := h.fetchOptionsBuilder.NewFetchOptions(r.Context(), r)
+	if err != nil {
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	logger := logging.WithContext(ctx, h.instrumentOpts)
+	req, err := h.parseRequest(r)
+	if err != nil {
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	result, err := h.storage.CompleteTags(ctx, req.query, opts)
+	if err != nil {
+		logger.Error("unable to complete tags", zap.Error(err))
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	values := completedValues(result, req.query.CompleteNameOnly, req.filter, req.limit)
+	if err := handleroptions.AddDBResultResponseHeaders(w, result.Metadata, opts); err != nil {
+		logger.Error("unable to render tags header", zap.Error(err))
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	if h.handlerType != listTagsHandlerType {
+		xhttp.WriteJSONResponse(w, values, logger)
+		return
+	}
+
+	tags := make([]tagResult, 0, len(values))
+	for _, value := range values {
+		tags = append(tags, tagResult{Tag: value})
+	}
+	xhttp.WriteJSONResponse(w, tags, logger)
+}
+
+type tagResult struct {
+	Tag string `json:"tag"`
+}
+
+func (h *graphiteTagsHandler) parseRequest(r *http.Request) (tagsRequest, error) {
+	if err := r.ParseForm(); err != nil {
+		return tagsRequest{}, xerrors.NewInvalidParamsError(err)
+	}
+
+	from, until, err := parseFromUntil(r)
+	if err != nil {
+		return tagsRequest{}, err
+	}
+
+	limit := defaultTagsLimit
+	if str := r.FormValue("limit"); str != "" {
+		limit, err = strconv.Atoi(str)
+		if err != nil || limit <= 0 {
+			return tagsRequest{}, xerrors.NewInvalidParamsError(
+				fmt.Errorf("invalid 'limit': %s", str))
+		}
+	}
+
+	exprs := make([]string, 0, len(r.Form["expr"])+len(r.Form["expr[]"]))
+	exprs = append(exprs, r.Form["expr"]...)
+	exprs = append(exprs, r.Form["expr[]"]...)
+	matchers := models.Matchers{{Type: models.MatchField, Name: graphite.NameTag}}
+	if len(exprs) > 0 {
+		matchers, err = graphitestorage.TranslateTagExpressionsToMatchers(exprs)
+		if err != nil {
+			return tagsRequest{}, xerrors.NewInvalidParamsError(err)
+		}
+	}
+
+	query := &storage.CompleteTagsQuery{
+		CompleteNameOnly: true,
+		TagMatchers:      matchers,
+		Start:            xtime.ToUnixNano(from),
+		End:              xtime.ToUnixNano(until),
+	}
+
+	switch h.handlerType {
+	case listTagsHandlerType:
+		filter := func([]byte) bool { return true }
+		if str := r.FormValue("filter"); str != "" {
+			// NB: as with Graphite, the filter only needs to match the start
+			// of the tag.
+			re, err := regexp.Compile("^(?:" + str + ")")
+			if err != nil {
+				return tagsRequest{}, xerrors.NewInvalidParamsError(
+					fmt.Errorf("invalid 'filter': %w", err))
+			}
+			filter = re.Match
+		}
+		return tagsRequest{query: query, filter: filter, limit: limit}, nil
+
+	case autoCompleteTagsHandlerType:
+		// Tags already used by the expressions are not completed.
+		used := make(map[string]struct{}, len(matchers))
+		for _, m := range matchers {
+			used[string(m.Name)] = struct{}{}
+		}
+		if len(exprs) == 0 {
+			used = nil
+		}
+
+		prefix := []byte(r.FormValue("tagPrefix"))
+		filter := func(tag []byte) bool {
+			_, ok := used[string(tag)]
+			return !ok && bytes.HasPrefix(tag, prefix)
+		}
+		return tagsRequest{query: query, filter: filter, limit: limit}, nil
+
+	default:
+		tag := r.FormValue("tag")
+		if tag == "" {
+			return tagsRequest{}, xerrors.NewInvalidParamsError(errMissingTag)
+		}
+
+		prefix := r.FormValue("valuePrefix")
+		valueMatcher := models.Matcher{Type: models.MatchField, Name: []byte(tag)}
+		if prefix != "" {
+			valueMatcher = models.Matcher{
+				Type:  models.MatchRegexp,
+				Name:  []byte(tag),
+				Value: []byte(regexp.QuoteMeta(prefix) + ".*"),
+			}
+		}
+
+		query.CompleteNameOnly = false
+		query.FilterNameTags = [][]byte{[]byte(tag)}
+		query.TagMatchers = append(matchers, valueMatcher)
+		filter := func(value []byte) bool {
+			return bytes.HasPrefix(value, []byte(prefix))
+		}
+		return tagsRequest{query: query, filter: filter, limit: limit}, nil
+	}
+}
+
+// completedValues returns the sorted tag names, or tag values, of the result
+// that pass the filter, up to the limit. Tags used for graphite paths are
+// never returned.
+func completedValues(
+	result *consolidators.CompleteTagsResult,
+	nameOnly bool,
+	filter func(value []byte) bool,
+	limit int,
+) []string {
+	seen := make(map[string]struct{})
+	for _, tag := range result.CompletedTags {
+		if bytes.HasPrefix(tag.Name, graphite.Prefix) {
+			continue
+		}
+
+		if nameOnly {
+			if filter(tag.Name) {
+				seen[string(tag.Name)] = struct{}{}
+			}
+			continue
+		}
+
+		for _, value := range tag.Values {
+			if filter(value) {
+				seen[string(value)] = struct{}{}
+			}
+		}
+	}
+
+	values := make([]string, 0, len(seen))
+	for value := range seen {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+
+	if len(values) > limit {
+		values = values[:limit]
+	}
+	return values
+}
@@ -0,0 +1,211 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/m3/consolidators"
+	xtest "github.com/m3db/m3/src/x/test"
+)
+
+type testMatcher struct {
+	Type  models.MatchType
+	Name  string
+	Value string
+}
+
+func testTagsHandlerOptions(t *testing.T, store storage.Storage) options.HandlerOptions {
+	builder, err := handleroptions.NewFetchOptionsBuilder(
+		handleroptions.FetchOptionsBuilderOptions{
+			Timeout: 15 * time.Second,
+		})
+	require.NoError(t, err)
+
+	return options.EmptyHandlerOptions().
+		SetGraphiteFindFetchOptionsBuilder(builder).
+		SetStorage(store)
+}
+
+func testServeTags(
+	t *testing.T,
+	h http.Handler,
+	params url.Values,
+) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, TagsURL+"?"+params.Encode(), nil)
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func queryMatchers(q *storage.CompleteTagsQuery) []testMatcher {
+	matchers := make([]testMatcher, 0, len(q.TagMatchers))
+	for _, m := range q.TagMatchers {
+		matchers = append(matchers, testMatcher{
+			Type:  m.Type,
+			Name:  string(m.Name),
+			Value: string(m.Value),
+		})
+	}
+	return matchers
+}
+
+func TestTagsHandler(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	store := storage.NewMockStorage(ctrl)
+	store.EXPECT().CompleteTags(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ interface{}, q *storage.CompleteTagsQuery, _ *storage.FetchOptions) (
+			*consolidators.CompleteTagsResult, error,
+		) {
+			assert.True(t, q.CompleteNameOnly)
+			assert.Equal(t, []testMatcher{
+				{Type: models.MatchField, Name: "name"},
+			}, queryMatchers(q))
+			return &consolidators.CompleteTagsResult{
+				CompleteNameOnly: true,
+				CompletedTags: []consolidators.CompletedTag{
+					{Name: b("name")},
+					{Name: b("host")},
+					{Name: b("dc")},
+					{Name: b("datacenter")},
+					{Name: b("__g0__")},
+				},
+				Metadata: block.NewResultMetadata(),
+			}, nil
+		})
+
+	h := NewTagsHandler(testTagsHandlerOptions(t, store))
+	recorder := testServeTags(t, h, url.Values{"filter": []string{"d|h"}, "limit": []string{"2"}})
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+	var actual []tagResult
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &actual))
+	assert.Equal(t, []tagResult{{Tag: "datacenter"}, {Tag: "dc"}}, actual)
+}
+
+func TestTagsAutoCompleteTagsHandler(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	store := storage.NewMockStorage(ctrl)
+	store.EXPECT().CompleteTags(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ interface{}, q *storage.CompleteTagsQuery, _ *storage.FetchOptions) (
+			*consolidators.CompleteTagsResult, error,
+		) {
+			assert.True(t, q.CompleteNameOnly)
+			assert.Equal(t, []testMatcher{
+				{Type: models.MatchEqual, Name: "name", Value: "cpu"},
+				{Type: models.MatchNotEqual, Name: "dc", Value: "eu"},
+			}, queryMatchers(q))
+			return &consolidators.CompleteTagsResult{
+				CompleteNameOnly: true,
+				CompletedTags: []consolidators.CompletedTag{
+					{Name: b("name")},
+					{Name: b("host")},
+					{Name: b("dc")},
+					{Name: b("datacenter")},
+					{Name: b("env")},
+				},
+				Metadata: block.NewResultMetadata(),
+			}, nil
+		})
+
+	h := NewTagsAutoCompleteTagsHandler(testTagsHandlerOptions(t, store))
+	recorder := testServeTags(t, h, url.Values{
+		"expr":      []string{"name=cpu", "dc!=eu"},
+		"tagPrefix": []string{"d"},
+	})
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+	var actual []string
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &actual))
+	assert.Equal(t, []string{"datacenter"}, actual)
+}
+
+func TestTagsAutoCompleteValuesHandler(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	store := storage.NewMockStorage(ctrl)
+	store.EXPECT().CompleteTags(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ interface{}, q *storage.CompleteTagsQuery, _ *storage.FetchOptions) (
+			*consolidators.CompleteTagsResult, error,
+		) {
+			assert.False(t, q.CompleteNameOnly)
+			assert.Equal(t, [][]byte{b("dc")}, q.FilterNameTags)
+			assert.Equal(t, []testMatcher{
+				{Type: models.MatchField, Name: "name"},
+				{Type: models.MatchRegexp, Name: "dc", Value: `us\..*`},
+			}, queryMatchers(q))
+			return &consolidators.CompleteTagsResult{
+				CompletedTags: []consolidators.CompletedTag{
+					{Name: b("dc"), Values: bs("us.west", "us.east", "eu")},
+				},
+				Metadata: block.NewResultMetadata(),
+			}, nil
+		})
+
+	h := NewTagsAutoCompleteValuesHandler(testTagsHandlerOptions(t, store))
+	recorder := testServeTags(t, h, url.Values{
+		"tag":         []string{"dc"},
+		"valuePrefix": []string{"us."},
+	})
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+	var actual []string
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &actual))
+	assert.Equal(t, []string{"us.east", "us.west"}, actual)
+}
+
+func TestTagsHandlersInvalidParams(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	opts := testTagsHandlerOptions(t, storage.NewMockStorage(ctrl))
+	for _, test := range []struct {
+		handler http.Handler
+		params  url.Values
+	}{
+		{NewTagsHandler(opts), url.Values{"filter": []string{"(a"}}},
+		{NewTagsHandler(opts), url.Values{"limit": []string{"-1"}}},
+		{NewTagsAutoCompleteTagsHandler(opts), url.Values{"expr": []string{"dc!=eu"}}},
+		{NewTagsAutoCompleteValuesHandler(opts), url.Values{}},
+	} {
+		recorder := testServeTags(t, test.handler, test.params)
+		assert.Equal(t, http.StatusBadRequest, recorder.Code, test.params.Encode())
+	}
+}
@@ -40,6 +40,7 @@ import (
 	"github.com/m3db/m3/src/query/api/v1/options"
 	"github.com/m3db/m3/src/query/api/v1/route"
 	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/tenant"
 	"github.com/m3db/m3/src/query/ts"
 	"github.com/m3db/m3/src/query/util/logging"
 	xerrors "github.com/m3db/m3/src/x/errors"
@@ -284,6 +285,26 @@ func (ii *ingestIterator) CurrentMetadata() ts.Metadata {
 	return ii.metadatas[ii.pointIndex]
 }
 
+func (iwh *ingestWriteHandler) tenants() tenant.Tenants {
+	if m3dbOpts := iwh.handlerOpts.M3DBOptions(); m3dbOpts != nil {
+		return m3dbOpts.Tenants()
+	}
+	return nil
+}
+
+func numDatapoints(points []imodels.Point) int {
+	n := 0
+	for _, point := range points {
+		// NB: each field of a point is written as a separate datapoint.
+		fields, err := point.Fields()
+		if err != nil {
+			continue
+		}
+		n += len(fields)
+	}
+	return n
+}
+
 // NewInfluxWriterHandler returns a new influx write handler.
 func NewInfluxWriterHandler(options options.HandlerOptions) http.Handler {
 	return &ingestWriteHandler{
@@ -374,6 +395,25 @@ func (iwh *ingestWriteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
+	t, err := handleroptions.ParseTenant(r, iwh.tenants())
+	if err != nil {
+		xhttp.WriteError(w, xhttp.NewError(err, http.StatusBadRequest))
+		return
+	}
+	if t != nil {
+		// NB: the tenant tag is set after the tags are mapped so that the
+		// tenant of the series written cannot be overridden.
+		writeTags = writeTags.AddOrUpdateTag(models.Tag{
+			Name:  iwh.tenants().TagName(),
+			Value: []byte(t.Name),
+		})
+
+		if err := t.WriteLimit.Inc(numDatapoints(points), nil); err != nil {
+			xhttp.WriteError(w, xhttp.NewError(err, http.StatusTooManyRequests))
+			return
+		}
+	}
+
 	opts := ingest.WriteOptions{}
 	iter := &ingestIterator{points: points, tagOpts: iwh.tagOpts, promRewriter: iwh.promRewriter, writeTags: writeTags}
 	batchErr := iwh.handlerOpts.DownsamplerAndWriter().WriteBatch(r.Context(), iter, opts)
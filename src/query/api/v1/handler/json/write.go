@@ -28,11 +28,13 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
 	"github.com/m3db/m3/src/query/api/v1/options"
 	"github.com/m3db/m3/src/query/api/v1/route"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/storage/m3/storagemetadata"
+	"github.com/m3db/m3/src/query/tenant"
 	"github.com/m3db/m3/src/query/ts"
 	"github.com/m3db/m3/src/query/util"
 	"github.com/m3db/m3/src/query/util/logging"
@@ -54,14 +56,20 @@ const (
 type WriteJSONHandler struct {
 	opts           options.HandlerOptions
 	store          storage.Storage
+	tenants        tenant.Tenants
 	instrumentOpts instrument.Options
 }
 
 // NewWriteJSONHandler returns a new instance of handler.
 func NewWriteJSONHandler(opts options.HandlerOptions) http.Handler {
+	var tenants tenant.Tenants
+	if m3dbOpts := opts.M3DBOptions(); m3dbOpts != nil {
+		tenants = m3dbOpts.Tenants()
+	}
 	return &WriteJSONHandler{
 		opts:           opts,
 		store:          opts.Storage(),
+		tenants:        tenants,
 		instrumentOpts: opts.InstrumentOpts(),
 	}
 }
@@ -83,13 +91,27 @@ func (h *WriteJSONHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	writeQuery, err := h.newWriteQuery(req)
+	t, err := handleroptions.ParseTenant(r, h.tenants)
+	if err != nil {
+		xhttp.WriteError(w, xhttp.NewError(err, http.StatusBadRequest))
+		return
+	}
+
+	writeQuery, err := h.newWriteQuery(req, t)
 	if err != nil {
 		logger := logging.WithContext(r.Context(), h.instrumentOpts)
 		logger.Error("parsing error",
 			zap.String("remoteAddr", r.RemoteAddr),
 			zap.Error(err))
 		xhttp.WriteError(w, err)
+		return
+	}
+
+	if t != nil {
+		if err := t.WriteLimit.Inc(1, nil); err != nil {
+			xhttp.WriteError(w, xhttp.NewError(err, http.StatusTooManyRequests))
+			return
+		}
 	}
 
 	if err := h.store.Write(r.Context(), writeQuery); err != nil {
@@ -101,7 +123,10 @@ func (h *WriteJSONHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func (h *WriteJSONHandler) newWriteQuery(req *WriteQuery) (*storage.WriteQuery, error) {
+func (h *WriteJSONHandler) newWriteQuery(
+	req *WriteQuery,
+	t *tenant.Tenant,
+) (*storage.WriteQuery, error) {
 	parsedTime, err := util.ParseTimeString(req.Timestamp)
 	if err != nil {
 		return nil, err
@@ -111,6 +136,14 @@ func (h *WriteJSONHandler) newWriteQuery(req *WriteQuery) (*storage.WriteQuery,
 	for n, v := range req.Tags {
 		tags = tags.AddTag(models.Tag{Name: []byte(n), Value: []byte(v)})
 	}
+	if t != nil {
+		// NB: the tenant tag is set last so that the tenant of the series
+		// written cannot be overridden by the tags of the request.
+		tags = tags.AddOrUpdateTag(models.Tag{
+			Name:  h.tenants.TagName(),
+			Value: []byte(t.Name),
+		})
+	}
 
 	return storage.NewWriteQuery(storage.WriteQueryOptions{
 		Tags: tags,
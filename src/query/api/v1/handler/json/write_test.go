@@ -28,13 +28,21 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/query/api/v1/options"
 	"github.com/m3db/m3/src/query/models"
+	m3storage "github.com/m3db/m3/src/query/storage/m3"
+	"github.com/m3db/m3/src/query/tenant"
 	"github.com/m3db/m3/src/query/test/m3"
+	"github.com/m3db/m3/src/x/headers"
+	"github.com/m3db/m3/src/x/ident"
+	"github.com/m3db/m3/src/x/instrument"
+	xtime "github.com/m3db/m3/src/x/time"
 )
 
 func TestFailingJSONWriteParsing(t *testing.T) {
@@ -95,6 +103,78 @@ func TestJSONWrite(t *testing.T) {
 	require.Equal(t, http.StatusOK, resp.Code)
 }
 
+func TestJSONWriteTenant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := tenant.Configuration{
+		Tenants: []tenant.TenantConfiguration{{
+			Name: "team-a",
+			Limits: tenant.LimitsConfiguration{
+				MaxWrittenDatapoints: &tenant.LookbackLimitConfiguration{
+					Value:    2,
+					Lookback: time.Hour,
+				},
+			},
+		}},
+	}
+	tenants, err := cfg.NewTenants(instrument.NewOptions())
+	require.NoError(t, err)
+	defer tenants.Close()
+
+	storage, session := m3.NewStorageAndSession(t, ctrl)
+	session.EXPECT().IteratorPools().
+		Return(nil, nil).AnyTimes()
+
+	opts := options.EmptyHandlerOptions().
+		SetTagOptions(models.NewTagOptions()).
+		SetStorage(storage).
+		SetM3DBOptions(m3storage.NewOptions(encoding.NewOptions()).
+			SetTenants(tenants))
+	handler := NewWriteJSONHandler(opts).(*WriteJSONHandler)
+
+	newRequest := func(tenantName string) *http.Request {
+		// The tenant tag cannot be overridden by the tags of the request.
+		req := httptest.NewRequest(JSONWriteHTTPMethod, WriteJSONURL,
+			strings.NewReader(`{
+				"tags": { "tag_one": "val_one", "tenant": "team-b" },
+				"timestamp": "1534952005",
+				"value": 10.0
+			}`))
+		req.Header.Set(headers.TenantHeader, tenantName)
+		return req
+	}
+
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, newRequest("team-c"))
+	require.Equal(t, http.StatusBadRequest, resp.Code)
+
+	session.EXPECT().
+		WriteTagged(gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_, _ ident.ID, tags ident.TagIterator,
+			_ xtime.UnixNano, _ float64, _ xtime.Unit, _ []byte) error {
+			var values []string
+			for tags.Next() {
+				tag := tags.Current()
+				if tag.Name.String() == "tenant" {
+					values = append(values, tag.Value.String())
+				}
+			}
+			require.Equal(t, []string{"team-a"}, values)
+			return nil
+		})
+
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, newRequest("team-a"))
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+
+	// The second request exceeds the written datapoints limit of the tenant.
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, newRequest("team-a"))
+	require.Equal(t, http.StatusTooManyRequests, resp.Code)
+}
+
 func TestJSONWriteError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
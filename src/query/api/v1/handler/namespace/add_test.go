@@ -129,6 +129,7 @@ func TestNamespaceAddHandler(t *testing.T) {
 						"aggregationOptions":    nil,
 						"bootstrapEnabled":      true,
 						"cacheBlocksOnRetrieve": false,
+						"filesetCompression":    "NONE",
 						"flushEnabled":          true,
 						"writesToCommitLog":     true,
 						"cleanupEnabled":        true,
@@ -150,6 +151,7 @@ func TestNamespaceAddHandler(t *testing.T) {
 						},
 						"runtimeOptions":    nil,
 						"schemaOptions":     nil,
+						"coldTierAgeNanos":  "0",
 						"coldWritesEnabled": false,
 						"extendedOptions":   xtest.NewTestExtendedOptionsJSON("foo"),
 					},
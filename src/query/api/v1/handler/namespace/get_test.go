@@ -126,7 +126,9 @@ func TestNamespaceGetHandler(t *testing.T) {
 						"bootstrapEnabled":      true,
 						"cacheBlocksOnRetrieve": nil,
 						"cleanupEnabled":        false,
+						"coldTierAgeNanos":      "0",
 						"coldWritesEnabled":     false,
+						"filesetCompression":    "NONE",
 						"flushEnabled":          true,
 						"indexOptions":          nil,
 						"repairEnabled":         false,
@@ -211,7 +213,9 @@ func TestNamespaceGetHandlerWithDebug(t *testing.T) {
 						"bootstrapEnabled":      true,
 						"cacheBlocksOnRetrieve": nil,
 						"cleanupEnabled":        false,
+						"coldTierAgeDuration":   "0s",
 						"coldWritesEnabled":     false,
+						"filesetCompression":    "NONE",
 						"flushEnabled":          true,
 						"indexOptions":          nil,
 						"repairEnabled":         false,
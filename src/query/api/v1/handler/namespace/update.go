@@ -52,19 +52,30 @@ var (
 
 	fieldNameRetentionOptions   = "RetentionOptions"
 	fieldNameRetentionPeriod    = "RetentionPeriodNanos"
+	fieldNameBlockSize          = "BlockSizeNanos"
 	fieldNameRuntimeOptions     = "RuntimeOptions"
 	fieldNameAggregationOptions = "AggregationOptions"
 	fieldNameExtendedOptions    = "ExtendedOptions"
+	fieldNameColdTierAgeNanos   = "ColdTierAgeNanos"
+	fieldNameFilesetCompression = "FilesetCompression"
 
 	errEmptyNamespaceName      = errors.New("must specify namespace name")
 	errEmptyNamespaceOptions   = errors.New("update options cannot be empty")
 	errNamespaceFieldImmutable = errors.New("namespace option field is immutable")
+	errBlockSizeNotMultiple    = errors.New("block size must be a multiple or a divisor of the current block size")
+
+	allowedUpdateRetentionOptionsFields = map[string]struct{}{
+		fieldNameRetentionPeriod: {},
+		fieldNameBlockSize:       {},
+	}
 
 	allowedUpdateOptionsFields = map[string]struct{}{
 		fieldNameRetentionOptions:   {},
 		fieldNameRuntimeOptions:     {},
 		fieldNameAggregationOptions: {},
 		fieldNameExtendedOptions:    {},
+		fieldNameColdTierAgeNanos:   {},
+		fieldNameFilesetCompression: {},
 	}
 )
 
@@ -170,7 +181,10 @@ func validateUpdateRequest(req *admin.NamespaceUpdateRequest) error {
 		for i := 0; i < optsVal.NumField(); i++ {
 			field := optsVal.Field(i)
 			fieldName := optsVal.Type().Field(i).Name
-			if !field.IsZero() && fieldName != fieldNameRetentionPeriod {
+			if field.IsZero() {
+				continue
+			}
+			if _, ok := allowedUpdateRetentionOptionsFields[fieldName]; !ok {
 				return fmt.Errorf("%s.%s: %w", fieldNameRetentionOptions, fieldName, errNamespaceFieldImmutable)
 			}
 		}
@@ -222,6 +236,26 @@ func (h *UpdateHandler) Update(
 					"error constructing new metadata: %w", err))
 			}
 		}
+
+		// Existing filesets are re-blocked into the new block size by the
+		// filesystem bootstrapper, which requires block boundaries to line up.
+		if newNanos := newRetentionOpts.BlockSizeNanos; newNanos != 0 {
+			dur := namespace.FromNanos(newNanos)
+			curr := ns.Options().RetentionOptions().BlockSize()
+			if dur%curr != 0 && curr%dur != 0 {
+				return emptyReg, xerrors.NewInvalidParamsError(fmt.Errorf(
+					"block size %s: %w", dur, errBlockSizeNotMultiple))
+			}
+			retentionOpts := ns.Options().RetentionOptions().
+				SetBlockSize(dur)
+			opts := ns.Options().
+				SetRetentionOptions(retentionOpts)
+			ns, err = namespace.NewMetadata(ns.ID(), opts)
+			if err != nil {
+				return emptyReg, xerrors.NewInvalidParamsError(fmt.Errorf(
+					"error constructing new metadata: %w", err))
+			}
+		}
 	}
 
 	// Update runtime options.
@@ -271,6 +305,32 @@ func (h *UpdateHandler) Update(
 		}
 	}
 
+	// Update cold tier age.
+	if newNanos := updateReq.Options.ColdTierAgeNanos; newNanos != 0 {
+		opts := ns.Options().
+			SetColdTierAge(namespace.FromNanos(newNanos))
+		ns, err = namespace.NewMetadata(ns.ID(), opts)
+		if err != nil {
+			return emptyReg, xerrors.NewInvalidParamsError(fmt.Errorf(
+				"error constructing new metadata: %w", err))
+		}
+	}
+
+	// Update fileset compression.
+	if newCompression := updateReq.Options.FilesetCompression; newCompression != nsproto.FileSetCompression_NONE {
+		compressionType, err := namespace.ToFileSetCompression(newCompression)
+		if err != nil {
+			return emptyReg, xerrors.NewInvalidParamsError(err)
+		}
+		opts := ns.Options().
+			SetFileSetCompression(compressionType)
+		ns, err = namespace.NewMetadata(ns.ID(), opts)
+		if err != nil {
+			return emptyReg, xerrors.NewInvalidParamsError(fmt.Errorf(
+				"error constructing new metadata: %w", err))
+		}
+	}
+
 	// Update the namespace in case an update occurred.
 	newMetadata[updateReq.Name] = ns
 
@@ -177,6 +177,7 @@ func TestNamespaceUpdateHandler(t *testing.T) {
 						},
 						"bootstrapEnabled":      true,
 						"cacheBlocksOnRetrieve": true,
+						"filesetCompression":    "NONE",
 						"flushEnabled":          true,
 						"writesToCommitLog":     true,
 						"cleanupEnabled":        false,
@@ -201,6 +202,7 @@ func TestNamespaceUpdateHandler(t *testing.T) {
 						},
 						"schemaOptions":     nil,
 						"stagingState":      xjson.Map{"status": "UNKNOWN"},
+						"coldTierAgeNanos":  "0",
 						"coldWritesEnabled": false,
 						"extendedOptions":   xtest.NewTestExtendedOptionsJSON("bar"),
 					},
@@ -238,6 +240,7 @@ func TestNamespaceUpdateHandler(t *testing.T) {
 						"aggregationOptions":    nil,
 						"bootstrapEnabled":      true,
 						"cacheBlocksOnRetrieve": true,
+						"filesetCompression":    "NONE",
 						"flushEnabled":          true,
 						"writesToCommitLog":     true,
 						"cleanupEnabled":        false,
@@ -259,6 +262,7 @@ func TestNamespaceUpdateHandler(t *testing.T) {
 						"runtimeOptions":    nil,
 						"schemaOptions":     nil,
 						"stagingState":      xjson.Map{"status": "UNKNOWN"},
+						"coldTierAgeNanos":  "0",
 						"coldWritesEnabled": false,
 						"extendedOptions":   xtest.NewTestExtendedOptionsJSON("foo"),
 					},
@@ -272,6 +276,85 @@ func TestNamespaceUpdateHandler(t *testing.T) {
 		xtest.Diff(expected, actual))
 }
 
+func TestNamespaceUpdateHandlerBlockSize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient, mockKV := setupNamespaceTest(t, ctrl)
+	updateHandler := NewUpdateHandler(mockClient, instrument.NewOptions())
+	mockClient.EXPECT().Store(gomock.Any()).Return(mockKV, nil).Times(2)
+
+	registry := nsproto.Registry{
+		Namespaces: map[string]*nsproto.NamespaceOptions{
+			"testNamespace": {
+				BootstrapEnabled: true,
+				FlushEnabled:     true,
+				RetentionOptions: &nsproto.RetentionOptions{
+					RetentionPeriodNanos: 172800000000000,
+					BlockSizeNanos:       7200000000000,
+					BufferFutureNanos:    600000000000,
+					BufferPastNanos:      600000000000,
+				},
+			},
+		},
+	}
+
+	for _, test := range []struct {
+		name          string
+		blockSize     string
+		expStatusCode int
+	}{
+		{
+			name:          "multiple",
+			blockSize:     "4h",
+			expStatusCode: http.StatusOK,
+		},
+		{
+			name:          "notMultiple",
+			blockSize:     "3h",
+			expStatusCode: http.StatusBadRequest,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			mockValue := kv.NewMockValue(ctrl)
+			mockValue.EXPECT().Unmarshal(gomock.Any()).Return(nil).SetArg(0, registry)
+			mockValue.EXPECT().Version().Return(0)
+			mockKV.EXPECT().Get(M3DBNodeNamespacesKey).Return(mockValue, nil)
+
+			var updated *nsproto.Registry
+			if test.expStatusCode == http.StatusOK {
+				mockKV.EXPECT().CheckAndSet(M3DBNodeNamespacesKey, gomock.Any(), gomock.Not(nil)).
+					DoAndReturn(func(_ string, _ int, reg *nsproto.Registry) (int, error) {
+						updated = reg
+						return 1, nil
+					})
+			}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", "/namespace", xjson.MustNewTestReader(t, xjson.Map{
+				"name": "testNamespace",
+				"options": xjson.Map{
+					"retentionOptions": xjson.Map{
+						"blockSizeDuration": test.blockSize,
+					},
+				},
+			}))
+			updateHandler.ServeHTTP(svcDefaults, w, req)
+
+			resp := w.Result()
+			assert.Equal(t, test.expStatusCode, resp.StatusCode)
+			if test.expStatusCode != http.StatusOK {
+				return
+			}
+
+			require.NotNil(t, updated)
+			retentionOpts := updated.Namespaces["testNamespace"].RetentionOptions
+			assert.Equal(t, int64(14400000000000), retentionOpts.BlockSizeNanos)
+			assert.Equal(t, int64(172800000000000), retentionOpts.RetentionPeriodNanos)
+		})
+	}
+}
+
 func TestValidateUpdateRequest(t *testing.T) {
 	var (
 		reqEmptyName = &admin.NamespaceUpdateRequest{
@@ -299,7 +382,16 @@ func TestValidateUpdateRequest(t *testing.T) {
 			},
 		}
 
-		reqNonZeroBlockSize = &admin.NamespaceUpdateRequest{
+		reqNonZeroBufferPast = &admin.NamespaceUpdateRequest{
+			Name: "foo",
+			Options: &nsproto.NamespaceOptions{
+				RetentionOptions: &nsproto.RetentionOptions{
+					BufferPastNanos: 1,
+				},
+			},
+		}
+
+		reqValidBlockSize = &admin.NamespaceUpdateRequest{
 			Name: "foo",
 			Options: &nsproto.NamespaceOptions{
 				RetentionOptions: &nsproto.RetentionOptions{
@@ -316,6 +408,20 @@ func TestValidateUpdateRequest(t *testing.T) {
 				},
 			},
 		}
+
+		reqValidColdTierAge = &admin.NamespaceUpdateRequest{
+			Name: "foo",
+			Options: &nsproto.NamespaceOptions{
+				ColdTierAgeNanos: 1,
+			},
+		}
+
+		reqValidFilesetCompression = &admin.NamespaceUpdateRequest{
+			Name: "foo",
+			Options: &nsproto.NamespaceOptions{
+				FilesetCompression: nsproto.FileSetCompression_ZSTD,
+			},
+		}
 	)
 
 	for _, test := range []struct {
@@ -344,8 +450,8 @@ func TestValidateUpdateRequest(t *testing.T) {
 			expErr:  errNamespaceFieldImmutable,
 		},
 		{
-			name:    "nonZeroBlockSize",
-			request: reqNonZeroBlockSize,
+			name:    "nonZeroBufferPast",
+			request: reqNonZeroBufferPast,
 			expErr:  errNamespaceFieldImmutable,
 		},
 		{
@@ -353,6 +459,21 @@ func TestValidateUpdateRequest(t *testing.T) {
 			request: reqValid,
 			expErr:  nil,
 		},
+		{
+			name:    "validBlockSize",
+			request: reqValidBlockSize,
+			expErr:  nil,
+		},
+		{
+			name:    "validColdTierAge",
+			request: reqValidColdTierAge,
+			expErr:  nil,
+		},
+		{
+			name:    "validFilesetCompression",
+			request: reqValidFilesetCompression,
+			expErr:  nil,
+		},
 	} {
 		t.Run(test.name, func(t *testing.T) {
 			err := validateUpdateRequest(test.request)
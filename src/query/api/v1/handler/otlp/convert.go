@@ -0,0 +1,436 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package otlp
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/ts"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+const (
+	bucketSuffix = "_bucket"
+	countSuffix  = "_count"
+	sumSuffix    = "_sum"
+
+	bucketLabel   = "le"
+	quantileLabel = "quantile"
+
+	serviceNameAttribute       = "service.name"
+	serviceNamespaceAttribute  = "service.namespace"
+	serviceInstanceIDAttribute = "service.instance.id"
+
+	jobLabel      = "job"
+	instanceLabel = "instance"
+)
+
+// series is a single M3 series converted from an OTLP data point.
+type series struct {
+	tags       models.Tags
+	datapoints ts.Datapoints
+	attributes ts.SeriesAttributes
+}
+
+// convertResult is the result of converting an OTLP metrics payload.
+type convertResult struct {
+	series []series
+	// dropped is the number of data points that could not be mapped onto
+	// M3 series (e.g. unsupported data types or points with no value).
+	dropped int
+}
+
+// converter maps OTLP metrics onto M3 series using the same naming
+// conventions as the Prometheus OTLP translator: metric and label names are
+// sanitized, histograms are expanded into _bucket/_sum/_count series and
+// summaries into quantile/_sum/_count series.
+type converter struct {
+	tagOptions models.TagOptions
+	// promoteResourceAttributes are resource attributes copied onto every
+	// series as labels, in addition to job and instance.
+	promoteResourceAttributes []string
+}
+
+func newConverter(
+	tagOptions models.TagOptions,
+	promoteResourceAttributes []string,
+) *converter {
+	return &converter{
+		tagOptions:                tagOptions,
+		promoteResourceAttributes: promoteResourceAttributes,
+	}
+}
+
+func (c *converter) convert(md pdata.Metrics) convertResult {
+	var result convertResult
+	resourceMetrics := md.ResourceMetrics()
+	for i := 0; i < resourceMetrics.Len(); i++ {
+		rm := resourceMetrics.At(i)
+		resourceLabels := c.resourceLabels(rm.Resource())
+		libraryMetrics := rm.InstrumentationLibraryMetrics()
+		for j := 0; j < libraryMetrics.Len(); j++ {
+			metrics := libraryMetrics.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				c.convertMetric(metrics.At(k), resourceLabels, &result)
+			}
+		}
+	}
+	return result
+}
+
+func (c *converter) convertMetric(
+	metric pdata.Metric,
+	resourceLabels map[string]string,
+	result *convertResult,
+) {
+	name := sanitizeMetricName(metric.Name())
+	switch metric.DataType() {
+	case pdata.MetricDataTypeGauge:
+		points := metric.Gauge().DataPoints()
+		attrs := ts.SeriesAttributes{
+			Source:   ts.SourceTypePrometheus,
+			M3Type:   ts.M3MetricTypeGauge,
+			PromType: ts.PromMetricTypeGauge,
+		}
+		for i := 0; i < points.Len(); i++ {
+			c.addNumberPoint(name, points.At(i), resourceLabels, attrs, result)
+		}
+
+	case pdata.MetricDataTypeSum:
+		sum := metric.Sum()
+		points := sum.DataPoints()
+		attrs := ts.SeriesAttributes{
+			Source:   ts.SourceTypePrometheus,
+			M3Type:   ts.M3MetricTypeGauge,
+			PromType: ts.PromMetricTypeGauge,
+		}
+		if sum.IsMonotonic() {
+			attrs = counterAttributes(sum.AggregationTemporality())
+		}
+		for i := 0; i < points.Len(); i++ {
+			c.addNumberPoint(name, points.At(i), resourceLabels, attrs, result)
+		}
+
+	case pdata.MetricDataTypeHistogram:
+		histogram := metric.Histogram()
+		points := histogram.DataPoints()
+		attrs := counterAttributes(histogram.AggregationTemporality())
+		attrs.PromType = ts.PromMetricTypeHistogram
+		for i := 0; i < points.Len(); i++ {
+			c.addHistogramPoint(name, points.At(i), resourceLabels, attrs, result)
+		}
+
+	case pdata.MetricDataTypeSummary:
+		points := metric.Summary().DataPoints()
+		for i := 0; i < points.Len(); i++ {
+			c.addSummaryPoint(name, points.At(i), resourceLabels, result)
+		}
+
+	default:
+		// Exponential histograms (and unset data types) have no
+		// representation as M3 series.
+		result.dropped += dataPointCount(metric)
+	}
+}
+
+// counterAttributes returns the series attributes for monotonic data.
+// Cumulative data is stored as a regular Prometheus counter while delta data
+// is written as an M3 counter so that the aggregator sums the deltas when
+// downsampling instead of taking the last value.
+func counterAttributes(
+	temporality pdata.MetricAggregationTemporality,
+) ts.SeriesAttributes {
+	if temporality == pdata.MetricAggregationTemporalityDelta {
+		return ts.SeriesAttributes{
+			Source:   ts.SourceTypePrometheus,
+			M3Type:   ts.M3MetricTypeCounter,
+			PromType: ts.PromMetricTypeCounter,
+		}
+	}
+	return ts.SeriesAttributes{
+		Source:            ts.SourceTypePrometheus,
+		M3Type:            ts.M3MetricTypeGauge,
+		PromType:          ts.PromMetricTypeCounter,
+		HandleValueResets: true,
+	}
+}
+
+func (c *converter) addNumberPoint(
+	name string,
+	point pdata.NumberDataPoint,
+	resourceLabels map[string]string,
+	attrs ts.SeriesAttributes,
+	result *convertResult,
+) {
+	if point.Flags().HasFlag(pdata.MetricDataPointFlagNoRecordedValue) {
+		result.dropped++
+		return
+	}
+
+	var value float64
+	switch point.ValueType() {
+	case pdata.MetricValueTypeInt:
+		value = float64(point.IntVal())
+	case pdata.MetricValueTypeDouble:
+		value = point.DoubleVal()
+	default:
+		result.dropped++
+		return
+	}
+
+	labels := c.pointLabels(point.Attributes(), resourceLabels)
+	c.add(name, labels, point.Timestamp(), value, attrs, result)
+}
+
+func (c *converter) addHistogramPoint(
+	name string,
+	point pdata.HistogramDataPoint,
+	resourceLabels map[string]string,
+	attrs ts.SeriesAttributes,
+	result *convertResult,
+) {
+	if point.Flags().HasFlag(pdata.MetricDataPointFlagNoRecordedValue) {
+		result.dropped++
+		return
+	}
+
+	var (
+		labels    = c.pointLabels(point.Attributes(), resourceLabels)
+		timestamp = point.Timestamp()
+		bounds    = point.ExplicitBounds()
+		counts    = point.BucketCounts()
+		total     uint64
+	)
+	// OTLP bucket counts are per bucket, Prometheus buckets are cumulative.
+	// The final bucket count is the overflow bucket and is covered by the
+	// +Inf bucket which is always the total count.
+	for i, bound := range bounds {
+		if i >= len(counts) {
+			break
+		}
+		total += counts[i]
+		bucketLabels := withLabel(labels, bucketLabel, formatFloat(bound))
+		c.add(name+bucketSuffix, bucketLabels, timestamp, float64(total), attrs, result)
+	}
+
+	infLabels := withLabel(labels, bucketLabel, "+Inf")
+	c.add(name+bucketSuffix, infLabels, timestamp, float64(point.Count()), attrs, result)
+	c.add(name+countSuffix, labels, timestamp, float64(point.Count()), attrs, result)
+	c.add(name+sumSuffix, labels, timestamp, point.Sum(), attrs, result)
+}
+
+func (c *converter) addSummaryPoint(
+	name string,
+	point pdata.SummaryDataPoint,
+	resourceLabels map[string]string,
+	result *convertResult,
+) {
+	if point.Flags().HasFlag(pdata.MetricDataPointFlagNoRecordedValue) {
+		result.dropped++
+		return
+	}
+
+	var (
+		labels    = c.pointLabels(point.Attributes(), resourceLabels)
+		timestamp = point.Timestamp()
+		quantiles = point.QuantileValues()
+		attrs     = ts.SeriesAttributes{
+			Source:   ts.SourceTypePrometheus,
+			M3Type:   ts.M3MetricTypeGauge,
+			PromType: ts.PromMetricTypeSummary,
+		}
+		counterAttrs = ts.SeriesAttributes{
+			Source:            ts.SourceTypePrometheus,
+			M3Type:            ts.M3MetricTypeGauge,
+			PromType:          ts.PromMetricTypeSummary,
+			HandleValueResets: true,
+		}
+	)
+	for i := 0; i < quantiles.Len(); i++ {
+		q := quantiles.At(i)
+		quantileLabels := withLabel(labels, quantileLabel, formatFloat(q.Quantile()))
+		c.add(name, quantileLabels, timestamp, q.Value(), attrs, result)
+	}
+
+	c.add(name+countSuffix, labels, timestamp, float64(point.Count()), counterAttrs, result)
+	c.add(name+sumSuffix, labels, timestamp, point.Sum(), counterAttrs, result)
+}
+
+func (c *converter) add(
+	name string,
+	labels map[string]string,
+	timestamp pdata.Timestamp,
+	value float64,
+	attrs ts.SeriesAttributes,
+	result *convertResult,
+) {
+	tags := models.NewTags(len(labels)+1, c.tagOptions)
+	tags = tags.AddTagWithoutNormalizing(models.Tag{
+		Name:  c.tagOptions.MetricName(),
+		Value: []byte(name),
+	})
+	for k, v := range labels {
+		tags = tags.AddTagWithoutNormalizing(models.Tag{
+			Name:  []byte(k),
+			Value: []byte(v),
+		})
+	}
+
+	result.series = append(result.series, series{
+		tags: tags.Normalize(),
+		datapoints: ts.Datapoints{{
+			// Truncate to milliseconds to match the precision used by the
+			// Prometheus remote write path.
+			Timestamp: xtime.UnixNano(timestamp).Truncate(time.Millisecond),
+			Value:     value,
+		}},
+		attributes: attrs,
+	})
+}
+
+func (c *converter) resourceLabels(resource pdata.Resource) map[string]string {
+	var (
+		attrs  = resource.Attributes()
+		labels = make(map[string]string, 2+len(c.promoteResourceAttributes))
+	)
+	if v, ok := attrs.Get(serviceNameAttribute); ok {
+		job := v.AsString()
+		if ns, ok := attrs.Get(serviceNamespaceAttribute); ok {
+			job = ns.AsString() + "/" + job
+		}
+		labels[jobLabel] = job
+	}
+	if v, ok := attrs.Get(serviceInstanceIDAttribute); ok {
+		labels[instanceLabel] = v.AsString()
+	}
+	for _, name := range c.promoteResourceAttributes {
+		if v, ok := attrs.Get(name); ok {
+			addLabel(labels, sanitizeLabelName(name), v.AsString())
+		}
+	}
+	return labels
+}
+
+func (c *converter) pointLabels(
+	attrs pdata.AttributeMap,
+	resourceLabels map[string]string,
+) map[string]string {
+	labels := make(map[string]string, attrs.Len()+len(resourceLabels))
+	// Sort so that values of attributes which collide once sanitized are
+	// joined in a deterministic order.
+	attrs.Sort().Range(func(k string, v pdata.AttributeValue) bool {
+		addLabel(labels, sanitizeLabelName(k), v.AsString())
+		return true
+	})
+	for k, v := range resourceLabels {
+		// Data point attributes take precedence over resource labels.
+		if _, ok := labels[k]; !ok {
+			labels[k] = v
+		}
+	}
+	return labels
+}
+
+// addLabel adds a label, joining values with ";" if the sanitized name
+// collides with an existing label (the same behavior as Prometheus).
+func addLabel(labels map[string]string, name, value string) {
+	if existing, ok := labels[name]; ok {
+		labels[name] = existing + ";" + value
+		return
+	}
+	labels[name] = value
+}
+
+func withLabel(labels map[string]string, name, value string) map[string]string {
+	result := make(map[string]string, len(labels)+1)
+	for k, v := range labels {
+		result[k] = v
+	}
+	result[name] = value
+	return result
+}
+
+func dataPointCount(metric pdata.Metric) int {
+	switch metric.DataType() {
+	case pdata.MetricDataTypeExponentialHistogram:
+		return metric.ExponentialHistogram().DataPoints().Len()
+	default:
+		return 0
+	}
+}
+
+// sanitizeMetricName replaces characters that are not valid in a Prometheus
+// metric name with underscores, prefixing names that start with a digit.
+func sanitizeMetricName(name string) string {
+	if startsWithDigit(name) {
+		name = "_" + name
+	}
+	return sanitize(name, func(i int, r rune) bool {
+		return r == ':' || isLabelRune(i, r)
+	})
+}
+
+// sanitizeLabelName replaces characters that are not valid in a Prometheus
+// label name with underscores, prefixing names that start with a digit.
+func sanitizeLabelName(name string) string {
+	if startsWithDigit(name) {
+		name = "key_" + name
+	}
+	return sanitize(name, isLabelRune)
+}
+
+func startsWithDigit(name string) bool {
+	return len(name) > 0 && name[0] >= '0' && name[0] <= '9'
+}
+
+func isLabelRune(i int, r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r == '_' ||
+		(i > 0 && r >= '0' && r <= '9')
+}
+
+func sanitize(name string, valid func(i int, r rune) bool) string {
+	var b strings.Builder
+	b.Grow(len(name))
+	for i, r := range name {
+		if valid(i, r) {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,217 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package otlp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/ts"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+var testTimestamp = time.Unix(1600000000, 123456789)
+
+func newTestMetrics() (pdata.Metrics, pdata.MetricSlice) {
+	md := pdata.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().InsertString("service.name", "checkout")
+	rm.Resource().Attributes().InsertString("service.namespace", "shop")
+	rm.Resource().Attributes().InsertString("service.instance.id", "host-1:8080")
+	rm.Resource().Attributes().InsertString("cloud.region", "us-east-1")
+	metrics := rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+	return md, metrics
+}
+
+func seriesStrings(t *testing.T, result convertResult) []string {
+	out := make([]string, 0, len(result.series))
+	for _, s := range result.series {
+		require.Equal(t, 1, len(s.datapoints))
+		assert.Equal(t, xtime.ToUnixNano(testTimestamp.Truncate(time.Millisecond)),
+			s.datapoints[0].Timestamp)
+		out = append(out, s.tags.String()+" "+
+			formatFloat(s.datapoints[0].Value))
+	}
+	return out
+}
+
+func TestConvertGauge(t *testing.T) {
+	md, metrics := newTestMetrics()
+	m := metrics.AppendEmpty()
+	m.SetName("http.server.active_requests")
+	m.SetDataType(pdata.MetricDataTypeGauge)
+	dp := m.Gauge().DataPoints().AppendEmpty()
+	dp.SetTimestamp(pdata.NewTimestampFromTime(testTimestamp))
+	dp.SetIntVal(42)
+	dp.Attributes().InsertString("http.method", "GET")
+	dp.Attributes().InsertString("2xx", "yes")
+
+	result := newConverter(models.NewTagOptions(), nil).convert(md)
+	assert.Equal(t, 0, result.dropped)
+	assert.Equal(t, []string{
+		"__name__: http_server_active_requests, http_method: GET, " +
+			"instance: host-1:8080, job: shop/checkout, key_2xx: yes 42",
+	}, seriesStrings(t, result))
+	assert.Equal(t, ts.SeriesAttributes{
+		Source:   ts.SourceTypePrometheus,
+		M3Type:   ts.M3MetricTypeGauge,
+		PromType: ts.PromMetricTypeGauge,
+	}, result.series[0].attributes)
+}
+
+func TestConvertPromoteResourceAttributes(t *testing.T) {
+	md, metrics := newTestMetrics()
+	m := metrics.AppendEmpty()
+	m.SetName("up")
+	m.SetDataType(pdata.MetricDataTypeGauge)
+	dp := m.Gauge().DataPoints().AppendEmpty()
+	dp.SetTimestamp(pdata.NewTimestampFromTime(testTimestamp))
+	dp.SetDoubleVal(1)
+
+	result := newConverter(models.NewTagOptions(),
+		[]string{"cloud.region", "missing"}).convert(md)
+	assert.Equal(t, []string{
+		"__name__: up, cloud_region: us-east-1, instance: host-1:8080, job: shop/checkout 1",
+	}, seriesStrings(t, result))
+}
+
+func TestConvertSumTemporality(t *testing.T) {
+	md, metrics := newTestMetrics()
+	for _, temporality := range []pdata.MetricAggregationTemporality{
+		pdata.MetricAggregationTemporalityCumulative,
+		pdata.MetricAggregationTemporalityDelta,
+	} {
+		m := metrics.AppendEmpty()
+		m.SetName("requests_" + temporality.String())
+		m.SetDataType(pdata.MetricDataTypeSum)
+		m.Sum().SetIsMonotonic(true)
+		m.Sum().SetAggregationTemporality(temporality)
+		dp := m.Sum().DataPoints().AppendEmpty()
+		dp.SetTimestamp(pdata.NewTimestampFromTime(testTimestamp))
+		dp.SetDoubleVal(5)
+	}
+	m := metrics.AppendEmpty()
+	m.SetName("queue_size")
+	m.SetDataType(pdata.MetricDataTypeSum)
+	m.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+	dp := m.Sum().DataPoints().AppendEmpty()
+	dp.SetTimestamp(pdata.NewTimestampFromTime(testTimestamp))
+	dp.SetDoubleVal(3)
+
+	result := newConverter(models.NewTagOptions(), nil).convert(md)
+	require.Equal(t, 3, len(result.series))
+
+	assert.Equal(t, ts.SeriesAttributes{
+		Source:            ts.SourceTypePrometheus,
+		M3Type:            ts.M3MetricTypeGauge,
+		PromType:          ts.PromMetricTypeCounter,
+		HandleValueResets: true,
+	}, result.series[0].attributes)
+	assert.Equal(t, ts.SeriesAttributes{
+		Source:   ts.SourceTypePrometheus,
+		M3Type:   ts.M3MetricTypeCounter,
+		PromType: ts.PromMetricTypeCounter,
+	}, result.series[1].attributes)
+	assert.Equal(t, ts.SeriesAttributes{
+		Source:   ts.SourceTypePrometheus,
+		M3Type:   ts.M3MetricTypeGauge,
+		PromType: ts.PromMetricTypeGauge,
+	}, result.series[2].attributes)
+}
+
+func TestConvertHistogram(t *testing.T) {
+	md, metrics := newTestMetrics()
+	m := metrics.AppendEmpty()
+	m.SetName("rpc.duration")
+	m.SetDataType(pdata.MetricDataTypeHistogram)
+	m.Histogram().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+	dp := m.Histogram().DataPoints().AppendEmpty()
+	dp.SetTimestamp(pdata.NewTimestampFromTime(testTimestamp))
+	dp.SetExplicitBounds([]float64{0.1, 1})
+	dp.SetBucketCounts([]uint64{2, 3, 4})
+	dp.SetCount(9)
+	dp.SetSum(12.5)
+
+	result := newConverter(models.NewTagOptions(), nil).convert(md)
+	assert.Equal(t, []string{
+		"__name__: rpc_duration_bucket, instance: host-1:8080, job: shop/checkout, le: 0.1 2",
+		"__name__: rpc_duration_bucket, instance: host-1:8080, job: shop/checkout, le: 1 5",
+		"__name__: rpc_duration_bucket, instance: host-1:8080, job: shop/checkout, le: +Inf 9",
+		"__name__: rpc_duration_count, instance: host-1:8080, job: shop/checkout 9",
+		"__name__: rpc_duration_sum, instance: host-1:8080, job: shop/checkout 12.5",
+	}, seriesStrings(t, result))
+	for _, s := range result.series {
+		assert.Equal(t, ts.PromMetricTypeHistogram, s.attributes.PromType)
+		assert.True(t, s.attributes.HandleValueResets)
+	}
+}
+
+func TestConvertSummary(t *testing.T) {
+	md, metrics := newTestMetrics()
+	m := metrics.AppendEmpty()
+	m.SetName("gc_pause")
+	m.SetDataType(pdata.MetricDataTypeSummary)
+	dp := m.Summary().DataPoints().AppendEmpty()
+	dp.SetTimestamp(pdata.NewTimestampFromTime(testTimestamp))
+	dp.SetCount(10)
+	dp.SetSum(2)
+	q := dp.QuantileValues().AppendEmpty()
+	q.SetQuantile(0.99)
+	q.SetValue(0.5)
+
+	result := newConverter(models.NewTagOptions(), nil).convert(md)
+	assert.Equal(t, []string{
+		"__name__: gc_pause, instance: host-1:8080, job: shop/checkout, quantile: 0.99 0.5",
+		"__name__: gc_pause_count, instance: host-1:8080, job: shop/checkout 10",
+		"__name__: gc_pause_sum, instance: host-1:8080, job: shop/checkout 2",
+	}, seriesStrings(t, result))
+}
+
+func TestConvertDropsUnsupported(t *testing.T) {
+	md, metrics := newTestMetrics()
+	m := metrics.AppendEmpty()
+	m.SetName("exp")
+	m.SetDataType(pdata.MetricDataTypeExponentialHistogram)
+	m.ExponentialHistogram().DataPoints().AppendEmpty()
+
+	m = metrics.AppendEmpty()
+	m.SetName("empty")
+	m.SetDataType(pdata.MetricDataTypeGauge)
+	dp := m.Gauge().DataPoints().AppendEmpty()
+	dp.SetFlags(pdata.NewMetricDataPointFlags(pdata.MetricDataPointFlagNoRecordedValue))
+
+	result := newConverter(models.NewTagOptions(), nil).convert(md)
+	assert.Equal(t, 0, len(result.series))
+	assert.Equal(t, 2, result.dropped)
+}
+
+func TestSanitizeNames(t *testing.T) {
+	assert.Equal(t, "http_server_duration", sanitizeMetricName("http.server.duration"))
+	assert.Equal(t, "ns:metric_name", sanitizeMetricName("ns:metric-name"))
+	assert.Equal(t, "_0metric", sanitizeMetricName("0metric"))
+	assert.Equal(t, "key_0label", sanitizeLabelName("0label"))
+	assert.Equal(t, "label_with_dots", sanitizeLabelName("label.with.dots"))
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package otlp
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/collector/model/otlpgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/x/headers"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+)
+
+type metricsServer struct {
+	writer *metricsWriter
+}
+
+// NewGRPCServer returns a new gRPC server with the OTLP metrics service
+// registered.
+func NewGRPCServer(
+	opts options.HandlerOptions,
+	serverOpts ...grpc.ServerOption,
+) (*grpc.Server, error) {
+	writer, err := newMetricsWriter(opts, "otlp-grpc-write")
+	if err != nil {
+		return nil, err
+	}
+
+	server := grpc.NewServer(serverOpts...)
+	otlpgrpc.RegisterMetricsServer(server, &metricsServer{writer: writer})
+	return server, nil
+}
+
+func (s *metricsServer) Export(
+	ctx context.Context,
+	req otlpgrpc.MetricsRequest,
+) (otlpgrpc.MetricsResponse, error) {
+	var remoteAddr string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		remoteAddr = p.Addr.String()
+	}
+
+	t, err := handleroptions.ParseTenantName(tenantName(ctx), s.writer.tenants)
+	if err != nil {
+		s.writer.metrics.incError(xhttp.NewError(err, http.StatusBadRequest))
+		return otlpgrpc.NewMetricsResponse(), status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := s.writer.write(ctx, req.Metrics(), t, remoteAddr); err != nil {
+		return otlpgrpc.NewMetricsResponse(), status.Error(grpcCode(err), err.Error())
+	}
+
+	return otlpgrpc.NewMetricsResponse(), nil
+}
+
+// tenantName returns the tenant of a request from the tenant header set
+// in the gRPC metadata of the request, if any.
+func tenantName(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(headers.TenantHeader)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// grpcCode maps the HTTP status of a write error onto the gRPC code the
+// OTLP exporters use to decide whether to retry.
+func grpcCode(err error) codes.Code {
+	httpErr, ok := err.(xhttp.Error) // nolint:errorlint
+	if !ok {
+		return codes.Unavailable
+	}
+
+	switch httpErr.Code() {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	default:
+		return codes.Unavailable
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package otlp implements OpenTelemetry (OTLP) metrics ingestion over HTTP
+// and gRPC.
+package otlp
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+
+	"go.opentelemetry.io/collector/model/otlpgrpc"
+
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/api/v1/route"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+)
+
+const (
+	// WriteURL is the url for the OTLP/HTTP metrics write handler, the
+	// suffix matches the default OTLP/HTTP metrics path.
+	WriteURL = route.Prefix + "/otlp/v1/metrics"
+
+	// WriteHTTPMethod is the HTTP method used with this resource.
+	WriteHTTPMethod = http.MethodPost
+
+	protobufContentType = "application/x-protobuf"
+	jsonContentType     = "application/json"
+)
+
+var (
+	errEmptyBody       = errors.New("empty request body")
+	errRequestTooLarge = errors.New("request body too large")
+)
+
+// WriteHandler is the OTLP/HTTP metrics write handler, it accepts both the
+// binary protobuf and JSON encodings of ExportMetricsServiceRequest.
+type WriteHandler struct {
+	writer          *metricsWriter
+	maxRequestBytes int64
+}
+
+// NewWriteHandler returns a new OTLP/HTTP metrics write handler.
+func NewWriteHandler(opts options.HandlerOptions) (http.Handler, error) {
+	writer, err := newMetricsWriter(opts, "otlp-http-write")
+	if err != nil {
+		return nil, err
+	}
+	return &WriteHandler{
+		writer:          writer,
+		maxRequestBytes: opts.Config().OTLP.MaxRequestBytesOrDefault(),
+	}, nil
+}
+
+func (h *WriteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	contentType, err := requestContentType(r)
+	if err != nil {
+		h.writeError(w, xhttp.NewError(err, http.StatusUnsupportedMediaType))
+		return
+	}
+
+	body, err := readBody(w, r, h.maxRequestBytes)
+	if errors.Is(err, errRequestTooLarge) {
+		h.writeError(w, xhttp.NewError(err, http.StatusRequestEntityTooLarge))
+		return
+	}
+	if err != nil {
+		h.writeError(w, xhttp.NewError(err, http.StatusBadRequest))
+		return
+	}
+
+	t, err := handleroptions.ParseTenant(r, h.writer.tenants)
+	if err != nil {
+		h.writeError(w, xhttp.NewError(err, http.StatusBadRequest))
+		return
+	}
+
+	var req otlpgrpc.MetricsRequest
+	switch contentType {
+	case jsonContentType:
+		req, err = otlpgrpc.UnmarshalJSONMetricsRequest(body)
+	default:
+		req, err = otlpgrpc.UnmarshalMetricsRequest(body)
+	}
+	if err != nil {
+		h.writeError(w, xhttp.NewError(err, http.StatusBadRequest))
+		return
+	}
+
+	if err := h.writer.write(r.Context(), req.Metrics(), t, r.RemoteAddr); err != nil {
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	var (
+		resp = otlpgrpc.NewMetricsResponse()
+		data []byte
+	)
+	switch contentType {
+	case jsonContentType:
+		data, err = resp.MarshalJSON()
+	default:
+		data, err = resp.Marshal()
+	}
+	if err != nil {
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	w.Header().Set(xhttp.HeaderContentType, contentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+func (h *WriteHandler) writeError(w http.ResponseWriter, err error) {
+	h.writer.metrics.incError(err)
+	xhttp.WriteError(w, err)
+}
+
+func requestContentType(r *http.Request) (string, error) {
+	v := r.Header.Get(xhttp.HeaderContentType)
+	if v == "" {
+		return protobufContentType, nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(v)
+	if err != nil {
+		return "", err
+	}
+
+	switch mediaType {
+	case protobufContentType, jsonContentType:
+		return mediaType, nil
+	default:
+		return "", fmt.Errorf("unsupported content type: %s", mediaType)
+	}
+}
+
+// readBody reads the request body, rejecting bodies larger than the max size
+// either as received or once decompressed.
+func readBody(w http.ResponseWriter, r *http.Request, maxBytes int64) ([]byte, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, errEmptyBody
+	}
+	defer r.Body.Close()
+
+	body := http.MaxBytesReader(w, r.Body, maxBytes)
+	var reader io.Reader = body
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(body)
+		if err != nil {
+			return nil, bodyReadError(err)
+		}
+		defer gz.Close()
+		// NB: reading one byte past the max size tells bodies of exactly the
+		// max size apart from larger ones.
+		reader = io.LimitReader(gz, maxBytes+1)
+	}
+
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, bodyReadError(err)
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, errRequestTooLarge
+	}
+	return data, nil
+}
+
+func bodyReadError(err error) error {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return errRequestTooLarge
+	}
+	return err
+}
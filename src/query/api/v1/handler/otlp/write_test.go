@@ -0,0 +1,393 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package otlp
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/otlpgrpc"
+	"go.opentelemetry.io/collector/model/pdata"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest"
+	"github.com/m3db/m3/src/cmd/services/m3query/config"
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/models"
+	m3storage "github.com/m3db/m3/src/query/storage/m3"
+	"github.com/m3db/m3/src/query/tenant"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/headers"
+	"github.com/m3db/m3/src/x/instrument"
+	xtest "github.com/m3db/m3/src/x/test"
+)
+
+func makeOptions(ds ingest.DownsamplerAndWriter) options.HandlerOptions {
+	return options.EmptyHandlerOptions().
+		SetDownsamplerAndWriter(ds).
+		SetTagOptions(models.NewTagOptions())
+}
+
+func newTestRequest() otlpgrpc.MetricsRequest {
+	md, metrics := newTestMetrics()
+	m := metrics.AppendEmpty()
+	m.SetName("up")
+	m.SetDataType(pdata.MetricDataTypeGauge)
+	dp := m.Gauge().DataPoints().AppendEmpty()
+	dp.SetTimestamp(pdata.NewTimestampFromTime(testTimestamp))
+	dp.SetDoubleVal(1)
+
+	req := otlpgrpc.NewMetricsRequest()
+	req.SetMetrics(md)
+	return req
+}
+
+func expectWriteBatch(
+	t *testing.T,
+	ds *ingest.MockDownsamplerAndWriter,
+	err ingest.BatchError,
+) {
+	ds.EXPECT().
+		WriteBatch(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			iter ingest.DownsampleAndWriteIter,
+			_ ingest.WriteOptions,
+		) ingest.BatchError {
+			require.True(t, iter.Next())
+			value := iter.Current()
+			assert.Equal(t,
+				"__name__: up, instance: host-1:8080, job: shop/checkout",
+				value.Tags.String())
+			require.Equal(t, 1, len(value.Datapoints))
+			assert.Equal(t, 1.0, value.Datapoints[0].Value)
+			require.False(t, iter.Next())
+			return err
+		})
+}
+
+func TestWriteHandler(t *testing.T) {
+	protoBody, err := newTestRequest().Marshal()
+	require.NoError(t, err)
+	jsonBody, err := newTestRequest().MarshalJSON()
+	require.NoError(t, err)
+
+	var gzipped bytes.Buffer
+	gz := gzip.NewWriter(&gzipped)
+	_, err = gz.Write(protoBody)
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	tests := []struct {
+		name            string
+		body            []byte
+		headers         map[string]string
+		expectWrite     bool
+		expectedStatus  int
+		expectedContent string
+	}{
+		{
+			name:            "protobuf",
+			body:            protoBody,
+			headers:         map[string]string{"Content-Type": protobufContentType},
+			expectWrite:     true,
+			expectedStatus:  http.StatusOK,
+			expectedContent: protobufContentType,
+		},
+		{
+			name:            "default content type",
+			body:            protoBody,
+			expectWrite:     true,
+			expectedStatus:  http.StatusOK,
+			expectedContent: protobufContentType,
+		},
+		{
+			name:            "json",
+			body:            jsonBody,
+			headers:         map[string]string{"Content-Type": "application/json; charset=utf-8"},
+			expectWrite:     true,
+			expectedStatus:  http.StatusOK,
+			expectedContent: jsonContentType,
+		},
+		{
+			name: "gzip",
+			body: gzipped.Bytes(),
+			headers: map[string]string{
+				"Content-Type":     protobufContentType,
+				"Content-Encoding": "gzip",
+			},
+			expectWrite:     true,
+			expectedStatus:  http.StatusOK,
+			expectedContent: protobufContentType,
+		},
+		{
+			name:           "unsupported content type",
+			body:           protoBody,
+			headers:        map[string]string{"Content-Type": "text/plain"},
+			expectedStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:           "invalid body",
+			body:           []byte("not a protobuf"),
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := xtest.NewController(t)
+			defer ctrl.Finish()
+
+			ds := ingest.NewMockDownsamplerAndWriter(ctrl)
+			if test.expectWrite {
+				expectWriteBatch(t, ds, nil)
+			}
+
+			handler, err := NewWriteHandler(makeOptions(ds))
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(WriteHTTPMethod, WriteURL, bytes.NewReader(test.body))
+			for k, v := range test.headers {
+				req.Header.Set(k, v)
+			}
+			writer := httptest.NewRecorder()
+			handler.ServeHTTP(writer, req)
+
+			resp := writer.Result()
+			defer resp.Body.Close()
+			require.Equal(t, test.expectedStatus, resp.StatusCode)
+			if test.expectedContent != "" {
+				assert.Equal(t, test.expectedContent, resp.Header.Get("Content-Type"))
+			}
+		})
+	}
+}
+
+func TestWriteHandlerRequestTooLarge(t *testing.T) {
+	body, err := newTestRequest().Marshal()
+	require.NoError(t, err)
+
+	// Gzip bodies smaller than the max size are rejected once decompressed
+	// past it.
+	var gzipped bytes.Buffer
+	gz := gzip.NewWriter(&gzipped)
+	_, err = gz.Write(bytes.Repeat([]byte{0}, 4*len(body)))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	require.Less(t, gzipped.Len(), len(body))
+
+	tests := []struct {
+		name    string
+		body    []byte
+		headers map[string]string
+	}{
+		{
+			name: "raw body",
+			body: append(append([]byte(nil), body...), body...),
+		},
+		{
+			name:    "decompressed body",
+			body:    gzipped.Bytes(),
+			headers: map[string]string{"Content-Encoding": "gzip"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := xtest.NewController(t)
+			defer ctrl.Finish()
+
+			opts := makeOptions(ingest.NewMockDownsamplerAndWriter(ctrl)).
+				SetConfig(config.Configuration{
+					OTLP: &config.OTLPConfiguration{MaxRequestBytes: int64(len(body))},
+				})
+			handler, err := NewWriteHandler(opts)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(WriteHTTPMethod, WriteURL, bytes.NewReader(test.body))
+			for k, v := range test.headers {
+				req.Header.Set(k, v)
+			}
+			writer := httptest.NewRecorder()
+			handler.ServeHTTP(writer, req)
+
+			resp := writer.Result()
+			defer resp.Body.Close()
+			require.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+		})
+	}
+}
+
+func TestWriteHandlerWriteErrors(t *testing.T) {
+	body, err := newTestRequest().Marshal()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{
+			name:           "bad request",
+			err:            xerrors.NewInvalidParamsError(errors.New("bad tags")),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "internal error",
+			err:            errors.New("storage unavailable"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := xtest.NewController(t)
+			defer ctrl.Finish()
+
+			ds := ingest.NewMockDownsamplerAndWriter(ctrl)
+			expectWriteBatch(t, ds, xerrors.NewMultiError().Add(test.err))
+
+			handler, err := NewWriteHandler(makeOptions(ds))
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(WriteHTTPMethod, WriteURL, bytes.NewReader(body))
+			writer := httptest.NewRecorder()
+			handler.ServeHTTP(writer, req)
+
+			resp := writer.Result()
+			defer resp.Body.Close()
+			require.Equal(t, test.expectedStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestGRPCExport(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	ds := ingest.NewMockDownsamplerAndWriter(ctrl)
+	writer, err := newMetricsWriter(makeOptions(ds), "test")
+	require.NoError(t, err)
+	server := &metricsServer{writer: writer}
+
+	expectWriteBatch(t, ds, nil)
+	_, err = server.Export(context.Background(), newTestRequest())
+	require.NoError(t, err)
+
+	expectWriteBatch(t, ds, xerrors.NewMultiError().
+		Add(xerrors.NewInvalidParamsError(errors.New("bad tags"))))
+	_, err = server.Export(context.Background(), newTestRequest())
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestWriteTenant(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := tenant.Configuration{
+		Required: true,
+		Tenants: []tenant.TenantConfiguration{{
+			Name: "team-a",
+			Limits: tenant.LimitsConfiguration{
+				MaxWrittenDatapoints: &tenant.LookbackLimitConfiguration{
+					Value:    3,
+					Lookback: time.Hour,
+				},
+			},
+		}},
+	}
+	tenants, err := cfg.NewTenants(instrument.NewOptions())
+	require.NoError(t, err)
+	defer tenants.Close()
+
+	ds := ingest.NewMockDownsamplerAndWriter(ctrl)
+	opts := makeOptions(ds).
+		SetM3DBOptions(m3storage.NewOptions(encoding.NewOptions()).
+			SetTenants(tenants))
+	handler, err := NewWriteHandler(opts)
+	require.NoError(t, err)
+	writer, err := newMetricsWriter(opts, "test")
+	require.NoError(t, err)
+	server := &metricsServer{writer: writer}
+
+	body, err := newTestRequest().Marshal()
+	require.NoError(t, err)
+	serveHTTP := func(tenantName string) int {
+		req := httptest.NewRequest(WriteHTTPMethod, WriteURL, bytes.NewReader(body))
+		if tenantName != "" {
+			req.Header.Set(headers.TenantHeader, tenantName)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		return recorder.Code
+	}
+	export := func(tenantName string) codes.Code {
+		ctx := context.Background()
+		if tenantName != "" {
+			ctx = metadata.NewIncomingContext(ctx,
+				metadata.Pairs(headers.TenantHeader, tenantName))
+		}
+		_, err := server.Export(ctx, newTestRequest())
+		return status.Code(err)
+	}
+
+	// Requests without a tenant or with an unknown tenant are rejected.
+	require.Equal(t, http.StatusBadRequest, serveHTTP(""))
+	require.Equal(t, http.StatusBadRequest, serveHTTP("team-b"))
+	require.Equal(t, codes.InvalidArgument, export(""))
+	require.Equal(t, codes.InvalidArgument, export("team-b"))
+
+	ds.EXPECT().
+		WriteBatch(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			iter ingest.DownsampleAndWriteIter,
+			_ ingest.WriteOptions,
+		) ingest.BatchError {
+			require.True(t, iter.Next())
+			assert.Equal(t,
+				"__name__: up, instance: host-1:8080, job: shop/checkout, tenant: team-a",
+				iter.Current().Tags.String())
+			require.False(t, iter.Next())
+			return nil
+		}).
+		Times(2)
+
+	require.Equal(t, http.StatusOK, serveHTTP("team-a"))
+	require.Equal(t, codes.OK, export("team-a"))
+
+	// The third datapoint written exceeds the limit of the tenant.
+	require.Equal(t, http.StatusTooManyRequests, serveHTTP("team-a"))
+	require.Equal(t, codes.ResourceExhausted, export("team-a"))
+}
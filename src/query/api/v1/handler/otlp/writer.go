@@ -0,0 +1,245 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package otlp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/uber-go/tally"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest"
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/tenant"
+	"github.com/m3db/m3/src/query/util/logging"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+)
+
+var (
+	errNoDownsamplerAndWriter = errors.New("no downsampler and writer set")
+	errNoTagOptions           = errors.New("no tag options set")
+)
+
+// metricsWriter converts OTLP metrics and writes them through the
+// downsampler and writer, it is shared by the HTTP and gRPC receivers.
+type metricsWriter struct {
+	downsamplerAndWriter ingest.DownsamplerAndWriter
+	converter            *converter
+	storeMetricsType     bool
+	tenants              tenant.Tenants
+	instrumentOpts       instrument.Options
+	metrics              writeMetrics
+}
+
+type writeMetrics struct {
+	writeSuccess       tally.Counter
+	writeErrorsServer  tally.Counter
+	writeErrorsClient  tally.Counter
+	writeBatchLatency  tally.Histogram
+	datapointsWritten  tally.Counter
+	datapointsDropped  tally.Counter
+	seriesPerWriteSize tally.Histogram
+}
+
+func newWriteMetrics(scope tally.Scope) (writeMetrics, error) {
+	buckets, err := ingest.NewLatencyBuckets()
+	if err != nil {
+		return writeMetrics{}, err
+	}
+	return writeMetrics{
+		writeSuccess:      scope.SubScope("write").Counter("success"),
+		writeErrorsServer: scope.SubScope("write").Tagged(map[string]string{"code": "5XX"}).Counter("errors"),
+		writeErrorsClient: scope.SubScope("write").Tagged(map[string]string{"code": "4XX"}).Counter("errors"),
+		writeBatchLatency: scope.SubScope("write").Histogram("batch-latency", buckets.WriteLatencyBuckets),
+		datapointsWritten: scope.SubScope("datapoints").Counter("written"),
+		datapointsDropped: scope.SubScope("datapoints").Counter("dropped"),
+		seriesPerWriteSize: scope.SubScope("write").Histogram("series",
+			tally.MustMakeExponentialValueBuckets(1, 2, 16)),
+	}, nil
+}
+
+func (m *writeMetrics) incError(err error) {
+	if xhttp.IsClientError(err) {
+		m.writeErrorsClient.Inc(1)
+	} else {
+		m.writeErrorsServer.Inc(1)
+	}
+}
+
+func newMetricsWriter(
+	opts options.HandlerOptions,
+	handler string,
+) (*metricsWriter, error) {
+	var (
+		downsamplerAndWriter = opts.DownsamplerAndWriter()
+		tagOptions           = opts.TagOptions()
+		instrumentOpts       = opts.InstrumentOpts()
+	)
+	if downsamplerAndWriter == nil {
+		return nil, errNoDownsamplerAndWriter
+	}
+	if tagOptions == nil {
+		return nil, errNoTagOptions
+	}
+
+	scope := instrumentOpts.MetricsScope().
+		Tagged(map[string]string{"handler": handler})
+	metrics, err := newWriteMetrics(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	var promoteResourceAttributes []string
+	if cfg := opts.Config(); cfg.OTLP != nil {
+		promoteResourceAttributes = cfg.OTLP.PromoteResourceAttributes
+	}
+
+	var tenants tenant.Tenants
+	if m3dbOpts := opts.M3DBOptions(); m3dbOpts != nil {
+		tenants = m3dbOpts.Tenants()
+	}
+
+	return &metricsWriter{
+		downsamplerAndWriter: downsamplerAndWriter,
+		converter:            newConverter(tagOptions, promoteResourceAttributes),
+		storeMetricsType:     opts.StoreMetricsType(),
+		tenants:              tenants,
+		instrumentOpts:       instrumentOpts,
+		metrics:              metrics,
+	}, nil
+}
+
+// write converts and writes the metrics of a tenant, if any, returning an
+// xhttp error carrying the status code the caller should respond with on
+// failure.
+func (w *metricsWriter) write(
+	ctx context.Context,
+	md pdata.Metrics,
+	t *tenant.Tenant,
+	remoteAddr string,
+) error {
+	stopwatch := w.metrics.writeBatchLatency.Start()
+	defer stopwatch.Stop()
+
+	result := w.converter.convert(md)
+	w.metrics.datapointsDropped.Inc(int64(result.dropped))
+	w.metrics.seriesPerWriteSize.RecordValue(float64(len(result.series)))
+	if len(result.series) == 0 {
+		w.metrics.writeSuccess.Inc(1)
+		return nil
+	}
+
+	if t != nil {
+		if err := t.WriteLimit.Inc(len(result.series), nil); err != nil {
+			err = xhttp.NewError(err, http.StatusTooManyRequests)
+			w.metrics.incError(err)
+			return err
+		}
+
+		// NB: the tenant tag is set after the conversion so that the tenant
+		// of the series written cannot be overridden by the attributes of
+		// the metrics, the namespaces of the tenant are then enforced by the
+		// storage and the downsampler from the tenant tag.
+		tag := models.Tag{Name: w.tenants.TagName(), Value: []byte(t.Name)}
+		for i := range result.series {
+			result.series[i].tags = result.series[i].tags.AddOrUpdateTag(tag)
+		}
+	}
+
+	iter := newSeriesIter(result.series, w.storeMetricsType)
+	batchErr := w.downsamplerAndWriter.WriteBatch(ctx, iter, ingest.WriteOptions{})
+	if batchErr == nil {
+		w.metrics.writeSuccess.Inc(1)
+		w.metrics.datapointsWritten.Inc(int64(len(result.series)))
+		return nil
+	}
+
+	var (
+		errs                 = batchErr.Errors()
+		lastRegularErr       string
+		lastBadRequestErr    string
+		numRegular           int
+		numBadRequest        int
+		numResourceExhausted int
+	)
+	for _, err := range errs {
+		switch {
+		case client.IsResourceExhaustedError(err):
+			numResourceExhausted++
+			lastBadRequestErr = err.Error()
+		case client.IsBadRequestError(err):
+			numBadRequest++
+			lastBadRequestErr = err.Error()
+		case xerrors.IsInvalidParams(err):
+			numBadRequest++
+			lastBadRequestErr = err.Error()
+		default:
+			numRegular++
+			lastRegularErr = err.Error()
+		}
+	}
+
+	var status int
+	switch {
+	case numBadRequest == len(errs):
+		status = http.StatusBadRequest
+	case numResourceExhausted > 0:
+		status = http.StatusTooManyRequests
+	default:
+		status = http.StatusInternalServerError
+	}
+
+	logger := logging.WithContext(ctx, w.instrumentOpts)
+	logger.Error("write error",
+		zap.String("remoteAddr", remoteAddr),
+		zap.Int("httpResponseStatusCode", status),
+		zap.Int("numResourceExhaustedErrors", numResourceExhausted),
+		zap.Int("numRegularErrors", numRegular),
+		zap.Int("numBadRequestErrors", numBadRequest),
+		zap.String("lastRegularError", lastRegularErr),
+		zap.String("lastBadRequestErr", lastBadRequestErr))
+
+	var resultErrMessage string
+	if lastRegularErr != "" {
+		resultErrMessage = fmt.Sprintf("retryable_errors: count=%d, last=%s",
+			numRegular, lastRegularErr)
+	}
+	if lastBadRequestErr != "" {
+		var sep string
+		if lastRegularErr != "" {
+			sep = ", "
+		}
+		resultErrMessage = fmt.Sprintf("%s%sbad_request_errors: count=%d, last=%s",
+			resultErrMessage, sep, numBadRequest+numResourceExhausted, lastBadRequestErr)
+	}
+
+	resultErr := xhttp.NewError(errors.New(resultErrMessage), status)
+	w.metrics.incError(resultErr)
+	return resultErr
+}
@@ -35,6 +35,7 @@ import (
 	"github.com/uber-go/tally"
 	"go.uber.org/zap"
 
+	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/native"
 	"github.com/m3db/m3/src/query/api/v1/options"
@@ -120,6 +121,17 @@ func (h *readHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	params := request.Params
 	fetchOptions := request.FetchOpts
+	if step := params.Step; !h.opts.instant && step > 0 &&
+		params.Start.Truncate(step) == params.Start &&
+		params.End.Truncate(step) == params.End {
+		// NB: the range query is evaluated at multiples of the step so the
+		// datapoints read for its instant vector selectors can be downsampled
+		// to the last datapoint of each step window by the database.
+		fetchOptions.Downsample = &storage.DownsampleOptions{
+			Step: step,
+			Type: encoding.DownsampleLast,
+		}
+	}
 
 	// NB (@shreyas): We put the FetchOptions in context so it can be
 	// retrieved in the queryable object as there is no other way to pass
@@ -26,6 +26,8 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/golang/snappy"
@@ -35,6 +37,7 @@ import (
 	xpromql "github.com/m3db/m3/src/query/parser/promql"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/storage/m3/consolidators"
+	"github.com/m3db/m3/src/query/storage/prommetadata"
 	"github.com/m3db/m3/src/query/ts"
 	"github.com/m3db/m3/src/query/util"
 	"github.com/m3db/m3/src/query/util/json"
@@ -51,6 +54,7 @@ const (
 	formatErrStr = "error parsing param: %s, error: %v"
 
 	filterNameTagsParam = "tag"
+	metricTypeParam     = "metric_type"
 	errFormatStr        = "error parsing param: %s, error: %v"
 	tolerance           = 0.0000001
 )
@@ -291,6 +295,62 @@ func ParseMatch(
 	return matchers, true, nil
 }
 
+// MetricTypeMatch is a parsed metric type filter.
+type MetricTypeMatch struct {
+	// Matcher selects the series of metrics of the metric type.
+	Matcher models.Matcher
+	// None is true if there are no metrics of the metric type, in which case
+	// there are no series to select.
+	None bool
+}
+
+// ParseMetricTypeMatch parses the metric type param from the request and
+// returns a matcher selecting the series of metrics of that type, based on
+// the metadata received with remote write.
+func ParseMetricTypeMatch(
+	r *http.Request,
+	store prommetadata.Store,
+	tagOptions models.TagOptions,
+) (MetricTypeMatch, bool, error) {
+	metricType := r.FormValue(metricTypeParam)
+	if metricType == "" {
+		return MetricTypeMatch{}, false, nil
+	}
+
+	if store == nil {
+		return MetricTypeMatch{}, false, xerrors.NewInvalidParamsError(
+			fmt.Errorf("%s param requires metadata to be enabled", metricTypeParam))
+	}
+
+	var names []string
+	for _, m := range store.Query("") {
+		if m.Type != metricType {
+			continue
+		}
+
+		name := regexp.QuoteMeta(m.Metric)
+		switch m.Type {
+		case prommetadata.TypeHistogram, prommetadata.TypeGaugeHistogram:
+			name += "(_bucket|_sum|_count)?"
+		case prommetadata.TypeSummary:
+			name += "(_sum|_count)?"
+		}
+		names = append(names, name)
+	}
+
+	if len(names) == 0 {
+		return MetricTypeMatch{None: true}, true, nil
+	}
+
+	matcher, err := models.NewMatcher(models.MatchRegexp,
+		tagOptions.MetricName(), []byte(strings.Join(names, "|")))
+	if err != nil {
+		return MetricTypeMatch{}, false, err
+	}
+
+	return MetricTypeMatch{Matcher: matcher}, true, nil
+}
+
 func parseMatch(
 	parseOpts xpromql.ParseOptions,
 	tagOptions models.TagOptions,
@@ -34,9 +34,11 @@ import (
 
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser/promql"
+	"github.com/m3db/m3/src/query/storage/prommetadata"
 	"github.com/m3db/m3/src/query/test"
 	xerrors "github.com/m3db/m3/src/x/errors"
 	xhttp "github.com/m3db/m3/src/x/net/http"
+	xtest "github.com/m3db/m3/src/x/test"
 )
 
 func TestPromCompressedReadSuccess(t *testing.T) {
@@ -256,3 +258,47 @@ func TestParseMatch(t *testing.T) {
 		})
 	}
 }
+
+func TestParseMetricTypeMatch(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	tagOpts := models.NewTagOptions()
+	store := prommetadata.NewMockStore(ctrl)
+	store.EXPECT().Query("").Return([]prommetadata.Metadata{
+		{Metric: "latency", Type: prommetadata.TypeHistogram},
+		{Metric: "requests.total", Type: prommetadata.TypeCounter},
+		{Metric: "up", Type: prommetadata.TypeGauge},
+	}).AnyTimes()
+
+	req := httptest.NewRequest(http.MethodGet, "/?metric_type=counter", nil)
+	match, ok, err := ParseMetricTypeMatch(req, store, tagOpts)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.False(t, match.None)
+	assert.Equal(t, models.MatchRegexp, match.Matcher.Type)
+	assert.Equal(t, "__name__", string(match.Matcher.Name))
+	assert.Equal(t, `requests\.total`, string(match.Matcher.Value))
+
+	req = httptest.NewRequest(http.MethodGet, "/?metric_type=histogram", nil)
+	match, ok, err = ParseMetricTypeMatch(req, store, tagOpts)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, `latency(_bucket|_sum|_count)?`, string(match.Matcher.Value))
+
+	req = httptest.NewRequest(http.MethodGet, "/?metric_type=summary", nil)
+	match, ok, err = ParseMetricTypeMatch(req, store, tagOpts)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.True(t, match.None)
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	_, ok, err = ParseMetricTypeMatch(req, store, tagOpts)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	req = httptest.NewRequest(http.MethodGet, "/?metric_type=counter", nil)
+	_, _, err = ParseMetricTypeMatch(req, nil, tagOpts)
+	require.Error(t, err)
+	assert.True(t, xerrors.IsInvalidParams(err))
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/m3db/m3/src/query/errors"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/storage/m3/storagemetadata"
+	"github.com/m3db/m3/src/query/tenant"
 	"github.com/m3db/m3/src/query/util"
 	xerrors "github.com/m3db/m3/src/x/errors"
 	"github.com/m3db/m3/src/x/headers"
@@ -78,6 +79,9 @@ type FetchOptionsBuilderOptions struct {
 	Limits        FetchOptionsBuilderLimitsOptions
 	RestrictByTag *storage.RestrictByTag
 	Timeout       time.Duration
+	// Tenants restrict the queries of a tenant to the series and namespaces
+	// of the tenant.
+	Tenants tenant.Tenants
 }
 
 // Validate validates the fetch options builder options.
@@ -491,6 +495,26 @@ func (b fetchOptionsBuilder) newFetchOptions(
 		fetchOpts.RestrictQueryOptions.RestrictByTag = defaultTagOpts
 	}
 
+	// NB: the tenant restriction is applied last so that neither the header
+	// nor the default restrict by tags options can override it.
+	t, err := ParseTenant(req, b.opts.Tenants)
+	if err != nil {
+		return nil, nil, err
+	}
+	if t != nil {
+		fetchOpts.RestrictQueryOptions = newOrExistingRestrictQueryOptions(fetchOpts)
+		restrict, err := restrictByTenantTag(
+			fetchOpts.RestrictQueryOptions.RestrictByTag,
+			b.opts.Tenants.TagName(), t.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+		fetchOpts.RestrictQueryOptions.RestrictByTag = restrict
+		fetchOpts.RestrictQueryOptions.RestrictByTenant = &storage.RestrictByTenant{
+			Tenant: t.Name,
+		}
+	}
+
 	if restrict := fetchOpts.RestrictQueryOptions; restrict != nil {
 		if err := restrict.Validate(); err != nil {
 			err = fmt.Errorf(
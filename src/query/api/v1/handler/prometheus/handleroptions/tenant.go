@@ -0,0 +1,101 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package handleroptions
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/tenant"
+	"github.com/m3db/m3/src/x/headers"
+)
+
+var (
+	errTenantsNotConfigured = errors.New("tenant specified but no tenants configured")
+	errTenantRequired       = errors.New("tenant required: set the " +
+		headers.TenantHeader + " header")
+)
+
+// ParseTenant parses the tenant of a request from the tenant header, it
+// returns nil if the request does not specify a tenant and tenants are
+// not required.
+func ParseTenant(req *http.Request, tenants tenant.Tenants) (*tenant.Tenant, error) {
+	return ParseTenantName(req.Header.Get(headers.TenantHeader), tenants)
+}
+
+// ParseTenantName parses the tenant of a request from the name of the
+// tenant, it returns nil if the name is empty and tenants are not required.
+// It is used by the receivers that do not read the tenant from the tenant
+// header of an HTTP request, such as the OTLP gRPC receiver.
+func ParseTenantName(name string, tenants tenant.Tenants) (*tenant.Tenant, error) {
+	if tenants == nil {
+		if name != "" {
+			return nil, errTenantsNotConfigured
+		}
+		return nil, nil
+	}
+
+	if name == "" {
+		if tenants.Required() {
+			return nil, errTenantRequired
+		}
+		return nil, nil
+	}
+
+	t, ok := tenants.Tenant(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown tenant: %s", name)
+	}
+	return t, nil
+}
+
+// restrictByTenantTag returns a copy of the tag restrictions that also
+// restricts the query to the series of a tenant, any existing restriction
+// on the tenant tag is replaced.
+func restrictByTenantTag(
+	existing *storage.RestrictByTag,
+	tagName []byte,
+	tenantName string,
+) (*storage.RestrictByTag, error) {
+	tenantMatcher, err := models.NewMatcher(models.MatchEqual, tagName,
+		[]byte(tenantName))
+	if err != nil {
+		return nil, err
+	}
+
+	restrict := &storage.RestrictByTag{}
+	for _, m := range existing.GetMatchers() {
+		if bytes.Equal(m.Name, tagName) {
+			continue
+		}
+		restrict.Restrict = append(restrict.Restrict, m)
+	}
+	restrict.Restrict = append(restrict.Restrict, tenantMatcher)
+
+	if existing != nil && existing.Strip != nil {
+		restrict.Strip = make([][]byte, len(existing.Strip))
+		copy(restrict.Strip, existing.Strip)
+	}
+	return restrict, nil
+}
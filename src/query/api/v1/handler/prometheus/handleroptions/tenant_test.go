@@ -0,0 +1,150 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package handleroptions
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/tenant"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/headers"
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+func newTestTenants(t *testing.T, required bool) tenant.Tenants {
+	cfg := tenant.Configuration{
+		Required: required,
+		Tenants: []tenant.TenantConfiguration{
+			{Name: "team-a"},
+			{Name: "team-b"},
+		},
+	}
+	tenants, err := cfg.NewTenants(instrument.NewOptions())
+	require.NoError(t, err)
+	t.Cleanup(tenants.Close)
+	return tenants
+}
+
+func TestFetchOptionsWithTenant(t *testing.T) {
+	builder, err := NewFetchOptionsBuilder(FetchOptionsBuilderOptions{
+		RestrictByTag: &storage.RestrictByTag{
+			Restrict: models.Matchers{
+				mustMatcher("tenant", "team-b", models.MatchEqual),
+			},
+		},
+		Timeout: 10 * time.Second,
+		Tenants: newTestTenants(t, false),
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Add(headers.TenantHeader, "team-a")
+	req.Header.Add(headers.RestrictByTagsJSONHeader, stripSpace(`{
+		"match":[
+			{"name":"a", "value":"b", "type":"EQUAL"},
+			{"name":"tenant", "value":"team-b", "type":"EQUAL"}
+		],
+		"strip":["foo"]
+	}`))
+
+	_, opts, err := builder.NewFetchOptions(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, &storage.RestrictQueryOptions{
+		RestrictByTag: &storage.RestrictByTag{
+			Restrict: models.Matchers{
+				mustMatcher("a", "b", models.MatchEqual),
+				mustMatcher("tenant", "team-a", models.MatchEqual),
+			},
+			Strip: toStrip("foo"),
+		},
+		RestrictByTenant: &storage.RestrictByTenant{Tenant: "team-a"},
+	}, opts.RestrictQueryOptions)
+
+	// The default restrict by tags options are left untouched.
+	req = httptest.NewRequest("GET", "/", nil)
+	req.Header.Add(headers.TenantHeader, "team-a")
+
+	_, opts, err = builder.NewFetchOptions(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, &storage.RestrictQueryOptions{
+		RestrictByTag: &storage.RestrictByTag{
+			Restrict: models.Matchers{
+				mustMatcher("tenant", "team-a", models.MatchEqual),
+			},
+		},
+		RestrictByTenant: &storage.RestrictByTenant{Tenant: "team-a"},
+	}, opts.RestrictQueryOptions)
+
+	req = httptest.NewRequest("GET", "/", nil)
+	_, opts, err = builder.NewFetchOptions(context.Background(), req)
+	require.NoError(t, err)
+	require.Nil(t, opts.RestrictQueryOptions.GetRestrictByTenant())
+	require.Equal(t, models.Matchers{
+		mustMatcher("tenant", "team-b", models.MatchEqual),
+	}, opts.RestrictQueryOptions.GetRestrictByTag().GetMatchers())
+}
+
+func TestFetchOptionsWithTenantErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		tenants tenant.Tenants
+		tenant  string
+	}{
+		{
+			name:   "no tenants configured",
+			tenant: "team-a",
+		},
+		{
+			name:    "unknown tenant",
+			tenants: newTestTenants(t, false),
+			tenant:  "team-c",
+		},
+		{
+			name:    "tenant required",
+			tenants: newTestTenants(t, true),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			builder, err := NewFetchOptionsBuilder(FetchOptionsBuilderOptions{
+				Timeout: 10 * time.Second,
+				Tenants: test.tenants,
+			})
+			require.NoError(t, err)
+
+			req := httptest.NewRequest("GET", "/", nil)
+			if test.tenant != "" {
+				req.Header.Add(headers.TenantHeader, test.tenant)
+			}
+
+			_, _, err = builder.NewFetchOptions(context.Background(), req)
+			require.Error(t, err)
+			require.True(t, xerrors.IsInvalidParams(err))
+		})
+	}
+}
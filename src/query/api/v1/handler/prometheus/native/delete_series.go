@@ -0,0 +1,150 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/api/v1/route"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser/promql"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/m3"
+	"github.com/m3db/m3/src/query/tenant"
+	"github.com/m3db/m3/src/query/util/logging"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+const (
+	// DeleteSeriesURL is the url for the delete series endpoint.
+	DeleteSeriesURL = route.DeleteSeriesURL
+)
+
+var errNoClusters = errors.New("no M3DB clusters configured to delete series from")
+
+// DeleteSeriesHTTPMethods are the HTTP methods for the delete series handler.
+var DeleteSeriesHTTPMethods = []string{http.MethodPost, http.MethodPut}
+
+// DeleteSeriesHandler represents a handler for the delete series endpoint, it
+// deletes the datapoints within a time range of the series matching the
+// selectors from all the namespaces of the M3DB clusters, or from the
+// namespaces of the tenant of the request.
+type DeleteSeriesHandler struct {
+	clusters            m3.Clusters
+	tenants             tenant.Tenants
+	tagOptions          models.TagOptions
+	fetchOptionsBuilder handleroptions.FetchOptionsBuilder
+	parseOpts           promql.ParseOptions
+	instrumentOpts      instrument.Options
+}
+
+// NewDeleteSeriesHandler returns a new instance of handler.
+func NewDeleteSeriesHandler(opts options.HandlerOptions) http.Handler {
+	var tenants tenant.Tenants
+	if m3dbOpts := opts.M3DBOptions(); m3dbOpts != nil {
+		tenants = m3dbOpts.Tenants()
+	}
+	return &DeleteSeriesHandler{
+		clusters:            opts.Clusters(),
+		tenants:             tenants,
+		tagOptions:          opts.TagOptions(),
+		fetchOptionsBuilder: opts.FetchOptionsBuilder(),
+		parseOpts:           opts.Engine().Options().ParseOptions(),
+		instrumentOpts:      opts.InstrumentOpts(),
+	}
+}
+
+func (h *DeleteSeriesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, fetchOpts, rErr := h.fetchOptionsBuilder.NewFetchOptions(r.Context(), r)
+	if rErr != nil {
+		xhttp.WriteError(w, rErr)
+		return
+	}
+
+	logger := logging.WithContext(ctx, h.instrumentOpts)
+
+	queries, err := prometheus.ParseSeriesMatchQuery(r, h.parseOpts, h.tagOptions)
+	if err != nil {
+		logger.Error("unable to parse delete series request", zap.Error(err))
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	if h.clusters == nil {
+		xhttp.WriteError(w, errNoClusters)
+		return
+	}
+
+	// NB: the tenant is validated when building the fetch options, so only
+	// the namespaces of the tenant need to be skipped.
+	var t *tenant.Tenant
+	restrict := fetchOpts.RestrictQueryOptions.GetRestrictByTenant()
+	if restrict != nil && h.tenants != nil {
+		t, _ = h.tenants.Tenant(restrict.Tenant)
+	}
+
+	for _, query := range queries {
+		m3query, err := storage.FetchQueryToM3Query(query, fetchOpts)
+		if err != nil {
+			xhttp.WriteError(w, xerrors.NewInvalidParamsError(err))
+			return
+		}
+
+		var (
+			start = xtime.ToUnixNano(query.Start)
+			end   = xtime.ToUnixNano(query.End)
+		)
+		for _, ns := range h.clusters.ClusterNamespaces() {
+			if t != nil && !t.HasNamespace(ns.NamespaceID().String()) {
+				continue
+			}
+
+			deleted, err := ns.Session().DeleteSeries(ctx, ns.NamespaceID(),
+				m3query, start, end)
+			if err != nil {
+				logger.Error("unable to delete series",
+					zap.Stringer("namespace", ns.NamespaceID()),
+					zap.String("query", query.Raw),
+					zap.Error(err))
+				xhttp.WriteError(w, err)
+				return
+			}
+
+			logger.Info("deleted series",
+				zap.Stringer("namespace", ns.NamespaceID()),
+				zap.String("query", query.Raw),
+				zap.Time("start", query.Start),
+				zap.Time("end", query.End),
+				zap.Int64("replicaSeries", deleted))
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
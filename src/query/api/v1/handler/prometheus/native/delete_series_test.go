@@ -0,0 +1,193 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage/m3"
+	"github.com/m3db/m3/src/query/tenant"
+	"github.com/m3db/m3/src/x/headers"
+	"github.com/m3db/m3/src/x/ident"
+	"github.com/m3db/m3/src/x/instrument"
+	xtest "github.com/m3db/m3/src/x/test"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+func newTestDeleteSeriesHandler(t *testing.T, session client.Session) http.Handler {
+	clusters, err := m3.NewClusters(m3.UnaggregatedClusterNamespaceDefinition{
+		NamespaceID: ident.StringID("test-ns"),
+		Session:     session,
+		Retention:   24 * time.Hour,
+	})
+	require.NoError(t, err)
+
+	fb, err := handleroptions.NewFetchOptionsBuilder(
+		handleroptions.FetchOptionsBuilderOptions{Timeout: 15 * time.Second})
+	require.NoError(t, err)
+
+	engine := executor.NewEngine(executor.NewEngineOptions().
+		SetInstrumentOptions(instrument.NewOptions()))
+	return NewDeleteSeriesHandler(options.EmptyHandlerOptions().
+		SetClusters(clusters).
+		SetEngine(engine).
+		SetTagOptions(models.NewTagOptions()).
+		SetFetchOptionsBuilder(fb))
+}
+
+func TestDeleteSeriesHandler(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		session = client.NewMockSession(ctrl)
+		h       = newTestDeleteSeriesHandler(t, session)
+		start   = time.Unix(1000, 0)
+		end     = time.Unix(2000, 0)
+	)
+
+	var queries []string
+	session.EXPECT().
+		DeleteSeries(gomock.Any(), ident.NewIDMatcher("test-ns"), gomock.Any(),
+			xtime.ToUnixNano(start), xtime.ToUnixNano(end)).
+		DoAndReturn(func(
+			_ context.Context,
+			_ ident.ID,
+			q index.Query,
+			_, _ xtime.UnixNano,
+		) (int64, error) {
+			queries = append(queries, q.String())
+			return 1, nil
+		}).
+		Times(2)
+
+	values := url.Values{}
+	values.Add("match[]", `foo{bar="baz"}`)
+	values.Add("match[]", `qux`)
+	values.Set("start", "1000")
+	values.Set("end", "2000")
+
+	req := httptest.NewRequest(http.MethodPost, DeleteSeriesURL+"?"+values.Encode(), nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+	require.Equal(t, []string{
+		"conjunction(term(__name__,foo), term(bar,baz))",
+		"term(__name__,qux)",
+	}, queries)
+}
+
+func TestDeleteSeriesHandlerRequiresMatchers(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	h := newTestDeleteSeriesHandler(t, client.NewMockSession(ctrl))
+
+	req := httptest.NewRequest(http.MethodPost, DeleteSeriesURL, nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestDeleteSeriesHandlerTenant(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	session := client.NewMockSession(ctrl)
+	clusters, err := m3.NewClusters(m3.UnaggregatedClusterNamespaceDefinition{
+		NamespaceID: ident.StringID("test-ns"),
+		Session:     session,
+		Retention:   24 * time.Hour,
+	}, m3.AggregatedClusterNamespaceDefinition{
+		NamespaceID: ident.StringID("agg-ns"),
+		Session:     session,
+		Retention:   48 * time.Hour,
+		Resolution:  time.Minute,
+	})
+	require.NoError(t, err)
+
+	cfg := tenant.Configuration{
+		Tenants: []tenant.TenantConfiguration{
+			{Name: "team-a", Namespaces: []string{"agg-ns"}},
+		},
+	}
+	tenants, err := cfg.NewTenants(instrument.NewOptions())
+	require.NoError(t, err)
+	defer tenants.Close()
+
+	fb, err := handleroptions.NewFetchOptionsBuilder(
+		handleroptions.FetchOptionsBuilderOptions{
+			Timeout: 15 * time.Second,
+			Tenants: tenants,
+		})
+	require.NoError(t, err)
+
+	engine := executor.NewEngine(executor.NewEngineOptions().
+		SetInstrumentOptions(instrument.NewOptions()))
+	h := NewDeleteSeriesHandler(options.EmptyHandlerOptions().
+		SetClusters(clusters).
+		SetEngine(engine).
+		SetTagOptions(models.NewTagOptions()).
+		SetFetchOptionsBuilder(fb).
+		SetM3DBOptions(m3.NewOptions(encoding.NewOptions()).SetTenants(tenants)))
+
+	// Only the series of the tenant are deleted from the namespaces of the
+	// tenant.
+	session.EXPECT().
+		DeleteSeries(gomock.Any(), ident.NewIDMatcher("agg-ns"), gomock.Any(),
+			gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			_ ident.ID,
+			q index.Query,
+			_, _ xtime.UnixNano,
+		) (int64, error) {
+			require.Equal(t,
+				"conjunction(term(__name__,foo), term(tenant,team-a))", q.String())
+			return 1, nil
+		})
+
+	values := url.Values{}
+	values.Add("match[]", `foo`)
+	values.Set("start", "1000")
+	values.Set("end", "2000")
+
+	req := httptest.NewRequest(http.MethodPost, DeleteSeriesURL+"?"+values.Encode(), nil)
+	req.Header.Set(headers.TenantHeader, "team-a")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+}
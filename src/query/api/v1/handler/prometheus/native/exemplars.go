@@ -0,0 +1,231 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/prometheus/prometheus/promql/parser"
+	"go.uber.org/zap"
+
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/api/v1/route"
+	"github.com/m3db/m3/src/query/errors"
+	"github.com/m3db/m3/src/query/functions/utils"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser/promql"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/exemplar"
+	"github.com/m3db/m3/src/query/util/json"
+	"github.com/m3db/m3/src/query/util/logging"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+)
+
+const (
+	// QueryExemplarsURL is the url for querying exemplars.
+	QueryExemplarsURL = route.QueryExemplarsURL
+
+	queryExemplarsParam = "query"
+)
+
+// QueryExemplarsHTTPMethods are the HTTP methods for this handler.
+var QueryExemplarsHTTPMethods = []string{http.MethodGet, http.MethodPost}
+
+// QueryExemplarsHandler represents a handler for the query exemplars
+// endpoint, it returns the exemplars of the series selected by a PromQL
+// expression.
+type QueryExemplarsHandler struct {
+	exemplarStore       exemplar.Store
+	fetchOptionsBuilder handleroptions.FetchOptionsBuilder
+	parseOpts           promql.ParseOptions
+	instrumentOpts      instrument.Options
+	tagOpts             models.TagOptions
+}
+
+// NewQueryExemplarsHandler returns a new instance of handler.
+func NewQueryExemplarsHandler(opts options.HandlerOptions) http.Handler {
+	return &QueryExemplarsHandler{
+		exemplarStore:       opts.ExemplarStore(),
+		fetchOptionsBuilder: opts.FetchOptionsBuilder(),
+		parseOpts:           promql.NewParseOptions().SetNowFn(opts.NowFn()),
+		instrumentOpts:      opts.InstrumentOpts(),
+		tagOpts:             opts.TagOptions(),
+	}
+}
+
+func (h *QueryExemplarsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set(xhttp.HeaderContentType, xhttp.ContentTypeJSON)
+
+	ctx, opts, rErr := h.fetchOptionsBuilder.NewFetchOptions(r.Context(), r)
+	if rErr != nil {
+		xhttp.WriteError(w, rErr)
+		return
+	}
+
+	start, end, err := prometheus.ParseStartAndEnd(r, h.parseOpts)
+	if err != nil {
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	selectors, err := h.parseSelectors(r.FormValue(queryExemplarsParam))
+	if err != nil {
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	// Exemplar storage is optional, like Prometheus respond with no results
+	// if it is not enabled.
+	var results []exemplar.SeriesExemplars
+	if h.exemplarStore != nil {
+		logger := logging.WithContext(ctx, h.instrumentOpts)
+		for _, matchers := range selectors {
+			query := &storage.FetchQuery{
+				Raw:         r.FormValue(queryExemplarsParam),
+				TagMatchers: matchers,
+				Start:       start,
+				End:         end,
+			}
+
+			result, err := h.exemplarStore.Query(ctx, query, opts)
+			if err != nil {
+				logger.Error("unable to query exemplars", zap.Error(err))
+				if errors.IsTimeout(err) {
+					err = errors.NewErrQueryTimeout(err)
+				}
+				xhttp.WriteError(w, err)
+				return
+			}
+
+			results = append(results, result...)
+		}
+	}
+
+	if err := renderQueryExemplarsResultsJSON(w, dedupeSeriesExemplars(results)); err != nil {
+		logger := logging.WithContext(ctx, h.instrumentOpts)
+		logger.Error("unable to render exemplars", zap.Error(err))
+	}
+}
+
+// parseSelectors parses the query and returns the matchers of each series
+// selector in it.
+func (h *QueryExemplarsHandler) parseSelectors(query string) ([]models.Matchers, error) {
+	if query == "" {
+		return nil, xerrors.NewInvalidParamsError(
+			fmt.Errorf("missing %s param", queryExemplarsParam))
+	}
+
+	expr, err := parser.ParseExpr(query)
+	if err != nil {
+		return nil, xerrors.NewInvalidParamsError(err)
+	}
+
+	promSelectors := parser.ExtractSelectors(expr)
+	selectors := make([]models.Matchers, 0, len(promSelectors))
+	for _, promMatchers := range promSelectors {
+		matchers, err := promql.LabelMatchersToModelMatcher(promMatchers, h.tagOpts)
+		if err != nil {
+			return nil, xerrors.NewInvalidParamsError(err)
+		}
+		selectors = append(selectors, matchers)
+	}
+
+	return selectors, nil
+}
+
+// dedupeSeriesExemplars drops series selected by more than one selector.
+func dedupeSeriesExemplars(results []exemplar.SeriesExemplars) []exemplar.SeriesExemplars {
+	var (
+		seen    = make(map[string]struct{}, len(results))
+		deduped = make([]exemplar.SeriesExemplars, 0, len(results))
+	)
+	for _, result := range results {
+		id := string(result.Tags.ID())
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		deduped = append(deduped, result)
+	}
+
+	sort.Slice(deduped, func(i, j int) bool {
+		return string(deduped[i].Tags.ID()) < string(deduped[j].Tags.ID())
+	})
+	return deduped
+}
+
+func renderQueryExemplarsResultsJSON(
+	w io.Writer,
+	results []exemplar.SeriesExemplars,
+) error {
+	jw := json.NewWriter(w)
+	jw.BeginObject()
+
+	jw.BeginObjectField("status")
+	jw.WriteString("success")
+
+	jw.BeginObjectField("data")
+	jw.BeginArray()
+
+	for _, series := range results {
+		jw.BeginObject()
+		jw.BeginObjectField("seriesLabels")
+		renderTagsJSON(jw, series.Tags)
+
+		jw.BeginObjectField("exemplars")
+		jw.BeginArray()
+		for _, e := range series.Exemplars {
+			jw.BeginObject()
+			jw.BeginObjectField("labels")
+			renderTagsJSON(jw, e.Labels)
+			jw.BeginObjectField("value")
+			jw.WriteString(utils.FormatFloat(e.Value))
+			jw.BeginObjectField("timestamp")
+			jw.WriteFloat64(float64(e.Timestamp.ToNormalizedTime(time.Millisecond)) / 1000)
+			jw.EndObject()
+		}
+		jw.EndArray()
+
+		jw.EndObject()
+	}
+
+	jw.EndArray()
+	jw.EndObject()
+
+	return jw.Close()
+}
+
+func renderTagsJSON(jw json.Writer, tags models.Tags) {
+	jw.BeginObject()
+	for _, t := range tags.Tags {
+		jw.BeginObjectBytesField(t.Name)
+		jw.WriteBytesString(t.Value)
+	}
+	jw.EndObject()
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/exemplar"
+	xtest "github.com/m3db/m3/src/x/test"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+func newTestQueryExemplarsHandler(t *testing.T, store exemplar.Store) http.Handler {
+	fb, err := handleroptions.NewFetchOptionsBuilder(
+		handleroptions.FetchOptionsBuilderOptions{Timeout: 15 * time.Second})
+	require.NoError(t, err)
+
+	opts := options.EmptyHandlerOptions().
+		SetFetchOptionsBuilder(fb).
+		SetTagOptions(models.NewTagOptions()).
+		SetNowFn(time.Now).
+		SetExemplarStore(store)
+	return NewQueryExemplarsHandler(opts)
+}
+
+func TestQueryExemplars(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		tagOpts = models.NewTagOptions()
+		series  = models.NewTags(2, tagOpts).
+			AddTag(models.Tag{Name: b("__name__"), Value: b("requests_total")}).
+			AddTag(models.Tag{Name: b("job"), Value: b("api")})
+		result = []exemplar.SeriesExemplars{{
+			Tags: series,
+			Exemplars: []exemplar.Exemplar{{
+				Labels: models.NewTags(1, tagOpts).
+					AddTag(models.Tag{Name: b("trace_id"), Value: b("abc")}),
+				Value:     1.5,
+				Timestamp: xtime.UnixNano(1600096945479 * int64(time.Millisecond)),
+			}},
+		}}
+		queried []*storage.FetchQuery
+	)
+
+	store := exemplar.NewMockStore(ctrl)
+	store.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ interface{},
+			query *storage.FetchQuery,
+			_ *storage.FetchOptions,
+		) ([]exemplar.SeriesExemplars, error) {
+			queried = append(queried, query)
+			return result, nil
+		}).
+		Times(2)
+
+	h := newTestQueryExemplarsHandler(t, store)
+
+	// Both selectors return the same series, which is only returned once.
+	params := url.Values{
+		"query": []string{`rate(requests_total[5m]) / rate(requests_total{job="api"}[5m])`},
+		"start": []string{"1600096000"},
+		"end":   []string{"1600097000"},
+	}
+	req := httptest.NewRequest(http.MethodGet, QueryExemplarsURL+"?"+params.Encode(), nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	body, err := ioutil.ReadAll(w.Result().Body)
+	require.NoError(t, err)
+
+	expected := `{"status":"success","data":[{` +
+		`"seriesLabels":{"__name__":"requests_total","job":"api"},` +
+		`"exemplars":[{"labels":{"trace_id":"abc"},"value":"1.5","timestamp":1600096945.479000}]` +
+		`}]}`
+	assert.Equal(t, expected, string(body))
+
+	require.Len(t, queried, 2)
+	assert.Equal(t, time.Unix(1600096000, 0), queried[0].Start)
+	assert.Equal(t, time.Unix(1600097000, 0), queried[0].End)
+	assert.Len(t, queried[0].TagMatchers, 1)
+	assert.Len(t, queried[1].TagMatchers, 2)
+}
+
+func TestQueryExemplarsNoStore(t *testing.T) {
+	h := newTestQueryExemplarsHandler(t, nil)
+
+	req := httptest.NewRequest(http.MethodGet,
+		QueryExemplarsURL+"?query=requests_total", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"status":"success","data":[]}`, w.Body.String())
+}
+
+func TestQueryExemplarsInvalidQuery(t *testing.T) {
+	h := newTestQueryExemplarsHandler(t, nil)
+
+	for _, query := range []string{"", "sum("} {
+		req := httptest.NewRequest(http.MethodGet,
+			QueryExemplarsURL+"?"+url.Values{"query": []string{query}}.Encode(), nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, "query %q", query)
+	}
+}
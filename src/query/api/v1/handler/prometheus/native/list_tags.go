@@ -35,6 +35,8 @@ import (
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser/promql"
 	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/m3/consolidators"
+	"github.com/m3db/m3/src/query/storage/prommetadata"
 	"github.com/m3db/m3/src/query/util/logging"
 	xerrors "github.com/m3db/m3/src/x/errors"
 	"github.com/m3db/m3/src/x/instrument"
@@ -53,6 +55,7 @@ var ListTagsHTTPMethods = []string{http.MethodGet, http.MethodPost}
 // ListTagsHandler represents a handler for list tags endpoint.
 type ListTagsHandler struct {
 	storage             storage.Storage
+	metadataStore       prommetadata.Store
 	fetchOptionsBuilder handleroptions.FetchOptionsBuilder
 	parseOpts           promql.ParseOptions
 	instrumentOpts      instrument.Options
@@ -63,6 +66,7 @@ type ListTagsHandler struct {
 func NewListTagsHandler(opts options.HandlerOptions) http.Handler {
 	return &ListTagsHandler{
 		storage:             opts.Storage(),
+		metadataStore:       opts.MetadataStore(),
 		fetchOptionsBuilder: opts.FetchOptionsBuilder(),
 		parseOpts: promql.NewParseOptions().
 			SetRequireStartEndTime(opts.Config().Query.RequireLabelsEndpointStartEndTime).
@@ -88,13 +92,13 @@ func (h *ListTagsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	tagMatchers := models.Matchers{{Type: models.MatchAll}}
-	reqTagMatchers, ok, err := prometheus.ParseMatch(r, h.parseOpts, h.tagOpts)
+	reqTagMatchers, reqTagMatchersSet, err := prometheus.ParseMatch(r, h.parseOpts, h.tagOpts)
 	if err != nil {
 		err = xerrors.NewInvalidParamsError(err)
 		xhttp.WriteError(w, err)
 		return
 	}
-	if ok {
+	if reqTagMatchersSet {
 		if n := len(reqTagMatchers); n != 1 {
 			err = xerrors.NewInvalidParamsError(fmt.Errorf(
 				"only single tag matcher allowed: actual=%d", n))
@@ -104,6 +108,19 @@ func (h *ListTagsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		tagMatchers = reqTagMatchers[0].Matchers
 	}
 
+	metricTypeMatch, ok, err := prometheus.ParseMetricTypeMatch(r,
+		h.metadataStore, h.tagOpts)
+	if err != nil {
+		xhttp.WriteError(w, err)
+		return
+	}
+	if ok {
+		if !reqTagMatchersSet {
+			tagMatchers = nil
+		}
+		tagMatchers = append(tagMatchers, metricTypeMatch.Matcher)
+	}
+
 	query := &storage.CompleteTagsQuery{
 		CompleteNameOnly: true,
 		TagMatchers:      tagMatchers,
@@ -113,14 +130,18 @@ func (h *ListTagsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	logger := logging.WithContext(ctx, h.instrumentOpts)
 
-	result, err := h.storage.CompleteTags(ctx, query, opts)
-	if err != nil {
-		logger.Error("unable to complete tags", zap.Error(err))
-		if errors.IsTimeout(err) {
-			err = errors.NewErrQueryTimeout(err)
+	// There are no labels to return if no metrics have the metric type.
+	result := &consolidators.CompleteTagsResult{CompleteNameOnly: true}
+	if !metricTypeMatch.None {
+		result, err = h.storage.CompleteTags(ctx, query, opts)
+		if err != nil {
+			logger.Error("unable to complete tags", zap.Error(err))
+			if errors.IsTimeout(err) {
+				err = errors.NewErrQueryTimeout(err)
+			}
+			xhttp.WriteError(w, err)
+			return
 		}
-		xhttp.WriteError(w, err)
-		return
 	}
 
 	err = handleroptions.AddDBResultResponseHeaders(w, result.Metadata, opts)
@@ -39,6 +39,7 @@ import (
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/storage/m3/consolidators"
+	"github.com/m3db/m3/src/query/storage/prommetadata"
 	"github.com/m3db/m3/src/x/headers"
 	xtest "github.com/m3db/m3/src/x/test"
 	xtime "github.com/m3db/m3/src/x/time"
@@ -242,3 +243,53 @@ func TestListTagsUseRequestContext(t *testing.T) {
 	assert.Equal(t, 499, w.Code, "Status code not 499")
 	assert.Contains(t, w.Body.String(), "context canceled")
 }
+
+func TestListTagsMetricType(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	metadataStore := prommetadata.NewMockStore(ctrl)
+	metadataStore.EXPECT().Query("").Return([]prommetadata.Metadata{
+		{Metric: "requests_total", Type: prommetadata.TypeCounter},
+	}).AnyTimes()
+
+	now := xtime.Now()
+	fb, err := handleroptions.NewFetchOptionsBuilder(
+		handleroptions.FetchOptionsBuilderOptions{Timeout: 15 * time.Second})
+	require.NoError(t, err)
+	store := storage.NewMockStorage(ctrl)
+	opts := options.EmptyHandlerOptions().
+		SetStorage(store).
+		SetMetadataStore(metadataStore).
+		SetFetchOptionsBuilder(fb).
+		SetTagOptions(models.NewTagOptions()).
+		SetNowFn(now.ToTime)
+	h := NewListTagsHandler(opts)
+
+	store.EXPECT().CompleteTags(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			query *storage.CompleteTagsQuery,
+			_ *storage.FetchOptions,
+		) (*consolidators.CompleteTagsResult, error) {
+			require.Len(t, query.TagMatchers, 1)
+			assert.Equal(t, `__name__=~"requests_total"`, query.TagMatchers[0].String())
+			return &consolidators.CompleteTagsResult{
+				CompleteNameOnly: true,
+				CompletedTags:    []consolidators.CompletedTag{{Name: b("job")}},
+			}, nil
+		})
+
+	req := httptest.NewRequest(http.MethodGet, "/labels?metric_type=counter", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"status":"success","data":["job"]}`, w.Body.String())
+
+	// No metrics are gauges so storage is not queried.
+	req = httptest.NewRequest(http.MethodGet, "/labels?metric_type=gauge", nil)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"status":"success","data":[]}`, w.Body.String())
+}
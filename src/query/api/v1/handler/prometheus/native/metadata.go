@@ -0,0 +1,288 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/prometheus/prometheus/promql/parser"
+	"go.uber.org/zap"
+
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/api/v1/route"
+	"github.com/m3db/m3/src/query/storage/prommetadata"
+	"github.com/m3db/m3/src/query/tenant"
+	"github.com/m3db/m3/src/query/util/json"
+	"github.com/m3db/m3/src/query/util/logging"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+)
+
+const (
+	// MetadataURL is the url for the metric metadata endpoint.
+	MetadataURL = route.MetadataURL
+
+	// TargetsMetadataURL is the url for the targets metadata endpoint.
+	TargetsMetadataURL = route.TargetsMetadataURL
+
+	metadataMetricParam         = "metric"
+	metadataLimitParam          = "limit"
+	metadataLimitPerMetricParam = "limit_per_metric"
+	metadataMatchTargetParam    = "match_target"
+)
+
+// MetadataHTTPMethods are the HTTP methods for the metadata handlers.
+var MetadataHTTPMethods = []string{http.MethodGet}
+
+var errMetadataTenant = errors.New("metric metadata is not available to tenants")
+
+// MetadataHandler represents a handler for the metric metadata endpoint, it
+// returns the type, help and unit of metrics received with remote write.
+// The metadata is shared by all tenants so it is not served to tenants.
+type MetadataHandler struct {
+	metadataStore  prommetadata.Store
+	tenants        tenant.Tenants
+	instrumentOpts instrument.Options
+}
+
+// NewMetadataHandler returns a new instance of handler.
+func NewMetadataHandler(opts options.HandlerOptions) http.Handler {
+	return &MetadataHandler{
+		metadataStore:  opts.MetadataStore(),
+		tenants:        metadataTenants(opts),
+		instrumentOpts: opts.InstrumentOpts(),
+	}
+}
+
+func (h *MetadataHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set(xhttp.HeaderContentType, xhttp.ContentTypeJSON)
+
+	if err := checkMetadataTenant(r, h.tenants); err != nil {
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	limit, err := parseMetadataLimit(r, metadataLimitParam)
+	if err != nil {
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	limitPerMetric, err := parseMetadataLimit(r, metadataLimitPerMetricParam)
+	if err != nil {
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	results := queryMetadata(h.metadataStore, r.FormValue(metadataMetricParam))
+	if limit >= 0 && len(results) > limit {
+		results = results[:limit]
+	}
+
+	// Only a single entry is kept per metric, so a limit per metric of zero
+	// is the only limit that drops entries.
+	if limitPerMetric == 0 {
+		results = nil
+	}
+
+	if err := renderMetadataResultsJSON(w, results); err != nil {
+		logger := logging.WithContext(r.Context(), h.instrumentOpts)
+		logger.Error("unable to render metadata", zap.Error(err))
+	}
+}
+
+// TargetsMetadataHandler represents a handler for the targets metadata
+// endpoint. Metrics are not associated with scrape targets once written to
+// M3, so the metadata of each metric is returned with an empty target.
+type TargetsMetadataHandler struct {
+	metadataStore  prommetadata.Store
+	tenants        tenant.Tenants
+	instrumentOpts instrument.Options
+}
+
+// NewTargetsMetadataHandler returns a new instance of handler.
+func NewTargetsMetadataHandler(opts options.HandlerOptions) http.Handler {
+	return &TargetsMetadataHandler{
+		metadataStore:  opts.MetadataStore(),
+		tenants:        metadataTenants(opts),
+		instrumentOpts: opts.InstrumentOpts(),
+	}
+}
+
+func (h *TargetsMetadataHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set(xhttp.HeaderContentType, xhttp.ContentTypeJSON)
+
+	if err := checkMetadataTenant(r, h.tenants); err != nil {
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	limit, err := parseMetadataLimit(r, metadataLimitParam)
+	if err != nil {
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	matchesTarget := true
+	if matchTarget := r.FormValue(metadataMatchTargetParam); matchTarget != "" {
+		matchers, err := parser.ParseMetricSelector(matchTarget)
+		if err != nil {
+			xhttp.WriteError(w, xerrors.NewInvalidParamsError(err))
+			return
+		}
+
+		// The target of every metric is an empty label set.
+		for _, m := range matchers {
+			if !m.Matches("") {
+				matchesTarget = false
+				break
+			}
+		}
+	}
+
+	var results []prommetadata.Metadata
+	if matchesTarget {
+		results = queryMetadata(h.metadataStore, r.FormValue(metadataMetricParam))
+	}
+	if limit >= 0 && len(results) > limit {
+		results = results[:limit]
+	}
+
+	if err := renderTargetsMetadataResultsJSON(w, results); err != nil {
+		logger := logging.WithContext(r.Context(), h.instrumentOpts)
+		logger.Error("unable to render targets metadata", zap.Error(err))
+	}
+}
+
+func metadataTenants(opts options.HandlerOptions) tenant.Tenants {
+	if m3dbOpts := opts.M3DBOptions(); m3dbOpts != nil {
+		return m3dbOpts.Tenants()
+	}
+	return nil
+}
+
+// checkMetadataTenant returns an invalid params error for the requests of a
+// tenant, the metadata store is keyed by metric name only and is shared by
+// all tenants so it cannot be restricted to the metrics of a tenant.
+func checkMetadataTenant(r *http.Request, tenants tenant.Tenants) error {
+	t, err := handleroptions.ParseTenant(r, tenants)
+	if err != nil {
+		return xerrors.NewInvalidParamsError(err)
+	}
+	if t != nil {
+		return xerrors.NewInvalidParamsError(errMetadataTenant)
+	}
+	return nil
+}
+
+// queryMetadata returns the metadata of metrics, metadata storage is optional
+// and like Prometheus there are no results if it is not enabled.
+func queryMetadata(store prommetadata.Store, metric string) []prommetadata.Metadata {
+	if store == nil {
+		return nil
+	}
+	return store.Query(metric)
+}
+
+// parseMetadataLimit parses a limit param, a negative limit or no limit
+// returns all results.
+func parseMetadataLimit(r *http.Request, param string) (int, error) {
+	str := r.FormValue(param)
+	if str == "" {
+		return -1, nil
+	}
+
+	limit, err := strconv.Atoi(str)
+	if err != nil {
+		return 0, xerrors.NewInvalidParamsError(
+			fmt.Errorf("invalid %s param: %w", param, err))
+	}
+	return limit, nil
+}
+
+func renderMetadataResultsJSON(
+	w io.Writer,
+	results []prommetadata.Metadata,
+) error {
+	jw := json.NewWriter(w)
+	jw.BeginObject()
+
+	jw.BeginObjectField("status")
+	jw.WriteString("success")
+
+	jw.BeginObjectField("data")
+	jw.BeginObject()
+	for _, m := range results {
+		jw.BeginObjectField(m.Metric)
+		jw.BeginArray()
+		jw.BeginObject()
+		renderMetadataFieldsJSON(jw, m)
+		jw.EndObject()
+		jw.EndArray()
+	}
+	jw.EndObject()
+
+	jw.EndObject()
+	return jw.Close()
+}
+
+func renderTargetsMetadataResultsJSON(
+	w io.Writer,
+	results []prommetadata.Metadata,
+) error {
+	jw := json.NewWriter(w)
+	jw.BeginObject()
+
+	jw.BeginObjectField("status")
+	jw.WriteString("success")
+
+	jw.BeginObjectField("data")
+	jw.BeginArray()
+	for _, m := range results {
+		jw.BeginObject()
+		jw.BeginObjectField("target")
+		jw.BeginObject()
+		jw.EndObject()
+		jw.BeginObjectField("metric")
+		jw.WriteString(m.Metric)
+		renderMetadataFieldsJSON(jw, m)
+		jw.EndObject()
+	}
+	jw.EndArray()
+
+	jw.EndObject()
+	return jw.Close()
+}
+
+func renderMetadataFieldsJSON(jw json.Writer, m prommetadata.Metadata) {
+	jw.BeginObjectField("type")
+	jw.WriteString(m.Type)
+	jw.BeginObjectField("help")
+	jw.WriteString(m.Help)
+	jw.BeginObjectField("unit")
+	jw.WriteString(m.Unit)
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/storage/m3"
+	"github.com/m3db/m3/src/query/storage/prommetadata"
+	"github.com/m3db/m3/src/query/tenant"
+	"github.com/m3db/m3/src/x/headers"
+	"github.com/m3db/m3/src/x/instrument"
+	xtest "github.com/m3db/m3/src/x/test"
+)
+
+func newTestMetadataStore(t *testing.T) prommetadata.Store {
+	ctrl := xtest.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	all := []prommetadata.Metadata{
+		{Metric: "latency", Type: prommetadata.TypeHistogram, Unit: "seconds"},
+		{Metric: "requests_total", Type: prommetadata.TypeCounter, Help: "Total requests."},
+	}
+	store := prommetadata.NewMockStore(ctrl)
+	store.EXPECT().Query("").Return(all).AnyTimes()
+	store.EXPECT().Query("latency").Return(all[:1]).AnyTimes()
+	return store
+}
+
+func serveMetadataRequest(t *testing.T, h http.Handler, target string) (int, string) {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w.Code, w.Body.String()
+}
+
+func TestMetadata(t *testing.T) {
+	opts := options.EmptyHandlerOptions().SetMetadataStore(newTestMetadataStore(t))
+	h := NewMetadataHandler(opts)
+
+	code, body := serveMetadataRequest(t, h, MetadataURL)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, `{"status":"success","data":{`+
+		`"latency":[{"type":"histogram","help":"","unit":"seconds"}],`+
+		`"requests_total":[{"type":"counter","help":"Total requests.","unit":""}]}}`, body)
+
+	code, body = serveMetadataRequest(t, h, MetadataURL+"?metric=latency")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, `{"status":"success","data":{`+
+		`"latency":[{"type":"histogram","help":"","unit":"seconds"}]}}`, body)
+
+	code, body = serveMetadataRequest(t, h, MetadataURL+"?limit=1")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, `{"status":"success","data":{`+
+		`"latency":[{"type":"histogram","help":"","unit":"seconds"}]}}`, body)
+
+	code, body = serveMetadataRequest(t, h, MetadataURL+"?limit_per_metric=0")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, `{"status":"success","data":{}}`, body)
+
+	code, _ = serveMetadataRequest(t, h, MetadataURL+"?limit=abc")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestMetadataNoStore(t *testing.T) {
+	h := NewMetadataHandler(options.EmptyHandlerOptions())
+
+	code, body := serveMetadataRequest(t, h, MetadataURL)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, `{"status":"success","data":{}}`, body)
+}
+
+func TestTargetsMetadata(t *testing.T) {
+	opts := options.EmptyHandlerOptions().SetMetadataStore(newTestMetadataStore(t))
+	h := NewTargetsMetadataHandler(opts)
+
+	code, body := serveMetadataRequest(t, h, TargetsMetadataURL+"?metric=latency")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, `{"status":"success","data":[`+
+		`{"target":{},"metric":"latency","type":"histogram","help":"","unit":"seconds"}]}`, body)
+
+	code, body = serveMetadataRequest(t, h, TargetsMetadataURL+`?match_target={job="api"}`)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, `{"status":"success","data":[]}`, body)
+
+	code, _ = serveMetadataRequest(t, h, TargetsMetadataURL+`?match_target={job=`)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestMetadataTenant(t *testing.T) {
+	cfg := tenant.Configuration{
+		Tenants: []tenant.TenantConfiguration{{Name: "team-a"}},
+	}
+	tenants, err := cfg.NewTenants(instrument.NewOptions())
+	require.NoError(t, err)
+	defer tenants.Close()
+
+	opts := options.EmptyHandlerOptions().
+		SetMetadataStore(newTestMetadataStore(t)).
+		SetM3DBOptions(m3.NewOptions(encoding.NewOptions()).SetTenants(tenants))
+
+	// The metadata is shared by all tenants so it is not served to tenants.
+	for _, test := range []struct {
+		handler http.Handler
+		target  string
+	}{
+		{handler: NewMetadataHandler(opts), target: MetadataURL},
+		{handler: NewTargetsMetadataHandler(opts), target: TargetsMetadataURL},
+	} {
+		req := httptest.NewRequest(http.MethodGet, test.target, nil)
+		req.Header.Set(headers.TenantHeader, "team-a")
+		w := httptest.NewRecorder()
+		test.handler.ServeHTTP(w, req)
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+		code, _ := serveMetadataRequest(t, test.handler, test.target)
+		require.Equal(t, http.StatusOK, code)
+	}
+}
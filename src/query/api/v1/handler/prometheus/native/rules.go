@@ -0,0 +1,258 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/rules"
+
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/api/v1/route"
+	"github.com/m3db/m3/src/query/ruler"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+)
+
+const (
+	// RulesURL is the url for the rules endpoint.
+	RulesURL = route.RulesURL
+
+	// AlertsURL is the url for the alerts endpoint.
+	AlertsURL = route.AlertsURL
+
+	rulesTypeParam     = "type"
+	rulesTypeAlert     = "alert"
+	rulesTypeRecord    = "record"
+	ruleTypeAlerting   = "alerting"
+	ruleTypeRecording  = "recording"
+	rulesStatusSuccess = "success"
+)
+
+// RulesHTTPMethods are the HTTP methods for the rules and alerts handlers.
+var RulesHTTPMethods = []string{http.MethodGet}
+
+type rulesResponse struct {
+	Status string    `json:"status"`
+	Data   rulesData `json:"data"`
+}
+
+type rulesData struct {
+	Groups []ruleGroup `json:"groups"`
+}
+
+type ruleGroup struct {
+	Name           string        `json:"name"`
+	File           string        `json:"file"`
+	Rules          []interface{} `json:"rules"`
+	Interval       float64       `json:"interval"`
+	Limit          int           `json:"limit"`
+	EvaluationTime float64       `json:"evaluationTime"`
+	LastEvaluation time.Time     `json:"lastEvaluation"`
+}
+
+type alertingRule struct {
+	State          string           `json:"state"`
+	Name           string           `json:"name"`
+	Query          string           `json:"query"`
+	Duration       float64          `json:"duration"`
+	Labels         labels.Labels    `json:"labels"`
+	Annotations    labels.Labels    `json:"annotations"`
+	Alerts         []alert          `json:"alerts"`
+	Health         rules.RuleHealth `json:"health"`
+	LastError      string           `json:"lastError,omitempty"`
+	EvaluationTime float64          `json:"evaluationTime"`
+	LastEvaluation time.Time        `json:"lastEvaluation"`
+	Type           string           `json:"type"`
+}
+
+type recordingRule struct {
+	Name           string           `json:"name"`
+	Query          string           `json:"query"`
+	Labels         labels.Labels    `json:"labels,omitempty"`
+	Health         rules.RuleHealth `json:"health"`
+	LastError      string           `json:"lastError,omitempty"`
+	EvaluationTime float64          `json:"evaluationTime"`
+	LastEvaluation time.Time        `json:"lastEvaluation"`
+	Type           string           `json:"type"`
+}
+
+type alertsResponse struct {
+	Status string     `json:"status"`
+	Data   alertsData `json:"data"`
+}
+
+type alertsData struct {
+	Alerts []alert `json:"alerts"`
+}
+
+type alert struct {
+	Labels      labels.Labels `json:"labels"`
+	Annotations labels.Labels `json:"annotations"`
+	State       string        `json:"state"`
+	ActiveAt    *time.Time    `json:"activeAt,omitempty"`
+	Value       string        `json:"value"`
+}
+
+// RulesHandler represents a handler for the rules endpoint, it returns the
+// status of the recording and alerting rules evaluated by the coordinator.
+type RulesHandler struct {
+	ruler          ruler.Ruler
+	instrumentOpts instrument.Options
+}
+
+// NewRulesHandler returns a new instance of handler.
+func NewRulesHandler(opts options.HandlerOptions) http.Handler {
+	return &RulesHandler{
+		ruler:          opts.Ruler(),
+		instrumentOpts: opts.InstrumentOpts(),
+	}
+}
+
+func (h *RulesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	typ := r.FormValue(rulesTypeParam)
+	if typ != "" && typ != rulesTypeAlert && typ != rulesTypeRecord {
+		xhttp.WriteError(w, xerrors.NewInvalidParamsError(
+			fmt.Errorf("invalid %s param: %s", rulesTypeParam, typ)))
+		return
+	}
+
+	// Rule evaluation is optional, there are no rule groups if it is not
+	// enabled.
+	groups := []ruleGroup{}
+	if h.ruler != nil {
+		for _, g := range h.ruler.RuleGroups() {
+			groups = append(groups, newRuleGroup(g, typ))
+		}
+	}
+
+	xhttp.WriteJSONResponse(w, rulesResponse{
+		Status: rulesStatusSuccess,
+		Data:   rulesData{Groups: groups},
+	}, h.instrumentOpts.Logger())
+}
+
+func newRuleGroup(g *rules.Group, typ string) ruleGroup {
+	group := ruleGroup{
+		Name:           g.Name(),
+		File:           g.File(),
+		Rules:          []interface{}{},
+		Interval:       g.Interval().Seconds(),
+		Limit:          g.Limit(),
+		EvaluationTime: g.GetEvaluationTime().Seconds(),
+		LastEvaluation: g.GetLastEvaluation(),
+	}
+
+	for _, rule := range g.Rules() {
+		var lastError string
+		if err := rule.LastError(); err != nil {
+			lastError = err.Error()
+		}
+
+		switch rule := rule.(type) {
+		case *rules.AlertingRule:
+			if typ == rulesTypeRecord {
+				continue
+			}
+			group.Rules = append(group.Rules, alertingRule{
+				State:          rule.State().String(),
+				Name:           rule.Name(),
+				Query:          rule.Query().String(),
+				Duration:       rule.HoldDuration().Seconds(),
+				Labels:         rule.Labels(),
+				Annotations:    rule.Annotations(),
+				Alerts:         newAlerts(rule.ActiveAlerts()),
+				Health:         rule.Health(),
+				LastError:      lastError,
+				EvaluationTime: rule.GetEvaluationDuration().Seconds(),
+				LastEvaluation: rule.GetEvaluationTimestamp(),
+				Type:           ruleTypeAlerting,
+			})
+		case *rules.RecordingRule:
+			if typ == rulesTypeAlert {
+				continue
+			}
+			group.Rules = append(group.Rules, recordingRule{
+				Name:           rule.Name(),
+				Query:          rule.Query().String(),
+				Labels:         rule.Labels(),
+				Health:         rule.Health(),
+				LastError:      lastError,
+				EvaluationTime: rule.GetEvaluationDuration().Seconds(),
+				LastEvaluation: rule.GetEvaluationTimestamp(),
+				Type:           ruleTypeRecording,
+			})
+		}
+	}
+
+	return group
+}
+
+func newAlerts(active []*rules.Alert) []alert {
+	alerts := make([]alert, 0, len(active))
+	for _, a := range active {
+		activeAt := a.ActiveAt
+		alerts = append(alerts, alert{
+			Labels:      a.Labels,
+			Annotations: a.Annotations,
+			State:       a.State.String(),
+			ActiveAt:    &activeAt,
+			Value:       strconv.FormatFloat(a.Value, 'e', -1, 64),
+		})
+	}
+	return alerts
+}
+
+// AlertsHandler represents a handler for the alerts endpoint, it returns the
+// active alerts of the alerting rules evaluated by the coordinator.
+type AlertsHandler struct {
+	ruler          ruler.Ruler
+	instrumentOpts instrument.Options
+}
+
+// NewAlertsHandler returns a new instance of handler.
+func NewAlertsHandler(opts options.HandlerOptions) http.Handler {
+	return &AlertsHandler{
+		ruler:          opts.Ruler(),
+		instrumentOpts: opts.InstrumentOpts(),
+	}
+}
+
+func (h *AlertsHandler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	alerts := []alert{}
+	if h.ruler != nil {
+		for _, g := range h.ruler.RuleGroups() {
+			for _, rule := range g.AlertingRules() {
+				alerts = append(alerts, newAlerts(rule.ActiveAlerts())...)
+			}
+		}
+	}
+
+	xhttp.WriteJSONResponse(w, alertsResponse{
+		Status: rulesStatusSuccess,
+		Data:   alertsData{Alerts: alerts},
+	}, h.instrumentOpts.Logger())
+}
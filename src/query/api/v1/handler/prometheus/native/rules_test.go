@@ -0,0 +1,141 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/prometheus/prometheus/promql/parser"
+	"github.com/prometheus/prometheus/rules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/ruler"
+	xtest "github.com/m3db/m3/src/x/test"
+)
+
+func newTestRuleGroup(t *testing.T) *rules.Group {
+	recordExpr, err := parser.ParseExpr(`sum by (job) (up)`)
+	require.NoError(t, err)
+	alertExpr, err := parser.ParseExpr(`up == 0`)
+	require.NoError(t, err)
+
+	alerting := rules.NewAlertingRule("InstanceDown", alertExpr, 5*time.Minute,
+		labels.FromStrings("severity", "page"), labels.FromStrings("summary", "down"),
+		nil, "", true, log.NewNopLogger())
+
+	// Evaluate the alerting rule so that it has a pending alert.
+	ts := time.Unix(1600000000, 0)
+	_, err = alerting.Eval(context.Background(), ts,
+		func(context.Context, string, time.Time) (promql.Vector, error) {
+			return promql.Vector{{
+				Metric: labels.FromStrings("__name__", "up", "instance", "a"),
+				Point:  promql.Point{T: ts.UnixNano() / int64(time.Millisecond), V: 0},
+			}}, nil
+		}, nil, 0)
+	require.NoError(t, err)
+
+	return rules.NewGroup(rules.GroupOptions{
+		Name:     "test",
+		File:     "test.yml",
+		Interval: time.Minute,
+		Rules: []rules.Rule{
+			rules.NewRecordingRule("job:up:sum", recordExpr, nil),
+			alerting,
+		},
+		Opts: &rules.ManagerOptions{},
+	})
+}
+
+func serveRulesRequest(h http.Handler, target string) (int, string) {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w.Code, w.Body.String()
+}
+
+func TestRules(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	r := ruler.NewMockRuler(ctrl)
+	r.EXPECT().RuleGroups().Return([]*rules.Group{newTestRuleGroup(t)}).AnyTimes()
+	h := NewRulesHandler(options.EmptyHandlerOptions().SetRuler(r))
+
+	code, body := serveRulesRequest(h, RulesURL)
+	require.Equal(t, http.StatusOK, code)
+	assert.JSONEq(t, `{"status":"success","data":{"groups":[{
+		"name":"test","file":"test.yml","interval":60,"limit":0,
+		"evaluationTime":0,"lastEvaluation":"0001-01-01T00:00:00Z",
+		"rules":[
+			{"name":"job:up:sum","query":"sum by(job) (up)","health":"unknown",
+			 "evaluationTime":0,"lastEvaluation":"0001-01-01T00:00:00Z","type":"recording"},
+			{"state":"pending","name":"InstanceDown","query":"up == 0","duration":300,
+			 "labels":{"severity":"page"},"annotations":{"summary":"down"},
+			 "alerts":[{"labels":{"alertname":"InstanceDown","instance":"a","severity":"page"},
+			   "annotations":{"summary":"down"},"state":"pending",
+			   "activeAt":"2020-09-13T12:26:40Z","value":"0e+00"}],
+			 "health":"unknown","evaluationTime":0,"lastEvaluation":"0001-01-01T00:00:00Z",
+			 "type":"alerting"}
+		]}]}}`, body)
+
+	code, body = serveRulesRequest(h, RulesURL+"?type=record")
+	require.Equal(t, http.StatusOK, code)
+	assert.Contains(t, body, `"job:up:sum"`)
+	assert.NotContains(t, body, `"InstanceDown"`)
+
+	code, _ = serveRulesRequest(h, RulesURL+"?type=invalid")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestAlerts(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	r := ruler.NewMockRuler(ctrl)
+	r.EXPECT().RuleGroups().Return([]*rules.Group{newTestRuleGroup(t)})
+	h := NewAlertsHandler(options.EmptyHandlerOptions().SetRuler(r))
+
+	code, body := serveRulesRequest(h, AlertsURL)
+	require.Equal(t, http.StatusOK, code)
+	assert.JSONEq(t, `{"status":"success","data":{"alerts":[
+		{"labels":{"alertname":"InstanceDown","instance":"a","severity":"page"},
+		 "annotations":{"summary":"down"},"state":"pending",
+		 "activeAt":"2020-09-13T12:26:40Z","value":"0e+00"}]}}`, body)
+}
+
+func TestRulesAndAlertsNoRuler(t *testing.T) {
+	code, body := serveRulesRequest(NewRulesHandler(options.EmptyHandlerOptions()), RulesURL)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, `{"status":"success","data":{"groups":[]}}`, body)
+
+	code, body = serveRulesRequest(NewAlertsHandler(options.EmptyHandlerOptions()), AlertsURL)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, `{"status":"success","data":{"alerts":[]}}`, body)
+}
@@ -0,0 +1,290 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package native
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/api/v1/route"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/m3"
+	"github.com/m3db/m3/src/query/tenant"
+	"github.com/m3db/m3/src/query/util"
+	"github.com/m3db/m3/src/query/util/json"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/clock"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+const (
+	// TSDBStatusURL is the url for the TSDB status endpoint.
+	TSDBStatusURL = route.TSDBStatusURL
+
+	tsdbStatusNamespaceParam = "namespace"
+	tsdbStatusLimitParam     = "limit"
+)
+
+// TSDBStatusHTTPMethods are the HTTP methods for the TSDB status handler.
+var TSDBStatusHTTPMethods = []string{http.MethodGet}
+
+// TSDBStatusHandler represents a handler for the TSDB status endpoint, like
+// the Prometheus endpoint it reports the cardinality of the series of a
+// namespace, and additionally the series churn of each index block.
+type TSDBStatusHandler struct {
+	clusters            m3.Clusters
+	tagOptions          models.TagOptions
+	fetchOptionsBuilder handleroptions.FetchOptionsBuilder
+	tenants             tenant.Tenants
+	nowFn               clock.NowFn
+	instrumentOpts      instrument.Options
+}
+
+// NewTSDBStatusHandler returns a new instance of handler.
+func NewTSDBStatusHandler(opts options.HandlerOptions) http.Handler {
+	var tenants tenant.Tenants
+	if m3dbOpts := opts.M3DBOptions(); m3dbOpts != nil {
+		tenants = m3dbOpts.Tenants()
+	}
+	return &TSDBStatusHandler{
+		clusters:            opts.Clusters(),
+		tagOptions:          opts.TagOptions(),
+		fetchOptionsBuilder: opts.FetchOptionsBuilder(),
+		tenants:             tenants,
+		nowFn:               opts.NowFn(),
+		instrumentOpts:      opts.InstrumentOpts(),
+	}
+}
+
+func (h *TSDBStatusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set(xhttp.HeaderContentType, xhttp.ContentTypeJSON)
+
+	ctx, fetchOpts, rErr := h.fetchOptionsBuilder.NewFetchOptions(r.Context(), r)
+	if rErr != nil {
+		xhttp.WriteError(w, rErr)
+		return
+	}
+
+	opts, err := h.parseCardinalityOptions(r, fetchOpts)
+	if err != nil {
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	ns, err := h.namespace(r.FormValue(tsdbStatusNamespaceParam), fetchOpts)
+	if err != nil {
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	logger := logging.WithContext(ctx, h.instrumentOpts)
+	result, err := ns.Session().Cardinality(ctx, ns.NamespaceID(), opts)
+	if err != nil {
+		logger.Error("unable to compute cardinality",
+			zap.Stringer("namespace", ns.NamespaceID()),
+			zap.Error(err))
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	if err := renderTSDBStatusJSON(w, result); err != nil {
+		logger.Error("unable to render tsdb status", zap.Error(err))
+	}
+}
+
+// parseCardinalityOptions parses the time range and limit of the request,
+// the range defaults to the latest index block. The cardinality is restricted
+// to the series matching the tag restrictions of the fetch options, such as
+// the series of the tenant of the request.
+func (h *TSDBStatusHandler) parseCardinalityOptions(
+	r *http.Request,
+	fetchOpts *storage.FetchOptions,
+) (index.CardinalityOptions, error) {
+	if err := r.ParseForm(); err != nil {
+		return index.CardinalityOptions{}, xerrors.NewInvalidParamsError(err)
+	}
+
+	end, err := util.ParseTimeStringWithDefault(r.FormValue("end"), h.nowFn())
+	if err != nil {
+		return index.CardinalityOptions{}, xerrors.NewInvalidParamsError(err)
+	}
+
+	start, err := util.ParseTimeStringWithDefault(r.FormValue("start"), end)
+	if err != nil {
+		return index.CardinalityOptions{}, xerrors.NewInvalidParamsError(err)
+	}
+
+	if start.After(end) {
+		err := fmt.Errorf("start %v must be before end %v", start, end)
+		return index.CardinalityOptions{}, xerrors.NewInvalidParamsError(err)
+	}
+
+	opts := index.CardinalityOptions{
+		StartInclusive: xtime.ToUnixNano(start),
+		// NB: the end is made exclusive so that the index block that
+		// contains the end is reported on.
+		EndExclusive: xtime.ToUnixNano(end.Add(time.Nanosecond)),
+		NameField:    h.tagOptions.MetricName(),
+	}
+	if v := r.FormValue(tsdbStatusLimitParam); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit <= 0 {
+			err := fmt.Errorf("invalid %s: %s", tsdbStatusLimitParam, v)
+			return index.CardinalityOptions{}, xerrors.NewInvalidParamsError(err)
+		}
+		opts.Limit = limit
+	}
+
+	restrict := fetchOpts.RestrictQueryOptions.GetRestrictByTag().GetMatchers()
+	if len(restrict) > 0 {
+		q, err := storage.FetchQueryToM3Query(&storage.FetchQuery{}, fetchOpts)
+		if err != nil {
+			return index.CardinalityOptions{}, xerrors.NewInvalidParamsError(err)
+		}
+		opts.Query = q
+	}
+	return opts, nil
+}
+
+// namespace returns the cluster namespace with the name, or the unaggregated
+// namespace if the name is empty, it must be a namespace of the tenant of the
+// request if any.
+func (h *TSDBStatusHandler) namespace(
+	name string,
+	fetchOpts *storage.FetchOptions,
+) (m3.ClusterNamespace, error) {
+	if h.clusters == nil {
+		return nil, errNoClusters
+	}
+
+	var (
+		ns    m3.ClusterNamespace
+		found bool
+	)
+	if name == "" {
+		ns, found = h.clusters.UnaggregatedClusterNamespace()
+		if !found {
+			return nil, xerrors.NewInvalidParamsError(
+				fmt.Errorf("no unaggregated namespace, %s must be set",
+					tsdbStatusNamespaceParam))
+		}
+	} else {
+		for _, clusterNamespace := range h.clusters.ClusterNamespaces() {
+			if clusterNamespace.NamespaceID().String() == name {
+				ns, found = clusterNamespace, true
+				break
+			}
+		}
+		if !found {
+			return nil, xerrors.NewInvalidParamsError(
+				fmt.Errorf("unknown namespace: %s", name))
+		}
+	}
+
+	restrict := fetchOpts.RestrictQueryOptions.GetRestrictByTenant()
+	if restrict == nil || h.tenants == nil {
+		return ns, nil
+	}
+	t, ok := h.tenants.Tenant(restrict.Tenant)
+	if !ok || !t.HasNamespace(ns.NamespaceID().String()) {
+		return nil, xerrors.NewInvalidParamsError(
+			fmt.Errorf("namespace %s is not a namespace of tenant %s",
+				ns.NamespaceID().String(), restrict.Tenant))
+	}
+	return ns, nil
+}
+
+func renderTSDBStatusJSON(w io.Writer, result index.CardinalityResult) error {
+	jw := json.NewWriter(w)
+	jw.BeginObject()
+
+	jw.BeginObjectField("status")
+	jw.WriteString("success")
+
+	jw.BeginObjectField("data")
+	jw.BeginObject()
+
+	jw.BeginObjectField("headStats")
+	jw.BeginObject()
+	jw.BeginObjectField("numSeries")
+	jw.WriteInt(int(result.NumSeries))
+	jw.EndObject()
+
+	jw.BeginObjectField("approximate")
+	jw.WriteBool(result.Approximate)
+
+	renderCardinalityStatsJSON(jw, "seriesCountByMetricName",
+		result.SeriesCountByMetricName)
+	renderCardinalityStatsJSON(jw, "labelValueCountByLabelName",
+		result.LabelValueCountByLabelName)
+	renderCardinalityStatsJSON(jw, "seriesCountByLabelValuePair",
+		result.SeriesCountByLabelValuePair)
+
+	jw.BeginObjectField("seriesChurnByBlock")
+	jw.BeginArray()
+	for _, b := range result.Blocks {
+		jw.BeginObject()
+		jw.BeginObjectField("blockStart")
+		jw.WriteString(b.BlockStart.ToTime().UTC().Format(time.RFC3339))
+		jw.BeginObjectField("numSeries")
+		jw.WriteInt(int(b.NumSeries))
+		jw.BeginObjectField("newSeries")
+		jw.WriteInt(int(b.NewSeries))
+		jw.BeginObjectField("removedSeries")
+		jw.WriteInt(int(b.RemovedSeries))
+		jw.EndObject()
+	}
+	jw.EndArray()
+
+	jw.EndObject()
+
+	jw.EndObject()
+	return jw.Close()
+}
+
+func renderCardinalityStatsJSON(
+	jw json.Writer,
+	field string,
+	stats []index.CardinalityStat,
+) {
+	jw.BeginObjectField(field)
+	jw.BeginArray()
+	for _, stat := range stats {
+		jw.BeginObject()
+		jw.BeginObjectField("name")
+		jw.WriteBytesString(stat.Name)
+		jw.BeginObjectField("value")
+		jw.WriteInt(int(stat.Value))
+		jw.EndObject()
+	}
+	jw.EndArray()
+}
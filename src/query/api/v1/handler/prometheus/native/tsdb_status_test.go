@@ -0,0 +1,211 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package native
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/m3ninx/idx"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage/m3"
+	"github.com/m3db/m3/src/query/tenant"
+	"github.com/m3db/m3/src/x/headers"
+	"github.com/m3db/m3/src/x/ident"
+	"github.com/m3db/m3/src/x/instrument"
+	xtest "github.com/m3db/m3/src/x/test"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+func newTestTSDBStatusHandler(t *testing.T, session client.Session) http.Handler {
+	clusters, err := m3.NewClusters(m3.UnaggregatedClusterNamespaceDefinition{
+		NamespaceID: ident.StringID("test-ns"),
+		Session:     session,
+		Retention:   24 * time.Hour,
+	})
+	require.NoError(t, err)
+
+	fb, err := handleroptions.NewFetchOptionsBuilder(
+		handleroptions.FetchOptionsBuilderOptions{Timeout: 15 * time.Second})
+	require.NoError(t, err)
+
+	return NewTSDBStatusHandler(options.EmptyHandlerOptions().
+		SetClusters(clusters).
+		SetTagOptions(models.NewTagOptions()).
+		SetFetchOptionsBuilder(fb))
+}
+
+func TestTSDBStatusHandler(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		session = client.NewMockSession(ctrl)
+		h       = newTestTSDBStatusHandler(t, session)
+		start   = time.Unix(7200, 0)
+		end     = time.Unix(10800, 0)
+	)
+
+	session.EXPECT().
+		Cardinality(gomock.Any(), ident.NewIDMatcher("test-ns"),
+			index.CardinalityOptions{
+				StartInclusive: xtime.ToUnixNano(start),
+				EndExclusive:   xtime.ToUnixNano(end.Add(time.Nanosecond)),
+				Limit:          2,
+				NameField:      []byte("__name__"),
+			}).
+		Return(index.CardinalityResult{
+			NumSeries: 3,
+			SeriesCountByMetricName: []index.CardinalityStat{
+				{Name: []byte("foo"), Value: 2},
+				{Name: []byte("bar"), Value: 1},
+			},
+			LabelValueCountByLabelName: []index.CardinalityStat{
+				{Name: []byte("__name__"), Value: 2},
+			},
+			SeriesCountByLabelValuePair: []index.CardinalityStat{
+				{Name: []byte("__name__=foo"), Value: 2},
+			},
+			Blocks: []index.BlockCardinality{
+				{BlockStart: xtime.ToUnixNano(start), NumSeries: 3, NewSeries: 1, RemovedSeries: 2},
+			},
+		}, nil)
+
+	values := url.Values{}
+	values.Set("start", "7200")
+	values.Set("end", "10800")
+	values.Set("limit", "2")
+
+	req := httptest.NewRequest(http.MethodGet, TSDBStatusURL+"?"+values.Encode(), nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.JSONEq(t, `{
+		"status": "success",
+		"data": {
+			"headStats": {"numSeries": 3},
+			"approximate": false,
+			"seriesCountByMetricName": [
+				{"name": "foo", "value": 2},
+				{"name": "bar", "value": 1}
+			],
+			"labelValueCountByLabelName": [
+				{"name": "__name__", "value": 2}
+			],
+			"seriesCountByLabelValuePair": [
+				{"name": "__name__=foo", "value": 2}
+			],
+			"seriesChurnByBlock": [
+				{
+					"blockStart": "1970-01-01T02:00:00Z",
+					"numSeries": 3,
+					"newSeries": 1,
+					"removedSeries": 2
+				}
+			]
+		}
+	}`, w.Body.String())
+}
+
+func TestTSDBStatusHandlerTenant(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	session := client.NewMockSession(ctrl)
+	clusters, err := m3.NewClusters(m3.UnaggregatedClusterNamespaceDefinition{
+		NamespaceID: ident.StringID("test-ns"),
+		Session:     session,
+		Retention:   24 * time.Hour,
+	})
+	require.NoError(t, err)
+
+	cfg := tenant.Configuration{
+		Tenants: []tenant.TenantConfiguration{
+			{Name: "team-a"},
+			{Name: "team-b", Namespaces: []string{"other-ns"}},
+		},
+	}
+	tenants, err := cfg.NewTenants(instrument.NewOptions())
+	require.NoError(t, err)
+	defer tenants.Close()
+
+	fb, err := handleroptions.NewFetchOptionsBuilder(
+		handleroptions.FetchOptionsBuilderOptions{
+			Timeout: 15 * time.Second,
+			Tenants: tenants,
+		})
+	require.NoError(t, err)
+
+	h := NewTSDBStatusHandler(options.EmptyHandlerOptions().
+		SetClusters(clusters).
+		SetTagOptions(models.NewTagOptions()).
+		SetFetchOptionsBuilder(fb).
+		SetM3DBOptions(m3.NewOptions(encoding.NewOptions()).SetTenants(tenants)))
+
+	// The cardinality is restricted to the series of the tenant.
+	session.EXPECT().
+		Cardinality(gomock.Any(), ident.NewIDMatcher("test-ns"), gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			_ ident.ID,
+			opts index.CardinalityOptions,
+		) (index.CardinalityResult, error) {
+			expected := idx.NewTermQuery([]byte("tenant"), []byte("team-a"))
+			require.True(t, expected.Equal(opts.Query.Query), opts.Query.String())
+			return index.CardinalityResult{}, nil
+		})
+
+	req := httptest.NewRequest(http.MethodGet, TSDBStatusURL, nil)
+	req.Header.Set(headers.TenantHeader, "team-a")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	// And to the namespaces of the tenant.
+	req = httptest.NewRequest(http.MethodGet, TSDBStatusURL, nil)
+	req.Header.Set(headers.TenantHeader, "team-b")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+}
+
+func TestTSDBStatusHandlerUnknownNamespace(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	h := newTestTSDBStatusHandler(t, client.NewMockSession(ctrl))
+
+	req := httptest.NewRequest(http.MethodGet, TSDBStatusURL+"?namespace=foo", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
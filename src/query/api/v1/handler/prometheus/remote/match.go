@@ -33,6 +33,7 @@ import (
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser/promql"
 	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/prommetadata"
 	"github.com/m3db/m3/src/query/util/logging"
 	"github.com/m3db/m3/src/x/instrument"
 	xhttp "github.com/m3db/m3/src/x/net/http"
@@ -45,6 +46,7 @@ var PromSeriesMatchHTTPMethods = []string{http.MethodGet, http.MethodPost}
 // the prometheus series matcher endpoint.
 type PromSeriesMatchHandler struct {
 	storage             storage.Storage
+	metadataStore       prommetadata.Store
 	tagOptions          models.TagOptions
 	fetchOptionsBuilder handleroptions.FetchOptionsBuilder
 	instrumentOpts      instrument.Options
@@ -58,6 +60,7 @@ func NewPromSeriesMatchHandler(opts options.HandlerOptions) http.Handler {
 	return &PromSeriesMatchHandler{
 		tagOptions:          opts.TagOptions(),
 		storage:             opts.Storage(),
+		metadataStore:       opts.MetadataStore(),
 		fetchOptionsBuilder: opts.FetchOptionsBuilder(),
 		instrumentOpts:      opts.InstrumentOpts(),
 		parseOpts: opts.Engine().Options().ParseOptions().
@@ -84,6 +87,21 @@ func (h *PromSeriesMatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	metricTypeMatch, ok, err := prometheus.ParseMetricTypeMatch(r,
+		h.metadataStore, h.tagOptions)
+	if err != nil {
+		xhttp.WriteError(w, err)
+		return
+	}
+	if ok && metricTypeMatch.None {
+		// There are no series to return if no metrics have the metric type.
+		queries = nil
+	} else if ok {
+		for _, query := range queries {
+			query.TagMatchers = append(query.TagMatchers, metricTypeMatch.Matcher)
+		}
+	}
+
 	results := make([]models.Metrics, len(queries))
 	meta := block.NewResultMetadata()
 	for i, query := range queries {
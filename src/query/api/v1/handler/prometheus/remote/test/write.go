@@ -32,6 +32,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/m3db/m3/src/query/generated/proto/prompb"
+	"github.com/m3db/m3/src/query/generated/proto/prompbv2"
 )
 
 // GeneratePromWriteRequest generates a Prometheus remote
@@ -88,6 +89,18 @@ func GeneratePromWriteRequestBodyBytes(
 	return compressed
 }
 
+// GeneratePromWriteV2RequestBody generates a Prometheus remote write 2.0
+// request body.
+func GeneratePromWriteV2RequestBody(
+	t require.TestingT,
+	req *prompbv2.Request,
+) io.Reader {
+	data, err := proto.Marshal(req)
+	require.NoError(t, err)
+
+	return bytes.NewReader(snappy.Encode(nil, data))
+}
+
 // ReadPromWriteRequestBody reads a Prometheus remote
 // write request body.
 func ReadPromWriteRequestBody(
@@ -42,15 +42,20 @@ import (
 
 	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest"
 	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/encoding/histogram"
 	"github.com/m3db/m3/src/metrics/policy"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
 	"github.com/m3db/m3/src/query/api/v1/options"
 	"github.com/m3db/m3/src/query/api/v1/route"
 	"github.com/m3db/m3/src/query/generated/proto/prompb"
+	"github.com/m3db/m3/src/query/generated/proto/prompbv2"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/exemplar"
 	"github.com/m3db/m3/src/query/storage/m3/storagemetadata"
+	"github.com/m3db/m3/src/query/storage/prommetadata"
+	"github.com/m3db/m3/src/query/tenant"
 	"github.com/m3db/m3/src/query/ts"
 	"github.com/m3db/m3/src/query/util/logging"
 	"github.com/m3db/m3/src/x/clock"
@@ -106,6 +111,12 @@ var (
 		Metadata:   ts.Metadata{},
 	}
 
+	nativeHistogramAttributes = ts.SeriesAttributes{
+		M3Type:   ts.M3MetricTypeGauge,
+		PromType: ts.PromMetricTypeHistogram,
+		Source:   ts.SourceTypePrometheus,
+	}
+
 	headerToMetricType = map[string]prompb.MetricType{
 		"counter":         prompb.MetricType_COUNTER,
 		"gauge":           prompb.MetricType_GAUGE,
@@ -120,8 +131,11 @@ var (
 // PromWriteHandler represents a handler for prometheus write endpoint.
 type PromWriteHandler struct {
 	downsamplerAndWriter   ingest.DownsamplerAndWriter
+	exemplarStore          exemplar.Store
+	metadataStore          prommetadata.Store
 	tagOptions             models.TagOptions
 	storeMetricsType       bool
+	tenants                tenant.Tenants
 	forwarding             handleroptions.PromWriteHandlerForwardingOptions
 	forwardTimeout         time.Duration
 	forwardHTTPClient      *http.Client
@@ -158,6 +172,11 @@ func NewPromWriteHandler(options options.HandlerOptions) (http.Handler, error) {
 		return nil, errNoNowFn
 	}
 
+	var tenants tenant.Tenants
+	if m3dbOpts := options.M3DBOptions(); m3dbOpts != nil {
+		tenants = m3dbOpts.Tenants()
+	}
+
 	scope := options.InstrumentOpts().
 		MetricsScope().
 		Tagged(map[string]string{"handler": "remote-write"})
@@ -193,8 +212,11 @@ func NewPromWriteHandler(options options.HandlerOptions) (http.Handler, error) {
 
 	return &PromWriteHandler{
 		downsamplerAndWriter:   downsamplerAndWriter,
+		exemplarStore:          options.ExemplarStore(),
+		metadataStore:          options.MetadataStore(),
 		tagOptions:             tagOptions,
 		storeMetricsType:       options.StoreMetricsType(),
+		tenants:                tenants,
 		forwarding:             forwarding,
 		forwardTimeout:         forwardTimeout,
 		forwardHTTPClient:      xhttp.NewHTTPClient(forwardHTTPOpts),
@@ -221,6 +243,7 @@ type promWriteMetrics struct {
 	forwardLatency           tally.Histogram
 	forwardShadowKeep        tally.Counter
 	forwardShadowDrop        tally.Counter
+	exemplarErrors           tally.Counter
 }
 
 func (m *promWriteMetrics) incError(err error) {
@@ -250,6 +273,7 @@ func newPromWriteMetrics(scope tally.Scope) (promWriteMetrics, error) {
 		forwardLatency:           scope.SubScope("forward").Histogram("latency", buckets.WriteLatencyBuckets),
 		forwardShadowKeep:        scope.SubScope("forward").SubScope("shadow").Counter("keep"),
 		forwardShadowDrop:        scope.SubScope("forward").SubScope("shadow").Counter("drop"),
+		exemplarErrors:           scope.SubScope("exemplars").Counter("errors"),
 	}, nil
 }
 
@@ -268,6 +292,15 @@ func (h *PromWriteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		req  = checkedReq.Request
 		opts = checkedReq.Options
 	)
+	if t := checkedReq.Tenant; t != nil {
+		if err := t.WriteLimit.Inc(numDatapoints(req), nil); err != nil {
+			err = xhttp.NewError(err, http.StatusTooManyRequests)
+			h.metrics.incError(err)
+			xhttp.WriteError(w, err)
+			return
+		}
+	}
+
 	// Begin async forwarding.
 	// NB(r): Be careful about not returning buffers to pool
 	// if the request bodies ever get pooled until after
@@ -326,7 +359,10 @@ func (h *PromWriteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	batchErr := h.write(r.Context(), req, opts)
+	stats, batchErr := h.write(r.Context(), req, opts, checkedReq.Tenant)
+	if checkedReq.Protocol == remoteWriteProtocolV2 {
+		setPromWriteStatsHeaders(w.Header(), stats)
+	}
 
 	// Record ingestion delay latency
 	now := h.nowFn()
@@ -414,12 +450,17 @@ type parseRequestResult struct {
 	Request        *prompb.WriteRequest
 	Options        ingest.WriteOptions
 	CompressResult prometheus.ParsePromCompressedRequestResult
+	Protocol       remoteWriteProtocol
+	Tenant         *tenant.Tenant
 }
 
 func (h *PromWriteHandler) checkedParseRequest(
 	r *http.Request,
 ) (parseRequestResult, error) {
 	result, err := h.parseRequest(r)
+	if errors.Is(err, errUnsupportedRemoteWriteProtocol) {
+		return parseRequestResult{}, xhttp.NewError(err, http.StatusUnsupportedMediaType)
+	}
 	if err != nil {
 		// Always invalid request if parsing fails params.
 		return parseRequestResult{}, xerrors.NewInvalidParamsError(err)
@@ -484,14 +525,33 @@ func (h *PromWriteHandler) parseRequest(
 		}
 	}
 
+	protocol, err := parseRemoteWriteProtocol(r.Header.Get(xhttp.HeaderContentType))
+	if err != nil {
+		return parseRequestResult{}, err
+	}
+
 	result, err := prometheus.ParsePromCompressedRequest(r)
 	if err != nil {
 		return parseRequestResult{}, err
 	}
 
 	var req prompb.WriteRequest
-	if err := proto.Unmarshal(result.UncompressedBody, &req); err != nil {
-		return parseRequestResult{}, err
+	switch protocol {
+	case remoteWriteProtocolV2:
+		var reqV2 prompbv2.Request
+		if err := proto.Unmarshal(result.UncompressedBody, &reqV2); err != nil {
+			return parseRequestResult{}, err
+		}
+
+		converted, err := promWriteRequestV2ToV1(&reqV2, h.nowFn())
+		if err != nil {
+			return parseRequestResult{}, err
+		}
+		req = *converted
+	default:
+		if err := proto.Unmarshal(result.UncompressedBody, &req); err != nil {
+			return parseRequestResult{}, err
+		}
 	}
 
 	if mapStr := r.Header.Get(headers.MapTagsByJSONHeader); mapStr != "" {
@@ -505,6 +565,26 @@ func (h *PromWriteHandler) parseRequest(
 		}
 	}
 
+	t, err := handleroptions.ParseTenant(r, h.tenants)
+	if err != nil {
+		return parseRequestResult{}, err
+	}
+	if t != nil {
+		// NB: the tenant tag is set after the tags are mapped so that the
+		// tenant of the series written cannot be overridden.
+		tenantOpts := handleroptions.MapTagsOptions{
+			TagMappers: []handleroptions.TagMapper{{
+				Write: handleroptions.WriteOp{
+					Tag:   string(h.tenants.TagName()),
+					Value: t.Name,
+				},
+			}},
+		}
+		if err := mapTags(&req, tenantOpts); err != nil {
+			return parseRequestResult{}, err
+		}
+	}
+
 	if promType := r.Header.Get(headers.PromTypeHeader); promType != "" {
 		tp, ok := headerToMetricType[strings.ToLower(promType)]
 		if !ok {
@@ -533,20 +613,150 @@ func (h *PromWriteHandler) parseRequest(
 		Request:        &req,
 		Options:        opts,
 		CompressResult: result,
+		Protocol:       protocol,
+		Tenant:         t,
 	}, nil
 }
 
+func numDatapoints(r *prompb.WriteRequest) int {
+	n := 0
+	for _, series := range r.Timeseries {
+		n += len(series.Samples) + len(series.Histograms)
+	}
+	return n
+}
+
 func (h *PromWriteHandler) write(
 	ctx context.Context,
 	r *prompb.WriteRequest,
 	opts ingest.WriteOptions,
-) ingest.BatchError {
+	t *tenant.Tenant,
+) (promWriteStats, ingest.BatchError) {
+	var (
+		stats promWriteStats
+		errs  xerrors.MultiError
+	)
 	iter, err := newPromTSIter(r.Timeseries, h.tagOptions, h.storeMetricsType)
 	if err != nil {
-		var errs xerrors.MultiError
-		return errs.Add(err)
+		return stats, errs.Add(err)
+	}
+	batchErr := h.downsamplerAndWriter.WriteBatch(ctx, iter, opts)
+	if batchErr == nil {
+		stats.samples = iter.numSamples()
+	}
+
+	errs = appendBatchErrors(errs, batchErr)
+
+	histogramIter, err := newPromHistogramIter(r.Timeseries, h.tagOptions)
+	if err != nil {
+		// NB: keep the errors of the samples already written.
+		return stats, errs.Add(err)
+	}
+
+	if histogramIter.len() > 0 {
+		// Native histograms can not be aggregated by the downsampler, so only
+		// write them directly to storage.
+		histogramOpts := opts
+		histogramOpts.DownsampleOverride = true
+		histogramOpts.DownsampleMappingRules = nil
+		histogramBatchErr := h.downsamplerAndWriter.WriteBatch(ctx, histogramIter, histogramOpts)
+		if histogramBatchErr == nil {
+			stats.histograms = histogramIter.len()
+		}
+		errs = appendBatchErrors(errs, histogramBatchErr)
+	}
+
+	stats.exemplars = h.writeExemplars(ctx, r.Timeseries)
+	if t == nil {
+		// NB: the metadata store is keyed by metric name only and shared by
+		// all tenants, so the metadata of the writes of a tenant is not
+		// stored and the metadata endpoints are not served to tenants.
+		h.writeMetadata(r.Metadata)
+	}
+
+	if errs.NumErrors() == 0 {
+		return stats, nil
+	}
+	return stats, errs
+}
+
+func appendBatchErrors(errs xerrors.MultiError, batchErr ingest.BatchError) xerrors.MultiError {
+	if batchErr == nil {
+		return errs
+	}
+	for _, err := range batchErr.Errors() {
+		errs = errs.Add(err)
+	}
+	return errs
+}
+
+// writeExemplars writes the request exemplars to the exemplar store and
+// returns the number of exemplars written. Exemplars are best effort, failing
+// to store them does not fail the write.
+func (h *PromWriteHandler) writeExemplars(
+	ctx context.Context,
+	timeseries []prompb.TimeSeries,
+) int {
+	if h.exemplarStore == nil {
+		return 0
+	}
+
+	series := promExemplarSeries(timeseries, h.tagOptions)
+	if len(series) == 0 {
+		return 0
+	}
+
+	if err := h.exemplarStore.Write(ctx, series); err != nil {
+		h.metrics.exemplarErrors.Inc(1)
+		logger := logging.WithContext(ctx, h.instrumentOpts)
+		logger.Error("exemplar write error", zap.Error(err))
+		return 0
+	}
+
+	var written int
+	for _, s := range series {
+		written += len(s.Exemplars)
+	}
+	return written
+}
+
+func (h *PromWriteHandler) writeMetadata(metadata []prompb.MetricMetadata) {
+	if h.metadataStore == nil || len(metadata) == 0 {
+		return
+	}
+
+	converted := make([]prommetadata.Metadata, 0, len(metadata))
+	for _, m := range metadata {
+		converted = append(converted, prommetadata.FromProm(m))
+	}
+	h.metadataStore.Write(converted)
+}
+
+func promExemplarSeries(
+	timeseries []prompb.TimeSeries,
+	tagOpts models.TagOptions,
+) []exemplar.SeriesExemplars {
+	var result []exemplar.SeriesExemplars
+	for _, promTS := range timeseries {
+		if len(promTS.Exemplars) == 0 {
+			continue
+		}
+
+		series := exemplar.SeriesExemplars{
+			Tags:      storage.PromLabelsToM3Tags(promTS.Labels, tagOpts),
+			Exemplars: make([]exemplar.Exemplar, 0, len(promTS.Exemplars)),
+		}
+		for _, promExemplar := range promTS.Exemplars {
+			series.Exemplars = append(series.Exemplars, exemplar.Exemplar{
+				Labels:    storage.PromLabelsToM3Tags(promExemplar.Labels, tagOpts),
+				Value:     promExemplar.Value,
+				Timestamp: xtime.ToUnixNano(storage.PromTimestampToTime(promExemplar.Timestamp)),
+			})
+		}
+		result = append(result, series)
 	}
-	return h.downsamplerAndWriter.WriteBatch(ctx, iter, opts)
+
+	return result
 }
 
 func (h *PromWriteHandler) forward(
@@ -556,6 +766,15 @@ func (h *PromWriteHandler) forward(
 	target handleroptions.PromWriteHandlerForwardTargetOptions,
 ) error {
 	body := bytes.NewReader(res.CompressResult.CompressedBody)
+	if res.Protocol == remoteWriteProtocolV2 {
+		// Forward targets may not support remote write 2.0, always forward
+		// the converted remote write 1.0 request.
+		buffer, err := encodeWriteRequest(res.Request, nil)
+		if err != nil {
+			return err
+		}
+		body.Reset(buffer)
+	}
 	if shadowOpts := target.Shadow; shadowOpts != nil {
 		// Need to send a subset of the original series to the shadow target.
 		buffer, err := h.buildForwardShadowRequestBody(res, shadowOpts)
@@ -665,12 +884,16 @@ func (h *PromWriteHandler) buildForwardShadowRequestBody(
 		shadowReq.Timeseries = append(shadowReq.Timeseries, ts)
 	}
 
-	encoded, err := proto.Marshal(shadowReq)
+	return encodeWriteRequest(shadowReq, buffer[:0])
+}
+
+func encodeWriteRequest(req *prompb.WriteRequest, buffer []byte) ([]byte, error) {
+	encoded, err := proto.Marshal(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal forwarding shadow request: %w", err)
+		return nil, fmt.Errorf("failed to marshal forwarding request: %w", err)
 	}
 
-	return snappy.Encode(buffer[:0], encoded), nil
+	return snappy.Encode(buffer, encoded), nil
 }
 
 // buildPseudoIDWithLabelsLikelySorted will build a pseudo ID that can be
@@ -734,6 +957,11 @@ func newPromTSIter(
 
 	graphiteTagOpts := tagOpts.SetIDSchemeType(models.TypeGraphite)
 	for _, promTS := range timeseries {
+		if len(promTS.Samples) == 0 && len(promTS.Histograms) > 0 {
+			// Native histogram only series are written by promHistogramIter.
+			continue
+		}
+
 		attributes, err := storage.PromTimeSeriesToSeriesAttributes(promTS)
 		if err != nil {
 			return nil, err
@@ -771,6 +999,14 @@ type promTSIter struct {
 	storeMetricsType bool
 }
 
+func (i *promTSIter) numSamples() int {
+	var n int
+	for _, datapoints := range i.datapoints {
+		n += len(datapoints)
+	}
+	return n
+}
+
 func (i *promTSIter) Next() bool {
 	if i.err != nil {
 		return false
@@ -844,6 +1080,92 @@ func (i *promTSIter) SetCurrentMetadata(metadata ts.Metadata) {
 	i.metadatas[i.idx] = metadata
 }
 
+// promHistogramIter iterates over the native histogram samples of a write
+// request. Each histogram sample is returned as a separate value since the
+// histogram is stored in the annotation of its datapoint.
+type promHistogramIter struct {
+	idx        int
+	tags       []models.Tags
+	datapoints []ts.Datapoints
+	annotation [][]byte
+	metadatas  []ts.Metadata
+}
+
+func newPromHistogramIter(
+	timeseries []prompb.TimeSeries,
+	tagOpts models.TagOptions,
+) (*promHistogramIter, error) {
+	iter := &promHistogramIter{idx: -1}
+	for _, promTS := range timeseries {
+		if len(promTS.Histograms) == 0 {
+			continue
+		}
+
+		tags := storage.PromLabelsToM3Tags(promTS.Labels, tagOpts)
+		for _, promHistogram := range promTS.Histograms {
+			h, err := storage.PromHistogramToM3(promHistogram)
+			if err != nil {
+				return nil, xerrors.NewInvalidParamsError(err)
+			}
+
+			iter.tags = append(iter.tags, tags)
+			iter.datapoints = append(iter.datapoints, ts.Datapoints{{
+				Timestamp: xtime.ToUnixNano(storage.PromTimestampToTime(promHistogram.Timestamp)),
+				Value:     h.Count,
+			}})
+			iter.annotation = append(iter.annotation, histogram.AppendAnnotation(nil, h))
+		}
+	}
+
+	return iter, nil
+}
+
+func (i *promHistogramIter) len() int {
+	return len(i.tags)
+}
+
+func (i *promHistogramIter) Next() bool {
+	i.idx++
+	return i.idx < len(i.tags)
+}
+
+func (i *promHistogramIter) Current() ingest.IterValue {
+	if len(i.tags) == 0 || i.idx < 0 || i.idx >= len(i.tags) {
+		return defaultValue
+	}
+
+	value := ingest.IterValue{
+		Tags:       i.tags[i.idx],
+		Datapoints: i.datapoints[i.idx],
+		Attributes: nativeHistogramAttributes,
+		Unit:       xtime.Millisecond,
+		Annotation: i.annotation[i.idx],
+	}
+	if i.idx < len(i.metadatas) {
+		value.Metadata = i.metadatas[i.idx]
+	}
+	return value
+}
+
+func (i *promHistogramIter) Reset() error {
+	i.idx = -1
+	return nil
+}
+
+func (i *promHistogramIter) Error() error {
+	return nil
+}
+
+func (i *promHistogramIter) SetCurrentMetadata(metadata ts.Metadata) {
+	if len(i.metadatas) == 0 {
+		i.metadatas = make([]ts.Metadata, len(i.tags))
+	}
+	if i.idx < 0 || i.idx >= len(i.metadatas) {
+		return
+	}
+	i.metadatas[i.idx] = metadata
+}
+
 type sortableLabels []prompb.Label
 
 func (t sortableLabels) Len() int      { return len(t) }
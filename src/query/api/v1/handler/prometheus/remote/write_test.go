@@ -41,6 +41,8 @@ import (
 
 	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest"
 	"github.com/m3db/m3/src/cmd/services/m3query/config"
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/histogram"
 	"github.com/m3db/m3/src/dbnode/generated/proto/annotation"
 	"github.com/m3db/m3/src/metrics/policy"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
@@ -48,12 +50,17 @@ import (
 	"github.com/m3db/m3/src/query/api/v1/options"
 	"github.com/m3db/m3/src/query/generated/proto/prompb"
 	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage/exemplar"
+	"github.com/m3db/m3/src/query/storage/m3"
 	"github.com/m3db/m3/src/query/storage/m3/storagemetadata"
+	"github.com/m3db/m3/src/query/storage/prommetadata"
+	"github.com/m3db/m3/src/query/tenant"
 	xclock "github.com/m3db/m3/src/x/clock"
 	xerrors "github.com/m3db/m3/src/x/errors"
 	"github.com/m3db/m3/src/x/headers"
 	"github.com/m3db/m3/src/x/instrument"
 	xtest "github.com/m3db/m3/src/x/test"
+	xtime "github.com/m3db/m3/src/x/time"
 )
 
 func makeOptions(ds ingest.DownsamplerAndWriter) options.HandlerOptions {
@@ -142,6 +149,73 @@ func TestPromWriteError(t *testing.T) {
 	require.True(t, bytes.Contains(body, []byte(batchErr.Error())))
 }
 
+func TestPromWriteTenant(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := tenant.Configuration{
+		Tenants: []tenant.TenantConfiguration{{
+			Name: "team-a",
+			Limits: tenant.LimitsConfiguration{
+				MaxWrittenDatapoints: &tenant.LookbackLimitConfiguration{
+					Value:    6,
+					Lookback: time.Hour,
+				},
+			},
+		}},
+	}
+	tenants, err := cfg.NewTenants(instrument.NewOptions())
+	require.NoError(t, err)
+	defer tenants.Close()
+
+	mockDownsamplerAndWriter := ingest.NewMockDownsamplerAndWriter(ctrl)
+	opts := makeOptions(mockDownsamplerAndWriter).
+		SetM3DBOptions(m3.NewOptions(encoding.NewOptions()).SetTenants(tenants))
+	handler, err := NewPromWriteHandler(opts)
+	require.NoError(t, err)
+
+	newRequest := func(tenantName string) *http.Request {
+		promReq := test.GeneratePromWriteRequest()
+		promReqBody := test.GeneratePromWriteRequestBody(t, promReq)
+		req := httptest.NewRequest(PromWriteHTTPMethod, PromWriteURL, promReqBody)
+		req.Header.Set(headers.TenantHeader, tenantName)
+		// The tenant tag cannot be overridden by mapping tags.
+		req.Header.Set(headers.MapTagsByJSONHeader,
+			`{"tagMappers":[{"write":{"tag":"tenant","value":"team-b"}}]}`)
+		return req
+	}
+
+	r, err := handler.(*PromWriteHandler).parseRequest(newRequest("team-a"))
+	require.NoError(t, err)
+	require.Equal(t, "team-a", r.Tenant.Name)
+	for _, ts := range r.Request.Timeseries {
+		var values []string
+		for _, l := range ts.Labels {
+			if string(l.Name) == "tenant" {
+				values = append(values, string(l.Value))
+			}
+		}
+		require.Equal(t, []string{"team-a"}, values)
+	}
+
+	writer := httptest.NewRecorder()
+	handler.ServeHTTP(writer, newRequest("team-c"))
+	require.Equal(t, http.StatusBadRequest, writer.Result().StatusCode)
+
+	mockDownsamplerAndWriter.
+		EXPECT().
+		WriteBatch(gomock.Any(), gomock.Any(), gomock.Any())
+
+	writer = httptest.NewRecorder()
+	handler.ServeHTTP(writer, newRequest("team-a"))
+	require.Equal(t, http.StatusOK, writer.Result().StatusCode)
+
+	// The second request exceeds the written datapoints limit of the tenant.
+	writer = httptest.NewRecorder()
+	handler.ServeHTTP(writer, newRequest("team-a"))
+	require.Equal(t, http.StatusTooManyRequests, writer.Result().StatusCode)
+}
+
 func TestWriteErrorMetricCount(t *testing.T) {
 	ctrl := xtest.NewController(t)
 	defer ctrl.Finish()
@@ -348,6 +422,272 @@ func TestPromWriteOpenMetricsTypes(t *testing.T) {
 	}, secondAnnotationPayload, "second annotation invalidated")
 }
 
+func TestPromWriteNativeHistograms(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		capturedIters []ingest.DownsampleAndWriteIter
+		capturedOpts  []ingest.WriteOptions
+	)
+	mockDownsamplerAndWriter := ingest.NewMockDownsamplerAndWriter(ctrl)
+	mockDownsamplerAndWriter.
+		EXPECT().
+		WriteBatch(gomock.Any(), gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, iter ingest.DownsampleAndWriteIter, opts ingest.WriteOptions) ingest.BatchError {
+			capturedIters = append(capturedIters, iter)
+			capturedOpts = append(capturedOpts, opts)
+			return nil
+		}).
+		Times(2)
+
+	opts := makeOptions(mockDownsamplerAndWriter)
+
+	promReq := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels:  []prompb.Label{{Name: []byte("__name__"), Value: []byte("floats")}},
+				Samples: []prompb.Sample{{Value: 1, Timestamp: 1000}},
+			},
+			{
+				Labels: []prompb.Label{{Name: []byte("__name__"), Value: []byte("latency")}},
+				Histograms: []prompb.Histogram{{
+					Count:          &prompb.Histogram_CountInt{CountInt: 5},
+					Sum:            9,
+					ZeroCount:      &prompb.Histogram_ZeroCountInt{ZeroCountInt: 0},
+					PositiveSpans:  []prompb.BucketSpan{{Offset: 1, Length: 2}},
+					PositiveDeltas: []int64{4, -3},
+					Timestamp:      2000,
+				}},
+			},
+		},
+	}
+
+	executeWriteRequest(t, opts, promReq)
+	require.Len(t, capturedIters, 2)
+
+	floatIter := capturedIters[0]
+	require.True(t, floatIter.Next())
+	assert.Equal(t, 1.0, floatIter.Current().Datapoints[0].Value)
+	require.False(t, floatIter.Next())
+	assert.False(t, capturedOpts[0].DownsampleOverride)
+
+	histogramIter := capturedIters[1]
+	require.True(t, histogramIter.Next())
+	value := histogramIter.Current()
+	require.Len(t, value.Datapoints, 1)
+	assert.Equal(t, 5.0, value.Datapoints[0].Value)
+	assert.Equal(t, xtime.UnixNano(2*time.Second), value.Datapoints[0].Timestamp)
+
+	h, err := histogram.DecodeAnnotation(value.Annotation)
+	require.NoError(t, err)
+	assert.Equal(t, []float64{4, 1}, h.PositiveBuckets)
+	assert.Equal(t, 9.0, h.Sum)
+	require.False(t, histogramIter.Next())
+	assert.True(t, capturedOpts[1].DownsampleOverride)
+	assert.Empty(t, capturedOpts[1].DownsampleMappingRules)
+}
+
+func TestPromWriteInvalidNativeHistogram(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	mockDownsamplerAndWriter := ingest.NewMockDownsamplerAndWriter(ctrl)
+	mockDownsamplerAndWriter.
+		EXPECT().
+		WriteBatch(gomock.Any(), gomock.Any(), gomock.Any()).
+		AnyTimes()
+
+	handler, err := NewPromWriteHandler(makeOptions(mockDownsamplerAndWriter))
+	require.NoError(t, err)
+
+	promReq := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{{
+			Labels: []prompb.Label{{Name: []byte("__name__"), Value: []byte("latency")}},
+			Histograms: []prompb.Histogram{{
+				Schema:         100,
+				Count:          &prompb.Histogram_CountInt{CountInt: 1},
+				PositiveSpans:  []prompb.BucketSpan{{Offset: 1, Length: 1}},
+				PositiveDeltas: []int64{1},
+			}},
+		}},
+	}
+
+	promReqBody := test.GeneratePromWriteRequestBody(t, promReq)
+	req := httptest.NewRequest(PromWriteHTTPMethod, PromWriteURL, promReqBody)
+
+	writer := httptest.NewRecorder()
+	handler.ServeHTTP(writer, req)
+	assert.Equal(t, http.StatusBadRequest, writer.Result().StatusCode)
+}
+
+func TestPromWriteInvalidNativeHistogramKeepsBatchErrors(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	var batchErr xerrors.MultiError
+	batchErr = batchErr.Add(errors.New("sample write error"))
+	mockDownsamplerAndWriter := ingest.NewMockDownsamplerAndWriter(ctrl)
+	mockDownsamplerAndWriter.
+		EXPECT().
+		WriteBatch(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(batchErr)
+
+	handler, err := NewPromWriteHandler(makeOptions(mockDownsamplerAndWriter))
+	require.NoError(t, err)
+
+	promReq := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels:  []prompb.Label{{Name: []byte("__name__"), Value: []byte("floats")}},
+				Samples: []prompb.Sample{{Value: 1, Timestamp: 1000}},
+			},
+			{
+				Labels: []prompb.Label{{Name: []byte("__name__"), Value: []byte("latency")}},
+				Histograms: []prompb.Histogram{{
+					Schema:         100,
+					Count:          &prompb.Histogram_CountInt{CountInt: 1},
+					PositiveSpans:  []prompb.BucketSpan{{Offset: 1, Length: 1}},
+					PositiveDeltas: []int64{1},
+				}},
+			},
+		},
+	}
+
+	_, writeErr := handler.(*PromWriteHandler).write(context.Background(), promReq,
+		ingest.WriteOptions{}, nil)
+	require.NotNil(t, writeErr)
+	errs := writeErr.Errors()
+	require.Len(t, errs, 2)
+	var numInvalidParams int
+	for _, err := range errs {
+		if xerrors.IsInvalidParams(err) {
+			numInvalidParams++
+			continue
+		}
+		assert.EqualError(t, err, "sample write error")
+	}
+	assert.Equal(t, 1, numInvalidParams)
+}
+
+func TestPromWriteExemplars(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	mockDownsamplerAndWriter := ingest.NewMockDownsamplerAndWriter(ctrl)
+	mockDownsamplerAndWriter.
+		EXPECT().
+		WriteBatch(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	var written []exemplar.SeriesExemplars
+	mockExemplarStore := exemplar.NewMockStore(ctrl)
+	mockExemplarStore.EXPECT().Write(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, series []exemplar.SeriesExemplars) error {
+			written = series
+			// Exemplar write errors do not fail the request.
+			return errors.New("exemplar write error")
+		})
+
+	opts := makeOptions(mockDownsamplerAndWriter).SetExemplarStore(mockExemplarStore)
+	executeWriteRequest(t, opts, &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels:  []prompb.Label{{Name: []byte("__name__"), Value: []byte("requests_total")}},
+				Samples: []prompb.Sample{{Value: 5, Timestamp: 2000}},
+				Exemplars: []prompb.Exemplar{{
+					Labels:    []prompb.Label{{Name: []byte("trace_id"), Value: []byte("abc")}},
+					Value:     1,
+					Timestamp: 1500,
+				}},
+			},
+			{
+				Labels:  []prompb.Label{{Name: []byte("__name__"), Value: []byte("no_exemplars")}},
+				Samples: []prompb.Sample{{Value: 1, Timestamp: 2000}},
+			},
+		},
+	})
+
+	require.Len(t, written, 1)
+	tags := written[0].Tags
+	name, ok := tags.Name()
+	require.True(t, ok)
+	assert.Equal(t, "requests_total", string(name))
+
+	require.Len(t, written[0].Exemplars, 1)
+	e := written[0].Exemplars[0]
+	assert.Equal(t, 1.0, e.Value)
+	assert.Equal(t, xtime.UnixNano(1500*time.Millisecond), e.Timestamp)
+	traceID, ok := e.Labels.Get([]byte("trace_id"))
+	require.True(t, ok)
+	assert.Equal(t, "abc", string(traceID))
+}
+
+func TestPromWriteMetadata(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	mockDownsamplerAndWriter := ingest.NewMockDownsamplerAndWriter(ctrl)
+	mockDownsamplerAndWriter.
+		EXPECT().
+		WriteBatch(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	mockMetadataStore := prommetadata.NewMockStore(ctrl)
+	mockMetadataStore.EXPECT().Write([]prommetadata.Metadata{{
+		Metric: "requests_total",
+		Type:   prommetadata.TypeCounter,
+		Help:   "Total requests.",
+		Unit:   "requests",
+	}})
+
+	opts := makeOptions(mockDownsamplerAndWriter).SetMetadataStore(mockMetadataStore)
+	executeWriteRequest(t, opts, &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{{
+			Labels:  []prompb.Label{{Name: []byte("__name__"), Value: []byte("requests_total")}},
+			Samples: []prompb.Sample{{Value: 5, Timestamp: 2000}},
+		}},
+		Metadata: []prompb.MetricMetadata{{
+			Type:             prompb.MetricType_COUNTER,
+			MetricFamilyName: "requests_total",
+			Help:             "Total requests.",
+			Unit:             "requests",
+		}},
+	})
+}
+
+func TestPromWriteMetadataTenant(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	mockDownsamplerAndWriter := ingest.NewMockDownsamplerAndWriter(ctrl)
+	mockDownsamplerAndWriter.
+		EXPECT().
+		WriteBatch(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	// The metadata of the writes of a tenant is not stored.
+	mockMetadataStore := prommetadata.NewMockStore(ctrl)
+
+	opts := makeOptions(mockDownsamplerAndWriter).SetMetadataStore(mockMetadataStore)
+	handler, err := NewPromWriteHandler(opts)
+	require.NoError(t, err)
+
+	promReq := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{{
+			Labels:  []prompb.Label{{Name: []byte("__name__"), Value: []byte("requests_total")}},
+			Samples: []prompb.Sample{{Value: 5, Timestamp: 2000}},
+		}},
+		Metadata: []prompb.MetricMetadata{{
+			Type:             prompb.MetricType_COUNTER,
+			MetricFamilyName: "requests_total",
+		}},
+	}
+	_, writeErr := handler.(*PromWriteHandler).write(context.Background(), promReq,
+		ingest.WriteOptions{}, &tenant.Tenant{Name: "team-a"})
+	require.Nil(t, writeErr)
+}
+
 func TestPromWriteGraphiteMetricsTypes(t *testing.T) {
 	ctrl := xtest.NewController(t)
 	defer ctrl.Finish()
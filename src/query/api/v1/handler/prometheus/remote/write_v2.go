@@ -0,0 +1,367 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package remote
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/m3db/m3/src/query/generated/proto/prompb"
+	"github.com/m3db/m3/src/query/generated/proto/prompbv2"
+	"github.com/m3db/m3/src/x/headers"
+)
+
+// remoteWriteProtocol is the protocol version of a remote write request.
+type remoteWriteProtocol uint8
+
+const (
+	remoteWriteProtocolV1 remoteWriteProtocol = iota
+	remoteWriteProtocolV2
+)
+
+const (
+	// remoteWriteProtoV1 is the proto message name of remote write 1.0
+	// requests, given by the proto parameter of the content type.
+	remoteWriteProtoV1 = "prometheus.WriteRequest"
+	// remoteWriteProtoV2 is the proto message name of remote write 2.0
+	// requests, given by the proto parameter of the content type.
+	remoteWriteProtoV2 = "io.prometheus.write.v2.Request"
+
+	// metricNameLabel is the label holding the metric name.
+	metricNameLabel = "__name__"
+	// quantileLabel is the label holding the quantile of summary series.
+	quantileLabel = "quantile"
+
+	// createdTimestampWindow is how recent a created timestamp must be for a
+	// zero sample to be written at it, it matches the default buffer past of
+	// namespaces. Senders repeat the created timestamp with every request, a
+	// zero sample at an older created timestamp would be out of bounds and
+	// fail the write or replace the value previously written at it.
+	createdTimestampWindow = 10 * time.Minute
+)
+
+var (
+	errUnsupportedRemoteWriteProtocol = errors.New("unsupported remote write protocol")
+	errInvalidSymbolTable             = errors.New("remote write symbols must start with an empty string")
+	errOddLabelRefs                   = errors.New("remote write label refs must have an even length")
+)
+
+// promWriteStats are the number of values written for a remote write request,
+// reported to remote write 2.0 senders with the response headers.
+type promWriteStats struct {
+	samples    int
+	histograms int
+	exemplars  int
+}
+
+// parseRemoteWriteProtocol returns the remote write protocol of a request
+// from its content type. Requests without a protobuf content type are treated
+// as remote write 1.0 requests for backwards compatibility.
+func parseRemoteWriteProtocol(contentType string) (remoteWriteProtocol, error) {
+	if contentType == "" {
+		return remoteWriteProtocolV1, nil
+	}
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType != "application/x-protobuf" {
+		return remoteWriteProtocolV1, nil
+	}
+
+	switch proto := params["proto"]; proto {
+	case "", remoteWriteProtoV1:
+		return remoteWriteProtocolV1, nil
+	case remoteWriteProtoV2:
+		return remoteWriteProtocolV2, nil
+	default:
+		return remoteWriteProtocolV1, fmt.Errorf("%w: %s", errUnsupportedRemoteWriteProtocol, proto)
+	}
+}
+
+// setPromWriteStatsHeaders sets the remote write 2.0 response headers.
+func setPromWriteStatsHeaders(h http.Header, stats promWriteStats) {
+	h.Set(headers.PromRemoteWriteSamplesWrittenHeader, strconv.Itoa(stats.samples))
+	h.Set(headers.PromRemoteWriteHistogramsWrittenHeader, strconv.Itoa(stats.histograms))
+	h.Set(headers.PromRemoteWriteExemplarsWrittenHeader, strconv.Itoa(stats.exemplars))
+}
+
+// promWriteRequestV2ToV1 converts a remote write 2.0 request to the remote
+// write 1.0 representation the write path uses, resolving label and metadata
+// references against the request symbol table.
+func promWriteRequestV2ToV1(
+	req *prompbv2.Request,
+	now time.Time,
+) (*prompb.WriteRequest, error) {
+	symbols := req.Symbols
+	if len(symbols) > 0 && symbols[0] != "" {
+		return nil, errInvalidSymbolTable
+	}
+
+	var (
+		result = &prompb.WriteRequest{
+			Timeseries: make([]prompb.TimeSeries, 0, len(req.Timeseries)),
+		}
+		metadataByFamily = make(map[string]struct{})
+	)
+	for _, series := range req.Timeseries {
+		labels, err := resolveLabelRefs(symbols, series.LabelsRefs)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(series.Samples) > 0 && len(series.Histograms) > 0 {
+			return nil, fmt.Errorf("series %s has both samples and histograms",
+				seriesName(labels))
+		}
+
+		for _, ref := range []uint32{series.Metadata.HelpRef, series.Metadata.UnitRef} {
+			if int(ref) >= len(symbols) {
+				return nil, fmt.Errorf("metadata symbol ref %d out of range", ref)
+			}
+		}
+
+		promType := promMetadataTypeV2ToV1(series.Metadata.Type)
+		if metadata, ok := promMetadataV2ToV1(symbols, series.Metadata, promType,
+			seriesName(labels)); ok {
+			if _, ok := metadataByFamily[metadata.MetricFamilyName]; !ok {
+				metadataByFamily[metadata.MetricFamilyName] = struct{}{}
+				result.Metadata = append(result.Metadata, metadata)
+			}
+		}
+
+		converted := prompb.TimeSeries{
+			Labels:     labels,
+			Samples:    make([]prompb.Sample, 0, len(series.Samples)+1),
+			Exemplars:  make([]prompb.Exemplar, 0, len(series.Exemplars)),
+			Histograms: make([]prompb.Histogram, 0, len(series.Histograms)),
+			Type:       promType,
+		}
+
+		for _, exemplar := range series.Exemplars {
+			exemplarLabels, err := resolveLabelRefs(symbols, exemplar.LabelsRefs)
+			if err != nil {
+				return nil, err
+			}
+
+			converted.Exemplars = append(converted.Exemplars, prompb.Exemplar{
+				Labels:    exemplarLabels,
+				Value:     exemplar.Value,
+				Timestamp: exemplar.Timestamp,
+			})
+		}
+
+		if ct := series.CreatedTimestamp; isRecentCreatedTimestamp(ct, now) &&
+			len(series.Samples) > 0 && ct < series.Samples[0].Timestamp &&
+			isCumulativeSeries(promType, labels) {
+			// NB: a zero sample at the created timestamp marks the start of the
+			// counter so the first increase is not lost by rate and increase.
+			converted.Samples = append(converted.Samples, prompb.Sample{Timestamp: ct})
+		}
+
+		for _, sample := range series.Samples {
+			converted.Samples = append(converted.Samples, prompb.Sample{
+				Value:     sample.Value,
+				Timestamp: sample.Timestamp,
+			})
+		}
+
+		for _, h := range series.Histograms {
+			converted.Histograms = append(converted.Histograms, promHistogramV2ToV1(h))
+		}
+
+		result.Timeseries = append(result.Timeseries, converted)
+	}
+
+	return result, nil
+}
+
+func resolveLabelRefs(symbols []string, refs []uint32) ([]prompb.Label, error) {
+	if len(refs)%2 != 0 {
+		return nil, errOddLabelRefs
+	}
+
+	labels := make([]prompb.Label, 0, len(refs)/2)
+	for i := 0; i < len(refs); i += 2 {
+		nameRef, valueRef := refs[i], refs[i+1]
+		if int(nameRef) >= len(symbols) || int(valueRef) >= len(symbols) {
+			return nil, fmt.Errorf("label symbol refs %d=%d out of range", nameRef, valueRef)
+		}
+
+		labels = append(labels, prompb.Label{
+			Name:  []byte(symbols[nameRef]),
+			Value: []byte(symbols[valueRef]),
+		})
+	}
+
+	return labels, nil
+}
+
+func seriesName(labels []prompb.Label) string {
+	for _, l := range labels {
+		if string(l.Name) == metricNameLabel {
+			return string(l.Value)
+		}
+	}
+	return ""
+}
+
+// isRecentCreatedTimestamp returns true if the created timestamp, in
+// milliseconds, is set and within the created timestamp window.
+func isRecentCreatedTimestamp(ct int64, now time.Time) bool {
+	return ct != 0 && ct >= now.Add(-createdTimestampWindow).UnixMilli()
+}
+
+// isCumulativeSeries returns true if the series values are cumulative and
+// start from zero at the created timestamp.
+func isCumulativeSeries(promType prompb.MetricType, labels []prompb.Label) bool {
+	switch promType {
+	case prompb.MetricType_COUNTER, prompb.MetricType_HISTOGRAM:
+		return true
+	case prompb.MetricType_SUMMARY:
+		// Only the _sum and _count series of summaries are cumulative.
+		for _, l := range labels {
+			if string(l.Name) == quantileLabel {
+				return false
+			}
+		}
+		return strings.HasSuffix(seriesName(labels), "_sum") ||
+			strings.HasSuffix(seriesName(labels), "_count")
+	default:
+		return false
+	}
+}
+
+func promMetadataTypeV2ToV1(t prompbv2.Metadata_MetricType) prompb.MetricType {
+	switch t {
+	case prompbv2.Metadata_METRIC_TYPE_COUNTER:
+		return prompb.MetricType_COUNTER
+	case prompbv2.Metadata_METRIC_TYPE_GAUGE:
+		return prompb.MetricType_GAUGE
+	case prompbv2.Metadata_METRIC_TYPE_HISTOGRAM:
+		return prompb.MetricType_HISTOGRAM
+	case prompbv2.Metadata_METRIC_TYPE_GAUGEHISTOGRAM:
+		return prompb.MetricType_GAUGE_HISTOGRAM
+	case prompbv2.Metadata_METRIC_TYPE_SUMMARY:
+		return prompb.MetricType_SUMMARY
+	case prompbv2.Metadata_METRIC_TYPE_INFO:
+		return prompb.MetricType_INFO
+	case prompbv2.Metadata_METRIC_TYPE_STATESET:
+		return prompb.MetricType_STATESET
+	default:
+		return prompb.MetricType_UNKNOWN
+	}
+}
+
+// promMetadataV2ToV1 returns the metric family metadata of a series, false if
+// the series has no metadata.
+func promMetadataV2ToV1(
+	symbols []string,
+	metadata prompbv2.Metadata,
+	promType prompb.MetricType,
+	name string,
+) (prompb.MetricMetadata, bool) {
+	if name == "" || (metadata.Type == prompbv2.Metadata_METRIC_TYPE_UNSPECIFIED &&
+		metadata.HelpRef == 0 && metadata.UnitRef == 0) {
+		return prompb.MetricMetadata{}, false
+	}
+
+	return prompb.MetricMetadata{
+		Type:             promType,
+		MetricFamilyName: metricFamilyName(name, promType),
+		Help:             symbols[metadata.HelpRef],
+		Unit:             symbols[metadata.UnitRef],
+	}, true
+}
+
+// metricFamilyName returns the metric family name of a series, the series of
+// histograms and summaries have a suffix added to the family name.
+func metricFamilyName(name string, promType prompb.MetricType) string {
+	var suffixes []string
+	switch promType {
+	case prompb.MetricType_HISTOGRAM, prompb.MetricType_GAUGE_HISTOGRAM:
+		suffixes = []string{"_bucket", "_sum", "_count"}
+	case prompb.MetricType_SUMMARY:
+		suffixes = []string{"_sum", "_count"}
+	}
+
+	for _, suffix := range suffixes {
+		if family := strings.TrimSuffix(name, suffix); family != name {
+			return family
+		}
+	}
+	return name
+}
+
+func promHistogramV2ToV1(h prompbv2.Histogram) prompb.Histogram {
+	result := prompb.Histogram{
+		Sum:            h.Sum,
+		Schema:         h.Schema,
+		ZeroThreshold:  h.ZeroThreshold,
+		NegativeSpans:  promSpansV2ToV1(h.NegativeSpans),
+		NegativeDeltas: h.NegativeDeltas,
+		NegativeCounts: h.NegativeCounts,
+		PositiveSpans:  promSpansV2ToV1(h.PositiveSpans),
+		PositiveDeltas: h.PositiveDeltas,
+		PositiveCounts: h.PositiveCounts,
+		Timestamp:      h.Timestamp,
+	}
+
+	switch count := h.Count.(type) {
+	case *prompbv2.Histogram_CountInt:
+		result.Count = &prompb.Histogram_CountInt{CountInt: count.CountInt}
+	case *prompbv2.Histogram_CountFloat:
+		result.Count = &prompb.Histogram_CountFloat{CountFloat: count.CountFloat}
+	}
+
+	switch count := h.ZeroCount.(type) {
+	case *prompbv2.Histogram_ZeroCountInt:
+		result.ZeroCount = &prompb.Histogram_ZeroCountInt{ZeroCountInt: count.ZeroCountInt}
+	case *prompbv2.Histogram_ZeroCountFloat:
+		result.ZeroCount = &prompb.Histogram_ZeroCountFloat{ZeroCountFloat: count.ZeroCountFloat}
+	}
+
+	switch h.ResetHint {
+	case prompbv2.Histogram_RESET_HINT_YES:
+		result.ResetHint = prompb.Histogram_YES
+	case prompbv2.Histogram_RESET_HINT_NO:
+		result.ResetHint = prompb.Histogram_NO
+	case prompbv2.Histogram_RESET_HINT_GAUGE:
+		result.ResetHint = prompb.Histogram_GAUGE
+	}
+
+	return result
+}
+
+func promSpansV2ToV1(spans []prompbv2.BucketSpan) []prompb.BucketSpan {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	result := make([]prompb.BucketSpan, 0, len(spans))
+	for _, span := range spans {
+		result = append(result, prompb.BucketSpan{Offset: span.Offset, Length: span.Length})
+	}
+	return result
+}
@@ -0,0 +1,302 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package remote
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/remote/test"
+	"github.com/m3db/m3/src/query/generated/proto/prompb"
+	"github.com/m3db/m3/src/query/generated/proto/prompbv2"
+	"github.com/m3db/m3/src/query/storage/exemplar"
+	"github.com/m3db/m3/src/x/headers"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+	xtest "github.com/m3db/m3/src/x/test"
+)
+
+const testContentTypeV2 = "application/x-protobuf;proto=io.prometheus.write.v2.Request"
+
+// testPromWriteV2Now is the time remote write 2.0 test requests are converted
+// at, the test created timestamps are within the created timestamp window.
+var testPromWriteV2Now = time.UnixMilli(3000)
+
+func TestParseRemoteWriteProtocol(t *testing.T) {
+	tests := []struct {
+		contentType string
+		expected    remoteWriteProtocol
+		expectedErr bool
+	}{
+		{contentType: "", expected: remoteWriteProtocolV1},
+		{contentType: "application/x-protobuf", expected: remoteWriteProtocolV1},
+		{contentType: "application/x-protobuf;proto=prometheus.WriteRequest", expected: remoteWriteProtocolV1},
+		{contentType: testContentTypeV2, expected: remoteWriteProtocolV2},
+		{contentType: "application/x-protobuf; proto=io.prometheus.write.v2.Request", expected: remoteWriteProtocolV2},
+		{contentType: "application/x-protobuf;proto=io.prometheus.write.v3.Request", expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.contentType, func(t *testing.T) {
+			protocol, err := parseRemoteWriteProtocol(tt.contentType)
+			if tt.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, protocol)
+		})
+	}
+}
+
+func newTestPromWriteV2Request() *prompbv2.Request {
+	return &prompbv2.Request{
+		Symbols: []string{"", "__name__", "requests_total", "job", "api", "latency",
+			"help text", "trace_id", "abc"},
+		Timeseries: []prompbv2.TimeSeries{
+			{
+				LabelsRefs:       []uint32{1, 2, 3, 4},
+				Samples:          []prompbv2.Sample{{Value: 3, Timestamp: 2000}, {Value: 5, Timestamp: 3000}},
+				Exemplars:        []prompbv2.Exemplar{{LabelsRefs: []uint32{7, 8}, Value: 1, Timestamp: 2000}},
+				Metadata:         prompbv2.Metadata{Type: prompbv2.Metadata_METRIC_TYPE_COUNTER, HelpRef: 6},
+				CreatedTimestamp: 1000,
+			},
+			{
+				LabelsRefs: []uint32{1, 5, 3, 4},
+				Histograms: []prompbv2.Histogram{{
+					Count:          &prompbv2.Histogram_CountInt{CountInt: 5},
+					Sum:            9,
+					ZeroCount:      &prompbv2.Histogram_ZeroCountInt{ZeroCountInt: 0},
+					PositiveSpans:  []prompbv2.BucketSpan{{Offset: 1, Length: 2}},
+					PositiveDeltas: []int64{4, -3},
+					ResetHint:      prompbv2.Histogram_RESET_HINT_NO,
+					Timestamp:      2000,
+				}},
+				Metadata: prompbv2.Metadata{Type: prompbv2.Metadata_METRIC_TYPE_HISTOGRAM},
+			},
+		},
+	}
+}
+
+func TestPromWriteRequestV2ToV1(t *testing.T) {
+	req, err := promWriteRequestV2ToV1(newTestPromWriteV2Request(), testPromWriteV2Now)
+	require.NoError(t, err)
+	require.Len(t, req.Timeseries, 2)
+
+	counter := req.Timeseries[0]
+	assert.Equal(t, []prompb.Label{
+		{Name: []byte("__name__"), Value: []byte("requests_total")},
+		{Name: []byte("job"), Value: []byte("api")},
+	}, counter.Labels)
+	assert.Equal(t, prompb.MetricType_COUNTER, counter.Type)
+	// The created timestamp adds a zero sample before the first sample.
+	assert.Equal(t, []prompb.Sample{
+		{Value: 0, Timestamp: 1000},
+		{Value: 3, Timestamp: 2000},
+		{Value: 5, Timestamp: 3000},
+	}, counter.Samples)
+	assert.Equal(t, []prompb.Exemplar{{
+		Labels:    []prompb.Label{{Name: []byte("trace_id"), Value: []byte("abc")}},
+		Value:     1,
+		Timestamp: 2000,
+	}}, counter.Exemplars)
+
+	hist := req.Timeseries[1]
+	assert.Equal(t, prompb.MetricType_HISTOGRAM, hist.Type)
+	assert.Empty(t, hist.Samples)
+	require.Len(t, hist.Histograms, 1)
+	assert.Equal(t, prompb.Histogram{
+		Count:          &prompb.Histogram_CountInt{CountInt: 5},
+		Sum:            9,
+		ZeroCount:      &prompb.Histogram_ZeroCountInt{ZeroCountInt: 0},
+		PositiveSpans:  []prompb.BucketSpan{{Offset: 1, Length: 2}},
+		PositiveDeltas: []int64{4, -3},
+		ResetHint:      prompb.Histogram_NO,
+		Timestamp:      2000,
+	}, hist.Histograms[0])
+
+	assert.Equal(t, []prompb.MetricMetadata{
+		{
+			Type:             prompb.MetricType_COUNTER,
+			MetricFamilyName: "requests_total",
+			Help:             "help text",
+		},
+		{
+			Type:             prompb.MetricType_HISTOGRAM,
+			MetricFamilyName: "latency",
+		},
+	}, req.Metadata)
+}
+
+func TestPromWriteRequestV2ToV1CreatedTimestamp(t *testing.T) {
+	req := newTestPromWriteV2Request()
+	req.Timeseries = req.Timeseries[:1]
+
+	// Created timestamps are ignored for gauges.
+	req.Timeseries[0].Metadata.Type = prompbv2.Metadata_METRIC_TYPE_GAUGE
+	converted, err := promWriteRequestV2ToV1(req, testPromWriteV2Now)
+	require.NoError(t, err)
+	assert.Len(t, converted.Timeseries[0].Samples, 2)
+
+	// Created timestamps are ignored if not before the first sample.
+	req.Timeseries[0].Metadata.Type = prompbv2.Metadata_METRIC_TYPE_COUNTER
+	req.Timeseries[0].CreatedTimestamp = 2000
+	converted, err = promWriteRequestV2ToV1(req, testPromWriteV2Now)
+	require.NoError(t, err)
+	assert.Len(t, converted.Timeseries[0].Samples, 2)
+
+	// Created timestamps older than the window are ignored, they are sent
+	// with every request for long running counters.
+	req.Timeseries[0].CreatedTimestamp = 1000
+	now := time.UnixMilli(1000).Add(createdTimestampWindow + time.Millisecond)
+	converted, err = promWriteRequestV2ToV1(req, now)
+	require.NoError(t, err)
+	assert.Equal(t, []prompb.Sample{
+		{Value: 3, Timestamp: 2000},
+		{Value: 5, Timestamp: 3000},
+	}, converted.Timeseries[0].Samples)
+
+	// Created timestamps at the edge of the window are kept.
+	now = time.UnixMilli(1000).Add(createdTimestampWindow)
+	converted, err = promWriteRequestV2ToV1(req, now)
+	require.NoError(t, err)
+	assert.Len(t, converted.Timeseries[0].Samples, 3)
+}
+
+func TestPromWriteRequestV2ToV1Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(req *prompbv2.Request)
+	}{
+		{
+			name:   "non empty first symbol",
+			mutate: func(req *prompbv2.Request) { req.Symbols[0] = "foo" },
+		},
+		{
+			name:   "odd label refs",
+			mutate: func(req *prompbv2.Request) { req.Timeseries[0].LabelsRefs = []uint32{1} },
+		},
+		{
+			name:   "label ref out of range",
+			mutate: func(req *prompbv2.Request) { req.Timeseries[0].LabelsRefs = []uint32{1, 100} },
+		},
+		{
+			name:   "metadata ref out of range",
+			mutate: func(req *prompbv2.Request) { req.Timeseries[0].Metadata.UnitRef = 100 },
+		},
+		{
+			name: "exemplar ref out of range",
+			mutate: func(req *prompbv2.Request) {
+				req.Timeseries[0].Exemplars[0].LabelsRefs = []uint32{100, 1}
+			},
+		},
+		{
+			name: "samples and histograms",
+			mutate: func(req *prompbv2.Request) {
+				req.Timeseries[0].Histograms = req.Timeseries[1].Histograms
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newTestPromWriteV2Request()
+			tt.mutate(req)
+			_, err := promWriteRequestV2ToV1(req, testPromWriteV2Now)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestPromWriteV2(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	var numWritten int
+	mockDownsamplerAndWriter := ingest.NewMockDownsamplerAndWriter(ctrl)
+	mockDownsamplerAndWriter.
+		EXPECT().
+		WriteBatch(gomock.Any(), gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, iter ingest.DownsampleAndWriteIter, _ ingest.WriteOptions) ingest.BatchError {
+			for iter.Next() {
+				numWritten += len(iter.Current().Datapoints)
+			}
+			return nil
+		}).
+		Times(2)
+
+	var exemplars []exemplar.SeriesExemplars
+	mockExemplarStore := exemplar.NewMockStore(ctrl)
+	mockExemplarStore.EXPECT().Write(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, series []exemplar.SeriesExemplars) error {
+			exemplars = series
+			return nil
+		})
+
+	opts := makeOptions(mockDownsamplerAndWriter).
+		SetExemplarStore(mockExemplarStore).
+		SetNowFn(func() time.Time { return testPromWriteV2Now })
+	handler, err := NewPromWriteHandler(opts)
+	require.NoError(t, err)
+
+	body := test.GeneratePromWriteV2RequestBody(t, newTestPromWriteV2Request())
+	req := httptest.NewRequest(PromWriteHTTPMethod, PromWriteURL, body)
+	req.Header.Set(xhttp.HeaderContentType, testContentTypeV2)
+
+	writer := httptest.NewRecorder()
+	handler.ServeHTTP(writer, req)
+	resp := writer.Result()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	assert.Equal(t, 4, numWritten)
+	assert.Equal(t, "3", resp.Header.Get(headers.PromRemoteWriteSamplesWrittenHeader))
+	assert.Equal(t, "1", resp.Header.Get(headers.PromRemoteWriteHistogramsWrittenHeader))
+	assert.Equal(t, "1", resp.Header.Get(headers.PromRemoteWriteExemplarsWrittenHeader))
+
+	require.Len(t, exemplars, 1)
+	assert.Equal(t, "requests_total", string(exemplars[0].Tags.Tags[0].Value))
+	require.Len(t, exemplars[0].Exemplars, 1)
+	assert.Equal(t, 1.0, exemplars[0].Exemplars[0].Value)
+	assert.Equal(t, "trace_id", string(exemplars[0].Exemplars[0].Labels.Tags[0].Name))
+}
+
+func TestPromWriteV2UnsupportedProtocol(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	handler, err := NewPromWriteHandler(makeOptions(ingest.NewMockDownsamplerAndWriter(ctrl)))
+	require.NoError(t, err)
+
+	body := test.GeneratePromWriteV2RequestBody(t, newTestPromWriteV2Request())
+	req := httptest.NewRequest(PromWriteHTTPMethod, PromWriteURL, body)
+	req.Header.Set(xhttp.HeaderContentType, "application/x-protobuf;proto=io.prometheus.write.v3.Request")
+
+	writer := httptest.NewRecorder()
+	handler.ServeHTTP(writer, req)
+	assert.Equal(t, http.StatusUnsupportedMediaType, writer.Result().StatusCode)
+}
@@ -0,0 +1,370 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package resultscache
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+
+	"github.com/m3db/m3/src/x/headers"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+)
+
+const (
+	defaultSplitInterval = 24 * time.Hour
+	defaultMaxFreshness  = 10 * time.Minute
+
+	cacheControlHeader = "Cache-Control"
+)
+
+// limitedHeaders are the response headers set when results are incomplete.
+var limitedHeaders = []string{
+	headers.LimitHeader,
+	headers.ReturnedDataLimitedHeader,
+	headers.WarningsHeader,
+}
+
+type handlerMetrics struct {
+	hits        tally.Counter
+	misses      tally.Counter
+	executions  tally.Counter
+	uncacheable tally.Counter
+	errors      tally.Counter
+}
+
+func newHandlerMetrics(scope tally.Scope) handlerMetrics {
+	return handlerMetrics{
+		hits:        scope.Counter("hits"),
+		misses:      scope.Counter("misses"),
+		executions:  scope.Counter("executions"),
+		uncacheable: scope.Counter("uncacheable"),
+		errors:      scope.Counter("errors"),
+	}
+}
+
+type handler struct {
+	base          http.Handler
+	backend       Backend
+	keyPrefix     string
+	splitInterval int64
+	maxFreshness  time.Duration
+	nowFn         func() time.Time
+	logger        *zap.Logger
+	metrics       handlerMetrics
+}
+
+// NewHandler returns a handler that serves range queries from the results
+// cache, only the parts of queries that are not cached are executed by the
+// base handler. Results are cached per split interval once the interval is
+// older than the max freshness, taking negative offsets and the @ modifier
+// into account. Requests with a "Cache-Control: no-cache" header bypass the
+// cache.
+func NewHandler(base http.Handler, opts Options) http.Handler {
+	splitInterval := opts.SplitInterval
+	if splitInterval <= 0 {
+		splitInterval = defaultSplitInterval
+	}
+	maxFreshness := opts.MaxFreshness
+	if maxFreshness <= 0 {
+		maxFreshness = defaultMaxFreshness
+	}
+	nowFn := opts.NowFn
+	if nowFn == nil {
+		nowFn = time.Now
+	}
+	iOpts := opts.InstrumentOptions
+	if iOpts == nil {
+		iOpts = instrument.NewOptions()
+	}
+
+	return &handler{
+		base:          base,
+		backend:       opts.Backend,
+		keyPrefix:     opts.KeyPrefix,
+		splitInterval: splitInterval.Milliseconds(),
+		maxFreshness:  maxFreshness,
+		nowFn:         nowFn,
+		logger:        iOpts.Logger(),
+		metrics:       newHandlerMetrics(iOpts.MetricsScope().SubScope("results-cache")),
+	}
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.backend == nil || bypassCache(r) {
+		h.base.ServeHTTP(w, r)
+		return
+	}
+
+	now := h.nowFn()
+	q, err := parseRangeQuery(r, now)
+	if err != nil {
+		// Let the base handler respond to invalid requests.
+		h.metrics.uncacheable.Inc(1)
+		h.base.ServeHTTP(w, r)
+		return
+	}
+
+	cutoff := toMillis(now.Add(-h.maxFreshness))
+	extents := splitRangeQuery(q, h.splitInterval, cutoff)
+	if len(extents) == 0 {
+		h.metrics.uncacheable.Inc(1)
+		h.base.ServeHTTP(w, r)
+		return
+	}
+
+	var (
+		ctx     = r.Context()
+		keys    = make([]string, len(extents))
+		fetch   = make([]string, 0, len(extents))
+		results = make([][]series, len(extents))
+		cached  = make([]bool, len(extents))
+	)
+	for i, e := range extents {
+		if e.cacheable {
+			keys[i] = q.cacheKey(h.keyPrefix, h.splitInterval, e)
+			fetch = append(fetch, keys[i])
+		}
+	}
+	values := h.backend.Fetch(ctx, fetch)
+	for i, e := range extents {
+		if !e.cacheable {
+			continue
+		}
+		value, ok := values[keys[i]]
+		if ok {
+			if err := json.Unmarshal(value, &results[i]); err != nil {
+				h.logger.Warn("unable to decode cached results", zap.Error(err))
+				ok = false
+			}
+		}
+		cached[i] = ok
+		if ok {
+			h.metrics.hits.Inc(1)
+		} else {
+			h.metrics.misses.Inc(1)
+		}
+	}
+
+	var (
+		warnings []string
+		store    = make(map[string][]byte)
+	)
+	for i := 0; i < len(extents); {
+		if cached[i] {
+			i++
+			continue
+		}
+
+		// Execute consecutive extents that are not cached with one query,
+		// cacheable extents are executed in full so that they can be cached.
+		j := i
+		for j < len(extents) && !cached[j] {
+			j++
+		}
+		run := extents[i:j]
+		start, end := run[0].start, run[len(run)-1].end
+		if run[0].cacheable {
+			start = run[0].fullStart
+		}
+		if run[len(run)-1].cacheable {
+			end = run[len(run)-1].fullEnd
+		}
+
+		h.metrics.executions.Inc(1)
+		rec := h.execute(r, q, start, end)
+		if rec.status != http.StatusOK {
+			rec.writeTo(w)
+			return
+		}
+		resp, err := decodeResponse(rec.body.Bytes())
+		if err != nil {
+			h.logger.Error("unable to decode query results", zap.Error(err))
+			h.metrics.errors.Inc(1)
+			rec.writeTo(w)
+			return
+		}
+		split, err := splitResult(resp.Data.Result, run)
+		if err != nil {
+			h.logger.Error("unable to split query results", zap.Error(err))
+			h.metrics.errors.Inc(1)
+			rec.writeTo(w)
+			return
+		}
+
+		// Incomplete results are returned but not cached.
+		complete := len(resp.Warnings) == 0 && !limited(rec.header)
+		for k, e := range run {
+			results[i+k] = split[k]
+			if !e.cacheable || !complete {
+				continue
+			}
+			value, err := json.Marshal(split[k])
+			if err != nil {
+				h.logger.Warn("unable to encode query results", zap.Error(err))
+				continue
+			}
+			store[keys[i+k]] = value
+		}
+
+		mergeHeaders(w.Header(), rec.header)
+		warnings = mergeWarnings(warnings, resp.Warnings)
+		i = j
+	}
+	if len(store) > 0 {
+		h.backend.Store(ctx, store)
+	}
+
+	builder := newMatrixBuilder()
+	for i, e := range extents {
+		if err := builder.add(results[i], e.start, e.end); err != nil {
+			h.logger.Error("unable to merge query results", zap.Error(err))
+			h.metrics.errors.Inc(1)
+			xhttp.WriteError(w, err)
+			return
+		}
+	}
+
+	xhttp.WriteJSONResponse(w, response{
+		Status: statusSuccess,
+		Data: responseData{
+			ResultType: matrixType,
+			Result:     builder.build(),
+		},
+		Warnings: warnings,
+	}, h.logger)
+}
+
+// execute executes the query between start and end with the base handler.
+func (h *handler) execute(r *http.Request, q rangeQuery, start, end int64) *responseRecorder {
+	params := make(url.Values, len(q.params))
+	for name, values := range q.params {
+		params[name] = values
+	}
+	params.Set(startParam, formatMillis(start))
+	params.Set(endParam, formatMillis(end))
+
+	req := r.Clone(r.Context())
+	req.Method = http.MethodGet
+	req.URL.RawQuery = params.Encode()
+	req.Body = http.NoBody
+	req.ContentLength = 0
+	req.Header.Del(xhttp.HeaderContentType)
+	req.Form = nil
+	req.PostForm = nil
+
+	rec := newResponseRecorder()
+	h.base.ServeHTTP(rec, req)
+	return rec
+}
+
+func bypassCache(r *http.Request) bool {
+	for _, v := range r.Header.Values(cacheControlHeader) {
+		if strings.Contains(v, "no-cache") || strings.Contains(v, "no-store") {
+			return true
+		}
+	}
+	return false
+}
+
+func limited(header http.Header) bool {
+	for _, name := range limitedHeaders {
+		if header.Get(name) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func mergeHeaders(dst, src http.Header) {
+	for name, values := range src {
+		for _, v := range values {
+			if !contains(dst.Values(name), v) {
+				dst.Add(name, v)
+			}
+		}
+	}
+}
+
+func mergeWarnings(dst, src []string) []string {
+	for _, v := range src {
+		if !contains(dst, v) {
+			dst = append(dst, v)
+		}
+	}
+	return dst
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// responseRecorder records the response of the base handler.
+type responseRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newResponseRecorder() *responseRecorder {
+	return &responseRecorder{header: make(http.Header)}
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.body.Write(b)
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+}
+
+func (r *responseRecorder) writeTo(w http.ResponseWriter) {
+	for name, values := range r.header {
+		w.Header()[name] = values
+	}
+	status := r.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+	_, _ = w.Write(r.body.Bytes())
+}
@@ -0,0 +1,260 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package resultscache
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/x/headers"
+)
+
+var testNow = time.Date(2020, time.September, 13, 12, 0, 0, 0, time.UTC)
+
+type testExecution struct {
+	start, end float64
+}
+
+// testBaseHandler renders a matrix with a series whose values are the
+// timestamps of its samples, and a series with samples before the
+// old cutoff only.
+type testBaseHandler struct {
+	executions []testExecution
+	warning    string
+	limited    bool
+}
+
+func (h *testBaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start, _ := strconv.ParseFloat(r.FormValue("start"), 64)
+	end, _ := strconv.ParseFloat(r.FormValue("end"), 64)
+	step, _ := time.ParseDuration(r.FormValue("step"))
+	h.executions = append(h.executions, testExecution{start: start, end: end})
+
+	var a, b []string
+	for t := start; t <= end; t += step.Seconds() {
+		v := fmt.Sprintf(`[%s,"%s"]`, strconv.FormatFloat(t, 'f', -1, 64),
+			strconv.FormatFloat(t, 'f', -1, 64))
+		a = append(a, v)
+		if t < float64(testNow.Add(-36*time.Hour).Unix()) {
+			b = append(b, v)
+		}
+	}
+	result := []string{fmt.Sprintf(`{"metric":{"__name__":"up","job":"a"},"values":[%s]}`,
+		strings.Join(a, ","))}
+	if len(b) > 0 {
+		result = append(result, fmt.Sprintf(`{"metric":{"job":"b","__name__":"up"},"values":[%s]}`,
+			strings.Join(b, ",")))
+	}
+
+	var warnings string
+	if h.warning != "" {
+		warnings = fmt.Sprintf(`,"warnings":[%q]`, h.warning)
+	}
+	if h.limited {
+		w.Header().Set(headers.LimitHeader, "true")
+	}
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintf(w, `{"status":"success","data":{"resultType":"matrix","result":[%s]}%s}`,
+		strings.Join(result, ","), warnings)
+}
+
+func newTestHandler(base http.Handler) http.Handler {
+	return NewHandler(base, Options{
+		Backend:       NewLRUBackend(LRUBackendOptions{}),
+		SplitInterval: 24 * time.Hour,
+		MaxFreshness:  10 * time.Minute,
+		NowFn:         func() time.Time { return testNow },
+	})
+}
+
+func queryRange(t *testing.T, h http.Handler, query string, start, end time.Time) (int, response) {
+	params := url.Values{
+		"query": []string{query},
+		"start": []string{strconv.FormatInt(start.Unix(), 10)},
+		"end":   []string{strconv.FormatInt(end.Unix(), 10)},
+		"step":  []string{"1h"},
+	}
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/query_range",
+		strings.NewReader(params.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	var resp response
+	if w.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	}
+	return w.Code, resp
+}
+
+func uncachedResponse(t *testing.T, query string, start, end time.Time) response {
+	code, resp := queryRange(t, &testBaseHandler{}, query, start, end)
+	require.Equal(t, http.StatusOK, code)
+	return resp
+}
+
+func requireEqualResults(t *testing.T, expected, actual response) {
+	expectedJSON, err := json.Marshal(expected)
+	require.NoError(t, err)
+	actualJSON, err := json.Marshal(actual)
+	require.NoError(t, err)
+	require.JSONEq(t, string(expectedJSON), string(actualJSON))
+}
+
+func TestHandlerCachesCompletedIntervals(t *testing.T) {
+	var (
+		base  = &testBaseHandler{}
+		h     = newTestHandler(base)
+		start = testNow.Add(-72 * time.Hour)
+		end   = testNow
+	)
+
+	code, resp := queryRange(t, h, "up", start, end)
+	require.Equal(t, http.StatusOK, code)
+	requireEqualResults(t, uncachedResponse(t, "up", start, end), resp)
+	// The first interval is completed, so it is executed in full to be cached.
+	require.Equal(t, []testExecution{
+		{start: float64(start.Truncate(24 * time.Hour).Unix()), end: float64(end.Unix())},
+	}, base.executions)
+
+	// Only the interval of today is executed again.
+	base.executions = nil
+	start = start.Add(time.Hour)
+	code, resp = queryRange(t, h, "up", start, end)
+	require.Equal(t, http.StatusOK, code)
+	requireEqualResults(t, uncachedResponse(t, "up", start, end), resp)
+	today := testNow.Truncate(24 * time.Hour)
+	require.Equal(t, []testExecution{
+		{start: float64(today.Unix()), end: float64(end.Unix())},
+	}, base.executions)
+}
+
+func TestHandlerExecutesIntervalsInFull(t *testing.T) {
+	var (
+		base  = &testBaseHandler{}
+		h     = newTestHandler(base)
+		start = testNow.Add(-50 * time.Hour)
+		end   = testNow.Add(-30 * time.Hour)
+	)
+
+	code, resp := queryRange(t, h, "up", start, end)
+	require.Equal(t, http.StatusOK, code)
+	requireEqualResults(t, uncachedResponse(t, "up", start, end), resp)
+
+	// Both intervals are completed, so they are executed in full and cached.
+	day := 24 * time.Hour
+	require.Equal(t, []testExecution{{
+		start: float64(start.Truncate(day).Unix()),
+		end:   float64(end.Truncate(day).Add(day - time.Hour).Unix()),
+	}}, base.executions)
+
+	base.executions = nil
+	start, end = start.Add(-time.Hour), end.Add(time.Hour)
+	code, resp = queryRange(t, h, "up", start, end)
+	require.Equal(t, http.StatusOK, code)
+	requireEqualResults(t, uncachedResponse(t, "up", start, end), resp)
+	require.Empty(t, base.executions)
+}
+
+func TestHandlerNegativeOffset(t *testing.T) {
+	var (
+		base  = &testBaseHandler{}
+		h     = newTestHandler(base)
+		query = "up offset -1d"
+		start = testNow.Add(-72 * time.Hour)
+		end   = testNow
+	)
+
+	_, _ = queryRange(t, h, query, start, end)
+	base.executions = nil
+	_, _ = queryRange(t, h, query, start, end)
+
+	// Yesterday depends on data of today, so it is not cached.
+	yesterday := testNow.Truncate(24 * time.Hour).Add(-24 * time.Hour)
+	require.Equal(t, []testExecution{
+		{start: float64(yesterday.Unix()), end: float64(end.Unix())},
+	}, base.executions)
+}
+
+func TestHandlerIncompleteResultsNotCached(t *testing.T) {
+	tests := []struct {
+		name string
+		base *testBaseHandler
+	}{
+		{name: "warnings", base: &testBaseHandler{warning: "partial results"}},
+		{name: "limited", base: &testBaseHandler{limited: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				h     = newTestHandler(tt.base)
+				start = testNow.Add(-72 * time.Hour)
+				end   = testNow.Add(-48 * time.Hour)
+			)
+			code, resp := queryRange(t, h, "up", start, end)
+			require.Equal(t, http.StatusOK, code)
+			if tt.base.warning != "" {
+				assert.Equal(t, []string{tt.base.warning}, resp.Warnings)
+			}
+
+			_, _ = queryRange(t, h, "up", start, end)
+			assert.Len(t, tt.base.executions, 2)
+		})
+	}
+}
+
+func TestHandlerUncacheableQueries(t *testing.T) {
+	var (
+		base  = &testBaseHandler{}
+		h     = newTestHandler(base)
+		start = testNow.Add(-72 * time.Hour)
+		end   = testNow
+	)
+
+	for _, query := range []string{"up @ start()", "up{"} {
+		base.executions = nil
+		_, _ = queryRange(t, h, query, start, end)
+		require.Equal(t, []testExecution{
+			{start: float64(start.Unix()), end: float64(end.Unix())},
+		}, base.executions, query)
+	}
+}
+
+func TestHandlerErrorResponse(t *testing.T) {
+	base := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"status":"error","error":"bad query"}`))
+	})
+	h := newTestHandler(base)
+
+	code, _ := queryRange(t, h, "up", testNow.Add(-time.Hour), testNow)
+	require.Equal(t, http.StatusBadRequest, code)
+}
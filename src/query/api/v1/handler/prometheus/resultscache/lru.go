@@ -0,0 +1,81 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package resultscache
+
+import (
+	"context"
+	"time"
+
+	"github.com/uber-go/tally"
+
+	"github.com/m3db/m3/src/x/cache"
+)
+
+const defaultLRUTTL = time.Hour
+
+// LRUBackendOptions are the options of an in-process LRU backend.
+type LRUBackendOptions struct {
+	// TTL is how long results are cached for, defaults to 1h.
+	TTL time.Duration
+	// MaxEntries is the maximum number of cached results.
+	MaxEntries int
+	// MetricsScope is the metrics scope.
+	MetricsScope tally.Scope
+}
+
+type lruBackend struct {
+	lru *cache.LRU
+}
+
+// NewLRUBackend returns a backend that caches results in process.
+func NewLRUBackend(opts LRUBackendOptions) Backend {
+	ttl := opts.TTL
+	if ttl <= 0 {
+		ttl = defaultLRUTTL
+	}
+	return &lruBackend{
+		lru: cache.NewLRU(&cache.LRUOptions{
+			TTL:        ttl,
+			MaxEntries: opts.MaxEntries,
+			Metrics:    opts.MetricsScope,
+		}),
+	}
+}
+
+func (b *lruBackend) Fetch(_ context.Context, keys []string) map[string][]byte {
+	values := make(map[string][]byte, len(keys))
+	for _, key := range keys {
+		value, ok := b.lru.TryGet(key)
+		if !ok {
+			continue
+		}
+		if bytes, ok := value.([]byte); ok {
+			values[key] = bytes
+		}
+	}
+	return values
+}
+
+func (b *lruBackend) Store(_ context.Context, values map[string][]byte) {
+	for key, value := range values {
+		b.lru.Put(key, value)
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package resultscache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+const (
+	statusSuccess = "success"
+	matrixType    = "matrix"
+)
+
+var errInvalidSample = errors.New("invalid sample")
+
+type response struct {
+	Status   string       `json:"status"`
+	Data     responseData `json:"data"`
+	Warnings []string     `json:"warnings,omitempty"`
+}
+
+type responseData struct {
+	ResultType string   `json:"resultType"`
+	Result     []series `json:"result"`
+}
+
+// series is a series of a matrix, samples are kept as they were rendered.
+type series struct {
+	Metric json.RawMessage   `json:"metric"`
+	Values []json.RawMessage `json:"values"`
+}
+
+func decodeResponse(body []byte) (response, error) {
+	var r response
+	if err := json.Unmarshal(body, &r); err != nil {
+		return response{}, err
+	}
+	if r.Status != statusSuccess {
+		return response{}, fmt.Errorf("unexpected status: %s", r.Status)
+	}
+	if r.Data.ResultType != matrixType {
+		return response{}, fmt.Errorf("unexpected result type: %s", r.Data.ResultType)
+	}
+	return r, nil
+}
+
+// sampleTime returns the timestamp in milliseconds of a rendered sample.
+func sampleTime(sample json.RawMessage) (int64, error) {
+	var values []json.Number
+	if err := json.Unmarshal(sample, &values); err != nil {
+		return 0, err
+	}
+	if len(values) != 2 {
+		return 0, errInvalidSample
+	}
+	seconds, err := strconv.ParseFloat(values[0].String(), 64)
+	if err != nil {
+		return 0, err
+	}
+	return int64(math.Round(seconds * float64(millisPerSecond))), nil
+}
+
+// matrixBuilder merges series split across extents into a matrix.
+type matrixBuilder struct {
+	series  []*series
+	indexes map[string]int
+}
+
+func newMatrixBuilder() *matrixBuilder {
+	return &matrixBuilder{indexes: make(map[string]int)}
+}
+
+// add adds the samples of the series between start and end, extents must be
+// added in time order.
+func (b *matrixBuilder) add(result []series, start, end int64) error {
+	for _, s := range result {
+		var values []json.RawMessage
+		for _, v := range s.Values {
+			t, err := sampleTime(v)
+			if err != nil {
+				return err
+			}
+			if t >= start && t <= end {
+				values = append(values, v)
+			}
+		}
+		if len(values) == 0 {
+			continue
+		}
+
+		id, err := seriesID(s.Metric)
+		if err != nil {
+			return err
+		}
+		i, ok := b.indexes[id]
+		if !ok {
+			b.indexes[id] = len(b.series)
+			b.series = append(b.series, &series{Metric: s.Metric})
+			i = len(b.series) - 1
+		}
+		b.series[i].Values = append(b.series[i].Values, values...)
+	}
+	return nil
+}
+
+func (b *matrixBuilder) build() []series {
+	result := make([]series, 0, len(b.series))
+	for _, s := range b.series {
+		result = append(result, *s)
+	}
+	return result
+}
+
+// seriesID returns an identifier of the labels of a series that does not
+// depend on the order the labels were rendered in.
+func seriesID(metric json.RawMessage) (string, error) {
+	var labels map[string]string
+	if err := json.Unmarshal(metric, &labels); err != nil {
+		return "", err
+	}
+	// Map keys are marshalled in sorted order.
+	id, err := json.Marshal(labels)
+	if err != nil {
+		return "", err
+	}
+	return string(id), nil
+}
+
+// splitResult splits the series of a result into the extents the samples
+// belong to.
+func splitResult(result []series, extents []extent) ([][]series, error) {
+	split := make([][]series, len(extents))
+	for _, s := range result {
+		var (
+			i      int
+			values = make([][]json.RawMessage, len(extents))
+		)
+		for _, v := range s.Values {
+			t, err := sampleTime(v)
+			if err != nil {
+				return nil, err
+			}
+			// Samples are in time order, so the extent index only moves forward.
+			for i < len(extents) && t > extents[i].fullEnd {
+				i++
+			}
+			if i == len(extents) {
+				break
+			}
+			if t >= extents[i].fullStart {
+				values[i] = append(values[i], v)
+			}
+		}
+		for j, v := range values {
+			if len(v) > 0 {
+				split[j] = append(split[j], series{Metric: s.Metric, Values: v})
+			}
+		}
+	}
+	return split, nil
+}
@@ -0,0 +1,303 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package resultscache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/x/headers"
+)
+
+const (
+	queryParam = "query"
+	startParam = "start"
+	endParam   = "end"
+
+	millisPerSecond = int64(time.Second / time.Millisecond)
+)
+
+var (
+	errStepNotSet  = errors.New("step not set")
+	errInvalidStep = errors.New("step must be a positive number of milliseconds")
+	errStartOrEnd  = errors.New("query uses @ start() or @ end()")
+
+	// keyIgnoredParams are the request params that do not change results.
+	keyIgnoredParams = map[string]struct{}{
+		startParam:                  {},
+		endParam:                    {},
+		handleroptions.TimeoutParam: {},
+		handleroptions.StepParam:    {},
+		queryParam:                  {},
+	}
+
+	// keyIgnoredHeaders are the M3 request headers that do not change results.
+	keyIgnoredHeaders = map[string]struct{}{
+		headers.SourceHeader: {},
+	}
+)
+
+// rangeQuery is a range query, times are in milliseconds as Prometheus
+// evaluates queries at millisecond precision.
+type rangeQuery struct {
+	query string
+	start int64
+	end   int64
+	step  int64
+
+	// lookahead is how long after a step the data that the result of the
+	// step depends on can be, this is non zero when negative offsets are used.
+	lookahead int64
+	// fixedTime is the latest time used with the @ modifier, if any.
+	fixedTime int64
+
+	params  url.Values
+	headers http.Header
+
+	// keyBase is the part of cache keys shared by all extents of the query.
+	keyBase string
+}
+
+func parseRangeQuery(r *http.Request, now time.Time) (rangeQuery, error) {
+	if err := r.ParseForm(); err != nil {
+		return rangeQuery{}, err
+	}
+
+	query := r.FormValue(queryParam)
+	start, err := prometheus.ParseTime(r, startParam, now)
+	if err != nil {
+		return rangeQuery{}, err
+	}
+	end, err := prometheus.ParseTime(r, endParam, now)
+	if err != nil {
+		return rangeQuery{}, err
+	}
+	step, ok, err := handleroptions.ParseStep(r)
+	if err != nil {
+		return rangeQuery{}, err
+	}
+	if !ok {
+		return rangeQuery{}, errStepNotSet
+	}
+	if step < time.Millisecond || step%time.Millisecond != 0 {
+		return rangeQuery{}, errInvalidStep
+	}
+
+	expr, err := parser.ParseExpr(query)
+	if err != nil {
+		return rangeQuery{}, err
+	}
+	lookahead, fixedTime, err := dataTimeBounds(expr)
+	if err != nil {
+		return rangeQuery{}, err
+	}
+
+	q := rangeQuery{
+		query:     query,
+		start:     toMillis(start),
+		end:       toMillis(end),
+		step:      step.Milliseconds(),
+		lookahead: lookahead.Milliseconds(),
+		fixedTime: fixedTime,
+		params:    r.Form,
+		headers:   r.Header,
+	}
+	q.keyBase = q.cacheKeyBase()
+	return q, nil
+}
+
+// dataTimeBounds returns the lookahead and the latest @ modifier time of a
+// query, queries that are evaluated relative to their start or end cannot be
+// split and return an error.
+func dataTimeBounds(expr parser.Expr) (time.Duration, int64, error) {
+	var (
+		lookahead time.Duration
+		fixedTime int64
+	)
+	visit := func(offset time.Duration, ts *int64, startOrEnd parser.ItemType) error {
+		if startOrEnd == parser.START || startOrEnd == parser.END {
+			return errStartOrEnd
+		}
+		// Offsets of nested selectors add up, so summing negative offsets
+		// bounds the lookahead of the query.
+		if offset < 0 {
+			lookahead -= offset
+		}
+		if ts != nil && *ts > fixedTime {
+			fixedTime = *ts
+		}
+		return nil
+	}
+	err := parser.Walk(inspector(func(node parser.Node) error {
+		switch n := node.(type) {
+		case *parser.VectorSelector:
+			return visit(n.OriginalOffset, n.Timestamp, n.StartOrEnd)
+		case *parser.SubqueryExpr:
+			return visit(n.OriginalOffset, n.Timestamp, n.StartOrEnd)
+		}
+		return nil
+	}), expr, nil)
+	return lookahead, fixedTime, err
+}
+
+type inspector func(parser.Node) error
+
+func (f inspector) Visit(node parser.Node, _ []parser.Node) (parser.Visitor, error) {
+	if err := f(node); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// cacheKeyBase returns the part of cache keys that identifies the query
+// regardless of its time range: the query, the step grid and all request
+// params, such as the lookback, and headers that can change results.
+func (q rangeQuery) cacheKeyBase() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "query=%s\nstep=%d\nphase=%d\n", q.query, q.step, mod(q.start, q.step))
+
+	params := make([]string, 0, len(q.params))
+	for name := range q.params {
+		if _, ok := keyIgnoredParams[name]; !ok {
+			params = append(params, name)
+		}
+	}
+	sort.Strings(params)
+	for _, name := range params {
+		fmt.Fprintf(&b, "param:%s=%s\n", name, strings.Join(q.params[name], ","))
+	}
+
+	names := make([]string, 0, len(q.headers))
+	for name := range q.headers {
+		if _, ok := keyIgnoredHeaders[name]; ok {
+			continue
+		}
+		if strings.HasPrefix(name, headers.M3HeaderPrefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(&b, "header:%s=%s\n", name, strings.Join(q.headers[name], ","))
+	}
+	return b.String()
+}
+
+// extent is the part of a query that falls in one split interval.
+type extent struct {
+	// intervalStart is the start of the split interval.
+	intervalStart int64
+	// start and end are the first and last steps of the query in the interval.
+	start int64
+	end   int64
+	// fullStart and fullEnd are the first and last steps in the interval
+	// of the step grid of the query.
+	fullStart int64
+	fullEnd   int64
+	// cacheable is true if results in the interval can no longer change.
+	cacheable bool
+}
+
+// splitRangeQuery splits a query into the extents of each interval that
+// contains a step of the query.
+func splitRangeQuery(q rangeQuery, interval, cutoff int64) []extent {
+	var extents []extent
+	for intervalStart := q.start - mod(q.start, interval); intervalStart <= q.end; intervalStart += interval {
+		intervalEnd := intervalStart + interval
+		fullStart := q.alignUp(intervalStart)
+		if fullStart >= intervalEnd {
+			// The interval does not contain any step of the query.
+			continue
+		}
+		fullEnd := q.alignUp(intervalEnd) - q.step
+
+		e := extent{
+			intervalStart: intervalStart,
+			start:         fullStart,
+			end:           fullEnd,
+			fullStart:     fullStart,
+			fullEnd:       fullEnd,
+		}
+		if e.start < q.start {
+			e.start = q.start
+		}
+		if lastStep := q.end - mod(q.end-q.start, q.step); e.end > lastStep {
+			e.end = lastStep
+		}
+
+		// The last step of the interval depends on data up to the lookahead
+		// after it, and all steps depend on data at the @ modifier time.
+		dataEnd := fullEnd + q.lookahead
+		if q.fixedTime > dataEnd {
+			dataEnd = q.fixedTime
+		}
+		e.cacheable = dataEnd < cutoff
+		extents = append(extents, e)
+	}
+	return extents
+}
+
+// alignUp returns the first step of the query grid at or after t.
+func (q rangeQuery) alignUp(t int64) int64 {
+	return t + mod(q.start-t, q.step)
+}
+
+// cacheKey returns the cache key of the results of an extent.
+func (q rangeQuery) cacheKey(prefix string, interval int64, e extent) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%sinterval=%d\nintervalStart=%d\n", q.keyBase, interval, e.intervalStart)
+	return prefix + hex.EncodeToString(h.Sum(nil))
+}
+
+func toMillis(t time.Time) int64 {
+	// Parsed times can be off by rounding errors, round them to the closest
+	// millisecond like the timestamps of Prometheus.
+	return t.Round(time.Millisecond).UnixNano() / int64(time.Millisecond)
+}
+
+func formatMillis(t int64) string {
+	sign := ""
+	if t < 0 {
+		sign = "-"
+		t = -t
+	}
+	return fmt.Sprintf("%s%d.%03d", sign, t/millisPerSecond, t%millisPerSecond)
+}
+
+// mod returns the non-negative remainder of a divided by b.
+func mod(a, b int64) int64 {
+	m := a % b
+	if m < 0 {
+		m += b
+	}
+	return m
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package resultscache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/prometheus/promql/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataTimeBounds(t *testing.T) {
+	tests := []struct {
+		query     string
+		lookahead time.Duration
+		fixedTime int64
+		err       bool
+	}{
+		{query: "rate(up[5m])"},
+		{query: "up offset 1h"},
+		{query: "up offset -1h", lookahead: time.Hour},
+		{query: "max_over_time(up[5m] offset -5m)[1h:1m] offset -1h", lookahead: 65 * time.Minute},
+		{query: "up @ 1600000000 + up @ 1500000000", fixedTime: 1600000000000},
+		{query: "up @ end()", err: true},
+		{query: "rate(up[5m] @ start())", err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			expr, err := parser.ParseExpr(tt.query)
+			require.NoError(t, err)
+
+			lookahead, fixedTime, err := dataTimeBounds(expr)
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.lookahead, lookahead)
+			assert.Equal(t, tt.fixedTime, fixedTime)
+		})
+	}
+}
+
+func TestSplitRangeQuery(t *testing.T) {
+	hour := time.Hour.Milliseconds()
+	q := rangeQuery{
+		// The step grid is offset by 10m from the intervals.
+		start: 2*hour + 10*time.Minute.Milliseconds(),
+		end:   10 * hour,
+		step:  hour,
+	}
+
+	extents := splitRangeQuery(q, 4*hour, 8*hour)
+	require.Equal(t, []extent{
+		{
+			intervalStart: 0,
+			start:         q.start,
+			end:           q.start + hour,
+			fullStart:     q.start - 2*hour,
+			fullEnd:       q.start + hour,
+			cacheable:     true,
+		},
+		{
+			intervalStart: 4 * hour,
+			start:         q.start + 2*hour,
+			end:           q.start + 5*hour,
+			fullStart:     q.start + 2*hour,
+			fullEnd:       q.start + 5*hour,
+			cacheable:     true,
+		},
+		{
+			intervalStart: 8 * hour,
+			start:         q.start + 6*hour,
+			end:           q.start + 7*hour,
+			fullStart:     q.start + 6*hour,
+			fullEnd:       q.start + 9*hour,
+			cacheable:     false,
+		},
+	}, extents)
+
+	// Extents with a lookahead past the cutoff are not cacheable.
+	q.lookahead = 3 * hour
+	extents = splitRangeQuery(q, 4*hour, 8*hour)
+	require.Len(t, extents, 3)
+	assert.True(t, extents[0].cacheable)
+	assert.False(t, extents[1].cacheable)
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package resultscache provides a results cache for Prometheus range queries
+// that splits queries by interval and only executes the parts of queries
+// that are not cached.
+package resultscache
+
+import (
+	"context"
+	"time"
+
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+// Backend stores cached query results, it can be an in-process cache or a
+// remote cache such as memcached or redis.
+type Backend interface {
+	// Fetch returns the cached values of the keys that are found, a failure
+	// to fetch a key is treated as a cache miss.
+	Fetch(ctx context.Context, keys []string) map[string][]byte
+
+	// Store stores the values of the keys, storing values is best effort.
+	Store(ctx context.Context, values map[string][]byte)
+}
+
+// Options are the options of a results cache handler.
+type Options struct {
+	// Backend stores the cached results.
+	Backend Backend
+	// KeyPrefix is prepended to cache keys, it distinguishes the results of
+	// handlers that share a backend.
+	KeyPrefix string
+	// SplitInterval is the interval range queries are split by, queries are
+	// split at multiples of the interval since the epoch.
+	SplitInterval time.Duration
+	// MaxFreshness is how long before now results can still change, results
+	// that depend on more recent data are not cached.
+	MaxFreshness time.Duration
+	// NowFn is the function used to get the current time.
+	NowFn clock.NowFn
+	// InstrumentOptions are the instrument options.
+	InstrumentOptions instrument.Options
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/downsample"
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest"
+	"github.com/m3db/m3/src/metrics/rules/view"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/api/v1/route"
+	"github.com/m3db/m3/src/query/util/logging"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+)
+
+const (
+	// RuleSetPreviewURL is the url to preview the impact of a ruleset on
+	// the series recently sampled by the downsampler.
+	RuleSetPreviewURL = route.Prefix + "/downsample/ruleset/preview"
+
+	// RuleSetPreviewHTTPMethod is the HTTP method used with this resource.
+	RuleSetPreviewHTTPMethod = http.MethodPost
+)
+
+const (
+	// ruleSetPreviewScope is the scope of the series a ruleset is previewed
+	// against, the series are sampled by each coordinator from the writes it
+	// receives and are not merged across coordinators.
+	ruleSetPreviewScope = "coordinator"
+)
+
+var (
+	errNoDownsampler      = errors.New("downsampler is not configured")
+	errNoRuleSetNamespace = errors.New("ruleset namespace is required")
+)
+
+// RuleSetPreviewHandler previews the impact of a proposed ruleset on the
+// series recently sampled by the downsampler.
+type RuleSetPreviewHandler struct {
+	downsamplerAndWriter ingest.DownsamplerAndWriter
+	instrumentOpts       instrument.Options
+}
+
+// NewRuleSetPreviewHandler returns a new instance of handler.
+func NewRuleSetPreviewHandler(opts options.HandlerOptions) http.Handler {
+	return &RuleSetPreviewHandler{
+		downsamplerAndWriter: opts.DownsamplerAndWriter(),
+		instrumentOpts:       opts.InstrumentOpts(),
+	}
+}
+
+// RuleSetPreviewResponse is the response of the ruleset preview handler.
+type RuleSetPreviewResponse struct {
+	Namespace string `json:"namespace"`
+	// Scope is the scope of the sampled series, the series written to the
+	// coordinator serving the preview only.
+	Scope           string                      `json:"scope"`
+	SampledSeries   int                         `json:"sampledSeries"`
+	EstimatedSeries float64                     `json:"estimatedSeries"`
+	DroppedSeries   int                         `json:"droppedSeries"`
+	DroppedRatio    float64                     `json:"droppedRatio"`
+	RollupTargets   []RollupTargetPreviewResult `json:"rollupTargets"`
+}
+
+// RollupTargetPreviewResult is the preview of a single rollup target.
+type RollupTargetPreviewResult struct {
+	RollupRuleName        string  `json:"rollupRuleName"`
+	TargetIndex           int     `json:"targetIndex"`
+	SampledSeries         int     `json:"sampledSeries"`
+	EstimatedSeries       float64 `json:"estimatedSeries"`
+	SampledOutputSeries   int     `json:"sampledOutputSeries"`
+	EstimatedOutputSeries float64 `json:"estimatedOutputSeries"`
+}
+
+func (h *RuleSetPreviewHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := logging.WithContext(r.Context(), h.instrumentOpts)
+
+	var rsv view.RuleSet
+	if err := json.NewDecoder(r.Body).Decode(&rsv); err != nil {
+		logger.Error("unable to parse request", zap.Error(err))
+		xhttp.WriteError(w, xerrors.NewInvalidParamsError(err))
+		return
+	}
+	if rsv.Namespace == "" {
+		xhttp.WriteError(w, xerrors.NewInvalidParamsError(errNoRuleSetNamespace))
+		return
+	}
+
+	var downsampler downsample.Downsampler
+	if h.downsamplerAndWriter != nil {
+		downsampler = h.downsamplerAndWriter.Downsampler()
+	}
+	if downsampler == nil {
+		xhttp.WriteError(w, xhttp.NewError(errNoDownsampler, http.StatusBadRequest))
+		return
+	}
+
+	preview, err := downsampler.PreviewRuleSet(rsv)
+	if errors.Is(err, downsample.ErrSeriesSamplingDisabled) {
+		xhttp.WriteError(w, xhttp.NewError(err, http.StatusBadRequest))
+		return
+	}
+	if err != nil {
+		logger.Error("unable to preview ruleset", zap.Error(err))
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	resp := RuleSetPreviewResponse{
+		Namespace:       rsv.Namespace,
+		Scope:           ruleSetPreviewScope,
+		SampledSeries:   preview.SampledSeries,
+		EstimatedSeries: preview.EstimatedSeries,
+		DroppedSeries:   preview.DroppedSeries,
+		DroppedRatio:    preview.DroppedRatio,
+		RollupTargets:   make([]RollupTargetPreviewResult, 0, len(preview.RollupTargets)),
+	}
+	for _, target := range preview.RollupTargets {
+		resp.RollupTargets = append(resp.RollupTargets, RollupTargetPreviewResult{
+			RollupRuleName:        target.RuleName,
+			TargetIndex:           target.TargetIndex,
+			SampledSeries:         target.SampledSeries,
+			EstimatedSeries:       target.EstimatedSeries,
+			SampledOutputSeries:   target.SampledOutputSeries,
+			EstimatedOutputSeries: target.EstimatedOutputSeries,
+		})
+	}
+	xhttp.WriteJSONResponse(w, resp, logger)
+}
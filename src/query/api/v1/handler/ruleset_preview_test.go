@@ -0,0 +1,164 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package handler
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/downsample"
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest"
+	"github.com/m3db/m3/src/metrics/rules/view"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	xtest "github.com/m3db/m3/src/x/test"
+)
+
+func TestRuleSetPreviewHandler(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	downsampler := downsample.NewMockDownsampler(ctrl)
+	downsampler.EXPECT().
+		PreviewRuleSet(gomock.Any()).
+		DoAndReturn(func(rs view.RuleSet) (downsample.RuleSetPreview, error) {
+			assert.Equal(t, "default", rs.Namespace)
+			assert.Equal(t, 1, len(rs.MappingRules))
+			return downsample.RuleSetPreview{
+				SampledSeries:   10,
+				EstimatedSeries: 1000,
+				DroppedSeries:   5,
+				DroppedRatio:    0.5,
+				RollupTargets: []downsample.RollupTargetPreview{
+					{
+						RuleName:              "rollup",
+						TargetIndex:           0,
+						SampledSeries:         5,
+						EstimatedSeries:       500,
+						SampledOutputSeries:   2,
+						EstimatedOutputSeries: 2,
+					},
+				},
+			}, nil
+		})
+	downsamplerAndWriter := ingest.NewMockDownsamplerAndWriter(ctrl)
+	downsamplerAndWriter.EXPECT().Downsampler().Return(downsampler)
+
+	opts := options.EmptyHandlerOptions().SetDownsamplerAndWriter(downsamplerAndWriter)
+	handler := NewRuleSetPreviewHandler(opts)
+
+	body := `{
+		"id": "default",
+		"mappingRules": [
+			{"name": "drop", "filter": "__name__:debug_*", "dropPolicy": "drop_must"}
+		]
+	}`
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(RuleSetPreviewHTTPMethod, RuleSetPreviewURL, strings.NewReader(body))
+	handler.ServeHTTP(w, req)
+
+	resp := w.Result()
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(respBody))
+
+	expected := xtest.MustPrettyJSONString(t, `{
+		"namespace": "default",
+		"scope": "coordinator",
+		"sampledSeries": 10,
+		"estimatedSeries": 1000,
+		"droppedSeries": 5,
+		"droppedRatio": 0.5,
+		"rollupTargets": [
+			{
+				"rollupRuleName": "rollup",
+				"targetIndex": 0,
+				"sampledSeries": 5,
+				"estimatedSeries": 500,
+				"sampledOutputSeries": 2,
+				"estimatedOutputSeries": 2
+			}
+		]
+	}`)
+	actual := xtest.MustPrettyJSONString(t, string(respBody))
+	assert.Equal(t, expected, actual, xtest.Diff(expected, actual))
+}
+
+func TestRuleSetPreviewHandlerErrors(t *testing.T) {
+	tests := []struct {
+		name               string
+		body               string
+		previewErr         error
+		expectedStatusCode int
+	}{
+		{
+			name:               "invalid body",
+			body:               `{`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "no namespace",
+			body:               `{}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "sampling disabled",
+			body:               `{"id": "default"}`,
+			previewErr:         downsample.ErrSeriesSamplingDisabled,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := xtest.NewController(t)
+			defer ctrl.Finish()
+
+			downsampler := downsample.NewMockDownsampler(ctrl)
+			downsamplerAndWriter := ingest.NewMockDownsamplerAndWriter(ctrl)
+			if test.previewErr != nil {
+				downsampler.EXPECT().
+					PreviewRuleSet(gomock.Any()).
+					Return(downsample.RuleSetPreview{}, test.previewErr)
+				downsamplerAndWriter.EXPECT().Downsampler().Return(downsampler)
+			}
+
+			opts := options.EmptyHandlerOptions().SetDownsamplerAndWriter(downsamplerAndWriter)
+			handler := NewRuleSetPreviewHandler(opts)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(RuleSetPreviewHTTPMethod, RuleSetPreviewURL,
+				strings.NewReader(test.body))
+			handler.ServeHTTP(w, req)
+
+			resp := w.Result()
+			defer resp.Body.Close()
+			require.Equal(t, test.expectedStatusCode, resp.StatusCode)
+		})
+	}
+}
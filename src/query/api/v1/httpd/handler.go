@@ -42,9 +42,11 @@ import (
 	m3json "github.com/m3db/m3/src/query/api/v1/handler/json"
 	"github.com/m3db/m3/src/query/api/v1/handler/namespace"
 	"github.com/m3db/m3/src/query/api/v1/handler/openapi"
+	"github.com/m3db/m3/src/query/api/v1/handler/otlp"
 	"github.com/m3db/m3/src/query/api/v1/handler/prom"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/native"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/remote"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/resultscache"
 	"github.com/m3db/m3/src/query/api/v1/handler/topic"
 	"github.com/m3db/m3/src/query/api/v1/middleware"
 	"github.com/m3db/m3/src/query/api/v1/options"
@@ -158,6 +160,11 @@ func (h *Handler) RegisterRoutes() error {
 	nativePromReadHandler := native.NewPromReadHandler(nativeSourceOpts)
 	nativePromReadInstantHandler := native.NewPromReadInstantHandler(nativeSourceOpts)
 
+	// Range queries are served from the results cache when enabled, the
+	// engines are cached separately since their results can differ.
+	promqlQueryHandler = h.withResultsCache(promqlQueryHandler, nativeSourceOpts, "prometheus/")
+	nativePromReadHandler = h.withResultsCache(nativePromReadHandler, nativeSourceOpts, "m3query/")
+
 	h.options.QueryRouter().Setup(options.QueryRouterOptions{
 		DefaultQueryEngine: h.options.DefaultQueryEngine(),
 		PromqlHandler:      promqlQueryHandler.ServeHTTP,
@@ -253,6 +260,21 @@ func (h *Handler) RegisterRoutes() error {
 		return err
 	}
 
+	// OpenTelemetry OTLP/HTTP metrics write endpoint.
+	otlpWriteHandler, err := otlp.NewWriteHandler(h.options)
+	if err != nil {
+		return err
+	}
+	if err := h.registry.Register(queryhttp.RegisterOptions{
+		Path:    otlp.WriteURL,
+		Handler: otlpWriteHandler,
+		Methods: methods(otlp.WriteHTTPMethod),
+		// Register with no response logging for write calls since so frequent.
+		MiddlewareOverride: middleware.WithNoResponseLogging,
+	}); err != nil {
+		return err
+	}
+
 	// Native M3 search and write endpoints.
 	if err := h.registry.Register(queryhttp.RegisterOptions{
 		Path:    handler.SearchURL,
@@ -278,6 +300,15 @@ func (h *Handler) RegisterRoutes() error {
 		return err
 	}
 
+	// Downsampler ruleset preview endpoint.
+	if err := h.registry.Register(queryhttp.RegisterOptions{
+		Path:    handler.RuleSetPreviewURL,
+		Handler: handler.NewRuleSetPreviewHandler(h.options),
+		Methods: methods(handler.RuleSetPreviewHTTPMethod),
+	}); err != nil {
+		return err
+	}
+
 	// Tag completion endpoints.
 	if err := h.registry.Register(queryhttp.RegisterOptions{
 		Path:               native.CompleteTagsURL,
@@ -332,6 +363,66 @@ func (h *Handler) RegisterRoutes() error {
 		return err
 	}
 
+	// Exemplar query endpoints.
+	if err := h.registry.Register(queryhttp.RegisterOptions{
+		Path:               native.QueryExemplarsURL,
+		Handler:            native.NewQueryExemplarsHandler(h.options),
+		Methods:            native.QueryExemplarsHTTPMethods,
+		MiddlewareOverride: native.WithQueryParams,
+	}); err != nil {
+		return err
+	}
+
+	// Metadata, rules and alerts endpoints.
+	if err := h.registry.Register(queryhttp.RegisterOptions{
+		Path:    native.MetadataURL,
+		Handler: native.NewMetadataHandler(h.options),
+		Methods: native.MetadataHTTPMethods,
+	}); err != nil {
+		return err
+	}
+	if err := h.registry.Register(queryhttp.RegisterOptions{
+		Path:    native.TargetsMetadataURL,
+		Handler: native.NewTargetsMetadataHandler(h.options),
+		Methods: native.MetadataHTTPMethods,
+	}); err != nil {
+		return err
+	}
+	if err := h.registry.Register(queryhttp.RegisterOptions{
+		Path:    native.RulesURL,
+		Handler: native.NewRulesHandler(h.options),
+		Methods: native.RulesHTTPMethods,
+	}); err != nil {
+		return err
+	}
+	if err := h.registry.Register(queryhttp.RegisterOptions{
+		Path:    native.AlertsURL,
+		Handler: native.NewAlertsHandler(h.options),
+		Methods: native.RulesHTTPMethods,
+	}); err != nil {
+		return err
+	}
+
+	// TSDB status endpoint.
+	if err := h.registry.Register(queryhttp.RegisterOptions{
+		Path:               native.TSDBStatusURL,
+		Handler:            native.NewTSDBStatusHandler(h.options),
+		Methods:            native.TSDBStatusHTTPMethods,
+		MiddlewareOverride: native.WithQueryParams,
+	}); err != nil {
+		return err
+	}
+
+	// Series deletion endpoint.
+	if err := h.registry.Register(queryhttp.RegisterOptions{
+		Path:               native.DeleteSeriesURL,
+		Handler:            native.NewDeleteSeriesHandler(h.options),
+		Methods:            native.DeleteSeriesHTTPMethods,
+		MiddlewareOverride: native.WithQueryParams,
+	}); err != nil {
+		return err
+	}
+
 	// Graphite routable endpoints.
 	h.options.GraphiteRenderRouter().Setup(options.GraphiteRenderRouterOptions{
 		RenderHandler: graphite.NewRenderHandler(h.options).ServeHTTP,
@@ -353,6 +444,27 @@ func (h *Handler) RegisterRoutes() error {
 	}); err != nil {
 		return err
 	}
+	if err := h.registry.Register(queryhttp.RegisterOptions{
+		Path:    graphite.TagsURL,
+		Handler: graphite.NewTagsHandler(h.options),
+		Methods: graphite.TagsHTTPMethods,
+	}); err != nil {
+		return err
+	}
+	if err := h.registry.Register(queryhttp.RegisterOptions{
+		Path:    graphite.TagsAutoCompleteTagsURL,
+		Handler: graphite.NewTagsAutoCompleteTagsHandler(h.options),
+		Methods: graphite.TagsHTTPMethods,
+	}); err != nil {
+		return err
+	}
+	if err := h.registry.Register(queryhttp.RegisterOptions{
+		Path:    graphite.TagsAutoCompleteValuesURL,
+		Handler: graphite.NewTagsAutoCompleteValuesHandler(h.options),
+		Methods: graphite.TagsHTTPMethods,
+	}); err != nil {
+		return err
+	}
 
 	placementOpts, err := h.placementOpts()
 	if err != nil {
@@ -511,6 +623,30 @@ func (h *Handler) RegisterRoutes() error {
 	return nil
 }
 
+func (h *Handler) withResultsCache(
+	base http.Handler,
+	opts options.HandlerOptions,
+	keyPrefix string,
+) http.Handler {
+	backend := h.options.ResultsCache()
+	if backend == nil {
+		return base
+	}
+
+	var cfg config.ResultsCacheConfiguration
+	if c := h.options.Config().Query.ResultsCache; c != nil {
+		cfg = *c
+	}
+	return resultscache.NewHandler(base, resultscache.Options{
+		Backend:           backend,
+		KeyPrefix:         keyPrefix,
+		SplitInterval:     cfg.SplitInterval,
+		MaxFreshness:      cfg.MaxFreshness,
+		NowFn:             h.options.NowFn(),
+		InstrumentOptions: opts.InstrumentOpts(),
+	})
+}
+
 func (h *Handler) placementOpts() (placementhandler.HandlerOptions, error) {
 	return placementhandler.NewHandlerOptions(
 		h.options.ClusterClient(),
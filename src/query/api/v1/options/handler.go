@@ -38,13 +38,17 @@ import (
 	"github.com/m3db/m3/src/dbnode/encoding"
 	dbnamespace "github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/resultscache"
 	"github.com/m3db/m3/src/query/api/v1/middleware"
 	"github.com/m3db/m3/src/query/api/v1/validators"
 	"github.com/m3db/m3/src/query/executor"
 	graphite "github.com/m3db/m3/src/query/graphite/storage"
 	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/ruler"
 	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/exemplar"
 	"github.com/m3db/m3/src/query/storage/m3"
+	"github.com/m3db/m3/src/query/storage/prommetadata"
 	"github.com/m3db/m3/src/query/ts"
 	"github.com/m3db/m3/src/x/clock"
 	"github.com/m3db/m3/src/x/instrument"
@@ -283,6 +287,28 @@ type HandlerOptions interface {
 	DefaultLookback() time.Duration
 	// SetDefaultLookback sets the default value of lookback duration.
 	SetDefaultLookback(value time.Duration) HandlerOptions
+
+	// ExemplarStore returns the exemplar store, nil if exemplars are not stored.
+	ExemplarStore() exemplar.Store
+	// SetExemplarStore sets the exemplar store.
+	SetExemplarStore(value exemplar.Store) HandlerOptions
+
+	// MetadataStore returns the Prometheus metric metadata store, nil if
+	// metadata is not stored.
+	MetadataStore() prommetadata.Store
+	// SetMetadataStore sets the Prometheus metric metadata store.
+	SetMetadataStore(value prommetadata.Store) HandlerOptions
+
+	// Ruler returns the rule evaluator, nil if rules are not evaluated.
+	Ruler() ruler.Ruler
+	// SetRuler sets the rule evaluator.
+	SetRuler(value ruler.Ruler) HandlerOptions
+
+	// ResultsCache returns the range query results cache backend, nil if
+	// results are not cached.
+	ResultsCache() resultscache.Backend
+	// SetResultsCache sets the range query results cache backend.
+	SetResultsCache(value resultscache.Backend) HandlerOptions
 }
 
 // HandlerOptions represents handler options.
@@ -317,6 +343,10 @@ type handlerOptions struct {
 	graphiteRenderRouter              GraphiteRenderRouter
 	graphiteFindRouter                GraphiteFindRouter
 	defaultLookback                   time.Duration
+	exemplarStore                     exemplar.Store
+	metadataStore                     prommetadata.Store
+	ruler                             ruler.Ruler
+	resultsCache                      resultscache.Backend
 }
 
 // EmptyHandlerOptions returns  default handler options.
@@ -714,5 +744,45 @@ func (o *handlerOptions) SetDefaultLookback(value time.Duration) HandlerOptions
 	return &opts
 }
 
+func (o *handlerOptions) ExemplarStore() exemplar.Store {
+	return o.exemplarStore
+}
+
+func (o *handlerOptions) SetExemplarStore(value exemplar.Store) HandlerOptions {
+	opts := *o
+	opts.exemplarStore = value
+	return &opts
+}
+
+func (o *handlerOptions) MetadataStore() prommetadata.Store {
+	return o.metadataStore
+}
+
+func (o *handlerOptions) SetMetadataStore(value prommetadata.Store) HandlerOptions {
+	opts := *o
+	opts.metadataStore = value
+	return &opts
+}
+
+func (o *handlerOptions) Ruler() ruler.Ruler {
+	return o.ruler
+}
+
+func (o *handlerOptions) SetRuler(value ruler.Ruler) HandlerOptions {
+	opts := *o
+	opts.ruler = value
+	return &opts
+}
+
+func (o *handlerOptions) ResultsCache() resultscache.Backend {
+	return o.resultsCache
+}
+
+func (o *handlerOptions) SetResultsCache(value resultscache.Backend) HandlerOptions {
+	opts := *o
+	opts.resultsCache = value
+	return &opts
+}
+
 // KVStoreProtoParser parses protobuf messages based off specific keys.
 type KVStoreProtoParser func(key string) (protoiface.MessageV1, error)
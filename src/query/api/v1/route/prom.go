@@ -38,4 +38,25 @@ const (
 
 	// SeriesMatchURL is the url for remote prom series matcher handler.
 	SeriesMatchURL = Prefix + "/series"
+
+	// QueryExemplarsURL is the url for the query exemplars endpoint.
+	QueryExemplarsURL = Prefix + "/query_exemplars"
+
+	// MetadataURL is the url for the metric metadata endpoint.
+	MetadataURL = Prefix + "/metadata"
+
+	// TargetsMetadataURL is the url for the targets metadata endpoint.
+	TargetsMetadataURL = Prefix + "/targets/metadata"
+
+	// RulesURL is the url for the rules endpoint.
+	RulesURL = Prefix + "/rules"
+
+	// AlertsURL is the url for the alerts endpoint.
+	AlertsURL = Prefix + "/alerts"
+
+	// DeleteSeriesURL is the url for the delete series endpoint.
+	DeleteSeriesURL = Prefix + "/admin/tsdb/delete_series"
+
+	// TSDBStatusURL is the url for the TSDB status endpoint.
+	TSDBStatusURL = Prefix + "/status/tsdb"
 )
@@ -0,0 +1,170 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package linear
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/functions/utils"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+)
+
+const (
+	// HistogramCountType returns the count of observations of native histograms.
+	//
+	// NB: native histograms are read as classic histograms, so this selects the
+	// series of each native histogram holding its count and drops the metric
+	// name and native histogram tags; other series, including classic
+	// histograms, are ignored as they are by Prometheus.
+	HistogramCountType = "histogram_count"
+
+	// HistogramSumType returns the sum of observations of native histograms.
+	//
+	// NB: this selects the series of each native histogram holding its sum and
+	// drops the metric name and native histogram tags; other series are ignored.
+	HistogramSumType = "histogram_sum"
+)
+
+// NewHistogramStatOp creates a new histogram count or sum operation.
+func NewHistogramStatOp(opType string) (parser.Params, error) {
+	if opType != HistogramCountType && opType != HistogramSumType {
+		return nil, fmt.Errorf("operator not supported: %s", opType)
+	}
+
+	return histogramStatOp{opType: opType}, nil
+}
+
+// histogramStatOp stores required properties for histogram count and sum ops.
+type histogramStatOp struct {
+	opType string
+}
+
+// OpType for the operator.
+func (o histogramStatOp) OpType() string {
+	return o.opType
+}
+
+// String representation.
+func (o histogramStatOp) String() string {
+	return fmt.Sprintf("type: %s", o.OpType())
+}
+
+// Node creates an execution node.
+func (o histogramStatOp) Node(
+	controller *transform.Controller,
+	_ transform.Options,
+) transform.OpNode {
+	return &histogramStatNode{
+		op:         o,
+		controller: controller,
+	}
+}
+
+type histogramStatNode struct {
+	op         histogramStatOp
+	controller *transform.Controller
+}
+
+func (n *histogramStatNode) Params() parser.Params {
+	return n.op
+}
+
+// Process the block
+func (n *histogramStatNode) Process(
+	queryCtx *models.QueryContext,
+	ID parser.NodeID,
+	b block.Block,
+) error {
+	return transform.ProcessSimpleBlock(n, n.controller, queryCtx, ID, b)
+}
+
+func (n *histogramStatNode) matches(value []byte) bool {
+	if n.op.opType == HistogramSumType {
+		return bytes.Equal(value, models.NativeHistogramSum)
+	}
+
+	return bytes.Equal(value, models.NativeHistogramCount)
+}
+
+func (n *histogramStatNode) ProcessBlock(
+	queryCtx *models.QueryContext,
+	ID parser.NodeID,
+	b block.Block,
+) (block.Block, error) {
+	stepIter, err := b.StepIter()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		meta        = b.Meta()
+		seriesMetas = utils.FlattenMetadata(meta, stepIter.SeriesMeta())
+		indices     = make([]int, 0, len(seriesMetas))
+		metas       = make([]block.SeriesMeta, 0, len(seriesMetas))
+	)
+
+	for i, seriesMeta := range seriesMetas {
+		tags := seriesMeta.Tags
+		value, found := tags.Get(models.NativeHistogramTagName)
+		if !found || !n.matches(value) {
+			continue
+		}
+
+		excludeTags := [][]byte{tags.Opts.MetricName(), models.NativeHistogramTagName}
+		indices = append(indices, i)
+		metas = append(metas, block.SeriesMeta{
+			Name: seriesMeta.Name,
+			Tags: tags.TagsWithoutKeys(excludeTags),
+		})
+	}
+
+	meta.Tags, metas = utils.DedupeMetadata(metas, meta.Tags.Opts)
+	builder, err := n.controller.BlockBuilder(queryCtx, meta, metas)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = builder.AddCols(stepIter.StepCount()); err != nil {
+		return nil, err
+	}
+
+	values := make([]float64, len(indices))
+	for index := 0; stepIter.Next(); index++ {
+		stepValues := stepIter.Current().Values()
+		for i, idx := range indices {
+			values[i] = stepValues[idx]
+		}
+
+		if err := builder.AppendValues(index, values); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = stepIter.Err(); err != nil {
+		return nil, err
+	}
+
+	return builder.Build(), nil
+}
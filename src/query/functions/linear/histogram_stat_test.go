@@ -0,0 +1,120 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package linear
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/test/executor"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+func TestHistogramStatOpInvalidType(t *testing.T) {
+	_, err := NewHistogramStatOp(HistogramQuantileType)
+	require.Error(t, err)
+}
+
+func testHistogramStat(t *testing.T, opType string) *executor.SinkNode {
+	op, err := NewHistogramStatOp(opType)
+	require.NoError(t, err)
+
+	tagOpts := models.NewTagOptions().
+		SetIDSchemeType(models.TypeQuoted).
+		SetMetricName([]byte("name")).
+		SetBucketName([]byte("bucket"))
+
+	newTags := func(instance string) models.Tags {
+		return models.NewTags(3, tagOpts).SetName([]byte("foo")).AddTag(models.Tag{
+			Name:  []byte("instance"),
+			Value: []byte(instance),
+		})
+	}
+
+	withHistogramTag := func(tags models.Tags, value []byte) models.Tags {
+		return tags.AddTag(models.Tag{Name: models.NativeHistogramTagName, Value: value})
+	}
+
+	seriesMetas := []block.SeriesMeta{
+		{Tags: newTags("a").SetBucket([]byte("1"))},
+		{Tags: newTags("a").SetBucket([]byte("+Inf"))},
+		{Tags: withHistogramTag(newTags("a"), models.NativeHistogramCount)},
+		{Tags: withHistogramTag(newTags("a"), models.NativeHistogramSum)},
+		{Tags: withHistogramTag(newTags("b"), models.NativeHistogramCount)},
+		{Tags: withHistogramTag(newTags("b"), models.NativeHistogramSum)},
+		// these series should not be part of the output, since they are
+		// classic histogram buckets rather than native histogram series.
+		{Tags: newTags("c").SetBucket([]byte("+Inf"))},
+		{Tags: newTags("c").SetBucket([]byte("sum"))},
+	}
+
+	v := [][]float64{
+		{1, 2, 3},
+		{4, 5, 6},
+		{7, 8, 9},
+		{10, 11, 12},
+		{13, 14, 15},
+		{16, 17, 18},
+		{19, 20, 21},
+		{22, 23, 24},
+	}
+
+	bounds := models.Bounds{
+		Start:    xtime.Now(),
+		Duration: time.Minute * 3,
+		StepSize: time.Minute,
+	}
+
+	bl := test.NewBlockFromValuesWithSeriesMeta(bounds, seriesMetas, v)
+	c, sink := executor.NewControllerWithSink(parser.NodeID(rune(1)))
+	node := op.(histogramStatOp).Node(c, transform.Options{})
+	err = node.Process(models.NoopQueryContext(), parser.NodeID(rune(0)), bl)
+	require.NoError(t, err)
+
+	return sink
+}
+
+func TestHistogramCount(t *testing.T) {
+	sink := testHistogramStat(t, HistogramCountType)
+	assert.Equal(t, [][]float64{{7, 8, 9}, {13, 14, 15}}, sink.Values)
+	require.Len(t, sink.Metas, 2)
+	assert.Equal(t, "a", string(sink.Metas[0].Tags.Tags[0].Value))
+	assert.Equal(t, "b", string(sink.Metas[1].Tags.Tags[0].Value))
+	assert.Equal(t, 0, sink.Meta.Tags.Len())
+}
+
+func TestHistogramSum(t *testing.T) {
+	sink := testHistogramStat(t, HistogramSumType)
+	assert.Equal(t, [][]float64{{10, 11, 12}, {16, 17, 18}}, sink.Values)
+	require.Len(t, sink.Metas, 2)
+	for _, meta := range sink.Metas {
+		require.Equal(t, 1, meta.Tags.Len())
+		assert.Equal(t, "instance", string(meta.Tags.Tags[0].Name))
+	}
+}
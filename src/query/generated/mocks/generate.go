@@ -22,6 +22,9 @@
 //go:generate sh -c "mockgen -package=downsample $PACKAGE/src/cmd/services/m3coordinator/downsample Downsampler,MetricsAppender,SamplesAppender | genclean -pkg $PACKAGE/src/cmd/services/m3coordinator/downsample -out ../../../cmd/services/m3coordinator/downsample/downsample_mock.go"
 //go:generate sh -c "mockgen -package=storage -destination=../../storage/storage_mock.go $PACKAGE/src/query/storage Storage"
 //go:generate sh -c "mockgen -package=m3 -destination=../../storage/m3/m3_mock.go $PACKAGE/src/query/storage/m3 Storage,ClusterNamespace,Clusters"
+//go:generate sh -c "mockgen -package=exemplar -destination=../../storage/exemplar/exemplar_mock.go $PACKAGE/src/query/storage/exemplar Store"
+//go:generate sh -c "mockgen -package=prommetadata -destination=../../storage/prommetadata/prommetadata_mock.go $PACKAGE/src/query/storage/prommetadata Store"
+//go:generate sh -c "mockgen -package=ruler -destination=../../ruler/ruler_mock.go $PACKAGE/src/query/ruler Ruler"
 //go:generate sh -c "mockgen -package=ts -destination=../../ts/ts_mock.go $PACKAGE/src/query/ts Values"
 //go:generate sh -c "mockgen -package=block -destination=../../block/block_mock.go $PACKAGE/src/query/block Block,StepIter,Builder,Step,SeriesIter"
 //go:generate sh -c "mockgen -package=ingest -destination=../../../cmd/services/m3coordinator/ingest/write_mock.go $PACKAGE/src/cmd/services/m3coordinator/ingest DownsamplerAndWriter"
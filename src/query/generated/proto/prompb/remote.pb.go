@@ -36,6 +36,10 @@
 		QueryResult
 		Sample
 		TimeSeries
+		MetricMetadata
+		Exemplar
+		Histogram
+		BucketSpan
 		Label
 		Labels
 		LabelMatcher
@@ -61,7 +65,8 @@ var _ = math.Inf
 const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
 type WriteRequest struct {
-	Timeseries []TimeSeries `protobuf:"bytes,1,rep,name=timeseries" json:"timeseries"`
+	Timeseries []TimeSeries     `protobuf:"bytes,1,rep,name=timeseries" json:"timeseries"`
+	Metadata   []MetricMetadata `protobuf:"bytes,3,rep,name=metadata" json:"metadata"`
 }
 
 func (m *WriteRequest) Reset()                    { *m = WriteRequest{} }
@@ -76,6 +81,13 @@ func (m *WriteRequest) GetTimeseries() []TimeSeries {
 	return nil
 }
 
+func (m *WriteRequest) GetMetadata() []MetricMetadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
 type ReadRequest struct {
 	Queries []*Query `protobuf:"bytes,1,rep,name=queries" json:"queries,omitempty"`
 }
@@ -191,6 +203,18 @@ func (m *WriteRequest) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
+	if len(m.Metadata) > 0 {
+		for _, msg := range m.Metadata {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintRemote(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
 	return i, nil
 }
 
@@ -342,6 +366,12 @@ func (m *WriteRequest) Size() (n int) {
 			n += 1 + l + sovRemote(uint64(l))
 		}
 	}
+	if len(m.Metadata) > 0 {
+		for _, e := range m.Metadata {
+			l = e.Size()
+			n += 1 + l + sovRemote(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -472,6 +502,37 @@ func (m *WriteRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRemote
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRemote
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Metadata = append(m.Metadata, MetricMetadata{})
+			if err := m.Metadata[len(m.Metadata)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRemote(dAtA[iNdEx:])
@@ -965,28 +1026,29 @@ func init() {
 }
 
 var fileDescriptorRemote = []byte{
-	// 363 bytes of a gzipped FileDescriptorProto
+	// 382 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x92, 0xc1, 0x4a, 0xeb, 0x40,
-	0x14, 0x86, 0x9b, 0xdb, 0x7b, 0xdb, 0xcb, 0xb4, 0x48, 0x89, 0x9b, 0xd8, 0x45, 0x94, 0xac, 0xba,
-	0xb0, 0x19, 0x30, 0x20, 0x2e, 0x44, 0xa5, 0x2e, 0xdc, 0x58, 0xc1, 0xb1, 0x20, 0xb8, 0x29, 0x49,
-	0x73, 0x4c, 0x03, 0x9d, 0x4c, 0x3a, 0x73, 0xb2, 0xe8, 0x5b, 0xb8, 0xf3, 0x95, 0xba, 0xf4, 0x09,
-	0x44, 0xea, 0x8b, 0x48, 0x66, 0x88, 0xa6, 0xe0, 0x46, 0x37, 0x21, 0x39, 0xff, 0xf7, 0xff, 0xfc,
-	0x39, 0x33, 0xe4, 0x22, 0x49, 0x71, 0x5e, 0x44, 0xfe, 0x4c, 0x70, 0xca, 0x83, 0x38, 0xa2, 0x3c,
-	0xa0, 0x4a, 0xce, 0xe8, 0xb2, 0x00, 0xb9, 0xa2, 0x09, 0x64, 0x20, 0x43, 0x84, 0x98, 0xe6, 0x52,
-	0xa0, 0x28, 0x9f, 0x3c, 0x8f, 0xa8, 0x04, 0x2e, 0x10, 0x7c, 0x3d, 0xb3, 0xbb, 0x3c, 0x28, 0xc7,
-	0x80, 0x73, 0x28, 0x54, 0xff, 0xfc, 0x37, 0x79, 0xb8, 0xca, 0x41, 0x99, 0xb8, 0xfe, 0xb0, 0x16,
-	0x90, 0x88, 0x44, 0x18, 0x32, 0x2a, 0x1e, 0xf5, 0x97, 0xb1, 0x95, 0x6f, 0x06, 0xf7, 0x6e, 0x48,
-	0xf7, 0x5e, 0xa6, 0x08, 0x0c, 0x96, 0x05, 0x28, 0xb4, 0xcf, 0x08, 0xc1, 0x94, 0x83, 0x02, 0x99,
-	0x82, 0x72, 0xac, 0x83, 0xe6, 0xa0, 0x73, 0xe4, 0xf8, 0xf5, 0x8a, 0xfe, 0x24, 0xe5, 0x70, 0xa7,
-	0xf5, 0xd1, 0xdf, 0xf5, 0xeb, 0x7e, 0x83, 0xd5, 0x1c, 0xde, 0x29, 0xe9, 0x30, 0x08, 0xe3, 0x2a,
-	0x6e, 0x48, 0xda, 0x65, 0xf3, 0xaf, 0xac, 0xdd, 0xed, 0xac, 0xdb, 0xf2, 0xb7, 0x58, 0xc5, 0x78,
-	0x97, 0xa4, 0x6b, 0xdc, 0x2a, 0x17, 0x99, 0x02, 0x3b, 0x20, 0x6d, 0x09, 0xaa, 0x58, 0x60, 0x65,
-	0xdf, 0xfb, 0xce, 0xae, 0x09, 0x56, 0x91, 0xde, 0xb3, 0x45, 0xfe, 0x69, 0xc1, 0x3e, 0x24, 0xb6,
-	0xc2, 0x50, 0xe2, 0x54, 0x17, 0xc4, 0x90, 0xe7, 0x53, 0x5e, 0x26, 0x59, 0x83, 0x26, 0xeb, 0x69,
-	0x65, 0x52, 0x09, 0x63, 0x65, 0x0f, 0x48, 0x0f, 0xb2, 0x78, 0x9b, 0xfd, 0xa3, 0xd9, 0x1d, 0xc8,
-	0xe2, 0x3a, 0x79, 0x4c, 0xfe, 0xf3, 0x10, 0x67, 0x73, 0x90, 0xca, 0x69, 0xea, 0x5e, 0xfd, 0xed,
-	0x5e, 0xd7, 0x61, 0x04, 0x8b, 0xb1, 0x41, 0xd8, 0x27, 0xeb, 0x5d, 0x91, 0x4e, 0xad, 0xb1, 0x7d,
-	0xf2, 0x93, 0x5d, 0xd7, 0xb7, 0x3c, 0x72, 0xd6, 0x1b, 0xd7, 0x7a, 0xd9, 0xb8, 0xd6, 0xdb, 0xc6,
-	0xb5, 0x9e, 0xde, 0xdd, 0xc6, 0x43, 0xcb, 0x5c, 0x85, 0xa8, 0xa5, 0x8f, 0x35, 0xf8, 0x08, 0x00,
-	0x00, 0xff, 0xff, 0x24, 0xf8, 0x63, 0xea, 0x98, 0x02, 0x00, 0x00,
+	0x14, 0x86, 0x6f, 0x6e, 0xef, 0x6d, 0xcb, 0xb4, 0x5c, 0xca, 0x5c, 0x17, 0xb5, 0x48, 0x95, 0xac,
+	0xba, 0xb0, 0x09, 0x18, 0x10, 0x17, 0x52, 0xa5, 0x2e, 0xdc, 0x98, 0x85, 0xb1, 0x20, 0xb8, 0x29,
+	0x93, 0xe4, 0x98, 0x06, 0x3a, 0x49, 0x3a, 0x73, 0xb2, 0xe8, 0x4b, 0xb8, 0xf5, 0x95, 0xba, 0xf4,
+	0x09, 0x44, 0xfa, 0x24, 0x92, 0x49, 0x53, 0x26, 0xe0, 0x46, 0x37, 0x21, 0x99, 0xf3, 0x7d, 0x3f,
+	0x7f, 0x66, 0x86, 0x5c, 0x47, 0x31, 0x2e, 0x72, 0xdf, 0x0a, 0x52, 0x6e, 0x73, 0x27, 0xf4, 0x6d,
+	0xee, 0xd8, 0x52, 0x04, 0xf6, 0x2a, 0x07, 0xb1, 0xb6, 0x23, 0x48, 0x40, 0x30, 0x84, 0xd0, 0xce,
+	0x44, 0x8a, 0x69, 0xf1, 0xe4, 0x99, 0x6f, 0x0b, 0xe0, 0x29, 0x82, 0xa5, 0xd6, 0x68, 0x97, 0x3b,
+	0xc5, 0x32, 0xe0, 0x02, 0x72, 0x39, 0xb8, 0xfa, 0x49, 0x1e, 0xae, 0x33, 0x90, 0x65, 0xdc, 0x60,
+	0xac, 0x05, 0x44, 0x69, 0x94, 0x96, 0xa4, 0x9f, 0x3f, 0xab, 0xaf, 0x52, 0x2b, 0xde, 0x4a, 0xdc,
+	0x7c, 0x31, 0x48, 0xf7, 0x51, 0xc4, 0x08, 0x1e, 0xac, 0x72, 0x90, 0x48, 0x27, 0x84, 0x60, 0xcc,
+	0x41, 0x82, 0x88, 0x41, 0xf6, 0x8d, 0x93, 0xc6, 0xa8, 0x73, 0xd6, 0xb7, 0xf4, 0x8e, 0xd6, 0x2c,
+	0xe6, 0xf0, 0xa0, 0xe6, 0xd3, 0x3f, 0x9b, 0xf7, 0xe3, 0x5f, 0x9e, 0x66, 0xd0, 0x09, 0x69, 0x73,
+	0x40, 0x16, 0x32, 0x64, 0xfd, 0x86, 0xb2, 0x8f, 0xea, 0xb6, 0x0b, 0x28, 0xe2, 0xc0, 0xdd, 0x31,
+	0xbb, 0x84, 0xbd, 0x63, 0x5e, 0x92, 0x8e, 0x07, 0x2c, 0xac, 0xea, 0x8c, 0x49, 0x6b, 0x95, 0xeb,
+	0x5d, 0xfe, 0xd7, 0xd3, 0xee, 0x8b, 0x7d, 0xf1, 0x2a, 0xc6, 0xbc, 0x21, 0xdd, 0xd2, 0x96, 0x59,
+	0x9a, 0x48, 0xa0, 0x0e, 0x69, 0x09, 0x90, 0xf9, 0x12, 0x2b, 0xfd, 0xf0, 0x2b, 0x5d, 0x11, 0x5e,
+	0x45, 0x9a, 0xaf, 0x06, 0xf9, 0xab, 0x06, 0xf4, 0x94, 0x50, 0x89, 0x4c, 0xe0, 0x5c, 0xfd, 0x20,
+	0x32, 0x9e, 0xcd, 0x79, 0x91, 0x64, 0x8c, 0x1a, 0x5e, 0x4f, 0x4d, 0x66, 0xd5, 0xc0, 0x95, 0x74,
+	0x44, 0x7a, 0x90, 0x84, 0x75, 0xf6, 0xb7, 0x62, 0xff, 0x41, 0x12, 0xea, 0xe4, 0x39, 0x69, 0x73,
+	0x86, 0xc1, 0x02, 0x84, 0xdc, 0x6d, 0xd2, 0xa0, 0xde, 0xeb, 0x8e, 0xf9, 0xb0, 0x74, 0x4b, 0xc4,
+	0xdb, 0xb3, 0xe6, 0x2d, 0xe9, 0x68, 0x8d, 0xe9, 0xc5, 0x77, 0xce, 0x4a, 0x3f, 0xa5, 0xe9, 0xc1,
+	0x66, 0x3b, 0x34, 0xde, 0xb6, 0x43, 0xe3, 0x63, 0x3b, 0x34, 0x9e, 0x9a, 0xe5, 0x3d, 0xf2, 0x9b,
+	0xea, 0x4e, 0x38, 0x9f, 0x03, 0x00, 0xe9, 0x8a, 0xa1, 0x08, 0xd5, 0x02, 0x00, 0x00,
 }
@@ -0,0 +1,190 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// TagSeparator separates the name and the tags of a tagged series name.
+	// NB: a.b.c;dc=us;host=a would become the following tag set:
+	// {dc: us}
+	// {host: a}
+	// {name: a.b.c}
+	TagSeparator = ';'
+
+	// TagValueSeparator separates the name and the value of a tag in a
+	// tagged series name.
+	TagValueSeparator = '='
+)
+
+var (
+	// NameTag is the tag holding the name of a tagged series.
+	NameTag = []byte("name")
+
+	errEmptyTaggedName  = errors.New("tagged series name is empty")
+	errEmptyTagName     = errors.New("tag name is empty")
+	errEmptyTagValue    = errors.New("tag value is empty")
+	errTagValueTilde    = errors.New("tag value cannot start with ~")
+	errReservedTagName  = errors.New("tag name is reserved for graphite paths")
+	errMissingSeparator = errors.New("tag is missing the = separator")
+)
+
+// TaggedNameTag is a tag of a tagged series name.
+type TaggedNameTag struct {
+	Name  []byte
+	Value []byte
+}
+
+// IsTaggedName returns true if the series name is a tagged name,
+// i.e. a.b.c;tag=value.
+func IsTaggedName(name []byte) bool {
+	return bytes.IndexByte(name, TagSeparator) >= 0
+}
+
+// ParseTaggedName parses a tagged series name such as a.b.c;dc=us;host=a into
+// its name and its tags. The returned tags are sorted by name and, as with
+// Graphite, the last value of a tag that appears more than once is used.
+func ParseTaggedName(name []byte) ([]byte, []TaggedNameTag, error) {
+	idx := bytes.IndexByte(name, TagSeparator)
+	if idx < 0 {
+		return name, nil, nil
+	}
+
+	path := name[:idx]
+	if len(path) == 0 {
+		return nil, nil, errEmptyTaggedName
+	}
+
+	var tags []TaggedNameTag
+	for _, tag := range bytes.Split(name[idx+1:], []byte{TagSeparator}) {
+		sep := bytes.IndexByte(tag, TagValueSeparator)
+		if sep < 0 {
+			return nil, nil, fmt.Errorf("invalid tag %q: %w", tag, errMissingSeparator)
+		}
+
+		tagName, tagValue := tag[:sep], tag[sep+1:]
+		if err := ValidateTag(tagName, tagValue); err != nil {
+			return nil, nil, err
+		}
+
+		if bytes.Equal(tagName, NameTag) {
+			return nil, nil, fmt.Errorf("invalid tag %q: name is set by the series name", tag)
+		}
+
+		replaced := false
+		for i := range tags {
+			if bytes.Equal(tags[i].Name, tagName) {
+				tags[i].Value = tagValue
+				replaced = true
+				break
+			}
+		}
+
+		if !replaced {
+			tags = append(tags, TaggedNameTag{Name: tagName, Value: tagValue})
+		}
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		return bytes.Compare(tags[i].Name, tags[j].Name) < 0
+	})
+
+	return path, tags, nil
+}
+
+// ValidateTag validates a tag of a tagged series name according to the
+// Graphite rules: tag names cannot contain any of ;!^= and tag values
+// cannot contain ; or start with ~. Tag names used for graphite paths
+// are reserved.
+func ValidateTag(name, value []byte) error {
+	if len(name) == 0 {
+		return errEmptyTagName
+	}
+
+	if idx := bytes.IndexAny(name, ";!^="); idx >= 0 {
+		return fmt.Errorf("invalid tag name %q: contains %q", name, name[idx])
+	}
+
+	if _, ok := TagIndex(name); ok {
+		return fmt.Errorf("invalid tag name %q: %w", name, errReservedTagName)
+	}
+
+	if len(value) == 0 {
+		return fmt.Errorf("invalid tag %q: %w", name, errEmptyTagValue)
+	}
+
+	if bytes.IndexByte(value, TagSeparator) >= 0 {
+		return fmt.Errorf("invalid tag %q value %q: contains ';'", name, value)
+	}
+
+	if value[0] == '~' {
+		return fmt.Errorf("invalid tag %q value %q: %w", name, value, errTagValueTilde)
+	}
+
+	return nil
+}
+
+// SeriesTags returns the tags of a series by its name, including the name
+// tag. Series that are not tagged only have the name tag.
+func SeriesTags(name string) map[string]string {
+	path, tags, err := ParseTaggedName([]byte(name))
+	if err != nil {
+		return map[string]string{string(NameTag): name}
+	}
+
+	result := make(map[string]string, len(tags)+1)
+	result[string(NameTag)] = string(path)
+	for _, tag := range tags {
+		result[string(tag.Name)] = string(tag.Value)
+	}
+
+	return result
+}
+
+// FormatTaggedName formats a tagged series name from its tags, which must
+// include the name tag, in the canonical a.b.c;tag1=value1;tag2=value2
+// form with tags sorted by name.
+func FormatTaggedName(tags map[string]string) string {
+	names := make([]string, 0, len(tags))
+	for name := range tags {
+		if name != string(NameTag) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString(tags[string(NameTag)])
+	for _, name := range names {
+		b.WriteByte(TagSeparator)
+		b.WriteString(name)
+		b.WriteByte(TagValueSeparator)
+		b.WriteString(tags[name])
+	}
+
+	return b.String()
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTaggedName(t *testing.T) {
+	name, tags, err := ParseTaggedName([]byte("a.b.c;host=b;dc=us;host=a;expr=x=y"))
+	require.NoError(t, err)
+	assert.Equal(t, "a.b.c", string(name))
+	assert.Equal(t, []TaggedNameTag{
+		{Name: []byte("dc"), Value: []byte("us")},
+		{Name: []byte("expr"), Value: []byte("x=y")},
+		{Name: []byte("host"), Value: []byte("a")},
+	}, tags)
+
+	name, tags, err = ParseTaggedName([]byte("a.b.c"))
+	require.NoError(t, err)
+	assert.Equal(t, "a.b.c", string(name))
+	assert.Nil(t, tags)
+}
+
+func TestParseTaggedNameInvalid(t *testing.T) {
+	for _, name := range []string{
+		";dc=us",
+		"a.b;dc",
+		"a.b;=us",
+		"a.b;dc=",
+		"a.b;d!c=us",
+		"a.b;d^c=us",
+		"a.b;dc=~us",
+		"a.b;__g0__=us",
+		"a.b;name=c",
+		"a.b;dc=us;",
+	} {
+		_, _, err := ParseTaggedName([]byte(name))
+		assert.Error(t, err, name)
+	}
+}
+
+func TestSeriesTags(t *testing.T) {
+	assert.Equal(t, map[string]string{"name": "a.b", "dc": "us"},
+		SeriesTags("a.b;dc=us"))
+	assert.Equal(t, map[string]string{"name": "a.b"}, SeriesTags("a.b"))
+	assert.Equal(t, map[string]string{"name": "a.b;dc"}, SeriesTags("a.b;dc"))
+}
+
+func TestFormatTaggedName(t *testing.T) {
+	assert.Equal(t, "a.b;dc=us;host=a", FormatTaggedName(map[string]string{
+		"host": "a",
+		"name": "a.b",
+		"dc":   "us",
+	}))
+	assert.Equal(t, "a.b", FormatTaggedName(map[string]string{"name": "a.b"}))
+}
@@ -21,6 +21,7 @@
 package native
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"runtime"
@@ -30,6 +31,7 @@ import (
 
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/graphite/common"
+	"github.com/m3db/m3/src/query/graphite/graphite"
 	"github.com/m3db/m3/src/query/graphite/ts"
 	xerrors "github.com/m3db/m3/src/x/errors"
 )
@@ -129,6 +131,37 @@ func medianSeries(ctx *common.Context, series multiplePathSpecs) (ts.SeriesList,
 	}, nil
 }
 
+// averageZeroSeries takes a list of series and returns a new series containing
+// the average of all values at each datapoint, treating NaNs as zero.
+func averageZeroSeries(ctx *common.Context, series multiplePathSpecs) (ts.SeriesList, error) {
+	if len(series.Values) == 0 {
+		return ts.NewSeriesList(), nil
+	}
+	normalized, start, end, millisPerStep, err := common.Normalize(ctx, ts.SeriesList(series))
+	if err != nil {
+		return ts.NewSeriesList(), err
+	}
+	numSteps := ts.NumSteps(start, end, millisPerStep)
+	values := ts.NewValues(ctx, millisPerStep, numSteps)
+
+	for i := 0; i < numSteps; i++ {
+		var sum float64
+		for _, series := range normalized.Values {
+			if v := series.ValueAt(i); !math.IsNaN(v) {
+				sum += v
+			}
+		}
+		values.SetValueAt(i, sum/float64(len(normalized.Values)))
+	}
+
+	name := wrapPathExpr(avgZeroSeriesFnName, ts.SeriesList(series))
+	output := ts.NewSeries(ctx, name, start, values)
+	return ts.SeriesList{
+		Values:   []*ts.Series{output},
+		Metadata: series.Metadata,
+	}, nil
+}
+
 // lastSeries takes a list of series and returns a new series containing the
 // last value at each datapoint
 func lastSeries(ctx *common.Context, series multiplePathSpecs) (ts.SeriesList, error) {
@@ -292,6 +325,68 @@ func divideSeriesLists(ctx *common.Context, dividendSeriesList, divisorSeriesLis
 	return r, nil
 }
 
+// aggregateSeriesLists takes two series lists of the same length and returns
+// a series for each pair of series at the same position in the lists, which
+// is the value of the pair aggregated at each datapoint using the specified
+// function. Supports the same functions as aggregate.
+func aggregateSeriesLists(
+	ctx *common.Context,
+	firstSeriesList, secondSeriesList singlePathSpec,
+	fname string,
+) (ts.SeriesList, error) {
+	if len(firstSeriesList.Values) != len(secondSeriesList.Values) {
+		err := xerrors.NewInvalidParamsError(fmt.Errorf(
+			"aggregateSeriesLists both SeriesLists must have exactly the same length"))
+		return ts.NewSeriesList(), err
+	}
+
+	// If either list is not sorted yet then apply a default sort for deterministic results.
+	if !firstSeriesList.SortApplied {
+		// Use sort.Stable for deterministic output.
+		sort.Stable(ts.SeriesByName(firstSeriesList.Values))
+		firstSeriesList.SortApplied = true
+	}
+	if !secondSeriesList.SortApplied {
+		// Use sort.Stable for deterministic output.
+		sort.Stable(ts.SeriesByName(secondSeriesList.Values))
+		secondSeriesList.SortApplied = true
+	}
+
+	results := make([]*ts.Series, 0, len(firstSeriesList.Values))
+	for idx, first := range firstSeriesList.Values {
+		pair := ts.SeriesList{
+			Values:   []*ts.Series{first, secondSeriesList.Values[idx]},
+			Metadata: secondSeriesList.Metadata.CombineMetadata(firstSeriesList.Metadata),
+		}
+		aggregated, err := aggregate(ctx, singlePathSpec(pair), fname)
+		if err != nil {
+			return ts.NewSeriesList(), err
+		}
+		results = append(results, aggregated.Values...)
+	}
+
+	r := ts.SeriesList(firstSeriesList)
+	r.Values = results
+	return r, nil
+}
+
+// sumSeriesLists adds the series at the same position in two series lists.
+func sumSeriesLists(ctx *common.Context, firstSeriesList, secondSeriesList singlePathSpec) (ts.SeriesList, error) {
+	return aggregateSeriesLists(ctx, firstSeriesList, secondSeriesList, sumFnName)
+}
+
+// diffSeriesLists subtracts the series of the second series list from the
+// series at the same position in the first series list.
+func diffSeriesLists(ctx *common.Context, firstSeriesList, secondSeriesList singlePathSpec) (ts.SeriesList, error) {
+	return aggregateSeriesLists(ctx, firstSeriesList, secondSeriesList, diffFnName)
+}
+
+// multiplySeriesLists multiplies the series at the same position in two
+// series lists.
+func multiplySeriesLists(ctx *common.Context, firstSeriesList, secondSeriesList singlePathSpec) (ts.SeriesList, error) {
+	return aggregateSeriesLists(ctx, firstSeriesList, secondSeriesList, multiplyFnName)
+}
+
 // aggregate takes a list of series and returns a new series containing the
 // value aggregated across the series at each datapoint using the specified function.
 // This function can be used with aggregation functions average (or avg), avg_zero,
@@ -309,6 +404,8 @@ func aggregate(ctx *common.Context, series singlePathSpec, fname string) (ts.Ser
 		return medianSeries(ctx, multiplePathSpecs(series))
 	case avgFnName, averageFnName, averageSeriesFnName:
 		return averageSeries(ctx, multiplePathSpecs(series))
+	case avgZeroFnName, avgZeroSeriesFnName:
+		return averageZeroSeries(ctx, multiplePathSpecs(series))
 	case multiplyFnName, multiplySeriesFnName:
 		return multiplySeries(ctx, multiplePathSpecs(series))
 	case diffFnName, diffSeriesFnName:
@@ -322,8 +419,6 @@ func aggregate(ctx *common.Context, series singlePathSpec, fname string) (ts.Ser
 	case stddevFnName, stdevFnName, stddevSeriesFnName:
 		return stddevSeries(ctx, multiplePathSpecs(series))
 	default:
-		// Median: the movingMedian() method already implemented is returning an series non compatible result. skip support for now.
-		// avg_zero is not implemented, skip support for now unless later identified actual use cases.
 		return ts.NewSeriesList(), xerrors.NewInvalidParamsError(fmt.Errorf("invalid func %s", fname))
 	}
 }
@@ -702,6 +797,41 @@ func groupByNodes(ctx *common.Context, seriesList singlePathSpec, fname string,
 	return applyFnToMetaSeries(ctx, seriesList, metaSeries, fname)
 }
 
+// groupByTags takes a serieslist and maps a callback to subgroups within as
+// defined by the values of the given tags
+//
+//	&target=groupByTags(seriesByTag("name=cpu","dc=~us-.*"),"sum","dc")
+//
+// Would return a series for each dc which is the result of applying the "sum"
+// aggregation to the series of the dc. Series are named after the tags they
+// are grouped by, i.e. sum;dc=us-east, and after the grouped name if the
+// series are also grouped by name, i.e. cpu;dc=us-east.
+func groupByTags(ctx *common.Context, seriesList singlePathSpec, fname string, tags ...string) (ts.SeriesList, error) {
+	if len(tags) == 0 {
+		return ts.NewSeriesList(), xerrors.NewInvalidParamsError(
+			errors.New("groupByTags requires at least one tag"))
+	}
+
+	metaSeries := make(map[string][]*ts.Series)
+	for _, s := range seriesList.Values {
+		var (
+			seriesTags = graphite.SeriesTags(s.Name())
+			keyTags    = make(map[string]string, len(tags)+1)
+		)
+		for _, tag := range tags {
+			keyTags[tag] = seriesTags[tag]
+		}
+		if _, ok := keyTags[string(graphite.NameTag)]; !ok {
+			keyTags[string(graphite.NameTag)] = fname
+		}
+
+		key := graphite.FormatTaggedName(keyTags)
+		metaSeries[key] = append(metaSeries[key], s)
+	}
+
+	return applyFnToMetaSeries(ctx, seriesList, metaSeries, fname)
+}
+
 func applyFnToMetaSeries(ctx *common.Context, series singlePathSpec, metaSeries map[string][]*ts.Series, fname string) (ts.SeriesList, error) {
 	newSeries := make([]*ts.Series, 0, len(metaSeries))
 	for key, metaSeries := range metaSeries {
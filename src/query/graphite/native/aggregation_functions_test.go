@@ -906,6 +906,68 @@ func TestGroupByNodes(t *testing.T) {
 	}
 }
 
+func TestGroupByTags(t *testing.T) {
+	var (
+		start, _ = time.Parse(time.RFC1123, "Mon, 27 Jul 2015 19:41:19 GMT")
+		end, _   = time.Parse(time.RFC1123, "Mon, 27 Jul 2015 19:43:19 GMT")
+		ctx      = common.NewContext(common.ContextOptions{Start: start, End: end})
+		inputs   = []*ts.Series{
+			ts.NewSeries(ctx, "cpu.load;dc=us;host=a", start,
+				ts.NewConstantValues(ctx, 1, 12, 10000)),
+			ts.NewSeries(ctx, "cpu.load;dc=us;host=b", start,
+				ts.NewConstantValues(ctx, 2, 12, 10000)),
+			ts.NewSeries(ctx, "cpu.load;dc=eu;host=c", start,
+				ts.NewConstantValues(ctx, 4, 12, 10000)),
+			ts.NewSeries(ctx, "cpu.idle;host=d", start,
+				ts.NewConstantValues(ctx, 8, 12, 10000)),
+		}
+	)
+	defer ctx.Close()
+
+	type result struct {
+		name      string
+		sumOfVals float64
+	}
+
+	tests := []struct {
+		fname           string
+		tags            []string
+		expectedResults []result
+	}{
+		{"sum", []string{"dc"}, []result{
+			{"sum;dc=", 8 * 12},
+			{"sum;dc=eu", 4 * 12},
+			{"sum;dc=us", 3 * 12},
+		}},
+		{"max", []string{"dc", "name"}, []result{
+			{"cpu.idle;dc=", 8 * 12},
+			{"cpu.load;dc=eu", 4 * 12},
+			{"cpu.load;dc=us", 2 * 12},
+		}},
+	}
+
+	for _, test := range tests {
+		outSeries, err := groupByTags(ctx, singlePathSpec{
+			Values: inputs,
+		}, test.fname, test.tags...)
+		require.NoError(t, err)
+		require.Equal(t, len(test.expectedResults), len(outSeries.Values))
+
+		outSeries, _ = sortByName(ctx, singlePathSpec(outSeries), false, false)
+
+		for i, expected := range test.expectedResults {
+			series := outSeries.Values[i]
+			assert.Equal(t, expected.name, series.Name(),
+				"wrong name for %v %s (%d)", test.tags, test.fname, i)
+			assert.Equal(t, expected.sumOfVals, series.SafeSum(),
+				"wrong result for %v %s (%d)", test.tags, test.fname, i)
+		}
+	}
+
+	_, err := groupByTags(ctx, singlePathSpec{Values: inputs}, "sum")
+	require.Error(t, err)
+}
+
 func TestWeightedAverage(t *testing.T) {
 	ctx, _ := newConsolidationTestSeries()
 	defer ctx.Close()
@@ -964,3 +1026,65 @@ func TestCountSeries(t *testing.T) {
 	common.CompareOutputsAndExpected(t, input[1].MillisPerStep(), input[1].StartTime(),
 		[]common.TestSeries{expected}, results.Values)
 }
+
+func TestAggregateSeriesLists(t *testing.T) {
+	ctx := common.NewTestContext()
+	defer func() { _ = ctx.Close() }()
+
+	nan := math.NaN()
+	stepSize := 60000
+	newSeries := func(name string, values ...float64) *ts.Series {
+		return ts.NewSeries(ctx, name, ctx.StartTime, common.NewTestSeriesValues(ctx, stepSize, values))
+	}
+
+	tests := []struct {
+		fn       func(*common.Context, singlePathSpec, singlePathSpec) (ts.SeriesList, error)
+		expected []common.TestSeries
+	}{
+		{
+			fn: sumSeriesLists,
+			expected: []common.TestSeries{
+				{Name: "sumSeries(a,c)", Data: []float64{11, 2, 30}},
+				{Name: "sumSeries(b,d)", Data: []float64{5, 6, 7}},
+			},
+		},
+		{
+			fn: diffSeriesLists,
+			expected: []common.TestSeries{
+				{Name: "diffSeries(a,c)", Data: []float64{-9, 2, -30}},
+				{Name: "diffSeries(b,d)", Data: []float64{-1, -2, -3}},
+			},
+		},
+		{
+			fn: multiplySeriesLists,
+			expected: []common.TestSeries{
+				{Name: "multiplySeries(a,c)", Data: []float64{10, 2, 0}},
+				{Name: "multiplySeries(b,d)", Data: []float64{6, 8, 10}},
+			},
+		},
+		{
+			fn: func(ctx *common.Context, first, second singlePathSpec) (ts.SeriesList, error) {
+				return aggregateSeriesLists(ctx, first, second, "avg_zero")
+			},
+			expected: []common.TestSeries{
+				{Name: "avg_zeroSeries(a,c)", Data: []float64{5.5, 1, 15}},
+				{Name: "avg_zeroSeries(b,d)", Data: []float64{2.5, 3, 3.5}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		// The series of each list are matched by name order.
+		first := singlePathSpec{Values: []*ts.Series{newSeries("b", 2, 2, 2), newSeries("a", 1, 2, 0)}}
+		second := singlePathSpec{Values: []*ts.Series{newSeries("c", 10, nan, 30), newSeries("d", 3, 4, 5)}}
+
+		r, err := test.fn(ctx, first, second)
+		require.NoError(t, err)
+		common.CompareOutputsAndExpected(t, stepSize, ctx.StartTime, test.expected, r.Values)
+	}
+
+	_, err := sumSeriesLists(ctx,
+		singlePathSpec{Values: []*ts.Series{newSeries("a", 1)}},
+		singlePathSpec{Values: []*ts.Series{newSeries("b", 1), newSeries("c", 1)}})
+	require.Error(t, err)
+}
@@ -21,10 +21,16 @@
 package native
 
 import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/m3db/m3/src/query/graphite/common"
+	"github.com/m3db/m3/src/query/graphite/graphite"
 	"github.com/m3db/m3/src/query/graphite/ts"
+	xerrors "github.com/m3db/m3/src/x/errors"
 )
 
 // alias takes one metric or a wildcard seriesList and a string in quotes.
@@ -69,7 +75,111 @@ func aliasByNode(ctx *common.Context, seriesList singlePathSpec, nodes ...int) (
 	return ts.SeriesList(seriesList), nil
 }
 
+// aliasByTags renames a time series result according to a subset of the nodes
+// of its name and its tags. Numbers are used as nodes of the name, anything
+// else as a tag, tags that a series does not have are empty.
+func aliasByTags(ctx *common.Context, seriesList singlePathSpec, tags ...genericInterface) (ts.SeriesList, error) {
+	renamed := make([]*ts.Series, 0, ts.SeriesList(seriesList).Len())
+	for _, series := range seriesList.Values {
+		var (
+			seriesTags = graphite.SeriesTags(series.Name())
+			nameParts  = strings.Split(seriesTags[string(graphite.NameTag)], ".")
+			newParts   = make([]string, 0, len(tags))
+		)
+		for _, tag := range tags {
+			switch v := tag.(type) {
+			case float64:
+				node := int(v)
+				if node < 0 {
+					node += len(nameParts)
+				}
+				if node < 0 || node >= len(nameParts) {
+					newParts = append(newParts, "")
+					continue
+				}
+				newParts = append(newParts, nameParts[node])
+			default:
+				newParts = append(newParts, seriesTags[fmt.Sprint(v)])
+			}
+		}
+		renamed = append(renamed, series.RenamedTo(strings.Join(newParts, ".")))
+	}
+	seriesList.Values = renamed
+	return ts.SeriesList(seriesList), nil
+}
+
 // aliasSub runs series names through a regex search/replace.
 func aliasSub(ctx *common.Context, input singlePathSpec, search, replace string) (ts.SeriesList, error) {
 	return common.AliasSub(ctx, ts.SeriesList(input), search, replace)
 }
+
+// aliasQuery renames each series to newName formatted with the last value of
+// the series returned by the query built by running the name of the series
+// through a regex search/replace.
+func aliasQuery(
+	ctx *common.Context,
+	seriesList singlePathSpec,
+	search, replace, newName string,
+) (ts.SeriesList, error) {
+	renamed := make([]*ts.Series, 0, len(seriesList.Values))
+	for _, series := range seriesList.Values {
+		queried, err := common.AliasSub(ctx, ts.NewSeriesListWithSeries(series), search, replace)
+		if err != nil {
+			return ts.NewSeriesList(), xerrors.NewInvalidParamsError(err)
+		}
+
+		query := queried.Values[0].Name()
+		result, err := evaluateTarget(ctx, query)
+		if err != nil {
+			return ts.NewSeriesList(), err
+		}
+		if result.Len() == 0 {
+			return ts.NewSeriesList(), xerrors.NewInvalidParamsError(
+				fmt.Errorf("no series found with query: %s", query))
+		}
+
+		value := result.Values[0].SafeLastValue()
+		if math.IsNaN(value) {
+			return ts.NewSeriesList(), xerrors.NewInvalidParamsError(
+				fmt.Errorf("cannot get last value of series: %s", result.Values[0].Name()))
+		}
+
+		renamed = append(renamed, series.RenamedTo(formatPythonValue(newName, value)))
+	}
+
+	r := ts.SeriesList(seriesList)
+	r.Values = renamed
+	return r, nil
+}
+
+var pythonFormatRe = regexp.MustCompile(`%[-+ #0]*[0-9]*(?:\.[0-9]+)?[diouxXeEfFgGs%]`)
+
+// formatPythonValue formats a value the way python's % operator formats a
+// float, e.g. "%d" formats 1.5 as 1 and "%s" formats 1 as 1.0.
+func formatPythonValue(format string, value float64) string {
+	return pythonFormatRe.ReplaceAllStringFunc(format, func(verb string) string {
+		var (
+			flags = verb[:len(verb)-1]
+			kind  = verb[len(verb)-1]
+		)
+		switch kind {
+		case '%':
+			return "%"
+		case 'd', 'i', 'u':
+			return fmt.Sprintf(flags+"d", int64(value))
+		case 'o', 'x', 'X':
+			return fmt.Sprintf(flags+string(kind), int64(value))
+		case 's':
+			str := strconv.FormatFloat(value, 'f', -1, 64)
+			if !strings.ContainsAny(str, ".IN") {
+				str += ".0"
+			}
+			return fmt.Sprintf(flags+"s", str)
+		case 'g', 'G':
+			if !strings.Contains(flags, ".") {
+				flags += ".6"
+			}
+		}
+		return fmt.Sprintf(flags+string(kind), value)
+	})
+}
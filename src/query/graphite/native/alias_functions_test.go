@@ -377,3 +377,78 @@ func TestGroupByNodeAndAliasSubAndScopeMetric(t *testing.T) {
 	require.Equal(t, seriesList.Values[0].Name(), "a")
 	require.Equal(t, seriesList.Values[1].Name(), "b")
 }
+
+func TestAliasByTags(t *testing.T) {
+	ctrl := xgomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := storage.NewMockStorage(ctrl)
+	engine := NewEngine(store, CompileOptions{})
+	ctx := common.NewContext(common.ContextOptions{Start: time.Now().Add(-1 * time.Hour), End: time.Now(), Engine: engine})
+
+	stepSize := int((10 * time.Minute) / time.Millisecond)
+	store.EXPECT().FetchByQuery(gomock.Any(), `seriesByTag("name=~cpu")`, gomock.Any()).DoAndReturn(
+		buildTestSeriesFn(stepSize, "cpu.load;dc=us;host=a", "cpu.idle;host=b", "cpu.user"))
+
+	expr, err := engine.Compile("aliasByTags(seriesByTag('name=~cpu'), 1, 'dc', -1)")
+	require.NoError(t, err)
+
+	results, err := expr.Execute(ctx)
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(results.Values))
+	for _, series := range results.Values {
+		names = append(names, series.Name())
+	}
+	assert.Equal(t, []string{"load.us.load", "idle..idle", "user..user"}, names)
+}
+
+func TestAliasQuery(t *testing.T) {
+	ctrl := xgomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := storage.NewMockStorage(ctrl)
+	engine := NewEngine(store, CompileOptions{})
+	ctx := common.NewContext(common.ContextOptions{Start: time.Now().Add(-1 * time.Hour), End: time.Now(), Engine: engine})
+
+	stepSize := int((10 * time.Minute) / time.Millisecond)
+	store.EXPECT().FetchByQuery(gomock.Any(), "*.cake", gomock.Any()).DoAndReturn(
+		buildTestSeriesFn(stepSize, "chicago.cake", "new_york_city.cake"))
+	store.EXPECT().FetchByQuery(gomock.Any(), "chicago.cake", gomock.Any()).DoAndReturn(
+		buildTestSeriesFn(stepSize, "chicago.cake"))
+	store.EXPECT().FetchByQuery(gomock.Any(), "new_york_city.cake", gomock.Any()).DoAndReturn(
+		buildTestSeriesFn(stepSize, "new_york_city.cake"))
+
+	expr, err := engine.Compile(`aliasQuery(*.cake, "(.*)", "\1", "%s pies, %d cakes, %.2f%%")`)
+	require.NoError(t, err)
+
+	results, err := expr.Execute(ctx)
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(results.Values))
+	for _, series := range results.Values {
+		names = append(names, series.Name())
+	}
+	assert.Equal(t, []string{
+		"5.0 pies, 5 cakes, 5.00%",
+		"4.0 pies, 4 cakes, 4.00%",
+	}, names)
+}
+
+func TestFormatPythonValue(t *testing.T) {
+	for _, test := range []struct {
+		format   string
+		value    float64
+		expected string
+	}{
+		{format: "%d", value: 1.9, expected: "1"},
+		{format: "%i%%", value: -2.5, expected: "-2%"},
+		{format: "%s", value: 3, expected: "3.0"},
+		{format: "%s", value: 0.25, expected: "0.25"},
+		{format: "%5.1f", value: 3.14159, expected: "  3.1"},
+		{format: "%g", value: 0.000012345678, expected: "1.23457e-05"},
+		{format: "no verbs", value: 1, expected: "no verbs"},
+	} {
+		assert.Equal(t, test.expected, formatPythonValue(test.format, test.value), test.format)
+	}
+}
@@ -35,6 +35,7 @@ import (
 
 	"github.com/m3db/m3/src/query/graphite/common"
 	"github.com/m3db/m3/src/query/graphite/graphite"
+	"github.com/m3db/m3/src/query/graphite/storage"
 	"github.com/m3db/m3/src/query/graphite/ts"
 	"github.com/m3db/m3/src/query/util"
 	xerrors "github.com/m3db/m3/src/x/errors"
@@ -51,6 +52,7 @@ const (
 	gamma               = 0.1
 	beta                = 0.0035
 	defaultXFilesFactor = 0.0
+	maxTimeStackShifts  = 100
 )
 
 func joinPathExpr(series ts.SeriesList) string {
@@ -267,6 +269,24 @@ func minimumAbove(ctx *common.Context, series singlePathSpec, n float64) (ts.Ser
 	return aboveByFunction(ctx, series, sr, n)
 }
 
+// maximumBelow takes one metric or a wildcard seriesList followed by an floating point number n,
+// returns only the metrics with a maximum value below or equal to n.
+func maximumBelow(ctx *common.Context, series singlePathSpec, n float64) (ts.SeriesList, error) {
+	sr := ts.SeriesReducerMax.Reducer()
+	return compareByFunction(ctx, series, sr, func(stats, threshold float64) bool {
+		return stats <= threshold
+	}, n)
+}
+
+// minimumBelow takes one metric or a wildcard seriesList followed by an floating point number n,
+// returns only the metrics with a minimum value below or equal to n.
+func minimumBelow(ctx *common.Context, series singlePathSpec, n float64) (ts.SeriesList, error) {
+	sr := ts.SeriesReducerMin.Reducer()
+	return compareByFunction(ctx, series, sr, func(stats, threshold float64) bool {
+		return stats <= threshold
+	}, n)
+}
+
 // averageAbove takes one metric or a wildcard seriesList followed by an floating point number n,
 // returns only the metrics with an average value above n.
 func averageAbove(ctx *common.Context, series singlePathSpec, n float64) (ts.SeriesList, error) {
@@ -395,6 +415,64 @@ func timeShift(
 	}, nil
 }
 
+// timeStack draws the selected metrics shifted in time multiple times, once for
+// each multiple of timeShiftUnit from timeShiftStart up to but not including
+// timeShiftEnd. As with timeShift, if no sign is given a minus sign is implied.
+func timeStack(
+	ctx *common.Context,
+	seriesList singlePathSpec,
+	timeShiftUnit string,
+	timeShiftStart, timeShiftEnd int,
+) (ts.SeriesList, error) {
+	if !(strings.HasPrefix(timeShiftUnit, "+") || strings.HasPrefix(timeShiftUnit, "-")) {
+		timeShiftUnit = "-" + timeShiftUnit
+	}
+
+	delta, err := common.ParseInterval(timeShiftUnit)
+	if err != nil {
+		return ts.NewSeriesList(), xerrors.NewInvalidParamsError(
+			fmt.Errorf("invalid timeStack parameter %s: %w", timeShiftUnit, err))
+	}
+
+	if timeShiftEnd < timeShiftStart {
+		return ts.NewSeriesList(), xerrors.NewInvalidParamsError(
+			fmt.Errorf("timeStack end %d must not be before start %d", timeShiftEnd, timeShiftStart))
+	}
+	if timeShiftEnd-timeShiftStart > maxTimeStackShifts {
+		return ts.NewSeriesList(), xerrors.NewInvalidParamsError(
+			fmt.Errorf("timeStack cannot stack more than %d shifts, requested %d",
+				maxTimeStackShifts, timeShiftEnd-timeShiftStart))
+	}
+
+	if len(seriesList.Values) == 0 {
+		return ts.SeriesList(seriesList), nil
+	}
+
+	// NB: all series of the list have the same path expression, so shifting
+	// the first series shifts all of them.
+	target := seriesList.Values[0].Specification
+	results := make([]*ts.Series, 0, len(seriesList.Values)*(timeShiftEnd-timeShiftStart))
+	for shift := timeShiftStart; shift < timeShiftEnd; shift++ {
+		innerDelta := delta * time.Duration(shift)
+		opts := common.NewChildContextOptions()
+		opts.AdjustTimeRange(innerDelta, innerDelta, 0, 0)
+
+		shifted, err := evaluateTarget(ctx.NewChildContext(opts), target)
+		if err != nil {
+			return ts.NewSeriesList(), err
+		}
+
+		for _, series := range shifted.Values {
+			name := fmt.Sprintf("timeShift(%s, %s, %d)", series.Name(), timeShiftUnit, shift)
+			results = append(results, series.Shift(-1*innerDelta).RenamedTo(name))
+		}
+	}
+
+	r := ts.SeriesList(seriesList)
+	r.Values = results
+	return r, nil
+}
+
 // delay shifts all samples later by an integer number of steps. This can be used
 // for custom derivative calculations, among other things. Note: this will pad
 // the early end of the data with NaN for every step shifted. delay complements
@@ -1432,6 +1510,26 @@ func group(_ *common.Context, input multiplePathSpecs) (ts.SeriesList, error) {
 	return ts.SeriesList(input), nil
 }
 
+// unique takes an arbitrary number of pathspecs and returns the series with
+// unique names, the first series with a name is kept.
+func unique(_ *common.Context, input multiplePathSpecs) (ts.SeriesList, error) {
+	var (
+		seen   = make(map[string]struct{}, len(input.Values))
+		output = make([]*ts.Series, 0, len(input.Values))
+	)
+	for _, series := range input.Values {
+		if _, ok := seen[series.Name()]; ok {
+			continue
+		}
+		seen[series.Name()] = struct{}{}
+		output = append(output, series)
+	}
+
+	r := ts.SeriesList(input)
+	r.Values = output
+	return r, nil
+}
+
 func derivativeTemplate(ctx *common.Context, input singlePathSpec, nameTemplate string,
 	fn func(float64, float64) float64) (ts.SeriesList, error) {
 	output := make([]*ts.Series, len(input.Values))
@@ -1491,25 +1589,31 @@ func integral(ctx *common.Context, input singlePathSpec) (ts.SeriesList, error)
 
 // integralByInterval will do the same as integral funcion, except it resets the total to 0
 // at the given time in the parameter “from”. Useful for finding totals per hour/day/week.
+// Intervals are aligned to the start of the series, NaNs keep the current total.
 func integralByInterval(ctx *common.Context, input singlePathSpec, intervalString string) (ts.SeriesList, error) {
 	intervalUnit, err := common.ParseInterval(intervalString)
 	if err != nil {
 		return ts.NewSeriesList(), err
 	}
+	interval := intervalUnit.Milliseconds()
+	if interval <= 0 {
+		return ts.NewSeriesList(), common.ErrInvalidIntervalFormat
+	}
 	results := make([]*ts.Series, 0, len(input.Values))
 
 	for _, series := range input.Values {
 		var (
-			stepsPerInterval = intervalUnit.Milliseconds() / int64(series.MillisPerStep())
-			outVals          = ts.NewValues(ctx, series.MillisPerStep(), series.Len())
-			stepCounter      int64
-			currentSum       float64
+			millisPerStep = int64(series.MillisPerStep())
+			outVals       = ts.NewValues(ctx, series.MillisPerStep(), series.Len())
+			currentSum    float64
 		)
 
 		for i := 0; i < series.Len(); i++ {
-			if stepCounter == stepsPerInterval {
-				// startNewInterval
-				stepCounter = 0
+			// Start a new interval if an interval boundary is crossed since the
+			// previous step, which handles steps larger than the interval and
+			// intervals that are not a multiple of the step.
+			offset := int64(i) * millisPerStep
+			if i > 0 && offset/interval != (offset-millisPerStep)/interval {
 				currentSum = 0.0
 			}
 			n := series.ValueAt(i)
@@ -1517,7 +1621,6 @@ func integralByInterval(ctx *common.Context, input singlePathSpec, intervalStrin
 				currentSum += n
 			}
 			outVals.SetValueAt(i, currentSum)
-			stepCounter += 1
 		}
 
 		newName := fmt.Sprintf("integralByInterval(%s, %s)", series.Name(), intervalString)
@@ -1529,6 +1632,123 @@ func integralByInterval(ctx *common.Context, input singlePathSpec, intervalStrin
 	return r, nil
 }
 
+// linearRegression draws the linear regression of each series, computed from
+// the values of the series between startSourceAt and endSourceAt which
+// default to the time range of the query.
+func linearRegression(
+	ctx *common.Context,
+	seriesList singlePathSpec,
+	startSourceAt, endSourceAt string,
+) (ts.SeriesList, error) {
+	var (
+		now         = time.Now()
+		sourceStart = ctx.StartTime
+		sourceEnd   = ctx.EndTime
+		err         error
+	)
+	if startSourceAt != "" {
+		sourceStart, err = graphite.ParseTime(startSourceAt, now, 0)
+		if err != nil {
+			return ts.NewSeriesList(), xerrors.NewInvalidParamsError(err)
+		}
+	}
+	if endSourceAt != "" {
+		sourceEnd, err = graphite.ParseTime(endSourceAt, now, 0)
+		if err != nil {
+			return ts.NewSeriesList(), xerrors.NewInvalidParamsError(err)
+		}
+	}
+
+	// Fetch the source of the regression of each series if it is not the
+	// series itself, series are matched to their source by name.
+	var (
+		useSources = startSourceAt != "" || endSourceAt != ""
+		sources    = make(map[string]*ts.Series, len(seriesList.Values))
+	)
+	if useSources {
+		opts := common.NewChildContextOptions()
+		opts.AdjustTimeRange(sourceStart.Sub(ctx.StartTime), sourceEnd.Sub(ctx.EndTime), 0, 0)
+		sourceCtx := ctx.NewChildContext(opts)
+
+		fetched := make(map[string]struct{})
+		for _, series := range seriesList.Values {
+			if _, ok := fetched[series.Specification]; ok {
+				continue
+			}
+			fetched[series.Specification] = struct{}{}
+
+			sourceList, err := evaluateTarget(sourceCtx, series.Specification)
+			if err != nil {
+				return ts.NewSeriesList(), err
+			}
+			for _, source := range sourceList.Values {
+				sources[source.Name()] = source
+			}
+		}
+	}
+
+	results := make([]*ts.Series, 0, len(seriesList.Values))
+	for _, series := range seriesList.Values {
+		source := series
+		if useSources {
+			var ok bool
+			if source, ok = sources[series.Name()]; !ok {
+				continue
+			}
+		}
+
+		factor, offset, ok := linearRegressionAnalysis(source)
+		if !ok {
+			continue
+		}
+
+		var (
+			start         = float64(series.StartTime().Unix())
+			stepInSeconds = float64(series.MillisPerStep()) / millisPerSecond
+			vals          = ts.NewValues(ctx, series.MillisPerStep(), series.Len())
+		)
+		for i := 0; i < series.Len(); i++ {
+			vals.SetValueAt(i, offset+(start+float64(i)*stepInSeconds)*factor)
+		}
+
+		name := fmt.Sprintf("linearRegression(%s, %d, %d)",
+			series.Name(), sourceStart.Unix(), sourceEnd.Unix())
+		results = append(results, ts.NewSeries(ctx, name, series.StartTime(), vals))
+	}
+
+	r := ts.SeriesList(seriesList)
+	r.Values = results
+	return r, nil
+}
+
+// linearRegressionAnalysis returns the factor and offset of the least squares
+// regression line of a series, in terms of unix seconds.
+func linearRegressionAnalysis(series *ts.Series) (float64, float64, bool) {
+	var n, sumI, sumV, sumII, sumIV float64
+	for i := 0; i < series.Len(); i++ {
+		v := series.ValueAt(i)
+		if math.IsNaN(v) {
+			continue
+		}
+		idx := float64(i)
+		n++
+		sumI += idx
+		sumV += v
+		sumII += idx * idx
+		sumIV += idx * v
+	}
+
+	denominator := n*sumII - sumI*sumI
+	if denominator == 0 {
+		return 0, 0, false
+	}
+
+	stepInSeconds := float64(series.MillisPerStep()) / millisPerSecond
+	factor := (n*sumIV - sumI*sumV) / denominator / stepInSeconds
+	offset := (sumII*sumV-sumIV*sumI)/denominator - factor*float64(series.StartTime().Unix())
+	return factor, offset, true
+}
+
 // This is the opposite of the integral function.  This is useful for taking a
 // running total metric and calculating the delta between subsequent data
 // points.
@@ -2019,6 +2239,26 @@ func holtWintersConfidenceBandsInternal(ctx *common.Context, seriesList singlePa
 	return r, nil
 }
 
+// holtWintersConfidenceArea performs a Holt-Winters forecast using the series as
+// input data and returns the lower and upper confidence bands of each series
+// as an area between them.
+func holtWintersConfidenceArea(ctx *common.Context, seriesList singlePathSpec, delta float64) (ts.SeriesList, error) {
+	bands, err := holtWintersConfidenceBands(ctx, seriesList, delta)
+	if err != nil {
+		return ts.NewSeriesList(), err
+	}
+
+	results := make([]*ts.Series, 0, len(bands.Values))
+	for index, series := range seriesList.Values {
+		name := fmt.Sprintf("holtWintersConfidenceArea(%s)", series.Name())
+		lower, upper := bands.Values[2*index], bands.Values[2*index+1]
+		results = append(results, lower.RenamedTo(name), upper.RenamedTo(name))
+	}
+
+	bands.Values = results
+	return bands, nil
+}
+
 // holtWintersAberration performs a Holt-Winters forecast using the series as input data and
 // plots the positive or negative deviation of the series data from the forecast.
 func holtWintersAberration(ctx *common.Context, seriesList singlePathSpec, delta float64) (ts.SeriesList, error) {
@@ -2199,6 +2439,42 @@ func squareRoot(ctx *common.Context, seriesList singlePathSpec) (ts.SeriesList,
 	)
 }
 
+// exp raises e to the power of each datapoint.
+func exp(ctx *common.Context, seriesList singlePathSpec) (ts.SeriesList, error) {
+	return transform(
+		ctx,
+		seriesList,
+		func(fname string) string { return fmt.Sprintf(wrappingFmt, "exp", fname) },
+		math.Exp,
+	)
+}
+
+// sigmoid applies the logistic function 1 / (1 + e^-x) to each datapoint.
+func sigmoid(ctx *common.Context, seriesList singlePathSpec) (ts.SeriesList, error) {
+	return transform(
+		ctx,
+		seriesList,
+		func(fname string) string { return fmt.Sprintf(wrappingFmt, "sigmoid", fname) },
+		func(v float64) float64 { return 1 / (1 + math.Exp(-v)) },
+	)
+}
+
+// logit applies the inverse of the logistic function log(x / (1 - x)) to each
+// datapoint. Datapoints outside of the open interval (0, 1) become null.
+func logit(ctx *common.Context, seriesList singlePathSpec) (ts.SeriesList, error) {
+	return transform(
+		ctx,
+		seriesList,
+		func(fname string) string { return fmt.Sprintf(wrappingFmt, "logit", fname) },
+		func(v float64) float64 {
+			if !(v > 0 && v < 1) {
+				return math.NaN()
+			}
+			return math.Log(v / (1 - v))
+		},
+	)
+}
+
 // stdev takes one metric or a wildcard seriesList followed by an integer N. Draw the standard deviation
 // of all metrics passed for the past N datapoints. If the ratio of null points in the window is greater than
 // windowTolerance, skip the calculation.
@@ -2248,6 +2524,85 @@ func removeBelowPercentile(ctx *common.Context, seriesList singlePathSpec, perce
 		common.LessThan)
 }
 
+// removeBetweenPercentile removes series that do not have a value outside of
+// the n-th and (100-n)-th percentiles of the values of all series at the
+// same time.
+func removeBetweenPercentile(ctx *common.Context, seriesList singlePathSpec, n float64) (ts.SeriesList, error) {
+	if n < 50 {
+		n = 100 - n
+	}
+
+	if len(seriesList.Values) == 0 {
+		return ts.SeriesList(seriesList), nil
+	}
+
+	normalized, _, _, _, err := common.Normalize(ctx, ts.SeriesList(seriesList))
+	if err != nil {
+		return ts.NewSeriesList(), err
+	}
+
+	var (
+		numSteps        = normalized.Values[0].Len()
+		lowPercentiles  = make([]float64, numSteps)
+		highPercentiles = make([]float64, numSteps)
+		valuesAtTime    = make([]float64, len(normalized.Values))
+	)
+	for i := 0; i < numSteps; i++ {
+		for j, series := range normalized.Values {
+			valuesAtTime[j] = series.ValueAt(i)
+		}
+		// NB: GetPercentile sorts its input.
+		lowPercentiles[i] = common.GetPercentile(valuesAtTime, 100-n, false)
+		for j, series := range normalized.Values {
+			valuesAtTime[j] = series.ValueAt(i)
+		}
+		highPercentiles[i] = common.GetPercentile(valuesAtTime, n, false)
+	}
+
+	results := make([]*ts.Series, 0, len(seriesList.Values))
+	for idx, series := range normalized.Values {
+		for i := 0; i < numSteps; i++ {
+			v := series.ValueAt(i)
+			if !math.IsNaN(v) && !(lowPercentiles[i] < v && v < highPercentiles[i]) {
+				results = append(results, seriesList.Values[idx])
+				break
+			}
+		}
+	}
+
+	r := ts.SeriesList(seriesList)
+	r.Values = results
+	return r, nil
+}
+
+// averageOutsidePercentile removes series with an average value between the
+// n-th and (100-n)-th percentiles of the averages of all series.
+func averageOutsidePercentile(_ *common.Context, seriesList singlePathSpec, n float64) (ts.SeriesList, error) {
+	if n < 50 {
+		n = 100 - n
+	}
+
+	averages := make([]float64, 0, len(seriesList.Values))
+	for _, series := range seriesList.Values {
+		averages = append(averages, series.SafeAvg())
+	}
+
+	// NB: GetPercentile sorts its input.
+	lowPercentile := common.GetPercentile(append([]float64(nil), averages...), 100-n, false)
+	highPercentile := common.GetPercentile(append([]float64(nil), averages...), n, false)
+
+	results := make([]*ts.Series, 0, len(seriesList.Values))
+	for idx, series := range seriesList.Values {
+		if avg := averages[idx]; !(lowPercentile < avg && avg < highPercentile) {
+			results = append(results, series)
+		}
+	}
+
+	r := ts.SeriesList(seriesList)
+	r.Values = results
+	return r, nil
+}
+
 // randomWalkFunction returns a random walk starting at 0.
 // Note: step has a unit of seconds.
 func randomWalkFunction(ctx *common.Context, name string, step int) (ts.SeriesList, error) {
@@ -2777,6 +3132,36 @@ func offsetToZero(ctx *common.Context, seriesList singlePathSpec) (ts.SeriesList
 	return r, nil
 }
 
+// minMax scales the values of each series between 0 and 1 using the minimum
+// and maximum value of the series, the values of a constant series become 0.
+func minMax(ctx *common.Context, seriesList singlePathSpec) (ts.SeriesList, error) {
+	results := make([]*ts.Series, len(seriesList.Values))
+	for idx, series := range seriesList.Values {
+		var (
+			minimum  = series.SafeMin()
+			maximum  = series.SafeMax()
+			numSteps = series.Len()
+			vals     = ts.NewValues(ctx, series.MillisPerStep(), numSteps)
+		)
+		for i := 0; i < numSteps; i++ {
+			v := series.ValueAt(i)
+			switch {
+			case math.IsNaN(v):
+			case maximum == minimum:
+				vals.SetValueAt(i, 0)
+			default:
+				vals.SetValueAt(i, (v-minimum)/(maximum-minimum))
+			}
+		}
+		name := fmt.Sprintf("minMax(%s)", series.Name())
+		results[idx] = ts.NewSeries(ctx, name, series.StartTime(), vals)
+	}
+
+	r := ts.SeriesList(seriesList)
+	r.Values = results
+	return r, nil
+}
+
 // timeFunction returns the timestamp for each X value.
 // Note: step is measured in seconds.
 func timeFunction(ctx *common.Context, name string, step int) (ts.SeriesList, error) {
@@ -2798,6 +3183,81 @@ func timeFunction(ctx *common.Context, name string, step int) (ts.SeriesList, er
 	return ts.NewSeriesListWithSeries(series), nil
 }
 
+// sinFunction returns the sine of the timestamp of each X value, multiplied
+// by the amplitude.
+// Note: step is measured in seconds.
+func sinFunction(ctx *common.Context, name string, amplitude float64, step int) (ts.SeriesList, error) {
+	if step <= 0 {
+		return ts.NewSeriesList(), xerrors.NewInvalidParamsError(
+			fmt.Errorf("step must be a positive int but instead is %d", step))
+	}
+
+	stepSizeInMilli := step * millisPerSecond
+	numSteps := ts.NumSteps(ctx.StartTime, ctx.EndTime, stepSizeInMilli)
+	vals := ts.NewValues(ctx, stepSizeInMilli, numSteps)
+	start := ctx.StartTime.Truncate(time.Second)
+	for current, index := start.Unix(), 0; index < numSteps; index++ {
+		vals.SetValueAt(index, math.Sin(float64(current))*amplitude)
+		current += int64(step)
+	}
+
+	series := ts.NewSeries(ctx, name, start, vals)
+	return ts.NewSeriesListWithSeries(series), nil
+}
+
+// areaBetween draws the area between exactly two series, the first being the
+// lower bound and the second the upper bound.
+func areaBetween(_ *common.Context, seriesList singlePathSpec) (ts.SeriesList, error) {
+	if len(seriesList.Values) != 2 {
+		return ts.NewSeriesList(), xerrors.NewInvalidParamsError(
+			fmt.Errorf("areaBetween expects exactly 2 series but got %d", len(seriesList.Values)))
+	}
+
+	lower, upper := seriesList.Values[0], seriesList.Values[1]
+	name := fmt.Sprintf("areaBetween(%s)", upper.Specification)
+
+	r := ts.SeriesList(seriesList)
+	r.Values = []*ts.Series{lower.RenamedTo(name), upper.RenamedTo(name)}
+	return r, nil
+}
+
+// stacked takes one metric or a wildcard seriesList and changes each series to
+// be the running total of itself and all the series before it, so that the
+// series are drawn stacked on top of each other. Nulls are left as nulls and do
+// not contribute to the total.
+func stacked(ctx *common.Context, seriesList singlePathSpec, stack string) (ts.SeriesList, error) {
+	var (
+		results = make([]*ts.Series, 0, len(seriesList.Values))
+		totals  []float64
+	)
+	for _, series := range seriesList.Values {
+		numSteps := series.Len()
+		if numSteps > len(totals) {
+			totals = append(totals, make([]float64, numSteps-len(totals))...)
+		}
+
+		vals := ts.NewValues(ctx, series.MillisPerStep(), numSteps)
+		for i := 0; i < numSteps; i++ {
+			v := series.ValueAt(i)
+			if math.IsNaN(v) {
+				continue
+			}
+			totals[i] += v
+			vals.SetValueAt(i, totals[i])
+		}
+
+		name := fmt.Sprintf(wrappingFmt, "stacked", series.Name())
+		if stack != "" {
+			name = fmt.Sprintf("stacked(%s, %s)", series.Name(), stack)
+		}
+		results = append(results, ts.NewSeries(ctx, name, series.StartTime(), vals))
+	}
+
+	r := ts.SeriesList(seriesList)
+	r.Values = results
+	return r, nil
+}
+
 // dashed draws the selected metrics with a dotted line with segments of length f.
 func dashed(_ *common.Context, seriesList singlePathSpec, dashLength float64) (ts.SeriesList, error) {
 	if dashLength <= 0 {
@@ -2817,6 +3277,46 @@ func dashed(_ *common.Context, seriesList singlePathSpec, dashLength float64) (t
 	return r, nil
 }
 
+// color sets the color of the selected metrics when rendered as a graph, it
+// does not change the series.
+func color(_ *common.Context, seriesList singlePathSpec, _ string) (ts.SeriesList, error) {
+	return ts.SeriesList(seriesList), nil
+}
+
+// alphaFunction sets the alpha of the selected metrics when rendered as a
+// graph, it does not change the series.
+func alphaFunction(_ *common.Context, seriesList singlePathSpec, _ float64) (ts.SeriesList, error) {
+	return ts.SeriesList(seriesList), nil
+}
+
+// lineWidth sets the line width of the selected metrics when rendered as a
+// graph, it does not change the series.
+func lineWidth(_ *common.Context, seriesList singlePathSpec, _ float64) (ts.SeriesList, error) {
+	return ts.SeriesList(seriesList), nil
+}
+
+// secondYAxis draws the selected metrics on the second Y axis.
+func secondYAxis(_ *common.Context, seriesList singlePathSpec) (ts.SeriesList, error) {
+	return renameSeries(seriesList, "secondYAxis(%s)"), nil
+}
+
+// drawAsInfinite draws a vertical line at each datapoint of the selected
+// metrics with a non-zero value.
+func drawAsInfinite(_ *common.Context, seriesList singlePathSpec) (ts.SeriesList, error) {
+	return renameSeries(seriesList, "drawAsInfinite(%s)"), nil
+}
+
+func renameSeries(seriesList singlePathSpec, format string) ts.SeriesList {
+	results := make([]*ts.Series, len(seriesList.Values))
+	for idx, s := range seriesList.Values {
+		results[idx] = s.RenamedTo(fmt.Sprintf(format, s.Name()))
+	}
+
+	r := ts.SeriesList(seriesList)
+	r.Values = results
+	return r
+}
+
 // threshold draws a horizontal line at value f across the graph.
 func threshold(ctx *common.Context, value float64, label string, color string) (ts.SeriesList, error) {
 	seriesList, err := constantLine(ctx, value)
@@ -2834,6 +3334,45 @@ func threshold(ctx *common.Context, value float64, label string, color string) (
 	return ts.NewSeriesListWithSeries(series), nil
 }
 
+// verticalLine draws a vertical line at the given time, which must be within
+// the time range of the query.
+func verticalLine(ctx *common.Context, at string, label string, _ string) (ts.SeriesList, error) {
+	t, err := graphite.ParseTime(at, time.Now(), 0)
+	if err != nil {
+		return ts.NewSeriesList(), xerrors.NewInvalidParamsError(err)
+	}
+	t = t.Truncate(time.Second)
+
+	if t.Before(ctx.StartTime) {
+		return ts.NewSeriesList(), xerrors.NewInvalidParamsError(
+			fmt.Errorf("verticalLine timestamp %d is before the start of the range", t.Unix()))
+	}
+	if t.After(ctx.EndTime) {
+		return ts.NewSeriesList(), xerrors.NewInvalidParamsError(
+			fmt.Errorf("verticalLine timestamp %d is after the end of the range", t.Unix()))
+	}
+
+	if label == "" {
+		label = fmt.Sprintf("verticalLine(%s)", at)
+	}
+
+	vals := ts.NewConstantValues(ctx, 1, 2, millisPerSecond)
+	return ts.NewSeriesListWithSeries(ts.NewSeries(ctx, label, t, vals)), nil
+}
+
+// seriesByTag returns the tagged series matching all of the given tag
+// expressions, i.e. seriesByTag('name=cpu.load', 'dc=~us-.*', 'host!=a').
+func seriesByTag(ctx *common.Context, tagExpressions ...string) (ts.SeriesList, error) {
+	// NB: validate the expressions first since fetches of queries that cannot
+	// be translated return empty results rather than an error.
+	if _, err := storage.TranslateTagExpressionsToMatchers(tagExpressions); err != nil {
+		return ts.NewSeriesList(), xerrors.NewInvalidParamsError(err)
+	}
+
+	query := storage.FormatSeriesByTagQuery(tagExpressions)
+	return newFetchExpression(query).Execute(ctx)
+}
+
 func init() {
 	// functions - in alpha ordering
 	MustRegisterFunction(absolute)
@@ -2841,13 +3380,18 @@ func init() {
 	MustRegisterFunction(aggregateLine).WithDefaultParams(map[uint8]interface{}{
 		2: "avg", // f
 	})
+	MustRegisterFunction(aggregateSeriesLists)
 	MustRegisterFunction(aggregateWithWildcards).WithDefaultParams(map[uint8]interface{}{
 		3: -1, // positions
 	})
 	MustRegisterFunction(alias)
 	MustRegisterFunction(aliasByMetric)
 	MustRegisterFunction(aliasByNode)
+	MustRegisterFunction(aliasByTags)
+	MustRegisterFunction(aliasQuery)
 	MustRegisterFunction(aliasSub)
+	MustRegisterFunction(alphaFunction)
+	MustRegisterFunction(areaBetween)
 	MustRegisterFunction(applyByNode).WithDefaultParams(map[uint8]interface{}{
 		4: "", // newName
 	})
@@ -2856,12 +3400,14 @@ func init() {
 	})
 	MustRegisterFunction(averageAbove)
 	MustRegisterFunction(averageBelow)
+	MustRegisterFunction(averageOutsidePercentile)
 	MustRegisterFunction(averageSeries)
 	MustRegisterFunction(averageSeriesWithWildcards).WithDefaultParams(map[uint8]interface{}{
 		2: -1, // positions
 	})
 	MustRegisterFunction(cactiStyle)
 	MustRegisterFunction(changed)
+	MustRegisterFunction(color)
 	MustRegisterFunction(consolidateBy)
 	MustRegisterFunction(constantLine)
 	MustRegisterFunction(countSeries)
@@ -2874,9 +3420,12 @@ func init() {
 	MustRegisterFunction(delay)
 	MustRegisterFunction(derivative)
 	MustRegisterFunction(diffSeries)
+	MustRegisterFunction(diffSeriesLists)
 	MustRegisterFunction(divideSeries)
 	MustRegisterFunction(divideSeriesLists)
+	MustRegisterFunction(drawAsInfinite)
 	MustRegisterFunction(exclude)
+	MustRegisterFunction(exp)
 	MustRegisterFunction(exponentialMovingAverage).
 		WithoutUnaryContextShifterSkipFetchOptimization()
 	MustRegisterFunction(fallbackSeries)
@@ -2887,6 +3436,7 @@ func init() {
 		3: "average", // fname
 	})
 	MustRegisterFunction(groupByNodes)
+	MustRegisterFunction(groupByTags)
 	MustRegisterFunction(highest).WithDefaultParams(map[uint8]interface{}{
 		2: 1,         // n,
 		3: "average", // f
@@ -2898,6 +3448,9 @@ func init() {
 		3: false, // alignToInterval
 	})
 	MustRegisterFunction(holtWintersAberration)
+	MustRegisterFunction(holtWintersConfidenceArea).WithDefaultParams(map[uint8]interface{}{
+		2: 3.0, // delta
+	})
 	MustRegisterFunction(holtWintersConfidenceBands)
 	MustRegisterFunction(holtWintersForecast)
 	MustRegisterFunction(identity)
@@ -2913,9 +3466,15 @@ func init() {
 	})
 	MustRegisterFunction(legendValue)
 	MustRegisterFunction(limit)
+	MustRegisterFunction(linearRegression).WithDefaultParams(map[uint8]interface{}{
+		2: "", // startSourceAt
+		3: "", // endSourceAt
+	})
+	MustRegisterFunction(lineWidth)
 	MustRegisterFunction(logarithm).WithDefaultParams(map[uint8]interface{}{
 		2: 10.0, // base
 	})
+	MustRegisterFunction(logit)
 	MustRegisterFunction(lowest).WithDefaultParams(map[uint8]interface{}{
 		2: 1,         // n,
 		3: "average", // f
@@ -2924,8 +3483,11 @@ func init() {
 	MustRegisterFunction(lowestCurrent)
 	MustRegisterFunction(maxSeries)
 	MustRegisterFunction(maximumAbove)
+	MustRegisterFunction(maximumBelow)
+	MustRegisterFunction(minMax)
 	MustRegisterFunction(minSeries)
 	MustRegisterFunction(minimumAbove)
+	MustRegisterFunction(minimumBelow)
 	MustRegisterFunction(mostDeviant)
 	MustRegisterFunction(movingAverage).
 		WithDefaultParams(map[uint8]interface{}{
@@ -2959,6 +3521,7 @@ func init() {
 		}).
 		WithoutUnaryContextShifterSkipFetchOptimization()
 	MustRegisterFunction(multiplySeries)
+	MustRegisterFunction(multiplySeriesLists)
 	MustRegisterFunction(multiplySeriesWithWildcards).WithDefaultParams(map[uint8]interface{}{
 		2: -1, // positions
 	})
@@ -2984,6 +3547,7 @@ func init() {
 	MustRegisterFunction(removeAboveValue)
 	MustRegisterFunction(removeBelowPercentile)
 	MustRegisterFunction(removeBelowValue)
+	MustRegisterFunction(removeBetweenPercentile)
 	MustRegisterFunction(removeEmptySeries).WithDefaultParams(map[uint8]interface{}{
 		2: 0.0, // xFilesFactor
 	})
@@ -2992,6 +3556,13 @@ func init() {
 	})
 	MustRegisterFunction(scale)
 	MustRegisterFunction(scaleToSeconds)
+	MustRegisterFunction(secondYAxis)
+	MustRegisterFunction(seriesByTag)
+	MustRegisterFunction(sigmoid)
+	MustRegisterFunction(sinFunction).WithDefaultParams(map[uint8]interface{}{
+		2: 1.0, // amplitude
+		3: 60,  // step
+	})
 	MustRegisterFunction(sortBy).WithDefaultParams(map[uint8]interface{}{
 		2: "average", // fn
 		3: false,     // reverse
@@ -3004,6 +3575,9 @@ func init() {
 	})
 	MustRegisterFunction(sortByTotal)
 	MustRegisterFunction(squareRoot)
+	MustRegisterFunction(stacked).WithDefaultParams(map[uint8]interface{}{
+		2: "", // stack
+	})
 	MustRegisterFunction(stdev).WithDefaultParams(map[uint8]interface{}{
 		3: 0.1, // windowTolerance
 	})
@@ -3020,6 +3594,7 @@ func init() {
 		3: "", // fname
 	})
 	MustRegisterFunction(sumSeries)
+	MustRegisterFunction(sumSeriesLists)
 	MustRegisterFunction(sumSeriesWithWildcards).WithDefaultParams(map[uint8]interface{}{
 		2: -1, // positions
 	})
@@ -3036,24 +3611,36 @@ func init() {
 		3: true,  // resetEnd
 		4: false, // alignDst
 	})
+	MustRegisterFunction(timeStack).WithDefaultParams(map[uint8]interface{}{
+		2: "1d", // timeShiftUnit
+		3: 0,    // timeShiftStart
+		4: 7,    // timeShiftEnd
+	})
 	MustRegisterFunction(timeSlice).WithDefaultParams(map[uint8]interface{}{
 		3: "now", // endTime
 	})
 	MustRegisterFunction(transformNull).WithDefaultParams(map[uint8]interface{}{
 		2: 0.0, // defaultValue
 	})
+	MustRegisterFunction(unique)
 	MustRegisterFunction(useSeriesAbove)
+	MustRegisterFunction(verticalLine).WithDefaultParams(map[uint8]interface{}{
+		2: "", // label
+		3: "", // color
+	})
 	MustRegisterFunction(weightedAverage)
 
 	// alias functions - in alpha ordering
 	MustRegisterAliasedFunction("abs", absolute)
-	MustRegisterAliasedFunction("aliasByTags", aliasByNode)
+	MustRegisterAliasedFunction("alpha", alphaFunction)
 	MustRegisterAliasedFunction("avg", averageSeries)
 	MustRegisterAliasedFunction("log", logarithm)
 	MustRegisterAliasedFunction("max", maxSeries)
 	MustRegisterAliasedFunction("min", minSeries)
+	MustRegisterAliasedFunction("pct", asPercent)
 	MustRegisterAliasedFunction("randomWalk", randomWalkFunction)
 	MustRegisterAliasedFunction("round", roundFunction)
+	MustRegisterAliasedFunction("sin", sinFunction)
 	MustRegisterAliasedFunction("sum", sumSeries)
 	MustRegisterAliasedFunction("time", timeFunction)
 }
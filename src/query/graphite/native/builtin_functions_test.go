@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"strconv"
 	"testing"
 	"time"
 
@@ -40,6 +41,7 @@ import (
 	"github.com/m3db/m3/src/query/graphite/ts"
 	querystorage "github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/storage/m3/consolidators"
+	xerrors "github.com/m3db/m3/src/x/errors"
 	xgomock "github.com/m3db/m3/src/x/test"
 )
 
@@ -3672,6 +3674,87 @@ func TestSquareRoot(t *testing.T) {
 		expected, results.Values)
 }
 
+func TestExpSigmoidLogit(t *testing.T) {
+	ctx := common.NewTestContext()
+	defer func() { _ = ctx.Close() }()
+
+	nan := math.NaN()
+	stepSize := 10000
+	input := singlePathSpec{Values: []*ts.Series{
+		ts.NewSeries(ctx, "foo", ctx.StartTime,
+			common.NewTestSeriesValues(ctx, stepSize, []float64{0.0, 0.5, 1.0, -1.0, nan})),
+	}}
+
+	results, err := exp(ctx, input)
+	require.NoError(t, err)
+	common.CompareOutputsAndExpected(t, stepSize, ctx.StartTime, []common.TestSeries{
+		{Name: "exp(foo)", Data: []float64{1.0, 1.64872, 2.71828, 0.36788, nan}},
+	}, results.Values)
+
+	results, err = sigmoid(ctx, input)
+	require.NoError(t, err)
+	common.CompareOutputsAndExpected(t, stepSize, ctx.StartTime, []common.TestSeries{
+		{Name: "sigmoid(foo)", Data: []float64{0.5, 0.62246, 0.73106, 0.26894, nan}},
+	}, results.Values)
+
+	results, err = logit(ctx, input)
+	require.NoError(t, err)
+	common.CompareOutputsAndExpected(t, stepSize, ctx.StartTime, []common.TestSeries{
+		{Name: "logit(foo)", Data: []float64{nan, 0.0, nan, nan, nan}},
+	}, results.Values)
+}
+
+func TestAreaBetween(t *testing.T) {
+	ctx := common.NewTestContext()
+	defer func() { _ = ctx.Close() }()
+
+	stepSize := 10000
+	lower := ts.NewSeries(ctx, "foo.lower", ctx.StartTime,
+		common.NewTestSeriesValues(ctx, stepSize, []float64{1.0, 2.0}))
+	upper := ts.NewSeries(ctx, "foo.upper", ctx.StartTime,
+		common.NewTestSeriesValues(ctx, stepSize, []float64{3.0, 4.0}))
+	upper.Specification = "foo.*"
+
+	results, err := areaBetween(ctx, singlePathSpec{Values: []*ts.Series{lower, upper}})
+	require.NoError(t, err)
+	common.CompareOutputsAndExpected(t, stepSize, ctx.StartTime, []common.TestSeries{
+		{Name: "areaBetween(foo.*)", Data: []float64{1.0, 2.0}},
+		{Name: "areaBetween(foo.*)", Data: []float64{3.0, 4.0}},
+	}, results.Values)
+
+	_, err = areaBetween(ctx, singlePathSpec{Values: []*ts.Series{lower}})
+	require.Error(t, err)
+	require.True(t, xerrors.IsInvalidParams(err))
+}
+
+func TestStacked(t *testing.T) {
+	ctx := common.NewTestContext()
+	defer func() { _ = ctx.Close() }()
+
+	nan := math.NaN()
+	stepSize := 10000
+	input := singlePathSpec{Values: []*ts.Series{
+		ts.NewSeries(ctx, "foo", ctx.StartTime,
+			common.NewTestSeriesValues(ctx, stepSize, []float64{1.0, nan, 3.0})),
+		ts.NewSeries(ctx, "bar", ctx.StartTime,
+			common.NewTestSeriesValues(ctx, stepSize, []float64{2.0, 2.0, nan})),
+		ts.NewSeries(ctx, "baz", ctx.StartTime,
+			common.NewTestSeriesValues(ctx, stepSize, []float64{3.0, 3.0, 3.0})),
+	}}
+
+	results, err := stacked(ctx, input, "")
+	require.NoError(t, err)
+	common.CompareOutputsAndExpected(t, stepSize, ctx.StartTime, []common.TestSeries{
+		{Name: "stacked(foo)", Data: []float64{1.0, nan, 3.0}},
+		{Name: "stacked(bar)", Data: []float64{3.0, 2.0, nan}},
+		{Name: "stacked(baz)", Data: []float64{6.0, 5.0, 6.0}},
+	}, results.Values)
+
+	results, err = stacked(ctx, input, "mystack")
+	require.NoError(t, err)
+	require.Equal(t, "stacked(foo, mystack)", results.Values[0].Name())
+}
+
 func TestStdev(t *testing.T) {
 	ctx := common.NewTestContext()
 	defer func() { _ = ctx.Close() }()
@@ -4579,24 +4662,351 @@ func TestThreshold(t *testing.T) {
 	require.Equal(t, "1.000", results[0].Name())
 }
 
+func TestSeriesByTag(t *testing.T) {
+	ctrl := xgomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := storage.NewMockStorage(ctrl)
+	engine := NewEngine(store, CompileOptions{})
+	ctx := common.NewContext(common.ContextOptions{Start: time.Now().Add(-1 * time.Hour), End: time.Now(), Engine: engine})
+
+	stepSize := int((10 * time.Minute) / time.Millisecond)
+	store.EXPECT().FetchByQuery(gomock.Any(), `seriesByTag("name=cpu.load","dc=~us-.*")`, gomock.Any()).
+		DoAndReturn(buildTestSeriesFn(stepSize, "cpu.load;dc=us-east", "cpu.load;dc=us-west"))
+
+	expr, err := engine.Compile(`seriesByTag('name=cpu.load', "dc=~us-.*")`)
+	require.NoError(t, err)
+
+	results, err := expr.Execute(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(results.Values))
+	assert.Equal(t, "cpu.load;dc=us-east", results.Values[0].Name())
+	assert.Equal(t, "cpu.load;dc=us-west", results.Values[1].Name())
+
+	// At least one expression must match a non-empty value.
+	expr, err = engine.Compile(`seriesByTag('dc!=us-east')`)
+	require.NoError(t, err)
+	_, err = expr.Execute(ctx)
+	require.Error(t, err)
+}
+
+func TestMaximumBelow(t *testing.T) {
+	testComparatorFunc(t, maximumBelow, -10, nil)
+	testComparatorFunc(t, maximumBelow, 600, []int{2, 3})
+	testComparatorFunc(t, maximumBelow, 100000, []int{0, 2, 3, 4})
+}
+
+func TestMinimumBelow(t *testing.T) {
+	testComparatorFunc(t, minimumBelow, -1000, nil)
+	testComparatorFunc(t, minimumBelow, -5, []int{2, 3})
+	testComparatorFunc(t, minimumBelow, 0, []int{0, 2, 3, 4})
+}
+
+func testOutsidePercentileInput(ctx *common.Context) []*ts.Series {
+	nan := math.NaN()
+	inputs := []common.TestSeries{
+		{Name: "a", Data: []float64{1, 1, 1}},
+		{Name: "b", Data: []float64{2, nan, 2}},
+		{Name: "c", Data: []float64{3, 3, 3}},
+		{Name: "d", Data: []float64{4, 4, 4}},
+		{Name: "e", Data: []float64{5, 5, 5}},
+	}
+	series := make([]*ts.Series, 0, len(inputs))
+	for _, input := range inputs {
+		series = append(series, ts.NewSeries(ctx, input.Name, ctx.StartTime,
+			common.NewTestSeriesValues(ctx, 60000, input.Data)))
+	}
+	return series
+}
+
+func TestRemoveBetweenPercentile(t *testing.T) {
+	ctx := common.NewTestContext()
+	defer func() { _ = ctx.Close() }()
+
+	for _, n := range []float64{30, 70} {
+		input := testOutsidePercentileInput(ctx)
+		r, err := removeBetweenPercentile(ctx, singlePathSpec{Values: input}, n)
+		require.NoError(t, err)
+
+		// The 30th and 70th percentiles are 2 and 5 for the first and last
+		// steps, and 3 and 5 for the second step where b has no value.
+		require.Equal(t, 4, r.Len())
+		assert.Equal(t, input[0], r.Values[0])
+		assert.Equal(t, input[1], r.Values[1])
+		assert.Equal(t, input[2], r.Values[2])
+		assert.Equal(t, input[4], r.Values[3])
+	}
+}
+
+func TestAverageOutsidePercentile(t *testing.T) {
+	ctx := common.NewTestContext()
+	defer func() { _ = ctx.Close() }()
+
+	for _, n := range []float64{30, 70} {
+		input := testOutsidePercentileInput(ctx)
+		r, err := averageOutsidePercentile(ctx, singlePathSpec{Values: input}, n)
+		require.NoError(t, err)
+
+		// The 30th and 70th percentiles of the averages are 2 and 5.
+		require.Equal(t, 3, r.Len())
+		assert.Equal(t, input[0], r.Values[0])
+		assert.Equal(t, input[1], r.Values[1])
+		assert.Equal(t, input[4], r.Values[2])
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	ctx := common.NewTestContext()
+	defer func() { _ = ctx.Close() }()
+
+	nan := math.NaN()
+	stepSize := 10000
+	input := []*ts.Series{
+		ts.NewSeries(ctx, "foo", ctx.StartTime,
+			common.NewTestSeriesValues(ctx, stepSize, []float64{1, 2, nan, 5})),
+		ts.NewSeries(ctx, "bar", ctx.StartTime,
+			common.NewTestSeriesValues(ctx, stepSize, []float64{3, nan, 3})),
+	}
+
+	r, err := minMax(ctx, singlePathSpec{Values: input})
+	require.NoError(t, err)
+
+	expected := []common.TestSeries{
+		{Name: "minMax(foo)", Data: []float64{0, 0.25, nan, 1}},
+		{Name: "minMax(bar)", Data: []float64{0, nan, 0}},
+	}
+	common.CompareOutputsAndExpected(t, stepSize, ctx.StartTime, expected, r.Values)
+}
+
+func TestLinearRegression(t *testing.T) {
+	ctx := common.NewTestContext()
+	defer func() { _ = ctx.Close() }()
+
+	nan := math.NaN()
+	stepSize := 60000
+	input := []*ts.Series{
+		ts.NewSeries(ctx, "foo", ctx.StartTime,
+			common.NewTestSeriesValues(ctx, stepSize, []float64{1, nan, 5, 7})),
+		ts.NewSeries(ctx, "bar", ctx.StartTime,
+			common.NewTestSeriesValues(ctx, stepSize, []float64{2, nan, nan})),
+	}
+
+	r, err := linearRegression(ctx, singlePathSpec{Values: input}, "", "")
+	require.NoError(t, err)
+
+	// There are not enough values in bar to compute a regression.
+	name := fmt.Sprintf("linearRegression(foo, %d, %d)", ctx.StartTime.Unix(), ctx.EndTime.Unix())
+	expected := []common.TestSeries{
+		{Name: name, Data: []float64{1, 3, 5, 7}},
+	}
+	common.CompareOutputsAndExpected(t, stepSize, ctx.StartTime, expected, r.Values)
+}
+
+func TestLinearRegressionWithSource(t *testing.T) {
+	ctrl := xgomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := storage.NewMockStorage(ctrl)
+	now := time.Now().Truncate(time.Hour)
+	engine := NewEngine(store, CompileOptions{})
+	startTime := now.Add(-3 * time.Minute)
+	endTime := now.Add(-time.Minute)
+	ctx := common.NewContext(common.ContextOptions{
+		Start:  startTime,
+		End:    endTime,
+		Engine: engine,
+	})
+	defer func() { _ = ctx.Close() }()
+
+	stepSize := 60000
+	sourceStart := now.Add(-time.Hour)
+	store.EXPECT().FetchByQuery(gomock.Any(), "foo.bar.g.zed", gomock.Any()).DoAndReturn(
+		buildTestSeriesFn(stepSize, "foo.bar.g.zed")).Times(2)
+
+	target := fmt.Sprintf("linearRegression(foo.bar.g.zed, '%d')", sourceStart.Unix())
+	expr, err := engine.Compile(target)
+	require.NoError(t, err)
+	res, err := expr.Execute(ctx)
+	require.NoError(t, err)
+
+	// The source series is constant so the regression is constant too.
+	expected := common.TestSeries{
+		Name: fmt.Sprintf("linearRegression(foo.bar.g.zed, %d, %d)", sourceStart.Unix(), endTime.Unix()),
+		Data: []float64{1.0, 1.0},
+	}
+	common.CompareOutputsAndExpected(t, stepSize, startTime,
+		[]common.TestSeries{expected}, res.Values)
+}
+
+func TestSinFunction(t *testing.T) {
+	ctx := common.NewTestContext()
+	now := time.Now()
+	truncatedNow := float64(now.Truncate(time.Second).Unix())
+	ctx.StartTime = now
+	ctx.EndTime = now.Add(2 * time.Minute)
+	defer func() { _ = ctx.Close() }()
+
+	results, err := sinFunction(ctx, "foo", 2, 60)
+	require.NoError(t, err)
+	expected := common.TestSeries{
+		Name: "foo",
+		Data: []float64{2 * math.Sin(truncatedNow), 2 * math.Sin(truncatedNow+60)},
+	}
+	common.CompareOutputsAndExpected(t, 60000, now.Truncate(time.Second),
+		[]common.TestSeries{expected}, results.Values)
+
+	_, err = sinFunction(ctx, "foo", 1, 0)
+	require.Error(t, err)
+}
+
+func TestTimeStack(t *testing.T) {
+	ctrl := xgomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := storage.NewMockStorage(ctrl)
+	now := time.Now().Truncate(time.Hour)
+	engine := NewEngine(store, CompileOptions{})
+	startTime := now.Add(-3 * time.Minute)
+	endTime := now.Add(-time.Minute)
+	ctx := common.NewContext(common.ContextOptions{
+		Start:  startTime,
+		End:    endTime,
+		Engine: engine,
+	})
+	defer func() { _ = ctx.Close() }()
+
+	stepSize := 60000
+	store.EXPECT().FetchByQuery(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		buildTestSeriesFn(stepSize, "foo.bar.q.zed")).AnyTimes()
+
+	expr, err := engine.Compile("timeStack(foo.bar.q.zed, '1min', 0, 3)")
+	require.NoError(t, err)
+	res, err := expr.Execute(ctx)
+	require.NoError(t, err)
+
+	expected := []common.TestSeries{
+		{Name: "timeShift(foo.bar.q.zed, -1min, 0)", Data: []float64{0.0, 0.0}},
+		{Name: "timeShift(foo.bar.q.zed, -1min, 1)", Data: []float64{0.0, 0.0}},
+		{Name: "timeShift(foo.bar.q.zed, -1min, 2)", Data: []float64{0.0, 0.0}},
+	}
+	common.CompareOutputsAndExpected(t, stepSize, startTime, expected, res.Values)
+}
+
+func TestTimeStackInvalidRange(t *testing.T) {
+	ctx := common.NewTestContext()
+	defer func() { _ = ctx.Close() }()
+
+	input := singlePathSpec{Values: []*ts.Series{
+		ts.NewSeries(ctx, "foo", ctx.StartTime, ts.NewConstantValues(ctx, 1, 2, 10)),
+	}}
+
+	_, err := timeStack(ctx, input, "1min", 3, 0)
+	require.Error(t, err)
+	require.True(t, xerrors.IsInvalidParams(err))
+
+	_, err = timeStack(ctx, input, "1min", 0, maxTimeStackShifts+1)
+	require.Error(t, err)
+	require.True(t, xerrors.IsInvalidParams(err))
+}
+
+func TestUnique(t *testing.T) {
+	ctx := common.NewTestContext()
+	defer func() { _ = ctx.Close() }()
+
+	values := ts.NewConstantValues(ctx, 10.0, 5, 10)
+	foo := ts.NewSeries(ctx, "foo", ctx.StartTime, values)
+	bar := ts.NewSeries(ctx, "bar", ctx.StartTime, values)
+	otherFoo := ts.NewSeries(ctx, "foo", ctx.StartTime, values)
+
+	r, err := unique(ctx, multiplePathSpecs{Values: []*ts.Series{foo, bar, otherFoo}})
+	require.NoError(t, err)
+	require.Equal(t, 2, r.Len())
+	assert.Equal(t, foo, r.Values[0])
+	assert.Equal(t, bar, r.Values[1])
+}
+
+func TestVerticalLine(t *testing.T) {
+	ctx := common.NewTestContext()
+	defer func() { _ = ctx.Close() }()
+
+	at := ctx.StartTime.Add(10 * time.Minute)
+	atString := strconv.FormatInt(at.Unix(), 10)
+
+	r, err := verticalLine(ctx, atString, "", "")
+	require.NoError(t, err)
+	expected := common.TestSeries{
+		Name: fmt.Sprintf("verticalLine(%s)", atString),
+		Data: []float64{1, 1},
+	}
+	common.CompareOutputsAndExpected(t, 1000, at, []common.TestSeries{expected}, r.Values)
+
+	r, err = verticalLine(ctx, atString, "deploy", "red")
+	require.NoError(t, err)
+	require.Equal(t, 1, r.Len())
+	assert.Equal(t, "deploy", r.Values[0].Name())
+
+	before := strconv.FormatInt(ctx.StartTime.Add(-time.Minute).Unix(), 10)
+	_, err = verticalLine(ctx, before, "", "")
+	require.Error(t, err)
+
+	after := strconv.FormatInt(ctx.EndTime.Add(time.Minute).Unix(), 10)
+	_, err = verticalLine(ctx, after, "", "")
+	require.Error(t, err)
+}
+
+func TestCosmeticFunctions(t *testing.T) {
+	ctx := common.NewTestContext()
+	defer func() { _ = ctx.Close() }()
+
+	input := singlePathSpec{Values: getTestInput(ctx)}
+
+	r, err := color(ctx, input, "red")
+	require.NoError(t, err)
+	assert.Equal(t, input.Values, r.Values)
+
+	r, err = alphaFunction(ctx, input, 0.5)
+	require.NoError(t, err)
+	assert.Equal(t, input.Values, r.Values)
+
+	r, err = lineWidth(ctx, input, 2)
+	require.NoError(t, err)
+	assert.Equal(t, input.Values, r.Values)
+
+	r, err = secondYAxis(ctx, input)
+	require.NoError(t, err)
+	require.Equal(t, len(input.Values), r.Len())
+	assert.Equal(t, "secondYAxis(foo)", r.Values[0].Name())
+
+	r, err = drawAsInfinite(ctx, input)
+	require.NoError(t, err)
+	require.Equal(t, len(input.Values), r.Len())
+	assert.Equal(t, "drawAsInfinite(foo)", r.Values[0].Name())
+}
+
 func TestFunctionsRegistered(t *testing.T) {
 	fnames := []string{
 		"abs",
 		"absolute",
 		"aggregate",
 		"aggregateLine",
+		"aggregateSeriesLists",
 		"alias",
 		"aliasByMetric",
 		"aliasByNode",
 		"aliasByTags",
+		"aliasQuery",
 		"aliasSub",
+		"alpha",
+		"areaBetween",
 		"asPercent",
 		"averageAbove",
+		"averageOutsidePercentile",
 		"averageSeries",
 		"averageSeriesWithWildcards",
 		"avg",
 		"cactiStyle",
 		"changed",
+		"color",
 		"consolidateBy",
 		"constantLine",
 		"countSeries",
@@ -4607,21 +5017,26 @@ func TestFunctionsRegistered(t *testing.T) {
 		"delay",
 		"derivative",
 		"diffSeries",
+		"diffSeriesLists",
 		"divideSeries",
 		"divideSeriesLists",
+		"drawAsInfinite",
 		"exclude",
+		"exp",
 		"exponentialMovingAverage",
 		"fallbackSeries",
 		"grep",
 		"group",
 		"groupByNode",
 		"groupByNodes",
+		"groupByTags",
 		"highest",
 		"highestAverage",
 		"highestCurrent",
 		"highestMax",
 		"hitcount",
 		"holtWintersAberration",
+		"holtWintersConfidenceArea",
 		"holtWintersConfidenceBands",
 		"holtWintersForecast",
 		"identity",
@@ -4632,15 +5047,21 @@ func TestFunctionsRegistered(t *testing.T) {
 		"keepLastValue",
 		"legendValue",
 		"limit",
+		"linearRegression",
+		"lineWidth",
 		"log",
 		"logarithm",
+		"logit",
 		"lowest",
 		"lowestAverage",
 		"lowestCurrent",
 		"max",
+		"maximumBelow",
 		"maxSeries",
 		"maximumAbove",
 		"min",
+		"minimumBelow",
+		"minMax",
 		"minSeries",
 		"minimumAbove",
 		"mostDeviant",
@@ -4650,10 +5071,12 @@ func TestFunctionsRegistered(t *testing.T) {
 		"movingMax",
 		"movingMin",
 		"multiplySeries",
+		"multiplySeriesLists",
 		"nonNegativeDerivative",
 		"nPercentile",
 		"offset",
 		"offsetToZero",
+		"pct",
 		"perSecond",
 		"pow",
 		"powSeries",
@@ -4664,28 +5087,39 @@ func TestFunctionsRegistered(t *testing.T) {
 		"removeAboveValue",
 		"removeBelowPercentile",
 		"removeBelowValue",
+		"removeBetweenPercentile",
 		"removeEmptySeries",
 		"scale",
 		"scaleToSeconds",
+		"secondYAxis",
+		"seriesByTag",
+		"sigmoid",
+		"sin",
+		"sinFunction",
 		"smartSummarize",
 		"sortByMaxima",
 		"sortByMinima",
 		"sortByName",
 		"sortByTotal",
 		"squareRoot",
+		"stacked",
 		"stdev",
 		"stddevSeries",
 		"substr",
 		"sum",
 		"sumSeries",
 		"summarize",
+		"sumSeriesLists",
 		"threshold",
 		"time",
 		"timeFunction",
 		"timeShift",
 		"timeSlice",
+		"timeStack",
 		"transformNull",
+		"unique",
 		"useSeriesAbove",
+		"verticalLine",
 		"weightedAverage",
 	}
 
@@ -46,6 +46,8 @@ const (
 	averageFnName        = "average"
 	averageSeriesFnName  = "averageSeries"
 	avgFnName            = "avg"
+	avgZeroFnName        = "avg_zero"
+	avgZeroSeriesFnName  = "avg_zeroSeries"
 	countFnName          = "count"
 	countSeriesFnName    = "countSeries"
 	currentFnName        = "current"
@@ -195,6 +197,7 @@ var (
 	singlePathSpecType         = reflect.TypeOf(singlePathSpec{})
 	multiplePathSpecsType      = reflect.TypeOf(multiplePathSpecs{})
 	interfaceType              = reflect.TypeOf([]genericInterface{}).Elem()
+	interfaceSliceType         = reflect.SliceOf(interfaceType)
 	float64Type                = reflect.TypeOf(float64(100))
 	float64SliceType           = reflect.SliceOf(float64Type)
 	intType                    = reflect.TypeOf(int(0))
@@ -214,7 +217,8 @@ var allowableTypes = reflectTypeSet{
 	seriesListType,
 	singlePathSpecType,
 	multiplePathSpecsType,
-	interfaceType, // only for function parameters
+	interfaceType,      // only for function parameters
+	interfaceSliceType, // only for function parameters
 	float64Type,
 	float64SliceType,
 	intType,
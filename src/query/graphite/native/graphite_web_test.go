@@ -0,0 +1,151 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/query/graphite/common"
+	"github.com/m3db/m3/src/query/graphite/context"
+	"github.com/m3db/m3/src/query/graphite/storage"
+	xtest "github.com/m3db/m3/src/query/graphite/testing"
+	"github.com/m3db/m3/src/query/graphite/ts"
+	xgomock "github.com/m3db/m3/src/x/test"
+)
+
+// graphiteWebFixtureDelta allows for the last digits of floating point values
+// to differ between Go and Python.
+const graphiteWebFixtureDelta = 1e-12
+
+// graphiteWebFixture is a render target together with the stored series it
+// reads and the series graphite-web renders for it, the series use the JSON
+// format of the graphite-web render API.
+type graphiteWebFixture struct {
+	Target string              `json:"target"`
+	Series []graphiteWebSeries `json:"series"`
+	Render []graphiteWebSeries `json:"render"`
+}
+
+type graphiteWebSeries struct {
+	Target     string        `json:"target"`
+	Datapoints [][2]*float64 `json:"datapoints"`
+}
+
+func (s graphiteWebSeries) start() time.Time {
+	return time.Unix(int64(*s.Datapoints[0][1]), 0)
+}
+
+func (s graphiteWebSeries) millisPerStep() int {
+	if len(s.Datapoints) < 2 {
+		return 60000
+	}
+	return int(*s.Datapoints[1][1]-*s.Datapoints[0][1]) * 1000
+}
+
+func (s graphiteWebSeries) values() []float64 {
+	values := make([]float64, 0, len(s.Datapoints))
+	for _, dp := range s.Datapoints {
+		if dp[0] == nil {
+			values = append(values, math.NaN())
+			continue
+		}
+		values = append(values, *dp[0])
+	}
+	return values
+}
+
+// TestGraphiteWebFixtures compares the values rendered for the targets in
+// testdata/graphite-web with the values graphite-web renders for them, series
+// names are not compared since they differ for some functions.
+func TestGraphiteWebFixtures(t *testing.T) {
+	files, err := filepath.Glob("testdata/graphite-web/*.json")
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	for _, file := range files {
+		file := file
+		t.Run(strings.TrimSuffix(filepath.Base(file), ".json"), func(t *testing.T) {
+			data, err := ioutil.ReadFile(file)
+			require.NoError(t, err)
+
+			var fixture graphiteWebFixture
+			require.NoError(t, json.Unmarshal(data, &fixture))
+			require.NotEmpty(t, fixture.Series)
+			testGraphiteWebFixture(t, fixture)
+		})
+	}
+}
+
+func testGraphiteWebFixture(t *testing.T, fixture graphiteWebFixture) {
+	ctrl := xgomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		store  = storage.NewMockStorage(ctrl)
+		engine = NewEngine(store, CompileOptions{})
+		first  = fixture.Series[0]
+		start  = first.start()
+		end    = start.Add(time.Duration(len(first.Datapoints)*first.millisPerStep()) * time.Millisecond)
+		ctx    = common.NewContext(common.ContextOptions{Start: start, End: end, Engine: engine})
+	)
+	defer func() { _ = ctx.Close() }()
+
+	store.EXPECT().FetchByQuery(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, query string, _ storage.FetchOptions) (*storage.FetchResult, error) {
+			var series []*ts.Series
+			for _, s := range fixture.Series {
+				if ok, _ := path.Match(query, s.Target); !ok {
+					continue
+				}
+				series = append(series, ts.NewSeries(ctx, s.Target, s.start(),
+					common.NewTestSeriesValues(ctx, s.millisPerStep(), s.values())))
+			}
+			return &storage.FetchResult{SeriesList: series}, nil
+		}).AnyTimes()
+
+	expr, err := engine.Compile(fixture.Target)
+	require.NoError(t, err)
+
+	result, err := expr.Execute(ctx)
+	require.NoError(t, err)
+	require.Equal(t, len(fixture.Render), result.Len())
+
+	for i, expected := range fixture.Render {
+		actual := result.Values[i]
+		require.Equal(t, expected.start(), actual.StartTime(), "start of %s", expected.Target)
+		require.Equal(t, expected.millisPerStep(), actual.MillisPerStep(), "step of %s", expected.Target)
+		require.Equal(t, len(expected.Datapoints), actual.Len(), "length of %s", expected.Target)
+		for j, v := range expected.values() {
+			xtest.InDeltaWithNaNs(t, v, actual.ValueAt(j), graphiteWebFixtureDelta,
+				"value %d of %s", j, expected.Target)
+		}
+	}
+}
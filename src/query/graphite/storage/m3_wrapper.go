@@ -173,7 +173,19 @@ func translateQuery(
 	fetchOpts FetchOptions,
 	opts M3WrappedStorageOptions,
 ) (*storage.FetchQuery, error) {
-	matchers, _, err := TranslateQueryToMatchersWithTerminator(query)
+	var (
+		matchers models.Matchers
+		err      error
+	)
+	if IsSeriesByTagQuery(query) {
+		var exprs []string
+		exprs, err = ParseSeriesByTagQuery(query)
+		if err == nil {
+			matchers, err = TranslateTagExpressionsToMatchers(exprs)
+		}
+	} else {
+		matchers, _, err = TranslateQueryToMatchersWithTerminator(query)
+	}
 	if err != nil {
 		return nil, err
 	}
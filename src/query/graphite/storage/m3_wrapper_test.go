@@ -84,6 +84,25 @@ func TestTranslateQuery(t *testing.T) {
 	assert.Equal(t, expected, matchers)
 }
 
+func TestTranslateSeriesByTagQuery(t *testing.T) {
+	query := `seriesByTag('name=foo.bar','dc!=us')`
+	opts := FetchOptions{
+		StartTime: time.Now().Add(-time.Hour),
+		EndTime:   time.Now(),
+	}
+
+	translated, err := translateQuery(query, opts, M3WrappedStorageOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, query, translated.Raw)
+	assert.Equal(t, models.Matchers{
+		{Type: models.MatchEqual, Name: []byte("name"), Value: []byte("foo.bar")},
+		{Type: models.MatchNotEqual, Name: []byte("dc"), Value: []byte("us")},
+	}, translated.TagMatchers)
+
+	_, err = translateQuery(`seriesByTag('dc!=us')`, opts, M3WrappedStorageOptions{})
+	require.Error(t, err)
+}
+
 func TestTranslateQueryStarStar(t *testing.T) {
 	query := `foo**bar`
 	end := time.Now()
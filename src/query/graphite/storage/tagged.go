@@ -0,0 +1,188 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/m3db/m3/src/query/models"
+)
+
+const (
+	// SeriesByTagFunction is the name of the function selecting tagged series.
+	SeriesByTagFunction = "seriesByTag"
+
+	seriesByTagPrefix = SeriesByTagFunction + "("
+)
+
+var (
+	tagExpressionRegexp = regexp.MustCompile(`^([^;!=]+)(!?=~?)(.*)$`)
+
+	errNoTagExpressions      = errors.New("at least one tag expression is required")
+	errNoPositiveExpressions = errors.New(
+		"at least one tag expression must match a non-empty value")
+)
+
+// IsSeriesByTagQuery returns true if the query selects tagged series, i.e.
+// is a seriesByTag('tag=value', ...) query.
+func IsSeriesByTagQuery(query string) bool {
+	return strings.HasPrefix(query, seriesByTagPrefix)
+}
+
+// FormatSeriesByTagQuery formats the seriesByTag query selecting tagged
+// series by the given tag expressions.
+func FormatSeriesByTagQuery(exprs []string) string {
+	var b strings.Builder
+	b.WriteString(seriesByTagPrefix)
+	for i, expr := range exprs {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.Quote(expr))
+	}
+	b.WriteByte(')')
+	return b.String()
+}
+
+// ParseSeriesByTagQuery parses the tag expressions of a seriesByTag query.
+func ParseSeriesByTagQuery(query string) ([]string, error) {
+	if !IsSeriesByTagQuery(query) || !strings.HasSuffix(query, ")") {
+		return nil, fmt.Errorf("invalid %s query: %s", SeriesByTagFunction, query)
+	}
+
+	var (
+		args  = query[len(seriesByTagPrefix) : len(query)-1]
+		exprs []string
+	)
+	for {
+		args = strings.TrimLeft(args, " ")
+		if len(args) == 0 {
+			break
+		}
+
+		var expr string
+		switch args[0] {
+		case '"':
+			quoted, err := strconv.QuotedPrefix(args)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s query: %s", SeriesByTagFunction, query)
+			}
+			expr, _ = strconv.Unquote(quoted)
+			args = args[len(quoted):]
+		case '\'':
+			end := strings.IndexByte(args[1:], '\'')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid %s query: %s", SeriesByTagFunction, query)
+			}
+			expr = args[1 : end+1]
+			args = args[end+2:]
+		default:
+			return nil, fmt.Errorf("invalid %s query: %s", SeriesByTagFunction, query)
+		}
+
+		exprs = append(exprs, expr)
+		args = strings.TrimLeft(args, " ")
+		if len(args) > 0 {
+			if args[0] != ',' {
+				return nil, fmt.Errorf("invalid %s query: %s", SeriesByTagFunction, query)
+			}
+			args = args[1:]
+		}
+	}
+
+	return exprs, nil
+}
+
+// TranslateTagExpressionsToMatchers converts Graphite tag expressions to
+// tag matchers. Tag expressions are of the form:
+//
+//	tag=value    tag is equal to value, or does not exist if value is empty
+//	tag!=value   tag is not equal to value, or exists if value is empty
+//	tag=~regex   tag value starts with a match of regex
+//	tag!=~regex  tag value does not start with a match of regex
+//
+// At least one expression must match a non-empty value, as with Graphite.
+func TranslateTagExpressionsToMatchers(exprs []string) (models.Matchers, error) {
+	if len(exprs) == 0 {
+		return nil, errNoTagExpressions
+	}
+
+	var (
+		matchers = make(models.Matchers, 0, len(exprs))
+		positive bool
+	)
+	for _, expr := range exprs {
+		parts := tagExpressionRegexp.FindStringSubmatch(expr)
+		if parts == nil {
+			return nil, fmt.Errorf("invalid tag expression: %s", expr)
+		}
+
+		var (
+			name  = []byte(parts[1])
+			value = parts[3]
+			m     models.Matcher
+			err   error
+		)
+		switch parts[2] {
+		case "=":
+			if value == "" {
+				m, err = models.NewMatcher(models.MatchNotField, name, nil)
+			} else {
+				m, err = models.NewMatcher(models.MatchEqual, name, []byte(value))
+				positive = true
+			}
+		case "!=":
+			if value == "" {
+				m, err = models.NewMatcher(models.MatchField, name, nil)
+			} else {
+				m, err = models.NewMatcher(models.MatchNotEqual, name, []byte(value))
+			}
+		case "=~":
+			// NB: Graphite regular expressions only need to match the start of
+			// the value while matchers need to match the whole value.
+			m, err = models.NewMatcher(models.MatchRegexp, name, tagRegexp(value))
+			if err == nil && !regexp.MustCompile("^"+string(m.Value)+"$").MatchString("") {
+				positive = true
+			}
+		case "!=~":
+			m, err = models.NewMatcher(models.MatchNotRegexp, name, tagRegexp(value))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid tag expression %s: %w", expr, err)
+		}
+
+		matchers = append(matchers, m)
+	}
+
+	if !positive {
+		return nil, errNoPositiveExpressions
+	}
+
+	return matchers, nil
+}
+
+func tagRegexp(value string) []byte {
+	return []byte("(?:" + value + ").*")
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/query/models"
+)
+
+func TestSeriesByTagQuery(t *testing.T) {
+	exprs := []string{"name=a.b", `dc=~us-"east|west"`, "host!=a,b"}
+	query := FormatSeriesByTagQuery(exprs)
+	assert.True(t, IsSeriesByTagQuery(query))
+
+	parsed, err := ParseSeriesByTagQuery(query)
+	require.NoError(t, err)
+	assert.Equal(t, exprs, parsed)
+
+	parsed, err = ParseSeriesByTagQuery(`seriesByTag('name=a.b', "dc=us")`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"name=a.b", "dc=us"}, parsed)
+
+	for _, query := range []string{
+		`seriesByTag('name=a.b'`,
+		`seriesByTag('name=a.b)`,
+		`seriesByTag(name=a.b)`,
+		`seriesByTag('name=a.b' 'dc=us')`,
+	} {
+		_, err := ParseSeriesByTagQuery(query)
+		assert.Error(t, err, query)
+	}
+}
+
+func TestTranslateTagExpressionsToMatchers(t *testing.T) {
+	matchers, err := TranslateTagExpressionsToMatchers([]string{
+		"name=a.b",
+		"dc=",
+		"dc!=",
+		"host!=a",
+		"env=~prod|stag",
+		"role!=~^db",
+	})
+	require.NoError(t, err)
+
+	type matcher struct {
+		Type  models.MatchType
+		Name  string
+		Value string
+	}
+	actual := make([]matcher, 0, len(matchers))
+	for _, m := range matchers {
+		actual = append(actual, matcher{Type: m.Type, Name: string(m.Name), Value: string(m.Value)})
+	}
+	assert.Equal(t, []matcher{
+		{Type: models.MatchEqual, Name: "name", Value: "a.b"},
+		{Type: models.MatchNotField, Name: "dc"},
+		{Type: models.MatchField, Name: "dc"},
+		{Type: models.MatchNotEqual, Name: "host", Value: "a"},
+		{Type: models.MatchRegexp, Name: "env", Value: "(?:prod|stag).*"},
+		{Type: models.MatchNotRegexp, Name: "role", Value: "(?:^db).*"},
+	}, actual)
+}
+
+func TestTranslateTagExpressionsToMatchersInvalid(t *testing.T) {
+	for _, exprs := range [][]string{
+		nil,
+		{"name"},
+		{"=a"},
+		{"name=~(a"},
+		{"dc=", "host!=a"},
+		{"name=~.*"},
+	} {
+		_, err := TranslateTagExpressionsToMatchers(exprs)
+		assert.Error(t, err, exprs)
+	}
+}
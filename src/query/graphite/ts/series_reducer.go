@@ -20,7 +20,10 @@
 
 package ts
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+)
 
 // SeriesReducerApproach defines an approach to reduce a series to a single value.
 type SeriesReducerApproach string
@@ -34,9 +37,17 @@ const (
 	SeriesReducerStdDev SeriesReducerApproach = "stddev"
 	SeriesReducerLast   SeriesReducerApproach = "last"
 
+	SeriesReducerMedian   SeriesReducerApproach = "median"
+	SeriesReducerDiff     SeriesReducerApproach = "diff"
+	SeriesReducerCount    SeriesReducerApproach = "count"
+	SeriesReducerRange    SeriesReducerApproach = "range"
+	SeriesReducerMultiply SeriesReducerApproach = "multiply"
+	SeriesReducerAvgZero  SeriesReducerApproach = "avg_zero"
+
 	SeriesReducerAverage SeriesReducerApproach = "average" // alias for "avg"
 	SeriesReducerTotal   SeriesReducerApproach = "total"   // alias for "sum"
 	SeriesReducerCurrent SeriesReducerApproach = "current" // alias for "last"
+	SeriesReducerRangeOf SeriesReducerApproach = "rangeOf" // alias for "range"
 )
 
 // SeriesReducer reduces a series to a single value.
@@ -59,13 +70,78 @@ func (sa SeriesReducerApproach) Reducer() SeriesReducer {
 }
 
 var seriesReducers = map[SeriesReducerApproach]SeriesReducer{
-	SeriesReducerAvg:     func(b *Series) float64 { return b.SafeAvg() },
-	SeriesReducerAverage: func(b *Series) float64 { return b.SafeAvg() },
-	SeriesReducerTotal:   func(b *Series) float64 { return b.SafeSum() },
-	SeriesReducerSum:     func(b *Series) float64 { return b.SafeSum() },
-	SeriesReducerMin:     func(b *Series) float64 { return b.SafeMin() },
-	SeriesReducerMax:     func(b *Series) float64 { return b.SafeMax() },
-	SeriesReducerStdDev:  func(b *Series) float64 { return b.SafeStdDev() },
-	SeriesReducerLast:    func(b *Series) float64 { return b.SafeLastValue() },
-	SeriesReducerCurrent: func(b *Series) float64 { return b.SafeLastValue() },
+	SeriesReducerAvg:      func(b *Series) float64 { return b.SafeAvg() },
+	SeriesReducerAverage:  func(b *Series) float64 { return b.SafeAvg() },
+	SeriesReducerTotal:    func(b *Series) float64 { return b.SafeSum() },
+	SeriesReducerSum:      func(b *Series) float64 { return b.SafeSum() },
+	SeriesReducerMin:      func(b *Series) float64 { return b.SafeMin() },
+	SeriesReducerMax:      func(b *Series) float64 { return b.SafeMax() },
+	SeriesReducerStdDev:   func(b *Series) float64 { return b.SafeStdDev() },
+	SeriesReducerLast:     func(b *Series) float64 { return b.SafeLastValue() },
+	SeriesReducerCurrent:  func(b *Series) float64 { return b.SafeLastValue() },
+	SeriesReducerMedian:   reduceMedian,
+	SeriesReducerDiff:     reduceDiff,
+	SeriesReducerCount:    reduceCount,
+	SeriesReducerRange:    reduceRange,
+	SeriesReducerRangeOf:  reduceRange,
+	SeriesReducerMultiply: reduceMultiply,
+	SeriesReducerAvgZero:  reduceAvgZero,
+}
+
+func reduceMedian(b *Series) float64 {
+	vals := b.SafeValues()
+	return Median(vals, len(vals))
+}
+
+// reduceDiff subtracts all but the first value from the first value.
+func reduceDiff(b *Series) float64 {
+	vals := b.SafeValues()
+	if len(vals) == 0 {
+		return math.NaN()
+	}
+
+	diff := vals[0]
+	for _, v := range vals[1:] {
+		diff -= v
+	}
+	return diff
+}
+
+func reduceCount(b *Series) float64 {
+	vals := b.SafeValues()
+	if len(vals) == 0 {
+		return math.NaN()
+	}
+	return float64(len(vals))
+}
+
+func reduceRange(b *Series) float64 {
+	stats := b.CalcStatistics()
+	return stats.Max - stats.Min
+}
+
+func reduceMultiply(b *Series) float64 {
+	vals := b.SafeValues()
+	if len(vals) == 0 {
+		return math.NaN()
+	}
+
+	product := 1.0
+	for _, v := range vals {
+		product *= v
+	}
+	return product
+}
+
+// reduceAvgZero averages the values of a series, treating NaNs as zero.
+func reduceAvgZero(b *Series) float64 {
+	if b.Len() == 0 {
+		return math.NaN()
+	}
+
+	var sum float64
+	for _, v := range b.SafeValues() {
+		sum += v
+	}
+	return sum / float64(b.Len())
 }
@@ -397,6 +397,49 @@ func buildBenchmarkDatapoints() testDatapoints {
 	return datapoints
 }
 
+func TestSeriesReducers(t *testing.T) {
+	ctx := context.New()
+	defer ctx.Close()
+
+	nan := math.NaN()
+	input := []float64{4, nan, 1, 3, 2}
+	values := NewValues(ctx, 1000, len(input))
+	for i, v := range input {
+		values.SetValueAt(i, v)
+	}
+	series := NewSeries(ctx, "foo", time.Now(), values)
+	empty := NewSeries(ctx, "empty", time.Now(), NewConstantValues(ctx, nan, 5, 1000))
+
+	tests := []struct {
+		approach      SeriesReducerApproach
+		expected      float64
+		expectedEmpty float64
+	}{
+		{SeriesReducerAvg, 2.5, nan},
+		{SeriesReducerSum, 10, 0},
+		{SeriesReducerMin, 1, nan},
+		{SeriesReducerMax, 4, nan},
+		{SeriesReducerLast, 2, nan},
+		{SeriesReducerMedian, 2.5, nan},
+		{SeriesReducerDiff, -2, nan},
+		{SeriesReducerCount, 4, nan},
+		{SeriesReducerRange, 3, nan},
+		{SeriesReducerRangeOf, 3, nan},
+		{SeriesReducerMultiply, 24, nan},
+		{SeriesReducerAvgZero, 2, 0},
+	}
+
+	for _, test := range tests {
+		reducer, ok := test.approach.SafeReducer()
+		require.True(t, ok, string(test.approach))
+		xtest.Equalish(t, test.expected, reducer(series), string(test.approach))
+		xtest.Equalish(t, test.expectedEmpty, reducer(empty), string(test.approach))
+	}
+
+	_, ok := SeriesReducerApproach("unknown").SafeReducer()
+	require.False(t, ok)
+}
+
 func BenchmarkUint64Adds(b *testing.B) {
 	nan := math.Float64bits(math.NaN())
 	datapoints := buildBenchmarkDatapoints()
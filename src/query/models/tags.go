@@ -30,10 +30,24 @@ import (
 	"github.com/cespare/xxhash/v2"
 
 	"github.com/m3db/m3/src/metrics/generated/proto/metricpb"
+	"github.com/m3db/m3/src/query/graphite/graphite"
 	xerrors "github.com/m3db/m3/src/x/errors"
 )
 
 var (
+	// NativeHistogramTagName is the tag of the series read from a native
+	// histogram that hold the count and the sum of its observations, its
+	// value is either NativeHistogramCount or NativeHistogramSum. Tag names
+	// starting with "__" are reserved, so it never collides with a tag of
+	// a metric.
+	NativeHistogramTagName = []byte("__m3_native_histogram__")
+	// NativeHistogramCount is the value of the native histogram tag of the
+	// series holding the count of observations of a native histogram.
+	NativeHistogramCount = []byte("count")
+	// NativeHistogramSum is the value of the native histogram tag of the
+	// series holding the sum of observations of a native histogram.
+	NativeHistogramSum = []byte("sum")
+
 	errNoTags = errors.New("no tags")
 )
 
@@ -246,6 +260,18 @@ func (t sortableTagsNumericallyAsc) Swap(i, j int) {
 }
 func (t sortableTagsNumericallyAsc) Less(i, j int) bool {
 	iName, jName := t.Tags[i].Name, t.Tags[j].Name
+	iPath := bytes.HasPrefix(iName, graphite.Prefix)
+	jPath := bytes.HasPrefix(jName, graphite.Prefix)
+	if iPath != jPath {
+		// Path tags are sorted before the tags of tagged series.
+		return iPath
+	}
+
+	if !iPath {
+		// Tags of tagged series are sorted lexically.
+		return bytes.Compare(iName, jName) == -1
+	}
+
 	lenDiff := len(iName) - len(jName)
 	if lenDiff < 0 {
 		return true
@@ -21,6 +21,9 @@
 package models
 
 import (
+	"bytes"
+
+	"github.com/m3db/m3/src/query/graphite/graphite"
 	"github.com/m3db/m3/src/query/models/strconv"
 	"github.com/m3db/m3/src/query/util/writer"
 )
@@ -218,6 +221,10 @@ func idLenGraphite(t Tags) int {
 }
 
 func graphiteID(t Tags) []byte {
+	if name, ok := t.Get(graphite.NameTag); ok {
+		return graphiteTaggedID(t, name)
+	}
+
 	// TODO: pool these bytes.
 	id := make([]byte, idLenGraphite(t))
 	idx := 0
@@ -231,3 +238,40 @@ func graphiteID(t Tags) []byte {
 	copy(id[idx:], t.Tags[lastIndex].Value)
 	return id
 }
+
+// graphiteTaggedID generates the ID of a tagged series in the canonical
+// graphite form, i.e. {name:a.b.c},{dc:us},{host:a} -> a.b.c;dc=us;host=a.
+// Any path tags, such as an aggregation suffix, are appended to the name.
+func graphiteTaggedID(t Tags, name []byte) []byte {
+	idLen := len(name)
+	for _, tag := range t.Tags {
+		if bytes.HasPrefix(tag.Name, graphite.Prefix) {
+			idLen += 1 + len(tag.Value)
+		} else if !bytes.Equal(tag.Name, graphite.NameTag) {
+			idLen += 2 + len(tag.Name) + len(tag.Value)
+		}
+	}
+
+	id := make([]byte, 0, idLen)
+	id = append(id, name...)
+	for _, tag := range t.Tags {
+		if bytes.HasPrefix(tag.Name, graphite.Prefix) {
+			id = append(id, graphiteSep)
+			id = append(id, tag.Value...)
+		}
+	}
+
+	for _, tag := range t.Tags {
+		if bytes.HasPrefix(tag.Name, graphite.Prefix) ||
+			bytes.Equal(tag.Name, graphite.NameTag) {
+			continue
+		}
+
+		id = append(id, graphite.TagSeparator)
+		id = append(id, tag.Name...)
+		id = append(id, graphite.TagValueSeparator)
+		id = append(id, tag.Value...)
+	}
+
+	return id
+}
@@ -76,6 +76,23 @@ func TestLongTagNewIDOutOfOrderGraphite(t *testing.T) {
 	assert.Equal(t, []byte("v0.v1.v2.v3.v4.v5.v6.v7.v8.v9.v10.v11.v12"), actual)
 }
 
+func TestTaggedNewIDGraphite(t *testing.T) {
+	opts := NewTagOptions().SetIDSchemeType(TypeGraphite)
+	tags := NewTags(3, opts).AddTags([]Tag{
+		{Name: []byte("host"), Value: []byte("a")},
+		{Name: []byte("name"), Value: []byte("foo.bar")},
+		{Name: []byte("dc"), Value: []byte("us")},
+	})
+	require.NoError(t, tags.Validate())
+	assert.Equal(t, []byte("foo.bar;dc=us;host=a"), tags.ID())
+
+	// Path tags such as aggregation suffixes are appended to the name.
+	tags = tags.AddTag(Tag{Name: graphite.TagName(0), Value: []byte("sum")})
+	require.NoError(t, tags.Validate())
+	assert.Equal(t, graphite.TagName(0), tags.Tags[0].Name)
+	assert.Equal(t, []byte("foo.bar.sum;dc=us;host=a"), tags.ID())
+}
+
 func TestLongTagNewIDOutOfOrderQuotedWithEscape(t *testing.T) {
 	tags := testLongTagIDOutOfOrder(t, TypeQuoted)
 	tags = tags.AddTag(Tag{Name: []byte(`t5""`), Value: []byte(`v"5`)})
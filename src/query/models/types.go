@@ -62,9 +62,11 @@ const (
 	// ingestion path, as it ignores tag names and is very prone to collisions if
 	// used on non-graphite data.
 	// {__g0__:v1},{__g1__:v2} -> v1.v2
+	// Tagged series are identified by their canonical graphite name instead.
+	// {dc:us},{name:v1.v2} -> v1.v2;dc=us
 	//
-	// NB: when TypeGraphite is specified, tags are ordered numerically rather
-	// than lexically.
+	// NB: when TypeGraphite is specified, path tags are ordered numerically
+	// rather than lexically, before any other tags.
 	//
 	// NB 2: while the graphite scheme is valid, it is not available to choose as
 	// a general ID scheme; instead, it is set on any metric coming through the
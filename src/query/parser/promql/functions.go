@@ -0,0 +1,51 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package promql
+
+import (
+	"sync"
+
+	pql "github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/m3db/m3/src/query/functions/linear"
+)
+
+var registerFunctionsOnce sync.Once
+
+// RegisterFunctions registers the native histogram functions M3 supports with
+// the vendored Prometheus parser, which predates native histograms, so that
+// queries using them can be parsed. It must be called before the engines
+// parsing such queries are used and is safe to call more than once.
+func RegisterFunctions() {
+	registerFunctionsOnce.Do(func() {
+		for _, name := range []string{linear.HistogramCountType, linear.HistogramSumType} {
+			if _, ok := pql.Functions[name]; ok {
+				continue
+			}
+
+			pql.Functions[name] = &pql.Function{
+				Name:       name,
+				ArgTypes:   []pql.ValueType{pql.ValueTypeVector},
+				ReturnType: pql.ValueTypeVector,
+			}
+		}
+	})
+}
@@ -235,9 +235,12 @@ var linearParseTests = []struct {
 	{"year(up)", linear.YearType},
 
 	{"histogram_quantile(1,up)", linear.HistogramQuantileType},
+	{"histogram_count(up)", linear.HistogramCountType},
+	{"histogram_sum(up)", linear.HistogramSumType},
 }
 
 func TestLinearParses(t *testing.T) {
+	RegisterFunctions()
 	for _, tt := range linearParseTests {
 		t.Run(tt.q, func(t *testing.T) {
 			q := tt.q
@@ -24,7 +24,7 @@ import (
 	"github.com/uber-go/tally"
 
 	"github.com/m3db/m3/src/dbnode/encoding"
-	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/encoding/histogram"
 	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/dbnode/x/xio"
 	"github.com/m3db/m3/src/dbnode/x/xpool"
@@ -205,7 +205,7 @@ func BuildIteratorPools(
 	encodingOpts = encodingOpts.
 		SetReaderIteratorPool(readerIteratorPool)
 
-	readerIteratorPool.Init(m3tsz.DefaultReaderIteratorAllocFn(encodingOpts))
+	readerIteratorPool.Init(histogram.DefaultReaderIteratorAllocFn(encodingOpts))
 
 	pools.multiReaderIterator = encoding.NewMultiReaderIteratorPool(defaultPerSeriesPoolOpts)
 	pools.multiReaderIterator.Init(
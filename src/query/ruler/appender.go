@@ -0,0 +1,125 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ruler
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/prometheus/model/exemplar"
+	"github.com/prometheus/prometheus/model/labels"
+	promstorage "github.com/prometheus/prometheus/storage"
+
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/ts"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+// appendable writes the samples appended by rule evaluation through the
+// coordinator write path, so that they are downsampled like any other write.
+// Samples are only written if isLeader returns true.
+type appendable struct {
+	writer     ingest.DownsamplerAndWriter
+	tagOptions models.TagOptions
+	isLeader   func() bool
+}
+
+var _ promstorage.Appendable = (*appendable)(nil)
+
+func (a *appendable) Appender(ctx context.Context) promstorage.Appender {
+	return &appender{
+		ctx:        ctx,
+		writer:     a.writer,
+		tagOptions: a.tagOptions,
+		isLeader:   a.isLeader,
+	}
+}
+
+type appenderSample struct {
+	labels labels.Labels
+	t      int64
+	v      float64
+}
+
+// appender buffers samples until committed, rule groups commit once per
+// evaluation of each rule.
+type appender struct {
+	ctx        context.Context
+	writer     ingest.DownsamplerAndWriter
+	tagOptions models.TagOptions
+	isLeader   func() bool
+	samples    []appenderSample
+}
+
+func (a *appender) Append(
+	ref promstorage.SeriesRef,
+	l labels.Labels,
+	t int64,
+	v float64,
+) (promstorage.SeriesRef, error) {
+	a.samples = append(a.samples, appenderSample{labels: l, t: t, v: v})
+	return 0, nil
+}
+
+func (a *appender) AppendExemplar(
+	ref promstorage.SeriesRef,
+	_ labels.Labels,
+	_ exemplar.Exemplar,
+) (promstorage.SeriesRef, error) {
+	// Rule evaluation never appends exemplars.
+	return ref, nil
+}
+
+func (a *appender) Commit() error {
+	if !a.isLeader() {
+		// Another ruler is the leader and writes the same samples.
+		a.samples = nil
+		return nil
+	}
+
+	var multiErr xerrors.MultiError
+	for _, s := range a.samples {
+		tags := models.NewTags(len(s.labels), a.tagOptions)
+		for _, l := range s.labels {
+			tags = tags.AddTag(models.Tag{Name: []byte(l.Name), Value: []byte(l.Value)})
+		}
+
+		datapoints := ts.Datapoints{{
+			Timestamp: xtime.UnixNano(s.t * int64(time.Millisecond)),
+			Value:     s.v,
+		}}
+		err := a.writer.Write(a.ctx, tags, datapoints, xtime.Millisecond, nil,
+			ingest.WriteOptions{}, ts.SourceTypePrometheus)
+		if err != nil {
+			multiErr = multiErr.Add(err)
+		}
+	}
+
+	a.samples = nil
+	return multiErr.FinalError()
+}
+
+func (a *appender) Rollback() error {
+	a.samples = nil
+	return nil
+}
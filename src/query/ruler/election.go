@@ -0,0 +1,200 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ruler
+
+import (
+	"time"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+
+	clusterclient "github.com/m3db/m3/src/cluster/client"
+	"github.com/m3db/m3/src/cluster/services"
+	"github.com/m3db/m3/src/cluster/services/leader/campaign"
+)
+
+const (
+	defaultElectionID            = "ruler"
+	defaultCampaignRetryInterval = 5 * time.Second
+)
+
+// leaderElection campaigns for the leadership of the ruler election, only
+// the leader writes recording rule results and sends alerts.
+type leaderElection struct {
+	clusterClient clusterclient.Client
+	serviceID     services.ServiceID
+	electionOpts  services.ElectionOptions
+	service       services.LeaderService
+	electionID    string
+	campaignOpts  services.CampaignOptions
+	retryInterval time.Duration
+	logger        *zap.Logger
+
+	isLeader       atomic.Bool
+	leaderGauge    tally.Gauge
+	campaignErrors tally.Counter
+
+	closeCh chan struct{}
+	doneCh  chan struct{}
+}
+
+func newLeaderElection(
+	clusterClient clusterclient.Client,
+	opts LeaderElectionOptions,
+	logger *zap.Logger,
+	scope tally.Scope,
+) (*leaderElection, error) {
+	campaignOpts, err := services.NewCampaignOptions()
+	if err != nil {
+		return nil, err
+	}
+	electionID := opts.ElectionID
+	if electionID == "" {
+		electionID = defaultElectionID
+	}
+	electionOpts := opts.ElectionOptions
+	if electionOpts == nil {
+		electionOpts = services.NewElectionOptions()
+	}
+
+	e := &leaderElection{
+		clusterClient:  clusterClient,
+		serviceID:      opts.ServiceID,
+		electionOpts:   electionOpts,
+		electionID:     electionID,
+		campaignOpts:   campaignOpts,
+		retryInterval:  defaultCampaignRetryInterval,
+		logger:         logger,
+		leaderGauge:    scope.Gauge("leader"),
+		campaignErrors: scope.Counter("campaign-errors"),
+		closeCh:        make(chan struct{}),
+		doneCh:         make(chan struct{}),
+	}
+	go e.campaignLoop()
+	return e, nil
+}
+
+func (e *leaderElection) IsLeader() bool {
+	return e.isLeader.Load()
+}
+
+func (e *leaderElection) setLeader(leader bool) {
+	if e.isLeader.Swap(leader) != leader {
+		e.logger.Info("ruler leadership changed", zap.Bool("leader", leader))
+	}
+	if leader {
+		e.leaderGauge.Update(1)
+	} else {
+		e.leaderGauge.Update(0)
+	}
+}
+
+func (e *leaderElection) campaignLoop() {
+	defer close(e.doneCh)
+
+	for {
+		statusCh, err := e.campaign()
+		if err != nil {
+			e.campaignErrors.Inc(1)
+			e.logger.Error("unable to campaign for ruler leadership", zap.Error(err))
+		} else if !e.watchCampaign(statusCh) {
+			return
+		}
+
+		select {
+		case <-time.After(e.retryInterval):
+		case <-e.closeCh:
+			e.closeService()
+			return
+		}
+	}
+}
+
+func (e *leaderElection) campaign() (<-chan campaign.Status, error) {
+	if e.service == nil {
+		// NB: the cluster client may not be ready yet, the leader service is
+		// created on a later attempt.
+		svcs, err := e.clusterClient.Services(nil)
+		if err != nil {
+			return nil, err
+		}
+		service, err := svcs.LeaderService(e.serviceID, e.electionOpts)
+		if err != nil {
+			return nil, err
+		}
+		e.service = service
+	}
+	return e.service.Campaign(e.electionID, e.campaignOpts)
+}
+
+// watchCampaign follows the campaign status until the campaign ends, it
+// returns false if the election was closed.
+func (e *leaderElection) watchCampaign(statusCh <-chan campaign.Status) bool {
+	for {
+		select {
+		case status, ok := <-statusCh:
+			if !ok {
+				e.setLeader(false)
+				return true
+			}
+			switch status.State {
+			case campaign.Leader:
+				e.setLeader(true)
+			case campaign.Follower:
+				e.setLeader(false)
+			case campaign.Error:
+				e.setLeader(false)
+				e.campaignErrors.Inc(1)
+				e.logger.Error("ruler leadership campaign error", zap.Error(status.Err))
+			}
+		case <-e.closeCh:
+			e.setLeader(false)
+			// NB: the campaign channel must be consumed until it is closed,
+			// closing the leader service ends all campaigns.
+			e.closeService()
+			for range statusCh {
+			}
+			return false
+		}
+	}
+}
+
+func (e *leaderElection) closeService() {
+	if e.service == nil {
+		return
+	}
+	if err := e.service.Close(); err != nil {
+		e.logger.Warn("unable to close ruler leader service", zap.Error(err))
+	}
+}
+
+// close stops campaigning, resigning the leadership if held so that another
+// coordinator takes over without waiting for the leadership to expire.
+func (e *leaderElection) close() {
+	if e.IsLeader() {
+		if err := e.service.Resign(e.electionID); err != nil {
+			e.logger.Warn("unable to resign ruler leadership", zap.Error(err))
+		}
+	}
+	close(e.closeCh)
+	<-e.doneCh
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ruler
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/prometheus/model/rulefmt"
+	"github.com/prometheus/prometheus/promql/parser"
+	"github.com/prometheus/prometheus/rules"
+)
+
+// kvIdentifierPrefix prefixes the KV key of a rule file to tell it apart
+// from rule file paths.
+const kvIdentifierPrefix = "kv:"
+
+// groupLoader loads rule groups from rule files or from the last rule file
+// read from the KV store.
+type groupLoader struct {
+	sync.RWMutex
+
+	kvRules map[string][]byte
+}
+
+var _ rules.GroupLoader = (*groupLoader)(nil)
+
+func newGroupLoader() *groupLoader {
+	return &groupLoader{kvRules: make(map[string][]byte)}
+}
+
+// setKVRules sets the rule file read from a KV key, it returns the
+// identifier to load the rule file with.
+func (l *groupLoader) setKVRules(key string, value []byte) string {
+	l.Lock()
+	defer l.Unlock()
+
+	identifier := kvIdentifierPrefix + key
+	l.kvRules[identifier] = value
+	return identifier
+}
+
+func (l *groupLoader) Load(identifier string) (*rulefmt.RuleGroups, []error) {
+	if !strings.HasPrefix(identifier, kvIdentifierPrefix) {
+		return rules.FileLoader{}.Load(identifier)
+	}
+
+	l.RLock()
+	value, ok := l.kvRules[identifier]
+	l.RUnlock()
+	if !ok {
+		return nil, []error{fmt.Errorf("no rules loaded from %s", identifier)}
+	}
+	return rulefmt.Parse(value)
+}
+
+func (l *groupLoader) Parse(query string) (parser.Expr, error) {
+	return parser.ParseExpr(query)
+}
+
+// expandFiles returns the rule files matching the given paths, the last
+// element of a path may be a glob pattern.
+func expandFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, pattern := range paths {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule file pattern %s: %w", pattern, err)
+		}
+		files = append(files, matches...)
+	}
+	sort.Strings(files)
+	return files, nil
+}
@@ -0,0 +1,205 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ruler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/rules"
+	"github.com/prometheus/prometheus/util/strutil"
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+
+	xhttp "github.com/m3db/m3/src/x/net/http"
+)
+
+const (
+	defaultAlertmanagerTimeout = 10 * time.Second
+
+	// notifierQueueCapacity is the number of alert batches buffered for
+	// sending, batches are dropped once the queue is full.
+	notifierQueueCapacity = 1000
+)
+
+// alertmanagerAlert is an alert in the Alertmanager v2 API format.
+type alertmanagerAlert struct {
+	Labels       labels.Labels `json:"labels"`
+	Annotations  labels.Labels `json:"annotations"`
+	StartsAt     time.Time     `json:"startsAt,omitempty"`
+	EndsAt       time.Time     `json:"endsAt,omitempty"`
+	GeneratorURL string        `json:"generatorURL,omitempty"`
+}
+
+type notifierMetrics struct {
+	sent    tally.Counter
+	dropped tally.Counter
+	errors  tally.Counter
+}
+
+func newNotifierMetrics(scope tally.Scope) notifierMetrics {
+	return notifierMetrics{
+		sent:    scope.Counter("sent"),
+		dropped: scope.Counter("dropped"),
+		errors:  scope.Counter("errors"),
+	}
+}
+
+// notifier sends alerts to Alertmanager-compatible webhooks, alerts are
+// queued and sent asynchronously so that slow webhooks do not delay rule
+// evaluation.
+type notifier struct {
+	alertmanagers  []AlertmanagerOptions
+	externalURL    string
+	externalLabels labels.Labels
+	client         *http.Client
+	logger         *zap.Logger
+	metrics        notifierMetrics
+
+	queue  chan []alertmanagerAlert
+	doneCh chan struct{}
+}
+
+func newNotifier(
+	alertmanagers []AlertmanagerOptions,
+	externalURL string,
+	externalLabels labels.Labels,
+	logger *zap.Logger,
+	scope tally.Scope,
+) *notifier {
+	n := &notifier{
+		alertmanagers:  alertmanagers,
+		externalURL:    externalURL,
+		externalLabels: externalLabels,
+		client:         &http.Client{},
+		logger:         logger,
+		metrics:        newNotifierMetrics(scope),
+		queue:          make(chan []alertmanagerAlert, notifierQueueCapacity),
+		doneCh:         make(chan struct{}),
+	}
+	go n.run()
+	return n
+}
+
+// notify is the rules.NotifyFunc of the notifier.
+func (n *notifier) notify(_ context.Context, expr string, alerts ...*rules.Alert) {
+	if len(n.alertmanagers) == 0 || len(alerts) == 0 {
+		return
+	}
+
+	batch := make([]alertmanagerAlert, 0, len(alerts))
+	for _, alert := range alerts {
+		a := alertmanagerAlert{
+			Labels:       n.withExternalLabels(alert.Labels),
+			Annotations:  alert.Annotations,
+			StartsAt:     alert.FiredAt,
+			EndsAt:       alert.ValidUntil,
+			GeneratorURL: n.externalURL + strutil.TableLinkForExpression(expr),
+		}
+		if !alert.ResolvedAt.IsZero() {
+			a.EndsAt = alert.ResolvedAt
+		}
+		batch = append(batch, a)
+	}
+
+	select {
+	case n.queue <- batch:
+	default:
+		n.metrics.dropped.Inc(int64(len(batch)))
+		n.logger.Warn("alert queue full, dropping alerts", zap.Int("alerts", len(batch)))
+	}
+}
+
+// withExternalLabels adds the external labels not already set to the alert
+// labels.
+func (n *notifier) withExternalLabels(lset labels.Labels) labels.Labels {
+	if len(n.externalLabels) == 0 {
+		return lset
+	}
+
+	b := labels.NewBuilder(lset)
+	for _, l := range n.externalLabels {
+		if lset.Get(l.Name) == "" {
+			b.Set(l.Name, l.Value)
+		}
+	}
+	return b.Labels()
+}
+
+func (n *notifier) run() {
+	defer close(n.doneCh)
+	for batch := range n.queue {
+		body, err := json.Marshal(batch)
+		if err != nil {
+			n.metrics.errors.Inc(1)
+			n.logger.Error("unable to marshal alerts", zap.Error(err))
+			continue
+		}
+
+		for _, am := range n.alertmanagers {
+			if err := n.send(am, body); err != nil {
+				n.metrics.errors.Inc(1)
+				n.logger.Error("unable to send alerts",
+					zap.String("url", am.URL), zap.Error(err))
+				continue
+			}
+			n.metrics.sent.Inc(int64(len(batch)))
+		}
+	}
+}
+
+func (n *notifier) send(am AlertmanagerOptions, body []byte) error {
+	timeout := am.Timeout
+	if timeout <= 0 {
+		timeout = defaultAlertmanagerTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, am.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set(xhttp.HeaderContentType, xhttp.ContentTypeJSON)
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() // nolint:errcheck
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// close stops the notifier once queued alerts are sent, alerts must not be
+// sent once closed.
+func (n *notifier) close() {
+	close(n.queue)
+	<-n.doneCh
+}
@@ -0,0 +1,345 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ruler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	kitlogzap "github.com/go-kit/kit/log/zap"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/rules"
+	promstorage "github.com/prometheus/prometheus/storage"
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	clusterclient "github.com/m3db/m3/src/cluster/client"
+	"github.com/m3db/m3/src/cluster/generated/proto/commonpb"
+	"github.com/m3db/m3/src/cluster/kv"
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/prometheus"
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+const (
+	defaultEvaluationInterval = time.Minute
+	defaultReloadInterval     = time.Minute
+	defaultQueryTimeout       = time.Minute
+
+	// These match the Prometheus defaults.
+	outageTolerance = time.Hour
+	forGracePeriod  = 10 * time.Minute
+	resendDelay     = time.Minute
+)
+
+var (
+	errEngineNotSet        = errors.New("ruler engine not set")
+	errStorageNotSet       = errors.New("ruler storage not set")
+	errWriterNotSet        = errors.New("ruler writer not set")
+	errClusterClientNotSet = errors.New(
+		"ruler cluster client not set, required to load rules from KV and for leader election")
+)
+
+type rulerMetrics struct {
+	reloadSuccess tally.Counter
+	reloadErrors  tally.Counter
+}
+
+func newRulerMetrics(scope tally.Scope) rulerMetrics {
+	return rulerMetrics{
+		reloadSuccess: scope.Counter("reload-success"),
+		reloadErrors:  scope.Counter("reload-errors"),
+	}
+}
+
+type ruler struct {
+	manager  *rules.Manager
+	loader   *groupLoader
+	notifier *notifier
+	election *leaderElection
+
+	files              []string
+	clusterClient      clusterclient.Client
+	kvKey              string
+	kvWatch            kv.ValueWatch
+	evaluationInterval time.Duration
+	reloadInterval     time.Duration
+	externalLabels     labels.Labels
+	externalURL        string
+	logger             *zap.Logger
+	metrics            rulerMetrics
+
+	closeCh chan struct{}
+	doneCh  chan struct{}
+}
+
+// NewRuler returns a ruler that loads rule groups from rule files and the KV
+// store and starts evaluating them. Rule files are reloaded periodically and
+// the KV rule file whenever it changes.
+func NewRuler(opts Options) (Ruler, error) {
+	if opts.Engine == nil {
+		return nil, errEngineNotSet
+	}
+	if opts.Storage == nil {
+		return nil, errStorageNotSet
+	}
+	if opts.Writer == nil {
+		return nil, errWriterNotSet
+	}
+	if (opts.KVKey != "" || opts.LeaderElection != nil) && opts.ClusterClient == nil {
+		return nil, errClusterClientNotSet
+	}
+
+	externalURL, err := url.Parse(opts.ExternalURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid external URL: %w", err)
+	}
+
+	evaluationInterval := opts.EvaluationInterval
+	if evaluationInterval <= 0 {
+		evaluationInterval = defaultEvaluationInterval
+	}
+	reloadInterval := opts.ReloadInterval
+	if reloadInterval <= 0 {
+		reloadInterval = defaultReloadInterval
+	}
+	queryTimeout := opts.QueryTimeout
+	if queryTimeout <= 0 {
+		queryTimeout = defaultQueryTimeout
+	}
+	iOpts := opts.InstrumentOptions
+	if iOpts == nil {
+		iOpts = instrument.NewOptions()
+	}
+
+	var (
+		logger         = iOpts.Logger()
+		scope          = iOpts.MetricsScope().SubScope("ruler")
+		externalLabels = labels.FromMap(opts.ExternalLabels)
+		loader         = newGroupLoader()
+		n              = newNotifier(opts.Alertmanagers, opts.ExternalURL,
+			externalLabels, logger, scope.SubScope("notifications"))
+		queryable = &fetchOptionsQueryable{
+			Queryable: prometheus.NewPrometheusQueryable(prometheus.PrometheusOptions{
+				Storage:           opts.Storage,
+				InstrumentOptions: iOpts,
+			}),
+			timeout: queryTimeout,
+		}
+		kitLogger = kitlogzap.NewZapSugarLogger(logger, zapcore.InfoLevel)
+		election  *leaderElection
+	)
+
+	if opts.LeaderElection != nil {
+		election, err = newLeaderElection(opts.ClusterClient, *opts.LeaderElection,
+			logger, scope.SubScope("election"))
+		if err != nil {
+			n.close()
+			return nil, err
+		}
+	}
+	isLeader := func() bool {
+		return election == nil || election.IsLeader()
+	}
+
+	manager := rules.NewManager(&rules.ManagerOptions{
+		ExternalURL: externalURL,
+		QueryFunc:   rules.EngineQueryFunc(opts.Engine, queryable),
+		NotifyFunc: func(ctx context.Context, expr string, alerts ...*rules.Alert) {
+			if isLeader() {
+				n.notify(ctx, expr, alerts...)
+			}
+		},
+		Context: context.Background(),
+		Appendable: &appendable{
+			writer:     opts.Writer,
+			tagOptions: opts.TagOptions,
+			isLeader:   isLeader,
+		},
+		Queryable:       queryable,
+		Logger:          log.With(kitLogger, "component", "ruler"),
+		Registerer:      opts.Registerer,
+		OutageTolerance: outageTolerance,
+		ForGracePeriod:  forGracePeriod,
+		ResendDelay:     resendDelay,
+		GroupLoader:     loader,
+	})
+
+	r := &ruler{
+		manager:            manager,
+		loader:             loader,
+		notifier:           n,
+		election:           election,
+		files:              opts.Files,
+		clusterClient:      opts.ClusterClient,
+		kvKey:              opts.KVKey,
+		evaluationInterval: evaluationInterval,
+		reloadInterval:     reloadInterval,
+		externalLabels:     externalLabels,
+		externalURL:        opts.ExternalURL,
+		logger:             logger,
+		metrics:            newRulerMetrics(scope),
+		closeCh:            make(chan struct{}),
+		doneCh:             make(chan struct{}),
+	}
+
+	// Fail on invalid rules at startup rather than evaluating no rules.
+	if err := r.reload(); err != nil {
+		if election != nil {
+			election.close()
+		}
+		n.close()
+		return nil, err
+	}
+
+	// Run blocks until the manager is stopped.
+	go manager.Run()
+	go r.reloadLoop()
+	return r, nil
+}
+
+func (r *ruler) RuleGroups() []*rules.Group {
+	return r.manager.RuleGroups()
+}
+
+func (r *ruler) Close() error {
+	close(r.closeCh)
+	<-r.doneCh
+
+	if r.election != nil {
+		r.election.close()
+	}
+	r.manager.Stop()
+	r.notifier.close()
+	if r.kvWatch != nil {
+		r.kvWatch.Close()
+	}
+	return nil
+}
+
+func (r *ruler) reloadLoop() {
+	defer close(r.doneCh)
+
+	ticker := time.NewTicker(r.reloadInterval)
+	defer ticker.Stop()
+
+	for {
+		var kvCh <-chan struct{}
+		if r.kvWatch != nil {
+			kvCh = r.kvWatch.C()
+		}
+
+		select {
+		case <-ticker.C:
+		case <-kvCh:
+		case <-r.closeCh:
+			return
+		}
+
+		if err := r.reload(); err != nil {
+			r.metrics.reloadErrors.Inc(1)
+			r.logger.Error("unable to reload rules", zap.Error(err))
+			continue
+		}
+		r.metrics.reloadSuccess.Inc(1)
+	}
+}
+
+// reload loads the rule groups, groups that did not change keep being
+// evaluated without interruption.
+func (r *ruler) reload() error {
+	files, err := expandFiles(r.files)
+	if err != nil {
+		return err
+	}
+
+	if r.kvKey != "" {
+		identifier, ok, err := r.loadKVRules()
+		if err != nil {
+			return err
+		}
+		if ok {
+			files = append(files, identifier)
+		}
+	}
+
+	return r.manager.Update(r.evaluationInterval, files, r.externalLabels,
+		r.externalURL)
+}
+
+// loadKVRules loads the rule file from the KV store and returns the
+// identifier it is loaded with, ok is false if there is no rule file.
+func (r *ruler) loadKVRules() (string, bool, error) {
+	if r.kvWatch == nil {
+		store, err := r.clusterClient.KV()
+		if err != nil {
+			// The KV store is not available until the cluster client is
+			// ready, the watch is established on a later reload.
+			r.logger.Warn("unable to load rules from KV", zap.Error(err))
+			return "", false, nil
+		}
+
+		watch, err := store.Watch(r.kvKey)
+		if err != nil {
+			return "", false, err
+		}
+		r.kvWatch = watch
+	}
+
+	value := r.kvWatch.Get()
+	if value == nil {
+		return "", false, nil
+	}
+
+	var rulesProto commonpb.StringProto
+	if err := value.Unmarshal(&rulesProto); err != nil {
+		return "", false, fmt.Errorf("unable to unmarshal rules from KV: %w", err)
+	}
+	return r.loader.setKVRules(r.kvKey, []byte(rulesProto.Value)), true, nil
+}
+
+// fetchOptionsQueryable sets the fetch options the M3 queryable reads from
+// the context, which the query handlers build from the request.
+type fetchOptionsQueryable struct {
+	promstorage.Queryable
+
+	timeout time.Duration
+}
+
+func (q *fetchOptionsQueryable) Querier(
+	ctx context.Context,
+	mint, maxt int64,
+) (promstorage.Querier, error) {
+	fetchOpts := storage.NewFetchOptions()
+	fetchOpts.Timeout = q.timeout
+
+	ctx = context.WithValue(ctx, prometheus.FetchOptionsContextKey, fetchOpts)
+	// Result metadata such as warnings is not reported for rule queries.
+	ctx = context.WithValue(ctx, prometheus.BlockResultMetadataFnKey,
+		func(block.ResultMetadata) {})
+	return q.Queryable.Querier(ctx, mint, maxt)
+}
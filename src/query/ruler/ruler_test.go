@@ -0,0 +1,321 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ruler
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/prometheus/prometheus/rules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	clusterclient "github.com/m3db/m3/src/cluster/client"
+	"github.com/m3db/m3/src/cluster/generated/proto/commonpb"
+	"github.com/m3db/m3/src/cluster/kv"
+	memcluster "github.com/m3db/m3/src/cluster/mem"
+	"github.com/m3db/m3/src/cluster/services"
+	"github.com/m3db/m3/src/cluster/services/leader/campaign"
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest"
+	"github.com/m3db/m3/src/query/generated/proto/prompb"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/ts"
+	xtest "github.com/m3db/m3/src/x/test"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+const testRules = `
+groups:
+  - name: test
+    interval: 50ms
+    rules:
+      - record: job:up:sum
+        expr: sum by (job) (up)
+      - alert: InstanceDown
+        expr: up == 0
+        labels:
+          severity: page
+        annotations:
+          summary: "{{ $labels.instance }} is down"
+`
+
+func newTestEngine() *promql.Engine {
+	return promql.NewEngine(promql.EngineOpts{
+		MaxSamples:    1000,
+		Timeout:       time.Minute,
+		LookbackDelta: 5 * time.Minute,
+	})
+}
+
+// newTestStorage returns a storage with a single up series that is down.
+func newTestStorage(ctrl *gomock.Controller) storage.Storage {
+	store := storage.NewMockStorage(ctrl)
+	store.EXPECT().FetchProm(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			query *storage.FetchQuery,
+			_ *storage.FetchOptions,
+		) (storage.PromResult, error) {
+			for _, m := range query.TagMatchers {
+				if string(m.Name) == "__name__" && string(m.Value) != "up" {
+					return storage.PromResult{PromResult: &prompb.QueryResult{}}, nil
+				}
+			}
+			return storage.PromResult{
+				PromResult: &prompb.QueryResult{
+					Timeseries: []*prompb.TimeSeries{{
+						Labels: []prompb.Label{
+							{Name: []byte("__name__"), Value: []byte("up")},
+							{Name: []byte("instance"), Value: []byte("a")},
+							{Name: []byte("job"), Value: []byte("api")},
+						},
+						Samples: []prompb.Sample{{
+							Value:     0,
+							Timestamp: time.Now().Add(-time.Second).UnixNano() / int64(time.Millisecond),
+						}},
+					}},
+				},
+			}, nil
+		}).
+		AnyTimes()
+	return store
+}
+
+type testWriter struct {
+	sync.Mutex
+
+	names map[string]struct{}
+}
+
+func newTestWriter(ctrl *gomock.Controller) (*testWriter, ingest.DownsamplerAndWriter) {
+	w := &testWriter{names: make(map[string]struct{})}
+	writer := ingest.NewMockDownsamplerAndWriter(ctrl)
+	writer.EXPECT().
+		Write(gomock.Any(), gomock.Any(), gomock.Any(), xtime.Millisecond,
+			gomock.Any(), gomock.Any(), ts.SourceTypePrometheus).
+		DoAndReturn(func(
+			_ context.Context,
+			tags models.Tags,
+			_ ts.Datapoints,
+			_ xtime.Unit,
+			_ []byte,
+			_ ingest.WriteOptions,
+			_ ts.SourceType,
+		) error {
+			name, _ := tags.Name()
+			w.Lock()
+			w.names[string(name)] = struct{}{}
+			w.Unlock()
+			return nil
+		}).
+		AnyTimes()
+	return w, writer
+}
+
+func (w *testWriter) written(name string) bool {
+	w.Lock()
+	defer w.Unlock()
+	_, ok := w.names[name]
+	return ok
+}
+
+func TestRulerEvaluatesRules(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	dir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "test.rules.yml"),
+		[]byte(testRules), 0o600))
+
+	var (
+		alertsLock sync.Mutex
+		alerts     []alertmanagerAlert
+	)
+	alertmanager := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var received []alertmanagerAlert
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		alertsLock.Lock()
+		alerts = append(alerts, received...)
+		alertsLock.Unlock()
+	}))
+	defer alertmanager.Close()
+
+	w, writer := newTestWriter(ctrl)
+	r, err := NewRuler(Options{
+		Files:          []string{filepath.Join(dir, "*.yml")},
+		ExternalLabels: map[string]string{"cluster": "test"},
+		ExternalURL:    "http://m3query:7201",
+		Alertmanagers:  []AlertmanagerOptions{{URL: alertmanager.URL}},
+		Engine:         newTestEngine(),
+		Storage:        newTestStorage(ctrl),
+		Writer:         writer,
+		TagOptions:     models.NewTagOptions(),
+	})
+	require.NoError(t, err)
+	defer r.Close() // nolint:errcheck
+
+	groups := r.RuleGroups()
+	require.Len(t, groups, 1)
+	assert.Equal(t, "test", groups[0].Name())
+	assert.Equal(t, 50*time.Millisecond, groups[0].Interval())
+	require.Len(t, groups[0].Rules(), 2)
+
+	require.Eventually(t, func() bool {
+		return w.written("job:up:sum") && w.written("ALERTS")
+	}, 10*time.Second, 10*time.Millisecond)
+
+	require.Eventually(t, func() bool {
+		alertsLock.Lock()
+		defer alertsLock.Unlock()
+		return len(alerts) > 0
+	}, 10*time.Second, 10*time.Millisecond)
+
+	alertsLock.Lock()
+	a := alerts[0]
+	alertsLock.Unlock()
+	assert.Equal(t, "InstanceDown", a.Labels.Get("alertname"))
+	assert.Equal(t, "page", a.Labels.Get("severity"))
+	assert.Equal(t, "test", a.Labels.Get("cluster"))
+	assert.Equal(t, "a is down", a.Annotations.Get("summary"))
+	assert.Contains(t, a.GeneratorURL, "http://m3query:7201/graph?g0.expr=")
+
+	alerting := groups[0].AlertingRules()
+	require.Len(t, alerting, 1)
+	assert.Equal(t, rules.StateFiring, alerting[0].State())
+}
+
+func TestRulerOnlyLeaderWrites(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	dir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "test.rules.yml"),
+		[]byte(testRules), 0o600))
+
+	statusCh := make(chan campaign.Status, 1)
+	statusCh <- campaign.NewStatus(campaign.Follower)
+	serviceID := services.NewServiceID().SetName("m3coordinator")
+	leaderService := services.NewMockLeaderService(ctrl)
+	leaderService.EXPECT().Campaign("ruler", gomock.Any()).Return(statusCh, nil)
+	svcs := services.NewMockServices(ctrl)
+	svcs.EXPECT().LeaderService(serviceID, gomock.Any()).Return(leaderService, nil)
+	clusterClient := clusterclient.NewMockClient(ctrl)
+	clusterClient.EXPECT().Services(gomock.Any()).Return(svcs, nil)
+
+	w, writer := newTestWriter(ctrl)
+	r, err := NewRuler(Options{
+		Files:         []string{filepath.Join(dir, "*.yml")},
+		Engine:        newTestEngine(),
+		Storage:       newTestStorage(ctrl),
+		Writer:        writer,
+		TagOptions:    models.NewTagOptions(),
+		ClusterClient: clusterClient,
+		LeaderElection: &LeaderElectionOptions{
+			ServiceID: serviceID,
+		},
+	})
+	require.NoError(t, err)
+
+	// Followers evaluate rules without writing the results.
+	groups := r.RuleGroups()
+	require.Len(t, groups, 1)
+	require.Eventually(t, func() bool {
+		return !groups[0].GetLastEvaluation().IsZero()
+	}, 10*time.Second, 10*time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+	assert.False(t, w.written("job:up:sum"))
+
+	statusCh <- campaign.NewStatus(campaign.Leader)
+	require.Eventually(t, func() bool {
+		return w.written("job:up:sum")
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// The leader resigns on close.
+	leaderService.EXPECT().Resign("ruler").Return(nil)
+	leaderService.EXPECT().Close().DoAndReturn(func() error {
+		close(statusCh)
+		return nil
+	})
+	require.NoError(t, r.Close())
+}
+
+func TestRulerLoadsRulesFromKV(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	clusterClient := memcluster.New(kv.NewOverrideOptions())
+	store, err := clusterClient.KV()
+	require.NoError(t, err)
+
+	_, writer := newTestWriter(ctrl)
+	r, err := NewRuler(Options{
+		ClusterClient:  clusterClient,
+		KVKey:          "_m3query/rules",
+		ReloadInterval: time.Hour,
+		Engine:         newTestEngine(),
+		Storage:        newTestStorage(ctrl),
+		Writer:         writer,
+		TagOptions:     models.NewTagOptions(),
+	})
+	require.NoError(t, err)
+	defer r.Close() // nolint:errcheck
+
+	assert.Empty(t, r.RuleGroups())
+
+	// Rules are reloaded as soon as the KV rule file changes.
+	_, err = store.Set("_m3query/rules", &commonpb.StringProto{Value: testRules})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		groups := r.RuleGroups()
+		return len(groups) == 1 && groups[0].File() == "kv:_m3query/rules"
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func TestRulerInvalidRules(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	file := filepath.Join(t.TempDir(), "invalid.yml")
+	require.NoError(t, ioutil.WriteFile(file, []byte("groups:\n  - name: test\n    rules:\n      - record: a\n        expr: sum(\n"), 0o600))
+
+	_, writer := newTestWriter(ctrl)
+	_, err := NewRuler(Options{
+		Files:   []string{file},
+		Engine:  newTestEngine(),
+		Storage: newTestStorage(ctrl),
+		Writer:  writer,
+	})
+	require.Error(t, err)
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package ruler evaluates Prometheus recording and alerting rules against
+// M3, recording rule results are written back through the coordinator write
+// path and alerts are sent to Alertmanager-compatible webhooks.
+package ruler
+
+import (
+	"time"
+
+	extprom "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/prometheus/prometheus/rules"
+
+	clusterclient "github.com/m3db/m3/src/cluster/client"
+	"github.com/m3db/m3/src/cluster/services"
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+// Ruler evaluates rule groups on schedule.
+type Ruler interface {
+	// RuleGroups returns the rule groups being evaluated.
+	RuleGroups() []*rules.Group
+
+	// Close stops evaluating rules.
+	Close() error
+}
+
+// Options are the options for a ruler.
+type Options struct {
+	// Files are the paths of Prometheus rule files to load, the last element
+	// of a path may be a glob pattern.
+	Files []string
+	// ClusterClient is the cluster client used to reach the KV store, it is
+	// only required if KVKey is set.
+	ClusterClient clusterclient.Client
+	// KVKey is the cluster KV key of a Prometheus rule file to load.
+	KVKey string
+	// EvaluationInterval is the interval rule groups are evaluated at if a
+	// group does not set an interval.
+	EvaluationInterval time.Duration
+	// ReloadInterval is how often rule files are reloaded.
+	ReloadInterval time.Duration
+	// QueryTimeout is the timeout of rule queries.
+	QueryTimeout time.Duration
+	// ExternalLabels are labels added to the alerts sent to Alertmanager.
+	ExternalLabels map[string]string
+	// ExternalURL is the URL used for the generator URL of alerts.
+	ExternalURL string
+	// Alertmanagers are the webhooks alerts are sent to.
+	Alertmanagers []AlertmanagerOptions
+	// LeaderElection if set elects the ruler that writes the results of
+	// recording rules and sends alerts, so that rules can be evaluated by
+	// several coordinators without duplicate writes. Every ruler evaluates
+	// rules so that the alert state is kept when the leader changes. If not
+	// set every ruler writes and sends alerts. ClusterClient is required to
+	// hold the election.
+	LeaderElection *LeaderElectionOptions
+	// Engine is the PromQL engine rules are evaluated with.
+	Engine *promql.Engine
+	// Storage is the storage rules are evaluated against.
+	Storage storage.Storage
+	// Writer writes the results of recording rules and the alert series.
+	Writer ingest.DownsamplerAndWriter
+	// TagOptions are the tag options of written series.
+	TagOptions models.TagOptions
+	// Registerer registers the Prometheus rule evaluation metrics, they are
+	// not registered if nil.
+	Registerer extprom.Registerer
+	// InstrumentOptions are the instrument options.
+	InstrumentOptions instrument.Options
+}
+
+// LeaderElectionOptions are the options of the ruler leader election.
+type LeaderElectionOptions struct {
+	// ServiceID is the service the election is held for, rulers evaluating
+	// the same rules must use the same service ID.
+	ServiceID services.ServiceID
+	// ElectionOptions are the election timeouts and lease TTL.
+	ElectionOptions services.ElectionOptions
+	// ElectionID is the ID of the election, defaults to "ruler".
+	ElectionID string
+}
+
+// AlertmanagerOptions are the options of an Alertmanager-compatible webhook.
+type AlertmanagerOptions struct {
+	// URL is the URL alerts are posted to.
+	URL string
+	// Timeout is the timeout for sending alerts.
+	Timeout time.Duration
+}
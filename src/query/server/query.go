@@ -57,7 +57,9 @@ import (
 	"github.com/m3db/m3/src/cmd/services/m3query/config"
 	"github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/query/api/v1/handler/otlp"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/resultscache"
 	"github.com/m3db/m3/src/query/api/v1/httpd"
 	"github.com/m3db/m3/src/query/api/v1/options"
 	m3dbcluster "github.com/m3db/m3/src/query/cluster/m3db"
@@ -69,13 +71,18 @@ import (
 	"github.com/m3db/m3/src/query/pools"
 	"github.com/m3db/m3/src/query/promqlengine"
 	tsdbremote "github.com/m3db/m3/src/query/remote"
+	"github.com/m3db/m3/src/query/ruler"
 	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/exemplar"
 	"github.com/m3db/m3/src/query/storage/fanout"
 	"github.com/m3db/m3/src/query/storage/m3"
 	"github.com/m3db/m3/src/query/storage/m3/consolidators"
+	promstorage "github.com/m3db/m3/src/query/storage/prometheus"
+	"github.com/m3db/m3/src/query/storage/prommetadata"
 	"github.com/m3db/m3/src/query/storage/promremote"
 	"github.com/m3db/m3/src/query/storage/remote"
 	"github.com/m3db/m3/src/query/stores/m3db"
+	"github.com/m3db/m3/src/query/tenant"
 	"github.com/m3db/m3/src/x/clock"
 	xconfig "github.com/m3db/m3/src/x/config"
 	"github.com/m3db/m3/src/x/instrument"
@@ -169,6 +176,11 @@ type RunOptions struct {
 
 	// ApplyCustomRuleStore provides an option to swap the backend used for the rule stores.
 	ApplyCustomRuleStore downsample.CustomRuleStoreFn
+
+	// ResultsCacheBackend is an optional backend, such as memcached or redis,
+	// used instead of the in-process LRU backend when the range query results
+	// cache is enabled.
+	ResultsCacheBackend resultscache.Backend
 }
 
 // InstrumentOptionsReady is a set of instrument options
@@ -343,12 +355,23 @@ func Run(runOpts RunOptions) RunResult {
 		timeout = *runOpts.DBConfig.Client.FetchTimeout
 	}
 
+	var tenants tenant.Tenants
+	if cfg.Tenants != nil {
+		tenants, err = cfg.Tenants.NewTenants(instrumentOptions)
+		if err != nil {
+			logger.Fatal("could not create tenants", zap.Error(err))
+		}
+
+		defer tenants.Close()
+	}
+
 	fetchOptsBuilderLimitsOpts := cfg.Limits.PerQuery.AsFetchOptionsBuilderLimitsOptions()
 	fetchOptsBuilder, err := handleroptions.NewFetchOptionsBuilder(
 		handleroptions.FetchOptionsBuilderOptions{
 			Limits:        fetchOptsBuilderLimitsOpts,
 			RestrictByTag: storageRestrictByTags,
 			Timeout:       timeout,
+			Tenants:       tenants,
 		})
 	if err != nil {
 		logger.Fatal("could not set fetch options parser", zap.Error(err))
@@ -409,7 +432,8 @@ func Run(runOpts RunOptions) RunResult {
 		SetReadWorkerPool(readWorkerPool).
 		SetWriteWorkerPool(writeWorkerPool).
 		SetSeriesConsolidationMatchOptions(matchOptions).
-		SetPromConvertOptions(promConvertOptions)
+		SetPromConvertOptions(promConvertOptions).
+		SetTenants(tenants)
 
 	if runOpts.ApplyCustomTSDBOptions != nil {
 		tsdbOpts, err = runOpts.ApplyCustomTSDBOptions(tsdbOpts, instrumentOptions)
@@ -562,6 +586,11 @@ func Run(runOpts RunOptions) RunResult {
 			SetParseOptions(engineOpts.ParseOptions().SetParseFn(fn))
 	}
 
+	// NB: the parser and the Prometheus engine are vendored and predate native
+	// histograms, register the native histogram functions M3 supports.
+	promql.RegisterFunctions()
+	promstorage.RegisterFunctions()
+
 	engine := executor.NewEngine(engineOpts)
 	downsamplerAndWriter, err := newDownsamplerAndWriter(
 		backendStorage,
@@ -630,6 +659,7 @@ func Run(runOpts RunOptions) RunResult {
 					Limits:        fetchOptsBuilderLimitsOpts,
 					RestrictByTag: storageRestrictByTags,
 					Timeout:       timeout,
+					Tenants:       tenants,
 				})
 			if err != nil {
 				logger.Fatal("could not set graphite find fetch options parser", zap.Error(err))
@@ -642,6 +672,7 @@ func Run(runOpts RunOptions) RunResult {
 					Limits:        fetchOptsBuilderLimitsOpts,
 					RestrictByTag: storageRestrictByTags,
 					Timeout:       timeout,
+					Tenants:       tenants,
 				})
 			if err != nil {
 				logger.Fatal("could not set graphite find fetch options parser", zap.Error(err))
@@ -680,6 +711,104 @@ func Run(runOpts RunOptions) RunResult {
 		logger.Fatal("unable to set up handler options", zap.Error(err))
 	}
 
+	if cfg.Exemplars != nil {
+		if m3dbClusters == nil {
+			logger.Fatal("exemplar storage requires an M3DB backend")
+		}
+
+		exemplarStore, err := exemplar.NewStore(exemplar.StoreOptions{
+			Clusters:          m3dbClusters,
+			Namespace:         cfg.Exemplars.Namespace,
+			WriteConcurrency:  cfg.Exemplars.WriteConcurrency,
+			TagOptions:        tagOptions,
+			InstrumentOptions: instrumentOptions,
+		})
+		if err != nil {
+			logger.Fatal("unable to create exemplar store", zap.Error(err))
+		}
+
+		handlerOptions = handlerOptions.SetExemplarStore(exemplarStore)
+	}
+
+	if cfg.Metadata != nil && cfg.Metadata.Enabled {
+		if clusterClient == nil {
+			logger.Fatal("metadata storage requires a cluster management KV store")
+		}
+
+		metadataStore, err := prommetadata.NewStore(prommetadata.StoreOptions{
+			ClusterClient:      clusterClient,
+			SyncInterval:       cfg.Metadata.SyncInterval,
+			Retention:          cfg.Metadata.Retention,
+			MaxMetricsPerShard: cfg.Metadata.MaxMetricsPerShard,
+			InstrumentOptions:  instrumentOptions,
+		})
+		if err != nil {
+			logger.Fatal("unable to create metadata store", zap.Error(err))
+		}
+		defer metadataStore.Close()
+
+		handlerOptions = handlerOptions.SetMetadataStore(metadataStore)
+	}
+
+	if cfg.Rules != nil {
+		alertmanagers := make([]ruler.AlertmanagerOptions, 0, len(cfg.Rules.Alertmanagers))
+		for _, am := range cfg.Rules.Alertmanagers {
+			alertmanagers = append(alertmanagers, ruler.AlertmanagerOptions{
+				URL:     am.URL,
+				Timeout: am.Timeout,
+			})
+		}
+
+		var leaderElection *ruler.LeaderElectionOptions
+		if electionCfg := cfg.Rules.LeaderElection; electionCfg != nil {
+			if clusterClient == nil {
+				logger.Fatal("ruler leader election requires a cluster management KV store")
+			}
+			leaderElection = &ruler.LeaderElectionOptions{
+				ServiceID:       electionCfg.ServiceID.NewServiceID(),
+				ElectionOptions: electionCfg.Election.NewOptions(),
+				ElectionID:      electionCfg.ElectionID,
+			}
+		}
+
+		r, err := ruler.NewRuler(ruler.Options{
+			Files:              cfg.Rules.Files,
+			ClusterClient:      clusterClient,
+			KVKey:              cfg.Rules.KVKey,
+			EvaluationInterval: cfg.Rules.EvaluationInterval,
+			ReloadInterval:     cfg.Rules.ReloadInterval,
+			QueryTimeout:       cfg.Query.TimeoutOrDefault(),
+			ExternalLabels:     cfg.Rules.ExternalLabels,
+			ExternalURL:        cfg.Rules.ExternalURL,
+			Alertmanagers:      alertmanagers,
+			LeaderElection:     leaderElection,
+			Engine:             defaultPrometheusEngine,
+			Storage:            backendStorage,
+			Writer:             downsamplerAndWriter,
+			TagOptions:         tagOptions,
+			Registerer:         prometheusEngineRegistry,
+			InstrumentOptions:  instrumentOptions,
+		})
+		if err != nil {
+			logger.Fatal("unable to create ruler", zap.Error(err))
+		}
+		defer r.Close() // nolint:errcheck
+
+		handlerOptions = handlerOptions.SetRuler(r)
+	}
+
+	if cacheCfg := cfg.Query.ResultsCache; cacheCfg != nil {
+		backend := runOpts.ResultsCacheBackend
+		if backend == nil {
+			backend = resultscache.NewLRUBackend(resultscache.LRUBackendOptions{
+				TTL:          cacheCfg.TTL,
+				MaxEntries:   cacheCfg.MaxEntries,
+				MetricsScope: instrumentOptions.MetricsScope().SubScope("results-cache"),
+			})
+		}
+		handlerOptions = handlerOptions.SetResultsCache(backend)
+	}
+
 	var customHandlerOpts options.CustomHandlerOptions
 	if runOpts.CustomHandlerOptions != nil {
 		customHandlerOpts, err = runOpts.CustomHandlerOptions(instrumentOptions)
@@ -765,12 +894,22 @@ func Run(runOpts RunOptions) RunResult {
 	}
 
 	if cfg.Carbon != nil && cfg.Carbon.Ingester != nil {
+		if tenants != nil && tenants.Required() {
+			// NB: the carbon line protocol cannot carry a tenant, so its writes
+			// would bypass the tenants of the coordinator.
+			logger.Fatal("carbon ingestion cannot be enabled when tenants are required")
+		}
 		server := startCarbonIngestion(*cfg.Carbon.Ingester, listenerOpts,
 			instrumentOptions, logger, m3dbClusters, clusterNamespacesWatcher,
 			downsamplerAndWriter)
 		defer server.Close()
 	}
 
+	if cfg.OTLP != nil && cfg.OTLP.GRPC != nil {
+		server := startOTLPGRPCServer(*cfg.OTLP.GRPC, handlerOptions, logger)
+		defer server.Stop()
+	}
+
 	// Stop our async watch and now block waiting for the interrupt.
 	intWatchCancel()
 	select {
@@ -1277,7 +1416,7 @@ func startCarbonIngestion(
 	m3dbClusters m3.Clusters,
 	clusterNamespacesWatcher m3.ClusterNamespacesWatcher,
 	downsamplerAndWriter ingest.DownsamplerAndWriter,
-) xserver.Server {
+) carbonIngestionServers {
 	logger.Info("carbon ingestion enabled, configuring ingester")
 
 	// Setup worker pool.
@@ -1341,7 +1480,87 @@ func startCarbonIngestion(
 
 	logger.Info("started carbon ingestion server", zap.String("listenAddress", carbonListenAddress))
 
-	return carbonServer
+	servers := carbonIngestionServers{servers: []xserver.Server{carbonServer}}
+	if pickleListenAddress := ingesterCfg.PickleListenAddress; pickleListenAddress != "" {
+		pickleServer := xserver.NewServer(pickleListenAddress, ingester.PickleHandler(), serverOpts)
+		logger.Info("starting carbon pickle ingestion server", zap.String("listenAddress", pickleListenAddress))
+		if err := pickleServer.ListenAndServe(); err != nil {
+			logger.Fatal("unable to start carbon pickle ingestion server at listen address",
+				zap.String("listenAddress", pickleListenAddress), zap.Error(err))
+		}
+
+		logger.Info("started carbon pickle ingestion server", zap.String("listenAddress", pickleListenAddress))
+		servers.servers = append(servers.servers, pickleServer)
+	}
+
+	if udpListenAddress := ingesterCfg.UDPListenAddress; udpListenAddress != "" {
+		logger.Info("starting carbon udp ingestion listener", zap.String("listenAddress", udpListenAddress))
+		packetConn, err := net.ListenPacket("udp", udpListenAddress)
+		if err != nil {
+			logger.Fatal("unable to start carbon udp ingestion listener at listen address",
+				zap.String("listenAddress", udpListenAddress), zap.Error(err))
+		}
+
+		go ingester.HandlePackets(packetConn)
+		logger.Info("started carbon udp ingestion listener", zap.String("listenAddress", udpListenAddress))
+		servers.packetConn = packetConn
+	}
+
+	return servers
+}
+
+// carbonIngestionServers are the servers and the UDP listener of carbon
+// ingestion.
+type carbonIngestionServers struct {
+	servers    []xserver.Server
+	packetConn net.PacketConn
+}
+
+func (s carbonIngestionServers) Close() {
+	for _, server := range s.servers {
+		server.Close()
+	}
+	if s.packetConn != nil {
+		s.packetConn.Close() // nolint:errcheck
+	}
+}
+
+func startOTLPGRPCServer(
+	grpcCfg config.OTLPGRPCConfiguration,
+	handlerOpts options.HandlerOptions,
+	logger *zap.Logger,
+) *grpc.Server {
+	logger.Info("otlp grpc ingestion enabled, configuring receiver")
+
+	var serverOpts []grpc.ServerOption
+	if grpcCfg.MaxRecvMsgSize > 0 {
+		serverOpts = append(serverOpts, grpc.MaxRecvMsgSize(grpcCfg.MaxRecvMsgSize))
+	}
+
+	iOpts := handlerOpts.InstrumentOpts()
+	server, err := otlp.NewGRPCServer(handlerOpts.SetInstrumentOpts(
+		iOpts.SetMetricsScope(iOpts.MetricsScope().SubScope("ingest-otlp"))),
+		serverOpts...)
+	if err != nil {
+		logger.Fatal("unable to create otlp grpc receiver", zap.Error(err))
+	}
+
+	listenAddress := grpcCfg.ListenAddressOrDefault()
+	listener, err := net.Listen("tcp", listenAddress)
+	if err != nil {
+		logger.Fatal("unable to listen on otlp grpc listen address",
+			zap.String("listenAddress", listenAddress), zap.Error(err))
+	}
+
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			logger.Error("error from serving otlp grpc receiver", zap.Error(err))
+		}
+	}()
+
+	logger.Info("started otlp grpc receiver", zap.String("listenAddress", listenAddress))
+
+	return server
 }
 
 func newDownsamplerAndWriter(
@@ -28,6 +28,7 @@ import (
 
 	"github.com/prometheus/common/model"
 
+	"github.com/m3db/m3/src/dbnode/encoding/histogram"
 	"github.com/m3db/m3/src/dbnode/generated/proto/annotation"
 	"github.com/m3db/m3/src/query/generated/proto/prompb"
 	"github.com/m3db/m3/src/query/models"
@@ -312,6 +313,90 @@ func PromSamplesToM3Datapoints(samples []prompb.Sample) ts.Datapoints {
 	return datapoints
 }
 
+// PromHistogramToM3 converts a Prometheus native histogram to its M3
+// storage representation.
+func PromHistogramToM3(h prompb.Histogram) (histogram.Histogram, error) {
+	result := histogram.Histogram{
+		CounterResetHint: promResetHintToM3(h.ResetHint),
+		Schema:           h.Schema,
+		ZeroThreshold:    h.ZeroThreshold,
+		Sum:              h.Sum,
+		PositiveSpans:    promSpansToM3(h.PositiveSpans),
+		NegativeSpans:    promSpansToM3(h.NegativeSpans),
+	}
+
+	switch count := h.Count.(type) {
+	case *prompb.Histogram_CountInt:
+		result.Count = float64(count.CountInt)
+	case *prompb.Histogram_CountFloat:
+		result.Count = count.CountFloat
+	}
+
+	switch count := h.ZeroCount.(type) {
+	case *prompb.Histogram_ZeroCountInt:
+		result.ZeroCount = float64(count.ZeroCountInt)
+	case *prompb.Histogram_ZeroCountFloat:
+		result.ZeroCount = count.ZeroCountFloat
+	}
+
+	if len(h.PositiveCounts) > 0 || len(h.NegativeCounts) > 0 {
+		result.PositiveBuckets = append([]float64(nil), h.PositiveCounts...)
+		result.NegativeBuckets = append([]float64(nil), h.NegativeCounts...)
+	} else {
+		result.PositiveBuckets = promDeltasToM3(h.PositiveDeltas)
+		result.NegativeBuckets = promDeltasToM3(h.NegativeDeltas)
+	}
+
+	if err := result.Validate(); err != nil {
+		return histogram.Histogram{}, fmt.Errorf("invalid native histogram: %w", err)
+	}
+
+	return result, nil
+}
+
+func promResetHintToM3(hint prompb.Histogram_ResetHint) histogram.CounterResetHint {
+	switch hint {
+	case prompb.Histogram_YES:
+		return histogram.CounterReset
+	case prompb.Histogram_NO:
+		return histogram.NotCounterReset
+	case prompb.Histogram_GAUGE:
+		return histogram.GaugeType
+	default:
+		return histogram.UnknownCounterReset
+	}
+}
+
+func promSpansToM3(spans []prompb.BucketSpan) []histogram.Span {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	result := make([]histogram.Span, 0, len(spans))
+	for _, span := range spans {
+		result = append(result, histogram.Span{Offset: span.Offset, Length: span.Length})
+	}
+
+	return result
+}
+
+func promDeltasToM3(deltas []int64) []float64 {
+	if len(deltas) == 0 {
+		return nil
+	}
+
+	var (
+		result = make([]float64, 0, len(deltas))
+		count  int64
+	)
+	for _, delta := range deltas {
+		count += delta
+		result = append(result, float64(count))
+	}
+
+	return result
+}
+
 // PromReadQueryToM3 converts a prometheus read query to m3 read query
 func PromReadQueryToM3(query *prompb.Query) (*FetchQuery, error) {
 	tagMatchers, err := PromMatchersToM3(query.Matchers)
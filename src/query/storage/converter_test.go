@@ -29,6 +29,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/m3db/m3/src/dbnode/encoding/histogram"
 	"github.com/m3db/m3/src/dbnode/generated/proto/annotation"
 	"github.com/m3db/m3/src/query/generated/proto/prompb"
 	"github.com/m3db/m3/src/query/models"
@@ -576,3 +577,51 @@ type promMetricTypeWithBool struct {
 	metricType        ts.PromMetricType
 	handleValueResets bool
 }
+
+func TestPromHistogramToM3(t *testing.T) {
+	h, err := PromHistogramToM3(prompb.Histogram{
+		Count:          &prompb.Histogram_CountInt{CountInt: 8},
+		Sum:            12.5,
+		Schema:         1,
+		ZeroThreshold:  0.001,
+		ZeroCount:      &prompb.Histogram_ZeroCountInt{ZeroCountInt: 1},
+		PositiveSpans:  []prompb.BucketSpan{{Offset: 0, Length: 2}, {Offset: 1, Length: 1}},
+		PositiveDeltas: []int64{2, 1, -2},
+		NegativeSpans:  []prompb.BucketSpan{{Offset: 1, Length: 1}},
+		NegativeDeltas: []int64{1},
+		ResetHint:      prompb.Histogram_NO,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, histogram.Histogram{
+		CounterResetHint: histogram.NotCounterReset,
+		Schema:           1,
+		ZeroThreshold:    0.001,
+		ZeroCount:        1,
+		Count:            8,
+		Sum:              12.5,
+		PositiveSpans:    []histogram.Span{{Offset: 0, Length: 2}, {Offset: 1, Length: 1}},
+		PositiveBuckets:  []float64{2, 3, 1},
+		NegativeSpans:    []histogram.Span{{Offset: 1, Length: 1}},
+		NegativeBuckets:  []float64{1},
+	}, h)
+
+	h, err = PromHistogramToM3(prompb.Histogram{
+		Count:          &prompb.Histogram_CountFloat{CountFloat: 2.5},
+		ZeroCount:      &prompb.Histogram_ZeroCountFloat{ZeroCountFloat: 0.5},
+		PositiveSpans:  []prompb.BucketSpan{{Offset: 2, Length: 1}},
+		PositiveCounts: []float64{2},
+		ResetHint:      prompb.Histogram_GAUGE,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, histogram.GaugeType, h.CounterResetHint)
+	assert.Equal(t, 2.5, h.Count)
+	assert.Equal(t, 0.5, h.ZeroCount)
+	assert.Equal(t, []float64{2}, h.PositiveBuckets)
+
+	_, err = PromHistogramToM3(prompb.Histogram{
+		Count:          &prompb.Histogram_CountInt{CountInt: 1},
+		PositiveSpans:  []prompb.BucketSpan{{Offset: 0, Length: 2}},
+		PositiveDeltas: []int64{1},
+	})
+	require.Error(t, err)
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exemplar
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/query/models"
+)
+
+const annotationVersion = 1
+
+var (
+	// annotationPrefix starts every exemplar annotation. Exemplar annotations
+	// are never empty, since an empty annotation is not written by the encoder
+	// and would be read back as the annotation of the previous datapoint.
+	annotationPrefix = []byte{0x00, 'e', 'x'}
+
+	errAnnotationTooShort = errors.New("exemplar annotation is truncated")
+	errNotAnnotation      = errors.New("annotation is not an exemplar annotation")
+)
+
+// AppendAnnotation encodes the exemplar labels and appends them to buf.
+func AppendAnnotation(buf []byte, labels models.Tags) ts.Annotation {
+	buf = append(buf, annotationPrefix...)
+	buf = append(buf, annotationVersion)
+	buf = binary.AppendUvarint(buf, uint64(labels.Len()))
+	for _, tag := range labels.Tags {
+		buf = appendBytes(buf, tag.Name)
+		buf = appendBytes(buf, tag.Value)
+	}
+	return buf
+}
+
+func appendBytes(buf []byte, b []byte) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(b)))
+	return append(buf, b...)
+}
+
+// DecodeAnnotation decodes the exemplar labels from an annotation written
+// with AppendAnnotation.
+func DecodeAnnotation(
+	annotation ts.Annotation,
+	tagOpts models.TagOptions,
+) (models.Tags, error) {
+	if !bytes.HasPrefix(annotation, annotationPrefix) {
+		return models.EmptyTags(), errNotAnnotation
+	}
+
+	buf := annotation[len(annotationPrefix):]
+	if len(buf) == 0 {
+		return models.EmptyTags(), errAnnotationTooShort
+	}
+	if version := buf[0]; version != annotationVersion {
+		return models.EmptyTags(), fmt.Errorf(
+			"unknown exemplar annotation version: %d", version)
+	}
+	buf = buf[1:]
+
+	count, n := binary.Uvarint(buf)
+	if n <= 0 || count > uint64(len(buf)) {
+		return models.EmptyTags(), errAnnotationTooShort
+	}
+	buf = buf[n:]
+
+	labels := models.NewTags(int(count), tagOpts)
+	for i := uint64(0); i < count; i++ {
+		var name, value []byte
+		if name, buf = readBytes(buf); name == nil {
+			return models.EmptyTags(), errAnnotationTooShort
+		}
+		if value, buf = readBytes(buf); value == nil {
+			return models.EmptyTags(), errAnnotationTooShort
+		}
+		labels = labels.AddTag(models.Tag{Name: name, Value: value})
+	}
+
+	return labels, nil
+}
+
+// readBytes reads a length prefixed byte slice, returning a nil slice if the
+// buffer is truncated.
+func readBytes(buf []byte) ([]byte, []byte) {
+	length, n := binary.Uvarint(buf)
+	if n <= 0 || length > uint64(len(buf)-n) {
+		return nil, buf
+	}
+
+	end := n + int(length)
+	return append([]byte{}, buf[n:end]...), buf[end:]
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exemplar
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/query/models"
+)
+
+func TestAnnotationRoundTrip(t *testing.T) {
+	tagOpts := models.NewTagOptions()
+	labels := models.NewTags(2, tagOpts).
+		AddTag(models.Tag{Name: []byte("trace_id"), Value: []byte("abc")}).
+		AddTag(models.Tag{Name: []byte("span_id"), Value: []byte("")})
+
+	annotation := AppendAnnotation(nil, labels)
+	decoded, err := DecodeAnnotation(annotation, tagOpts)
+	require.NoError(t, err)
+	assert.True(t, labels.Equals(decoded))
+
+	// Exemplars without labels still have a non empty annotation.
+	annotation = AppendAnnotation(nil, models.EmptyTags())
+	assert.NotEmpty(t, annotation)
+	decoded, err = DecodeAnnotation(annotation, tagOpts)
+	require.NoError(t, err)
+	assert.Equal(t, 0, decoded.Len())
+}
+
+func TestDecodeAnnotationErrors(t *testing.T) {
+	tagOpts := models.NewTagOptions()
+	labels := models.NewTags(1, tagOpts).
+		AddTag(models.Tag{Name: []byte("trace_id"), Value: []byte("abc")})
+	annotation := AppendAnnotation(nil, labels)
+
+	_, err := DecodeAnnotation([]byte("payload"), tagOpts)
+	assert.Error(t, err)
+
+	for i := len(annotationPrefix); i < len(annotation); i++ {
+		_, err := DecodeAnnotation(annotation[:i], tagOpts)
+		assert.Error(t, err, "truncated at %d", i)
+	}
+
+	invalidVersion := append([]byte{}, annotation...)
+	invalidVersion[len(annotationPrefix)] = 2
+	_, err = DecodeAnnotation(invalidVersion, tagOpts)
+	assert.Error(t, err)
+}
@@ -0,0 +1,261 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exemplar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/m3"
+	"github.com/m3db/m3/src/query/storage/m3/consolidators"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/ident"
+	"github.com/m3db/m3/src/x/instrument"
+	xsync "github.com/m3db/m3/src/x/sync"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"go.uber.org/zap"
+)
+
+var (
+	errNamespaceNotSet = errors.New("exemplar namespace not set")
+	errClustersNotSet  = errors.New("exemplar clusters not set")
+	errSessionNotReady = errors.New("exemplar store session not ready")
+)
+
+// DefaultWriteConcurrency is the default number of series whose exemplars
+// are written concurrently by a store.
+const DefaultWriteConcurrency = 64
+
+// StoreOptions are the options for an exemplar store.
+type StoreOptions struct {
+	// Clusters are the clusters the exemplar namespace lives in, the session
+	// of the unaggregated cluster namespace is used to reach it.
+	Clusters m3.Clusters
+	// Namespace is the namespace exemplars are written to, it must be indexed
+	// and its retention is the exemplar retention.
+	Namespace string
+	// TagOptions are the tag options used to decode series tags.
+	TagOptions models.TagOptions
+	// WriteConcurrency is the number of series whose exemplars are written
+	// concurrently, shared by all writes. Defaults to DefaultWriteConcurrency.
+	WriteConcurrency int
+	// InstrumentOptions are the instrument options.
+	InstrumentOptions instrument.Options
+}
+
+// Validate validates the store options.
+func (o StoreOptions) Validate() error {
+	if o.Clusters == nil {
+		return errClustersNotSet
+	}
+	if o.Namespace == "" {
+		return errNamespaceNotSet
+	}
+	return nil
+}
+
+type store struct {
+	clusters     m3.Clusters
+	namespace    ident.ID
+	tagOpts      models.TagOptions
+	writeWorkers xsync.WorkerPool
+	logger       *zap.Logger
+}
+
+// NewStore returns an exemplar store that persists exemplars in M3DB as
+// datapoints of the series they were recorded for, with the exemplar labels
+// encoded in the datapoint annotation. Exemplars are not kept alongside the
+// series of the metrics namespaces but in a dedicated namespace, which keys
+// them by series ID and block and gives them their own retention.
+func NewStore(opts StoreOptions) (Store, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	tagOpts := opts.TagOptions
+	if tagOpts == nil {
+		tagOpts = models.NewTagOptions()
+	}
+	iOpts := opts.InstrumentOptions
+	if iOpts == nil {
+		iOpts = instrument.NewOptions()
+	}
+
+	writeConcurrency := opts.WriteConcurrency
+	if writeConcurrency <= 0 {
+		writeConcurrency = DefaultWriteConcurrency
+	}
+	writeWorkers := xsync.NewWorkerPool(writeConcurrency)
+	writeWorkers.Init()
+
+	return &store{
+		clusters:     opts.Clusters,
+		namespace:    ident.StringID(opts.Namespace),
+		tagOpts:      tagOpts,
+		writeWorkers: writeWorkers,
+		logger:       iOpts.Logger(),
+	}, nil
+}
+
+func (s *store) session() (client.Session, error) {
+	namespace, ok := s.clusters.UnaggregatedClusterNamespace()
+	if !ok {
+		return nil, errSessionNotReady
+	}
+
+	// NB: exemplars are datapoints of the series they were recorded for, they
+	// would be read as samples if the namespace also stored metrics.
+	for _, metricsNamespace := range s.clusters.ClusterNamespaces() {
+		if metricsNamespace.NamespaceID().Equal(s.namespace) {
+			return nil, fmt.Errorf(
+				"exemplar namespace %s is also a metrics namespace", s.namespace)
+		}
+	}
+	return namespace.Session(), nil
+}
+
+func (s *store) Write(_ context.Context, series []SeriesExemplars) error {
+	session, err := s.session()
+	if err != nil {
+		return err
+	}
+
+	var (
+		wg       sync.WaitGroup
+		lock     sync.Mutex
+		multiErr = xerrors.NewMultiError()
+	)
+	for _, seriesExemplars := range series {
+		if len(seriesExemplars.Exemplars) == 0 {
+			continue
+		}
+
+		seriesExemplars := seriesExemplars // Capture var
+		wg.Add(1)
+		s.writeWorkers.Go(func() {
+			defer wg.Done()
+			if err := s.writeSeries(session, seriesExemplars); err != nil {
+				lock.Lock()
+				multiErr = multiErr.Add(err)
+				lock.Unlock()
+			}
+		})
+	}
+
+	wg.Wait()
+	return multiErr.FinalError()
+}
+
+func (s *store) writeSeries(session client.Session, series SeriesExemplars) error {
+	var (
+		id         = ident.BytesID(series.Tags.ID())
+		tagIter    = storage.TagsToIdentTagIterator(series.Tags)
+		annotation []byte
+	)
+	defer tagIter.Close()
+
+	for _, exemplar := range series.Exemplars {
+		annotation = AppendAnnotation(annotation[:0], exemplar.Labels)
+		// NB: the session holds on to the annotation until the write is done,
+		// so each write gets its own copy.
+		exemplarTagIter := tagIter.Duplicate()
+		err := session.WriteTagged(s.namespace, id, exemplarTagIter,
+			exemplar.Timestamp, exemplar.Value, xtime.Millisecond,
+			append([]byte{}, annotation...))
+		exemplarTagIter.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *store) Query(
+	ctx context.Context,
+	query *storage.FetchQuery,
+	options *storage.FetchOptions,
+) ([]SeriesExemplars, error) {
+	session, err := s.session()
+	if err != nil {
+		return nil, err
+	}
+
+	m3query, err := storage.FetchQueryToM3Query(query, options)
+	if err != nil {
+		return nil, err
+	}
+
+	queryOpts, err := storage.FetchOptionsToM3Options(options, query)
+	if err != nil {
+		return nil, err
+	}
+
+	iters, _, err := session.FetchTagged(ctx, s.namespace, m3query, queryOpts)
+	if err != nil {
+		return nil, err
+	}
+	defer iters.Close()
+
+	result := make([]SeriesExemplars, 0, iters.Len())
+	for _, iter := range iters.Iters() {
+		tags, err := consolidators.FromIdentTagIteratorToTags(iter.Tags(), s.tagOpts)
+		if err != nil {
+			return nil, err
+		}
+
+		series := SeriesExemplars{Tags: tags}
+		for iter.Next() {
+			dp, _, annotation := iter.Current()
+			labels, err := DecodeAnnotation(annotation, s.tagOpts)
+			if err != nil {
+				s.logger.Warn("skipping invalid exemplar",
+					zap.String("series", iter.ID().String()), zap.Error(err))
+				continue
+			}
+
+			series.Exemplars = append(series.Exemplars, Exemplar{
+				Labels:    labels,
+				Value:     dp.Value,
+				Timestamp: dp.TimestampNanos,
+			})
+		}
+		if err := iter.Err(); err != nil {
+			return nil, err
+		}
+
+		if len(series.Exemplars) > 0 {
+			result = append(result, series)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return string(result[i].Tags.ID()) < string(result[j].Tags.ID())
+	})
+	return result, nil
+}
@@ -0,0 +1,303 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exemplar
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/m3"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+type testWrite struct {
+	id         string
+	tags       models.Tags
+	timestamp  xtime.UnixNano
+	value      float64
+	annotation ts.Annotation
+}
+
+func newTestStore(t *testing.T, session client.Session) Store {
+	clusters, err := m3.NewClusters(m3.UnaggregatedClusterNamespaceDefinition{
+		NamespaceID: ident.StringID("metrics"),
+		Session:     session,
+		Retention:   24 * time.Hour,
+	})
+	require.NoError(t, err)
+
+	store, err := NewStore(StoreOptions{
+		Clusters:  clusters,
+		Namespace: "exemplars",
+	})
+	require.NoError(t, err)
+	return store
+}
+
+func newTestSeriesIterator(
+	t *testing.T,
+	tags models.Tags,
+	start xtime.UnixNano,
+	writes []testWrite,
+) encoding.SeriesIterator {
+	encodingOpts := encoding.NewOptions()
+	encoder := m3tsz.NewEncoder(start, nil, true, encodingOpts)
+	for _, w := range writes {
+		require.NoError(t, encoder.Encode(ts.Datapoint{
+			TimestampNanos: w.timestamp,
+			Value:          w.value,
+		}, xtime.Millisecond, w.annotation))
+	}
+
+	replica := encoding.NewMultiReaderIterator(
+		m3tsz.DefaultReaderIteratorAllocFn(encodingOpts), nil)
+	replica.ResetSliceOfSlices(xio.NewReaderSliceOfSlicesFromBlockReadersIterator(
+		[][]xio.BlockReader{{{
+			SegmentReader: xio.NewSegmentReader(encoder.Discard()),
+			Start:         start,
+			BlockSize:     time.Hour,
+		}}}), nil)
+
+	return encoding.NewSeriesIterator(encoding.SeriesIteratorOptions{
+		ID:             ident.BytesID(tags.ID()),
+		Namespace:      ident.StringID("exemplars"),
+		Tags:           storage.TagsToIdentTagIterator(tags),
+		Replicas:       []encoding.MultiReaderIterator{replica},
+		StartInclusive: start,
+		EndExclusive:   start.Add(time.Hour),
+	}, nil)
+}
+
+func TestStoreWriteAndQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		tagOpts = models.NewTagOptions()
+		start   = xtime.Now().Truncate(time.Hour)
+		series  = models.NewTags(2, tagOpts).
+			AddTag(models.Tag{Name: []byte("__name__"), Value: []byte("requests_total")}).
+			AddTag(models.Tag{Name: []byte("job"), Value: []byte("api")})
+		traceA = models.NewTags(1, tagOpts).
+			AddTag(models.Tag{Name: []byte("trace_id"), Value: []byte("a")})
+		traceB = models.NewTags(1, tagOpts).
+			AddTag(models.Tag{Name: []byte("trace_id"), Value: []byte("b")})
+		input = []SeriesExemplars{
+			{
+				Tags: series,
+				Exemplars: []Exemplar{
+					{Labels: traceA, Value: 1, Timestamp: start.Add(time.Minute)},
+					{Labels: traceB, Value: 2, Timestamp: start.Add(2 * time.Minute)},
+					// Exemplars without labels do not inherit the previous labels.
+					{Labels: models.EmptyTags(), Value: 3, Timestamp: start.Add(3 * time.Minute)},
+				},
+			},
+			{Tags: models.NewTags(0, tagOpts)},
+		}
+		lock   sync.Mutex
+		writes []testWrite
+	)
+
+	session := client.NewMockSession(ctrl)
+	session.EXPECT().
+		WriteTagged(ident.NewIDMatcher("exemplars"), gomock.Any(), gomock.Any(),
+			gomock.Any(), gomock.Any(), xtime.Millisecond, gomock.Any()).
+		DoAndReturn(func(
+			_, id ident.ID,
+			tags ident.TagIterator,
+			timestamp xtime.UnixNano,
+			value float64,
+			_ xtime.Unit,
+			annotation []byte,
+		) error {
+			lock.Lock()
+			defer lock.Unlock()
+			writes = append(writes, testWrite{
+				id:         id.String(),
+				timestamp:  timestamp,
+				value:      value,
+				annotation: annotation,
+			})
+			return nil
+		}).Times(3)
+
+	store := newTestStore(t, session)
+	require.NoError(t, store.Write(context.Background(), input))
+	require.Len(t, writes, 3)
+	for _, w := range writes {
+		assert.Equal(t, string(series.ID()), w.id)
+	}
+
+	session.EXPECT().
+		FetchTagged(gomock.Any(), ident.NewIDMatcher("exemplars"), gomock.Any(), gomock.Any()).
+		Return(encoding.NewSeriesIterators([]encoding.SeriesIterator{
+			newTestSeriesIterator(t, series, start, writes),
+		}), client.FetchResponseMetadata{Exhaustive: true}, nil)
+
+	result, err := store.Query(context.Background(), &storage.FetchQuery{
+		TagMatchers: models.Matchers{{
+			Type:  models.MatchEqual,
+			Name:  []byte("job"),
+			Value: []byte("api"),
+		}},
+		Start: start.ToTime(),
+		End:   start.Add(time.Hour).ToTime(),
+	}, storage.NewFetchOptions())
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.True(t, series.Equals(result[0].Tags))
+
+	require.Len(t, result[0].Exemplars, 3)
+	for i, actual := range result[0].Exemplars {
+		expected := input[0].Exemplars[i]
+		assert.True(t, expected.Labels.Equals(actual.Labels), "exemplar %d", i)
+		assert.Equal(t, expected.Value, actual.Value)
+		assert.Equal(t, expected.Timestamp, actual.Timestamp)
+	}
+}
+
+func TestStoreSessionNotReady(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	clusters := m3.NewMockClusters(ctrl)
+	clusters.EXPECT().UnaggregatedClusterNamespace().Return(nil, false).AnyTimes()
+
+	store, err := NewStore(StoreOptions{Clusters: clusters, Namespace: "exemplars"})
+	require.NoError(t, err)
+
+	assert.Error(t, store.Write(context.Background(), nil))
+	_, err = store.Query(context.Background(), &storage.FetchQuery{},
+		storage.NewFetchOptions())
+	assert.Error(t, err)
+}
+
+func TestStoreWriteConcurrencyIsBounded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		tagOpts   = models.NewTagOptions()
+		start     = xtime.Now().Truncate(time.Hour)
+		numSeries = 10
+		input     []SeriesExemplars
+		lock      sync.Mutex
+		inflight  int
+		maxSeen   int
+	)
+	for i := 0; i < numSeries; i++ {
+		input = append(input, SeriesExemplars{
+			Tags: models.NewTags(1, tagOpts).AddTag(models.Tag{
+				Name:  []byte("series"),
+				Value: []byte{byte('a' + i)},
+			}),
+			Exemplars: []Exemplar{{Labels: models.EmptyTags(), Value: 1, Timestamp: start}},
+		})
+	}
+
+	session := client.NewMockSession(ctrl)
+	session.EXPECT().
+		WriteTagged(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_, _ ident.ID,
+			_ ident.TagIterator,
+			_ xtime.UnixNano,
+			_ float64,
+			_ xtime.Unit,
+			_ []byte,
+		) error {
+			lock.Lock()
+			inflight++
+			if inflight > maxSeen {
+				maxSeen = inflight
+			}
+			lock.Unlock()
+
+			time.Sleep(time.Millisecond)
+
+			lock.Lock()
+			inflight--
+			lock.Unlock()
+			return nil
+		}).Times(numSeries)
+
+	clusters, err := m3.NewClusters(m3.UnaggregatedClusterNamespaceDefinition{
+		NamespaceID: ident.StringID("metrics"),
+		Session:     session,
+		Retention:   24 * time.Hour,
+	})
+	require.NoError(t, err)
+
+	store, err := NewStore(StoreOptions{
+		Clusters:         clusters,
+		Namespace:        "exemplars",
+		WriteConcurrency: 2,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, store.Write(context.Background(), input))
+	assert.True(t, maxSeen <= 2, "max concurrent writes %d", maxSeen)
+}
+
+func TestStoreRejectsMetricsNamespace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	clusters, err := m3.NewClusters(m3.UnaggregatedClusterNamespaceDefinition{
+		NamespaceID: ident.StringID("metrics"),
+		Session:     client.NewMockSession(ctrl),
+		Retention:   24 * time.Hour,
+	})
+	require.NoError(t, err)
+
+	store, err := NewStore(StoreOptions{Clusters: clusters, Namespace: "metrics"})
+	require.NoError(t, err)
+
+	assert.Error(t, store.Write(context.Background(), nil))
+	_, err = store.Query(context.Background(), &storage.FetchQuery{},
+		storage.NewFetchOptions())
+	assert.Error(t, err)
+}
+
+func TestNewStoreValidates(t *testing.T) {
+	_, err := NewStore(StoreOptions{Namespace: "exemplars"})
+	assert.Error(t, err)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	_, err = NewStore(StoreOptions{Clusters: m3.NewMockClusters(ctrl)})
+	assert.Error(t, err)
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package exemplar stores Prometheus exemplars alongside the series they
+// were recorded for and queries them back by series matchers.
+package exemplar
+
+import (
+	"context"
+
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+// Exemplar is a single exemplar recorded for a series.
+type Exemplar struct {
+	// Labels are the exemplar labels, such as a trace ID.
+	Labels models.Tags
+	// Value is the exemplar value.
+	Value float64
+	// Timestamp is the time the exemplar was recorded at.
+	Timestamp xtime.UnixNano
+}
+
+// SeriesExemplars are the exemplars recorded for a single series.
+type SeriesExemplars struct {
+	// Tags are the tags of the series the exemplars were recorded for.
+	Tags models.Tags
+	// Exemplars are the series exemplars in timestamp order.
+	Exemplars []Exemplar
+}
+
+// Store persists exemplars and queries them back.
+type Store interface {
+	// Write persists the exemplars of each series.
+	Write(ctx context.Context, series []SeriesExemplars) error
+
+	// Query returns the exemplars of the series matching the query in the
+	// query time range.
+	Query(
+		ctx context.Context,
+		query *storage.FetchQuery,
+		options *storage.FetchOptions,
+	) ([]SeriesExemplars, error)
+}
@@ -82,7 +82,7 @@ func FetchOptionsToM3Options(
 		return index.QueryOptions{}, err
 	}
 
-	return index.QueryOptions{
+	opts := index.QueryOptions{
 		SeriesLimit:                   fetchOptions.SeriesLimit,
 		InstanceMultiple:              fetchOptions.InstanceMultiple,
 		DocsLimit:                     fetchOptions.DocsLimit,
@@ -93,7 +93,12 @@ func FetchOptionsToM3Options(
 		Source:                        fetchOptions.Source,
 		StartInclusive:                xtime.ToUnixNano(start),
 		EndExclusive:                  xtime.ToUnixNano(end),
-	}, nil
+	}
+	if d := fetchOptions.Downsample; d != nil {
+		opts.DownsampleStep = d.Step
+		opts.DownsampleType = d.Type
+	}
+	return opts, nil
 }
 
 func convertStartEndWithRangeLimit(
@@ -27,6 +27,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/x/ident"
@@ -321,6 +322,27 @@ func TestFetchQueryToM3Query(t *testing.T) {
 	}
 }
 
+func TestFetchOptionsToM3OptionsDownsample(t *testing.T) {
+	query := &FetchQuery{
+		Start: now.Add(-time.Hour),
+		End:   now,
+	}
+
+	opts, err := FetchOptionsToM3Options(&FetchOptions{}, query)
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(0), opts.DownsampleStep)
+
+	opts, err = FetchOptionsToM3Options(&FetchOptions{
+		Downsample: &DownsampleOptions{
+			Step: time.Minute,
+			Type: encoding.DownsampleSum,
+		},
+	}, query)
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, opts.DownsampleStep)
+	assert.Equal(t, encoding.DownsampleSum, opts.DownsampleType)
+}
+
 func TestFetchOptionsToAggregateOptions(t *testing.T) {
 	now := time.Now()
 
@@ -27,6 +27,7 @@ import (
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/storage/m3/consolidators"
 	"github.com/m3db/m3/src/query/storage/m3/storagemetadata"
+	"github.com/m3db/m3/src/query/tenant"
 	xerrors "github.com/m3db/m3/src/x/errors"
 	xtime "github.com/m3db/m3/src/x/time"
 )
@@ -89,10 +90,17 @@ func resolveClusterNamespacesForQuery(
 	start,
 	end xtime.UnixNano,
 	clusters Clusters,
+	tenants tenant.Tenants,
 	opts *storage.FanoutOptions,
 	restrict *storage.RestrictQueryOptions,
 	relatedQueryOpts *storage.RelatedQueryOptions,
 ) (consolidators.QueryFanoutType, resolvedNamespaces, error) {
+	// Restrict the namespaces to those of the tenant, if any.
+	clusters, opts, err := resolveTenantClusters(clusters, tenants, opts, restrict)
+	if err != nil {
+		return consolidators.NamespaceInvalid, nil, err
+	}
+
 	// Calculate a new start time if related query opts are present.
 	// NB: We do not calculate a new end time because it does not factor
 	// into namespace selection.
@@ -161,7 +169,7 @@ func resolveClusterNamespacesForQueryLogicalPlan(
 	// If so, return it and shortcircuit, as unaggregated will necessarily have
 	// every metric.
 	ns, initialized := clusters.UnaggregatedClusterNamespace()
-	if !initialized {
+	if !initialized && opts.FanoutUnaggregated != storage.FanoutForceDisable {
 		return consolidators.NamespaceInvalid, nil, errUnaggregatedNamespaceUninitialized
 	}
 
@@ -485,7 +493,7 @@ func mustStitchWithUnaggregated(
 	narrowing narrowing,
 	unaggregated unaggregatedNamespaceDetails,
 ) (resolvedNamespace, bool) {
-	if !narrowing.end.IsZero() {
+	if !narrowing.end.IsZero() && unaggregated.satisfies != disabled {
 		// completeAggregated namespace will not have the most recent data available, will
 		// have to query unaggregated namespace for it and then stitch the responses together.
 		unaggregatedNarrowed := resolved(unaggregated.clusterNamespace)
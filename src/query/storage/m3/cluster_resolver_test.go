@@ -71,7 +71,7 @@ func TestFanoutUnaggregatedDisableReturnsAggregatedNamespaces(t *testing.T) {
 
 	start := xtime.Now()
 	end := start.Add(time.Hour * 24 * -90)
-	_, clusters, err := resolveClusterNamespacesForQuery(start, start, end, store.clusters, opts,
+	_, clusters, err := resolveClusterNamespacesForQuery(start, start, end, store.clusters, nil, opts,
 		nil, nil)
 	require.NoError(t, err)
 	require.Equal(t, 1, len(clusters))
@@ -91,7 +91,7 @@ func TestFanoutUnaggregatedEnabledReturnsUnaggregatedNamespaces(t *testing.T) {
 	start := xtime.Now()
 	end := start.Add(time.Hour * 24 * -90)
 	_, clusters, err := resolveClusterNamespacesForQuery(start,
-		start, end, store.clusters, opts, nil, nil)
+		start, end, store.clusters, nil, opts, nil, nil)
 	require.NoError(t, err)
 	require.Equal(t, 1, len(clusters))
 	assert.Equal(t, "metrics_unaggregated", clusters[0].NamespaceID().String())
@@ -111,7 +111,7 @@ func TestGraphitePath(t *testing.T) {
 
 	start := xtime.Now()
 	end := start.Add(time.Second * -30)
-	_, clusters, err := resolveClusterNamespacesForQuery(start, start, end, store.clusters, opts,
+	_, clusters, err := resolveClusterNamespacesForQuery(start, start, end, store.clusters, nil, opts,
 		nil, nil)
 	require.NoError(t, err)
 	require.Equal(t, 4, len(clusters))
@@ -474,7 +474,7 @@ func TestResolveClusterNamespacesForQueryWithOptions(t *testing.T) {
 			}
 
 			fanoutType, clusters, err := resolveClusterNamespacesForQuery(now,
-				start, end, clusters, tt.opts, tt.restrict,
+				start, end, clusters, nil, tt.opts, tt.restrict,
 				&storage.RelatedQueryOptions{Timespans: relatedQueries})
 			if tt.expectedErr != nil {
 				assert.Error(t, err)
@@ -555,7 +555,7 @@ func TestLongUnaggregatedRetention(t *testing.T) {
 	}
 
 	fanoutType, ns, err := resolveClusterNamespacesForQuery(now, start, end, clusters,
-		opts, nil, nil)
+		nil, opts, nil, nil)
 
 	require.NoError(t, err)
 	actualNames := make([]string, len(ns))
@@ -603,7 +603,7 @@ func TestExampleCase(t *testing.T) {
 	for i := 27; i < 17520; i++ {
 		start := now.Add(time.Hour * -1 * time.Duration(i))
 		fanoutType, clusters, err := resolveClusterNamespacesForQuery(now, start, end, ns,
-			&storage.FanoutOptions{}, nil, nil)
+			nil, &storage.FanoutOptions{}, nil, nil)
 
 		require.NoError(t, err)
 		actualNames := make([]string, len(clusters))
@@ -650,7 +650,7 @@ func TestDeduplicatePartialAggregateNamespaces(t *testing.T) {
 
 	start := now.Add(-48 * time.Hour)
 	fanoutType, clusters, err := resolveClusterNamespacesForQuery(now, start, end, ns,
-		&storage.FanoutOptions{}, nil, nil)
+		nil, &storage.FanoutOptions{}, nil, nil)
 	require.NoError(t, err)
 
 	actualNames := make([]string, len(clusters))
@@ -702,7 +702,7 @@ func TestResolveNamespaceWithDataLatency(t *testing.T) {
 	)
 
 	fanoutType, clusters, err := resolveClusterNamespacesForQuery(now, start, end, ns,
-		&storage.FanoutOptions{}, nil, nil)
+		nil, &storage.FanoutOptions{}, nil, nil)
 	require.NoError(t, err)
 
 	actualNamespaces := make(map[string]narrowing)
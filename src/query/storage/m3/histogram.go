@@ -0,0 +1,338 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package m3
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/histogram"
+	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/m3/consolidators"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+var histogramInfBucket = []byte("+Inf")
+
+type histogramSample struct {
+	timestamp xtime.UnixNano
+	histogram histogram.Histogram
+}
+
+// expandNativeHistograms replaces every series holding native histogram
+// samples with the classic histogram series derived from it: one series per
+// bucket upper bound labelled with the bucket tag (cumulative counts, the
+// +Inf bucket holding the total count). This lets both query engines treat
+// native histograms as classic ones, e.g. with histogram_quantile. The count
+// and the sum of observations are carried by two more series labelled with
+// the reserved native histogram tag rather than the bucket tag, so they
+// never collide with buckets and are ignored by histogram_quantile. The float
+// samples of series that switched to or from native histograms within the
+// queried range are kept in a series with the original tags.
+//
+// NB: the original iterators of expanded series are consumed but not closed,
+// they are closed with the rest of the fetch result.
+func expandNativeHistograms(
+	result consolidators.SeriesFetchResult,
+	tagOpts models.TagOptions,
+) (consolidators.SeriesFetchResult, error) {
+	var (
+		seriesIters   = result.SeriesIterators()
+		isHistogram   = make([]bool, len(seriesIters))
+		hasHistograms bool
+	)
+	for i, iter := range seriesIters {
+		ok, err := isNativeHistogramSeries(iter)
+		if err != nil {
+			return consolidators.SeriesFetchResult{}, err
+		}
+		isHistogram[i] = ok
+		hasHistograms = hasHistograms || ok
+	}
+	if !hasHistograms {
+		return result, nil
+	}
+
+	var (
+		count = result.Count()
+		iters = make([]encoding.SeriesIterator, 0, count)
+		tags  = make([]*models.Tags, 0, count)
+	)
+
+	for i := 0; i < count; i++ {
+		iter, seriesTags, err := result.IterTagsAtIndex(i, tagOpts)
+		if err != nil {
+			return consolidators.SeriesFetchResult{}, err
+		}
+
+		if !isHistogram[i] {
+			iters = append(iters, iter)
+			tags = append(tags, &seriesTags)
+			continue
+		}
+
+		expandedIters, expandedTags, err := expandNativeHistogramSeries(iter, seriesTags)
+		if err != nil {
+			return consolidators.SeriesFetchResult{}, err
+		}
+
+		iters = append(iters, expandedIters...)
+		tags = append(tags, expandedTags...)
+	}
+
+	return consolidators.NewSeriesFetchResult(
+		encoding.NewSeriesIterators(iters),
+		tags,
+		result.Metadata,
+	)
+}
+
+// isNativeHistogramSeries returns true if any sample of the series holds a
+// native histogram. Series only switch to the histogram encoding scheme once
+// they hold a histogram and may hold them in annotations of plain m3tsz
+// streams, so every block of every replica is scanned: streams written by
+// the histogram encoder are recognized without being decoded, the others are
+// decoded until a histogram annotation is found. The replicas are rewound
+// once scanned, like when shallow cloning them, so this does not advance the
+// series iterator.
+func isNativeHistogramSeries(iter encoding.SeriesIterator) (bool, error) {
+	if iter == nil {
+		return false, nil
+	}
+
+	replicas, err := iter.Replicas()
+	if err != nil {
+		return false, err
+	}
+
+	for _, replica := range replicas {
+		if replica == nil {
+			continue
+		}
+		ok, err := replicaHasNativeHistograms(replica.Readers())
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+
+	return false, nil
+}
+
+func replicaHasNativeHistograms(readers xio.ReaderSliceOfSlicesIterator) (bool, error) {
+	if readers == nil {
+		return false, nil
+	}
+
+	// NB: the multi reader iterator already called Next on the readers when
+	// it was reset, so the first pass starts on the current readers.
+	initIdx := readers.Index()
+	defer readers.RewindToIndex(initIdx)
+
+	for next := true; next; next = readers.Next() {
+		length, _, _ := readers.CurrentReaders()
+		for i := 0; i < length; i++ {
+			ok, err := blockHasNativeHistograms(readers.CurrentReaderAt(i))
+			if err != nil || ok {
+				return ok, err
+			}
+		}
+	}
+
+	return false, nil
+}
+
+func blockHasNativeHistograms(reader xio.BlockReader) (bool, error) {
+	if reader.SegmentReader == nil {
+		return false, nil
+	}
+
+	seg, err := reader.Segment()
+	if err != nil {
+		return false, err
+	}
+
+	var data []byte
+	if seg.Head != nil {
+		data = seg.Head.Bytes()
+	}
+	if seg.Tail != nil && seg.Tail.Len() > 0 {
+		data = append(append([]byte(nil), data...), seg.Tail.Bytes()...)
+	}
+	if len(data) == 0 {
+		return false, nil
+	}
+	if histogram.IsStream(data) {
+		return true, nil
+	}
+
+	iter := m3tsz.NewReaderIterator(xio.NewBytesReader64(data),
+		m3tsz.DefaultIntOptimizationEnabled, encoding.NewOptions())
+	defer iter.Close()
+	for iter.Next() {
+		if _, _, annotation := iter.Current(); histogram.IsAnnotation(annotation) {
+			return true, nil
+		}
+	}
+
+	return false, iter.Err()
+}
+
+func expandNativeHistogramSeries(
+	iter encoding.SeriesIterator,
+	tags models.Tags,
+) ([]encoding.SeriesIterator, []*models.Tags, error) {
+	var (
+		samples []histogramSample
+		floats  []ts.Datapoint
+		bounds  = make(map[float64]struct{})
+	)
+
+	for iter.Next() {
+		dp, _, annotation := iter.Current()
+		if !histogram.IsAnnotation(annotation) {
+			floats = append(floats, dp)
+			continue
+		}
+
+		h, err := histogram.DecodeAnnotation(annotation)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, bound := range h.UpperBounds() {
+			if !math.IsInf(bound, 1) {
+				bounds[bound] = struct{}{}
+			}
+		}
+
+		samples = append(samples, histogramSample{
+			timestamp: dp.TimestampNanos,
+			histogram: h,
+		})
+	}
+
+	if err := iter.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		iters   []encoding.SeriesIterator
+		allTags []*models.Tags
+	)
+
+	// The float samples of the series are kept as a series of their own with
+	// the original tags.
+	if len(floats) > 0 {
+		floatTags := tags.Clone()
+		iters = append(iters, newDerivedSeriesIterator(iter, floatTags, floats))
+		allTags = append(allTags, &floatTags)
+	}
+
+	if len(samples) == 0 {
+		return iters, allTags, nil
+	}
+
+	sortedBounds := make([]float64, 0, len(bounds))
+	for bound := range bounds {
+		sortedBounds = append(sortedBounds, bound)
+	}
+	sort.Float64s(sortedBounds)
+
+	addSeries := func(seriesTags models.Tags, valueFn func(h histogram.Histogram) float64) {
+		dps := make([]ts.Datapoint, 0, len(samples))
+		for _, sample := range samples {
+			dps = append(dps, ts.Datapoint{
+				TimestampNanos: sample.timestamp,
+				Value:          valueFn(sample.histogram),
+			})
+		}
+		iters = append(iters, newDerivedSeriesIterator(iter, seriesTags, dps))
+		allTags = append(allTags, &seriesTags)
+	}
+
+	for _, bound := range sortedBounds {
+		bound := bound
+		addSeries(tags.Clone().SetBucket([]byte(strconv.FormatFloat(bound, 'f', -1, 64))),
+			func(h histogram.Histogram) float64 { return h.CumulativeCount(bound) })
+	}
+
+	addSeries(tags.Clone().SetBucket(histogramInfBucket),
+		func(h histogram.Histogram) float64 { return h.Count })
+	addSeries(tags.Clone().AddOrUpdateTag(models.Tag{
+		Name:  models.NativeHistogramTagName,
+		Value: models.NativeHistogramCount,
+	}), func(h histogram.Histogram) float64 { return h.Count })
+	addSeries(tags.Clone().AddOrUpdateTag(models.Tag{
+		Name:  models.NativeHistogramTagName,
+		Value: models.NativeHistogramSum,
+	}), func(h histogram.Histogram) float64 { return h.Sum })
+
+	return iters, allTags, nil
+}
+
+func newDerivedSeriesIterator(
+	source encoding.SeriesIterator,
+	tags models.Tags,
+	dps []ts.Datapoint,
+) encoding.SeriesIterator {
+	var (
+		encodingOpts = encoding.NewOptions()
+		start        = dps[0].TimestampNanos
+		end          = dps[len(dps)-1].TimestampNanos
+		encoder      = m3tsz.NewEncoder(start, nil, m3tsz.DefaultIntOptimizationEnabled,
+			encodingOpts)
+	)
+
+	for _, dp := range dps {
+		// NB: encoding only fails for out of order samples, which the series
+		// iterator never returns.
+		_ = encoder.Encode(dp, xtime.Nanosecond, nil)
+	}
+
+	reader := xio.BlockReader{
+		SegmentReader: xio.NewSegmentReader(encoder.Discard()),
+		Start:         start,
+		BlockSize:     time.Duration(end-start) + 1,
+	}
+
+	replica := encoding.NewMultiReaderIterator(
+		m3tsz.DefaultReaderIteratorAllocFn(encodingOpts), nil)
+	replica.ResetSliceOfSlices(
+		xio.NewReaderSliceOfSlicesFromBlockReadersIterator([][]xio.BlockReader{{reader}}),
+		nil)
+
+	return encoding.NewSeriesIterator(encoding.SeriesIteratorOptions{
+		ID:             ident.BytesID(tags.ID()),
+		Namespace:      ident.StringID(source.Namespace().String()),
+		Tags:           storage.TagsToIdentTagIterator(tags),
+		Replicas:       []encoding.MultiReaderIterator{replica},
+		StartInclusive: source.Start(),
+		EndExclusive:   source.End(),
+	}, nil)
+}
@@ -0,0 +1,249 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package m3
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/histogram"
+	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/m3/consolidators"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+type testHistogramDatapoint struct {
+	value      float64
+	annotation ts.Annotation
+}
+
+func newTestHistogramSeriesIterator(
+	t *testing.T,
+	name string,
+	start xtime.UnixNano,
+	dps []testHistogramDatapoint,
+) encoding.SeriesIterator {
+	encoder := m3tsz.NewEncoder(start, nil, true, encoding.NewOptions())
+	return newTestHistogramSeriesIteratorWithEncoder(t, name, start, dps, encoder)
+}
+
+func newTestHistogramSeriesIteratorWithEncoder(
+	t *testing.T,
+	name string,
+	start xtime.UnixNano,
+	dps []testHistogramDatapoint,
+	encoder encoding.Encoder,
+) encoding.SeriesIterator {
+	encodingOpts := encoding.NewOptions()
+	for i, dp := range dps {
+		require.NoError(t, encoder.Encode(ts.Datapoint{
+			TimestampNanos: start.Add(time.Duration(i) * time.Minute),
+			Value:          dp.value,
+		}, xtime.Millisecond, dp.annotation))
+	}
+
+	replica := encoding.NewMultiReaderIterator(
+		histogram.DefaultReaderIteratorAllocFn(encodingOpts), nil)
+	replica.ResetSliceOfSlices(xio.NewReaderSliceOfSlicesFromBlockReadersIterator(
+		[][]xio.BlockReader{{{
+			SegmentReader: xio.NewSegmentReader(encoder.Discard()),
+			Start:         start,
+			BlockSize:     time.Hour,
+		}}}), nil)
+
+	tags := models.NewTags(1, models.NewTagOptions()).
+		AddTag(models.Tag{Name: []byte("__name__"), Value: []byte(name)})
+	return encoding.NewSeriesIterator(encoding.SeriesIteratorOptions{
+		ID:             ident.StringID(name),
+		Namespace:      ident.StringID("ns"),
+		Tags:           storage.TagsToIdentTagIterator(tags),
+		Replicas:       []encoding.MultiReaderIterator{replica},
+		StartInclusive: start,
+		EndExclusive:   start.Add(time.Hour),
+	}, nil)
+}
+
+func readTestSeries(t *testing.T, iter encoding.SeriesIterator) []float64 {
+	var values []float64
+	for iter.Next() {
+		dp, _, _ := iter.Current()
+		values = append(values, dp.Value)
+	}
+
+	require.NoError(t, iter.Err())
+	return values
+}
+
+func TestExpandNativeHistograms(t *testing.T) {
+	var (
+		start = xtime.Now().Truncate(time.Hour)
+		h1    = histogram.Histogram{
+			Count:           3,
+			Sum:             4.5,
+			PositiveSpans:   []histogram.Span{{Offset: 1, Length: 1}},
+			PositiveBuckets: []float64{3},
+		}
+		h2 = histogram.Histogram{
+			Count:           5,
+			Sum:             9,
+			PositiveSpans:   []histogram.Span{{Offset: 1, Length: 2}},
+			PositiveBuckets: []float64{4, 1},
+		}
+	)
+
+	iters := encoding.NewSeriesIterators([]encoding.SeriesIterator{
+		newTestHistogramSeriesIterator(t, "floats", start, []testHistogramDatapoint{
+			{value: 1}, {value: 2},
+		}),
+		newTestHistogramSeriesIterator(t, "latency", start, []testHistogramDatapoint{
+			{value: h1.Count, annotation: histogram.AppendAnnotation(nil, h1)},
+			{value: h2.Count, annotation: histogram.AppendAnnotation(nil, h2)},
+		}),
+	})
+
+	result, err := consolidators.NewSeriesFetchResult(iters, nil, block.NewResultMetadata())
+	require.NoError(t, err)
+
+	tagOpts := models.NewTagOptions()
+	result, err = expandNativeHistograms(result, tagOpts)
+	require.NoError(t, err)
+	require.Equal(t, 6, result.Count())
+
+	expected := []struct {
+		name      string
+		bucket    string
+		histogram string
+		values    []float64
+	}{
+		{name: "floats", values: []float64{1, 2}},
+		{name: "latency", bucket: "2", values: []float64{3, 4}},
+		{name: "latency", bucket: "4", values: []float64{3, 5}},
+		{name: "latency", bucket: "+Inf", values: []float64{3, 5}},
+		{name: "latency", histogram: "count", values: []float64{3, 5}},
+		{name: "latency", histogram: "sum", values: []float64{4.5, 9}},
+	}
+
+	for i, ex := range expected {
+		iter, tags, err := result.IterTagsAtIndex(i, tagOpts)
+		require.NoError(t, err)
+
+		name, ok := tags.Name()
+		require.True(t, ok)
+		assert.Equal(t, ex.name, string(name))
+
+		bucket, ok := tags.Bucket()
+		assert.Equal(t, ex.bucket != "", ok)
+		assert.Equal(t, ex.bucket, string(bucket))
+
+		histogramValue, ok := tags.Get(models.NativeHistogramTagName)
+		assert.Equal(t, ex.histogram != "", ok)
+		assert.Equal(t, ex.histogram, string(histogramValue))
+		assert.Equal(t, ex.values, readTestSeries(t, iter))
+	}
+}
+
+func TestExpandNativeHistogramsNoHistograms(t *testing.T) {
+	start := xtime.Now().Truncate(time.Hour)
+	iters := encoding.NewSeriesIterators([]encoding.SeriesIterator{
+		newTestHistogramSeriesIterator(t, "floats", start, []testHistogramDatapoint{
+			{value: 1}, {value: 2},
+		}),
+	})
+
+	result, err := consolidators.NewSeriesFetchResult(iters, nil, block.NewResultMetadata())
+	require.NoError(t, err)
+
+	expanded, err := expandNativeHistograms(result, models.NewTagOptions())
+	require.NoError(t, err)
+	assert.Equal(t, result.SeriesIterators(), expanded.SeriesIterators())
+	assert.Equal(t, []float64{1, 2}, readTestSeries(t, expanded.SeriesIterators()[0]))
+}
+
+func TestExpandNativeHistogramsFloatsThenHistograms(t *testing.T) {
+	var (
+		start = xtime.Now().Truncate(time.Hour)
+		h     = histogram.Histogram{
+			Count:           3,
+			Sum:             4.5,
+			PositiveSpans:   []histogram.Span{{Offset: 1, Length: 1}},
+			PositiveBuckets: []float64{3},
+		}
+		dps = []testHistogramDatapoint{
+			{value: 1},
+			{value: 2},
+			{value: h.Count, annotation: histogram.AppendAnnotation(nil, h)},
+		}
+	)
+
+	for _, test := range []struct {
+		name    string
+		encoder encoding.Encoder
+	}{
+		{name: "annotations", encoder: m3tsz.NewEncoder(start, nil, true, encoding.NewOptions())},
+		{name: "histogram scheme", encoder: histogram.NewEncoder(start, true, encoding.NewOptions())},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			iters := encoding.NewSeriesIterators([]encoding.SeriesIterator{
+				newTestHistogramSeriesIteratorWithEncoder(t, "latency", start, dps, test.encoder),
+			})
+			result, err := consolidators.NewSeriesFetchResult(iters, nil, block.NewResultMetadata())
+			require.NoError(t, err)
+
+			tagOpts := models.NewTagOptions()
+			result, err = expandNativeHistograms(result, tagOpts)
+			require.NoError(t, err)
+			require.Equal(t, 5, result.Count())
+
+			// The float samples are kept in a series with the original tags.
+			expected := []struct {
+				bucket    string
+				histogram string
+				values    []float64
+			}{
+				{values: []float64{1, 2}},
+				{bucket: "2", values: []float64{3}},
+				{bucket: "+Inf", values: []float64{3}},
+				{histogram: "count", values: []float64{3}},
+				{histogram: "sum", values: []float64{4.5}},
+			}
+			for i, ex := range expected {
+				iter, tags, err := result.IterTagsAtIndex(i, tagOpts)
+				require.NoError(t, err)
+
+				bucket, _ := tags.Bucket()
+				assert.Equal(t, ex.bucket, string(bucket))
+				histogramValue, _ := tags.Get(models.NativeHistogramTagName)
+				assert.Equal(t, ex.histogram, string(histogramValue))
+				assert.Equal(t, ex.values, readTestSeries(t, iter))
+			}
+		})
+	}
+}
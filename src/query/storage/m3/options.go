@@ -32,6 +32,7 @@ import (
 	"github.com/m3db/m3/src/query/pools"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/storage/m3/consolidators"
+	"github.com/m3db/m3/src/query/tenant"
 	"github.com/m3db/m3/src/x/instrument"
 	"github.com/m3db/m3/src/x/pool"
 	xsync "github.com/m3db/m3/src/x/sync"
@@ -144,6 +145,7 @@ type encodedBlockOptions struct {
 	blockSeriesProcessor          BlockSeriesProcessor
 	adminOptions                  []client.CustomAdminOption
 	promConvertOptions            storage.PromConvertOptions
+	tenants                       tenant.Tenants
 	instrumented                  bool
 }
 
@@ -341,6 +343,16 @@ func (o *encodedBlockOptions) PromConvertOptions() storage.PromConvertOptions {
 	return o.promConvertOptions
 }
 
+func (o *encodedBlockOptions) SetTenants(value tenant.Tenants) Options {
+	opts := *o
+	opts.tenants = value
+	return &opts
+}
+
+func (o *encodedBlockOptions) Tenants() tenant.Tenants {
+	return o.tenants
+}
+
 func (o *encodedBlockOptions) Validate() error {
 	if o.lookbackDuration < 0 {
 		return errors.New("unable to validate block options; negative lookback")
@@ -30,12 +30,14 @@ import (
 
 	"github.com/opentracing/opentracing-go/log"
 	"github.com/prometheus/common/model"
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
 	coordmodel "github.com/m3db/m3/src/cmd/services/m3coordinator/model"
 	"github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/dbnode/storage/limits"
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/errors"
 	"github.com/m3db/m3/src/query/generated/proto/prompb"
@@ -43,6 +45,7 @@ import (
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/storage/m3/consolidators"
 	"github.com/m3db/m3/src/query/storage/m3/storagemetadata"
+	"github.com/m3db/m3/src/query/tenant"
 	"github.com/m3db/m3/src/query/tracepoint"
 	"github.com/m3db/m3/src/query/ts"
 	xcontext "github.com/m3db/m3/src/x/context"
@@ -71,6 +74,8 @@ var (
 	errNoNamespacesConfigured             = goerrors.New("no namespaces configured")
 	errUnaggregatedNamespaceUninitialized = goerrors.New(
 		"unaggregated namespace is not yet initialized")
+	errTenantsNotConfigured = goerrors.New(
+		"query restricted to a tenant but no tenants configured")
 )
 
 type m3storage struct {
@@ -108,6 +113,7 @@ func (s *m3storage) QueryStorageMetadataAttributes(
 		xtime.ToUnixNano(queryStart),
 		xtime.ToUnixNano(queryEnd),
 		s.clusters,
+		s.opts.Tenants(),
 		opts.FanoutOptions,
 		opts.RestrictQueryOptions,
 		opts.RelatedQueryOptions)
@@ -207,6 +213,11 @@ func (s *m3storage) FetchProm(
 	}
 
 	result.Metadata.Resolutions = resolutions
+	result, err = expandNativeHistograms(result, s.opts.TagOptions())
+	if err != nil {
+		return storage.PromResult{}, err
+	}
+
 	fetchResult, err := storage.SeriesIteratorsToPromResult(
 		ctx,
 		result,
@@ -342,6 +353,14 @@ func (s *m3storage) FetchCompressedResult(
 	}
 
 	result.Metadata.Resolutions = resolutions
+	result, err = expandNativeHistograms(result, s.opts.TagOptions())
+	if err != nil {
+		accumulator.Close()
+		return consolidators.SeriesFetchResult{
+			Metadata: block.NewResultMetadata(),
+		}, noop, err
+	}
+
 	return result, accumulator.Close, nil
 }
 
@@ -384,6 +403,7 @@ func (s *m3storage) fetchCompressed(
 		queryStart,
 		queryEnd,
 		s.clusters,
+		s.opts.Tenants(),
 		options.FanoutOptions,
 		options.RestrictQueryOptions,
 		options.RelatedQueryOptions,
@@ -424,6 +444,17 @@ func (s *m3storage) fetchCompressed(
 		return nil, index.Query{}, errNoNamespacesConfigured
 	}
 
+	queryLimit, err := s.tenantQueryLimit(options)
+	if err != nil {
+		return nil, index.Query{}, err
+	}
+	if queryLimit != nil {
+		// Reject the query early if the limit is already exceeded.
+		if err := queryLimit.Inc(0, options.Source); err != nil {
+			return nil, index.Query{}, err
+		}
+	}
+
 	matchOpts := s.opts.SeriesConsolidationMatchOptions()
 	tagOpts := s.opts.TagOptions()
 	limitOpts := consolidators.LimitOptions{
@@ -434,6 +465,7 @@ func (s *m3storage) fetchCompressed(
 		RequireExhaustive: queryOptions.InstanceMultiple > 0 && options.RequireExhaustive,
 	}
 	result := consolidators.NewMultiFetchResult(fanout, matchOpts, tagOpts, limitOpts)
+	fetched := atomic.NewInt64(0)
 	for _, namespace := range namespaces {
 		namespace := namespace // Capture var
 
@@ -448,6 +480,9 @@ func (s *m3storage) fetchCompressed(
 			namespaceID := namespace.NamespaceID()
 			narrowedQueryOpts := narrowQueryOpts(queryOptions, namespace)
 			iters, metadata, err := session.FetchTagged(ctx, namespaceID, m3query, narrowedQueryOpts)
+			if err == nil {
+				fetched.Add(int64(iters.Len()))
+			}
 			if err == nil && sampled {
 				span.LogFields(
 					log.String("namespace", namespaceID.String()),
@@ -485,9 +520,28 @@ func (s *m3storage) fetchCompressed(
 	default:
 	}
 
+	if queryLimit != nil {
+		if err := queryLimit.Inc(int(fetched.Load()), options.Source); err != nil {
+			result.Close()
+			return nil, index.Query{}, err
+		}
+	}
+
 	return result, m3query, err
 }
 
+// tenantQueryLimit returns the query limit of the tenant a query is
+// restricted to, nil if the query is not restricted to a tenant.
+func (s *m3storage) tenantQueryLimit(
+	options *storage.FetchOptions,
+) (limits.LookbackLimit, error) {
+	t, err := resolveTenant(s.opts.Tenants(), options.RestrictQueryOptions)
+	if err != nil || t == nil {
+		return nil, err
+	}
+	return t.QueryLimit, nil
+}
+
 func (s *m3storage) SearchSeries(
 	ctx context.Context,
 	query *storage.FetchQuery,
@@ -587,6 +641,7 @@ func (s *m3storage) CompleteTags(
 		queryStart,
 		queryEnd,
 		s.clusters,
+		s.opts.Tenants(),
 		options.FanoutOptions,
 		options.RestrictQueryOptions,
 		nil)
@@ -731,6 +786,7 @@ func (s *m3storage) SearchCompressed(
 		queryStart,
 		queryEnd,
 		s.clusters,
+		s.opts.Tenants(),
 		options.FanoutOptions,
 		options.RestrictQueryOptions,
 		nil)
@@ -841,6 +897,10 @@ func (s *m3storage) Write(
 		return err
 	}
 
+	if err := s.checkTenantWritesNamespace(tags, namespace); err != nil {
+		return err
+	}
+
 	// Set id to NoFinalize to avoid cloning it in write operations
 	id.NoFinalize()
 
@@ -899,6 +959,30 @@ func (s *m3storage) Write(
 	return multiErr.lastError()
 }
 
+// checkTenantWritesNamespace returns an invalid params error if the tenant
+// of a series, if any, does not write a namespace.
+func (s *m3storage) checkTenantWritesNamespace(
+	tags models.Tags,
+	namespace ClusterNamespace,
+) error {
+	tenants := s.opts.Tenants()
+	if tenants == nil {
+		return nil
+	}
+
+	name, ok := tags.Get(tenants.TagName())
+	if !ok {
+		return nil
+	}
+
+	t, ok := tenants.Tenant(string(name))
+	if !ok || t.HasNamespace(namespace.NamespaceID().String()) {
+		return nil
+	}
+	return xerrors.NewInvalidParamsError(fmt.Errorf("%w: tenant=%s, namespace=%s",
+		tenant.ErrNamespaceNotWritten, t.Name, namespace.NamespaceID().String()))
+}
+
 func (s *m3storage) Type() storage.Type {
 	return storage.TypeLocalDC
 }
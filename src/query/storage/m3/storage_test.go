@@ -35,14 +35,17 @@ import (
 	"github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/dbnode/storage/limits"
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/generated/proto/prompb"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/storage/m3/consolidators"
 	"github.com/m3db/m3/src/query/storage/m3/storagemetadata"
+	"github.com/m3db/m3/src/query/tenant"
 	"github.com/m3db/m3/src/query/test/seriesiter"
 	"github.com/m3db/m3/src/query/ts"
+	xerrors "github.com/m3db/m3/src/x/errors"
 	"github.com/m3db/m3/src/x/ident"
 	"github.com/m3db/m3/src/x/instrument"
 	"github.com/m3db/m3/src/x/sync"
@@ -338,6 +341,141 @@ func TestWriteToReadOnlyNamespaceFail(t *testing.T) {
 		fmt.Sprintf("unexpected error string: %v", err.Error()))
 }
 
+func setupWithTenants(
+	t *testing.T,
+	ctrl *gomock.Controller,
+	cfg tenant.Configuration,
+) (storage.Storage, testSessions) {
+	store, sessions := setup(t, ctrl)
+	tenants, err := cfg.NewTenants(instrument.NewTestOptions(t))
+	require.NoError(t, err)
+	t.Cleanup(tenants.Close)
+
+	m3store, ok := store.(*m3storage)
+	require.True(t, ok)
+	m3store.opts = m3store.opts.SetTenants(tenants)
+	return store, sessions
+}
+
+func TestLocalWriteTenantNamespaces(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	store, sessions := setupWithTenants(t, ctrl, tenant.Configuration{
+		Tenants: []tenant.TenantConfiguration{{
+			Name:       "team-a",
+			Namespaces: []string{"metrics_aggregated_1m:30d"},
+		}},
+	})
+
+	// Only the writes to the namespaces of the tenant are written, the
+	// writes to other namespaces are rejected.
+	sessions.aggregated1MonthRetention1MinuteResolution.EXPECT().
+		WriteTagged(ident.NewIDMatcher("metrics_aggregated_1m:30d"),
+			gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any(), gomock.Any()).
+		Times(2)
+
+	for _, test := range []struct {
+		attrs   storagemetadata.Attributes
+		written bool
+	}{
+		{
+			attrs: storagemetadata.Attributes{
+				MetricsType: storagemetadata.UnaggregatedMetricsType,
+			},
+		},
+		{
+			attrs: storagemetadata.Attributes{
+				MetricsType: storagemetadata.AggregatedMetricsType,
+				Retention:   test1MonthRetention,
+				Resolution:  time.Minute,
+			},
+			written: true,
+		},
+		{
+			attrs: storagemetadata.Attributes{
+				MetricsType: storagemetadata.AggregatedMetricsType,
+				Retention:   test3MonthRetention,
+				Resolution:  5 * time.Minute,
+			},
+		},
+	} {
+		tags := models.EmptyTags().AddTags([]models.Tag{
+			{Name: []byte("foo"), Value: []byte("bar")},
+			{Name: []byte("tenant"), Value: []byte("team-a")},
+		})
+		writeQuery, err := storage.NewWriteQuery(storage.WriteQueryOptions{
+			Tags: tags,
+			Unit: xtime.Millisecond,
+			Datapoints: ts.Datapoints{
+				{Timestamp: xtime.Now(), Value: 1.0},
+				{Timestamp: xtime.Now().Add(-10 * time.Second), Value: 2.0},
+			},
+			Attributes: test.attrs,
+		})
+		require.NoError(t, err)
+
+		err = store.Write(context.TODO(), writeQuery)
+		if test.written {
+			require.NoError(t, err)
+			continue
+		}
+		require.Error(t, err)
+		require.True(t, xerrors.IsInvalidParams(err))
+		require.True(t, tenant.IsNamespaceNotWritten(err))
+	}
+}
+
+func TestLocalReadTenantQueryLimit(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	store, sessions := setupWithTenants(t, ctrl, tenant.Configuration{
+		Tenants: []tenant.TenantConfiguration{{
+			Name: "team-a",
+			Limits: tenant.LimitsConfiguration{
+				MaxFetchedSeries: &tenant.LookbackLimitConfiguration{
+					Value:    2,
+					Lookback: time.Hour,
+				},
+			},
+		}},
+	})
+	testTags := seriesiter.GenerateTag()
+
+	// NB: the third query is rejected before fetching any series.
+	sessions.unaggregated1MonthRetention.EXPECT().
+		FetchTagged(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			_ ident.ID,
+			_ index.Query,
+			_ index.QueryOptions,
+		) (encoding.SeriesIterators, client.FetchResponseMetadata, error) {
+			return seriesiter.NewMockSeriesIters(ctrl, testTags, 1, 2),
+				testFetchResponseMetadata, nil
+		}).
+		Times(2)
+
+	opts := buildFetchOpts()
+	opts.RestrictQueryOptions = &storage.RestrictQueryOptions{
+		RestrictByTenant: &storage.RestrictByTenant{Tenant: "team-a"},
+	}
+
+	results, err := store.FetchProm(context.TODO(), newFetchReq(), opts)
+	require.NoError(t, err)
+	assertFetchResult(t, results, testTags)
+
+	_, err = store.FetchProm(context.TODO(), newFetchReq(), opts)
+	require.Error(t, err)
+	require.True(t, limits.IsQueryLimitExceededError(err))
+
+	_, err = store.FetchProm(context.TODO(), newFetchReq(), opts)
+	require.Error(t, err)
+	require.True(t, limits.IsQueryLimitExceededError(err))
+}
+
 func TestLocalWriteAggregatedInvalidMetricsTypeError(t *testing.T) {
 	ctrl := xtest.NewController(t)
 	defer ctrl.Finish()
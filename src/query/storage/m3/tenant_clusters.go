@@ -0,0 +1,134 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package m3
+
+import (
+	"fmt"
+
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/tenant"
+)
+
+// tenantClusters restricts clusters to the namespaces of a tenant.
+type tenantClusters struct {
+	Clusters
+
+	tenant *tenant.Tenant
+}
+
+var _ Clusters = (*tenantClusters)(nil)
+
+func newTenantClusters(clusters Clusters, t *tenant.Tenant) Clusters {
+	return &tenantClusters{Clusters: clusters, tenant: t}
+}
+
+func (c *tenantClusters) ClusterNamespaces() ClusterNamespaces {
+	return c.filter(c.Clusters.ClusterNamespaces())
+}
+
+func (c *tenantClusters) NonReadyClusterNamespaces() ClusterNamespaces {
+	return c.filter(c.Clusters.NonReadyClusterNamespaces())
+}
+
+func (c *tenantClusters) UnaggregatedClusterNamespace() (ClusterNamespace, bool) {
+	namespace, ok := c.Clusters.UnaggregatedClusterNamespace()
+	if !ok || !c.hasNamespace(namespace) {
+		return nil, false
+	}
+	return namespace, true
+}
+
+func (c *tenantClusters) AggregatedClusterNamespace(
+	attrs RetentionResolution,
+) (ClusterNamespace, bool) {
+	namespace, ok := c.Clusters.AggregatedClusterNamespace(attrs)
+	if !ok || !c.hasNamespace(namespace) {
+		return nil, false
+	}
+	return namespace, true
+}
+
+func (c *tenantClusters) Close() error {
+	// NB: the underlying clusters are shared by all tenants and closed
+	// by their owner.
+	return nil
+}
+
+func (c *tenantClusters) hasNamespace(namespace ClusterNamespace) bool {
+	return c.tenant.HasNamespace(namespace.NamespaceID().String())
+}
+
+func (c *tenantClusters) filter(namespaces ClusterNamespaces) ClusterNamespaces {
+	filtered := make(ClusterNamespaces, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		if c.hasNamespace(namespace) {
+			filtered = append(filtered, namespace)
+		}
+	}
+	return filtered
+}
+
+// resolveTenant returns the tenant a query is restricted to, nil if the
+// query is not restricted to a tenant.
+func resolveTenant(
+	tenants tenant.Tenants,
+	restrict *storage.RestrictQueryOptions,
+) (*tenant.Tenant, error) {
+	tenantRestrict := restrict.GetRestrictByTenant()
+	if tenantRestrict == nil {
+		return nil, nil
+	}
+	if tenants == nil {
+		return nil, errTenantsNotConfigured
+	}
+
+	t, ok := tenants.Tenant(tenantRestrict.Tenant)
+	if !ok {
+		return nil, fmt.Errorf("unknown tenant: %s", tenantRestrict.Tenant)
+	}
+	return t, nil
+}
+
+// resolveTenantClusters restricts the clusters to the namespaces of the
+// tenant a query is restricted to. If the unaggregated namespace is not one
+// of the namespaces of the tenant the query does not fan out to it.
+func resolveTenantClusters(
+	clusters Clusters,
+	tenants tenant.Tenants,
+	opts *storage.FanoutOptions,
+	restrict *storage.RestrictQueryOptions,
+) (Clusters, *storage.FanoutOptions, error) {
+	t, err := resolveTenant(tenants, restrict)
+	if err != nil || t == nil {
+		return clusters, opts, err
+	}
+
+	unaggregated, ok := clusters.UnaggregatedClusterNamespace()
+	if ok && !t.HasNamespace(unaggregated.NamespaceID().String()) {
+		var disabled storage.FanoutOptions
+		if opts != nil {
+			disabled = *opts
+		}
+		disabled.FanoutUnaggregated = storage.FanoutForceDisable
+		opts = &disabled
+	}
+
+	return newTenantClusters(clusters, t), opts, nil
+}
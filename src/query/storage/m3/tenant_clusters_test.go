@@ -0,0 +1,153 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package m3
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/tenant"
+	"github.com/m3db/m3/src/x/ident"
+	"github.com/m3db/m3/src/x/instrument"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+func newTestTenants(t *testing.T) tenant.Tenants {
+	cfg := tenant.Configuration{
+		Tenants: []tenant.TenantConfiguration{
+			{
+				Name:       "team-a",
+				Namespaces: []string{"metrics_10s_24h", "metrics_180s_360h"},
+			},
+			{
+				Name:       "team-b",
+				Namespaces: []string{"metrics_600s_17520h"},
+			},
+			{
+				Name: "team-c",
+			},
+		},
+	}
+	tenants, err := cfg.NewTenants(instrument.NewOptions())
+	require.NoError(t, err)
+	t.Cleanup(tenants.Close)
+	return tenants
+}
+
+func TestResolveClusterNamespacesForQueryWithTenant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	session := client.NewMockSession(ctrl)
+	clusters, err := NewClusters(
+		UnaggregatedClusterNamespaceDefinition{
+			NamespaceID: ident.StringID("metrics_10s_24h"),
+			Retention:   24 * time.Hour,
+			Session:     session,
+		}, AggregatedClusterNamespaceDefinition{
+			NamespaceID: ident.StringID("metrics_180s_360h"),
+			Retention:   360 * time.Hour,
+			Resolution:  180 * time.Second,
+			Downsample:  &ClusterNamespaceDownsampleOptions{All: true},
+			Session:     session,
+		}, AggregatedClusterNamespaceDefinition{
+			NamespaceID: ident.StringID("metrics_600s_17520h"),
+			Retention:   17520 * time.Hour,
+			Resolution:  600 * time.Second,
+			Downsample:  &ClusterNamespaceDownsampleOptions{All: true},
+			Session:     session,
+		},
+	)
+	require.NoError(t, err)
+
+	var (
+		tenants = newTestTenants(t)
+		now     = xtime.Now()
+		end     = now
+	)
+	tests := []struct {
+		name     string
+		tenant   string
+		start    xtime.UnixNano
+		expected []string
+	}{
+		{
+			name:     "recent query",
+			tenant:   "team-a",
+			start:    now.Add(-time.Hour),
+			expected: []string{"metrics_10s_24h"},
+		},
+		{
+			name:     "query past tenant retention",
+			tenant:   "team-a",
+			start:    now.Add(-1000 * time.Hour),
+			expected: []string{"metrics_180s_360h"},
+		},
+		{
+			name:     "recent query without unaggregated namespace",
+			tenant:   "team-b",
+			start:    now.Add(-time.Hour),
+			expected: []string{"metrics_600s_17520h"},
+		},
+		{
+			name:     "query past retention without tenant namespaces",
+			tenant:   "team-c",
+			start:    now.Add(-1000 * time.Hour),
+			expected: []string{"metrics_600s_17520h"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			restrict := &storage.RestrictQueryOptions{
+				RestrictByTenant: &storage.RestrictByTenant{Tenant: test.tenant},
+			}
+			_, namespaces, err := resolveClusterNamespacesForQuery(now,
+				test.start, end, clusters, tenants, &storage.FanoutOptions{},
+				restrict, nil)
+			require.NoError(t, err)
+
+			actual := make([]string, 0, len(namespaces))
+			for _, ns := range namespaces {
+				actual = append(actual, ns.NamespaceID().String())
+			}
+			sort.Strings(actual)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+
+	restrict := &storage.RestrictQueryOptions{
+		RestrictByTenant: &storage.RestrictByTenant{Tenant: "team-d"},
+	}
+	_, _, err = resolveClusterNamespacesForQuery(now, now.Add(-time.Hour), end,
+		clusters, tenants, &storage.FanoutOptions{}, restrict, nil)
+	require.Error(t, err)
+
+	_, _, err = resolveClusterNamespacesForQuery(now, now.Add(-time.Hour), end,
+		clusters, nil, &storage.FanoutOptions{}, restrict, nil)
+	require.Equal(t, errTenantsNotConfigured, err)
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/storage/m3/consolidators"
+	"github.com/m3db/m3/src/query/tenant"
 	queryts "github.com/m3db/m3/src/query/ts"
 	"github.com/m3db/m3/src/x/instrument"
 	"github.com/m3db/m3/src/x/pool"
@@ -215,6 +216,11 @@ type Options interface {
 	// PromConvertOptions returns options for converting raw series iterators
 	// to a Prometheus-compatible result.
 	PromConvertOptions() storage.PromConvertOptions
+	// SetTenants sets the tenants, the reads and writes of a tenant are
+	// restricted to the namespaces of the tenant.
+	SetTenants(value tenant.Tenants) Options
+	// Tenants returns the tenants.
+	Tenants() tenant.Tenants
 	// Validate ensures that the given block options are valid.
 	Validate() error
 }
@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/histogram"
 	"github.com/m3db/m3/src/dbnode/generated/proto/annotation"
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/query/block"
@@ -73,7 +74,9 @@ func iteratorToPromResult(
 
 		if firstDP && maxResolution >= resolutionThreshold {
 			firstAnnotation := iter.FirstAnnotation()
-			if len(firstAnnotation) > 0 {
+			// NB: native histogram series hold histograms rather than
+			// a payload in their annotations.
+			if len(firstAnnotation) > 0 && !histogram.IsAnnotation(firstAnnotation) {
 				if err := annotationPayload.Unmarshal(firstAnnotation); err != nil {
 					return nil, err
 				}
@@ -0,0 +1,92 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package prometheus
+
+import (
+	"sync"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/m3db/m3/src/query/functions/linear"
+	"github.com/m3db/m3/src/query/models"
+)
+
+var registerFunctionsOnce sync.Once
+
+// RegisterFunctions registers the native histogram functions with the
+// Prometheus engine, which predates native histograms. Native histograms are
+// read as classic histograms (see the m3 storage), so the functions select the
+// matching series of each histogram. It must be called before the engine
+// evaluates queries using them and is safe to call more than once.
+func RegisterFunctions() {
+	registerFunctionsOnce.Do(func() {
+		if _, ok := promql.FunctionCalls[linear.HistogramCountType]; !ok {
+			promql.FunctionCalls[linear.HistogramCountType] = funcHistogramCount
+		}
+
+		if _, ok := promql.FunctionCalls[linear.HistogramSumType]; !ok {
+			promql.FunctionCalls[linear.HistogramSumType] = funcHistogramSum
+		}
+	})
+}
+
+func funcHistogramCount(
+	vals []parser.Value,
+	_ parser.Expressions,
+	enh *promql.EvalNodeHelper,
+) promql.Vector {
+	return selectNativeHistogramSeries(vals, enh, string(models.NativeHistogramCount))
+}
+
+func funcHistogramSum(
+	vals []parser.Value,
+	_ parser.Expressions,
+	enh *promql.EvalNodeHelper,
+) promql.Vector {
+	return selectNativeHistogramSeries(vals, enh, string(models.NativeHistogramSum))
+}
+
+// selectNativeHistogramSeries selects the series read from native histograms
+// whose native histogram label matches the value, other series (including
+// classic histograms) are ignored as they are by Prometheus.
+func selectNativeHistogramSeries(
+	vals []parser.Value,
+	enh *promql.EvalNodeHelper,
+	value string,
+) promql.Vector {
+	nativeHistogramLabel := string(models.NativeHistogramTagName)
+	for _, sample := range vals[0].(promql.Vector) {
+		if sample.Metric.Get(nativeHistogramLabel) != value {
+			continue
+		}
+
+		enh.Out = append(enh.Out, promql.Sample{
+			Metric: labels.NewBuilder(sample.Metric).
+				Del(labels.MetricName, nativeHistogramLabel).
+				Labels(),
+			Point: promql.Point{V: sample.V},
+		})
+	}
+
+	return enh.Out
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package prometheus
+
+import (
+	"testing"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/prometheus/prometheus/promql/parser"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/m3db/m3/src/query/functions/linear"
+	"github.com/m3db/m3/src/query/models"
+)
+
+func TestHistogramFunctions(t *testing.T) {
+	nativeHistogramLabel := string(models.NativeHistogramTagName)
+	newSample := func(instance, name, value string, v float64) promql.Sample {
+		return promql.Sample{
+			Metric: labels.FromStrings(labels.MetricName, "foo",
+				"instance", instance, name, value),
+			Point: promql.Point{V: v},
+		}
+	}
+
+	vals := []parser.Value{promql.Vector{
+		newSample("a", labels.BucketLabel, "1", 1),
+		newSample("a", labels.BucketLabel, "+Inf", 2),
+		newSample("a", nativeHistogramLabel, "count", 2),
+		newSample("a", nativeHistogramLabel, "sum", 3),
+		newSample("b", nativeHistogramLabel, "count", 4),
+		newSample("b", nativeHistogramLabel, "sum", 5),
+		// Classic histograms are ignored, including a bucket named sum.
+		newSample("c", labels.BucketLabel, "+Inf", 6),
+		newSample("c", labels.BucketLabel, "sum", 7),
+		{Metric: labels.FromStrings(labels.MetricName, "foo"), Point: promql.Point{V: 8}},
+	}}
+
+	assert.Equal(t, promql.Vector{
+		{Metric: labels.FromStrings("instance", "a"), Point: promql.Point{V: 2}},
+		{Metric: labels.FromStrings("instance", "b"), Point: promql.Point{V: 4}},
+	}, funcHistogramCount(vals, nil, &promql.EvalNodeHelper{}))
+
+	assert.Equal(t, promql.Vector{
+		{Metric: labels.FromStrings("instance", "a"), Point: promql.Point{V: 3}},
+		{Metric: labels.FromStrings("instance", "b"), Point: promql.Point{V: 5}},
+	}, funcHistogramSum(vals, nil, &promql.EvalNodeHelper{}))
+}
+
+func TestRegisterFunctions(t *testing.T) {
+	RegisterFunctions()
+	RegisterFunctions()
+	assert.NotNil(t, promql.FunctionCalls[linear.HistogramCountType])
+	assert.NotNil(t, promql.FunctionCalls[linear.HistogramSumType])
+}
@@ -136,6 +136,7 @@ func (q *querier) Select(
 		q.logger.Error("fetch options not provided in context", zap.Error(err))
 		return promstorage.ErrSeriesSet(err)
 	}
+	fetchOptions = downsampleFetchOptions(fetchOptions, hints)
 
 	result, err := q.storage.FetchProm(q.ctx, query, fetchOptions)
 	if err != nil {
@@ -161,6 +162,30 @@ func (q *querier) Select(
 	return seriesSet
 }
 
+// downsampleFetchOptions only keeps the downsampling requested by the handler
+// for instant vector selectors evaluated at multiples of the downsample step,
+// for those keeping the last datapoint of each step window returns the same
+// samples as reading every datapoint.
+func downsampleFetchOptions(
+	fetchOptions *storage.FetchOptions,
+	hints *promstorage.SelectHints,
+) *storage.FetchOptions {
+	downsample := fetchOptions.Downsample
+	if downsample == nil {
+		return fetchOptions
+	}
+
+	step := downsample.Step.Milliseconds()
+	if hints != nil && hints.Range == 0 && hints.Step == step && step > 0 &&
+		hints.End%step == 0 {
+		return fetchOptions
+	}
+
+	fetchOptions = fetchOptions.Clone()
+	fetchOptions.Downsample = nil
+	return fetchOptions
+}
+
 func (q *querier) LabelValues(string, ...*labels.Matcher) ([]string, promstorage.Warnings, error) {
 	// TODO (@shreyas): Implement this.
 	q.logger.Warn("calling unsupported LabelValues method")
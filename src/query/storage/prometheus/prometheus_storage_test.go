@@ -32,6 +32,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/generated/proto/prompb"
 	"github.com/m3db/m3/src/query/models"
@@ -162,3 +163,59 @@ func TestSelectWithMetaInContext(t *testing.T) {
 	// NB: assert warnings on context were propagated.
 	assert.Equal(t, []string{"warn_warning"}, res.WarningStrings())
 }
+
+func TestDownsampleFetchOptions(t *testing.T) {
+	step := time.Minute
+	end := time.Now().Truncate(time.Hour).UnixNano() / int64(time.Millisecond)
+	fetchOptions := storage.NewFetchOptions()
+	fetchOptions.Downsample = &storage.DownsampleOptions{
+		Step: step,
+		Type: encoding.DownsampleLast,
+	}
+
+	tests := []struct {
+		name     string
+		hints    *promstorage.SelectHints
+		expected bool
+	}{
+		{
+			name:     "instant vector selector",
+			hints:    &promstorage.SelectHints{End: end, Step: step.Milliseconds()},
+			expected: true,
+		},
+		{
+			name: "matrix selector",
+			hints: &promstorage.SelectHints{
+				End:   end,
+				Step:  step.Milliseconds(),
+				Range: (5 * time.Minute).Milliseconds(),
+			},
+		},
+		{
+			name:  "subquery step",
+			hints: &promstorage.SelectHints{End: end, Step: (30 * time.Second).Milliseconds()},
+		},
+		{
+			name:  "misaligned offset",
+			hints: &promstorage.SelectHints{End: end - 1000, Step: step.Milliseconds()},
+		},
+		{
+			name: "no hints",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := downsampleFetchOptions(fetchOptions, tt.hints)
+			if tt.expected {
+				assert.Equal(t, fetchOptions.Downsample, result.Downsample)
+			} else {
+				assert.Nil(t, result.Downsample)
+			}
+			require.NotNil(t, fetchOptions.Downsample)
+		})
+	}
+
+	result := downsampleFetchOptions(storage.NewFetchOptions(),
+		&promstorage.SelectHints{End: end, Step: step.Milliseconds()})
+	assert.Nil(t, result.Downsample)
+}
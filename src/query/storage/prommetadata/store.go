@@ -0,0 +1,530 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package prommetadata
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+
+	clusterclient "github.com/m3db/m3/src/cluster/client"
+	"github.com/m3db/m3/src/cluster/kv"
+	"github.com/m3db/m3/src/query/generated/proto/metadatapb"
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+const (
+	defaultKeyPrefix          = "_m3query/metadata"
+	defaultNumShards          = 16
+	defaultSyncInterval       = 10 * time.Second
+	defaultMaxMetricsPerShard = 512
+	defaultRetention          = 24 * time.Hour
+
+	// maxMetricNameLength, maxHelpLength and maxUnitLength bound the size of
+	// the metadata of a metric, so that a shard with the default maximum
+	// number of metrics stays well below the etcd request size limit. Longer
+	// help and unit are truncated while metrics with longer names are dropped.
+	maxMetricNameLength = 256
+	maxHelpLength       = 512
+	maxUnitLength       = 64
+
+	// maxCheckAndSetAttempts is the number of times a shard update is retried
+	// when another coordinator updated the shard concurrently.
+	maxCheckAndSetAttempts = 3
+)
+
+var errClusterClientNotSet = errors.New("metadata store cluster client not set")
+
+// StoreOptions are the options for a KV backed metadata store.
+type StoreOptions struct {
+	// ClusterClient is the cluster client used to reach the KV store.
+	ClusterClient clusterclient.Client
+	// KeyPrefix is the prefix of the KV keys metadata is stored under.
+	KeyPrefix string
+	// NumShards is the number of KV keys metadata is spread across.
+	NumShards int
+	// MaxMetricsPerShard is the maximum number of metrics stored under a KV
+	// key, the least recently written metrics are evicted from full keys.
+	MaxMetricsPerShard int
+	// Retention is how long the metadata of a metric is kept once it is no
+	// longer written.
+	Retention time.Duration
+	// SyncInterval is how often changed metadata is written to the KV store
+	// and the metadata written by other coordinators is read back.
+	SyncInterval time.Duration
+	// NowFn returns the current time, defaults to time.Now.
+	NowFn clock.NowFn
+	// InstrumentOptions are the instrument options.
+	InstrumentOptions instrument.Options
+}
+
+type storeMetrics struct {
+	syncSuccess tally.Counter
+	syncErrors  tally.Counter
+	metrics     tally.Gauge
+	dropped     tally.Counter
+	expired     tally.Counter
+	evicted     tally.Counter
+}
+
+func newStoreMetrics(scope tally.Scope) storeMetrics {
+	return storeMetrics{
+		syncSuccess: scope.Counter("sync-success"),
+		syncErrors:  scope.Counter("sync-errors"),
+		metrics:     scope.Gauge("metrics"),
+		dropped:     scope.Counter("dropped"),
+		expired:     scope.Counter("expired"),
+		evicted:     scope.Counter("evicted"),
+	}
+}
+
+// entry is the metadata of a metric and the last time, in seconds since the
+// epoch, it was written by any coordinator.
+type entry struct {
+	metadata    Metadata
+	lastWritten int64
+}
+
+type kvStore struct {
+	sync.RWMutex
+
+	client             clusterclient.Client
+	keyPrefix          string
+	numShards          int
+	maxMetricsPerShard int
+	retention          time.Duration
+	syncInterval       time.Duration
+	nowFn              clock.NowFn
+	logger             *zap.Logger
+	metrics            storeMetrics
+
+	// metadata is the metadata of all metrics, pending holds the metadata
+	// written since the last sync.
+	metadata map[string]entry
+	pending  map[string]entry
+
+	closeCh chan struct{}
+	doneCh  chan struct{}
+}
+
+// NewStore returns a metadata store that keeps metadata in memory and
+// periodically syncs it with the cluster KV store, so that metadata written
+// through any coordinator can be queried from all of them.
+func NewStore(opts StoreOptions) (Store, error) {
+	if opts.ClusterClient == nil {
+		return nil, errClusterClientNotSet
+	}
+
+	keyPrefix := opts.KeyPrefix
+	if keyPrefix == "" {
+		keyPrefix = defaultKeyPrefix
+	}
+	numShards := opts.NumShards
+	if numShards <= 0 {
+		numShards = defaultNumShards
+	}
+	maxMetricsPerShard := opts.MaxMetricsPerShard
+	if maxMetricsPerShard <= 0 {
+		maxMetricsPerShard = defaultMaxMetricsPerShard
+	}
+	retention := opts.Retention
+	if retention <= 0 {
+		retention = defaultRetention
+	}
+	syncInterval := opts.SyncInterval
+	if syncInterval <= 0 {
+		syncInterval = defaultSyncInterval
+	}
+	nowFn := opts.NowFn
+	if nowFn == nil {
+		nowFn = time.Now
+	}
+	iOpts := opts.InstrumentOptions
+	if iOpts == nil {
+		iOpts = instrument.NewOptions()
+	}
+
+	s := &kvStore{
+		client:             opts.ClusterClient,
+		keyPrefix:          keyPrefix,
+		numShards:          numShards,
+		maxMetricsPerShard: maxMetricsPerShard,
+		retention:          retention,
+		syncInterval:       syncInterval,
+		nowFn:              nowFn,
+		logger:             iOpts.Logger(),
+		metrics:            newStoreMetrics(iOpts.MetricsScope().SubScope("prom-metadata")),
+		metadata:           make(map[string]entry),
+		pending:            make(map[string]entry),
+		closeCh:            make(chan struct{}),
+		doneCh:             make(chan struct{}),
+	}
+	go s.syncLoop()
+	return s, nil
+}
+
+func (s *kvStore) Write(metadata []Metadata) {
+	now := s.nowFn().Unix()
+	if !s.changed(metadata, now) {
+		return
+	}
+
+	s.Lock()
+	for _, m := range metadata {
+		m, ok := sanitize(m)
+		if !ok {
+			s.metrics.dropped.Inc(1)
+			continue
+		}
+		existing, ok := s.metadata[m.Metric]
+		if ok && !s.needsWrite(existing, m, now) {
+			continue
+		}
+		if _, ok := s.pending[m.Metric]; !ok &&
+			len(s.pending) >= s.numShards*s.maxMetricsPerShard {
+			// NB: the number of metrics pending is bounded too so that the
+			// metrics in memory are at most twice the metrics in the KV store.
+			s.metrics.dropped.Inc(1)
+			continue
+		}
+		e := entry{metadata: m, lastWritten: now}
+		s.metadata[m.Metric] = e
+		s.pending[m.Metric] = e
+	}
+	s.Unlock()
+}
+
+// changed returns true if any of the metadata differs from the stored
+// metadata or needs to be written again to not expire, since metadata rarely
+// changes this avoids taking the write lock for most writes.
+func (s *kvStore) changed(metadata []Metadata, now int64) bool {
+	s.RLock()
+	defer s.RUnlock()
+	for _, m := range metadata {
+		m, ok := sanitize(m)
+		if !ok {
+			continue
+		}
+		if existing, ok := s.metadata[m.Metric]; !ok || s.needsWrite(existing, m, now) {
+			return true
+		}
+	}
+	return false
+}
+
+// needsWrite returns true if the metadata changed or if it was last written
+// long enough ago that it must be written again to not expire.
+func (s *kvStore) needsWrite(existing entry, m Metadata, now int64) bool {
+	refreshAfter := int64(s.retention / time.Second / 4)
+	return existing.metadata != m || now-existing.lastWritten >= refreshAfter
+}
+
+// sanitize truncates the help and unit of the metadata, it returns false if
+// the metadata has no metric name or a metric name that is too long.
+func sanitize(m Metadata) (Metadata, bool) {
+	if m.Metric == "" || len(m.Metric) > maxMetricNameLength {
+		return Metadata{}, false
+	}
+	m.Help = truncate(m.Help, maxHelpLength)
+	m.Unit = truncate(m.Unit, maxUnitLength)
+	return m, true
+}
+
+// truncate truncates a string to at most n bytes without splitting a rune.
+func truncate(str string, n int) string {
+	if len(str) <= n {
+		return str
+	}
+	for n > 0 && !utf8.RuneStart(str[n]) {
+		n--
+	}
+	return str[:n]
+}
+
+func (s *kvStore) Query(metric string) []Metadata {
+	s.RLock()
+	defer s.RUnlock()
+
+	if metric != "" {
+		e, ok := s.metadata[metric]
+		if !ok {
+			return nil
+		}
+		return []Metadata{e.metadata}
+	}
+
+	result := make([]Metadata, 0, len(s.metadata))
+	for _, e := range s.metadata {
+		result = append(result, e.metadata)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Metric < result[j].Metric
+	})
+	return result
+}
+
+func (s *kvStore) Close() error {
+	close(s.closeCh)
+	<-s.doneCh
+	return nil
+}
+
+func (s *kvStore) syncLoop() {
+	defer close(s.doneCh)
+
+	ticker := time.NewTicker(s.syncInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.sync(); err != nil {
+			s.metrics.syncErrors.Inc(1)
+			s.logger.Warn("unable to sync prometheus metadata", zap.Error(err))
+		} else {
+			s.metrics.syncSuccess.Inc(1)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-s.closeCh:
+			// Persist any metadata written since the last sync.
+			if err := s.sync(); err != nil {
+				s.logger.Warn("unable to sync prometheus metadata", zap.Error(err))
+			}
+			return
+		}
+	}
+}
+
+// sync writes the pending metadata to the KV store, expiring and evicting
+// metadata from the shards as needed, and reloads the metadata of all
+// metrics from it.
+func (s *kvStore) sync() error {
+	store, err := s.client.KV()
+	if err != nil {
+		return err
+	}
+
+	s.Lock()
+	pending := s.pending
+	s.pending = make(map[string]entry)
+	s.Unlock()
+
+	pendingByShard := make(map[int][]entry)
+	for _, e := range pending {
+		shard := s.shard(e.metadata.Metric)
+		pendingByShard[shard] = append(pendingByShard[shard], e)
+	}
+
+	var (
+		expireBefore = s.nowFn().Add(-s.retention).Unix()
+		loaded       = make(map[string]entry)
+		firstErr     error
+	)
+	for shard := 0; shard < s.numShards; shard++ {
+		set, err := s.syncShard(store, shard, pendingByShard[shard], expireBefore)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			// Retry the shard updates on the next sync.
+			s.Lock()
+			for _, e := range pendingByShard[shard] {
+				if _, ok := s.pending[e.metadata.Metric]; !ok {
+					s.pending[e.metadata.Metric] = e
+				}
+			}
+			s.Unlock()
+			continue
+		}
+
+		for _, m := range set.Metadata {
+			loaded[m.MetricFamilyName] = entry{
+				metadata: Metadata{
+					Metric: m.MetricFamilyName,
+					Type:   m.Type,
+					Help:   m.Help,
+					Unit:   m.Unit,
+				},
+				lastWritten: m.LastWrittenUnixSec,
+			}
+		}
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	if firstErr != nil {
+		// Only add metadata, so metadata of shards that failed to load is kept.
+		for metric, e := range loaded {
+			if _, ok := s.pending[metric]; !ok {
+				s.metadata[metric] = e
+			}
+		}
+		return firstErr
+	}
+
+	// Metadata written during the sync is not in the KV store yet.
+	for metric, e := range s.pending {
+		loaded[metric] = e
+	}
+	s.metadata = loaded
+	s.metrics.metrics.Update(float64(len(loaded)))
+	return nil
+}
+
+// syncShard merges the updates into the shard metadata set and returns the
+// resulting set.
+func (s *kvStore) syncShard(
+	store kv.Store,
+	shard int,
+	updates []entry,
+	expireBefore int64,
+) (*metadatapb.MetricMetadataSet, error) {
+	key := s.shardKey(shard)
+	for attempt := 0; ; attempt++ {
+		set, version, err := getShard(store, key)
+		if err != nil {
+			return nil, err
+		}
+		result := mergeShard(set, updates, expireBefore, s.maxMetricsPerShard)
+		if !result.changed {
+			return set, nil
+		}
+
+		_, err = store.CheckAndSet(key, version, set)
+		if err == nil {
+			s.metrics.expired.Inc(int64(result.expired))
+			s.metrics.evicted.Inc(int64(result.evicted))
+			return set, nil
+		}
+		if !errors.Is(err, kv.ErrVersionMismatch) || attempt+1 >= maxCheckAndSetAttempts {
+			return nil, fmt.Errorf("unable to update metadata key %s: %w", key, err)
+		}
+	}
+}
+
+func getShard(store kv.Store, key string) (*metadatapb.MetricMetadataSet, int, error) {
+	set := &metadatapb.MetricMetadataSet{}
+	value, err := store.Get(key)
+	if errors.Is(err, kv.ErrNotFound) {
+		return set, kv.UninitializedVersion, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := value.Unmarshal(set); err != nil {
+		return nil, 0, err
+	}
+	return set, value.Version(), nil
+}
+
+type mergeResult struct {
+	changed bool
+	expired int
+	evicted int
+}
+
+// mergeShard merges the updates into the set, removes the metadata last
+// written before expireBefore and evicts the least recently written metadata
+// above maxMetrics.
+func mergeShard(
+	set *metadatapb.MetricMetadataSet,
+	updates []entry,
+	expireBefore int64,
+	maxMetrics int,
+) mergeResult {
+	var result mergeResult
+
+	indexes := make(map[string]int, len(set.Metadata))
+	for i, m := range set.Metadata {
+		indexes[m.MetricFamilyName] = i
+	}
+
+	for _, update := range updates {
+		m := metadatapb.MetricMetadata{
+			MetricFamilyName:   update.metadata.Metric,
+			Type:               update.metadata.Type,
+			Help:               update.metadata.Help,
+			Unit:               update.metadata.Unit,
+			LastWrittenUnixSec: update.lastWritten,
+		}
+		i, ok := indexes[update.metadata.Metric]
+		if !ok {
+			indexes[update.metadata.Metric] = len(set.Metadata)
+			set.Metadata = append(set.Metadata, m)
+			result.changed = true
+			continue
+		}
+		// NB: another coordinator wrote the metadata more recently.
+		if set.Metadata[i].LastWrittenUnixSec > m.LastWrittenUnixSec {
+			continue
+		}
+		if set.Metadata[i] != m {
+			set.Metadata[i] = m
+			result.changed = true
+		}
+	}
+
+	kept := set.Metadata[:0]
+	for _, m := range set.Metadata {
+		if m.LastWrittenUnixSec < expireBefore {
+			result.expired++
+			continue
+		}
+		kept = append(kept, m)
+	}
+	set.Metadata = kept
+
+	if len(set.Metadata) > maxMetrics {
+		sort.Slice(set.Metadata, func(i, j int) bool {
+			return set.Metadata[i].LastWrittenUnixSec > set.Metadata[j].LastWrittenUnixSec
+		})
+		result.evicted = len(set.Metadata) - maxMetrics
+		set.Metadata = set.Metadata[:maxMetrics]
+	}
+
+	if result.expired > 0 || result.evicted > 0 {
+		result.changed = true
+	}
+	if result.changed {
+		sort.Slice(set.Metadata, func(i, j int) bool {
+			return set.Metadata[i].MetricFamilyName < set.Metadata[j].MetricFamilyName
+		})
+	}
+	return result
+}
+
+func (s *kvStore) shard(metric string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(metric))
+	return int(h.Sum32() % uint32(s.numShards))
+}
+
+func (s *kvStore) shardKey(shard int) string {
+	return fmt.Sprintf("%s/%d", s.keyPrefix, shard)
+}
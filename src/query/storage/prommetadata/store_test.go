@@ -0,0 +1,240 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package prommetadata
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+
+	clusterclient "github.com/m3db/m3/src/cluster/client"
+	"github.com/m3db/m3/src/cluster/kv"
+	memcluster "github.com/m3db/m3/src/cluster/mem"
+	"github.com/m3db/m3/src/query/generated/proto/metadatapb"
+	"github.com/m3db/m3/src/query/generated/proto/prompb"
+)
+
+var testNow = time.Unix(1700000000, 0)
+
+func newTestStore(t *testing.T, client clusterclient.Client) *kvStore {
+	return newTestStoreWithOptions(t, StoreOptions{
+		ClusterClient: client,
+		NumShards:     4,
+		NowFn:         func() time.Time { return testNow },
+	})
+}
+
+func newTestStoreWithOptions(t *testing.T, opts StoreOptions) *kvStore {
+	opts.SyncInterval = time.Hour
+	store, err := NewStore(opts)
+	require.NoError(t, err)
+	return store.(*kvStore)
+}
+
+func TestStoreWriteQuery(t *testing.T) {
+	store := newTestStore(t, memcluster.New(kv.NewOverrideOptions()))
+	defer store.Close()
+
+	store.Write([]Metadata{
+		{Metric: "requests_total", Type: TypeCounter, Help: "Requests."},
+		{Metric: "latency_seconds", Type: TypeHistogram, Unit: "seconds"},
+		{Metric: "", Type: TypeGauge},
+	})
+
+	assert.Equal(t, []Metadata{
+		{Metric: "latency_seconds", Type: TypeHistogram, Unit: "seconds"},
+		{Metric: "requests_total", Type: TypeCounter, Help: "Requests."},
+	}, store.Query(""))
+	assert.Equal(t, []Metadata{
+		{Metric: "requests_total", Type: TypeCounter, Help: "Requests."},
+	}, store.Query("requests_total"))
+	assert.Empty(t, store.Query("unknown"))
+}
+
+func TestStoreSyncBetweenStores(t *testing.T) {
+	client := memcluster.New(kv.NewOverrideOptions())
+	first := newTestStore(t, client)
+	defer first.Close()
+	second := newTestStore(t, client)
+	defer second.Close()
+
+	first.Write([]Metadata{{Metric: "requests_total", Type: TypeCounter}})
+	second.Write([]Metadata{{Metric: "memory_bytes", Type: TypeGauge}})
+	require.NoError(t, first.sync())
+	require.NoError(t, second.sync())
+	require.NoError(t, first.sync())
+
+	expected := []Metadata{
+		{Metric: "memory_bytes", Type: TypeGauge},
+		{Metric: "requests_total", Type: TypeCounter},
+	}
+	assert.Equal(t, expected, first.Query(""))
+	assert.Equal(t, expected, second.Query(""))
+
+	// Updated metadata replaces the existing metadata.
+	second.Write([]Metadata{{Metric: "requests_total", Type: TypeCounter, Help: "Requests."}})
+	require.NoError(t, second.sync())
+	require.NoError(t, first.sync())
+	assert.Equal(t, []Metadata{
+		{Metric: "requests_total", Type: TypeCounter, Help: "Requests."},
+	}, first.Query("requests_total"))
+
+	// Metadata is spread across the shard keys.
+	kvStore, err := client.KV()
+	require.NoError(t, err)
+	var numMetrics int
+	for shard := 0; shard < first.numShards; shard++ {
+		set, _, err := getShard(kvStore, first.shardKey(shard))
+		require.NoError(t, err)
+		numMetrics += len(set.Metadata)
+	}
+	assert.Equal(t, 2, numMetrics)
+}
+
+func TestStoreCloseSyncsPending(t *testing.T) {
+	client := memcluster.New(kv.NewOverrideOptions())
+	store := newTestStore(t, client)
+	store.Write([]Metadata{{Metric: "requests_total", Type: TypeCounter}})
+	require.NoError(t, store.Close())
+
+	kvStore, err := client.KV()
+	require.NoError(t, err)
+	set, _, err := getShard(kvStore, store.shardKey(store.shard("requests_total")))
+	require.NoError(t, err)
+	assert.Equal(t, []metadatapb.MetricMetadata{
+		{MetricFamilyName: "requests_total", Type: TypeCounter, LastWrittenUnixSec: testNow.Unix()},
+	}, set.Metadata)
+}
+
+func TestStoreTruncatesMetadata(t *testing.T) {
+	store := newTestStore(t, memcluster.New(kv.NewOverrideOptions()))
+	defer store.Close()
+
+	store.Write([]Metadata{
+		{Metric: "requests_total", Help: strings.Repeat("h", maxHelpLength+1),
+			Unit: strings.Repeat("u", maxUnitLength+1)},
+		// Help is not truncated in the middle of a rune.
+		{Metric: "latency_seconds", Help: strings.Repeat("h", maxHelpLength-1) + "é"},
+		// Metrics with too long names are dropped.
+		{Metric: strings.Repeat("m", maxMetricNameLength+1)},
+	})
+
+	assert.Equal(t, []Metadata{
+		{Metric: "latency_seconds", Help: strings.Repeat("h", maxHelpLength-1)},
+		{Metric: "requests_total", Help: strings.Repeat("h", maxHelpLength),
+			Unit: strings.Repeat("u", maxUnitLength)},
+	}, store.Query(""))
+}
+
+func TestStoreExpiresMetadata(t *testing.T) {
+	var (
+		client = memcluster.New(kv.NewOverrideOptions())
+		now    = atomic.NewInt64(testNow.Unix())
+		opts   = StoreOptions{
+			ClusterClient: client,
+			NumShards:     1,
+			Retention:     4 * time.Hour,
+			NowFn:         func() time.Time { return time.Unix(now.Load(), 0) },
+		}
+		first  = newTestStoreWithOptions(t, opts)
+		second = newTestStoreWithOptions(t, opts)
+	)
+	defer first.Close()
+	defer second.Close()
+
+	first.Write([]Metadata{
+		{Metric: "requests_total", Type: TypeCounter},
+		{Metric: "memory_bytes", Type: TypeGauge},
+	})
+	require.NoError(t, first.sync())
+
+	// Unchanged metadata is written again once a quarter of the retention
+	// passed, from any coordinator, so that it does not expire.
+	now.Add(int64(time.Hour / time.Second))
+	require.NoError(t, second.sync())
+	second.Write([]Metadata{{Metric: "requests_total", Type: TypeCounter}})
+	require.NoError(t, second.sync())
+
+	now.Add(int64(3*time.Hour/time.Second + 1))
+	require.NoError(t, first.sync())
+	assert.Equal(t, []Metadata{
+		{Metric: "requests_total", Type: TypeCounter},
+	}, first.Query(""))
+}
+
+func TestStoreEvictsLeastRecentlyWrittenMetadata(t *testing.T) {
+	var (
+		client = memcluster.New(kv.NewOverrideOptions())
+		now    = atomic.NewInt64(testNow.Unix())
+		store  = newTestStoreWithOptions(t, StoreOptions{
+			ClusterClient:      client,
+			NumShards:          1,
+			MaxMetricsPerShard: 2,
+			NowFn:              func() time.Time { return time.Unix(now.Load(), 0) },
+		})
+	)
+	defer store.Close()
+
+	for _, metric := range []string{"a", "b", "c"} {
+		store.Write([]Metadata{{Metric: metric, Type: TypeGauge}})
+		require.NoError(t, store.sync())
+		now.Inc()
+	}
+	assert.Equal(t, []Metadata{
+		{Metric: "b", Type: TypeGauge},
+		{Metric: "c", Type: TypeGauge},
+	}, store.Query(""))
+
+	kvStore, err := client.KV()
+	require.NoError(t, err)
+	set, _, err := getShard(kvStore, store.shardKey(0))
+	require.NoError(t, err)
+	assert.Len(t, set.Metadata, 2)
+
+	// The metrics pending a sync are bounded too.
+	store.Write([]Metadata{
+		{Metric: "d", Type: TypeGauge},
+		{Metric: "e", Type: TypeGauge},
+		{Metric: "f", Type: TypeGauge},
+	})
+	store.RLock()
+	assert.Len(t, store.pending, 2)
+	store.RUnlock()
+}
+
+func TestFromProm(t *testing.T) {
+	assert.Equal(t, Metadata{
+		Metric: "latency_seconds",
+		Type:   TypeGaugeHistogram,
+		Help:   "Latency.",
+		Unit:   "seconds",
+	}, FromProm(prompb.MetricMetadata{
+		Type:             prompb.MetricType_GAUGE_HISTOGRAM,
+		MetricFamilyName: "latency_seconds",
+		Help:             "Latency.",
+		Unit:             "seconds",
+	}))
+	assert.Equal(t, TypeUnknown, TypeFromProm(prompb.MetricType(100)))
+}
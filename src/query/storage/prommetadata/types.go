@@ -0,0 +1,98 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package prommetadata stores Prometheus metric metadata, the type, help and
+// unit of each metric family, received with remote write.
+package prommetadata
+
+import (
+	"github.com/m3db/m3/src/query/generated/proto/prompb"
+)
+
+// Prometheus metric types as returned by the Prometheus metadata API.
+const (
+	TypeUnknown        = "unknown"
+	TypeCounter        = "counter"
+	TypeGauge          = "gauge"
+	TypeHistogram      = "histogram"
+	TypeGaugeHistogram = "gaugehistogram"
+	TypeSummary        = "summary"
+	TypeInfo           = "info"
+	TypeStateset       = "stateset"
+)
+
+// Metadata is the metadata of a metric family.
+type Metadata struct {
+	// Metric is the metric family name.
+	Metric string
+	// Type is the Prometheus metric type.
+	Type string
+	// Help is the metric help text.
+	Help string
+	// Unit is the metric unit.
+	Unit string
+}
+
+// Store stores metric metadata.
+type Store interface {
+	// Write records metric metadata, metadata that changed is persisted
+	// asynchronously.
+	Write(metadata []Metadata)
+
+	// Query returns the metadata of all metrics sorted by metric name, or only
+	// the metadata of the given metric if not empty.
+	Query(metric string) []Metadata
+
+	// Close stops persisting metadata.
+	Close() error
+}
+
+// TypeFromProm returns the Prometheus metric type of a remote write metric
+// type.
+func TypeFromProm(t prompb.MetricType) string {
+	switch t {
+	case prompb.MetricType_COUNTER:
+		return TypeCounter
+	case prompb.MetricType_GAUGE:
+		return TypeGauge
+	case prompb.MetricType_HISTOGRAM:
+		return TypeHistogram
+	case prompb.MetricType_GAUGE_HISTOGRAM:
+		return TypeGaugeHistogram
+	case prompb.MetricType_SUMMARY:
+		return TypeSummary
+	case prompb.MetricType_INFO:
+		return TypeInfo
+	case prompb.MetricType_STATESET:
+		return TypeStateset
+	default:
+		return TypeUnknown
+	}
+}
+
+// FromProm returns the metadata of remote write metric metadata.
+func FromProm(metadata prompb.MetricMetadata) Metadata {
+	return Metadata{
+		Metric: metadata.MetricFamilyName,
+		Type:   TypeFromProm(metadata.Type),
+		Help:   metadata.Help,
+		Unit:   metadata.Unit,
+	}
+}
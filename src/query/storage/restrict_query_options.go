@@ -69,6 +69,16 @@ func (o *RestrictQueryOptions) GetRestrictByTag() *RestrictByTag {
 	return o.RestrictByTag
 }
 
+// GetRestrictByTenant provides the tenant restrictions if present; nil
+// otherwise.
+func (o *RestrictQueryOptions) GetRestrictByTenant() *RestrictByTenant {
+	if o == nil {
+		return nil
+	}
+
+	return o.RestrictByTenant
+}
+
 // GetMatchers provides the tag matchers by which results are restricted if
 // present; nil otherwise.
 func (o *RestrictByTag) GetMatchers() models.Matchers {
@@ -149,7 +159,7 @@ func (q *FetchQuery) WithAppliedOptions(
 	// Since must apply matchers will always be small (usually 1)
 	// it's better to not allocate intermediate datastructure and just
 	// perform n^2 matching.
-	existing := result.TagMatchers
+	existing := make(models.Matchers, 0, len(result.TagMatchers)+len(restrict))
 	for _, existingMatcher := range result.TagMatchers {
 		willBeOverridden := false
 		for _, matcher := range restrict {
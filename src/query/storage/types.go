@@ -151,10 +151,23 @@ type FetchOptions struct {
 	IterateEqualTimestampStrategy *encoding.IterateEqualTimestampStrategy
 	// Source is the source for the query.
 	Source []byte
+	// Downsample if set asks the database to consolidate the datapoints of
+	// each step window into a single datapoint before returning them.
+	Downsample *DownsampleOptions
 
 	RelatedQueryOptions *RelatedQueryOptions
 }
 
+// DownsampleOptions describes how the database consolidates the datapoints
+// of each step window of a fetch, the windows are aligned to the Unix epoch
+// and include their end.
+type DownsampleOptions struct {
+	// Step is the size of the step windows.
+	Step time.Duration
+	// Type is how the datapoints of each step window are consolidated.
+	Type encoding.DownsampleType
+}
+
 // QueryTimespan represents the start and end time of a query
 type QueryTimespan struct {
 	Start xtime.UnixNano
@@ -216,6 +229,12 @@ type RestrictByTag struct {
 	Strip [][]byte
 }
 
+// RestrictByTenant restricts a query to the namespaces of a tenant.
+type RestrictByTenant struct {
+	// Tenant is the name of the tenant.
+	Tenant string
+}
+
 // RestrictQueryOptions restricts the query to a specific set of conditions.
 type RestrictQueryOptions struct {
 	// RestrictByType are specific restrictions to stick to a single data type.
@@ -226,6 +245,8 @@ type RestrictQueryOptions struct {
 	// RestrictByTypes are specific restrictions to query from specified data
 	// types.
 	RestrictByTypes []*RestrictByType
+	// RestrictByTenant restricts the query to the namespaces of a tenant.
+	RestrictByTenant *RestrictByTenant
 }
 
 // Querier handles queries against a storage.
@@ -210,6 +210,37 @@ func (s *AsyncSession) Aggregate(
 	return s.session.Aggregate(ctx, namespace, q, opts)
 }
 
+// Cardinality computes the cardinality of the series indexed by a namespace.
+func (s *AsyncSession) Cardinality(
+	ctx context.Context,
+	namespace ident.ID,
+	opts index.CardinalityOptions,
+) (index.CardinalityResult, error) {
+	s.RLock()
+	defer s.RUnlock()
+	if s.err != nil {
+		return index.CardinalityResult{}, s.err
+	}
+
+	return s.session.Cardinality(ctx, namespace, opts)
+}
+
+// DeleteSeries deletes the datapoints within the range of the series matching the query.
+func (s *AsyncSession) DeleteSeries(
+	ctx context.Context,
+	namespace ident.ID,
+	q index.Query,
+	start, end xtime.UnixNano,
+) (int64, error) {
+	s.RLock()
+	defer s.RUnlock()
+	if s.err != nil {
+		return 0, s.err
+	}
+
+	return s.session.DeleteSeries(ctx, namespace, q, start, end)
+}
+
 // ShardID returns the given shard for an ID for callers
 // to easily discern what shard is failing when operations
 // for given IDs begin failing.
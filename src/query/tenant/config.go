@@ -0,0 +1,168 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package tenant
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/storage/limits"
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+const (
+	fetchedSeriesLimitName     = "fetched-series"
+	writtenDatapointsLimitName = "written-datapoints"
+)
+
+var errNoTenants = errors.New("no tenants configured")
+
+// Configuration is the configuration of the tenants of a coordinator.
+type Configuration struct {
+	// TagName is the name of the tag identifying the tenant of a series,
+	// defaults to "tenant".
+	TagName string `yaml:"tagName"`
+
+	// Required rejects the reads and writes that do not specify a tenant.
+	Required bool `yaml:"required"`
+
+	// Tenants are the tenants, the reads and writes of other tenants
+	// are rejected.
+	Tenants []TenantConfiguration `yaml:"tenants"`
+}
+
+// TenantConfiguration is the configuration of a tenant.
+type TenantConfiguration struct {
+	// Name is the name of the tenant.
+	Name string `yaml:"name" validate:"nonzero"`
+
+	// Namespaces are the names of the namespaces the tenant reads and
+	// writes, if empty the tenant reads and writes all namespaces.
+	Namespaces []string `yaml:"namespaces"`
+
+	// Limits are the limits of the tenant.
+	Limits LimitsConfiguration `yaml:"limits"`
+}
+
+// LimitsConfiguration is the configuration of the limits of a tenant.
+type LimitsConfiguration struct {
+	// MaxFetchedSeries limits the number of series fetched by the queries
+	// of the tenant within a lookback period.
+	MaxFetchedSeries *LookbackLimitConfiguration `yaml:"maxFetchedSeries"`
+
+	// MaxWrittenDatapoints limits the number of datapoints written by the
+	// tenant within a lookback period.
+	MaxWrittenDatapoints *LookbackLimitConfiguration `yaml:"maxWrittenDatapoints"`
+}
+
+// LookbackLimitConfiguration is the configuration of a limit enforced
+// within a lookback period.
+type LookbackLimitConfiguration struct {
+	// Value sets the max value for the limit.
+	Value int64 `yaml:"value" validate:"min=0"`
+	// Lookback is the period in which the limit is enforced.
+	Lookback time.Duration `yaml:"lookback" validate:"min=0"`
+}
+
+func (c *LookbackLimitConfiguration) options() (limits.LookbackLimitOptions, error) {
+	opts := limits.DefaultLookbackLimitOptions()
+	if c == nil {
+		return opts, nil
+	}
+	if c.Value < 0 {
+		return opts, fmt.Errorf("limit value must be >= 0: %d", c.Value)
+	}
+	if c.Lookback <= 0 {
+		return opts, fmt.Errorf("limit lookback must be > 0: %s", c.Lookback)
+	}
+	opts.Limit = c.Value
+	opts.Lookback = c.Lookback
+	return opts, nil
+}
+
+// NewTenants creates the tenants from the configuration and starts the
+// background resetting of the limits of the tenants.
+func (c Configuration) NewTenants(iOpts instrument.Options) (Tenants, error) {
+	if len(c.Tenants) == 0 {
+		return nil, errNoTenants
+	}
+
+	tagName := c.TagName
+	if tagName == "" {
+		tagName = DefaultTagName
+	}
+
+	var (
+		sourceLoggerBuilder = limits.NewOptions().SourceLoggerBuilder()
+		scope               = iOpts.MetricsScope().SubScope("tenant")
+		result              = make(map[string]*Tenant, len(c.Tenants))
+	)
+	for _, cfg := range c.Tenants {
+		if cfg.Name == "" {
+			return nil, errors.New("tenant name must be set")
+		}
+		if _, ok := result[cfg.Name]; ok {
+			return nil, fmt.Errorf("duplicate tenant: %s", cfg.Name)
+		}
+
+		queryLimitOpts, err := cfg.Limits.MaxFetchedSeries.options()
+		if err != nil {
+			return nil, fmt.Errorf("invalid max fetched series for tenant %s: %w",
+				cfg.Name, err)
+		}
+		writeLimitOpts, err := cfg.Limits.MaxWrittenDatapoints.options()
+		if err != nil {
+			return nil, fmt.Errorf("invalid max written datapoints for tenant %s: %w",
+				cfg.Name, err)
+		}
+
+		var namespaces map[string]struct{}
+		if len(cfg.Namespaces) > 0 {
+			namespaces = make(map[string]struct{}, len(cfg.Namespaces))
+			for _, ns := range cfg.Namespaces {
+				namespaces[ns] = struct{}{}
+			}
+		}
+
+		tenantIOpts := iOpts.SetMetricsScope(scope.Tagged(map[string]string{
+			"tenant": cfg.Name,
+		}))
+		result[cfg.Name] = &Tenant{
+			Name: cfg.Name,
+			QueryLimit: limits.NewLookbackLimit(fetchedSeriesLimitName,
+				queryLimitOpts, tenantIOpts, sourceLoggerBuilder),
+			WriteLimit: limits.NewLookbackLimit(writtenDatapointsLimitName,
+				writeLimitOpts, tenantIOpts, sourceLoggerBuilder),
+			namespaces: namespaces,
+		}
+	}
+
+	for _, tenant := range result {
+		tenant.QueryLimit.Start()
+		tenant.WriteLimit.Start()
+	}
+
+	return &tenants{
+		tagName:  []byte(tagName),
+		required: c.Required,
+		tenants:  result,
+	}, nil
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package tenant
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+
+	"github.com/m3db/m3/src/dbnode/storage/limits"
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+func TestConfigurationNewTenants(t *testing.T) {
+	var cfg Configuration
+	require.NoError(t, yaml.Unmarshal([]byte(`
+required: true
+tenants:
+  - name: team-a
+    namespaces:
+      - default
+      - metrics_1m_90d
+    limits:
+      maxFetchedSeries:
+        value: 10
+        lookback: 1m
+  - name: team-b
+`), &cfg))
+
+	tenants, err := cfg.NewTenants(instrument.NewOptions())
+	require.NoError(t, err)
+	defer tenants.Close()
+
+	assert.Equal(t, []byte(DefaultTagName), tenants.TagName())
+	assert.True(t, tenants.Required())
+
+	_, ok := tenants.Tenant("team-c")
+	assert.False(t, ok)
+
+	a, ok := tenants.Tenant("team-a")
+	require.True(t, ok)
+	assert.Equal(t, "team-a", a.Name)
+	assert.True(t, a.HasNamespace("default"))
+	assert.True(t, a.HasNamespace("metrics_1m_90d"))
+	assert.False(t, a.HasNamespace("metrics_10m_1y"))
+	assert.Equal(t, limits.LookbackLimitOptions{
+		Limit:    10,
+		Lookback: time.Minute,
+	}, a.QueryLimit.Options())
+	assert.Equal(t, limits.DefaultLookbackLimitOptions(), a.WriteLimit.Options())
+
+	require.NoError(t, a.QueryLimit.Inc(9, nil))
+	require.Error(t, a.QueryLimit.Inc(1, nil))
+
+	b, ok := tenants.Tenant("team-b")
+	require.True(t, ok)
+	assert.True(t, b.HasNamespace("metrics_10m_1y"))
+	require.NoError(t, b.QueryLimit.Inc(100, nil))
+}
+
+func TestConfigurationNewTenantsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Configuration
+	}{
+		{
+			name: "no tenants",
+			cfg:  Configuration{},
+		},
+		{
+			name: "no tenant name",
+			cfg: Configuration{
+				Tenants: []TenantConfiguration{{}},
+			},
+		},
+		{
+			name: "duplicate tenant",
+			cfg: Configuration{
+				Tenants: []TenantConfiguration{{Name: "a"}, {Name: "a"}},
+			},
+		},
+		{
+			name: "no limit lookback",
+			cfg: Configuration{
+				Tenants: []TenantConfiguration{{
+					Name: "a",
+					Limits: LimitsConfiguration{
+						MaxWrittenDatapoints: &LookbackLimitConfiguration{Value: 10},
+					},
+				}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := test.cfg.NewTenants(instrument.NewOptions())
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+// Package tenant provides the tenants of a coordinator, the reads and writes
+// of a tenant are isolated to the series with the tenant tag set to the name
+// of the tenant and to the namespaces of the tenant.
+package tenant
+
+import (
+	"errors"
+
+	"github.com/m3db/m3/src/dbnode/storage/limits"
+	xerrors "github.com/m3db/m3/src/x/errors"
+)
+
+// DefaultTagName is the default name of the tag identifying the tenant of
+// a series.
+const DefaultTagName = "tenant"
+
+// ErrNamespaceNotWritten is the error of the writes of a tenant to a
+// namespace that is not a namespace of the tenant.
+var ErrNamespaceNotWritten = errors.New("namespace is not written by tenant")
+
+// IsNamespaceNotWritten returns whether an error, or any error it contains,
+// is ErrNamespaceNotWritten.
+func IsNamespaceNotWritten(err error) bool {
+	for err != nil {
+		if errors.Is(err, ErrNamespaceNotWritten) {
+			return true
+		}
+		// nolint:errorlint
+		if multiErr, ok := err.(xerrors.MultiError); ok {
+			for _, e := range multiErr.Errors() {
+				if IsNamespaceNotWritten(e) {
+					return true
+				}
+			}
+			return false
+		}
+		err = xerrors.InnerError(err)
+	}
+	return false
+}
+
+// Tenants are the tenants of a coordinator.
+type Tenants interface {
+	// TagName returns the name of the tag identifying the tenant of a series.
+	TagName() []byte
+
+	// Required returns whether reads and writes must specify a tenant.
+	Required() bool
+
+	// Tenant returns the tenant with a name.
+	Tenant(name string) (*Tenant, bool)
+
+	// Close stops the background resetting of the limits of the tenants.
+	Close()
+}
+
+// Tenant is a tenant of a coordinator.
+type Tenant struct {
+	// Name is the name of the tenant, the value of the tenant tag of the
+	// series of the tenant.
+	Name string
+	// QueryLimit limits the number of series fetched by the queries of
+	// the tenant.
+	QueryLimit limits.LookbackLimit
+	// WriteLimit limits the number of datapoints written by the tenant.
+	WriteLimit limits.LookbackLimit
+
+	namespaces map[string]struct{}
+}
+
+// HasNamespace returns whether the tenant reads and writes a namespace, a
+// tenant without namespaces reads and writes all namespaces.
+func (t *Tenant) HasNamespace(namespace string) bool {
+	if len(t.namespaces) == 0 {
+		return true
+	}
+	_, ok := t.namespaces[namespace]
+	return ok
+}
+
+type tenants struct {
+	tagName  []byte
+	required bool
+	tenants  map[string]*Tenant
+}
+
+func (t *tenants) TagName() []byte {
+	return t.tagName
+}
+
+func (t *tenants) Required() bool {
+	return t.required
+}
+
+func (t *tenants) Tenant(name string) (*Tenant, bool) {
+	tenant, ok := t.tenants[name]
+	return tenant, ok
+}
+
+func (t *tenants) Close() {
+	for _, tenant := range t.tenants {
+		tenant.QueryLimit.Stop()
+		tenant.WriteLimit.Stop()
+	}
+}
@@ -52,6 +52,18 @@ const (
 	// field `headerToMetricType`)
 	PromTypeHeader = "Prometheus-Metric-Type"
 
+	// PromRemoteWriteSamplesWrittenHeader is the remote write 2.0 response
+	// header with the number of samples written.
+	PromRemoteWriteSamplesWrittenHeader = "X-Prometheus-Remote-Write-Samples-Written"
+
+	// PromRemoteWriteHistogramsWrittenHeader is the remote write 2.0 response
+	// header with the number of native histogram samples written.
+	PromRemoteWriteHistogramsWrittenHeader = "X-Prometheus-Remote-Write-Histograms-Written"
+
+	// PromRemoteWriteExemplarsWrittenHeader is the remote write 2.0 response
+	// header with the number of exemplars written.
+	PromRemoteWriteExemplarsWrittenHeader = "X-Prometheus-Remote-Write-Exemplars-Written"
+
 	// WriteTypeHeader is a header that controls if default
 	// writes should be written to both unaggregated and aggregated
 	// namespaces, or if unaggregated values are skipped and
@@ -89,6 +101,11 @@ const (
 	// incoming write requests. See `MapTagsOptions` for structure.
 	MapTagsByJSONHeader = M3HeaderPrefix + "Map-Tags-JSON"
 
+	// TenantHeader is the tenant of a read or write request, reads are
+	// restricted to the series and namespaces of the tenant and the tenant
+	// tag is set on the series written.
+	TenantHeader = M3HeaderPrefix + "Tenant"
+
 	// ReadConsistencyLevelHeader defines the read consistency enforced for a query.
 	ReadConsistencyLevelHeader = M3HeaderPrefix + "Read-Consistency-Level"
 